@@ -16,6 +16,7 @@ import (
 	"github.com/saintgo7/saas-kerp/internal/config"
 	"github.com/saintgo7/saas-kerp/internal/database"
 	"github.com/saintgo7/saas-kerp/internal/handler"
+	"github.com/saintgo7/saas-kerp/internal/pii"
 	"github.com/saintgo7/saas-kerp/internal/router"
 )
 
@@ -28,7 +29,7 @@ func main() {
 	}
 
 	// Initialize logger
-	logger, err := initLogger(cfg)
+	logger, logLevel, err := initLogger(cfg)
 	if err != nil {
 		fmt.Printf("Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -42,7 +43,8 @@ func main() {
 	)
 
 	// Initialize database
-	db, err := database.NewPostgresDB(&cfg.Database, logger)
+	dbPassword := database.NewDBPassword(cfg.Database.Password)
+	db, err := database.NewPostgresDBWithPassword(&cfg.Database, dbPassword, logger)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
@@ -55,6 +57,8 @@ func main() {
 
 	// Initialize Redis
 	rdb := database.NewRedisClient(&cfg.Redis)
+	redisResilience := database.NewRedisResilience(&cfg.Redis, logger)
+	rdb.AddHook(redisResilience)
 	defer func() {
 		if err := database.CloseRedis(rdb); err != nil {
 			logger.Error("Error closing Redis connection", zap.Error(err))
@@ -80,14 +84,55 @@ func main() {
 	}
 
 	// Initialize JWT service
-	jwtService := auth.NewJWTService(&cfg.JWT)
+	jwtService, err := auth.LoadJWTService(&cfg.JWT)
+	if err != nil {
+		logger.Fatal("Failed to load JWT keys", zap.Error(err))
+	}
 
 	// Initialize handlers
-	handlers := handler.NewHandlers(db, rdb, logger, jwtService, cfg.App.Version)
+	handlers := handler.NewHandlers(db, rdb, redisResilience, nc, logger, jwtService, &cfg.OCR, &cfg.Email, &cfg.Export, &cfg.Attachment, &cfg.Credentials, &cfg.Popbill, &cfg.NTS, &cfg.Inbox, &cfg.Plan, &cfg.Backup, &cfg.Retention, cfg.App.Version)
 
 	// Initialize router
 	r := router.New(cfg, logger, jwtService, handlers)
 
+	// Apply reloaded configuration and rotated secrets
+	watcher := config.NewWatcher(cfg, func(err error) {
+		logger.Error("Configuration reload failed, keeping the current configuration", zap.Error(err))
+	})
+	watcher.OnReload(func(old, new *config.Config) {
+		if new.Log.Level != old.Log.Level {
+			logLevel.SetLevel(parseLogLevel(new.Log.Level))
+		}
+		if new.RateLimit != old.RateLimit {
+			r.RateLimit().Apply(&new.RateLimit)
+		}
+		if new.Database.Password != old.Database.Password {
+			dbPassword.Set(new.Database.Password)
+		}
+		if new.JWT.Secret != old.JWT.Secret {
+			jwtService.RotateSecret(new.JWT.Secret)
+		}
+		if new.Popbill.WebhookSecret != old.Popbill.WebhookSecret {
+			handlers.PopbillWebhook.RotateSecret(new.Popbill.WebhookSecret)
+		}
+		logger.Info("Configuration reloaded", zap.Strings("changed", config.ChangedSections(old, new)))
+		if restart := restartRequired(old, new); len(restart) > 0 {
+			logger.Warn("Configuration changes take effect after a restart", zap.Strings("sections", restart))
+		}
+	})
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go watcher.Run(watchCtx)
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			logger.Info("Reloading configuration (SIGHUP)")
+			watcher.Reload(watchCtx)
+		}
+	}()
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.App.Port),
@@ -108,6 +153,18 @@ func main() {
 		}
 	}()
 
+	// Profiles and runtime variables on the internal diagnostics listener
+	var diagnostics *http.Server
+	if cfg.Diagnostics.Addr != "" {
+		diagnostics = router.NewDiagnosticsServer(&cfg.Diagnostics)
+		go func() {
+			logger.Info("Diagnostics listening", zap.String("address", diagnostics.Addr))
+			if err := diagnostics.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Diagnostics listener failed", zap.Error(err))
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -119,6 +176,9 @@ func main() {
 	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if diagnostics != nil {
+		_ = diagnostics.Shutdown(ctx)
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
@@ -126,8 +186,9 @@ func main() {
 	logger.Info("Server exited gracefully")
 }
 
-// initLogger initializes the zap logger based on configuration
-func initLogger(cfg *config.Config) (*zap.Logger, error) {
+// initLogger initializes the zap logger based on configuration. The returned
+// level changes the level of the logger while it runs.
+func initLogger(cfg *config.Config) (*zap.Logger, zap.AtomicLevel, error) {
 	var zapCfg zap.Config
 
 	if cfg.IsDevelopment() {
@@ -138,21 +199,45 @@ func initLogger(cfg *config.Config) (*zap.Logger, error) {
 	}
 
 	// Set log level
-	switch cfg.Log.Level {
-	case "debug":
-		zapCfg.Level.SetLevel(zap.DebugLevel)
-	case "info":
-		zapCfg.Level.SetLevel(zap.InfoLevel)
-	case "warn":
-		zapCfg.Level.SetLevel(zap.WarnLevel)
-	case "error":
-		zapCfg.Level.SetLevel(zap.ErrorLevel)
-	}
+	zapCfg.Level.SetLevel(parseLogLevel(cfg.Log.Level))
 
 	// Set encoding format
 	if cfg.Log.Format == "console" {
 		zapCfg.Encoding = "console"
 	}
 
-	return zapCfg.Build()
+	var opts []zap.Option
+	if cfg.Log.MaskPII {
+		opts = append(opts, zap.WrapCore(pii.NewCore))
+	}
+
+	logger, err := zapCfg.Build(opts...)
+	return logger, zapCfg.Level, err
+}
+
+// parseLogLevel returns the zap level of a validated log.level
+func parseLogLevel(level string) zapcore.Level {
+	switch level {
+	case "debug":
+		return zap.DebugLevel
+	case "warn":
+		return zap.WarnLevel
+	case "error":
+		return zap.ErrorLevel
+	}
+	return zap.InfoLevel
+}
+
+// restartRequired returns the changed sections that are not applied while
+// running. Of the hot-reloaded sections only some settings apply, so the
+// rest of them is compared with those settings reset.
+func restartRequired(old, new *config.Config) []string {
+	o, n := *old, *new
+	o.Log.Level, n.Log.Level = "", ""
+	o.RateLimit, n.RateLimit = config.RateLimitConfig{}, config.RateLimitConfig{}
+	o.Database.Password, n.Database.Password = "", ""
+	o.JWT.Secret, n.JWT.Secret = "", ""
+	o.Popbill.WebhookSecret, n.Popbill.WebhookSecret = "", ""
+	o.SecretStore, n.SecretStore = config.SecretStoreConfig{}, config.SecretStoreConfig{}
+	return config.ChangedSections(&o, &n)
 }
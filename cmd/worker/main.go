@@ -1,24 +1,685 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+	"github.com/saintgo7/saas-kerp/internal/cron"
+	"github.com/saintgo7/saas-kerp/internal/database"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/nts"
+	"github.com/saintgo7/saas-kerp/internal/pii"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/secrets"
+	"github.com/saintgo7/saas-kerp/internal/service"
 )
 
 func main() {
-	log.Println("K-ERP Worker starting...")
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	var logger *zap.Logger
+	if cfg.IsDevelopment() {
+		logger, err = zap.NewDevelopment()
+	} else {
+		logger, err = zap.NewProduction()
+	}
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Log.MaskPII {
+		logger = logger.WithOptions(zap.WrapCore(pii.NewCore))
+	}
+	defer logger.Sync()
+
+	logger.Info("K-ERP Worker starting...", zap.String("version", cfg.App.Version))
+
+	// Initialize database
+	dbPassword := database.NewDBPassword(cfg.Database.Password)
+	db, err := database.NewPostgresDBWithPassword(&cfg.Database, dbPassword, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer func() {
+		if err := database.CloseDB(db); err != nil {
+			logger.Error("Error closing database connection", zap.Error(err))
+		}
+	}()
+
+	// Initialize services
+	taxCodeRepo := repository.NewTaxCodeRepository(db)
+	companyRepo := repository.NewCompanyRepository(db)
+	voucherService := service.NewVoucherService(
+		repository.NewVoucherRepository(db),
+		repository.NewAccountRepository(db),
+		taxCodeRepo,
+		service.NewCompanySettingsService(companyRepo),
+		repository.NewLedgerRepository(db),
+		nil, // vouchers generated by the worker are not limited by the plan
+		repository.NewBankAccountRepository(db),
+	)
+	jobService := service.NewJobService(repository.NewJobRepository(db))
+	notificationService := service.NewNotificationService(newEmailProvider(&cfg.Email), newPushProviders(&cfg.Push, logger)...)
+	reportScheduleService := service.NewReportScheduleService(
+		repository.NewReportScheduleRepository(db),
+		repository.NewUserRepository(db),
+		service.NewReportService(repository.NewLedgerRepository(db), repository.NewAccountRepository(db), taxCodeRepo, companyRepo),
+		notificationService,
+		service.NewEmailTemplateService(repository.NewEmailTemplateRepository(db)),
+		jobService,
+	)
+	dataExportService := service.NewDataExportService(
+		repository.NewDataExportRepository(db),
+		companyRepo,
+		cfg.Export.SigningSecret,
+		cfg.Export.LinkTTL,
+		cfg.Export.Retention,
+	)
+	popbillWebhookService := service.NewPopbillWebhookService(
+		repository.NewPopbillWebhookRepository(db),
+		repository.NewTaxInvoiceRepositoryGorm(db),
+		cfg.Popbill.WebhookSecret,
+		cfg.Popbill.WebhookTolerance,
+	)
+	businessVerificationService := service.NewBusinessVerificationService(
+		repository.NewPartnerRepositoryGorm(db),
+		nts.NewClient(nts.Config{
+			BaseURL:    cfg.NTS.BaseURL,
+			ServiceKey: cfg.NTS.ServiceKey,
+			Timeout:    cfg.NTS.Timeout,
+		}),
+	)
+	loanService := service.NewLoanService(
+		repository.NewLoanRepository(db),
+		repository.NewAccountRepository(db),
+		voucherService,
+	)
+	grantService := service.NewGrantService(
+		repository.NewGrantRepository(db),
+		repository.NewAccountRepository(db),
+		repository.NewVoucherRepository(db),
+		voucherService,
+	)
+	revenueScheduleService := service.NewRevenueScheduleService(
+		repository.NewRevenueScheduleRepository(db),
+		repository.NewTaxInvoiceRepositoryGorm(db),
+		repository.NewPartnerRepositoryGorm(db),
+		repository.NewAccountRepository(db),
+		repository.NewVoucherRepository(db),
+		voucherService,
+	)
+	prepaidExpenseService := service.NewPrepaidExpenseService(
+		repository.NewPrepaidExpenseRepository(db),
+		repository.NewAccountRepository(db),
+		repository.NewVoucherRepository(db),
+		voucherService,
+	)
+	voucherAnomalyService := service.NewVoucherAnomalyService(repository.NewVoucherAnomalyRepository(db))
+	ledgerService := service.NewLedgerService(
+		repository.NewLedgerRepository(db),
+		repository.NewAccountRepository(db),
+		repository.NewCloseChecklistRepository(db),
+		repository.NewYearRolloverRepository(db),
+		repository.NewCloseConfirmationRepository(db),
+		repository.NewAuditLockRepository(db),
+		service.NewCompanySettingsService(companyRepo),
+		nil,
+	)
+	legacyImportService := service.NewLegacyImportService(
+		repository.NewAccountRepository(db),
+		repository.NewPartnerRepositoryGorm(db),
+		repository.NewVoucherRepository(db),
+		repository.NewLedgerRepository(db),
+		service.NewAccountService(repository.NewAccountRepository(db)),
+		service.NewPartnerService(repository.NewPartnerRepositoryGorm(db), service.NewCompanySettingsService(companyRepo), repository.NewAuditLockRepository(db)),
+		voucherService,
+		service.NewCompanySettingsService(companyRepo),
+	)
+	approvalService := service.NewApprovalService(
+		repository.NewPushDeviceRepository(db),
+		repository.NewUserRepository(db),
+		voucherService,
+		service.NewCompanySettingsService(companyRepo),
+		service.NewVoucherSignatureService(repository.NewVoucherSignatureRepository(db), repository.NewUserRepository(db), companyRepo),
+		notificationService,
+		jobService,
+		logger,
+	)
+	service.RegisterJobHandlers(jobService, ledgerService, legacyImportService, reportScheduleService, approvalService)
+	meteringService := service.NewMeteringService(repository.NewUsageRepository(db), newBillingProvider(&cfg.Billing))
+	noteService := service.NewNoteService(
+		repository.NewNoteRepository(db),
+		repository.NewPartnerRepositoryGorm(db),
+		repository.NewAccountRepository(db),
+		repository.NewBankAccountRepository(db),
+		companyRepo,
+		repository.NewUserRepository(db),
+		voucherService,
+		notificationService,
+		service.NewEmailTemplateService(repository.NewEmailTemplateRepository(db)),
+	)
+	contractService := service.NewContractService(
+		repository.NewContractRepository(db),
+		repository.NewPartnerRepositoryGorm(db),
+		repository.NewAccountRepository(db),
+		taxCodeRepo,
+		repository.NewBankAccountRepository(db),
+		companyRepo,
+		repository.NewUserRepository(db),
+		repository.NewTaxInvoiceRepositoryGorm(db),
+		voucherService,
+		notificationService,
+		service.NewEmailTemplateService(repository.NewEmailTemplateRepository(db)),
+	)
+	partitionService := service.NewPartitionService(
+		repository.NewPartitionRepository(db),
+		cfg.Worker.PartitionYearsAhead,
+		cfg.Worker.PartitionHashCount,
+	)
+
+	dataRetentionService := service.NewDataRetentionService(
+		repository.NewDataRetentionRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewPartnerRepositoryGorm(db),
+		domain.RetentionPolicy{
+			AccountingYears:      cfg.Retention.AccountingYears,
+			TaxYears:             cfg.Retention.TaxYears,
+			AuditLogDays:         cfg.Retention.AuditLogDays,
+			SessionDays:          cfg.Retention.SessionDays,
+			DeletionDeadlineDays: cfg.Retention.DeletionDeadlineDays,
+		},
+	)
+	var backupStaging repository.BackupStagingRepository
+	if cfg.Backup.StagingDSN != "" {
+		stagingDB, err := database.NewStagingDB(cfg.Backup.StagingDSN)
+		if err != nil {
+			logger.Error("Backup restores disabled", zap.Error(err))
+		} else {
+			defer database.CloseDB(stagingDB)
+			backupStaging = repository.NewBackupStagingRepository(stagingDB)
+		}
+	}
+	backupService := service.NewBackupService(
+		repository.NewBackupRepository(db),
+		repository.NewDataExportRepository(db),
+		companyRepo,
+		newBackupStorage(&cfg.Backup),
+		newKeyManager(&cfg.Credentials, logger),
+		backupStaging,
+		newBackupOptions(&cfg.Backup),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Rotated database passwords; other changes need a restart of the worker
+	watcher := config.NewWatcher(cfg, func(err error) {
+		logger.Error("Configuration reload failed, keeping the current configuration", zap.Error(err))
+	})
+	watcher.OnReload(func(old, new *config.Config) {
+		if new.Database.Password != old.Database.Password {
+			dbPassword.Set(new.Database.Password)
+			logger.Info("Database password rotated")
+		}
+	})
+	go watcher.Run(ctx)
+
+	// Recurring tasks with their cron schedules in scheduled_tasks. Each run is
+	// claimed in the database, so with several worker replicas it runs once.
+	schedulerService := service.NewSchedulerService(repository.NewScheduledTaskRepository(db), workerID())
+	registerScheduledTasks(schedulerService, cfg, logger, &scheduledServices{
+		vouchers:     voucherService,
+		verification: businessVerificationService,
+		loans:        loanService,
+		grants:       grantService,
+		revenue:      revenueScheduleService,
+		prepaid:      prepaidExpenseService,
+		ledger:       ledgerService,
+		metering:     meteringService,
+		partitions:   partitionService,
+		retention:    dataRetentionService,
+		notes:        noteService,
+		contracts:    contractService,
+	})
+	go runPeriodic(ctx, cfg.Worker.SchedulerInterval, func(ctx context.Context) {
+		if _, err := schedulerService.RunDue(ctx, time.Now()); err != nil {
+			logger.Error("Scheduled task failed", zap.Error(err))
+		}
+	})
+
+	// Background jobs: queued recalculations, imports and report deliveries
+	go runPeriodic(ctx, cfg.Worker.JobInterval, func(ctx context.Context) {
+		count, err := jobService.ProcessPending(database.WithPrimary(ctx))
+		if err != nil {
+			logger.Error("Background job failed", zap.Error(err))
+		}
+		if count > 0 {
+			logger.Info("Background jobs run", zap.Int("count", count))
+		}
+	})
+
+	// Scheduled report delivery; due runs are queued as report delivery jobs
+	go runPeriodic(ctx, cfg.Worker.ReportScheduleInterval, func(ctx context.Context) {
+		count, err := reportScheduleService.ProcessDue(ctx, time.Now())
+		if err != nil {
+			logger.Error("Scheduled report delivery failed", zap.Error(err))
+		}
+		if count > 0 {
+			logger.Info("Scheduled reports queued", zap.Int("count", count))
+		}
+	})
+
+	// Full tenant data exports
+	go runPeriodic(ctx, cfg.Worker.DataExportInterval, func(ctx context.Context) {
+		count, err := dataExportService.ProcessPending(ctx)
+		if err != nil {
+			logger.Error("Data export generation failed", zap.Error(err))
+		}
+		if count > 0 {
+			logger.Info("Data exports processed", zap.Int("count", count))
+		}
+
+		purged, err := dataExportService.PurgeExpired(ctx, time.Now())
+		if err != nil {
+			logger.Error("Expired data export cleanup failed", zap.Error(err))
+		}
+		if purged > 0 {
+			logger.Info("Expired data exports deleted", zap.Int64("count", purged))
+		}
+	})
+
+	// Popbill tax invoice state callbacks
+	go runPeriodic(ctx, cfg.Worker.PopbillWebhookInterval, func(ctx context.Context) {
+		count, err := popbillWebhookService.ProcessPending(ctx)
+		if err != nil {
+			logger.Error("Popbill webhook processing failed", zap.Error(err))
+		}
+		if count > 0 {
+			logger.Info("Popbill webhooks processed", zap.Int("count", count))
+		}
+	})
+
+	// Anomaly flags on newly posted vouchers, for the controllers' review worklist
+	go runPeriodic(ctx, cfg.Worker.AnomalyScoringInterval, func(ctx context.Context) {
+		count, err := voucherAnomalyService.ScorePending(database.WithPrimary(ctx))
+		if err != nil {
+			logger.Error("Voucher anomaly scoring failed", zap.Error(err))
+		}
+		if count > 0 {
+			logger.Info("Posted vouchers scored for anomalies", zap.Int("count", count))
+		}
+	})
+
+	// Encrypted tenant backups and restores into staging; skipped without backup storage
+	if cfg.Backup.Storage != "" {
+		go runPeriodic(ctx, cfg.Worker.BackupInterval, func(ctx context.Context) {
+			now := time.Now()
+			scheduled, err := backupService.ScheduleDue(ctx, now)
+			if err != nil {
+				logger.Error("Backup scheduling failed", zap.Error(err))
+			}
+			if scheduled > 0 {
+				logger.Info("Scheduled backups queued", zap.Int64("count", scheduled))
+			}
+
+			count, err := backupService.ProcessPending(ctx)
+			if err != nil {
+				logger.Error("Backup generation failed", zap.Error(err))
+			}
+			if count > 0 {
+				logger.Info("Backups processed", zap.Int("count", count))
+			}
+
+			restored, err := backupService.ProcessRestores(ctx)
+			if err != nil {
+				logger.Error("Backup restore failed", zap.Error(err))
+			}
+			if restored > 0 {
+				logger.Info("Backup restores processed", zap.Int("count", restored))
+			}
+
+			purged, err := backupService.PurgeExpired(ctx, now)
+			if err != nil {
+				logger.Error("Expired backup cleanup failed", zap.Error(err))
+			}
+			if purged > 0 {
+				logger.Info("Expired backups deleted", zap.Int("count", purged))
+			}
+		})
+	}
+
+	// TODO: Initialize NATS consumer
 
 	// Wait for shutdown signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	log.Println("Worker is running. Press Ctrl+C to stop.")
-
-	// TODO: Initialize NATS consumer
-	// TODO: Process background jobs
+	logger.Info("Worker is running. Press Ctrl+C to stop.")
 
 	<-sigChan
-	log.Println("Worker shutting down...")
+	logger.Info("Worker shutting down...")
+}
+
+// scheduledServices are the services run by the scheduled tasks
+type scheduledServices struct {
+	vouchers     service.VoucherService
+	verification service.BusinessVerificationService
+	loans        service.LoanService
+	grants       service.GrantService
+	revenue      service.RevenueScheduleService
+	prepaid      service.PrepaidExpenseService
+	ledger       service.LedgerService
+	metering     service.MeteringService
+	partitions   service.PartitionService
+	retention    service.DataRetentionService
+	notes        service.NoteService
+	contracts    service.ContractService
+}
+
+// registerScheduledTasks registers the functions of the tasks seeded in
+// scheduled_tasks. Each runs for its scheduled time, so that a run made up
+// after a downtime covers the period it was scheduled for.
+func registerScheduledTasks(scheduler service.SchedulerService, cfg *config.Config, logger *zap.Logger, svc *scheduledServices) {
+	// Auto-reversal of accrual/deferral vouchers; read from the primary so that a
+	// voucher reversed by the previous run is never picked up again
+	scheduler.Register("auto_reversal", func(ctx context.Context, at time.Time) error {
+		reversals, err := svc.vouchers.ProcessAutoReversals(database.WithPrimary(ctx), at)
+		if len(reversals) > 0 {
+			logger.Info("Auto-reversal vouchers generated", zap.Int("count", len(reversals)))
+		}
+		return err
+	})
+
+	// Posting of approved vouchers whose scheduled date has arrived
+	scheduler.Register("scheduled_posting", func(ctx context.Context, at time.Time) error {
+		posted, err := svc.vouchers.ProcessScheduledPostings(database.WithPrimary(ctx), at)
+		if len(posted) > 0 {
+			logger.Info("Scheduled vouchers posted", zap.Int("count", len(posted)))
+		}
+		return err
+	})
+
+	// Revalidation of partner business numbers; skipped without an NTS service key
+	if cfg.NTS.ServiceKey != "" {
+		scheduler.Register("partner_verification", func(ctx context.Context, at time.Time) error {
+			result, err := svc.verification.RevalidatePartners(ctx, at.Add(-cfg.NTS.RevalidateAfter), cfg.NTS.RevalidateBatchSize)
+			if result == nil {
+				return err
+			}
+			if result.Closed > 0 {
+				logger.Warn("Closed businesses found among partners", zap.Int("count", result.Closed))
+			}
+			if result.Checked > 0 {
+				logger.Info("Partner business numbers revalidated", zap.Int("count", result.Checked))
+			}
+			return err
+		})
+	}
+
+	// Interest accrual of loans at the last month end; the accrual vouchers are
+	// drafts, reversed by the auto-reversal task once posted
+	scheduler.Register("loan_accrual", func(ctx context.Context, at time.Time) error {
+		periodEnd := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		run, err := svc.loans.AccrueInterest(database.WithPrimary(ctx), nil, periodEnd)
+		if run != nil && run.Accrued > 0 {
+			logger.Info("Loan interest accrual vouchers generated",
+				zap.Int("count", run.Accrued), zap.Time("period_end", periodEnd))
+		}
+		return err
+	})
+
+	// Income recognition of government grants at the last month end
+	scheduler.Register("grant_recognition", func(ctx context.Context, at time.Time) error {
+		periodEnd := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		run, err := svc.grants.RecognizeIncome(database.WithPrimary(ctx), nil, periodEnd)
+		if run != nil && run.Recognized > 0 {
+			logger.Info("Grant income recognition vouchers generated",
+				zap.Int("count", run.Recognized), zap.Time("period_end", periodEnd))
+		}
+		return err
+	})
+
+	// Recognition of deferred revenue at the last month end
+	scheduler.Register("revenue_recognition", func(ctx context.Context, at time.Time) error {
+		periodEnd := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		run, err := svc.revenue.RecognizeRevenue(database.WithPrimary(ctx), nil, periodEnd)
+		if run != nil && run.Recognized > 0 {
+			logger.Info("Revenue recognition vouchers generated",
+				zap.Int("count", run.Recognized), zap.Time("period_end", periodEnd))
+		}
+		return err
+	})
+
+	// Amortization of prepaid expenses at the last month end
+	scheduler.Register("prepaid_amortization", func(ctx context.Context, at time.Time) error {
+		periodEnd := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		run, err := svc.prepaid.Amortize(database.WithPrimary(ctx), nil, periodEnd)
+		if run != nil && run.Amortized > 0 {
+			logger.Info("Prepaid expense amortization vouchers generated",
+				zap.Int("count", run.Amortized), zap.Time("period_end", periodEnd))
+		}
+		return err
+	})
+
+	// Carry-forward of the previous year once a company opens the periods of
+	// the current one
+	scheduler.Register("year_rollover", func(ctx context.Context, at time.Time) error {
+		runs, err := svc.ledger.RollOverOpenedYears(database.WithPrimary(ctx), at.Year())
+		for _, run := range runs {
+			logger.Info("Fiscal year rolled over",
+				zap.String("company_id", run.CompanyID.String()),
+				zap.Int("from_year", run.FromYear), zap.Float64("net_income", run.NetIncome))
+		}
+		return err
+	})
+
+	// Monthly usage metering; closed months are exported to the billing provider
+	scheduler.Register("usage_aggregation", func(ctx context.Context, at time.Time) error {
+		if _, err := svc.metering.Aggregate(ctx, at); err != nil {
+			return fmt.Errorf("usage aggregation: %w", err)
+		}
+		count, err := svc.metering.Export(ctx, at)
+		if count > 0 {
+			logger.Info("Usage records exported to billing", zap.Int("count", count))
+		}
+		return err
+	})
+
+	// voucher_entries fiscal year partitions
+	scheduler.Register("partition_maintenance", func(ctx context.Context, at time.Time) error {
+		years, err := svc.partitions.Maintain(ctx, at)
+		if len(years) > 0 {
+			logger.Info("Voucher entry partitions created", zap.Ints("fiscal_years", years))
+		}
+		return err
+	})
+
+	// Retention periods of tokens and audit client details
+	scheduler.Register("data_retention", func(ctx context.Context, at time.Time) error {
+		run, err := svc.retention.Enforce(ctx, at)
+		if run != nil && (run.TokensDeleted > 0 || run.AuditEntriesCleared > 0) {
+			logger.Info("Data retention enforced",
+				zap.Int64("tokens_deleted", run.TokensDeleted),
+				zap.Int64("audit_entries_cleared", run.AuditEntriesCleared))
+		}
+		return err
+	})
+
+	// Email alerts to the admins of the notes nearing maturity
+	scheduler.Register("note_maturity_alert", func(ctx context.Context, at time.Time) error {
+		count, err := svc.notes.AlertMaturities(database.WithPrimary(ctx), at)
+		if count > 0 {
+			logger.Info("Note maturity alerts sent", zap.Int("count", count))
+		}
+		return err
+	})
+	scheduler.Register("contract_milestone_reminder", func(ctx context.Context, at time.Time) error {
+		count, err := svc.contracts.RemindMilestones(database.WithPrimary(ctx), at)
+		if count > 0 {
+			logger.Info("Contract milestone reminders sent", zap.Int("count", count))
+		}
+		return err
+	})
+}
+
+// workerID identifies this worker process as the holder of the scheduled tasks it runs
+func workerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "worker"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// runPeriodic runs job immediately and then on every interval until ctx is cancelled
+func runPeriodic(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newEmailProvider creates the configured outgoing email provider, or nil if email is disabled
+func newEmailProvider(cfg *config.EmailConfig) provider.EmailProvider {
+	switch cfg.Provider {
+	case string(provider.ProviderTypeSMTP):
+		return provider.NewSMTPEmailProvider(&provider.SMTPConfig{
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			Username: cfg.Username,
+			Password: cfg.Password,
+			From:     cfg.From,
+			FromName: cfg.FromName,
+			Timeout:  cfg.Timeout,
+		})
+	}
+	return nil
+}
+
+// newPushProviders creates the configured push providers. A provider whose
+// key cannot be loaded is left out, so the worker still runs without it.
+func newPushProviders(cfg *config.PushConfig, logger *zap.Logger) []provider.PushProvider {
+	var providers []provider.PushProvider
+	if cfg.FCMCredentialsFile != "" {
+		fcm, err := provider.NewFCMPushProvider(&provider.FCMConfig{
+			ProjectID:       cfg.FCMProjectID,
+			CredentialsFile: cfg.FCMCredentialsFile,
+			Timeout:         cfg.Timeout,
+		})
+		if err != nil {
+			logger.Error("FCM push notifications disabled", zap.Error(err))
+		} else {
+			providers = append(providers, fcm)
+		}
+	}
+	if cfg.APNsKeyFile != "" {
+		apns, err := provider.NewAPNsPushProvider(&provider.APNsConfig{
+			KeyID:   cfg.APNsKeyID,
+			TeamID:  cfg.APNsTeamID,
+			KeyFile: cfg.APNsKeyFile,
+			Topic:   cfg.APNsTopic,
+			Sandbox: cfg.APNsSandbox,
+			Timeout: cfg.Timeout,
+		})
+		if err != nil {
+			logger.Error("APNs push notifications disabled", zap.Error(err))
+		} else {
+			providers = append(providers, apns)
+		}
+	}
+	return providers
+}
+
+// newBillingProvider creates the configured billing provider, or nil if usage is not exported
+func newBillingProvider(cfg *config.BillingConfig) provider.BillingProvider {
+	switch cfg.Provider {
+	case string(provider.ProviderTypeStripe):
+		return provider.NewStripeBillingProvider(&provider.StripeConfig{
+			SecretKey:   cfg.StripeSecretKey,
+			BaseURL:     cfg.StripeBaseURL,
+			EventPrefix: cfg.StripeEventPrefix,
+			Timeout:     cfg.Timeout,
+		})
+	}
+	return nil
+}
+
+// newKeyManager creates the key manager that wraps backup data keys, or nil if
+// credentials.key_provider is not set
+func newKeyManager(cfg *config.CredentialsConfig, logger *zap.Logger) secrets.KeyManager {
+	switch cfg.KeyProvider {
+	case "local":
+		keys := map[string]string{cfg.MasterKeyID: cfg.MasterKey}
+		for _, k := range cfg.RetiredKeys {
+			keys[k.ID] = k.Key
+		}
+		km, err := secrets.NewLocalKeyManager(cfg.MasterKeyID, keys)
+		if err != nil {
+			logger.Error("Backup encryption disabled", zap.Error(err))
+			return nil
+		}
+		return km
+	case "vault":
+		return secrets.NewVaultTransitKeyManager(&secrets.VaultTransitConfig{
+			Address: cfg.VaultAddress,
+			Token:   cfg.VaultToken,
+			KeyName: cfg.VaultTransitKey,
+			Timeout: cfg.VaultTimeout,
+		})
+	}
+	return nil
+}
+
+// newBackupStorage creates the configured backup object storage, or nil if backups are disabled
+func newBackupStorage(cfg *config.BackupConfig) provider.ObjectStorage {
+	switch cfg.Storage {
+	case string(provider.ProviderTypeS3):
+		return provider.NewS3Storage(&provider.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+			Timeout:         cfg.StorageTimeout,
+		})
+	case string(provider.ProviderTypeFile):
+		return provider.NewFileStorage(cfg.FileDir)
+	}
+	return nil
+}
+
+// newBackupOptions creates the schedule, retention and storage layout of backups
+func newBackupOptions(cfg *config.BackupConfig) service.BackupOptions {
+	options := service.BackupOptions{
+		Retention: domain.BackupRetention{Days: cfg.RetentionDays, MonthlyMonths: cfg.MonthlyRetentionMonths},
+	}
+	if cfg.Storage == string(provider.ProviderTypeS3) {
+		options.StoragePrefix = cfg.S3Prefix
+	}
+	options.Schedule, _ = cron.Parse(cfg.Schedule)
+	options.Location, _ = time.LoadLocation(cfg.Timezone)
+	return options
 }
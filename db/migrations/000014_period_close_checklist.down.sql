@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: Period Close Checklist (Rollback)
+
+DROP TRIGGER IF EXISTS set_period_close_tasks_updated_at ON period_close_tasks;
+DROP TRIGGER IF EXISTS set_close_checklist_tasks_updated_at ON close_checklist_tasks;
+
+DROP TABLE IF EXISTS period_close_tasks;
+DROP TABLE IF EXISTS close_checklist_tasks;
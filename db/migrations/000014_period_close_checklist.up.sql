@@ -0,0 +1,88 @@
+-- K-ERP v0.2 Migration: Period Close Checklist
+-- Configurable month-end close tasks and per-period completion tracking
+
+-- ============================================
+-- CLOSE CHECKLIST TASKS (Company configuration)
+-- ============================================
+CREATE TABLE close_checklist_tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    -- Task info
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    description VARCHAR(500),
+
+    -- Settings
+    is_mandatory BOOLEAN DEFAULT TRUE,
+    is_active BOOLEAN DEFAULT TRUE,
+    sort_order INTEGER DEFAULT 0,
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(company_id, code)
+);
+
+CREATE INDEX idx_close_checklist_tasks_company ON close_checklist_tasks(company_id);
+
+COMMENT ON TABLE close_checklist_tasks IS 'Month-end close checklist tasks configured per company';
+
+-- ============================================
+-- PERIOD CLOSE TASKS (Per-period status)
+-- ============================================
+CREATE TABLE period_close_tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES close_checklist_tasks(id) ON DELETE CASCADE,
+
+    -- Period
+    fiscal_year INTEGER NOT NULL,
+    fiscal_month INTEGER NOT NULL CHECK (fiscal_month BETWEEN 1 AND 12),
+
+    -- Status
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'skipped')),
+    note VARCHAR(500),
+    completed_at TIMESTAMPTZ,
+    completed_by UUID REFERENCES users(id),
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(company_id, task_id, fiscal_year, fiscal_month)
+);
+
+CREATE INDEX idx_period_close_tasks_period ON period_close_tasks(company_id, fiscal_year, fiscal_month);
+
+COMMENT ON TABLE period_close_tasks IS 'Completion status of close checklist tasks per fiscal period';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE close_checklist_tasks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE period_close_tasks ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_close_checklist_tasks ON close_checklist_tasks
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_close_checklist_tasks ON close_checklist_tasks
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_period_close_tasks ON period_close_tasks
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_period_close_tasks ON period_close_tasks
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_close_checklist_tasks_updated_at
+    BEFORE UPDATE ON close_checklist_tasks
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_period_close_tasks_updated_at
+    BEFORE UPDATE ON period_close_tasks
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Auto-Reversing Vouchers (Rollback)
+
+DROP INDEX IF EXISTS idx_vouchers_auto_reverse_pending;
+
+ALTER TABLE vouchers DROP COLUMN IF EXISTS auto_reverse;
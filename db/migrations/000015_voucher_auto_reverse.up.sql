@@ -0,0 +1,10 @@
+-- K-ERP v0.2 Migration: Auto-Reversing Vouchers
+-- Accrual/deferral vouchers reversed automatically on the first day of the next period
+
+ALTER TABLE vouchers ADD COLUMN auto_reverse BOOLEAN DEFAULT FALSE;
+
+-- Worker lookup of posted auto-reversing vouchers which are not reversed yet
+CREATE INDEX idx_vouchers_auto_reverse_pending ON vouchers(voucher_date)
+    WHERE auto_reverse = TRUE AND status = 'posted' AND reversed_by_id IS NULL;
+
+COMMENT ON COLUMN vouchers.auto_reverse IS 'Generate reversal dated the first day of the next period when it opens';
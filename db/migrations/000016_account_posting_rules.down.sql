@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Account Posting Rules (Rollback)
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS posting_rules;
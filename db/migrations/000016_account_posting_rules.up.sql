@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Account Posting Rules
+-- Per-account constraints (required dimensions, allowed voucher types, amount limits)
+
+ALTER TABLE accounts ADD COLUMN posting_rules JSONB;
+
+COMMENT ON COLUMN accounts.posting_rules IS 'Posting rules enforced on voucher entries (required dimensions, allowed voucher types, min/max amount)';
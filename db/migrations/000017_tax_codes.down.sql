@@ -0,0 +1,12 @@
+-- K-ERP v0.2 Migration: Tax Codes (Rollback)
+
+DROP INDEX IF EXISTS idx_voucher_entries_tax_code;
+
+ALTER TABLE voucher_entries
+    DROP COLUMN IF EXISTS is_tax_line,
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_code_id;
+
+DROP TRIGGER IF EXISTS set_tax_codes_updated_at ON tax_codes;
+
+DROP TABLE IF EXISTS tax_codes;
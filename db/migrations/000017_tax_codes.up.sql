@@ -0,0 +1,68 @@
+-- K-ERP v0.2 Migration: Tax Codes
+-- VAT tax code master data and tax code references on voucher entries
+
+-- ============================================
+-- TAX CODES (부가세 코드)
+-- ============================================
+CREATE TABLE tax_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    -- Identification
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+
+    -- Classification
+    tax_type VARCHAR(20) NOT NULL CHECK (tax_type IN ('output', 'input')),
+    tax_category VARCHAR(20) NOT NULL DEFAULT 'taxable' CHECK (tax_category IN ('taxable', 'zero_rated', 'exempt')),
+    rate DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (rate BETWEEN 0 AND 100),
+    is_deductible BOOLEAN DEFAULT TRUE,
+
+    -- Account receiving generated VAT lines
+    vat_account_id UUID REFERENCES accounts(id),
+
+    is_active BOOLEAN DEFAULT TRUE,
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(company_id, code)
+);
+
+CREATE INDEX idx_tax_codes_company ON tax_codes(company_id);
+
+COMMENT ON TABLE tax_codes IS 'VAT tax codes (rate, type, deductibility, VAT account)';
+COMMENT ON COLUMN tax_codes.is_deductible IS 'Input VAT deductible; non-deductible VAT stays in the cost account';
+
+-- ============================================
+-- VOUCHER ENTRIES
+-- ============================================
+ALTER TABLE voucher_entries
+    ADD COLUMN tax_code_id UUID REFERENCES tax_codes(id),
+    ADD COLUMN tax_amount DECIMAL(18,2) NOT NULL DEFAULT 0,
+    ADD COLUMN is_tax_line BOOLEAN DEFAULT FALSE;
+
+CREATE INDEX idx_voucher_entries_tax_code ON voucher_entries(company_id, tax_code_id)
+    WHERE tax_code_id IS NOT NULL;
+
+COMMENT ON COLUMN voucher_entries.tax_amount IS 'VAT amount split from the tax-inclusive entry amount';
+COMMENT ON COLUMN voucher_entries.is_tax_line IS 'Line generated for the VAT amount of a tax-coded entry';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE tax_codes ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tax_codes ON tax_codes
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_tax_codes ON tax_codes
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_tax_codes_updated_at
+    BEFORE UPDATE ON tax_codes
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Voucher Print Templates (Rollback)
+
+DROP TRIGGER IF EXISTS set_voucher_print_templates_updated_at ON voucher_print_templates;
+
+DROP TABLE IF EXISTS voucher_print_templates;
@@ -0,0 +1,51 @@
+-- K-ERP v0.2 Migration: Voucher Print Templates
+-- Per-company customization of the printed voucher (전표 출력)
+
+-- ============================================
+-- VOUCHER PRINT TEMPLATES
+-- ============================================
+CREATE TABLE voucher_print_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    -- Header
+    title VARCHAR(50),
+    logo_data BYTEA,
+    logo_content_type VARCHAR(50),
+
+    -- Approval block (결재란)
+    show_approval_block BOOLEAN DEFAULT TRUE,
+    approval_labels JSONB NOT NULL DEFAULT '["담당", "검토", "승인"]',
+
+    -- Body
+    show_partner BOOLEAN DEFAULT TRUE,
+
+    -- Footer
+    footer_text VARCHAR(500),
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(company_id)
+);
+
+COMMENT ON TABLE voucher_print_templates IS 'Company customization of printed vouchers (logo, approval labels, footer)';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_print_templates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_print_templates ON voucher_print_templates
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_print_templates ON voucher_print_templates
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_voucher_print_templates_updated_at
+    BEFORE UPDATE ON voucher_print_templates
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Voucher Signatures (Rollback)
+
+DROP TABLE IF EXISTS voucher_signatures;
+
+ALTER TABLE users DROP COLUMN IF EXISTS signing_pin_hash;
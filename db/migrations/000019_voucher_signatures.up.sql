@@ -0,0 +1,52 @@
+-- K-ERP v0.2 Migration: Voucher Signatures
+-- Signature image / PIN confirmation captured on voucher approval and posting (전자서명)
+
+-- ============================================
+-- USER SIGNING PIN
+-- ============================================
+ALTER TABLE users ADD COLUMN signing_pin_hash VARCHAR(255);
+
+COMMENT ON COLUMN users.signing_pin_hash IS 'bcrypt hash of the PIN confirming approval signatures';
+
+-- ============================================
+-- VOUCHER SIGNATURES
+-- ============================================
+CREATE TABLE voucher_signatures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('approve', 'post')),
+    user_id UUID NOT NULL REFERENCES users(id),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('image', 'pin', 'image_pin')),
+
+    -- Handwritten signature image
+    image_data BYTEA,
+    image_content_type VARCHAR(50),
+
+    -- SHA-256 of the voucher content at signing time
+    document_hash VARCHAR(64) NOT NULL,
+
+    -- Signing context
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    signed_at TIMESTAMPTZ NOT NULL,
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_voucher_signatures_voucher ON voucher_signatures(company_id, voucher_id, signed_at);
+
+COMMENT ON TABLE voucher_signatures IS 'Approval signature artifacts kept for audit; rows are append-only';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_signatures ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_signatures ON voucher_signatures
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_signatures ON voucher_signatures
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Report Schedules (Rollback)
+
+DROP TRIGGER IF EXISTS set_report_schedules_updated_at ON report_schedules;
+
+DROP TABLE IF EXISTS report_schedule_runs;
+DROP TABLE IF EXISTS report_schedules;
@@ -0,0 +1,99 @@
+-- K-ERP v0.2 Migration: Report Schedules
+-- Scheduled report generation and email delivery (정기 보고서 발송)
+
+-- ============================================
+-- REPORT SCHEDULES
+-- ============================================
+CREATE TABLE report_schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    -- Report definition
+    name VARCHAR(100) NOT NULL,
+    report_type VARCHAR(50) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    format VARCHAR(10) NOT NULL DEFAULT 'pdf',
+
+    -- Schedule (five-field cron expression evaluated in timezone)
+    cron_expr VARCHAR(100) NOT NULL,
+    timezone VARCHAR(50) NOT NULL DEFAULT 'Asia/Seoul',
+
+    -- Delivery
+    recipients JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN DEFAULT TRUE,
+
+    -- Run state
+    next_run_at TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    last_status VARCHAR(20),
+    failure_count INTEGER DEFAULT 0,
+
+    -- Audit
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_report_schedule_type CHECK (report_type IN ('trial_balance', 'balance_sheet', 'income_statement', 'vat_return')),
+    CONSTRAINT chk_report_schedule_format CHECK (format IN ('csv', 'pdf'))
+);
+
+CREATE INDEX idx_report_schedules_company ON report_schedules(company_id);
+CREATE INDEX idx_report_schedules_due ON report_schedules(next_run_at) WHERE is_active = TRUE;
+
+COMMENT ON TABLE report_schedules IS 'Reports generated on a cron schedule and emailed to recipients';
+COMMENT ON COLUMN report_schedules.failure_count IS 'Consecutive failed runs; the schedule is paused after 5';
+
+-- ============================================
+-- REPORT SCHEDULE RUNS
+-- ============================================
+CREATE TABLE report_schedule_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL REFERENCES report_schedules(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    manual BOOLEAN DEFAULT FALSE,
+
+    -- Resolved period
+    period_from DATE,
+    period_to DATE,
+
+    -- Output
+    file_name VARCHAR(200),
+    file_size INTEGER DEFAULT 0,
+    recipient_count INTEGER DEFAULT 0,
+    error TEXT,
+
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_report_schedule_run_status CHECK (status IN ('running', 'success', 'failed'))
+);
+
+CREATE INDEX idx_report_schedule_runs_schedule ON report_schedule_runs(schedule_id, started_at DESC);
+
+COMMENT ON TABLE report_schedule_runs IS 'Run history of report schedules';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE report_schedules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE report_schedule_runs ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_report_schedules ON report_schedules
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_report_schedules ON report_schedules
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_report_schedule_runs ON report_schedule_runs
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_report_schedule_runs ON report_schedule_runs
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_report_schedules_updated_at
+    BEFORE UPDATE ON report_schedules
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
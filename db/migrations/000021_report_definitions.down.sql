@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Report Definitions (Rollback)
+
+DROP TRIGGER IF EXISTS set_report_definitions_updated_at ON report_definitions;
+
+DROP TABLE IF EXISTS report_definitions;
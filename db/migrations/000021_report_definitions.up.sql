@@ -0,0 +1,43 @@
+-- K-ERP v0.2 Migration: Report Definitions
+-- Saved custom reports over ledger data (사용자 정의 보고서)
+
+-- ============================================
+-- REPORT DEFINITIONS
+-- ============================================
+CREATE TABLE report_definitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+
+    -- Account ranges/types, amount columns, groupings and default period
+    spec JSONB NOT NULL DEFAULT '{}',
+
+    -- Audit
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_report_definitions_company ON report_definitions(company_id);
+
+COMMENT ON TABLE report_definitions IS 'Saved custom reports compiled to aggregate queries over posted voucher entries';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE report_definitions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_report_definitions ON report_definitions
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_report_definitions ON report_definitions
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_report_definitions_updated_at
+    BEFORE UPDATE ON report_definitions
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
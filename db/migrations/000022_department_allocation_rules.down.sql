@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Department Allocation Rules (Rollback)
+
+DROP TRIGGER IF EXISTS set_department_allocation_rules_updated_at ON department_allocation_rules;
+
+DROP TABLE IF EXISTS department_allocation_rules;
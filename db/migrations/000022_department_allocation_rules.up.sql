@@ -0,0 +1,52 @@
+-- K-ERP v0.2 Migration: Department Allocation Rules
+-- Shared cost allocation for the income statement by department (부서별 공통비 배부)
+
+-- ============================================
+-- DEPARTMENT ALLOCATION RULES
+-- ============================================
+CREATE TABLE department_allocation_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    name VARCHAR(100) NOT NULL,
+
+    -- NULL allocates entries posted without a department (공통비)
+    source_department_id UUID REFERENCES departments(id) ON DELETE CASCADE,
+
+    -- Inclusive account code range; empty applies to all expense accounts
+    account_from VARCHAR(10),
+    account_to VARCHAR(10),
+
+    -- [{"department_id": "...", "ratio": 60}, ...] summing to 100
+    targets JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_allocation_account_range CHECK (
+        (account_from IS NULL AND account_to IS NULL) OR account_from <= account_to
+    )
+);
+
+CREATE INDEX idx_department_allocation_rules_company ON department_allocation_rules(company_id);
+
+COMMENT ON TABLE department_allocation_rules IS 'Ratio-based rules distributing shared revenue/expense amounts to departments';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE department_allocation_rules ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_department_allocation_rules ON department_allocation_rules
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_department_allocation_rules ON department_allocation_rules
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_department_allocation_rules_updated_at
+    BEFORE UPDATE ON department_allocation_rules
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
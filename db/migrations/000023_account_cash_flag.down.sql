@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Cash Account Flag (Rollback)
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS is_cash_account;
@@ -0,0 +1,9 @@
+-- K-ERP v0.2 Migration: Cash Account Flag
+-- Designates the cash/bank accounts listed in the cash book (현금출납장)
+
+ALTER TABLE accounts ADD COLUMN is_cash_account BOOLEAN NOT NULL DEFAULT false;
+
+-- Standard chart of accounts: 현금, 보통예금
+UPDATE accounts SET is_cash_account = true WHERE code IN ('110101', '110102');
+
+COMMENT ON COLUMN accounts.is_cash_account IS 'Cash or bank account included in the cash book report';
@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: User Company Memberships (Rollback)
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS company_id;
+
+DROP TRIGGER IF EXISTS set_user_company_memberships_updated_at ON user_company_memberships;
+
+DROP TABLE IF EXISTS user_company_memberships;
@@ -0,0 +1,56 @@
+-- K-ERP v0.2 Migration: User Company Memberships
+-- Users serving several companies with a per-company role (e.g. outsourced bookkeepers)
+
+-- ============================================
+-- USER COMPANY MEMBERSHIPS
+-- ============================================
+CREATE TABLE user_company_memberships (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    role VARCHAR(50) NOT NULL DEFAULT 'user',
+    is_default BOOLEAN NOT NULL DEFAULT false,  -- the user's home company (users.company_id)
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_user_company_memberships UNIQUE (user_id, company_id),
+    CONSTRAINT chk_membership_role CHECK (role IN ('admin', 'user', 'viewer'))
+);
+
+CREATE INDEX idx_user_company_memberships_company ON user_company_memberships(company_id);
+
+COMMENT ON TABLE user_company_memberships IS 'Companies a user may switch to, with the role held in each';
+
+-- Home company memberships of existing users; the home role is always taken from the user record
+INSERT INTO user_company_memberships (company_id, user_id, is_default, is_active)
+SELECT company_id, id, true, COALESCE(status, 'active') = 'active'
+FROM users
+WHERE deleted_at IS NULL
+ON CONFLICT (user_id, company_id) DO NOTHING;
+
+-- ============================================
+-- SESSION COMPANY
+-- ============================================
+-- Refresh tokens issued by a company switch keep the session in that company
+ALTER TABLE refresh_tokens ADD COLUMN company_id UUID REFERENCES companies(id) ON DELETE CASCADE;
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE user_company_memberships ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_user_company_memberships ON user_company_memberships
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_user_company_memberships ON user_company_memberships
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_user_company_memberships_updated_at
+    BEFORE UPDATE ON user_company_memberships
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
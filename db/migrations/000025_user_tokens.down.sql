@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: User Tokens (Rollback)
+
+DROP TRIGGER IF EXISTS set_user_tokens_updated_at ON user_tokens;
+
+DROP TABLE IF EXISTS user_tokens;
@@ -0,0 +1,47 @@
+-- K-ERP v0.2 Migration: User Tokens
+-- One-time tokens for user invitations and email verification
+
+-- ============================================
+-- USER TOKENS
+-- ============================================
+CREATE TABLE user_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    purpose VARCHAR(30) NOT NULL CHECK (purpose IN ('invite', 'email_verification')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,  -- SHA-256 of the emailed token
+    email VARCHAR(255) NOT NULL,
+
+    -- Invite: role granted on acceptance; verification: user being verified
+    role VARCHAR(50) CHECK (role IN ('admin', 'user', 'viewer')),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_tokens_pending ON user_tokens(company_id, purpose, email) WHERE used_at IS NULL;
+
+COMMENT ON TABLE user_tokens IS 'Invitation and email verification tokens; only the token hash is stored';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE user_tokens ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_user_tokens ON user_tokens
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_user_tokens ON user_tokens
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_user_tokens_updated_at
+    BEFORE UPDATE ON user_tokens
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: User Administration Fields (Rollback)
+-- users.role is kept: it may predate this migration
+
+DROP INDEX IF EXISTS idx_users_role;
+
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
@@ -0,0 +1,13 @@
+-- K-ERP v0.2 Migration: User Administration Fields
+-- Company role on the user record and administrator-forced password resets
+
+-- Role held in the home company (admin, user, viewer); the last active admin cannot be removed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(50) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('admin', 'user', 'viewer'));
+
+-- Set by a forced reset; cleared when the user changes the temporary password
+ALTER TABLE users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_users_role ON users(company_id, role) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN users.must_change_password IS 'Password was reset by an administrator and must be changed at next login';
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Data Exports (Rollback)
+
+DROP TRIGGER IF EXISTS set_data_exports_updated_at ON data_exports;
+
+DROP TABLE IF EXISTS data_exports;
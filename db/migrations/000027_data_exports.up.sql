@@ -0,0 +1,53 @@
+-- K-ERP v0.2 Migration: Data Exports
+-- Full tenant data exports generated by the worker and downloaded through signed links
+
+-- ============================================
+-- DATA EXPORTS
+-- ============================================
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    format VARCHAR(10) NOT NULL DEFAULT 'csv' CHECK (format IN ('csv', 'json')),
+
+    -- Zip archive with one file per dataset and a manifest.json
+    file_name VARCHAR(200),
+    file_size INTEGER NOT NULL DEFAULT 0,
+    file_data BYTEA,
+    row_counts JSONB,
+    error TEXT,
+
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,  -- the row and its archive are deleted after this time
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_data_exports_company ON data_exports(company_id, created_at DESC);
+CREATE INDEX idx_data_exports_active ON data_exports(created_at) WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_data_exports_expires ON data_exports(expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON TABLE data_exports IS 'Full tenant data export archives (accounts, vouchers, ledger, partners, tax invoices)';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE data_exports ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_data_exports ON data_exports
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_data_exports ON data_exports
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_data_exports_updated_at
+    BEFORE UPDATE ON data_exports
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,95 @@
+-- K-ERP v0.2 Migration: Voucher Entries Partitioning (Rollback)
+
+-- ============================================
+-- VOUCHER_ENTRIES (Unpartitioned)
+-- ============================================
+CREATE TABLE voucher_entries_unpartitioned (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    -- Entry info
+    line_no INTEGER NOT NULL,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+
+    -- Amounts (one must be zero)
+    debit_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    credit_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+
+    -- Description
+    description VARCHAR(200),
+
+    -- Dimensions
+    partner_id UUID REFERENCES partners(id),
+    department_id UUID REFERENCES departments(id),
+    project_id UUID REFERENCES projects(id),
+    cost_center_id UUID REFERENCES cost_centers(id),
+
+    -- Tags for analysis
+    tags JSONB DEFAULT '[]',
+
+    -- VAT
+    tax_code_id UUID REFERENCES tax_codes(id),
+    tax_amount DECIMAL(18,2) NOT NULL DEFAULT 0,
+    is_tax_line BOOLEAN DEFAULT FALSE,
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(voucher_id, line_no),
+    CONSTRAINT chk_entry_amount CHECK (
+        (debit_amount > 0 AND credit_amount = 0) OR
+        (debit_amount = 0 AND credit_amount > 0)
+    )
+);
+
+INSERT INTO voucher_entries_unpartitioned (
+    id, voucher_id, company_id, line_no, account_id,
+    debit_amount, credit_amount, description,
+    partner_id, department_id, project_id, cost_center_id, tags,
+    tax_code_id, tax_amount, is_tax_line, created_at
+)
+SELECT
+    id, voucher_id, company_id, line_no, account_id,
+    debit_amount, credit_amount, description,
+    partner_id, department_id, project_id, cost_center_id, tags,
+    tax_code_id, tax_amount, is_tax_line, created_at
+FROM voucher_entries;
+
+-- Drops every partition as well
+DROP TABLE voucher_entries;
+DROP FUNCTION IF EXISTS create_voucher_entries_partition(INTEGER, INTEGER);
+
+ALTER TABLE voucher_entries_unpartitioned RENAME TO voucher_entries;
+ALTER INDEX voucher_entries_unpartitioned_pkey RENAME TO voucher_entries_pkey;
+ALTER TABLE voucher_entries RENAME CONSTRAINT voucher_entries_unpartitioned_voucher_id_line_no_key TO voucher_entries_voucher_id_line_no_key;
+
+-- ============================================
+-- INDEXES
+-- ============================================
+CREATE INDEX idx_voucher_entries_voucher ON voucher_entries(voucher_id);
+CREATE INDEX idx_voucher_entries_account ON voucher_entries(company_id, account_id);
+CREATE INDEX idx_voucher_entries_partner ON voucher_entries(company_id, partner_id) WHERE partner_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_department ON voucher_entries(company_id, department_id) WHERE department_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_project ON voucher_entries(company_id, project_id) WHERE project_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_tax_code ON voucher_entries(company_id, tax_code_id)
+    WHERE tax_code_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_tags_gin
+    ON voucher_entries USING GIN (tags jsonb_path_ops)
+    WHERE tags != '[]'::JSONB;
+
+COMMENT ON TABLE voucher_entries IS 'Individual debit/credit entries within a voucher';
+COMMENT ON COLUMN voucher_entries.tax_amount IS 'VAT amount split from the tax-inclusive entry amount';
+COMMENT ON COLUMN voucher_entries.is_tax_line IS 'Line generated for the VAT amount of a tax-coded entry';
+COMMENT ON CONSTRAINT chk_entry_amount ON voucher_entries IS 'Each line must be either debit or credit, not both';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_entries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_entries ON voucher_entries
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_entries ON voucher_entries
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
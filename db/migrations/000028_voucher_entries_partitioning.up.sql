@@ -0,0 +1,180 @@
+-- K-ERP v0.2 Migration: Voucher Entries Partitioning
+-- voucher_entries is range partitioned by the fiscal year of its voucher so that
+-- period reports only scan the partitions of the years they cover. Yearly
+-- partitions can be split further by hash of company_id for large installations.
+-- Fiscal years follow the calendar, as in ledger_balances.
+
+-- ============================================
+-- SET ASIDE THE UNPARTITIONED TABLE
+-- ============================================
+ALTER TABLE voucher_entries RENAME TO voucher_entries_unpartitioned;
+ALTER INDEX voucher_entries_pkey RENAME TO voucher_entries_unpartitioned_pkey;
+
+DROP INDEX IF EXISTS idx_voucher_entries_voucher;
+DROP INDEX IF EXISTS idx_voucher_entries_account;
+DROP INDEX IF EXISTS idx_voucher_entries_partner;
+DROP INDEX IF EXISTS idx_voucher_entries_department;
+DROP INDEX IF EXISTS idx_voucher_entries_project;
+DROP INDEX IF EXISTS idx_voucher_entries_tags_gin;
+DROP INDEX IF EXISTS idx_voucher_entries_tax_code;
+
+-- ============================================
+-- VOUCHER_ENTRIES (Partitioned by Fiscal Year)
+-- ============================================
+CREATE TABLE voucher_entries (
+    id UUID NOT NULL DEFAULT uuid_generate_v7(),
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    -- Partition key: year of the voucher date
+    fiscal_year INTEGER NOT NULL,
+
+    -- Entry info
+    line_no INTEGER NOT NULL,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+
+    -- Amounts (one must be zero)
+    debit_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    credit_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+
+    -- Description
+    description VARCHAR(200),
+
+    -- Dimensions
+    partner_id UUID REFERENCES partners(id),
+    department_id UUID REFERENCES departments(id),
+    project_id UUID REFERENCES projects(id),
+    cost_center_id UUID REFERENCES cost_centers(id),
+
+    -- Tags for analysis
+    tags JSONB DEFAULT '[]',
+
+    -- VAT
+    tax_code_id UUID REFERENCES tax_codes(id),
+    tax_amount DECIMAL(18,2) NOT NULL DEFAULT 0,
+    is_tax_line BOOLEAN DEFAULT FALSE,
+
+    -- Audit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Unique keys must include the partition key; all entries of a voucher share it
+    PRIMARY KEY (id, fiscal_year),
+    UNIQUE(voucher_id, line_no, fiscal_year),
+    CONSTRAINT chk_entry_amount CHECK (
+        (debit_amount > 0 AND credit_amount = 0) OR
+        (debit_amount = 0 AND credit_amount > 0)
+    )
+) PARTITION BY RANGE (fiscal_year);
+
+-- Entries of years without a partition; the maintenance job moves them out
+CREATE TABLE voucher_entries_default PARTITION OF voucher_entries DEFAULT;
+
+-- ============================================
+-- PARTITION MAINTENANCE
+-- ============================================
+CREATE OR REPLACE FUNCTION create_voucher_entries_partition(p_year INTEGER, p_hash_partitions INTEGER DEFAULT 0)
+RETURNS BOOLEAN AS $$
+DECLARE
+    partition_name TEXT := format('voucher_entries_y%s', p_year);
+    i INTEGER;
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    -- A partition cannot be created while the default partition holds rows of its range
+    CREATE TEMP TABLE voucher_entries_moving (LIKE voucher_entries);
+    WITH moved AS (
+        DELETE FROM voucher_entries_default WHERE fiscal_year = p_year RETURNING *
+    )
+    INSERT INTO voucher_entries_moving SELECT * FROM moved;
+
+    IF p_hash_partitions > 1 THEN
+        EXECUTE format('CREATE TABLE %I PARTITION OF voucher_entries FOR VALUES FROM (%s) TO (%s) PARTITION BY HASH (company_id)',
+            partition_name, p_year, p_year + 1);
+        FOR i IN 0 .. p_hash_partitions - 1 LOOP
+            EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES WITH (MODULUS %s, REMAINDER %s)',
+                partition_name || '_h' || i, partition_name, p_hash_partitions, i);
+        END LOOP;
+    ELSE
+        EXECUTE format('CREATE TABLE %I PARTITION OF voucher_entries FOR VALUES FROM (%s) TO (%s)',
+            partition_name, p_year, p_year + 1);
+    END IF;
+
+    EXECUTE format('INSERT INTO %I SELECT * FROM voucher_entries_moving', partition_name);
+    DROP TABLE voucher_entries_moving;
+
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON FUNCTION create_voucher_entries_partition(INTEGER, INTEGER) IS
+    'Create the voucher_entries partition of a fiscal year, optionally hash partitioned by company';
+
+-- Partitions of every year with vouchers through next year
+DO $$
+DECLARE
+    first_year INTEGER;
+    y INTEGER;
+BEGIN
+    SELECT COALESCE(MIN(EXTRACT(YEAR FROM voucher_date))::INTEGER, EXTRACT(YEAR FROM CURRENT_DATE)::INTEGER)
+    INTO first_year
+    FROM vouchers;
+
+    FOR y IN first_year .. EXTRACT(YEAR FROM CURRENT_DATE)::INTEGER + 1 LOOP
+        PERFORM create_voucher_entries_partition(y);
+    END LOOP;
+END;
+$$;
+
+-- ============================================
+-- COPY ENTRIES
+-- ============================================
+INSERT INTO voucher_entries (
+    id, voucher_id, company_id, fiscal_year, line_no, account_id,
+    debit_amount, credit_amount, description,
+    partner_id, department_id, project_id, cost_center_id, tags,
+    tax_code_id, tax_amount, is_tax_line, created_at
+)
+SELECT
+    ve.id, ve.voucher_id, ve.company_id, EXTRACT(YEAR FROM v.voucher_date)::INTEGER, ve.line_no, ve.account_id,
+    ve.debit_amount, ve.credit_amount, ve.description,
+    ve.partner_id, ve.department_id, ve.project_id, ve.cost_center_id, ve.tags,
+    ve.tax_code_id, ve.tax_amount, ve.is_tax_line, ve.created_at
+FROM voucher_entries_unpartitioned ve
+JOIN vouchers v ON ve.voucher_id = v.id;
+
+DROP TABLE voucher_entries_unpartitioned;
+
+-- ============================================
+-- INDEXES (created on every partition)
+-- ============================================
+CREATE INDEX idx_voucher_entries_voucher ON voucher_entries(voucher_id);
+CREATE INDEX idx_voucher_entries_account ON voucher_entries(company_id, account_id);
+CREATE INDEX idx_voucher_entries_partner ON voucher_entries(company_id, partner_id) WHERE partner_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_department ON voucher_entries(company_id, department_id) WHERE department_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_project ON voucher_entries(company_id, project_id) WHERE project_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_tax_code ON voucher_entries(company_id, tax_code_id)
+    WHERE tax_code_id IS NOT NULL;
+CREATE INDEX idx_voucher_entries_tags_gin
+    ON voucher_entries USING GIN (tags jsonb_path_ops)
+    WHERE tags != '[]'::JSONB;
+
+COMMENT ON TABLE voucher_entries IS 'Individual debit/credit entries within a voucher, partitioned by fiscal year';
+COMMENT ON COLUMN voucher_entries.fiscal_year IS 'Year of the voucher date; partition key';
+COMMENT ON COLUMN voucher_entries.tax_amount IS 'VAT amount split from the tax-inclusive entry amount';
+COMMENT ON COLUMN voucher_entries.is_tax_line IS 'Line generated for the VAT amount of a tax-coded entry';
+COMMENT ON CONSTRAINT chk_entry_amount ON voucher_entries IS 'Each line must be either debit or credit, not both';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_entries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_entries ON voucher_entries
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_entries ON voucher_entries
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+ANALYZE voucher_entries;
@@ -0,0 +1,4 @@
+-- K-ERP v0.2 Migration: Voucher Filter Indexes (Rollback)
+
+DROP INDEX IF EXISTS idx_vouchers_created_by;
+DROP INDEX IF EXISTS idx_vouchers_no_prefix;
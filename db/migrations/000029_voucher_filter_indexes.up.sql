@@ -0,0 +1,15 @@
+-- K-ERP v0.2 Migration: Voucher Filter Indexes
+-- Indexes for the voucher list filters on number prefix and creator
+
+-- ============================================
+-- COMPOSITE INDEXES: Vouchers
+-- ============================================
+
+-- Voucher number prefix match (LIKE 'GEN-2024%'); text_pattern_ops works under any collation
+CREATE INDEX idx_vouchers_no_prefix
+    ON vouchers(company_id, voucher_no text_pattern_ops);
+
+-- Vouchers by creator
+CREATE INDEX idx_vouchers_created_by
+    ON vouchers(company_id, created_by)
+    WHERE created_by IS NOT NULL;
@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Voucher Status History (Rollback)
+
+DROP TRIGGER IF EXISTS record_vouchers_status ON vouchers;
+DROP FUNCTION IF EXISTS trigger_record_voucher_status();
+
+DROP TABLE IF EXISTS voucher_status_history;
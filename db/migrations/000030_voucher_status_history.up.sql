@@ -0,0 +1,98 @@
+-- K-ERP v0.2 Migration: Voucher Status History
+-- Every status change of a voucher is recorded by a trigger so that the voucher
+-- detail can show its workflow timeline, including earlier rejections and
+-- resubmissions whose timestamps are overwritten on the voucher itself.
+
+-- ============================================
+-- VOUCHER STATUS HISTORY
+-- ============================================
+CREATE TABLE voucher_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+
+    -- NULL from_status is the creation of the voucher
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+
+    changed_by UUID REFERENCES users(id),
+    reason VARCHAR(500),
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_voucher_status_history_voucher ON voucher_status_history(company_id, voucher_id, changed_at);
+
+COMMENT ON TABLE voucher_status_history IS 'Voucher workflow timeline written by trigger; rows are append-only';
+COMMENT ON COLUMN voucher_status_history.changed_by IS 'User recorded on the voucher for the new status (submitted_by, approved_by, ...)';
+
+-- ============================================
+-- TRIGGER: Record Status Changes
+-- ============================================
+CREATE OR REPLACE FUNCTION trigger_record_voucher_status()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_status VARCHAR(20);
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.status IS NOT DISTINCT FROM OLD.status THEN
+            RETURN NEW;
+        END IF;
+        old_status := OLD.status;
+    END IF;
+
+    INSERT INTO voucher_status_history (company_id, voucher_id, from_status, to_status, changed_by, reason)
+    VALUES (
+        NEW.company_id, NEW.id, old_status, NEW.status,
+        CASE
+            WHEN TG_OP = 'INSERT' THEN NEW.created_by
+            WHEN NEW.status = 'pending' THEN NEW.submitted_by
+            WHEN NEW.status = 'approved' THEN NEW.approved_by
+            WHEN NEW.status = 'rejected' THEN NEW.rejected_by
+            WHEN NEW.status = 'posted' THEN NEW.posted_by
+        END,
+        CASE WHEN NEW.status = 'rejected' THEN NEW.rejection_reason END
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_vouchers_status
+    AFTER INSERT OR UPDATE OF status ON vouchers
+    FOR EACH ROW EXECUTE FUNCTION trigger_record_voucher_status();
+
+-- ============================================
+-- BACKFILL
+-- ============================================
+-- Existing vouchers get the steps their timestamps still show
+INSERT INTO voucher_status_history (company_id, voucher_id, from_status, to_status, changed_by, reason, changed_at)
+SELECT company_id, id, NULL, 'draft', created_by, NULL, created_at FROM vouchers
+UNION ALL
+SELECT company_id, id, 'draft', 'pending', submitted_by, NULL, submitted_at FROM vouchers WHERE submitted_at IS NOT NULL
+UNION ALL
+SELECT company_id, id, 'pending', 'rejected', rejected_by, rejection_reason, rejected_at FROM vouchers WHERE rejected_at IS NOT NULL
+UNION ALL
+SELECT company_id, id, 'pending', 'approved', approved_by, NULL, approved_at FROM vouchers WHERE approved_at IS NOT NULL
+UNION ALL
+SELECT company_id, id, CASE WHEN approved_at IS NULL THEN 'draft' ELSE 'approved' END, 'posted', posted_by, NULL, posted_at
+FROM vouchers WHERE posted_at IS NOT NULL
+UNION ALL
+SELECT company_id, id,
+    CASE
+        WHEN approved_at IS NOT NULL THEN 'approved'
+        WHEN rejected_at IS NOT NULL THEN 'rejected'
+        WHEN submitted_at IS NOT NULL THEN 'pending'
+        ELSE 'draft'
+    END,
+    'cancelled', NULL, NULL, updated_at
+FROM vouchers WHERE status = 'cancelled';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_status_history ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_status_history ON voucher_status_history
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_status_history ON voucher_status_history
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
@@ -0,0 +1,16 @@
+-- K-ERP v0.2 Migration: Attachment Virus Scanning (Rollback)
+
+DROP TABLE IF EXISTS quarantined_files;
+
+-- Attachments stored in the database have no storage path to keep
+DELETE FROM voucher_attachments WHERE storage_path IS NULL;
+UPDATE vouchers v SET attachment_count = (
+    SELECT COUNT(*) FROM voucher_attachments a WHERE a.voucher_id = v.id
+) WHERE attachment_count > 0;
+
+ALTER TABLE voucher_attachments DROP COLUMN IF EXISTS scanned_at;
+ALTER TABLE voucher_attachments DROP COLUMN IF EXISTS scanner;
+ALTER TABLE voucher_attachments DROP COLUMN IF EXISTS scan_status;
+ALTER TABLE voucher_attachments ALTER COLUMN file_size TYPE INTEGER;
+ALTER TABLE voucher_attachments ALTER COLUMN storage_path SET NOT NULL;
+ALTER TABLE voucher_attachments DROP COLUMN IF EXISTS file_data;
@@ -0,0 +1,56 @@
+-- K-ERP v0.2 Migration: Attachment Virus Scanning
+-- Voucher attachments are stored in the database and scanned for malware before
+-- they are stored or served. Infected files are moved to quarantined_files.
+
+-- ============================================
+-- VOUCHER_ATTACHMENTS: Content and Scan Verdict
+-- ============================================
+ALTER TABLE voucher_attachments ADD COLUMN file_data BYTEA;
+ALTER TABLE voucher_attachments ALTER COLUMN storage_path DROP NOT NULL;
+ALTER TABLE voucher_attachments ALTER COLUMN file_size TYPE BIGINT;
+ALTER TABLE voucher_attachments ADD COLUMN scan_status VARCHAR(20) NOT NULL DEFAULT 'unscanned'
+    CHECK (scan_status IN ('clean', 'unscanned'));
+ALTER TABLE voucher_attachments ADD COLUMN scanner VARCHAR(50);
+ALTER TABLE voucher_attachments ADD COLUMN scanned_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN voucher_attachments.file_data IS 'File content; storage_path is kept for files stored outside the database';
+COMMENT ON COLUMN voucher_attachments.scan_status IS 'unscanned files are scanned before they are first served';
+
+-- ============================================
+-- QUARANTINED FILES
+-- ============================================
+CREATE TABLE quarantined_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    -- File info
+    file_name VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    file_type VARCHAR(100),
+    file_data BYTEA,
+
+    -- Verdict
+    signature VARCHAR(255) NOT NULL,
+    scanner VARCHAR(50) NOT NULL,
+
+    -- Upload context
+    uploaded_by UUID REFERENCES users(id),
+    ip_address VARCHAR(45),
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quarantined_files_company ON quarantined_files(company_id, detected_at DESC);
+
+COMMENT ON TABLE quarantined_files IS 'Uploaded files in which malware was detected; never served to users';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE quarantined_files ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_quarantined_files ON quarantined_files
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_quarantined_files ON quarantined_files
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
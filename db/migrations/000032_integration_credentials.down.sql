@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Integration Credentials (Rollback)
+
+DROP TABLE IF EXISTS integration_credentials;
+
+COMMENT ON TABLE popbill_configs IS 'Popbill API configuration per company';
@@ -0,0 +1,58 @@
+-- K-ERP v0.2 Migration: Integration Credentials
+-- Per-company credentials of external services (Popbill, bank accounts), stored
+-- with envelope encryption: the JSON credentials are encrypted with a per-row
+-- data key, which is itself encrypted with the master key identified by key_id.
+
+-- ============================================
+-- INTEGRATION CREDENTIALS
+-- ============================================
+CREATE TABLE integration_credentials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('popbill', 'bank')),
+    identifier VARCHAR(100) NOT NULL,  -- 'default' for Popbill, bank code and account number for banks
+    hint VARCHAR(200) NOT NULL DEFAULT '',  -- non-secret summary shown in listings
+
+    -- Envelope encryption
+    key_id VARCHAR(100) NOT NULL,
+    encrypted_key BYTEA NOT NULL,
+    ciphertext BYTEA NOT NULL,
+
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Result of the last credential test
+    last_tested_at TIMESTAMPTZ,
+    last_test_succeeded BOOLEAN,
+    last_test_error TEXT,
+
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(company_id, provider, identifier)
+);
+
+CREATE INDEX idx_integration_credentials_key ON integration_credentials(key_id);
+
+COMMENT ON TABLE integration_credentials IS 'Envelope encrypted credentials of external integrations per company';
+COMMENT ON COLUMN integration_credentials.key_id IS 'Master key the data key is encrypted with; used to find rows to re-seal after a rotation';
+COMMENT ON TABLE popbill_configs IS 'Unused; superseded by integration_credentials';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE integration_credentials ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_integration_credentials ON integration_credentials
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_integration_credentials ON integration_credentials
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_integration_credentials_updated_at
+    BEFORE UPDATE ON integration_credentials
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
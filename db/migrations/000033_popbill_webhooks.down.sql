@@ -0,0 +1,4 @@
+-- K-ERP v0.2 Migration: Popbill Webhooks (Rollback)
+
+DROP INDEX IF EXISTS idx_tax_invoices_asp_invoice;
+DROP TABLE IF EXISTS popbill_webhook_events;
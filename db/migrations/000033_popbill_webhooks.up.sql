@@ -0,0 +1,56 @@
+-- K-ERP v0.2 Migration: Popbill Webhooks
+-- Tax invoice state callbacks from Popbill. Each verified callback is stored on
+-- arrival and applied to its invoice by the worker; event_key rejects replays.
+-- The company is filled in once the invoice of the callback has been found.
+
+-- ============================================
+-- POPBILL WEBHOOK EVENTS
+-- ============================================
+CREATE TABLE popbill_webhook_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,
+
+    event_key VARCHAR(100) NOT NULL UNIQUE,  -- Popbill event ID, or SHA-256 of the body
+    event_type VARCHAR(50) NOT NULL DEFAULT '',
+    corp_num VARCHAR(20) NOT NULL DEFAULT '',
+    item_key VARCHAR(100) NOT NULL,
+    state_code INTEGER NOT NULL DEFAULT 0,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ,
+
+    -- Processing
+    status VARCHAR(20) NOT NULL DEFAULT 'received'
+        CHECK (status IN ('received', 'processing', 'processed', 'ignored', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    tax_invoice_id UUID REFERENCES tax_invoices(id) ON DELETE SET NULL,
+
+    remote_addr VARCHAR(45) NOT NULL DEFAULT '',
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    processed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_popbill_webhook_events_pending ON popbill_webhook_events(received_at)
+    WHERE status IN ('received', 'processing', 'failed');
+CREATE INDEX idx_popbill_webhook_events_company ON popbill_webhook_events(company_id, received_at DESC)
+    WHERE company_id IS NOT NULL;
+
+-- Invoices are looked up by their Popbill document number
+CREATE INDEX idx_tax_invoices_asp_invoice ON tax_invoices(asp_provider, asp_invoice_id)
+    WHERE asp_invoice_id IS NOT NULL;
+
+COMMENT ON TABLE popbill_webhook_events IS 'Tax invoice state callbacks received from Popbill';
+COMMENT ON COLUMN popbill_webhook_events.company_id IS 'Company of the matched invoice; NULL until the callback is applied';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE popbill_webhook_events ENABLE ROW LEVEL SECURITY;
+
+-- Callbacks arrive without a tenant; only the admin context sees unmatched ones
+CREATE POLICY tenant_isolation_popbill_webhook_events ON popbill_webhook_events
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_popbill_webhook_events ON popbill_webhook_events
+    FOR INSERT WITH CHECK (company_id IS NULL OR company_id = current_tenant_id() OR is_admin_context());
@@ -0,0 +1,4 @@
+-- K-ERP v0.2 Migration: Tax Invoice Bulk Issues (Rollback)
+
+DROP TABLE IF EXISTS tax_invoice_bulk_issue_items;
+DROP TABLE IF EXISTS tax_invoice_bulk_issues;
@@ -0,0 +1,83 @@
+-- K-ERP v0.2 Migration: Tax Invoice Bulk Issues
+-- Issuance of many draft sales invoices through Popbill at once. The result of
+-- every invoice is kept so that only the failed ones are sent again on retry.
+
+-- ============================================
+-- TAX INVOICE BULK ISSUES
+-- ============================================
+CREATE TABLE tax_invoice_bulk_issues (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'processing'
+        CHECK (status IN ('processing', 'completed', 'partially_failed', 'failed')),
+    total_count INTEGER NOT NULL DEFAULT 0,
+    succeeded_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 1,
+
+    requested_by UUID REFERENCES users(id),
+    completed_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tax_invoice_bulk_issues_company ON tax_invoice_bulk_issues(company_id, created_at DESC);
+
+COMMENT ON TABLE tax_invoice_bulk_issues IS 'Bulk issuance requests of tax invoices through Popbill';
+
+-- ============================================
+-- TAX INVOICE BULK ISSUE ITEMS
+-- ============================================
+CREATE TABLE tax_invoice_bulk_issue_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    bulk_issue_id UUID NOT NULL REFERENCES tax_invoice_bulk_issues(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    tax_invoice_id UUID NOT NULL REFERENCES tax_invoices(id) ON DELETE CASCADE,
+    line_no INTEGER NOT NULL,
+
+    -- Result of the last attempt
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed')),
+    nts_confirm_number VARCHAR(50),
+    asp_invoice_id VARCHAR(100),
+    error_code VARCHAR(50),
+    error_message TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (bulk_issue_id, tax_invoice_id)
+);
+
+CREATE INDEX idx_tax_invoice_bulk_issue_items_issue ON tax_invoice_bulk_issue_items(bulk_issue_id, line_no);
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE tax_invoice_bulk_issues ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tax_invoice_bulk_issue_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tax_invoice_bulk_issues ON tax_invoice_bulk_issues
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_tax_invoice_bulk_issues ON tax_invoice_bulk_issues
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_tax_invoice_bulk_issue_items ON tax_invoice_bulk_issue_items
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_tax_invoice_bulk_issue_items ON tax_invoice_bulk_issue_items
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_tax_invoice_bulk_issues_updated_at
+    BEFORE UPDATE ON tax_invoice_bulk_issues
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_tax_invoice_bulk_issue_items_updated_at
+    BEFORE UPDATE ON tax_invoice_bulk_issue_items
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
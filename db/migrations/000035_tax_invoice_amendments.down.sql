@@ -0,0 +1,8 @@
+-- K-ERP v0.2 Migration: Tax Invoice Amendments (Rollback)
+
+DROP INDEX IF EXISTS idx_tax_invoices_original;
+ALTER TABLE tax_invoices
+    DROP CONSTRAINT IF EXISTS chk_tax_invoices_amendment,
+    DROP COLUMN IF EXISTS original_nts_confirm_number,
+    DROP COLUMN IF EXISTS original_invoice_id,
+    DROP COLUMN IF EXISTS amendment_reason;
@@ -0,0 +1,20 @@
+-- K-ERP v0.2 Migration: Tax Invoice Amendments
+-- Amended tax invoices (수정세금계산서) reference the original invoice and its
+-- NTS confirmation number, with the NTS reason code of the amendment.
+
+-- ============================================
+-- TAX INVOICES
+-- ============================================
+ALTER TABLE tax_invoices
+    ADD COLUMN amendment_reason SMALLINT NOT NULL DEFAULT 0
+        CHECK (amendment_reason BETWEEN 0 AND 6),  -- 0: original invoice
+    ADD COLUMN original_invoice_id UUID REFERENCES tax_invoices(id) ON DELETE RESTRICT,
+    ADD COLUMN original_nts_confirm_number VARCHAR(50),
+    ADD CONSTRAINT chk_tax_invoices_amendment
+        CHECK ((amendment_reason = 0) = (original_invoice_id IS NULL));
+
+CREATE INDEX idx_tax_invoices_original ON tax_invoices(original_invoice_id)
+    WHERE original_invoice_id IS NOT NULL;
+
+COMMENT ON COLUMN tax_invoices.amendment_reason IS 'NTS amendment reason: 1 entry error, 2 supply change, 3 return, 4 contract cancellation, 5 local L/C, 6 duplicate issuance';
+COMMENT ON COLUMN tax_invoices.original_invoice_id IS 'Original invoice of an amended invoice';
@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Tax Invoice Deliveries (Rollback)
+
+DROP TABLE IF EXISTS tax_invoice_deliveries;
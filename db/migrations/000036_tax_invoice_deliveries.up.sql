@@ -0,0 +1,49 @@
+-- K-ERP v0.2 Migration: Tax Invoice Deliveries
+-- Emails of tax invoices to the buyer. The Message-ID of every email is kept so
+-- that bounce notices of the mail provider can be matched to the invoice.
+
+-- ============================================
+-- TAX INVOICE DELIVERIES
+-- ============================================
+CREATE TABLE tax_invoice_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    tax_invoice_id UUID NOT NULL REFERENCES tax_invoices(id) ON DELETE CASCADE,
+
+    recipient VARCHAR(255) NOT NULL,
+    cc TEXT,
+    message_id VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('sent', 'failed', 'bounced')),
+    error TEXT,
+
+    sent_by UUID REFERENCES users(id),
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    bounced_at TIMESTAMPTZ,
+    bounce_reason TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tax_invoice_deliveries_invoice ON tax_invoice_deliveries(company_id, tax_invoice_id, sent_at DESC);
+
+COMMENT ON TABLE tax_invoice_deliveries IS 'Emails of tax invoices to buyers with their delivery status';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE tax_invoice_deliveries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tax_invoice_deliveries ON tax_invoice_deliveries
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_tax_invoice_deliveries ON tax_invoice_deliveries
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_tax_invoice_deliveries_updated_at
+    BEFORE UPDATE ON tax_invoice_deliveries
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,10 @@
+-- K-ERP v0.2 Migration: Partner Business Verification (Rollback)
+
+DROP INDEX IF EXISTS idx_partners_business_status;
+DROP INDEX IF EXISTS idx_partners_business_verified;
+ALTER TABLE partners
+    DROP COLUMN IF EXISTS business_verified_at,
+    DROP COLUMN IF EXISTS representative_verified,
+    DROP COLUMN IF EXISTS business_closed_on,
+    DROP COLUMN IF EXISTS business_tax_type,
+    DROP COLUMN IF EXISTS business_status;
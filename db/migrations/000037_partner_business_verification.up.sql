@@ -0,0 +1,24 @@
+-- K-ERP v0.2 Migration: Partner Business Verification
+-- Registration status of partner business numbers as reported by the NTS
+-- business status API (사업자등록 상태조회). The worker verifies partners again
+-- periodically so that closed businesses are flagged.
+
+-- ============================================
+-- PARTNERS
+-- ============================================
+ALTER TABLE partners
+    ADD COLUMN business_status VARCHAR(20) NOT NULL DEFAULT ''
+        CHECK (business_status IN ('', 'active', 'suspended', 'closed', 'unregistered')),  -- '': not verified
+    ADD COLUMN business_tax_type VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN business_closed_on DATE,
+    ADD COLUMN representative_verified BOOLEAN,
+    ADD COLUMN business_verified_at TIMESTAMPTZ;
+
+CREATE INDEX idx_partners_business_verified ON partners(business_verified_at NULLS FIRST)
+    WHERE is_active = true AND deleted_at IS NULL;
+CREATE INDEX idx_partners_business_status ON partners(company_id, business_status)
+    WHERE business_status IN ('suspended', 'closed', 'unregistered');
+
+COMMENT ON COLUMN partners.business_status IS 'NTS registration status: active, suspended, closed or unregistered';
+COMMENT ON COLUMN partners.business_closed_on IS 'Closing date reported by NTS';
+COMMENT ON COLUMN partners.representative_verified IS 'Whether the representative matched the NTS registration; NULL if not checked';
@@ -0,0 +1,4 @@
+-- K-ERP v0.2 Migration: AP Matching (Rollback)
+
+DROP INDEX IF EXISTS idx_vouchers_unreferenced_purchase;
+DROP INDEX IF EXISTS idx_tax_invoices_unmatched_purchase;
@@ -0,0 +1,16 @@
+-- K-ERP v0.2 Migration: AP Matching
+-- Received purchase tax invoices are matched to the purchase vouchers booking
+-- them. The match is kept in tax_invoices.voucher_id and in the reference
+-- document of the voucher; these indexes serve the unmatched worklist.
+
+-- ============================================
+-- TAX INVOICES
+-- ============================================
+CREATE INDEX idx_tax_invoices_unmatched_purchase ON tax_invoices(company_id, issue_date)
+    WHERE invoice_type = 'purchase' AND voucher_id IS NULL;
+
+-- ============================================
+-- VOUCHERS
+-- ============================================
+CREATE INDEX idx_vouchers_unreferenced_purchase ON vouchers(company_id, voucher_date)
+    WHERE voucher_type = 'purchase' AND reference_id IS NULL;
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Email Inbox (Rollback)
+
+DROP TABLE IF EXISTS inbox_items;
+DROP TABLE IF EXISTS inbox_documents;
+DROP TABLE IF EXISTS inbox_aliases;
@@ -0,0 +1,94 @@
+-- K-ERP v0.2 Migration: Email Inbox
+-- Suppliers email invoices to a per-company alias address. The PDF and XML
+-- attachments are stored as documents and queued as inbox items until a clerk
+-- converts them into vouchers or discards them.
+
+-- ============================================
+-- INBOX ALIASES
+-- ============================================
+CREATE TABLE inbox_aliases (
+    company_id UUID PRIMARY KEY REFERENCES companies(id) ON DELETE CASCADE,
+    alias VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE inbox_aliases IS 'Local part of the inbound invoice address of each company';
+
+-- ============================================
+-- INBOX DOCUMENTS
+-- ============================================
+CREATE TABLE inbox_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    file_size BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    data BYTEA NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE inbox_documents IS 'Invoice files received by email';
+
+-- ============================================
+-- INBOX ITEMS
+-- ============================================
+CREATE TABLE inbox_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES inbox_documents(id) ON DELETE CASCADE,
+
+    message_id VARCHAR(255),
+    sender VARCHAR(255),
+    subject VARCHAR(500),
+    received_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processed', 'discarded')),
+
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    processed_by UUID REFERENCES users(id),
+    processed_at TIMESTAMPTZ,
+    note VARCHAR(500),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inbox_items_queue ON inbox_items(company_id, status, received_at);
+CREATE INDEX idx_inbox_items_message ON inbox_items(company_id, message_id) WHERE message_id IS NOT NULL;
+
+COMMENT ON TABLE inbox_items IS 'Invoices received by email, to be converted into vouchers';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE inbox_aliases ENABLE ROW LEVEL SECURITY;
+ALTER TABLE inbox_documents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE inbox_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_inbox_aliases ON inbox_aliases
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_inbox_aliases ON inbox_aliases
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_inbox_documents ON inbox_documents
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_inbox_documents ON inbox_documents
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_inbox_items ON inbox_items
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_inbox_items ON inbox_items
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_inbox_items_updated_at
+    BEFORE UPDATE ON inbox_items
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Loans (Rollback)
+
+DROP TABLE IF EXISTS loan_interest_accruals;
+DROP TABLE IF EXISTS loan_repayments;
+DROP TABLE IF EXISTS loan_installments;
+DROP TABLE IF EXISTS loans;
@@ -0,0 +1,144 @@
+-- K-ERP v0.2 Migration: Loans
+-- Borrowings with their monthly repayment schedule. Repayments generate payment
+-- vouchers split into principal and interest; the worker accrues the unpaid
+-- interest of every month end with an auto-reversing voucher.
+
+-- ============================================
+-- LOANS
+-- ============================================
+CREATE TABLE loans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    loan_no VARCHAR(50) NOT NULL,
+    lender VARCHAR(200) NOT NULL,
+    partner_id UUID REFERENCES partners(id),
+    principal BIGINT NOT NULL CHECK (principal > 0),
+    interest_rate DECIMAL(7,4) NOT NULL CHECK (interest_rate >= 0 AND interest_rate < 100),
+    start_date DATE NOT NULL,
+    maturity_date DATE NOT NULL,
+    repayment_method VARCHAR(20) NOT NULL
+        CHECK (repayment_method IN ('bullet', 'equal_principal', 'equal_payment')),
+    description VARCHAR(500),
+
+    loan_account_id UUID NOT NULL REFERENCES accounts(id),
+    interest_expense_account_id UUID NOT NULL REFERENCES accounts(id),
+    accrued_interest_account_id UUID NOT NULL REFERENCES accounts(id),
+    settlement_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    outstanding_principal BIGINT NOT NULL CHECK (outstanding_principal >= 0),
+    interest_paid_through DATE NOT NULL,
+    accrued_through DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'repaid')),
+
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_loans_loan_no UNIQUE (company_id, loan_no),
+    CONSTRAINT chk_loans_term CHECK (maturity_date > start_date)
+);
+
+CREATE INDEX idx_loans_accrual ON loans(status, accrued_through) WHERE status = 'active';
+
+COMMENT ON TABLE loans IS 'Borrowings of the company with their outstanding principal';
+
+-- ============================================
+-- LOAN INSTALLMENTS
+-- ============================================
+CREATE TABLE loan_installments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    loan_id UUID NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+
+    seq INTEGER NOT NULL,
+    due_date DATE NOT NULL,
+    principal BIGINT NOT NULL,
+    interest BIGINT NOT NULL,
+    paid_principal BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT uq_loan_installments_seq UNIQUE (loan_id, seq)
+);
+
+COMMENT ON TABLE loan_installments IS 'Monthly repayment schedule of loans';
+
+-- ============================================
+-- LOAN REPAYMENTS
+-- ============================================
+CREATE TABLE loan_repayments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    loan_id UUID NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+
+    repayment_date DATE NOT NULL,
+    principal BIGINT NOT NULL CHECK (principal >= 0),
+    interest BIGINT NOT NULL CHECK (interest >= 0),
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_loan_repayments_loan ON loan_repayments(company_id, loan_id, repayment_date);
+
+COMMENT ON TABLE loan_repayments IS 'Payments to lenders split into principal and interest';
+
+-- ============================================
+-- LOAN INTEREST ACCRUALS
+-- ============================================
+CREATE TABLE loan_interest_accruals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    loan_id UUID NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+
+    period_end DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_loan_interest_accruals_period UNIQUE (loan_id, period_end)
+);
+
+COMMENT ON TABLE loan_interest_accruals IS 'Month-end interest accrual vouchers of loans';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE loans ENABLE ROW LEVEL SECURITY;
+ALTER TABLE loan_installments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE loan_repayments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE loan_interest_accruals ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_loans ON loans
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_loans ON loans
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_loan_installments ON loan_installments
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_loan_installments ON loan_installments
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_loan_repayments ON loan_repayments
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_loan_repayments ON loan_repayments
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_loan_interest_accruals ON loan_interest_accruals
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_loan_interest_accruals ON loan_interest_accruals
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_loans_updated_at
+    BEFORE UPDATE ON loans
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Government Grants (Rollback)
+
+DROP TABLE IF EXISTS grant_recognitions;
+DROP TABLE IF EXISTS grant_expenses;
+DROP TABLE IF EXISTS grant_receipts;
+DROP TABLE IF EXISTS grants;
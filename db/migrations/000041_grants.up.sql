@@ -0,0 +1,149 @@
+-- K-ERP v0.2 Migration: Government Grants
+-- Awarded government subsidies (정부보조금). Money received is deferred and
+-- recognized as income by the worker every month end, as the linked eligible
+-- expenses are incurred or evenly over the program.
+
+-- ============================================
+-- GRANTS
+-- ============================================
+CREATE TABLE grants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    grant_no VARCHAR(50) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    agency VARCHAR(200) NOT NULL,
+    partner_id UUID REFERENCES partners(id),
+    awarded_amount BIGINT NOT NULL CHECK (awarded_amount > 0),
+    program_start DATE NOT NULL,
+    program_end DATE NOT NULL,
+    recognition_method VARCHAR(20) NOT NULL
+        CHECK (recognition_method IN ('expense', 'straight_line')),
+    description VARCHAR(500),
+
+    deferred_income_account_id UUID NOT NULL REFERENCES accounts(id),
+    income_account_id UUID NOT NULL REFERENCES accounts(id),
+    bank_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    received_amount BIGINT NOT NULL DEFAULT 0,
+    recognized_amount BIGINT NOT NULL DEFAULT 0,
+    recognized_through DATE,
+
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_grants_grant_no UNIQUE (company_id, grant_no),
+    CONSTRAINT chk_grants_program CHECK (program_end >= program_start),
+    CONSTRAINT chk_grants_received CHECK (received_amount BETWEEN 0 AND awarded_amount),
+    CONSTRAINT chk_grants_recognized CHECK (recognized_amount BETWEEN 0 AND received_amount)
+);
+
+CREATE INDEX idx_grants_recognition ON grants(recognized_through)
+    WHERE received_amount > recognized_amount;
+
+COMMENT ON TABLE grants IS 'Government subsidies awarded to the company with their deferred income';
+
+-- ============================================
+-- GRANT RECEIPTS
+-- ============================================
+CREATE TABLE grant_receipts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    grant_id UUID NOT NULL REFERENCES grants(id) ON DELETE CASCADE,
+
+    receipt_date DATE NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_grant_receipts_grant ON grant_receipts(company_id, grant_id, receipt_date);
+
+COMMENT ON TABLE grant_receipts IS 'Grant money paid in by the awarding agency';
+
+-- ============================================
+-- GRANT EXPENSES
+-- ============================================
+CREATE TABLE grant_expenses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    grant_id UUID NOT NULL REFERENCES grants(id) ON DELETE CASCADE,
+
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    voucher_no VARCHAR(20) NOT NULL,
+    voucher_date DATE NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    description VARCHAR(500),
+
+    linked_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_grant_expenses_voucher UNIQUE (grant_id, voucher_id)
+);
+
+CREATE INDEX idx_grant_expenses_grant ON grant_expenses(company_id, grant_id, voucher_date);
+CREATE INDEX idx_grant_expenses_date ON grant_expenses(company_id, voucher_date);
+
+COMMENT ON TABLE grant_expenses IS 'Eligible expense vouchers funded by grants, for the utilization report';
+
+-- ============================================
+-- GRANT RECOGNITIONS
+-- ============================================
+CREATE TABLE grant_recognitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    grant_id UUID NOT NULL REFERENCES grants(id) ON DELETE CASCADE,
+
+    period_end DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_grant_recognitions_period UNIQUE (grant_id, period_end)
+);
+
+COMMENT ON TABLE grant_recognitions IS 'Month-end income recognition vouchers of grants';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE grants ENABLE ROW LEVEL SECURITY;
+ALTER TABLE grant_receipts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE grant_expenses ENABLE ROW LEVEL SECURITY;
+ALTER TABLE grant_recognitions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_grants ON grants
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_grants ON grants
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_grant_receipts ON grant_receipts
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_grant_receipts ON grant_receipts
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_grant_expenses ON grant_expenses
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_grant_expenses ON grant_expenses
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_grant_recognitions ON grant_recognitions
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_grant_recognitions ON grant_recognitions
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_grants_updated_at
+    BEFORE UPDATE ON grants
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
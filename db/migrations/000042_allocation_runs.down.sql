@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Allocation Runs (Rollback)
+
+DROP TABLE IF EXISTS allocation_runs;
+
+ALTER TABLE department_allocation_rules DROP COLUMN IF EXISTS basis;
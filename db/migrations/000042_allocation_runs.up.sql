@@ -0,0 +1,67 @@
+-- K-ERP v0.2 Migration: Allocation Runs
+-- Allocation rules gain a basis (fixed ratio, headcount or revenue) and project
+-- targets. A period-end run books the allocation of the active rules as one
+-- adjustment voucher; reversing the run cancels or reverses that voucher.
+
+-- ============================================
+-- DEPARTMENT ALLOCATION RULES
+-- ============================================
+ALTER TABLE department_allocation_rules
+    ADD COLUMN basis VARCHAR(20) NOT NULL DEFAULT 'fixed'
+        CHECK (basis IN ('fixed', 'headcount', 'revenue'));
+
+COMMENT ON COLUMN department_allocation_rules.targets IS
+    '[{"department_id": "...", "project_id": "...", "ratio": 60, "headcount": 12}, ...]';
+
+-- ============================================
+-- ALLOCATION RUNS
+-- ============================================
+CREATE TABLE allocation_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'generated'
+        CHECK (status IN ('generated', 'reversed')),
+
+    -- Amounts moved by each rule, account and source department
+    lines JSONB NOT NULL DEFAULT '[]',
+    total_amount DECIMAL(18,2) NOT NULL DEFAULT 0,
+
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    reversal_voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    reversed_by UUID REFERENCES users(id),
+    reversed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_allocation_runs_period CHECK (period_end >= period_start)
+);
+
+-- One live run per period; a reversed period can be run again
+CREATE UNIQUE INDEX uq_allocation_runs_generated ON allocation_runs(company_id, period_end)
+    WHERE status = 'generated';
+CREATE INDEX idx_allocation_runs_company ON allocation_runs(company_id, period_end DESC);
+
+COMMENT ON TABLE allocation_runs IS 'Period-end cost allocation vouchers generated from the allocation rules';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE allocation_runs ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_allocation_runs ON allocation_runs
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_allocation_runs ON allocation_runs
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_allocation_runs_updated_at
+    BEFORE UPDATE ON allocation_runs
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,31 @@
+-- K-ERP v0.2 Migration: Voucher Approval Exemption (Rollback)
+
+CREATE OR REPLACE FUNCTION trigger_record_voucher_status()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_status VARCHAR(20);
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.status IS NOT DISTINCT FROM OLD.status THEN
+            RETURN NEW;
+        END IF;
+        old_status := OLD.status;
+    END IF;
+
+    INSERT INTO voucher_status_history (company_id, voucher_id, from_status, to_status, changed_by, reason)
+    VALUES (
+        NEW.company_id, NEW.id, old_status, NEW.status,
+        CASE
+            WHEN TG_OP = 'INSERT' THEN NEW.created_by
+            WHEN NEW.status = 'pending' THEN NEW.submitted_by
+            WHEN NEW.status = 'approved' THEN NEW.approved_by
+            WHEN NEW.status = 'rejected' THEN NEW.rejected_by
+            WHEN NEW.status = 'posted' THEN NEW.posted_by
+        END,
+        CASE WHEN NEW.status = 'rejected' THEN NEW.rejection_reason END
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE vouchers DROP COLUMN IF EXISTS approval_exempt_reason;
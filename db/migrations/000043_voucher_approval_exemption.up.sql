@@ -0,0 +1,45 @@
+-- K-ERP v0.2 Migration: Voucher Approval Exemption
+-- Companies may let low-value vouchers of some types skip the approval queue
+-- (company settings approval_exemption). Such vouchers are posted on submit;
+-- the posting keeps the exemption as its reason in the status history.
+
+-- ============================================
+-- VOUCHERS
+-- ============================================
+ALTER TABLE vouchers ADD COLUMN approval_exempt_reason VARCHAR(200);
+
+COMMENT ON COLUMN vouchers.approval_exempt_reason IS 'Set when the voucher was posted on submit without approval';
+
+-- ============================================
+-- TRIGGER: Record Status Changes
+-- ============================================
+CREATE OR REPLACE FUNCTION trigger_record_voucher_status()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_status VARCHAR(20);
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.status IS NOT DISTINCT FROM OLD.status THEN
+            RETURN NEW;
+        END IF;
+        old_status := OLD.status;
+    END IF;
+
+    INSERT INTO voucher_status_history (company_id, voucher_id, from_status, to_status, changed_by, reason)
+    VALUES (
+        NEW.company_id, NEW.id, old_status, NEW.status,
+        CASE
+            WHEN TG_OP = 'INSERT' THEN NEW.created_by
+            WHEN NEW.status = 'pending' THEN NEW.submitted_by
+            WHEN NEW.status = 'approved' THEN NEW.approved_by
+            WHEN NEW.status = 'rejected' THEN NEW.rejected_by
+            WHEN NEW.status = 'posted' THEN NEW.posted_by
+        END,
+        CASE
+            WHEN NEW.status = 'rejected' THEN NEW.rejection_reason
+            WHEN NEW.status = 'posted' THEN NULLIF(NEW.approval_exempt_reason, '')
+        END
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
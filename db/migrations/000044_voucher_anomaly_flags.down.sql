@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Voucher Anomaly Flags (Rollback)
+
+DROP TABLE IF EXISTS voucher_anomaly_flags;
+
+DROP INDEX IF EXISTS idx_vouchers_anomaly_unscored;
+ALTER TABLE vouchers DROP COLUMN IF EXISTS anomaly_scored_at;
@@ -0,0 +1,67 @@
+-- K-ERP v0.2 Migration: Voucher Anomaly Flags
+-- Posted vouchers are scored by the worker: entry amounts far from the usual
+-- amounts of their account, weekend postings and large round amounts are
+-- flagged for review by the controllers.
+
+-- ============================================
+-- VOUCHERS
+-- ============================================
+ALTER TABLE vouchers ADD COLUMN anomaly_scored_at TIMESTAMPTZ;
+
+-- Vouchers posted before scoring existed are not scored, so that the worklist
+-- starts with new postings instead of the whole history
+UPDATE vouchers SET anomaly_scored_at = NOW() WHERE status = 'posted';
+
+CREATE INDEX idx_vouchers_anomaly_unscored ON vouchers(posted_at)
+    WHERE status = 'posted' AND anomaly_scored_at IS NULL;
+
+-- ============================================
+-- VOUCHER ANOMALY FLAGS
+-- ============================================
+CREATE TABLE voucher_anomaly_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    voucher_id UUID NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+
+    -- voucher_entries is partitioned, so the entry is not a foreign key
+    entry_id UUID,
+    account_id UUID REFERENCES accounts(id),
+
+    rule VARCHAR(30) NOT NULL
+        CHECK (rule IN ('amount_outlier', 'weekend_posting', 'round_amount')),
+    score DECIMAL(8,2) NOT NULL DEFAULT 0,
+    detail VARCHAR(300),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'dismissed', 'confirmed')),
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMPTZ,
+    review_note VARCHAR(500),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_voucher_anomaly_flags_worklist ON voucher_anomaly_flags(company_id, status, score DESC);
+CREATE INDEX idx_voucher_anomaly_flags_voucher ON voucher_anomaly_flags(voucher_id);
+
+COMMENT ON TABLE voucher_anomaly_flags IS 'Unusual postings flagged by anomaly scoring for review';
+COMMENT ON COLUMN voucher_anomaly_flags.score IS 'Z-score of amount outliers; 1 for the weekend and round amount heuristics';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_anomaly_flags ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_anomaly_flags ON voucher_anomaly_flags
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_anomaly_flags ON voucher_anomaly_flags
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_voucher_anomaly_flags_updated_at
+    BEFORE UPDATE ON voucher_anomaly_flags
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
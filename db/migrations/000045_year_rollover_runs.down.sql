@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Year Rollover Runs (Rollback)
+
+DROP TABLE IF EXISTS year_rollover_runs;
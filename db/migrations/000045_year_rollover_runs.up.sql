@@ -0,0 +1,51 @@
+-- K-ERP v0.2 Migration: Year Rollover Runs
+-- The closing balances of December are carried into January of the next
+-- fiscal year once its periods are opened: balance sheet accounts open with
+-- their net balance, revenue and expense accounts at zero with the net income
+-- transferred to retained earnings. Each rollover is recorded here.
+
+-- ============================================
+-- YEAR ROLLOVER RUNS
+-- ============================================
+CREATE TABLE year_rollover_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    from_year INTEGER NOT NULL,
+    to_year INTEGER NOT NULL CHECK (to_year = from_year + 1),
+
+    retained_earnings_account_id UUID NOT NULL REFERENCES accounts(id),
+    net_income DECIMAL(18,2) NOT NULL DEFAULT 0,
+
+    carried_accounts INTEGER NOT NULL DEFAULT 0,
+    zeroed_accounts INTEGER NOT NULL DEFAULT 0,
+
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('auto', 'manual')),
+    created_by UUID REFERENCES users(id),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_year_rollover_runs_year UNIQUE (company_id, from_year)
+);
+
+COMMENT ON TABLE year_rollover_runs IS 'Carry-forwards of closing balances into the next fiscal year';
+COMMENT ON COLUMN year_rollover_runs.net_income IS 'Revenue less expense of from_year transferred to retained earnings; negative for a loss';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE year_rollover_runs ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_year_rollover_runs ON year_rollover_runs
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_year_rollover_runs ON year_rollover_runs
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_year_rollover_runs_updated_at
+    BEFORE UPDATE ON year_rollover_runs
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
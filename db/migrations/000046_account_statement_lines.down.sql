@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Account Statement Lines (Rollback)
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS statement_line;
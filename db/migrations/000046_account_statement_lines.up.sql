@@ -0,0 +1,41 @@
+-- K-ERP v0.2 Migration: Account Statement Lines
+-- Maps accounts to the balance sheet and income statement lines (재무제표 과목)
+-- they roll up to; accounts without a line inherit their parent's
+
+ALTER TABLE accounts ADD COLUMN statement_line VARCHAR(40);
+
+-- Standard chart of accounts: map the account groups, their children inherit
+UPDATE accounts a
+SET statement_line = m.statement_line
+FROM (VALUES
+    ('1101', 'asset', 'cash_and_equivalents'),
+    ('1102', 'asset', 'short_term_investments'),
+    ('1103', 'asset', 'trade_receivables'),
+    ('1104', 'asset', 'other_current_assets'),
+    ('1105', 'asset', 'inventories'),
+    ('1201', 'asset', 'long_term_investments'),
+    ('1202', 'asset', 'property_plant_equipment'),
+    ('1203', 'asset', 'intangible_assets'),
+    ('1204', 'asset', 'other_non_current_assets'),
+    ('2101', 'liability', 'trade_payables'),
+    ('2102', 'liability', 'short_term_borrowings'),
+    ('2103', 'liability', 'other_current_liabilities'),
+    ('210306', 'liability', 'income_tax_payable'),
+    ('2201', 'liability', 'long_term_borrowings'),
+    ('2202', 'liability', 'retirement_benefit_obligations'),
+    ('2203', 'liability', 'deferred_tax_liabilities'),
+    ('31', 'equity', 'share_capital'),
+    ('32', 'equity', 'capital_surplus'),
+    ('33', 'equity', 'retained_earnings'),
+    ('41', 'revenue', 'revenue'),
+    ('42', 'revenue', 'non_operating_income'),
+    ('51', 'expense', 'cost_of_sales'),
+    ('52', 'expense', 'selling_admin_expenses'),
+    ('53', 'expense', 'non_operating_expenses'),
+    ('54', 'expense', 'income_tax_expense')
+) AS m(code, account_type, statement_line)
+WHERE a.code = m.code
+  AND a.account_type = m.account_type
+  AND a.statement_line IS NULL;
+
+COMMENT ON COLUMN accounts.statement_line IS 'Financial statement line code; NULL rolls up with the parent account';
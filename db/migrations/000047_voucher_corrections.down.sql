@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Voucher Corrections (Rollback)
+
+DROP INDEX IF EXISTS idx_vouchers_correction_of;
+
+ALTER TABLE vouchers DROP COLUMN IF EXISTS corrected_by_id;
+ALTER TABLE vouchers DROP COLUMN IF EXISTS correction_of_id;
@@ -0,0 +1,10 @@
+-- K-ERP v0.2 Migration: Voucher Corrections
+-- Posted vouchers corrected by a reversal and a corrected voucher (대체 전표)
+
+ALTER TABLE vouchers ADD COLUMN correction_of_id UUID REFERENCES vouchers(id);
+ALTER TABLE vouchers ADD COLUMN corrected_by_id UUID REFERENCES vouchers(id);
+
+CREATE INDEX idx_vouchers_correction_of ON vouchers(correction_of_id) WHERE correction_of_id IS NOT NULL;
+
+COMMENT ON COLUMN vouchers.correction_of_id IS 'Posted voucher this voucher corrects';
+COMMENT ON COLUMN vouchers.corrected_by_id IS 'Voucher replacing this reversed posted voucher';
@@ -0,0 +1,4 @@
+-- K-ERP v0.2 Migration: Partial Voucher Reversals (Rollback)
+
+ALTER TABLE voucher_entries DROP COLUMN IF EXISTS reversed_amount;
+ALTER TABLE vouchers DROP COLUMN IF EXISTS reversed_amount;
@@ -0,0 +1,14 @@
+-- K-ERP v0.2 Migration: Partial Voucher Reversals
+-- Cumulative reversed amounts blocking reversals beyond the posted amounts
+
+ALTER TABLE vouchers ADD COLUMN reversed_amount DECIMAL(18,2) NOT NULL DEFAULT 0;
+ALTER TABLE voucher_entries ADD COLUMN reversed_amount DECIMAL(18,2) NOT NULL DEFAULT 0;
+
+-- Vouchers reversed before partial reversals were reversed in full
+UPDATE vouchers SET reversed_amount = total_debit WHERE reversed_by_id IS NOT NULL;
+UPDATE voucher_entries e SET reversed_amount = e.debit_amount + e.credit_amount
+    FROM vouchers v
+    WHERE v.id = e.voucher_id AND v.reversed_by_id IS NOT NULL;
+
+COMMENT ON COLUMN vouchers.reversed_amount IS 'Debit total reversed by full or partial reversals';
+COMMENT ON COLUMN voucher_entries.reversed_amount IS 'Amount of the entry reversed by full or partial reversals';
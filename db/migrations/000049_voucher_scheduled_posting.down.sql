@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Scheduled Voucher Posting (Rollback)
+
+DROP INDEX IF EXISTS idx_vouchers_scheduled_posting;
+
+ALTER TABLE vouchers DROP COLUMN IF EXISTS scheduled_by;
+ALTER TABLE vouchers DROP COLUMN IF EXISTS scheduled_post_date;
@@ -0,0 +1,12 @@
+-- K-ERP v0.2 Migration: Scheduled Voucher Posting
+-- Approved vouchers posted by the worker on a future date
+
+ALTER TABLE vouchers ADD COLUMN scheduled_post_date DATE;
+ALTER TABLE vouchers ADD COLUMN scheduled_by UUID REFERENCES users(id);
+
+-- Worker lookup of approved vouchers due for posting
+CREATE INDEX idx_vouchers_scheduled_posting ON vouchers(scheduled_post_date)
+    WHERE status = 'approved' AND scheduled_post_date IS NOT NULL;
+
+COMMENT ON COLUMN vouchers.scheduled_post_date IS 'Date the approved voucher is posted by the worker, once its period is open';
+COMMENT ON COLUMN vouchers.scheduled_by IS 'User who scheduled the posting, recorded as the poster';
@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: Voucher Tags (Rollback)
+
+DROP TABLE IF EXISTS voucher_tags;
+
+DROP INDEX IF EXISTS idx_vouchers_tags;
+
+ALTER TABLE vouchers DROP COLUMN IF EXISTS tags;
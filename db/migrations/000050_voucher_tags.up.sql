@@ -0,0 +1,47 @@
+-- K-ERP v0.2 Migration: Voucher Tags
+-- Free-form tags on vouchers for ad-hoc analyses such as "audit-adjustment"
+-- or "covid-subsidy". Vouchers carry their tags by name; the company's tags
+-- are registered here as they are first used.
+
+ALTER TABLE vouchers ADD COLUMN tags JSONB NOT NULL DEFAULT '[]';
+
+-- Containment lookups of the list filter and the report builder (tags @> '["x"]')
+CREATE INDEX idx_vouchers_tags ON vouchers USING GIN (tags jsonb_path_ops);
+
+COMMENT ON COLUMN vouchers.tags IS 'Tag names of the voucher, a JSON array of strings';
+
+-- ============================================
+-- VOUCHER TAGS
+-- ============================================
+CREATE TABLE voucher_tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    name VARCHAR(50) NOT NULL,
+    description VARCHAR(200),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_voucher_tags_name UNIQUE (company_id, name)
+);
+
+COMMENT ON TABLE voucher_tags IS 'Tags of the company''s vouchers; renaming or deleting a tag applies to the tagged vouchers';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE voucher_tags ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_voucher_tags ON voucher_tags
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_voucher_tags ON voucher_tags
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_voucher_tags_updated_at
+    BEFORE UPDATE ON voucher_tags
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
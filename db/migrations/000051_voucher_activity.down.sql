@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: Voucher Activity (Rollback)
+
+DROP INDEX IF EXISTS idx_voucher_attachments_company_uploaded;
+DROP INDEX IF EXISTS idx_audit_logs_activity;
+
+DROP TRIGGER IF EXISTS audit_vouchers_edit ON vouchers;
+DROP FUNCTION IF EXISTS trigger_audit_voucher_edit();
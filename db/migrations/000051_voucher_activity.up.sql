@@ -0,0 +1,47 @@
+-- K-ERP v0.2 Migration: Voucher Activity
+-- The activity feeds combine the voucher status history, the attachments and
+-- the audit log. Edits of a voucher's header are recorded in the audit log by
+-- a trigger, with the old and new values of the changed columns; comments are
+-- written to the audit log by the application.
+
+-- ============================================
+-- TRIGGER: Record Voucher Edits
+-- ============================================
+CREATE OR REPLACE FUNCTION trigger_audit_voucher_edit()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB := to_jsonb(OLD);
+    new_row JSONB := to_jsonb(NEW);
+    old_values JSONB := '{}';
+    new_values JSONB := '{}';
+    col TEXT;
+BEGIN
+    FOREACH col IN ARRAY ARRAY['voucher_date', 'voucher_type', 'description', 'reference_type', 'reference_id',
+        'total_debit', 'total_credit', 'auto_reverse', 'tags']
+    LOOP
+        IF old_row -> col IS DISTINCT FROM new_row -> col THEN
+            old_values := old_values || jsonb_build_object(col, old_row -> col);
+            new_values := new_values || jsonb_build_object(col, new_row -> col);
+        END IF;
+    END LOOP;
+
+    IF new_values = '{}' THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO audit_logs (company_id, user_id, action, entity_type, entity_id, old_values, new_values)
+    VALUES (NEW.company_id, NEW.updated_by, 'update', 'voucher', NEW.id, old_values, new_values);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_vouchers_edit
+    AFTER UPDATE OF voucher_date, voucher_type, description, reference_type, reference_id,
+        total_debit, total_credit, auto_reverse, tags ON vouchers
+    FOR EACH ROW EXECUTE FUNCTION trigger_audit_voucher_edit();
+
+-- Feed lookups of the company's comments and edits, newest first
+CREATE INDEX idx_audit_logs_activity ON audit_logs(company_id, created_at DESC)
+    WHERE action IN ('comment', 'update');
+
+CREATE INDEX idx_voucher_attachments_company_uploaded ON voucher_attachments(company_id, uploaded_at DESC);
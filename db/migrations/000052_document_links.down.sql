@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Document Links (Rollback)
+
+DROP TABLE IF EXISTS document_links;
@@ -0,0 +1,52 @@
+-- K-ERP v0.2 Migration: Document Links
+-- Typed many-to-many links between the company's documents, such as a voucher,
+-- the tax invoice it books and the loan it repays. A link is undirected and
+-- stored once, with the (type, id) pair ordered so that the source sorts first.
+
+-- ============================================
+-- DOCUMENT LINKS
+-- ============================================
+CREATE TABLE document_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    source_type VARCHAR(30) NOT NULL
+        CHECK (source_type IN ('voucher', 'tax_invoice', 'loan', 'grant', 'allocation_run')),
+    source_id UUID NOT NULL,
+    target_type VARCHAR(30) NOT NULL
+        CHECK (target_type IN ('voucher', 'tax_invoice', 'loan', 'grant', 'allocation_run')),
+    target_id UUID NOT NULL,
+    note VARCHAR(500),
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_document_links_pair UNIQUE (company_id, source_type, source_id, target_type, target_id),
+    CONSTRAINT chk_document_links_ordered CHECK (
+        source_type COLLATE "C" < target_type COLLATE "C" OR (source_type = target_type AND source_id < target_id))
+);
+
+-- Related documents lookups from either side of the link
+CREATE INDEX idx_document_links_source ON document_links(company_id, source_type, source_id);
+CREATE INDEX idx_document_links_target ON document_links(company_id, target_type, target_id);
+
+COMMENT ON TABLE document_links IS 'Links between documents of the company; the referenced documents are validated by the application';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE document_links ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_document_links ON document_links
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_document_links ON document_links
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_document_links_updated_at
+    BEFORE UPDATE ON document_links
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,11 @@
+-- K-ERP v0.2 Migration: Usage Metering (Rollback)
+
+DROP TRIGGER IF EXISTS usage_tax_invoices_issued ON tax_invoices;
+DROP TRIGGER IF EXISTS usage_vouchers_created ON vouchers;
+DROP FUNCTION IF EXISTS trigger_usage_tax_invoice_issued();
+DROP FUNCTION IF EXISTS trigger_usage_voucher_created();
+
+DROP TABLE IF EXISTS usage_records;
+DROP TABLE IF EXISTS usage_events;
+
+ALTER TABLE companies DROP COLUMN IF EXISTS billing_customer_id;
@@ -0,0 +1,125 @@
+-- K-ERP v0.2 Migration: Usage Metering
+-- Billable events of tenants (vouchers created, sales tax invoices issued),
+-- their monthly aggregation by the worker together with the peak number of
+-- active users, and the billing customer the usage is exported to.
+-- Metering starts with this migration; earlier activity is not backfilled so
+-- that months already billed are not billed again.
+
+-- ============================================
+-- BILLING CUSTOMER
+-- ============================================
+-- Customer of the company at the billing provider (e.g. a Stripe customer ID),
+-- set when the subscription is created. Usage is not exported without one.
+ALTER TABLE companies ADD COLUMN billing_customer_id VARCHAR(100);
+
+-- ============================================
+-- USAGE EVENTS
+-- ============================================
+CREATE TABLE usage_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    metric VARCHAR(30) NOT NULL CHECK (metric IN ('vouchers', 'tax_invoices')),
+    quantity BIGINT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    source_id UUID,  -- the voucher or tax invoice; an event is recorded once per source
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_usage_events_source UNIQUE (metric, source_id)
+);
+
+-- Monthly aggregation scans the events of a month
+CREATE INDEX idx_usage_events_occurred ON usage_events(occurred_at, metric);
+
+COMMENT ON TABLE usage_events IS 'Billable events of the company, recorded by triggers in the transaction of the change';
+
+-- ============================================
+-- USAGE RECORDS
+-- ============================================
+CREATE TABLE usage_records (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    period DATE NOT NULL CHECK (EXTRACT(DAY FROM period) = 1),  -- first day of the month (KST)
+    metric VARCHAR(30) NOT NULL CHECK (metric IN ('active_users', 'vouchers', 'tax_invoices')),
+    quantity BIGINT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+
+    -- Export to the billing provider after the month has closed
+    exported_at TIMESTAMPTZ,
+    export_reference VARCHAR(200),
+    export_error TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_usage_records_month UNIQUE (company_id, period, metric)
+);
+
+-- Export worklist of closed months
+CREATE INDEX idx_usage_records_pending ON usage_records(period) WHERE exported_at IS NULL;
+
+COMMENT ON TABLE usage_records IS 'Monthly billable usage of the company by metric; exported records are final';
+
+-- ============================================
+-- EVENT TRIGGERS
+-- ============================================
+CREATE OR REPLACE FUNCTION trigger_usage_voucher_created()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO usage_events (company_id, metric, source_id)
+    VALUES (NEW.company_id, 'vouchers', NEW.id)
+    ON CONFLICT (metric, source_id) DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER usage_vouchers_created
+    AFTER INSERT ON vouchers
+    FOR EACH ROW EXECUTE FUNCTION trigger_usage_voucher_created();
+
+-- A sales invoice counts when it leaves draft, or is created already issued;
+-- amendments are invoices of their own and count as well
+CREATE OR REPLACE FUNCTION trigger_usage_tax_invoice_issued()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.invoice_type = 'sales'
+        AND NEW.status IN ('issued', 'transmitted', 'confirmed')
+        AND (TG_OP = 'INSERT' OR OLD.status = 'draft') THEN
+        INSERT INTO usage_events (company_id, metric, source_id)
+        VALUES (NEW.company_id, 'tax_invoices', NEW.id)
+        ON CONFLICT (metric, source_id) DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER usage_tax_invoices_issued
+    AFTER INSERT OR UPDATE OF status ON tax_invoices
+    FOR EACH ROW EXECUTE FUNCTION trigger_usage_tax_invoice_issued();
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE usage_events ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_usage_events ON usage_events
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_usage_events ON usage_events
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+ALTER TABLE usage_records ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_usage_records ON usage_records
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_usage_records ON usage_records
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_usage_records_updated_at
+    BEFORE UPDATE ON usage_records
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,14 @@
+-- K-ERP v0.2 Migration: Subscription Plans (Rollback)
+
+DROP INDEX IF EXISTS idx_usage_events_company;
+
+DROP TRIGGER IF EXISTS company_plan_changed ON companies;
+DROP FUNCTION IF EXISTS trigger_company_plan_changed();
+
+DROP INDEX IF EXISTS idx_companies_plan;
+ALTER TABLE companies
+    DROP COLUMN IF EXISTS plan_changed_at,
+    DROP COLUMN IF EXISTS previous_plan_code,
+    DROP COLUMN IF EXISTS plan_code;
+
+DROP TABLE IF EXISTS plans;
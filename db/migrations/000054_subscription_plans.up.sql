@@ -0,0 +1,79 @@
+-- K-ERP v0.2 Migration: Subscription Plans
+-- Plans with limits on active users, vouchers created per month and
+-- attachment storage, and the plan of each company. Existing companies are
+-- moved to the enterprise plan (no limits) so that the upgrade does not block
+-- them; new companies start on the free plan.
+
+-- ============================================
+-- PLANS
+-- ============================================
+-- Global catalog shared by all tenants; a NULL limit is unlimited
+CREATE TABLE plans (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+
+    max_users BIGINT CHECK (max_users > 0),
+    max_vouchers_per_month BIGINT CHECK (max_vouchers_per_month > 0),
+    max_storage_bytes BIGINT CHECK (max_storage_bytes > 0),
+
+    upgrade_to VARCHAR(30) REFERENCES plans(code),  -- suggested when a limit is reached
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE plans IS 'Subscription plans and their limits; NULL limits are unlimited';
+
+INSERT INTO plans (code, name, max_users, max_vouchers_per_month, max_storage_bytes, sort_order) VALUES
+    ('enterprise', 'Enterprise', NULL, NULL, NULL, 40),
+    ('business', 'Business', 50, 20000, 107374182400, 30),    -- 100GB
+    ('starter', 'Starter', 10, 2000, 10737418240, 20),        -- 10GB
+    ('free', 'Free', 3, 100, 1073741824, 10);                 -- 1GB
+
+UPDATE plans SET upgrade_to = 'starter' WHERE code = 'free';
+UPDATE plans SET upgrade_to = 'business' WHERE code = 'starter';
+UPDATE plans SET upgrade_to = 'enterprise' WHERE code = 'business';
+
+-- ============================================
+-- COMPANY PLAN
+-- ============================================
+ALTER TABLE companies
+    ADD COLUMN plan_code VARCHAR(30) NOT NULL DEFAULT 'enterprise' REFERENCES plans(code),
+    ADD COLUMN previous_plan_code VARCHAR(30) REFERENCES plans(code),
+    ADD COLUMN plan_changed_at TIMESTAMPTZ;
+
+-- Existing companies keep working without limits; new ones start free
+ALTER TABLE companies ALTER COLUMN plan_code SET DEFAULT 'free';
+
+CREATE INDEX idx_companies_plan ON companies(plan_code);
+
+-- The previous plan's limits apply during the grace period after a change
+CREATE OR REPLACE FUNCTION trigger_company_plan_changed()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.plan_code IS DISTINCT FROM OLD.plan_code THEN
+        NEW.previous_plan_code = OLD.plan_code;
+        NEW.plan_changed_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER company_plan_changed
+    BEFORE UPDATE OF plan_code ON companies
+    FOR EACH ROW EXECUTE FUNCTION trigger_company_plan_changed();
+
+-- ============================================
+-- INDEXES
+-- ============================================
+-- Vouchers created this month are counted from the usage events on every voucher creation
+CREATE INDEX idx_usage_events_company ON usage_events(company_id, metric, occurred_at);
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_plans_updated_at
+    BEFORE UPDATE ON plans
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Attachment Thumbnails (Rollback)
+
+COMMENT ON COLUMN voucher_attachments.file_type IS NULL;
+ALTER TABLE voucher_attachments DROP COLUMN IF EXISTS has_thumbnail;
+ALTER TABLE voucher_attachments DROP COLUMN IF EXISTS thumbnail_data;
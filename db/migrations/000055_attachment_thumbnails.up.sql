@@ -0,0 +1,13 @@
+-- K-ERP v0.2 Migration: Attachment Thumbnails
+-- Image attachments carry a JPEG thumbnail, rendered on upload, so voucher
+-- detail screens can show previews without downloading the originals.
+
+-- ============================================
+-- VOUCHER_ATTACHMENTS: Thumbnail
+-- ============================================
+ALTER TABLE voucher_attachments ADD COLUMN thumbnail_data BYTEA;
+ALTER TABLE voucher_attachments ADD COLUMN has_thumbnail BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN voucher_attachments.file_type IS 'MIME type sniffed from the content; HEIC photos are stored converted to JPEG';
+COMMENT ON COLUMN voucher_attachments.thumbnail_data IS 'JPEG preview of image attachments';
+COMMENT ON COLUMN voucher_attachments.has_thumbnail IS 'Lets attachment lists tell previews apart without loading thumbnail_data';
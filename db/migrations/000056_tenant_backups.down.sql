@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: Tenant Backups (Rollback)
+
+DROP TRIGGER IF EXISTS set_backup_restores_updated_at ON backup_restores;
+DROP TRIGGER IF EXISTS set_tenant_backups_updated_at ON tenant_backups;
+
+DROP TABLE IF EXISTS backup_restores;
+DROP TABLE IF EXISTS tenant_backups;
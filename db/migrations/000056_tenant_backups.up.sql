@@ -0,0 +1,101 @@
+-- K-ERP v0.2 Migration: Tenant Backups
+-- Nightly encrypted backups of each company in object storage, and their
+-- restores into the staging database for support
+
+-- ============================================
+-- TENANT BACKUPS
+-- ============================================
+CREATE TABLE tenant_backups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('scheduled', 'manual')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    scheduled_for TIMESTAMPTZ,  -- run of the backup schedule, for scheduled backups
+
+    -- Data export archive (JSON), envelope encrypted; the wrapped data key
+    -- is kept here and never stored next to the object
+    storage_key VARCHAR(300),
+    size BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64),  -- hex SHA-256 of the stored object
+    key_id VARCHAR(100),
+    encrypted_key BYTEA,
+    row_counts JSONB,
+    monthly BOOLEAN NOT NULL DEFAULT false,  -- first backup of the month, kept for the monthly retention
+    error TEXT,
+
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,  -- the object and the row are deleted after this time
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One scheduled backup per company and run, however many workers schedule it
+CREATE UNIQUE INDEX idx_tenant_backups_scheduled ON tenant_backups(company_id, scheduled_for)
+    WHERE scheduled_for IS NOT NULL;
+CREATE INDEX idx_tenant_backups_company ON tenant_backups(company_id, created_at DESC);
+CREATE INDEX idx_tenant_backups_active ON tenant_backups(created_at) WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_tenant_backups_expires ON tenant_backups(expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON TABLE tenant_backups IS 'Encrypted restore points of tenant data in object storage';
+
+-- ============================================
+-- BACKUP RESTORES
+-- ============================================
+CREATE TABLE backup_restores (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    backup_id UUID NOT NULL REFERENCES tenant_backups(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    reason TEXT NOT NULL,  -- e.g. the support ticket
+    target_schema VARCHAR(63),  -- schema of the staging database holding the restored data
+    row_counts JSONB,
+    error TEXT,
+
+    requested_by UUID NOT NULL REFERENCES users(id),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_backup_restores_backup ON backup_restores(backup_id, created_at DESC);
+CREATE INDEX idx_backup_restores_active ON backup_restores(created_at) WHERE status IN ('pending', 'processing');
+
+COMMENT ON TABLE backup_restores IS 'Restores of tenant backups into the staging database';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE tenant_backups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE backup_restores ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tenant_backups ON tenant_backups
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_tenant_backups ON tenant_backups
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_backup_restores ON backup_restores
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_backup_restores ON backup_restores
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_tenant_backups_updated_at
+    BEFORE UPDATE ON tenant_backups
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_backup_restores_updated_at
+    BEFORE UPDATE ON backup_restores
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
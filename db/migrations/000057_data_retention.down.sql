@@ -0,0 +1,6 @@
+-- K-ERP v0.2 Migration: Data Retention (Rollback)
+
+DROP TRIGGER IF EXISTS set_data_deletion_requests_updated_at ON data_deletion_requests;
+
+DROP INDEX IF EXISTS idx_audit_logs_client_details;
+DROP TABLE IF EXISTS data_deletion_requests;
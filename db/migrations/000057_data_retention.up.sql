@@ -0,0 +1,55 @@
+-- K-ERP v0.2 Migration: Data Retention
+-- Deletion requests of personal data (GDPR art. 17, PIPA art. 36). Completed
+-- requests anonymize the subject; accounting records referring to it are kept.
+
+-- ============================================
+-- DATA DELETION REQUESTS
+-- ============================================
+CREATE TABLE data_deletion_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    subject_type VARCHAR(20) NOT NULL CHECK (subject_type IN ('user', 'partner')),
+    subject_id UUID NOT NULL,  -- users.id or partners.id
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'completed', 'rejected')),
+    reason TEXT,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    due_at TIMESTAMPTZ NOT NULL,  -- statutory deadline of the request
+
+    processed_by UUID REFERENCES users(id),
+    processed_at TIMESTAMPTZ,
+    rejection_reason TEXT,  -- legal basis of a refusal
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One pending request per subject
+CREATE UNIQUE INDEX idx_data_deletion_requests_pending ON data_deletion_requests(company_id, subject_type, subject_id)
+    WHERE status = 'pending';
+CREATE INDEX idx_data_deletion_requests_company ON data_deletion_requests(company_id, status, due_at);
+
+COMMENT ON TABLE data_deletion_requests IS 'Requests to delete the personal data of users and partner contacts';
+
+-- Clearing of audit client details past the retention period
+CREATE INDEX idx_audit_logs_client_details ON audit_logs(created_at)
+    WHERE ip_address IS NOT NULL OR user_agent IS NOT NULL;
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE data_deletion_requests ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_data_deletion_requests ON data_deletion_requests
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_data_deletion_requests ON data_deletion_requests
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_data_deletion_requests_updated_at
+    BEFORE UPDATE ON data_deletion_requests
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Data Export Masking (Rollback)
+
+ALTER TABLE data_exports DROP COLUMN IF EXISTS mask_pii;
@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: Data Export Masking
+-- Full exports may partially mask emails, phone numbers and business
+-- registration numbers, for archives handed to third parties
+
+ALTER TABLE data_exports ADD COLUMN mask_pii BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN data_exports.mask_pii IS 'Personal data in the archive is partially masked';
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Close Confirmations (Rollback)
+
+DROP TRIGGER IF EXISTS set_close_confirmations_updated_at ON close_confirmations;
+
+DROP TABLE IF EXISTS close_confirmations;
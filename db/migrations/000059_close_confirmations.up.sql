@@ -0,0 +1,59 @@
+-- K-ERP v0.2 Migration: Close Confirmations
+-- Two-person rule for period and year-end closes: under dual control a close
+-- initiated by one user is performed only once another user confirms it.
+
+-- ============================================
+-- CLOSE CONFIRMATIONS
+-- ============================================
+CREATE TABLE close_confirmations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('period', 'year_end')),
+    year INTEGER NOT NULL,
+    month INTEGER NOT NULL DEFAULT 0 CHECK (month BETWEEN 0 AND 12),  -- 0 for year-end closes
+
+    -- Parameters of the close, applied as initiated
+    override BOOLEAN NOT NULL DEFAULT FALSE,
+    retained_earnings_account_id UUID REFERENCES accounts(id),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired')),
+    initiated_by UUID NOT NULL REFERENCES users(id),
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    confirmed_by UUID REFERENCES users(id),
+    confirmed_at TIMESTAMPTZ,
+    cancelled_by UUID REFERENCES users(id),
+    cancelled_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_close_confirmations_confirmer CHECK (confirmed_by IS NULL OR confirmed_by <> initiated_by)
+);
+
+-- One pending close per period or year
+CREATE UNIQUE INDEX idx_close_confirmations_pending ON close_confirmations(company_id, kind, year, month)
+    WHERE status = 'pending';
+CREATE INDEX idx_close_confirmations_company ON close_confirmations(company_id, created_at DESC);
+
+COMMENT ON TABLE close_confirmations IS 'Closes initiated under dual control and their confirmation by a second user';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE close_confirmations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_close_confirmations ON close_confirmations
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_close_confirmations ON close_confirmations
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_close_confirmations_updated_at
+    BEFORE UPDATE ON close_confirmations
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Audit Locks (Rollback)
+
+DROP TRIGGER IF EXISTS set_audit_locks_updated_at ON audit_locks;
+
+DROP TABLE IF EXISTS audit_locks;
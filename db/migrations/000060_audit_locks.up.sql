@@ -0,0 +1,55 @@
+-- K-ERP v0.2 Migration: Audit Locks
+-- Fiscal years locked after their external audit sign-off: their periods
+-- cannot be reopened and no voucher can be reversed into them. Only platform
+-- operators can lift a lock; lifted locks are kept with who lifted them and why.
+
+-- ============================================
+-- AUDIT LOCKS
+-- ============================================
+CREATE TABLE audit_locks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    fiscal_year INTEGER NOT NULL,
+    reference VARCHAR(200),  -- audit report or sign-off reference
+
+    locked_by UUID NOT NULL REFERENCES users(id),
+    locked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    unlocked_by UUID REFERENCES users(id),
+    unlocked_at TIMESTAMPTZ,
+    unlock_reason TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_audit_locks_unlock CHECK (
+        (unlocked_at IS NULL AND unlocked_by IS NULL) OR
+        (unlocked_at IS NOT NULL AND unlocked_by IS NOT NULL AND COALESCE(unlock_reason, '') <> '')
+    )
+);
+
+-- One active lock per fiscal year
+CREATE UNIQUE INDEX idx_audit_locks_active ON audit_locks(company_id, fiscal_year)
+    WHERE unlocked_at IS NULL;
+CREATE INDEX idx_audit_locks_company ON audit_locks(company_id, fiscal_year DESC);
+
+COMMENT ON TABLE audit_locks IS 'Fiscal years locked after external audit sign-off and the lifting of those locks';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE audit_locks ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_audit_locks ON audit_locks
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_audit_locks ON audit_locks
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_audit_locks_updated_at
+    BEFORE UPDATE ON audit_locks
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
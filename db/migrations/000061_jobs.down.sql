@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Jobs (Rollback)
+
+DROP TRIGGER IF EXISTS set_jobs_updated_at ON jobs;
+
+DROP TABLE IF EXISTS jobs;
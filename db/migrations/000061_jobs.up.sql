@@ -0,0 +1,59 @@
+-- K-ERP v0.2 Migration: Jobs
+-- Background jobs run by the worker: ledger recalculations, legacy imports and
+-- scheduled report deliveries. Failed attempts are retried with a growing delay;
+-- operators can retry failed and cancelled jobs and cancel unfinished ones.
+
+-- ============================================
+-- JOBS
+-- ============================================
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    data BYTEA,  -- binary input such as an uploaded import file
+
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 3,
+    last_error TEXT,
+    result JSONB,
+
+    run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_jobs_type CHECK (type IN ('ledger_recalculation', 'legacy_import', 'report_delivery')),
+    CONSTRAINT chk_jobs_status CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+    CONSTRAINT chk_jobs_attempts CHECK (attempts >= 0 AND max_attempts > 0)
+);
+
+-- Claiming due jobs
+CREATE INDEX idx_jobs_pending ON jobs(run_at) WHERE status IN ('queued', 'running');
+CREATE INDEX idx_jobs_company ON jobs(company_id, created_at DESC);
+CREATE INDEX idx_jobs_created ON jobs(created_at DESC);
+
+COMMENT ON TABLE jobs IS 'Background jobs run by the worker';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE jobs ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_jobs ON jobs
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_jobs ON jobs
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_jobs_updated_at
+    BEFORE UPDATE ON jobs
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
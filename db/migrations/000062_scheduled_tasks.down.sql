@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Scheduled Tasks (Rollback)
+
+DROP TRIGGER IF EXISTS set_scheduled_tasks_updated_at ON scheduled_tasks;
+
+DROP TABLE IF EXISTS scheduled_tasks;
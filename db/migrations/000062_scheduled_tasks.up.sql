@@ -0,0 +1,57 @@
+-- K-ERP v0.2 Migration: Scheduled Tasks
+-- Recurring tasks of the worker with their cron schedules. Every worker replica
+-- runs the scheduler; a run is claimed by a conditional update of next_run_at
+-- and a lease (locked_by, locked_until), so each scheduled time runs once.
+
+-- ============================================
+-- SCHEDULED TASKS
+-- ============================================
+CREATE TABLE scheduled_tasks (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(200),
+
+    cron_expr VARCHAR(100) NOT NULL,
+    timezone VARCHAR(50) NOT NULL DEFAULT 'Asia/Seoul',
+    catch_up VARCHAR(10) NOT NULL DEFAULT 'once',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    next_run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_by VARCHAR(200),
+    locked_until TIMESTAMPTZ,
+
+    last_scheduled_at TIMESTAMPTZ,
+    last_started_at TIMESTAMPTZ,
+    last_finished_at TIMESTAMPTZ,
+    last_status VARCHAR(20),
+    last_error TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_scheduled_tasks_catch_up CHECK (catch_up IN ('skip', 'once', 'all')),
+    CONSTRAINT chk_scheduled_tasks_last_status CHECK (
+        last_status IS NULL OR last_status IN ('running', 'succeeded', 'failed', 'skipped')
+    )
+);
+
+COMMENT ON TABLE scheduled_tasks IS 'Recurring worker tasks, their cron schedules and last runs';
+COMMENT ON COLUMN scheduled_tasks.catch_up IS 'Runs missed while no worker ran: skip drops late runs, once makes them up by one run, all makes up each';
+
+-- The tasks formerly run on fixed worker intervals; due at once after the migration
+INSERT INTO scheduled_tasks (name, description, cron_expr) VALUES
+    ('auto_reversal', 'Auto-reversal of accrual and deferral vouchers', '0 * * * *'),
+    ('scheduled_posting', 'Posting of approved vouchers whose scheduled date has arrived', '0 * * * *'),
+    ('partner_verification', 'Revalidation of partner business numbers against NTS', '0 */6 * * *'),
+    ('loan_accrual', 'Interest accrual of loans at the last month end', '0 */6 * * *'),
+    ('grant_recognition', 'Income recognition of government grants at the last month end', '0 */6 * * *'),
+    ('year_rollover', 'Carry-forward of closing balances into newly opened fiscal years', '0 */6 * * *'),
+    ('usage_aggregation', 'Monthly usage aggregation and export to billing', '0 * * * *'),
+    ('partition_maintenance', 'Creation of voucher_entries fiscal year partitions', '0 3 * * *'),
+    ('data_retention', 'Enforcement of the data retention periods', '0 */6 * * *');
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_scheduled_tasks_updated_at
+    BEFORE UPDATE ON scheduled_tasks
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,5 @@
+-- K-ERP v0.2 Migration: Email Templates (Rollback)
+
+DROP TRIGGER IF EXISTS set_email_templates_updated_at ON email_templates;
+
+DROP TABLE IF EXISTS email_templates;
@@ -0,0 +1,51 @@
+-- K-ERP v0.2 Migration: Email Templates
+-- Company templates of the emails sent for events such as invitations and
+-- report deliveries. Every save adds a version; at most one version of an
+-- event is active. Without an active version the system default is used.
+
+-- ============================================
+-- EMAIL TEMPLATES
+-- ============================================
+CREATE TABLE email_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    event_type VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL,
+    subject VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_email_templates_version UNIQUE (company_id, event_type, version),
+    CONSTRAINT chk_email_templates_event_type CHECK (event_type IN (
+        'invitation', 'email_verification', 'report_delivery', 'report_failure', 'partner_statement'
+    )),
+    CONSTRAINT chk_email_templates_version CHECK (version > 0)
+);
+
+-- One active version per event
+CREATE UNIQUE INDEX uq_email_templates_active ON email_templates(company_id, event_type) WHERE is_active;
+
+COMMENT ON TABLE email_templates IS 'Versions of the company email templates per event';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE email_templates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_email_templates ON email_templates
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_email_templates ON email_templates
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_email_templates_updated_at
+    BEFORE UPDATE ON email_templates
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,10 @@
+-- K-ERP v0.2 Migration: Push Devices (Rollback)
+
+DELETE FROM jobs WHERE type = 'approval_push';
+ALTER TABLE jobs DROP CONSTRAINT chk_jobs_type;
+ALTER TABLE jobs ADD CONSTRAINT chk_jobs_type
+    CHECK (type IN ('ledger_recalculation', 'legacy_import', 'report_delivery'));
+
+DROP TRIGGER IF EXISTS set_push_devices_updated_at ON push_devices;
+
+DROP TABLE IF EXISTS push_devices;
@@ -0,0 +1,52 @@
+-- K-ERP v0.2 Migration: Push Devices
+-- Mobile devices registered for push notifications of approval requests. A
+-- token identifies one app installation; the worker sends the notifications
+-- as approval_push jobs and removes tokens the push service rejects.
+
+-- ============================================
+-- PUSH DEVICES
+-- ============================================
+CREATE TABLE push_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    platform VARCHAR(10) NOT NULL,
+    token VARCHAR(512) NOT NULL,
+    device_name VARCHAR(100),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_push_devices_token UNIQUE (company_id, token),
+    CONSTRAINT chk_push_devices_platform CHECK (platform IN ('fcm', 'apns'))
+);
+
+CREATE INDEX idx_push_devices_user ON push_devices(company_id, user_id);
+CREATE INDEX idx_push_devices_token ON push_devices(token);
+
+COMMENT ON TABLE push_devices IS 'Devices receiving push notifications of the mobile app';
+
+-- Approval pushes are queued as background jobs
+ALTER TABLE jobs DROP CONSTRAINT chk_jobs_type;
+ALTER TABLE jobs ADD CONSTRAINT chk_jobs_type
+    CHECK (type IN ('ledger_recalculation', 'legacy_import', 'report_delivery', 'approval_push'));
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE push_devices ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_push_devices ON push_devices
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_push_devices ON push_devices
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_push_devices_updated_at
+    BEFORE UPDATE ON push_devices
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,9 @@
+-- K-ERP v0.2 Migration: Company Bank Accounts (Rollback)
+
+DROP INDEX IF EXISTS idx_voucher_entries_bank_account;
+ALTER TABLE voucher_entries DROP COLUMN IF EXISTS bank_account_id;
+
+DROP TRIGGER IF EXISTS set_company_bank_accounts_updated_at ON company_bank_accounts;
+
+DROP TABLE IF EXISTS bank_account_balances;
+DROP TABLE IF EXISTS company_bank_accounts;
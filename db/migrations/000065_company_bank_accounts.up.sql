@@ -0,0 +1,92 @@
+-- K-ERP v0.2 Migration: Company Bank Accounts
+-- Bank accounts of the company with the cash account of the chart they book
+-- to and their latest balance at the bank, reported by statement imports,
+-- account inquiries or by hand. Payment vouchers name the bank account they
+-- pay from on the entry crediting its cash account.
+
+-- ============================================
+-- COMPANY BANK ACCOUNTS
+-- ============================================
+CREATE TABLE company_bank_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    name VARCHAR(100) NOT NULL,
+    bank_code VARCHAR(3) NOT NULL,
+    bank_name VARCHAR(50),
+    account_number VARCHAR(20) NOT NULL,
+    account_holder VARCHAR(100),
+    currency VARCHAR(3) NOT NULL DEFAULT 'KRW',
+
+    account_id UUID NOT NULL REFERENCES accounts(id),
+
+    balance DECIMAL(18,2) NOT NULL DEFAULT 0,
+    balance_as_of TIMESTAMPTZ,
+    balance_source VARCHAR(20)
+        CHECK (balance_source IN ('statement_import', 'open_banking', 'manual')),
+
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_company_bank_accounts_number UNIQUE (company_id, bank_code, account_number)
+);
+
+CREATE INDEX idx_company_bank_accounts_account ON company_bank_accounts(company_id, account_id);
+
+COMMENT ON TABLE company_bank_accounts IS 'Bank accounts of the company and the cash accounts they book to';
+
+-- ============================================
+-- BANK ACCOUNT BALANCES
+-- ============================================
+CREATE TABLE bank_account_balances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    bank_account_id UUID NOT NULL REFERENCES company_bank_accounts(id) ON DELETE CASCADE,
+
+    as_of TIMESTAMPTZ NOT NULL,
+    balance DECIMAL(18,2) NOT NULL,
+    source VARCHAR(20) NOT NULL
+        CHECK (source IN ('statement_import', 'open_banking', 'manual')),
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bank_account_balances_account ON bank_account_balances(bank_account_id, as_of DESC);
+
+COMMENT ON TABLE bank_account_balances IS 'Balances reported for the bank accounts of the company';
+
+-- ============================================
+-- VOUCHER ENTRIES
+-- ============================================
+ALTER TABLE voucher_entries ADD COLUMN bank_account_id UUID REFERENCES company_bank_accounts(id);
+
+CREATE INDEX idx_voucher_entries_bank_account ON voucher_entries(company_id, bank_account_id) WHERE bank_account_id IS NOT NULL;
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE company_bank_accounts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE bank_account_balances ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_company_bank_accounts ON company_bank_accounts
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_company_bank_accounts ON company_bank_accounts
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_bank_account_balances ON bank_account_balances
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_bank_account_balances ON bank_account_balances
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_company_bank_accounts_updated_at
+    BEFORE UPDATE ON company_bank_accounts
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,10 @@
+-- K-ERP v0.2 Migration: Payment Batches (Rollback)
+
+DROP TRIGGER IF EXISTS set_payment_batches_updated_at ON payment_batches;
+
+DROP TABLE IF EXISTS payment_batch_items;
+DROP TABLE IF EXISTS payment_batches;
+
+ALTER TABLE partners
+    DROP COLUMN IF EXISTS bank_account_number,
+    DROP COLUMN IF EXISTS bank_account_holder;
@@ -0,0 +1,114 @@
+-- K-ERP v0.2 Migration: Payment Batches
+-- Bulk transfers (대량이체) of payables due from a bank account of the
+-- company. The transfer file of the paying bank is uploaded to internet
+-- banking; the outcome of each transfer is recorded after execution and the
+-- paid items are booked by a payment voucher. Partners gain the bank account
+-- their payables are transferred to.
+
+-- ============================================
+-- PARTNER BANK ACCOUNTS
+-- ============================================
+-- bank_code is already there; the encrypted number it was meant for was
+-- never used
+ALTER TABLE partners
+    ADD COLUMN bank_account_number VARCHAR(20),
+    ADD COLUMN bank_account_holder VARCHAR(100);
+
+-- ============================================
+-- PAYMENT BATCHES
+-- ============================================
+CREATE TABLE payment_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    batch_no VARCHAR(30) NOT NULL,
+    bank_account_id UUID NOT NULL REFERENCES company_bank_accounts(id),
+    bank_code VARCHAR(3) NOT NULL,
+    transfer_date DATE NOT NULL,
+    due_by DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'generated',
+    item_count INTEGER NOT NULL DEFAULT 0,
+    total_amount BIGINT NOT NULL DEFAULT 0,
+    file_name VARCHAR(100) NOT NULL,
+
+    executed_at TIMESTAMPTZ,
+    paid_count INTEGER NOT NULL DEFAULT 0,
+    paid_amount BIGINT NOT NULL DEFAULT 0,
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_payment_batches_no UNIQUE (company_id, batch_no),
+    CONSTRAINT chk_payment_batches_status CHECK (status IN ('generated', 'executed', 'cancelled'))
+);
+
+CREATE INDEX idx_payment_batches_status ON payment_batches(company_id, status, transfer_date DESC);
+
+COMMENT ON TABLE payment_batches IS 'Bulk transfers of payables due from a bank account of the company';
+
+-- ============================================
+-- PAYMENT BATCH ITEMS
+-- ============================================
+CREATE TABLE payment_batch_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    batch_id UUID NOT NULL REFERENCES payment_batches(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+
+    -- voucher_entries is partitioned: the entry is referenced without a key
+    entry_id UUID NOT NULL,
+    voucher_id UUID NOT NULL REFERENCES vouchers(id),
+    voucher_no VARCHAR(30),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    partner_id UUID NOT NULL REFERENCES partners(id),
+    due_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+
+    bank_code VARCHAR(3) NOT NULL,
+    bank_account_number VARCHAR(20) NOT NULL,
+    bank_account_holder VARCHAR(100) NOT NULL,
+    payer_memo VARCHAR(50),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'in_payment',
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_payment_batch_items_seq UNIQUE (batch_id, seq),
+    CONSTRAINT chk_payment_batch_items_amount CHECK (amount > 0),
+    CONSTRAINT chk_payment_batch_items_status CHECK (status IN ('in_payment', 'paid', 'failed', 'cancelled'))
+);
+
+CREATE INDEX idx_payment_batch_items_entry ON payment_batch_items(company_id, entry_id)
+    WHERE status IN ('in_payment', 'paid');
+CREATE INDEX idx_payment_batch_items_partner ON payment_batch_items(company_id, partner_id)
+    WHERE status IN ('in_payment', 'paid');
+
+COMMENT ON TABLE payment_batch_items IS 'Payables transferred by payment batches';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE payment_batches ENABLE ROW LEVEL SECURITY;
+ALTER TABLE payment_batch_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_payment_batches ON payment_batches
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_payment_batches ON payment_batches
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_payment_batch_items ON payment_batch_items
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_payment_batch_items ON payment_batch_items
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_payment_batches_updated_at
+    BEFORE UPDATE ON payment_batches
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,13 @@
+-- K-ERP v0.2 Migration: Notes Register (Rollback)
+
+DELETE FROM scheduled_tasks WHERE name = 'note_maturity_alert';
+
+DELETE FROM email_templates WHERE event_type = 'note_maturity';
+ALTER TABLE email_templates DROP CONSTRAINT chk_email_templates_event_type;
+ALTER TABLE email_templates ADD CONSTRAINT chk_email_templates_event_type CHECK (event_type IN (
+    'invitation', 'email_verification', 'report_delivery', 'report_failure', 'partner_statement'
+));
+
+DROP TRIGGER IF EXISTS set_notes_updated_at ON notes;
+
+DROP TABLE IF EXISTS notes;
@@ -0,0 +1,93 @@
+-- K-ERP v0.2 Migration: Notes Register
+-- Promissory notes and checks (어음·수표) received from customers and issued
+-- to suppliers, with the vouchers of their receipt or issue, discounting at
+-- a bank and settlement at maturity. The worker notifies the notes nearing
+-- maturity by email.
+
+-- ============================================
+-- NOTES
+-- ============================================
+CREATE TABLE notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    note_no VARCHAR(50) NOT NULL,
+    direction VARCHAR(20) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    partner_id UUID NOT NULL REFERENCES partners(id),
+    drawer VARCHAR(100),
+    amount BIGINT NOT NULL,
+    issue_date DATE NOT NULL,
+    maturity_date DATE NOT NULL,
+    booked_date DATE NOT NULL,
+    paying_bank VARCHAR(100),
+    description VARCHAR(500),
+
+    note_account_id UUID NOT NULL REFERENCES accounts(id),
+    counter_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'outstanding',
+
+    discounted_date DATE,
+    discount_charge BIGINT NOT NULL DEFAULT 0,
+    settled_date DATE,
+    bank_account_id UUID REFERENCES company_bank_accounts(id),
+
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    discount_voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    settlement_voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    maturity_alerted_at TIMESTAMPTZ,
+
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_notes_no UNIQUE (company_id, direction, note_no),
+    CONSTRAINT chk_notes_direction CHECK (direction IN ('received', 'issued')),
+    CONSTRAINT chk_notes_kind CHECK (kind IN ('promissory', 'electronic', 'check')),
+    CONSTRAINT chk_notes_status CHECK (status IN ('outstanding', 'discounted', 'settled')),
+    CONSTRAINT chk_notes_amount CHECK (amount > 0),
+    CONSTRAINT chk_notes_maturity CHECK (maturity_date >= issue_date),
+    CONSTRAINT chk_notes_discount CHECK (discount_charge >= 0 AND discount_charge < amount)
+);
+
+CREATE INDEX idx_notes_maturity ON notes(company_id, maturity_date) WHERE status = 'outstanding';
+CREATE INDEX idx_notes_partner ON notes(company_id, partner_id);
+CREATE INDEX idx_notes_alert ON notes(maturity_date)
+    WHERE status = 'outstanding' AND maturity_alerted_at IS NULL;
+
+COMMENT ON TABLE notes IS 'Promissory notes and checks received from or issued to partners';
+COMMENT ON COLUMN notes.booked_date IS 'Date the note was received or handed over, the date of its voucher';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE notes ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_notes ON notes
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_notes ON notes
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- MATURITY ALERTS
+-- ============================================
+ALTER TABLE email_templates DROP CONSTRAINT chk_email_templates_event_type;
+ALTER TABLE email_templates ADD CONSTRAINT chk_email_templates_event_type CHECK (event_type IN (
+    'invitation', 'email_verification', 'report_delivery', 'report_failure', 'partner_statement',
+    'note_maturity'
+));
+
+-- 00:00 UTC is 09:00 KST
+INSERT INTO scheduled_tasks (name, description, cron_expr) VALUES
+    ('note_maturity_alert', 'Email alerts of notes nearing maturity', '0 0 * * *');
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_notes_updated_at
+    BEFORE UPDATE ON notes
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,9 @@
+-- K-ERP v0.2 Migration: Petty Cash (Rollback)
+
+DROP TRIGGER IF EXISTS set_petty_cash_transactions_updated_at ON petty_cash_transactions;
+DROP TRIGGER IF EXISTS set_petty_cash_replenishments_updated_at ON petty_cash_replenishments;
+DROP TRIGGER IF EXISTS set_petty_cash_funds_updated_at ON petty_cash_funds;
+
+DROP TABLE IF EXISTS petty_cash_transactions;
+DROP TABLE IF EXISTS petty_cash_replenishments;
+DROP TABLE IF EXISTS petty_cash_funds;
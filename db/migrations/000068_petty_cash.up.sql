@@ -0,0 +1,136 @@
+-- K-ERP v0.2 Migration: Petty Cash
+-- Imprest petty cash funds (소액현금) of departments, the expense claims paid
+-- from them and the periodic replenishments that book the claims by a
+-- payment voucher and bring the cash on hand back to the float.
+
+-- ============================================
+-- PETTY CASH FUNDS
+-- ============================================
+CREATE TABLE petty_cash_funds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    name VARCHAR(100) NOT NULL,
+    department_id UUID NOT NULL REFERENCES departments(id),
+    custodian_id UUID REFERENCES users(id),
+
+    float_amount BIGINT NOT NULL,
+    established_date DATE NOT NULL,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    bank_account_id UUID NOT NULL REFERENCES company_bank_accounts(id),
+
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_petty_cash_funds_name UNIQUE (company_id, name),
+    CONSTRAINT chk_petty_cash_funds_float CHECK (float_amount > 0)
+);
+
+CREATE INDEX idx_petty_cash_funds_department ON petty_cash_funds(company_id, department_id);
+
+COMMENT ON TABLE petty_cash_funds IS 'Imprest petty cash funds of departments';
+COMMENT ON COLUMN petty_cash_funds.float_amount IS 'Cash the fund holds once replenished';
+
+-- ============================================
+-- PETTY CASH REPLENISHMENTS
+-- ============================================
+CREATE TABLE petty_cash_replenishments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    fund_id UUID NOT NULL REFERENCES petty_cash_funds(id) ON DELETE CASCADE,
+
+    request_date DATE NOT NULL,
+    claims_through DATE NOT NULL,
+    claim_count INTEGER NOT NULL,
+    amount BIGINT NOT NULL,
+    bank_account_id UUID NOT NULL REFERENCES company_bank_accounts(id),
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_petty_cash_replenishments_amount CHECK (claim_count > 0 AND amount > 0)
+);
+
+CREATE INDEX idx_petty_cash_replenishments_fund ON petty_cash_replenishments(fund_id, request_date DESC);
+
+COMMENT ON TABLE petty_cash_replenishments IS 'Replenishments of petty cash funds by payment vouchers';
+
+-- ============================================
+-- PETTY CASH TRANSACTIONS
+-- ============================================
+CREATE TABLE petty_cash_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    fund_id UUID NOT NULL REFERENCES petty_cash_funds(id) ON DELETE CASCADE,
+
+    transaction_date DATE NOT NULL,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    payee VARCHAR(100),
+    description VARCHAR(200) NOT NULL,
+    receipt_no VARCHAR(50),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    replenishment_id UUID REFERENCES petty_cash_replenishments(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_petty_cash_transactions_amount CHECK (amount > 0),
+    CONSTRAINT chk_petty_cash_transactions_status CHECK (status IN ('pending', 'replenished'))
+);
+
+CREATE INDEX idx_petty_cash_transactions_fund ON petty_cash_transactions(fund_id, transaction_date);
+CREATE INDEX idx_petty_cash_transactions_pending ON petty_cash_transactions(fund_id)
+    WHERE status = 'pending';
+CREATE INDEX idx_petty_cash_transactions_replenishment ON petty_cash_transactions(replenishment_id)
+    WHERE replenishment_id IS NOT NULL;
+
+COMMENT ON TABLE petty_cash_transactions IS 'Expense claims paid from petty cash funds';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE petty_cash_funds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE petty_cash_replenishments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE petty_cash_transactions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_petty_cash_funds ON petty_cash_funds
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_petty_cash_funds ON petty_cash_funds
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_petty_cash_replenishments ON petty_cash_replenishments
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_petty_cash_replenishments ON petty_cash_replenishments
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_petty_cash_transactions ON petty_cash_transactions
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_petty_cash_transactions ON petty_cash_transactions
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_petty_cash_funds_updated_at
+    BEFORE UPDATE ON petty_cash_funds
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_petty_cash_replenishments_updated_at
+    BEFORE UPDATE ON petty_cash_replenishments
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_petty_cash_transactions_updated_at
+    BEFORE UPDATE ON petty_cash_transactions
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
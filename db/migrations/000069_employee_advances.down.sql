@@ -0,0 +1,8 @@
+-- K-ERP v0.2 Migration: Employee Advances (Rollback)
+
+DROP TRIGGER IF EXISTS set_employee_advance_settlements_updated_at ON employee_advance_settlements;
+DROP TRIGGER IF EXISTS set_employee_advances_updated_at ON employee_advances;
+
+DROP TABLE IF EXISTS employee_advance_expenses;
+DROP TABLE IF EXISTS employee_advance_settlements;
+DROP TABLE IF EXISTS employee_advances;
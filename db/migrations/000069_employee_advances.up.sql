@@ -0,0 +1,127 @@
+-- K-ERP v0.2 Migration: Employee Advances
+-- Cash advanced to employees (가지급금·전도금) and its settlement by the
+-- expenses claimed, the cash refunded or the expenses reimbursed, each booked
+-- by a voucher against the advance account.
+
+-- ============================================
+-- EMPLOYEE ADVANCES
+-- ============================================
+CREATE TABLE employee_advances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    employee_id UUID NOT NULL REFERENCES users(id),
+    department_id UUID REFERENCES departments(id),
+    purpose VARCHAR(200) NOT NULL,
+    advance_date DATE NOT NULL,
+    due_date DATE,
+    amount BIGINT NOT NULL,
+
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    bank_account_id UUID NOT NULL REFERENCES company_bank_accounts(id),
+
+    settled_amount BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'outstanding',
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_employee_advances_amount CHECK (amount > 0),
+    CONSTRAINT chk_employee_advances_settled CHECK (settled_amount >= 0 AND settled_amount <= amount),
+    CONSTRAINT chk_employee_advances_status CHECK (status IN ('outstanding', 'settled')),
+    CONSTRAINT chk_employee_advances_due CHECK (due_date IS NULL OR due_date >= advance_date)
+);
+
+CREATE INDEX idx_employee_advances_employee ON employee_advances(company_id, employee_id, advance_date);
+CREATE INDEX idx_employee_advances_outstanding ON employee_advances(company_id, advance_date)
+    WHERE status = 'outstanding';
+
+COMMENT ON TABLE employee_advances IS 'Cash advanced to employees and settled by their expenses';
+COMMENT ON COLUMN employee_advances.settled_amount IS 'Expenses and refunds applied to the advance';
+
+-- ============================================
+-- EMPLOYEE ADVANCE SETTLEMENTS
+-- ============================================
+CREATE TABLE employee_advance_settlements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    advance_id UUID NOT NULL REFERENCES employee_advances(id) ON DELETE CASCADE,
+
+    settlement_date DATE NOT NULL,
+    expense_amount BIGINT NOT NULL,
+    refund_amount BIGINT NOT NULL DEFAULT 0,
+    reimburse_amount BIGINT NOT NULL DEFAULT 0,
+    applied_amount BIGINT NOT NULL,
+    bank_account_id UUID REFERENCES company_bank_accounts(id),
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_employee_advance_settlements_amounts CHECK (
+        expense_amount >= 0 AND refund_amount >= 0 AND reimburse_amount >= 0 AND applied_amount >= 0
+    )
+);
+
+CREATE INDEX idx_employee_advance_settlements_advance ON employee_advance_settlements(advance_id, settlement_date);
+
+COMMENT ON TABLE employee_advance_settlements IS 'Settlements of employee advances by expenses and refunds';
+
+CREATE TABLE employee_advance_expenses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    settlement_id UUID NOT NULL REFERENCES employee_advance_settlements(id) ON DELETE CASCADE,
+
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    description VARCHAR(200) NOT NULL,
+    receipt_no VARCHAR(50),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_employee_advance_expenses_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_employee_advance_expenses_settlement ON employee_advance_expenses(settlement_id);
+
+COMMENT ON TABLE employee_advance_expenses IS 'Expenses claimed by settlements of employee advances';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE employee_advances ENABLE ROW LEVEL SECURITY;
+ALTER TABLE employee_advance_settlements ENABLE ROW LEVEL SECURITY;
+ALTER TABLE employee_advance_expenses ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_employee_advances ON employee_advances
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_employee_advances ON employee_advances
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_employee_advance_settlements ON employee_advance_settlements
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_employee_advance_settlements ON employee_advance_settlements
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_employee_advance_expenses ON employee_advance_expenses
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_employee_advance_expenses ON employee_advance_expenses
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_employee_advances_updated_at
+    BEFORE UPDATE ON employee_advances
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_employee_advance_settlements_updated_at
+    BEFORE UPDATE ON employee_advance_settlements
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
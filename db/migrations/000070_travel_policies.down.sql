@@ -0,0 +1,25 @@
+-- K-ERP v0.2 Migration: Travel Policies (Rollback)
+
+DROP INDEX IF EXISTS idx_employee_advance_settlements_review;
+
+ALTER TABLE employee_advance_settlements
+    DROP CONSTRAINT IF EXISTS chk_employee_advance_settlements_review,
+    DROP COLUMN IF EXISTS review_note,
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS reviewed_by,
+    DROP COLUMN IF EXISTS review_status,
+    DROP COLUMN IF EXISTS policy_violations,
+    DROP COLUMN IF EXISTS policy_id;
+
+ALTER TABLE employee_advance_expenses
+    DROP CONSTRAINT IF EXISTS chk_employee_advance_expenses_category,
+    DROP COLUMN IF EXISTS distance_km,
+    DROP COLUMN IF EXISTS days,
+    DROP COLUMN IF EXISTS destination,
+    DROP COLUMN IF EXISTS category;
+
+DROP TRIGGER IF EXISTS set_travel_policies_updated_at ON travel_policies;
+
+DROP TABLE IF EXISTS travel_policies;
+
+ALTER TABLE users DROP COLUMN IF EXISTS grade;
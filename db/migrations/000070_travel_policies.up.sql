@@ -0,0 +1,85 @@
+-- K-ERP v0.2 Migration: Travel Policies
+-- Travel expense policies (여비규정) of the company or its departments: per
+-- diem rates by destination and grade, the receipt threshold and the mileage
+-- rate. Settlements of employee advances are checked against the policy and
+-- their violations flagged for the approver.
+
+-- Grade (직급) of the user the per diem rates are looked up by
+ALTER TABLE users ADD COLUMN grade VARCHAR(50);
+
+-- ============================================
+-- TRAVEL POLICIES
+-- ============================================
+CREATE TABLE travel_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    name VARCHAR(100) NOT NULL,
+    department_id UUID REFERENCES departments(id) ON DELETE CASCADE,
+
+    per_diem_rates JSONB NOT NULL DEFAULT '[]',
+    receipt_threshold BIGINT NOT NULL DEFAULT 0,
+    mileage_rate BIGINT NOT NULL DEFAULT 0,
+
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_travel_policies_name UNIQUE (company_id, name),
+    CONSTRAINT chk_travel_policies_amounts CHECK (receipt_threshold >= 0 AND mileage_rate >= 0)
+);
+
+-- One active policy for the whole company and one for each department
+CREATE UNIQUE INDEX uq_travel_policies_active ON travel_policies(
+    company_id, COALESCE(department_id, '00000000-0000-0000-0000-000000000000')
+) WHERE is_active;
+
+COMMENT ON TABLE travel_policies IS 'Travel expense policies of companies, overridden by departments';
+COMMENT ON COLUMN travel_policies.per_diem_rates IS 'Daily allowances by destination and grade; empty fields match any';
+COMMENT ON COLUMN travel_policies.receipt_threshold IS 'Expenses from this amount need a receipt, 0 for none';
+
+-- ============================================
+-- POLICY CHECKS OF ADVANCE SETTLEMENTS
+-- ============================================
+ALTER TABLE employee_advance_expenses
+    ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT 'general',
+    ADD COLUMN destination VARCHAR(50),
+    ADD COLUMN days INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN distance_km INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_employee_advance_expenses_category CHECK (
+        category IN ('general', 'per_diem', 'mileage') AND days >= 0 AND distance_km >= 0
+    );
+
+ALTER TABLE employee_advance_settlements
+    ADD COLUMN policy_id UUID REFERENCES travel_policies(id) ON DELETE SET NULL,
+    ADD COLUMN policy_violations JSONB,
+    ADD COLUMN review_status VARCHAR(20) NOT NULL DEFAULT 'none',
+    ADD COLUMN reviewed_by UUID REFERENCES users(id),
+    ADD COLUMN reviewed_at TIMESTAMPTZ,
+    ADD COLUMN review_note VARCHAR(500),
+    ADD CONSTRAINT chk_employee_advance_settlements_review CHECK (review_status IN ('none', 'pending', 'reviewed'));
+
+CREATE INDEX idx_employee_advance_settlements_review ON employee_advance_settlements(company_id, settlement_date)
+    WHERE review_status = 'pending';
+
+COMMENT ON COLUMN employee_advance_settlements.review_status IS 'Review of the policy violations by the approver';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE travel_policies ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_travel_policies ON travel_policies
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_travel_policies ON travel_policies
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_travel_policies_updated_at
+    BEFORE UPDATE ON travel_policies
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,13 @@
+-- K-ERP v0.2 Migration: Documents (Rollback)
+
+DROP TRIGGER IF EXISTS set_document_file_links_updated_at ON document_file_links;
+DROP TRIGGER IF EXISTS set_document_versions_updated_at ON document_versions;
+DROP TRIGGER IF EXISTS set_document_files_updated_at ON document_files;
+DROP TRIGGER IF EXISTS set_document_folder_permissions_updated_at ON document_folder_permissions;
+DROP TRIGGER IF EXISTS set_document_folders_updated_at ON document_folders;
+
+DROP TABLE IF EXISTS document_file_links;
+DROP TABLE IF EXISTS document_versions;
+DROP TABLE IF EXISTS document_files;
+DROP TABLE IF EXISTS document_folder_permissions;
+DROP TABLE IF EXISTS document_folders;
@@ -0,0 +1,183 @@
+-- K-ERP v0.2 Migration: Documents
+-- Document library (문서함) of the company: a folder tree with role
+-- permissions, files with searchable metadata and versions, and links of files
+-- to the business documents they support.
+
+-- ============================================
+-- DOCUMENT FOLDERS
+-- ============================================
+CREATE TABLE document_folders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    parent_id UUID REFERENCES document_folders(id),
+    name VARCHAR(100) NOT NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_document_folders_parent CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+-- Folder names are unique among their siblings
+CREATE UNIQUE INDEX uq_document_folders_name ON document_folders(
+    company_id, COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), LOWER(name)
+);
+
+COMMENT ON TABLE document_folders IS 'Folder tree of the document library; folders without parent are at the root';
+
+CREATE TABLE document_folder_permissions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    folder_id UUID NOT NULL REFERENCES document_folders(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL,
+    access VARCHAR(20) NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_document_folder_permissions_role UNIQUE (folder_id, role),
+    CONSTRAINT chk_document_folder_permissions_access CHECK (access IN ('none', 'read', 'write', 'manage'))
+);
+
+COMMENT ON TABLE document_folder_permissions IS 'Access of roles to folders; the nearest folder up the tree with permissions decides';
+
+-- ============================================
+-- DOCUMENT FILES
+-- ============================================
+CREATE TABLE document_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    folder_id UUID NOT NULL REFERENCES document_folders(id),
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(500),
+    metadata JSONB NOT NULL DEFAULT '{}',
+
+    current_version INTEGER NOT NULL DEFAULT 0,
+    file_type VARCHAR(100),
+    file_size BIGINT NOT NULL DEFAULT 0,
+
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_document_files_name ON document_files(folder_id, LOWER(name));
+CREATE INDEX idx_document_files_company ON document_files(company_id, updated_at DESC);
+CREATE INDEX idx_document_files_metadata ON document_files USING GIN (metadata jsonb_path_ops);
+
+COMMENT ON TABLE document_files IS 'Files of the document library';
+COMMENT ON COLUMN document_files.metadata IS 'Free key-value fields the files are searched by';
+
+CREATE TABLE document_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    file_id UUID NOT NULL REFERENCES document_files(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    file_type VARCHAR(100),
+    file_data BYTEA,
+    scan_status VARCHAR(20) NOT NULL DEFAULT 'unscanned',
+    scanner VARCHAR(50),
+    scanned_at TIMESTAMPTZ,
+    comment VARCHAR(500),
+
+    uploaded_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_document_versions_version UNIQUE (file_id, version),
+    CONSTRAINT chk_document_versions_scan_status CHECK (scan_status IN ('clean', 'unscanned'))
+);
+
+COMMENT ON TABLE document_versions IS 'Uploaded contents of document files, scanned for malware before they are stored';
+
+-- ============================================
+-- DOCUMENT FILE LINKS
+-- ============================================
+CREATE TABLE document_file_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    file_id UUID NOT NULL REFERENCES document_files(id) ON DELETE CASCADE,
+    entity_type VARCHAR(30) NOT NULL,
+    entity_id UUID NOT NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_document_file_links_entity UNIQUE (file_id, entity_type, entity_id)
+);
+
+CREATE INDEX idx_document_file_links_entity ON document_file_links(company_id, entity_type, entity_id);
+
+COMMENT ON TABLE document_file_links IS 'Links of library files to the business documents they support';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE document_folders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_folder_permissions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_files ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_versions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_file_links ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_document_folders ON document_folders
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_document_folders ON document_folders
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_document_folder_permissions ON document_folder_permissions
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_document_folder_permissions ON document_folder_permissions
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_document_files ON document_files
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_document_files ON document_files
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_document_versions ON document_versions
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_document_versions ON document_versions
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_document_file_links ON document_file_links
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_document_file_links ON document_file_links
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_document_folders_updated_at
+    BEFORE UPDATE ON document_folders
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_document_folder_permissions_updated_at
+    BEFORE UPDATE ON document_folder_permissions
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_document_files_updated_at
+    BEFORE UPDATE ON document_files
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_document_versions_updated_at
+    BEFORE UPDATE ON document_versions
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_document_file_links_updated_at
+    BEFORE UPDATE ON document_file_links
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,18 @@
+-- K-ERP v0.2 Migration: Contract Register (Rollback)
+
+DELETE FROM scheduled_tasks WHERE name = 'contract_milestone_reminder';
+
+DELETE FROM email_templates WHERE event_type = 'contract_milestone';
+ALTER TABLE email_templates DROP CONSTRAINT chk_email_templates_event_type;
+ALTER TABLE email_templates ADD CONSTRAINT chk_email_templates_event_type CHECK (event_type IN (
+    'invitation', 'email_verification', 'report_delivery', 'report_failure', 'partner_statement',
+    'note_maturity'
+));
+
+DROP TRIGGER IF EXISTS set_contract_collections_updated_at ON contract_collections;
+DROP TRIGGER IF EXISTS set_contract_milestones_updated_at ON contract_milestones;
+DROP TRIGGER IF EXISTS set_contracts_updated_at ON contracts;
+
+DROP TABLE IF EXISTS contract_collections;
+DROP TABLE IF EXISTS contract_milestones;
+DROP TABLE IF EXISTS contracts;
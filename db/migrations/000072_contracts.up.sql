@@ -0,0 +1,159 @@
+-- K-ERP v0.2 Migration: Contract Register
+-- Sales contracts with partners and their billing milestones (청구 일정).
+-- Invoicing a milestone drafts its sales tax invoice and voucher; receipts
+-- are booked per milestone. The worker reminds the admins of milestones
+-- nearing their due date by email.
+
+-- ============================================
+-- CONTRACTS
+-- ============================================
+CREATE TABLE contracts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    contract_no VARCHAR(50) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    partner_id UUID NOT NULL REFERENCES partners(id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    description VARCHAR(500),
+
+    tax_code_id UUID REFERENCES tax_codes(id),
+    revenue_account_id UUID NOT NULL REFERENCES accounts(id),
+    receivable_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_contracts_no UNIQUE (company_id, contract_no),
+    CONSTRAINT chk_contracts_status CHECK (status IN ('active', 'completed')),
+    CONSTRAINT chk_contracts_amount CHECK (amount > 0),
+    CONSTRAINT chk_contracts_period CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_contracts_partner ON contracts(company_id, partner_id);
+CREATE INDEX idx_contracts_status ON contracts(company_id, status);
+
+COMMENT ON TABLE contracts IS 'Sales contracts billed in milestones';
+COMMENT ON COLUMN contracts.amount IS 'Contracted supply amount excluding VAT, the total of the milestones';
+
+-- ============================================
+-- CONTRACT MILESTONES
+-- ============================================
+CREATE TABLE contract_milestones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    contract_id UUID NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+
+    seq INTEGER NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    due_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+
+    invoice_date DATE,
+    tax_amount BIGINT NOT NULL DEFAULT 0,
+    tax_invoice_id UUID REFERENCES tax_invoices(id) ON DELETE SET NULL,
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    collected_amount BIGINT NOT NULL DEFAULT 0,
+    reminded_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_contract_milestones_seq UNIQUE (contract_id, seq),
+    CONSTRAINT chk_contract_milestones_status CHECK (status IN ('scheduled', 'invoiced', 'collected')),
+    CONSTRAINT chk_contract_milestones_amount CHECK (amount > 0),
+    CONSTRAINT chk_contract_milestones_collected CHECK (collected_amount >= 0 AND collected_amount <= amount + tax_amount)
+);
+
+CREATE INDEX idx_contract_milestones_due ON contract_milestones(company_id, due_date) WHERE status = 'scheduled';
+CREATE INDEX idx_contract_milestones_reminder ON contract_milestones(due_date)
+    WHERE status = 'scheduled' AND reminded_at IS NULL;
+
+COMMENT ON TABLE contract_milestones IS 'Billing milestones of contracts and their invoicing';
+COMMENT ON COLUMN contract_milestones.amount IS 'Supply amount excluding VAT; tax_amount is set on invoicing';
+
+-- ============================================
+-- CONTRACT COLLECTIONS
+-- ============================================
+CREATE TABLE contract_collections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    contract_id UUID NOT NULL REFERENCES contracts(id) ON DELETE CASCADE,
+    milestone_id UUID NOT NULL REFERENCES contract_milestones(id) ON DELETE CASCADE,
+
+    collected_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    bank_account_id UUID NOT NULL REFERENCES company_bank_accounts(id),
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_contract_collections_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_contract_collections_contract ON contract_collections(contract_id, collected_date);
+
+COMMENT ON TABLE contract_collections IS 'Receipts of invoiced contract milestones';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE contracts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE contract_milestones ENABLE ROW LEVEL SECURITY;
+ALTER TABLE contract_collections ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_contracts ON contracts
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_contracts ON contracts
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_contract_milestones ON contract_milestones
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_contract_milestones ON contract_milestones
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_contract_collections ON contract_collections
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_contract_collections ON contract_collections
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- MILESTONE REMINDERS
+-- ============================================
+ALTER TABLE email_templates DROP CONSTRAINT chk_email_templates_event_type;
+ALTER TABLE email_templates ADD CONSTRAINT chk_email_templates_event_type CHECK (event_type IN (
+    'invitation', 'email_verification', 'report_delivery', 'report_failure', 'partner_statement',
+    'note_maturity', 'contract_milestone'
+));
+
+-- 00:00 UTC is 09:00 KST
+INSERT INTO scheduled_tasks (name, description, cron_expr) VALUES
+    ('contract_milestone_reminder', 'Email reminders of contract milestones due for billing', '0 0 * * *');
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_contracts_updated_at
+    BEFORE UPDATE ON contracts
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_contract_milestones_updated_at
+    BEFORE UPDATE ON contract_milestones
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_contract_collections_updated_at
+    BEFORE UPDATE ON contract_collections
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
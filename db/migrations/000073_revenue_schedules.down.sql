@@ -0,0 +1,9 @@
+-- K-ERP v0.2 Migration: Revenue Recognition Schedules (Rollback)
+
+DELETE FROM scheduled_tasks WHERE name = 'revenue_recognition';
+
+DROP TRIGGER IF EXISTS set_revenue_schedule_lines_updated_at ON revenue_schedule_lines;
+DROP TRIGGER IF EXISTS set_revenue_schedules_updated_at ON revenue_schedules;
+
+DROP TABLE IF EXISTS revenue_schedule_lines;
+DROP TABLE IF EXISTS revenue_schedules;
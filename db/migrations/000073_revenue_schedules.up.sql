@@ -0,0 +1,107 @@
+-- K-ERP v0.2 Migration: Revenue Recognition Schedules
+-- Deferral of the revenue of sales tax invoices covering a service period
+-- (선수수익) and its monthly recognition, posted by the worker at each month
+-- end once the deferral voucher is posted.
+
+-- ============================================
+-- REVENUE SCHEDULES
+-- ============================================
+CREATE TABLE revenue_schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    tax_invoice_id UUID NOT NULL REFERENCES tax_invoices(id),
+    invoice_number VARCHAR(50) NOT NULL,
+    partner_id UUID REFERENCES partners(id),
+    description VARCHAR(500) NOT NULL,
+    service_start DATE NOT NULL,
+    service_end DATE NOT NULL,
+    amount BIGINT NOT NULL,
+
+    revenue_account_id UUID NOT NULL REFERENCES accounts(id),
+    deferred_revenue_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    deferral_date DATE NOT NULL,
+    deferral_voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    recognized_amount BIGINT NOT NULL DEFAULT 0,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_revenue_schedules_invoice UNIQUE (company_id, tax_invoice_id),
+    CONSTRAINT chk_revenue_schedules_status CHECK (status IN ('active', 'completed')),
+    CONSTRAINT chk_revenue_schedules_amount CHECK (amount > 0),
+    CONSTRAINT chk_revenue_schedules_period CHECK (service_end >= service_start),
+    CONSTRAINT chk_revenue_schedules_recognized CHECK (recognized_amount >= 0 AND recognized_amount <= amount)
+);
+
+CREATE INDEX idx_revenue_schedules_status ON revenue_schedules(company_id, status);
+
+COMMENT ON TABLE revenue_schedules IS 'Deferred revenue of sales tax invoices recognized over their service period';
+COMMENT ON COLUMN revenue_schedules.amount IS 'Deferred supply amount, at most the supply amount of the invoice';
+
+-- ============================================
+-- REVENUE SCHEDULE LINES
+-- ============================================
+CREATE TABLE revenue_schedule_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL REFERENCES revenue_schedules(id) ON DELETE CASCADE,
+
+    seq INTEGER NOT NULL,
+    period_end DATE NOT NULL,
+    days INTEGER NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    posted_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_revenue_schedule_lines_seq UNIQUE (schedule_id, seq),
+    CONSTRAINT chk_revenue_schedule_lines_status CHECK (status IN ('pending', 'posted')),
+    CONSTRAINT chk_revenue_schedule_lines_amount CHECK (amount >= 0)
+);
+
+CREATE INDEX idx_revenue_schedule_lines_due ON revenue_schedule_lines(period_end) WHERE status = 'pending';
+
+COMMENT ON TABLE revenue_schedule_lines IS 'Monthly recognitions of revenue schedules, prorated by service days';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE revenue_schedules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE revenue_schedule_lines ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_revenue_schedules ON revenue_schedules
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_revenue_schedules ON revenue_schedules
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_revenue_schedule_lines ON revenue_schedule_lines
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_revenue_schedule_lines ON revenue_schedule_lines
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- MONTH-END RECOGNITION
+-- ============================================
+INSERT INTO scheduled_tasks (name, description, cron_expr) VALUES
+    ('revenue_recognition', 'Posting of deferred revenue recognized at the last month end', '0 */6 * * *');
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_revenue_schedules_updated_at
+    BEFORE UPDATE ON revenue_schedules
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_revenue_schedule_lines_updated_at
+    BEFORE UPDATE ON revenue_schedule_lines
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,9 @@
+-- K-ERP v0.2 Migration: Prepaid Expense Amortization (Rollback)
+
+DELETE FROM scheduled_tasks WHERE name = 'prepaid_amortization';
+
+DROP TRIGGER IF EXISTS set_prepaid_expense_lines_updated_at ON prepaid_expense_lines;
+DROP TRIGGER IF EXISTS set_prepaid_expenses_updated_at ON prepaid_expenses;
+
+DROP TABLE IF EXISTS prepaid_expense_lines;
+DROP TABLE IF EXISTS prepaid_expenses;
@@ -0,0 +1,107 @@
+-- K-ERP v0.2 Migration: Prepaid Expense Amortization
+-- Prepaid expenses (선급비용) moved out of the expense booked by a posted
+-- voucher and amortized monthly over the period they cover, posted by the
+-- worker at each month end once the prepayment voucher is posted.
+
+-- ============================================
+-- PREPAID EXPENSES
+-- ============================================
+CREATE TABLE prepaid_expenses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    voucher_id UUID NOT NULL REFERENCES vouchers(id),
+    voucher_no VARCHAR(20) NOT NULL,
+    partner_id UUID REFERENCES partners(id),
+    description VARCHAR(500) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    amount BIGINT NOT NULL,
+
+    expense_account_id UUID NOT NULL REFERENCES accounts(id),
+    prepaid_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    prepayment_date DATE NOT NULL,
+    prepayment_voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    amortized_amount BIGINT NOT NULL DEFAULT 0,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_prepaid_expenses_voucher UNIQUE (company_id, voucher_id, expense_account_id),
+    CONSTRAINT chk_prepaid_expenses_status CHECK (status IN ('active', 'completed')),
+    CONSTRAINT chk_prepaid_expenses_amount CHECK (amount > 0),
+    CONSTRAINT chk_prepaid_expenses_period CHECK (period_end >= period_start),
+    CONSTRAINT chk_prepaid_expenses_amortized CHECK (amortized_amount >= 0 AND amortized_amount <= amount)
+);
+
+CREATE INDEX idx_prepaid_expenses_status ON prepaid_expenses(company_id, status);
+
+COMMENT ON TABLE prepaid_expenses IS 'Prepaid expenses of posted vouchers amortized over the period they cover';
+COMMENT ON COLUMN prepaid_expenses.amount IS 'Prepaid amount, at most the expense debited by the voucher';
+
+-- ============================================
+-- PREPAID EXPENSE LINES
+-- ============================================
+CREATE TABLE prepaid_expense_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    prepaid_expense_id UUID NOT NULL REFERENCES prepaid_expenses(id) ON DELETE CASCADE,
+
+    seq INTEGER NOT NULL,
+    period_end DATE NOT NULL,
+    days INTEGER NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+    posted_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_prepaid_expense_lines_seq UNIQUE (prepaid_expense_id, seq),
+    CONSTRAINT chk_prepaid_expense_lines_status CHECK (status IN ('pending', 'posted')),
+    CONSTRAINT chk_prepaid_expense_lines_amount CHECK (amount >= 0)
+);
+
+CREATE INDEX idx_prepaid_expense_lines_due ON prepaid_expense_lines(period_end) WHERE status = 'pending';
+
+COMMENT ON TABLE prepaid_expense_lines IS 'Monthly amortizations of prepaid expenses, prorated by days';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE prepaid_expenses ENABLE ROW LEVEL SECURITY;
+ALTER TABLE prepaid_expense_lines ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_prepaid_expenses ON prepaid_expenses
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_prepaid_expenses ON prepaid_expenses
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_prepaid_expense_lines ON prepaid_expense_lines
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_prepaid_expense_lines ON prepaid_expense_lines
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- MONTH-END AMORTIZATION
+-- ============================================
+INSERT INTO scheduled_tasks (name, description, cron_expr) VALUES
+    ('prepaid_amortization', 'Posting of prepaid expenses amortized at the last month end', '0 */6 * * *');
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_prepaid_expenses_updated_at
+    BEFORE UPDATE ON prepaid_expenses
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_prepaid_expense_lines_updated_at
+    BEFORE UPDATE ON prepaid_expense_lines
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,9 @@
+-- K-ERP v0.2 Migration: Inventory Counts (Rollback)
+
+DROP TRIGGER IF EXISTS set_inventory_count_lines_updated_at ON inventory_count_lines;
+DROP TRIGGER IF EXISTS set_inventory_counts_updated_at ON inventory_counts;
+DROP TRIGGER IF EXISTS set_inventory_items_updated_at ON inventory_items;
+
+DROP TABLE IF EXISTS inventory_count_lines;
+DROP TABLE IF EXISTS inventory_counts;
+DROP TABLE IF EXISTS inventory_items;
@@ -0,0 +1,136 @@
+-- K-ERP v0.2 Migration: Inventory Counts
+-- Items kept in stock with their quantities on hand, and stocktakes (재고실사)
+-- snapshotting them, recording the counted quantities and posting the
+-- variances as an inventory adjustment voucher on approval.
+
+-- ============================================
+-- INVENTORY ITEMS
+-- ============================================
+CREATE TABLE inventory_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    unit VARCHAR(20) NOT NULL DEFAULT 'EA',
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    unit_cost BIGINT NOT NULL DEFAULT 0,
+    quantity DECIMAL(18, 4) NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_inventory_items_code UNIQUE (company_id, code),
+    CONSTRAINT chk_inventory_items_unit_cost CHECK (unit_cost >= 0),
+    CONSTRAINT chk_inventory_items_quantity CHECK (quantity >= 0)
+);
+
+CREATE INDEX idx_inventory_items_account ON inventory_items(company_id, account_id) WHERE is_active;
+
+COMMENT ON TABLE inventory_items IS 'Items kept in stock with their quantities on hand, set by posted inventory counts';
+
+-- ============================================
+-- INVENTORY COUNTS
+-- ============================================
+CREATE TABLE inventory_counts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    count_date DATE NOT NULL,
+    description VARCHAR(200) NOT NULL,
+    account_id UUID REFERENCES accounts(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+
+    loss_account_id UUID NOT NULL REFERENCES accounts(id),
+    gain_account_id UUID NOT NULL REFERENCES accounts(id),
+
+    submitted_by UUID REFERENCES users(id),
+    submitted_at TIMESTAMPTZ,
+    approved_by UUID REFERENCES users(id),
+    approved_at TIMESTAMPTZ,
+    voucher_id UUID REFERENCES vouchers(id) ON DELETE SET NULL,
+
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_inventory_counts_status CHECK (status IN ('open', 'submitted', 'posted', 'cancelled'))
+);
+
+-- One count in progress per company
+CREATE UNIQUE INDEX uq_inventory_counts_in_progress ON inventory_counts(company_id)
+    WHERE status IN ('open', 'submitted');
+CREATE INDEX idx_inventory_counts_date ON inventory_counts(company_id, count_date DESC);
+
+COMMENT ON TABLE inventory_counts IS 'Stocktakes posting the variances of the counted quantities on approval';
+
+-- ============================================
+-- INVENTORY COUNT LINES
+-- ============================================
+CREATE TABLE inventory_count_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    count_id UUID NOT NULL REFERENCES inventory_counts(id) ON DELETE CASCADE,
+
+    item_id UUID NOT NULL REFERENCES inventory_items(id),
+    item_code VARCHAR(50) NOT NULL,
+    item_name VARCHAR(200) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    unit_cost BIGINT NOT NULL,
+    system_quantity DECIMAL(18, 4) NOT NULL,
+    counted_quantity DECIMAL(18, 4),
+    note VARCHAR(200),
+    counted_by UUID REFERENCES users(id),
+    counted_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_inventory_count_lines_item UNIQUE (count_id, item_id),
+    CONSTRAINT chk_inventory_count_lines_counted CHECK (counted_quantity IS NULL OR counted_quantity >= 0)
+);
+
+COMMENT ON COLUMN inventory_count_lines.system_quantity IS 'Quantity on hand when the count opened';
+
+-- ============================================
+-- ROW LEVEL SECURITY
+-- ============================================
+ALTER TABLE inventory_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE inventory_counts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE inventory_count_lines ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_inventory_items ON inventory_items
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_inventory_items ON inventory_items
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_inventory_counts ON inventory_counts
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_inventory_counts ON inventory_counts
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_isolation_inventory_count_lines ON inventory_count_lines
+    USING (company_id = current_tenant_id() OR is_admin_context());
+
+CREATE POLICY tenant_insert_inventory_count_lines ON inventory_count_lines
+    FOR INSERT WITH CHECK (company_id = current_tenant_id() OR is_admin_context());
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+CREATE TRIGGER set_inventory_items_updated_at
+    BEFORE UPDATE ON inventory_items
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_inventory_counts_updated_at
+    BEFORE UPDATE ON inventory_counts
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_inventory_count_lines_updated_at
+    BEFORE UPDATE ON inventory_count_lines
+    FOR EACH ROW EXECUTE FUNCTION trigger_set_updated_at();
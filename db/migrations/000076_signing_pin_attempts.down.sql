@@ -0,0 +1,3 @@
+-- K-ERP v0.2 Migration: Signing PIN Attempts (Rollback)
+
+ALTER TABLE users DROP COLUMN IF EXISTS signing_pin_attempts;
@@ -0,0 +1,7 @@
+-- K-ERP v0.2 Migration: Signing PIN Attempts
+-- Incorrect signing PINs are counted and lock signing until an administrator
+-- resets the count or the user sets a new PIN
+
+ALTER TABLE users ADD COLUMN signing_pin_attempts INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN users.signing_pin_attempts IS 'signing PIN confirmations since the last correct one; signing locks at the limit';
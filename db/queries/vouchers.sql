@@ -138,6 +138,7 @@ WHERE company_id = $1
 INSERT INTO voucher_entries (
     voucher_id,
     company_id,
+    fiscal_year,
     line_no,
     account_id,
     debit_amount,
@@ -149,7 +150,7 @@ INSERT INTO voucher_entries (
     cost_center_id,
     tags
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 )
 RETURNING *;
 
@@ -12,6 +12,7 @@ INSERT INTO permissions (code, name, module, description) VALUES
 ('user.edit', 'Edit Users', 'user', 'Edit user information'),
 ('user.delete', 'Delete Users', 'user', 'Deactivate or delete users'),
 ('user.role', 'Manage User Roles', 'user', 'Assign roles to users'),
+('user.invite', 'Invite Users', 'user', 'Invite users to the company by email'),
 
 -- Role management
 ('role.view', 'View Roles', 'role', 'View roles and permissions'),
@@ -33,6 +34,11 @@ INSERT INTO permissions (code, name, module, description) VALUES
 ('voucher.approve', 'Approve Vouchers', 'accounting', 'Approve pending vouchers'),
 ('voucher.post', 'Post Vouchers', 'accounting', 'Post approved vouchers to ledger'),
 ('voucher.reverse', 'Reverse Vouchers', 'accounting', 'Create reversal entries'),
+('voucher.print', 'Print Vouchers', 'accounting', 'Print vouchers and customize the print template'),
+
+-- Accounting - Tax Codes
+('tax_code.view', 'View Tax Codes', 'accounting', 'View VAT tax codes'),
+('tax_code.manage', 'Manage Tax Codes', 'accounting', 'Create and edit VAT tax codes'),
 
 -- Accounting - Reports
 ('report.trial_balance', 'View Trial Balance', 'report', 'View trial balance report'),
@@ -40,6 +46,16 @@ INSERT INTO permissions (code, name, module, description) VALUES
 ('report.income_statement', 'View Income Statement', 'report', 'View profit and loss'),
 ('report.balance_sheet', 'View Balance Sheet', 'report', 'View financial position'),
 ('report.cash_flow', 'View Cash Flow', 'report', 'View cash flow statement'),
+('report.vat_return', 'View VAT Return', 'report', 'View VAT return summary'),
+('report.schedule.view', 'View Report Schedules', 'report', 'View scheduled report deliveries'),
+('report.schedule.manage', 'Manage Report Schedules', 'report', 'Create, edit and run scheduled report deliveries'),
+('report.custom.view', 'View Custom Reports', 'report', 'Run and export saved custom reports'),
+('report.custom.manage', 'Manage Custom Reports', 'report', 'Create and edit custom report definitions'),
+('report.department_income', 'View Department Income Statement', 'report', 'View profit and loss by department'),
+('report.allocation.manage', 'Manage Allocation Rules', 'report', 'Create and edit department cost allocation rules'),
+('report.partner_ledger', 'View Partner Ledger', 'report', 'View partner statements of account'),
+('report.partner_ledger.send', 'Send Partner Statements', 'report', 'Email monthly statements to partners'),
+('report.cash_book', 'View Cash Book', 'report', 'View cash and bank book'),
 
 -- Tax Invoices
 ('invoice.view', 'View Invoices', 'invoice', 'View tax invoices'),
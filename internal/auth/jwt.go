@@ -4,6 +4,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -15,22 +17,65 @@ import (
 
 // JWTService handles JWT token operations
 type JWTService struct {
-	secret          []byte
+	mu              sync.RWMutex
+	signing         *signingKey
+	keys            map[string]*signingKey // verification keys by kid; "" is the shared secret
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 	issuer          string
+
+	// The shared secret replaced by RotateSecret, accepted until previousUntil
+	previousHMAC  *signingKey
+	previousUntil time.Time
 }
 
-// NewJWTService creates a new JWT service
+// NewJWTService creates a JWT service signing with the shared secret (HS256)
 func NewJWTService(cfg *config.JWTConfig) *JWTService {
+	hmac := newHMACKey(cfg.Secret)
 	return &JWTService{
-		secret:          []byte(cfg.Secret),
+		signing:         hmac,
+		keys:            map[string]*signingKey{"": hmac},
 		accessTokenTTL:  cfg.AccessTokenTTL,
 		refreshTokenTTL: cfg.RefreshTokenTTL,
 		issuer:          cfg.Issuer,
 	}
 }
 
+// LoadJWTService creates a JWT service from the configured keys. With a signing key id,
+// tokens are signed with that asymmetric key and carry its kid; every configured key is
+// accepted for verification so tokens signed before a rotation stay valid until they expire.
+// Without one it behaves like NewJWTService.
+func LoadJWTService(cfg *config.JWTConfig) (*JWTService, error) {
+	s := NewJWTService(cfg)
+	if cfg.SigningKeyID == "" {
+		return s, nil
+	}
+	if !cfg.AcceptHMAC {
+		delete(s.keys, "")
+	}
+
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadSigningKey(keyCfg)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := s.keys[key.id]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id: %s", key.id)
+		}
+		s.keys[key.id] = key
+	}
+
+	signing, ok := s.keys[cfg.SigningKeyID]
+	if !ok || signing.id == "" {
+		return nil, fmt.Errorf("jwt signing key %s is not configured", cfg.SigningKeyID)
+	}
+	if signing.private == nil {
+		return nil, fmt.Errorf("jwt signing key %s has no private key", cfg.SigningKeyID)
+	}
+	s.signing = signing
+	return s, nil
+}
+
 // GenerateAccessToken generates a new access token
 func (s *JWTService) GenerateAccessToken(userID, companyID uuid.UUID, email, name string, roles []string) (string, error) {
 	now := time.Now()
@@ -51,8 +96,15 @@ func (s *JWTService) GenerateAccessToken(userID, companyID uuid.UUID, email, nam
 		TokenType: TokenTypeAccess,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.secret)
+	s.mu.RLock()
+	signing := s.signing
+	s.mu.RUnlock()
+
+	token := jwt.NewWithClaims(signing.method, claims)
+	if signing.id != "" {
+		token.Header["kid"] = signing.id
+	}
+	tokenString, err := token.SignedString(signing.private)
 	if err != nil {
 		return "", errors.Wrap(errors.CodeInternal, "failed to sign token", err)
 	}
@@ -72,11 +124,26 @@ func (s *JWTService) GenerateRefreshToken() (string, error) {
 // ValidateToken validates and parses a JWT token
 func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		// Tokens without a kid were signed with the shared secret
+		kid, _ := token.Header["kid"].(string)
+		s.mu.RLock()
+		key, ok := s.keys[kid]
+		previous := s.previousHMAC
+		if kid != "" || time.Now().After(s.previousUntil) {
+			previous = nil
+		}
+		s.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %q", kid)
+		}
+		// Validate signing method against the key, never the token's own claim
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return s.secret, nil
+		if previous != nil {
+			return jwt.VerificationKeySet{Keys: []jwt.VerificationKey{key.public, previous.public}}, nil
+		}
+		return key.public, nil
 	})
 
 	if err != nil {
@@ -114,6 +181,48 @@ func (s *JWTService) GenerateTokenPair(userID, companyID uuid.UUID, email, name
 	}, nil
 }
 
+// JWKS returns the public verification keys for other services to validate tokens.
+// The shared secret is never included.
+func (s *JWTService) JWKS() JWKS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JWKS{Keys: []JWK{}}
+	for _, id := range ids {
+		if jwk, ok := s.keys[id].jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// RotateSecret replaces the shared secret, e.g. after it was rotated in the
+// secret store. Tokens signed with the previous secret keep verifying for an
+// access token TTL, until the last of them has expired. A service that does
+// not accept the shared secret is unaffected.
+func (s *JWTService) RotateSecret(secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.keys[""]
+	if !ok {
+		return
+	}
+	hmac := newHMACKey(secret)
+	s.keys[""] = hmac
+	if s.signing == current {
+		s.signing = hmac
+	}
+	s.previousHMAC = current
+	s.previousUntil = time.Now().Add(s.accessTokenTTL)
+}
+
 // GetAccessTokenTTL returns the access token TTL
 func (s *JWTService) GetAccessTokenTTL() time.Duration {
 	return s.accessTokenTTL
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+// Supported asymmetric signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// signingKey is a key tokens are signed or verified with, identified by its kid
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private interface{} // nil for verification-only keys
+	public  interface{}
+}
+
+// newHMACKey creates the shared-secret key. It carries no kid and is never published.
+func newHMACKey(secret string) *signingKey {
+	return &signingKey{
+		method:  jwt.SigningMethodHS256,
+		private: []byte(secret),
+		public:  []byte(secret),
+	}
+}
+
+// loadSigningKey reads an asymmetric key from its PEM files. The public key is
+// derived from the private key when only the private key is configured.
+func loadSigningKey(cfg config.JWTKeyConfig) (*signingKey, error) {
+	if cfg.ID == "" {
+		return nil, fmt.Errorf("jwt key id is required")
+	}
+	if cfg.PrivateKeyFile == "" && cfg.PublicKeyFile == "" {
+		return nil, fmt.Errorf("jwt key %s: private_key_file or public_key_file is required", cfg.ID)
+	}
+
+	key := &signingKey{id: cfg.ID}
+	var privatePEM, publicPEM []byte
+	var err error
+	if cfg.PrivateKeyFile != "" {
+		if privatePEM, err = os.ReadFile(cfg.PrivateKeyFile); err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", cfg.ID, err)
+		}
+	}
+	if cfg.PublicKeyFile != "" {
+		if publicPEM, err = os.ReadFile(cfg.PublicKeyFile); err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", cfg.ID, err)
+		}
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+		if privatePEM != nil {
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, fmt.Errorf("jwt key %s: %w", cfg.ID, err)
+			}
+			key.private, key.public = private, &private.PublicKey
+		}
+		if publicPEM != nil {
+			if key.public, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM); err != nil {
+				return nil, fmt.Errorf("jwt key %s: %w", cfg.ID, err)
+			}
+		}
+	case AlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		if privatePEM != nil {
+			private, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, fmt.Errorf("jwt key %s: %w", cfg.ID, err)
+			}
+			edPrivate, ok := private.(ed25519.PrivateKey)
+			if !ok {
+				return nil, fmt.Errorf("jwt key %s: not an Ed25519 private key", cfg.ID)
+			}
+			key.private, key.public = edPrivate, edPrivate.Public()
+		}
+		if publicPEM != nil {
+			if key.public, err = jwt.ParseEdPublicKeyFromPEM(publicPEM); err != nil {
+				return nil, fmt.Errorf("jwt key %s: %w", cfg.ID, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("jwt key %s: unsupported algorithm %q", cfg.ID, cfg.Algorithm)
+	}
+	return key, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwk converts the public half of an asymmetric key, returning false for shared secrets
+func (k *signingKey) jwk() (JWK, bool) {
+	b64 := base64.RawURLEncoding
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType:   "RSA",
+			KeyID:     k.id,
+			Use:       "sig",
+			Algorithm: k.method.Alg(),
+			N:         b64.EncodeToString(public.N.Bytes()),
+			E:         b64.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType:   "OKP",
+			KeyID:     k.id,
+			Use:       "sig",
+			Algorithm: k.method.Alg(),
+			Curve:     "Ed25519",
+			X:         b64.EncodeToString(public),
+		}, true
+	}
+	return JWK{}, false
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+// writePrivateKey writes a PKCS#8 private key PEM into dir and returns its path
+func writePrivateKey(t *testing.T, dir, name string, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+// writePublicKey writes a PKIX public key PEM into dir and returns its path
+func writePublicKey(t *testing.T, dir, name string, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name+".pub.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func testKeyConfig() config.JWTConfig {
+	return config.JWTConfig{
+		Secret:          "test-secret-key-for-testing-purpose",
+		AccessTokenTTL:  time.Hour,
+		RefreshTokenTTL: 24 * time.Hour,
+		Issuer:          "test-issuer",
+	}
+}
+
+func TestLoadJWTService_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// Before rotation: sign with the RSA key
+	oldCfg := testKeyConfig()
+	oldCfg.SigningKeyID = "2026-04"
+	oldCfg.Keys = []config.JWTKeyConfig{
+		{ID: "2026-04", Algorithm: AlgorithmRS256, PrivateKeyFile: writePrivateKey(t, dir, "2026-04", rsaKey)},
+	}
+	oldService, err := LoadJWTService(&oldCfg)
+	require.NoError(t, err)
+
+	userID, companyID := uuid.New(), uuid.New()
+	oldToken, err := oldService.GenerateAccessToken(userID, companyID, "test@example.com", "Test User", []string{"user"})
+	require.NoError(t, err)
+
+	// After rotation: sign with the Ed25519 key, keep verifying the RSA key
+	newCfg := testKeyConfig()
+	newCfg.SigningKeyID = "2026-10"
+	newCfg.Keys = []config.JWTKeyConfig{
+		{ID: "2026-10", Algorithm: AlgorithmEdDSA, PrivateKeyFile: writePrivateKey(t, dir, "2026-10", edPrivate)},
+		{ID: "2026-04", Algorithm: AlgorithmRS256, PublicKeyFile: writePublicKey(t, dir, "2026-04", &rsaKey.PublicKey)},
+	}
+	newService, err := LoadJWTService(&newCfg)
+	require.NoError(t, err)
+
+	claims, err := newService.ValidateToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+
+	newToken, err := newService.GenerateAccessToken(userID, companyID, "test@example.com", "Test User", []string{"user"})
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Header["alg"])
+
+	_, err = newService.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// The old service does not know the new key
+	_, err = oldService.ValidateToken(newToken)
+	assert.Error(t, err)
+
+	// HMAC tokens are rejected unless accept_hmac is set
+	hmacToken, err := NewJWTService(&newCfg).GenerateAccessToken(userID, companyID, "test@example.com", "Test User", []string{"user"})
+	require.NoError(t, err)
+	_, err = newService.ValidateToken(hmacToken)
+	assert.Error(t, err)
+
+	jwks := newService.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "2026-04", jwks.Keys[0].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "2026-10", jwks.Keys[1].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+}
+
+func TestLoadJWTService_AcceptHMAC(t *testing.T) {
+	dir := t.TempDir()
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cfg := testKeyConfig()
+	cfg.SigningKeyID = "2026-10"
+	cfg.AcceptHMAC = true
+	cfg.Keys = []config.JWTKeyConfig{
+		{ID: "2026-10", Algorithm: AlgorithmEdDSA, PrivateKeyFile: writePrivateKey(t, dir, "2026-10", edPrivate)},
+	}
+	service, err := LoadJWTService(&cfg)
+	require.NoError(t, err)
+
+	hmacToken, err := NewJWTService(&cfg).GenerateAccessToken(uuid.New(), uuid.New(), "test@example.com", "Test User", []string{"user"})
+	require.NoError(t, err)
+	_, err = service.ValidateToken(hmacToken)
+	assert.NoError(t, err)
+
+	// The shared secret is never published
+	assert.Len(t, service.JWKS().Keys, 1)
+}
+
+func TestLoadJWTService_VerificationOnlySigningKey(t *testing.T) {
+	dir := t.TempDir()
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cfg := testKeyConfig()
+	cfg.SigningKeyID = "2026-10"
+	cfg.Keys = []config.JWTKeyConfig{
+		{ID: "2026-10", Algorithm: AlgorithmEdDSA, PublicKeyFile: writePublicKey(t, dir, "2026-10", edPublic)},
+	}
+	_, err = LoadJWTService(&cfg)
+	assert.Error(t, err)
+}
+
+func TestJWTService_RotateSecret(t *testing.T) {
+	cfg := testKeyConfig()
+	service := NewJWTService(&cfg)
+	oldToken, err := service.GenerateAccessToken(uuid.New(), uuid.New(), "test@example.com", "Test User", []string{"user"})
+	require.NoError(t, err)
+
+	service.RotateSecret("rotated-secret")
+	newToken, err := service.GenerateAccessToken(uuid.New(), uuid.New(), "test@example.com", "Test User", []string{"user"})
+	require.NoError(t, err)
+
+	// Tokens of both secrets verify until the old ones have expired
+	_, err = service.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	_, err = service.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// A service that only knows the old secret rejects new tokens
+	_, err = NewJWTService(&cfg).ValidateToken(newToken)
+	assert.Error(t, err)
+
+	service.mu.Lock()
+	service.previousUntil = time.Now().Add(-time.Second)
+	service.mu.Unlock()
+	_, err = service.ValidateToken(oldToken)
+	assert.Error(t, err)
+}
@@ -50,6 +50,16 @@ func GenerateVerificationToken() (string, error) {
 	return GenerateRandomToken(32)
 }
 
+// GenerateTemporaryPassword generates a one-time password for an administrator-forced reset.
+// The fixed prefix keeps it within ValidatePassword's character class requirements.
+func GenerateTemporaryPassword() (string, error) {
+	token, err := GenerateRandomToken(12)
+	if err != nil {
+		return "", err
+	}
+	return "Kt9" + token, nil
+}
+
 // ValidatePassword checks if a password meets minimum requirements
 func ValidatePassword(password string) []string {
 	var errors []string
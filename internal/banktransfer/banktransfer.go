@@ -0,0 +1,126 @@
+// Package banktransfer writes the bulk transfer files (대량이체 파일) that the
+// internet banking of Korean banks uploads to pay many payees at once.
+//
+// Each bank has its own layout, chosen by the 3-digit 금융기관 code of the
+// paying account: KB국민은행 takes comma-separated text, 신한은행 tab-separated
+// text with a header row and 우리은행 fixed-width records framed by a header
+// and a trailer. All files are CP949 (EUC-KR) text with CRLF line endings, as
+// the banking programs expect.
+package banktransfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/text/encoding/korean"
+)
+
+// Bank codes of the supported layouts
+const (
+	BankKookmin = "004" // KB국민은행
+	BankWoori   = "020" // 우리은행
+	BankShinhan = "088" // 신한은행
+)
+
+// ContentType is the MIME type of the files
+const ContentType = "text/plain; charset=euc-kr"
+
+// Errors
+var (
+	ErrUnsupportedBank = errors.New("bank has no bulk transfer file layout")
+	ErrInvalidTransfer = errors.New("invalid bulk transfer")
+)
+
+// Transfer is one payment of a bulk transfer
+type Transfer struct {
+	BankCode      string // 금융기관 code of the payee's bank
+	AccountNumber string // digits only
+	AccountHolder string // 예금주, checked by the bank before the transfer
+	Amount        int64
+	PayeeMemo     string // 받는분 통장표시: shown to the payee, usually the payer's name
+	PayerMemo     string // 내 통장표시: shown in the paying account
+}
+
+// File is a bulk transfer from one paying account
+type File struct {
+	AccountNumber string // paying account, digits only
+	TransferDate  time.Time
+	Transfers     []Transfer
+}
+
+// Total returns the sum of the transfers
+func (f *File) Total() int64 {
+	var total int64
+	for _, t := range f.Transfers {
+		total += t.Amount
+	}
+	return total
+}
+
+// maxAccountDigits is the length of the longest account numbers of Korean
+// banks, which the delimited layouts hold whole
+const maxAccountDigits = 20
+
+// validate checks the transfers before they are written. Account numbers
+// longer than accountDigits, the field of the layout, are refused rather
+// than cut to an account that is not the payee's.
+func (f *File) validate(accountDigits int) error {
+	if f.AccountNumber == "" || len(f.Transfers) == 0 {
+		return ErrInvalidTransfer
+	}
+	if len(f.AccountNumber) > accountDigits {
+		return fmt.Errorf("%w: paying account number is longer than %d digits", ErrInvalidTransfer, accountDigits)
+	}
+	for i, t := range f.Transfers {
+		if len(t.BankCode) != 3 || t.AccountNumber == "" || t.Amount <= 0 {
+			return fmt.Errorf("transfer %d: %w", i+1, ErrInvalidTransfer)
+		}
+		if len(t.AccountNumber) > accountDigits {
+			return fmt.Errorf("transfer %d: %w: account number is longer than %d digits", i+1, ErrInvalidTransfer, accountDigits)
+		}
+	}
+	return nil
+}
+
+// Layout writes the bulk transfer file of a bank
+type Layout interface {
+	// Bank returns the bank code of the layout
+	Bank() string
+	// Extension returns the file name extension the banking program expects
+	Extension() string
+	Write(w io.Writer, f *File) error
+}
+
+// layouts are the supported layouts by bank code
+var layouts = map[string]Layout{
+	BankKookmin: kookminLayout{},
+	BankShinhan: shinhanLayout{},
+	BankWoori:   wooriLayout{},
+}
+
+// LayoutFor returns the layout of the bank of the paying account
+func LayoutFor(bankCode string) (Layout, error) {
+	layout, ok := layouts[bankCode]
+	if !ok {
+		return nil, ErrUnsupportedBank
+	}
+	return layout, nil
+}
+
+// encode converts text to CP949, replacing characters it cannot represent
+func encode(s string) []byte {
+	b, err := korean.EUCKR.NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		// Characters outside CP949 are dropped one by one
+		var out []byte
+		for _, r := range s {
+			if rb, err := korean.EUCKR.NewEncoder().Bytes([]byte(string(r))); err == nil {
+				out = append(out, rb...)
+			}
+		}
+		return out
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+package banktransfer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/korean"
+
+	"github.com/saintgo7/saas-kerp/internal/banktransfer"
+)
+
+func newFile() *banktransfer.File {
+	return &banktransfer.File{
+		AccountNumber: "1002123456789",
+		TransferDate:  time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC),
+		Transfers: []banktransfer.Transfer{
+			{BankCode: "088", AccountNumber: "110123456789", AccountHolder: "(주)한빛상사", Amount: 1500000, PayeeMemo: "케이이알피주식회사", PayerMemo: "한빛상사"},
+			{BankCode: "004", AccountNumber: "12345678901234", AccountHolder: "다온물산", Amount: 250000, PayeeMemo: "케이이알피", PayerMemo: "다온,물산"},
+		},
+	}
+}
+
+func write(t *testing.T, bankCode string, f *banktransfer.File) []string {
+	layout, err := banktransfer.LayoutFor(bankCode)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, layout.Write(&buf, f))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	for i, line := range lines {
+		decoded, err := korean.EUCKR.NewDecoder().String(line)
+		require.NoError(t, err)
+		lines[i] = decoded
+	}
+	return lines
+}
+
+func TestKookminLayout(t *testing.T) {
+	lines := write(t, banktransfer.BankKookmin, newFile())
+	require.Len(t, lines, 2)
+	// Memos are cut to seven Korean characters and lose their delimiters
+	assert.Equal(t, "088,110123456789,1500000,케이이알피주식,한빛상사", lines[0])
+	assert.Equal(t, "004,12345678901234,250000,케이이알피,다온 물산", lines[1])
+}
+
+func TestShinhanLayout(t *testing.T) {
+	lines := write(t, banktransfer.BankShinhan, newFile())
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "입금은행\t"))
+	assert.Equal(t, "088\t110123456789\t1500000\t(주)한빛상사\t케이이알피주식\t한빛상사", lines[1])
+}
+
+func TestWooriLayout(t *testing.T) {
+	layout, err := banktransfer.LayoutFor(banktransfer.BankWoori)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, layout.Write(&buf, newFile()))
+
+	records := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, records, 4)
+	for _, record := range records {
+		assert.Len(t, record, 100) // bytes in CP949
+	}
+	assert.True(t, strings.HasPrefix(records[0], "11002123456789   20240710"))
+	assert.True(t, strings.HasPrefix(records[1], "2000001088110123456789    0000001500000"))
+	assert.True(t, strings.HasPrefix(records[3], "3000002000000001750000"))
+}
+
+func TestLayoutFor(t *testing.T) {
+	_, err := banktransfer.LayoutFor("081")
+	assert.ErrorIs(t, err, banktransfer.ErrUnsupportedBank)
+
+	layout, _ := banktransfer.LayoutFor(banktransfer.BankKookmin)
+	f := newFile()
+	f.Transfers[1].Amount = 0
+	assert.ErrorIs(t, layout.Write(&bytes.Buffer{}, f), banktransfer.ErrInvalidTransfer)
+}
+
+func TestLayoutAccountWidth(t *testing.T) {
+	// 17 digits fit the delimited layouts but not 우리은행's 16-byte fields
+	long := "12345678901234567"
+
+	for _, bankCode := range []string{banktransfer.BankKookmin, banktransfer.BankShinhan} {
+		layout, _ := banktransfer.LayoutFor(bankCode)
+		f := newFile()
+		f.Transfers[0].AccountNumber = long
+		assert.NoError(t, layout.Write(&bytes.Buffer{}, f), bankCode)
+
+		f.Transfers[0].AccountNumber = long + "0123"
+		assert.ErrorIs(t, layout.Write(&bytes.Buffer{}, f), banktransfer.ErrInvalidTransfer, bankCode)
+	}
+
+	woori, _ := banktransfer.LayoutFor(banktransfer.BankWoori)
+	f := newFile()
+	f.Transfers[1].AccountNumber = long
+	assert.ErrorIs(t, woori.Write(&bytes.Buffer{}, f), banktransfer.ErrInvalidTransfer)
+
+	f = newFile()
+	f.AccountNumber = long
+	assert.ErrorIs(t, woori.Write(&bytes.Buffer{}, f), banktransfer.ErrInvalidTransfer)
+}
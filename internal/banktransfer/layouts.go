@@ -0,0 +1,149 @@
+package banktransfer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Passbook memos are limited to 14 bytes, seven Korean characters
+const memoBytes = 14
+
+// kookminLayout is KB국민은행's 대량이체 text: one comma-separated record per
+// transfer of the payee bank, account, amount, payee memo and payer memo,
+// without a header
+type kookminLayout struct{}
+
+func (kookminLayout) Bank() string      { return BankKookmin }
+func (kookminLayout) Extension() string { return "csv" }
+
+func (kookminLayout) Write(w io.Writer, f *File) error {
+	if err := f.validate(maxAccountDigits); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, t := range f.Transfers {
+		writeRecord(&buf, ',',
+			[]byte(t.BankCode), []byte(t.AccountNumber), []byte(fmt.Sprint(t.Amount)),
+			text(t.PayeeMemo, memoBytes), text(t.PayerMemo, memoBytes))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// shinhanLayout is 신한은행's 대량이체 text: tab-separated records after a
+// header row, with the account holder the bank checks before the transfer
+type shinhanLayout struct{}
+
+func (shinhanLayout) Bank() string      { return BankShinhan }
+func (shinhanLayout) Extension() string { return "txt" }
+
+func (shinhanLayout) Write(w io.Writer, f *File) error {
+	if err := f.validate(maxAccountDigits); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	writeRecord(&buf, '\t', encode("입금은행"), encode("입금계좌번호"), encode("이체금액"),
+		encode("예금주"), encode("받는분통장표시"), encode("내통장표시"))
+	for _, t := range f.Transfers {
+		writeRecord(&buf, '\t',
+			[]byte(t.BankCode), []byte(t.AccountNumber), []byte(fmt.Sprint(t.Amount)),
+			text(t.AccountHolder, 40), text(t.PayeeMemo, memoBytes), text(t.PayerMemo, memoBytes))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// wooriLayout is 우리은행's 대량이체 file of 100-byte records: a header with
+// the paying account and transfer date, a data record per transfer and a
+// trailer with the count and total the bank checks the file against
+type wooriLayout struct{}
+
+// wooriRecordBytes is the length of the records of 우리은행's file
+const wooriRecordBytes = 100
+
+// wooriAccountBytes is the width of the account fields of 우리은행's file
+const wooriAccountBytes = 16
+
+func (wooriLayout) Bank() string      { return BankWoori }
+func (wooriLayout) Extension() string { return "txt" }
+
+func (wooriLayout) Write(w io.Writer, f *File) error {
+	if err := f.validate(wooriAccountBytes); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	writeFixed(&buf, "1", alpha(f.AccountNumber, wooriAccountBytes), f.TransferDate.Format("20060102"))
+	for i, t := range f.Transfers {
+		writeFixed(&buf, "2", numeric(int64(i+1), 6), t.BankCode, alpha(t.AccountNumber, wooriAccountBytes),
+			numeric(t.Amount, 13), fixed(t.AccountHolder, 20), fixed(t.PayeeMemo, memoBytes), fixed(t.PayerMemo, memoBytes))
+	}
+	writeFixed(&buf, "3", numeric(int64(len(f.Transfers)), 6), numeric(f.Total(), 15))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeRecord writes a delimited record ending in CRLF
+func writeRecord(buf *bytes.Buffer, sep byte, fields ...[]byte) {
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(sep)
+		}
+		buf.Write(field)
+	}
+	buf.WriteString("\r\n")
+}
+
+// writeFixed writes a fixed-width record of 우리은행's file, padded with
+// spaces to the record length, ending in CRLF
+func writeFixed(buf *bytes.Buffer, fields ...string) {
+	var record []byte
+	for _, field := range fields {
+		record = append(record, field...)
+	}
+	buf.Write(record)
+	buf.Write(bytes.Repeat([]byte(" "), wooriRecordBytes-len(record)))
+	buf.WriteString("\r\n")
+}
+
+// text returns s in CP949, cut to at most n bytes without splitting a
+// character. Delimiters and line breaks become spaces.
+func text(s string, n int) []byte {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ',', '\t', '\r', '\n', '"':
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(s))
+
+	var out []byte
+	for _, r := range s {
+		b := encode(string(r))
+		if len(out)+len(b) > n {
+			break
+		}
+		out = append(out, b...)
+	}
+	return out
+}
+
+// fixed returns s in CP949 as a field of n bytes, padded with spaces
+func fixed(s string, n int) string {
+	b := text(s, n)
+	return string(b) + strings.Repeat(" ", n-len(b))
+}
+
+// alpha returns an ASCII field of n bytes, left-aligned and padded with spaces
+func alpha(s string, n int) string {
+	if len(s) > n {
+		s = s[:n]
+	}
+	return s + strings.Repeat(" ", n-len(s))
+}
+
+// numeric returns v as a field of n digits, padded with zeros
+func numeric(v int64, n int) string {
+	return fmt.Sprintf("%0*d", n, v)
+}
@@ -4,14 +4,33 @@ import "time"
 
 // Config holds all application configuration
 type Config struct {
-	App       AppConfig       `mapstructure:"app"`
-	Database  DatabaseConfig  `mapstructure:"database"`
-	Redis     RedisConfig     `mapstructure:"redis"`
-	NATS      NATSConfig      `mapstructure:"nats"`
-	JWT       JWTConfig       `mapstructure:"jwt"`
-	CORS      CORSConfig      `mapstructure:"cors"`
-	RateLimit RateLimitConfig `mapstructure:"ratelimit"`
-	Log       LogConfig       `mapstructure:"log"`
+	App         AppConfig         `mapstructure:"app"`
+	Database    DatabaseConfig    `mapstructure:"database"`
+	Redis       RedisConfig       `mapstructure:"redis"`
+	NATS        NATSConfig        `mapstructure:"nats"`
+	JWT         JWTConfig         `mapstructure:"jwt"`
+	CORS        CORSConfig        `mapstructure:"cors"`
+	Security    SecurityConfig    `mapstructure:"security"`
+	RateLimit   RateLimitConfig   `mapstructure:"ratelimit"`
+	Log         LogConfig         `mapstructure:"log"`
+	Worker      WorkerConfig      `mapstructure:"worker"`
+	OCR         OCRConfig         `mapstructure:"ocr"`
+	Email       EmailConfig       `mapstructure:"email"`
+	Push        PushConfig        `mapstructure:"push"`
+	Export      ExportConfig      `mapstructure:"export"`
+	Attachment  AttachmentConfig  `mapstructure:"attachment"`
+	Credentials CredentialsConfig `mapstructure:"credentials"`
+	Popbill     PopbillConfig     `mapstructure:"popbill"`
+	NTS         NTSConfig         `mapstructure:"nts"`
+	Inbox       InboxConfig       `mapstructure:"inbox"`
+	Billing     BillingConfig     `mapstructure:"billing"`
+	Plan        PlanConfig        `mapstructure:"plan"`
+	SecretStore SecretStoreConfig `mapstructure:"secret_store"`
+	Reload      ReloadConfig      `mapstructure:"reload"`
+	Diagnostics DiagnosticsConfig `mapstructure:"diagnostics"`
+	Errors      ErrorsConfig      `mapstructure:"errors"`
+	Backup      BackupConfig      `mapstructure:"backup"`
+	Retention   RetentionConfig   `mapstructure:"retention"`
 }
 
 // AppConfig holds application-level configuration
@@ -34,6 +53,16 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	// StatementTimeout cancels statements running longer than this on the server; 0 disables it
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"`
+	// SlowQueryThreshold is the duration above which queries are logged as slow
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+
+	// ReplicaDSN is the connection string of a read replica; list and report
+	// queries are sent to it when set. It uses the same pool settings.
+	ReplicaDSN string `mapstructure:"replica_dsn"`
 }
 
 // RedisConfig holds Redis configuration
@@ -42,6 +71,15 @@ type RedisConfig struct {
 	Port     int    `mapstructure:"port"`
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db"`
+
+	// Commands are not sent for RetryInterval after FailureThreshold consecutive
+	// connection failures, then a single trial command is; 0 disables it
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	RetryInterval    time.Duration `mapstructure:"retry_interval"`
+
+	// Degradation overrides the policy of features while Redis is unavailable,
+	// "fail_open" to carry on without Redis or "fail_closed" to fail the operation
+	Degradation map[string]string `mapstructure:"degradation"`
 }
 
 // NATSConfig holds NATS JetStream configuration
@@ -56,6 +94,20 @@ type JWTConfig struct {
 	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`
 	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
 	Issuer          string        `mapstructure:"issuer"`
+
+	// Asymmetric signing. When SigningKeyID is empty tokens are signed with Secret (HS256).
+	// Keep the previous key in Keys after a rotation so its tokens verify until they expire.
+	SigningKeyID string         `mapstructure:"signing_key_id"`
+	Keys         []JWTKeyConfig `mapstructure:"keys"`
+	AcceptHMAC   bool           `mapstructure:"accept_hmac"` // keep accepting Secret-signed tokens while moving off HS256
+}
+
+// JWTKeyConfig holds an asymmetric JWT key, published in the JWKS under its kid
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"id"`               // kid
+	Algorithm      string `mapstructure:"algorithm"`        // RS256 or EdDSA
+	PrivateKeyFile string `mapstructure:"private_key_file"` // PEM; required for the signing key only
+	PublicKeyFile  string `mapstructure:"public_key_file"`  // PEM; derived from the private key when empty
 }
 
 // CORSConfig holds CORS configuration
@@ -64,6 +116,30 @@ type CORSConfig struct {
 	AllowedMethods []string `mapstructure:"allowed_methods"`
 	AllowedHeaders []string `mapstructure:"allowed_headers"`
 	MaxAge         int      `mapstructure:"max_age"`
+
+	AllowCredentials bool     `mapstructure:"allow_credentials"` // never sent for the "*" origin
+	ExposedHeaders   []string `mapstructure:"exposed_headers"`
+
+	// EnvAllowedOrigins overrides AllowedOrigins for the app.env it is keyed by
+	EnvAllowedOrigins map[string][]string `mapstructure:"env_allowed_origins"`
+}
+
+// SecurityConfig holds HTTP security header and request body limit configuration
+type SecurityConfig struct {
+	HSTSMaxAge            int    `mapstructure:"hsts_max_age"` // seconds; 0 disables HSTS. Only sent over HTTPS
+	HSTSIncludeSubdomains bool   `mapstructure:"hsts_include_subdomains"`
+	FrameOptions          string `mapstructure:"frame_options"` // DENY or SAMEORIGIN
+	ContentSecurityPolicy string `mapstructure:"content_security_policy"`
+	ReferrerPolicy        string `mapstructure:"referrer_policy"`
+
+	MaxBodySize   int64 `mapstructure:"max_body_size"`   // bytes, for non-multipart request bodies
+	MaxUploadSize int64 `mapstructure:"max_upload_size"` // bytes, for multipart file uploads
+
+	// Shape of JSON bodies
+	MaxJSONDepth       int `mapstructure:"max_json_depth"`        // nesting of objects and arrays
+	MaxJSONArrayLength int `mapstructure:"max_json_array_length"` // items of any array
+	MaxVoucherEntries  int `mapstructure:"max_voucher_entries"`   // entries (or reversal lines) of a voucher
+	MaxBulkItems       int `mapstructure:"max_bulk_items"`        // items of a bulk call
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -77,6 +153,42 @@ type RateLimitConfig struct {
 type LogConfig struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+	// MaskPII partially masks email addresses, phone numbers and business
+	// registration numbers in log messages and string fields
+	MaskPII bool `mapstructure:"mask_pii"`
+}
+
+// WorkerConfig holds background worker configuration
+type WorkerConfig struct {
+	ReportScheduleInterval time.Duration `mapstructure:"report_schedule_interval"`
+	DataExportInterval     time.Duration `mapstructure:"data_export_interval"`
+	PopbillWebhookInterval time.Duration `mapstructure:"popbill_webhook_interval"`
+
+	// Background jobs queued by the API and by report schedules
+	JobInterval time.Duration `mapstructure:"job_interval"`
+
+	// How often the recurring tasks of scheduled_tasks are checked; their
+	// schedules are cron expressions stored in the database
+	SchedulerInterval time.Duration `mapstructure:"scheduler_interval"`
+
+	// Anomaly scoring of posted vouchers
+	AnomalyScoringInterval time.Duration `mapstructure:"anomaly_scoring_interval"`
+
+	// Nightly tenant backups, restores into staging and backup retention
+	BackupInterval time.Duration `mapstructure:"backup_interval"`
+
+	// voucher_entries partitions created by the partition_maintenance task
+	PartitionYearsAhead int `mapstructure:"partition_years_ahead"` // future fiscal years created in advance
+	PartitionHashCount  int `mapstructure:"partition_hash_count"`  // company hash sub-partitions per new year, 0 for none
+}
+
+// OCRConfig holds receipt OCR provider configuration
+type OCRConfig struct {
+	Provider     string        `mapstructure:"provider"` // "clova" or empty to disable
+	InvokeURL    string        `mapstructure:"invoke_url"`
+	SecretKey    string        `mapstructure:"secret_key"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	MaxImageSize int64         `mapstructure:"max_image_size"`
 }
 
 // IsProduction returns true if running in production environment
@@ -89,6 +201,14 @@ func (c *Config) IsDevelopment() bool {
 	return c.App.Env == "development"
 }
 
+// OriginsFor returns the origin allowlist for the environment
+func (c *CORSConfig) OriginsFor(env string) []string {
+	if origins, ok := c.EnvAllowedOrigins[env]; ok && len(origins) > 0 {
+		return origins
+	}
+	return c.AllowedOrigins
+}
+
 // DSN returns PostgreSQL connection string
 func (c *DatabaseConfig) DSN() string {
 	return "host=" + c.Host +
@@ -98,3 +218,242 @@ func (c *DatabaseConfig) DSN() string {
 		" dbname=" + c.Name +
 		" sslmode=" + c.SSLMode
 }
+
+// EmailConfig holds outgoing email (notification) configuration
+type EmailConfig struct {
+	Provider string        `mapstructure:"provider"` // "smtp" or empty to disable
+	Host     string        `mapstructure:"host"`
+	Port     int           `mapstructure:"port"`
+	Username string        `mapstructure:"username"`
+	Password string        `mapstructure:"password"`
+	From     string        `mapstructure:"from"`
+	FromName string        `mapstructure:"from_name"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// LinkBaseURL is the web app address used for links in emails (invitations, verification)
+	LinkBaseURL string `mapstructure:"link_base_url"`
+
+	// BounceWebhookSecret is the token the mail provider sends with bounce
+	// notices in the X-Bounce-Token header; the endpoint is disabled when empty
+	BounceWebhookSecret string `mapstructure:"bounce_webhook_secret"`
+}
+
+// PushConfig holds the push notification services of the mobile app, used by
+// the worker; each service is disabled while its key file is empty
+type PushConfig struct {
+	FCMProjectID       string `mapstructure:"fcm_project_id"`       // taken from the credentials when empty
+	FCMCredentialsFile string `mapstructure:"fcm_credentials_file"` // service account key (JSON)
+
+	APNsKeyID   string `mapstructure:"apns_key_id"`
+	APNsTeamID  string `mapstructure:"apns_team_id"`
+	APNsKeyFile string `mapstructure:"apns_key_file"` // authentication key (.p8)
+	APNsTopic   string `mapstructure:"apns_topic"`    // bundle ID of the app
+	APNsSandbox bool   `mapstructure:"apns_sandbox"`  // development environment
+
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// ExportConfig holds tenant data export configuration
+type ExportConfig struct {
+	// SigningSecret signs download links; downloads are unavailable when empty
+	SigningSecret string        `mapstructure:"signing_secret"`
+	LinkTTL       time.Duration `mapstructure:"link_ttl"`  // validity of a download link
+	Retention     time.Duration `mapstructure:"retention"` // completed archives are deleted after this
+}
+
+// AttachmentConfig holds voucher attachment upload, virus scanning and image
+// processing configuration
+type AttachmentConfig struct {
+	MaxFileSize   int64         `mapstructure:"max_file_size"`
+	Scanner       string        `mapstructure:"scanner"`        // "clamav", or empty to store files unscanned
+	ClamAVAddress string        `mapstructure:"clamav_address"` // clamd TCP address
+	ScanTimeout   time.Duration `mapstructure:"scan_timeout"`
+
+	ThumbnailSize   int           `mapstructure:"thumbnail_size"`   // longest side of image previews in pixels
+	HEICConverter   string        `mapstructure:"heic_converter"`   // "imagemagick", or empty to store HEIC photos as uploaded
+	ImageMagickPath string        `mapstructure:"imagemagick_path"` // magick binary built with libheif
+	ConvertTimeout  time.Duration `mapstructure:"convert_timeout"`
+}
+
+// CredentialsConfig holds the envelope encryption of stored integration credentials
+// (Popbill, bank accounts). Credentials cannot be stored when KeyProvider is empty.
+type CredentialsConfig struct {
+	KeyProvider string `mapstructure:"key_provider"` // "local" or "vault"
+
+	// Local master key, base64 encoded 32 bytes; set KERP_CREDENTIALS_MASTER_KEY rather than the file.
+	// Move the previous key to RetiredKeys after a rotation so existing credentials still open.
+	MasterKeyID string                `mapstructure:"master_key_id"`
+	MasterKey   string                `mapstructure:"master_key"`
+	RetiredKeys []CredentialKeyConfig `mapstructure:"retired_keys"`
+
+	// Vault transit engine
+	VaultAddress    string        `mapstructure:"vault_address"`
+	VaultToken      string        `mapstructure:"vault_token"`
+	VaultTransitKey string        `mapstructure:"vault_transit_key"`
+	VaultTimeout    time.Duration `mapstructure:"vault_timeout"`
+
+	// TestTimeout bounds the provider call made to test credentials
+	TestTimeout time.Duration `mapstructure:"test_timeout"`
+}
+
+// CredentialKeyConfig holds a retired local master key
+type CredentialKeyConfig struct {
+	ID  string `mapstructure:"id"`
+	Key string `mapstructure:"key"`
+}
+
+// PopbillConfig holds Popbill API client configuration; credentials are registered per company
+type PopbillConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Transient failures are retried with exponential backoff and full jitter
+	RetryMaxAttempts int           `mapstructure:"retry_max_attempts"` // including the first attempt
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`
+
+	// The circuit opens after BreakerThreshold consecutive outage failures; 0 disables the breaker
+	BreakerThreshold   int           `mapstructure:"breaker_threshold"`
+	BreakerOpenTimeout time.Duration `mapstructure:"breaker_open_timeout"` // time before a trial call
+
+	// ShareTokens caches access tokens in Redis so that API instances reuse one token
+	ShareTokens bool `mapstructure:"share_tokens"`
+
+	// Webhook callbacks are signed with HMAC-SHA256 of WebhookSecret; the endpoint
+	// is disabled when it is empty. Callbacks older than WebhookTolerance are rejected.
+	WebhookSecret    string        `mapstructure:"webhook_secret"`
+	WebhookTolerance time.Duration `mapstructure:"webhook_tolerance"`
+
+	// Bulk issuance sends up to BulkIssueConcurrency invoices to Popbill at a time
+	BulkIssueConcurrency int `mapstructure:"bulk_issue_concurrency"`
+	BulkIssueMaxInvoices int `mapstructure:"bulk_issue_max_invoices"` // per request
+}
+
+// NTSConfig holds the NTS business registration status API configuration
+type NTSConfig struct {
+	BaseURL    string        `mapstructure:"base_url"`
+	ServiceKey string        `mapstructure:"service_key"` // public data portal key; empty disables verification
+	Timeout    time.Duration `mapstructure:"timeout"`
+
+	// Partners verified longer than RevalidateAfter ago are checked again by the
+	// worker, at most RevalidateBatchSize per run
+	RevalidateAfter     time.Duration `mapstructure:"revalidate_after"`
+	RevalidateBatchSize int           `mapstructure:"revalidate_batch_size"`
+}
+
+// InboxConfig holds the inbound email configuration. Each company receives
+// invoices at <alias>@Domain; the mail service (an SES receipt rule or an MTA
+// pipe) posts the messages to the inbound webhook.
+type InboxConfig struct {
+	Domain            string `mapstructure:"domain"`              // empty disables the inbox
+	WebhookSecret     string `mapstructure:"webhook_secret"`      // token of the webhook; empty disables it
+	MaxMessageSize    int64  `mapstructure:"max_message_size"`    // bytes
+	MaxAttachmentSize int64  `mapstructure:"max_attachment_size"` // bytes; larger attachments are skipped
+}
+
+// BillingConfig holds the billing provider receiving the metered usage of
+// tenants. Usage of companies without a billing customer is not exported.
+type BillingConfig struct {
+	Provider string        `mapstructure:"provider"` // "stripe", or empty to meter without exporting
+	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// Stripe billing meters; meter event names are the prefix and the metric
+	StripeSecretKey   string `mapstructure:"stripe_secret_key"`
+	StripeBaseURL     string `mapstructure:"stripe_base_url"`
+	StripeEventPrefix string `mapstructure:"stripe_event_prefix"`
+}
+
+// PlanConfig holds the enforcement of subscription plan limits on users,
+// vouchers per month and attachment storage
+type PlanConfig struct {
+	Enforcement  string        `mapstructure:"enforcement"`   // "enforce", "warn" (log only) or "off"
+	GracePercent int           `mapstructure:"grace_percent"` // usage allowed above a limit before operations fail
+	GracePeriod  time.Duration `mapstructure:"grace_period"`  // the previous plan's higher limits apply this long after a change
+}
+
+// SecretStoreConfig holds the external store of configuration secrets. The
+// store's keys are configuration keys (e.g. "database.password") and override
+// the file and the environment. It is read again on every reload, so rotated
+// secrets are picked up without a restart.
+type SecretStoreConfig struct {
+	Provider string        `mapstructure:"provider"` // "vault", "ssm", or empty to disable
+	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// Vault KV version 2 secret
+	VaultAddress string `mapstructure:"vault_address"`
+	VaultToken   string `mapstructure:"vault_token"`
+	VaultMount   string `mapstructure:"vault_mount"`
+	VaultPath    string `mapstructure:"vault_path"`
+
+	// SSM Parameter Store path; credentials fall back to the standard AWS_* variables
+	SSMRegion          string `mapstructure:"ssm_region"`
+	SSMPath            string `mapstructure:"ssm_path"`
+	SSMEndpoint        string `mapstructure:"ssm_endpoint"`
+	AWSAccessKeyID     string `mapstructure:"aws_access_key_id"`
+	AWSSecretAccessKey string `mapstructure:"aws_secret_access_key"`
+	AWSSessionToken    string `mapstructure:"aws_session_token"`
+}
+
+// ReloadConfig holds the periodic configuration reload. Log level, rate limits
+// and the database, JWT and Popbill webhook secrets are applied without a
+// restart; other changes are logged and take effect on the next restart.
+type ReloadConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // 0 disables periodic reloads; SIGHUP always reloads
+}
+
+// DiagnosticsConfig holds the diagnostics listener serving pprof profiles and
+// expvar variables. It is not authenticated and must not be reachable from
+// outside the cluster.
+type DiagnosticsConfig struct {
+	Addr string `mapstructure:"addr"` // e.g. "127.0.0.1:6060"; empty disables the listener
+}
+
+// ErrorsConfig holds the error tracker receiving the panics recovered from
+// requests, with their stack, route and request ID
+type ErrorsConfig struct {
+	Reporter  string        `mapstructure:"reporter"`   // "sentry", or empty to only log panics
+	SentryDSN string        `mapstructure:"sentry_dsn"` // https://<key>@<host>/<project>
+	Timeout   time.Duration `mapstructure:"timeout"`
+}
+
+// BackupConfig holds the scheduled tenant backups. Each company's data is
+// archived, envelope encrypted with the credentials key provider and stored
+// in object storage. Backups are disabled when Storage is empty.
+type BackupConfig struct {
+	Schedule string `mapstructure:"schedule"` // cron expression of the nightly backup
+	Timezone string `mapstructure:"timezone"` // IANA zone of the schedule
+
+	// Retention of restore points; the first backup of each month is kept longer
+	RetentionDays          int `mapstructure:"retention_days"`
+	MonthlyRetentionMonths int `mapstructure:"monthly_retention_months"`
+
+	Storage        string        `mapstructure:"storage"` // "s3", "file", or empty to disable backups
+	StorageTimeout time.Duration `mapstructure:"storage_timeout"`
+
+	// S3 or S3-compatible bucket; credentials fall back to the standard AWS_* variables
+	S3Endpoint         string `mapstructure:"s3_endpoint"` // overrides the regional endpoint
+	S3Region           string `mapstructure:"s3_region"`
+	S3Bucket           string `mapstructure:"s3_bucket"`
+	S3Prefix           string `mapstructure:"s3_prefix"`
+	AWSAccessKeyID     string `mapstructure:"aws_access_key_id"`
+	AWSSecretAccessKey string `mapstructure:"aws_secret_access_key"`
+	AWSSessionToken    string `mapstructure:"aws_session_token"`
+
+	// Local directory, for development and single-host installs
+	FileDir string `mapstructure:"file_dir"`
+
+	// StagingDSN is the database backups are restored into for support; each
+	// restore gets its own schema. Restores are disabled when empty.
+	StagingDSN string `mapstructure:"staging_dsn"`
+}
+
+// RetentionConfig holds the retention period of each data class and the
+// deadline of personal data deletion requests. Accounting and tax records
+// are never deleted by the service; their periods are the statutory minimum
+// that deletion requests cannot shorten.
+type RetentionConfig struct {
+	AccountingYears      int `mapstructure:"accounting_years"`       // vouchers and ledgers, at least 10
+	TaxYears             int `mapstructure:"tax_years"`              // tax invoices, at least 5
+	AuditLogDays         int `mapstructure:"audit_log_days"`         // IP addresses and user agents of audit entries are cleared after this
+	SessionDays          int `mapstructure:"session_days"`           // expired and revoked tokens are deleted after this
+	DeletionDeadlineDays int `mapstructure:"deletion_deadline_days"` // deletion requests are due within this, at most 30
+}
@@ -1,14 +1,24 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/viper"
+
+	"github.com/saintgo7/saas-kerp/internal/secrets"
 )
 
 // Load reads configuration from file and environment variables
 func Load() (*Config, error) {
+	return LoadContext(context.Background())
+}
+
+// LoadContext reads configuration from file and environment variables and
+// overlays the secrets of the configured secret store
+func LoadContext(ctx context.Context) (*Config, error) {
 	v := viper.New()
 
 	// Config file settings
@@ -39,6 +49,34 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Overlay the secret store, which is configured by the file and environment only
+	if store := newSecretStore(&cfg.SecretStore); store != nil {
+		values, err := store.Fetch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secrets from %s: %w", store.Name(), err)
+		}
+		for key, value := range values {
+			if strings.HasPrefix(key, "secret_store.") || !v.IsSet(key) {
+				return nil, fmt.Errorf("unknown configuration key in %s: %s", store.Name(), key)
+			}
+			v.Set(key, value)
+		}
+		cfg = Config{}
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	// Apply the per-environment CORS allowlist
+	cfg.CORS.AllowedOrigins = cfg.CORS.OriginsFor(cfg.App.Env)
+
+	// Backup credentials fall back to the standard AWS variables
+	if cfg.Backup.Storage == "s3" && cfg.Backup.AWSAccessKeyID == "" {
+		cfg.Backup.AWSAccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		cfg.Backup.AWSSecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		cfg.Backup.AWSSessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
@@ -46,6 +84,37 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// newSecretStore returns the store of the provider, or nil when there is
+// none. An unknown provider is reported by Validate.
+func newSecretStore(cfg *SecretStoreConfig) secrets.Store {
+	switch cfg.Provider {
+	case "vault":
+		return secrets.NewVaultKVStore(&secrets.VaultKVConfig{
+			Address: cfg.VaultAddress,
+			Token:   cfg.VaultToken,
+			Mount:   cfg.VaultMount,
+			Path:    cfg.VaultPath,
+			Timeout: cfg.Timeout,
+		})
+	case "ssm":
+		if cfg.AWSAccessKeyID == "" {
+			cfg.AWSAccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+			cfg.AWSSecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+			cfg.AWSSessionToken = os.Getenv("AWS_SESSION_TOKEN")
+		}
+		return secrets.NewSSMStore(&secrets.SSMConfig{
+			Region:          cfg.SSMRegion,
+			Path:            cfg.SSMPath,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+			Endpoint:        cfg.SSMEndpoint,
+			Timeout:         cfg.Timeout,
+		})
+	}
+	return nil
+}
+
 // setDefaults sets default configuration values
 func setDefaults(v *viper.Viper) {
 	// App defaults
@@ -65,12 +134,18 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 5)
 	v.SetDefault("database.conn_max_lifetime", "5m")
+	v.SetDefault("database.conn_max_idle_time", "1m")
+	v.SetDefault("database.statement_timeout", "30s")
+	v.SetDefault("database.slow_query_threshold", "200ms")
+	v.SetDefault("database.replica_dsn", "")
 
 	// Redis defaults
 	v.SetDefault("redis.host", "localhost")
 	v.SetDefault("redis.port", 6379)
 	v.SetDefault("redis.password", "")
 	v.SetDefault("redis.db", 0)
+	v.SetDefault("redis.failure_threshold", 3)
+	v.SetDefault("redis.retry_interval", "10s")
 
 	// NATS defaults
 	v.SetDefault("nats.url", "nats://localhost:4222")
@@ -81,12 +156,29 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("jwt.access_token_ttl", "15m")
 	v.SetDefault("jwt.refresh_token_ttl", "168h")
 	v.SetDefault("jwt.issuer", "kerp-api")
+	v.SetDefault("jwt.signing_key_id", "")
+	v.SetDefault("jwt.accept_hmac", false)
 
 	// CORS defaults
 	v.SetDefault("cors.allowed_origins", []string{"http://localhost:3000", "http://localhost:5173"})
 	v.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"})
-	v.SetDefault("cors.allowed_headers", []string{"Authorization", "Content-Type", "X-Request-ID"})
+	v.SetDefault("cors.allowed_headers", []string{"Authorization", "Content-Type", "X-Request-ID", "X-Read-Primary"})
 	v.SetDefault("cors.max_age", 86400)
+	v.SetDefault("cors.allow_credentials", true)
+	v.SetDefault("cors.exposed_headers", []string{"X-Request-ID", "Content-Disposition"})
+
+	// Security header and body limit defaults
+	v.SetDefault("security.hsts_max_age", 31536000)
+	v.SetDefault("security.hsts_include_subdomains", true)
+	v.SetDefault("security.frame_options", "DENY")
+	v.SetDefault("security.content_security_policy", "default-src 'none'; frame-ancestors 'none'")
+	v.SetDefault("security.referrer_policy", "strict-origin-when-cross-origin")
+	v.SetDefault("security.max_body_size", 1<<20)
+	v.SetDefault("security.max_upload_size", 20<<20)
+	v.SetDefault("security.max_json_depth", 32)
+	v.SetDefault("security.max_json_array_length", 10000)
+	v.SetDefault("security.max_voucher_entries", 1000)
+	v.SetDefault("security.max_bulk_items", 500)
 
 	// Rate limit defaults
 	v.SetDefault("ratelimit.enabled", false)
@@ -96,4 +188,154 @@ func setDefaults(v *viper.Viper) {
 	// Log defaults
 	v.SetDefault("log.level", "info")
 	v.SetDefault("log.format", "json")
+	v.SetDefault("log.mask_pii", true)
+
+	// Worker defaults
+	v.SetDefault("worker.report_schedule_interval", "1m")
+	v.SetDefault("worker.data_export_interval", "1m")
+	v.SetDefault("worker.popbill_webhook_interval", "10s")
+	v.SetDefault("worker.job_interval", "5s")
+	v.SetDefault("worker.scheduler_interval", "30s")
+	v.SetDefault("worker.anomaly_scoring_interval", "10m")
+	v.SetDefault("worker.backup_interval", "5m")
+	v.SetDefault("worker.partition_years_ahead", 1)
+	v.SetDefault("worker.partition_hash_count", 0)
+
+	// OCR defaults
+	v.SetDefault("ocr.provider", "")
+	v.SetDefault("ocr.secret_key", "")
+	v.SetDefault("ocr.timeout", "30s")
+	v.SetDefault("ocr.max_image_size", 10<<20)
+
+	// Email defaults
+	v.SetDefault("email.provider", "")
+	v.SetDefault("email.port", 587)
+	v.SetDefault("email.username", "")
+	v.SetDefault("email.password", "")
+	v.SetDefault("email.from_name", "K-ERP")
+	v.SetDefault("email.timeout", "30s")
+	v.SetDefault("email.link_base_url", "http://localhost:3000")
+	v.SetDefault("email.bounce_webhook_secret", "")
+
+	// Push defaults
+	v.SetDefault("push.fcm_project_id", "")
+	v.SetDefault("push.fcm_credentials_file", "")
+	v.SetDefault("push.apns_key_id", "")
+	v.SetDefault("push.apns_team_id", "")
+	v.SetDefault("push.apns_key_file", "")
+	v.SetDefault("push.apns_topic", "")
+	v.SetDefault("push.apns_sandbox", false)
+	v.SetDefault("push.timeout", "10s")
+
+	// Export defaults
+	v.SetDefault("export.signing_secret", "")
+	v.SetDefault("export.link_ttl", "1h")
+	v.SetDefault("export.retention", "168h")
+
+	// Attachment defaults
+	v.SetDefault("attachment.max_file_size", 10<<20)
+	v.SetDefault("attachment.scanner", "")
+	v.SetDefault("attachment.clamav_address", "localhost:3310")
+	v.SetDefault("attachment.scan_timeout", "30s")
+	v.SetDefault("attachment.thumbnail_size", 320)
+	v.SetDefault("attachment.heic_converter", "")
+	v.SetDefault("attachment.imagemagick_path", "magick")
+	v.SetDefault("attachment.convert_timeout", "30s")
+
+	// Credentials defaults
+	v.SetDefault("credentials.key_provider", "")
+	v.SetDefault("credentials.master_key_id", "default")
+	v.SetDefault("credentials.master_key", "")
+	v.SetDefault("credentials.vault_address", "")
+	v.SetDefault("credentials.vault_token", "")
+	v.SetDefault("credentials.vault_transit_key", "kerp-credentials")
+	v.SetDefault("credentials.vault_timeout", "10s")
+	v.SetDefault("credentials.test_timeout", "15s")
+
+	// Popbill defaults
+	v.SetDefault("popbill.timeout", "30s")
+	v.SetDefault("popbill.retry_max_attempts", 3)
+	v.SetDefault("popbill.retry_base_delay", "200ms")
+	v.SetDefault("popbill.retry_max_delay", "2s")
+	v.SetDefault("popbill.breaker_threshold", 5)
+	v.SetDefault("popbill.breaker_open_timeout", "30s")
+	v.SetDefault("popbill.share_tokens", true)
+	v.SetDefault("popbill.webhook_secret", "")
+	v.SetDefault("popbill.webhook_tolerance", "5m")
+	v.SetDefault("popbill.bulk_issue_concurrency", 4)
+	v.SetDefault("popbill.bulk_issue_max_invoices", 100)
+
+	// NTS defaults
+	v.SetDefault("nts.base_url", "https://api.odcloud.kr/api/nts-businessman/v1")
+	v.SetDefault("nts.service_key", "")
+	v.SetDefault("nts.timeout", "10s")
+	v.SetDefault("nts.revalidate_after", "720h")
+	v.SetDefault("nts.revalidate_batch_size", 500)
+
+	// Inbox defaults
+	v.SetDefault("inbox.domain", "")
+	v.SetDefault("inbox.webhook_secret", "")
+	v.SetDefault("inbox.max_message_size", 25*1024*1024)
+	v.SetDefault("inbox.max_attachment_size", 10*1024*1024)
+
+	// Billing defaults
+	v.SetDefault("billing.provider", "")
+	v.SetDefault("billing.timeout", "30s")
+	v.SetDefault("billing.stripe_secret_key", "")
+	v.SetDefault("billing.stripe_base_url", "https://api.stripe.com")
+	v.SetDefault("billing.stripe_event_prefix", "kerp_")
+
+	// Plan defaults
+	v.SetDefault("plan.enforcement", "enforce")
+	v.SetDefault("plan.grace_percent", 10)
+	v.SetDefault("plan.grace_period", "720h")
+
+	// Secret store defaults
+	v.SetDefault("secret_store.provider", "")
+	v.SetDefault("secret_store.timeout", "10s")
+	v.SetDefault("secret_store.vault_address", "")
+	v.SetDefault("secret_store.vault_token", "")
+	v.SetDefault("secret_store.vault_mount", "secret")
+	v.SetDefault("secret_store.vault_path", "")
+	v.SetDefault("secret_store.ssm_region", "")
+	v.SetDefault("secret_store.ssm_path", "")
+	v.SetDefault("secret_store.ssm_endpoint", "")
+	v.SetDefault("secret_store.aws_access_key_id", "")
+	v.SetDefault("secret_store.aws_secret_access_key", "")
+	v.SetDefault("secret_store.aws_session_token", "")
+
+	// Reload defaults
+	v.SetDefault("reload.interval", "1m")
+
+	// Diagnostics defaults
+	v.SetDefault("diagnostics.addr", "")
+
+	// Error reporting defaults
+	v.SetDefault("errors.reporter", "")
+	v.SetDefault("errors.sentry_dsn", "")
+	v.SetDefault("errors.timeout", "5s")
+
+	// Backup defaults
+	v.SetDefault("backup.schedule", "0 2 * * *")
+	v.SetDefault("backup.timezone", "Asia/Seoul")
+	v.SetDefault("backup.retention_days", 30)
+	v.SetDefault("backup.monthly_retention_months", 12)
+	v.SetDefault("backup.storage", "")
+	v.SetDefault("backup.storage_timeout", "5m")
+	v.SetDefault("backup.s3_endpoint", "")
+	v.SetDefault("backup.s3_region", "")
+	v.SetDefault("backup.s3_bucket", "")
+	v.SetDefault("backup.s3_prefix", "backups")
+	v.SetDefault("backup.aws_access_key_id", "")
+	v.SetDefault("backup.aws_secret_access_key", "")
+	v.SetDefault("backup.aws_session_token", "")
+	v.SetDefault("backup.file_dir", "")
+	v.SetDefault("backup.staging_dsn", "")
+
+	// Retention defaults
+	v.SetDefault("retention.accounting_years", 10)
+	v.SetDefault("retention.tax_years", 5)
+	v.SetDefault("retention.audit_log_days", 365)
+	v.SetDefault("retention.session_days", 30)
+	v.SetDefault("retention.deletion_deadline_days", 10)
 }
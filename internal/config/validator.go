@@ -1,8 +1,13 @@
 package config
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/cron"
 )
 
 // Validate checks if the configuration is valid
@@ -35,15 +40,59 @@ func (c *Config) Validate() error {
 		errs = append(errs, fmt.Errorf("invalid database.max_open_conns: %d", c.Database.MaxOpenConns))
 	}
 
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("invalid database.max_idle_conns: %d (must be between 0 and max_open_conns)", c.Database.MaxIdleConns))
+	}
+
+	if c.Database.ConnMaxLifetime < 0 || c.Database.ConnMaxIdleTime < 0 || c.Database.StatementTimeout < 0 {
+		errs = append(errs, errors.New("database.conn_max_lifetime, conn_max_idle_time and statement_timeout must not be negative"))
+	}
+
+	if c.Database.SlowQueryThreshold <= 0 {
+		errs = append(errs, errors.New("database.slow_query_threshold must be positive"))
+	}
+
+	// Redis validation
+	if c.Redis.FailureThreshold < 0 {
+		errs = append(errs, errors.New("redis.failure_threshold must not be negative"))
+	}
+	if c.Redis.FailureThreshold > 0 && c.Redis.RetryInterval <= 0 {
+		errs = append(errs, errors.New("redis.retry_interval must be positive"))
+	}
+	for feature, policy := range c.Redis.Degradation {
+		if policy != "fail_open" && policy != "fail_closed" {
+			errs = append(errs, fmt.Errorf("invalid redis.degradation.%s: %s (must be fail_open or fail_closed)", feature, policy))
+		}
+	}
+
 	// JWT validation
-	if c.JWT.Secret == "" {
+	usesSecret := c.JWT.SigningKeyID == "" || c.JWT.AcceptHMAC
+	if usesSecret && c.JWT.Secret == "" {
 		errs = append(errs, errors.New("jwt.secret is required"))
 	}
 
-	if c.App.Env == "production" && c.JWT.Secret == "change-me-in-production" {
+	if usesSecret && c.App.Env == "production" && c.JWT.Secret == "change-me-in-production" {
 		errs = append(errs, errors.New("jwt.secret must be changed in production"))
 	}
 
+	if c.JWT.SigningKeyID != "" {
+		found := false
+		for _, key := range c.JWT.Keys {
+			if key.ID == c.JWT.SigningKeyID {
+				found = true
+				if key.PrivateKeyFile == "" {
+					errs = append(errs, fmt.Errorf("jwt signing key %s requires private_key_file", key.ID))
+				}
+			}
+			if key.Algorithm != "RS256" && key.Algorithm != "EdDSA" {
+				errs = append(errs, fmt.Errorf("invalid jwt key algorithm for %s: %s", key.ID, key.Algorithm))
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("jwt.signing_key_id %s not found in jwt.keys", c.JWT.SigningKeyID))
+		}
+	}
+
 	if c.JWT.AccessTokenTTL <= 0 {
 		errs = append(errs, errors.New("jwt.access_token_ttl must be positive"))
 	}
@@ -57,6 +106,50 @@ func (c *Config) Validate() error {
 		errs = append(errs, errors.New("cors.allowed_origins must have at least one origin"))
 	}
 
+	if c.App.Env == "production" {
+		for _, origin := range c.CORS.AllowedOrigins {
+			if origin == "*" {
+				errs = append(errs, errors.New("cors.allowed_origins must not contain * in production"))
+				break
+			}
+		}
+	}
+
+	// Security validation
+	switch c.Security.FrameOptions {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		errs = append(errs, fmt.Errorf("invalid security.frame_options: %s (must be DENY or SAMEORIGIN)", c.Security.FrameOptions))
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("invalid security.hsts_max_age: %d", c.Security.HSTSMaxAge))
+	}
+
+	if c.Security.MaxBodySize <= 0 {
+		errs = append(errs, errors.New("security.max_body_size must be positive"))
+	}
+
+	if c.Security.MaxUploadSize <= 0 {
+		errs = append(errs, errors.New("security.max_upload_size must be positive"))
+	}
+
+	if c.Security.MaxJSONDepth <= 0 {
+		errs = append(errs, errors.New("security.max_json_depth must be positive"))
+	}
+
+	if c.Security.MaxJSONArrayLength <= 0 {
+		errs = append(errs, errors.New("security.max_json_array_length must be positive"))
+	}
+
+	if c.Security.MaxVoucherEntries <= 0 || c.Security.MaxVoucherEntries > c.Security.MaxJSONArrayLength {
+		errs = append(errs, errors.New("security.max_voucher_entries must be positive and at most security.max_json_array_length"))
+	}
+
+	if c.Security.MaxBulkItems <= 0 || c.Security.MaxBulkItems > c.Security.MaxJSONArrayLength {
+		errs = append(errs, errors.New("security.max_bulk_items must be positive and at most security.max_json_array_length"))
+	}
+
 	// Rate limit validation
 	if c.RateLimit.Enabled {
 		if c.RateLimit.RequestsPerSecond < 1 {
@@ -67,6 +160,314 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Worker validation
+	if c.Worker.ReportScheduleInterval <= 0 {
+		errs = append(errs, errors.New("worker.report_schedule_interval must be positive"))
+	}
+	if c.Worker.DataExportInterval <= 0 {
+		errs = append(errs, errors.New("worker.data_export_interval must be positive"))
+	}
+	if c.Worker.PopbillWebhookInterval <= 0 {
+		errs = append(errs, errors.New("worker.popbill_webhook_interval must be positive"))
+	}
+	if c.Worker.JobInterval <= 0 {
+		errs = append(errs, errors.New("worker.job_interval must be positive"))
+	}
+	if c.Worker.SchedulerInterval <= 0 {
+		errs = append(errs, errors.New("worker.scheduler_interval must be positive"))
+	}
+	if c.Worker.AnomalyScoringInterval <= 0 {
+		errs = append(errs, errors.New("worker.anomaly_scoring_interval must be positive"))
+	}
+	if c.Worker.BackupInterval <= 0 {
+		errs = append(errs, errors.New("worker.backup_interval must be positive"))
+	}
+	if c.Worker.PartitionYearsAhead < 0 {
+		errs = append(errs, errors.New("worker.partition_years_ahead must not be negative"))
+	}
+	if c.Worker.PartitionHashCount < 0 || c.Worker.PartitionHashCount == 1 {
+		errs = append(errs, errors.New("worker.partition_hash_count must be 0 or at least 2"))
+	}
+
+	// OCR validation
+	switch c.OCR.Provider {
+	case "":
+	case "clova":
+		if c.OCR.InvokeURL == "" || c.OCR.SecretKey == "" {
+			errs = append(errs, errors.New("ocr.invoke_url and ocr.secret_key are required for the clova provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid ocr.provider: %s", c.OCR.Provider))
+	}
+	if c.OCR.MaxImageSize <= 0 {
+		errs = append(errs, errors.New("ocr.max_image_size must be positive"))
+	}
+
+	// Email validation
+	switch c.Email.Provider {
+	case "":
+	case "smtp":
+		if c.Email.Host == "" || c.Email.From == "" {
+			errs = append(errs, errors.New("email.host and email.from are required for the smtp provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid email.provider: %s", c.Email.Provider))
+	}
+
+	// Push validation
+	if c.Push.APNsKeyFile != "" && (c.Push.APNsKeyID == "" || c.Push.APNsTeamID == "" || c.Push.APNsTopic == "") {
+		errs = append(errs, errors.New("push.apns_key_id, push.apns_team_id and push.apns_topic are required with push.apns_key_file"))
+	}
+	if (c.Push.FCMCredentialsFile != "" || c.Push.APNsKeyFile != "") && c.Push.Timeout <= 0 {
+		errs = append(errs, errors.New("push.timeout must be positive"))
+	}
+
+	// Export validation
+	if c.Export.LinkTTL <= 0 {
+		errs = append(errs, errors.New("export.link_ttl must be positive"))
+	}
+	if c.Export.Retention < c.Export.LinkTTL {
+		errs = append(errs, errors.New("export.retention must not be shorter than export.link_ttl"))
+	}
+
+	// Attachment validation
+	if c.Attachment.MaxFileSize <= 0 {
+		errs = append(errs, errors.New("attachment.max_file_size must be positive"))
+	}
+	if c.Attachment.MaxFileSize > c.Security.MaxUploadSize {
+		errs = append(errs, errors.New("attachment.max_file_size must not exceed security.max_upload_size"))
+	}
+	switch c.Attachment.Scanner {
+	case "":
+	case "clamav":
+		if c.Attachment.ClamAVAddress == "" {
+			errs = append(errs, errors.New("attachment.clamav_address is required for the clamav scanner"))
+		}
+		if c.Attachment.ScanTimeout <= 0 {
+			errs = append(errs, errors.New("attachment.scan_timeout must be positive"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid attachment.scanner: %s", c.Attachment.Scanner))
+	}
+	if c.Attachment.ThumbnailSize < 0 {
+		errs = append(errs, errors.New("attachment.thumbnail_size must not be negative"))
+	}
+	switch c.Attachment.HEICConverter {
+	case "":
+	case "imagemagick":
+		if c.Attachment.ImageMagickPath == "" {
+			errs = append(errs, errors.New("attachment.imagemagick_path is required for the imagemagick converter"))
+		}
+		if c.Attachment.ConvertTimeout <= 0 {
+			errs = append(errs, errors.New("attachment.convert_timeout must be positive"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid attachment.heic_converter: %s", c.Attachment.HEICConverter))
+	}
+
+	// Credentials validation
+	switch c.Credentials.KeyProvider {
+	case "":
+	case "local":
+		if c.Credentials.MasterKeyID == "" {
+			errs = append(errs, errors.New("credentials.master_key_id is required for the local key provider"))
+		}
+		if !isMasterKey(c.Credentials.MasterKey) {
+			errs = append(errs, errors.New("credentials.master_key must be 32 bytes, base64 encoded"))
+		}
+		for _, k := range c.Credentials.RetiredKeys {
+			if k.ID == "" || k.ID == c.Credentials.MasterKeyID || !isMasterKey(k.Key) {
+				errs = append(errs, fmt.Errorf("invalid credentials.retired_keys entry: %q", k.ID))
+			}
+		}
+	case "vault":
+		if c.Credentials.VaultAddress == "" || c.Credentials.VaultToken == "" || c.Credentials.VaultTransitKey == "" {
+			errs = append(errs, errors.New("credentials.vault_address, vault_token and vault_transit_key are required for the vault key provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid credentials.key_provider: %s", c.Credentials.KeyProvider))
+	}
+	if c.Credentials.TestTimeout <= 0 {
+		errs = append(errs, errors.New("credentials.test_timeout must be positive"))
+	}
+
+	// Popbill validation
+	if c.Popbill.Timeout <= 0 {
+		errs = append(errs, errors.New("popbill.timeout must be positive"))
+	}
+	if c.Popbill.RetryMaxAttempts < 1 {
+		errs = append(errs, errors.New("popbill.retry_max_attempts must be at least 1"))
+	}
+	if c.Popbill.RetryBaseDelay < 0 || c.Popbill.RetryMaxDelay < c.Popbill.RetryBaseDelay {
+		errs = append(errs, errors.New("popbill.retry_max_delay must not be shorter than popbill.retry_base_delay"))
+	}
+	if c.Popbill.BreakerThreshold < 0 {
+		errs = append(errs, errors.New("popbill.breaker_threshold must not be negative"))
+	}
+	if c.Popbill.BreakerThreshold > 0 && c.Popbill.BreakerOpenTimeout <= 0 {
+		errs = append(errs, errors.New("popbill.breaker_open_timeout must be positive"))
+	}
+	if c.Popbill.WebhookSecret != "" && c.Popbill.WebhookTolerance <= 0 {
+		errs = append(errs, errors.New("popbill.webhook_tolerance must be positive"))
+	}
+	if c.Popbill.BulkIssueConcurrency < 1 {
+		errs = append(errs, errors.New("popbill.bulk_issue_concurrency must be at least 1"))
+	}
+	if c.Popbill.BulkIssueMaxInvoices < 1 {
+		errs = append(errs, errors.New("popbill.bulk_issue_max_invoices must be at least 1"))
+	}
+
+	// NTS validation
+	if c.NTS.Timeout <= 0 {
+		errs = append(errs, errors.New("nts.timeout must be positive"))
+	}
+	if c.NTS.RevalidateAfter <= 0 {
+		errs = append(errs, errors.New("nts.revalidate_after must be positive"))
+	}
+	if c.NTS.RevalidateBatchSize < 1 {
+		errs = append(errs, errors.New("nts.revalidate_batch_size must be at least 1"))
+	}
+
+	// Inbox validation
+	if c.Inbox.WebhookSecret != "" && c.Inbox.Domain == "" {
+		errs = append(errs, errors.New("inbox.domain is required when inbox.webhook_secret is set"))
+	}
+	if c.Inbox.MaxMessageSize <= 0 {
+		errs = append(errs, errors.New("inbox.max_message_size must be positive"))
+	}
+	if c.Inbox.MaxAttachmentSize <= 0 {
+		errs = append(errs, errors.New("inbox.max_attachment_size must be positive"))
+	}
+
+	// Billing validation
+	switch c.Billing.Provider {
+	case "":
+	case "stripe":
+		if c.Billing.StripeSecretKey == "" {
+			errs = append(errs, errors.New("billing.stripe_secret_key is required for the stripe provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid billing.provider: %s", c.Billing.Provider))
+	}
+	if c.Billing.Provider != "" && c.Billing.Timeout <= 0 {
+		errs = append(errs, errors.New("billing.timeout must be positive"))
+	}
+
+	// Plan validation
+	switch c.Plan.Enforcement {
+	case "enforce", "warn", "off":
+	default:
+		errs = append(errs, fmt.Errorf("invalid plan.enforcement: %s", c.Plan.Enforcement))
+	}
+	if c.Plan.GracePercent < 0 || c.Plan.GracePercent > 100 {
+		errs = append(errs, errors.New("plan.grace_percent must be between 0 and 100"))
+	}
+	if c.Plan.GracePeriod < 0 {
+		errs = append(errs, errors.New("plan.grace_period must not be negative"))
+	}
+
+	// Secret store validation
+	switch c.SecretStore.Provider {
+	case "":
+	case "vault":
+		if c.SecretStore.VaultAddress == "" || c.SecretStore.VaultToken == "" || c.SecretStore.VaultPath == "" {
+			errs = append(errs, errors.New("secret_store.vault_address, vault_token and vault_path are required for the vault provider"))
+		}
+	case "ssm":
+		if c.SecretStore.SSMRegion == "" || c.SecretStore.SSMPath == "" {
+			errs = append(errs, errors.New("secret_store.ssm_region and ssm_path are required for the ssm provider"))
+		}
+		if c.SecretStore.AWSAccessKeyID == "" || c.SecretStore.AWSSecretAccessKey == "" {
+			errs = append(errs, errors.New("secret_store.aws_access_key_id and aws_secret_access_key (or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY) are required for the ssm provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid secret_store.provider: %s", c.SecretStore.Provider))
+	}
+	if c.SecretStore.Provider != "" && c.SecretStore.Timeout <= 0 {
+		errs = append(errs, errors.New("secret_store.timeout must be positive"))
+	}
+
+	// Reload validation
+	if c.Reload.Interval < 0 {
+		errs = append(errs, errors.New("reload.interval must not be negative"))
+	}
+
+	// Diagnostics validation
+	if c.Diagnostics.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.Diagnostics.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("diagnostics.addr must be host:port: %w", err))
+		}
+	}
+
+	// Error reporting validation
+	switch c.Errors.Reporter {
+	case "":
+	case "sentry":
+		if c.Errors.SentryDSN == "" {
+			errs = append(errs, errors.New("errors.sentry_dsn is required for the sentry reporter"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid errors.reporter: %s", c.Errors.Reporter))
+	}
+	if c.Errors.Timeout < 0 {
+		errs = append(errs, errors.New("errors.timeout must not be negative"))
+	}
+
+	// Backup validation
+	switch c.Backup.Storage {
+	case "":
+	case "s3":
+		if c.Backup.S3Region == "" || c.Backup.S3Bucket == "" {
+			errs = append(errs, errors.New("backup.s3_region and s3_bucket are required for the s3 storage"))
+		}
+		if c.Backup.AWSAccessKeyID == "" || c.Backup.AWSSecretAccessKey == "" {
+			errs = append(errs, errors.New("backup.aws_access_key_id and aws_secret_access_key (or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY) are required for the s3 storage"))
+		}
+	case "file":
+		if c.Backup.FileDir == "" {
+			errs = append(errs, errors.New("backup.file_dir is required for the file storage"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid backup.storage: %s", c.Backup.Storage))
+	}
+	if c.Backup.Storage != "" {
+		if c.Credentials.KeyProvider == "" {
+			errs = append(errs, errors.New("credentials.key_provider is required to encrypt backups"))
+		}
+		if _, err := cron.Parse(c.Backup.Schedule); err != nil {
+			errs = append(errs, fmt.Errorf("invalid backup.schedule: %w", err))
+		}
+		if _, err := time.LoadLocation(c.Backup.Timezone); err != nil {
+			errs = append(errs, fmt.Errorf("invalid backup.timezone: %w", err))
+		}
+		if c.Backup.StorageTimeout <= 0 {
+			errs = append(errs, errors.New("backup.storage_timeout must be positive"))
+		}
+	}
+	if c.Backup.RetentionDays < 1 {
+		errs = append(errs, errors.New("backup.retention_days must be at least 1"))
+	}
+	if c.Backup.MonthlyRetentionMonths < 0 {
+		errs = append(errs, errors.New("backup.monthly_retention_months must not be negative"))
+	}
+
+	// Retention validation; the minimums are statutory
+	if c.Retention.AccountingYears < 10 {
+		errs = append(errs, errors.New("retention.accounting_years must be at least 10 (Commercial Act art. 33)"))
+	}
+	if c.Retention.TaxYears < 5 {
+		errs = append(errs, errors.New("retention.tax_years must be at least 5 (Framework Act on National Taxes art. 85-3)"))
+	}
+	if c.Retention.AuditLogDays < 1 {
+		errs = append(errs, errors.New("retention.audit_log_days must be at least 1"))
+	}
+	if c.Retention.SessionDays < 1 {
+		errs = append(errs, errors.New("retention.session_days must be at least 1"))
+	}
+	if c.Retention.DeletionDeadlineDays < 1 || c.Retention.DeletionDeadlineDays > 30 {
+		errs = append(errs, errors.New("retention.deletion_deadline_days must be between 1 and 30"))
+	}
+
 	// Log validation
 	validLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
 	if !validLevels[c.Log.Level] {
@@ -84,3 +485,9 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// isMasterKey checks that s is a base64 encoded 32-byte key
+func isMasterKey(s string) bool {
+	key, err := base64.StdEncoding.DecodeString(s)
+	return err == nil && len(key) == 32
+}
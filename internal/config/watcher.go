@@ -0,0 +1,98 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ReloadFunc applies a reloaded configuration. It is called with the
+// previous and the new configuration whenever they differ.
+type ReloadFunc func(old, new *Config)
+
+// Watcher reloads the configuration, including the secrets of the secret
+// store, and hands changes to the registered reload functions. A reload that
+// fails to read or validate keeps the current configuration.
+type Watcher struct {
+	onError func(error)
+
+	mu       sync.Mutex
+	current  *Config
+	handlers []ReloadFunc
+}
+
+// NewWatcher creates a watcher of the loaded configuration. onError is called
+// with the error of a failed reload.
+func NewWatcher(current *Config, onError func(error)) *Watcher {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	return &Watcher{current: current, onError: onError}
+}
+
+// OnReload registers a function applying changes
+func (w *Watcher) OnReload(fn ReloadFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, fn)
+}
+
+// Current returns the configuration in effect
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Run reloads at the reload interval of the current configuration until ctx
+// is done. It returns at once when the interval is 0.
+func (w *Watcher) Run(ctx context.Context) {
+	interval := w.Current().Reload.Interval
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Reload(ctx)
+		}
+	}
+}
+
+// Reload reads the configuration again and applies it if it changed
+func (w *Watcher) Reload(ctx context.Context) {
+	next, err := LoadContext(ctx)
+	if err != nil {
+		w.onError(err)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if reflect.DeepEqual(w.current, next) {
+		return
+	}
+	old := w.current
+	w.current = next
+	for _, fn := range w.handlers {
+		fn(old, next)
+	}
+}
+
+// ChangedSections returns the top-level keys (e.g. "database") of the
+// sections that differ between two configurations
+func ChangedSections(old, new *Config) []string {
+	var sections []string
+	ov, nv := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	for i := 0; i < ov.NumField(); i++ {
+		if !reflect.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+			sections = append(sections, ov.Type().Field(i).Tag.Get("mapstructure"))
+		}
+	}
+	return sections
+}
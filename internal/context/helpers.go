@@ -6,6 +6,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
 )
 
 // GetRequestID returns the request ID from context
@@ -23,6 +25,21 @@ func SetRequestID(c *gin.Context, id string) {
 	c.Set(KeyRequestID, id)
 }
 
+// GetLocale returns the negotiated response locale from context
+func GetLocale(c *gin.Context) i18n.Locale {
+	if v, exists := c.Get(KeyLocale); exists {
+		if loc, ok := v.(i18n.Locale); ok {
+			return loc
+		}
+	}
+	return i18n.DefaultLocale
+}
+
+// SetLocale sets the response locale in context
+func SetLocale(c *gin.Context, loc i18n.Locale) {
+	c.Set(KeyLocale, loc)
+}
+
 // GetUserID returns the user ID from context
 func GetUserID(c *gin.Context) uuid.UUID {
 	if v, exists := c.Get(KeyUserID); exists {
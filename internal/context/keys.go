@@ -7,6 +7,7 @@ const (
 	KeyStartTime  = "start_time"
 	KeyClientIP   = "client_ip"
 	KeyUserAgent  = "user_agent"
+	KeyLocale     = "locale"
 
 	// Authentication
 	KeyUserID    = "user_id"
@@ -0,0 +1,58 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/cron"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := cron.Parse(expr)
+			assert.ErrorIs(t, err, cron.ErrInvalidExpression)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	kst := time.FixedZone("KST", 9*3600)
+	from := time.Date(2026, 10, 14, 10, 30, 45, 0, kst) // Wednesday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 14, 10, 31, 0, 0, kst)},
+		{"*/15 * * * *", time.Date(2026, 10, 14, 10, 45, 0, 0, kst)},
+		{"0 9 * * *", time.Date(2026, 10, 15, 9, 0, 0, 0, kst)},
+		{"0 9 * * 1-5", time.Date(2026, 10, 15, 9, 0, 0, 0, kst)},
+		{"0 9 * * 1", time.Date(2026, 10, 19, 9, 0, 0, 0, kst)},
+		{"0 9 * * 7", time.Date(2026, 10, 18, 9, 0, 0, 0, kst)},
+		{"0 8 1 * *", time.Date(2026, 11, 1, 8, 0, 0, 0, kst)},
+		{"0 8 1,15 * *", time.Date(2026, 10, 15, 8, 0, 0, 0, kst)},
+		{"0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, kst)},
+		{"@monthly", time.Date(2026, 11, 1, 0, 0, 0, 0, kst)},
+		{"0 9 31 * *", time.Date(2026, 10, 31, 9, 0, 0, 0, kst)},
+		// Day of month OR day of week when both are restricted
+		{"0 9 20 * 5", time.Date(2026, 10, 16, 9, 0, 0, 0, kst)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := cron.Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextNeverMatches(t *testing.T) {
+	s, err := cron.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
@@ -3,35 +3,83 @@ package database
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
 
 	"github.com/saintgo7/saas-kerp/internal/config"
 )
 
+// DBPassword holds the database password used for new connections, so that a
+// rotated password takes effect without reopening the pool. Open connections
+// keep working and are replaced as they reach conn_max_lifetime.
+type DBPassword struct {
+	value atomic.Value
+}
+
+// NewDBPassword creates a password holder
+func NewDBPassword(password string) *DBPassword {
+	p := &DBPassword{}
+	p.Set(password)
+	return p
+}
+
+// Set replaces the password
+func (p *DBPassword) Set(password string) {
+	p.value.Store(password)
+}
+
+// Get returns the current password
+func (p *DBPassword) Get() string {
+	return p.value.Load().(string)
+}
+
 // NewPostgresDB creates a new PostgreSQL connection using GORM
 func NewPostgresDB(cfg *config.DatabaseConfig, zapLogger *zap.Logger) (*gorm.DB, error) {
+	return NewPostgresDBWithPassword(cfg, NewDBPassword(cfg.Password), zapLogger)
+}
+
+// NewPostgresDBWithPassword creates a new PostgreSQL connection whose new
+// connections authenticate with the current value of password
+func NewPostgresDBWithPassword(cfg *config.DatabaseConfig, password *DBPassword, zapLogger *zap.Logger) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
+		"host=%s port=%d user=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.SSLMode,
 	)
+	dsn = withStatementTimeout(dsn, cfg.StatementTimeout)
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+	connConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	pool := stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(func(ctx context.Context, c *pgx.ConnConfig) error {
+		c.Password = password.Get()
+		return nil
+	}))
 
 	// Configure GORM logger
 	var gormLogger logger.Interface
 	if zapLogger != nil {
-		gormLogger = newGormLogger(zapLogger)
+		gormLogger = newGormLogger(zapLogger, cfg.SlowQueryThreshold)
 	} else {
 		gormLogger = logger.Default.LogMode(logger.Silent)
 	}
 
 	db, err := gorm.Open(postgres.New(postgres.Config{
-		DSN:                  dsn,
-		PreferSimpleProtocol: true,
+		Conn: pool,
 	}), &gorm.Config{
 		Logger:                                   gormLogger,
 		DisableForeignKeyConstraintWhenMigrating: true,
@@ -50,6 +98,7 @@ func NewPostgresDB(cfg *config.DatabaseConfig, zapLogger *zap.Logger) (*gorm.DB,
 	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	// Verify connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -59,6 +108,52 @@ func NewPostgresDB(cfg *config.DatabaseConfig, zapLogger *zap.Logger) (*gorm.DB,
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	if cfg.ReplicaDSN != "" {
+		if err := useReplica(db, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// withStatementTimeout sets the statement_timeout run-time parameter in a
+// key=value or postgres:// URL connection string
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return dsn
+	}
+	ms := strconv.FormatInt(timeout.Milliseconds(), 10)
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		q := u.Query()
+		q.Set("statement_timeout", ms)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	return dsn + " statement_timeout=" + ms
+}
+
+// NewStagingDB opens the staging database that backups are restored into.
+// No connection is made until the first restore, so an unreachable staging
+// database does not keep the worker from starting. Statements are not
+// logged, as the inserts carry tenant data.
+func NewStagingDB(dsn string) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid staging database configuration: %w", err)
+	}
+	connConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	pool := stdlib.OpenDB(*connConfig)
+	pool.SetMaxOpenConns(2)
+	pool.SetConnMaxIdleTime(time.Minute)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger:               logger.Default.LogMode(logger.Silent),
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open staging database: %w", err)
+	}
 	return db, nil
 }
 
@@ -88,14 +183,16 @@ func Transaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 
 // gormLogger adapts zap logger to GORM logger interface
 type gormZapLogger struct {
-	logger *zap.Logger
-	level  logger.LogLevel
+	logger        *zap.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
 }
 
-func newGormLogger(zapLogger *zap.Logger) logger.Interface {
+func newGormLogger(zapLogger *zap.Logger, slowThreshold time.Duration) logger.Interface {
 	return &gormZapLogger{
-		logger: zapLogger,
-		level:  logger.Info,
+		logger:        zapLogger,
+		level:         logger.Info,
+		slowThreshold: slowThreshold,
 	}
 }
 
@@ -138,12 +235,16 @@ func (l *gormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 			zap.Duration("elapsed", elapsed),
 			zap.Int64("rows", rows),
 			zap.String("sql", sql),
+			zap.String("repository", repositoryCaller()),
 		)
-	case elapsed > 200*time.Millisecond && l.level >= logger.Warn:
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
 		l.logger.Warn("slow query",
 			zap.Duration("elapsed", elapsed),
+			zap.Duration("threshold", l.slowThreshold),
 			zap.Int64("rows", rows),
 			zap.String("sql", sql),
+			zap.String("repository", repositoryCaller()),
+			zap.String("source", utils.FileWithLineNum()),
 		)
 	case l.level >= logger.Info:
 		l.logger.Debug("gorm trace",
@@ -153,3 +254,36 @@ func (l *gormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 		)
 	}
 }
+
+// repositoryPackage is the function name prefix of the repository implementations
+const repositoryPackage = "github.com/saintgo7/saas-kerp/internal/repository."
+
+// repositoryCaller returns the repository method that issued the query being
+// traced, e.g. "ledgerRepositoryGorm.GetAccountLedger", or "" if there is none
+func repositoryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, repositoryPackage) {
+			return repositoryMethod(strings.TrimPrefix(frame.Function, repositoryPackage))
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// repositoryMethod shortens a function name within the repository package, such as
+// "(*voucherRepositoryGorm).Create.func1", to "voucherRepositoryGorm.Create"
+func repositoryMethod(name string) string {
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	parts := strings.Split(name, ".")
+	for len(parts) > 1 && strings.HasPrefix(parts[len(parts)-1], "func") {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
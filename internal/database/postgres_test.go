@@ -0,0 +1,35 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		expected string
+	}{
+		{"method", "(*ledgerRepositoryGorm).GetAccountLedger", "ledgerRepositoryGorm.GetAccountLedger"},
+		{"transaction closure", "(*voucherRepositoryGorm).Create.func1", "voucherRepositoryGorm.Create"},
+		{"nested closure", "(*dataExportRepositoryGorm).ClaimNext.func1.1", "dataExportRepositoryGorm.ClaimNext"},
+		{"function", "NewVoucherRepository", "NewVoucherRepository"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, repositoryMethod(tt.function))
+		})
+	}
+}
+
+func TestWithStatementTimeout(t *testing.T) {
+	assert.Equal(t, "host=db dbname=kerp statement_timeout=30000",
+		withStatementTimeout("host=db dbname=kerp", 30*time.Second))
+	assert.Equal(t, "postgres://kerp@replica:5432/kerp?sslmode=disable&statement_timeout=1500",
+		withStatementTimeout("postgres://kerp@replica:5432/kerp?sslmode=disable", 1500*time.Millisecond))
+	assert.Equal(t, "host=db", withStatementTimeout("host=db", 0))
+}
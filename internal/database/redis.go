@@ -43,14 +43,18 @@ func CloseRedis(client *redis.Client) error {
 	return client.Close()
 }
 
-// RedisCache provides caching operations
+// RedisCache provides caching operations. Errors of an unavailable Redis
+// are handled by the degradation policy of the cache's feature: fail-open
+// caches report misses, drop writes and grant locks.
 type RedisCache struct {
-	client *redis.Client
+	client     *redis.Client
+	resilience *RedisResilience
+	feature    string
 }
 
-// NewRedisCache creates a new RedisCache instance
-func NewRedisCache(client *redis.Client) *RedisCache {
-	return &RedisCache{client: client}
+// NewRedisCache creates a new RedisCache instance for a feature; resilience may be nil
+func NewRedisCache(client *redis.Client, resilience *RedisResilience, feature string) *RedisCache {
+	return &RedisCache{client: client, resilience: resilience, feature: feature}
 }
 
 // Get retrieves a value from cache
@@ -59,36 +63,85 @@ func (c *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	if err == redis.Nil {
 		return "", nil
 	}
-	return val, err
+	return val, c.resilience.Degrade(c.feature, err)
 }
 
 // Set stores a value in cache with TTL
 func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	return c.client.Set(ctx, key, value, ttl).Err()
+	return c.resilience.Degrade(c.feature, c.client.Set(ctx, key, value, ttl).Err())
 }
 
 // Delete removes a key from cache
 func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
-	return c.client.Del(ctx, keys...).Err()
+	return c.resilience.Degrade(c.feature, c.client.Del(ctx, keys...).Err())
 }
 
 // Exists checks if a key exists
 func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
 	n, err := c.client.Exists(ctx, key).Result()
-	return n > 0, err
+	return n > 0, c.resilience.Degrade(c.feature, err)
 }
 
 // SetNX sets a value only if it doesn't exist (for distributed locks)
 func (c *RedisCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
-	return c.client.SetNX(ctx, key, value, ttl).Result()
+	ok, err := c.client.SetNX(ctx, key, value, ttl).Result()
+	if err != nil {
+		if err = c.resilience.Degrade(c.feature, err); err == nil {
+			return true, nil
+		}
+	}
+	return ok, err
 }
 
 // Incr increments a counter
 func (c *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
-	return c.client.Incr(ctx, key).Result()
+	n, err := c.client.Incr(ctx, key).Result()
+	if err != nil {
+		if err = c.resilience.Degrade(c.feature, err); err == nil {
+			return 1, nil
+		}
+	}
+	return n, err
 }
 
 // Expire sets TTL on existing key
 func (c *RedisCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
-	return c.client.Expire(ctx, key, ttl).Err()
+	return c.resilience.Degrade(c.feature, c.client.Expire(ctx, key, ttl).Err())
+}
+
+// ZAdd adds members to a sorted set, or updates their scores
+func (c *RedisCache) ZAdd(ctx context.Context, key string, score float64, members ...string) error {
+	zs := make([]redis.Z, len(members))
+	for i, member := range members {
+		zs[i] = redis.Z{Score: score, Member: member}
+	}
+	return c.resilience.Degrade(c.feature, c.client.ZAdd(ctx, key, zs...).Err())
+}
+
+// ZRem removes members from a sorted set
+func (c *RedisCache) ZRem(ctx context.Context, key string, members ...string) error {
+	args := make([]interface{}, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+	return c.resilience.Degrade(c.feature, c.client.ZRem(ctx, key, args...).Err())
+}
+
+// ZRevRange returns the members of a sorted set from rank start to stop,
+// highest score first
+func (c *RedisCache) ZRevRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	members, err := c.client.ZRevRange(ctx, key, start, stop).Result()
+	return members, c.resilience.Degrade(c.feature, err)
+}
+
+// ZCard returns the number of members of a sorted set
+func (c *RedisCache) ZCard(ctx context.Context, key string) (int64, error) {
+	n, err := c.client.ZCard(ctx, key).Result()
+	return n, c.resilience.Degrade(c.feature, err)
+}
+
+// ZRemRangeByRank removes the members of a sorted set from rank start to
+// stop, lowest score first
+func (c *RedisCache) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) error {
+	return c.resilience.Degrade(c.feature, c.client.ZRemRangeByRank(ctx, key, start, stop).Err())
 }
@@ -0,0 +1,323 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+// ErrRedisUnavailable is returned without sending the command while Redis is
+// considered down, and by fail-closed features while it is unavailable.
+var ErrRedisUnavailable = errors.New("redis is unavailable")
+
+// Redis health states
+const (
+	RedisHealthy    = "healthy"
+	RedisDegraded   = "degraded"   // commands are not sent until the retry interval has passed
+	RedisRecovering = "recovering" // a trial command is in flight
+)
+
+// DegradationPolicy is how a feature behaves while Redis is unavailable
+type DegradationPolicy string
+
+const (
+	// FailOpen carries on without Redis: cache reads miss, writes are dropped,
+	// counters start over and locks are granted
+	FailOpen DegradationPolicy = "fail_open"
+	// FailClosed fails the operation with ErrRedisUnavailable
+	FailClosed DegradationPolicy = "fail_closed"
+)
+
+// Features backed by Redis
+const (
+	RedisFeatureReportCache = "report_cache"
+	RedisFeatureRateLimit   = "rate_limit"
+	RedisFeatureIdempotency = "idempotency"
+	RedisFeatureLocks       = "locks"
+	RedisFeatureShortcuts   = "shortcuts"
+)
+
+// defaultDegradationPolicies are the policies of the features unless
+// configured otherwise. Features that only save work fail open; features
+// that guard against doing work twice fail closed. Unknown features fail closed.
+var defaultDegradationPolicies = map[string]DegradationPolicy{
+	RedisFeatureReportCache: FailOpen,
+	RedisFeatureRateLimit:   FailOpen,
+	RedisFeatureIdempotency: FailClosed,
+	RedisFeatureLocks:       FailClosed,
+	RedisFeatureShortcuts:   FailOpen,
+}
+
+// RedisResilience tracks the health of Redis and the degraded operations of
+// the features using it. Added to the client as a hook, it sees every
+// command: after consecutive connection failures it stops sending commands
+// for the retry interval, so that callers fail fast instead of waiting for
+// timeouts, and then lets a single trial command through. A nil
+// RedisResilience sends every command and passes errors through.
+type RedisResilience struct {
+	threshold     int
+	retryInterval time.Duration
+	policies      map[string]DegradationPolicy
+	logger        *zap.Logger
+	now           func() time.Time
+
+	mu            sync.Mutex
+	state         string
+	failures      int
+	lastError     string
+	degradedSince *time.Time
+	retryAt       time.Time
+	rejected      int64
+	features      map[string]*redisFeatureStats
+}
+
+// redisFeatureStats counts the operations of a feature that ran degraded
+type redisFeatureStats struct {
+	degraded       int64
+	lastDegradedAt *time.Time
+}
+
+// NewRedisResilience creates the resilience layer of the configuration
+func NewRedisResilience(cfg *config.RedisConfig, logger *zap.Logger) *RedisResilience {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	policies := make(map[string]DegradationPolicy, len(defaultDegradationPolicies)+len(cfg.Degradation))
+	for feature, policy := range defaultDegradationPolicies {
+		policies[feature] = policy
+	}
+	for feature, policy := range cfg.Degradation {
+		policies[feature] = DegradationPolicy(policy)
+	}
+	return &RedisResilience{
+		threshold:     cfg.FailureThreshold,
+		retryInterval: cfg.RetryInterval,
+		policies:      policies,
+		logger:        logger,
+		now:           time.Now,
+		state:         RedisHealthy,
+		features:      make(map[string]*redisFeatureStats),
+	}
+}
+
+// Available reports whether commands are being sent to Redis
+func (r *RedisResilience) Available() bool {
+	if r == nil {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state == RedisHealthy
+}
+
+// Policy returns the degradation policy of a feature
+func (r *RedisResilience) Policy(feature string) DegradationPolicy {
+	if r == nil {
+		return FailClosed
+	}
+	if policy, ok := r.policies[feature]; ok {
+		return policy
+	}
+	return FailClosed
+}
+
+// Degrade applies the feature's policy to the error of a Redis operation.
+// Errors other than unavailability are returned as is. Otherwise the
+// degraded operation is counted, and nil is returned for fail-open features
+// and ErrRedisUnavailable for fail-closed ones.
+func (r *RedisResilience) Degrade(feature string, err error) error {
+	if r == nil || !IsRedisUnavailable(err) {
+		return err
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	stats, ok := r.features[feature]
+	if !ok {
+		stats = &redisFeatureStats{}
+		r.features[feature] = stats
+	}
+	stats.degraded++
+	stats.lastDegradedAt = &now
+	r.mu.Unlock()
+
+	if r.Policy(feature) == FailOpen {
+		return nil
+	}
+	return ErrRedisUnavailable
+}
+
+// IsRedisUnavailable reports whether an error means Redis could not be
+// reached, as opposed to a miss or an error reply to the command
+func IsRedisUnavailable(err error) bool {
+	if errors.Is(err, ErrRedisUnavailable) {
+		return true
+	}
+	return isRedisOutage(err)
+}
+
+// isRedisOutage reports whether a command failure counts against the health
+// of Redis. Misses, error replies and cancelled requests do not.
+func isRedisOutage(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var replyErr redis.Error
+	if errors.As(err, &replyErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, redis.ErrClosed)
+}
+
+// allow returns ErrRedisUnavailable if the command must not be sent
+func (r *RedisResilience) allow() error {
+	if r.threshold <= 0 {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.state {
+	case RedisDegraded:
+		if r.now().Before(r.retryAt) {
+			r.rejected++
+			return ErrRedisUnavailable
+		}
+		r.state = RedisRecovering
+	case RedisRecovering:
+		r.rejected++
+		return ErrRedisUnavailable
+	}
+	return nil
+}
+
+// record records the outcome of a sent command
+func (r *RedisResilience) record(err error) {
+	outage := isRedisOutage(err)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !outage {
+		if r.state != RedisHealthy {
+			r.logger.Info("Redis recovered", zap.Int64("rejected_commands", r.rejected))
+		}
+		r.state = RedisHealthy
+		r.failures = 0
+		r.degradedSince = nil
+		return
+	}
+
+	r.failures++
+	r.lastError = err.Error()
+	if r.threshold <= 0 {
+		return
+	}
+	if r.state == RedisRecovering || r.failures >= r.threshold {
+		if r.state == RedisHealthy {
+			now := r.now()
+			r.degradedSince = &now
+			r.logger.Warn("Redis unavailable, degrading dependent features",
+				zap.Int("failures", r.failures), zap.Error(err))
+		}
+		r.state = RedisDegraded
+		r.retryAt = r.now().Add(r.retryInterval)
+	}
+}
+
+// DialHook implements redis.Hook
+func (r *RedisResilience) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implements redis.Hook
+func (r *RedisResilience) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := r.allow(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		err := next(ctx, cmd)
+		r.record(err)
+		return err
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook
+func (r *RedisResilience) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := r.allow(); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		err := next(ctx, cmds)
+		r.record(err)
+		return err
+	}
+}
+
+// RedisFeatureStats is the degraded operation count of a feature
+type RedisFeatureStats struct {
+	Feature            string            `json:"feature"`
+	Policy             DegradationPolicy `json:"policy"`
+	DegradedOperations int64             `json:"degraded_operations"`
+	LastDegradedAt     *time.Time        `json:"last_degraded_at,omitempty"`
+}
+
+// RedisResilienceStats is a snapshot of the health of Redis and of the
+// degraded operations since the start of the instance
+type RedisResilienceStats struct {
+	State               string              `json:"state"`
+	ConsecutiveFailures int                 `json:"consecutive_failures"`
+	LastError           string              `json:"last_error,omitempty"`
+	DegradedSince       *time.Time          `json:"degraded_since,omitempty"`
+	RejectedCommands    int64               `json:"rejected_commands"`
+	Features            []RedisFeatureStats `json:"features"`
+}
+
+// Stats returns a snapshot of the health and the degraded operations by feature
+func (r *RedisResilience) Stats() RedisResilienceStats {
+	if r == nil {
+		return RedisResilienceStats{State: RedisHealthy, Features: []RedisFeatureStats{}}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := RedisResilienceStats{
+		State:               r.state,
+		ConsecutiveFailures: r.failures,
+		LastError:           r.lastError,
+		DegradedSince:       r.degradedSince,
+		RejectedCommands:    r.rejected,
+		Features:            make([]RedisFeatureStats, 0, len(r.policies)),
+	}
+	for feature, policy := range r.policies {
+		feat := RedisFeatureStats{Feature: feature, Policy: policy}
+		if counted, ok := r.features[feature]; ok {
+			feat.DegradedOperations = counted.degraded
+			feat.LastDegradedAt = counted.lastDegradedAt
+		}
+		stats.Features = append(stats.Features, feat)
+	}
+	for feature, counted := range r.features {
+		if _, ok := r.policies[feature]; !ok {
+			stats.Features = append(stats.Features, RedisFeatureStats{
+				Feature: feature, Policy: FailClosed, DegradedOperations: counted.degraded, LastDegradedAt: counted.lastDegradedAt,
+			})
+		}
+	}
+	sort.Slice(stats.Features, func(i, j int) bool { return stats.Features[i].Feature < stats.Features[j].Feature })
+	return stats
+}
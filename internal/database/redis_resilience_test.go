@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+// unreachableRedis returns a client of a port nothing listens on, with the resilience hook
+func unreachableRedis(t *testing.T, cfg *config.RedisConfig) (*redis.Client, *RedisResilience) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	resilience := NewRedisResilience(cfg, nil)
+	client.AddHook(resilience)
+	return client, resilience
+}
+
+func TestRedisResilience_DegradesAndRetries(t *testing.T) {
+	ctx := context.Background()
+	client, resilience := unreachableRedis(t, &config.RedisConfig{FailureThreshold: 2, RetryInterval: time.Minute})
+	now := time.Now()
+	resilience.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		err := client.Get(ctx, "k").Err()
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrRedisUnavailable))
+	}
+	assert.False(t, resilience.Available())
+
+	// Degraded: commands are not sent
+	assert.ErrorIs(t, client.Get(ctx, "k").Err(), ErrRedisUnavailable)
+	assert.Equal(t, int64(1), resilience.Stats().RejectedCommands)
+
+	// After the retry interval a trial command is sent, and fails again
+	now = now.Add(time.Minute)
+	err := client.Get(ctx, "k").Err()
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRedisUnavailable))
+	assert.ErrorIs(t, client.Get(ctx, "k").Err(), ErrRedisUnavailable)
+
+	stats := resilience.Stats()
+	assert.Equal(t, RedisDegraded, stats.State)
+	assert.Equal(t, 3, stats.ConsecutiveFailures)
+	assert.NotNil(t, stats.DegradedSince)
+}
+
+func TestRedisResilience_RecoversOnSuccess(t *testing.T) {
+	resilience := NewRedisResilience(&config.RedisConfig{FailureThreshold: 1, RetryInterval: time.Second}, nil)
+	resilience.record(io.EOF)
+	assert.Equal(t, RedisDegraded, resilience.Stats().State)
+	assert.Equal(t, "EOF", resilience.Stats().LastError)
+
+	// Misses and error replies are successful round trips
+	resilience.state = RedisRecovering
+	resilience.record(redis.Nil)
+	assert.True(t, resilience.Available())
+	assert.Nil(t, resilience.Stats().DegradedSince)
+}
+
+func TestRedisResilience_Degrade(t *testing.T) {
+	resilience := NewRedisResilience(&config.RedisConfig{
+		Degradation: map[string]string{RedisFeatureRateLimit: "fail_closed"},
+	}, nil)
+
+	assert.NoError(t, resilience.Degrade(RedisFeatureReportCache, ErrRedisUnavailable))
+	assert.ErrorIs(t, resilience.Degrade(RedisFeatureIdempotency, ErrRedisUnavailable), ErrRedisUnavailable)
+	assert.ErrorIs(t, resilience.Degrade(RedisFeatureRateLimit, &timeoutError{}), ErrRedisUnavailable)
+	assert.ErrorIs(t, resilience.Degrade("unknown", ErrRedisUnavailable), ErrRedisUnavailable)
+
+	// Other errors are not degradation
+	replyErr := redis.Nil
+	assert.Equal(t, replyErr, resilience.Degrade(RedisFeatureReportCache, replyErr))
+	assert.NoError(t, resilience.Degrade(RedisFeatureReportCache, nil))
+
+	counts := map[string]int64{}
+	for _, f := range resilience.Stats().Features {
+		counts[f.Feature] = f.DegradedOperations
+	}
+	assert.Equal(t, map[string]int64{
+		RedisFeatureReportCache: 1,
+		RedisFeatureRateLimit:   1,
+		RedisFeatureIdempotency: 1,
+		RedisFeatureLocks:       0,
+		RedisFeatureShortcuts:   0,
+		"unknown":               1,
+	}, counts)
+
+	var nilResilience *RedisResilience
+	assert.Equal(t, ErrRedisUnavailable, nilResilience.Degrade(RedisFeatureReportCache, ErrRedisUnavailable))
+	assert.True(t, nilResilience.Available())
+}
+
+// timeoutError is a network timeout
+type timeoutError struct{}
+
+func (*timeoutError) Error() string   { return "i/o timeout" }
+func (*timeoutError) Timeout() bool   { return true }
+func (*timeoutError) Temporary() bool { return true }
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose queries all go to the primary database.
+// Use it where a read must see a write made just before, such as the
+// status check of a workflow action.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether the context sends reads to the primary database
+func UsesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// useReplica routes reads to the read replica. Writes, transactions, locking
+// reads and queries with a WithPrimary context stay on the primary.
+func useReplica(db *gorm.DB, cfg *config.DatabaseConfig) error {
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{postgres.New(postgres.Config{
+			DSN:                  withStatementTimeout(cfg.ReplicaDSN, cfg.StatementTimeout),
+			PreferSimpleProtocol: true,
+		})},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(cfg.ConnMaxLifetime).
+		SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+
+	forcePrimary := func(db *gorm.DB) {
+		if db.Statement.Context != nil && UsesPrimary(db.Statement.Context) {
+			dbresolver.Write.ModifyStatement(db.Statement)
+		}
+	}
+	if err := db.Callback().Query().Before("gorm:db_resolver").Register("kerp:force_primary", forcePrimary); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:db_resolver").Register("kerp:force_primary", forcePrimary); err != nil {
+		return err
+	}
+	return db.Callback().Raw().Before("gorm:db_resolver").Register("kerp:force_primary", forcePrimary)
+}
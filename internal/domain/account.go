@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
 )
 
 // AccountType represents the five major account classifications in K-IFRS
@@ -77,10 +79,18 @@ type Account struct {
 	// Sub-classification
 	AccountCategory string `gorm:"type:varchar(50)" json:"account_category,omitempty"`
 
+	// Financial statement line the account rolls up to; when empty the
+	// nearest mapped ancestor's line, or the default of the type, is used
+	StatementLine string `gorm:"type:varchar(40)" json:"statement_line,omitempty"`
+
 	// Settings
 	IsActive           bool `gorm:"default:true" json:"is_active"`
 	IsControlAccount   bool `gorm:"default:false" json:"is_control_account"`
 	AllowDirectPosting bool `gorm:"default:true" json:"allow_direct_posting"`
+	IsCashAccount      bool `gorm:"default:false" json:"is_cash_account"` // cash/bank account listed in the cash book
+
+	// Posting rules enforced on voucher entries
+	PostingRules *PostingRules `gorm:"type:jsonb;serializer:json" json:"posting_rules,omitempty"`
 
 	// Display order
 	SortOrder int `gorm:"default:0" json:"sort_order"`
@@ -105,7 +115,7 @@ func (a *Account) Validate() error {
 	if !a.AccountNature.IsValid() {
 		return ErrInvalidAccountNature
 	}
-	return nil
+	return ValidateStatementLine(a.AccountType, a.StatementLine)
 }
 
 // SetDefaults sets default values based on account type
@@ -143,30 +153,20 @@ func (a *Account) IsCreditNature() bool {
 
 // GetTypeLabel returns Korean label for account type
 func (a *Account) GetTypeLabel() string {
-	switch a.AccountType {
-	case AccountTypeAsset:
-		return "자산"
-	case AccountTypeLiability:
-		return "부채"
-	case AccountTypeEquity:
-		return "자본"
-	case AccountTypeRevenue:
-		return "수익"
-	case AccountTypeExpense:
-		return "비용"
-	default:
-		return ""
-	}
+	return a.LocalizedTypeLabel(i18n.Korean)
+}
+
+// LocalizedTypeLabel returns the account type label in the locale
+func (a *Account) LocalizedTypeLabel(loc i18n.Locale) string {
+	return i18n.Label(loc, "account_type", string(a.AccountType))
 }
 
 // GetNatureLabel returns Korean label for account nature
 func (a *Account) GetNatureLabel() string {
-	switch a.AccountNature {
-	case AccountNatureDebit:
-		return "차변"
-	case AccountNatureCredit:
-		return "대변"
-	default:
-		return ""
-	}
+	return a.LocalizedNatureLabel(i18n.Korean)
+}
+
+// LocalizedNatureLabel returns the account nature label in the locale
+func (a *Account) LocalizedNatureLabel(loc i18n.Locale) string {
+	return i18n.Label(loc, "account_nature", string(a.AccountNature))
 }
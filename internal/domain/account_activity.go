@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Account activity series limits
+const (
+	AccountActivityDefaultMonths = 12
+	AccountActivityMaxMonths     = 36
+)
+
+// AccountActivityPoint is the activity of an account in one month
+type AccountActivityPoint struct {
+	FiscalYear  int     `json:"fiscal_year"`
+	FiscalMonth int     `json:"fiscal_month"`
+	Period      string  `json:"period"` // 2024-03
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	Closing     float64 `json:"closing"` // balance at the month end on the account's normal side
+}
+
+// AccountActivity is the monthly series of an account, oldest month first,
+// for dashboard sparklines
+type AccountActivity struct {
+	AccountID     uuid.UUID              `json:"account_id"`
+	AccountCode   string                 `json:"account_code"`
+	AccountName   string                 `json:"account_name"`
+	AccountNature AccountNature          `json:"account_nature"`
+	Points        []AccountActivityPoint `json:"points"`
+}
+
+// BuildAccountActivity builds the series of the months ending at toYear and
+// toMonth from the ledger balances of the account. Balances before the first
+// month seed its closing; months without a balance carry the previous
+// closing with no activity.
+func BuildAccountActivity(account *Account, balances []LedgerBalance, toYear, toMonth, months int) *AccountActivity {
+	activity := &AccountActivity{
+		AccountID:     account.ID,
+		AccountCode:   account.Code,
+		AccountName:   account.Name,
+		AccountNature: account.AccountNature,
+		Points:        make([]AccountActivityPoint, 0, months),
+	}
+	sign := 1.0
+	if account.AccountNature == AccountNatureCredit {
+		sign = -1
+	}
+
+	index := func(year, month int) int { return year*12 + month - 1 }
+	last := index(toYear, toMonth)
+	first := last - months + 1
+
+	byPeriod := make(map[int]LedgerBalance, len(balances))
+	closing, seeded := 0.0, -1
+	for _, b := range balances {
+		i := index(b.FiscalYear, b.FiscalMonth)
+		if i >= first {
+			byPeriod[i] = b
+		} else if i > seeded {
+			closing, seeded = b.GetClosingBalance(), i
+		}
+	}
+
+	for i := first; i <= last; i++ {
+		year, month := i/12, i%12+1
+		point := AccountActivityPoint{
+			FiscalYear:  year,
+			FiscalMonth: month,
+			Period:      fmt.Sprintf("%d-%02d", year, month),
+		}
+		if b, ok := byPeriod[i]; ok {
+			point.Debit = b.PeriodDebit
+			point.Credit = b.PeriodCredit
+			closing = b.GetClosingBalance()
+		}
+		if closing != 0 {
+			point.Closing = roundAmount(sign * closing)
+		}
+		activity.Points = append(activity.Points, point)
+	}
+	return activity
+}
@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestBuildAccountActivity(t *testing.T) {
+	account := &domain.Account{Code: "401", Name: "상품매출", AccountNature: domain.AccountNatureCredit}
+	account.ID = uuid.New()
+	balances := []domain.LedgerBalance{
+		{FiscalYear: 2024, FiscalMonth: 9, ClosingCredit: 1000},
+		{FiscalYear: 2024, FiscalMonth: 11, PeriodCredit: 500, ClosingCredit: 1500},
+		{FiscalYear: 2025, FiscalMonth: 1, PeriodDebit: 100, PeriodCredit: 300, OpeningCredit: 1500, ClosingDebit: 100, ClosingCredit: 1800},
+	}
+
+	activity := domain.BuildAccountActivity(account, balances, 2025, 2, 4)
+	require.Len(t, activity.Points, 4)
+
+	assert.Equal(t, "2024-11", activity.Points[0].Period)
+	assert.Equal(t, 500.0, activity.Points[0].Credit)
+	assert.Equal(t, 1500.0, activity.Points[0].Closing)
+
+	// December has no balance and carries November's closing
+	assert.Equal(t, 0.0, activity.Points[1].Credit)
+	assert.Equal(t, 1500.0, activity.Points[1].Closing)
+
+	assert.Equal(t, 1700.0, activity.Points[2].Closing)
+	assert.Equal(t, "2025-02", activity.Points[3].Period)
+	assert.Equal(t, 1700.0, activity.Points[3].Closing)
+}
+
+func TestBuildAccountActivitySeedsFromEarlierBalance(t *testing.T) {
+	account := &domain.Account{AccountNature: domain.AccountNatureDebit}
+	balances := []domain.LedgerBalance{{FiscalYear: 2024, FiscalMonth: 3, ClosingDebit: 800, ClosingCredit: 200}}
+
+	activity := domain.BuildAccountActivity(account, balances, 2024, 6, 2)
+	require.Len(t, activity.Points, 2)
+	assert.Equal(t, "2024-05", activity.Points[0].Period)
+	assert.Equal(t, 600.0, activity.Points[0].Closing)
+	assert.Equal(t, 600.0, activity.Points[1].Closing)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Account lookup limits
+const (
+	AccountLookupLimit    = 20
+	AccountLookupMaxLimit = 50
+)
+
+// AccountLookup is the compact form of an active account served to type-ahead
+// fields of entry forms
+type AccountLookup struct {
+	ID       uuid.UUID `json:"id"`
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	Postable bool      `json:"postable"` // entries may be posted to it directly
+
+	names []string // lower-cased name and English name the query is matched to
+}
+
+// IsPostable returns true if entries may be posted to the account directly
+func (a *Account) IsPostable() bool {
+	return a.IsActive && !a.IsControlAccount && a.AllowDirectPosting
+}
+
+// NewAccountLookup returns the lookup form of the account
+func NewAccountLookup(a *Account) AccountLookup {
+	lookup := AccountLookup{ID: a.ID, Code: a.Code, Name: a.Name, Postable: a.IsPostable()}
+	lookup.names = []string{strings.ToLower(a.Name)}
+	if a.NameEn != "" {
+		lookup.names = append(lookup.names, strings.ToLower(a.NameEn))
+	}
+	return lookup
+}
+
+// MatchAccountLookups returns up to limit accounts whose code or name starts
+// with the query, ignoring case: the code matches first, then the name
+// matches, each in the order of the accounts
+func MatchAccountLookups(accounts []AccountLookup, query string, limit int) []AccountLookup {
+	query = strings.ToLower(strings.TrimSpace(query))
+	matches := make([]AccountLookup, 0, limit)
+	var byName []AccountLookup
+	for _, a := range accounts {
+		if len(matches) == limit {
+			return matches
+		}
+		if strings.HasPrefix(strings.ToLower(a.Code), query) {
+			matches = append(matches, a)
+			continue
+		}
+		if len(byName) < limit && a.matchesName(query) {
+			byName = append(byName, a)
+		}
+	}
+	for _, a := range byName {
+		if len(matches) == limit {
+			break
+		}
+		matches = append(matches, a)
+	}
+	return matches
+}
+
+// matchesName returns true if the name or the English name starts with the query
+func (a *AccountLookup) matchesName(query string) bool {
+	for _, name := range a.names {
+		if strings.HasPrefix(name, query) {
+			return true
+		}
+	}
+	return false
+}
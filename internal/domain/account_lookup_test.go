@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestMatchAccountLookups(t *testing.T) {
+	lookup := func(code, name, nameEn string) domain.AccountLookup {
+		return domain.NewAccountLookup(&domain.Account{Code: code, Name: name, NameEn: nameEn, IsActive: true, AllowDirectPosting: true})
+	}
+	accounts := []domain.AccountLookup{
+		lookup("101000", "현금", "Cash"),
+		lookup("103000", "보통예금", "Ordinary deposits"),
+		lookup("813100", "복리후생비", "Employee benefits"),
+		lookup("813200", "보험료", "Insurance"),
+	}
+	codes := func(matches []domain.AccountLookup) []string {
+		result := make([]string, len(matches))
+		for i, m := range matches {
+			result[i] = m.Code
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"813100", "813200"}, codes(domain.MatchAccountLookups(accounts, "813", 20)))
+	assert.Equal(t, []string{"103000", "813200"}, codes(domain.MatchAccountLookups(accounts, "보", 20)))
+	assert.Equal(t, []string{"101000"}, codes(domain.MatchAccountLookups(accounts, " CASH", 20)))
+	assert.Equal(t, []string{"101000", "103000"}, codes(domain.MatchAccountLookups(accounts, "", 2)))
+	assert.True(t, accounts[0].Postable)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Posting rule errors
+var (
+	ErrPostingRulePartnerRequired    = errors.New("partner is required for this account")
+	ErrPostingRuleDepartmentRequired = errors.New("department is required for this account")
+	ErrPostingRuleProjectRequired    = errors.New("project is required for this account")
+	ErrPostingRuleCostCenterRequired = errors.New("cost center is required for this account")
+	ErrPostingRuleVoucherType        = errors.New("voucher type is not allowed for this account")
+	ErrPostingRuleAmountBelowMin     = errors.New("entry amount is below the account minimum")
+	ErrPostingRuleAmountAboveMax     = errors.New("entry amount exceeds the account maximum")
+	ErrPostingRuleInvalidRange       = errors.New("minimum amount cannot exceed maximum amount")
+)
+
+// PostingRules defines per-account constraints enforced when entries are posted
+// (e.g., accounts receivable always requires a partner)
+type PostingRules struct {
+	// Required dimensions
+	RequirePartner    bool `json:"require_partner,omitempty"`
+	RequireDepartment bool `json:"require_department,omitempty"`
+	RequireProject    bool `json:"require_project,omitempty"`
+	RequireCostCenter bool `json:"require_cost_center,omitempty"`
+
+	// Allowed voucher types (empty allows all)
+	AllowedVoucherTypes []VoucherType `json:"allowed_voucher_types,omitempty"`
+
+	// Entry amount limits
+	MinAmount *float64 `json:"min_amount,omitempty"`
+	MaxAmount *float64 `json:"max_amount,omitempty"`
+}
+
+// Validate validates the rule definition
+func (r *PostingRules) Validate() error {
+	for _, t := range r.AllowedVoucherTypes {
+		if !t.IsValid() {
+			return ErrInvalidVoucherType
+		}
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount {
+		return ErrPostingRuleInvalidRange
+	}
+	return nil
+}
+
+// IsEmpty returns true if no constraint is configured
+func (r *PostingRules) IsEmpty() bool {
+	return !r.RequirePartner && !r.RequireDepartment && !r.RequireProject && !r.RequireCostCenter &&
+		len(r.AllowedVoucherTypes) == 0 && r.MinAmount == nil && r.MaxAmount == nil
+}
+
+// AllowsVoucherType checks if the voucher type is allowed
+func (r *PostingRules) AllowsVoucherType(voucherType VoucherType) bool {
+	if len(r.AllowedVoucherTypes) == 0 {
+		return true
+	}
+	for _, t := range r.AllowedVoucherTypes {
+		if t == voucherType {
+			return true
+		}
+	}
+	return false
+}
+
+// Check validates an entry of the given voucher type against the rules
+func (r *PostingRules) Check(entry *VoucherEntry, voucherType VoucherType) error {
+	if r.RequirePartner && entry.PartnerID == nil {
+		return ErrPostingRulePartnerRequired
+	}
+	if r.RequireDepartment && entry.DepartmentID == nil {
+		return ErrPostingRuleDepartmentRequired
+	}
+	if r.RequireProject && entry.ProjectID == nil {
+		return ErrPostingRuleProjectRequired
+	}
+	if r.RequireCostCenter && entry.CostCenterID == nil {
+		return ErrPostingRuleCostCenterRequired
+	}
+	if voucherType != "" && !r.AllowsVoucherType(voucherType) {
+		return ErrPostingRuleVoucherType
+	}
+
+	amount := entry.GetAmount()
+	if r.MinAmount != nil && amount < *r.MinAmount {
+		return ErrPostingRuleAmountBelowMin
+	}
+	if r.MaxAmount != nil && amount > *r.MaxAmount {
+		return ErrPostingRuleAmountAboveMax
+	}
+	return nil
+}
+
+// PostingRuleViolation reports which entry broke an account posting rule
+type PostingRuleViolation struct {
+	LineNo      int
+	AccountCode string
+	Err         error
+}
+
+// Error implements the error interface
+func (v *PostingRuleViolation) Error() string {
+	return fmt.Sprintf("line %d (account %s): %s", v.LineNo, v.AccountCode, v.Err.Error())
+}
+
+// Unwrap returns the underlying rule error
+func (v *PostingRuleViolation) Unwrap() error {
+	return v.Err
+}
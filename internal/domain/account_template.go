@@ -0,0 +1,275 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrAccountTemplateNotFound is returned for an unknown industry template
+var ErrAccountTemplateNotFound = errors.New("account template not found")
+
+// Industry identifies a chart of accounts template
+type Industry string
+
+const (
+	IndustryGeneral       Industry = "general"       // 일반
+	IndustryManufacturing Industry = "manufacturing" // 제조업
+	IndustryRetail        Industry = "retail"        // 도소매업
+	IndustryConstruction  Industry = "construction"  // 건설업
+	IndustryService       Industry = "service"       // 서비스업
+)
+
+// AccountTemplateEntry is an account of a template. Its parent precedes it
+// in the template.
+type AccountTemplateEntry struct {
+	Code            string        `json:"code"`
+	ParentCode      string        `json:"parent_code,omitempty"`
+	Name            string        `json:"name"`
+	NameEn          string        `json:"name_en"`
+	AccountType     AccountType   `json:"account_type"`
+	AccountNature   AccountNature `json:"account_nature"`
+	AccountCategory string        `json:"account_category,omitempty"`
+	StatementLine   string        `json:"statement_line,omitempty"` // children without one inherit it
+	IsCashAccount   bool          `json:"is_cash_account"`
+}
+
+// ToAccount returns a new account of the company for the entry; the parent
+// is set by the caller
+func (e AccountTemplateEntry) ToAccount(companyID uuid.UUID) Account {
+	return Account{
+		TenantModel:        TenantModel{CompanyID: companyID},
+		Code:               e.Code,
+		Name:               e.Name,
+		NameEn:             e.NameEn,
+		AccountType:        e.AccountType,
+		AccountNature:      e.AccountNature,
+		AccountCategory:    e.AccountCategory,
+		StatementLine:      e.StatementLine,
+		IsActive:           true,
+		AllowDirectPosting: true,
+		IsCashAccount:      e.IsCashAccount,
+	}
+}
+
+// AccountTemplate is a chart of accounts for an industry, with each group
+// mapped to its financial statement line
+type AccountTemplate struct {
+	Industry    Industry               `json:"industry"`
+	Name        string                 `json:"name"`
+	NameEn      string                 `json:"name_en"`
+	Description string                 `json:"description"`
+	Accounts    []AccountTemplateEntry `json:"accounts"`
+}
+
+// templateAccount builds an entry on the normal side of its type
+func templateAccount(code, parent, name, nameEn string, t AccountType, category, line string) AccountTemplateEntry {
+	e := AccountTemplateEntry{
+		Code: code, ParentCode: parent, Name: name, NameEn: nameEn,
+		AccountType: t, AccountCategory: category, StatementLine: line,
+	}
+	a := Account{AccountType: t}
+	a.SetDefaults()
+	e.AccountNature = a.AccountNature
+	return e
+}
+
+// contraAccount builds an entry on the opposite side of its type, such as an
+// allowance or accumulated depreciation
+func contraAccount(code, parent, name, nameEn string, t AccountType, category string) AccountTemplateEntry {
+	e := templateAccount(code, parent, name, nameEn, t, category, "")
+	if e.AccountNature == AccountNatureDebit {
+		e.AccountNature = AccountNatureCredit
+	} else {
+		e.AccountNature = AccountNatureDebit
+	}
+	return e
+}
+
+func cashAccount(e AccountTemplateEntry) AccountTemplateEntry {
+	e.IsCashAccount = true
+	return e
+}
+
+// standardAccounts is the standard chart of accounts, the same as the
+// create_standard_accounts seed
+var standardAccounts = []AccountTemplateEntry{
+	templateAccount("1", "", "자산", "Assets", AccountTypeAsset, "", ""),
+	templateAccount("11", "1", "유동자산", "Current Assets", AccountTypeAsset, "current", ""),
+	templateAccount("1101", "11", "현금및현금성자산", "Cash and Cash Equivalents", AccountTypeAsset, "current", "cash_and_equivalents"),
+	cashAccount(templateAccount("110101", "1101", "현금", "Cash", AccountTypeAsset, "current", "")),
+	cashAccount(templateAccount("110102", "1101", "보통예금", "Checking Account", AccountTypeAsset, "current", "")),
+	templateAccount("110103", "1101", "정기예금", "Time Deposit", AccountTypeAsset, "current", ""),
+	templateAccount("1102", "11", "단기금융자산", "Short-term Financial Assets", AccountTypeAsset, "current", "short_term_investments"),
+	templateAccount("1103", "11", "매출채권", "Accounts Receivable", AccountTypeAsset, "current", "trade_receivables"),
+	templateAccount("110301", "1103", "외상매출금", "Trade Receivables", AccountTypeAsset, "current", ""),
+	templateAccount("110302", "1103", "받을어음", "Notes Receivable", AccountTypeAsset, "current", ""),
+	contraAccount("110303", "1103", "대손충당금", "Allowance for Bad Debts", AccountTypeAsset, "current"),
+	templateAccount("1104", "11", "기타채권", "Other Receivables", AccountTypeAsset, "current", "other_current_assets"),
+	templateAccount("110401", "1104", "미수금", "Accrued Receivables", AccountTypeAsset, "current", ""),
+	templateAccount("110402", "1104", "미수수익", "Accrued Revenue", AccountTypeAsset, "current", ""),
+	templateAccount("110403", "1104", "선급금", "Advance Payments", AccountTypeAsset, "current", ""),
+	templateAccount("110404", "1104", "선급비용", "Prepaid Expenses", AccountTypeAsset, "current", ""),
+	templateAccount("1105", "11", "재고자산", "Inventories", AccountTypeAsset, "current", "inventories"),
+	templateAccount("110501", "1105", "상품", "Merchandise", AccountTypeAsset, "current", ""),
+	templateAccount("110502", "1105", "제품", "Finished Goods", AccountTypeAsset, "current", ""),
+	templateAccount("110503", "1105", "원재료", "Raw Materials", AccountTypeAsset, "current", ""),
+	templateAccount("110504", "1105", "재공품", "Work in Process", AccountTypeAsset, "current", ""),
+	templateAccount("12", "1", "비유동자산", "Non-current Assets", AccountTypeAsset, "non_current", ""),
+	templateAccount("1201", "12", "장기금융자산", "Long-term Financial Assets", AccountTypeAsset, "non_current", "long_term_investments"),
+	templateAccount("1202", "12", "유형자산", "Property, Plant and Equipment", AccountTypeAsset, "non_current", "property_plant_equipment"),
+	templateAccount("120201", "1202", "토지", "Land", AccountTypeAsset, "non_current", ""),
+	templateAccount("120202", "1202", "건물", "Buildings", AccountTypeAsset, "non_current", ""),
+	contraAccount("120203", "1202", "건물감가상각누계액", "Accumulated Depreciation - Buildings", AccountTypeAsset, "non_current"),
+	templateAccount("120204", "1202", "기계장치", "Machinery", AccountTypeAsset, "non_current", ""),
+	contraAccount("120205", "1202", "기계장치감가상각누계액", "Accumulated Depreciation - Machinery", AccountTypeAsset, "non_current"),
+	templateAccount("120206", "1202", "차량운반구", "Vehicles", AccountTypeAsset, "non_current", ""),
+	contraAccount("120207", "1202", "차량운반구감가상각누계액", "Accumulated Depreciation - Vehicles", AccountTypeAsset, "non_current"),
+	templateAccount("120208", "1202", "비품", "Furniture and Fixtures", AccountTypeAsset, "non_current", ""),
+	contraAccount("120209", "1202", "비품감가상각누계액", "Accumulated Depreciation - F&F", AccountTypeAsset, "non_current"),
+	templateAccount("1203", "12", "무형자산", "Intangible Assets", AccountTypeAsset, "non_current", "intangible_assets"),
+	templateAccount("120301", "1203", "영업권", "Goodwill", AccountTypeAsset, "non_current", ""),
+	templateAccount("120302", "1203", "소프트웨어", "Software", AccountTypeAsset, "non_current", ""),
+	templateAccount("1204", "12", "기타비유동자산", "Other Non-current Assets", AccountTypeAsset, "non_current", "other_non_current_assets"),
+	templateAccount("120401", "1204", "보증금", "Deposits", AccountTypeAsset, "non_current", ""),
+
+	templateAccount("2", "", "부채", "Liabilities", AccountTypeLiability, "", ""),
+	templateAccount("21", "2", "유동부채", "Current Liabilities", AccountTypeLiability, "current", ""),
+	templateAccount("2101", "21", "매입채무", "Trade Payables", AccountTypeLiability, "current", "trade_payables"),
+	templateAccount("210101", "2101", "외상매입금", "Accounts Payable", AccountTypeLiability, "current", ""),
+	templateAccount("210102", "2101", "지급어음", "Notes Payable", AccountTypeLiability, "current", ""),
+	templateAccount("2102", "21", "단기차입금", "Short-term Borrowings", AccountTypeLiability, "current", "short_term_borrowings"),
+	templateAccount("2103", "21", "기타유동부채", "Other Current Liabilities", AccountTypeLiability, "current", "other_current_liabilities"),
+	templateAccount("210301", "2103", "미지급금", "Accrued Payables", AccountTypeLiability, "current", ""),
+	templateAccount("210302", "2103", "미지급비용", "Accrued Expenses", AccountTypeLiability, "current", ""),
+	templateAccount("210303", "2103", "선수금", "Advances Received", AccountTypeLiability, "current", ""),
+	templateAccount("210304", "2103", "예수금", "Withholdings", AccountTypeLiability, "current", ""),
+	templateAccount("210305", "2103", "부가세예수금", "VAT Payable", AccountTypeLiability, "current", ""),
+	templateAccount("210306", "2103", "미지급법인세", "Income Tax Payable", AccountTypeLiability, "current", "income_tax_payable"),
+	templateAccount("22", "2", "비유동부채", "Non-current Liabilities", AccountTypeLiability, "non_current", ""),
+	templateAccount("2201", "22", "장기차입금", "Long-term Borrowings", AccountTypeLiability, "non_current", "long_term_borrowings"),
+	templateAccount("2202", "22", "퇴직급여충당부채", "Retirement Benefit Obligations", AccountTypeLiability, "non_current", "retirement_benefit_obligations"),
+	templateAccount("2203", "22", "이연법인세부채", "Deferred Tax Liabilities", AccountTypeLiability, "non_current", "deferred_tax_liabilities"),
+
+	templateAccount("3", "", "자본", "Equity", AccountTypeEquity, "", ""),
+	templateAccount("31", "3", "자본금", "Share Capital", AccountTypeEquity, "", "share_capital"),
+	templateAccount("3101", "31", "보통주자본금", "Common Stock", AccountTypeEquity, "", ""),
+	templateAccount("32", "3", "자본잉여금", "Capital Surplus", AccountTypeEquity, "", "capital_surplus"),
+	templateAccount("3201", "32", "주식발행초과금", "Share Premium", AccountTypeEquity, "", ""),
+	templateAccount("33", "3", "이익잉여금", "Retained Earnings", AccountTypeEquity, "", "retained_earnings"),
+	templateAccount("3301", "33", "이익준비금", "Legal Reserve", AccountTypeEquity, "", ""),
+	templateAccount("3302", "33", "미처분이익잉여금", "Unappropriated Retained Earnings", AccountTypeEquity, "", ""),
+	templateAccount("3303", "33", "당기순이익", "Net Income", AccountTypeEquity, "", ""),
+
+	templateAccount("4", "", "수익", "Revenue", AccountTypeRevenue, "", ""),
+	templateAccount("41", "4", "매출", "Sales", AccountTypeRevenue, "operating", "revenue"),
+	templateAccount("4101", "41", "상품매출", "Merchandise Sales", AccountTypeRevenue, "operating", ""),
+	templateAccount("4102", "41", "제품매출", "Product Sales", AccountTypeRevenue, "operating", ""),
+	templateAccount("4103", "41", "용역매출", "Service Revenue", AccountTypeRevenue, "operating", ""),
+	templateAccount("42", "4", "영업외수익", "Non-operating Income", AccountTypeRevenue, "non_operating", "non_operating_income"),
+	templateAccount("4201", "42", "이자수익", "Interest Income", AccountTypeRevenue, "non_operating", ""),
+	templateAccount("4202", "42", "배당금수익", "Dividend Income", AccountTypeRevenue, "non_operating", ""),
+	templateAccount("4203", "42", "외환차익", "Foreign Exchange Gains", AccountTypeRevenue, "non_operating", ""),
+	templateAccount("4204", "42", "유형자산처분이익", "Gain on Disposal of PPE", AccountTypeRevenue, "non_operating", ""),
+	templateAccount("4205", "42", "잡이익", "Miscellaneous Income", AccountTypeRevenue, "non_operating", ""),
+
+	templateAccount("5", "", "비용", "Expenses", AccountTypeExpense, "", ""),
+	templateAccount("51", "5", "매출원가", "Cost of Sales", AccountTypeExpense, "cost", "cost_of_sales"),
+	templateAccount("5101", "51", "상품매출원가", "Cost of Merchandise Sold", AccountTypeExpense, "cost", ""),
+	templateAccount("5102", "51", "제품매출원가", "Cost of Goods Sold", AccountTypeExpense, "cost", ""),
+	templateAccount("52", "5", "판매비와관리비", "Selling and Administrative Expenses", AccountTypeExpense, "operating", "selling_admin_expenses"),
+	templateAccount("5201", "52", "급여", "Salaries", AccountTypeExpense, "operating", ""),
+	templateAccount("5202", "52", "퇴직급여", "Retirement Benefits", AccountTypeExpense, "operating", ""),
+	templateAccount("5203", "52", "복리후생비", "Employee Benefits", AccountTypeExpense, "operating", ""),
+	templateAccount("5204", "52", "여비교통비", "Travel and Transportation", AccountTypeExpense, "operating", ""),
+	templateAccount("5205", "52", "접대비", "Entertainment", AccountTypeExpense, "operating", ""),
+	templateAccount("5206", "52", "통신비", "Communication", AccountTypeExpense, "operating", ""),
+	templateAccount("5207", "52", "수도광열비", "Utilities", AccountTypeExpense, "operating", ""),
+	templateAccount("5208", "52", "세금과공과", "Taxes and Dues", AccountTypeExpense, "operating", ""),
+	templateAccount("5209", "52", "감가상각비", "Depreciation", AccountTypeExpense, "operating", ""),
+	templateAccount("5210", "52", "지급임차료", "Rent", AccountTypeExpense, "operating", ""),
+	templateAccount("5211", "52", "보험료", "Insurance", AccountTypeExpense, "operating", ""),
+	templateAccount("5212", "52", "차량유지비", "Vehicle Maintenance", AccountTypeExpense, "operating", ""),
+	templateAccount("5213", "52", "운반비", "Shipping", AccountTypeExpense, "operating", ""),
+	templateAccount("5214", "52", "소모품비", "Supplies", AccountTypeExpense, "operating", ""),
+	templateAccount("5215", "52", "도서인쇄비", "Books and Printing", AccountTypeExpense, "operating", ""),
+	templateAccount("5216", "52", "수선비", "Repairs", AccountTypeExpense, "operating", ""),
+	templateAccount("5217", "52", "광고선전비", "Advertising", AccountTypeExpense, "operating", ""),
+	templateAccount("5218", "52", "지급수수료", "Commissions", AccountTypeExpense, "operating", ""),
+	templateAccount("5219", "52", "대손상각비", "Bad Debt Expense", AccountTypeExpense, "operating", ""),
+	templateAccount("5220", "52", "무형자산상각비", "Amortization", AccountTypeExpense, "operating", ""),
+	templateAccount("5221", "52", "잡비", "Miscellaneous Expenses", AccountTypeExpense, "operating", ""),
+	templateAccount("53", "5", "영업외비용", "Non-operating Expenses", AccountTypeExpense, "non_operating", "non_operating_expenses"),
+	templateAccount("5301", "53", "이자비용", "Interest Expense", AccountTypeExpense, "non_operating", ""),
+	templateAccount("5302", "53", "외환차손", "Foreign Exchange Losses", AccountTypeExpense, "non_operating", ""),
+	templateAccount("5303", "53", "유형자산처분손실", "Loss on Disposal of PPE", AccountTypeExpense, "non_operating", ""),
+	templateAccount("5304", "53", "기부금", "Donations", AccountTypeExpense, "non_operating", ""),
+	templateAccount("5305", "53", "잡손실", "Miscellaneous Losses", AccountTypeExpense, "non_operating", ""),
+	templateAccount("54", "5", "법인세비용", "Income Tax Expense", AccountTypeExpense, "tax", "income_tax_expense"),
+	templateAccount("5401", "54", "법인세비용", "Corporate Income Tax", AccountTypeExpense, "tax", ""),
+}
+
+// industryAccounts are the accounts each industry adds to the standard chart
+var industryAccounts = map[Industry][]AccountTemplateEntry{
+	IndustryManufacturing: {
+		templateAccount("110505", "1105", "저장품", "Supplies Inventory", AccountTypeAsset, "current", ""),
+		templateAccount("120210", "1202", "건설중인자산", "Construction in Progress", AccountTypeAsset, "non_current", ""),
+		templateAccount("55", "5", "제조원가", "Manufacturing Costs", AccountTypeExpense, "cost", "cost_of_sales"),
+		templateAccount("5501", "55", "원재료비", "Direct Materials", AccountTypeExpense, "cost", ""),
+		templateAccount("5502", "55", "노무비", "Direct Labor", AccountTypeExpense, "cost", ""),
+		templateAccount("5503", "55", "제조경비", "Manufacturing Overhead", AccountTypeExpense, "cost", ""),
+		templateAccount("5504", "55", "외주가공비", "Subcontracted Processing", AccountTypeExpense, "cost", ""),
+	},
+	IndustryRetail: {
+		templateAccount("110506", "1105", "미착상품", "Goods in Transit", AccountTypeAsset, "current", ""),
+		contraAccount("4104", "41", "매출할인", "Sales Discounts", AccountTypeRevenue, "operating"),
+		contraAccount("4105", "41", "매출환입및에누리", "Sales Returns and Allowances", AccountTypeRevenue, "operating"),
+		contraAccount("5103", "51", "매입할인", "Purchase Discounts", AccountTypeExpense, "cost"),
+		templateAccount("5222", "52", "판매수수료", "Sales Commissions", AccountTypeExpense, "operating", ""),
+	},
+	IndustryConstruction: {
+		templateAccount("110405", "1104", "미청구공사", "Unbilled Construction Receivables", AccountTypeAsset, "current", ""),
+		templateAccount("110507", "1105", "미완성공사", "Construction Work in Progress", AccountTypeAsset, "current", ""),
+		templateAccount("210307", "2103", "초과청구공사", "Billings in Excess of Costs", AccountTypeLiability, "current", ""),
+		templateAccount("210308", "2103", "하자보수충당부채", "Warranty Provision", AccountTypeLiability, "current", ""),
+		templateAccount("4106", "41", "공사수익", "Construction Revenue", AccountTypeRevenue, "operating", ""),
+		templateAccount("5104", "51", "공사원가", "Construction Costs", AccountTypeExpense, "cost", ""),
+	},
+	IndustryService: {
+		templateAccount("120303", "1203", "개발비", "Development Costs", AccountTypeAsset, "non_current", ""),
+		templateAccount("5105", "51", "용역원가", "Cost of Services", AccountTypeExpense, "cost", ""),
+		templateAccount("5223", "52", "외주용역비", "Outsourced Services", AccountTypeExpense, "operating", ""),
+		templateAccount("5224", "52", "경상연구개발비", "Research and Development", AccountTypeExpense, "operating", ""),
+	},
+}
+
+// accountTemplates describes the templates in display order
+var accountTemplates = []AccountTemplate{
+	{Industry: IndustryGeneral, Name: "일반", NameEn: "General", Description: "Standard chart of accounts for most small and medium-sized companies"},
+	{Industry: IndustryManufacturing, Name: "제조업", NameEn: "Manufacturing", Description: "Adds manufacturing cost accounts, supplies and construction in progress"},
+	{Industry: IndustryRetail, Name: "도소매업", NameEn: "Wholesale and retail", Description: "Adds goods in transit, sales discounts and returns, and purchase discounts"},
+	{Industry: IndustryConstruction, Name: "건설업", NameEn: "Construction", Description: "Adds construction revenue and costs, unbilled receivables and excess billings"},
+	{Industry: IndustryService, Name: "서비스업", NameEn: "Services", Description: "Adds cost of services, outsourcing, research and development costs"},
+}
+
+// AccountTemplates returns the industry templates without their accounts
+func AccountTemplates() []AccountTemplate {
+	templates := make([]AccountTemplate, len(accountTemplates))
+	copy(templates, accountTemplates)
+	return templates
+}
+
+// FindAccountTemplate returns the template of the industry with its accounts:
+// the standard chart followed by the industry's additions, each after its parent
+func FindAccountTemplate(industry Industry) (*AccountTemplate, error) {
+	for _, t := range accountTemplates {
+		if t.Industry != industry {
+			continue
+		}
+		t.Accounts = make([]AccountTemplateEntry, 0, len(standardAccounts)+len(industryAccounts[industry]))
+		t.Accounts = append(t.Accounts, standardAccounts...)
+		t.Accounts = append(t.Accounts, industryAccounts[industry]...)
+		return &t, nil
+	}
+	return nil, ErrAccountTemplateNotFound
+}
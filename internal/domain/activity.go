@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Activity errors
+var (
+	ErrCommentRequired = errors.New("comment is required")
+	ErrCommentTooLong  = errors.New("comment must be at most 2000 characters")
+)
+
+// MaxCommentLength is the maximum length of a comment in characters
+const MaxCommentLength = 2000
+
+// Audit log actions and entity types
+const (
+	AuditActionUpdate  = "update"  // recorded by the voucher edit trigger
+	AuditActionComment = "comment" // the comment is the "comment" of the new values
+
+	AuditEntityVoucher = "voucher"
+)
+
+// AuditLog is an entry of the audit trail. Voucher edits are recorded by a
+// trigger with the changed columns; comments are written by the application.
+type AuditLog struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID  uuid.UUID       `gorm:"type:uuid;not null" json:"company_id"`
+	UserID     *uuid.UUID      `gorm:"type:uuid" json:"user_id,omitempty"`
+	Action     string          `gorm:"type:varchar(50);not null" json:"action"`
+	EntityType string          `gorm:"type:varchar(50);not null" json:"entity_type"`
+	EntityID   *uuid.UUID      `gorm:"type:uuid" json:"entity_id,omitempty"`
+	OldValues  json.RawMessage `gorm:"type:jsonb" json:"old_values,omitempty"`
+	NewValues  json.RawMessage `gorm:"type:jsonb" json:"new_values,omitempty"`
+	IPAddress  *string         `gorm:"type:inet" json:"ip_address,omitempty"`
+	UserAgent  string          `gorm:"type:varchar(500)" json:"user_agent,omitempty"`
+	CreatedAt  time.Time       `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// NewVoucherComment builds the audit log entry of a comment on a voucher
+func NewVoucherComment(companyID, voucherID, userID uuid.UUID, comment string) (*AuditLog, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, ErrCommentRequired
+	}
+	if utf8.RuneCountInString(comment) > MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+	values, err := json.Marshal(map[string]string{"comment": comment})
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{
+		CompanyID:  companyID,
+		UserID:     &userID,
+		Action:     AuditActionComment,
+		EntityType: AuditEntityVoucher,
+		EntityID:   &voucherID,
+		NewValues:  values,
+	}, nil
+}
+
+// ActivityKind identifies an item of an activity feed
+type ActivityKind string
+
+const (
+	ActivityStatusChange ActivityKind = "status_change" // from the voucher status history
+	ActivityComment      ActivityKind = "comment"       // from the audit log
+	ActivityAttachment   ActivityKind = "attachment"    // an uploaded voucher attachment
+	ActivityEdit         ActivityKind = "edit"          // from the audit log
+)
+
+// IsValid checks if the kind is valid
+func (k ActivityKind) IsValid() bool {
+	switch k {
+	case ActivityStatusChange, ActivityComment, ActivityAttachment, ActivityEdit:
+		return true
+	}
+	return false
+}
+
+// LocalizedLabel returns the kind label in the locale
+func (k ActivityKind) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "activity_kind", string(k)); label != "" {
+		return label
+	}
+	return string(k)
+}
+
+// ActivityChange is a field changed by an edit, with its JSON values
+type ActivityChange struct {
+	Field    string          `json:"field"`
+	OldValue json.RawMessage `json:"old_value"`
+	NewValue json.RawMessage `json:"new_value"`
+}
+
+// Activity is an item of an activity feed, newest first
+type Activity struct {
+	Kind        ActivityKind `json:"kind"`
+	EntityType  string       `json:"entity_type"`
+	EntityID    uuid.UUID    `json:"entity_id"`
+	EntityLabel string       `json:"entity_label,omitempty"` // voucher number
+	UserID      *uuid.UUID   `json:"user_id,omitempty"`
+	UserName    string       `json:"user_name,omitempty"`
+
+	// Status changes only
+	FromStatus VoucherStatus `json:"from_status,omitempty"`
+	ToStatus   VoucherStatus `json:"to_status,omitempty"`
+
+	// Comment, rejection reason or attachment file name
+	Message string `json:"message,omitempty"`
+
+	// Edits only
+	Changes []ActivityChange `json:"changes,omitempty"`
+
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ActivityChanges lists the fields of an edit's new values by name, with the
+// old values alongside. Values that cannot be decoded yield no changes.
+func ActivityChanges(oldValues, newValues json.RawMessage) []ActivityChange {
+	var before, after map[string]json.RawMessage
+	if len(newValues) == 0 || json.Unmarshal(newValues, &after) != nil {
+		return nil
+	}
+	if len(oldValues) > 0 {
+		_ = json.Unmarshal(oldValues, &before)
+	}
+
+	changes := make([]ActivityChange, 0, len(after))
+	for field, value := range after {
+		old := before[field]
+		if old == nil {
+			old = json.RawMessage("null")
+		}
+		changes = append(changes, ActivityChange{Field: field, OldValue: old, NewValue: value})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
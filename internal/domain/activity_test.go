@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Activity Tests
+// ============================================================================
+
+func TestNewVoucherComment(t *testing.T) {
+	companyID, voucherID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	log, err := domain.NewVoucherComment(companyID, voucherID, userID, "  증빙 확인 부탁드립니다 ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AuditActionComment, log.Action)
+	assert.Equal(t, domain.AuditEntityVoucher, log.EntityType)
+	assert.Equal(t, voucherID, *log.EntityID)
+	assert.JSONEq(t, `{"comment": "증빙 확인 부탁드립니다"}`, string(log.NewValues))
+
+	_, err = domain.NewVoucherComment(companyID, voucherID, userID, "   ")
+	assert.ErrorIs(t, err, domain.ErrCommentRequired)
+
+	_, err = domain.NewVoucherComment(companyID, voucherID, userID, strings.Repeat("가", domain.MaxCommentLength+1))
+	assert.ErrorIs(t, err, domain.ErrCommentTooLong)
+}
+
+func TestActivityChanges(t *testing.T) {
+	changes := domain.ActivityChanges(
+		json.RawMessage(`{"description": "old", "total_debit": 100}`),
+		json.RawMessage(`{"total_debit": 200, "description": "new", "tags": ["audit"]}`),
+	)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "description", changes[0].Field)
+	assert.JSONEq(t, `"old"`, string(changes[0].OldValue))
+	assert.Equal(t, "tags", changes[1].Field)
+	assert.JSONEq(t, `null`, string(changes[1].OldValue))
+	assert.Equal(t, "total_debit", changes[2].Field)
+	assert.JSONEq(t, `200`, string(changes[2].NewValue))
+
+	assert.Nil(t, domain.ActivityChanges(nil, json.RawMessage(`not json`)))
+}
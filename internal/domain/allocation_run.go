@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Allocation run errors
+var (
+	ErrAllocationRunNotFound = errors.New("allocation run not found")
+	ErrAllocationRunExists   = errors.New("allocation has already been run for the period")
+	ErrAllocationRunReversed = errors.New("allocation run is already reversed")
+	ErrNothingToAllocate     = errors.New("no posted amounts to allocate for the period")
+)
+
+// AllocationRunStatus represents the status of an allocation run
+type AllocationRunStatus string
+
+const (
+	AllocationRunStatusGenerated AllocationRunStatus = "generated"
+	AllocationRunStatusReversed  AllocationRunStatus = "reversed"
+)
+
+// IsValid checks if the status is valid
+func (s AllocationRunStatus) IsValid() bool {
+	return s == AllocationRunStatusGenerated || s == AllocationRunStatusReversed
+}
+
+// AllocationShare is the part of an allocated amount given to one target
+type AllocationShare struct {
+	DepartmentID uuid.UUID  `json:"department_id"`
+	ProjectID    *uuid.UUID `json:"project_id,omitempty"`
+	Ratio        float64    `json:"ratio"` // percent actually applied, after the basis is resolved
+	Amount       float64    `json:"amount"`
+}
+
+// AllocationLine is the amount of one account of the source department moved
+// to the targets of a rule. Amounts have the natural sign of the account.
+type AllocationLine struct {
+	RuleID             uuid.UUID         `json:"rule_id"`
+	RuleName           string            `json:"rule_name"`
+	AccountID          uuid.UUID         `json:"account_id"`
+	AccountCode        string            `json:"account_code"`
+	AccountName        string            `json:"account_name"`
+	AccountType        AccountType       `json:"account_type"`
+	SourceDepartmentID *uuid.UUID        `json:"source_department_id,omitempty"` // nil for entries without a department
+	SourceProjectID    *uuid.UUID        `json:"source_project_id,omitempty"`
+	Amount             float64           `json:"amount"`
+	Shares             []AllocationShare `json:"shares"`
+}
+
+// debitSide returns true if the targets of the line are debited: expenses are
+// moved by crediting the source, revenue by debiting it
+func (l *AllocationLine) debitSide() bool {
+	return (l.AccountType == AccountTypeRevenue) == (l.Amount < 0)
+}
+
+// AllocationRun is a period-end allocation (배부) of the active rules, booked
+// as one adjustment voucher on the last day of the period
+type AllocationRun struct {
+	TenantModel
+
+	PeriodStart time.Time           `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd   time.Time           `gorm:"type:date;not null" json:"period_end"`
+	Status      AllocationRunStatus `gorm:"type:varchar(20);not null;default:generated" json:"status"`
+
+	Lines       []AllocationLine `gorm:"type:jsonb;serializer:json" json:"lines"`
+	TotalAmount float64          `gorm:"type:decimal(18,2);not null;default:0" json:"total_amount"` // sum of the absolute amounts moved
+
+	VoucherID         *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	ReversalVoucherID *uuid.UUID `gorm:"type:uuid" json:"reversal_voucher_id,omitempty"` // nil when the draft voucher was cancelled
+
+	CreatedBy  *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	ReversedBy *uuid.UUID `gorm:"type:uuid" json:"reversed_by,omitempty"`
+	ReversedAt *time.Time `json:"reversed_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (AllocationRun) TableName() string {
+	return "allocation_runs"
+}
+
+// NewAllocationRun builds the allocation of the rules over the posted totals
+// of the period
+func NewAllocationRun(companyID uuid.UUID, periodStart, periodEnd time.Time, totals []DepartmentAccountTotal, rules []DepartmentAllocationRule) *AllocationRun {
+	run := &AllocationRun{
+		TenantModel: TenantModel{CompanyID: companyID},
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Status:      AllocationRunStatusGenerated,
+		Lines:       BuildAllocationLines(totals, rules),
+	}
+	if run.Lines == nil {
+		run.Lines = []AllocationLine{}
+	}
+	for _, l := range run.Lines {
+		if l.Amount < 0 {
+			run.TotalAmount -= l.Amount
+		} else {
+			run.TotalAmount += l.Amount
+		}
+	}
+	run.TotalAmount = roundAmount(run.TotalAmount)
+	return run
+}
+
+// VoucherEntries returns the entries of the allocation voucher: for each line
+// the source department is relieved of the amount and each target takes its
+// share, on the same account
+func (r *AllocationRun) VoucherEntries() []VoucherEntry {
+	var entries []VoucherEntry
+	for _, l := range r.Lines {
+		amount := l.Amount
+		if amount < 0 {
+			amount = -amount
+		}
+		targetsDebited := l.debitSide()
+
+		source := VoucherEntry{
+			CompanyID:    r.CompanyID,
+			AccountID:    l.AccountID,
+			Description:  "배부: " + l.RuleName,
+			DepartmentID: l.SourceDepartmentID,
+			ProjectID:    l.SourceProjectID,
+		}
+		if targetsDebited {
+			source.SetCredit(amount)
+		} else {
+			source.SetDebit(amount)
+		}
+		entries = append(entries, source)
+
+		for _, share := range l.Shares {
+			shareAmount := share.Amount
+			if shareAmount < 0 {
+				shareAmount = -shareAmount
+			}
+			if shareAmount == 0 {
+				continue
+			}
+			departmentID := share.DepartmentID
+			target := VoucherEntry{
+				CompanyID:    r.CompanyID,
+				AccountID:    l.AccountID,
+				Description:  "배부: " + l.RuleName,
+				DepartmentID: &departmentID,
+				ProjectID:    share.ProjectID,
+			}
+			if targetsDebited {
+				target.SetDebit(shareAmount)
+			} else {
+				target.SetCredit(shareAmount)
+			}
+			entries = append(entries, target)
+		}
+	}
+	return entries
+}
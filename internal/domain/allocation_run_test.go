@@ -0,0 +1,75 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestNewAllocationRun(t *testing.T) {
+	salesID, serviceID, projectID := uuid.New(), uuid.New(), uuid.New()
+	revenue, rent := uuid.New(), uuid.New()
+	totals := []domain.DepartmentAccountTotal{
+		{DepartmentID: &salesID, AccountID: revenue, AccountCode: "401", AccountType: domain.AccountTypeRevenue, Credit: 3000},
+		{DepartmentID: &serviceID, AccountID: revenue, AccountCode: "401", AccountType: domain.AccountTypeRevenue, Credit: 1000},
+		{AccountID: rent, AccountCode: "819", AccountType: domain.AccountTypeExpense, Debit: 1000},
+	}
+	rules := []domain.DepartmentAllocationRule{{
+		Name:     "Shared rent",
+		Basis:    domain.AllocationBasisRevenue,
+		IsActive: true,
+		Targets:  []domain.AllocationTarget{{DepartmentID: salesID, ProjectID: &projectID}, {DepartmentID: serviceID}},
+	}}
+
+	periodStart := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	run := domain.NewAllocationRun(uuid.New(), periodStart, periodStart.AddDate(0, 1, -1), totals, rules)
+	require.Len(t, run.Lines, 1)
+	line := run.Lines[0]
+	assert.Nil(t, line.SourceDepartmentID)
+	assert.Equal(t, 1000.0, line.Amount)
+	require.Len(t, line.Shares, 2)
+	assert.Equal(t, 75.0, line.Shares[0].Ratio)
+	assert.Equal(t, 750.0, line.Shares[0].Amount)
+	assert.Equal(t, 250.0, line.Shares[1].Amount)
+	assert.Equal(t, 1000.0, run.TotalAmount)
+
+	// The unassigned rent is credited and debited to the targets on the same account
+	entries := run.VoucherEntries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, rent, entries[0].AccountID)
+	assert.Nil(t, entries[0].DepartmentID)
+	assert.Equal(t, 1000.0, entries[0].CreditAmount)
+	assert.Equal(t, &salesID, entries[1].DepartmentID)
+	assert.Equal(t, &projectID, entries[1].ProjectID)
+	assert.Equal(t, 750.0, entries[1].DebitAmount)
+	assert.Equal(t, &serviceID, entries[2].DepartmentID)
+	assert.Equal(t, 250.0, entries[2].DebitAmount)
+}
+
+func TestAllocationRunVoucherEntriesRevenue(t *testing.T) {
+	salesID, serviceID := uuid.New(), uuid.New()
+	revenue := uuid.New()
+	totals := []domain.DepartmentAccountTotal{
+		{DepartmentID: &salesID, AccountID: revenue, AccountCode: "401", AccountType: domain.AccountTypeRevenue, Credit: 500},
+	}
+	rules := []domain.DepartmentAllocationRule{{
+		Name:               "Shared contract",
+		SourceDepartmentID: &salesID,
+		AccountFrom:        "401",
+		AccountTo:          "401",
+		IsActive:           true,
+		Targets:            []domain.AllocationTarget{{DepartmentID: serviceID, Ratio: 100}},
+	}}
+
+	run := domain.NewAllocationRun(uuid.New(), time.Time{}, time.Time{}, totals, rules)
+	entries := run.VoucherEntries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, 500.0, entries[0].DebitAmount, "revenue is taken from the source by a debit")
+	assert.Equal(t, 500.0, entries[1].CreditAmount)
+	assert.Equal(t, &serviceID, entries[1].DepartmentID)
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AP matching errors
+var (
+	ErrTaxInvoiceNotMatchable   = errors.New("only received purchase tax invoices can be matched")
+	ErrTaxInvoiceAlreadyMatched = errors.New("tax invoice is already matched to a voucher")
+	ErrTaxInvoiceNotMatched     = errors.New("tax invoice is not matched to a voucher")
+	ErrVoucherNotMatchable      = errors.New("voucher cannot be matched to a purchase tax invoice")
+	ErrVoucherAlreadyReferenced = errors.New("voucher is already linked to another document")
+	ErrInvalidAPMatchTolerance  = errors.New("invalid matching tolerance")
+)
+
+// Tolerance limits
+const (
+	MaxAPMatchDateDays = 60
+	MaxAPMatchAmount   = 100000
+)
+
+// APMatchTolerance is how far a voucher may be from a purchase tax invoice and
+// still match it
+type APMatchTolerance struct {
+	DateDays int   `json:"date_days"` // voucher date within this many days of the issue date
+	Amount   int64 `json:"amount"`    // voucher total within this many won of the invoice total
+}
+
+// DefaultAPMatchTolerance returns the tolerance used when none is given: vouchers
+// booked within a week, allowing for VAT rounding differences
+func DefaultAPMatchTolerance() APMatchTolerance {
+	return APMatchTolerance{DateDays: 7, Amount: 10}
+}
+
+// Validate checks the tolerance limits
+func (t APMatchTolerance) Validate() error {
+	if t.DateDays < 0 || t.DateDays > MaxAPMatchDateDays {
+		return ErrInvalidAPMatchTolerance
+	}
+	if t.Amount < 0 || t.Amount > MaxAPMatchAmount {
+		return ErrInvalidAPMatchTolerance
+	}
+	return nil
+}
+
+// APMatch is a purchase tax invoice linked to the voucher booking it
+type APMatch struct {
+	TaxInvoiceID     uuid.UUID `json:"tax_invoice_id"`
+	InvoiceNumber    string    `json:"invoice_number"`
+	VoucherID        uuid.UUID `json:"voucher_id"`
+	VoucherNo        string    `json:"voucher_no"`
+	AmountDifference int64     `json:"amount_difference"` // voucher total minus invoice total
+	DateDifference   int       `json:"date_difference"`   // days from the issue date to the voucher date
+}
+
+// NewAPMatch describes the match of the invoice with the voucher
+func NewAPMatch(invoice *TaxInvoice, voucher *Voucher) APMatch {
+	return APMatch{
+		TaxInvoiceID:     invoice.ID,
+		InvoiceNumber:    invoice.InvoiceNumber,
+		VoucherID:        voucher.ID,
+		VoucherNo:        voucher.VoucherNo,
+		AmountDifference: int64(math.Round(voucher.TotalDebit)) - invoice.TotalAmount,
+		DateDifference:   daysBetween(invoice.IssueDate, voucher.VoucherDate),
+	}
+}
+
+// Within returns true if the differences of the match are within the tolerance
+func (m APMatch) Within(t APMatchTolerance) bool {
+	return absInt64(m.AmountDifference) <= t.Amount && absInt64(int64(m.DateDifference)) <= int64(t.DateDays)
+}
+
+// closerThan returns true if the match is closer than other, by amount first
+func (m APMatch) closerThan(other APMatch) bool {
+	if a, b := absInt64(m.AmountDifference), absInt64(other.AmountDifference); a != b {
+		return a < b
+	}
+	return absInt64(int64(m.DateDifference)) < absInt64(int64(other.DateDifference))
+}
+
+// BestAPMatch returns the closest of the candidate matches within the
+// tolerance. Two equally close candidates are ambiguous and leave the invoice
+// for a clerk; ok is false then and when no candidate is close enough.
+func BestAPMatch(candidates []APMatch, t APMatchTolerance) (best APMatch, ok bool) {
+	ambiguous := false
+	for _, m := range candidates {
+		if !m.Within(t) {
+			continue
+		}
+		switch {
+		case !ok || m.closerThan(best):
+			best, ok, ambiguous = m, true, false
+		case !best.closerThan(m):
+			ambiguous = true
+		}
+	}
+	if ambiguous {
+		return APMatch{}, false
+	}
+	return best, ok
+}
+
+// APMatchRun summarizes an automatic matching run
+type APMatchRun struct {
+	Checked   int       `json:"checked"`   // unmatched invoices considered
+	Matched   []APMatch `json:"matched"`   // invoices matched by the run
+	Ambiguous int       `json:"ambiguous"` // invoices with several equally close vouchers
+}
+
+// APWorklist is what is left to match by hand in a period
+type APWorklist struct {
+	Invoices []*TaxInvoice `json:"invoices"` // purchase invoices without voucher
+	Vouchers []Voucher     `json:"vouchers"` // purchase vouchers without reference document
+}
+
+// CanBeMatched returns true if the invoice is a received purchase invoice
+// which can be linked to a voucher
+func (t *TaxInvoice) CanBeMatched() bool {
+	if t.InvoiceType != TaxInvoiceTypePurchase {
+		return false
+	}
+	return t.Status != TaxInvoiceStatusCancelled && t.Status != TaxInvoiceStatusRejected
+}
+
+// CanBeMatched returns true if the voucher can book a purchase invoice
+func (v *Voucher) CanBeMatched() bool {
+	return v.VoucherType == VoucherTypePurchase &&
+		v.Status != VoucherStatusCancelled && v.Status != VoucherStatusRejected
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+// absInt64 returns the absolute value of v
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
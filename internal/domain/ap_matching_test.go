@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestBestAPMatch(t *testing.T) {
+	tolerance := domain.DefaultAPMatchTolerance()
+	exact := domain.APMatch{VoucherID: uuid.New(), AmountDifference: 0, DateDifference: 3}
+	rounded := domain.APMatch{VoucherID: uuid.New(), AmountDifference: -1, DateDifference: 0}
+	late := domain.APMatch{VoucherID: uuid.New(), AmountDifference: 0, DateDifference: 10}
+
+	best, ok := domain.BestAPMatch([]domain.APMatch{rounded, late, exact}, tolerance)
+	assert.True(t, ok)
+	assert.Equal(t, exact.VoucherID, best.VoucherID, "the amount decides before the date")
+
+	_, ok = domain.BestAPMatch([]domain.APMatch{late}, tolerance)
+	assert.False(t, ok, "outside the date tolerance")
+
+	twin := exact
+	twin.VoucherID = uuid.New()
+	_, ok = domain.BestAPMatch([]domain.APMatch{exact, rounded, twin}, tolerance)
+	assert.False(t, ok, "two equally close vouchers are ambiguous")
+
+	assert.ErrorIs(t, domain.APMatchTolerance{DateDays: -1}.Validate(), domain.ErrInvalidAPMatchTolerance)
+}
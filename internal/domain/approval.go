@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrApprovalPINRequired is returned by the one-tap approval actions, which are
+// confirmed by the signing PIN of the approver
+var ErrApprovalPINRequired = errors.New("PIN confirmation is required")
+
+// ApprovalItemType is the kind of document waiting in an approval inbox
+type ApprovalItemType string
+
+const (
+	ApprovalItemVoucher ApprovalItemType = "voucher" // 전표 결재
+)
+
+// ApprovalItem summarizes a document waiting for the approval of a user, for
+// the compact inbox of the mobile app
+type ApprovalItem struct {
+	Type          ApprovalItemType
+	ID            uuid.UUID
+	Number        string
+	Date          time.Time
+	Title         string
+	Amount        float64
+	RequestedBy   *uuid.UUID
+	RequesterName string
+	RequestedAt   *time.Time
+}
+
+// NewVoucherApprovalItem summarizes a voucher pending approval; the requester
+// is its submitter
+func NewVoucherApprovalItem(v *Voucher) ApprovalItem {
+	requestedBy := v.SubmittedBy
+	if requestedBy == nil {
+		requestedBy = v.CreatedBy
+	}
+	return ApprovalItem{
+		Type:        ApprovalItemVoucher,
+		ID:          v.ID,
+		Number:      v.VoucherNo,
+		Date:        v.VoucherDate,
+		Title:       v.Description,
+		Amount:      v.TotalDebit,
+		RequestedBy: requestedBy,
+		RequestedAt: v.SubmittedAt,
+	}
+}
+
+// CanApprove returns true if the user may approve documents of the company;
+// viewers have read-only access
+func (u *User) CanApprove() bool {
+	return u.IsActive() && u.Role != UserRoleViewer
+}
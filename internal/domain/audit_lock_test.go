@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// AuditLock Tests
+// ============================================================================
+
+func TestAuditLock_Unlock(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	operator := uuid.New()
+
+	lock := domain.NewAuditLock(uuid.New(), 2025, "  감사보고서 2026-031 ", uuid.New(), now)
+	assert.Equal(t, "감사보고서 2026-031", lock.Reference)
+	assert.True(t, lock.IsActive())
+
+	assert.ErrorIs(t, lock.Unlock(operator, "  ", now), domain.ErrAuditUnlockReason)
+	assert.True(t, lock.IsActive())
+
+	require.NoError(t, lock.Unlock(operator, "수정 감사 의견에 따른 재작성", now.Add(time.Hour)))
+	assert.False(t, lock.IsActive())
+	assert.Equal(t, operator, *lock.UnlockedBy)
+	assert.Equal(t, "수정 감사 의견에 따른 재작성", lock.UnlockReason)
+	assert.ErrorIs(t, lock.Unlock(operator, "again", now), domain.ErrAuditLockAlreadyUnlocked)
+}
+
+func TestAuditLock_AuditLog(t *testing.T) {
+	operator := uuid.New()
+	lock := domain.NewAuditLock(uuid.New(), 2025, "", uuid.New(), time.Now())
+	lock.ID = uuid.New()
+	before := *lock
+	require.NoError(t, lock.Unlock(operator, "restatement", time.Now()))
+
+	log, err := lock.AuditLog(domain.AuditActionAuditUnlock, &operator, &before)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AuditEntityAuditLock, log.EntityType)
+	assert.Equal(t, lock.ID, *log.EntityID)
+	assert.Equal(t, lock.CompanyID, log.CompanyID)
+
+	var old, values map[string]interface{}
+	require.NoError(t, json.Unmarshal(log.OldValues, &old))
+	require.NoError(t, json.Unmarshal(log.NewValues, &values))
+	assert.Nil(t, old["unlocked_at"])
+	assert.Equal(t, "restatement", values["unlock_reason"])
+	assert.Equal(t, operator.String(), values["unlocked_by"])
+
+	log, err = lock.AuditLog(domain.AuditActionAuditLock, &lock.LockedBy, nil)
+	require.NoError(t, err)
+	assert.Nil(t, log.OldValues)
+}
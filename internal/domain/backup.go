@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tenant backup errors
+var (
+	ErrBackupNotFound              = errors.New("backup not found")
+	ErrBackupRestoreNotFound       = errors.New("backup restore not found")
+	ErrBackupInProgress            = errors.New("a backup of the company is already in progress")
+	ErrBackupNotRestorable         = errors.New("backup is not completed or has expired")
+	ErrBackupRestoreReasonRequired = errors.New("a reason is required to restore a backup")
+	ErrBackupRestoreReasonTooLong  = errors.New("restore reason must be at most 500 characters")
+	ErrBackupsDisabled             = errors.New("backups are not configured")
+	ErrBackupRestoreDisabled       = errors.New("restores into staging are not configured")
+	ErrBackupChecksumMismatch      = errors.New("backup does not match its checksum")
+)
+
+// maxBackupRestoreReason is the length limit of the reason recorded for a restore
+const maxBackupRestoreReason = 500
+
+// BackupStatus represents the state of a backup or of a restore
+type BackupStatus string
+
+const (
+	BackupStatusPending    BackupStatus = "pending"
+	BackupStatusProcessing BackupStatus = "processing"
+	BackupStatusCompleted  BackupStatus = "completed"
+	BackupStatusFailed     BackupStatus = "failed"
+)
+
+// BackupTrigger tells why a backup was taken
+type BackupTrigger string
+
+const (
+	BackupTriggerScheduled BackupTrigger = "scheduled" // nightly run of the backup schedule
+	BackupTriggerManual    BackupTrigger = "manual"    // requested by support
+)
+
+// BackupRetention is how long restore points are kept. The first backup of
+// each month is kept for MonthlyMonths, every other backup for Days.
+type BackupRetention struct {
+	Days          int
+	MonthlyMonths int
+}
+
+// ExpiresAt returns when a backup completed at the given time is deleted
+func (p BackupRetention) ExpiresAt(completedAt time.Time, monthly bool) time.Time {
+	expiresAt := completedAt.AddDate(0, 0, p.Days)
+	if monthly {
+		if kept := completedAt.AddDate(0, p.MonthlyMonths, 0); kept.After(expiresAt) {
+			return kept
+		}
+	}
+	return expiresAt
+}
+
+// TenantBackup is a restore point of a company's data: the data export
+// archive (JSON), envelope encrypted and stored in object storage. The
+// wrapped data key is kept here, never next to the object.
+type TenantBackup struct {
+	TenantModel
+
+	Trigger      BackupTrigger `gorm:"type:varchar(20);not null" json:"trigger"`
+	Status       BackupStatus  `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	ScheduledFor *time.Time    `json:"scheduled_for,omitempty"` // run of the schedule, for scheduled backups
+
+	// Output: the encrypted archive in object storage
+	StorageKey   string         `gorm:"type:varchar(300)" json:"storage_key,omitempty"`
+	Size         int64          `gorm:"not null;default:0" json:"size"`
+	Checksum     string         `gorm:"type:varchar(64)" json:"checksum,omitempty"` // hex SHA-256 of the stored object
+	KeyID        string         `gorm:"type:varchar(100)" json:"-"`
+	EncryptedKey []byte         `gorm:"type:bytea" json:"-"`
+	RowCounts    map[string]int `gorm:"type:jsonb;serializer:json" json:"row_counts,omitempty"`
+	Monthly      bool           `gorm:"not null;default:false" json:"monthly"` // kept for the monthly retention
+	Error        string         `gorm:"type:text" json:"error,omitempty"`
+
+	RequestedBy *uuid.UUID `gorm:"type:uuid" json:"requested_by,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // the object and the row are deleted after this time
+}
+
+// TableName specifies the table name for GORM
+func (TenantBackup) TableName() string {
+	return "tenant_backups"
+}
+
+// NewManualBackup creates a pending backup requested by support
+func NewManualBackup(companyID, requestedBy uuid.UUID) *TenantBackup {
+	return &TenantBackup{
+		TenantModel: TenantModel{CompanyID: companyID},
+		Trigger:     BackupTriggerManual,
+		Status:      BackupStatusPending,
+		RequestedBy: &requestedBy,
+	}
+}
+
+// StorageKeyFor returns the object key of the backup under prefix
+func (b *TenantBackup) StorageKeyFor(prefix string) string {
+	key := fmt.Sprintf("%s/%s.zip.enc", b.CompanyID, b.ID)
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+	return key
+}
+
+// Complete records the stored object and when it expires
+func (b *TenantBackup) Complete(storageKey, checksum string, size int64, keyID string, encryptedKey []byte, rowCounts map[string]int, monthly bool, retention BackupRetention, at time.Time) {
+	expiresAt := retention.ExpiresAt(at, monthly)
+	b.Status = BackupStatusCompleted
+	b.StorageKey = storageKey
+	b.Checksum = checksum
+	b.Size = size
+	b.KeyID = keyID
+	b.EncryptedKey = encryptedKey
+	b.RowCounts = rowCounts
+	b.Monthly = monthly
+	b.Error = ""
+	b.CompletedAt = &at
+	b.ExpiresAt = &expiresAt
+}
+
+// Fail records the reason the backup could not be taken; the failure is kept
+// for the daily retention so that support can see it
+func (b *TenantBackup) Fail(err error, retention BackupRetention, at time.Time) {
+	expiresAt := retention.ExpiresAt(at, false)
+	b.Status = BackupStatusFailed
+	b.Error = err.Error()
+	b.CompletedAt = &at
+	b.ExpiresAt = &expiresAt
+}
+
+// IsRestorable returns true if the backup can be restored at the given time
+func (b *TenantBackup) IsRestorable(at time.Time) bool {
+	return b.Status == BackupStatusCompleted && b.ExpiresAt != nil && at.Before(*b.ExpiresAt)
+}
+
+// EncryptionContext binds the ciphertext of the backup to its company and ID
+func (b *TenantBackup) EncryptionContext() []byte {
+	return []byte("tenant_backup:" + b.CompanyID.String() + ":" + b.ID.String())
+}
+
+// BackupRestore is the restore of a backup into the staging database, where
+// support inspects the data of a point in time. Restores never write to the
+// production tables; each one gets a schema of its own.
+type BackupRestore struct {
+	TenantModel
+
+	BackupID     uuid.UUID      `gorm:"type:uuid;not null" json:"backup_id"`
+	Status       BackupStatus   `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Reason       string         `gorm:"type:text;not null" json:"reason"`
+	TargetSchema string         `gorm:"type:varchar(63)" json:"target_schema,omitempty"`
+	RowCounts    map[string]int `gorm:"type:jsonb;serializer:json" json:"row_counts,omitempty"`
+	Error        string         `gorm:"type:text" json:"error,omitempty"`
+
+	RequestedBy uuid.UUID  `gorm:"type:uuid;not null" json:"requested_by"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (BackupRestore) TableName() string {
+	return "backup_restores"
+}
+
+// NewBackupRestore creates a pending restore of a backup. The reason, such as
+// the support ticket, is recorded for the audit of access to tenant data.
+func NewBackupRestore(backup *TenantBackup, reason string, requestedBy uuid.UUID, now time.Time) (*BackupRestore, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrBackupRestoreReasonRequired
+	}
+	if len([]rune(reason)) > maxBackupRestoreReason {
+		return nil, ErrBackupRestoreReasonTooLong
+	}
+	if !backup.IsRestorable(now) {
+		return nil, ErrBackupNotRestorable
+	}
+	return &BackupRestore{
+		TenantModel: TenantModel{CompanyID: backup.CompanyID},
+		BackupID:    backup.ID,
+		Status:      BackupStatusPending,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+	}, nil
+}
+
+// schemaUnsafe matches the characters not allowed in staging schema names
+var schemaUnsafe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// StagingSchema returns the schema the restore is loaded into, named after
+// the company code and the restore so that schemas of a company sort together
+func (r *BackupRestore) StagingSchema(companyCode string) string {
+	code := schemaUnsafe.ReplaceAllString(strings.ToLower(companyCode), "_")
+	if len(code) > 32 {
+		code = code[:32]
+	}
+	id := strings.ReplaceAll(r.ID.String(), "-", "")
+	return "restore_" + strings.Trim(code, "_") + "_" + id[len(id)-12:]
+}
+
+// Complete records the loaded schema
+func (r *BackupRestore) Complete(schema string, rowCounts map[string]int, at time.Time) {
+	r.Status = BackupStatusCompleted
+	r.TargetSchema = schema
+	r.RowCounts = rowCounts
+	r.Error = ""
+	r.CompletedAt = &at
+}
+
+// Fail records the reason the restore could not be loaded
+func (r *BackupRestore) Fail(err error, at time.Time) {
+	r.Status = BackupStatusFailed
+	r.Error = err.Error()
+	r.CompletedAt = &at
+}
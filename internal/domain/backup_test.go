@@ -0,0 +1,121 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// BackupRetention Tests
+// ============================================================================
+
+func TestBackupRetention_ExpiresAt(t *testing.T) {
+	completedAt := time.Date(2026, 3, 1, 2, 10, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		retention domain.BackupRetention
+		monthly   bool
+		want      time.Time
+	}{
+		{"daily", domain.BackupRetention{Days: 30, MonthlyMonths: 12}, false, time.Date(2026, 3, 31, 2, 10, 0, 0, time.UTC)},
+		{"monthly", domain.BackupRetention{Days: 30, MonthlyMonths: 12}, true, time.Date(2027, 3, 1, 2, 10, 0, 0, time.UTC)},
+		{"monthly shorter than daily", domain.BackupRetention{Days: 90, MonthlyMonths: 1}, true, time.Date(2026, 5, 30, 2, 10, 0, 0, time.UTC)},
+		{"no monthly retention", domain.BackupRetention{Days: 30}, true, time.Date(2026, 3, 31, 2, 10, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.retention.ExpiresAt(completedAt, tt.monthly))
+		})
+	}
+}
+
+// ============================================================================
+// TenantBackup Tests
+// ============================================================================
+
+func TestTenantBackup_CompleteAndFail(t *testing.T) {
+	retention := domain.BackupRetention{Days: 30, MonthlyMonths: 12}
+	at := time.Date(2026, 3, 1, 2, 10, 0, 0, time.UTC)
+
+	backup := domain.NewManualBackup(uuid.New(), uuid.New())
+	backup.ID = uuid.New()
+	assert.Equal(t, domain.BackupStatusPending, backup.Status)
+	assert.False(t, backup.IsRestorable(at))
+
+	key := backup.StorageKeyFor("/backups/")
+	assert.Equal(t, "backups/"+backup.CompanyID.String()+"/"+backup.ID.String()+".zip.enc", key)
+
+	backup.Complete(key, "abc", 42, "k1", []byte("wrapped"), map[string]int{"accounts": 3}, true, retention, at)
+	assert.Equal(t, domain.BackupStatusCompleted, backup.Status)
+	require.NotNil(t, backup.ExpiresAt)
+	assert.Equal(t, at.AddDate(1, 0, 0), *backup.ExpiresAt)
+	assert.True(t, backup.IsRestorable(at.AddDate(0, 6, 0)))
+	assert.False(t, backup.IsRestorable(at.AddDate(1, 0, 0)))
+
+	failed := domain.NewManualBackup(uuid.New(), uuid.New())
+	failed.Fail(errors.New("storage unavailable"), retention, at)
+	assert.Equal(t, domain.BackupStatusFailed, failed.Status)
+	assert.Equal(t, "storage unavailable", failed.Error)
+	assert.Equal(t, at.AddDate(0, 0, 30), *failed.ExpiresAt)
+	assert.False(t, failed.IsRestorable(at))
+}
+
+// ============================================================================
+// BackupRestore Tests
+// ============================================================================
+
+func TestNewBackupRestore(t *testing.T) {
+	at := time.Date(2026, 3, 1, 2, 10, 0, 0, time.UTC)
+	backup := domain.NewManualBackup(uuid.New(), uuid.New())
+	backup.ID = uuid.New()
+	backup.Complete("key", "abc", 42, "k1", nil, nil, false, domain.BackupRetention{Days: 30}, at)
+
+	tests := []struct {
+		name    string
+		reason  string
+		now     time.Time
+		wantErr error
+	}{
+		{"valid", "  ticket #1234  ", at.AddDate(0, 0, 1), nil},
+		{"no reason", "   ", at.AddDate(0, 0, 1), domain.ErrBackupRestoreReasonRequired},
+		{"reason too long", strings.Repeat("가", 501), at.AddDate(0, 0, 1), domain.ErrBackupRestoreReasonTooLong},
+		{"expired", "ticket #1234", at.AddDate(0, 0, 31), domain.ErrBackupNotRestorable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore, err := domain.NewBackupRestore(backup, tt.reason, uuid.New(), tt.now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ticket #1234", restore.Reason)
+			assert.Equal(t, backup.ID, restore.BackupID)
+			assert.Equal(t, backup.CompanyID, restore.CompanyID)
+			assert.Equal(t, domain.BackupStatusPending, restore.Status)
+		})
+	}
+}
+
+func TestBackupRestore_StagingSchema(t *testing.T) {
+	restore := &domain.BackupRestore{}
+	restore.ID = uuid.MustParse("0b6f3c2e-8d1a-4c57-9e2b-5f4a7d9e1c3b")
+
+	assert.Equal(t, "restore_acme_kr_5f4a7d9e1c3b", restore.StagingSchema("ACME-KR"))
+	assert.Equal(t, "restore__5f4a7d9e1c3b", restore.StagingSchema("한글"))
+
+	schema := restore.StagingSchema(strings.Repeat("a", 80))
+	assert.LessOrEqual(t, len(schema), 63)
+	assert.Equal(t, "restore_"+strings.Repeat("a", 32)+"_5f4a7d9e1c3b", schema)
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// BalanceExceptionDefaultMonths is the number of months checked by default
+const BalanceExceptionDefaultMonths = 1
+
+// BalanceExceptionKind identifies why a closing balance is flagged
+type BalanceExceptionKind string
+
+const (
+	// BalanceExceptionNatureViolation is a balance on the side opposite to the
+	// account nature, such as a debit balance on trade payables
+	BalanceExceptionNatureViolation BalanceExceptionKind = "nature_violation"
+	// BalanceExceptionNegativeBalance is a credit balance on a cash or bank
+	// account, which cannot hold less than nothing
+	BalanceExceptionNegativeBalance BalanceExceptionKind = "negative_balance"
+)
+
+// BalanceException is an account whose closing balance of a period
+// contradicts its nature. Balances are signed on the side of the account
+// nature, so a flagged closing balance is negative.
+type BalanceException struct {
+	FiscalYear     int                  `json:"fiscal_year"`
+	FiscalMonth    int                  `json:"fiscal_month"`
+	AccountID      uuid.UUID            `json:"account_id"`
+	AccountCode    string               `json:"account_code"`
+	AccountName    string               `json:"account_name"`
+	AccountType    AccountType          `json:"account_type"`
+	AccountNature  AccountNature        `json:"account_nature"`
+	Kind           BalanceExceptionKind `json:"kind"`
+	OpeningBalance float64              `json:"opening_balance"`
+	PeriodDebit    float64              `json:"period_debit"`
+	PeriodCredit   float64              `json:"period_credit"`
+	ClosingBalance float64              `json:"closing_balance"`
+	// IsNew is set when the opening balance was fine, so the entries of the
+	// period turned it
+	IsNew bool `json:"is_new"`
+}
+
+// BalanceExceptionReport lists the balance exceptions of the months ending at
+// ToYear/ToMonth, oldest period first
+type BalanceExceptionReport struct {
+	FromYear   int                          `json:"from_year"`
+	FromMonth  int                          `json:"from_month"`
+	ToYear     int                          `json:"to_year"`
+	ToMonth    int                          `json:"to_month"`
+	Exceptions []BalanceException           `json:"exceptions"`
+	Counts     map[BalanceExceptionKind]int `json:"counts"`
+}
+
+// natureBalance signs the debit and credit amounts on the side of the nature
+func natureBalance(nature AccountNature, debit, credit float64) float64 {
+	if nature == AccountNatureCredit {
+		return roundAmount(credit - debit)
+	}
+	return roundAmount(debit - credit)
+}
+
+// FindBalanceExceptions returns the balances of a period that close on the
+// side opposite to their account nature, by account code. Balances without
+// their account loaded are skipped.
+func FindBalanceExceptions(balances []LedgerBalance) []BalanceException {
+	var exceptions []BalanceException
+	for _, b := range balances {
+		a := b.Account
+		if a == nil {
+			continue
+		}
+		closing := natureBalance(a.AccountNature, b.ClosingDebit, b.ClosingCredit)
+		if closing >= 0 {
+			continue
+		}
+		opening := natureBalance(a.AccountNature, b.OpeningDebit, b.OpeningCredit)
+
+		kind := BalanceExceptionNatureViolation
+		if a.IsCashAccount {
+			kind = BalanceExceptionNegativeBalance
+		}
+		exceptions = append(exceptions, BalanceException{
+			FiscalYear:     b.FiscalYear,
+			FiscalMonth:    b.FiscalMonth,
+			AccountID:      b.AccountID,
+			AccountCode:    a.Code,
+			AccountName:    a.Name,
+			AccountType:    a.AccountType,
+			AccountNature:  a.AccountNature,
+			Kind:           kind,
+			OpeningBalance: opening,
+			PeriodDebit:    b.PeriodDebit,
+			PeriodCredit:   b.PeriodCredit,
+			ClosingBalance: closing,
+			IsNew:          opening >= 0,
+		})
+	}
+	sort.Slice(exceptions, func(i, j int) bool { return exceptions[i].AccountCode < exceptions[j].AccountCode })
+	return exceptions
+}
+
+// NewBalanceExceptionReport builds the report of the months ending at
+// toYear/toMonth from the exceptions found in each of them
+func NewBalanceExceptionReport(toYear, toMonth, months int, exceptions []BalanceException) *BalanceExceptionReport {
+	last := toYear*12 + toMonth - 1
+	first := last - months + 1
+	report := &BalanceExceptionReport{
+		FromYear:   first / 12,
+		FromMonth:  first%12 + 1,
+		ToYear:     toYear,
+		ToMonth:    toMonth,
+		Exceptions: exceptions,
+		Counts:     make(map[BalanceExceptionKind]int),
+	}
+	if report.Exceptions == nil {
+		report.Exceptions = []BalanceException{}
+	}
+	for _, e := range exceptions {
+		report.Counts[e.Kind]++
+	}
+	return report
+}
@@ -0,0 +1,62 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestFindBalanceExceptions(t *testing.T) {
+	balance := func(code string, nature domain.AccountNature, cash bool, openDebit, openCredit, closeDebit, closeCredit float64) domain.LedgerBalance {
+		return domain.LedgerBalance{
+			AccountID:     uuid.New(),
+			FiscalYear:    2025,
+			FiscalMonth:   3,
+			OpeningDebit:  openDebit,
+			OpeningCredit: openCredit,
+			ClosingDebit:  closeDebit,
+			ClosingCredit: closeCredit,
+			Account:       &domain.Account{Code: code, AccountNature: nature, IsCashAccount: cash},
+		}
+	}
+	exceptions := domain.FindBalanceExceptions([]domain.LedgerBalance{
+		balance("210101", domain.AccountNatureCredit, false, 0, 500, 800, 500),
+		balance("110101", domain.AccountNatureDebit, true, 200, 0, 1000, 1300),
+		balance("110301", domain.AccountNatureDebit, false, 0, 0, 700, 0),
+		balance("110302", domain.AccountNatureCredit, false, 0, 0, 0, 100), // allowance, credit is its nature
+		{AccountID: uuid.New(), ClosingCredit: 100},                        // account not loaded
+	})
+	require.Len(t, exceptions, 2)
+
+	assert.Equal(t, "110101", exceptions[0].AccountCode)
+	assert.Equal(t, domain.BalanceExceptionNegativeBalance, exceptions[0].Kind)
+	assert.Equal(t, -300.0, exceptions[0].ClosingBalance)
+	assert.True(t, exceptions[0].IsNew)
+
+	assert.Equal(t, "210101", exceptions[1].AccountCode)
+	assert.Equal(t, domain.BalanceExceptionNatureViolation, exceptions[1].Kind)
+	assert.Equal(t, -300.0, exceptions[1].ClosingBalance)
+	assert.Equal(t, 500.0, exceptions[1].OpeningBalance)
+}
+
+func TestNewBalanceExceptionReport(t *testing.T) {
+	report := domain.NewBalanceExceptionReport(2025, 2, 3, nil)
+	assert.Equal(t, 2024, report.FromYear)
+	assert.Equal(t, 12, report.FromMonth)
+	assert.NotNil(t, report.Exceptions)
+
+	report = domain.NewBalanceExceptionReport(2025, 2, 1, []domain.BalanceException{
+		{Kind: domain.BalanceExceptionNatureViolation},
+		{Kind: domain.BalanceExceptionNatureViolation},
+		{Kind: domain.BalanceExceptionNegativeBalance},
+	})
+	assert.Equal(t, 2, report.FromMonth)
+	assert.Equal(t, map[domain.BalanceExceptionKind]int{
+		domain.BalanceExceptionNatureViolation: 2,
+		domain.BalanceExceptionNegativeBalance: 1,
+	}, report.Counts)
+}
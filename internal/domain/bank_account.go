@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bank account errors
+var (
+	ErrBankAccountNotFound = errors.New("bank account not found")
+	ErrBankAccountExists   = errors.New("bank account already registered")
+	ErrBankAccountInUse    = errors.New("bank account has voucher entries")
+	ErrInvalidBankAccount  = errors.New("invalid bank account")
+	ErrInvalidBankBalance  = errors.New("invalid bank balance")
+
+	ErrBankAccountRequired = errors.New("payment from bank needs the bank account")
+	ErrBankAccountMismatch = errors.New("bank account belongs to another account")
+	ErrBankAccountInactive = errors.New("bank account is inactive")
+)
+
+// BankBalanceSource tells where a bank balance was taken from
+type BankBalanceSource string
+
+const (
+	BankBalanceStatementImport BankBalanceSource = "statement_import" // an imported bank statement
+	BankBalanceOpenBanking     BankBalanceSource = "open_banking"     // account inquiry at the bank
+	BankBalanceManual          BankBalanceSource = "manual"
+)
+
+// IsValid checks if the balance source is valid
+func (s BankBalanceSource) IsValid() bool {
+	switch s {
+	case BankBalanceStatementImport, BankBalanceOpenBanking, BankBalanceManual:
+		return true
+	}
+	return false
+}
+
+// CompanyBankAccount is a bank account of the company (법인 계좌) with the
+// cash account of the chart it books to. Several bank accounts may share one
+// cash account. The balance is the latest balance reported by the bank.
+type CompanyBankAccount struct {
+	TenantModel
+
+	Name          string `gorm:"type:varchar(100);not null" json:"name"`    // e.g. 운영자금
+	BankCode      string `gorm:"type:varchar(3);not null" json:"bank_code"` // 3-digit 금융기관 code, e.g. 004
+	BankName      string `gorm:"type:varchar(50)" json:"bank_name,omitempty"`
+	AccountNumber string `gorm:"type:varchar(20);not null" json:"-"` // digits only, masked in responses
+	AccountHolder string `gorm:"type:varchar(100)" json:"account_holder,omitempty"`
+	Currency      string `gorm:"type:varchar(3);not null;default:KRW" json:"currency"`
+
+	AccountID uuid.UUID `gorm:"type:uuid;not null" json:"account_id"` // cash account of the chart
+
+	Balance       float64           `gorm:"type:decimal(18,2);not null;default:0" json:"balance"`
+	BalanceAsOf   *time.Time        `json:"balance_as_of,omitempty"`
+	BalanceSource BankBalanceSource `gorm:"type:varchar(20)" json:"balance_source,omitempty"`
+
+	IsActive bool `gorm:"not null;default:true" json:"is_active"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (CompanyBankAccount) TableName() string {
+	return "company_bank_accounts"
+}
+
+// Normalize trims the fields and strips the dashes of the account number
+func (b *CompanyBankAccount) Normalize() {
+	b.Name = strings.TrimSpace(b.Name)
+	b.BankCode = strings.TrimSpace(b.BankCode)
+	b.BankName = strings.TrimSpace(b.BankName)
+	b.AccountNumber = digitsOnly(b.AccountNumber)
+	b.AccountHolder = strings.TrimSpace(b.AccountHolder)
+	b.Currency = strings.ToUpper(strings.TrimSpace(b.Currency))
+	if b.Currency == "" {
+		b.Currency = "KRW"
+	}
+}
+
+// Validate checks the required fields
+func (b *CompanyBankAccount) Validate() error {
+	switch {
+	case b.Name == "":
+		return ErrInvalidBankAccount
+	case len(b.BankCode) != 3 || digitsOnly(b.BankCode) != b.BankCode:
+		return ErrInvalidBankAccount
+	case len(b.AccountNumber) < 8 || len(b.AccountNumber) > 20:
+		return ErrInvalidBankAccount
+	case len(b.Currency) != 3:
+		return ErrInvalidBankAccount
+	case b.AccountID == uuid.Nil:
+		return ErrInvalidBankAccount
+	}
+	return nil
+}
+
+// MaskedNumber returns the account number with all but the last four digits
+// masked, e.g. ********5678
+func (b *CompanyBankAccount) MaskedNumber() string {
+	if len(b.AccountNumber) <= 4 {
+		return b.AccountNumber
+	}
+	return strings.Repeat("*", len(b.AccountNumber)-4) + b.AccountNumber[len(b.AccountNumber)-4:]
+}
+
+// ApplyBalance makes the reported balance the current balance of the account
+// unless a later one is already known. It returns true if it did.
+func (b *CompanyBankAccount) ApplyBalance(balance *BankAccountBalance) bool {
+	if b.BalanceAsOf != nil && balance.AsOf.Before(*b.BalanceAsOf) {
+		return false
+	}
+	asOf := balance.AsOf
+	b.Balance = balance.Balance
+	b.BalanceAsOf = &asOf
+	b.BalanceSource = balance.Source
+	return true
+}
+
+// BankAccountBalance is a balance of a bank account reported at a time, by a
+// statement import, an account inquiry or by hand
+type BankAccountBalance struct {
+	ID            uuid.UUID         `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID     uuid.UUID         `gorm:"type:uuid;not null" json:"company_id"`
+	BankAccountID uuid.UUID         `gorm:"type:uuid;not null" json:"bank_account_id"`
+	AsOf          time.Time         `gorm:"not null" json:"as_of"`
+	Balance       float64           `gorm:"type:decimal(18,2);not null" json:"balance"`
+	Source        BankBalanceSource `gorm:"type:varchar(20);not null" json:"source"`
+	CreatedBy     *uuid.UUID        `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt     time.Time         `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (BankAccountBalance) TableName() string {
+	return "bank_account_balances"
+}
+
+// Validate checks the balance report
+func (b *BankAccountBalance) Validate(now time.Time) error {
+	if !b.Source.IsValid() || b.AsOf.IsZero() || b.AsOf.After(now) {
+		return ErrInvalidBankBalance
+	}
+	return nil
+}
+
+// BankBalanceSummary compares the balances the banks report for the bank
+// accounts of a cash account with its balance in the books
+type BankBalanceSummary struct {
+	AccountID    uuid.UUID            `json:"account_id"`
+	AccountCode  string               `json:"account_code"`
+	AccountName  string               `json:"account_name"`
+	BookBalance  float64              `json:"book_balance"`
+	BankBalance  float64              `json:"bank_balance"` // sum of the latest balances of the bank accounts
+	Difference   float64              `json:"difference"`   // bank less book
+	BankAccounts []CompanyBankAccount `json:"bank_accounts"`
+}
+
+// AssignEntryBankAccounts checks the bank accounts of voucher entries against
+// the bank accounts of their cash accounts. An entry naming a bank account
+// must book to its cash account, and the bank account must be active. When
+// required, a payment voucher crediting a cash account with bank accounts
+// must name the bank account paid from; it is assigned if there is only one.
+func AssignEntryBankAccounts(voucherType VoucherType, entries []VoucherEntry, banks []CompanyBankAccount, required bool) error {
+	byID := make(map[uuid.UUID]*CompanyBankAccount, len(banks))
+	byAccount := make(map[uuid.UUID][]*CompanyBankAccount)
+	for i := range banks {
+		byID[banks[i].ID] = &banks[i]
+		if banks[i].IsActive {
+			byAccount[banks[i].AccountID] = append(byAccount[banks[i].AccountID], &banks[i])
+		}
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		if e.BankAccountID != nil {
+			bank, ok := byID[*e.BankAccountID]
+			if !ok || bank.AccountID != e.AccountID {
+				return ErrBankAccountMismatch
+			}
+			if !bank.IsActive {
+				return ErrBankAccountInactive
+			}
+			continue
+		}
+		if !required || voucherType != VoucherTypePayment || e.CreditAmount == 0 {
+			continue
+		}
+		switch linked := byAccount[e.AccountID]; len(linked) {
+		case 0:
+		case 1:
+			e.BankAccountID = &linked[0].ID
+		default:
+			return ErrBankAccountRequired
+		}
+	}
+	return nil
+}
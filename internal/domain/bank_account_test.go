@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestCompanyBankAccount_Normalize(t *testing.T) {
+	bank := domain.CompanyBankAccount{Name: " 운영자금 ", BankCode: "004", AccountNumber: "123-456-78-901234", AccountID: uuid.New()}
+	bank.Normalize()
+	require.NoError(t, bank.Validate())
+	assert.Equal(t, "12345678901234", bank.AccountNumber)
+	assert.Equal(t, "KRW", bank.Currency)
+	assert.Equal(t, "**********1234", bank.MaskedNumber())
+
+	bank.BankCode = "KB"
+	assert.ErrorIs(t, bank.Validate(), domain.ErrInvalidBankAccount)
+}
+
+func TestCompanyBankAccount_ApplyBalance(t *testing.T) {
+	var bank domain.CompanyBankAccount
+	now := time.Now()
+
+	assert.True(t, bank.ApplyBalance(&domain.BankAccountBalance{AsOf: now, Balance: 5000, Source: domain.BankBalanceOpenBanking}))
+	assert.Equal(t, 5000.0, bank.Balance)
+	assert.Equal(t, domain.BankBalanceOpenBanking, bank.BalanceSource)
+
+	// An older statement is kept in the history only
+	assert.False(t, bank.ApplyBalance(&domain.BankAccountBalance{AsOf: now.Add(-time.Hour), Balance: 3000, Source: domain.BankBalanceStatementImport}))
+	assert.Equal(t, 5000.0, bank.Balance)
+
+	assert.ErrorIs(t, (&domain.BankAccountBalance{AsOf: now.Add(time.Hour), Source: domain.BankBalanceManual}).Validate(now), domain.ErrInvalidBankBalance)
+}
+
+func TestAssignEntryBankAccounts(t *testing.T) {
+	depositID, expenseID := uuid.New(), uuid.New()
+	bank := func(active bool) domain.CompanyBankAccount {
+		b := domain.CompanyBankAccount{AccountID: depositID, IsActive: active}
+		b.ID = uuid.New()
+		return b
+	}
+	operating, payroll, closed := bank(true), bank(true), bank(false)
+	payment := func() []domain.VoucherEntry {
+		return []domain.VoucherEntry{
+			{AccountID: expenseID, DebitAmount: 1000},
+			{AccountID: depositID, CreditAmount: 1000},
+		}
+	}
+
+	t.Run("assigns the only bank account", func(t *testing.T) {
+		entries := payment()
+		require.NoError(t, domain.AssignEntryBankAccounts(domain.VoucherTypePayment, entries, []domain.CompanyBankAccount{operating, closed}, true))
+		require.NotNil(t, entries[1].BankAccountID)
+		assert.Equal(t, operating.ID, *entries[1].BankAccountID)
+		assert.Nil(t, entries[0].BankAccountID)
+	})
+
+	t.Run("requires a choice among bank accounts", func(t *testing.T) {
+		banks := []domain.CompanyBankAccount{operating, payroll}
+		assert.ErrorIs(t, domain.AssignEntryBankAccounts(domain.VoucherTypePayment, payment(), banks, true), domain.ErrBankAccountRequired)
+		assert.NoError(t, domain.AssignEntryBankAccounts(domain.VoucherTypePayment, payment(), banks, false))
+		assert.NoError(t, domain.AssignEntryBankAccounts(domain.VoucherTypeGeneral, payment(), banks, true))
+
+		entries := payment()
+		entries[1].BankAccountID = &payroll.ID
+		assert.NoError(t, domain.AssignEntryBankAccounts(domain.VoucherTypePayment, entries, banks, true))
+	})
+
+	t.Run("checks the named bank account", func(t *testing.T) {
+		banks := []domain.CompanyBankAccount{operating, closed}
+		entries := payment()
+		entries[0].BankAccountID = &operating.ID
+		assert.ErrorIs(t, domain.AssignEntryBankAccounts(domain.VoucherTypePayment, entries, banks, false), domain.ErrBankAccountMismatch)
+
+		entries = payment()
+		entries[1].BankAccountID = &closed.ID
+		assert.ErrorIs(t, domain.AssignEntryBankAccounts(domain.VoucherTypePayment, entries, banks, false), domain.ErrBankAccountInactive)
+	})
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Business number verification errors
+var (
+	ErrInvalidBusinessNumber        = errors.New("invalid business number")
+	ErrInvalidBusinessVerification  = errors.New("invalid business verification request")
+	ErrBusinessVerificationDisabled = errors.New("business number verification is not configured")
+	ErrBusinessVerificationFailed   = errors.New("business number lookup at NTS failed")
+)
+
+// BusinessStatus is the registration status of a business at NTS
+type BusinessStatus string
+
+const (
+	BusinessStatusActive       BusinessStatus = "active"       // 계속사업자
+	BusinessStatusSuspended    BusinessStatus = "suspended"    // 휴업자
+	BusinessStatusClosed       BusinessStatus = "closed"       // 폐업자
+	BusinessStatusUnregistered BusinessStatus = "unregistered" // 국세청에 등록되지 않은 번호
+)
+
+// BusinessVerificationRequest is a business number to verify. With a partner,
+// the number and representative default to those of the partner. The
+// representative is checked only together with the opening date, which NTS
+// requires for the check.
+type BusinessVerificationRequest struct {
+	PartnerID      *uuid.UUID
+	BusinessNumber string
+	Representative string
+	StartDate      *time.Time
+}
+
+// BusinessVerification is the NTS registration status of a business number
+type BusinessVerification struct {
+	PartnerID      *uuid.UUID     `json:"partner_id,omitempty"`
+	BusinessNumber string         `json:"business_number"`
+	Status         BusinessStatus `json:"status"`
+	TaxType        string         `json:"tax_type,omitempty"`
+	ClosedOn       *time.Time     `json:"closed_on,omitempty"`
+
+	// RepresentativeMatched is set when the representative was checked
+	RepresentativeMatched *bool     `json:"representative_matched,omitempty"`
+	VerifiedAt            time.Time `json:"verified_at"`
+}
+
+// IsClosed returns true if the business is closed
+func (v *BusinessVerification) IsClosed() bool {
+	return v.Status == BusinessStatusClosed
+}
+
+// BusinessNumberDigits returns the digits of a business number, dropping the
+// dashes of the 000-00-00000 form
+func BusinessNumberDigits(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, number)
+}
+
+// businessNumberWeights are the check digit weights of a business number
+var businessNumberWeights = [9]int{1, 3, 7, 1, 3, 7, 1, 3, 5}
+
+// ValidateBusinessNumber checks the length and check digit of a business
+// number given as digits
+func ValidateBusinessNumber(digits string) error {
+	if len(digits) != 10 {
+		return fmt.Errorf("%w: %q must be 10 digits", ErrInvalidBusinessNumber, digits)
+	}
+	sum := 0
+	for i, w := range businessNumberWeights {
+		d := int(digits[i] - '0')
+		sum += d * w
+		if i == 8 {
+			sum += d * w / 10
+		}
+	}
+	if (10-sum%10)%10 != int(digits[9]-'0') {
+		return fmt.Errorf("%w: %q has an invalid check digit", ErrInvalidBusinessNumber, digits)
+	}
+	return nil
+}
+
+// ApplyBusinessVerification records the verification on the partner. It
+// returns true if the partner was not known to be closed before.
+func (p *Partner) ApplyBusinessVerification(v *BusinessVerification) bool {
+	newlyClosed := v.IsClosed() && p.BusinessStatus != BusinessStatusClosed
+	p.BusinessStatus = v.Status
+	p.BusinessTaxType = v.TaxType
+	p.BusinessClosedOn = v.ClosedOn
+	if v.RepresentativeMatched != nil {
+		p.RepresentativeVerified = v.RepresentativeMatched
+	}
+	verifiedAt := v.VerifiedAt
+	p.BusinessVerifiedAt = &verifiedAt
+	return newlyClosed
+}
+
+// ResetBusinessVerification clears the verification, e.g. when the business
+// number of the partner changes
+func (p *Partner) ResetBusinessVerification() {
+	p.BusinessStatus = ""
+	p.BusinessTaxType = ""
+	p.BusinessClosedOn = nil
+	p.RepresentativeVerified = nil
+	p.BusinessVerifiedAt = nil
+}
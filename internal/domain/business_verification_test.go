@@ -0,0 +1,40 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestValidateBusinessNumber(t *testing.T) {
+	assert.Equal(t, "1234567891", domain.BusinessNumberDigits("123-45-67891"))
+
+	assert.NoError(t, domain.ValidateBusinessNumber("1234567891"))
+	assert.NoError(t, domain.ValidateBusinessNumber("2208100001"))
+	assert.ErrorIs(t, domain.ValidateBusinessNumber("1234567890"), domain.ErrInvalidBusinessNumber)
+	assert.ErrorIs(t, domain.ValidateBusinessNumber("123456789"), domain.ErrInvalidBusinessNumber)
+}
+
+func TestPartner_ApplyBusinessVerification(t *testing.T) {
+	matched := true
+	partner := &domain.Partner{BusinessNumber: "123-45-67891"}
+	assert.False(t, partner.ApplyBusinessVerification(&domain.BusinessVerification{
+		Status:                domain.BusinessStatusActive,
+		RepresentativeMatched: &matched,
+		VerifiedAt:            time.Now(),
+	}))
+
+	// A closed business is reported once; the representative check is kept
+	closed := &domain.BusinessVerification{Status: domain.BusinessStatusClosed, VerifiedAt: time.Now()}
+	assert.True(t, partner.ApplyBusinessVerification(closed))
+	assert.False(t, partner.ApplyBusinessVerification(closed))
+	assert.Equal(t, domain.BusinessStatusClosed, partner.BusinessStatus)
+	assert.Equal(t, &matched, partner.RepresentativeVerified)
+
+	partner.ResetBusinessVerification()
+	assert.Empty(t, partner.BusinessStatus)
+	assert.Nil(t, partner.BusinessVerifiedAt)
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotCashAccount is returned when the cash book is requested for an account not designated as cash/bank
+var ErrNotCashAccount = errors.New("account is not designated as a cash account")
+
+// CashBookEntry is a posted entry on a cash/bank account (현금출납장)
+type CashBookEntry struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	VoucherID      uuid.UUID `json:"voucher_id"`
+	VoucherNo      string    `json:"voucher_no"`
+	VoucherDate    time.Time `json:"voucher_date"`
+	VoucherType    string    `json:"voucher_type"`
+	EntryID        uuid.UUID `json:"entry_id"`
+	LineNo         int       `json:"line_no"`
+	Description    string    `json:"description"`
+	PartnerName    string    `json:"partner_name,omitempty"`
+	CounterAccount string    `json:"counter_account,omitempty"` // other-side accounts of the voucher (상대계정)
+	Receipt        float64   `json:"receipt"`                   // debit (입금)
+	Payment        float64   `json:"payment"`                   // credit (출금)
+	Balance        float64   `json:"balance"`                   // Running balance
+}
+
+// CashBookDay groups the entries of one day with the day's totals
+type CashBookDay struct {
+	Date     time.Time       `json:"date"`
+	Receipts float64         `json:"receipts"`
+	Payments float64         `json:"payments"`
+	Balance  float64         `json:"balance"` // closing balance of the day
+	Entries  []CashBookEntry `json:"entries"`
+}
+
+// CashBookAccount is the cash book of one cash/bank account
+type CashBookAccount struct {
+	AccountID      uuid.UUID     `json:"account_id"`
+	AccountCode    string        `json:"account_code"`
+	AccountName    string        `json:"account_name"`
+	OpeningBalance float64       `json:"opening_balance"`
+	TotalReceipts  float64       `json:"total_receipts"`
+	TotalPayments  float64       `json:"total_payments"`
+	ClosingBalance float64       `json:"closing_balance"`
+	Days           []CashBookDay `json:"days"`
+}
+
+// NewCashBookAccount builds the cash book of an account from its opening balance and
+// chronologically ordered entries, filling in running balances and daily totals
+func NewCashBookAccount(account *Account, openingBalance float64, entries []CashBookEntry) *CashBookAccount {
+	book := &CashBookAccount{
+		AccountID:      account.ID,
+		AccountCode:    account.Code,
+		AccountName:    account.Name,
+		OpeningBalance: openingBalance,
+		Days:           []CashBookDay{},
+	}
+
+	balance := openingBalance
+	for _, e := range entries {
+		balance += e.Receipt - e.Payment
+		e.Balance = balance
+		book.TotalReceipts += e.Receipt
+		book.TotalPayments += e.Payment
+
+		last := len(book.Days) - 1
+		if last < 0 || !book.Days[last].Date.Equal(e.VoucherDate) {
+			book.Days = append(book.Days, CashBookDay{Date: e.VoucherDate})
+			last++
+		}
+		day := &book.Days[last]
+		day.Receipts += e.Receipt
+		day.Payments += e.Payment
+		day.Balance = balance
+		day.Entries = append(day.Entries, e)
+	}
+	book.ClosingBalance = balance
+	return book
+}
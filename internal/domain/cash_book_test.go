@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// CashBook Tests
+// ============================================================================
+
+func TestNewCashBookAccount(t *testing.T) {
+	day1 := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 9, 3, 0, 0, 0, 0, time.UTC)
+	account := &domain.Account{Code: "110101", Name: "현금"}
+
+	book := domain.NewCashBookAccount(account, 1000, []domain.CashBookEntry{
+		{VoucherDate: day1, VoucherNo: "V-001", Receipt: 500},
+		{VoucherDate: day1, VoucherNo: "V-002", Payment: 200},
+		{VoucherDate: day2, VoucherNo: "V-003", Payment: 300},
+	})
+
+	require.Len(t, book.Days, 2)
+	assert.Equal(t, 500.0, book.Days[0].Receipts)
+	assert.Equal(t, 200.0, book.Days[0].Payments)
+	assert.Equal(t, 1300.0, book.Days[0].Balance)
+	assert.Equal(t, 1500.0, book.Days[0].Entries[0].Balance)
+	assert.Equal(t, 1000.0, book.Days[1].Balance)
+	assert.Equal(t, 500.0, book.TotalReceipts)
+	assert.Equal(t, 500.0, book.TotalPayments)
+	assert.Equal(t, 1000.0, book.ClosingBalance)
+
+	empty := domain.NewCashBookAccount(account, 1000, nil)
+	assert.Empty(t, empty.Days)
+	assert.Equal(t, 1000.0, empty.ClosingBalance)
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Close checklist errors
+var (
+	ErrCloseTaskNotFound        = errors.New("close checklist task not found")
+	ErrCloseTaskCodeExists      = errors.New("close checklist task code already exists")
+	ErrCloseTaskCodeEmpty       = errors.New("close checklist task code is required")
+	ErrCloseTaskNameEmpty       = errors.New("close checklist task name is required")
+	ErrCloseChecklistIncomplete = errors.New("mandatory close checklist tasks are not completed")
+	ErrCloseOverrideForbidden   = errors.New("closing with an incomplete checklist requires admin role")
+	ErrInvalidCloseTaskStatus   = errors.New("invalid close checklist task status")
+)
+
+// CloseTaskStatus represents the completion status of a checklist task for a period
+type CloseTaskStatus string
+
+const (
+	CloseTaskStatusPending   CloseTaskStatus = "pending"
+	CloseTaskStatusCompleted CloseTaskStatus = "completed"
+	CloseTaskStatusSkipped   CloseTaskStatus = "skipped"
+)
+
+// IsValid checks if the task status is valid
+func (s CloseTaskStatus) IsValid() bool {
+	switch s {
+	case CloseTaskStatusPending, CloseTaskStatusCompleted, CloseTaskStatusSkipped:
+		return true
+	}
+	return false
+}
+
+// IsDone returns true if the task no longer blocks period close
+func (s CloseTaskStatus) IsDone() bool {
+	return s == CloseTaskStatusCompleted || s == CloseTaskStatusSkipped
+}
+
+// CloseChecklistTask is a company-configured task to be performed before month-end close
+// (e.g., bank reconciled, depreciation run, accruals posted)
+type CloseChecklistTask struct {
+	TenantModel
+
+	// Task info
+	Code        string `gorm:"type:varchar(50);not null" json:"code"`
+	Name        string `gorm:"type:varchar(200);not null" json:"name"`
+	Description string `gorm:"type:varchar(500)" json:"description,omitempty"`
+
+	// Settings
+	IsMandatory bool `gorm:"default:true" json:"is_mandatory"`
+	IsActive    bool `gorm:"default:true" json:"is_active"`
+	SortOrder   int  `gorm:"default:0" json:"sort_order"`
+}
+
+// TableName specifies the table name for GORM
+func (CloseChecklistTask) TableName() string {
+	return "close_checklist_tasks"
+}
+
+// Validate validates the checklist task data
+func (t *CloseChecklistTask) Validate() error {
+	if t.Code == "" {
+		return ErrCloseTaskCodeEmpty
+	}
+	if t.Name == "" {
+		return ErrCloseTaskNameEmpty
+	}
+	return nil
+}
+
+// PeriodCloseTask tracks the status of a checklist task for a specific fiscal period
+type PeriodCloseTask struct {
+	BaseModel
+	CompanyID uuid.UUID `gorm:"type:uuid;not null;index" json:"company_id"`
+	TaskID    uuid.UUID `gorm:"type:uuid;not null" json:"task_id"`
+
+	// Period
+	FiscalYear  int `gorm:"not null" json:"fiscal_year"`
+	FiscalMonth int `gorm:"not null" json:"fiscal_month"`
+
+	// Status
+	Status      CloseTaskStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Note        string          `gorm:"type:varchar(500)" json:"note,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	CompletedBy *uuid.UUID      `gorm:"type:uuid" json:"completed_by,omitempty"`
+
+	// Relations
+	Task *CloseChecklistTask `gorm:"foreignKey:TaskID" json:"task,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PeriodCloseTask) TableName() string {
+	return "period_close_tasks"
+}
+
+// Complete marks the task as completed by the given user
+func (t *PeriodCloseTask) Complete(userID uuid.UUID, note string) {
+	now := time.Now()
+	t.Status = CloseTaskStatusCompleted
+	t.Note = note
+	t.CompletedAt = &now
+	t.CompletedBy = &userID
+}
+
+// Skip marks the task as skipped (not applicable this period)
+func (t *PeriodCloseTask) Skip(userID uuid.UUID, note string) {
+	now := time.Now()
+	t.Status = CloseTaskStatusSkipped
+	t.Note = note
+	t.CompletedAt = &now
+	t.CompletedBy = &userID
+}
+
+// Reset returns the task to pending
+func (t *PeriodCloseTask) Reset() {
+	t.Status = CloseTaskStatusPending
+	t.Note = ""
+	t.CompletedAt = nil
+	t.CompletedBy = nil
+}
+
+// PeriodChecklistItem combines a checklist task with its status for a period
+type PeriodChecklistItem struct {
+	Task   CloseChecklistTask `json:"task"`
+	Status *PeriodCloseTask   `json:"status,omitempty"`
+}
+
+// IsDone returns true if the task has been completed or skipped for the period
+func (i *PeriodChecklistItem) IsDone() bool {
+	return i.Status != nil && i.Status.Status.IsDone()
+}
+
+// PeriodChecklist is the checklist for a single fiscal period
+type PeriodChecklist struct {
+	FiscalYear  int                   `json:"fiscal_year"`
+	FiscalMonth int                   `json:"fiscal_month"`
+	Items       []PeriodChecklistItem `json:"items"`
+}
+
+// OutstandingMandatory returns mandatory tasks which are not yet done
+func (c *PeriodChecklist) OutstandingMandatory() []CloseChecklistTask {
+	var outstanding []CloseChecklistTask
+	for i := range c.Items {
+		if c.Items[i].Task.IsMandatory && !c.Items[i].IsDone() {
+			outstanding = append(outstanding, c.Items[i].Task)
+		}
+	}
+	return outstanding
+}
+
+// IsComplete returns true if all mandatory tasks are done
+func (c *PeriodChecklist) IsComplete() bool {
+	return len(c.OutstandingMandatory()) == 0
+}
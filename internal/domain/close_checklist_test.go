@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// PeriodCloseTask Tests
+// ============================================================================
+
+func TestPeriodCloseTask_CompleteAndReset(t *testing.T) {
+	userID := uuid.New()
+	task := &domain.PeriodCloseTask{Status: domain.CloseTaskStatusPending}
+
+	task.Complete(userID, "reconciled to statement")
+	assert.Equal(t, domain.CloseTaskStatusCompleted, task.Status)
+	require.NotNil(t, task.CompletedBy)
+	assert.Equal(t, userID, *task.CompletedBy)
+	assert.NotNil(t, task.CompletedAt)
+
+	task.Reset()
+	assert.Equal(t, domain.CloseTaskStatusPending, task.Status)
+	assert.Nil(t, task.CompletedBy)
+	assert.Nil(t, task.CompletedAt)
+	assert.Empty(t, task.Note)
+}
+
+// ============================================================================
+// PeriodChecklist Tests
+// ============================================================================
+
+func TestPeriodChecklist_IsComplete(t *testing.T) {
+	newTask := func(code string, mandatory bool) domain.CloseChecklistTask {
+		task := domain.CloseChecklistTask{Code: code, Name: code, IsMandatory: mandatory}
+		task.ID = uuid.New()
+		return task
+	}
+	status := func(s domain.CloseTaskStatus) *domain.PeriodCloseTask {
+		return &domain.PeriodCloseTask{Status: s}
+	}
+
+	tests := []struct {
+		name        string
+		items       []domain.PeriodChecklistItem
+		complete    bool
+		outstanding int
+	}{
+		{"empty checklist", nil, true, 0},
+		{"mandatory without status", []domain.PeriodChecklistItem{
+			{Task: newTask("BANK_REC", true)},
+		}, false, 1},
+		{"mandatory pending", []domain.PeriodChecklistItem{
+			{Task: newTask("BANK_REC", true), Status: status(domain.CloseTaskStatusPending)},
+		}, false, 1},
+		{"mandatory completed and skipped", []domain.PeriodChecklistItem{
+			{Task: newTask("BANK_REC", true), Status: status(domain.CloseTaskStatusCompleted)},
+			{Task: newTask("DEPRECIATION", true), Status: status(domain.CloseTaskStatusSkipped)},
+		}, true, 0},
+		{"optional task pending", []domain.PeriodChecklistItem{
+			{Task: newTask("BANK_REC", true), Status: status(domain.CloseTaskStatusCompleted)},
+			{Task: newTask("REVIEW", false)},
+		}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklist := &domain.PeriodChecklist{FiscalYear: 2024, FiscalMonth: 1, Items: tt.items}
+			assert.Equal(t, tt.complete, checklist.IsComplete())
+			assert.Len(t, checklist.OutstandingMandatory(), tt.outstanding)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Close confirmation errors
+var (
+	ErrCloseConfirmationNotFound     = errors.New("close confirmation not found")
+	ErrCloseConfirmationClosed       = errors.New("close confirmation is already closed")
+	ErrCloseConfirmationExpired      = errors.New("close confirmation has expired")
+	ErrCloseConfirmationMismatch     = errors.New("close does not match the pending confirmation")
+	ErrCloseSelfConfirmation         = errors.New("a close must be confirmed by another user")
+	ErrInvalidCloseConfirmationHours = errors.New("close confirmation hours must be between 1 and 168")
+)
+
+// Validity of close confirmations in hours
+const (
+	DefaultCloseConfirmationHours = 24
+	MaxCloseConfirmationHours     = 168
+)
+
+// Audit log actions of two-person closes
+const (
+	AuditActionCloseInitiate = "close_initiate"
+	AuditActionCloseConfirm  = "close_confirm"
+	AuditActionCloseCancel   = "close_cancel"
+	AuditActionCloseExpire   = "close_expire"
+
+	AuditEntityCloseConfirmation = "close_confirmation"
+)
+
+// CloseKind is the close a confirmation is for
+type CloseKind string
+
+const (
+	CloseKindPeriod  CloseKind = "period"   // a month, see LedgerService.ClosePeriod
+	CloseKindYearEnd CloseKind = "year_end" // the year rollover, see LedgerService.PerformYearEndClose
+)
+
+// CloseConfirmationStatus represents the state of a close confirmation
+type CloseConfirmationStatus string
+
+const (
+	CloseConfirmationPending   CloseConfirmationStatus = "pending"
+	CloseConfirmationConfirmed CloseConfirmationStatus = "confirmed" // the close was performed
+	CloseConfirmationCancelled CloseConfirmationStatus = "cancelled"
+	CloseConfirmationExpired   CloseConfirmationStatus = "expired" // not confirmed within the validity window
+)
+
+// CloseConfirmation is a period or year-end close initiated by one user under
+// dual control. The close is performed only when a different user confirms it
+// before ExpiresAt.
+type CloseConfirmation struct {
+	TenantModel
+
+	Kind  CloseKind `gorm:"type:varchar(20);not null" json:"kind"`
+	Year  int       `gorm:"not null" json:"year"`
+	Month int       `gorm:"not null;default:0" json:"month,omitempty"` // 0 for year-end closes
+
+	// Parameters of the close, applied as initiated when it is confirmed
+	Override                  bool       `gorm:"not null;default:false" json:"override,omitempty"`
+	RetainedEarningsAccountID *uuid.UUID `gorm:"type:uuid" json:"retained_earnings_account_id,omitempty"`
+
+	Status      CloseConfirmationStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	InitiatedBy uuid.UUID               `gorm:"type:uuid;not null" json:"initiated_by"`
+	ExpiresAt   time.Time               `gorm:"not null" json:"expires_at"`
+
+	ConfirmedBy *uuid.UUID `gorm:"type:uuid" json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CancelledBy *uuid.UUID `gorm:"type:uuid" json:"cancelled_by,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (CloseConfirmation) TableName() string {
+	return "close_confirmations"
+}
+
+// NewPeriodCloseConfirmation creates a pending confirmation of a period close
+func NewPeriodCloseConfirmation(companyID uuid.UUID, year, month int, override bool, initiatedBy uuid.UUID, validity time.Duration, now time.Time) *CloseConfirmation {
+	return &CloseConfirmation{
+		TenantModel: TenantModel{CompanyID: companyID},
+		Kind:        CloseKindPeriod,
+		Year:        year,
+		Month:       month,
+		Override:    override,
+		Status:      CloseConfirmationPending,
+		InitiatedBy: initiatedBy,
+		ExpiresAt:   now.Add(validity),
+	}
+}
+
+// NewYearEndCloseConfirmation creates a pending confirmation of a year-end close
+func NewYearEndCloseConfirmation(companyID uuid.UUID, year int, retainedEarningsAccountID, initiatedBy uuid.UUID, validity time.Duration, now time.Time) *CloseConfirmation {
+	return &CloseConfirmation{
+		TenantModel:               TenantModel{CompanyID: companyID},
+		Kind:                      CloseKindYearEnd,
+		Year:                      year,
+		RetainedEarningsAccountID: &retainedEarningsAccountID,
+		Status:                    CloseConfirmationPending,
+		InitiatedBy:               initiatedBy,
+		ExpiresAt:                 now.Add(validity),
+	}
+}
+
+// IsPending returns true if the close awaits confirmation
+func (c *CloseConfirmation) IsPending() bool {
+	return c.Status == CloseConfirmationPending
+}
+
+// IsExpired returns true if the confirmation is still pending past its validity window
+func (c *CloseConfirmation) IsExpired(now time.Time) bool {
+	return c.IsPending() && !now.Before(c.ExpiresAt)
+}
+
+// StatusAt returns the status, reporting pending confirmations past their
+// validity window as expired before they are marked so
+func (c *CloseConfirmation) StatusAt(now time.Time) CloseConfirmationStatus {
+	if c.IsExpired(now) {
+		return CloseConfirmationExpired
+	}
+	return c.Status
+}
+
+// Matches returns true if a close with the parameters is the one initiated
+func (c *CloseConfirmation) Matches(override bool, retainedEarningsAccountID *uuid.UUID) bool {
+	if c.Kind == CloseKindYearEnd {
+		return retainedEarningsAccountID != nil && c.RetainedEarningsAccountID != nil &&
+			*retainedEarningsAccountID == *c.RetainedEarningsAccountID
+	}
+	return c.Override == override
+}
+
+// Confirm records the confirmation by a user other than the initiator
+func (c *CloseConfirmation) Confirm(userID uuid.UUID, now time.Time) error {
+	if !c.IsPending() {
+		return ErrCloseConfirmationClosed
+	}
+	if c.IsExpired(now) {
+		return ErrCloseConfirmationExpired
+	}
+	if userID == c.InitiatedBy {
+		return ErrCloseSelfConfirmation
+	}
+	c.Status = CloseConfirmationConfirmed
+	c.ConfirmedBy = &userID
+	c.ConfirmedAt = &now
+	return nil
+}
+
+// Cancel withdraws a pending close
+func (c *CloseConfirmation) Cancel(userID uuid.UUID, now time.Time) error {
+	if !c.IsPending() {
+		return ErrCloseConfirmationClosed
+	}
+	c.Status = CloseConfirmationCancelled
+	c.CancelledBy = &userID
+	c.CancelledAt = &now
+	return nil
+}
+
+// Expire marks a pending confirmation past its validity window as expired
+func (c *CloseConfirmation) Expire() {
+	if c.IsPending() {
+		c.Status = CloseConfirmationExpired
+	}
+}
+
+// AuditLog builds the audit log entry of an action on the confirmation; the
+// new values are the state of the confirmation after it
+func (c *CloseConfirmation) AuditLog(action string, userID *uuid.UUID) (*AuditLog, error) {
+	values, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{
+		CompanyID:  c.CompanyID,
+		UserID:     userID,
+		Action:     action,
+		EntityType: AuditEntityCloseConfirmation,
+		EntityID:   &c.ID,
+		NewValues:  values,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// CloseConfirmation Tests
+// ============================================================================
+
+func TestCloseConfirmation_Confirm(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	initiator, confirmer := uuid.New(), uuid.New()
+	newConfirmation := func() *domain.CloseConfirmation {
+		return domain.NewPeriodCloseConfirmation(uuid.New(), 2026, 3, false, initiator, 24*time.Hour, now)
+	}
+
+	confirmation := newConfirmation()
+	assert.True(t, confirmation.IsPending())
+	assert.ErrorIs(t, confirmation.Confirm(initiator, now.Add(time.Hour)), domain.ErrCloseSelfConfirmation)
+	require.NoError(t, confirmation.Confirm(confirmer, now.Add(time.Hour)))
+	assert.Equal(t, domain.CloseConfirmationConfirmed, confirmation.Status)
+	assert.Equal(t, confirmer, *confirmation.ConfirmedBy)
+	assert.ErrorIs(t, confirmation.Confirm(confirmer, now.Add(time.Hour)), domain.ErrCloseConfirmationClosed)
+	assert.ErrorIs(t, confirmation.Cancel(initiator, now.Add(time.Hour)), domain.ErrCloseConfirmationClosed)
+
+	expired := newConfirmation()
+	assert.Equal(t, domain.CloseConfirmationPending, expired.StatusAt(now.Add(23*time.Hour)))
+	assert.Equal(t, domain.CloseConfirmationExpired, expired.StatusAt(now.Add(24*time.Hour)))
+	assert.ErrorIs(t, expired.Confirm(confirmer, now.Add(24*time.Hour)), domain.ErrCloseConfirmationExpired)
+	expired.Expire()
+	assert.Equal(t, domain.CloseConfirmationExpired, expired.Status)
+
+	cancelled := newConfirmation()
+	require.NoError(t, cancelled.Cancel(initiator, now))
+	assert.Equal(t, domain.CloseConfirmationCancelled, cancelled.Status)
+	assert.ErrorIs(t, cancelled.Confirm(confirmer, now), domain.ErrCloseConfirmationClosed)
+}
+
+func TestCloseConfirmation_Matches(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	accountID := uuid.New()
+
+	period := domain.NewPeriodCloseConfirmation(uuid.New(), 2026, 3, true, uuid.New(), time.Hour, now)
+	assert.True(t, period.Matches(true, nil))
+	assert.False(t, period.Matches(false, nil))
+
+	yearEnd := domain.NewYearEndCloseConfirmation(uuid.New(), 2025, accountID, uuid.New(), time.Hour, now)
+	assert.Equal(t, 0, yearEnd.Month)
+	assert.True(t, yearEnd.Matches(false, &accountID))
+	other := uuid.New()
+	assert.False(t, yearEnd.Matches(false, &other))
+	assert.False(t, yearEnd.Matches(false, nil))
+}
+
+func TestCloseConfirmation_AuditLog(t *testing.T) {
+	initiator := uuid.New()
+	confirmation := domain.NewPeriodCloseConfirmation(uuid.New(), 2026, 3, false, initiator, time.Hour, time.Now())
+	confirmation.ID = uuid.New()
+
+	log, err := confirmation.AuditLog(domain.AuditActionCloseInitiate, &initiator)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AuditEntityCloseConfirmation, log.EntityType)
+	assert.Equal(t, confirmation.ID, *log.EntityID)
+	assert.Equal(t, confirmation.CompanyID, log.CompanyID)
+
+	var values map[string]interface{}
+	require.NoError(t, json.Unmarshal(log.NewValues, &values))
+	assert.Equal(t, "period", values["kind"])
+	assert.Equal(t, "pending", values["status"])
+	assert.Equal(t, initiator.String(), values["initiated_by"])
+}
+
+func TestCompanySettings_CloseConfirmationValidity(t *testing.T) {
+	settings := domain.DefaultCompanySettings()
+	assert.Equal(t, 24*time.Hour, settings.CloseConfirmationValidity())
+
+	hours := 4
+	settings.CloseConfirmationHours = &hours
+	assert.Equal(t, 4*time.Hour, settings.CloseConfirmationValidity())
+	assert.NoError(t, settings.Validate())
+
+	hours = 169
+	assert.ErrorIs(t, settings.Validate(), domain.ErrInvalidCloseConfirmationHours)
+}
@@ -44,6 +44,17 @@ type CompanySettings struct {
 	Timezone           string `json:"timezone"`               // Timezone: Asia/Seoul
 	DateFormat         string `json:"date_format"`            // Date format: YYYY-MM-DD
 	Language           string `json:"language"`               // Default language: ko, en
+	RequireApprovalSignature bool `json:"require_approval_signature"` // Approve/Post must carry a signature or PIN
+	RoundingRule RoundingRule `json:"rounding_rule,omitempty"` // Rounding of amounts to DecimalPlaces; empty means truncate
+	RequireApproval *bool `json:"require_approval,omitempty"` // Vouchers must be approved before posting; nil means required
+	ApprovalExemption *ApprovalExemption `json:"approval_exemption,omitempty"` // Low-value vouchers posted on submit; nil means none
+	DuplicateCheckMode DuplicateCheckMode `json:"duplicate_check_mode,omitempty"` // New vouchers matching an existing one; empty means warn
+	DuplicateCheckDays *int `json:"duplicate_check_days,omitempty"` // Days around the voucher date searched; nil means 7
+	EnforceSegregationOfDuties bool `json:"enforce_segregation_of_duties,omitempty"` // Creators and submitters cannot approve or post their vouchers
+	RetainedEarningsAccountID *uuid.UUID `json:"retained_earnings_account_id,omitempty"` // Equity account taking net income at the year rollover
+	RequireDualCloseControl bool `json:"require_dual_close_control,omitempty"` // Period and year-end closes must be confirmed by a second user
+	CloseConfirmationHours *int `json:"close_confirmation_hours,omitempty"` // Hours a close awaits confirmation; nil means 24
+	PartnerCodeRule *PartnerCodeRule `json:"partner_code_rule,omitempty"` // Codes of partners created without one; nil means five digits without prefix
 }
 
 // DefaultCompanySettings returns default settings for a new company
@@ -59,6 +70,7 @@ func DefaultCompanySettings() CompanySettings {
 		Timezone:           "Asia/Seoul",
 		DateFormat:         "YYYY-MM-DD",
 		Language:           "ko",
+		RoundingRule:       RoundingTruncate,
 	}
 }
 
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Company settings errors
+var (
+	ErrInvalidFiscalYearStart = errors.New("fiscal year start must be a month between 1 and 12")
+	ErrInvalidDecimalPlaces   = errors.New("decimal places must be between 0 and 4")
+	ErrInvalidRoundingRule    = errors.New("invalid rounding rule")
+	ErrInvalidDefaultTaxRate  = errors.New("default tax rate must be between 0 and 100")
+
+	ErrInvalidApprovalExemption = errors.New("approval exemption needs a positive maximum amount and at least one valid voucher type")
+)
+
+// RoundingRule determines how amounts are rounded to the company's decimal places
+type RoundingRule string
+
+const (
+	RoundingTruncate RoundingRule = "truncate" // 절사
+	RoundingHalfUp   RoundingRule = "half_up"  // 반올림
+	RoundingUp       RoundingRule = "up"       // 절상
+)
+
+// IsValid checks if the rounding rule is valid
+func (r RoundingRule) IsValid() bool {
+	switch r {
+	case RoundingTruncate, RoundingHalfUp, RoundingUp:
+		return true
+	}
+	return false
+}
+
+// Round rounds v to the given number of decimal places. Negative amounts are
+// rounded by magnitude, so -1.5 truncates to -1 and rounds up to -2.
+func (r RoundingRule) Round(v float64, places int) float64 {
+	scale := math.Pow10(places)
+	x := math.Abs(v) * scale
+	// Absorb binary representation error, e.g. 1.005 * 100 = 100.49999999999999
+	const epsilon = 1e-9
+	switch r {
+	case RoundingHalfUp:
+		x = math.Floor(x + 0.5 + epsilon)
+	case RoundingUp:
+		x = math.Ceil(x - epsilon)
+	default:
+		x = math.Floor(x + epsilon)
+	}
+	return math.Copysign(x/scale, v)
+}
+
+// Rounding returns the configured rounding rule. Settings saved before the rule
+// existed truncate, which is how VAT has always been calculated (원 미만 절사).
+func (s CompanySettings) Rounding() RoundingRule {
+	if s.RoundingRule == "" {
+		return RoundingTruncate
+	}
+	return s.RoundingRule
+}
+
+// RoundAmount rounds an amount to the company's decimal places using its rounding rule
+func (s CompanySettings) RoundAmount(v float64) float64 {
+	return s.Rounding().Round(v, s.DecimalPlaces)
+}
+
+// ApprovalRequired returns true if vouchers must be approved before they can be posted
+func (s CompanySettings) ApprovalRequired() bool {
+	return s.RequireApproval == nil || *s.RequireApproval
+}
+
+// ApprovalExemption lets vouchers of the listed types whose total is below
+// MaxAmount skip the approval queue: they are posted as soon as they are submitted
+type ApprovalExemption struct {
+	Enabled      bool          `json:"enabled"`
+	MaxAmount    float64       `json:"max_amount"` // exclusive
+	VoucherTypes []VoucherType `json:"voucher_types"`
+}
+
+// Validate checks the exemption; a disabled exemption is kept as entered
+func (e *ApprovalExemption) Validate() error {
+	if !e.Enabled {
+		return nil
+	}
+	if e.MaxAmount <= 0 || len(e.VoucherTypes) == 0 {
+		return ErrInvalidApprovalExemption
+	}
+	for _, t := range e.VoucherTypes {
+		if !t.IsValid() {
+			return ErrInvalidApprovalExemption
+		}
+	}
+	return nil
+}
+
+// Exempts returns true if the voucher may be posted without approval
+func (e *ApprovalExemption) Exempts(v *Voucher) bool {
+	if e == nil || !e.Enabled || v.TotalDebit >= e.MaxAmount {
+		return false
+	}
+	for _, t := range e.VoucherTypes {
+		if t == v.VoucherType {
+			return true
+		}
+	}
+	return false
+}
+
+// Reason describes the exemption in the voucher's status history
+func (e *ApprovalExemption) Reason(v *Voucher) string {
+	return fmt.Sprintf("approval exempt: %s voucher below %s", v.VoucherType, strconv.FormatFloat(e.MaxAmount, 'f', -1, 64))
+}
+
+// ApprovalExempt returns true if a submitted voucher is posted right away.
+// Exemptions only matter while approval is required, and never apply when
+// approvals must be signed since no signature is captured on submit.
+func (s CompanySettings) ApprovalExempt(v *Voucher) bool {
+	return s.ApprovalRequired() && !s.RequireApprovalSignature && s.ApprovalExemption.Exempts(v)
+}
+
+// CloseConfirmationValidity returns how long a close initiated under dual
+// control awaits confirmation
+func (s CompanySettings) CloseConfirmationValidity() time.Duration {
+	hours := DefaultCloseConfirmationHours
+	if s.CloseConfirmationHours != nil {
+		hours = *s.CloseConfirmationHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// Validate checks the settings values
+func (s CompanySettings) Validate() error {
+	if s.FiscalYearStart < 1 || s.FiscalYearStart > 12 {
+		return ErrInvalidFiscalYearStart
+	}
+	if s.DecimalPlaces < 0 || s.DecimalPlaces > 4 {
+		return ErrInvalidDecimalPlaces
+	}
+	if s.RoundingRule != "" && !s.RoundingRule.IsValid() {
+		return ErrInvalidRoundingRule
+	}
+	if s.TaxRate < 0 || s.TaxRate > 100 {
+		return ErrInvalidDefaultTaxRate
+	}
+	if s.ApprovalExemption != nil {
+		if err := s.ApprovalExemption.Validate(); err != nil {
+			return err
+		}
+	}
+	if h := s.CloseConfirmationHours; h != nil && (*h < 1 || *h > MaxCloseConfirmationHours) {
+		return ErrInvalidCloseConfirmationHours
+	}
+	if s.PartnerCodeRule != nil {
+		if err := s.PartnerCodeRule.Validate(); err != nil {
+			return err
+		}
+	}
+	return s.validateDuplicateCheck()
+}
@@ -0,0 +1,111 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// CompanySettings Tests
+// ============================================================================
+
+func TestRoundingRule_Round(t *testing.T) {
+	tests := []struct {
+		rule   domain.RoundingRule
+		value  float64
+		places int
+		want   float64
+	}{
+		{domain.RoundingTruncate, 1234.9, 0, 1234},
+		{domain.RoundingHalfUp, 1234.5, 0, 1235},
+		{domain.RoundingHalfUp, 1234.49, 0, 1234},
+		{domain.RoundingUp, 1234.1, 0, 1235},
+		{domain.RoundingHalfUp, 1.005, 2, 1.01},
+		{domain.RoundingTruncate, -1.5, 0, -1},
+		{domain.RoundingUp, -1.5, 0, -2},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.rule.Round(tt.value, tt.places), "%s %v", tt.rule, tt.value)
+	}
+}
+
+func TestCompanySettings_Defaults(t *testing.T) {
+	// Settings stored before rounding and approval existed keep the previous behavior
+	var legacy domain.CompanySettings
+	assert.Equal(t, domain.RoundingTruncate, legacy.Rounding())
+	assert.True(t, legacy.ApprovalRequired())
+
+	requireApproval := false
+	legacy.RequireApproval = &requireApproval
+	assert.False(t, legacy.ApprovalRequired())
+}
+
+func TestTaxCode_SplitInclusiveFor(t *testing.T) {
+	taxCode := &domain.TaxCode{TaxCategory: domain.TaxCategoryTaxable, Rate: 10}
+
+	supply, tax := taxCode.SplitInclusive(10005)
+	assert.Equal(t, 9096.0, supply)
+	assert.Equal(t, 909.0, tax)
+
+	settings := domain.DefaultCompanySettings()
+	settings.RoundingRule = domain.RoundingHalfUp
+	supply, tax = taxCode.SplitInclusiveFor(10005, settings)
+	assert.Equal(t, 9095.0, supply)
+	assert.Equal(t, 910.0, tax)
+}
+
+func TestCompanySettings_Validate(t *testing.T) {
+	settings := domain.DefaultCompanySettings()
+	assert.NoError(t, settings.Validate())
+
+	settings.RoundingRule = "bankers"
+	assert.Equal(t, domain.ErrInvalidRoundingRule, settings.Validate())
+
+	settings = domain.DefaultCompanySettings()
+	settings.FiscalYearStart = 13
+	assert.Equal(t, domain.ErrInvalidFiscalYearStart, settings.Validate())
+
+	settings = domain.DefaultCompanySettings()
+	assert.Equal(t, domain.DuplicateCheckWarn, settings.DuplicateCheck())
+	assert.Equal(t, 7, settings.DuplicateCheckWindow())
+	days := 120
+	settings.DuplicateCheckDays = &days
+	assert.Equal(t, domain.ErrInvalidDuplicateCheck, settings.Validate())
+}
+
+func TestCompanySettings_ApprovalExempt(t *testing.T) {
+	settings := domain.DefaultCompanySettings()
+	voucher := &domain.Voucher{VoucherType: domain.VoucherTypePayment, TotalDebit: 49000}
+	assert.False(t, settings.ApprovalExempt(voucher))
+
+	settings.ApprovalExemption = &domain.ApprovalExemption{
+		Enabled:      true,
+		MaxAmount:    50000,
+		VoucherTypes: []domain.VoucherType{domain.VoucherTypePayment},
+	}
+	assert.NoError(t, settings.Validate())
+	assert.True(t, settings.ApprovalExempt(voucher))
+	assert.Equal(t, "approval exempt: payment voucher below 50000", settings.ApprovalExemption.Reason(voucher))
+
+	voucher.TotalDebit = 50000
+	assert.False(t, settings.ApprovalExempt(voucher), "the maximum amount is exclusive")
+
+	voucher.TotalDebit = 1000
+	voucher.VoucherType = domain.VoucherTypeGeneral
+	assert.False(t, settings.ApprovalExempt(voucher))
+
+	voucher.VoucherType = domain.VoucherTypePayment
+	settings.RequireApprovalSignature = true
+	assert.False(t, settings.ApprovalExempt(voucher), "signed approvals are never skipped")
+
+	settings.RequireApprovalSignature = false
+	settings.ApprovalExemption.VoucherTypes = nil
+	assert.Equal(t, domain.ErrInvalidApprovalExemption, settings.Validate())
+
+	settings.ApprovalExemption.Enabled = false
+	assert.NoError(t, settings.Validate())
+}
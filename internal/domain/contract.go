@@ -0,0 +1,313 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Contract errors
+var (
+	ErrContractNotFound           = errors.New("contract not found")
+	ErrContractNoExists           = errors.New("contract number already exists")
+	ErrInvalidContract            = errors.New("invalid contract")
+	ErrContractMilestoneNotFound  = errors.New("contract milestone not found")
+	ErrContractMilestoneInvoiced  = errors.New("contract milestone is already invoiced")
+	ErrContractMilestoneScheduled = errors.New("contract milestone is not invoiced yet")
+	ErrContractMilestoneChanged   = errors.New("contract milestone was changed meanwhile")
+	ErrInvalidContractCollection  = errors.New("invalid contract collection")
+	ErrContractInvoiceNoExists    = errors.New("tax invoice number already exists")
+	ErrContractInvoiceIncomplete  = errors.New("business details of the company or partner are incomplete for a tax invoice")
+)
+
+// ContractStatus is the status of a contract
+type ContractStatus string
+
+const (
+	ContractStatusActive    ContractStatus = "active"    // milestones left to invoice or collect
+	ContractStatusCompleted ContractStatus = "completed" // every milestone invoiced and collected
+)
+
+// IsValid checks if the status is valid
+func (s ContractStatus) IsValid() bool {
+	return s == ContractStatusActive || s == ContractStatusCompleted
+}
+
+// ContractMilestoneStatus is the billing status of a milestone
+type ContractMilestoneStatus string
+
+const (
+	ContractMilestoneScheduled ContractMilestoneStatus = "scheduled" // not invoiced yet
+	ContractMilestoneInvoiced  ContractMilestoneStatus = "invoiced"  // tax invoice drafted, not fully collected
+	ContractMilestoneCollected ContractMilestoneStatus = "collected" // invoiced total collected
+)
+
+// IsValid checks if the status is valid
+func (s ContractMilestoneStatus) IsValid() bool {
+	switch s {
+	case ContractMilestoneScheduled, ContractMilestoneInvoiced, ContractMilestoneCollected:
+		return true
+	}
+	return false
+}
+
+// ContractMilestoneReminderDays is how many days before its due date a
+// milestone not invoiced yet is notified
+const ContractMilestoneReminderDays = 7
+
+// Contract is a sales contract with a partner (계약), billed in milestones
+// (청구 일정). Invoicing a milestone drafts its sales tax invoice and the
+// voucher of the receivable; collections are booked against the receivable.
+// Amounts are supply amounts in won, VAT is added on invoicing.
+type Contract struct {
+	TenantModel
+
+	ContractNo  string    `gorm:"type:varchar(50);not null" json:"contract_no"`
+	Title       string    `gorm:"type:varchar(200);not null" json:"title"`
+	PartnerID   uuid.UUID `gorm:"type:uuid;not null" json:"partner_id"`
+	StartDate   time.Time `gorm:"type:date;not null" json:"start_date"`
+	EndDate     time.Time `gorm:"type:date;not null" json:"end_date"`
+	Amount      int64     `gorm:"not null" json:"amount"` // contracted supply amount, the total of the milestones
+	Description string    `gorm:"type:varchar(500)" json:"description,omitempty"`
+
+	// Booking of the invoices
+	TaxCodeID           *uuid.UUID `gorm:"type:uuid" json:"tax_code_id,omitempty"` // output VAT; none invoices without VAT
+	RevenueAccountID    uuid.UUID  `gorm:"type:uuid;not null" json:"revenue_account_id"`
+	ReceivableAccountID uuid.UUID  `gorm:"type:uuid;not null" json:"receivable_account_id"` // defaults to the partner's receivable
+
+	Status ContractStatus `gorm:"type:varchar(20);not null;default:active" json:"status"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	Milestones []ContractMilestone `gorm:"foreignKey:ContractID" json:"milestones,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Contract) TableName() string {
+	return "contracts"
+}
+
+// ContractMilestone is a billing milestone of a contract
+type ContractMilestone struct {
+	TenantModel
+
+	ContractID uuid.UUID               `gorm:"type:uuid;not null" json:"contract_id"`
+	Seq        int                     `gorm:"not null" json:"seq"`
+	Name       string                  `gorm:"type:varchar(200);not null" json:"name"` // 계약금, 중도금, 잔금 ...
+	DueDate    time.Time               `gorm:"type:date;not null" json:"due_date"`
+	Amount     int64                   `gorm:"not null" json:"amount"` // supply amount
+	Status     ContractMilestoneStatus `gorm:"type:varchar(20);not null;default:scheduled" json:"status"`
+
+	// Invoicing
+	InvoiceDate  *time.Time `gorm:"type:date" json:"invoice_date,omitempty"`
+	TaxAmount    int64      `gorm:"not null;default:0" json:"tax_amount"`
+	TaxInvoiceID *uuid.UUID `gorm:"type:uuid" json:"tax_invoice_id,omitempty"`
+	VoucherID    *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+
+	CollectedAmount int64      `gorm:"not null;default:0" json:"collected_amount"`
+	RemindedAt      *time.Time `json:"reminded_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (ContractMilestone) TableName() string {
+	return "contract_milestones"
+}
+
+// ContractCollection is a receipt of an invoiced milestone, with its voucher
+type ContractCollection struct {
+	TenantModel
+
+	ContractID    uuid.UUID  `gorm:"type:uuid;not null" json:"contract_id"`
+	MilestoneID   uuid.UUID  `gorm:"type:uuid;not null" json:"milestone_id"`
+	CollectedDate time.Time  `gorm:"type:date;not null" json:"collected_date"`
+	Amount        int64      `gorm:"not null" json:"amount"`
+	BankAccountID uuid.UUID  `gorm:"type:uuid;not null" json:"bank_account_id"`
+	VoucherID     *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	CreatedBy     *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (ContractCollection) TableName() string {
+	return "contract_collections"
+}
+
+// Validate checks the terms of a new contract and numbers its milestones in
+// due date order. The milestones must fall within the contract period and
+// add up to the contract amount.
+func (c *Contract) Validate() error {
+	c.ContractNo = strings.TrimSpace(c.ContractNo)
+	c.Title = strings.TrimSpace(c.Title)
+	switch {
+	case c.ContractNo == "", c.Title == "", c.PartnerID == uuid.Nil, c.Amount <= 0,
+		c.StartDate.IsZero(), c.EndDate.Before(c.StartDate),
+		c.RevenueAccountID == uuid.Nil, c.ReceivableAccountID == uuid.Nil, c.RevenueAccountID == c.ReceivableAccountID,
+		len(c.Milestones) == 0:
+		return ErrInvalidContract
+	}
+
+	var total int64
+	for i := range c.Milestones {
+		m := &c.Milestones[i]
+		m.Name = strings.TrimSpace(m.Name)
+		if m.Name == "" || m.Amount <= 0 || m.DueDate.Before(c.StartDate) || m.DueDate.After(c.EndDate) {
+			return ErrInvalidContract
+		}
+		total += m.Amount
+	}
+	if total != c.Amount {
+		return ErrInvalidContract
+	}
+
+	sort.SliceStable(c.Milestones, func(i, j int) bool {
+		return c.Milestones[i].DueDate.Before(c.Milestones[j].DueDate)
+	})
+	for i := range c.Milestones {
+		c.Milestones[i].CompanyID = c.CompanyID
+		c.Milestones[i].Seq = i + 1
+		c.Milestones[i].Status = ContractMilestoneScheduled
+	}
+	c.Status = ContractStatusActive
+	return nil
+}
+
+// Milestone returns the milestone of the contract with the ID
+func (c *Contract) Milestone(id uuid.UUID) (*ContractMilestone, error) {
+	for i := range c.Milestones {
+		if c.Milestones[i].ID == id {
+			return &c.Milestones[i], nil
+		}
+	}
+	return nil, ErrContractMilestoneNotFound
+}
+
+// IsSettled returns true if every milestone is invoiced and collected
+func (c *Contract) IsSettled() bool {
+	for i := range c.Milestones {
+		if c.Milestones[i].Status != ContractMilestoneCollected {
+			return false
+		}
+	}
+	return len(c.Milestones) > 0
+}
+
+// Total returns the invoiced total of the milestone, VAT included
+func (m *ContractMilestone) Total() int64 {
+	return m.Amount + m.TaxAmount
+}
+
+// Outstanding returns the invoiced total not collected yet
+func (m *ContractMilestone) Outstanding() int64 {
+	if m.Status == ContractMilestoneScheduled {
+		return 0
+	}
+	return m.Total() - m.CollectedAmount
+}
+
+// Invoice records the tax invoice of the milestone, issued on the date
+func (m *ContractMilestone) Invoice(date time.Time, taxAmount int64) error {
+	if m.Status != ContractMilestoneScheduled {
+		return ErrContractMilestoneInvoiced
+	}
+	m.Status = ContractMilestoneInvoiced
+	m.InvoiceDate = &date
+	m.TaxAmount = taxAmount
+	return nil
+}
+
+// Collect records a receipt of the invoiced total
+func (m *ContractMilestone) Collect(date time.Time, amount int64) error {
+	if m.Status == ContractMilestoneScheduled {
+		return ErrContractMilestoneScheduled
+	}
+	if amount <= 0 || amount > m.Outstanding() || date.Before(*m.InvoiceDate) {
+		return ErrInvalidContractCollection
+	}
+	m.CollectedAmount += amount
+	if m.CollectedAmount == m.Total() {
+		m.Status = ContractMilestoneCollected
+	}
+	return nil
+}
+
+// DaysToDue returns the days from today to the due date, negative when overdue
+func (m *ContractMilestone) DaysToDue(today time.Time) int {
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	due := time.Date(m.DueDate.Year(), m.DueDate.Month(), m.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+	return int(due.Sub(day).Hours() / 24)
+}
+
+// ContractBillingRow is a contract of the billing report: the contracted,
+// invoiced and collected amounts. Contracted and invoiced supply amounts
+// exclude VAT; the invoiced total and the collections include it.
+type ContractBillingRow struct {
+	ContractID     uuid.UUID      `json:"contract_id"`
+	ContractNo     string         `json:"contract_no"`
+	Title          string         `json:"title"`
+	PartnerID      uuid.UUID      `json:"partner_id"`
+	PartnerName    string         `json:"partner_name"`
+	EndDate        time.Time      `json:"end_date"`
+	Status         ContractStatus `json:"status"`
+	Contracted     int64          `json:"contracted"`
+	InvoicedSupply int64          `json:"invoiced_supply"`
+	InvoicedTotal  int64          `json:"invoiced_total"`
+	Collected      int64          `json:"collected"`
+	Uninvoiced     int64          `json:"uninvoiced"`  // contracted supply not invoiced yet
+	Outstanding    int64          `json:"outstanding"` // invoiced total not collected yet
+	OverdueCount   int            `json:"overdue_count"`
+}
+
+// ContractBillingReport is the contracted vs invoiced vs collected report
+type ContractBillingReport struct {
+	AsOf   time.Time            `json:"as_of"`
+	Rows   []ContractBillingRow `json:"rows"`
+	Totals ContractBillingRow   `json:"totals"`
+}
+
+// NewContractBillingRow sums the milestones of the contract; milestones due
+// before the day and not invoiced are overdue
+func NewContractBillingRow(c *Contract, partnerName string, asOf time.Time) ContractBillingRow {
+	row := ContractBillingRow{
+		ContractID:  c.ID,
+		ContractNo:  c.ContractNo,
+		Title:       c.Title,
+		PartnerID:   c.PartnerID,
+		PartnerName: partnerName,
+		EndDate:     c.EndDate,
+		Status:      c.Status,
+		Contracted:  c.Amount,
+	}
+	for i := range c.Milestones {
+		m := &c.Milestones[i]
+		if m.Status == ContractMilestoneScheduled {
+			if m.DaysToDue(asOf) < 0 {
+				row.OverdueCount++
+			}
+			continue
+		}
+		row.InvoicedSupply += m.Amount
+		row.InvoicedTotal += m.Total()
+		row.Collected += m.CollectedAmount
+	}
+	row.Uninvoiced = row.Contracted - row.InvoicedSupply
+	row.Outstanding = row.InvoicedTotal - row.Collected
+	return row
+}
+
+// NewContractBillingReport builds the report from its rows
+func NewContractBillingReport(asOf time.Time, rows []ContractBillingRow) *ContractBillingReport {
+	report := &ContractBillingReport{AsOf: asOf, Rows: rows}
+	for _, row := range rows {
+		report.Totals.Contracted += row.Contracted
+		report.Totals.InvoicedSupply += row.InvoicedSupply
+		report.Totals.InvoicedTotal += row.InvoicedTotal
+		report.Totals.Collected += row.Collected
+		report.Totals.Uninvoiced += row.Uninvoiced
+		report.Totals.Outstanding += row.Outstanding
+		report.Totals.OverdueCount += row.OverdueCount
+	}
+	return report
+}
@@ -0,0 +1,110 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newContract() *domain.Contract {
+	return &domain.Contract{
+		ContractNo:          " C-2026-001 ",
+		Title:               "ERP 구축",
+		PartnerID:           uuid.New(),
+		StartDate:           date(2026, 3, 1),
+		EndDate:             date(2026, 8, 31),
+		Amount:              50000000,
+		RevenueAccountID:    uuid.New(),
+		ReceivableAccountID: uuid.New(),
+		Milestones: []domain.ContractMilestone{
+			{Name: "잔금", DueDate: date(2026, 8, 31), Amount: 20000000},
+			{Name: "계약금", DueDate: date(2026, 3, 1), Amount: 10000000},
+			{Name: "중도금", DueDate: date(2026, 5, 31), Amount: 20000000},
+		},
+	}
+}
+
+func TestContract_Validate(t *testing.T) {
+	contract := newContract()
+	require.NoError(t, contract.Validate())
+	assert.Equal(t, "C-2026-001", contract.ContractNo)
+	assert.Equal(t, domain.ContractStatusActive, contract.Status)
+	// Milestones are numbered in due date order
+	assert.Equal(t, "계약금", contract.Milestones[0].Name)
+	assert.Equal(t, 3, contract.Milestones[2].Seq)
+	assert.Equal(t, domain.ContractMilestoneScheduled, contract.Milestones[2].Status)
+
+	short := newContract()
+	short.Milestones[0].Amount--
+	assert.ErrorIs(t, short.Validate(), domain.ErrInvalidContract)
+
+	late := newContract()
+	late.Milestones[0].DueDate = date(2026, 9, 1)
+	assert.ErrorIs(t, late.Validate(), domain.ErrInvalidContract)
+
+	sameAccount := newContract()
+	sameAccount.ReceivableAccountID = sameAccount.RevenueAccountID
+	assert.ErrorIs(t, sameAccount.Validate(), domain.ErrInvalidContract)
+}
+
+func TestContractMilestone_InvoiceAndCollect(t *testing.T) {
+	contract := newContract()
+	require.NoError(t, contract.Validate())
+	m := &contract.Milestones[0]
+
+	assert.ErrorIs(t, m.Collect(date(2026, 3, 5), 1000), domain.ErrContractMilestoneScheduled)
+	require.NoError(t, m.Invoice(date(2026, 3, 2), 1000000))
+	assert.ErrorIs(t, m.Invoice(date(2026, 3, 2), 1000000), domain.ErrContractMilestoneInvoiced)
+	assert.Equal(t, int64(11000000), m.Total())
+
+	assert.ErrorIs(t, m.Collect(date(2026, 3, 1), 1000), domain.ErrInvalidContractCollection)
+	assert.ErrorIs(t, m.Collect(date(2026, 3, 5), 11000001), domain.ErrInvalidContractCollection)
+	require.NoError(t, m.Collect(date(2026, 3, 5), 6000000))
+	assert.Equal(t, domain.ContractMilestoneInvoiced, m.Status)
+	assert.Equal(t, int64(5000000), m.Outstanding())
+	require.NoError(t, m.Collect(date(2026, 3, 20), 5000000))
+	assert.Equal(t, domain.ContractMilestoneCollected, m.Status)
+	assert.False(t, contract.IsSettled())
+}
+
+func TestNewContractBillingRow(t *testing.T) {
+	contract := newContract()
+	require.NoError(t, contract.Validate())
+	require.NoError(t, contract.Milestones[0].Invoice(date(2026, 3, 2), 1000000))
+	require.NoError(t, contract.Milestones[0].Collect(date(2026, 3, 5), 4000000))
+
+	row := domain.NewContractBillingRow(contract, "(주)한빛상사", date(2026, 6, 10))
+	assert.Equal(t, int64(50000000), row.Contracted)
+	assert.Equal(t, int64(10000000), row.InvoicedSupply)
+	assert.Equal(t, int64(11000000), row.InvoicedTotal)
+	assert.Equal(t, int64(4000000), row.Collected)
+	assert.Equal(t, int64(40000000), row.Uninvoiced)
+	assert.Equal(t, int64(7000000), row.Outstanding)
+	assert.Equal(t, 1, row.OverdueCount) // 중도금 due on May 31
+
+	report := domain.NewContractBillingReport(date(2026, 6, 10), []domain.ContractBillingRow{row, row})
+	assert.Equal(t, int64(14000000), report.Totals.Outstanding)
+}
+
+func TestTaxCode_TaxOnMatchesSplit(t *testing.T) {
+	taxCode := &domain.TaxCode{TaxType: domain.TaxTypeOutput, TaxCategory: domain.TaxCategoryTaxable, Rate: 10}
+	settings := domain.DefaultCompanySettings()
+	for _, supply := range []float64{1234567, 1000005, 99999, 15} {
+		tax := taxCode.TaxOn(supply, settings)
+		splitSupply, splitTax := taxCode.SplitInclusiveFor(supply+tax, settings)
+		assert.Equal(t, supply, splitSupply)
+		assert.Equal(t, tax, splitTax)
+	}
+
+	zeroRated := &domain.TaxCode{TaxType: domain.TaxTypeOutput, TaxCategory: domain.TaxCategoryZeroRated}
+	assert.Zero(t, zeroRated.TaxOn(1000000, settings))
+}
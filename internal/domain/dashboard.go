@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPaymentTermDays is the payment term of partners without one
+const DefaultPaymentTermDays = 30
+
+// DashboardSummary is the main screen of a company: the results of the
+// current month, the cash position, the overdue receivables and payables, the
+// approvals waiting for the user and the latest activity
+type DashboardSummary struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+
+	// Current month, from the ledger balances
+	Revenue   float64 `json:"revenue"`
+	Expense   float64 `json:"expense"`
+	NetIncome float64 `json:"net_income"`
+
+	// Balance of the designated cash and bank accounts
+	CashBalance float64 `json:"cash_balance"`
+
+	// Partner balances older than the partner's payment term
+	OverdueReceivables float64 `json:"overdue_receivables"`
+	OverduePayables    float64 `json:"overdue_payables"`
+
+	// Pending documents the user may approve; not shared between users
+	PendingApprovals int `json:"pending_approvals"`
+
+	RecentActivity []Activity `json:"recent_activity"`
+	GeneratedAt    time.Time  `json:"generated_at"`
+}
+
+// SetMonthResult sets the revenue, expense and net income of the month from
+// the ledger balances of the revenue and expense accounts
+func (d *DashboardSummary) SetMonthResult(revenues, expenses []LedgerBalance) {
+	d.Revenue, d.Expense = 0, 0
+	for _, b := range revenues {
+		d.Revenue += b.PeriodCredit - b.PeriodDebit
+	}
+	for _, b := range expenses {
+		d.Expense += b.PeriodDebit - b.PeriodCredit
+	}
+	d.NetIncome = d.Revenue - d.Expense
+}
+
+// PartnerOverdue computes the overdue receivables and payables as of the given
+// day. Vouchers carry no due dates, so a partner's balance is taken to be
+// settled oldest first: the part of a receivable (positive) balance not made up
+// of debits within the partner's payment term is overdue, and likewise for a
+// payable (negative) balance and the credits within the term. Entries must
+// cover at least the longest term before asOf; terms default to
+// DefaultPaymentTermDays.
+func PartnerOverdue(balances map[uuid.UUID]float64, entries []PartnerLedgerEntry, terms map[uuid.UUID]int, asOf time.Time) (receivables, payables float64) {
+	type recent struct{ debit, credit float64 }
+	recents := make(map[uuid.UUID]*recent)
+	for _, e := range entries {
+		if !e.VoucherDate.After(PaymentTermStart(asOf, terms[e.PartnerID])) {
+			continue
+		}
+		r := recents[e.PartnerID]
+		if r == nil {
+			r = &recent{}
+			recents[e.PartnerID] = r
+		}
+		r.debit += e.DebitAmount
+		r.credit += e.CreditAmount
+	}
+
+	for partnerID, balance := range balances {
+		r := recents[partnerID]
+		if r == nil {
+			r = &recent{}
+		}
+		switch {
+		case balance > 0:
+			receivables += max(0, balance-r.debit)
+		case balance < 0:
+			payables += max(0, -balance-r.credit)
+		}
+	}
+	return receivables, payables
+}
+
+// PaymentTermStart returns the last day before the payment term of a charge
+// due on asOf; charges after it are not due yet
+func PaymentTermStart(asOf time.Time, termDays int) time.Time {
+	if termDays <= 0 {
+		termDays = DefaultPaymentTermDays
+	}
+	day := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location())
+	return day.AddDate(0, 0, -termDays)
+}
@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Dashboard Tests
+// ============================================================================
+
+func TestDashboardSummary_SetMonthResult(t *testing.T) {
+	var d domain.DashboardSummary
+	d.SetMonthResult(
+		[]domain.LedgerBalance{{PeriodCredit: 1000}, {PeriodDebit: 100, PeriodCredit: 300}},
+		[]domain.LedgerBalance{{PeriodDebit: 700, PeriodCredit: 50}},
+	)
+
+	assert.Equal(t, 1200.0, d.Revenue)
+	assert.Equal(t, 650.0, d.Expense)
+	assert.Equal(t, 550.0, d.NetIncome)
+}
+
+func TestPartnerOverdue(t *testing.T) {
+	asOf := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	day := func(daysAgo int) time.Time { return time.Date(2026, 10, 14-daysAgo, 0, 0, 0, 0, time.UTC) }
+	customer, vendor, settled, shortTerm := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	balances := map[uuid.UUID]float64{
+		customer:  1000, // 400 charged within the term
+		vendor:    -500, // all of it billed within the term
+		settled:   0,
+		shortTerm: 300, // 7-day term; the charge 10 days ago is overdue
+	}
+	entries := []domain.PartnerLedgerEntry{
+		{PartnerID: customer, VoucherDate: day(45), DebitAmount: 600},
+		{PartnerID: customer, VoucherDate: day(10), DebitAmount: 400},
+		{PartnerID: customer, VoucherDate: day(5), CreditAmount: 200},
+		{PartnerID: vendor, VoucherDate: day(3), CreditAmount: 500},
+		{PartnerID: shortTerm, VoucherDate: day(10), DebitAmount: 300},
+	}
+	terms := map[uuid.UUID]int{shortTerm: 7}
+
+	receivables, payables := domain.PartnerOverdue(balances, entries, terms, asOf)
+	assert.Equal(t, 900.0, receivables)
+	assert.Equal(t, 0.0, payables)
+
+	// Charges on the last day of the term are overdue
+	receivables, _ = domain.PartnerOverdue(map[uuid.UUID]float64{customer: 100},
+		[]domain.PartnerLedgerEntry{{PartnerID: customer, VoucherDate: day(30), DebitAmount: 100}}, nil, asOf)
+	assert.Equal(t, 100.0, receivables)
+
+	_, payables = domain.PartnerOverdue(map[uuid.UUID]float64{vendor: -800},
+		[]domain.PartnerLedgerEntry{{PartnerID: vendor, VoucherDate: day(29), CreditAmount: 300}}, nil, asOf)
+	assert.Equal(t, 500.0, payables)
+}
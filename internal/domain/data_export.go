@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Data export errors
+var (
+	ErrDataExportNotFound        = errors.New("data export not found")
+	ErrDataExportNotReady        = errors.New("data export is not completed")
+	ErrDataExportExpired         = errors.New("data export has expired")
+	ErrDataExportInProgress      = errors.New("a data export is already in progress")
+	ErrInvalidDataExportFormat   = errors.New("invalid data export format")
+	ErrInvalidDataExportLink     = errors.New("invalid or expired download link")
+	ErrDataExportSigningDisabled = errors.New("data export download links are not configured")
+)
+
+// DataExportStatus represents the state of a tenant data export
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"
+	DataExportStatusProcessing DataExportStatus = "processing"
+	DataExportStatusCompleted  DataExportStatus = "completed"
+	DataExportStatusFailed     DataExportStatus = "failed"
+)
+
+// IsActive returns true if the export has not finished yet
+func (s DataExportStatus) IsActive() bool {
+	return s == DataExportStatusPending || s == DataExportStatusProcessing
+}
+
+// DataExportFormat is the file format of each dataset in the archive
+type DataExportFormat string
+
+const (
+	DataExportFormatCSV  DataExportFormat = "csv"
+	DataExportFormatJSON DataExportFormat = "json"
+)
+
+// IsValid checks if the export format is valid
+func (f DataExportFormat) IsValid() bool {
+	return f == DataExportFormatCSV || f == DataExportFormatJSON
+}
+
+// DataExportDataset is a table included in a full tenant export
+type DataExportDataset string
+
+const (
+	DataExportAccounts        DataExportDataset = "accounts"
+	DataExportPartners        DataExportDataset = "partners"
+	DataExportVouchers        DataExportDataset = "vouchers"
+	DataExportVoucherEntries  DataExportDataset = "voucher_entries"
+	DataExportLedgerBalances  DataExportDataset = "ledger_balances"
+	DataExportTaxInvoices     DataExportDataset = "tax_invoices"
+	DataExportTaxInvoiceItems DataExportDataset = "tax_invoice_items"
+)
+
+// FullDataExportDatasets lists the datasets of a full export in archive order
+var FullDataExportDatasets = []DataExportDataset{
+	DataExportAccounts,
+	DataExportPartners,
+	DataExportVouchers,
+	DataExportVoucherEntries,
+	DataExportLedgerBalances,
+	DataExportTaxInvoices,
+	DataExportTaxInvoiceItems,
+}
+
+// DataExport is an asynchronously generated archive of a company's data,
+// used for backups and when a tenant leaves the service
+type DataExport struct {
+	TenantModel
+
+	Status DataExportStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Format DataExportFormat `gorm:"type:varchar(10);not null;default:csv" json:"format"`
+	// MaskPII partially masks emails, phone numbers and business registration
+	// numbers, for archives handed to third parties
+	MaskPII bool `gorm:"not null;default:false" json:"mask_pii"`
+
+	// Output: a zip archive with one file per dataset and a manifest.json
+	FileName  string         `gorm:"type:varchar(200)" json:"file_name,omitempty"`
+	FileSize  int            `json:"file_size"`
+	FileData  []byte         `gorm:"type:bytea" json:"-"`
+	RowCounts map[string]int `gorm:"type:jsonb;serializer:json" json:"row_counts,omitempty"`
+	Error     string         `gorm:"type:text" json:"error,omitempty"`
+
+	RequestedBy *uuid.UUID `gorm:"type:uuid" json:"requested_by,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // the archive is deleted after this time
+}
+
+// TableName specifies the table name for GORM
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// NewDataExport creates a pending full export
+func NewDataExport(companyID uuid.UUID, format DataExportFormat, requestedBy uuid.UUID) (*DataExport, error) {
+	if format == "" {
+		format = DataExportFormatCSV
+	}
+	if !format.IsValid() {
+		return nil, ErrInvalidDataExportFormat
+	}
+	return &DataExport{
+		TenantModel: TenantModel{CompanyID: companyID},
+		Status:      DataExportStatusPending,
+		Format:      format,
+		RequestedBy: &requestedBy,
+	}, nil
+}
+
+// Complete stores the generated archive, which is kept for the retention period
+func (e *DataExport) Complete(fileName string, data []byte, rowCounts map[string]int, retention time.Duration) {
+	now := time.Now()
+	expiresAt := now.Add(retention)
+	e.Status = DataExportStatusCompleted
+	e.FileName = fileName
+	e.FileData = data
+	e.FileSize = len(data)
+	e.RowCounts = rowCounts
+	e.Error = ""
+	e.CompletedAt = &now
+	e.ExpiresAt = &expiresAt
+}
+
+// Fail records the reason the export could not be generated
+func (e *DataExport) Fail(err error) {
+	now := time.Now()
+	e.Status = DataExportStatusFailed
+	e.Error = err.Error()
+	e.CompletedAt = &now
+}
+
+// IsDownloadable returns true if the archive is available at the given time
+func (e *DataExport) IsDownloadable(at time.Time) bool {
+	return e.Status == DataExportStatusCompleted && e.ExpiresAt != nil && at.Before(*e.ExpiresAt)
+}
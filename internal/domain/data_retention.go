@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Data retention errors
+var (
+	ErrDeletionRequestNotFound      = errors.New("deletion request not found")
+	ErrDeletionRequestExists        = errors.New("a deletion request is already pending")
+	ErrDeletionRequestClosed        = errors.New("deletion request is already closed")
+	ErrInvalidDeletionSubject       = errors.New("invalid deletion request subject")
+	ErrDeletionRejectReasonRequired = errors.New("a reason is required to reject the request")
+	ErrDeletionReasonTooLong        = errors.New("deletion request reason must be at most 1000 characters")
+)
+
+// maxDeletionReason is the length limit of the reasons of a deletion request
+const maxDeletionReason = 1000
+
+// RetentionClass is a class of data that shares a retention period
+type RetentionClass string
+
+const (
+	RetentionAccountingRecords RetentionClass = "accounting_records" // vouchers, entries and ledgers
+	RetentionTaxRecords        RetentionClass = "tax_records"        // tax invoices
+	RetentionAuditLogs         RetentionClass = "audit_logs"         // client IP address and user agent of audit entries
+	RetentionSessions          RetentionClass = "sessions"           // refresh tokens and emailed one-time tokens
+	RetentionPersonalData      RetentionClass = "personal_data"      // names and contacts of deleted users and partner contacts
+)
+
+// RetentionAction is what happens to data of a class when its period ends
+type RetentionAction string
+
+const (
+	RetentionActionRetain    RetentionAction = "retain"    // kept; the period is the statutory minimum
+	RetentionActionAnonymize RetentionAction = "anonymize" // personal data is cleared, the record is kept
+	RetentionActionDelete    RetentionAction = "delete"
+)
+
+// RetentionRule is the retention period of a data class. Years and Days add up.
+type RetentionRule struct {
+	Class  RetentionClass  `json:"class"`
+	Action RetentionAction `json:"action"`
+	Years  int             `json:"years,omitempty"`
+	Days   int             `json:"days,omitempty"`
+	Basis  string          `json:"basis,omitempty"` // statute or policy the period follows
+}
+
+// Cutoff returns the time before which data of the class has outlived the period
+func (r RetentionRule) Cutoff(now time.Time) time.Time {
+	return now.AddDate(-r.Years, 0, -r.Days)
+}
+
+// RetentionPolicy is the retention rule of every data class
+type RetentionPolicy struct {
+	AccountingYears      int
+	TaxYears             int
+	AuditLogDays         int
+	SessionDays          int
+	DeletionDeadlineDays int
+}
+
+// Rules lists the rules of the policy in a stable order
+func (p RetentionPolicy) Rules() []RetentionRule {
+	return []RetentionRule{
+		{Class: RetentionAccountingRecords, Action: RetentionActionRetain, Years: p.AccountingYears, Basis: "Commercial Act art. 33"},
+		{Class: RetentionTaxRecords, Action: RetentionActionRetain, Years: p.TaxYears, Basis: "Framework Act on National Taxes art. 85-3"},
+		{Class: RetentionAuditLogs, Action: RetentionActionAnonymize, Days: p.AuditLogDays},
+		{Class: RetentionSessions, Action: RetentionActionDelete, Days: p.SessionDays},
+		{Class: RetentionPersonalData, Action: RetentionActionAnonymize, Days: p.DeletionDeadlineDays, Basis: "Personal Information Protection Act art. 36"},
+	}
+}
+
+// Rule returns the rule of a data class
+func (p RetentionPolicy) Rule(class RetentionClass) RetentionRule {
+	for _, r := range p.Rules() {
+		if r.Class == class {
+			return r
+		}
+	}
+	return RetentionRule{Class: class, Action: RetentionActionRetain}
+}
+
+// DeletionSubjectType is the kind of data subject whose personal data is deleted
+type DeletionSubjectType string
+
+const (
+	DeletionSubjectUser    DeletionSubjectType = "user"    // a user account of the company
+	DeletionSubjectPartner DeletionSubjectType = "partner" // the contact person of a partner
+)
+
+// IsValid checks if the subject type is valid
+func (t DeletionSubjectType) IsValid() bool {
+	return t == DeletionSubjectUser || t == DeletionSubjectPartner
+}
+
+// DeletionRequestStatus represents the state of a deletion request
+type DeletionRequestStatus string
+
+const (
+	DeletionRequestPending   DeletionRequestStatus = "pending"
+	DeletionRequestCompleted DeletionRequestStatus = "completed" // personal data was anonymized
+	DeletionRequestRejected  DeletionRequestStatus = "rejected"  // refused on a legal basis, recorded in the reason
+)
+
+// DataDeletionRequest is a request of a data subject to delete their personal
+// data (GDPR art. 17, PIPA art. 36). Completing it anonymizes the subject's
+// personal data; accounting and tax records that refer to the subject are kept
+// for their statutory period, so nothing is deleted outright.
+type DataDeletionRequest struct {
+	TenantModel
+
+	SubjectType DeletionSubjectType   `gorm:"type:varchar(20);not null" json:"subject_type"`
+	SubjectID   uuid.UUID             `gorm:"type:uuid;not null" json:"subject_id"`
+	Status      DeletionRequestStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Reason      string                `gorm:"type:text" json:"reason,omitempty"`
+	RequestedBy uuid.UUID             `gorm:"type:uuid;not null" json:"requested_by"`
+	DueAt       time.Time             `gorm:"not null" json:"due_at"` // the request must be handled by this time
+
+	ProcessedBy     *uuid.UUID `gorm:"type:uuid" json:"processed_by,omitempty"`
+	ProcessedAt     *time.Time `json:"processed_at,omitempty"`
+	RejectionReason string     `gorm:"type:text" json:"rejection_reason,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DataDeletionRequest) TableName() string {
+	return "data_deletion_requests"
+}
+
+// NewDataDeletionRequest creates a pending deletion request due deadlineDays after now
+func NewDataDeletionRequest(companyID uuid.UUID, subjectType DeletionSubjectType, subjectID, requestedBy uuid.UUID, reason string, deadlineDays int, now time.Time) (*DataDeletionRequest, error) {
+	if !subjectType.IsValid() || subjectID == uuid.Nil {
+		return nil, ErrInvalidDeletionSubject
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxDeletionReason {
+		return nil, ErrDeletionReasonTooLong
+	}
+	return &DataDeletionRequest{
+		TenantModel: TenantModel{CompanyID: companyID},
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		Status:      DeletionRequestPending,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		DueAt:       now.AddDate(0, 0, deadlineDays),
+	}, nil
+}
+
+// IsPending returns true if the request has not been handled yet
+func (r *DataDeletionRequest) IsPending() bool {
+	return r.Status == DeletionRequestPending
+}
+
+// IsOverdue returns true if the request is still pending past its deadline
+func (r *DataDeletionRequest) IsOverdue(now time.Time) bool {
+	return r.IsPending() && now.After(r.DueAt)
+}
+
+// Complete records that the subject's personal data was anonymized
+func (r *DataDeletionRequest) Complete(processedBy uuid.UUID, at time.Time) error {
+	if !r.IsPending() {
+		return ErrDeletionRequestClosed
+	}
+	r.Status = DeletionRequestCompleted
+	r.ProcessedBy = &processedBy
+	r.ProcessedAt = &at
+	return nil
+}
+
+// Reject refuses the request, e.g. while the subject's data is needed for a
+// pending legal claim
+func (r *DataDeletionRequest) Reject(processedBy uuid.UUID, reason string, at time.Time) error {
+	if !r.IsPending() {
+		return ErrDeletionRequestClosed
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrDeletionRejectReasonRequired
+	}
+	if utf8.RuneCountInString(reason) > maxDeletionReason {
+		return ErrDeletionReasonTooLong
+	}
+	r.Status = DeletionRequestRejected
+	r.RejectionReason = reason
+	r.ProcessedBy = &processedBy
+	r.ProcessedAt = &at
+	return nil
+}
+
+// AnonymizedUserName is the name of users whose personal data was deleted
+const AnonymizedUserName = "Deleted user"
+
+// AnonymizedEmail returns the placeholder address of an anonymized user; the
+// .invalid domain never receives mail (RFC 2606)
+func AnonymizedEmail(id uuid.UUID) string {
+	return "deleted-" + id.String() + "@anonymized.invalid"
+}
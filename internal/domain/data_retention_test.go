@@ -0,0 +1,126 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// RetentionPolicy Tests
+// ============================================================================
+
+func TestRetentionPolicy_Rule(t *testing.T) {
+	policy := domain.RetentionPolicy{AccountingYears: 10, TaxYears: 5, AuditLogDays: 365, SessionDays: 30, DeletionDeadlineDays: 10}
+	now := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	accounting := policy.Rule(domain.RetentionAccountingRecords)
+	assert.Equal(t, domain.RetentionActionRetain, accounting.Action)
+	assert.Equal(t, time.Date(2016, 3, 15, 9, 0, 0, 0, time.UTC), accounting.Cutoff(now))
+
+	sessions := policy.Rule(domain.RetentionSessions)
+	assert.Equal(t, domain.RetentionActionDelete, sessions.Action)
+	assert.Equal(t, time.Date(2026, 2, 13, 9, 0, 0, 0, time.UTC), sessions.Cutoff(now))
+
+	assert.Len(t, policy.Rules(), 5)
+	assert.Equal(t, domain.RetentionActionRetain, policy.Rule("unknown").Action)
+}
+
+// ============================================================================
+// DataDeletionRequest Tests
+// ============================================================================
+
+func TestNewDataDeletionRequest(t *testing.T) {
+	companyID, subjectID, userID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	req, err := domain.NewDataDeletionRequest(companyID, domain.DeletionSubjectUser, subjectID, userID, "  leaving the company ", 10, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeletionRequestPending, req.Status)
+	assert.Equal(t, "leaving the company", req.Reason)
+	assert.Equal(t, now.AddDate(0, 0, 10), req.DueAt)
+	assert.False(t, req.IsOverdue(now.AddDate(0, 0, 10)))
+	assert.True(t, req.IsOverdue(now.AddDate(0, 0, 11)))
+
+	_, err = domain.NewDataDeletionRequest(companyID, "customer", subjectID, userID, "", 10, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidDeletionSubject)
+
+	_, err = domain.NewDataDeletionRequest(companyID, domain.DeletionSubjectPartner, uuid.Nil, userID, "", 10, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidDeletionSubject)
+
+	_, err = domain.NewDataDeletionRequest(companyID, domain.DeletionSubjectPartner, subjectID, userID, strings.Repeat("가", 1001), 10, now)
+	assert.ErrorIs(t, err, domain.ErrDeletionReasonTooLong)
+}
+
+func TestDataDeletionRequest_CompleteAndReject(t *testing.T) {
+	now := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	newRequest := func() *domain.DataDeletionRequest {
+		req, err := domain.NewDataDeletionRequest(uuid.New(), domain.DeletionSubjectUser, uuid.New(), uuid.New(), "", 10, now)
+		require.NoError(t, err)
+		return req
+	}
+	adminID := uuid.New()
+
+	completed := newRequest()
+	require.NoError(t, completed.Complete(adminID, now))
+	assert.Equal(t, domain.DeletionRequestCompleted, completed.Status)
+	assert.Equal(t, adminID, *completed.ProcessedBy)
+	assert.False(t, completed.IsOverdue(now.AddDate(1, 0, 0)))
+	assert.ErrorIs(t, completed.Complete(adminID, now), domain.ErrDeletionRequestClosed)
+	assert.ErrorIs(t, completed.Reject(adminID, "litigation hold", now), domain.ErrDeletionRequestClosed)
+
+	rejected := newRequest()
+	assert.ErrorIs(t, rejected.Reject(adminID, "  ", now), domain.ErrDeletionRejectReasonRequired)
+	require.NoError(t, rejected.Reject(adminID, "litigation hold", now))
+	assert.Equal(t, domain.DeletionRequestRejected, rejected.Status)
+	assert.Equal(t, "litigation hold", rejected.RejectionReason)
+}
+
+// ============================================================================
+// Anonymization Tests
+// ============================================================================
+
+func TestUser_Anonymize(t *testing.T) {
+	user, err := domain.NewUser(uuid.New(), "kim@example.com", "password123", "김철수", domain.UserRoleUser)
+	require.NoError(t, err)
+	user.ID = uuid.New()
+	user.VerifyEmail()
+	user.UpdateLastLogin()
+
+	user.Anonymize()
+	assert.True(t, user.IsAnonymized())
+	assert.Equal(t, domain.AnonymizedEmail(user.ID), user.Email)
+	assert.Equal(t, domain.AnonymizedUserName, user.Name)
+	assert.Equal(t, domain.UserStatusInactive, user.Status)
+	assert.False(t, user.CheckPassword("password123"))
+	assert.Nil(t, user.LastLoginAt)
+	assert.Nil(t, user.EmailVerifiedAt)
+}
+
+func TestPartner_AnonymizeContact(t *testing.T) {
+	verified := true
+	partner := &domain.Partner{
+		Name:                   "(주)한빛상사",
+		BusinessNumber:         "123-45-67890",
+		Representative:         "이영희",
+		Phone:                  "02-1234-5678",
+		Email:                  "lee@hanbit.example",
+		Address:                "서울특별시 중구 세종대로 110",
+		RepresentativeVerified: &verified,
+	}
+
+	partner.AnonymizeContact()
+	assert.Empty(t, partner.Representative)
+	assert.Empty(t, partner.Phone)
+	assert.Empty(t, partner.Email)
+	assert.Nil(t, partner.RepresentativeVerified)
+	assert.Equal(t, "(주)한빛상사", partner.Name)
+	assert.Equal(t, "123-45-67890", partner.BusinessNumber)
+	assert.Equal(t, "서울특별시 중구 세종대로 110", partner.Address)
+}
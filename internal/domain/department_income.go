@@ -0,0 +1,456 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Department allocation errors
+var (
+	ErrAllocationRuleNotFound        = errors.New("allocation rule not found")
+	ErrAllocationRuleNameRequired    = errors.New("allocation rule name is required")
+	ErrAllocationTargetsRequired     = errors.New("at least one allocation target is required")
+	ErrAllocationRatioSum            = errors.New("allocation ratios must sum to 100")
+	ErrInvalidAllocationRatio        = errors.New("allocation ratio must be positive")
+	ErrDuplicateAllocationTarget     = errors.New("duplicate allocation target department")
+	ErrAllocationTargetIsSource      = errors.New("allocation target cannot be the source department")
+	ErrInvalidAllocationAccountRange = errors.New("invalid allocation account code range")
+	ErrInvalidAllocationBasis        = errors.New("invalid allocation basis")
+	ErrInvalidAllocationHeadcount    = errors.New("allocation headcount must be positive")
+)
+
+// AllocationBasis is how the shares of the allocation targets are determined
+type AllocationBasis string
+
+const (
+	// AllocationBasisFixed uses the ratio of each target
+	AllocationBasisFixed AllocationBasis = "fixed"
+	// AllocationBasisHeadcount uses the headcount of each target (인원수 기준)
+	AllocationBasisHeadcount AllocationBasis = "headcount"
+	// AllocationBasisRevenue uses the revenue the target departments posted in
+	// the allocated period (매출액 기준)
+	AllocationBasisRevenue AllocationBasis = "revenue"
+)
+
+// IsValid checks if the allocation basis is valid
+func (b AllocationBasis) IsValid() bool {
+	switch b {
+	case AllocationBasisFixed, AllocationBasisHeadcount, AllocationBasisRevenue:
+		return true
+	}
+	return false
+}
+
+// AllocationTarget is a department, and optionally a project of it, receiving
+// a share of allocated costs
+type AllocationTarget struct {
+	DepartmentID uuid.UUID  `json:"department_id"`
+	ProjectID    *uuid.UUID `json:"project_id,omitempty"`
+	Ratio        float64    `json:"ratio,omitempty"`     // percent for the fixed basis, all targets sum to 100
+	Headcount    int        `json:"headcount,omitempty"` // for the headcount basis
+}
+
+// DepartmentAllocationRule distributes shared revenue/expense amounts of a source department
+// (or of entries without a department) to other departments by fixed ratios, headcount or revenue
+type DepartmentAllocationRule struct {
+	TenantModel
+
+	Name  string          `gorm:"type:varchar(100);not null" json:"name"`
+	Basis AllocationBasis `gorm:"type:varchar(20);not null;default:fixed" json:"basis"`
+
+	// Source: nil allocates entries posted without a department (공통비)
+	SourceDepartmentID *uuid.UUID `gorm:"type:uuid" json:"source_department_id,omitempty"`
+
+	// Accounts the rule applies to (inclusive code range); empty applies to all expense accounts
+	AccountFrom string `gorm:"type:varchar(10)" json:"account_from,omitempty"`
+	AccountTo   string `gorm:"type:varchar(10)" json:"account_to,omitempty"`
+
+	Targets  []AllocationTarget `gorm:"type:jsonb;serializer:json" json:"targets"`
+	IsActive bool               `gorm:"default:true" json:"is_active"`
+}
+
+// TableName specifies the table name for GORM
+func (DepartmentAllocationRule) TableName() string {
+	return "department_allocation_rules"
+}
+
+// Validate validates the rule
+func (r *DepartmentAllocationRule) Validate() error {
+	if r.Name == "" {
+		return ErrAllocationRuleNameRequired
+	}
+	if (r.AccountFrom == "") != (r.AccountTo == "") || r.AccountFrom > r.AccountTo {
+		return ErrInvalidAllocationAccountRange
+	}
+	basis := r.basis()
+	if !basis.IsValid() {
+		return ErrInvalidAllocationBasis
+	}
+	if len(r.Targets) == 0 {
+		return ErrAllocationTargetsRequired
+	}
+	type targetKey struct{ department, project uuid.UUID }
+	seen := make(map[targetKey]bool)
+	total := 0.0
+	for _, t := range r.Targets {
+		switch {
+		case basis == AllocationBasisFixed && t.Ratio <= 0:
+			return ErrInvalidAllocationRatio
+		case basis == AllocationBasisHeadcount && t.Headcount <= 0:
+			return ErrInvalidAllocationHeadcount
+		}
+		key := targetKey{department: t.DepartmentID}
+		if t.ProjectID != nil {
+			key.project = *t.ProjectID
+		}
+		if seen[key] {
+			return ErrDuplicateAllocationTarget
+		}
+		if r.SourceDepartmentID != nil && *r.SourceDepartmentID == t.DepartmentID && t.ProjectID == nil {
+			return ErrAllocationTargetIsSource
+		}
+		seen[key] = true
+		total += t.Ratio
+	}
+	if basis == AllocationBasisFixed && math.Abs(total-100) > 0.0001 {
+		return ErrAllocationRatioSum
+	}
+	return nil
+}
+
+// basis returns the allocation basis; rules stored before bases existed are fixed
+func (r *DepartmentAllocationRule) basis() AllocationBasis {
+	if r.Basis == "" {
+		return AllocationBasisFixed
+	}
+	return r.Basis
+}
+
+// Ratios returns the percent share of each target. The revenue basis weighs
+// the targets by the revenue of their departments; nil is returned when none
+// of them has revenue and the rule has nothing to allocate by.
+func (r *DepartmentAllocationRule) Ratios(revenue map[uuid.UUID]float64) []float64 {
+	weights := make([]float64, len(r.Targets))
+	total := 0.0
+	for i, t := range r.Targets {
+		switch r.basis() {
+		case AllocationBasisHeadcount:
+			weights[i] = float64(t.Headcount)
+		case AllocationBasisRevenue:
+			weights[i] = math.Max(revenue[t.DepartmentID], 0)
+		default:
+			weights[i] = t.Ratio
+		}
+		total += weights[i]
+	}
+	if total <= 0 {
+		return nil
+	}
+	for i := range weights {
+		weights[i] = weights[i] / total * 100
+	}
+	return weights
+}
+
+// Applies returns true if the rule allocates the given department's amount on the account
+func (r *DepartmentAllocationRule) Applies(departmentID *uuid.UUID, accountCode string, accountType AccountType) bool {
+	if (r.SourceDepartmentID == nil) != (departmentID == nil) {
+		return false
+	}
+	if r.SourceDepartmentID != nil && *r.SourceDepartmentID != *departmentID {
+		return false
+	}
+	if r.AccountFrom == "" {
+		return accountType == AccountTypeExpense
+	}
+	return accountCode >= r.AccountFrom && accountCode <= r.AccountTo
+}
+
+// DepartmentAccountTotal is the posted activity of one account in one department
+type DepartmentAccountTotal struct {
+	DepartmentID   *uuid.UUID  `json:"department_id,omitempty"`
+	DepartmentCode string      `json:"department_code,omitempty"`
+	DepartmentName string      `json:"department_name,omitempty"`
+	AccountID      uuid.UUID   `json:"account_id"`
+	AccountCode    string      `json:"account_code"`
+	AccountName    string      `json:"account_name"`
+	AccountType    AccountType `json:"account_type"`
+	Debit          float64     `json:"debit"`
+	Credit         float64     `json:"credit"`
+}
+
+// Amount returns the natural-sign amount (revenue credit-positive, expense debit-positive)
+func (t *DepartmentAccountTotal) Amount() float64 {
+	if t.AccountType == AccountTypeRevenue {
+		return t.Credit - t.Debit
+	}
+	return t.Debit - t.Credit
+}
+
+// DepartmentIncomeLine is an account line of a department's income statement
+type DepartmentIncomeLine struct {
+	AccountID   uuid.UUID   `json:"account_id"`
+	AccountCode string      `json:"account_code"`
+	AccountName string      `json:"account_name"`
+	AccountType AccountType `json:"account_type"`
+	Amount      float64     `json:"amount"`
+	Allocated   float64     `json:"allocated"` // portion of Amount received (+) or given away (-) by allocation
+}
+
+// DepartmentIncome is the income statement of one department
+type DepartmentIncome struct {
+	DepartmentID   *uuid.UUID `json:"department_id,omitempty"` // nil for entries without a department
+	DepartmentCode string     `json:"department_code,omitempty"`
+	DepartmentName string     `json:"department_name"`
+	ParentID       *uuid.UUID `json:"parent_id,omitempty"`
+	Level          int        `json:"level"`
+
+	Revenue       []DepartmentIncomeLine `json:"revenue"`
+	Expenses      []DepartmentIncomeLine `json:"expenses"`
+	TotalRevenue  float64                `json:"total_revenue"`
+	TotalExpenses float64                `json:"total_expenses"`
+	NetIncome     float64                `json:"net_income"`
+}
+
+// DepartmentIncomeOptions controls how the department income statement is built
+type DepartmentIncomeOptions struct {
+	// Rules are applied in order; each rule sees the result of the previous ones
+	Rules []DepartmentAllocationRule
+	// Rollup includes each department's descendants in its figures
+	Rollup bool
+	// RootID limits the statement to this department and its descendants
+	RootID *uuid.UUID
+}
+
+// UnassignedDepartmentName labels entries posted without a department
+const UnassignedDepartmentName = "(미지정)"
+
+// BuildDepartmentIncome builds department income statements from posted account totals.
+// Departments are listed in hierarchy order; entries without a department come last.
+func BuildDepartmentIncome(departments []Department, totals []DepartmentAccountTotal, opts DepartmentIncomeOptions) []DepartmentIncome {
+	lines := allocateDepartmentTotals(totals, opts.Rules)
+
+	// Index the hierarchy, adding departments that only appear in the totals (e.g., inactive)
+	nodes := make(map[uuid.UUID]*DepartmentIncome)
+	var order []uuid.UUID
+	for _, d := range departments {
+		id := d.ID
+		nodes[id] = &DepartmentIncome{
+			DepartmentID: &id, DepartmentCode: d.Code, DepartmentName: d.Name,
+			ParentID: d.ParentID, Level: d.Level,
+		}
+		order = append(order, id)
+	}
+	for _, t := range totals {
+		if t.DepartmentID != nil && nodes[*t.DepartmentID] == nil {
+			id := *t.DepartmentID
+			nodes[id] = &DepartmentIncome{DepartmentID: &id, DepartmentCode: t.DepartmentCode, DepartmentName: t.DepartmentName, Level: 1}
+			order = append(order, id)
+		}
+	}
+	children := make(map[uuid.UUID][]uuid.UUID)
+	for _, id := range order {
+		if p := nodes[id].ParentID; p != nil && nodes[*p] != nil {
+			children[*p] = append(children[*p], id)
+		}
+	}
+
+	// Walk the tree depth-first so parents precede their children
+	var sorted []uuid.UUID
+	var walk func(id uuid.UUID)
+	walk = func(id uuid.UUID) {
+		sorted = append(sorted, id)
+		for _, c := range children[id] {
+			walk(c)
+		}
+	}
+	if opts.RootID != nil {
+		if nodes[*opts.RootID] == nil {
+			return []DepartmentIncome{}
+		}
+		walk(*opts.RootID)
+	} else {
+		for _, id := range order {
+			if p := nodes[id].ParentID; p == nil || nodes[*p] == nil {
+				walk(id)
+			}
+		}
+	}
+
+	// Lines contributing to each department: its own, plus its descendants' when rolling up
+	var collect func(id uuid.UUID) []DepartmentIncomeLine
+	collect = func(id uuid.UUID) []DepartmentIncomeLine {
+		own := lines[id]
+		if !opts.Rollup {
+			return own
+		}
+		result := append([]DepartmentIncomeLine(nil), own...)
+		for _, c := range children[id] {
+			result = append(result, collect(c)...)
+		}
+		return result
+	}
+
+	result := make([]DepartmentIncome, 0, len(sorted)+1)
+	for _, id := range sorted {
+		node := nodes[id]
+		node.setLines(collect(id))
+		result = append(result, *node)
+	}
+	if opts.RootID == nil {
+		if unassigned, ok := lines[uuid.Nil]; ok {
+			node := DepartmentIncome{DepartmentName: UnassignedDepartmentName, Level: 1}
+			node.setLines(unassigned)
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// setLines merges lines by account and computes the totals
+func (d *DepartmentIncome) setLines(lines []DepartmentIncomeLine) {
+	merged := make(map[uuid.UUID]*DepartmentIncomeLine)
+	var ids []uuid.UUID
+	for _, l := range lines {
+		if m, ok := merged[l.AccountID]; ok {
+			m.Amount += l.Amount
+			m.Allocated += l.Allocated
+			continue
+		}
+		line := l
+		merged[l.AccountID] = &line
+		ids = append(ids, l.AccountID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return merged[ids[i]].AccountCode < merged[ids[j]].AccountCode })
+
+	d.Revenue, d.Expenses = []DepartmentIncomeLine{}, []DepartmentIncomeLine{}
+	d.TotalRevenue, d.TotalExpenses = 0, 0
+	for _, id := range ids {
+		line := *merged[id]
+		line.Amount = roundAmount(line.Amount)
+		line.Allocated = roundAmount(line.Allocated)
+		if line.Amount == 0 && line.Allocated == 0 {
+			continue
+		}
+		if line.AccountType == AccountTypeRevenue {
+			d.Revenue = append(d.Revenue, line)
+			d.TotalRevenue += line.Amount
+		} else {
+			d.Expenses = append(d.Expenses, line)
+			d.TotalExpenses += line.Amount
+		}
+	}
+	d.TotalRevenue = roundAmount(d.TotalRevenue)
+	d.TotalExpenses = roundAmount(d.TotalExpenses)
+	d.NetIncome = roundAmount(d.TotalRevenue - d.TotalExpenses)
+}
+
+// allocationBucket is the amount of an account in a department (and project)
+// while the allocation rules are applied
+type allocationBucket struct {
+	departmentID *uuid.UUID
+	projectID    *uuid.UUID
+	line         DepartmentIncomeLine
+}
+
+// allocateDepartmentTotals converts totals to lines keyed by department (uuid.Nil for none)
+// and moves amounts according to the rules
+func allocateDepartmentTotals(totals []DepartmentAccountTotal, rules []DepartmentAllocationRule) map[uuid.UUID][]DepartmentIncomeLine {
+	buckets, _ := applyAllocationRules(totals, rules)
+
+	result := make(map[uuid.UUID][]DepartmentIncomeLine)
+	for _, b := range buckets {
+		key := uuid.Nil
+		if b.departmentID != nil {
+			key = *b.departmentID
+		}
+		result[key] = append(result[key], b.line)
+	}
+	return result
+}
+
+// BuildAllocationLines returns the amounts the active rules move from their
+// source departments to the targets, in rule order. Each rule sees the result
+// of the previous ones, as in the income statement by department.
+func BuildAllocationLines(totals []DepartmentAccountTotal, rules []DepartmentAllocationRule) []AllocationLine {
+	_, lines := applyAllocationRules(totals, rules)
+	return lines
+}
+
+// applyAllocationRules moves the amounts of the totals according to the rules
+// and records each move. Revenue shares are taken from the totals before any
+// allocation. The last target takes the rounding remainder.
+func applyAllocationRules(totals []DepartmentAccountTotal, rules []DepartmentAllocationRule) ([]allocationBucket, []AllocationLine) {
+	buckets := make([]allocationBucket, 0, len(totals))
+	revenue := make(map[uuid.UUID]float64)
+	for _, t := range totals {
+		buckets = append(buckets, allocationBucket{departmentID: t.DepartmentID, line: DepartmentIncomeLine{
+			AccountID: t.AccountID, AccountCode: t.AccountCode, AccountName: t.AccountName,
+			AccountType: t.AccountType, Amount: t.Amount(),
+		}})
+		if t.AccountType == AccountTypeRevenue && t.DepartmentID != nil {
+			revenue[*t.DepartmentID] += t.Amount()
+		}
+	}
+
+	var lines []AllocationLine
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+		ratios := rule.Ratios(revenue)
+		if ratios == nil {
+			continue
+		}
+		var moved []allocationBucket
+		for i := range buckets {
+			b := &buckets[i]
+			if b.line.Amount == 0 || !rule.Applies(b.departmentID, b.line.AccountCode, b.line.AccountType) {
+				continue
+			}
+			amount := b.line.Amount
+			allocation := AllocationLine{
+				RuleID:             rule.ID,
+				RuleName:           rule.Name,
+				AccountID:          b.line.AccountID,
+				AccountCode:        b.line.AccountCode,
+				AccountName:        b.line.AccountName,
+				AccountType:        b.line.AccountType,
+				SourceDepartmentID: b.departmentID,
+				SourceProjectID:    b.projectID,
+				Amount:             amount,
+			}
+			remaining := amount
+			for j, target := range rule.Targets {
+				share := roundAmount(amount * ratios[j] / 100)
+				if j == len(rule.Targets)-1 {
+					share = roundAmount(remaining)
+				}
+				remaining -= share
+				targetID := target.DepartmentID
+				line := b.line
+				line.Amount, line.Allocated = share, share
+				moved = append(moved, allocationBucket{departmentID: &targetID, projectID: target.ProjectID, line: line})
+				allocation.Shares = append(allocation.Shares, AllocationShare{
+					DepartmentID: target.DepartmentID,
+					ProjectID:    target.ProjectID,
+					Ratio:        roundAmount(ratios[j]),
+					Amount:       share,
+				})
+			}
+			b.line.Amount = 0
+			b.line.Allocated -= amount
+			lines = append(lines, allocation)
+		}
+		buckets = append(buckets, moved...)
+	}
+	return buckets, lines
+}
+
+// roundAmount rounds to 2 decimal places (the precision of voucher amounts)
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
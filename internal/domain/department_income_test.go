@@ -0,0 +1,115 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// DepartmentAllocationRule Tests
+// ============================================================================
+
+func TestDepartmentAllocationRule_Validate(t *testing.T) {
+	sales, admin := uuid.New(), uuid.New()
+	valid := func() domain.DepartmentAllocationRule {
+		return domain.DepartmentAllocationRule{
+			Name:    "Rent",
+			Targets: []domain.AllocationTarget{{DepartmentID: sales, Ratio: 60}, {DepartmentID: admin, Ratio: 40}},
+		}
+	}
+	rule := valid()
+	assert.NoError(t, rule.Validate())
+
+	rule = valid()
+	rule.Targets[1].Ratio = 30
+	assert.ErrorIs(t, rule.Validate(), domain.ErrAllocationRatioSum)
+
+	rule = valid()
+	rule.Targets[1].DepartmentID = sales
+	assert.ErrorIs(t, rule.Validate(), domain.ErrDuplicateAllocationTarget)
+
+	rule = valid()
+	rule.SourceDepartmentID = &sales
+	assert.ErrorIs(t, rule.Validate(), domain.ErrAllocationTargetIsSource)
+
+	rule = valid()
+	rule.AccountFrom = "800"
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidAllocationAccountRange)
+}
+
+// ============================================================================
+// BuildDepartmentIncome Tests
+// ============================================================================
+
+func TestBuildDepartmentIncome(t *testing.T) {
+	headID, salesID := uuid.New(), uuid.New()
+	head := domain.Department{Code: "D100", Name: "Head Office", Level: 1}
+	head.ID = headID
+	sales := domain.Department{Code: "D110", Name: "Sales", Level: 2, ParentID: &headID}
+	sales.ID = salesID
+	departments := []domain.Department{head, sales}
+
+	revenue, rent := uuid.New(), uuid.New()
+	totals := []domain.DepartmentAccountTotal{
+		{DepartmentID: &salesID, AccountID: revenue, AccountCode: "401", AccountName: "Sales", AccountType: domain.AccountTypeRevenue, Credit: 1000},
+		{DepartmentID: &headID, AccountID: rent, AccountCode: "819", AccountName: "Rent", AccountType: domain.AccountTypeExpense, Debit: 200},
+		{AccountID: rent, AccountCode: "819", AccountName: "Rent", AccountType: domain.AccountTypeExpense, Debit: 100},
+	}
+
+	result := domain.BuildDepartmentIncome(departments, totals, domain.DepartmentIncomeOptions{})
+	require.Len(t, result, 3)
+	assert.Equal(t, "Head Office", result[0].DepartmentName)
+	assert.Equal(t, 200.0, result[0].TotalExpenses)
+	assert.Equal(t, 1000.0, result[1].NetIncome)
+	assert.Nil(t, result[2].DepartmentID)
+	assert.Equal(t, domain.UnassignedDepartmentName, result[2].DepartmentName)
+
+	// Unassigned rent split 1/3 to head office, the rest (with rounding remainder) to sales
+	rules := []domain.DepartmentAllocationRule{{
+		Name:     "Shared rent",
+		IsActive: true,
+		Targets:  []domain.AllocationTarget{{DepartmentID: headID, Ratio: 33.33}, {DepartmentID: salesID, Ratio: 66.67}},
+	}}
+	result = domain.BuildDepartmentIncome(departments, totals, domain.DepartmentIncomeOptions{Rules: rules})
+	require.Len(t, result, 3)
+	assert.Equal(t, 233.33, result[0].TotalExpenses)
+	assert.Equal(t, 66.67, result[1].TotalExpenses)
+	assert.Equal(t, 66.67, result[1].Expenses[0].Allocated)
+	assert.Equal(t, 0.0, result[2].TotalExpenses)
+
+	// Roll-up includes sales in the head office figures
+	result = domain.BuildDepartmentIncome(departments, totals, domain.DepartmentIncomeOptions{Rules: rules, Rollup: true, RootID: &headID})
+	require.Len(t, result, 2)
+	assert.Equal(t, 1000.0, result[0].TotalRevenue)
+	assert.Equal(t, 300.0, result[0].TotalExpenses)
+	assert.Equal(t, 700.0, result[0].NetIncome)
+}
+
+func TestDepartmentAllocationRule_Ratios(t *testing.T) {
+	sales, admin := uuid.New(), uuid.New()
+
+	rule := domain.DepartmentAllocationRule{
+		Name:    "Welfare",
+		Basis:   domain.AllocationBasisHeadcount,
+		Targets: []domain.AllocationTarget{{DepartmentID: sales, Headcount: 3}, {DepartmentID: admin, Headcount: 1}},
+	}
+	require.NoError(t, rule.Validate())
+	assert.Equal(t, []float64{75, 25}, rule.Ratios(nil))
+
+	rule.Targets[1].Headcount = 0
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidAllocationHeadcount)
+
+	rule = domain.DepartmentAllocationRule{
+		Name:    "Marketing",
+		Basis:   domain.AllocationBasisRevenue,
+		Targets: []domain.AllocationTarget{{DepartmentID: sales}, {DepartmentID: admin}},
+	}
+	require.NoError(t, rule.Validate())
+	assert.Equal(t, []float64{80, 20}, rule.Ratios(map[uuid.UUID]float64{sales: 800, admin: 200}))
+	assert.Nil(t, rule.Ratios(map[uuid.UUID]float64{}), "nothing to allocate by without revenue")
+}
@@ -0,0 +1,325 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Document library errors
+var (
+	ErrDocumentFolderNotFound   = errors.New("document folder not found")
+	ErrInvalidDocumentFolder    = errors.New("invalid document folder")
+	ErrDocumentFolderExists     = errors.New("folder name already exists")
+	ErrDocumentFolderNotEmpty   = errors.New("document folder is not empty")
+	ErrDocumentFolderCycle      = errors.New("folder cannot move into its own subfolder")
+	ErrDocumentFileNotFound     = errors.New("document file not found")
+	ErrInvalidDocumentFile      = errors.New("invalid document file")
+	ErrDocumentFileExists       = errors.New("file name already exists in the folder")
+	ErrDocumentVersionNotFound  = errors.New("document version not found")
+	ErrDocumentFileChanged      = errors.New("file changed while uploading")
+	ErrDocumentFileLinkExists   = errors.New("file is already linked to the document")
+	ErrDocumentFileLinkNotFound = errors.New("document file link not found")
+	ErrInvalidDocumentAccess    = errors.New("invalid document access")
+	ErrDocumentAccessDenied     = errors.New("no access to the document folder")
+)
+
+// Limits of document metadata
+const (
+	MaxDocumentMetadataKeys   = 30
+	maxDocumentMetadataKey    = 50
+	maxDocumentMetadataValue  = 200
+	maxDocumentNameLength     = 255
+	maxDocumentFolderNameSize = 100
+)
+
+// DocumentAccess is the access of a role to a document folder; each level
+// includes the ones before it
+type DocumentAccess string
+
+const (
+	DocumentAccessNone   DocumentAccess = "none"
+	DocumentAccessRead   DocumentAccess = "read"   // list, search and download
+	DocumentAccessWrite  DocumentAccess = "write"  // upload, edit, link and delete files, add subfolders
+	DocumentAccessManage DocumentAccess = "manage" // rename, move and delete the folder, set its permissions
+)
+
+// documentAccessRank orders the access levels
+var documentAccessRank = map[DocumentAccess]int{
+	DocumentAccessNone:   0,
+	DocumentAccessRead:   1,
+	DocumentAccessWrite:  2,
+	DocumentAccessManage: 3,
+}
+
+// IsValid checks if the access is valid
+func (a DocumentAccess) IsValid() bool {
+	_, ok := documentAccessRank[a]
+	return ok
+}
+
+// Allows reports whether the access includes the required one
+func (a DocumentAccess) Allows(required DocumentAccess) bool {
+	return documentAccessRank[a] >= documentAccessRank[required]
+}
+
+// DocumentFolder is a folder of the company's document library (문서함).
+// Folders without a parent are at the root.
+type DocumentFolder struct {
+	TenantModel
+
+	ParentID *uuid.UUID `gorm:"type:uuid" json:"parent_id,omitempty"`
+	Name     string     `gorm:"type:varchar(100);not null" json:"name"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Access is the access of the requesting user, set when folders are listed
+	Access DocumentAccess `gorm:"-" json:"access,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DocumentFolder) TableName() string {
+	return "document_folders"
+}
+
+// Validate checks the folder name
+func (f *DocumentFolder) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || utf8.RuneCountInString(f.Name) > maxDocumentFolderNameSize || strings.Contains(f.Name, "/") {
+		return ErrInvalidDocumentFolder
+	}
+	if f.ParentID != nil && *f.ParentID == f.ID {
+		return ErrDocumentFolderCycle
+	}
+	return nil
+}
+
+// DocumentFolderPermission grants a role access to a folder and, unless they
+// have permissions of their own, its subfolders
+type DocumentFolderPermission struct {
+	TenantModel
+
+	FolderID uuid.UUID      `gorm:"type:uuid;not null" json:"folder_id"`
+	Role     UserRole       `gorm:"type:varchar(50);not null" json:"role"`
+	Access   DocumentAccess `gorm:"type:varchar(20);not null" json:"access"`
+}
+
+// TableName specifies the table name for GORM
+func (DocumentFolderPermission) TableName() string {
+	return "document_folder_permissions"
+}
+
+// DocumentTree is the folder tree of a company with its permissions, to
+// resolve the access of a role to each folder
+type DocumentTree struct {
+	folders map[uuid.UUID]DocumentFolder
+	rules   map[uuid.UUID][]DocumentFolderPermission
+}
+
+// NewDocumentTree builds the tree of the folders and permissions
+func NewDocumentTree(folders []DocumentFolder, permissions []DocumentFolderPermission) *DocumentTree {
+	t := &DocumentTree{
+		folders: make(map[uuid.UUID]DocumentFolder, len(folders)),
+		rules:   make(map[uuid.UUID][]DocumentFolderPermission),
+	}
+	for _, f := range folders {
+		t.folders[f.ID] = f
+	}
+	for _, p := range permissions {
+		t.rules[p.FolderID] = append(t.rules[p.FolderID], p)
+	}
+	return t
+}
+
+// Has reports whether the folder is in the tree
+func (t *DocumentTree) Has(folderID uuid.UUID) bool {
+	_, ok := t.folders[folderID]
+	return ok
+}
+
+// Access returns the access of the role to the folder, or to the root when
+// folderID is nil. Administrators manage every folder. Otherwise the nearest
+// folder up the tree with permissions decides, and roles it does not list
+// have no access; without any, users write and viewers read.
+func (t *DocumentTree) Access(role UserRole, folderID *uuid.UUID) DocumentAccess {
+	if role == UserRoleAdmin {
+		return DocumentAccessManage
+	}
+	// Bounded by the folder count in case of a cycle in stored data
+	for id, hops := folderID, 0; id != nil && hops <= len(t.folders); hops++ {
+		if rules := t.rules[*id]; len(rules) > 0 {
+			for _, rule := range rules {
+				if rule.Role == role {
+					return rule.Access
+				}
+			}
+			return DocumentAccessNone
+		}
+		folder, ok := t.folders[*id]
+		if !ok {
+			return DocumentAccessNone
+		}
+		id = folder.ParentID
+	}
+	if role == UserRoleViewer {
+		return DocumentAccessRead
+	}
+	return DocumentAccessWrite
+}
+
+// Readable returns the folders the role may read
+func (t *DocumentTree) Readable(role UserRole) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(t.folders))
+	for id := range t.folders {
+		folderID := id
+		if t.Access(role, &folderID).Allows(DocumentAccessRead) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// IsWithin reports whether the folder is the ancestor folder or one of its
+// subfolders
+func (t *DocumentTree) IsWithin(folderID, ancestorID uuid.UUID) bool {
+	id := &folderID
+	for hops := 0; id != nil && hops <= len(t.folders); hops++ {
+		if *id == ancestorID {
+			return true
+		}
+		folder, ok := t.folders[*id]
+		if !ok {
+			return false
+		}
+		id = folder.ParentID
+	}
+	return false
+}
+
+// DocumentFile is a file of the document library with its versions. The
+// metadata holds free key-value fields the files are searched by, such as
+// 계약번호 or 거래처.
+type DocumentFile struct {
+	TenantModel
+
+	FolderID    uuid.UUID         `gorm:"type:uuid;not null" json:"folder_id"`
+	Name        string            `gorm:"type:varchar(255);not null" json:"name"`
+	Description string            `gorm:"type:varchar(500)" json:"description,omitempty"`
+	Metadata    map[string]string `gorm:"type:jsonb;serializer:json" json:"metadata,omitempty"`
+
+	// Of the current version
+	CurrentVersion int    `gorm:"not null;default:0" json:"current_version"`
+	FileType       string `gorm:"type:varchar(100)" json:"file_type,omitempty"`
+	FileSize       int64  `gorm:"not null;default:0" json:"file_size"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	Versions []DocumentVersion  `gorm:"foreignKey:FileID" json:"versions,omitempty"`
+	Links    []DocumentFileLink `gorm:"foreignKey:FileID" json:"links,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DocumentFile) TableName() string {
+	return "document_files"
+}
+
+// Validate checks the name and metadata of the file and normalizes them
+func (f *DocumentFile) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	f.Description = strings.TrimSpace(f.Description)
+	if f.Name == "" || utf8.RuneCountInString(f.Name) > maxDocumentNameLength || f.FolderID == uuid.Nil {
+		return ErrInvalidDocumentFile
+	}
+	if len(f.Metadata) > MaxDocumentMetadataKeys {
+		return ErrInvalidDocumentFile
+	}
+	metadata := make(map[string]string, len(f.Metadata))
+	for key, value := range f.Metadata {
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "" || utf8.RuneCountInString(key) > maxDocumentMetadataKey ||
+			utf8.RuneCountInString(value) > maxDocumentMetadataValue {
+			return ErrInvalidDocumentFile
+		}
+		metadata[key] = value
+	}
+	f.Metadata = metadata
+	return nil
+}
+
+// AddVersion numbers the version as the next of the file and makes it current
+func (f *DocumentFile) AddVersion(v *DocumentVersion) {
+	v.CompanyID = f.CompanyID
+	v.FileID = f.ID
+	v.Version = f.CurrentVersion + 1
+	f.CurrentVersion = v.Version
+	f.FileType = v.FileType
+	f.FileSize = v.FileSize
+	f.UpdatedBy = v.UploadedBy
+}
+
+// DocumentVersion is an uploaded content of a document file. Like voucher
+// attachments, files are scanned before they are stored and their type is
+// sniffed from the content.
+type DocumentVersion struct {
+	TenantModel
+
+	FileID     uuid.UUID            `gorm:"type:uuid;not null" json:"file_id"`
+	Version    int                  `gorm:"not null" json:"version"`
+	FileName   string               `gorm:"type:varchar(255);not null" json:"file_name"`
+	FileSize   int64                `gorm:"not null" json:"file_size"`
+	FileType   string               `gorm:"type:varchar(100)" json:"file_type,omitempty"`
+	FileData   []byte               `gorm:"type:bytea" json:"-"`
+	ScanStatus AttachmentScanStatus `gorm:"type:varchar(20);not null;default:unscanned" json:"scan_status"`
+	Scanner    string               `gorm:"type:varchar(50)" json:"scanner,omitempty"`
+	ScannedAt  *time.Time           `json:"scanned_at,omitempty"`
+	Comment    string               `gorm:"type:varchar(500)" json:"comment,omitempty"`
+	UploadedBy *uuid.UUID           `gorm:"type:uuid" json:"uploaded_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DocumentVersion) TableName() string {
+	return "document_versions"
+}
+
+// QuarantineDocumentVersion builds the quarantine record of an infected
+// version; library files have no voucher
+func QuarantineDocumentVersion(v *DocumentVersion, signature, scanner, ipAddress string) *QuarantinedFile {
+	return &QuarantinedFile{
+		CompanyID:  v.CompanyID,
+		FileName:   v.FileName,
+		FileSize:   v.FileSize,
+		FileType:   v.FileType,
+		FileData:   v.FileData,
+		Signature:  signature,
+		Scanner:    scanner,
+		UploadedBy: v.UploadedBy,
+		IPAddress:  ipAddress,
+		DetectedAt: time.Now(),
+	}
+}
+
+// DocumentFileLink attaches a file of the library to a business document,
+// such as the contract of a loan or the quotation behind a voucher
+type DocumentFileLink struct {
+	TenantModel
+
+	FileID     uuid.UUID    `gorm:"type:uuid;not null" json:"file_id"`
+	EntityType DocumentType `gorm:"type:varchar(30);not null" json:"entity_type"`
+	EntityID   uuid.UUID    `gorm:"type:uuid;not null" json:"entity_id"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DocumentFileLink) TableName() string {
+	return "document_file_links"
+}
+
+// Entity returns the reference of the linked document
+func (l *DocumentFileLink) Entity() DocumentRef {
+	return DocumentRef{Type: l.EntityType, ID: l.EntityID}
+}
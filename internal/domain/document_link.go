@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Document link errors
+var (
+	ErrDocumentLinkNotFound   = errors.New("document link not found")
+	ErrDocumentLinkExists     = errors.New("documents are already linked")
+	ErrDocumentNotFound       = errors.New("linked document not found")
+	ErrInvalidDocumentType    = errors.New("invalid document type")
+	ErrDocumentLinkToItself   = errors.New("a document cannot be linked to itself")
+	ErrDocumentLinkNoteLength = errors.New("document link note must be at most 500 characters")
+)
+
+// MaxDocumentLinkNoteLength is the maximum length of a link note in characters
+const MaxDocumentLinkNoteLength = 500
+
+// DocumentType identifies the kind of a linkable business document
+type DocumentType string
+
+const (
+	DocumentVoucher       DocumentType = "voucher"
+	DocumentTaxInvoice    DocumentType = "tax_invoice"
+	DocumentLoan          DocumentType = "loan"
+	DocumentGrant         DocumentType = "grant"
+	DocumentAllocationRun DocumentType = "allocation_run"
+)
+
+// DocumentTypes lists the linkable document types
+var DocumentTypes = []DocumentType{
+	DocumentVoucher, DocumentTaxInvoice, DocumentLoan, DocumentGrant, DocumentAllocationRun,
+}
+
+// IsValid checks if the document type is valid
+func (t DocumentType) IsValid() bool {
+	for _, valid := range DocumentTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalizedLabel returns the document type label in the locale
+func (t DocumentType) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "document_type", string(t)); label != "" {
+		return label
+	}
+	return string(t)
+}
+
+// DocumentRef references a business document of the company
+type DocumentRef struct {
+	Type DocumentType `json:"type"`
+	ID   uuid.UUID    `json:"id"`
+}
+
+// less orders references by type and then ID
+func (r DocumentRef) less(other DocumentRef) bool {
+	if r.Type != other.Type {
+		return r.Type < other.Type
+	}
+	return r.ID.String() < other.ID.String()
+}
+
+// DocumentLink relates two documents of the company, such as a voucher and
+// the tax invoice it books. Links are undirected; the pair is stored ordered
+// so that a link and its mirror cannot both exist.
+type DocumentLink struct {
+	TenantModel
+
+	SourceType DocumentType `gorm:"type:varchar(30);not null" json:"source_type"`
+	SourceID   uuid.UUID    `gorm:"type:uuid;not null" json:"source_id"`
+	TargetType DocumentType `gorm:"type:varchar(30);not null" json:"target_type"`
+	TargetID   uuid.UUID    `gorm:"type:uuid;not null" json:"target_id"`
+	Note       string       `gorm:"type:varchar(500)" json:"note,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DocumentLink) TableName() string {
+	return "document_links"
+}
+
+// NewDocumentLink validates and builds the link between two documents
+func NewDocumentLink(companyID uuid.UUID, a, b DocumentRef, note string, createdBy uuid.UUID) (*DocumentLink, error) {
+	if !a.Type.IsValid() || !b.Type.IsValid() {
+		return nil, ErrInvalidDocumentType
+	}
+	if a == b {
+		return nil, ErrDocumentLinkToItself
+	}
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxDocumentLinkNoteLength {
+		return nil, ErrDocumentLinkNoteLength
+	}
+	if b.less(a) {
+		a, b = b, a
+	}
+
+	link := &DocumentLink{
+		SourceType: a.Type,
+		SourceID:   a.ID,
+		TargetType: b.Type,
+		TargetID:   b.ID,
+		Note:       note,
+		CreatedBy:  &createdBy,
+	}
+	link.CompanyID = companyID
+	return link, nil
+}
+
+// Source returns the reference of the link's first document
+func (l *DocumentLink) Source() DocumentRef {
+	return DocumentRef{Type: l.SourceType, ID: l.SourceID}
+}
+
+// Target returns the reference of the link's second document
+func (l *DocumentLink) Target() DocumentRef {
+	return DocumentRef{Type: l.TargetType, ID: l.TargetID}
+}
+
+// RelatedDocument is a document related to another, either by an explicit
+// link or by a voucher's reference
+type RelatedDocument struct {
+	Document DocumentRef `json:"document"`
+	Label    string      `json:"label"` // voucher, invoice, loan or grant number
+
+	// LinkID is the explicit link; nil for a voucher's reference
+	LinkID    *uuid.UUID `json:"link_id,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsReference reports whether the relation comes from a voucher's reference
+// rather than an explicit link
+func (d *RelatedDocument) IsReference() bool {
+	return d.LinkID == nil
+}
@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Document Link Tests
+// ============================================================================
+
+func TestNewDocumentLink(t *testing.T) {
+	companyID, userID := uuid.New(), uuid.New()
+	voucher := domain.DocumentRef{Type: domain.DocumentVoucher, ID: uuid.New()}
+	invoice := domain.DocumentRef{Type: domain.DocumentTaxInvoice, ID: uuid.New()}
+
+	link, err := domain.NewDocumentLink(companyID, voucher, invoice, " 3월 매출분 ", userID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice, link.Source())
+	assert.Equal(t, voucher, link.Target())
+	assert.Equal(t, "3월 매출분", link.Note)
+
+	// The mirror link is stored the same way
+	mirror, err := domain.NewDocumentLink(companyID, invoice, voucher, "", userID)
+	require.NoError(t, err)
+	assert.Equal(t, link.Source(), mirror.Source())
+	assert.Equal(t, link.Target(), mirror.Target())
+
+	_, err = domain.NewDocumentLink(companyID, voucher, voucher, "", userID)
+	assert.ErrorIs(t, err, domain.ErrDocumentLinkToItself)
+
+	_, err = domain.NewDocumentLink(companyID, voucher, domain.DocumentRef{Type: "sales_order", ID: uuid.New()}, "", userID)
+	assert.ErrorIs(t, err, domain.ErrInvalidDocumentType)
+
+	_, err = domain.NewDocumentLink(companyID, voucher, invoice, strings.Repeat("가", domain.MaxDocumentLinkNoteLength+1), userID)
+	assert.ErrorIs(t, err, domain.ErrDocumentLinkNoteLength)
+}
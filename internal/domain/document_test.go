@@ -0,0 +1,74 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestDocumentTreeAccess(t *testing.T) {
+	folder := func(parent *uuid.UUID) domain.DocumentFolder {
+		f := domain.DocumentFolder{ParentID: parent, Name: "폴더"}
+		f.ID = uuid.New()
+		return f
+	}
+	shared := folder(nil)
+	hr := folder(nil)
+	payroll := folder(&hr.ID)
+	archive := folder(&payroll.ID)
+
+	tree := domain.NewDocumentTree(
+		[]domain.DocumentFolder{shared, hr, payroll, archive},
+		[]domain.DocumentFolderPermission{
+			{FolderID: hr.ID, Role: domain.UserRoleUser, Access: domain.DocumentAccessRead},
+			{FolderID: payroll.ID, Role: domain.UserRoleUser, Access: domain.DocumentAccessNone},
+		},
+	)
+
+	// Without permissions users write and viewers read
+	assert.Equal(t, domain.DocumentAccessWrite, tree.Access(domain.UserRoleUser, &shared.ID))
+	assert.Equal(t, domain.DocumentAccessRead, tree.Access(domain.UserRoleViewer, &shared.ID))
+	assert.Equal(t, domain.DocumentAccessWrite, tree.Access(domain.UserRoleUser, nil))
+
+	// The nearest folder with permissions decides; unlisted roles have none
+	assert.Equal(t, domain.DocumentAccessRead, tree.Access(domain.UserRoleUser, &hr.ID))
+	assert.Equal(t, domain.DocumentAccessNone, tree.Access(domain.UserRoleViewer, &hr.ID))
+	assert.Equal(t, domain.DocumentAccessNone, tree.Access(domain.UserRoleUser, &archive.ID))
+	assert.Equal(t, domain.DocumentAccessManage, tree.Access(domain.UserRoleAdmin, &archive.ID))
+
+	assert.ElementsMatch(t, []uuid.UUID{shared.ID, hr.ID}, tree.Readable(domain.UserRoleUser))
+	assert.True(t, tree.IsWithin(archive.ID, hr.ID))
+	assert.False(t, tree.IsWithin(hr.ID, archive.ID))
+
+	assert.True(t, domain.DocumentAccessManage.Allows(domain.DocumentAccessWrite))
+	assert.False(t, domain.DocumentAccessRead.Allows(domain.DocumentAccessWrite))
+}
+
+func TestDocumentFileVersions(t *testing.T) {
+	file := &domain.DocumentFile{
+		FolderID: uuid.New(),
+		Name:     " 임대차계약서.pdf ",
+		Metadata: map[string]string{" 거래처 ": " 한빛상사 "},
+	}
+	file.ID = uuid.New()
+	require.NoError(t, file.Validate())
+	assert.Equal(t, "임대차계약서.pdf", file.Name)
+	assert.Equal(t, map[string]string{"거래처": "한빛상사"}, file.Metadata)
+
+	first := &domain.DocumentVersion{FileSize: 100, FileType: "application/pdf"}
+	file.AddVersion(first)
+	second := &domain.DocumentVersion{FileSize: 250, FileType: "application/pdf"}
+	file.AddVersion(second)
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, 2, second.Version)
+	assert.Equal(t, file.ID, second.FileID)
+	assert.Equal(t, 2, file.CurrentVersion)
+	assert.Equal(t, int64(250), file.FileSize)
+
+	file.Metadata = map[string]string{"": "값"}
+	assert.ErrorIs(t, file.Validate(), domain.ErrInvalidDocumentFile)
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/mailtemplate"
+)
+
+// Email template errors
+var (
+	ErrEmailTemplateNotFound        = errors.New("email template not found")
+	ErrInvalidEmailEventType        = errors.New("invalid email event type")
+	ErrEmailTemplateSubjectRequired = errors.New("email template subject is required")
+	ErrEmailTemplateBodyRequired    = errors.New("email template body is required")
+)
+
+// EmailEventType identifies the emails sent for an event; a company can
+// replace the system default template of each
+type EmailEventType string
+
+const (
+	EmailEventInvitation        EmailEventType = "invitation"         // 사용자 초대
+	EmailEventEmailVerification EmailEventType = "email_verification" // 이메일 주소 인증
+	EmailEventReportDelivery    EmailEventType = "report_delivery"    // 정기 보고서 발송
+	EmailEventReportFailure     EmailEventType = "report_failure"     // 정기 보고서 발송 실패 알림
+	EmailEventPartnerStatement  EmailEventType = "partner_statement"  // 거래처원장 발송
+	EmailEventNoteMaturity      EmailEventType = "note_maturity"      // 어음 만기 알림
+	EmailEventContractMilestone EmailEventType = "contract_milestone" // 계약 청구 일정 알림
+)
+
+// EmailEventTypes lists the event types in display order
+var EmailEventTypes = []EmailEventType{
+	EmailEventInvitation, EmailEventEmailVerification, EmailEventReportDelivery,
+	EmailEventReportFailure, EmailEventPartnerStatement, EmailEventNoteMaturity,
+	EmailEventContractMilestone,
+}
+
+// IsValid checks if the event type is valid
+func (t EmailEventType) IsValid() bool {
+	_, ok := emailEvents[t]
+	return ok
+}
+
+// EmailTemplateVariable describes a variable available to the templates of an event
+type EmailTemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Sample      string `json:"sample"` // value used by previews
+}
+
+// emailEvent holds the variables and the system default template of an event
+type emailEvent struct {
+	variables []EmailTemplateVariable
+	subject   string
+	body      string
+}
+
+// emailEvents are the system defaults, used until a company saves its own template
+var emailEvents = map[EmailEventType]emailEvent{
+	EmailEventInvitation: {
+		variables: []EmailTemplateVariable{
+			{"company_name", "회사명", "(주)케이랩"},
+			{"role", "초대된 역할", "user"},
+			{"invite_link", "초대 수락 링크", "https://app.example.com/invite/accept?token=sample"},
+			{"expires_at", "링크 만료 시각", "2026-04-08 09:00"},
+		},
+		subject: "[K-ERP] {{company_name}} 사용자 초대",
+		body: "{{company_name}}에서 K-ERP 사용자({{role}})로 초대했습니다.\n\n" +
+			"아래 링크에서 초대를 수락해 주십시오.\n{{invite_link}}\n\n" +
+			"링크 만료: {{expires_at}}",
+	},
+	EmailEventEmailVerification: {
+		variables: []EmailTemplateVariable{
+			{"user_name", "사용자 이름", "홍길동"},
+			{"verify_link", "인증 링크", "https://app.example.com/verify-email?token=sample"},
+			{"expires_at", "링크 만료 시각", "2026-04-02 09:00"},
+		},
+		subject: "[K-ERP] 이메일 주소 인증",
+		body: "{{user_name}} 님\n\n" +
+			"아래 링크에서 이메일 주소를 인증해 주십시오.\n{{verify_link}}\n\n" +
+			"링크 만료: {{expires_at}}",
+	},
+	EmailEventReportDelivery: {
+		variables: []EmailTemplateVariable{
+			{"schedule_name", "정기 보고서 이름", "월간 손익 보고"},
+			{"report_title", "보고서", "손익계산서"},
+			{"period_from", "기간 시작일", "2026-03-01"},
+			{"period_to", "기간 종료일", "2026-03-31"},
+		},
+		subject: "[K-ERP] {{schedule_name}} ({{report_title}})",
+		body: "{{schedule_name}}\n\n" +
+			"보고서: {{report_title}}\n기간: {{period_from}} ~ {{period_to}}\n\n" +
+			"첨부 파일을 확인해 주세요.\n이 메일은 K-ERP 정기 보고서 설정에 따라 자동 발송되었습니다.",
+	},
+	EmailEventReportFailure: {
+		variables: []EmailTemplateVariable{
+			{"schedule_name", "정기 보고서 이름", "월간 손익 보고"},
+			{"started_at", "실행 시각", "2026-04-01 08:00"},
+			{"error", "오류 내용", "send email: provider unavailable"},
+			{"failure_count", "연속 실패 횟수", "2"},
+			{"pause_notice", "일정이 일시 중지된 경우의 안내, 아니면 빈 값", ""},
+		},
+		subject: "[K-ERP] 정기 보고서 발송 실패: {{schedule_name}}",
+		body: "정기 보고서 '{{schedule_name}}' 발송에 실패했습니다.\n\n" +
+			"실행 시각: {{started_at}}\n오류: {{error}}\n연속 실패: {{failure_count}}회\n{{pause_notice}}",
+	},
+	EmailEventPartnerStatement: {
+		variables: []EmailTemplateVariable{
+			{"company_name", "회사명", "(주)케이랩"},
+			{"partner_name", "거래처명", "(주)한빛상사"},
+			{"period", "기간", "2026-01-01 ~ 2026-03-31"},
+			{"closing_balance", "기말 잔액", "1,250,000"},
+		},
+		subject: "[{{company_name}}] 거래처원장 ({{period}})",
+		body: "{{partner_name}} 귀하\n\n" +
+			"{{period}} 기간의 거래 내역을 첨부와 같이 보내드립니다.\n기말 잔액: {{closing_balance}}\n\n" +
+			"내역에 차이가 있는 경우 회신해 주시기 바랍니다.\n{{company_name}}",
+	},
+	EmailEventNoteMaturity: {
+		variables: []EmailTemplateVariable{
+			{"company_name", "회사명", "(주)케이랩"},
+			{"note_count", "만기 도래 어음 수", "2"},
+			{"notes", "만기 도래 어음 목록", "2026-04-10 받을어음 자가12345678 (주)한빛상사 5,000,000원\n2026-04-12 지급어음 아가87654321 대한물산 3,000,000원"},
+		},
+		subject: "[K-ERP] {{company_name}} 어음 만기 알림 ({{note_count}}건)",
+		body: "{{company_name}}의 어음 {{note_count}}건이 만기가 다가왔거나 지났습니다.\n\n" +
+			"{{notes}}\n\n" +
+			"결제 계좌의 잔액과 만기 결제 처리를 확인해 주세요.",
+	},
+	EmailEventContractMilestone: {
+		variables: []EmailTemplateVariable{
+			{"company_name", "회사명", "(주)케이랩"},
+			{"milestone_count", "청구 예정 건수", "2"},
+			{"milestones", "청구 예정 목록", "2026-05-31 C-2026-001 (주)한빛상사 중도금 30,000,000원 (D-3)\n2026-06-02 C-2026-004 대한물산 잔금 12,000,000원 (D-5)"},
+		},
+		subject: "[K-ERP] {{company_name}} 계약 청구 일정 알림 ({{milestone_count}}건)",
+		body: "{{company_name}}의 계약 청구 일정 {{milestone_count}}건이 다가왔거나 지났습니다.\n\n" +
+			"{{milestones}}\n\n" +
+			"계약 화면에서 세금계산서와 전표를 생성해 주세요.",
+	},
+}
+
+// Variables returns the variables available to the templates of the event
+func (t EmailEventType) Variables() []EmailTemplateVariable {
+	return emailEvents[t].variables
+}
+
+// SampleData returns the sample values of the variables, for previews
+func (t EmailEventType) SampleData() map[string]string {
+	data := make(map[string]string)
+	for _, v := range t.Variables() {
+		data[v.Name] = v.Sample
+	}
+	return data
+}
+
+// EmailTemplate is a version of a company's template for the emails of an
+// event. Saving a template adds a version; the active version is used until
+// the company saves another, restores an older one or resets the event to the
+// system default. The default itself is an EmailTemplate with version 0.
+type EmailTemplate struct {
+	TenantModel
+
+	EventType EmailEventType `gorm:"type:varchar(50);not null" json:"event_type"`
+	Version   int            `gorm:"not null" json:"version"`
+	Subject   string         `gorm:"type:varchar(200);not null" json:"subject"`
+	Body      string         `gorm:"type:text;not null" json:"body"`
+	IsActive  bool           `gorm:"not null;default:true" json:"is_active"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
+
+// DefaultEmailTemplate returns the system default template of the event
+func DefaultEmailTemplate(companyID uuid.UUID, eventType EmailEventType) *EmailTemplate {
+	event := emailEvents[eventType]
+	return &EmailTemplate{
+		TenantModel: TenantModel{CompanyID: companyID},
+		EventType:   eventType,
+		Subject:     event.subject,
+		Body:        event.body,
+		IsActive:    true,
+	}
+}
+
+// NewEmailTemplate creates a company's template; the repository numbers it
+// as the next version when it is saved
+func NewEmailTemplate(companyID uuid.UUID, eventType EmailEventType, subject, body string, createdBy uuid.UUID) *EmailTemplate {
+	return &EmailTemplate{
+		TenantModel: TenantModel{CompanyID: companyID},
+		EventType:   eventType,
+		Subject:     strings.TrimSpace(subject),
+		Body:        body,
+		IsActive:    true,
+		CreatedBy:   &createdBy,
+	}
+}
+
+// IsDefault returns true for the system default template
+func (t *EmailTemplate) IsDefault() bool {
+	return t.Version == 0
+}
+
+// Validate checks the template parses and uses only the variables of its event
+func (t *EmailTemplate) Validate() error {
+	if !t.EventType.IsValid() {
+		return ErrInvalidEmailEventType
+	}
+	if t.Subject == "" {
+		return ErrEmailTemplateSubjectRequired
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		return ErrEmailTemplateBodyRequired
+	}
+	_, _, err := t.parse()
+	return err
+}
+
+// Render substitutes the variables into the subject and body
+func (t *EmailTemplate) Render(vars map[string]string) (subject, body string, err error) {
+	subjectTmpl, bodyTmpl, err := t.parse()
+	if err != nil {
+		return "", "", err
+	}
+	// Values are placed in a header; line breaks would start new ones
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subjectTmpl.Render(vars))
+	return subject, bodyTmpl.Render(vars), nil
+}
+
+// parse parses the subject and body and checks their variables
+func (t *EmailTemplate) parse() (*mailtemplate.Template, *mailtemplate.Template, error) {
+	allowed := make([]string, 0, len(t.EventType.Variables()))
+	for _, v := range t.EventType.Variables() {
+		allowed = append(allowed, v.Name)
+	}
+
+	var tmpls [2]*mailtemplate.Template
+	for i, src := range []string{t.Subject, t.Body} {
+		tmpl, err := mailtemplate.Parse(src)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := tmpl.Check(allowed); err != nil {
+			return nil, nil, err
+		}
+		tmpls[i] = tmpl
+	}
+	return tmpls[0], tmpls[1], nil
+}
@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mailtemplate"
+)
+
+// ============================================================================
+// EmailTemplate Tests
+// ============================================================================
+
+func TestDefaultEmailTemplate_RendersEveryEvent(t *testing.T) {
+	companyID := uuid.New()
+	for _, eventType := range domain.EmailEventTypes {
+		t.Run(string(eventType), func(t *testing.T) {
+			tmpl := domain.DefaultEmailTemplate(companyID, eventType)
+			require.NoError(t, tmpl.Validate())
+			assert.True(t, tmpl.IsDefault())
+
+			subject, body, err := tmpl.Render(eventType.SampleData())
+			require.NoError(t, err)
+			assert.NotEmpty(t, subject)
+			assert.NotContains(t, body, "{{")
+		})
+	}
+}
+
+func TestEmailTemplate_Validate(t *testing.T) {
+	newTemplate := func(subject, body string) *domain.EmailTemplate {
+		return domain.NewEmailTemplate(uuid.New(), domain.EmailEventInvitation, subject, body, uuid.New())
+	}
+
+	t.Run("accepts the variables of the event", func(t *testing.T) {
+		assert.NoError(t, newTemplate("{{company_name}} 초대", "{{invite_link}}").Validate())
+	})
+
+	t.Run("rejects variables of other events", func(t *testing.T) {
+		err := newTemplate("초대", "{{verify_link}}").Validate()
+		assert.True(t, errors.Is(err, mailtemplate.ErrUnknownVariable))
+	})
+
+	t.Run("rejects invalid syntax", func(t *testing.T) {
+		err := newTemplate("초대", "{{invite_link").Validate()
+		assert.True(t, errors.Is(err, mailtemplate.ErrSyntax))
+	})
+
+	t.Run("requires a subject and a body", func(t *testing.T) {
+		assert.Equal(t, domain.ErrEmailTemplateSubjectRequired, newTemplate("  ", "본문").Validate())
+		assert.Equal(t, domain.ErrEmailTemplateBodyRequired, newTemplate("제목", "\n").Validate())
+	})
+
+	t.Run("rejects unknown events", func(t *testing.T) {
+		tmpl := domain.NewEmailTemplate(uuid.New(), "dunning", "제목", "본문", uuid.New())
+		assert.Equal(t, domain.ErrInvalidEmailEventType, tmpl.Validate())
+	})
+}
+
+func TestEmailTemplate_RenderKeepsSubjectOnOneLine(t *testing.T) {
+	tmpl := domain.NewEmailTemplate(uuid.New(), domain.EmailEventInvitation, "{{company_name}} 초대", "{{company_name}}", uuid.New())
+
+	subject, body, err := tmpl.Render(map[string]string{"company_name": "케이랩\r\nBcc: x@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "케이랩  Bcc: x@example.com 초대", subject)
+	assert.Equal(t, "케이랩\r\nBcc: x@example.com", body)
+}
@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Employee advance errors
+var (
+	ErrEmployeeAdvanceNotFound    = errors.New("employee advance not found")
+	ErrInvalidEmployeeAdvance     = errors.New("invalid employee advance")
+	ErrInvalidAdvanceSettlement   = errors.New("invalid advance settlement")
+	ErrEmployeeAdvanceSettled     = errors.New("employee advance is already settled")
+	ErrAdvanceRefundTooLarge      = errors.New("refund exceeds the advance balance")
+	ErrEmployeeAdvanceChanged     = errors.New("advance changed while settling")
+	ErrAdvanceSettlementNoExpense = errors.New("settlement needs expenses or a refund")
+	ErrAdvanceSettlementNotFound  = errors.New("advance settlement not found")
+)
+
+// EmployeeAdvanceStatus is the status of an employee advance
+type EmployeeAdvanceStatus string
+
+const (
+	EmployeeAdvanceOutstanding EmployeeAdvanceStatus = "outstanding" // not or partly settled
+	EmployeeAdvanceSettled     EmployeeAdvanceStatus = "settled"
+)
+
+// IsValid checks if the status is valid
+func (s EmployeeAdvanceStatus) IsValid() bool {
+	return s == EmployeeAdvanceOutstanding || s == EmployeeAdvanceSettled
+}
+
+// EmployeeAdvance is cash advanced to an employee (가지급금·전도금), e.g. for a
+// business trip, paid from a bank account into the advance account. The
+// employee settles it by the expenses spent and returns the rest; expenses
+// beyond the advance are reimbursed.
+type EmployeeAdvance struct {
+	TenantModel
+
+	EmployeeID   uuid.UUID  `gorm:"type:uuid;not null" json:"employee_id"` // user the cash is advanced to
+	DepartmentID *uuid.UUID `gorm:"type:uuid" json:"department_id,omitempty"`
+	Purpose      string     `gorm:"type:varchar(200);not null" json:"purpose"`
+	AdvanceDate  time.Time  `gorm:"type:date;not null" json:"advance_date"`
+	DueDate      *time.Time `gorm:"type:date" json:"due_date,omitempty"` // settlement due
+	Amount       int64      `gorm:"not null" json:"amount"`
+
+	AccountID     uuid.UUID `gorm:"type:uuid;not null" json:"account_id"`      // advance account, e.g. 가지급금
+	BankAccountID uuid.UUID `gorm:"type:uuid;not null" json:"bank_account_id"` // account the advance is paid from
+
+	SettledAmount int64                 `gorm:"not null;default:0" json:"settled_amount"` // expenses and refunds applied
+	Status        EmployeeAdvanceStatus `gorm:"type:varchar(20);not null;default:outstanding" json:"status"`
+	VoucherID     *uuid.UUID            `gorm:"type:uuid" json:"voucher_id,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	Settlements []EmployeeAdvanceSettlement `gorm:"foreignKey:AdvanceID" json:"settlements,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (EmployeeAdvance) TableName() string {
+	return "employee_advances"
+}
+
+// Validate checks a new advance
+func (a *EmployeeAdvance) Validate() error {
+	a.Purpose = strings.TrimSpace(a.Purpose)
+	switch {
+	case a.EmployeeID == uuid.Nil, a.Purpose == "", a.AdvanceDate.IsZero(), a.Amount <= 0,
+		a.AccountID == uuid.Nil, a.BankAccountID == uuid.Nil:
+		return ErrInvalidEmployeeAdvance
+	case a.DueDate != nil && a.DueDate.Before(a.AdvanceDate):
+		return ErrInvalidEmployeeAdvance
+	}
+	return nil
+}
+
+// Balance returns the advance not settled yet
+func (a *EmployeeAdvance) Balance() int64 {
+	return a.Amount - a.SettledAmount
+}
+
+// AdvanceExpense is an expense the employee spent from the advance
+type AdvanceExpense struct {
+	TenantModel
+
+	SettlementID uuid.UUID `gorm:"type:uuid;not null" json:"settlement_id"`
+	AccountID    uuid.UUID `gorm:"type:uuid;not null" json:"account_id"`
+	Amount       int64     `gorm:"not null" json:"amount"`
+	Description  string    `gorm:"type:varchar(200);not null" json:"description"`
+	ReceiptNo    string    `gorm:"type:varchar(50)" json:"receipt_no,omitempty"`
+
+	// Checked against the travel policy
+	Category    ExpenseCategory `gorm:"type:varchar(20);not null;default:general" json:"category"`
+	Destination string          `gorm:"type:varchar(50)" json:"destination,omitempty"`   // per diem
+	Days        int             `gorm:"not null;default:0" json:"days,omitempty"`        // per diem
+	DistanceKm  int             `gorm:"not null;default:0" json:"distance_km,omitempty"` // mileage
+}
+
+// TableName specifies the table name for GORM
+func (AdvanceExpense) TableName() string {
+	return "employee_advance_expenses"
+}
+
+// EmployeeAdvanceSettlement settles an advance by the expenses claimed and
+// the cash refunded. Expenses beyond the balance are reimbursed to the
+// employee; the offset voucher books all of them against the advance account.
+type EmployeeAdvanceSettlement struct {
+	TenantModel
+
+	AdvanceID       uuid.UUID  `gorm:"type:uuid;not null" json:"advance_id"`
+	SettlementDate  time.Time  `gorm:"type:date;not null" json:"settlement_date"`
+	ExpenseAmount   int64      `gorm:"not null" json:"expense_amount"`
+	RefundAmount    int64      `gorm:"not null;default:0" json:"refund_amount"`    // returned by the employee
+	ReimburseAmount int64      `gorm:"not null;default:0" json:"reimburse_amount"` // expenses beyond the balance
+	AppliedAmount   int64      `gorm:"not null" json:"applied_amount"`             // settled off the advance
+	BankAccountID   *uuid.UUID `gorm:"type:uuid" json:"bank_account_id,omitempty"` // refunded into or reimbursed from
+	VoucherID       *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+
+	// Violations of the travel policy, flagged for the approver
+	PolicyID         *uuid.UUID              `gorm:"type:uuid" json:"policy_id,omitempty"`
+	PolicyViolations []TravelPolicyViolation `gorm:"type:jsonb;serializer:json" json:"policy_violations,omitempty"`
+	ReviewStatus     SettlementReviewStatus  `gorm:"type:varchar(20);not null;default:none" json:"review_status"`
+	ReviewedBy       *uuid.UUID              `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time              `json:"reviewed_at,omitempty"`
+	ReviewNote       string                  `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	Expenses []AdvanceExpense `gorm:"foreignKey:SettlementID" json:"expenses"`
+}
+
+// TableName specifies the table name for GORM
+func (EmployeeAdvanceSettlement) TableName() string {
+	return "employee_advance_settlements"
+}
+
+// Settle applies the expenses and the refund to the balance of the advance
+// and returns the settlement. A refund is taken only when the expenses are
+// within the balance; expenses beyond it are reimbursed and settle it.
+func (a *EmployeeAdvance) Settle(date time.Time, expenses []AdvanceExpense, refund int64) (*EmployeeAdvanceSettlement, error) {
+	if a.Status == EmployeeAdvanceSettled {
+		return nil, ErrEmployeeAdvanceSettled
+	}
+	if date.IsZero() || date.Before(a.AdvanceDate) || refund < 0 {
+		return nil, ErrInvalidAdvanceSettlement
+	}
+	if len(expenses) == 0 && refund == 0 {
+		return nil, ErrAdvanceSettlementNoExpense
+	}
+
+	settlement := &EmployeeAdvanceSettlement{
+		TenantModel:    TenantModel{CompanyID: a.CompanyID},
+		AdvanceID:      a.ID,
+		SettlementDate: date,
+		RefundAmount:   refund,
+		ReviewStatus:   SettlementReviewNone,
+		Expenses:       expenses,
+	}
+	for i := range settlement.Expenses {
+		expense := &settlement.Expenses[i]
+		expense.CompanyID = a.CompanyID
+		expense.Description = strings.TrimSpace(expense.Description)
+		expense.ReceiptNo = strings.TrimSpace(expense.ReceiptNo)
+		expense.Destination = strings.TrimSpace(expense.Destination)
+		if expense.Category == "" {
+			expense.Category = ExpenseGeneral
+		}
+		if expense.AccountID == uuid.Nil || expense.Amount <= 0 || expense.Description == "" || !expense.Category.IsValid() {
+			return nil, ErrInvalidAdvanceSettlement
+		}
+		if (expense.Category == ExpensePerDiem && expense.Days <= 0) ||
+			(expense.Category == ExpenseMileage && expense.DistanceKm <= 0) {
+			return nil, ErrInvalidAdvanceSettlement
+		}
+		settlement.ExpenseAmount += expense.Amount
+	}
+
+	balance := a.Balance()
+	switch {
+	case settlement.ExpenseAmount > balance && refund > 0:
+		return nil, ErrAdvanceRefundTooLarge
+	case settlement.ExpenseAmount > balance:
+		settlement.ReimburseAmount = settlement.ExpenseAmount - balance
+		settlement.AppliedAmount = balance
+	case settlement.ExpenseAmount+refund > balance:
+		return nil, ErrAdvanceRefundTooLarge
+	default:
+		settlement.AppliedAmount = settlement.ExpenseAmount + refund
+	}
+
+	a.SettledAmount += settlement.AppliedAmount
+	if a.SettledAmount == a.Amount {
+		a.Status = EmployeeAdvanceSettled
+	}
+	return settlement, nil
+}
+
+// EmployeeAdvanceBalance is an advance with the part settled through a date
+type EmployeeAdvanceBalance struct {
+	AdvanceID    uuid.UUID  `json:"advance_id"`
+	EmployeeID   uuid.UUID  `json:"employee_id"`
+	EmployeeName string     `json:"employee_name"`
+	Purpose      string     `json:"purpose"`
+	AdvanceDate  time.Time  `json:"advance_date"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	Amount       int64      `json:"amount"`
+	Settled      int64      `json:"settled"`
+}
+
+// Outstanding returns the part not settled through the date
+func (b EmployeeAdvanceBalance) Outstanding() int64 {
+	return b.Amount - b.Settled
+}
+
+// EmployeeAdvanceSummary totals the advances outstanding of an employee at a date
+type EmployeeAdvanceSummary struct {
+	EmployeeID   uuid.UUID `json:"employee_id"`
+	EmployeeName string    `json:"employee_name"`
+	Count        int       `json:"count"`
+	Outstanding  int64     `json:"outstanding"`
+	Overdue      int64     `json:"overdue"` // outstanding past the due date
+	OldestDate   time.Time `json:"oldest_date"`
+
+	Advances []EmployeeAdvanceBalance `json:"advances"`
+}
+
+// SummarizeOutstandingAdvances totals the advances outstanding at asOf by
+// employee, the largest outstanding first
+func SummarizeOutstandingAdvances(balances []EmployeeAdvanceBalance, asOf time.Time) []EmployeeAdvanceSummary {
+	byEmployee := make(map[uuid.UUID]*EmployeeAdvanceSummary)
+	var order []uuid.UUID
+	for _, b := range balances {
+		outstanding := b.Outstanding()
+		if outstanding <= 0 || b.AdvanceDate.After(asOf) {
+			continue
+		}
+		summary, ok := byEmployee[b.EmployeeID]
+		if !ok {
+			summary = &EmployeeAdvanceSummary{EmployeeID: b.EmployeeID, EmployeeName: b.EmployeeName, OldestDate: b.AdvanceDate}
+			byEmployee[b.EmployeeID] = summary
+			order = append(order, b.EmployeeID)
+		}
+		summary.Count++
+		summary.Advances = append(summary.Advances, b)
+		summary.Outstanding += outstanding
+		if b.DueDate != nil && b.DueDate.Before(asOf) {
+			summary.Overdue += outstanding
+		}
+		if b.AdvanceDate.Before(summary.OldestDate) {
+			summary.OldestDate = b.AdvanceDate
+		}
+	}
+
+	summaries := make([]EmployeeAdvanceSummary, len(order))
+	for i, id := range order {
+		summaries[i] = *byEmployee[id]
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Outstanding > summaries[j].Outstanding
+	})
+	return summaries
+}
@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestEmployeeAdvanceSettle(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 6, d, 0, 0, 0, 0, time.UTC) }
+	newAdvance := func() *domain.EmployeeAdvance {
+		a := &domain.EmployeeAdvance{AdvanceDate: day(1), Amount: 500000, Status: domain.EmployeeAdvanceOutstanding}
+		a.ID = uuid.New()
+		a.CompanyID = uuid.New()
+		return a
+	}
+	expense := func(amount int64) domain.AdvanceExpense {
+		return domain.AdvanceExpense{AccountID: uuid.New(), Amount: amount, Description: "출장 숙박비"}
+	}
+
+	t.Run("partial then refund", func(t *testing.T) {
+		advance := newAdvance()
+		settlement, err := advance.Settle(day(5), []domain.AdvanceExpense{expense(200000), expense(80000)}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(280000), settlement.ExpenseAmount)
+		assert.Equal(t, int64(280000), settlement.AppliedAmount)
+		assert.Equal(t, advance.CompanyID, settlement.Expenses[0].CompanyID)
+		assert.Equal(t, domain.EmployeeAdvanceOutstanding, advance.Status)
+
+		settlement, err = advance.Settle(day(8), []domain.AdvanceExpense{expense(20000)}, 200000)
+		require.NoError(t, err)
+		assert.Equal(t, int64(220000), settlement.AppliedAmount)
+		assert.Equal(t, int64(0), advance.Balance())
+		assert.Equal(t, domain.EmployeeAdvanceSettled, advance.Status)
+
+		_, err = advance.Settle(day(9), nil, 1000)
+		assert.ErrorIs(t, err, domain.ErrEmployeeAdvanceSettled)
+	})
+
+	t.Run("expenses beyond the balance are reimbursed", func(t *testing.T) {
+		advance := newAdvance()
+		settlement, err := advance.Settle(day(5), []domain.AdvanceExpense{expense(530000)}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(500000), settlement.AppliedAmount)
+		assert.Equal(t, int64(30000), settlement.ReimburseAmount)
+		assert.Equal(t, domain.EmployeeAdvanceSettled, advance.Status)
+
+		_, err = newAdvance().Settle(day(5), []domain.AdvanceExpense{expense(530000)}, 1000)
+		assert.ErrorIs(t, err, domain.ErrAdvanceRefundTooLarge)
+	})
+
+	t.Run("invalid settlements", func(t *testing.T) {
+		advance := newAdvance()
+		_, err := advance.Settle(day(5), []domain.AdvanceExpense{expense(100000)}, 450000)
+		assert.ErrorIs(t, err, domain.ErrAdvanceRefundTooLarge)
+		_, err = advance.Settle(day(5), nil, 0)
+		assert.ErrorIs(t, err, domain.ErrAdvanceSettlementNoExpense)
+		_, err = advance.Settle(time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), nil, 1000)
+		assert.ErrorIs(t, err, domain.ErrInvalidAdvanceSettlement)
+		assert.Equal(t, int64(0), advance.SettledAmount)
+	})
+}
+
+func TestSummarizeOutstandingAdvances(t *testing.T) {
+	asOf := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	due := asOf.AddDate(0, 0, -10)
+	kim, lee := uuid.New(), uuid.New()
+	balances := []domain.EmployeeAdvanceBalance{
+		{EmployeeID: kim, EmployeeName: "김영수", AdvanceDate: asOf.AddDate(0, 0, -20), DueDate: &due, Amount: 300000, Settled: 100000},
+		{EmployeeID: lee, EmployeeName: "이민지", AdvanceDate: asOf.AddDate(0, 0, -5), Amount: 500000},
+		{EmployeeID: kim, EmployeeName: "김영수", AdvanceDate: asOf.AddDate(0, 0, -40), Amount: 150000},
+		{EmployeeID: kim, EmployeeName: "김영수", AdvanceDate: asOf.AddDate(0, 0, -3), Amount: 80000, Settled: 80000},
+		{EmployeeID: lee, EmployeeName: "이민지", AdvanceDate: asOf.AddDate(0, 0, 1), Amount: 90000},
+	}
+
+	summaries := domain.SummarizeOutstandingAdvances(balances, asOf)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, lee, summaries[0].EmployeeID)
+	assert.Equal(t, int64(500000), summaries[0].Outstanding)
+	assert.Equal(t, 1, summaries[0].Count)
+
+	assert.Equal(t, kim, summaries[1].EmployeeID)
+	assert.Equal(t, 2, summaries[1].Count)
+	assert.Equal(t, int64(350000), summaries[1].Outstanding)
+	assert.Equal(t, int64(200000), summaries[1].Overdue)
+	assert.Equal(t, asOf.AddDate(0, 0, -40), summaries[1].OldestDate)
+}
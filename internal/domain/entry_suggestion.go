@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ErrSuggestionCriteriaRequired is returned when entry suggestions are asked
+// for without a description or a partner
+var ErrSuggestionCriteriaRequired = errors.New("description or partner is required")
+
+// Entry suggestion limits
+const (
+	EntrySuggestionLimit          = 10
+	EntrySuggestionLookbackMonths = 24 // posted vouchers searched, up to today
+	entrySuggestionMaxKeywords    = 5
+	entrySuggestionMinKeywordLen  = 2 // in characters; shorter words match too much
+)
+
+// EntrySuggestion is an account, department and tax code combination of
+// posted entries with a similar description or the same partner, offered
+// while a voucher is typed in. Score ranks the combinations: each entry counts
+// once for the partner and once for the description it matches.
+type EntrySuggestion struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	AccountCode string    `json:"account_code"`
+	AccountName string    `json:"account_name"`
+
+	DepartmentID   *uuid.UUID `json:"department_id,omitempty"`
+	DepartmentCode string     `json:"department_code,omitempty"`
+	DepartmentName string     `json:"department_name,omitempty"`
+
+	TaxCodeID   *uuid.UUID `json:"tax_code_id,omitempty"`
+	TaxCode     string     `json:"tax_code,omitempty"`
+	TaxCodeName string     `json:"tax_code_name,omitempty"`
+
+	UseCount   int       `json:"use_count"`
+	Score      int       `json:"score"`
+	LastUsedAt time.Time `json:"last_used_at"` // voucher date of the latest entry
+}
+
+// SuggestionKeywords splits a description into the words matched against
+// earlier descriptions: distinct, lower-cased, of at least two characters and
+// at most five of them, in order
+func SuggestionKeywords(description string) []string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	keywords := make([]string, 0, entrySuggestionMaxKeywords)
+	seen := make(map[string]bool, len(words))
+	for _, w := range words {
+		if utf8.RuneCountInString(w) < entrySuggestionMinKeywordLen || seen[w] {
+			continue
+		}
+		seen[w] = true
+		keywords = append(keywords, w)
+		if len(keywords) == entrySuggestionMaxKeywords {
+			break
+		}
+	}
+	return keywords
+}
@@ -0,0 +1,15 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestSuggestionKeywords(t *testing.T) {
+	assert.Equal(t, []string{"4월", "사무실", "임대료", "office"}, domain.SuggestionKeywords("4월 사무실 임대료 - Office (임대료)"))
+	assert.Empty(t, domain.SuggestionKeywords(" a, b "))
+	assert.Len(t, domain.SuggestionKeywords("one two three four five six"), 5)
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPeriodGrouping is returned for an unknown grouping or a period
+// number outside its year
+var ErrInvalidPeriodGrouping = errors.New("period grouping must be month, quarter or half, with a number within the year")
+
+// PeriodGrouping is how the monthly fiscal periods of a year are grouped for
+// reporting. Quarterly (분기) and half-year (반기) groups are the periods of
+// Korean interim financial statements.
+type PeriodGrouping string
+
+const (
+	PeriodGroupingMonth   PeriodGrouping = "month"
+	PeriodGroupingQuarter PeriodGrouping = "quarter"
+	PeriodGroupingHalf    PeriodGrouping = "half"
+)
+
+// IsValid checks if the grouping is valid
+func (g PeriodGrouping) IsValid() bool {
+	switch g {
+	case PeriodGroupingMonth, PeriodGroupingQuarter, PeriodGroupingHalf:
+		return true
+	}
+	return false
+}
+
+// Months returns the number of months in a group
+func (g PeriodGrouping) Months() int {
+	switch g {
+	case PeriodGroupingQuarter:
+		return 3
+	case PeriodGroupingHalf:
+		return 6
+	}
+	return 1
+}
+
+// Count returns the number of groups in a fiscal year
+func (g PeriodGrouping) Count() int {
+	return 12 / g.Months()
+}
+
+// GroupOf returns the number of the group the fiscal month falls in, from 1
+func (g PeriodGrouping) GroupOf(month int) int {
+	return (month-1)/g.Months() + 1
+}
+
+// MonthRange returns the first and last fiscal month of group number n
+func (g PeriodGrouping) MonthRange(n int) (int, int, error) {
+	if !g.IsValid() || n < 1 || n > g.Count() {
+		return 0, 0, ErrInvalidPeriodGrouping
+	}
+	last := n * g.Months()
+	return last - g.Months() + 1, last, nil
+}
+
+// Name returns the name of group number n of the year, such as 2024-Q1 or
+// 2024-H2
+func (g PeriodGrouping) Name(year, n int) string {
+	switch g {
+	case PeriodGroupingQuarter:
+		return fmt.Sprintf("%d-Q%d", year, n)
+	case PeriodGroupingHalf:
+		return fmt.Sprintf("%d-H%d", year, n)
+	}
+	return fmt.Sprintf("%d-%02d", year, n)
+}
+
+// FiscalPeriodGroup is a quarter or half-year of the monthly fiscal periods
+type FiscalPeriodGroup struct {
+	Grouping      PeriodGrouping     `json:"grouping"`
+	FiscalYear    int                `json:"fiscal_year"`
+	Number        int                `json:"number"`
+	Name          string             `json:"name"`
+	FromMonth     int                `json:"from_month"`
+	ToMonth       int                `json:"to_month"`
+	StartDate     time.Time          `json:"start_date"`
+	EndDate       time.Time          `json:"end_date"`
+	Status        FiscalPeriodStatus `json:"status"`
+	MissingMonths []int              `json:"missing_months,omitempty"` // months without a created period
+	Periods       []FiscalPeriod     `json:"periods"`
+}
+
+// GroupFiscalPeriods groups the fiscal periods of a year. A group is open
+// while any of its months is open or not created yet, and locked once all of
+// them are locked.
+func GroupFiscalPeriods(year int, periods []FiscalPeriod, grouping PeriodGrouping) []FiscalPeriodGroup {
+	byMonth := make(map[int]FiscalPeriod, len(periods))
+	for _, p := range periods {
+		if p.FiscalYear == year {
+			byMonth[p.FiscalMonth] = p
+		}
+	}
+
+	groups := make([]FiscalPeriodGroup, 0, grouping.Count())
+	for n := 1; n <= grouping.Count(); n++ {
+		from, to, err := grouping.MonthRange(n)
+		if err != nil {
+			return nil
+		}
+		group := FiscalPeriodGroup{
+			Grouping:   grouping,
+			FiscalYear: year,
+			Number:     n,
+			Name:       grouping.Name(year, n),
+			FromMonth:  from,
+			ToMonth:    to,
+			StartDate:  time.Date(year, time.Month(from), 1, 0, 0, 0, 0, time.UTC),
+			EndDate:    time.Date(year, time.Month(to)+1, 0, 0, 0, 0, 0, time.UTC),
+			Status:     FiscalPeriodLocked,
+			Periods:    []FiscalPeriod{},
+		}
+		for month := from; month <= to; month++ {
+			p, ok := byMonth[month]
+			if !ok {
+				group.MissingMonths = append(group.MissingMonths, month)
+				group.Status = FiscalPeriodOpen
+				continue
+			}
+			group.Periods = append(group.Periods, p)
+			switch {
+			case p.Status == FiscalPeriodOpen:
+				group.Status = FiscalPeriodOpen
+			case p.Status == FiscalPeriodClosed && group.Status == FiscalPeriodLocked:
+				group.Status = FiscalPeriodClosed
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
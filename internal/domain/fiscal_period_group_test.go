@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestPeriodGroupingMonthRange(t *testing.T) {
+	from, to, err := domain.PeriodGroupingQuarter.MonthRange(3)
+	require.NoError(t, err)
+	assert.Equal(t, 7, from)
+	assert.Equal(t, 9, to)
+	assert.Equal(t, 3, domain.PeriodGroupingQuarter.GroupOf(9))
+	assert.Equal(t, 2, domain.PeriodGroupingHalf.GroupOf(7))
+
+	_, _, err = domain.PeriodGroupingHalf.MonthRange(3)
+	assert.ErrorIs(t, err, domain.ErrInvalidPeriodGrouping)
+}
+
+func TestGroupFiscalPeriods(t *testing.T) {
+	var periods []domain.FiscalPeriod
+	for month := 1; month <= 5; month++ {
+		status := domain.FiscalPeriodLocked
+		if month > 3 {
+			status = domain.FiscalPeriodClosed
+		}
+		periods = append(periods, domain.FiscalPeriod{FiscalYear: 2024, FiscalMonth: month, Status: status})
+	}
+
+	quarters := domain.GroupFiscalPeriods(2024, periods, domain.PeriodGroupingQuarter)
+	require.Len(t, quarters, 4)
+	assert.Equal(t, "2024-Q1", quarters[0].Name)
+	assert.Equal(t, domain.FiscalPeriodLocked, quarters[0].Status)
+	assert.Equal(t, domain.FiscalPeriodOpen, quarters[1].Status)
+	assert.Equal(t, []int{6}, quarters[1].MissingMonths)
+	assert.Equal(t, "2024-06-30", quarters[1].EndDate.Format("2006-01-02"))
+
+	halves := domain.GroupFiscalPeriods(2024, periods, domain.PeriodGroupingHalf)
+	require.Len(t, halves, 2)
+	assert.Equal(t, "2024-H1", halves[0].Name)
+	assert.Len(t, halves[0].Periods, 5)
+}
@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Grant errors
+var (
+	ErrGrantNotFound           = errors.New("grant not found")
+	ErrGrantNoExists           = errors.New("grant number already exists")
+	ErrInvalidGrant            = errors.New("invalid grant")
+	ErrGrantAmountExceeded     = errors.New("amount exceeds the awarded grant")
+	ErrGrantExpenseNotFound    = errors.New("grant expense not found")
+	ErrGrantExpenseLinked      = errors.New("voucher is already linked to the grant")
+	ErrGrantExpenseRecognized  = errors.New("expense is already recognized as grant income")
+	ErrGrantExpenseOutOfPeriod = errors.New("expense is outside the grant program period")
+	ErrGrantVoucherNotLinkable = errors.New("only posted vouchers can be linked to a grant")
+)
+
+// GrantRecognitionMethod is how the deferred grant income is recognized
+type GrantRecognitionMethod string
+
+const (
+	// GrantRecognitionExpense recognizes income as the eligible expenses linked
+	// to the grant are incurred (수익관련 보조금)
+	GrantRecognitionExpense GrantRecognitionMethod = "expense"
+	// GrantRecognitionStraightLine recognizes income evenly over the months of
+	// the program, e.g. the useful life of a subsidized asset (자산관련 보조금)
+	GrantRecognitionStraightLine GrantRecognitionMethod = "straight_line"
+)
+
+// IsValid checks if the recognition method is valid
+func (m GrantRecognitionMethod) IsValid() bool {
+	return m == GrantRecognitionExpense || m == GrantRecognitionStraightLine
+}
+
+// Grant is a government subsidy (정부보조금) awarded to the company. Money
+// received is deferred and recognized as income by the schedule of the
+// recognition method. Amounts are in won.
+type Grant struct {
+	TenantModel
+
+	GrantNo           string                 `gorm:"type:varchar(50);not null" json:"grant_no"` // agreement number of the agency
+	Name              string                 `gorm:"type:varchar(200);not null" json:"name"`    // program name (사업명)
+	Agency            string                 `gorm:"type:varchar(200);not null" json:"agency"`  // awarding agency (지원기관)
+	PartnerID         *uuid.UUID             `gorm:"type:uuid" json:"partner_id,omitempty"`
+	AwardedAmount     int64                  `gorm:"not null" json:"awarded_amount"`
+	ProgramStart      time.Time              `gorm:"type:date;not null" json:"program_start"`
+	ProgramEnd        time.Time              `gorm:"type:date;not null" json:"program_end"`
+	RecognitionMethod GrantRecognitionMethod `gorm:"type:varchar(20);not null" json:"recognition_method"`
+	Description       string                 `gorm:"type:varchar(500)" json:"description,omitempty"`
+
+	// Accounts of the generated vouchers
+	DeferredIncomeAccountID uuid.UUID `gorm:"type:uuid;not null" json:"deferred_income_account_id"` // 정부보조금 (이연수익)
+	IncomeAccountID         uuid.UUID `gorm:"type:uuid;not null" json:"income_account_id"`          // 정부보조금수익
+	BankAccountID           uuid.UUID `gorm:"type:uuid;not null" json:"bank_account_id"`            // account the grant is paid into
+
+	// Balances, updated by receipts and recognitions
+	ReceivedAmount    int64      `gorm:"not null;default:0" json:"received_amount"`
+	RecognizedAmount  int64      `gorm:"not null;default:0" json:"recognized_amount"`
+	RecognizedThrough *time.Time `gorm:"type:date" json:"recognized_through,omitempty"` // month end of the last recognition run
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Grant) TableName() string {
+	return "grants"
+}
+
+// GrantReceipt records grant money paid in by the agency
+type GrantReceipt struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID   uuid.UUID  `gorm:"type:uuid;not null" json:"company_id"`
+	GrantID     uuid.UUID  `gorm:"type:uuid;not null" json:"grant_id"`
+	ReceiptDate time.Time  `gorm:"type:date;not null" json:"receipt_date"`
+	Amount      int64      `gorm:"not null" json:"amount"`
+	VoucherID   *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	CreatedBy   *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt   time.Time  `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GrantReceipt) TableName() string {
+	return "grant_receipts"
+}
+
+// GrantExpense links an eligible expense voucher to the grant funding it. The
+// voucher number and date are kept for the utilization report.
+type GrantExpense struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID   uuid.UUID  `gorm:"type:uuid;not null" json:"company_id"`
+	GrantID     uuid.UUID  `gorm:"type:uuid;not null" json:"grant_id"`
+	VoucherID   uuid.UUID  `gorm:"type:uuid;not null" json:"voucher_id"`
+	VoucherNo   string     `gorm:"type:varchar(20);not null" json:"voucher_no"`
+	VoucherDate time.Time  `gorm:"type:date;not null" json:"voucher_date"`
+	Amount      int64      `gorm:"not null" json:"amount"` // part of the voucher funded by the grant
+	Description string     `gorm:"type:varchar(500)" json:"description,omitempty"`
+	LinkedBy    *uuid.UUID `gorm:"type:uuid" json:"linked_by,omitempty"`
+	CreatedAt   time.Time  `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GrantExpense) TableName() string {
+	return "grant_expenses"
+}
+
+// GrantRecognition records a recognition voucher moving deferred income to income
+type GrantRecognition struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID uuid.UUID  `gorm:"type:uuid;not null" json:"company_id"`
+	GrantID   uuid.UUID  `gorm:"type:uuid;not null" json:"grant_id"`
+	PeriodEnd time.Time  `gorm:"type:date;not null" json:"period_end"`
+	Amount    int64      `gorm:"not null" json:"amount"`
+	VoucherID *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	CreatedAt time.Time  `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GrantRecognition) TableName() string {
+	return "grant_recognitions"
+}
+
+// Validate checks the terms of a new grant
+func (g *Grant) Validate() error {
+	switch {
+	case g.GrantNo == "", g.Name == "", g.Agency == "":
+		return ErrInvalidGrant
+	case g.AwardedAmount <= 0:
+		return ErrInvalidGrant
+	case !g.RecognitionMethod.IsValid():
+		return ErrInvalidGrant
+	case g.ProgramEnd.Before(g.ProgramStart):
+		return ErrInvalidGrant
+	}
+	return nil
+}
+
+// DeferredBalance returns the grant money received but not yet recognized
+func (g *Grant) DeferredBalance() int64 {
+	return g.ReceivedAmount - g.RecognizedAmount
+}
+
+// ApplyReceipt adds money paid in by the agency
+func (g *Grant) ApplyReceipt(amount int64) error {
+	if amount <= 0 {
+		return ErrInvalidGrant
+	}
+	if g.ReceivedAmount+amount > g.AwardedAmount {
+		return ErrGrantAmountExceeded
+	}
+	g.ReceivedAmount += amount
+	return nil
+}
+
+// RecognizableThrough returns the income to be recognized cumulatively by
+// periodEnd: the eligible expenses incurred by then, or the elapsed months of
+// the program. It never exceeds the money received.
+func (g *Grant) RecognizableThrough(periodEnd time.Time, expenses []GrantExpense) int64 {
+	var amount int64
+	switch g.RecognitionMethod {
+	case GrantRecognitionExpense:
+		for _, e := range expenses {
+			if e.GrantID == g.ID && !e.VoucherDate.After(periodEnd) {
+				amount += e.Amount
+			}
+		}
+	case GrantRecognitionStraightLine:
+		total := grantMonths(g.ProgramStart, g.ProgramEnd)
+		elapsed := grantMonths(g.ProgramStart, periodEnd)
+		if elapsed > total {
+			elapsed = total
+		}
+		if elapsed > 0 {
+			amount = g.ReceivedAmount * int64(elapsed) / int64(total)
+		}
+	}
+	if amount > g.ReceivedAmount {
+		amount = g.ReceivedAmount
+	}
+	return amount
+}
+
+// NeedsRecognition returns true if the grant has deferred income and was not
+// yet run for the month ending at periodEnd
+func (g *Grant) NeedsRecognition(periodEnd time.Time) bool {
+	if g.ProgramStart.After(periodEnd) || g.DeferredBalance() <= 0 {
+		return false
+	}
+	return g.RecognizedThrough == nil || g.RecognizedThrough.Before(periodEnd)
+}
+
+// GrantRecognitionRun summarizes a month-end grant income recognition run
+type GrantRecognitionRun struct {
+	PeriodEnd  time.Time   `json:"period_end"`
+	Checked    int         `json:"checked"`    // grants with deferred income
+	Recognized int         `json:"recognized"` // grants a recognition voucher was generated for
+	Vouchers   []uuid.UUID `json:"vouchers"`   // draft recognition vouchers
+}
+
+// GrantUtilization is a grant in the utilization report
+type GrantUtilization struct {
+	GrantID           uuid.UUID              `json:"grant_id"`
+	GrantNo           string                 `json:"grant_no"`
+	Name              string                 `json:"name"`
+	Agency            string                 `json:"agency"`
+	ProgramStart      time.Time              `json:"program_start"`
+	ProgramEnd        time.Time              `json:"program_end"`
+	RecognitionMethod GrantRecognitionMethod `json:"recognition_method"`
+
+	AwardedAmount    int64   `json:"awarded_amount"`
+	ReceivedAmount   int64   `json:"received_amount"`
+	UtilizedAmount   int64   `json:"utilized_amount"`   // eligible expenses linked
+	UnutilizedAmount int64   `json:"unutilized_amount"` // awarded but not yet spent
+	UtilizationRate  float64 `json:"utilization_rate"`  // utilized over awarded, in percent
+	RecognizedAmount int64   `json:"recognized_amount"`
+	DeferredBalance  int64   `json:"deferred_balance"`
+
+	Expenses []GrantExpense `json:"expenses"`
+}
+
+// GrantUtilizationReport lists the use of the grants of the company at a date,
+// with the expenses funded by each for audit
+type GrantUtilizationReport struct {
+	AsOf   time.Time          `json:"as_of"`
+	Grants []GrantUtilization `json:"grants"`
+
+	TotalAwarded    int64 `json:"total_awarded"`
+	TotalReceived   int64 `json:"total_received"`
+	TotalUtilized   int64 `json:"total_utilized"`
+	TotalRecognized int64 `json:"total_recognized"`
+	TotalDeferred   int64 `json:"total_deferred"`
+}
+
+// NewGrantUtilization computes the use of the grant at asOf from its receipts,
+// expenses and recognitions
+func NewGrantUtilization(g *Grant, receipts []GrantReceipt, expenses []GrantExpense, recognitions []GrantRecognition, asOf time.Time) GrantUtilization {
+	row := GrantUtilization{
+		GrantID:           g.ID,
+		GrantNo:           g.GrantNo,
+		Name:              g.Name,
+		Agency:            g.Agency,
+		ProgramStart:      g.ProgramStart,
+		ProgramEnd:        g.ProgramEnd,
+		RecognitionMethod: g.RecognitionMethod,
+		AwardedAmount:     g.AwardedAmount,
+		Expenses:          []GrantExpense{},
+	}
+	for _, r := range receipts {
+		if r.GrantID == g.ID && !r.ReceiptDate.After(asOf) {
+			row.ReceivedAmount += r.Amount
+		}
+	}
+	for _, e := range expenses {
+		if e.GrantID == g.ID && !e.VoucherDate.After(asOf) {
+			row.UtilizedAmount += e.Amount
+			row.Expenses = append(row.Expenses, e)
+		}
+	}
+	for _, r := range recognitions {
+		if r.GrantID == g.ID && !r.PeriodEnd.After(asOf) {
+			row.RecognizedAmount += r.Amount
+		}
+	}
+	row.UnutilizedAmount = row.AwardedAmount - row.UtilizedAmount
+	row.UtilizationRate = float64(row.UtilizedAmount) / float64(row.AwardedAmount) * 100
+	row.DeferredBalance = row.ReceivedAmount - row.RecognizedAmount
+	return row
+}
+
+// grantMonths returns the number of calendar months from the month of start
+// through the month of end, both included
+func grantMonths(start, end time.Time) int {
+	if end.Before(start) {
+		return 0
+	}
+	return (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+}
@@ -0,0 +1,107 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func newTestGrant(method domain.GrantRecognitionMethod) *domain.Grant {
+	return &domain.Grant{
+		TenantModel:       domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}},
+		GrantNo:           "G-2024-001",
+		Name:              "스마트공장 구축 지원사업",
+		Agency:            "중소벤처기업부",
+		AwardedAmount:     12000000,
+		ProgramStart:      loanDate(2024, 1, 1),
+		ProgramEnd:        loanDate(2024, 12, 31),
+		RecognitionMethod: method,
+	}
+}
+
+func TestGrant_Validate(t *testing.T) {
+	grant := newTestGrant(domain.GrantRecognitionExpense)
+	assert.NoError(t, grant.Validate())
+
+	grant.ProgramEnd = loanDate(2023, 12, 31)
+	assert.ErrorIs(t, grant.Validate(), domain.ErrInvalidGrant)
+
+	grant = newTestGrant("quarterly")
+	assert.ErrorIs(t, grant.Validate(), domain.ErrInvalidGrant)
+}
+
+func TestGrant_ApplyReceipt(t *testing.T) {
+	grant := newTestGrant(domain.GrantRecognitionExpense)
+
+	require.NoError(t, grant.ApplyReceipt(8000000))
+	assert.ErrorIs(t, grant.ApplyReceipt(4000001), domain.ErrGrantAmountExceeded)
+	require.NoError(t, grant.ApplyReceipt(4000000))
+	assert.Equal(t, int64(12000000), grant.DeferredBalance())
+}
+
+func TestGrant_RecognizableThroughExpense(t *testing.T) {
+	grant := newTestGrant(domain.GrantRecognitionExpense)
+	grant.ReceivedAmount = 5000000
+	expenses := []domain.GrantExpense{
+		{GrantID: grant.ID, VoucherDate: loanDate(2024, 1, 15), Amount: 2000000},
+		{GrantID: grant.ID, VoucherDate: loanDate(2024, 2, 10), Amount: 4000000},
+		{GrantID: uuid.New(), VoucherDate: loanDate(2024, 1, 20), Amount: 1000000},
+	}
+
+	assert.Equal(t, int64(2000000), grant.RecognizableThrough(loanDate(2024, 1, 31), expenses))
+	assert.Equal(t, int64(5000000), grant.RecognizableThrough(loanDate(2024, 2, 29), expenses),
+		"income is limited to the money received")
+}
+
+func TestGrant_RecognizableThroughStraightLine(t *testing.T) {
+	grant := newTestGrant(domain.GrantRecognitionStraightLine)
+	grant.ReceivedAmount = 12000000
+
+	assert.Equal(t, int64(0), grant.RecognizableThrough(loanDate(2023, 12, 31), nil))
+	assert.Equal(t, int64(1000000), grant.RecognizableThrough(loanDate(2024, 1, 31), nil))
+	assert.Equal(t, int64(6000000), grant.RecognizableThrough(loanDate(2024, 6, 30), nil))
+	assert.Equal(t, int64(12000000), grant.RecognizableThrough(loanDate(2025, 3, 31), nil))
+}
+
+func TestGrant_NeedsRecognition(t *testing.T) {
+	grant := newTestGrant(domain.GrantRecognitionStraightLine)
+	assert.False(t, grant.NeedsRecognition(loanDate(2024, 1, 31)), "nothing received")
+
+	grant.ReceivedAmount = 12000000
+	assert.False(t, grant.NeedsRecognition(loanDate(2023, 12, 31)), "not started")
+	assert.True(t, grant.NeedsRecognition(loanDate(2024, 1, 31)))
+
+	recognized := loanDate(2024, 1, 31)
+	grant.RecognizedThrough = &recognized
+	assert.False(t, grant.NeedsRecognition(loanDate(2024, 1, 31)))
+	assert.True(t, grant.NeedsRecognition(loanDate(2024, 2, 29)))
+}
+
+func TestNewGrantUtilization(t *testing.T) {
+	grant := newTestGrant(domain.GrantRecognitionExpense)
+	receipts := []domain.GrantReceipt{
+		{GrantID: grant.ID, ReceiptDate: loanDate(2024, 1, 5), Amount: 6000000},
+		{GrantID: grant.ID, ReceiptDate: loanDate(2024, 7, 5), Amount: 6000000},
+	}
+	expenses := []domain.GrantExpense{
+		{GrantID: grant.ID, VoucherNo: "V-001", VoucherDate: loanDate(2024, 2, 10), Amount: 3000000},
+		{GrantID: grant.ID, VoucherNo: "V-002", VoucherDate: loanDate(2024, 8, 10), Amount: 3000000},
+	}
+	recognitions := []domain.GrantRecognition{
+		{GrantID: grant.ID, PeriodEnd: loanDate(2024, 2, 29), Amount: 3000000},
+	}
+
+	row := domain.NewGrantUtilization(grant, receipts, expenses, recognitions, loanDate(2024, 6, 30))
+	assert.Equal(t, int64(6000000), row.ReceivedAmount)
+	assert.Equal(t, int64(3000000), row.UtilizedAmount)
+	assert.Equal(t, int64(9000000), row.UnutilizedAmount)
+	assert.Equal(t, 25.0, row.UtilizationRate)
+	assert.Equal(t, int64(3000000), row.RecognizedAmount)
+	assert.Equal(t, int64(3000000), row.DeferredBalance)
+	require.Len(t, row.Expenses, 1)
+	assert.Equal(t, "V-001", row.Expenses[0].VoucherNo)
+}
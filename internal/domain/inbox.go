@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inbox errors
+var (
+	ErrInboxItemNotFound    = errors.New("inbox item not found")
+	ErrInboxItemClosed      = errors.New("inbox item was already processed or discarded")
+	ErrInboxAliasNotFound   = errors.New("inbox address not found")
+	ErrInboxWebhookDisabled = errors.New("inbound email webhook is not configured")
+	ErrInvalidInboxToken    = errors.New("invalid inbound email token")
+	ErrInvalidInboundEmail  = errors.New("invalid inbound email")
+)
+
+// InboxItemStatus represents the state of a document waiting in the inbox
+type InboxItemStatus string
+
+const (
+	InboxItemPending   InboxItemStatus = "pending"   // to be processed by a clerk
+	InboxItemProcessed InboxItemStatus = "processed" // converted into a voucher
+	InboxItemDiscarded InboxItemStatus = "discarded" // not an invoice, or a duplicate
+)
+
+// IsValid checks if the status is valid
+func (s InboxItemStatus) IsValid() bool {
+	switch s {
+	case InboxItemPending, InboxItemProcessed, InboxItemDiscarded:
+		return true
+	}
+	return false
+}
+
+// InboxAlias is the local part of the company's inbound address, e.g.
+// k7q2m9xw4d in k7q2m9xw4d@inbox.example.com. Suppliers send invoices to it.
+type InboxAlias struct {
+	CompanyID uuid.UUID `gorm:"type:uuid;primary_key" json:"company_id"`
+	Alias     string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"alias"`
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (InboxAlias) TableName() string {
+	return "inbox_aliases"
+}
+
+// inboxAliasAlphabet leaves out letters and digits easily confused when read out
+const inboxAliasAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// NewInboxAlias generates a random alias for the company
+func NewInboxAlias(companyID uuid.UUID) (*InboxAlias, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	for i := range b {
+		b[i] = inboxAliasAlphabet[int(b[i])%len(inboxAliasAlphabet)]
+	}
+	return &InboxAlias{CompanyID: companyID, Alias: string(b), CreatedAt: time.Now()}, nil
+}
+
+// Address returns the inbound address of the alias in the domain
+func (a *InboxAlias) Address(domain string) string {
+	return a.Alias + "@" + domain
+}
+
+// InboxDocument is a file received by email, kept apart from the item so that
+// lists do not load the content
+type InboxDocument struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID   uuid.UUID `gorm:"type:uuid;not null" json:"company_id"`
+	FileName    string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType string    `gorm:"type:varchar(100);not null" json:"content_type"`
+	FileSize    int64     `gorm:"not null" json:"file_size"`
+	SHA256      string    `gorm:"column:sha256;type:varchar(64);not null" json:"sha256"`
+	Data        []byte    `gorm:"type:bytea;not null" json:"-"`
+	CreatedAt   time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (InboxDocument) TableName() string {
+	return "inbox_documents"
+}
+
+// InboxItem is an invoice attachment of an inbound email, queued for a clerk
+// to convert into a voucher
+type InboxItem struct {
+	TenantModel
+
+	DocumentID uuid.UUID       `gorm:"type:uuid;not null" json:"document_id"`
+	MessageID  string          `gorm:"type:varchar(255)" json:"message_id,omitempty"`
+	Sender     string          `gorm:"type:varchar(255)" json:"sender,omitempty"`
+	Subject    string          `gorm:"type:varchar(500)" json:"subject,omitempty"`
+	ReceivedAt time.Time       `gorm:"not null" json:"received_at"`
+	Status     InboxItemStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+
+	// Set when the item is processed or discarded
+	VoucherID   *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	ProcessedBy *uuid.UUID `gorm:"type:uuid" json:"processed_by,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	Note        string     `gorm:"type:varchar(500)" json:"note,omitempty"`
+
+	// Relations
+	Document *InboxDocument `gorm:"foreignKey:DocumentID" json:"document,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (InboxItem) TableName() string {
+	return "inbox_items"
+}
+
+// Process marks the item as converted into the voucher
+func (i *InboxItem) Process(voucherID, userID uuid.UUID, at time.Time) error {
+	if i.Status != InboxItemPending {
+		return ErrInboxItemClosed
+	}
+	i.Status = InboxItemProcessed
+	i.VoucherID = &voucherID
+	i.ProcessedBy = &userID
+	i.ProcessedAt = &at
+	return nil
+}
+
+// Discard marks the item as not to be converted
+func (i *InboxItem) Discard(note string, userID uuid.UUID, at time.Time) error {
+	if i.Status != InboxItemPending {
+		return ErrInboxItemClosed
+	}
+	i.Status = InboxItemDiscarded
+	i.Note = note
+	i.ProcessedBy = &userID
+	i.ProcessedAt = &at
+	return nil
+}
+
+// InvoiceFileContentType returns the content type of an attachment if it is a
+// PDF or XML invoice, or "" otherwise. Mail clients often send invoices as
+// application/octet-stream, so the file extension decides as well.
+func InvoiceFileContentType(fileName, contentType string) string {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	switch contentType {
+	case "application/pdf":
+		return "application/pdf"
+	case "application/xml", "text/xml":
+		return "application/xml"
+	}
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".pdf":
+		return "application/pdf"
+	case ".xml":
+		return "application/xml"
+	}
+	return ""
+}
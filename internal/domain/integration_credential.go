@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Integration credential errors
+var (
+	ErrIntegrationCredentialNotFound = errors.New("integration credential not found")
+	ErrInvalidIntegrationCredential  = errors.New("invalid integration credential")
+	ErrCredentialEncryptionDisabled  = errors.New("credential encryption is not configured")
+	ErrCredentialTestFailed          = errors.New("credential test failed")
+)
+
+// IntegrationProvider is an external service a company connects to
+type IntegrationProvider string
+
+const (
+	IntegrationProviderPopbill IntegrationProvider = "popbill"
+	IntegrationProviderBank    IntegrationProvider = "bank"
+)
+
+// IsValid checks if the integration provider is valid
+func (p IntegrationProvider) IsValid() bool {
+	return p == IntegrationProviderPopbill || p == IntegrationProviderBank
+}
+
+// popbillCredentialIdentifier is the identifier of the single Popbill credential of a company
+const popbillCredentialIdentifier = "default"
+
+// IntegrationCredential holds the encrypted credentials of an external service.
+// The secret fields are only available to the service decrypting them at call time.
+type IntegrationCredential struct {
+	TenantModel
+
+	Provider   IntegrationProvider `gorm:"type:varchar(20);not null" json:"provider"`
+	Identifier string              `gorm:"type:varchar(100);not null" json:"identifier"`
+	Hint       string              `gorm:"type:varchar(200);not null;default:''" json:"hint"`
+
+	// Envelope encryption of the JSON credentials
+	KeyID        string `gorm:"type:varchar(100);not null" json:"-"`
+	EncryptedKey []byte `gorm:"type:bytea;not null" json:"-"`
+	Ciphertext   []byte `gorm:"type:bytea;not null" json:"-"`
+
+	IsActive bool `gorm:"not null;default:true" json:"is_active"`
+
+	LastTestedAt      *time.Time `json:"last_tested_at,omitempty"`
+	LastTestSucceeded *bool      `json:"last_test_succeeded,omitempty"`
+	LastTestError     string     `gorm:"type:text" json:"last_test_error,omitempty"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (IntegrationCredential) TableName() string {
+	return "integration_credentials"
+}
+
+// AAD returns the data the ciphertext is bound to, so that it cannot be
+// copied to another company's or provider's row
+func (c *IntegrationCredential) AAD() []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s", c.CompanyID, c.Provider, c.Identifier))
+}
+
+// RecordTest stores the outcome of a credential test
+func (c *IntegrationCredential) RecordTest(testErr error, at time.Time) {
+	succeeded := testErr == nil
+	c.LastTestedAt = &at
+	c.LastTestSucceeded = &succeeded
+	c.LastTestError = ""
+	if testErr != nil {
+		c.LastTestError = testErr.Error()
+	}
+}
+
+// PopbillCredentials are the Popbill partner credentials of a company
+type PopbillCredentials struct {
+	LinkID    string `json:"link_id"`
+	SecretKey string `json:"secret_key"`
+	CorpNum   string `json:"corp_num"` // business registration number, digits only
+	UserID    string `json:"user_id,omitempty"`
+	IsSandbox bool   `json:"is_sandbox"`
+}
+
+// Normalize trims the fields and strips the dashes of the business number
+func (p *PopbillCredentials) Normalize() {
+	p.LinkID = strings.TrimSpace(p.LinkID)
+	p.SecretKey = strings.TrimSpace(p.SecretKey)
+	p.CorpNum = digitsOnly(p.CorpNum)
+	p.UserID = strings.TrimSpace(p.UserID)
+}
+
+// Validate checks the required fields
+func (p *PopbillCredentials) Validate() error {
+	if p.LinkID == "" || p.SecretKey == "" {
+		return fmt.Errorf("%w: link_id and secret_key are required", ErrInvalidIntegrationCredential)
+	}
+	if len(p.CorpNum) != 10 {
+		return fmt.Errorf("%w: corp_num must be a 10-digit business registration number", ErrInvalidIntegrationCredential)
+	}
+	return nil
+}
+
+// Identifier returns the identifier of the credential row
+func (p *PopbillCredentials) Identifier() string {
+	return popbillCredentialIdentifier
+}
+
+// Hint returns a non-secret summary, e.g. "KERP / 123-45-67890 (sandbox)"
+func (p *PopbillCredentials) Hint() string {
+	hint := p.LinkID + " / " + p.CorpNum[:3] + "-" + p.CorpNum[3:5] + "-" + p.CorpNum[5:]
+	if p.IsSandbox {
+		hint += " (sandbox)"
+	}
+	return hint
+}
+
+// BankCredentials are the credentials of a bank account used for account
+// inquiry: the account password and, for banks requiring it, the internet
+// banking login of the corporate user
+type BankCredentials struct {
+	BankCode        string `json:"bank_code"` // 3-digit 금융기관 code, e.g. 004
+	AccountNumber   string `json:"account_number"`
+	AccountPassword string `json:"account_password"`
+	LoginID         string `json:"login_id,omitempty"`
+	LoginPassword   string `json:"login_password,omitempty"`
+}
+
+// Normalize trims the fields and strips the dashes of the account number
+func (b *BankCredentials) Normalize() {
+	b.BankCode = strings.TrimSpace(b.BankCode)
+	b.AccountNumber = digitsOnly(b.AccountNumber)
+	b.LoginID = strings.TrimSpace(b.LoginID)
+}
+
+// Validate checks the required fields
+func (b *BankCredentials) Validate() error {
+	if len(b.BankCode) != 3 || digitsOnly(b.BankCode) != b.BankCode {
+		return fmt.Errorf("%w: bank_code must be a 3-digit bank code", ErrInvalidIntegrationCredential)
+	}
+	if len(b.AccountNumber) < 8 || len(b.AccountNumber) > 20 {
+		return fmt.Errorf("%w: account_number must have 8 to 20 digits", ErrInvalidIntegrationCredential)
+	}
+	if b.AccountPassword == "" {
+		return fmt.Errorf("%w: account_password is required", ErrInvalidIntegrationCredential)
+	}
+	if (b.LoginID == "") != (b.LoginPassword == "") {
+		return fmt.Errorf("%w: login_id and login_password must be given together", ErrInvalidIntegrationCredential)
+	}
+	return nil
+}
+
+// Identifier returns the identifier of the credential row
+func (b *BankCredentials) Identifier() string {
+	return b.BankCode + "-" + b.AccountNumber
+}
+
+// Hint returns a non-secret summary with the account number masked, e.g. "004 ****5678"
+func (b *BankCredentials) Hint() string {
+	return b.BankCode + " " + strings.Repeat("*", len(b.AccountNumber)-4) + b.AccountNumber[len(b.AccountNumber)-4:]
+}
+
+func digitsOnly(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
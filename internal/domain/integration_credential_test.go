@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestPopbillCredentials_NormalizeAndValidate(t *testing.T) {
+	creds := &domain.PopbillCredentials{LinkID: " KERP ", SecretKey: "secret", CorpNum: "123-45-67890", IsSandbox: true}
+	creds.Normalize()
+
+	require.NoError(t, creds.Validate())
+	assert.Equal(t, "1234567890", creds.CorpNum)
+	assert.Equal(t, "KERP / 123-45-67890 (sandbox)", creds.Hint())
+	assert.Equal(t, "default", creds.Identifier())
+
+	creds.CorpNum = "12345"
+	assert.ErrorIs(t, creds.Validate(), domain.ErrInvalidIntegrationCredential)
+
+	creds.CorpNum, creds.SecretKey = "1234567890", ""
+	assert.ErrorIs(t, creds.Validate(), domain.ErrInvalidIntegrationCredential)
+}
+
+func TestBankCredentials_NormalizeAndValidate(t *testing.T) {
+	creds := &domain.BankCredentials{BankCode: "004", AccountNumber: "123-456-78-9012", AccountPassword: "1234"}
+	creds.Normalize()
+
+	require.NoError(t, creds.Validate())
+	assert.Equal(t, "004-123456789012", creds.Identifier())
+	assert.Equal(t, "004 ********9012", creds.Hint())
+
+	creds.LoginID = "corp-user"
+	assert.ErrorIs(t, creds.Validate(), domain.ErrInvalidIntegrationCredential, "login id without password")
+
+	creds.LoginID, creds.BankCode = "", "KB"
+	assert.ErrorIs(t, creds.Validate(), domain.ErrInvalidIntegrationCredential)
+}
+
+func TestIntegrationCredential_RecordTest(t *testing.T) {
+	credential := &domain.IntegrationCredential{Provider: domain.IntegrationProviderPopbill}
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	credential.RecordTest(errors.New("invalid link id"), at)
+	require.NotNil(t, credential.LastTestSucceeded)
+	assert.False(t, *credential.LastTestSucceeded)
+	assert.Equal(t, "invalid link id", credential.LastTestError)
+
+	credential.RecordTest(nil, at.Add(time.Hour))
+	assert.True(t, *credential.LastTestSucceeded)
+	assert.Empty(t, credential.LastTestError)
+	assert.Equal(t, at.Add(time.Hour), *credential.LastTestedAt)
+}
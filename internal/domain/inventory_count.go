@@ -0,0 +1,340 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inventory count errors
+var (
+	ErrInventoryItemNotFound            = errors.New("inventory item not found")
+	ErrInventoryItemCodeExists          = errors.New("inventory item code already exists")
+	ErrInvalidInventoryItem             = errors.New("invalid inventory item")
+	ErrInventoryCountNotFound           = errors.New("inventory count not found")
+	ErrInvalidInventoryCount            = errors.New("invalid inventory count")
+	ErrNoInventoryItems                 = errors.New("no inventory items to count")
+	ErrInventoryCountInProgress         = errors.New("another inventory count is in progress")
+	ErrInventoryCountNotOpen            = errors.New("inventory count is not open")
+	ErrInventoryCountNotSubmitted       = errors.New("inventory count is not submitted")
+	ErrInventoryCountIncomplete         = errors.New("inventory count has items not counted")
+	ErrInventoryCountChanged            = errors.New("inventory count changed meanwhile")
+	ErrInventoryCountApprovalForbidden  = errors.New("user may not approve the inventory count")
+	ErrInventoryCountItemNotInCount     = errors.New("item is not in the inventory count")
+	ErrInventoryCountSheetColumnMissing = errors.New("count sheet needs item code and quantity")
+)
+
+// InventoryItem is an item kept in stock (품목) with its quantity on hand,
+// booked at its unit cost on an inventory account such as 상품 or 원재료.
+// The quantity on hand is set by the posted inventory counts.
+type InventoryItem struct {
+	TenantModel
+
+	Code      string    `gorm:"type:varchar(50);not null" json:"code"`
+	Name      string    `gorm:"type:varchar(200);not null" json:"name"`
+	Unit      string    `gorm:"type:varchar(20);not null" json:"unit"`                 // EA, BOX, kg
+	AccountID uuid.UUID `gorm:"type:uuid;not null" json:"account_id"`                  // inventory account
+	UnitCost  int64     `gorm:"not null;default:0" json:"unit_cost"`                   // won per unit
+	Quantity  float64   `gorm:"type:decimal(18,4);not null;default:0" json:"quantity"` // on hand
+	IsActive  bool      `gorm:"not null;default:true" json:"is_active"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (InventoryItem) TableName() string {
+	return "inventory_items"
+}
+
+// Validate checks an item
+func (i *InventoryItem) Validate() error {
+	i.Code = strings.TrimSpace(i.Code)
+	i.Name = strings.TrimSpace(i.Name)
+	i.Unit = strings.TrimSpace(i.Unit)
+	if i.Unit == "" {
+		i.Unit = "EA"
+	}
+	if i.Code == "" || i.Name == "" || i.AccountID == uuid.Nil || i.UnitCost < 0 || i.Quantity < 0 {
+		return ErrInvalidInventoryItem
+	}
+	return nil
+}
+
+// InventoryCountStatus is the status of an inventory count
+type InventoryCountStatus string
+
+const (
+	InventoryCountOpen      InventoryCountStatus = "open"      // counting
+	InventoryCountSubmitted InventoryCountStatus = "submitted" // counted, awaiting approval
+	InventoryCountPosted    InventoryCountStatus = "posted"    // approved, adjustment posted
+	InventoryCountCancelled InventoryCountStatus = "cancelled"
+)
+
+// IsValid checks if the status is valid
+func (s InventoryCountStatus) IsValid() bool {
+	switch s {
+	case InventoryCountOpen, InventoryCountSubmitted, InventoryCountPosted, InventoryCountCancelled:
+		return true
+	}
+	return false
+}
+
+// InventoryCount is a stocktake (재고실사): opening it snapshots the system
+// quantities of the items, the counted quantities are recorded against them
+// and, on approval, the variances are posted as an inventory adjustment
+// voucher and become the quantities on hand. Shortages are booked to the
+// loss account (재고자산감모손실), overages to the gain account.
+type InventoryCount struct {
+	TenantModel
+
+	CountDate   time.Time            `gorm:"type:date;not null" json:"count_date"` // date of the adjustment voucher
+	Description string               `gorm:"type:varchar(200);not null" json:"description"`
+	AccountID   *uuid.UUID           `gorm:"type:uuid" json:"account_id,omitempty"` // items of one inventory account, all when nil
+	Status      InventoryCountStatus `gorm:"type:varchar(20);not null;default:open" json:"status"`
+
+	LossAccountID uuid.UUID `gorm:"type:uuid;not null" json:"loss_account_id"`
+	GainAccountID uuid.UUID `gorm:"type:uuid;not null" json:"gain_account_id"`
+
+	SubmittedBy *uuid.UUID `gorm:"type:uuid" json:"submitted_by,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ApprovedBy  *uuid.UUID `gorm:"type:uuid" json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time `json:"approved_at,omitempty"`
+	VoucherID   *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"` // nil when nothing differs
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	Lines []InventoryCountLine `gorm:"foreignKey:CountID" json:"lines,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (InventoryCount) TableName() string {
+	return "inventory_counts"
+}
+
+// InventoryCountLine is an item of the count with its snapshot and counted quantities
+type InventoryCountLine struct {
+	TenantModel
+
+	CountID         uuid.UUID  `gorm:"type:uuid;not null" json:"count_id"`
+	ItemID          uuid.UUID  `gorm:"type:uuid;not null" json:"item_id"`
+	ItemCode        string     `gorm:"type:varchar(50);not null" json:"item_code"`
+	ItemName        string     `gorm:"type:varchar(200);not null" json:"item_name"`
+	Unit            string     `gorm:"type:varchar(20);not null" json:"unit"`
+	AccountID       uuid.UUID  `gorm:"type:uuid;not null" json:"account_id"`
+	UnitCost        int64      `gorm:"not null" json:"unit_cost"`
+	SystemQuantity  float64    `gorm:"type:decimal(18,4);not null" json:"system_quantity"` // on hand when the count opened
+	CountedQuantity *float64   `gorm:"type:decimal(18,4)" json:"counted_quantity,omitempty"`
+	Note            string     `gorm:"type:varchar(200)" json:"note,omitempty"`
+	CountedBy       *uuid.UUID `gorm:"type:uuid" json:"counted_by,omitempty"`
+	CountedAt       *time.Time `json:"counted_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (InventoryCountLine) TableName() string {
+	return "inventory_count_lines"
+}
+
+// Variance returns the counted less the system quantity, zero until counted
+func (l *InventoryCountLine) Variance() float64 {
+	if l.CountedQuantity == nil {
+		return 0
+	}
+	return *l.CountedQuantity - l.SystemQuantity
+}
+
+// VarianceAmount returns the variance at the unit cost, rounded to the won
+func (l *InventoryCountLine) VarianceAmount() int64 {
+	return int64(math.Round(l.Variance() * float64(l.UnitCost)))
+}
+
+// Open checks a new count and snapshots the quantities on hand of the items
+func (c *InventoryCount) Open(items []InventoryItem) error {
+	c.Description = strings.TrimSpace(c.Description)
+	if c.CountDate.IsZero() || c.Description == "" ||
+		c.LossAccountID == uuid.Nil || c.GainAccountID == uuid.Nil {
+		return ErrInvalidInventoryCount
+	}
+	if len(items) == 0 {
+		return ErrNoInventoryItems
+	}
+	c.Status = InventoryCountOpen
+	c.Lines = make([]InventoryCountLine, 0, len(items))
+	for _, item := range items {
+		c.Lines = append(c.Lines, InventoryCountLine{
+			TenantModel:    TenantModel{CompanyID: c.CompanyID},
+			ItemID:         item.ID,
+			ItemCode:       item.Code,
+			ItemName:       item.Name,
+			Unit:           item.Unit,
+			AccountID:      item.AccountID,
+			UnitCost:       item.UnitCost,
+			SystemQuantity: item.Quantity,
+		})
+	}
+	return nil
+}
+
+// InventoryCountEntry is a counted quantity of an item, by its code
+type InventoryCountEntry struct {
+	ItemCode string
+	Quantity float64
+	Note     string
+}
+
+// Record sets the counted quantities of the items of the entries and returns
+// the lines changed. The count must be open; a code outside the count fails
+// with ErrInventoryCountItemNotInCount and records nothing.
+func (c *InventoryCount) Record(entries []InventoryCountEntry, userID uuid.UUID, at time.Time) ([]*InventoryCountLine, error) {
+	if c.Status != InventoryCountOpen {
+		return nil, ErrInventoryCountNotOpen
+	}
+	byCode := make(map[string]*InventoryCountLine, len(c.Lines))
+	for i := range c.Lines {
+		byCode[c.Lines[i].ItemCode] = &c.Lines[i]
+	}
+	for _, entry := range entries {
+		if _, ok := byCode[strings.TrimSpace(entry.ItemCode)]; !ok {
+			return nil, ErrInventoryCountItemNotInCount
+		}
+		if entry.Quantity < 0 || math.IsNaN(entry.Quantity) {
+			return nil, ErrInvalidInventoryCount
+		}
+	}
+
+	var changed []*InventoryCountLine
+	for _, entry := range entries {
+		line := byCode[strings.TrimSpace(entry.ItemCode)]
+		quantity := entry.Quantity
+		line.CountedQuantity = &quantity
+		line.Note = strings.TrimSpace(entry.Note)
+		line.CountedBy = &userID
+		line.CountedAt = &at
+		changed = append(changed, line)
+	}
+	return changed, nil
+}
+
+// Submit closes the counting for approval once every item is counted
+func (c *InventoryCount) Submit(userID uuid.UUID, at time.Time) error {
+	if c.Status != InventoryCountOpen {
+		return ErrInventoryCountNotOpen
+	}
+	for _, line := range c.Lines {
+		if line.CountedQuantity == nil {
+			return ErrInventoryCountIncomplete
+		}
+	}
+	c.Status = InventoryCountSubmitted
+	c.SubmittedBy = &userID
+	c.SubmittedAt = &at
+	return nil
+}
+
+// Approve marks a submitted count posted by the adjustment voucher, nil when
+// nothing differs
+func (c *InventoryCount) Approve(userID uuid.UUID, voucherID *uuid.UUID, at time.Time) error {
+	if c.Status != InventoryCountSubmitted {
+		return ErrInventoryCountNotSubmitted
+	}
+	c.Status = InventoryCountPosted
+	c.ApprovedBy = &userID
+	c.ApprovedAt = &at
+	c.VoucherID = voucherID
+	return nil
+}
+
+// Reopen returns a submitted count to counting, e.g. when the approver asks
+// for a recount
+func (c *InventoryCount) Reopen() error {
+	if c.Status != InventoryCountSubmitted {
+		return ErrInventoryCountNotSubmitted
+	}
+	c.Status = InventoryCountOpen
+	c.SubmittedBy = nil
+	c.SubmittedAt = nil
+	return nil
+}
+
+// Cancel drops a count not posted yet; the quantities on hand are kept
+func (c *InventoryCount) Cancel() error {
+	if c.Status != InventoryCountOpen && c.Status != InventoryCountSubmitted {
+		return ErrInventoryCountNotOpen
+	}
+	c.Status = InventoryCountCancelled
+	return nil
+}
+
+// InventoryCountVariance totals the variances of an inventory account
+type InventoryCountVariance struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Shortage  int64     `json:"shortage"` // counted below the system quantity, at cost
+	Overage   int64     `json:"overage"`  // counted above the system quantity, at cost
+}
+
+// InventoryCountSummary summarizes the progress and the variances of a count
+type InventoryCountSummary struct {
+	Items     int                      `json:"items"`
+	Counted   int                      `json:"counted"`
+	Differing int                      `json:"differing"` // counted items whose quantity differs
+	Shortage  int64                    `json:"shortage"`
+	Overage   int64                    `json:"overage"`
+	Net       int64                    `json:"net"` // overage less shortage
+	ByAccount []InventoryCountVariance `json:"by_account"`
+	Variances []InventoryCountLine     `json:"variances"` // differing lines, largest amount first
+}
+
+// Summary totals the variances of the counted lines by inventory account
+func (c *InventoryCount) Summary() *InventoryCountSummary {
+	summary := &InventoryCountSummary{Items: len(c.Lines), ByAccount: []InventoryCountVariance{}, Variances: []InventoryCountLine{}}
+	index := make(map[uuid.UUID]int)
+	for _, line := range c.Lines {
+		if line.CountedQuantity == nil {
+			continue
+		}
+		summary.Counted++
+		if line.Variance() == 0 {
+			continue
+		}
+		summary.Differing++
+		summary.Variances = append(summary.Variances, line)
+
+		i, ok := index[line.AccountID]
+		if !ok {
+			i = len(summary.ByAccount)
+			index[line.AccountID] = i
+			summary.ByAccount = append(summary.ByAccount, InventoryCountVariance{AccountID: line.AccountID})
+		}
+		if amount := line.VarianceAmount(); amount < 0 {
+			summary.ByAccount[i].Shortage -= amount
+			summary.Shortage -= amount
+		} else {
+			summary.ByAccount[i].Overage += amount
+			summary.Overage += amount
+		}
+	}
+	summary.Net = summary.Overage - summary.Shortage
+	sortVarianceLines(summary.Variances)
+	return summary
+}
+
+// sortVarianceLines orders the lines by the size of their variance amount,
+// then by item code
+func sortVarianceLines(lines []InventoryCountLine) {
+	abs := func(n int64) int64 {
+		if n < 0 {
+			return -n
+		}
+		return n
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		a, b := abs(lines[i].VarianceAmount()), abs(lines[j].VarianceAmount())
+		if a != b {
+			return a > b
+		}
+		return lines[i].ItemCode < lines[j].ItemCode
+	})
+}
@@ -0,0 +1,119 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// newInventoryCount opens a count of two merchandise items and one raw material
+func newInventoryCount(t *testing.T) (*domain.InventoryCount, uuid.UUID, uuid.UUID) {
+	merchandise, materials := uuid.New(), uuid.New()
+	items := []domain.InventoryItem{
+		{TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}}, Code: "A-001", Name: "볼트", Unit: "EA", AccountID: merchandise, UnitCost: 500, Quantity: 100},
+		{TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}}, Code: "A-002", Name: "너트", Unit: "EA", AccountID: merchandise, UnitCost: 300, Quantity: 50},
+		{TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}}, Code: "M-001", Name: "강판", Unit: "kg", AccountID: materials, UnitCost: 1200, Quantity: 20.5},
+	}
+	count := &domain.InventoryCount{
+		CountDate:     date(2026, 6, 30),
+		Description:   " 2026년 상반기 재고실사 ",
+		LossAccountID: uuid.New(),
+		GainAccountID: uuid.New(),
+	}
+	require.NoError(t, count.Open(items))
+	return count, merchandise, materials
+}
+
+func TestInventoryCount_Open(t *testing.T) {
+	count, _, _ := newInventoryCount(t)
+	assert.Equal(t, "2026년 상반기 재고실사", count.Description)
+	assert.Equal(t, domain.InventoryCountOpen, count.Status)
+	require.Len(t, count.Lines, 3)
+	assert.Equal(t, 20.5, count.Lines[2].SystemQuantity)
+	assert.Nil(t, count.Lines[2].CountedQuantity)
+
+	empty := &domain.InventoryCount{CountDate: date(2026, 6, 30), Description: "실사", LossAccountID: uuid.New(), GainAccountID: uuid.New()}
+	assert.ErrorIs(t, empty.Open(nil), domain.ErrNoInventoryItems)
+
+	noAccount := &domain.InventoryCount{CountDate: date(2026, 6, 30), Description: "실사"}
+	assert.ErrorIs(t, noAccount.Open(nil), domain.ErrInvalidInventoryCount)
+}
+
+func TestInventoryCount_Record(t *testing.T) {
+	count, _, _ := newInventoryCount(t)
+	userID := uuid.New()
+
+	// An unknown code records nothing
+	_, err := count.Record([]domain.InventoryCountEntry{{ItemCode: "A-001", Quantity: 98}, {ItemCode: "Z-999", Quantity: 1}}, userID, time.Now())
+	assert.ErrorIs(t, err, domain.ErrInventoryCountItemNotInCount)
+	assert.Nil(t, count.Lines[0].CountedQuantity)
+
+	_, err = count.Record([]domain.InventoryCountEntry{{ItemCode: "A-001", Quantity: -1}}, userID, time.Now())
+	assert.ErrorIs(t, err, domain.ErrInvalidInventoryCount)
+
+	changed, err := count.Record([]domain.InventoryCountEntry{{ItemCode: " A-001 ", Quantity: 98, Note: "파손 2개"}}, userID, time.Now())
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, -2.0, changed[0].Variance())
+	assert.Equal(t, int64(-1000), changed[0].VarianceAmount())
+
+	// Every item must be counted before submitting
+	assert.ErrorIs(t, count.Submit(userID, time.Now()), domain.ErrInventoryCountIncomplete)
+}
+
+func TestInventoryCount_Workflow(t *testing.T) {
+	count, _, _ := newInventoryCount(t)
+	counter, approver := uuid.New(), uuid.New()
+	_, err := count.Record([]domain.InventoryCountEntry{
+		{ItemCode: "A-001", Quantity: 100},
+		{ItemCode: "A-002", Quantity: 50},
+		{ItemCode: "M-001", Quantity: 20.5},
+	}, counter, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, count.Submit(counter, time.Now()))
+	assert.Equal(t, domain.InventoryCountSubmitted, count.Status)
+	_, err = count.Record([]domain.InventoryCountEntry{{ItemCode: "A-001", Quantity: 1}}, counter, time.Now())
+	assert.ErrorIs(t, err, domain.ErrInventoryCountNotOpen)
+
+	require.NoError(t, count.Reopen())
+	assert.Nil(t, count.SubmittedBy)
+	assert.ErrorIs(t, count.Approve(approver, nil, time.Now()), domain.ErrInventoryCountNotSubmitted)
+
+	require.NoError(t, count.Submit(counter, time.Now()))
+	require.NoError(t, count.Approve(approver, nil, time.Now()))
+	assert.Equal(t, domain.InventoryCountPosted, count.Status)
+	assert.ErrorIs(t, count.Cancel(), domain.ErrInventoryCountNotOpen)
+}
+
+func TestInventoryCount_Summary(t *testing.T) {
+	count, merchandise, materials := newInventoryCount(t)
+	_, err := count.Record([]domain.InventoryCountEntry{
+		{ItemCode: "A-001", Quantity: 98},   // -2 x 500
+		{ItemCode: "A-002", Quantity: 60},   // +10 x 300
+		{ItemCode: "M-001", Quantity: 15.5}, // -5 x 1,200
+	}, uuid.New(), time.Now())
+	require.NoError(t, err)
+
+	summary := count.Summary()
+	assert.Equal(t, 3, summary.Items)
+	assert.Equal(t, 3, summary.Counted)
+	assert.Equal(t, 3, summary.Differing)
+	assert.Equal(t, int64(7000), summary.Shortage)
+	assert.Equal(t, int64(3000), summary.Overage)
+	assert.Equal(t, int64(-4000), summary.Net)
+
+	require.Len(t, summary.ByAccount, 2)
+	assert.Equal(t, domain.InventoryCountVariance{AccountID: merchandise, Shortage: 1000, Overage: 3000}, summary.ByAccount[0])
+	assert.Equal(t, domain.InventoryCountVariance{AccountID: materials, Shortage: 6000}, summary.ByAccount[1])
+
+	// Largest variance amount first
+	require.Len(t, summary.Variances, 3)
+	assert.Equal(t, []string{"M-001", "A-002", "A-001"},
+		[]string{summary.Variances[0].ItemCode, summary.Variances[1].ItemCode, summary.Variances[2].ItemCode})
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job errors
+var (
+	ErrJobNotFound       = errors.New("job not found")
+	ErrJobNotRetryable   = errors.New("only failed or cancelled jobs can be retried")
+	ErrJobNotCancellable = errors.New("job is already finished")
+	ErrInvalidJobType    = errors.New("invalid job type")
+	ErrInvalidJobStatus  = errors.New("invalid job status")
+)
+
+// JobType identifies the work of a background job and the handler running it
+type JobType string
+
+const (
+	JobTypeLedgerRecalculation JobType = "ledger_recalculation" // see LedgerRecalculationPayload
+	JobTypeLegacyImport        JobType = "legacy_import"        // see LegacyImportPayload; the file is the job's data
+	JobTypeReportDelivery      JobType = "report_delivery"      // see ReportDeliveryPayload
+	JobTypeApprovalPush        JobType = "approval_push"        // see ApprovalPushPayload
+)
+
+// IsValid checks if the job type is valid
+func (t JobType) IsValid() bool {
+	switch t {
+	case JobTypeLedgerRecalculation, JobTypeLegacyImport, JobTypeReportDelivery, JobTypeApprovalPush:
+		return true
+	}
+	return false
+}
+
+// MaxAttempts returns the number of times a job of the type is run before it
+// is left failed. Imports are not retried since a failed import may have
+// saved part of its records; report deliveries are not either, since report
+// schedules record and alert their failed runs themselves. Approval pushes
+// are not retried either, so devices already notified are not notified twice.
+func (t JobType) MaxAttempts() int {
+	switch t {
+	case JobTypeLegacyImport, JobTypeReportDelivery, JobTypeApprovalPush:
+		return 1
+	}
+	return 3
+}
+
+// JobStatus represents the state of a background job
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued" // waiting for RunAt, also between attempts
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed" // no attempts left
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// IsValid checks if the job status is valid
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobStatusQueued, JobStatusRunning, JobStatusSucceeded, JobStatusFailed, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsFinished returns true if the job will not run again unless it is retried
+func (s JobStatus) IsFinished() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// Payloads of the job types
+type (
+	LedgerRecalculationPayload struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+	}
+
+	LegacyImportPayload struct {
+		Format          string    `json:"format"`
+		Dataset         string    `json:"dataset"`
+		FiscalYear      int       `json:"fiscal_year,omitempty"`
+		CashAccountCode string    `json:"cash_account_code,omitempty"`
+		UserID          uuid.UUID `json:"user_id"`
+	}
+
+	ReportDeliveryPayload struct {
+		ScheduleID uuid.UUID `json:"schedule_id"`
+		AsOf       time.Time `json:"as_of"` // the scheduled run time the report period is resolved from
+	}
+
+	ApprovalPushPayload struct {
+		VoucherID uuid.UUID `json:"voucher_id"` // the voucher submitted for approval
+	}
+)
+
+// Job is a unit of asynchronous work of a company run by the worker. Failed
+// attempts are retried with a growing delay until MaxAttempts is reached;
+// operators can retry failed and cancelled jobs and cancel unfinished ones.
+type Job struct {
+	TenantModel
+
+	Type    JobType         `gorm:"type:varchar(50);not null" json:"type"`
+	Payload json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
+	// Data is binary input of the job, such as an uploaded file; it is not listed
+	Data []byte `gorm:"type:bytea" json:"-"`
+
+	Status      JobStatus       `gorm:"type:varchar(20);not null;default:queued" json:"status"`
+	Attempts    int             `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int             `gorm:"not null;default:3" json:"max_attempts"`
+	LastError   string          `gorm:"type:text" json:"last_error,omitempty"`
+	Result      json.RawMessage `gorm:"type:jsonb" json:"result,omitempty"`
+
+	RunAt      time.Time  `gorm:"not null" json:"run_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// NewJob creates a queued job of the company, due now
+func NewJob(companyID uuid.UUID, jobType JobType, payload interface{}, createdBy *uuid.UUID, now time.Time) (*Job, error) {
+	if !jobType.IsValid() {
+		return nil, ErrInvalidJobType
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &Job{
+		TenantModel: TenantModel{CompanyID: companyID},
+		Type:        jobType,
+		Payload:     data,
+		Status:      JobStatusQueued,
+		MaxAttempts: jobType.MaxAttempts(),
+		RunAt:       now,
+		CreatedBy:   createdBy,
+	}, nil
+}
+
+// DecodePayload unmarshals the payload into v
+func (j *Job) DecodePayload(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// JobRetryDelay is how long a job waits after its attempt-th failed attempt:
+// a minute after the first, doubling up to an hour
+func JobRetryDelay(attempt int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Hour)
+}
+
+// Finish records the outcome of the current attempt. A failed attempt is
+// queued again after JobRetryDelay while attempts are left.
+func (j *Job) Finish(result json.RawMessage, err error, now time.Time) {
+	if err == nil {
+		j.Status = JobStatusSucceeded
+		j.Result = result
+		j.LastError = ""
+		j.FinishedAt = &now
+		return
+	}
+	j.LastError = err.Error()
+	if j.Attempts < j.MaxAttempts {
+		j.Status = JobStatusQueued
+		j.RunAt = now.Add(JobRetryDelay(j.Attempts))
+		return
+	}
+	j.Status = JobStatusFailed
+	j.FinishedAt = &now
+}
+
+// Retry queues a failed or cancelled job again with all its attempts
+func (j *Job) Retry(now time.Time) error {
+	if j.Status != JobStatusFailed && j.Status != JobStatusCancelled {
+		return ErrJobNotRetryable
+	}
+	j.Status = JobStatusQueued
+	j.Attempts = 0
+	j.RunAt = now
+	j.StartedAt = nil
+	j.FinishedAt = nil
+	return nil
+}
+
+// Cancel stops a job that is not finished. What a running job already did
+// stands; it is not run again.
+func (j *Job) Cancel(now time.Time) error {
+	if j.Status.IsFinished() {
+		return ErrJobNotCancellable
+	}
+	j.Status = JobStatusCancelled
+	j.FinishedAt = &now
+	return nil
+}
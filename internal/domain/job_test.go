@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Job Tests
+// ============================================================================
+
+func TestNewJob(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	job, err := domain.NewJob(uuid.New(), domain.JobTypeLedgerRecalculation,
+		domain.LedgerRecalculationPayload{Year: 2026, Month: 3}, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobStatusQueued, job.Status)
+	assert.Equal(t, 3, job.MaxAttempts)
+	assert.Equal(t, now, job.RunAt)
+
+	var payload domain.LedgerRecalculationPayload
+	require.NoError(t, job.DecodePayload(&payload))
+	assert.Equal(t, 3, payload.Month)
+
+	_, err = domain.NewJob(uuid.New(), "unknown", nil, nil, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidJobType)
+}
+
+func TestJobRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, domain.JobRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, domain.JobRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, domain.JobRetryDelay(3))
+	assert.Equal(t, time.Hour, domain.JobRetryDelay(20))
+}
+
+func TestJob_Finish(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	job, err := domain.NewJob(uuid.New(), domain.JobTypeLedgerRecalculation, domain.LedgerRecalculationPayload{}, nil, now)
+	require.NoError(t, err)
+
+	job.Attempts = 1
+	job.Finish(nil, errors.New("connection reset"), now)
+	assert.Equal(t, domain.JobStatusQueued, job.Status)
+	assert.Equal(t, now.Add(time.Minute), job.RunAt)
+	assert.Equal(t, "connection reset", job.LastError)
+	assert.Nil(t, job.FinishedAt)
+
+	job.Attempts = 3
+	job.Finish(nil, errors.New("connection reset"), now)
+	assert.Equal(t, domain.JobStatusFailed, job.Status)
+	assert.NotNil(t, job.FinishedAt)
+
+	require.NoError(t, job.Retry(now))
+	assert.Equal(t, domain.JobStatusQueued, job.Status)
+	assert.Equal(t, 0, job.Attempts)
+	assert.Nil(t, job.FinishedAt)
+
+	job.Attempts = 1
+	job.Finish([]byte(`{"ok":true}`), nil, now)
+	assert.Equal(t, domain.JobStatusSucceeded, job.Status)
+	assert.Empty(t, job.LastError)
+	assert.ErrorIs(t, job.Retry(now), domain.ErrJobNotRetryable)
+	assert.ErrorIs(t, job.Cancel(now), domain.ErrJobNotCancellable)
+}
+
+func TestJob_Cancel(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	job, err := domain.NewJob(uuid.New(), domain.JobTypeLegacyImport, domain.LegacyImportPayload{}, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, job.MaxAttempts)
+
+	assert.ErrorIs(t, job.Retry(now), domain.ErrJobNotRetryable)
+	require.NoError(t, job.Cancel(now))
+	assert.Equal(t, domain.JobStatusCancelled, job.Status)
+	require.NoError(t, job.Retry(now))
+	assert.Equal(t, domain.JobStatusQueued, job.Status)
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// KPI series errors
+var (
+	ErrInvalidKPIMetric         = errors.New("invalid metric")
+	ErrInvalidKPIGranularity    = errors.New("invalid granularity")
+	ErrInvalidKPIRange          = errors.New("invalid period range")
+	ErrKPIDimensionNotSupported = errors.New("cash series take no filters")
+)
+
+// KPI series limits
+const (
+	KPISeriesDefaultMonths = 12
+	KPISeriesMaxMonths     = 60
+)
+
+// KPIMetric is the figure a KPI series charts
+type KPIMetric string
+
+const (
+	KPIMetricRevenue KPIMetric = "revenue" // 매출, over the period
+	KPIMetricExpense KPIMetric = "expense" // 비용, over the period
+	KPIMetricCash    KPIMetric = "cash"    // 현금성 자산, at the end of the period
+)
+
+// IsValid checks if the metric is valid
+func (m KPIMetric) IsValid() bool {
+	return m == KPIMetricRevenue || m == KPIMetricExpense || m == KPIMetricCash
+}
+
+// AccountType returns the account type the metric sums, or empty for cash,
+// which sums the designated cash accounts
+func (m KPIMetric) AccountType() AccountType {
+	switch m {
+	case KPIMetricRevenue:
+		return AccountTypeRevenue
+	case KPIMetricExpense:
+		return AccountTypeExpense
+	}
+	return ""
+}
+
+// KPIGranularity is the length of the periods of a KPI series
+type KPIGranularity string
+
+const (
+	KPIGranularityMonth   KPIGranularity = "month"
+	KPIGranularityQuarter KPIGranularity = "quarter"
+	KPIGranularityYear    KPIGranularity = "year"
+)
+
+// IsValid checks if the granularity is valid
+func (g KPIGranularity) IsValid() bool {
+	return g == KPIGranularityMonth || g == KPIGranularityQuarter || g == KPIGranularityYear
+}
+
+// KPISeriesQuery selects a KPI series: the metric, the months it covers and
+// the department and project its revenue or expense is limited to
+type KPISeriesQuery struct {
+	Metric       KPIMetric
+	Granularity  KPIGranularity
+	FromYear     int
+	FromMonth    int
+	ToYear       int
+	ToMonth      int
+	DepartmentID *uuid.UUID
+	ProjectID    *uuid.UUID
+}
+
+// Validate checks the metric, the granularity and the range of the query
+func (q *KPISeriesQuery) Validate() error {
+	if !q.Metric.IsValid() {
+		return ErrInvalidKPIMetric
+	}
+	if !q.Granularity.IsValid() {
+		return ErrInvalidKPIGranularity
+	}
+	if q.FromMonth < 1 || q.FromMonth > 12 || q.ToMonth < 1 || q.ToMonth > 12 {
+		return ErrInvalidKPIRange
+	}
+	if months := q.Months(); months < 1 || months > KPISeriesMaxMonths {
+		return ErrInvalidKPIRange
+	}
+	// Cash entries are rarely tagged, so a filtered cash balance would mislead
+	if q.Metric == KPIMetricCash && (q.DepartmentID != nil || q.ProjectID != nil) {
+		return ErrKPIDimensionNotSupported
+	}
+	return nil
+}
+
+// Months returns the number of months the query covers
+func (q *KPISeriesQuery) Months() int {
+	return monthIndex(q.ToYear, q.ToMonth) - monthIndex(q.FromYear, q.FromMonth) + 1
+}
+
+// LedgerMonthTotal is the posted debits and credits of a set of accounts in a month
+type LedgerMonthTotal struct {
+	Year   int     `json:"year"`
+	Month  int     `json:"month"`
+	Debit  float64 `json:"debit"`
+	Credit float64 `json:"credit"`
+}
+
+// KPIPoint is the value of a KPI series in one period
+type KPIPoint struct {
+	Period string  `json:"period"` // 2026-03, 2026-Q1 or 2026
+	Value  float64 `json:"value"`
+}
+
+// KPISeries is a metric by period, oldest first, for dashboard charts
+type KPISeries struct {
+	Metric      KPIMetric      `json:"metric"`
+	Granularity KPIGranularity `json:"granularity"`
+	From        string         `json:"from"` // 2026-01
+	To          string         `json:"to"`
+	Points      []KPIPoint     `json:"points"`
+}
+
+// BuildKPISeries builds the series of the query from the monthly totals of its
+// accounts. Revenue and expense sum the months of each period on the side that
+// increases them; cash is the balance at the end of each period, starting from
+// the opening balance before the first month. Periods at the ends of the range
+// cover only its months.
+func BuildKPISeries(q KPISeriesQuery, totals []LedgerMonthTotal, opening float64) *KPISeries {
+	series := &KPISeries{
+		Metric:      q.Metric,
+		Granularity: q.Granularity,
+		From:        fmt.Sprintf("%d-%02d", q.FromYear, q.FromMonth),
+		To:          fmt.Sprintf("%d-%02d", q.ToYear, q.ToMonth),
+		Points:      []KPIPoint{},
+	}
+
+	net := make(map[int]float64, len(totals))
+	for _, t := range totals {
+		amount := t.Debit - t.Credit
+		if q.Metric == KPIMetricRevenue {
+			amount = -amount
+		}
+		net[monthIndex(t.Year, t.Month)] += amount
+	}
+
+	balance := opening
+	first, last := monthIndex(q.FromYear, q.FromMonth), monthIndex(q.ToYear, q.ToMonth)
+	for i := first; i <= last; i++ {
+		year, month := i/12, i%12+1
+		period := kpiPeriod(q.Granularity, year, month)
+		if n := len(series.Points); n == 0 || series.Points[n-1].Period != period {
+			series.Points = append(series.Points, KPIPoint{Period: period})
+		}
+		point := &series.Points[len(series.Points)-1]
+		if q.Metric == KPIMetricCash {
+			balance += net[i]
+			point.Value = roundAmount(balance)
+		} else {
+			point.Value = roundAmount(point.Value + net[i])
+		}
+	}
+	return series
+}
+
+// kpiPeriod returns the label of the period of the granularity holding the month
+func kpiPeriod(granularity KPIGranularity, year, month int) string {
+	switch granularity {
+	case KPIGranularityQuarter:
+		return fmt.Sprintf("%d-Q%d", year, (month-1)/3+1)
+	case KPIGranularityYear:
+		return fmt.Sprintf("%d", year)
+	}
+	return fmt.Sprintf("%d-%02d", year, month)
+}
+
+// monthIndex numbers the months consecutively
+func monthIndex(year, month int) int {
+	return year*12 + month - 1
+}
@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// KPI Series Tests
+// ============================================================================
+
+func TestKPISeriesQuery_Validate(t *testing.T) {
+	valid := domain.KPISeriesQuery{Metric: domain.KPIMetricRevenue, Granularity: domain.KPIGranularityMonth,
+		FromYear: 2025, FromMonth: 11, ToYear: 2026, ToMonth: 10}
+	require.NoError(t, valid.Validate())
+	assert.Equal(t, 12, valid.Months())
+
+	departmentID := uuid.New()
+	tests := []struct {
+		name   string
+		modify func(q *domain.KPISeriesQuery)
+		err    error
+	}{
+		{"unknown metric", func(q *domain.KPISeriesQuery) { q.Metric = "profit" }, domain.ErrInvalidKPIMetric},
+		{"unknown granularity", func(q *domain.KPISeriesQuery) { q.Granularity = "week" }, domain.ErrInvalidKPIGranularity},
+		{"from after to", func(q *domain.KPISeriesQuery) { q.FromYear = 2027 }, domain.ErrInvalidKPIRange},
+		{"too long", func(q *domain.KPISeriesQuery) { q.FromYear = 2020 }, domain.ErrInvalidKPIRange},
+		{"filtered cash", func(q *domain.KPISeriesQuery) {
+			q.Metric = domain.KPIMetricCash
+			q.DepartmentID = &departmentID
+		}, domain.ErrKPIDimensionNotSupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := valid
+			tt.modify(&q)
+			assert.ErrorIs(t, q.Validate(), tt.err)
+		})
+	}
+}
+
+func TestBuildKPISeries(t *testing.T) {
+	totals := []domain.LedgerMonthTotal{
+		{Year: 2026, Month: 2, Debit: 100, Credit: 1000},
+		{Year: 2026, Month: 3, Credit: 500},
+		{Year: 2026, Month: 4, Debit: 300, Credit: 200},
+	}
+	q := domain.KPISeriesQuery{Metric: domain.KPIMetricRevenue, Granularity: domain.KPIGranularityMonth,
+		FromYear: 2026, FromMonth: 1, ToYear: 2026, ToMonth: 4}
+
+	series := domain.BuildKPISeries(q, totals, 0)
+	assert.Equal(t, "2026-01", series.From)
+	assert.Equal(t, []domain.KPIPoint{
+		{Period: "2026-01", Value: 0}, {Period: "2026-02", Value: 900},
+		{Period: "2026-03", Value: 500}, {Period: "2026-04", Value: -100},
+	}, series.Points)
+
+	q.Granularity = domain.KPIGranularityQuarter
+	series = domain.BuildKPISeries(q, totals, 0)
+	assert.Equal(t, []domain.KPIPoint{{Period: "2026-Q1", Value: 1400}, {Period: "2026-Q2", Value: -100}}, series.Points)
+
+	// Expense increases on the debit side
+	q.Metric, q.Granularity = domain.KPIMetricExpense, domain.KPIGranularityYear
+	series = domain.BuildKPISeries(q, totals, 0)
+	assert.Equal(t, []domain.KPIPoint{{Period: "2026", Value: -1300}}, series.Points)
+
+	// Cash is the balance at the end of each period
+	q.Metric, q.Granularity = domain.KPIMetricCash, domain.KPIGranularityQuarter
+	series = domain.BuildKPISeries(q, totals, 5000)
+	assert.Equal(t, []domain.KPIPoint{{Period: "2026-Q1", Value: 3600}, {Period: "2026-Q2", Value: 3700}}, series.Points)
+}
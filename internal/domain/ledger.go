@@ -159,6 +159,31 @@ type TrialBalanceItem struct {
 	ClosingCredit   float64   `json:"closing_credit"`
 	IsSubTotal      bool      `json:"is_sub_total"`
 	IsTotal         bool      `json:"is_total"`
+
+	// Set on dimensional trial balances; a nil DimensionID is the entries
+	// without the dimension
+	DimensionID     *uuid.UUID `json:"dimension_id,omitempty"`
+	DimensionCode   string     `json:"dimension_code,omitempty"`
+	DimensionName   string     `json:"dimension_name,omitempty"`
+}
+
+// TrialBalanceDimension is the voucher entry dimension a trial balance is
+// broken down by, next to the account
+type TrialBalanceDimension string
+
+const (
+	TrialBalanceByDepartment TrialBalanceDimension = "department"
+	TrialBalanceByProject    TrialBalanceDimension = "project"
+	TrialBalanceByPartner    TrialBalanceDimension = "partner"
+)
+
+// IsValid checks if the dimension is valid
+func (d TrialBalanceDimension) IsValid() bool {
+	switch d {
+	case TrialBalanceByDepartment, TrialBalanceByProject, TrialBalanceByPartner:
+		return true
+	}
+	return false
 }
 
 // TrialBalance represents a trial balance report
@@ -170,6 +195,7 @@ type TrialBalance struct {
 	StartDate     time.Time          `json:"start_date"`
 	EndDate       time.Time          `json:"end_date"`
 	GeneratedAt   time.Time          `json:"generated_at"`
+	GroupBy       TrialBalanceDimension `json:"group_by,omitempty"` // empty for the trial balance by account
 	Items         []TrialBalanceItem `json:"items"`
 	TotalDebit    float64            `json:"total_debit"`
 	TotalCredit   float64            `json:"total_credit"`
@@ -0,0 +1,421 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Loan errors
+var (
+	ErrLoanNotFound          = errors.New("loan not found")
+	ErrLoanNoExists          = errors.New("loan number already exists")
+	ErrInvalidLoan           = errors.New("invalid loan")
+	ErrInvalidLoanRepayment  = errors.New("invalid loan repayment")
+	ErrLoanRepaid            = errors.New("loan is already repaid")
+	ErrLoanRepaymentTooLarge = errors.New("repayment exceeds the outstanding principal")
+)
+
+// LoanRepaymentMethod is how the principal of a loan is repaid
+type LoanRepaymentMethod string
+
+const (
+	LoanRepaymentBullet         LoanRepaymentMethod = "bullet"          // 만기일시상환: principal at maturity
+	LoanRepaymentEqualPrincipal LoanRepaymentMethod = "equal_principal" // 원금균등분할상환
+	LoanRepaymentEqualPayment   LoanRepaymentMethod = "equal_payment"   // 원리금균등분할상환
+)
+
+// IsValid checks if the repayment method is valid
+func (m LoanRepaymentMethod) IsValid() bool {
+	switch m {
+	case LoanRepaymentBullet, LoanRepaymentEqualPrincipal, LoanRepaymentEqualPayment:
+		return true
+	}
+	return false
+}
+
+// LoanStatus represents the state of a loan
+type LoanStatus string
+
+const (
+	LoanStatusActive LoanStatus = "active"
+	LoanStatusRepaid LoanStatus = "repaid"
+)
+
+// IsValid checks if the status is valid
+func (s LoanStatus) IsValid() bool {
+	return s == LoanStatusActive || s == LoanStatusRepaid
+}
+
+// MaxLoanMonths bounds the term of a loan, and so the size of its schedule
+const MaxLoanMonths = 600
+
+// Loan is money borrowed by the company, repaid monthly. Amounts are in won.
+// Interest is accrued at every month end by an auto-reversing voucher, so that
+// the interest expense of the month is booked before the bank charges it.
+type Loan struct {
+	TenantModel
+
+	LoanNo          string              `gorm:"type:varchar(50);not null" json:"loan_no"` // contract or account number at the lender
+	Lender          string              `gorm:"type:varchar(200);not null" json:"lender"`
+	PartnerID       *uuid.UUID          `gorm:"type:uuid" json:"partner_id,omitempty"`
+	Principal       int64               `gorm:"not null" json:"principal"`
+	InterestRate    float64             `gorm:"type:decimal(7,4);not null" json:"interest_rate"` // annual, in percent
+	StartDate       time.Time           `gorm:"type:date;not null" json:"start_date"`
+	MaturityDate    time.Time           `gorm:"type:date;not null" json:"maturity_date"`
+	RepaymentMethod LoanRepaymentMethod `gorm:"type:varchar(20);not null" json:"repayment_method"`
+	Description     string              `gorm:"type:varchar(500)" json:"description,omitempty"`
+
+	// Accounts of the generated vouchers
+	LoanAccountID            uuid.UUID `gorm:"type:uuid;not null" json:"loan_account_id"`             // 차입금
+	InterestExpenseAccountID uuid.UUID `gorm:"type:uuid;not null" json:"interest_expense_account_id"` // 이자비용
+	AccruedInterestAccountID uuid.UUID `gorm:"type:uuid;not null" json:"accrued_interest_account_id"` // 미지급비용
+	SettlementAccountID      uuid.UUID `gorm:"type:uuid;not null" json:"settlement_account_id"`       // account repayments are paid from
+
+	// Balances, updated by repayments and accruals
+	OutstandingPrincipal int64      `gorm:"not null" json:"outstanding_principal"`
+	InterestPaidThrough  time.Time  `gorm:"type:date;not null" json:"interest_paid_through"` // interest is paid up to and including this date
+	AccruedThrough       *time.Time `gorm:"type:date" json:"accrued_through,omitempty"`      // month end of the last accrual
+	Status               LoanStatus `gorm:"type:varchar(20);not null;default:active" json:"status"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	// Relations
+	Installments []LoanInstallment `gorm:"foreignKey:LoanID" json:"installments,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Loan) TableName() string {
+	return "loans"
+}
+
+// LoanInstallment is a scheduled monthly repayment of a loan. The interest is
+// the planned amount at a twelfth of the annual rate; the interest actually
+// charged is recorded with the repayment.
+type LoanInstallment struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID     uuid.UUID `gorm:"type:uuid;not null" json:"company_id"`
+	LoanID        uuid.UUID `gorm:"type:uuid;not null" json:"loan_id"`
+	Seq           int       `gorm:"not null" json:"seq"`
+	DueDate       time.Time `gorm:"type:date;not null" json:"due_date"`
+	Principal     int64     `gorm:"not null" json:"principal"`
+	Interest      int64     `gorm:"not null" json:"interest"`
+	PaidPrincipal int64     `gorm:"not null;default:0" json:"paid_principal"`
+}
+
+// TableName specifies the table name for GORM
+func (LoanInstallment) TableName() string {
+	return "loan_installments"
+}
+
+// UnpaidPrincipal returns the principal of the installment not yet repaid
+func (i *LoanInstallment) UnpaidPrincipal() int64 {
+	return i.Principal - i.PaidPrincipal
+}
+
+// LoanRepayment records a payment to the lender, split into principal and interest
+type LoanRepayment struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID     uuid.UUID  `gorm:"type:uuid;not null" json:"company_id"`
+	LoanID        uuid.UUID  `gorm:"type:uuid;not null" json:"loan_id"`
+	RepaymentDate time.Time  `gorm:"type:date;not null" json:"repayment_date"`
+	Principal     int64      `gorm:"not null" json:"principal"`
+	Interest      int64      `gorm:"not null" json:"interest"`
+	VoucherID     *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	CreatedBy     *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt     time.Time  `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LoanRepayment) TableName() string {
+	return "loan_repayments"
+}
+
+// LoanInterestAccrual records the month-end interest accrual voucher of a loan
+type LoanInterestAccrual struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID uuid.UUID  `gorm:"type:uuid;not null" json:"company_id"`
+	LoanID    uuid.UUID  `gorm:"type:uuid;not null" json:"loan_id"`
+	PeriodEnd time.Time  `gorm:"type:date;not null" json:"period_end"`
+	Amount    int64      `gorm:"not null" json:"amount"`
+	VoucherID *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	CreatedAt time.Time  `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LoanInterestAccrual) TableName() string {
+	return "loan_interest_accruals"
+}
+
+// Validate checks the terms of a new loan
+func (l *Loan) Validate() error {
+	switch {
+	case l.LoanNo == "", l.Lender == "":
+		return ErrInvalidLoan
+	case l.Principal <= 0:
+		return ErrInvalidLoan
+	case l.InterestRate < 0 || l.InterestRate >= 100:
+		return ErrInvalidLoan
+	case !l.RepaymentMethod.IsValid():
+		return ErrInvalidLoan
+	case !l.MaturityDate.After(l.StartDate):
+		return ErrInvalidLoan
+	case loanMonths(l.StartDate, l.MaturityDate) > MaxLoanMonths:
+		return ErrInvalidLoan
+	}
+	return nil
+}
+
+// InterestFor returns the interest on the outstanding principal for the days
+// after from up to and including to, on an actual/365 basis with amounts below
+// one won truncated as Korean banks do
+func (l *Loan) InterestFor(from, to time.Time) int64 {
+	return loanInterest(l.OutstandingPrincipal, l.InterestRate, daysBetween(from, to))
+}
+
+func loanInterest(principal int64, rate float64, days int) int64 {
+	if principal <= 0 || days <= 0 {
+		return 0
+	}
+	return int64(math.Floor(float64(principal) * rate / 100 * float64(days) / 365))
+}
+
+// BuildSchedule generates the monthly installments of the loan, due on the day
+// of the start date (or the month end of shorter months) with the last on the
+// maturity date. Rounding differences are taken by the last installment.
+func (l *Loan) BuildSchedule() []LoanInstallment {
+	n := loanMonths(l.StartDate, l.MaturityDate)
+	if n < 1 {
+		n = 1
+	}
+	rate := l.InterestRate / 100 / 12
+
+	var payment float64
+	if l.RepaymentMethod == LoanRepaymentEqualPayment && rate > 0 {
+		payment = math.Round(float64(l.Principal) * rate / (1 - math.Pow(1+rate, -float64(n))))
+	}
+
+	installments := make([]LoanInstallment, 0, n)
+	remaining := l.Principal
+	for seq := 1; seq <= n; seq++ {
+		interest := int64(math.Floor(float64(remaining) * rate))
+
+		var principal int64
+		switch {
+		case seq == n:
+			principal = remaining
+		case l.RepaymentMethod == LoanRepaymentBullet:
+			principal = 0
+		case l.RepaymentMethod == LoanRepaymentEqualPayment && payment > 0:
+			principal = int64(payment) - interest
+		default:
+			principal = l.Principal / int64(n)
+		}
+		if principal > remaining {
+			principal = remaining
+		}
+
+		dueDate := addMonthsClamped(l.StartDate, seq)
+		if seq == n {
+			dueDate = l.MaturityDate
+		}
+		installments = append(installments, LoanInstallment{
+			CompanyID: l.CompanyID,
+			LoanID:    l.ID,
+			Seq:       seq,
+			DueDate:   dueDate,
+			Principal: principal,
+			Interest:  interest,
+		})
+		remaining -= principal
+	}
+	return installments
+}
+
+// ScheduledPrincipal returns the unpaid principal of the installments due up to
+// and including the date, or of the next installment if none is due yet
+func (l *Loan) ScheduledPrincipal(date time.Time) int64 {
+	var due int64
+	for i := range l.Installments {
+		inst := &l.Installments[i]
+		if inst.UnpaidPrincipal() <= 0 {
+			continue
+		}
+		if inst.DueDate.After(date) {
+			if due == 0 {
+				due = inst.UnpaidPrincipal()
+			}
+			break
+		}
+		due += inst.UnpaidPrincipal()
+	}
+	if due > l.OutstandingPrincipal {
+		due = l.OutstandingPrincipal
+	}
+	return due
+}
+
+// ApplyRepayment reduces the outstanding principal, settling installments in
+// order, and moves the interest paid-through date to the repayment date
+func (l *Loan) ApplyRepayment(r *LoanRepayment) error {
+	if l.Status == LoanStatusRepaid {
+		return ErrLoanRepaid
+	}
+	if r.Principal < 0 || r.Interest < 0 || r.Principal+r.Interest == 0 {
+		return ErrInvalidLoanRepayment
+	}
+	if r.RepaymentDate.Before(l.InterestPaidThrough) {
+		return ErrInvalidLoanRepayment
+	}
+	if r.Principal > l.OutstandingPrincipal {
+		return ErrLoanRepaymentTooLarge
+	}
+
+	l.OutstandingPrincipal -= r.Principal
+	l.InterestPaidThrough = r.RepaymentDate
+	if l.OutstandingPrincipal == 0 {
+		l.Status = LoanStatusRepaid
+	}
+
+	remaining := r.Principal
+	for i := range l.Installments {
+		if remaining == 0 {
+			break
+		}
+		inst := &l.Installments[i]
+		pay := inst.UnpaidPrincipal()
+		if pay > remaining {
+			pay = remaining
+		}
+		inst.PaidPrincipal += pay
+		remaining -= pay
+	}
+	// Prepayments beyond the schedule are taken by the last installment
+	if remaining > 0 && len(l.Installments) > 0 {
+		l.Installments[len(l.Installments)-1].PaidPrincipal += remaining
+	}
+	return nil
+}
+
+// NeedsAccrual returns true if the interest of the month ending at periodEnd
+// is yet to be accrued
+func (l *Loan) NeedsAccrual(periodEnd time.Time) bool {
+	if l.Status != LoanStatusActive || l.StartDate.After(periodEnd) {
+		return false
+	}
+	return l.AccruedThrough == nil || l.AccruedThrough.Before(periodEnd)
+}
+
+// LoanAccrualRun summarizes a month-end interest accrual run
+type LoanAccrualRun struct {
+	PeriodEnd time.Time   `json:"period_end"`
+	Checked   int         `json:"checked"`  // active loans due for accrual
+	Accrued   int         `json:"accrued"`  // loans an accrual voucher was generated for
+	Vouchers  []uuid.UUID `json:"vouchers"` // draft accrual vouchers
+}
+
+// LoanOutstanding is a loan in the loans outstanding report
+type LoanOutstanding struct {
+	LoanID          uuid.UUID           `json:"loan_id"`
+	LoanNo          string              `json:"loan_no"`
+	Lender          string              `json:"lender"`
+	InterestRate    float64             `json:"interest_rate"`
+	StartDate       time.Time           `json:"start_date"`
+	MaturityDate    time.Time           `json:"maturity_date"`
+	RepaymentMethod LoanRepaymentMethod `json:"repayment_method"`
+
+	Principal       int64 `json:"principal"`
+	Repaid          int64 `json:"repaid"`
+	Outstanding     int64 `json:"outstanding"`
+	CurrentPortion  int64 `json:"current_portion"`  // principal due within a year (유동성장기부채)
+	AccruedInterest int64 `json:"accrued_interest"` // interest since the last repayment
+
+	NextDueDate   *time.Time `json:"next_due_date,omitempty"`
+	NextPrincipal int64      `json:"next_principal"`
+}
+
+// LoanOutstandingReport lists the principal owed on each loan at a date
+type LoanOutstandingReport struct {
+	AsOf  time.Time         `json:"as_of"`
+	Loans []LoanOutstanding `json:"loans"`
+
+	TotalOutstanding     int64 `json:"total_outstanding"`
+	TotalCurrentPortion  int64 `json:"total_current_portion"`
+	TotalAccruedInterest int64 `json:"total_accrued_interest"`
+}
+
+// NewLoanOutstanding computes the position of the loan at asOf from its
+// repayments, so that the report can be run for past dates
+func NewLoanOutstanding(l *Loan, repayments []LoanRepayment, asOf time.Time) LoanOutstanding {
+	row := LoanOutstanding{
+		LoanID:          l.ID,
+		LoanNo:          l.LoanNo,
+		Lender:          l.Lender,
+		InterestRate:    l.InterestRate,
+		StartDate:       l.StartDate,
+		MaturityDate:    l.MaturityDate,
+		RepaymentMethod: l.RepaymentMethod,
+		Principal:       l.Principal,
+	}
+
+	paidThrough := l.StartDate
+	for _, r := range repayments {
+		if r.LoanID != l.ID || r.RepaymentDate.After(asOf) {
+			continue
+		}
+		row.Repaid += r.Principal
+		if r.RepaymentDate.After(paidThrough) {
+			paidThrough = r.RepaymentDate
+		}
+	}
+	row.Outstanding = l.Principal - row.Repaid
+	row.AccruedInterest = loanInterest(row.Outstanding, l.InterestRate, daysBetween(paidThrough, asOf))
+
+	// Installments are settled in order, so the repaid principal covers the earliest ones
+	covered := row.Repaid
+	yearEnd := asOf.AddDate(1, 0, 0)
+	for _, inst := range l.Installments {
+		unpaid := inst.Principal
+		if covered >= unpaid {
+			covered -= unpaid
+			continue
+		}
+		unpaid -= covered
+		covered = 0
+
+		if row.NextDueDate == nil && inst.DueDate.After(asOf) {
+			dueDate := inst.DueDate
+			row.NextDueDate = &dueDate
+			row.NextPrincipal = unpaid
+		}
+		if !inst.DueDate.After(yearEnd) {
+			row.CurrentPortion += unpaid
+		}
+	}
+	if row.CurrentPortion > row.Outstanding {
+		row.CurrentPortion = row.Outstanding
+	}
+	return row
+}
+
+// loanMonths returns the number of monthly installments from start to maturity
+func loanMonths(start, maturity time.Time) int {
+	months := (maturity.Year()-start.Year())*12 + int(maturity.Month()-start.Month())
+	if maturity.Day() > start.Day() && addMonthsClamped(start, months).Before(maturity) {
+		months++
+	}
+	return months
+}
+
+// addMonthsClamped adds months to t, clamping the day to the end of shorter months
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
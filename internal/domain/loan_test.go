@@ -0,0 +1,159 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func loanDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestLoan(method domain.LoanRepaymentMethod, principal int64, rate float64, start, maturity time.Time) *domain.Loan {
+	loan := &domain.Loan{
+		TenantModel:          domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}},
+		LoanNo:               "L-001",
+		Lender:               "국민은행",
+		Principal:            principal,
+		InterestRate:         rate,
+		StartDate:            start,
+		MaturityDate:         maturity,
+		RepaymentMethod:      method,
+		OutstandingPrincipal: principal,
+		InterestPaidThrough:  start,
+		Status:               domain.LoanStatusActive,
+	}
+	loan.Installments = loan.BuildSchedule()
+	return loan
+}
+
+func sumPrincipal(installments []domain.LoanInstallment) int64 {
+	var sum int64
+	for _, inst := range installments {
+		sum += inst.Principal
+	}
+	return sum
+}
+
+func TestLoan_BuildSchedule(t *testing.T) {
+	start, maturity := loanDate(2024, 1, 10), loanDate(2025, 1, 10)
+
+	bullet := newTestLoan(domain.LoanRepaymentBullet, 12000000, 6, start, maturity)
+	require.Len(t, bullet.Installments, 12)
+	assert.Equal(t, int64(0), bullet.Installments[0].Principal)
+	assert.Equal(t, int64(60000), bullet.Installments[0].Interest)
+	assert.Equal(t, int64(12000000), bullet.Installments[11].Principal)
+	assert.Equal(t, maturity, bullet.Installments[11].DueDate)
+
+	equalPrincipal := newTestLoan(domain.LoanRepaymentEqualPrincipal, 12000000, 6, start, maturity)
+	require.Len(t, equalPrincipal.Installments, 12)
+	assert.Equal(t, int64(1000000), equalPrincipal.Installments[0].Principal)
+	assert.Equal(t, int64(60000), equalPrincipal.Installments[0].Interest)
+	assert.Equal(t, int64(55000), equalPrincipal.Installments[1].Interest)
+	assert.Equal(t, int64(12000000), sumPrincipal(equalPrincipal.Installments))
+
+	equalPayment := newTestLoan(domain.LoanRepaymentEqualPayment, 12000000, 6, start, maturity)
+	require.Len(t, equalPayment.Installments, 12)
+	first := equalPayment.Installments[0]
+	assert.Equal(t, int64(60000), first.Interest)
+	assert.Equal(t, int64(1032797), first.Principal+first.Interest, "annuity payment")
+	assert.Equal(t, int64(12000000), sumPrincipal(equalPayment.Installments))
+	assert.Greater(t, equalPayment.Installments[11].Principal, first.Principal)
+}
+
+func TestLoan_BuildScheduleClampsMonthEnd(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentEqualPrincipal, 3000000, 0, loanDate(2024, 1, 31), loanDate(2024, 4, 30))
+
+	require.Len(t, loan.Installments, 3)
+	assert.Equal(t, loanDate(2024, 2, 29), loan.Installments[0].DueDate)
+	assert.Equal(t, loanDate(2024, 3, 31), loan.Installments[1].DueDate)
+	assert.Equal(t, loanDate(2024, 4, 30), loan.Installments[2].DueDate)
+}
+
+func TestLoan_Validate(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentBullet, 1000000, 4.5, loanDate(2024, 1, 1), loanDate(2026, 1, 1))
+	assert.NoError(t, loan.Validate())
+
+	loan.MaturityDate = loan.StartDate
+	assert.ErrorIs(t, loan.Validate(), domain.ErrInvalidLoan)
+
+	loan.MaturityDate = loanDate(2100, 1, 1)
+	assert.ErrorIs(t, loan.Validate(), domain.ErrInvalidLoan, "terms beyond the schedule limit are rejected")
+}
+
+func TestLoan_InterestFor(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentBullet, 100000000, 4.5, loanDate(2024, 1, 1), loanDate(2026, 1, 1))
+
+	// 100,000,000 x 4.5% x 31/365 = 382,191.78, truncated
+	assert.Equal(t, int64(382191), loan.InterestFor(loanDate(2024, 1, 1), loanDate(2024, 2, 1)))
+	assert.Equal(t, int64(0), loan.InterestFor(loanDate(2024, 2, 1), loanDate(2024, 1, 1)))
+}
+
+func TestLoan_ApplyRepayment(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentEqualPrincipal, 3000000, 3.65, loanDate(2024, 1, 31), loanDate(2024, 4, 30))
+	assert.Equal(t, int64(1000000), loan.ScheduledPrincipal(loanDate(2024, 2, 29)))
+
+	// A prepayment settles the next installment in part
+	err := loan.ApplyRepayment(&domain.LoanRepayment{RepaymentDate: loanDate(2024, 2, 29), Principal: 1500000, Interest: 8700})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500000), loan.OutstandingPrincipal)
+	assert.Equal(t, loanDate(2024, 2, 29), loan.InterestPaidThrough)
+	assert.Equal(t, int64(1000000), loan.Installments[0].PaidPrincipal)
+	assert.Equal(t, int64(500000), loan.Installments[1].PaidPrincipal)
+	assert.Equal(t, int64(500000), loan.ScheduledPrincipal(loanDate(2024, 3, 31)))
+
+	err = loan.ApplyRepayment(&domain.LoanRepayment{RepaymentDate: loanDate(2024, 2, 1), Principal: 100})
+	assert.ErrorIs(t, err, domain.ErrInvalidLoanRepayment, "repayments before the last one are rejected")
+	err = loan.ApplyRepayment(&domain.LoanRepayment{RepaymentDate: loanDate(2024, 3, 31), Principal: 2000000})
+	assert.ErrorIs(t, err, domain.ErrLoanRepaymentTooLarge)
+
+	require.NoError(t, loan.ApplyRepayment(&domain.LoanRepayment{RepaymentDate: loanDate(2024, 3, 31), Principal: 1500000}))
+	assert.Equal(t, domain.LoanStatusRepaid, loan.Status)
+	assert.False(t, loan.NeedsAccrual(loanDate(2024, 4, 30)))
+	assert.ErrorIs(t, loan.ApplyRepayment(&domain.LoanRepayment{RepaymentDate: loanDate(2024, 4, 30), Interest: 1}), domain.ErrLoanRepaid)
+}
+
+func TestLoan_NeedsAccrual(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentBullet, 1000000, 4.5, loanDate(2024, 1, 15), loanDate(2025, 1, 15))
+
+	assert.False(t, loan.NeedsAccrual(loanDate(2023, 12, 31)), "not started")
+	assert.True(t, loan.NeedsAccrual(loanDate(2024, 1, 31)))
+
+	accrued := loanDate(2024, 1, 31)
+	loan.AccruedThrough = &accrued
+	assert.False(t, loan.NeedsAccrual(loanDate(2024, 1, 31)))
+	assert.True(t, loan.NeedsAccrual(loanDate(2024, 2, 29)))
+}
+
+func TestNewLoanOutstanding(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentEqualPrincipal, 3000000, 3.65, loanDate(2024, 1, 31), loanDate(2024, 4, 30))
+	repayments := []domain.LoanRepayment{
+		{LoanID: loan.ID, RepaymentDate: loanDate(2024, 2, 29), Principal: 1000000, Interest: 8700},
+		{LoanID: loan.ID, RepaymentDate: loanDate(2024, 4, 30), Principal: 1000000},
+		{LoanID: uuid.New(), RepaymentDate: loanDate(2024, 2, 29), Principal: 500000},
+	}
+
+	row := domain.NewLoanOutstanding(loan, repayments, loanDate(2024, 3, 31))
+	assert.Equal(t, int64(1000000), row.Repaid, "later repayments and other loans are ignored")
+	assert.Equal(t, int64(2000000), row.Outstanding)
+	assert.Equal(t, int64(2000000), row.CurrentPortion)
+	// 2,000,000 x 3.65% x 31/365
+	assert.Equal(t, int64(6200), row.AccruedInterest)
+	require.NotNil(t, row.NextDueDate)
+	assert.Equal(t, loanDate(2024, 4, 30), *row.NextDueDate)
+	assert.Equal(t, int64(1000000), row.NextPrincipal)
+}
+
+func TestNewLoanOutstandingCurrentPortion(t *testing.T) {
+	loan := newTestLoan(domain.LoanRepaymentBullet, 50000000, 5, loanDate(2024, 6, 1), loanDate(2027, 6, 1))
+
+	assert.Equal(t, int64(0), domain.NewLoanOutstanding(loan, nil, loanDate(2025, 12, 31)).CurrentPortion)
+	assert.Equal(t, int64(50000000), domain.NewLoanOutstanding(loan, nil, loanDate(2026, 6, 30)).CurrentPortion,
+		"the principal due at maturity becomes current a year before")
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Note errors
+var (
+	ErrNoteNotFound        = errors.New("note not found")
+	ErrNoteNoExists        = errors.New("note number already exists")
+	ErrInvalidNote         = errors.New("invalid note")
+	ErrInvalidNoteDiscount = errors.New("invalid note discount")
+	ErrNoteNotOutstanding  = errors.New("note is already discounted or settled")
+	ErrNoteNotDiscountable = errors.New("only received notes can be discounted")
+)
+
+// NoteDirection tells whether the company holds the note or owes it
+type NoteDirection string
+
+const (
+	NoteReceived NoteDirection = "received" // 받을어음: received from a customer
+	NoteIssued   NoteDirection = "issued"   // 지급어음: issued to a supplier
+)
+
+// IsValid checks if the direction is valid
+func (d NoteDirection) IsValid() bool {
+	return d == NoteReceived || d == NoteIssued
+}
+
+// NoteKind is the kind of instrument
+type NoteKind string
+
+const (
+	NoteKindPromissory NoteKind = "promissory" // 약속어음
+	NoteKindElectronic NoteKind = "electronic" // 전자어음
+	NoteKindCheck      NoteKind = "check"      // 당좌수표·가계수표, payable at sight
+)
+
+// IsValid checks if the kind is valid
+func (k NoteKind) IsValid() bool {
+	switch k {
+	case NoteKindPromissory, NoteKindElectronic, NoteKindCheck:
+		return true
+	}
+	return false
+}
+
+// NoteStatus is the status of a note
+type NoteStatus string
+
+const (
+	NoteStatusOutstanding NoteStatus = "outstanding" // held, or issued and not yet paid
+	NoteStatusDiscounted  NoteStatus = "discounted"  // 할인: sold to a bank before maturity
+	NoteStatusSettled     NoteStatus = "settled"     // 결제: collected or paid at maturity
+)
+
+// IsValid checks if the status is valid
+func (s NoteStatus) IsValid() bool {
+	switch s {
+	case NoteStatusOutstanding, NoteStatusDiscounted, NoteStatusSettled:
+		return true
+	}
+	return false
+}
+
+// NoteMaturityAlertDays is how many days before maturity the outstanding
+// notes are notified
+const NoteMaturityAlertDays = 7
+
+// Note is a promissory note or check (어음·수표) received from or issued to a
+// partner. Registering it books the note against the receivable or payable
+// it settles; received notes are discounted at a bank or collected at
+// maturity, issued notes are paid at maturity. Amounts are in won.
+type Note struct {
+	TenantModel
+
+	NoteNo       string        `gorm:"type:varchar(50);not null" json:"note_no"` // number printed on the instrument
+	Direction    NoteDirection `gorm:"type:varchar(20);not null" json:"direction"`
+	Kind         NoteKind      `gorm:"type:varchar(20);not null" json:"kind"`
+	PartnerID    uuid.UUID     `gorm:"type:uuid;not null" json:"partner_id"`
+	Drawer       string        `gorm:"type:varchar(100)" json:"drawer,omitempty"` // 발행인, when not the partner (배서어음)
+	Amount       int64         `gorm:"not null" json:"amount"`
+	IssueDate    time.Time     `gorm:"type:date;not null" json:"issue_date"`
+	MaturityDate time.Time     `gorm:"type:date;not null" json:"maturity_date"`
+	BookedDate   time.Time     `gorm:"type:date;not null" json:"booked_date"`          // received or handed over, the date of its voucher
+	PayingBank   string        `gorm:"type:varchar(100)" json:"paying_bank,omitempty"` // 지급은행 (지급장소)
+	Description  string        `gorm:"type:varchar(500)" json:"description,omitempty"`
+
+	// Accounts of the generated vouchers
+	NoteAccountID    uuid.UUID `gorm:"type:uuid;not null" json:"note_account_id"`    // 받을어음 or 지급어음
+	CounterAccountID uuid.UUID `gorm:"type:uuid;not null" json:"counter_account_id"` // receivable or payable settled by the note
+
+	Status NoteStatus `gorm:"type:varchar(20);not null;default:outstanding" json:"status"`
+
+	// Discounting and settlement
+	DiscountedDate *time.Time `gorm:"type:date" json:"discounted_date,omitempty"`
+	DiscountCharge int64      `gorm:"not null;default:0" json:"discount_charge"` // 할인료 kept by the bank
+	SettledDate    *time.Time `gorm:"type:date" json:"settled_date,omitempty"`
+	BankAccountID  *uuid.UUID `gorm:"type:uuid" json:"bank_account_id,omitempty"` // account paid into or from
+
+	// Vouchers of the receipt or issue, the discounting and the settlement
+	VoucherID           *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	DiscountVoucherID   *uuid.UUID `gorm:"type:uuid" json:"discount_voucher_id,omitempty"`
+	SettlementVoucherID *uuid.UUID `gorm:"type:uuid" json:"settlement_voucher_id,omitempty"`
+
+	MaturityAlertedAt *time.Time `json:"maturity_alerted_at,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Note) TableName() string {
+	return "notes"
+}
+
+// Validate checks the terms and accounts of a new note. A booked date left
+// empty is the issue date.
+func (n *Note) Validate() error {
+	n.NoteNo = strings.TrimSpace(n.NoteNo)
+	if n.BookedDate.IsZero() {
+		n.BookedDate = n.IssueDate
+	}
+	switch {
+	case n.NoteNo == "", !n.Direction.IsValid(), !n.Kind.IsValid(), n.PartnerID == uuid.Nil,
+		n.Amount <= 0, n.IssueDate.IsZero(), n.MaturityDate.Before(n.IssueDate),
+		n.NoteAccountID == uuid.Nil, n.CounterAccountID == uuid.Nil, n.NoteAccountID == n.CounterAccountID:
+		return ErrInvalidNote
+	}
+	// Checks are payable at sight
+	if n.Kind == NoteKindCheck && !n.MaturityDate.Equal(n.IssueDate) {
+		return ErrInvalidNote
+	}
+	return nil
+}
+
+// Discount sells a received note to the bank before maturity; the bank pays
+// the amount less its charge into the bank account
+func (n *Note) Discount(date time.Time, charge int64, bankAccountID uuid.UUID) error {
+	if n.Direction != NoteReceived {
+		return ErrNoteNotDiscountable
+	}
+	if n.Status != NoteStatusOutstanding {
+		return ErrNoteNotOutstanding
+	}
+	if charge < 0 || charge >= n.Amount || date.Before(n.BookedDate) || date.After(n.MaturityDate) {
+		return ErrInvalidNoteDiscount
+	}
+	n.Status = NoteStatusDiscounted
+	n.DiscountedDate = &date
+	n.DiscountCharge = charge
+	n.BankAccountID = &bankAccountID
+	return nil
+}
+
+// Settle records the collection or payment of the note through the bank account
+func (n *Note) Settle(date time.Time, bankAccountID uuid.UUID) error {
+	if n.Status != NoteStatusOutstanding {
+		return ErrNoteNotOutstanding
+	}
+	if date.Before(n.BookedDate) {
+		return ErrInvalidNote
+	}
+	n.Status = NoteStatusSettled
+	n.SettledDate = &date
+	n.BankAccountID = &bankAccountID
+	return nil
+}
+
+// DaysToMaturity returns the days from today to the maturity, negative when overdue
+func (n *Note) DaysToMaturity(today time.Time) int {
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	maturity := time.Date(n.MaturityDate.Year(), n.MaturityDate.Month(), n.MaturityDate.Day(), 0, 0, 0, 0, time.UTC)
+	return int(maturity.Sub(day).Hours() / 24)
+}
+
+// NeedsMaturityAlert returns true if the outstanding note matures within
+// NoteMaturityAlertDays, or is overdue, and was not notified yet
+func (n *Note) NeedsMaturityAlert(today time.Time) bool {
+	return n.Status == NoteStatusOutstanding && n.MaturityAlertedAt == nil &&
+		n.DaysToMaturity(today) <= NoteMaturityAlertDays
+}
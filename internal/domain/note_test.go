@@ -0,0 +1,84 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func newNote(direction domain.NoteDirection) *domain.Note {
+	return &domain.Note{
+		NoteNo:           " 자가12345678 ",
+		Direction:        direction,
+		Kind:             domain.NoteKindPromissory,
+		PartnerID:        uuid.New(),
+		Amount:           10000000,
+		IssueDate:        time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		MaturityDate:     time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		NoteAccountID:    uuid.New(),
+		CounterAccountID: uuid.New(),
+		Status:           domain.NoteStatusOutstanding,
+	}
+}
+
+func TestNote_Validate(t *testing.T) {
+	note := newNote(domain.NoteReceived)
+	require.NoError(t, note.Validate())
+	assert.Equal(t, "자가12345678", note.NoteNo)
+	assert.Equal(t, note.IssueDate, note.BookedDate)
+
+	early := newNote(domain.NoteReceived)
+	early.MaturityDate = early.IssueDate.AddDate(0, 0, -1)
+	assert.ErrorIs(t, early.Validate(), domain.ErrInvalidNote)
+
+	sameAccount := newNote(domain.NoteIssued)
+	sameAccount.CounterAccountID = sameAccount.NoteAccountID
+	assert.ErrorIs(t, sameAccount.Validate(), domain.ErrInvalidNote)
+
+	check := newNote(domain.NoteReceived)
+	check.Kind = domain.NoteKindCheck
+	assert.ErrorIs(t, check.Validate(), domain.ErrInvalidNote)
+	check.MaturityDate = check.IssueDate
+	assert.NoError(t, check.Validate())
+}
+
+func TestNote_DiscountAndSettle(t *testing.T) {
+	bankAccountID := uuid.New()
+
+	note := newNote(domain.NoteReceived)
+	require.NoError(t, note.Validate())
+	assert.ErrorIs(t, note.Discount(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), note.Amount, bankAccountID), domain.ErrInvalidNoteDiscount)
+	assert.ErrorIs(t, note.Discount(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), 0, bankAccountID), domain.ErrInvalidNoteDiscount)
+
+	require.NoError(t, note.Discount(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 150000, bankAccountID))
+	assert.Equal(t, domain.NoteStatusDiscounted, note.Status)
+	assert.Equal(t, int64(150000), note.DiscountCharge)
+	assert.Equal(t, bankAccountID, *note.BankAccountID)
+	assert.ErrorIs(t, note.Settle(note.MaturityDate, bankAccountID), domain.ErrNoteNotOutstanding)
+
+	issued := newNote(domain.NoteIssued)
+	require.NoError(t, issued.Validate())
+	assert.ErrorIs(t, issued.Discount(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 0, bankAccountID), domain.ErrNoteNotDiscountable)
+	require.NoError(t, issued.Settle(issued.MaturityDate, bankAccountID))
+	assert.Equal(t, domain.NoteStatusSettled, issued.Status)
+	assert.Equal(t, issued.MaturityDate, *issued.SettledDate)
+}
+
+func TestNote_NeedsMaturityAlert(t *testing.T) {
+	note := newNote(domain.NoteReceived)
+	today := time.Date(2026, 6, 23, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 7, note.DaysToMaturity(today))
+	assert.True(t, note.NeedsMaturityAlert(today))
+	assert.False(t, note.NeedsMaturityAlert(today.AddDate(0, 0, -1)))
+	assert.Equal(t, -2, note.DaysToMaturity(time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC)))
+
+	alertedAt := today
+	note.MaturityAlertedAt = &alertedAt
+	assert.False(t, note.NeedsMaturityAlert(today))
+}
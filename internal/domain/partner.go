@@ -2,6 +2,8 @@ package domain
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,6 +12,8 @@ import (
 var (
 	ErrPartnerNotFound   = errors.New("partner not found")
 	ErrPartnerCodeExists = errors.New("partner code already exists")
+
+	ErrInvalidPartnerBankAccount = errors.New("invalid partner bank account")
 )
 
 // Partner represents a business partner (customer/vendor)
@@ -43,6 +47,18 @@ type Partner struct {
 	ARAccountID     *uuid.UUID `gorm:"type:uuid" json:"ar_account_id,omitempty"` // Accounts Receivable
 	APAccountID     *uuid.UUID `gorm:"type:uuid" json:"ap_account_id,omitempty"` // Accounts Payable
 
+	// Bank account payables are transferred to
+	BankCode          string `gorm:"type:varchar(3)" json:"bank_code,omitempty"`
+	BankAccountNumber string `gorm:"type:varchar(20)" json:"bank_account_number,omitempty"` // digits only
+	BankAccountHolder string `gorm:"type:varchar(100)" json:"bank_account_holder,omitempty"`
+
+	// NTS registration status of the business number, set by verification
+	BusinessStatus         BusinessStatus `gorm:"type:varchar(20)" json:"business_status,omitempty"`
+	BusinessTaxType        string         `gorm:"type:varchar(100)" json:"business_tax_type,omitempty"`
+	BusinessClosedOn       *time.Time     `gorm:"type:date" json:"business_closed_on,omitempty"`
+	RepresentativeVerified *bool          `json:"representative_verified,omitempty"`
+	BusinessVerifiedAt     *time.Time     `json:"business_verified_at,omitempty"`
+
 	// Status
 	IsActive bool `gorm:"default:true" json:"is_active"`
 }
@@ -51,3 +67,35 @@ type Partner struct {
 func (Partner) TableName() string {
 	return "partners"
 }
+
+// NormalizeBankAccount strips the dashes of the bank account number and
+// checks that the bank account is complete or left out
+func (p *Partner) NormalizeBankAccount() error {
+	p.BankCode = strings.TrimSpace(p.BankCode)
+	p.BankAccountNumber = digitsOnly(p.BankAccountNumber)
+	p.BankAccountHolder = strings.TrimSpace(p.BankAccountHolder)
+	if p.BankCode == "" && p.BankAccountNumber == "" && p.BankAccountHolder == "" {
+		return nil
+	}
+	if len(p.BankCode) != 3 || digitsOnly(p.BankCode) != p.BankCode ||
+		len(p.BankAccountNumber) < 8 || len(p.BankAccountNumber) > 20 || p.BankAccountHolder == "" {
+		return ErrInvalidPartnerBankAccount
+	}
+	return nil
+}
+
+// HasBankAccount returns true if payables can be transferred to the partner
+func (p *Partner) HasBankAccount() bool {
+	return p.BankCode != "" && p.BankAccountNumber != ""
+}
+
+// AnonymizeContact clears the personal data of the partner's contact person
+// for a deletion request. The business name, number and address stay, as tax
+// invoices and vouchers of the partner are kept for their statutory period.
+func (p *Partner) AnonymizeContact() {
+	p.Representative = ""
+	p.Phone = ""
+	p.Fax = ""
+	p.Email = ""
+	p.RepresentativeVerified = nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Partner code rule errors
+var (
+	ErrInvalidPartnerCodeRule       = errors.New("invalid partner code rule")
+	ErrPartnerCodeSequenceExhausted = errors.New("partner code sequence is exhausted")
+)
+
+// Partner code rule limits
+const (
+	DefaultPartnerCodeDigits = 5
+	MinPartnerCodeDigits     = 3
+	MaxPartnerCodeDigits     = 10
+	MaxPartnerCodePrefix     = 10
+	MaxPartnerCodeLength     = 20 // partners.code
+)
+
+// PartnerCodeRule generates the code of partners created without one: the
+// prefix of the partner type followed by the next number of that prefix,
+// zero-padded to Digits
+type PartnerCodeRule struct {
+	CustomerPrefix string `json:"customer_prefix"` // e.g. C
+	VendorPrefix   string `json:"vendor_prefix"`   // e.g. V
+	BothPrefix     string `json:"both_prefix"`
+	Digits         int    `json:"digits"`
+}
+
+// PartnerCodes returns the partner code rule. Settings without one number
+// partners of all types in one sequence of five digits.
+func (s CompanySettings) PartnerCodes() PartnerCodeRule {
+	if s.PartnerCodeRule == nil {
+		return PartnerCodeRule{Digits: DefaultPartnerCodeDigits}
+	}
+	return *s.PartnerCodeRule
+}
+
+// Validate checks the rule
+func (r *PartnerCodeRule) Validate() error {
+	if r.Digits < MinPartnerCodeDigits || r.Digits > MaxPartnerCodeDigits {
+		return ErrInvalidPartnerCodeRule
+	}
+	for _, prefix := range []string{r.CustomerPrefix, r.VendorPrefix, r.BothPrefix} {
+		if len(prefix) > MaxPartnerCodePrefix || len(prefix)+r.Digits > MaxPartnerCodeLength {
+			return ErrInvalidPartnerCodeRule
+		}
+		for _, c := range prefix {
+			if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+				return ErrInvalidPartnerCodeRule
+			}
+		}
+	}
+	return nil
+}
+
+// Prefix returns the code prefix of a partner type
+func (r *PartnerCodeRule) Prefix(partnerType string) string {
+	switch partnerType {
+	case "customer":
+		return r.CustomerPrefix
+	case "vendor":
+		return r.VendorPrefix
+	}
+	return r.BothPrefix
+}
+
+// NextCode returns the code following last, the highest code of the prefix of
+// the partner type with Digits digits, or the first code when last is empty
+func (r *PartnerCodeRule) NextCode(partnerType, last string) (string, error) {
+	prefix := r.Prefix(partnerType)
+	next := uint64(1)
+	if last != "" {
+		n, err := strconv.ParseUint(strings.TrimPrefix(last, prefix), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("partner code %q does not follow the code rule", last)
+		}
+		next = n + 1
+	}
+
+	code := fmt.Sprintf("%s%0*d", prefix, r.Digits, next)
+	if len(code) > len(prefix)+r.Digits {
+		return "", ErrPartnerCodeSequenceExhausted
+	}
+	return code, nil
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// PartnerDuplicateThreshold is the score from which two partners are reported
+// as likely duplicates
+const PartnerDuplicateThreshold = 0.8
+
+// Reasons two partners are taken for duplicates
+const (
+	DuplicateReasonBusinessNumber     = "business_number"      // the same business number
+	DuplicateReasonBusinessNumberTypo = "business_number_typo" // one digit apart, with alike names
+	DuplicateReasonName               = "name"
+)
+
+// partnerNameAffixes are the legal forms dropped from names before they are
+// compared, so that 주식회사 한빛 and ㈜한빛 match
+var partnerNameAffixes = []string{
+	"주식회사", "(주)", "㈜", "유한회사", "(유)", "유한책임회사", "합자회사", "(합)", "합명회사",
+	"사단법인", "(사)", "재단법인", "(재)", "co.,ltd.", "co., ltd.", "co.,ltd", "co., ltd", "inc.", "corp.",
+}
+
+// PartnerRef identifies a partner in a duplicate pair
+type PartnerRef struct {
+	ID             uuid.UUID `json:"id"`
+	Code           string    `json:"code"`
+	Name           string    `json:"name"`
+	BusinessNumber string    `json:"business_number,omitempty"`
+}
+
+// PartnerDuplicate is a pair of partners that likely are the same business
+type PartnerDuplicate struct {
+	Partner   PartnerRef `json:"partner"`
+	Duplicate PartnerRef `json:"duplicate"`
+	Score     float64    `json:"score"` // 0 to 1
+	Reasons   []string   `json:"reasons"`
+}
+
+// NormalizePartnerName returns the name without its legal form, spaces and
+// punctuation, in lower case
+func NormalizePartnerName(name string) string {
+	name = strings.ToLower(name)
+	for _, affix := range partnerNameAffixes {
+		name = strings.ReplaceAll(name, affix, "")
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// NameSimilarity returns how alike two normalized names are, from 0 for
+// nothing in common to 1 for equal names, by their edit distance
+func NameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance returns the Levenshtein distance of two rune strings
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// duplicateCandidate is a partner prepared for comparison
+type duplicateCandidate struct {
+	ref    PartnerRef
+	name   []rune // normalized
+	digits string // of the business number, when complete
+}
+
+// FindPartnerDuplicates returns the pairs of partners scoring at least
+// PartnerDuplicateThreshold, highest score first. A pair scores 1 with the
+// same business number; a business number one digit apart is taken for a
+// typo only as far as the names are alike. Otherwise the pair scores the
+// similarity of the names.
+//
+// Only partners whose names start alike, or whose business numbers differ in
+// at most one digit, are compared, which keeps large partner lists tractable.
+func FindPartnerDuplicates(partners []Partner) []PartnerDuplicate {
+	candidates := make([]duplicateCandidate, len(partners))
+	byFirstRune := make(map[rune][]int)
+	byMaskedDigits := make(map[string][]int)
+	for i := range partners {
+		p := &partners[i]
+		c := duplicateCandidate{
+			ref:  PartnerRef{ID: p.ID, Code: p.Code, Name: p.Name, BusinessNumber: p.BusinessNumber},
+			name: []rune(NormalizePartnerName(p.Name)),
+		}
+		if digits := BusinessNumberDigits(p.BusinessNumber); len(digits) == 10 {
+			c.digits = digits
+			// Numbers one digit apart share the key masking that digit
+			for k := range digits {
+				key := digits[:k] + "*" + digits[k+1:]
+				byMaskedDigits[key] = append(byMaskedDigits[key], i)
+			}
+		}
+		if len(c.name) > 0 {
+			byFirstRune[c.name[0]] = append(byFirstRune[c.name[0]], i)
+		}
+		candidates[i] = c
+	}
+
+	type pair struct{ a, b int }
+	seen := make(map[pair]bool)
+	var duplicates []PartnerDuplicate
+	compare := func(group []int) {
+		for x := 0; x < len(group); x++ {
+			for y := x + 1; y < len(group); y++ {
+				p := pair{group[x], group[y]}
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
+				if d, ok := scorePartnerPair(&candidates[p.a], &candidates[p.b]); ok {
+					duplicates = append(duplicates, d)
+				}
+			}
+		}
+	}
+	for _, group := range byMaskedDigits {
+		compare(group)
+	}
+	for _, group := range byFirstRune {
+		compare(group)
+	}
+
+	slices.SortFunc(duplicates, func(a, b PartnerDuplicate) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Partner.Code, b.Partner.Code); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Duplicate.Code, b.Duplicate.Code)
+	})
+	return duplicates
+}
+
+// scorePartnerPair scores two partners, returning false below the threshold
+func scorePartnerPair(a, b *duplicateCandidate) (PartnerDuplicate, bool) {
+	d := PartnerDuplicate{Partner: a.ref, Duplicate: b.ref}
+	if a.ref.Code > b.ref.Code {
+		d.Partner, d.Duplicate = b.ref, a.ref
+	}
+
+	nameScore := 0.0
+	// A name shorter by a fifth of the longer one cannot score the threshold
+	if la, lb := len(a.name), len(b.name); la > 0 && lb > 0 && float64(min(la, lb)) >= float64(max(la, lb))*PartnerDuplicateThreshold {
+		nameScore = NameSimilarity(string(a.name), string(b.name))
+	}
+	if nameScore >= PartnerDuplicateThreshold {
+		d.Reasons = append(d.Reasons, DuplicateReasonName)
+	}
+	d.Score = nameScore
+
+	if a.digits != "" && b.digits != "" {
+		switch digitsApart(a.digits, b.digits) {
+		case 0:
+			d.Score = 1
+			d.Reasons = append([]string{DuplicateReasonBusinessNumber}, d.Reasons...)
+		case 1:
+			if score := (1 + nameScore) / 2; score >= PartnerDuplicateThreshold {
+				d.Score = max(d.Score, score)
+				d.Reasons = append([]string{DuplicateReasonBusinessNumberTypo}, d.Reasons...)
+			}
+		}
+	}
+	d.Score = roundScore(d.Score)
+	return d, len(d.Reasons) > 0
+}
+
+// digitsApart returns the number of positions at which two digit strings of
+// the same length differ
+func digitsApart(a, b string) int {
+	n := 0
+	for i := range a {
+		if a[i] != b[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// roundScore rounds a score to two decimals
+func roundScore(v float64) float64 {
+	return float64(int(v*100+0.5)) / 100
+}
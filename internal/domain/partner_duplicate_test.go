@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestNormalizePartnerName(t *testing.T) {
+	assert.Equal(t, "한빛상사", domain.NormalizePartnerName("(주) 한빛 상사"))
+	assert.Equal(t, "한빛상사", domain.NormalizePartnerName("주식회사 한빛상사"))
+	assert.Equal(t, "한빛상사", domain.NormalizePartnerName("㈜한빛-상사"))
+	assert.Equal(t, "acme", domain.NormalizePartnerName("ACME Co., Ltd."))
+}
+
+func TestFindPartnerDuplicates(t *testing.T) {
+	partner := func(code, name, businessNumber string) domain.Partner {
+		p := domain.Partner{Code: code, Name: name, BusinessNumber: businessNumber}
+		p.ID = uuid.New()
+		return p
+	}
+	partners := []domain.Partner{
+		partner("P001", "(주)한빛상사", "123-45-67890"),
+		partner("P002", "한빛상사", ""),
+		partner("P003", "다온물산", "123-45-67890"),
+		partner("P004", "한빛상사", "123-45-67891"), // one digit off, the same name
+		partner("P005", "미래테크", "987-65-43210"),
+		partner("P006", "누리건설", "987-65-43211"), // one digit off, another name
+	}
+
+	duplicates := domain.FindPartnerDuplicates(partners)
+	pairs := make(map[string]domain.PartnerDuplicate)
+	for _, d := range duplicates {
+		pairs[d.Partner.Code+"-"+d.Duplicate.Code] = d
+	}
+
+	require.Contains(t, pairs, "P001-P003")
+	assert.Equal(t, 1.0, pairs["P001-P003"].Score)
+	assert.Equal(t, []string{domain.DuplicateReasonBusinessNumber}, pairs["P001-P003"].Reasons)
+
+	require.Contains(t, pairs, "P001-P004")
+	assert.Equal(t, 1.0, pairs["P001-P004"].Score)
+	assert.Equal(t, []string{domain.DuplicateReasonBusinessNumberTypo, domain.DuplicateReasonName}, pairs["P001-P004"].Reasons)
+
+	require.Contains(t, pairs, "P001-P002")
+	assert.Equal(t, []string{domain.DuplicateReasonName}, pairs["P001-P002"].Reasons)
+
+	assert.NotContains(t, pairs, "P005-P006")
+	assert.Equal(t, 1.0, duplicates[0].Score)
+	assert.GreaterOrEqual(t, duplicates[len(duplicates)-1].Score, domain.PartnerDuplicateThreshold)
+}
+
+func TestPartnerCodeRule_NextCode(t *testing.T) {
+	rule := domain.CompanySettings{}.PartnerCodes()
+	code, err := rule.NextCode("customer", "")
+	require.NoError(t, err)
+	assert.Equal(t, "00001", code)
+
+	rule = domain.PartnerCodeRule{CustomerPrefix: "C", VendorPrefix: "V", Digits: 3}
+	require.NoError(t, rule.Validate())
+	code, err = rule.NextCode("vendor", "V041")
+	require.NoError(t, err)
+	assert.Equal(t, "V042", code)
+
+	_, err = rule.NextCode("customer", "C999")
+	assert.ErrorIs(t, err, domain.ErrPartnerCodeSequenceExhausted)
+
+	assert.ErrorIs(t, (&domain.PartnerCodeRule{CustomerPrefix: "C-", Digits: 5}).Validate(), domain.ErrInvalidPartnerCodeRule)
+	assert.ErrorIs(t, (&domain.PartnerCodeRule{BothPrefix: "ABCDEFGHIJ", Digits: 11}).Validate(), domain.ErrInvalidPartnerCodeRule)
+}
+
+func TestPartner_MergeFrom(t *testing.T) {
+	target := domain.Partner{Code: "P001", Name: "한빛상사", PartnerType: "customer", Phone: "02-123-4567"}
+	source := domain.Partner{Code: "P002", Name: "(주)한빛상사", PartnerType: "vendor",
+		BusinessNumber: "1234567890", BusinessStatus: domain.BusinessStatusActive, Phone: "02-000-0000", Email: "ar@hanbit.example"}
+
+	assert.True(t, target.MergeFrom(&source))
+	assert.Equal(t, "1234567890", target.BusinessNumber)
+	assert.Equal(t, domain.BusinessStatusActive, target.BusinessStatus)
+	assert.Equal(t, "02-123-4567", target.Phone)
+	assert.Equal(t, "ar@hanbit.example", target.Email)
+	assert.Equal(t, "both", target.PartnerType)
+
+	assert.False(t, target.MergeFrom(&domain.Partner{BusinessNumber: "9876543210", PartnerType: "both"}))
+	assert.Equal(t, "1234567890", target.BusinessNumber)
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PartnerLedgerEntry is a posted receivable/payable entry of a partner (거래처원장)
+type PartnerLedgerEntry struct {
+	PartnerID    uuid.UUID `json:"partner_id"`
+	VoucherID    uuid.UUID `json:"voucher_id"`
+	VoucherNo    string    `json:"voucher_no"`
+	VoucherDate  time.Time `json:"voucher_date"`
+	VoucherType  string    `json:"voucher_type"`
+	EntryID      uuid.UUID `json:"entry_id"`
+	LineNo       int       `json:"line_no"`
+	AccountID    uuid.UUID `json:"account_id"`
+	AccountCode  string    `json:"account_code"`
+	AccountName  string    `json:"account_name"`
+	Description  string    `json:"description"`
+	DebitAmount  float64   `json:"debit_amount"`
+	CreditAmount float64   `json:"credit_amount"`
+	Balance      float64   `json:"balance"` // Running balance
+}
+
+// PartnerLedger is the statement of account of a partner over a date range.
+// Balances are debit minus credit across the partner's AR/AP (balance sheet) accounts:
+// positive means the partner owes the company, negative means the company owes the partner.
+type PartnerLedger struct {
+	Partner        *Partner             `json:"partner"`
+	FromDate       time.Time            `json:"from_date"`
+	ToDate         time.Time            `json:"to_date"`
+	OpeningBalance float64              `json:"opening_balance"`
+	TotalDebit     float64              `json:"total_debit"`
+	TotalCredit    float64              `json:"total_credit"`
+	ClosingBalance float64              `json:"closing_balance"`
+	Entries        []PartnerLedgerEntry `json:"entries"`
+}
+
+// NewPartnerLedger builds a statement from the opening balance and the period entries,
+// filling in the running balance of each entry
+func NewPartnerLedger(partner *Partner, from, to time.Time, openingBalance float64, entries []PartnerLedgerEntry) *PartnerLedger {
+	ledger := &PartnerLedger{
+		Partner:        partner,
+		FromDate:       from,
+		ToDate:         to,
+		OpeningBalance: openingBalance,
+		Entries:        entries,
+	}
+	if ledger.Entries == nil {
+		ledger.Entries = []PartnerLedgerEntry{}
+	}
+
+	balance := openingBalance
+	for i := range ledger.Entries {
+		e := &ledger.Entries[i]
+		balance += e.DebitAmount - e.CreditAmount
+		e.Balance = balance
+		ledger.TotalDebit += e.DebitAmount
+		ledger.TotalCredit += e.CreditAmount
+	}
+	ledger.ClosingBalance = balance
+	return ledger
+}
+
+// IsEmpty returns true if the statement has no activity and no balance
+func (l *PartnerLedger) IsEmpty() bool {
+	return len(l.Entries) == 0 && l.OpeningBalance == 0 && l.ClosingBalance == 0
+}
+
+// PartnerStatementFailure records a statement that could not be mailed
+type PartnerStatementFailure struct {
+	PartnerID   uuid.UUID `json:"partner_id"`
+	PartnerName string    `json:"partner_name"`
+	Error       string    `json:"error"`
+}
+
+// PartnerStatementMailing summarizes a bulk statement mailing
+type PartnerStatementMailing struct {
+	Generated int                       `json:"generated"`
+	Sent      int                       `json:"sent"`
+	Skipped   int                       `json:"skipped"` // partners without an email address
+	Failures  []PartnerStatementFailure `json:"failures"`
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// PartnerLedger Tests
+// ============================================================================
+
+func TestNewPartnerLedger(t *testing.T) {
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+	partner := &domain.Partner{Code: "C001", Name: "Customer"}
+
+	ledger := domain.NewPartnerLedger(partner, from, to, 500, []domain.PartnerLedgerEntry{
+		{VoucherNo: "V-001", DebitAmount: 1100},
+		{VoucherNo: "V-002", CreditAmount: 1600},
+	})
+
+	assert.Equal(t, 1600.0, ledger.Entries[0].Balance)
+	assert.Equal(t, 0.0, ledger.Entries[1].Balance)
+	assert.Equal(t, 1100.0, ledger.TotalDebit)
+	assert.Equal(t, 1600.0, ledger.TotalCredit)
+	assert.Equal(t, 0.0, ledger.ClosingBalance)
+	assert.False(t, ledger.IsEmpty())
+
+	empty := domain.NewPartnerLedger(partner, from, to, 0, nil)
+	assert.NotNil(t, empty.Entries)
+	assert.True(t, empty.IsEmpty())
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Partner merge errors
+var (
+	ErrPartnerMergeSelf           = errors.New("a partner cannot be merged into itself")
+	ErrPartnerMergeBusinessNumber = errors.New("tax invoices tie partner to business number")
+)
+
+// Audit log action and entity of partner merges
+const (
+	AuditActionPartnerMerge = "partner_merge"
+	AuditEntityPartner      = "partner"
+)
+
+// PartnerMerge is the outcome of merging a duplicate partner, the source,
+// into the partner kept, the target. The rows of the source are re-pointed to
+// the target and the source is deleted.
+type PartnerMerge struct {
+	SourceID          uuid.UUID `json:"source_id"`
+	TargetID          uuid.UUID `json:"target_id"`
+	VoucherEntries    int64     `json:"voucher_entries"` // the AR/AP items of the partner ledger
+	Invoices          int64     `json:"invoices"`
+	Loans             int64     `json:"loans"`
+	Grants            int64     `json:"grants"`
+	PaymentBatchItems int64     `json:"payment_batch_items"` // the transfers of bulk payment batches
+	Notes             int64     `json:"notes"`               // promissory notes and checks (어음·수표)
+	Contracts         int64     `json:"contracts"`
+	RevenueSchedules  int64     `json:"revenue_schedules"`
+	PrepaidExpenses   int64     `json:"prepaid_expenses"`
+	// Tax invoices name partners by business number: those of the source
+	// follow when the target takes over its business number
+	BusinessNumberMoved bool `json:"business_number_moved"`
+}
+
+// MergeFrom fills the fields the partner left empty from the source partner
+// merged into it. A customer merged with a vendor becomes both. It returns
+// true if the partner took over the business number of the source.
+func (p *Partner) MergeFrom(source *Partner) bool {
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&p.NameEn, source.NameEn)
+	fill(&p.Representative, source.Representative)
+	fill(&p.Phone, source.Phone)
+	fill(&p.Fax, source.Fax)
+	fill(&p.Email, source.Email)
+	fill(&p.Website, source.Website)
+	if p.Address == "" {
+		p.ZipCode, p.Address, p.AddressDetail = source.ZipCode, source.Address, source.AddressDetail
+	}
+	if p.ARAccountID == nil {
+		p.ARAccountID = source.ARAccountID
+	}
+	if p.APAccountID == nil {
+		p.APAccountID = source.APAccountID
+	}
+	if !p.HasBankAccount() {
+		p.BankCode, p.BankAccountNumber, p.BankAccountHolder = source.BankCode, source.BankAccountNumber, source.BankAccountHolder
+	}
+	if p.PartnerType != source.PartnerType {
+		p.PartnerType = "both"
+	}
+
+	if p.BusinessNumber != "" || source.BusinessNumber == "" {
+		return false
+	}
+	// The NTS status belongs to the business number
+	p.BusinessNumber = source.BusinessNumber
+	p.BusinessStatus = source.BusinessStatus
+	p.BusinessTaxType = source.BusinessTaxType
+	p.BusinessClosedOn = source.BusinessClosedOn
+	p.RepresentativeVerified = source.RepresentativeVerified
+	p.BusinessVerifiedAt = source.BusinessVerifiedAt
+	return true
+}
+
+// AuditLog builds the audit log entry of the merge, on the target. The old
+// values are the source partner as it was and the new values the outcome.
+func (m *PartnerMerge) AuditLog(companyID uuid.UUID, userID *uuid.UUID, source *Partner) (*AuditLog, error) {
+	oldValues, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{
+		CompanyID:  companyID,
+		UserID:     userID,
+		Action:     AuditActionPartnerMerge,
+		EntityType: AuditEntityPartner,
+		EntityID:   &m.TargetID,
+		OldValues:  oldValues,
+		NewValues:  newValues,
+	}, nil
+}
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payment batch errors
+var (
+	ErrPaymentBatchNotFound  = errors.New("payment batch not found")
+	ErrNoPayablesDue         = errors.New("no approved payables are due")
+	ErrPaymentBatchNotOpen   = errors.New("payment batch is executed or cancelled")
+	ErrInvalidPaymentBatch   = errors.New("invalid payment batch")
+	ErrPaymentItemNotInBatch = errors.New("payment item is not in the batch")
+	ErrPayablesChanged       = errors.New("payables changed while generating the batch")
+)
+
+// PaymentBatchStatus is the status of a payment batch
+type PaymentBatchStatus string
+
+const (
+	PaymentBatchGenerated PaymentBatchStatus = "generated" // file generated, awaiting execution at the bank
+	PaymentBatchExecuted  PaymentBatchStatus = "executed"
+	PaymentBatchCancelled PaymentBatchStatus = "cancelled"
+)
+
+// IsValid checks if the status is valid
+func (s PaymentBatchStatus) IsValid() bool {
+	switch s {
+	case PaymentBatchGenerated, PaymentBatchExecuted, PaymentBatchCancelled:
+		return true
+	}
+	return false
+}
+
+// PaymentItemStatus is the status of a payable in a payment batch
+type PaymentItemStatus string
+
+const (
+	PaymentItemInPayment PaymentItemStatus = "in_payment"
+	PaymentItemPaid      PaymentItemStatus = "paid"
+	PaymentItemFailed    PaymentItemStatus = "failed" // rejected by the bank, due again
+	PaymentItemCancelled PaymentItemStatus = "cancelled"
+)
+
+// PaymentBatchReferenceType marks the payment vouchers of executed batches
+const PaymentBatchReferenceType = "payment_batch"
+
+// PayableItem is an open payable of a partner: the part of a credit entry of
+// an approved or posted voucher on the partner's AP account that is neither
+// paid nor in payment
+type PayableItem struct {
+	EntryID     uuid.UUID `json:"entry_id"`
+	VoucherID   uuid.UUID `json:"voucher_id"`
+	VoucherNo   string    `json:"voucher_no"`
+	VoucherDate time.Time `json:"voucher_date"`
+	DueDate     time.Time `json:"due_date"` // voucher date plus the partner's payment terms
+	Description string    `json:"description,omitempty"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Amount      float64   `json:"amount"`
+
+	PartnerID         uuid.UUID `json:"partner_id"`
+	PartnerCode       string    `json:"partner_code"`
+	PartnerName       string    `json:"partner_name"`
+	PaymentTermDays   int       `json:"-"`
+	BankCode          string    `json:"bank_code,omitempty"`
+	BankAccountNumber string    `json:"bank_account_number,omitempty"`
+	BankAccountHolder string    `json:"bank_account_holder,omitempty"`
+}
+
+// OpenPayables settles the credit entries of each partner's AP account first
+// in first out against its payable balance, less what is in payment, and
+// returns the open part of those left in due date order. Payments booked by
+// hand thus settle the oldest payables.
+func OpenPayables(items []PayableItem, balances map[uuid.UUID]float64) []PayableItem {
+	byPartner := make(map[uuid.UUID][]PayableItem)
+	for _, item := range items {
+		byPartner[item.PartnerID] = append(byPartner[item.PartnerID], item)
+	}
+
+	var open []PayableItem
+	for partnerID, partnerItems := range byPartner {
+		// The newest payables are the open ones
+		sort.SliceStable(partnerItems, func(i, j int) bool {
+			return partnerItems[i].VoucherDate.After(partnerItems[j].VoucherDate)
+		})
+		remaining := balances[partnerID]
+		for _, item := range partnerItems {
+			if remaining <= 0 {
+				break
+			}
+			item.Amount = math.Min(item.Amount, remaining)
+			remaining = roundAmount(remaining - item.Amount)
+			item.DueDate = item.VoucherDate.AddDate(0, 0, item.PaymentTermDays)
+			open = append(open, item)
+		}
+	}
+
+	sort.Slice(open, func(i, j int) bool {
+		if !open[i].DueDate.Equal(open[j].DueDate) {
+			return open[i].DueDate.Before(open[j].DueDate)
+		}
+		if open[i].PartnerCode != open[j].PartnerCode {
+			return open[i].PartnerCode < open[j].PartnerCode
+		}
+		return open[i].VoucherNo < open[j].VoucherNo
+	})
+	return open
+}
+
+// PaymentBatch is a bulk transfer (대량이체) of payables due from a bank
+// account of the company. Its file is uploaded to internet banking; once the
+// bank has executed it, the outcome of each transfer is recorded and the paid
+// items are booked by a payment voucher.
+type PaymentBatch struct {
+	TenantModel
+
+	BatchNo       string             `gorm:"type:varchar(30);not null" json:"batch_no"`
+	BankAccountID uuid.UUID          `gorm:"type:uuid;not null" json:"bank_account_id"` // paying account
+	BankCode      string             `gorm:"type:varchar(3);not null" json:"bank_code"` // layout of the file
+	TransferDate  time.Time          `gorm:"type:date;not null" json:"transfer_date"`
+	DueBy         time.Time          `gorm:"type:date;not null" json:"due_by"`
+	Status        PaymentBatchStatus `gorm:"type:varchar(20);not null;default:generated" json:"status"`
+	ItemCount     int                `gorm:"not null;default:0" json:"item_count"`
+	TotalAmount   int64              `gorm:"not null;default:0" json:"total_amount"`
+	FileName      string             `gorm:"type:varchar(100);not null" json:"file_name"`
+
+	// Outcome of the execution
+	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+	PaidCount  int        `gorm:"not null;default:0" json:"paid_count"`
+	PaidAmount int64      `gorm:"not null;default:0" json:"paid_amount"`
+	VoucherID  *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"` // payment voucher of the paid items
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	Items []PaymentBatchItem `gorm:"foreignKey:BatchID" json:"items,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PaymentBatch) TableName() string {
+	return "payment_batches"
+}
+
+// PaymentBatchItem is a payable transferred by a payment batch
+type PaymentBatchItem struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID uuid.UUID `gorm:"type:uuid;not null" json:"company_id"`
+	BatchID   uuid.UUID `gorm:"type:uuid;not null" json:"batch_id"`
+	Seq       int       `gorm:"not null" json:"seq"` // line of the transfer file
+
+	EntryID   uuid.UUID `gorm:"type:uuid;not null" json:"entry_id"`
+	VoucherID uuid.UUID `gorm:"type:uuid;not null" json:"voucher_id"`
+	VoucherNo string    `gorm:"type:varchar(30)" json:"voucher_no"`
+	AccountID uuid.UUID `gorm:"type:uuid;not null" json:"account_id"` // AP account settled
+	PartnerID uuid.UUID `gorm:"type:uuid;not null" json:"partner_id"`
+	DueDate   time.Time `gorm:"type:date;not null" json:"due_date"`
+	Amount    int64     `gorm:"not null" json:"amount"`
+
+	// Payee as written to the file
+	BankCode          string `gorm:"type:varchar(3);not null" json:"bank_code"`
+	BankAccountNumber string `gorm:"type:varchar(20);not null" json:"bank_account_number"`
+	BankAccountHolder string `gorm:"type:varchar(100);not null" json:"bank_account_holder"`
+	PayerMemo         string `gorm:"type:varchar(50)" json:"payer_memo,omitempty"`
+
+	Status PaymentItemStatus `gorm:"type:varchar(20);not null;default:in_payment" json:"status"`
+
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PaymentBatchItem) TableName() string {
+	return "payment_batch_items"
+}
+
+// PaymentBatchSkip is a payable due that a payment batch left out
+type PaymentBatchSkip struct {
+	Item   PayableItem `json:"item"`
+	Reason string      `json:"reason"`
+}
+
+// Reasons payables due are left out of a batch
+const (
+	PaymentSkipNoBankAccount = "partner_has_no_bank_account"
+	PaymentSkipBelowOneWon   = "amount_below_one_won"
+)
+
+// NewPaymentBatchItems turns the payables due into the items of a batch,
+// transferring whole won. Payables of partners without a bank account are
+// left out and returned as skips.
+func NewPaymentBatchItems(companyID uuid.UUID, payables []PayableItem) ([]PaymentBatchItem, []PaymentBatchSkip) {
+	var items []PaymentBatchItem
+	var skips []PaymentBatchSkip
+	for _, p := range payables {
+		amount := int64(math.Floor(p.Amount))
+		switch {
+		case p.BankCode == "" || p.BankAccountNumber == "":
+			skips = append(skips, PaymentBatchSkip{Item: p, Reason: PaymentSkipNoBankAccount})
+			continue
+		case amount < 1:
+			skips = append(skips, PaymentBatchSkip{Item: p, Reason: PaymentSkipBelowOneWon})
+			continue
+		}
+		holder := p.BankAccountHolder
+		if holder == "" {
+			holder = p.PartnerName
+		}
+		items = append(items, PaymentBatchItem{
+			CompanyID:         companyID,
+			Seq:               len(items) + 1,
+			EntryID:           p.EntryID,
+			VoucherID:         p.VoucherID,
+			VoucherNo:         p.VoucherNo,
+			AccountID:         p.AccountID,
+			PartnerID:         p.PartnerID,
+			DueDate:           p.DueDate,
+			Amount:            amount,
+			BankCode:          p.BankCode,
+			BankAccountNumber: p.BankAccountNumber,
+			BankAccountHolder: holder,
+			PayerMemo:         p.PartnerName,
+			Status:            PaymentItemInPayment,
+		})
+	}
+	return items, skips
+}
+
+// SetItems sets the items of the batch and its totals
+func (b *PaymentBatch) SetItems(items []PaymentBatchItem) {
+	b.Items = items
+	b.ItemCount = len(items)
+	b.TotalAmount = 0
+	for _, item := range items {
+		b.TotalAmount += item.Amount
+	}
+}
+
+// CanChange returns true if the batch awaits execution
+func (b *PaymentBatch) CanChange() bool {
+	return b.Status == PaymentBatchGenerated
+}
+
+// Execute records the outcome of the transfers executed by the bank: the
+// failed items are due again, the others are paid
+func (b *PaymentBatch) Execute(executedAt time.Time, failed []uuid.UUID) error {
+	if !b.CanChange() {
+		return ErrPaymentBatchNotOpen
+	}
+	failedSet := make(map[uuid.UUID]bool, len(failed))
+	for _, id := range failed {
+		failedSet[id] = true
+	}
+
+	b.PaidCount, b.PaidAmount = 0, 0
+	for i := range b.Items {
+		item := &b.Items[i]
+		if failedSet[item.ID] {
+			item.Status = PaymentItemFailed
+			delete(failedSet, item.ID)
+			continue
+		}
+		item.Status = PaymentItemPaid
+		b.PaidCount++
+		b.PaidAmount += item.Amount
+	}
+	if len(failedSet) > 0 {
+		return ErrPaymentItemNotInBatch
+	}
+
+	b.Status = PaymentBatchExecuted
+	b.ExecutedAt = &executedAt
+	return nil
+}
+
+// Cancel cancels the batch before execution, releasing its items
+func (b *PaymentBatch) Cancel() error {
+	if !b.CanChange() {
+		return ErrPaymentBatchNotOpen
+	}
+	for i := range b.Items {
+		b.Items[i].Status = PaymentItemCancelled
+	}
+	b.Status = PaymentBatchCancelled
+	return nil
+}
+
+// PaymentBatchCreation is a generated payment batch with the payables due it
+// left out
+type PaymentBatchCreation struct {
+	Batch   *PaymentBatch      `json:"batch"`
+	Skipped []PaymentBatchSkip `json:"skipped"`
+}
@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestOpenPayables(t *testing.T) {
+	vendorA, vendorB := uuid.New(), uuid.New()
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	payable := func(partnerID uuid.UUID, code, no string, date time.Time, amount float64, terms int) domain.PayableItem {
+		return domain.PayableItem{
+			EntryID: uuid.New(), PartnerID: partnerID, PartnerCode: code, VoucherNo: no,
+			VoucherDate: date, Amount: amount, PaymentTermDays: terms,
+		}
+	}
+	items := []domain.PayableItem{
+		payable(vendorA, "V001", "P-001", day(1), 1000, 30),
+		payable(vendorA, "V001", "P-002", day(10), 2000, 30),
+		payable(vendorB, "V002", "P-003", day(5), 500, 10),
+	}
+
+	// vendorA paid 1500 by hand: its oldest payable is settled, the next in part
+	open := domain.OpenPayables(items, map[uuid.UUID]float64{vendorA: 1500, vendorB: 500})
+	require.Len(t, open, 2)
+
+	assert.Equal(t, "P-003", open[0].VoucherNo)
+	assert.Equal(t, day(15), open[0].DueDate)
+	assert.Equal(t, 500.0, open[0].Amount)
+
+	assert.Equal(t, "P-002", open[1].VoucherNo)
+	assert.Equal(t, day(10).AddDate(0, 0, 30), open[1].DueDate)
+	assert.Equal(t, 1500.0, open[1].Amount)
+
+	assert.Empty(t, domain.OpenPayables(items, nil))
+}
+
+func TestNewPaymentBatchItems(t *testing.T) {
+	companyID := uuid.New()
+	payables := []domain.PayableItem{
+		{EntryID: uuid.New(), PartnerName: "(주)한빛", BankCode: "088", BankAccountNumber: "110123456789", Amount: 1200.7},
+		{EntryID: uuid.New(), PartnerName: "무통장상사", Amount: 300},
+		{EntryID: uuid.New(), PartnerName: "소액상회", BankCode: "004", BankAccountNumber: "123456", Amount: 0.5},
+		{EntryID: uuid.New(), PartnerName: "대한물산", BankCode: "020", BankAccountNumber: "1002123", BankAccountHolder: "대한물산 주식회사", Amount: 800},
+	}
+
+	items, skipped := domain.NewPaymentBatchItems(companyID, payables)
+	require.Len(t, items, 2)
+	assert.Equal(t, 1, items[0].Seq)
+	assert.Equal(t, int64(1200), items[0].Amount)
+	assert.Equal(t, "(주)한빛", items[0].BankAccountHolder)
+	assert.Equal(t, domain.PaymentItemInPayment, items[0].Status)
+	assert.Equal(t, 2, items[1].Seq)
+	assert.Equal(t, "대한물산 주식회사", items[1].BankAccountHolder)
+
+	require.Len(t, skipped, 2)
+	assert.Equal(t, domain.PaymentSkipNoBankAccount, skipped[0].Reason)
+	assert.Equal(t, domain.PaymentSkipBelowOneWon, skipped[1].Reason)
+}
+
+func TestPaymentBatch_Execute(t *testing.T) {
+	newBatch := func() *domain.PaymentBatch {
+		batch := &domain.PaymentBatch{Status: domain.PaymentBatchGenerated}
+		items := []domain.PaymentBatchItem{{Amount: 1000}, {Amount: 2000}, {Amount: 3000}}
+		for i := range items {
+			items[i].ID = uuid.New()
+		}
+		batch.SetItems(items)
+		return batch
+	}
+
+	batch := newBatch()
+	assert.Equal(t, int64(6000), batch.TotalAmount)
+	require.NoError(t, batch.Execute(time.Now(), []uuid.UUID{batch.Items[1].ID}))
+	assert.Equal(t, domain.PaymentBatchExecuted, batch.Status)
+	assert.Equal(t, 2, batch.PaidCount)
+	assert.Equal(t, int64(4000), batch.PaidAmount)
+	assert.Equal(t, domain.PaymentItemFailed, batch.Items[1].Status)
+	assert.Equal(t, domain.PaymentItemPaid, batch.Items[2].Status)
+
+	assert.ErrorIs(t, batch.Execute(time.Now(), nil), domain.ErrPaymentBatchNotOpen)
+	assert.ErrorIs(t, batch.Cancel(), domain.ErrPaymentBatchNotOpen)
+
+	assert.ErrorIs(t, newBatch().Execute(time.Now(), []uuid.UUID{uuid.New()}), domain.ErrPaymentItemNotInBatch)
+
+	cancelled := newBatch()
+	require.NoError(t, cancelled.Cancel())
+	assert.Equal(t, domain.PaymentBatchCancelled, cancelled.Status)
+	assert.Equal(t, domain.PaymentItemCancelled, cancelled.Items[0].Status)
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Petty cash errors
+var (
+	ErrPettyCashFundNotFound         = errors.New("petty cash fund not found")
+	ErrPettyCashFundExists           = errors.New("petty cash fund already exists")
+	ErrInvalidPettyCashFund          = errors.New("invalid petty cash fund")
+	ErrPettyCashFundInactive         = errors.New("petty cash fund is inactive")
+	ErrPettyCashClaimNotFound        = errors.New("petty cash claim not found")
+	ErrInvalidPettyCashClaim         = errors.New("invalid petty cash claim")
+	ErrPettyCashInsufficient         = errors.New("petty cash on hand is insufficient")
+	ErrPettyCashClaimReplenished     = errors.New("petty cash claim is replenished")
+	ErrNoPettyCashClaims             = errors.New("no petty cash claims to replenish")
+	ErrInvalidPettyCashReplenishment = errors.New("invalid petty cash replenishment")
+	ErrPettyCashClaimsChanged        = errors.New("claims changed while replenishing")
+)
+
+// PettyCashFund is the imprest fund (소액현금 전도금) a department pays small
+// expenses from. The custodian holds the float in cash; the claims paid from
+// it are replenished periodically from the bank account by a payment voucher,
+// which brings the cash on hand back to the float.
+type PettyCashFund struct {
+	TenantModel
+
+	Name         string     `gorm:"type:varchar(100);not null" json:"name"`
+	DepartmentID uuid.UUID  `gorm:"type:uuid;not null" json:"department_id"`
+	CustodianID  *uuid.UUID `gorm:"type:uuid" json:"custodian_id,omitempty"` // user holding the cash
+
+	FloatAmount     int64     `gorm:"not null" json:"float_amount"` // set when the fund is established
+	EstablishedDate time.Time `gorm:"type:date;not null" json:"established_date"`
+	AccountID       uuid.UUID `gorm:"type:uuid;not null" json:"account_id"`      // cash account of the fund, e.g. 소액현금
+	BankAccountID   uuid.UUID `gorm:"type:uuid;not null" json:"bank_account_id"` // account the fund is replenished from
+
+	VoucherID *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"` // payment voucher establishing the float
+	IsActive  bool       `gorm:"not null;default:true" json:"is_active"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PettyCashFund) TableName() string {
+	return "petty_cash_funds"
+}
+
+// Validate checks the required fields
+func (f *PettyCashFund) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	switch {
+	case f.Name == "", f.DepartmentID == uuid.Nil, f.FloatAmount <= 0, f.EstablishedDate.IsZero(),
+		f.AccountID == uuid.Nil, f.BankAccountID == uuid.Nil:
+		return ErrInvalidPettyCashFund
+	}
+	return nil
+}
+
+// PettyCashClaimStatus is the status of a petty cash claim
+type PettyCashClaimStatus string
+
+const (
+	PettyCashClaimPending     PettyCashClaimStatus = "pending"     // paid from the fund, not yet replenished
+	PettyCashClaimReplenished PettyCashClaimStatus = "replenished" // booked by a replenishment voucher
+)
+
+// IsValid checks if the status is valid
+func (s PettyCashClaimStatus) IsValid() bool {
+	return s == PettyCashClaimPending || s == PettyCashClaimReplenished
+}
+
+// PettyCashTransaction is an expense claim paid in cash from the fund. It is
+// booked to its expense account when the fund is replenished.
+type PettyCashTransaction struct {
+	TenantModel
+
+	FundID          uuid.UUID `gorm:"type:uuid;not null" json:"fund_id"`
+	TransactionDate time.Time `gorm:"type:date;not null" json:"transaction_date"`
+	AccountID       uuid.UUID `gorm:"type:uuid;not null" json:"account_id"` // expense account
+	Amount          int64     `gorm:"not null" json:"amount"`
+	Payee           string    `gorm:"type:varchar(100)" json:"payee,omitempty"`
+	Description     string    `gorm:"type:varchar(200);not null" json:"description"`
+	ReceiptNo       string    `gorm:"type:varchar(50)" json:"receipt_no,omitempty"` // 영수증 번호
+
+	Status          PettyCashClaimStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	ReplenishmentID *uuid.UUID           `gorm:"type:uuid" json:"replenishment_id,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"` // the claimant
+}
+
+// TableName specifies the table name for GORM
+func (PettyCashTransaction) TableName() string {
+	return "petty_cash_transactions"
+}
+
+// Validate checks a new claim
+func (t *PettyCashTransaction) Validate() error {
+	t.Payee = strings.TrimSpace(t.Payee)
+	t.Description = strings.TrimSpace(t.Description)
+	t.ReceiptNo = strings.TrimSpace(t.ReceiptNo)
+	switch {
+	case t.FundID == uuid.Nil, t.TransactionDate.IsZero(), t.AccountID == uuid.Nil,
+		t.Amount <= 0, t.Description == "":
+		return ErrInvalidPettyCashClaim
+	}
+	return nil
+}
+
+// PettyCashReplenishment brings the fund back to its float: the pending
+// claims through a date are booked to their expense accounts against the
+// bank account the cash is drawn from
+type PettyCashReplenishment struct {
+	TenantModel
+
+	FundID        uuid.UUID  `gorm:"type:uuid;not null" json:"fund_id"`
+	RequestDate   time.Time  `gorm:"type:date;not null" json:"request_date"` // date of the voucher
+	ClaimsThrough time.Time  `gorm:"type:date;not null" json:"claims_through"`
+	ClaimCount    int        `gorm:"not null" json:"claim_count"`
+	Amount        int64      `gorm:"not null" json:"amount"`
+	BankAccountID uuid.UUID  `gorm:"type:uuid;not null" json:"bank_account_id"`
+	VoucherID     *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	Claims []PettyCashTransaction `gorm:"foreignKey:ReplenishmentID" json:"claims,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PettyCashReplenishment) TableName() string {
+	return "petty_cash_replenishments"
+}
+
+// NewPettyCashReplenishment collects the pending claims of the fund dated
+// through the date into a replenishment requested on requestDate
+func NewPettyCashReplenishment(fund *PettyCashFund, claims []PettyCashTransaction, requestDate, through time.Time) (*PettyCashReplenishment, error) {
+	replenishment := &PettyCashReplenishment{
+		TenantModel:   TenantModel{CompanyID: fund.CompanyID},
+		FundID:        fund.ID,
+		RequestDate:   requestDate,
+		ClaimsThrough: through,
+		BankAccountID: fund.BankAccountID,
+	}
+	for _, claim := range claims {
+		if claim.FundID != fund.ID || claim.Status != PettyCashClaimPending || claim.TransactionDate.After(through) {
+			continue
+		}
+		replenishment.Claims = append(replenishment.Claims, claim)
+		replenishment.ClaimCount++
+		replenishment.Amount += claim.Amount
+	}
+	if replenishment.ClaimCount == 0 {
+		return nil, ErrNoPettyCashClaims
+	}
+	return replenishment, nil
+}
+
+// PettyCashReconciliation reconciles the cash a fund should hold with its
+// float, the claims not yet replenished and, when given, the cash counted.
+// BookBalance is the posted balance of the fund's cash account, which stays
+// at the float from the voucher establishing the fund on: the claims are
+// booked against the bank account when replenished.
+type PettyCashReconciliation struct {
+	FundID        uuid.UUID `json:"fund_id"`
+	FundName      string    `json:"fund_name"`
+	AsOf          time.Time `json:"as_of"`
+	FloatAmount   int64     `json:"float_amount"`
+	PendingCount  int       `json:"pending_count"`
+	PendingAmount int64     `json:"pending_amount"`
+	CashOnHand    int64     `json:"cash_on_hand"` // float less the pending claims
+	BookBalance   float64   `json:"book_balance"`
+
+	CountedCash *int64 `json:"counted_cash,omitempty"`
+	Difference  *int64 `json:"difference,omitempty"` // counted less expected; negative when short
+
+	PendingClaims []PettyCashTransaction `json:"pending_claims"`
+}
+
+// NewPettyCashReconciliation reconciles the fund with its pending claims dated
+// through asOf and the cash counted, if any
+func NewPettyCashReconciliation(fund *PettyCashFund, pending []PettyCashTransaction, asOf time.Time, bookBalance float64, counted *int64) *PettyCashReconciliation {
+	rec := &PettyCashReconciliation{
+		FundID:        fund.ID,
+		FundName:      fund.Name,
+		AsOf:          asOf,
+		FloatAmount:   fund.FloatAmount,
+		BookBalance:   bookBalance,
+		PendingClaims: []PettyCashTransaction{},
+	}
+	for _, claim := range pending {
+		if claim.Status != PettyCashClaimPending || claim.TransactionDate.After(asOf) {
+			continue
+		}
+		rec.PendingClaims = append(rec.PendingClaims, claim)
+		rec.PendingCount++
+		rec.PendingAmount += claim.Amount
+	}
+	rec.CashOnHand = rec.FloatAmount - rec.PendingAmount
+	if counted != nil {
+		difference := *counted - rec.CashOnHand
+		rec.CountedCash = counted
+		rec.Difference = &difference
+	}
+	return rec
+}
@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestNewPettyCashReplenishment(t *testing.T) {
+	fund := &domain.PettyCashFund{FloatAmount: 500000, BankAccountID: uuid.New()}
+	fund.ID = uuid.New()
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC) }
+	claim := func(date time.Time, amount int64, status domain.PettyCashClaimStatus) domain.PettyCashTransaction {
+		c := domain.PettyCashTransaction{FundID: fund.ID, TransactionDate: date, Amount: amount, Status: status}
+		c.ID = uuid.New()
+		return c
+	}
+	claims := []domain.PettyCashTransaction{
+		claim(day(3), 12000, domain.PettyCashClaimPending),
+		claim(day(8), 45000, domain.PettyCashClaimPending),
+		claim(day(2), 30000, domain.PettyCashClaimReplenished),
+		claim(day(16), 8000, domain.PettyCashClaimPending),
+	}
+
+	replenishment, err := domain.NewPettyCashReplenishment(fund, claims, day(16), day(15))
+	require.NoError(t, err)
+	assert.Equal(t, 2, replenishment.ClaimCount)
+	assert.Equal(t, int64(57000), replenishment.Amount)
+	assert.Equal(t, fund.BankAccountID, replenishment.BankAccountID)
+	assert.Equal(t, day(15), replenishment.ClaimsThrough)
+
+	_, err = domain.NewPettyCashReplenishment(fund, claims, day(2), day(2))
+	assert.ErrorIs(t, err, domain.ErrNoPettyCashClaims)
+}
+
+func TestNewPettyCashReconciliation(t *testing.T) {
+	fund := &domain.PettyCashFund{Name: "영업팀 소액현금", FloatAmount: 300000}
+	fund.ID = uuid.New()
+	asOf := time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC)
+	pending := []domain.PettyCashTransaction{
+		{TransactionDate: asOf.AddDate(0, 0, -3), Amount: 22000, Status: domain.PettyCashClaimPending},
+		{TransactionDate: asOf, Amount: 18000, Status: domain.PettyCashClaimPending},
+		{TransactionDate: asOf.AddDate(0, 0, 1), Amount: 5000, Status: domain.PettyCashClaimPending},
+	}
+
+	counted := int64(255000)
+	rec := domain.NewPettyCashReconciliation(fund, pending, asOf, 300000, &counted)
+	assert.Equal(t, 2, rec.PendingCount)
+	assert.Equal(t, int64(40000), rec.PendingAmount)
+	assert.Equal(t, int64(260000), rec.CashOnHand)
+	require.NotNil(t, rec.Difference)
+	assert.Equal(t, int64(-5000), *rec.Difference)
+
+	uncounted := domain.NewPettyCashReconciliation(fund, nil, asOf, 300000, nil)
+	assert.Equal(t, fund.FloatAmount, uncounted.CashOnHand)
+	assert.Nil(t, uncounted.Difference)
+	assert.Empty(t, uncounted.PendingClaims)
+}
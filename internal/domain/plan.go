@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Plan errors
+var (
+	ErrPlanNotFound      = errors.New("subscription plan not found")
+	ErrPlanLimitExceeded = errors.New("subscription plan limit exceeded")
+)
+
+// PlanLimit is a limited resource of a subscription plan
+type PlanLimit string
+
+const (
+	PlanLimitUsers    PlanLimit = "users"    // active members
+	PlanLimitVouchers PlanLimit = "vouchers" // vouchers created per month
+	PlanLimitStorage  PlanLimit = "storage"  // bytes of attachments and library files
+)
+
+// PlanLimits lists the plan limits in display order
+var PlanLimits = []PlanLimit{PlanLimitUsers, PlanLimitVouchers, PlanLimitStorage}
+
+// LocalizedLabel returns the limit label in the locale
+func (l PlanLimit) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "plan_limit", string(l)); label != "" {
+		return label
+	}
+	return string(l)
+}
+
+// Plan is a subscription plan. A nil limit is unlimited.
+type Plan struct {
+	Code                string    `gorm:"type:varchar(30);primary_key" json:"code"`
+	Name                string    `gorm:"type:varchar(100);not null" json:"name"`
+	MaxUsers            *int64    `json:"max_users"`
+	MaxVouchersPerMonth *int64    `json:"max_vouchers_per_month"`
+	MaxStorageBytes     *int64    `json:"max_storage_bytes"`
+	UpgradeTo           *string   `gorm:"type:varchar(30)" json:"upgrade_to,omitempty"` // the next plan up, suggested when a limit is reached
+	SortOrder           int       `gorm:"not null;default:0" json:"sort_order"`
+	IsActive            bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt           time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt           time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for Plan
+func (Plan) TableName() string {
+	return "plans"
+}
+
+// Max returns the plan's limit of the resource, or nil when it is unlimited
+func (p *Plan) Max(limit PlanLimit) *int64 {
+	switch limit {
+	case PlanLimitUsers:
+		return p.MaxUsers
+	case PlanLimitVouchers:
+		return p.MaxVouchersPerMonth
+	case PlanLimitStorage:
+		return p.MaxStorageBytes
+	}
+	return nil
+}
+
+// CompanyPlan is the plan of a company. After a plan change the previous
+// plan is kept, so that its limits can apply during the grace period.
+type CompanyPlan struct {
+	Plan          *Plan
+	PreviousPlan  *Plan // nil when the plan was never changed
+	PlanChangedAt *time.Time
+}
+
+// EffectiveMax returns the limit of the resource. Within grace of a plan
+// change, the higher limit of the previous and the current plan applies so
+// that a downgrade does not block companies already over the new limits.
+func (c *CompanyPlan) EffectiveMax(limit PlanLimit, grace time.Duration, now time.Time) *int64 {
+	max := c.Plan.Max(limit)
+	if c.PreviousPlan == nil || c.PlanChangedAt == nil || !now.Before(c.PlanChangedAt.Add(grace)) {
+		return max
+	}
+	previous := c.PreviousPlan.Max(limit)
+	if max == nil || previous == nil {
+		return nil
+	}
+	if *previous > *max {
+		return previous
+	}
+	return max
+}
+
+// PlanUsage is a company's usage of a limited resource
+type PlanUsage struct {
+	Limit   PlanLimit
+	Current int64
+	Max     *int64 // the effective limit, nil when unlimited
+}
+
+// PlanLimitError is returned when an operation would take the company's
+// usage of a resource over its plan's limit
+type PlanLimitError struct {
+	Limit     PlanLimit
+	Plan      string
+	Max       int64
+	Current   int64
+	UpgradeTo *Plan // the suggested plan, nil when there is none
+}
+
+func (e *PlanLimitError) Error() string {
+	msg := fmt.Sprintf("the %s limit of the %s plan is reached (%d of %d)", e.Limit, e.Plan, e.Current, e.Max)
+	if e.UpgradeTo != nil {
+		msg += fmt.Sprintf("; upgrade to the %s plan for more", e.UpgradeTo.Name)
+	}
+	return msg
+}
+
+// Unwrap makes the error match ErrPlanLimitExceeded
+func (e *PlanLimitError) Unwrap() error {
+	return ErrPlanLimitExceeded
+}
+
+// CheckPlanLimit returns a PlanLimitError when adding to the current usage
+// exceeds max plus gracePercent of it. A nil max is unlimited.
+func CheckPlanLimit(limit PlanLimit, plan string, max *int64, current, adding int64, gracePercent int) error {
+	if max == nil || adding <= 0 {
+		return nil
+	}
+	allowed := *max + *max*int64(gracePercent)/100
+	if current+adding <= allowed {
+		return nil
+	}
+	return &PlanLimitError{Limit: limit, Plan: plan, Max: *max, Current: current}
+}
@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Subscription Plan Tests
+// ============================================================================
+
+func limitOf(n int64) *int64 { return &n }
+
+func TestCheckPlanLimit(t *testing.T) {
+	// Unlimited
+	assert.NoError(t, domain.CheckPlanLimit(domain.PlanLimitUsers, "enterprise", nil, 1000, 1, 0))
+
+	// Up to the limit
+	assert.NoError(t, domain.CheckPlanLimit(domain.PlanLimitUsers, "free", limitOf(3), 2, 1, 0))
+
+	err := domain.CheckPlanLimit(domain.PlanLimitUsers, "free", limitOf(3), 3, 1, 0)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrPlanLimitExceeded))
+	var limitErr *domain.PlanLimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, domain.PlanLimitUsers, limitErr.Limit)
+	assert.Equal(t, "free", limitErr.Plan)
+	assert.Equal(t, int64(3), limitErr.Max)
+	assert.Equal(t, int64(3), limitErr.Current)
+
+	// 10% grace over 100 vouchers allows 110
+	assert.NoError(t, domain.CheckPlanLimit(domain.PlanLimitVouchers, "free", limitOf(100), 109, 1, 10))
+	assert.Error(t, domain.CheckPlanLimit(domain.PlanLimitVouchers, "free", limitOf(100), 110, 1, 10))
+
+	// Storage counts the bytes added
+	assert.Error(t, domain.CheckPlanLimit(domain.PlanLimitStorage, "free", limitOf(1000), 900, 200, 0))
+}
+
+func TestPlanLimitError_UpgradeHint(t *testing.T) {
+	err := &domain.PlanLimitError{Limit: domain.PlanLimitUsers, Plan: "free", Max: 3, Current: 3}
+	assert.NotContains(t, err.Error(), "upgrade")
+
+	err.UpgradeTo = &domain.Plan{Code: "starter", Name: "Starter"}
+	assert.Contains(t, err.Error(), "upgrade to the Starter plan")
+}
+
+func TestCompanyPlan_EffectiveMax(t *testing.T) {
+	free := &domain.Plan{Code: "free", MaxUsers: limitOf(3)}
+	starter := &domain.Plan{Code: "starter", MaxUsers: limitOf(10)}
+	enterprise := &domain.Plan{Code: "enterprise"}
+	changed := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	grace := 30 * 24 * time.Hour
+
+	// Never changed
+	plan := &domain.CompanyPlan{Plan: free}
+	assert.Equal(t, int64(3), *plan.EffectiveMax(domain.PlanLimitUsers, grace, changed))
+
+	// Downgraded: the higher limit applies during the grace period only
+	plan = &domain.CompanyPlan{Plan: free, PreviousPlan: starter, PlanChangedAt: &changed}
+	assert.Equal(t, int64(10), *plan.EffectiveMax(domain.PlanLimitUsers, grace, changed.Add(time.Hour)))
+	assert.Equal(t, int64(3), *plan.EffectiveMax(domain.PlanLimitUsers, grace, changed.Add(grace)))
+
+	// Upgraded: the new limit is already the higher one
+	plan = &domain.CompanyPlan{Plan: starter, PreviousPlan: free, PlanChangedAt: &changed}
+	assert.Equal(t, int64(10), *plan.EffectiveMax(domain.PlanLimitUsers, grace, changed.Add(time.Hour)))
+
+	// Downgraded from unlimited
+	plan = &domain.CompanyPlan{Plan: free, PreviousPlan: enterprise, PlanChangedAt: &changed}
+	assert.Nil(t, plan.EffectiveMax(domain.PlanLimitUsers, grace, changed.Add(time.Hour)))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Popbill webhook errors
+var (
+	ErrPopbillWebhookDisabled  = errors.New("popbill webhook is not configured")
+	ErrPopbillWebhookDuplicate = errors.New("popbill webhook event was already received")
+)
+
+// PopbillWebhookStatus represents the processing state of a received callback
+type PopbillWebhookStatus string
+
+const (
+	PopbillWebhookStatusReceived   PopbillWebhookStatus = "received"
+	PopbillWebhookStatusProcessing PopbillWebhookStatus = "processing"
+	PopbillWebhookStatusProcessed  PopbillWebhookStatus = "processed"
+	PopbillWebhookStatusIgnored    PopbillWebhookStatus = "ignored" // no matching invoice or nothing to change
+	PopbillWebhookStatusFailed     PopbillWebhookStatus = "failed"
+)
+
+// IsValid checks if the webhook status is valid
+func (s PopbillWebhookStatus) IsValid() bool {
+	switch s {
+	case PopbillWebhookStatusReceived, PopbillWebhookStatusProcessing, PopbillWebhookStatusProcessed,
+		PopbillWebhookStatusIgnored, PopbillWebhookStatusFailed:
+		return true
+	}
+	return false
+}
+
+// PopbillWebhookMaxAttempts is the number of times a failed callback is processed
+// before it is left failed for an administrator to look at
+const PopbillWebhookMaxAttempts = 5
+
+// PopbillWebhookEvent is a callback received from Popbill. It is stored as soon as
+// its signature is verified and applied to the tax invoice by the worker; the
+// company is only known once the invoice has been found.
+type PopbillWebhookEvent struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID *uuid.UUID `gorm:"type:uuid" json:"company_id,omitempty"`
+
+	// EventKey is unique per delivery; a replayed request is rejected by it
+	EventKey  string `gorm:"type:varchar(100);not null" json:"event_key"`
+	EventType string `gorm:"type:varchar(50);not null;default:''" json:"event_type"`
+	CorpNum   string `gorm:"type:varchar(20);not null;default:''" json:"corp_num"`
+	ItemKey   string `gorm:"type:varchar(100);not null" json:"item_key"`
+	StateCode int    `gorm:"not null;default:0" json:"state_code"`
+	Payload   []byte `gorm:"type:jsonb;not null" json:"-"`
+
+	OccurredAt *time.Time `json:"occurred_at,omitempty"` // event time reported by Popbill
+
+	Status       PopbillWebhookStatus `gorm:"type:varchar(20);not null;default:received" json:"status"`
+	Attempts     int                  `gorm:"not null;default:0" json:"attempts"`
+	Error        string               `gorm:"type:text" json:"error,omitempty"`
+	TaxInvoiceID *uuid.UUID           `gorm:"type:uuid" json:"tax_invoice_id,omitempty"`
+
+	RemoteAddr  string     `gorm:"type:varchar(45);not null;default:''" json:"remote_addr"`
+	ReceivedAt  time.Time  `gorm:"not null;default:now()" json:"received_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PopbillWebhookEvent) TableName() string {
+	return "popbill_webhook_events"
+}
+
+// Finish records the outcome of processing the event. Failures are retried
+// until PopbillWebhookMaxAttempts is reached.
+func (e *PopbillWebhookEvent) Finish(status PopbillWebhookStatus, err error, at time.Time) {
+	e.Status = status
+	e.Error = ""
+	if err != nil {
+		e.Error = err.Error()
+	}
+	e.ProcessedAt = &at
+}
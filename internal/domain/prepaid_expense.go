@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Prepaid expense errors
+var (
+	ErrPrepaidExpenseNotFound    = errors.New("prepaid expense not found")
+	ErrPrepaidExpenseExists      = errors.New("expense of the voucher is already prepaid")
+	ErrInvalidPrepaidExpense     = errors.New("invalid prepaid expense")
+	ErrPrepaidExpenseVoucher     = errors.New("only posted expense vouchers can be prepaid")
+	ErrPrepaidExpenseLineChanged = errors.New("prepaid amortization was posted meanwhile")
+)
+
+// PrepaidExpenseStatus is the status of a prepaid expense
+type PrepaidExpenseStatus string
+
+const (
+	PrepaidExpenseActive    PrepaidExpenseStatus = "active"    // amortizations left to post
+	PrepaidExpenseCompleted PrepaidExpenseStatus = "completed" // the prepaid expense is fully amortized
+)
+
+// IsValid checks if the status is valid
+func (s PrepaidExpenseStatus) IsValid() bool {
+	return s == PrepaidExpenseActive || s == PrepaidExpenseCompleted
+}
+
+// PrepaidExpenseLineStatus is the status of a monthly amortization
+type PrepaidExpenseLineStatus string
+
+const (
+	PrepaidExpenseLinePending PrepaidExpenseLineStatus = "pending"
+	PrepaidExpenseLinePosted  PrepaidExpenseLineStatus = "posted"
+)
+
+// PrepaidExpense marks the expense booked by a posted voucher as prepaid
+// (선급비용) and amortizes it month by month over the period it covers, such
+// as an insurance premium or a yearly license. Creating it generates the
+// voucher moving the expense to the prepaid account; the worker posts an
+// amortization voucher at each month end once that voucher is posted.
+// Amounts are in won.
+type PrepaidExpense struct {
+	TenantModel
+
+	VoucherID   uuid.UUID  `gorm:"type:uuid;not null" json:"voucher_id"` // expense voucher
+	VoucherNo   string     `gorm:"type:varchar(20);not null" json:"voucher_no"`
+	PartnerID   *uuid.UUID `gorm:"type:uuid" json:"partner_id,omitempty"`
+	Description string     `gorm:"type:varchar(500);not null" json:"description"`
+	PeriodStart time.Time  `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd   time.Time  `gorm:"type:date;not null" json:"period_end"`
+	Amount      int64      `gorm:"not null" json:"amount"` // at most the expense debited by the voucher
+
+	// Accounts of the generated vouchers
+	ExpenseAccountID uuid.UUID `gorm:"type:uuid;not null" json:"expense_account_id"`
+	PrepaidAccountID uuid.UUID `gorm:"type:uuid;not null" json:"prepaid_account_id"` // 선급비용
+
+	PrepaymentDate      time.Time  `gorm:"type:date;not null" json:"prepayment_date"` // date of the expense voucher
+	PrepaymentVoucherID *uuid.UUID `gorm:"type:uuid" json:"prepayment_voucher_id,omitempty"`
+
+	Status          PrepaidExpenseStatus `gorm:"type:varchar(20);not null;default:active" json:"status"`
+	AmortizedAmount int64                `gorm:"not null;default:0" json:"amortized_amount"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	Lines []PrepaidExpenseLine `gorm:"foreignKey:PrepaidExpenseID" json:"lines,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PrepaidExpense) TableName() string {
+	return "prepaid_expenses"
+}
+
+// PrepaidExpenseLine is the amortization of a month of the period
+type PrepaidExpenseLine struct {
+	TenantModel
+
+	PrepaidExpenseID uuid.UUID                `gorm:"type:uuid;not null" json:"prepaid_expense_id"`
+	Seq              int                      `gorm:"not null" json:"seq"`
+	PeriodEnd        time.Time                `gorm:"type:date;not null" json:"period_end"` // month end, the date of its voucher
+	Days             int                      `gorm:"not null" json:"days"`                 // days of the period in the month
+	Amount           int64                    `gorm:"not null" json:"amount"`
+	Status           PrepaidExpenseLineStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	VoucherID        *uuid.UUID               `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	PostedAt         *time.Time               `json:"posted_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (PrepaidExpenseLine) TableName() string {
+	return "prepaid_expense_lines"
+}
+
+// Validate checks the terms of a new prepaid expense and builds its monthly
+// lines
+func (p *PrepaidExpense) Validate() error {
+	p.Description = strings.TrimSpace(p.Description)
+	switch {
+	case p.VoucherID == uuid.Nil, p.Description == "", p.Amount <= 0,
+		p.PeriodStart.IsZero(), p.PeriodEnd.Before(p.PeriodStart),
+		p.ExpenseAccountID == uuid.Nil, p.PrepaidAccountID == uuid.Nil,
+		p.ExpenseAccountID == p.PrepaidAccountID:
+		return ErrInvalidPrepaidExpense
+	}
+	p.Lines = PrepaidExpenseLines(p.Amount, p.PeriodStart, p.PeriodEnd)
+	for i := range p.Lines {
+		p.Lines[i].CompanyID = p.CompanyID
+	}
+	p.Status = PrepaidExpenseActive
+	p.AmortizedAmount = 0
+	return nil
+}
+
+// PrepaidExpenseLines spreads the amount over the months of the period by the
+// days in each month, both ends included
+func PrepaidExpenseLines(amount int64, start, end time.Time) []PrepaidExpenseLine {
+	var lines []PrepaidExpenseLine
+	for _, share := range spreadByDays(amount, start, end) {
+		lines = append(lines, PrepaidExpenseLine{
+			Seq:       len(lines) + 1,
+			PeriodEnd: share.periodEnd,
+			Days:      share.days,
+			Amount:    share.amount,
+			Status:    PrepaidExpenseLinePending,
+		})
+	}
+	return lines
+}
+
+// RemainingBalance returns the prepaid expense not amortized yet
+func (p *PrepaidExpense) RemainingBalance() int64 {
+	return p.Amount - p.AmortizedAmount
+}
+
+// DueLines returns the pending lines of months ending on or before periodEnd
+func (p *PrepaidExpense) DueLines(periodEnd time.Time) []*PrepaidExpenseLine {
+	var lines []*PrepaidExpenseLine
+	for i := range p.Lines {
+		if p.Lines[i].Status == PrepaidExpenseLinePending && !p.Lines[i].PeriodEnd.After(periodEnd) {
+			lines = append(lines, &p.Lines[i])
+		}
+	}
+	return lines
+}
+
+// Amortize marks the line posted by the voucher, nil for a line rounded to
+// nothing, and adds it to the amortized amount; the prepaid expense is
+// completed with its last line
+func (p *PrepaidExpense) Amortize(line *PrepaidExpenseLine, voucherID *uuid.UUID, at time.Time) {
+	line.Status = PrepaidExpenseLinePosted
+	line.VoucherID = voucherID
+	line.PostedAt = &at
+	p.AmortizedAmount += line.Amount
+	if p.AmortizedAmount == p.Amount {
+		p.Status = PrepaidExpenseCompleted
+	}
+}
+
+// PrepaidAmortizationRun summarizes a month-end prepaid expense amortization run
+type PrepaidAmortizationRun struct {
+	PeriodEnd time.Time   `json:"period_end"`
+	Checked   int         `json:"checked"`   // prepaid expenses with amortizations due
+	Waiting   int         `json:"waiting"`   // prepaid expenses whose prepayment voucher is not posted yet
+	Amortized int         `json:"amortized"` // monthly amortizations posted
+	Vouchers  []uuid.UUID `json:"vouchers"`  // posted amortization vouchers
+}
+
+// PrepaidExpenseBalanceRow is the remaining balance of a prepaid expense at a date
+type PrepaidExpenseBalanceRow struct {
+	PrepaidExpenseID uuid.UUID  `json:"prepaid_expense_id"`
+	VoucherNo        string     `json:"voucher_no"`
+	Description      string     `json:"description"`
+	PartnerID        *uuid.UUID `json:"partner_id,omitempty"`
+	PrepaidAccountID uuid.UUID  `json:"prepaid_account_id"`
+	PeriodStart      time.Time  `json:"period_start"`
+	PeriodEnd        time.Time  `json:"period_end"`
+	Amount           int64      `json:"amount"`
+	Amortized        int64      `json:"amortized"`                   // posted through the date
+	Balance          int64      `json:"balance"`                     // prepaid at the date
+	Due              int64      `json:"due"`                         // months ended by the date but not posted yet
+	RemainingMonths  int        `json:"remaining_months"`            // months left to amortize after the date
+	NextAmortization *time.Time `json:"next_amortization,omitempty"` // month end of the next pending line
+}
+
+// PrepaidExpenseBalanceReport lists the prepaid expenses with a balance at a date
+type PrepaidExpenseBalanceReport struct {
+	AsOf   time.Time                  `json:"as_of"`
+	Rows   []PrepaidExpenseBalanceRow `json:"rows"`
+	Totals PrepaidExpenseBalanceRow   `json:"totals"`
+}
+
+// NewPrepaidExpenseBalanceReport builds the remaining balances of the prepaid
+// expenses at asOf. Posted amortizations count at the month end they are
+// dated; prepaid expenses booked after the date or fully amortized by it are
+// left out.
+func NewPrepaidExpenseBalanceReport(expenses []PrepaidExpense, asOf time.Time) *PrepaidExpenseBalanceReport {
+	report := &PrepaidExpenseBalanceReport{AsOf: asOf, Rows: []PrepaidExpenseBalanceRow{}}
+	for _, p := range expenses {
+		if p.PrepaymentDate.After(asOf) {
+			continue
+		}
+		row := PrepaidExpenseBalanceRow{
+			PrepaidExpenseID: p.ID,
+			VoucherNo:        p.VoucherNo,
+			Description:      p.Description,
+			PartnerID:        p.PartnerID,
+			PrepaidAccountID: p.PrepaidAccountID,
+			PeriodStart:      p.PeriodStart,
+			PeriodEnd:        p.PeriodEnd,
+			Amount:           p.Amount,
+		}
+		for _, line := range p.Lines {
+			switch {
+			case line.Status == PrepaidExpenseLinePosted && !line.PeriodEnd.After(asOf):
+				row.Amortized += line.Amount
+			case !line.PeriodEnd.After(asOf):
+				row.Due += line.Amount
+			default:
+				row.RemainingMonths++
+			}
+			if line.Status == PrepaidExpenseLinePending && row.NextAmortization == nil {
+				periodEnd := line.PeriodEnd
+				row.NextAmortization = &periodEnd
+			}
+		}
+		row.Balance = row.Amount - row.Amortized
+		if row.Balance == 0 {
+			continue
+		}
+
+		report.Rows = append(report.Rows, row)
+		report.Totals.Amount += row.Amount
+		report.Totals.Amortized += row.Amortized
+		report.Totals.Balance += row.Balance
+		report.Totals.Due += row.Due
+	}
+	return report
+}
@@ -0,0 +1,97 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func newPrepaidExpense() *domain.PrepaidExpense {
+	return &domain.PrepaidExpense{
+		VoucherID:        uuid.New(),
+		VoucherNo:        "GJ-2026-0042",
+		Description:      " 화재보험료 ",
+		PeriodStart:      date(2026, 1, 1),
+		PeriodEnd:        date(2026, 12, 31),
+		Amount:           3650000,
+		ExpenseAccountID: uuid.New(),
+		PrepaidAccountID: uuid.New(),
+		PrepaymentDate:   date(2026, 1, 1),
+	}
+}
+
+func TestPrepaidExpense_Validate(t *testing.T) {
+	expense := newPrepaidExpense()
+	require.NoError(t, expense.Validate())
+	assert.Equal(t, "화재보험료", expense.Description)
+	assert.Equal(t, domain.PrepaidExpenseActive, expense.Status)
+	require.Len(t, expense.Lines, 12)
+
+	// 10,000 a day: each month is amortized by its days
+	var total int64
+	for _, line := range expense.Lines {
+		assert.Equal(t, int64(line.Days)*10000, line.Amount)
+		total += line.Amount
+	}
+	assert.Equal(t, expense.Amount, total)
+
+	sameAccount := newPrepaidExpense()
+	sameAccount.PrepaidAccountID = sameAccount.ExpenseAccountID
+	assert.ErrorIs(t, sameAccount.Validate(), domain.ErrInvalidPrepaidExpense)
+
+	noAmount := newPrepaidExpense()
+	noAmount.Amount = 0
+	assert.ErrorIs(t, noAmount.Validate(), domain.ErrInvalidPrepaidExpense)
+}
+
+func TestPrepaidExpense_Amortize(t *testing.T) {
+	expense := newPrepaidExpense()
+	require.NoError(t, expense.Validate())
+
+	due := expense.DueLines(date(2026, 3, 31))
+	require.Len(t, due, 3)
+	for _, line := range due {
+		voucherID := uuid.New()
+		expense.Amortize(line, &voucherID, time.Now())
+	}
+	assert.Equal(t, int64(900000), expense.AmortizedAmount)
+	assert.Equal(t, int64(2750000), expense.RemainingBalance())
+	assert.Equal(t, domain.PrepaidExpenseActive, expense.Status)
+
+	for _, line := range expense.DueLines(date(2026, 12, 31)) {
+		expense.Amortize(line, nil, time.Now())
+	}
+	assert.Equal(t, domain.PrepaidExpenseCompleted, expense.Status)
+	assert.Zero(t, expense.RemainingBalance())
+}
+
+func TestNewPrepaidExpenseBalanceReport(t *testing.T) {
+	expense := newPrepaidExpense()
+	require.NoError(t, expense.Validate())
+	expense.Amortize(&expense.Lines[0], nil, time.Now())
+
+	amortized := newPrepaidExpense()
+	amortized.PeriodEnd = date(2026, 2, 28)
+	require.NoError(t, amortized.Validate())
+	for _, line := range amortized.DueLines(date(2026, 2, 28)) {
+		amortized.Amortize(line, nil, time.Now())
+	}
+
+	report := domain.NewPrepaidExpenseBalanceReport([]domain.PrepaidExpense{*expense, *amortized}, date(2026, 2, 28))
+	// The expense amortized by the date is left out
+	require.Len(t, report.Rows, 1)
+
+	row := report.Rows[0]
+	assert.Equal(t, int64(310000), row.Amortized)
+	assert.Equal(t, int64(3340000), row.Balance)
+	assert.Equal(t, int64(280000), row.Due) // February is not posted yet
+	assert.Equal(t, 10, row.RemainingMonths)
+	require.NotNil(t, row.NextAmortization)
+	assert.Equal(t, date(2026, 2, 28), *row.NextAmortization)
+	assert.Equal(t, row.Balance, report.Totals.Balance)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Push device errors
+var (
+	ErrInvalidPushPlatform = errors.New("invalid push platform")
+	ErrPushTokenRequired   = errors.New("push token is required")
+)
+
+// PushPlatform is the push service a device token belongs to
+type PushPlatform string
+
+const (
+	PushPlatformFCM  PushPlatform = "fcm"  // Firebase Cloud Messaging (Android, web)
+	PushPlatformAPNs PushPlatform = "apns" // Apple Push Notification service (iOS)
+)
+
+// IsValid checks if the push platform is valid
+func (p PushPlatform) IsValid() bool {
+	return p == PushPlatformFCM || p == PushPlatformAPNs
+}
+
+// PushDevice is a mobile device of a user registered for push notifications.
+// A token identifies one app installation; registering it again in the
+// company, also for another user, replaces its registration.
+type PushDevice struct {
+	TenantModel
+
+	UserID     uuid.UUID    `gorm:"type:uuid;not null;index" json:"user_id"`
+	Platform   PushPlatform `gorm:"type:varchar(10);not null" json:"platform"`
+	Token      string       `gorm:"type:varchar(512);not null" json:"-"`
+	DeviceName string       `gorm:"type:varchar(100)" json:"device_name,omitempty"`
+	LastSeenAt time.Time    `gorm:"not null" json:"last_seen_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PushDevice) TableName() string {
+	return "push_devices"
+}
+
+// NewPushDevice creates a device registration of the user
+func NewPushDevice(companyID, userID uuid.UUID, platform PushPlatform, token, deviceName string) (*PushDevice, error) {
+	if !platform.IsValid() {
+		return nil, ErrInvalidPushPlatform
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrPushTokenRequired
+	}
+	return &PushDevice{
+		TenantModel: TenantModel{CompanyID: companyID},
+		UserID:      userID,
+		Platform:    platform,
+		Token:       token,
+		DeviceName:  strings.TrimSpace(deviceName),
+		LastSeenAt:  time.Now(),
+	}, nil
+}
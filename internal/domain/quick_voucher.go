@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Quick entry errors
+var (
+	ErrUnknownEntryCode    = errors.New("unknown code")
+	ErrBalancingEntryCount = errors.New("only one entry may omit its amount")
+	ErrBalancingEntrySide  = errors.New("balancing entry is on the wrong side")
+)
+
+// QuickEntry is a voucher entry row as typed on the keyboard: the account,
+// partner, department, project and tax code by code and the amount on one
+// side. The amount of one row may be left out; it is the difference that
+// balances the voucher.
+type QuickEntry struct {
+	AccountCode    string
+	Side           AccountNature
+	Amount         float64 // VAT-inclusive with a tax code
+	Description    string
+	PartnerCode    string
+	DepartmentCode string
+	ProjectCode    string
+	TaxCode        string
+}
+
+// UnknownEntryCode is a code of a quick entry that matches nothing
+type UnknownEntryCode struct {
+	LineNo int    `json:"line_no"`
+	Field  string `json:"field"` // account, partner, department, project or tax_code
+	Code   string `json:"code"`
+}
+
+// UnknownCodesError reports all the codes of quick entries that match nothing
+type UnknownCodesError struct {
+	Codes []UnknownEntryCode
+}
+
+// Error implements the error interface
+func (e *UnknownCodesError) Error() string {
+	codes := make([]string, len(e.Codes))
+	for i, c := range e.Codes {
+		codes[i] = fmt.Sprintf("line %d %s %s", c.LineNo, c.Field, c.Code)
+	}
+	return "unknown codes: " + strings.Join(codes, ", ")
+}
+
+// Unwrap makes the error match ErrUnknownEntryCode
+func (e *UnknownCodesError) Unwrap() error {
+	return ErrUnknownEntryCode
+}
+
+// BalanceQuickEntries fills in the amount of the entry typed without one
+// with the difference of the others, which must fall on its side
+func BalanceQuickEntries(entries []QuickEntry) error {
+	balancing := -1
+	balance := 0.0 // debits less credits
+	for i, e := range entries {
+		switch {
+		case e.Amount == 0 && balancing >= 0:
+			return ErrBalancingEntryCount
+		case e.Amount == 0:
+			balancing = i
+		case e.Side == AccountNatureDebit:
+			balance += e.Amount
+		default:
+			balance -= e.Amount
+		}
+	}
+	if balancing < 0 {
+		return nil
+	}
+
+	amount := roundAmount(balance)
+	if entries[balancing].Side == AccountNatureDebit {
+		amount = -amount
+	}
+	if amount <= 0 {
+		return ErrBalancingEntrySide
+	}
+	entries[balancing].Amount = amount
+	return nil
+}
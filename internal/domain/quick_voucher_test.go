@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestBalanceQuickEntries(t *testing.T) {
+	entries := []domain.QuickEntry{
+		{AccountCode: "813100", Side: domain.AccountNatureDebit, Amount: 33000},
+		{AccountCode: "813200", Side: domain.AccountNatureDebit, Amount: 12000.5},
+		{AccountCode: "101000", Side: domain.AccountNatureCredit},
+	}
+	require.NoError(t, domain.BalanceQuickEntries(entries))
+	assert.Equal(t, 45000.5, entries[2].Amount)
+
+	// The balancing entry must take the difference on its side
+	entries[2] = domain.QuickEntry{AccountCode: "101000", Side: domain.AccountNatureDebit}
+	assert.ErrorIs(t, domain.BalanceQuickEntries(entries), domain.ErrBalancingEntrySide)
+
+	entries[1].Amount = 0
+	assert.ErrorIs(t, domain.BalanceQuickEntries(entries), domain.ErrBalancingEntryCount)
+}
+
+func TestUnknownCodesError(t *testing.T) {
+	err := &domain.UnknownCodesError{Codes: []domain.UnknownEntryCode{
+		{LineNo: 1, Field: "account", Code: "9999"}, {LineNo: 2, Field: "partner", Code: "P01"},
+	}}
+	assert.Equal(t, "unknown codes: line 1 account 9999, line 2 partner P01", err.Error())
+	assert.ErrorIs(t, err, domain.ErrUnknownEntryCode)
+}
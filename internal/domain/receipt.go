@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Receipt errors
+var (
+	ErrReceiptAmountMissing = errors.New("receipt total amount could not be recognized")
+	ErrReceiptImageTooLarge = errors.New("receipt image is too large")
+	ErrReceiptTaxCodeType   = errors.New("receipt requires an input (purchase) tax code")
+)
+
+// ReceiptSuggestion represents a voucher proposed from a recognized receipt.
+// It is not persisted; the client reviews it and submits it as a regular voucher.
+type ReceiptSuggestion struct {
+	// Recognized receipt fields
+	TransactionDate      *time.Time `json:"transaction_date,omitempty"`
+	VendorName           string     `json:"vendor_name,omitempty"`
+	VendorBusinessNumber string     `json:"vendor_business_number,omitempty"`
+	TotalAmount          float64    `json:"total_amount"`
+	SupplyAmount         float64    `json:"supply_amount"`
+	TaxAmount            float64    `json:"tax_amount"`
+	Confidence           float64    `json:"confidence"`
+
+	// Suggested voucher
+	VoucherDate time.Time      `json:"voucher_date"`
+	VoucherType VoucherType    `json:"voucher_type"`
+	Description string         `json:"description"`
+	PartnerID   *uuid.UUID     `json:"partner_id,omitempty"`
+	TaxCodeID   *uuid.UUID     `json:"tax_code_id,omitempty"`
+	Entries     []VoucherEntry `json:"entries"`
+
+	// Items the user should double-check before saving
+	Warnings []string `json:"warnings,omitempty"`
+}
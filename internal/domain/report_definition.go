@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Report definition errors
+var (
+	ErrReportDefinitionNotFound     = errors.New("report definition not found")
+	ErrReportDefinitionNameRequired = errors.New("report definition name is required")
+	ErrReportColumnsRequired        = errors.New("at least one report column is required")
+	ErrInvalidReportColumn          = errors.New("invalid report column")
+	ErrInvalidReportDimension       = errors.New("invalid report grouping")
+	ErrDuplicateReportDimension     = errors.New("duplicate report grouping")
+	ErrTooManyReportDimensions      = errors.New("too many report groupings")
+	ErrInvalidAccountRange          = errors.New("invalid account code range")
+	ErrInvalidReportDateRange       = errors.New("invalid report date range")
+)
+
+// MaxReportDimensions is the maximum number of groupings in a report definition
+const MaxReportDimensions = 3
+
+// ReportAmountColumn is an amount column of a custom report
+type ReportAmountColumn string
+
+const (
+	ReportColumnOpening ReportAmountColumn = "opening" // 기초잔액
+	ReportColumnDebit   ReportAmountColumn = "debit"   // 차변
+	ReportColumnCredit  ReportAmountColumn = "credit"  // 대변
+	ReportColumnClosing ReportAmountColumn = "closing" // 기말잔액
+)
+
+// IsValid checks if the column is valid
+func (c ReportAmountColumn) IsValid() bool {
+	switch c {
+	case ReportColumnOpening, ReportColumnDebit, ReportColumnCredit, ReportColumnClosing:
+		return true
+	}
+	return false
+}
+
+// Label returns the Korean column label
+func (c ReportAmountColumn) Label() string {
+	return c.LocalizedLabel(i18n.Korean)
+}
+
+// LocalizedLabel returns the column label in the locale
+func (c ReportAmountColumn) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "report_column", string(c)); label != "" {
+		return label
+	}
+	return string(c)
+}
+
+// ReportDimension is a voucher entry dimension a custom report can be grouped by
+type ReportDimension string
+
+const (
+	ReportDimensionDepartment ReportDimension = "department"
+	ReportDimensionPartner    ReportDimension = "partner"
+	ReportDimensionProject    ReportDimension = "project"
+)
+
+// IsValid checks if the dimension is valid
+func (d ReportDimension) IsValid() bool {
+	switch d {
+	case ReportDimensionDepartment, ReportDimensionPartner, ReportDimensionProject:
+		return true
+	}
+	return false
+}
+
+// Label returns the Korean dimension label
+func (d ReportDimension) Label() string {
+	return d.LocalizedLabel(i18n.Korean)
+}
+
+// LocalizedLabel returns the dimension label in the locale
+func (d ReportDimension) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "report_dimension", string(d)); label != "" {
+		return label
+	}
+	return string(d)
+}
+
+// AccountCodeRange selects accounts whose code lies between From and To (inclusive)
+type AccountCodeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ReportSpec is the compiled form of a custom report: which accounts, columns and groupings
+type ReportSpec struct {
+	// Account selection; an account matches if it is in any range and of any listed type.
+	// Empty lists select all accounts.
+	AccountRanges []AccountCodeRange `json:"account_ranges,omitempty"`
+	AccountTypes  []AccountType      `json:"account_types,omitempty"`
+
+	// Tags select the entries of vouchers carrying all of them, for ad-hoc
+	// analyses such as an audit adjustment or a subsidy
+	Tags []string `json:"tags,omitempty"`
+
+	Columns []ReportAmountColumn `json:"columns"`
+	GroupBy []ReportDimension    `json:"group_by,omitempty"`
+
+	// Default period when no explicit dates are given at run time
+	Period ReportPeriod `json:"period"`
+
+	// NaturalSign shows credit-nature accounts as positive balances
+	NaturalSign bool `json:"natural_sign"`
+	// IncludeZero keeps rows whose amounts are all zero
+	IncludeZero bool `json:"include_zero"`
+}
+
+// Validate validates the spec
+func (s *ReportSpec) Validate() error {
+	for _, r := range s.AccountRanges {
+		if r.From == "" || r.To == "" || r.From > r.To {
+			return ErrInvalidAccountRange
+		}
+	}
+	for _, t := range s.AccountTypes {
+		if !t.IsValid() {
+			return ErrInvalidAccountType
+		}
+	}
+	if len(s.Tags) > 0 {
+		tags, err := NormalizeVoucherTags(s.Tags)
+		if err != nil {
+			return err
+		}
+		s.Tags = tags
+	}
+	if len(s.Columns) == 0 {
+		return ErrReportColumnsRequired
+	}
+	for _, c := range s.Columns {
+		if !c.IsValid() {
+			return ErrInvalidReportColumn
+		}
+	}
+	if len(s.GroupBy) > MaxReportDimensions {
+		return ErrTooManyReportDimensions
+	}
+	seen := make(map[ReportDimension]bool)
+	for _, d := range s.GroupBy {
+		if !d.IsValid() {
+			return ErrInvalidReportDimension
+		}
+		if seen[d] {
+			return ErrDuplicateReportDimension
+		}
+		seen[d] = true
+	}
+	if !s.Period.IsValid() {
+		return ErrInvalidReportPeriod
+	}
+	return nil
+}
+
+// ReportDefinition is a saved custom report over ledger data
+type ReportDefinition struct {
+	TenantModel
+
+	Name        string     `gorm:"type:varchar(100);not null" json:"name"`
+	Description string     `gorm:"type:varchar(500)" json:"description,omitempty"`
+	Spec        ReportSpec `gorm:"type:jsonb;serializer:json" json:"spec"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (ReportDefinition) TableName() string {
+	return "report_definitions"
+}
+
+// Validate validates the definition
+func (d *ReportDefinition) Validate() error {
+	if d.Name == "" {
+		return ErrReportDefinitionNameRequired
+	}
+	return d.Spec.Validate()
+}
+
+// ReportQuery is a spec bound to a concrete date range, ready to be run
+type ReportQuery struct {
+	Spec     ReportSpec
+	FromDate time.Time
+	ToDate   time.Time
+
+	// YearStart is the start of the fiscal year containing FromDate;
+	// revenue and expense openings only accumulate from this date
+	YearStart time.Time
+}
+
+// ReportDimensionValue is the value of one grouping on a result row
+type ReportDimensionValue struct {
+	ID   *uuid.UUID `json:"id,omitempty"`
+	Code string     `json:"code,omitempty"`
+	Name string     `json:"name,omitempty"`
+}
+
+// ReportResultRow is an aggregated row of a custom report (debit-positive amounts)
+type ReportResultRow struct {
+	AccountID     uuid.UUID              `json:"account_id"`
+	AccountCode   string                 `json:"account_code"`
+	AccountName   string                 `json:"account_name"`
+	AccountType   AccountType            `json:"account_type"`
+	AccountNature AccountNature          `json:"account_nature"`
+	Dimensions    []ReportDimensionValue `json:"dimensions,omitempty"` // in GroupBy order
+	Opening       float64                `json:"opening"`
+	Debit         float64                `json:"debit"`
+	Credit        float64                `json:"credit"`
+}
+
+// Closing returns the closing balance
+func (r *ReportResultRow) Closing() float64 {
+	return r.Opening + r.Debit - r.Credit
+}
+
+// Amount returns the value of the given column, applying the natural sign if requested
+func (r *ReportResultRow) Amount(c ReportAmountColumn, naturalSign bool) float64 {
+	var v float64
+	switch c {
+	case ReportColumnOpening:
+		v = r.Opening
+	case ReportColumnDebit:
+		return r.Debit
+	case ReportColumnCredit:
+		return r.Credit
+	case ReportColumnClosing:
+		v = r.Closing()
+	}
+	if naturalSign && r.AccountNature == AccountNatureCredit {
+		v = -v
+	}
+	return v
+}
+
+// IsZero returns true if all amounts are zero
+func (r *ReportResultRow) IsZero() bool {
+	return r.Opening == 0 && r.Debit == 0 && r.Credit == 0
+}
+
+// FiscalYearStartOf returns the first day of the fiscal year containing the date,
+// for a fiscal year beginning in startMonth (1-12)
+func FiscalYearStartOf(date time.Time, startMonth int) time.Time {
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = 1
+	}
+	year := date.Year()
+	if int(date.Month()) < startMonth {
+		year--
+	}
+	return time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+}
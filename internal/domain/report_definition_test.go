@@ -0,0 +1,66 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// ReportSpec Tests
+// ============================================================================
+
+func TestReportSpec_Validate(t *testing.T) {
+	valid := func() domain.ReportSpec {
+		return domain.ReportSpec{
+			AccountRanges: []domain.AccountCodeRange{{From: "400", To: "499"}},
+			Columns:       []domain.ReportAmountColumn{domain.ReportColumnDebit, domain.ReportColumnCredit},
+			GroupBy:       []domain.ReportDimension{domain.ReportDimensionDepartment},
+			Period:        domain.ReportPeriodCurrentMonth,
+		}
+	}
+	spec := valid()
+	assert.NoError(t, spec.Validate())
+
+	spec = valid()
+	spec.AccountRanges = []domain.AccountCodeRange{{From: "500", To: "400"}}
+	assert.ErrorIs(t, spec.Validate(), domain.ErrInvalidAccountRange)
+
+	spec = valid()
+	spec.Columns = nil
+	assert.ErrorIs(t, spec.Validate(), domain.ErrReportColumnsRequired)
+
+	spec = valid()
+	spec.GroupBy = []domain.ReportDimension{domain.ReportDimensionPartner, domain.ReportDimensionPartner}
+	assert.ErrorIs(t, spec.Validate(), domain.ErrDuplicateReportDimension)
+
+	spec = valid()
+	spec.GroupBy = []domain.ReportDimension{"cost_center"}
+	assert.ErrorIs(t, spec.Validate(), domain.ErrInvalidReportDimension)
+}
+
+func TestReportResultRow_Amount(t *testing.T) {
+	row := domain.ReportResultRow{
+		AccountNature: domain.AccountNatureCredit,
+		Opening:       -1000,
+		Debit:         200,
+		Credit:        700,
+	}
+
+	assert.Equal(t, -1500.0, row.Closing())
+	assert.Equal(t, -1500.0, row.Amount(domain.ReportColumnClosing, false))
+	assert.Equal(t, 1500.0, row.Amount(domain.ReportColumnClosing, true))
+	assert.Equal(t, 1000.0, row.Amount(domain.ReportColumnOpening, true))
+	assert.Equal(t, 200.0, row.Amount(domain.ReportColumnDebit, true))
+}
+
+func TestFiscalYearStartOf(t *testing.T) {
+	date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), domain.FiscalYearStartOf(date, 1))
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), domain.FiscalYearStartOf(date, 4))
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), domain.FiscalYearStartOf(date, 0))
+}
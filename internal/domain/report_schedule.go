@@ -0,0 +1,299 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Report schedule errors
+var (
+	ErrReportScheduleNotFound     = errors.New("report schedule not found")
+	ErrReportScheduleNameRequired = errors.New("report schedule name is required")
+	ErrInvalidReportType          = errors.New("invalid report type")
+	ErrInvalidReportFormat        = errors.New("invalid report format")
+	ErrInvalidReportPeriod        = errors.New("invalid report period")
+	ErrInvalidCronExpression      = errors.New("invalid cron expression")
+	ErrInvalidTimezone            = errors.New("invalid timezone")
+	ErrReportRecipientsRequired   = errors.New("at least one recipient is required")
+	ErrInvalidReportRecipient     = errors.New("invalid recipient email address")
+	ErrTooManyReportRecipients    = errors.New("too many recipients")
+)
+
+// MaxReportRecipients is the maximum number of recipients per schedule
+const MaxReportRecipients = 20
+
+// MaxReportScheduleFailures is the number of consecutive failures after which a schedule is paused
+const MaxReportScheduleFailures = 5
+
+// ReportType represents a report that can be generated on a schedule
+type ReportType string
+
+const (
+	ReportTypeTrialBalance    ReportType = "trial_balance"    // 합계잔액시산표
+	ReportTypeBalanceSheet    ReportType = "balance_sheet"    // 재무상태표
+	ReportTypeIncomeStatement ReportType = "income_statement" // 손익계산서
+	ReportTypeVATReturn       ReportType = "vat_return"       // 부가가치세 신고
+)
+
+// IsValid checks if the report type is valid
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypeTrialBalance, ReportTypeBalanceSheet, ReportTypeIncomeStatement, ReportTypeVATReturn:
+		return true
+	}
+	return false
+}
+
+// Label returns the Korean report title
+func (t ReportType) Label() string {
+	return t.LocalizedLabel(i18n.Korean)
+}
+
+// LocalizedLabel returns the report title in the locale
+func (t ReportType) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "report_type", string(t)); label != "" {
+		return label
+	}
+	return string(t)
+}
+
+// ReportFormat represents the export file format
+type ReportFormat string
+
+const (
+	ReportFormatCSV ReportFormat = "csv"
+	ReportFormatPDF ReportFormat = "pdf"
+)
+
+// IsValid checks if the report format is valid
+func (f ReportFormat) IsValid() bool {
+	return f == ReportFormatCSV || f == ReportFormatPDF
+}
+
+// ContentType returns the MIME type of the format
+func (f ReportFormat) ContentType() string {
+	if f == ReportFormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ReportPeriod is a reporting period relative to the run date
+type ReportPeriod string
+
+const (
+	ReportPeriodCurrentMonth    ReportPeriod = "current_month"
+	ReportPeriodPreviousMonth   ReportPeriod = "previous_month"
+	ReportPeriodPreviousQuarter ReportPeriod = "previous_quarter"
+	ReportPeriodYearToDate      ReportPeriod = "year_to_date"
+	ReportPeriodPreviousYear    ReportPeriod = "previous_year"
+)
+
+// IsValid checks if the report period is valid
+func (p ReportPeriod) IsValid() bool {
+	switch p {
+	case ReportPeriodCurrentMonth, ReportPeriodPreviousMonth, ReportPeriodPreviousQuarter,
+		ReportPeriodYearToDate, ReportPeriodPreviousYear:
+		return true
+	}
+	return false
+}
+
+// ReportRange is a resolved month range (inclusive)
+type ReportRange struct {
+	FromYear  int
+	FromMonth int
+	ToYear    int
+	ToMonth   int
+}
+
+// StartDate returns the first day of the range
+func (r ReportRange) StartDate() time.Time {
+	return time.Date(r.FromYear, time.Month(r.FromMonth), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// EndDate returns the last day of the range
+func (r ReportRange) EndDate() time.Time {
+	return time.Date(r.ToYear, time.Month(r.ToMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// Resolve returns the month range of the period as of the given date
+func (p ReportPeriod) Resolve(asOf time.Time) ReportRange {
+	year, month := asOf.Year(), int(asOf.Month())
+	switch p {
+	case ReportPeriodPreviousMonth:
+		prev := time.Date(year, time.Month(month)-1, 1, 0, 0, 0, 0, time.UTC)
+		return ReportRange{prev.Year(), int(prev.Month()), prev.Year(), int(prev.Month())}
+	case ReportPeriodPreviousQuarter:
+		start := time.Date(year, time.Month((month-1)/3*3+1), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -3, 0)
+		end := start.AddDate(0, 2, 0)
+		return ReportRange{start.Year(), int(start.Month()), end.Year(), int(end.Month())}
+	case ReportPeriodYearToDate:
+		return ReportRange{year, 1, year, month}
+	case ReportPeriodPreviousYear:
+		return ReportRange{year - 1, 1, year - 1, 12}
+	}
+	return ReportRange{year, month, year, month}
+}
+
+// ReportParams holds the parameters of a scheduled report
+type ReportParams struct {
+	Period ReportPeriod `json:"period"`
+}
+
+// ReportSchedule defines a report generated on a cron schedule and emailed to recipients
+type ReportSchedule struct {
+	TenantModel
+
+	Name       string       `gorm:"type:varchar(100);not null" json:"name"`
+	ReportType ReportType   `gorm:"type:varchar(50);not null" json:"report_type"`
+	Params     ReportParams `gorm:"type:jsonb;serializer:json" json:"params"`
+	Format     ReportFormat `gorm:"type:varchar(10);not null;default:pdf" json:"format"`
+
+	// Schedule (standard five-field cron expression, evaluated in Timezone)
+	CronExpr string `gorm:"type:varchar(100);not null" json:"cron_expr"`
+	Timezone string `gorm:"type:varchar(50);not null;default:'Asia/Seoul'" json:"timezone"`
+
+	Recipients []string `gorm:"type:jsonb;serializer:json" json:"recipients"`
+	IsActive   bool     `gorm:"default:true" json:"is_active"`
+
+	// Run state
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastStatus   string     `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	FailureCount int        `gorm:"default:0" json:"failure_count"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (ReportSchedule) TableName() string {
+	return "report_schedules"
+}
+
+// Validate validates the schedule definition (the cron expression is checked by the service)
+func (s *ReportSchedule) Validate() error {
+	if s.Name == "" {
+		return ErrReportScheduleNameRequired
+	}
+	if !s.ReportType.IsValid() {
+		return ErrInvalidReportType
+	}
+	if !s.Format.IsValid() {
+		return ErrInvalidReportFormat
+	}
+	if !s.Params.Period.IsValid() {
+		return ErrInvalidReportPeriod
+	}
+	if _, err := s.Location(); err != nil {
+		return ErrInvalidTimezone
+	}
+	if len(s.Recipients) == 0 {
+		return ErrReportRecipientsRequired
+	}
+	if len(s.Recipients) > MaxReportRecipients {
+		return ErrTooManyReportRecipients
+	}
+	for _, r := range s.Recipients {
+		addr, err := mail.ParseAddress(r)
+		if err != nil || addr.Address != r {
+			return ErrInvalidReportRecipient
+		}
+	}
+	return nil
+}
+
+// Location returns the schedule's time zone
+func (s *ReportSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// RecordSuccess updates the run state after a successful run
+func (s *ReportSchedule) RecordSuccess(at time.Time) {
+	s.LastRunAt = &at
+	s.LastStatus = string(ReportRunStatusSuccess)
+	s.FailureCount = 0
+}
+
+// RecordFailure updates the run state after a failed run and pauses the schedule
+// once it has failed MaxReportScheduleFailures times in a row. It returns true if paused.
+func (s *ReportSchedule) RecordFailure(at time.Time) bool {
+	s.LastRunAt = &at
+	s.LastStatus = string(ReportRunStatusFailed)
+	s.FailureCount++
+	if s.FailureCount >= MaxReportScheduleFailures {
+		s.IsActive = false
+		return true
+	}
+	return false
+}
+
+// ReportRunStatus represents the outcome of a schedule run
+type ReportRunStatus string
+
+const (
+	ReportRunStatusRunning ReportRunStatus = "running"
+	ReportRunStatusSuccess ReportRunStatus = "success"
+	ReportRunStatusFailed  ReportRunStatus = "failed"
+)
+
+// ReportScheduleRun is the run history of a report schedule
+type ReportScheduleRun struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()" json:"id"`
+	CompanyID  uuid.UUID       `gorm:"type:uuid;not null" json:"company_id"`
+	ScheduleID uuid.UUID       `gorm:"type:uuid;not null;index" json:"schedule_id"`
+	Status     ReportRunStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Manual     bool            `gorm:"default:false" json:"manual"`
+
+	// Resolved period
+	PeriodFrom time.Time `gorm:"type:date" json:"period_from"`
+	PeriodTo   time.Time `gorm:"type:date" json:"period_to"`
+
+	// Output
+	FileName       string `gorm:"type:varchar(200)" json:"file_name,omitempty"`
+	FileSize       int    `json:"file_size"`
+	RecipientCount int    `json:"recipient_count"`
+	Error          string `gorm:"type:text" json:"error,omitempty"`
+
+	StartedAt  time.Time  `gorm:"not null" json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (ReportScheduleRun) TableName() string {
+	return "report_schedule_runs"
+}
+
+// Finish completes the run with the given outcome
+func (r *ReportScheduleRun) Finish(err error) {
+	now := time.Now()
+	r.FinishedAt = &now
+	if err != nil {
+		r.Status = ReportRunStatusFailed
+		r.Error = err.Error()
+		return
+	}
+	r.Status = ReportRunStatusSuccess
+}
+
+// ReportColumn is a column of a generated report table
+type ReportColumn struct {
+	Label   string `json:"label"`
+	Numeric bool   `json:"numeric,omitempty"`
+}
+
+// ReportTable is a generated report in tabular form, ready for export
+type ReportTable struct {
+	Title    string         `json:"title"`
+	Subtitle string         `json:"subtitle,omitempty"`
+	Columns  []ReportColumn `json:"columns"`
+	Rows     [][]string     `json:"rows"` // numeric cells hold plain numbers (e.g., "1234.5")
+}
@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// ReportPeriod Tests
+// ============================================================================
+
+func TestReportPeriod_Resolve(t *testing.T) {
+	asOf := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		period   domain.ReportPeriod
+		expected domain.ReportRange
+	}{
+		{domain.ReportPeriodCurrentMonth, domain.ReportRange{FromYear: 2026, FromMonth: 1, ToYear: 2026, ToMonth: 1}},
+		{domain.ReportPeriodPreviousMonth, domain.ReportRange{FromYear: 2025, FromMonth: 12, ToYear: 2025, ToMonth: 12}},
+		{domain.ReportPeriodPreviousQuarter, domain.ReportRange{FromYear: 2025, FromMonth: 10, ToYear: 2025, ToMonth: 12}},
+		{domain.ReportPeriodYearToDate, domain.ReportRange{FromYear: 2026, FromMonth: 1, ToYear: 2026, ToMonth: 1}},
+		{domain.ReportPeriodPreviousYear, domain.ReportRange{FromYear: 2025, FromMonth: 1, ToYear: 2025, ToMonth: 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.period.Resolve(asOf))
+		})
+	}
+}
+
+func TestReportRange_EndDate(t *testing.T) {
+	rng := domain.ReportRange{FromYear: 2024, FromMonth: 1, ToYear: 2024, ToMonth: 2}
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), rng.EndDate())
+}
+
+// ============================================================================
+// ReportSchedule Tests
+// ============================================================================
+
+func TestReportSchedule_Validate(t *testing.T) {
+	valid := func() *domain.ReportSchedule {
+		return &domain.ReportSchedule{
+			Name:       "월간 손익계산서",
+			ReportType: domain.ReportTypeIncomeStatement,
+			Params:     domain.ReportParams{Period: domain.ReportPeriodPreviousMonth},
+			Format:     domain.ReportFormatPDF,
+			CronExpr:   "0 8 1 * *",
+			Timezone:   "Asia/Seoul",
+			Recipients: []string{"cfo@example.com"},
+		}
+	}
+	assert.NoError(t, valid().Validate())
+
+	s := valid()
+	s.Recipients = nil
+	assert.ErrorIs(t, s.Validate(), domain.ErrReportRecipientsRequired)
+
+	s = valid()
+	s.Recipients = []string{"CFO <cfo@example.com>"}
+	assert.ErrorIs(t, s.Validate(), domain.ErrInvalidReportRecipient)
+
+	s = valid()
+	s.Timezone = "Mars/Olympus"
+	assert.ErrorIs(t, s.Validate(), domain.ErrInvalidTimezone)
+}
+
+func TestReportSchedule_RecordFailure_PausesAfterLimit(t *testing.T) {
+	s := &domain.ReportSchedule{IsActive: true}
+	now := time.Now()
+
+	for i := 1; i < domain.MaxReportScheduleFailures; i++ {
+		assert.False(t, s.RecordFailure(now))
+		assert.True(t, s.IsActive)
+	}
+	assert.True(t, s.RecordFailure(now))
+	assert.False(t, s.IsActive)
+
+	s.RecordSuccess(now)
+	assert.Equal(t, 0, s.FailureCount)
+	assert.Equal(t, string(domain.ReportRunStatusSuccess), s.LastStatus)
+}
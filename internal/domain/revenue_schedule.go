@@ -0,0 +1,287 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revenue schedule errors
+var (
+	ErrRevenueScheduleNotFound    = errors.New("revenue schedule not found")
+	ErrRevenueScheduleExists      = errors.New("tax invoice already has a revenue schedule")
+	ErrInvalidRevenueSchedule     = errors.New("invalid revenue schedule")
+	ErrRevenueScheduleInvoice     = errors.New("only sales tax invoices can be deferred")
+	ErrRevenueScheduleLineChanged = errors.New("revenue recognition was posted meanwhile")
+)
+
+// RevenueScheduleStatus is the status of a revenue schedule
+type RevenueScheduleStatus string
+
+const (
+	RevenueScheduleActive    RevenueScheduleStatus = "active"    // recognitions left to post
+	RevenueScheduleCompleted RevenueScheduleStatus = "completed" // the deferred revenue is fully recognized
+)
+
+// IsValid checks if the status is valid
+func (s RevenueScheduleStatus) IsValid() bool {
+	return s == RevenueScheduleActive || s == RevenueScheduleCompleted
+}
+
+// RevenueScheduleLineStatus is the status of a monthly recognition
+type RevenueScheduleLineStatus string
+
+const (
+	RevenueScheduleLinePending RevenueScheduleLineStatus = "pending"
+	RevenueScheduleLinePosted  RevenueScheduleLineStatus = "posted"
+)
+
+// RevenueSchedule defers the revenue of a sales tax invoice covering a service
+// period (선수수익) and recognizes it month by month over the period. Creating
+// it generates the deferral voucher moving the revenue to deferred revenue;
+// the worker posts a recognition voucher at each month end once the deferral
+// is posted. Amounts are supply amounts in won.
+type RevenueSchedule struct {
+	TenantModel
+
+	TaxInvoiceID  uuid.UUID  `gorm:"type:uuid;not null" json:"tax_invoice_id"`
+	InvoiceNumber string     `gorm:"type:varchar(50);not null" json:"invoice_number"`
+	PartnerID     *uuid.UUID `gorm:"type:uuid" json:"partner_id,omitempty"`
+	Description   string     `gorm:"type:varchar(500);not null" json:"description"`
+	ServiceStart  time.Time  `gorm:"type:date;not null" json:"service_start"`
+	ServiceEnd    time.Time  `gorm:"type:date;not null" json:"service_end"`
+	Amount        int64      `gorm:"not null" json:"amount"` // deferred supply amount, at most the invoice's
+
+	// Accounts of the generated vouchers
+	RevenueAccountID         uuid.UUID `gorm:"type:uuid;not null" json:"revenue_account_id"`
+	DeferredRevenueAccountID uuid.UUID `gorm:"type:uuid;not null" json:"deferred_revenue_account_id"` // 선수수익
+
+	DeferralDate      time.Time  `gorm:"type:date;not null" json:"deferral_date"` // issue date of the invoice
+	DeferralVoucherID *uuid.UUID `gorm:"type:uuid" json:"deferral_voucher_id,omitempty"`
+
+	Status           RevenueScheduleStatus `gorm:"type:varchar(20);not null;default:active" json:"status"`
+	RecognizedAmount int64                 `gorm:"not null;default:0" json:"recognized_amount"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	Lines []RevenueScheduleLine `gorm:"foreignKey:ScheduleID" json:"lines,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (RevenueSchedule) TableName() string {
+	return "revenue_schedules"
+}
+
+// RevenueScheduleLine is the recognition of a month of the service period
+type RevenueScheduleLine struct {
+	TenantModel
+
+	ScheduleID uuid.UUID                 `gorm:"type:uuid;not null" json:"schedule_id"`
+	Seq        int                       `gorm:"not null" json:"seq"`
+	PeriodEnd  time.Time                 `gorm:"type:date;not null" json:"period_end"` // month end, the date of its voucher
+	Days       int                       `gorm:"not null" json:"days"`                 // service days in the month
+	Amount     int64                     `gorm:"not null" json:"amount"`
+	Status     RevenueScheduleLineStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	VoucherID  *uuid.UUID                `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	PostedAt   *time.Time                `json:"posted_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (RevenueScheduleLine) TableName() string {
+	return "revenue_schedule_lines"
+}
+
+// Validate checks the terms of a new schedule and builds its monthly lines
+func (s *RevenueSchedule) Validate() error {
+	s.Description = strings.TrimSpace(s.Description)
+	switch {
+	case s.TaxInvoiceID == uuid.Nil, s.Description == "", s.Amount <= 0,
+		s.ServiceStart.IsZero(), s.ServiceEnd.Before(s.ServiceStart),
+		s.RevenueAccountID == uuid.Nil, s.DeferredRevenueAccountID == uuid.Nil,
+		s.RevenueAccountID == s.DeferredRevenueAccountID:
+		return ErrInvalidRevenueSchedule
+	}
+	s.Lines = RevenueScheduleLines(s.Amount, s.ServiceStart, s.ServiceEnd)
+	for i := range s.Lines {
+		s.Lines[i].CompanyID = s.CompanyID
+	}
+	s.Status = RevenueScheduleActive
+	s.RecognizedAmount = 0
+	return nil
+}
+
+// RevenueScheduleLines spreads the amount over the months of the service
+// period by the service days in each month, both ends included
+func RevenueScheduleLines(amount int64, start, end time.Time) []RevenueScheduleLine {
+	var lines []RevenueScheduleLine
+	for _, share := range spreadByDays(amount, start, end) {
+		lines = append(lines, RevenueScheduleLine{
+			Seq:       len(lines) + 1,
+			PeriodEnd: share.periodEnd,
+			Days:      share.days,
+			Amount:    share.amount,
+			Status:    RevenueScheduleLinePending,
+		})
+	}
+	return lines
+}
+
+// monthShare is the share of a month in an amount spread over a period
+type monthShare struct {
+	periodEnd time.Time
+	days      int
+	amount    int64
+}
+
+// spreadByDays spreads the amount over the months from start to end by the
+// days in each month, both ends included. The amounts are the differences of
+// the rounded cumulative shares, so that they add up to the amount.
+func spreadByDays(amount int64, start, end time.Time) []monthShare {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	totalDays := int64(end.Sub(start).Hours()/24) + 1
+
+	var shares []monthShare
+	var elapsed, spread int64
+	for from := start; !from.After(end); {
+		monthEnd := time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		to := monthEnd
+		if to.After(end) {
+			to = end
+		}
+		days := int64(to.Sub(from).Hours()/24) + 1
+		elapsed += days
+		cumulative := (amount*elapsed*2 + totalDays) / (totalDays * 2) // rounded half up
+		shares = append(shares, monthShare{periodEnd: monthEnd, days: int(days), amount: cumulative - spread})
+		spread = cumulative
+		from = monthEnd.AddDate(0, 0, 1)
+	}
+	return shares
+}
+
+// DeferredBalance returns the deferred revenue not recognized yet
+func (s *RevenueSchedule) DeferredBalance() int64 {
+	return s.Amount - s.RecognizedAmount
+}
+
+// DueLines returns the pending lines of months ending on or before periodEnd
+func (s *RevenueSchedule) DueLines(periodEnd time.Time) []*RevenueScheduleLine {
+	var lines []*RevenueScheduleLine
+	for i := range s.Lines {
+		if s.Lines[i].Status == RevenueScheduleLinePending && !s.Lines[i].PeriodEnd.After(periodEnd) {
+			lines = append(lines, &s.Lines[i])
+		}
+	}
+	return lines
+}
+
+// Recognize marks the line posted by the voucher, nil for a line rounded to
+// nothing, and adds it to the recognized amount; the schedule is completed
+// with its last line
+func (s *RevenueSchedule) Recognize(line *RevenueScheduleLine, voucherID *uuid.UUID, at time.Time) {
+	line.Status = RevenueScheduleLinePosted
+	line.VoucherID = voucherID
+	line.PostedAt = &at
+	s.RecognizedAmount += line.Amount
+	if s.RecognizedAmount == s.Amount {
+		s.Status = RevenueScheduleCompleted
+	}
+}
+
+// RevenueRecognitionRun summarizes a month-end revenue recognition run
+type RevenueRecognitionRun struct {
+	PeriodEnd  time.Time   `json:"period_end"`
+	Checked    int         `json:"checked"`    // schedules with recognitions due
+	Waiting    int         `json:"waiting"`    // schedules whose deferral voucher is not posted yet
+	Recognized int         `json:"recognized"` // monthly recognitions posted
+	Vouchers   []uuid.UUID `json:"vouchers"`   // posted recognition vouchers
+}
+
+// DeferredRevenueWaterfallRow is a schedule of the waterfall: the deferred
+// balance at the date and the months it is recognized in
+type DeferredRevenueWaterfallRow struct {
+	ScheduleID    uuid.UUID  `json:"schedule_id"`
+	InvoiceNumber string     `json:"invoice_number"`
+	Description   string     `json:"description"`
+	PartnerID     *uuid.UUID `json:"partner_id,omitempty"`
+	ServiceStart  time.Time  `json:"service_start"`
+	ServiceEnd    time.Time  `json:"service_end"`
+	Amount        int64      `json:"amount"`
+	Recognized    int64      `json:"recognized"` // posted through the date
+	Balance       int64      `json:"balance"`    // deferred at the date
+	Due           int64      `json:"due"`        // months ended by the date but not posted yet
+	Months        []int64    `json:"months"`     // recognition of each month of the waterfall
+	Later         int64      `json:"later"`      // recognition after the last month
+}
+
+// DeferredRevenueWaterfall spreads the deferred revenue at a date over the
+// following months
+type DeferredRevenueWaterfall struct {
+	AsOf   time.Time                     `json:"as_of"`
+	Months []time.Time                   `json:"months"` // month ends
+	Rows   []DeferredRevenueWaterfallRow `json:"rows"`
+	Totals DeferredRevenueWaterfallRow   `json:"totals"`
+}
+
+// NewDeferredRevenueWaterfall builds the waterfall of the schedules at asOf
+// over the given number of months from the next day. Posted recognitions
+// count at the month end they are dated; schedules deferred after the date or
+// with nothing left deferred are left out.
+func NewDeferredRevenueWaterfall(schedules []RevenueSchedule, asOf time.Time, months int) *DeferredRevenueWaterfall {
+	w := &DeferredRevenueWaterfall{AsOf: asOf, Rows: []DeferredRevenueWaterfallRow{}}
+	// The waterfall starts with the month of the day after asOf
+	cutoff := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	first := time.Date(cutoff.Year(), cutoff.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < months; i++ {
+		w.Months = append(w.Months, first.AddDate(0, i+1, -1))
+	}
+	w.Totals.Months = make([]int64, months)
+
+	for _, s := range schedules {
+		if s.DeferralDate.After(asOf) {
+			continue
+		}
+		row := DeferredRevenueWaterfallRow{
+			ScheduleID:    s.ID,
+			InvoiceNumber: s.InvoiceNumber,
+			Description:   s.Description,
+			PartnerID:     s.PartnerID,
+			ServiceStart:  s.ServiceStart,
+			ServiceEnd:    s.ServiceEnd,
+			Amount:        s.Amount,
+			Months:        make([]int64, months),
+		}
+		for _, line := range s.Lines {
+			switch {
+			case line.Status == RevenueScheduleLinePosted && !line.PeriodEnd.After(asOf):
+				row.Recognized += line.Amount
+			case !line.PeriodEnd.After(asOf):
+				row.Due += line.Amount
+			default:
+				month := (line.PeriodEnd.Year()-first.Year())*12 + int(line.PeriodEnd.Month()-first.Month())
+				if month < months {
+					row.Months[month] += line.Amount
+				} else {
+					row.Later += line.Amount
+				}
+			}
+		}
+		row.Balance = row.Amount - row.Recognized
+		if row.Balance == 0 {
+			continue
+		}
+
+		w.Rows = append(w.Rows, row)
+		w.Totals.Amount += row.Amount
+		w.Totals.Recognized += row.Recognized
+		w.Totals.Balance += row.Balance
+		w.Totals.Due += row.Due
+		w.Totals.Later += row.Later
+		for i, amount := range row.Months {
+			w.Totals.Months[i] += amount
+		}
+	}
+	return w
+}
@@ -0,0 +1,111 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func newRevenueSchedule() *domain.RevenueSchedule {
+	return &domain.RevenueSchedule{
+		TaxInvoiceID:             uuid.New(),
+		InvoiceNumber:            "20260115-0001",
+		Description:              " 유지보수 선수수익 ",
+		ServiceStart:             date(2026, 1, 15),
+		ServiceEnd:               date(2026, 4, 14),
+		Amount:                   1200000,
+		RevenueAccountID:         uuid.New(),
+		DeferredRevenueAccountID: uuid.New(),
+		DeferralDate:             date(2026, 1, 15),
+	}
+}
+
+func TestRevenueSchedule_Validate(t *testing.T) {
+	schedule := newRevenueSchedule()
+	require.NoError(t, schedule.Validate())
+	assert.Equal(t, "유지보수 선수수익", schedule.Description)
+	assert.Equal(t, domain.RevenueScheduleActive, schedule.Status)
+	require.Len(t, schedule.Lines, 4)
+	assert.Equal(t, date(2026, 4, 30), schedule.Lines[3].PeriodEnd)
+
+	reversed := newRevenueSchedule()
+	reversed.ServiceEnd = date(2026, 1, 14)
+	assert.ErrorIs(t, reversed.Validate(), domain.ErrInvalidRevenueSchedule)
+
+	sameAccount := newRevenueSchedule()
+	sameAccount.DeferredRevenueAccountID = sameAccount.RevenueAccountID
+	assert.ErrorIs(t, sameAccount.Validate(), domain.ErrInvalidRevenueSchedule)
+}
+
+func TestRevenueScheduleLines(t *testing.T) {
+	// 90 service days: 17 in January, 28 in February, 31 in March, 14 in April
+	lines := domain.RevenueScheduleLines(1200000, date(2026, 1, 15), date(2026, 4, 14))
+	require.Len(t, lines, 4)
+
+	var days []int
+	var amounts []int64
+	var total int64
+	for _, line := range lines {
+		days = append(days, line.Days)
+		amounts = append(amounts, line.Amount)
+		total += line.Amount
+	}
+	assert.Equal(t, []int{17, 28, 31, 14}, days)
+	assert.Equal(t, []int64{226667, 373333, 413333, 186667}, amounts)
+	assert.Equal(t, int64(1200000), total)
+
+	// A period within a month is a single line
+	single := domain.RevenueScheduleLines(100000, date(2026, 2, 1), date(2026, 2, 10))
+	require.Len(t, single, 1)
+	assert.Equal(t, int64(100000), single[0].Amount)
+	assert.Equal(t, date(2026, 2, 28), single[0].PeriodEnd)
+}
+
+func TestRevenueSchedule_Recognize(t *testing.T) {
+	schedule := newRevenueSchedule()
+	require.NoError(t, schedule.Validate())
+
+	due := schedule.DueLines(date(2026, 2, 28))
+	require.Len(t, due, 2)
+	for _, line := range due {
+		voucherID := uuid.New()
+		schedule.Recognize(line, &voucherID, time.Now())
+	}
+	assert.Equal(t, int64(600000), schedule.RecognizedAmount)
+	assert.Equal(t, int64(600000), schedule.DeferredBalance())
+	assert.Equal(t, domain.RevenueScheduleActive, schedule.Status)
+	assert.Empty(t, schedule.DueLines(date(2026, 2, 28)))
+
+	for _, line := range schedule.DueLines(date(2026, 4, 30)) {
+		schedule.Recognize(line, nil, time.Now())
+	}
+	assert.Equal(t, domain.RevenueScheduleCompleted, schedule.Status)
+}
+
+func TestNewDeferredRevenueWaterfall(t *testing.T) {
+	schedule := newRevenueSchedule()
+	require.NoError(t, schedule.Validate())
+	schedule.Recognize(&schedule.Lines[0], nil, time.Now())
+
+	later := newRevenueSchedule()
+	later.DeferralDate = date(2026, 3, 2)
+	require.NoError(t, later.Validate())
+
+	w := domain.NewDeferredRevenueWaterfall([]domain.RevenueSchedule{*schedule, *later}, date(2026, 2, 28), 1)
+	assert.Equal(t, []time.Time{date(2026, 3, 31)}, w.Months)
+	// The schedule deferred after the date is left out
+	require.Len(t, w.Rows, 1)
+
+	row := w.Rows[0]
+	assert.Equal(t, int64(226667), row.Recognized)
+	assert.Equal(t, int64(973333), row.Balance)
+	assert.Equal(t, int64(373333), row.Due) // February is not posted yet
+	assert.Equal(t, []int64{413333}, row.Months)
+	assert.Equal(t, int64(186667), row.Later)
+	assert.Equal(t, row.Balance, w.Totals.Balance)
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/cron"
+)
+
+// Scheduled task errors
+var (
+	ErrScheduledTaskNotFound = errors.New("scheduled task not found")
+	ErrInvalidCatchUpPolicy  = errors.New("invalid catch-up policy")
+)
+
+const (
+	// ScheduledTaskLease is how long a worker holds a task it claimed; another
+	// worker may claim the task again once the lease has run out
+	ScheduledTaskLease = time.Hour
+	// ScheduledTaskSkipGrace is how late a run of a task with the skip policy
+	// may start before it is dropped
+	ScheduledTaskSkipGrace = 5 * time.Minute
+)
+
+// CatchUpPolicy decides what happens to the runs of a task missed while no
+// worker was running
+type CatchUpPolicy string
+
+const (
+	CatchUpSkip CatchUpPolicy = "skip" // runs later than ScheduledTaskSkipGrace are dropped
+	CatchUpOnce CatchUpPolicy = "once" // the missed runs are made up by one run
+	CatchUpAll  CatchUpPolicy = "all"  // every missed run is made up, oldest first
+)
+
+// IsValid checks if the catch-up policy is valid
+func (p CatchUpPolicy) IsValid() bool {
+	return p == CatchUpSkip || p == CatchUpOnce || p == CatchUpAll
+}
+
+// Scheduled task run statuses
+const (
+	ScheduledTaskRunning   = "running"
+	ScheduledTaskSucceeded = "succeeded"
+	ScheduledTaskFailed    = "failed"
+	ScheduledTaskSkipped   = "skipped"
+)
+
+// ScheduledTask is a recurring task of the worker, such as the auto-reversal
+// of vouchers or the month-end loan accrual. Every worker replica runs the
+// scheduler; a run is claimed by a conditional update of the task, so each
+// scheduled time is run by one worker only.
+type ScheduledTask struct {
+	Name        string `gorm:"type:varchar(100);primaryKey" json:"name"`
+	Description string `gorm:"type:varchar(200)" json:"description,omitempty"`
+
+	// Schedule (standard five-field cron expression, evaluated in Timezone)
+	CronExpr string        `gorm:"type:varchar(100);not null" json:"cron_expr"`
+	Timezone string        `gorm:"type:varchar(50);not null;default:'Asia/Seoul'" json:"timezone"`
+	CatchUp  CatchUpPolicy `gorm:"type:varchar(10);not null;default:once" json:"catch_up"`
+	Enabled  bool          `gorm:"not null;default:true" json:"enabled"`
+
+	NextRunAt   time.Time  `gorm:"not null" json:"next_run_at"`
+	LockedBy    string     `gorm:"type:varchar(200)" json:"locked_by,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+
+	// Last run; LastScheduledAt is the scheduled time it ran for
+	LastScheduledAt *time.Time `json:"last_scheduled_at,omitempty"`
+	LastStartedAt   *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt  *time.Time `json:"last_finished_at,omitempty"`
+	LastStatus      string     `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	LastError       string     `gorm:"type:text" json:"last_error,omitempty"`
+
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ScheduledTask) TableName() string {
+	return "scheduled_tasks"
+}
+
+// Schedule parses the cron expression and the time zone of the task
+func (t *ScheduledTask) Schedule() (*cron.Schedule, *time.Location, error) {
+	schedule, err := cron.Parse(t.CronExpr)
+	if err != nil {
+		return nil, nil, ErrInvalidCronExpression
+	}
+	loc := time.UTC
+	if t.Timezone != "" {
+		if loc, err = time.LoadLocation(t.Timezone); err != nil {
+			return nil, nil, ErrInvalidTimezone
+		}
+	}
+	return schedule, loc, nil
+}
+
+// Reschedule validates the schedule and sets the next run to the first
+// scheduled time after now
+func (t *ScheduledTask) Reschedule(now time.Time) error {
+	if !t.CatchUp.IsValid() {
+		return ErrInvalidCatchUpPolicy
+	}
+	schedule, loc, err := t.Schedule()
+	if err != nil {
+		return err
+	}
+	next := schedule.Next(now.In(loc))
+	if next.IsZero() {
+		return ErrInvalidCronExpression
+	}
+	t.NextRunAt = next
+	return nil
+}
+
+// Claim takes the due run of the task for the worker owner and moves the next
+// run on according to the catch-up policy. It returns the scheduled time to
+// run for, and false if the run is dropped by the skip policy.
+func (t *ScheduledTask) Claim(owner string, now time.Time) (time.Time, bool, error) {
+	schedule, loc, err := t.Schedule()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	scheduledAt := t.NextRunAt
+	if t.CatchUp != CatchUpAll {
+		// The latest of the missed runs stands for all of them
+		for next := schedule.Next(scheduledAt.In(loc)); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+			scheduledAt = next
+		}
+	}
+	next := schedule.Next(scheduledAt.In(loc))
+	if next.IsZero() {
+		return time.Time{}, false, ErrInvalidCronExpression
+	}
+	t.NextRunAt = next
+	t.LastScheduledAt = &scheduledAt
+
+	if t.CatchUp == CatchUpSkip && now.Sub(scheduledAt) > ScheduledTaskSkipGrace {
+		t.LastStatus = ScheduledTaskSkipped
+		return scheduledAt, false, nil
+	}
+
+	until := now.Add(ScheduledTaskLease)
+	t.LockedBy = owner
+	t.LockedUntil = &until
+	t.LastStartedAt = &now
+	t.LastFinishedAt = nil
+	t.LastStatus = ScheduledTaskRunning
+	t.LastError = ""
+	return scheduledAt, true, nil
+}
+
+// Finish records the outcome of the claimed run and releases the task
+func (t *ScheduledTask) Finish(err error, now time.Time) {
+	t.LastFinishedAt = &now
+	t.LastStatus = ScheduledTaskSucceeded
+	t.LastError = ""
+	if err != nil {
+		t.LastStatus = ScheduledTaskFailed
+		t.LastError = err.Error()
+	}
+	t.LockedBy = ""
+	t.LockedUntil = nil
+}
@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// ScheduledTask Tests
+// ============================================================================
+
+func TestScheduledTask_Claim(t *testing.T) {
+	hour := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	newTask := func(policy domain.CatchUpPolicy) *domain.ScheduledTask {
+		// Hourly, last due three hours before now
+		return &domain.ScheduledTask{Name: "auto_reversal", CronExpr: "0 * * * *", Timezone: "UTC", CatchUp: policy, NextRunAt: hour}
+	}
+	now := hour.Add(3*time.Hour + 10*time.Minute)
+
+	t.Run("once makes up the missed runs by one", func(t *testing.T) {
+		task := newTask(domain.CatchUpOnce)
+		at, run, err := task.Claim("worker-1", now)
+		require.NoError(t, err)
+		assert.True(t, run)
+		assert.Equal(t, hour.Add(3*time.Hour), at)
+		assert.Equal(t, hour.Add(4*time.Hour), task.NextRunAt)
+		assert.Equal(t, "worker-1", task.LockedBy)
+		assert.Equal(t, domain.ScheduledTaskRunning, task.LastStatus)
+	})
+
+	t.Run("all makes up each missed run", func(t *testing.T) {
+		task := newTask(domain.CatchUpAll)
+		at, run, err := task.Claim("worker-1", now)
+		require.NoError(t, err)
+		assert.True(t, run)
+		assert.Equal(t, hour, at)
+		assert.Equal(t, hour.Add(time.Hour), task.NextRunAt)
+	})
+
+	t.Run("skip drops late runs", func(t *testing.T) {
+		task := newTask(domain.CatchUpSkip)
+		at, run, err := task.Claim("worker-1", now)
+		require.NoError(t, err)
+		assert.False(t, run)
+		assert.Equal(t, hour.Add(3*time.Hour), at)
+		assert.Equal(t, hour.Add(4*time.Hour), task.NextRunAt)
+		assert.Equal(t, domain.ScheduledTaskSkipped, task.LastStatus)
+		assert.Empty(t, task.LockedBy)
+
+		onTime := newTask(domain.CatchUpSkip)
+		_, run, err = onTime.Claim("worker-1", hour.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, run)
+	})
+
+	t.Run("finish releases the task", func(t *testing.T) {
+		task := newTask(domain.CatchUpOnce)
+		_, _, err := task.Claim("worker-1", now)
+		require.NoError(t, err)
+		task.Finish(errors.New("database is down"), now.Add(time.Minute))
+		assert.Equal(t, domain.ScheduledTaskFailed, task.LastStatus)
+		assert.Equal(t, "database is down", task.LastError)
+		assert.Empty(t, task.LockedBy)
+		assert.Nil(t, task.LockedUntil)
+	})
+}
+
+func TestScheduledTask_Reschedule(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	task := &domain.ScheduledTask{CronExpr: "0 3 * * *", Timezone: "Asia/Seoul", CatchUp: domain.CatchUpOnce}
+	require.NoError(t, task.Reschedule(now))
+	// 03:00 KST on the next day is 18:00 UTC
+	assert.True(t, task.NextRunAt.Equal(time.Date(2026, 4, 1, 18, 0, 0, 0, time.UTC)))
+
+	task.CronExpr = "every hour"
+	assert.ErrorIs(t, task.Reschedule(now), domain.ErrInvalidCronExpression)
+	task.CronExpr, task.Timezone = "@hourly", "Mars/Olympus"
+	assert.ErrorIs(t, task.Reschedule(now), domain.ErrInvalidTimezone)
+	task.Timezone, task.CatchUp = "UTC", "sometimes"
+	assert.ErrorIs(t, task.Reschedule(now), domain.ErrInvalidCatchUpPolicy)
+}
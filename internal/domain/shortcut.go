@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shortcut errors
+var (
+	ErrInvalidShortcutKind = errors.New("invalid shortcut kind")
+	ErrTooManyFavorites    = errors.New("too many favorites")
+	ErrShortcutsDisabled   = errors.New("shortcuts are disabled")
+)
+
+const (
+	// ShortcutListLimit is the number of shortcuts offered first by entry forms
+	ShortcutListLimit = 20
+	// ShortcutFavoriteLimit is the number of favorites a user may keep of a kind
+	ShortcutFavoriteLimit = 20
+	// ShortcutRecentTTL is how long the recent items of a user are kept since
+	// the last use
+	ShortcutRecentTTL = 90 * 24 * time.Hour
+)
+
+// ShortcutKind is the kind of items a user keeps shortcuts to
+type ShortcutKind string
+
+const (
+	ShortcutAccounts ShortcutKind = "accounts"
+	ShortcutPartners ShortcutKind = "partners"
+)
+
+// IsValid checks if the shortcut kind is valid
+func (k ShortcutKind) IsValid() bool {
+	return k == ShortcutAccounts || k == ShortcutPartners
+}
+
+// Shortcut is an account or partner offered first to a user: the user's
+// favorites, then the items the user used last on vouchers
+type Shortcut struct {
+	ID       uuid.UUID `json:"id"`
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	Favorite bool      `json:"favorite"`
+}
+
+// ShortcutOrder returns the IDs of the shortcuts in the order they are
+// offered: the favorites, newest first, then the recent items that are not
+// favorites, up to ShortcutListLimit
+func ShortcutOrder(favorites, recent []uuid.UUID) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, ShortcutListLimit)
+	seen := make(map[uuid.UUID]bool, len(favorites)+len(recent))
+	for _, list := range [][]uuid.UUID{favorites, recent} {
+		for _, id := range list {
+			if len(ids) == ShortcutListLimit {
+				return ids
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
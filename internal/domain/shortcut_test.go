@@ -0,0 +1,24 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestShortcutOrder(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	assert.Equal(t, []uuid.UUID{b, a, c}, domain.ShortcutOrder([]uuid.UUID{b}, []uuid.UUID{a, b, c}))
+	assert.Empty(t, domain.ShortcutOrder(nil, nil))
+
+	recent := make([]uuid.UUID, domain.ShortcutListLimit)
+	for i := range recent {
+		recent[i] = uuid.New()
+	}
+	ids := domain.ShortcutOrder([]uuid.UUID{a}, recent)
+	assert.Len(t, ids, domain.ShortcutListLimit)
+	assert.Equal(t, a, ids[0])
+}
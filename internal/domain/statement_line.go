@@ -0,0 +1,413 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Statement line errors
+var (
+	ErrInvalidStatementLine      = errors.New("unknown financial statement line")
+	ErrStatementLineTypeMismatch = errors.New("financial statement line belongs to another account type")
+)
+
+// FinancialStatementKind identifies a statutory financial statement
+type FinancialStatementKind string
+
+const (
+	FinancialStatementBalanceSheet    FinancialStatementKind = "balance_sheet"    // 재무상태표
+	FinancialStatementIncomeStatement FinancialStatementKind = "income_statement" // 손익계산서
+)
+
+// Balance sheet line groups
+const (
+	StatementGroupCurrentAssets         = "current_assets"
+	StatementGroupNonCurrentAssets      = "non_current_assets"
+	StatementGroupCurrentLiabilities    = "current_liabilities"
+	StatementGroupNonCurrentLiabilities = "non_current_liabilities"
+)
+
+// StatementLine is a caption of the statutory balance sheet or income
+// statement (일반기업회계기준) that accounts roll up to
+type StatementLine struct {
+	Code        string                 `json:"code"`
+	Statement   FinancialStatementKind `json:"statement"`
+	AccountType AccountType            `json:"account_type"`
+	Group       string                 `json:"group,omitempty"` // current or non-current, on the balance sheet
+	Name        string                 `json:"name"`
+	NameEn      string                 `json:"name_en"`
+}
+
+// LocalizedName returns the caption in the locale
+func (l StatementLine) LocalizedName(loc i18n.Locale) string {
+	if loc == i18n.English {
+		return l.NameEn
+	}
+	return l.Name
+}
+
+// statementLines are the captions in statement order
+var statementLines = []StatementLine{
+	{"cash_and_equivalents", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupCurrentAssets, "현금및현금성자산", "Cash and cash equivalents"},
+	{"short_term_investments", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupCurrentAssets, "단기투자자산", "Short-term investments"},
+	{"trade_receivables", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupCurrentAssets, "매출채권", "Trade receivables"},
+	{"inventories", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupCurrentAssets, "재고자산", "Inventories"},
+	{"other_current_assets", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupCurrentAssets, "기타유동자산", "Other current assets"},
+	{"long_term_investments", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupNonCurrentAssets, "장기투자자산", "Long-term investments"},
+	{"property_plant_equipment", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupNonCurrentAssets, "유형자산", "Property, plant and equipment"},
+	{"intangible_assets", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupNonCurrentAssets, "무형자산", "Intangible assets"},
+	{"other_non_current_assets", FinancialStatementBalanceSheet, AccountTypeAsset, StatementGroupNonCurrentAssets, "기타비유동자산", "Other non-current assets"},
+
+	{"trade_payables", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupCurrentLiabilities, "매입채무", "Trade payables"},
+	{"short_term_borrowings", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupCurrentLiabilities, "단기차입금", "Short-term borrowings"},
+	{"income_tax_payable", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupCurrentLiabilities, "당기법인세부채", "Income tax payable"},
+	{"other_current_liabilities", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupCurrentLiabilities, "기타유동부채", "Other current liabilities"},
+	{"long_term_borrowings", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupNonCurrentLiabilities, "장기차입금", "Long-term borrowings"},
+	{"retirement_benefit_obligations", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupNonCurrentLiabilities, "퇴직급여충당부채", "Retirement benefit obligations"},
+	{"deferred_tax_liabilities", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupNonCurrentLiabilities, "이연법인세부채", "Deferred tax liabilities"},
+	{"other_non_current_liabilities", FinancialStatementBalanceSheet, AccountTypeLiability, StatementGroupNonCurrentLiabilities, "기타비유동부채", "Other non-current liabilities"},
+
+	{"share_capital", FinancialStatementBalanceSheet, AccountTypeEquity, "", "자본금", "Share capital"},
+	{"capital_surplus", FinancialStatementBalanceSheet, AccountTypeEquity, "", "자본잉여금", "Capital surplus"},
+	{"capital_adjustments", FinancialStatementBalanceSheet, AccountTypeEquity, "", "자본조정", "Capital adjustments"},
+	{"accumulated_oci", FinancialStatementBalanceSheet, AccountTypeEquity, "", "기타포괄손익누계액", "Accumulated other comprehensive income"},
+	{"retained_earnings", FinancialStatementBalanceSheet, AccountTypeEquity, "", "이익잉여금", "Retained earnings"},
+
+	{"revenue", FinancialStatementIncomeStatement, AccountTypeRevenue, "", "매출액", "Revenue"},
+	{"cost_of_sales", FinancialStatementIncomeStatement, AccountTypeExpense, "", "매출원가", "Cost of sales"},
+	{"selling_admin_expenses", FinancialStatementIncomeStatement, AccountTypeExpense, "", "판매비와관리비", "Selling and administrative expenses"},
+	{"non_operating_income", FinancialStatementIncomeStatement, AccountTypeRevenue, "", "영업외수익", "Non-operating income"},
+	{"non_operating_expenses", FinancialStatementIncomeStatement, AccountTypeExpense, "", "영업외비용", "Non-operating expenses"},
+	{"income_tax_expense", FinancialStatementIncomeStatement, AccountTypeExpense, "", "법인세비용", "Income tax expense"},
+}
+
+// statementGroupNames are the captions of the balance sheet groups
+var statementGroupNames = map[string][2]string{
+	StatementGroupCurrentAssets:         {"유동자산", "Current assets"},
+	StatementGroupNonCurrentAssets:      {"비유동자산", "Non-current assets"},
+	StatementGroupCurrentLiabilities:    {"유동부채", "Current liabilities"},
+	StatementGroupNonCurrentLiabilities: {"비유동부채", "Non-current liabilities"},
+}
+
+// StatementLines returns the statement line catalog in statement order
+func StatementLines() []StatementLine {
+	lines := make([]StatementLine, len(statementLines))
+	copy(lines, statementLines)
+	return lines
+}
+
+// FindStatementLine looks up a statement line by code
+func FindStatementLine(code string) (StatementLine, bool) {
+	for _, l := range statementLines {
+		if l.Code == code {
+			return l, true
+		}
+	}
+	return StatementLine{}, false
+}
+
+// ValidateStatementLine checks that the line exists and belongs to the
+// account's type; an empty code clears the mapping and is always valid
+func ValidateStatementLine(accountType AccountType, code string) error {
+	if code == "" {
+		return nil
+	}
+	line, ok := FindStatementLine(code)
+	if !ok {
+		return ErrInvalidStatementLine
+	}
+	if line.AccountType != accountType {
+		return ErrStatementLineTypeMismatch
+	}
+	return nil
+}
+
+// DefaultStatementLine returns the line of accounts without a mapping, from
+// their type and category
+func DefaultStatementLine(accountType AccountType, category string) string {
+	switch accountType {
+	case AccountTypeAsset:
+		if category == "non_current" {
+			return "other_non_current_assets"
+		}
+		return "other_current_assets"
+	case AccountTypeLiability:
+		if category == "non_current" {
+			return "other_non_current_liabilities"
+		}
+		return "other_current_liabilities"
+	case AccountTypeEquity:
+		return "capital_adjustments"
+	case AccountTypeRevenue:
+		if category == "non_operating" {
+			return "non_operating_income"
+		}
+		return "revenue"
+	case AccountTypeExpense:
+		switch category {
+		case "cost":
+			return "cost_of_sales"
+		case "non_operating":
+			return "non_operating_expenses"
+		case "tax":
+			return "income_tax_expense"
+		}
+		return "selling_admin_expenses"
+	}
+	return ""
+}
+
+// ResolveStatementLines returns the statement line of each account: its own
+// mapping, else the nearest ancestor's mapping of the same account type, else
+// the default of its type and category
+func ResolveStatementLines(accounts []Account) map[uuid.UUID]string {
+	byID := make(map[uuid.UUID]*Account, len(accounts))
+	for i := range accounts {
+		byID[accounts[i].ID] = &accounts[i]
+	}
+
+	lines := make(map[uuid.UUID]string, len(accounts))
+	for i := range accounts {
+		a := &accounts[i]
+		line := ""
+		visited := make(map[uuid.UUID]bool)
+		for current := a; current != nil && !visited[current.ID]; {
+			visited[current.ID] = true
+			if current.StatementLine != "" && ValidateStatementLine(a.AccountType, current.StatementLine) == nil {
+				line = current.StatementLine
+				break
+			}
+			if current.ParentID == nil {
+				break
+			}
+			current = byID[*current.ParentID]
+		}
+		if line == "" {
+			line = DefaultStatementLine(a.AccountType, a.AccountCategory)
+		}
+		lines[a.ID] = line
+	}
+	return lines
+}
+
+// FinancialStatementRow is a line, group, total or rolled up account of a
+// financial statement. Level 1 rows are groups and totals, level 2 rows the
+// lines within a group; account rows are one level below their parent row.
+type FinancialStatementRow struct {
+	Code         string      `json:"code"`
+	Name         string      `json:"name"`
+	NameEn       string      `json:"name_en"`
+	AccountType  AccountType `json:"account_type,omitempty"`
+	Amount       float64     `json:"amount"`
+	Level        int         `json:"level"`
+	IsSubTotal   bool        `json:"is_sub_total"`
+	IsTotal      bool        `json:"is_total"`
+	AccountCount int         `json:"account_count"`
+
+	// Tree structure: the code of the row this one adds up to, and whether
+	// rows below it follow. AccountID is set on account rows.
+	ParentCode  string     `json:"parent_code,omitempty"`
+	HasChildren bool       `json:"has_children"`
+	AccountID   *uuid.UUID `json:"account_id,omitempty"`
+
+	own float64 // the row's own balance, on top of its children's
+}
+
+// LocalizedName returns the caption in the locale
+func (r FinancialStatementRow) LocalizedName(loc i18n.Locale) string {
+	if loc == i18n.English {
+		return r.NameEn
+	}
+	return r.Name
+}
+
+// FinancialStatement is a balance sheet or income statement with the accounts
+// rolled up to statement lines. Amounts are on the normal side of each section.
+type FinancialStatement struct {
+	Kind      FinancialStatementKind  `json:"kind"`
+	StartDate time.Time               `json:"start_date"`
+	EndDate   time.Time               `json:"end_date"`
+	Rows      []FinancialStatementRow `json:"rows"`
+
+	TotalAssets      float64 `json:"total_assets,omitempty"`
+	TotalLiabilities float64 `json:"total_liabilities,omitempty"`
+	TotalEquity      float64 `json:"total_equity,omitempty"`
+	TotalRevenue     float64 `json:"total_revenue,omitempty"`
+	TotalExpenses    float64 `json:"total_expenses,omitempty"`
+	NetIncome        float64 `json:"net_income"`
+}
+
+// IsBalanced returns true if the balance sheet assets equal liabilities and equity
+func (fs *FinancialStatement) IsBalanced() bool {
+	return roundAmount(fs.TotalAssets) == roundAmount(fs.TotalLiabilities+fs.TotalEquity)
+}
+
+// statementAmounts sums the trial balance items by statement line, each on
+// the normal side of its account type
+type statementAmounts struct {
+	amounts map[string]float64
+	counts  map[string]int
+}
+
+func sumStatementLines(items []TrialBalanceItem, lines map[uuid.UUID]string) statementAmounts {
+	s := statementAmounts{amounts: make(map[string]float64), counts: make(map[string]int)}
+	for _, item := range items {
+		line := itemStatementLine(item, lines)
+		s.amounts[line] += normalBalance(item)
+		s.counts[line]++
+	}
+	return s
+}
+
+// itemStatementLine returns the statement line of the item's account, or the
+// default of its type for accounts missing from lines
+func itemStatementLine(item TrialBalanceItem, lines map[uuid.UUID]string) string {
+	if line, ok := lines[item.AccountID]; ok {
+		return line
+	}
+	return DefaultStatementLine(AccountType(item.AccountType), "")
+}
+
+// normalBalance returns the closing balance of the item on the normal side of
+// its account type
+func normalBalance(item TrialBalanceItem) float64 {
+	amount := item.ClosingDebit - item.ClosingCredit
+	switch AccountType(item.AccountType) {
+	case AccountTypeLiability, AccountTypeEquity, AccountTypeRevenue:
+		return -amount
+	}
+	return amount
+}
+
+func (s statementAmounts) row(line StatementLine, level int) FinancialStatementRow {
+	return FinancialStatementRow{
+		Code:         line.Code,
+		Name:         line.Name,
+		NameEn:       line.NameEn,
+		AccountType:  line.AccountType,
+		Amount:       roundAmount(s.amounts[line.Code]),
+		Level:        level,
+		AccountCount: s.counts[line.Code],
+	}
+}
+
+// BuildBalanceSheet rolls the trial balance at the end of a month up to the
+// balance sheet lines. The current year's net income, still held by revenue
+// and expense accounts until the year is rolled over, is added to retained
+// earnings. Lines without accounts are left out.
+func BuildBalanceSheet(tb *TrialBalance, lines map[uuid.UUID]string) *FinancialStatement {
+	s := sumStatementLines(tb.Items, lines)
+	netIncome := 0.0
+	for _, l := range statementLines {
+		switch l.AccountType {
+		case AccountTypeRevenue:
+			netIncome += s.amounts[l.Code]
+		case AccountTypeExpense:
+			netIncome -= s.amounts[l.Code]
+		}
+	}
+	s.amounts["retained_earnings"] += netIncome
+
+	fs := &FinancialStatement{
+		Kind:      FinancialStatementBalanceSheet,
+		StartDate: tb.StartDate,
+		EndDate:   tb.EndDate,
+		NetIncome: roundAmount(netIncome),
+	}
+	for _, section := range []AccountType{AccountTypeAsset, AccountTypeLiability, AccountTypeEquity} {
+		total := 0.0
+		group := -1 // index of the current group row
+		for _, l := range statementLines {
+			if l.AccountType != section || (s.counts[l.Code] == 0 && roundAmount(s.amounts[l.Code]) == 0) {
+				continue
+			}
+			level := 1
+			if l.Group != "" {
+				level = 2
+				if group < 0 || fs.Rows[group].Code != l.Group {
+					names := statementGroupNames[l.Group]
+					fs.Rows = append(fs.Rows, FinancialStatementRow{Code: l.Group, Name: names[0], NameEn: names[1], AccountType: section, Level: 1, IsSubTotal: true, HasChildren: true})
+					group = len(fs.Rows) - 1
+				}
+			}
+			row := s.row(l, level)
+			row.ParentCode = l.Group
+			fs.Rows = append(fs.Rows, row)
+			if l.Group != "" {
+				fs.Rows[group].Amount = roundAmount(fs.Rows[group].Amount + row.Amount)
+				fs.Rows[group].AccountCount += row.AccountCount
+			}
+			total += row.Amount
+		}
+		total = roundAmount(total)
+
+		var name, nameEn string
+		switch section {
+		case AccountTypeAsset:
+			fs.TotalAssets, name, nameEn = total, "자산총계", "Total assets"
+		case AccountTypeLiability:
+			fs.TotalLiabilities, name, nameEn = total, "부채총계", "Total liabilities"
+		case AccountTypeEquity:
+			fs.TotalEquity, name, nameEn = total, "자본총계", "Total equity"
+		}
+		fs.Rows = append(fs.Rows, FinancialStatementRow{Code: "total_" + string(section), Name: name, NameEn: nameEn, AccountType: section, Amount: total, Level: 1, IsTotal: true})
+	}
+	fs.Rows = append(fs.Rows, FinancialStatementRow{
+		Code: "total_liabilities_and_equity", Name: "부채와자본총계", NameEn: "Total liabilities and equity",
+		Amount: roundAmount(fs.TotalLiabilities + fs.TotalEquity), Level: 1, IsTotal: true,
+	})
+	return fs
+}
+
+// BuildIncomeStatement rolls the trial balance of a month range up to the
+// income statement lines with the statutory profit subtotals. Every line is
+// shown, including those without activity.
+func BuildIncomeStatement(tb *TrialBalance, lines map[uuid.UUID]string) *FinancialStatement {
+	s := sumStatementLines(tb.Items, lines)
+	fs := &FinancialStatement{
+		Kind:      FinancialStatementIncomeStatement,
+		StartDate: tb.StartDate,
+		EndDate:   tb.EndDate,
+	}
+
+	profit := 0.0
+	subtotal := func(code, name, nameEn string, isTotal bool) {
+		fs.Rows = append(fs.Rows, FinancialStatementRow{
+			Code: code, Name: name, NameEn: nameEn, Amount: roundAmount(profit),
+			Level: 1, IsSubTotal: !isTotal, IsTotal: isTotal,
+		})
+	}
+	for _, l := range statementLines {
+		if l.Statement != FinancialStatementIncomeStatement {
+			continue
+		}
+		row := s.row(l, 1)
+		fs.Rows = append(fs.Rows, row)
+		if l.AccountType == AccountTypeRevenue {
+			profit += row.Amount
+			fs.TotalRevenue += row.Amount
+		} else {
+			profit -= row.Amount
+			fs.TotalExpenses += row.Amount
+		}
+
+		switch l.Code {
+		case "cost_of_sales":
+			subtotal("gross_profit", "매출총이익", "Gross profit", false)
+		case "selling_admin_expenses":
+			subtotal("operating_income", "영업이익", "Operating income", false)
+		case "non_operating_expenses":
+			subtotal("income_before_tax", "법인세비용차감전순이익", "Income before income tax", false)
+		case "income_tax_expense":
+			subtotal("net_income", "당기순이익", "Net income", true)
+		}
+	}
+	fs.TotalRevenue = roundAmount(fs.TotalRevenue)
+	fs.TotalExpenses = roundAmount(fs.TotalExpenses)
+	fs.NetIncome = roundAmount(profit)
+	return fs
+}
@@ -0,0 +1,222 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestResolveStatementLines(t *testing.T) {
+	account := func(code string, accountType domain.AccountType, parent *domain.Account, line string) domain.Account {
+		a := domain.Account{Code: code, AccountType: accountType, StatementLine: line}
+		a.ID = uuid.New()
+		if parent != nil {
+			a.ParentID = &parent.ID
+		}
+		return a
+	}
+	group := account("1103", domain.AccountTypeAsset, nil, "trade_receivables")
+	child := account("110301", domain.AccountTypeAsset, &group, "")
+	grandChild := account("11030101", domain.AccountTypeAsset, &child, "")
+	// An account mapped on its own wins over the parent's line
+	own := account("110302", domain.AccountTypeAsset, &group, "other_current_assets")
+	// A parent of another type does not pass its line down
+	liability := account("210101", domain.AccountTypeLiability, &group, "")
+
+	lines := domain.ResolveStatementLines([]domain.Account{group, child, grandChild, own, liability})
+	assert.Equal(t, "trade_receivables", lines[child.ID])
+	assert.Equal(t, "trade_receivables", lines[grandChild.ID])
+	assert.Equal(t, "other_current_assets", lines[own.ID])
+	assert.Equal(t, domain.DefaultStatementLine(domain.AccountTypeLiability, ""), lines[liability.ID])
+}
+
+func TestValidateStatementLine(t *testing.T) {
+	assert.NoError(t, domain.ValidateStatementLine(domain.AccountTypeAsset, ""))
+	assert.NoError(t, domain.ValidateStatementLine(domain.AccountTypeExpense, "cost_of_sales"))
+	assert.ErrorIs(t, domain.ValidateStatementLine(domain.AccountTypeAsset, "cost_of_sales"), domain.ErrStatementLineTypeMismatch)
+	assert.ErrorIs(t, domain.ValidateStatementLine(domain.AccountTypeAsset, "unknown"), domain.ErrInvalidStatementLine)
+}
+
+func newStatementTrialBalance(items ...domain.TrialBalanceItem) (*domain.TrialBalance, map[uuid.UUID]string) {
+	lines := make(map[uuid.UUID]string)
+	tb := &domain.TrialBalance{}
+	for i := range items {
+		items[i].AccountID = uuid.New()
+		lines[items[i].AccountID] = items[i].AccountCode
+	}
+	tb.Items = items
+	return tb, lines
+}
+
+func TestBuildBalanceSheet_IncludesNetIncome(t *testing.T) {
+	item := func(line string, accountType domain.AccountType, debit, credit float64) domain.TrialBalanceItem {
+		return domain.TrialBalanceItem{AccountCode: line, AccountType: string(accountType), ClosingDebit: debit, ClosingCredit: credit}
+	}
+	tb, lines := newStatementTrialBalance(
+		item("cash_and_equivalents", domain.AccountTypeAsset, 7000, 0),
+		item("cash_and_equivalents", domain.AccountTypeAsset, 1000, 0),
+		item("property_plant_equipment", domain.AccountTypeAsset, 5000, 0),
+		item("trade_payables", domain.AccountTypeLiability, 0, 2000),
+		item("share_capital", domain.AccountTypeEquity, 0, 10000),
+		item("revenue", domain.AccountTypeRevenue, 0, 3000),
+		item("selling_admin_expenses", domain.AccountTypeExpense, 2000, 0),
+	)
+
+	fs := domain.BuildBalanceSheet(tb, lines)
+	assert.Equal(t, 13000.0, fs.TotalAssets)
+	assert.Equal(t, 2000.0, fs.TotalLiabilities)
+	assert.Equal(t, 11000.0, fs.TotalEquity)
+	assert.Equal(t, 1000.0, fs.NetIncome)
+	assert.True(t, fs.IsBalanced())
+
+	rows := make(map[string]domain.FinancialStatementRow)
+	var codes []string
+	for _, r := range fs.Rows {
+		rows[r.Code] = r
+		codes = append(codes, r.Code)
+	}
+	assert.Equal(t, []string{
+		"current_assets", "cash_and_equivalents", "non_current_assets", "property_plant_equipment", "total_asset",
+		"current_liabilities", "trade_payables", "total_liability",
+		"share_capital", "retained_earnings", "total_equity",
+		"total_liabilities_and_equity",
+	}, codes)
+	assert.Equal(t, 8000.0, rows["current_assets"].Amount)
+	assert.Equal(t, 2, rows["cash_and_equivalents"].AccountCount)
+	assert.Equal(t, 1000.0, rows["retained_earnings"].Amount)
+	assert.Equal(t, 13000.0, rows["total_liabilities_and_equity"].Amount)
+}
+
+func TestBuildIncomeStatement_Subtotals(t *testing.T) {
+	item := func(line string, accountType domain.AccountType, debit, credit float64) domain.TrialBalanceItem {
+		return domain.TrialBalanceItem{AccountCode: line, AccountType: string(accountType), ClosingDebit: debit, ClosingCredit: credit}
+	}
+	tb, lines := newStatementTrialBalance(
+		item("revenue", domain.AccountTypeRevenue, 0, 10000),
+		item("cost_of_sales", domain.AccountTypeExpense, 6000, 0),
+		item("selling_admin_expenses", domain.AccountTypeExpense, 2500, 0),
+		item("non_operating_income", domain.AccountTypeRevenue, 0, 500),
+		item("non_operating_expenses", domain.AccountTypeExpense, 200, 0),
+		item("income_tax_expense", domain.AccountTypeExpense, 360, 0),
+	)
+
+	fs := domain.BuildIncomeStatement(tb, lines)
+	amounts := make(map[string]float64)
+	for _, r := range fs.Rows {
+		amounts[r.Code] = r.Amount
+	}
+	assert.Equal(t, 4000.0, amounts["gross_profit"])
+	assert.Equal(t, 1500.0, amounts["operating_income"])
+	assert.Equal(t, 1800.0, amounts["income_before_tax"])
+	assert.Equal(t, 1440.0, amounts["net_income"])
+	assert.Equal(t, 1440.0, fs.NetIncome)
+	assert.Equal(t, 10500.0, fs.TotalRevenue)
+	assert.Equal(t, 9060.0, fs.TotalExpenses)
+	require.NotEmpty(t, fs.Rows)
+	assert.True(t, fs.Rows[len(fs.Rows)-1].IsTotal)
+}
+
+func TestAccountTemplates_AreConsistent(t *testing.T) {
+	for _, summary := range domain.AccountTemplates() {
+		template, err := domain.FindAccountTemplate(summary.Industry)
+		require.NoError(t, err)
+
+		seen := make(map[string]domain.AccountType)
+		for _, e := range template.Accounts {
+			_, dup := seen[e.Code]
+			assert.False(t, dup, "%s: duplicate account %s", template.Industry, e.Code)
+			if e.ParentCode != "" {
+				_, ok := seen[e.ParentCode]
+				assert.True(t, ok, "%s: account %s precedes its parent %s", template.Industry, e.Code, e.ParentCode)
+			}
+			assert.NoError(t, domain.ValidateStatementLine(e.AccountType, e.StatementLine), "%s: account %s", template.Industry, e.Code)
+			seen[e.Code] = e.AccountType
+		}
+	}
+
+	_, err := domain.FindAccountTemplate("unknown")
+	assert.ErrorIs(t, err, domain.ErrAccountTemplateNotFound)
+}
+
+func TestFinancialStatement_AddAccountRollup(t *testing.T) {
+	account := func(code string, accountType domain.AccountType, parent *domain.Account, line string) domain.Account {
+		a := domain.Account{Code: code, Name: code, AccountType: accountType, StatementLine: line}
+		a.ID = uuid.New()
+		if parent != nil {
+			a.ParentID = &parent.ID
+		}
+		return a
+	}
+	cash := account("1101", domain.AccountTypeAsset, nil, "cash_and_equivalents")
+	deposits := account("110102", domain.AccountTypeAsset, &cash, "")
+	checking := account("11010201", domain.AccountTypeAsset, &deposits, "")
+	savings := account("11010202", domain.AccountTypeAsset, &deposits, "")
+	petty := account("110101", domain.AccountTypeAsset, &cash, "")
+	capital := account("31", domain.AccountTypeEquity, nil, "share_capital")
+	sales := account("41", domain.AccountTypeRevenue, nil, "revenue")
+	accounts := []domain.Account{cash, deposits, checking, savings, petty, capital, sales}
+	lines := domain.ResolveStatementLines(accounts)
+
+	item := func(a domain.Account, debit, credit float64) domain.TrialBalanceItem {
+		return domain.TrialBalanceItem{AccountID: a.ID, AccountCode: a.Code, AccountType: string(a.AccountType), ClosingDebit: debit, ClosingCredit: credit}
+	}
+	tb := &domain.TrialBalance{Items: []domain.TrialBalanceItem{
+		item(checking, 3000, 0),
+		item(savings, 2000, 0),
+		item(petty, 500, 0),
+		item(capital, 0, 4000),
+		item(sales, 0, 1500),
+	}}
+
+	fs := domain.BuildBalanceSheet(tb, lines)
+	fs.AddAccountRollup(tb, accounts, lines, domain.StatementDepthAll)
+	assert.Empty(t, fs.ValidateRollup())
+
+	rows := make(map[string]domain.FinancialStatementRow)
+	var codes []string
+	for _, r := range fs.Rows {
+		rows[r.Code] = r
+		codes = append(codes, r.Code)
+	}
+	assert.Equal(t, []string{
+		"current_assets", "cash_and_equivalents", "1101", "110101", "110102", "11010201", "11010202", "total_asset",
+		"total_liability",
+		"share_capital", "31", "retained_earnings", "current_net_income", "total_equity",
+		"total_liabilities_and_equity",
+	}, codes)
+	assert.Equal(t, 5500.0, rows["1101"].Amount)
+	assert.Equal(t, 3, rows["1101"].AccountCount)
+	assert.Equal(t, 5000.0, rows["110102"].Amount)
+	assert.Equal(t, "110102", rows["11010201"].ParentCode)
+	assert.Equal(t, 5, rows["11010201"].Level)
+	assert.True(t, rows["cash_and_equivalents"].HasChildren)
+	assert.False(t, rows["11010202"].HasChildren)
+	assert.Equal(t, 1500.0, rows["current_net_income"].Amount)
+
+	// Limited to two levels, the deposit accounts are folded into 110102
+	fs = domain.BuildBalanceSheet(tb, lines)
+	fs.AddAccountRollup(tb, accounts, lines, 2)
+	assert.Empty(t, fs.ValidateRollup())
+	for _, r := range fs.Rows {
+		assert.NotEqual(t, "110102", r.ParentCode)
+		if r.Code == "110102" {
+			assert.False(t, r.HasChildren)
+			assert.Equal(t, 5000.0, r.Amount)
+		}
+	}
+
+	// An account that does not add up fails at its own level and its line's
+	for i := range fs.Rows {
+		if fs.Rows[i].Code == "1101" {
+			fs.Rows[i].Amount = 5400
+		}
+	}
+	mismatches := fs.ValidateRollup()
+	require.Len(t, mismatches, 2)
+	assert.Contains(t, mismatches[0], "cash_and_equivalents (level 2)")
+	assert.Contains(t, mismatches[1], "1101 (level 3)")
+}
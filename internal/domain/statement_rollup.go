@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// StatementDepthAll shows every level of the account tree below the statement lines
+const StatementDepthAll = -1
+
+// rollupNode is an account within the tree of a statement line; amount and
+// count include the descendants
+type rollupNode struct {
+	id        uuid.UUID
+	code      string
+	name      string
+	nameEn    string
+	sortOrder int
+	own       float64
+	amount    float64
+	count     int
+	children  []*rollupNode
+}
+
+func (n *rollupNode) total() {
+	n.amount = n.own
+	for _, child := range n.children {
+		child.total()
+		n.amount += child.amount
+		n.count += child.count
+	}
+}
+
+func sortRollupNodes(nodes []*rollupNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].sortOrder != nodes[j].sortOrder {
+			return nodes[i].sortOrder < nodes[j].sortOrder
+		}
+		return nodes[i].code < nodes[j].code
+	})
+}
+
+// AddAccountRollup inserts the accounts below each statement line as a tree of
+// parent accounts with their subtotals. Parents roll up to the line only while
+// they map to the same line; the first parent mapped elsewhere ends the tree.
+// depth limits the account levels shown below a line, with StatementDepthAll
+// for every level; hidden accounts still add up to the rows shown. On the
+// balance sheet the current year's net income is shown below retained earnings.
+func (fs *FinancialStatement) AddAccountRollup(tb *TrialBalance, accounts []Account, lines map[uuid.UUID]string, depth int) {
+	if depth == 0 {
+		return
+	}
+
+	byID := make(map[uuid.UUID]*Account, len(accounts))
+	for i := range accounts {
+		byID[accounts[i].ID] = &accounts[i]
+	}
+	nodes := make(map[uuid.UUID]*rollupNode)
+	roots := make(map[string][]*rollupNode)
+
+	// node returns the account's node, creating the nodes of its parents within
+	// the same line first
+	var node func(id uuid.UUID, line string, item *TrialBalanceItem, visiting map[uuid.UUID]bool) *rollupNode
+	node = func(id uuid.UUID, line string, item *TrialBalanceItem, visiting map[uuid.UUID]bool) *rollupNode {
+		if n, ok := nodes[id]; ok {
+			return n
+		}
+		n := &rollupNode{id: id}
+		if a, ok := byID[id]; ok {
+			n.code, n.name, n.nameEn, n.sortOrder = a.Code, a.Name, a.NameEn, a.SortOrder
+		} else if item != nil {
+			n.code, n.name = item.AccountCode, item.AccountName
+		}
+		nodes[id] = n
+
+		visiting[id] = true
+		if a, ok := byID[id]; ok && a.ParentID != nil && !visiting[*a.ParentID] {
+			if _, known := byID[*a.ParentID]; known && lines[*a.ParentID] == line {
+				parent := node(*a.ParentID, line, nil, visiting)
+				parent.children = append(parent.children, n)
+				return n
+			}
+		}
+		roots[line] = append(roots[line], n)
+		return n
+	}
+	for i := range tb.Items {
+		item := &tb.Items[i]
+		n := node(item.AccountID, itemStatementLine(*item, lines), item, make(map[uuid.UUID]bool))
+		n.own += normalBalance(*item)
+		n.count++
+	}
+	for _, lineRoots := range roots {
+		for _, root := range lineRoots {
+			root.total()
+		}
+	}
+
+	var rows []FinancialStatementRow
+	var emit func(n *rollupNode, parent FinancialStatementRow, level int)
+	emit = func(n *rollupNode, parent FinancialStatementRow, level int) {
+		id := n.id
+		row := FinancialStatementRow{
+			Code:         n.code,
+			Name:         n.name,
+			NameEn:       n.nameEn,
+			AccountType:  parent.AccountType,
+			Amount:       roundAmount(n.amount),
+			Level:        parent.Level + 1,
+			AccountCount: n.count,
+			ParentCode:   parent.Code,
+			HasChildren:  len(n.children) > 0 && (depth < 0 || level < depth),
+			AccountID:    &id,
+			own:          roundAmount(n.own),
+		}
+		rows = append(rows, row)
+		if !row.HasChildren {
+			return
+		}
+		sortRollupNodes(n.children)
+		for _, child := range n.children {
+			emit(child, row, level+1)
+		}
+	}
+
+	for _, row := range fs.Rows {
+		if _, ok := FindStatementLine(row.Code); !ok || row.AccountID != nil {
+			rows = append(rows, row)
+			continue
+		}
+		lineRoots := roots[row.Code]
+		netIncome := fs.Kind == FinancialStatementBalanceSheet && row.Code == "retained_earnings" && fs.NetIncome != 0
+		row.HasChildren = len(lineRoots) > 0 || netIncome
+		rows = append(rows, row)
+
+		sortRollupNodes(lineRoots)
+		for _, root := range lineRoots {
+			emit(root, row, 1)
+		}
+		if netIncome {
+			rows = append(rows, FinancialStatementRow{
+				Code: "current_net_income", Name: "당기순이익", NameEn: "Net income for the year",
+				AccountType: row.AccountType, Amount: fs.NetIncome, Level: row.Level + 1,
+				ParentCode: row.Code, own: fs.NetIncome,
+			})
+		}
+	}
+	fs.Rows = rows
+}
+
+// ValidateRollup checks that the statement adds up level by level: every row
+// with children equals its own balance plus its children, every section total
+// the top rows of its section, and net income the revenue less the expenses.
+// It returns a message per row that does not add up.
+func (fs *FinancialStatement) ValidateRollup() []string {
+	children := make(map[string]float64)
+	sections := make(map[AccountType]float64)
+	for _, row := range fs.Rows {
+		switch {
+		case row.ParentCode != "":
+			children[row.ParentCode] += row.Amount
+		case !row.IsTotal && row.AccountType != "":
+			sections[row.AccountType] += row.Amount
+		}
+	}
+
+	var mismatches []string
+	check := func(row FinancialStatementRow, expected float64) {
+		if roundAmount(row.Amount-expected) != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s (level %d): %.2f does not match %.2f below it", row.Code, row.Level, row.Amount, roundAmount(expected)))
+		}
+	}
+	for _, row := range fs.Rows {
+		if row.HasChildren {
+			check(row, row.own+children[row.Code])
+		}
+		switch {
+		case row.IsTotal && row.AccountType != "":
+			check(row, sections[row.AccountType])
+		case row.Code == "total_liabilities_and_equity":
+			check(row, sections[AccountTypeLiability]+sections[AccountTypeEquity])
+		case fs.Kind == FinancialStatementIncomeStatement && row.Code == "net_income":
+			check(row, sections[AccountTypeRevenue]-sections[AccountTypeExpense])
+		}
+	}
+	return mismatches
+}
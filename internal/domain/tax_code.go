@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tax code errors
+var (
+	ErrTaxCodeNotFound     = errors.New("tax code not found")
+	ErrTaxCodeExists       = errors.New("tax code already exists")
+	ErrTaxCodeRequired     = errors.New("tax code is required")
+	ErrTaxCodeNameRequired = errors.New("tax code name is required")
+	ErrInvalidTaxType      = errors.New("invalid tax type")
+	ErrInvalidTaxCategory  = errors.New("invalid tax category")
+	ErrInvalidTaxRate      = errors.New("tax rate must be between 0 and 100")
+	ErrTaxCodeVATAccount   = errors.New("VAT account is required for taxable tax codes")
+	ErrTaxCodeInactive     = errors.New("tax code is inactive")
+	ErrTaxCodeInUse        = errors.New("tax code is used by voucher entries")
+)
+
+// TaxType represents the direction of VAT (부가가치세)
+type TaxType string
+
+const (
+	TaxTypeOutput TaxType = "output" // 매출세액
+	TaxTypeInput  TaxType = "input"  // 매입세액
+)
+
+// IsValid checks if the tax type is valid
+func (t TaxType) IsValid() bool {
+	return t == TaxTypeOutput || t == TaxTypeInput
+}
+
+// TaxCategory represents the VAT treatment of a supply
+type TaxCategory string
+
+const (
+	TaxCategoryTaxable   TaxCategory = "taxable"    // 과세
+	TaxCategoryZeroRated TaxCategory = "zero_rated" // 영세율
+	TaxCategoryExempt    TaxCategory = "exempt"     // 면세
+)
+
+// IsValid checks if the tax category is valid
+func (c TaxCategory) IsValid() bool {
+	switch c {
+	case TaxCategoryTaxable, TaxCategoryZeroRated, TaxCategoryExempt:
+		return true
+	}
+	return false
+}
+
+// TaxCode represents VAT master data applied to voucher entries
+type TaxCode struct {
+	TenantModel
+
+	// Identification
+	Code string `gorm:"type:varchar(20);not null" json:"code"`
+	Name string `gorm:"type:varchar(100);not null" json:"name"`
+
+	// Classification
+	TaxType     TaxType     `gorm:"type:varchar(20);not null" json:"tax_type"`
+	TaxCategory TaxCategory `gorm:"type:varchar(20);not null;default:taxable" json:"tax_category"`
+	Rate        float64     `gorm:"type:decimal(5,2);not null;default:0" json:"rate"`
+
+	// Input VAT deductibility (매입세액 공제 여부)
+	IsDeductible bool `gorm:"default:true" json:"is_deductible"`
+
+	// Account receiving the generated VAT line (e.g., 부가세예수금, 부가세대급금)
+	VATAccountID *uuid.UUID `gorm:"type:uuid" json:"vat_account_id,omitempty"`
+
+	IsActive bool `gorm:"default:true" json:"is_active"`
+
+	// Relations
+	VATAccount *Account `gorm:"foreignKey:VATAccountID" json:"vat_account,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (TaxCode) TableName() string {
+	return "tax_codes"
+}
+
+// Validate validates the tax code data
+func (t *TaxCode) Validate() error {
+	if t.Code == "" {
+		return ErrTaxCodeRequired
+	}
+	if t.Name == "" {
+		return ErrTaxCodeNameRequired
+	}
+	if !t.TaxType.IsValid() {
+		return ErrInvalidTaxType
+	}
+	if !t.TaxCategory.IsValid() {
+		return ErrInvalidTaxCategory
+	}
+	if t.Rate < 0 || t.Rate > 100 {
+		return ErrInvalidTaxRate
+	}
+	if t.GeneratesVATLine() && t.VATAccountID == nil {
+		return ErrTaxCodeVATAccount
+	}
+	return nil
+}
+
+// GeneratesVATLine returns true if entries with this code get a separate VAT line.
+// Non-deductible input VAT stays in the expense/asset account.
+func (t *TaxCode) GeneratesVATLine() bool {
+	if t.TaxCategory != TaxCategoryTaxable || t.Rate == 0 {
+		return false
+	}
+	return t.TaxType == TaxTypeOutput || t.IsDeductible
+}
+
+// SplitInclusive splits a tax-inclusive amount into supply amount and VAT.
+// VAT below one won is truncated (원 미만 절사).
+func (t *TaxCode) SplitInclusive(gross float64) (supply, tax float64) {
+	return t.SplitInclusiveFor(gross, DefaultCompanySettings())
+}
+
+// SplitInclusiveFor splits a tax-inclusive amount into supply amount and VAT,
+// rounding the VAT with the company's decimal places and rounding rule
+func (t *TaxCode) SplitInclusiveFor(gross float64, settings CompanySettings) (supply, tax float64) {
+	if t.TaxCategory != TaxCategoryTaxable || t.Rate == 0 {
+		return gross, 0
+	}
+	tax = settings.RoundAmount(gross * t.Rate / (100 + t.Rate))
+	// Entry amounts are already rounded; this only drops float noise like 100.30000000000001
+	return RoundingHalfUp.Round(gross-tax, settings.DecimalPlaces), tax
+}
+
+// TaxOn returns the VAT on a supply amount, rounded like SplitInclusiveFor so
+// that splitting the total again gives the same VAT
+func (t *TaxCode) TaxOn(supply float64, settings CompanySettings) float64 {
+	if t.TaxCategory != TaxCategoryTaxable || t.Rate == 0 {
+		return 0
+	}
+	return settings.RoundAmount(supply * t.Rate / 100)
+}
+
+// VATSummaryItem represents aggregated supply and VAT amounts per tax code for the VAT return
+type VATSummaryItem struct {
+	TaxCodeID    uuid.UUID   `json:"tax_code_id"`
+	Code         string      `json:"code"`
+	Name         string      `json:"name"`
+	TaxType      TaxType     `json:"tax_type"`
+	TaxCategory  TaxCategory `json:"tax_category"`
+	Rate         float64     `json:"rate"`
+	IsDeductible bool        `json:"is_deductible"`
+	EntryCount   int         `json:"entry_count"`
+	SupplyAmount float64     `json:"supply_amount"`
+	TaxAmount    float64     `json:"tax_amount"`
+}
+
+// VATReturn represents the VAT return (부가가치세 신고) figures for a tax period
+type VATReturn struct {
+	FromDate time.Time        `json:"from_date"`
+	ToDate   time.Time        `json:"to_date"`
+	Items    []VATSummaryItem `json:"items"`
+
+	// 매출
+	OutputSupplyAmount float64 `json:"output_supply_amount"`
+	OutputTaxAmount    float64 `json:"output_tax_amount"`
+
+	// 매입
+	InputSupplyAmount        float64 `json:"input_supply_amount"`
+	DeductibleInputTaxAmount float64 `json:"deductible_input_tax_amount"`
+	NonDeductibleInputTax    float64 `json:"non_deductible_input_tax"`
+
+	// 납부(환급)세액
+	PayableTaxAmount float64 `json:"payable_tax_amount"`
+}
+
+// NewVATReturn builds a VAT return from per-tax-code summaries
+func NewVATReturn(from, to time.Time, items []VATSummaryItem) *VATReturn {
+	r := &VATReturn{FromDate: from, ToDate: to, Items: items}
+	for _, item := range items {
+		switch item.TaxType {
+		case TaxTypeOutput:
+			r.OutputSupplyAmount += item.SupplyAmount
+			r.OutputTaxAmount += item.TaxAmount
+		case TaxTypeInput:
+			r.InputSupplyAmount += item.SupplyAmount
+			if item.IsDeductible {
+				r.DeductibleInputTaxAmount += item.TaxAmount
+			} else {
+				r.NonDeductibleInputTax += item.TaxAmount
+			}
+		}
+	}
+	r.PayableTaxAmount = r.OutputTaxAmount - r.DeductibleInputTaxAmount
+	return r
+}
@@ -1,12 +1,16 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrTaxInvoiceNotFound is returned when a tax invoice does not exist
+var ErrTaxInvoiceNotFound = errors.New("tax invoice not found")
+
 // TaxInvoiceType represents the type of tax invoice.
 type TaxInvoiceType string
 
@@ -70,6 +74,11 @@ type TaxInvoice struct {
 	ASPProvider  string `json:"asp_provider,omitempty"`
 	ASPInvoiceID string `json:"asp_invoice_id,omitempty"`
 
+	// Amendment (수정세금계산서); the reason is zero for an original invoice
+	AmendmentReason          TaxInvoiceAmendmentReason `json:"amendment_reason,omitempty"`
+	OriginalInvoiceID        *uuid.UUID                `json:"original_invoice_id,omitempty"`
+	OriginalNTSConfirmNumber string                    `json:"original_nts_confirm_number,omitempty"`
+
 	// Linked voucher
 	VoucherID *uuid.UUID `json:"voucher_id,omitempty"`
 
@@ -191,3 +200,56 @@ func (t *TaxInvoice) CanBeCancelled() bool {
 func (t *TaxInvoice) IsTransmitted() bool {
 	return t.Status == TaxInvoiceStatusTransmitted || t.Status == TaxInvoiceStatusConfirmed
 }
+
+// TableName specifies the table name for GORM
+func (TaxInvoiceHistory) TableName() string {
+	return "tax_invoice_history"
+}
+
+// taxInvoiceStatusRank orders statuses along the issuance flow; cancelled and
+// rejected are final
+var taxInvoiceStatusRank = map[TaxInvoiceStatus]int{
+	TaxInvoiceStatusDraft:       0,
+	TaxInvoiceStatusIssued:      1,
+	TaxInvoiceStatusTransmitted: 2,
+	TaxInvoiceStatusConfirmed:   3,
+	TaxInvoiceStatusCancelled:   4,
+	TaxInvoiceStatusRejected:    4,
+}
+
+// CanAdvanceTo checks if moving to next goes forward in the issuance flow.
+// ASP notifications can arrive out of order, and an older state must not
+// overwrite a newer one.
+func (t *TaxInvoice) CanAdvanceTo(next TaxInvoiceStatus) bool {
+	current, ok := taxInvoiceStatusRank[t.Status]
+	if !ok {
+		return false
+	}
+	rank, ok := taxInvoiceStatusRank[next]
+	return ok && rank > current
+}
+
+// ApplyNTSResult records the NTS confirmation number and times reported by the
+// ASP, keeping values already set. It returns true if anything changed.
+func (t *TaxInvoice) ApplyNTSResult(confirmNumber string, transmittedAt, confirmedAt *time.Time) bool {
+	changed := false
+	if confirmNumber != "" && t.NTSConfirmNumber == "" {
+		t.NTSConfirmNumber = confirmNumber
+		changed = true
+	}
+	if transmittedAt != nil && t.NTSTransmittedAt == nil {
+		t.NTSTransmittedAt = transmittedAt
+		changed = true
+	}
+	if confirmedAt != nil && t.NTSConfirmedAt == nil {
+		t.NTSConfirmedAt = confirmedAt
+		changed = true
+	}
+	return changed
+}
+
+// CanBeIssuedByASP checks if the invoice can be issued through an ASP.
+// Only supplier-side (sales) drafts are issued; purchase invoices are received.
+func (t *TaxInvoice) CanBeIssuedByASP() bool {
+	return t.Status == TaxInvoiceStatusDraft && t.InvoiceType == TaxInvoiceTypeSales
+}
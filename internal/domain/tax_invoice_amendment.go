@@ -0,0 +1,336 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tax invoice amendment errors
+var (
+	ErrInvalidTaxInvoiceAmendment = errors.New("invalid tax invoice amendment")
+	ErrTaxInvoiceNotAmendable     = errors.New("only issued sales invoices can be amended")
+	ErrTaxInvoiceAlreadyAmended   = errors.New("tax invoice was already cancelled by an amendment")
+)
+
+// TaxInvoiceAmendmentReason is the NTS reason code of an amended tax invoice (수정사유)
+type TaxInvoiceAmendmentReason int
+
+const (
+	TaxInvoiceAmendmentEntryError     TaxInvoiceAmendmentReason = 1 // 기재사항 착오·정정
+	TaxInvoiceAmendmentSupplyChange   TaxInvoiceAmendmentReason = 2 // 공급가액 변동
+	TaxInvoiceAmendmentReturn         TaxInvoiceAmendmentReason = 3 // 환입
+	TaxInvoiceAmendmentContractCancel TaxInvoiceAmendmentReason = 4 // 계약의 해제
+	TaxInvoiceAmendmentLocalLC        TaxInvoiceAmendmentReason = 5 // 내국신용장 사후개설
+	TaxInvoiceAmendmentDuplicate      TaxInvoiceAmendmentReason = 6 // 착오에 의한 이중발급
+)
+
+var taxInvoiceAmendmentLabels = map[TaxInvoiceAmendmentReason]string{
+	TaxInvoiceAmendmentEntryError:     "기재사항 착오·정정",
+	TaxInvoiceAmendmentSupplyChange:   "공급가액 변동",
+	TaxInvoiceAmendmentReturn:         "환입",
+	TaxInvoiceAmendmentContractCancel: "계약의 해제",
+	TaxInvoiceAmendmentLocalLC:        "내국신용장 사후개설",
+	TaxInvoiceAmendmentDuplicate:      "착오에 의한 이중발급",
+}
+
+// IsValid checks if the amendment reason is one of the six NTS reasons
+func (r TaxInvoiceAmendmentReason) IsValid() bool {
+	_, ok := taxInvoiceAmendmentLabels[r]
+	return ok
+}
+
+// Label returns the Korean name of the reason
+func (r TaxInvoiceAmendmentReason) Label() string {
+	return taxInvoiceAmendmentLabels[r]
+}
+
+// ReversesOriginal reports whether the amendment issues a negative invoice for
+// the whole original, leaving nothing of it to amend afterwards
+func (r TaxInvoiceAmendmentReason) ReversesOriginal() bool {
+	switch r {
+	case TaxInvoiceAmendmentEntryError, TaxInvoiceAmendmentContractCancel,
+		TaxInvoiceAmendmentLocalLC, TaxInvoiceAmendmentDuplicate:
+		return true
+	}
+	return false
+}
+
+// TaxInvoiceAmendment describes how an issued invoice is amended
+type TaxInvoiceAmendment struct {
+	Reason TaxInvoiceAmendmentReason
+
+	// IssueDate is the date of the change, return or cancellation. Entry errors,
+	// local L/C and duplicate issuance keep the date of the original.
+	IssueDate *time.Time
+
+	// SupplyAmount is the signed change of a supply change or the returned
+	// amount of a return. TaxAmount defaults to the tax rate of the original.
+	SupplyAmount int64
+	TaxAmount    *int64
+
+	// Correction holds the corrected values of an entry error
+	Correction *TaxInvoiceCorrection
+
+	Remarks string
+}
+
+// TaxInvoiceCorrection holds the corrected values of an invoice issued with
+// wrong entries; empty fields keep the original values
+type TaxInvoiceCorrection struct {
+	IssueDate           *time.Time
+	BuyerBusinessNumber string
+	BuyerName           string
+	BuyerCEOName        string
+	BuyerAddress        string
+	BuyerEmail          string
+	SupplyAmount        *int64
+	TaxAmount           *int64
+}
+
+// Validate checks that the amendment carries what its reason needs
+func (a *TaxInvoiceAmendment) Validate() error {
+	switch a.Reason {
+	case TaxInvoiceAmendmentEntryError:
+		if a.Correction == nil {
+			return fmt.Errorf("%w: correction is required for entry errors", ErrInvalidTaxInvoiceAmendment)
+		}
+	case TaxInvoiceAmendmentSupplyChange:
+		if a.IssueDate == nil || a.SupplyAmount == 0 {
+			return fmt.Errorf("%w: issue date and a non-zero supply amount change are required", ErrInvalidTaxInvoiceAmendment)
+		}
+	case TaxInvoiceAmendmentReturn:
+		if a.IssueDate == nil || a.SupplyAmount <= 0 {
+			return fmt.Errorf("%w: issue date and the returned supply amount are required", ErrInvalidTaxInvoiceAmendment)
+		}
+	case TaxInvoiceAmendmentContractCancel:
+		if a.IssueDate == nil {
+			return fmt.Errorf("%w: cancellation date is required", ErrInvalidTaxInvoiceAmendment)
+		}
+	case TaxInvoiceAmendmentLocalLC, TaxInvoiceAmendmentDuplicate:
+	default:
+		return fmt.Errorf("%w: unknown reason %d", ErrInvalidTaxInvoiceAmendment, a.Reason)
+	}
+	return nil
+}
+
+// CanBeAmended checks if the invoice can be amended. Amendments refer to the NTS
+// confirmation of an issued sales invoice; amended invoices are not amended
+// again, further amendments refer to the original.
+func (t *TaxInvoice) CanBeAmended() bool {
+	switch t.Status {
+	case TaxInvoiceStatusIssued, TaxInvoiceStatusTransmitted, TaxInvoiceStatusConfirmed:
+	default:
+		return false
+	}
+	return t.InvoiceType == TaxInvoiceTypeSales && t.AmendmentReason == 0
+}
+
+// BuildAmendedInvoices generates the draft amended invoices of the original, in
+// the order they are issued. Entry errors and local L/C produce a negative
+// invoice and a corrected one; cancellations and duplicates a negative invoice;
+// supply changes and returns an invoice for the difference. The original must
+// have its items loaded; invoices are numbered from the original's number and seq.
+func BuildAmendedInvoices(original *TaxInvoice, a *TaxInvoiceAmendment, seq int, userID uuid.UUID, now time.Time) ([]*TaxInvoice, error) {
+	if !original.CanBeAmended() {
+		return nil, ErrTaxInvoiceNotAmendable
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	var invoices []*TaxInvoice
+	next := func(issueDate time.Time, supply, tax int64) *TaxInvoice {
+		invoice := newAmendedInvoice(original, a, fmt.Sprintf("%s-M%d", original.InvoiceNumber, seq+len(invoices)), issueDate, supply, tax, userID, now)
+		invoices = append(invoices, invoice)
+		return invoice
+	}
+
+	switch a.Reason {
+	case TaxInvoiceAmendmentEntryError:
+		negative := next(original.IssueDate, -original.SupplyAmount, -original.TaxAmount)
+		negative.addItems(scaledItems(original.Items, -1, false))
+
+		c := a.Correction
+		issueDate := original.IssueDate
+		if c.IssueDate != nil {
+			issueDate = *c.IssueDate
+		}
+		supply, tax := original.SupplyAmount, original.TaxAmount
+		if c.SupplyAmount != nil {
+			supply = *c.SupplyAmount
+			tax = original.taxAtRate(supply)
+		}
+		if c.TaxAmount != nil {
+			tax = *c.TaxAmount
+		}
+		corrected := next(issueDate, supply, tax)
+		corrected.applyCorrection(c)
+		if supply == original.SupplyAmount && tax == original.TaxAmount {
+			corrected.addItems(scaledItems(original.Items, 1, false))
+		} else {
+			corrected.addItems([]TaxInvoiceItem{original.summaryItem(issueDate, supply, tax)})
+		}
+
+	case TaxInvoiceAmendmentSupplyChange, TaxInvoiceAmendmentReturn:
+		supply := a.SupplyAmount
+		if a.Reason == TaxInvoiceAmendmentReturn {
+			supply = -supply
+		}
+		tax := original.taxAtRate(supply)
+		if a.TaxAmount != nil {
+			tax = *a.TaxAmount
+			if a.Reason == TaxInvoiceAmendmentReturn {
+				tax = -tax
+			}
+		}
+		change := next(*a.IssueDate, supply, tax)
+		change.addItems([]TaxInvoiceItem{original.summaryItem(*a.IssueDate, supply, tax)})
+
+	case TaxInvoiceAmendmentContractCancel:
+		negative := next(*a.IssueDate, -original.SupplyAmount, -original.TaxAmount)
+		negative.addItems(scaledItems(original.Items, -1, false))
+
+	case TaxInvoiceAmendmentLocalLC:
+		negative := next(original.IssueDate, -original.SupplyAmount, -original.TaxAmount)
+		negative.addItems(scaledItems(original.Items, -1, false))
+		// The supply becomes zero-rated once the L/C is opened
+		zeroRated := next(original.IssueDate, original.SupplyAmount, 0)
+		zeroRated.addItems(scaledItems(original.Items, 1, true))
+
+	case TaxInvoiceAmendmentDuplicate:
+		negative := next(original.IssueDate, -original.SupplyAmount, -original.TaxAmount)
+		negative.addItems(scaledItems(original.Items, -1, false))
+	}
+
+	for _, invoice := range invoices {
+		if err := invoice.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTaxInvoiceAmendment, err)
+		}
+	}
+	return invoices, nil
+}
+
+// IsReversedBy reports whether one of the amendments cancelled the whole invoice
+func (t *TaxInvoice) IsReversedBy(amendments []*TaxInvoice) bool {
+	for _, amendment := range amendments {
+		if amendment.AmendmentReason.ReversesOriginal() && amendment.Status != TaxInvoiceStatusCancelled {
+			return true
+		}
+	}
+	return false
+}
+
+func newAmendedInvoice(original *TaxInvoice, a *TaxInvoiceAmendment, number string, issueDate time.Time, supply, tax int64, userID uuid.UUID, now time.Time) *TaxInvoice {
+	remarks := a.Remarks
+	if remarks == "" {
+		remarks = fmt.Sprintf("수정세금계산서(%s) 당초 %s", a.Reason.Label(), original.InvoiceNumber)
+	}
+	return &TaxInvoice{
+		ID:                       uuid.New(),
+		CompanyID:                original.CompanyID,
+		InvoiceNumber:            number,
+		InvoiceType:              original.InvoiceType,
+		IssueDate:                issueDate,
+		Status:                   TaxInvoiceStatusDraft,
+		SupplierBusinessNumber:   original.SupplierBusinessNumber,
+		SupplierName:             original.SupplierName,
+		SupplierCEOName:          original.SupplierCEOName,
+		SupplierAddress:          original.SupplierAddress,
+		SupplierBusinessType:     original.SupplierBusinessType,
+		SupplierBusinessItem:     original.SupplierBusinessItem,
+		SupplierEmail:            original.SupplierEmail,
+		BuyerBusinessNumber:      original.BuyerBusinessNumber,
+		BuyerName:                original.BuyerName,
+		BuyerCEOName:             original.BuyerCEOName,
+		BuyerAddress:             original.BuyerAddress,
+		BuyerBusinessType:        original.BuyerBusinessType,
+		BuyerBusinessItem:        original.BuyerBusinessItem,
+		BuyerEmail:               original.BuyerEmail,
+		SupplyAmount:             supply,
+		TaxAmount:                tax,
+		TotalAmount:              supply + tax,
+		AmendmentReason:          a.Reason,
+		OriginalInvoiceID:        &original.ID,
+		OriginalNTSConfirmNumber: original.NTSConfirmNumber,
+		Remarks:                  remarks,
+		CreatedBy:                &userID,
+		CreatedAt:                now,
+		UpdatedAt:                now,
+	}
+}
+
+func (t *TaxInvoice) applyCorrection(c *TaxInvoiceCorrection) {
+	if c.BuyerBusinessNumber != "" {
+		t.BuyerBusinessNumber = c.BuyerBusinessNumber
+	}
+	if c.BuyerName != "" {
+		t.BuyerName = c.BuyerName
+	}
+	if c.BuyerCEOName != "" {
+		t.BuyerCEOName = c.BuyerCEOName
+	}
+	if c.BuyerAddress != "" {
+		t.BuyerAddress = c.BuyerAddress
+	}
+	if c.BuyerEmail != "" {
+		t.BuyerEmail = c.BuyerEmail
+	}
+}
+
+// addItems attaches items to the invoice, numbering them in order
+func (t *TaxInvoice) addItems(items []TaxInvoiceItem) {
+	for i := range items {
+		items[i].ID = uuid.New()
+		items[i].TaxInvoiceID = t.ID
+		items[i].CompanyID = t.CompanyID
+		items[i].SequenceNumber = i + 1
+		items[i].CreatedAt = t.CreatedAt
+		items[i].UpdatedAt = t.CreatedAt
+	}
+	t.Items = items
+}
+
+// taxAtRate returns the tax on supply at the effective rate of the invoice
+func (t *TaxInvoice) taxAtRate(supply int64) int64 {
+	if t.SupplyAmount == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(supply) * float64(t.TaxAmount) / float64(t.SupplyAmount)))
+}
+
+// summaryItem is the single line of an invoice for an amount that does not
+// correspond to the original lines
+func (t *TaxInvoice) summaryItem(supplyDate time.Time, supply, tax int64) TaxInvoiceItem {
+	description := "수정"
+	if len(t.Items) > 0 {
+		description = t.Items[0].Description
+	}
+	return TaxInvoiceItem{
+		SupplyDate:  &supplyDate,
+		Description: description,
+		Quantity:    1,
+		UnitPrice:   float64(supply),
+		Amount:      supply,
+		TaxAmount:   tax,
+	}
+}
+
+// scaledItems copies items with quantities and amounts multiplied by sign;
+// zeroRated drops their tax
+func scaledItems(items []TaxInvoiceItem, sign int64, zeroRated bool) []TaxInvoiceItem {
+	copies := make([]TaxInvoiceItem, len(items))
+	for i, item := range items {
+		item.Quantity *= float64(sign)
+		item.Amount *= sign
+		item.TaxAmount *= sign
+		if zeroRated {
+			item.TaxAmount = 0
+		}
+		copies[i] = item
+	}
+	return copies
+}
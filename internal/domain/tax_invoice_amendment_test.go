@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func newIssuedTestInvoice() *domain.TaxInvoice {
+	id := uuid.New()
+	return &domain.TaxInvoice{
+		ID:                     id,
+		CompanyID:              uuid.New(),
+		InvoiceNumber:          "INV-001",
+		InvoiceType:            domain.TaxInvoiceTypeSales,
+		IssueDate:              time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Status:                 domain.TaxInvoiceStatusConfirmed,
+		SupplierBusinessNumber: "1234567890",
+		SupplierName:           "공급자",
+		BuyerBusinessNumber:    "2345678901",
+		BuyerName:              "공급받는자",
+		SupplyAmount:           1000000,
+		TaxAmount:              100000,
+		TotalAmount:            1100000,
+		NTSConfirmNumber:       "20240110-41000000-00000001",
+		Items: []domain.TaxInvoiceItem{
+			{TaxInvoiceID: id, Description: "컨설팅", Quantity: 2, UnitPrice: 500000, Amount: 1000000, TaxAmount: 100000},
+		},
+	}
+}
+
+func TestBuildAmendedInvoices_EntryError(t *testing.T) {
+	original := newIssuedTestInvoice()
+	amendment := &domain.TaxInvoiceAmendment{
+		Reason:     domain.TaxInvoiceAmendmentEntryError,
+		Correction: &domain.TaxInvoiceCorrection{BuyerBusinessNumber: "3456789012"},
+	}
+
+	invoices, err := domain.BuildAmendedInvoices(original, amendment, 1, uuid.New(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, invoices, 2)
+
+	negative, corrected := invoices[0], invoices[1]
+	assert.Equal(t, "INV-001-M1", negative.InvoiceNumber)
+	assert.Equal(t, int64(-1100000), negative.TotalAmount)
+	assert.Equal(t, original.IssueDate, negative.IssueDate)
+	assert.Equal(t, float64(-2), negative.Items[0].Quantity)
+	assert.Equal(t, negative.ID, negative.Items[0].TaxInvoiceID)
+
+	assert.Equal(t, "INV-001-M2", corrected.InvoiceNumber)
+	assert.Equal(t, "3456789012", corrected.BuyerBusinessNumber)
+	assert.Equal(t, int64(1100000), corrected.TotalAmount)
+	for _, invoice := range invoices {
+		assert.Equal(t, domain.TaxInvoiceStatusDraft, invoice.Status)
+		assert.Equal(t, domain.TaxInvoiceAmendmentEntryError, invoice.AmendmentReason)
+		assert.Equal(t, &original.ID, invoice.OriginalInvoiceID)
+		assert.Equal(t, original.NTSConfirmNumber, invoice.OriginalNTSConfirmNumber)
+	}
+}
+
+func TestBuildAmendedInvoices_SupplyChangeAndReturn(t *testing.T) {
+	original := newIssuedTestInvoice()
+	changeDate := time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)
+
+	invoices, err := domain.BuildAmendedInvoices(original, &domain.TaxInvoiceAmendment{
+		Reason: domain.TaxInvoiceAmendmentSupplyChange, IssueDate: &changeDate, SupplyAmount: 200000,
+	}, 3, uuid.New(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+	assert.Equal(t, "INV-001-M3", invoices[0].InvoiceNumber)
+	assert.Equal(t, changeDate, invoices[0].IssueDate)
+	assert.Equal(t, int64(20000), invoices[0].TaxAmount, "tax follows the rate of the original")
+
+	invoices, err = domain.BuildAmendedInvoices(original, &domain.TaxInvoiceAmendment{
+		Reason: domain.TaxInvoiceAmendmentReturn, IssueDate: &changeDate, SupplyAmount: 300000,
+	}, 1, uuid.New(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(-300000), invoices[0].SupplyAmount)
+	assert.Equal(t, int64(-30000), invoices[0].TaxAmount)
+	assert.Equal(t, int64(-330000), invoices[0].Items[0].Amount+invoices[0].Items[0].TaxAmount)
+}
+
+func TestBuildAmendedInvoices_LocalLC(t *testing.T) {
+	invoices, err := domain.BuildAmendedInvoices(newIssuedTestInvoice(), &domain.TaxInvoiceAmendment{
+		Reason: domain.TaxInvoiceAmendmentLocalLC,
+	}, 1, uuid.New(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, invoices, 2)
+	assert.Equal(t, int64(-1100000), invoices[0].TotalAmount)
+	assert.Equal(t, int64(1000000), invoices[1].TotalAmount, "the supply becomes zero-rated")
+	assert.Zero(t, invoices[1].Items[0].TaxAmount)
+}
+
+func TestBuildAmendedInvoices_Invalid(t *testing.T) {
+	original := newIssuedTestInvoice()
+
+	_, err := domain.BuildAmendedInvoices(original, &domain.TaxInvoiceAmendment{Reason: 7}, 1, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, domain.ErrInvalidTaxInvoiceAmendment)
+	_, err = domain.BuildAmendedInvoices(original, &domain.TaxInvoiceAmendment{Reason: domain.TaxInvoiceAmendmentContractCancel}, 1, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, domain.ErrInvalidTaxInvoiceAmendment, "cancellation date is required")
+
+	original.Status = domain.TaxInvoiceStatusDraft
+	_, err = domain.BuildAmendedInvoices(original, &domain.TaxInvoiceAmendment{Reason: domain.TaxInvoiceAmendmentDuplicate}, 1, uuid.New(), time.Now())
+	assert.ErrorIs(t, err, domain.ErrTaxInvoiceNotAmendable)
+}
+
+func TestTaxInvoice_IsReversedBy(t *testing.T) {
+	original := newIssuedTestInvoice()
+	change := &domain.TaxInvoice{AmendmentReason: domain.TaxInvoiceAmendmentSupplyChange, Status: domain.TaxInvoiceStatusIssued}
+	cancel := &domain.TaxInvoice{AmendmentReason: domain.TaxInvoiceAmendmentContractCancel, Status: domain.TaxInvoiceStatusDraft}
+
+	assert.False(t, original.IsReversedBy([]*domain.TaxInvoice{change}))
+	assert.True(t, original.IsReversedBy([]*domain.TaxInvoice{change, cancel}))
+
+	cancel.Status = domain.TaxInvoiceStatusCancelled
+	assert.False(t, original.IsReversedBy([]*domain.TaxInvoice{change, cancel}))
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tax invoice bulk issuance errors
+var (
+	ErrTaxInvoiceBulkIssueNotFound = errors.New("tax invoice bulk issuance not found")
+	ErrInvalidTaxInvoiceBulkIssue  = errors.New("invalid tax invoice bulk issuance")
+	ErrTaxInvoiceNotIssuable       = errors.New("only draft sales invoices can be issued")
+)
+
+// Error codes of failed bulk issuance items
+const (
+	BulkIssueErrorNotFound           = "NOT_FOUND"
+	BulkIssueErrorInvalidStatus      = "INVALID_STATUS"
+	BulkIssueErrorPopbillRejected    = "POPBILL_REJECTED"
+	BulkIssueErrorPopbillUnavailable = "POPBILL_UNAVAILABLE"
+	BulkIssueErrorInternal           = "INTERNAL_ERROR"
+)
+
+// TaxInvoiceBulkIssueStatus represents the outcome of a bulk issuance
+type TaxInvoiceBulkIssueStatus string
+
+const (
+	TaxInvoiceBulkIssueStatusProcessing      TaxInvoiceBulkIssueStatus = "processing"
+	TaxInvoiceBulkIssueStatusCompleted       TaxInvoiceBulkIssueStatus = "completed"
+	TaxInvoiceBulkIssueStatusPartiallyFailed TaxInvoiceBulkIssueStatus = "partially_failed"
+	TaxInvoiceBulkIssueStatusFailed          TaxInvoiceBulkIssueStatus = "failed"
+)
+
+// TaxInvoiceBulkIssueItemStatus represents the outcome for one invoice
+type TaxInvoiceBulkIssueItemStatus string
+
+const (
+	TaxInvoiceBulkIssueItemPending   TaxInvoiceBulkIssueItemStatus = "pending"
+	TaxInvoiceBulkIssueItemSucceeded TaxInvoiceBulkIssueItemStatus = "succeeded"
+	TaxInvoiceBulkIssueItemFailed    TaxInvoiceBulkIssueItemStatus = "failed"
+)
+
+// TaxInvoiceBulkIssue is a request to issue many draft invoices through Popbill.
+// It keeps the result of every invoice so that the failed ones can be retried.
+type TaxInvoiceBulkIssue struct {
+	TenantModel
+
+	Status         TaxInvoiceBulkIssueStatus `gorm:"type:varchar(20);not null;default:processing" json:"status"`
+	TotalCount     int                       `gorm:"not null;default:0" json:"total_count"`
+	SucceededCount int                       `gorm:"not null;default:0" json:"succeeded_count"`
+	FailedCount    int                       `gorm:"not null;default:0" json:"failed_count"`
+	Attempts       int                       `gorm:"not null;default:1" json:"attempts"`
+
+	RequestedBy *uuid.UUID `gorm:"type:uuid" json:"requested_by,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	Items []TaxInvoiceBulkIssueItem `gorm:"foreignKey:BulkIssueID" json:"items,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (TaxInvoiceBulkIssue) TableName() string {
+	return "tax_invoice_bulk_issues"
+}
+
+// TaxInvoiceBulkIssueItem is the result of issuing one invoice of a bulk issuance
+type TaxInvoiceBulkIssueItem struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	BulkIssueID  uuid.UUID `gorm:"type:uuid;not null" json:"bulk_issue_id"`
+	CompanyID    uuid.UUID `gorm:"type:uuid;not null" json:"company_id"`
+	TaxInvoiceID uuid.UUID `gorm:"type:uuid;not null" json:"tax_invoice_id"`
+	LineNo       int       `gorm:"not null" json:"line_no"`
+
+	Status           TaxInvoiceBulkIssueItemStatus `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	NTSConfirmNumber string                        `gorm:"type:varchar(50)" json:"nts_confirm_number,omitempty"`
+	ASPInvoiceID     string                        `gorm:"type:varchar(100)" json:"asp_invoice_id,omitempty"`
+	ErrorCode        string                        `gorm:"type:varchar(50)" json:"error_code,omitempty"`
+	ErrorMessage     string                        `gorm:"type:text" json:"error_message,omitempty"`
+	Attempts         int                           `gorm:"not null;default:0" json:"attempts"`
+
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (TaxInvoiceBulkIssueItem) TableName() string {
+	return "tax_invoice_bulk_issue_items"
+}
+
+// NewTaxInvoiceBulkIssue creates a bulk issuance of the invoices, in the given
+// order and without duplicates
+func NewTaxInvoiceBulkIssue(companyID, requestedBy uuid.UUID, invoiceIDs []uuid.UUID, maxInvoices int) (*TaxInvoiceBulkIssue, error) {
+	if len(invoiceIDs) == 0 || len(invoiceIDs) > maxInvoices {
+		return nil, ErrInvalidTaxInvoiceBulkIssue
+	}
+
+	issue := &TaxInvoiceBulkIssue{
+		TenantModel: TenantModel{BaseModel: BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		Status:      TaxInvoiceBulkIssueStatusProcessing,
+		Attempts:    1,
+		RequestedBy: &requestedBy,
+	}
+	seen := make(map[uuid.UUID]bool, len(invoiceIDs))
+	for _, id := range invoiceIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		issue.Items = append(issue.Items, TaxInvoiceBulkIssueItem{
+			ID:           uuid.New(),
+			BulkIssueID:  issue.ID,
+			CompanyID:    companyID,
+			TaxInvoiceID: id,
+			LineNo:       len(issue.Items) + 1,
+			Status:       TaxInvoiceBulkIssueItemPending,
+		})
+	}
+	issue.TotalCount = len(issue.Items)
+	return issue, nil
+}
+
+// Succeed records a successful issuance of the item's invoice
+func (i *TaxInvoiceBulkIssueItem) Succeed(invoice *TaxInvoice) {
+	i.Status = TaxInvoiceBulkIssueItemSucceeded
+	i.NTSConfirmNumber = invoice.NTSConfirmNumber
+	i.ASPInvoiceID = invoice.ASPInvoiceID
+	i.ErrorCode = ""
+	i.ErrorMessage = ""
+}
+
+// Fail records a failed issuance of the item's invoice
+func (i *TaxInvoiceBulkIssueItem) Fail(code string, err error) {
+	i.Status = TaxInvoiceBulkIssueItemFailed
+	i.ErrorCode = code
+	i.ErrorMessage = err.Error()
+}
+
+// Summarize counts the item results and sets the overall status
+func (b *TaxInvoiceBulkIssue) Summarize(at time.Time) {
+	b.SucceededCount, b.FailedCount = 0, 0
+	for _, item := range b.Items {
+		switch item.Status {
+		case TaxInvoiceBulkIssueItemSucceeded:
+			b.SucceededCount++
+		case TaxInvoiceBulkIssueItemFailed:
+			b.FailedCount++
+		}
+	}
+
+	switch {
+	case b.FailedCount == 0:
+		b.Status = TaxInvoiceBulkIssueStatusCompleted
+	case b.SucceededCount == 0:
+		b.Status = TaxInvoiceBulkIssueStatusFailed
+	default:
+		b.Status = TaxInvoiceBulkIssueStatusPartiallyFailed
+	}
+	b.CompletedAt = &at
+}
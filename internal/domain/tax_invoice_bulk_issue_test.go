@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestNewTaxInvoiceBulkIssue(t *testing.T) {
+	companyID, userID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+
+	issue, err := domain.NewTaxInvoiceBulkIssue(companyID, userID, []uuid.UUID{first, second, first}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, issue.TotalCount, "duplicates are issued once")
+	require.Len(t, issue.Items, 2)
+	assert.Equal(t, first, issue.Items[0].TaxInvoiceID)
+	assert.Equal(t, 2, issue.Items[1].LineNo)
+	assert.Equal(t, issue.ID, issue.Items[1].BulkIssueID)
+	assert.Equal(t, domain.TaxInvoiceBulkIssueItemPending, issue.Items[1].Status)
+
+	_, err = domain.NewTaxInvoiceBulkIssue(companyID, userID, nil, 3)
+	assert.ErrorIs(t, err, domain.ErrInvalidTaxInvoiceBulkIssue)
+	_, err = domain.NewTaxInvoiceBulkIssue(companyID, userID, []uuid.UUID{first, second}, 1)
+	assert.ErrorIs(t, err, domain.ErrInvalidTaxInvoiceBulkIssue)
+}
+
+func TestTaxInvoiceBulkIssue_Summarize(t *testing.T) {
+	issue, err := domain.NewTaxInvoiceBulkIssue(uuid.New(), uuid.New(), []uuid.UUID{uuid.New(), uuid.New()}, 10)
+	require.NoError(t, err)
+	at := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	issue.Items[0].Succeed(&domain.TaxInvoice{NTSConfirmNumber: "20240102-41000000-00000001", ASPInvoiceID: "024010200000000001"})
+	issue.Items[1].Fail(domain.BulkIssueErrorPopbillRejected, errors.New("duplicate management key"))
+	issue.Summarize(at)
+
+	assert.Equal(t, domain.TaxInvoiceBulkIssueStatusPartiallyFailed, issue.Status)
+	assert.Equal(t, 1, issue.SucceededCount)
+	assert.Equal(t, 1, issue.FailedCount)
+	assert.Equal(t, "duplicate management key", issue.Items[1].ErrorMessage)
+	assert.Equal(t, &at, issue.CompletedAt)
+
+	issue.Items[1].Succeed(&domain.TaxInvoice{ASPInvoiceID: "024010200000000002"})
+	issue.Summarize(at)
+	assert.Equal(t, domain.TaxInvoiceBulkIssueStatusCompleted, issue.Status)
+	assert.Empty(t, issue.Items[1].ErrorCode)
+
+	issue.Items[0].Fail(domain.BulkIssueErrorInternal, errors.New("boom"))
+	issue.Items[1].Fail(domain.BulkIssueErrorInternal, errors.New("boom"))
+	issue.Summarize(at)
+	assert.Equal(t, domain.TaxInvoiceBulkIssueStatusFailed, issue.Status)
+}
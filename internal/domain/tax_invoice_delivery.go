@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tax invoice delivery errors
+var (
+	ErrTaxInvoiceDeliveryNotFound = errors.New("tax invoice delivery not found")
+	ErrTaxInvoiceNotSendable      = errors.New("only issued invoices can be emailed")
+	ErrTaxInvoiceNoRecipient      = errors.New("tax invoice has no buyer email address")
+	ErrEmailBounceWebhookDisabled = errors.New("email bounce webhook is not configured")
+	ErrInvalidEmailBounceToken    = errors.New("invalid email bounce token")
+	ErrInvalidEmailBounceNotice   = errors.New("invalid email bounce notice")
+)
+
+// TaxInvoiceDeliveryStatus represents the state of an emailed tax invoice
+type TaxInvoiceDeliveryStatus string
+
+const (
+	TaxInvoiceDeliverySent    TaxInvoiceDeliveryStatus = "sent"
+	TaxInvoiceDeliveryFailed  TaxInvoiceDeliveryStatus = "failed"
+	TaxInvoiceDeliveryBounced TaxInvoiceDeliveryStatus = "bounced"
+)
+
+// TaxInvoiceDelivery records one email of a tax invoice to the buyer.
+// MessageID is the Message-ID header of the email, by which bounces are matched.
+type TaxInvoiceDelivery struct {
+	TenantModel
+
+	TaxInvoiceID uuid.UUID                `gorm:"type:uuid;not null" json:"tax_invoice_id"`
+	Recipient    string                   `gorm:"type:varchar(255);not null" json:"recipient"`
+	Cc           string                   `gorm:"type:text" json:"cc,omitempty"`
+	MessageID    string                   `gorm:"type:varchar(255);not null" json:"message_id"`
+	Status       TaxInvoiceDeliveryStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Error        string                   `gorm:"type:text" json:"error,omitempty"`
+
+	SentBy       *uuid.UUID `gorm:"type:uuid" json:"sent_by,omitempty"`
+	SentAt       time.Time  `gorm:"not null" json:"sent_at"`
+	BouncedAt    *time.Time `json:"bounced_at,omitempty"`
+	BounceReason string     `gorm:"type:text" json:"bounce_reason,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (TaxInvoiceDelivery) TableName() string {
+	return "tax_invoice_deliveries"
+}
+
+// CanBeSent checks if the invoice can be emailed to the buyer. Drafts are not
+// yet valid invoices, and cancelled or rejected ones must not reach the buyer.
+func (t *TaxInvoice) CanBeSent() bool {
+	return t.Status == TaxInvoiceStatusIssued || t.IsTransmitted()
+}
+
+// Bounce marks the delivery as bounced. It returns false if it was already
+// bounced or never sent, so that repeated notices are recorded once.
+func (d *TaxInvoiceDelivery) Bounce(reason string, at time.Time) bool {
+	if d.Status != TaxInvoiceDeliverySent {
+		return false
+	}
+	d.Status = TaxInvoiceDeliveryBounced
+	d.BouncedAt = &at
+	d.BounceReason = reason
+	return true
+}
+
+// EmailBounce is a bounce notice received from the mail provider
+type EmailBounce struct {
+	MessageID string
+	Recipient string
+	Reason    string
+	BouncedAt time.Time
+}
+
+// NormalizeMessageID returns a Message-ID with its angle brackets, as sent in
+// the header; providers report it with or without them.
+func NormalizeMessageID(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	return "<" + strings.Trim(id, "<>") + ">"
+}
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Travel policy errors
+var (
+	ErrTravelPolicyNotFound       = errors.New("travel policy not found")
+	ErrInvalidTravelPolicy        = errors.New("invalid travel policy")
+	ErrTravelPolicyExists         = errors.New("travel policy name already exists")
+	ErrTravelPolicyScopeExists    = errors.New("department has an active travel policy")
+	ErrSettlementReviewNotPending = errors.New("settlement has no violations pending review")
+	ErrSettlementReviewForbidden  = errors.New("user may not review the policy violations")
+)
+
+// ExpenseCategory classifies an expense claimed against the travel policy
+type ExpenseCategory string
+
+const (
+	ExpenseGeneral ExpenseCategory = "general"  // checked for its receipt only
+	ExpensePerDiem ExpenseCategory = "per_diem" // daily allowance (일비) for the days at the destination
+	ExpenseMileage ExpenseCategory = "mileage"  // own car use (자가운전) by the kilometre
+)
+
+// IsValid checks if the category is valid
+func (c ExpenseCategory) IsValid() bool {
+	switch c {
+	case ExpenseGeneral, ExpensePerDiem, ExpenseMileage:
+		return true
+	}
+	return false
+}
+
+// PerDiemRate is the daily allowance for travel to a destination by employees
+// of a grade. An empty destination or grade matches any.
+type PerDiemRate struct {
+	Destination string `json:"destination,omitempty"` // e.g. 국내, 서울, 해외
+	Grade       string `json:"grade,omitempty"`       // 직급 of the employee
+	DailyAmount int64  `json:"daily_amount"`
+}
+
+// TravelPolicy is the travel expense policy (여비규정) of the company, or of a
+// department it overrides. Claims breaking it are flagged for the approver.
+type TravelPolicy struct {
+	TenantModel
+
+	Name         string     `gorm:"type:varchar(100);not null" json:"name"`
+	DepartmentID *uuid.UUID `gorm:"type:uuid" json:"department_id,omitempty"` // nil for the whole company
+
+	PerDiemRates     []PerDiemRate `gorm:"type:jsonb;serializer:json" json:"per_diem_rates"`
+	ReceiptThreshold int64         `gorm:"not null;default:0" json:"receipt_threshold"` // expenses from this amount need a receipt, 0 for none
+	MileageRate      int64         `gorm:"not null;default:0" json:"mileage_rate"`      // per km, 0 when mileage is not paid
+
+	IsActive  bool       `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (TravelPolicy) TableName() string {
+	return "travel_policies"
+}
+
+// Validate checks the policy and normalizes its rates
+func (p *TravelPolicy) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || p.ReceiptThreshold < 0 || p.MileageRate < 0 {
+		return ErrInvalidTravelPolicy
+	}
+	seen := make(map[PerDiemRate]bool, len(p.PerDiemRates))
+	for i := range p.PerDiemRates {
+		rate := &p.PerDiemRates[i]
+		rate.Destination = strings.TrimSpace(rate.Destination)
+		rate.Grade = strings.TrimSpace(rate.Grade)
+		if rate.DailyAmount <= 0 {
+			return ErrInvalidTravelPolicy
+		}
+		key := PerDiemRate{Destination: strings.ToLower(rate.Destination), Grade: strings.ToLower(rate.Grade)}
+		if seen[key] {
+			return ErrInvalidTravelPolicy
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// PerDiem returns the daily allowance for the destination and grade. A rate
+// of both wins over one of the destination, then of the grade, then a rate
+// matching any.
+func (p *TravelPolicy) PerDiem(destination, grade string) (int64, bool) {
+	best, bestScore := int64(0), -1
+	for _, rate := range p.PerDiemRates {
+		score := 0
+		switch {
+		case rate.Destination == "":
+		case strings.EqualFold(rate.Destination, strings.TrimSpace(destination)):
+			score += 2
+		default:
+			continue
+		}
+		switch {
+		case rate.Grade == "":
+		case strings.EqualFold(rate.Grade, strings.TrimSpace(grade)):
+			score++
+		default:
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = rate.DailyAmount, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// TravelViolationRule identifies the policy rule a claimed expense breaks
+type TravelViolationRule string
+
+const (
+	TravelPerDiemExceeded TravelViolationRule = "per_diem_exceeded"
+	TravelNoPerDiemRate   TravelViolationRule = "no_per_diem_rate" // no rate covers the destination and grade
+	TravelMileageExceeded TravelViolationRule = "mileage_exceeded"
+	TravelMileageNotPaid  TravelViolationRule = "mileage_not_paid"
+	TravelReceiptMissing  TravelViolationRule = "receipt_missing"
+)
+
+// TravelPolicyViolation flags an expense of a claim breaking the policy
+type TravelPolicyViolation struct {
+	Line   int                 `json:"line"` // expense of the claim, from 1
+	Rule   TravelViolationRule `json:"rule"`
+	Amount int64               `json:"amount"`
+	Limit  int64               `json:"limit,omitempty"`
+	Detail string              `json:"detail"`
+}
+
+// Check returns the violations of the expenses claimed by an employee of the grade
+func (p *TravelPolicy) Check(expenses []AdvanceExpense, grade string) []TravelPolicyViolation {
+	var violations []TravelPolicyViolation
+	flag := func(line int, rule TravelViolationRule, amount, limit int64, detail string) {
+		violations = append(violations, TravelPolicyViolation{Line: line, Rule: rule, Amount: amount, Limit: limit, Detail: detail})
+	}
+
+	for i, expense := range expenses {
+		line := i + 1
+		switch expense.Category {
+		case ExpensePerDiem:
+			daily, ok := p.PerDiem(expense.Destination, grade)
+			if !ok {
+				flag(line, TravelNoPerDiemRate, expense.Amount, 0,
+					fmt.Sprintf("no per diem for %q and grade %q", expense.Destination, grade))
+				break
+			}
+			if limit := daily * int64(expense.Days); expense.Amount > limit {
+				flag(line, TravelPerDiemExceeded, expense.Amount, limit,
+					fmt.Sprintf("%d days at %d a day", expense.Days, daily))
+			}
+		case ExpenseMileage:
+			if p.MileageRate == 0 {
+				flag(line, TravelMileageNotPaid, expense.Amount, 0, "mileage is not paid")
+				break
+			}
+			if limit := p.MileageRate * int64(expense.DistanceKm); expense.Amount > limit {
+				flag(line, TravelMileageExceeded, expense.Amount, limit,
+					fmt.Sprintf("%d km at %d per km", expense.DistanceKm, p.MileageRate))
+			}
+		}
+		// The allowances are paid by the rates and need no receipt
+		if expense.Category == ExpenseGeneral && p.ReceiptThreshold > 0 &&
+			expense.Amount >= p.ReceiptThreshold && expense.ReceiptNo == "" {
+			flag(line, TravelReceiptMissing, expense.Amount, p.ReceiptThreshold, "receipt required")
+		}
+	}
+	return violations
+}
+
+// SettlementReviewStatus tracks the approver's review of policy violations
+type SettlementReviewStatus string
+
+const (
+	SettlementReviewNone     SettlementReviewStatus = "none"    // no violations
+	SettlementReviewPending  SettlementReviewStatus = "pending" // violations waiting for the approver
+	SettlementReviewReviewed SettlementReviewStatus = "reviewed"
+)
+
+// IsValid checks if the status is valid
+func (s SettlementReviewStatus) IsValid() bool {
+	switch s {
+	case SettlementReviewNone, SettlementReviewPending, SettlementReviewReviewed:
+		return true
+	}
+	return false
+}
+
+// FlagViolations records the violations of the travel policy on the settlement
+func (s *EmployeeAdvanceSettlement) FlagViolations(policyID uuid.UUID, violations []TravelPolicyViolation) {
+	s.PolicyID = &policyID
+	s.PolicyViolations = violations
+	s.ReviewStatus = SettlementReviewNone
+	if len(violations) > 0 {
+		s.ReviewStatus = SettlementReviewPending
+	}
+}
+
+// ReviewViolations records that the approver reviewed the violations
+func (s *EmployeeAdvanceSettlement) ReviewViolations(userID uuid.UUID, note string) error {
+	if s.ReviewStatus != SettlementReviewPending {
+		return ErrSettlementReviewNotPending
+	}
+	now := time.Now()
+	s.ReviewStatus = SettlementReviewReviewed
+	s.ReviewedBy = &userID
+	s.ReviewedAt = &now
+	s.ReviewNote = strings.TrimSpace(note)
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestTravelPolicyPerDiem(t *testing.T) {
+	policy := &domain.TravelPolicy{
+		Name: "여비규정",
+		PerDiemRates: []domain.PerDiemRate{
+			{DailyAmount: 20000},
+			{Grade: "부장", DailyAmount: 30000},
+			{Destination: "해외", DailyAmount: 50000},
+			{Destination: "해외", Grade: " 부장 ", DailyAmount: 70000},
+		},
+	}
+	require.NoError(t, policy.Validate())
+
+	cases := []struct {
+		destination, grade string
+		want               int64
+	}{
+		{"서울", "사원", 20000},
+		{"서울", "부장", 30000},
+		{"해외", "사원", 50000},
+		{"해외", "부장", 70000},
+	}
+	for _, tc := range cases {
+		daily, ok := policy.PerDiem(tc.destination, tc.grade)
+		assert.True(t, ok)
+		assert.Equal(t, tc.want, daily, "%s %s", tc.destination, tc.grade)
+	}
+
+	_, ok := (&domain.TravelPolicy{PerDiemRates: []domain.PerDiemRate{{Destination: "해외", DailyAmount: 50000}}}).PerDiem("서울", "")
+	assert.False(t, ok)
+
+	policy.PerDiemRates = append(policy.PerDiemRates, domain.PerDiemRate{Destination: "해외", Grade: "부장", DailyAmount: 1})
+	assert.ErrorIs(t, policy.Validate(), domain.ErrInvalidTravelPolicy)
+}
+
+func TestTravelPolicyCheck(t *testing.T) {
+	policy := &domain.TravelPolicy{
+		Name:             "여비규정",
+		PerDiemRates:     []domain.PerDiemRate{{Destination: "국내", DailyAmount: 25000}},
+		ReceiptThreshold: 30000,
+		MileageRate:      300,
+	}
+	expenses := []domain.AdvanceExpense{
+		{Category: domain.ExpensePerDiem, Destination: "국내", Days: 2, Amount: 50000},
+		{Category: domain.ExpensePerDiem, Destination: "국내", Days: 2, Amount: 60000},
+		{Category: domain.ExpensePerDiem, Destination: "해외", Days: 1, Amount: 10000},
+		{Category: domain.ExpenseMileage, DistanceKm: 120, Amount: 40000},
+		{Category: domain.ExpenseGeneral, Amount: 80000, ReceiptNo: "R-1"},
+		{Category: domain.ExpenseGeneral, Amount: 45000},
+		{Category: domain.ExpenseGeneral, Amount: 29000},
+	}
+
+	violations := policy.Check(expenses, "대리")
+	require.Len(t, violations, 4)
+	assert.Equal(t, domain.TravelPerDiemExceeded, violations[0].Rule)
+	assert.Equal(t, 2, violations[0].Line)
+	assert.Equal(t, int64(50000), violations[0].Limit)
+	assert.Equal(t, domain.TravelNoPerDiemRate, violations[1].Rule)
+	assert.Equal(t, domain.TravelMileageExceeded, violations[2].Rule)
+	assert.Equal(t, int64(36000), violations[2].Limit)
+	assert.Equal(t, domain.TravelReceiptMissing, violations[3].Rule)
+	assert.Equal(t, 6, violations[3].Line)
+
+	settlement := &domain.EmployeeAdvanceSettlement{}
+	settlement.FlagViolations(uuid.New(), violations)
+	assert.Equal(t, domain.SettlementReviewPending, settlement.ReviewStatus)
+	require.NoError(t, settlement.ReviewViolations(uuid.New(), "출장 일정 연장 확인"))
+	assert.Equal(t, domain.SettlementReviewReviewed, settlement.ReviewStatus)
+	assert.ErrorIs(t, settlement.ReviewViolations(uuid.New(), ""), domain.ErrSettlementReviewNotPending)
+
+	clean := &domain.EmployeeAdvanceSettlement{}
+	clean.FlagViolations(uuid.New(), policy.Check(expenses[:1], "대리"))
+	assert.Equal(t, domain.SettlementReviewNone, clean.ReviewStatus)
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Usage errors
+var (
+	ErrInvalidUsagePeriod = errors.New("usage period must be a month between from and to, at most 24 months long")
+)
+
+// MaxUsagePeriodMonths is the longest range of months returned by a usage query
+const MaxUsagePeriodMonths = 24
+
+// UsageMetric is a billable quantity of a tenant
+type UsageMetric string
+
+const (
+	// UsageMetricActiveUsers is the peak number of active members in the month
+	UsageMetricActiveUsers UsageMetric = "active_users"
+	// UsageMetricVouchers is the number of vouchers created in the month
+	UsageMetricVouchers UsageMetric = "vouchers"
+	// UsageMetricTaxInvoices is the number of sales tax invoices issued in the month
+	UsageMetricTaxInvoices UsageMetric = "tax_invoices"
+)
+
+// UsageMetrics lists the billable metrics in display order
+var UsageMetrics = []UsageMetric{UsageMetricActiveUsers, UsageMetricVouchers, UsageMetricTaxInvoices}
+
+// IsValid checks if the metric is valid
+func (m UsageMetric) IsValid() bool {
+	switch m {
+	case UsageMetricActiveUsers, UsageMetricVouchers, UsageMetricTaxInvoices:
+		return true
+	}
+	return false
+}
+
+// IsGauge reports whether the metric is a level sampled during the month
+// rather than a count of events
+func (m UsageMetric) IsGauge() bool {
+	return m == UsageMetricActiveUsers
+}
+
+// LocalizedLabel returns the metric label in the locale
+func (m UsageMetric) LocalizedLabel(loc i18n.Locale) string {
+	if label := i18n.Label(loc, "usage_metric", string(m)); label != "" {
+		return label
+	}
+	return string(m)
+}
+
+// usageLocation is the time zone of billing months
+var usageLocation = time.FixedZone("KST", 9*60*60)
+
+// UsagePeriodOf returns the first day of the billing month of t. Billing
+// months follow Korean time.
+func UsagePeriodOf(t time.Time) time.Time {
+	t = t.In(usageLocation)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, usageLocation)
+}
+
+// UsageEvent is a billable event. Voucher creation and tax invoice issuance
+// are recorded by database triggers in the transaction of the change.
+type UsageEvent struct {
+	ID         uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID  uuid.UUID   `gorm:"type:uuid;not null" json:"company_id"`
+	Metric     UsageMetric `gorm:"type:varchar(30);not null" json:"metric"`
+	Quantity   int64       `gorm:"not null;default:1" json:"quantity"`
+	SourceID   *uuid.UUID  `gorm:"type:uuid" json:"source_id,omitempty"` // the voucher or invoice; unique per metric
+	OccurredAt time.Time   `gorm:"not null" json:"occurred_at"`
+	CreatedAt  time.Time   `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for UsageEvent
+func (UsageEvent) TableName() string {
+	return "usage_events"
+}
+
+// UsageRecord is the monthly usage of a metric by a company, aggregated by
+// the worker and exported to the billing provider once the month has closed
+type UsageRecord struct {
+	TenantModel
+	Period          time.Time   `gorm:"type:date;not null" json:"period"` // first day of the month
+	Metric          UsageMetric `gorm:"type:varchar(30);not null" json:"metric"`
+	Quantity        int64       `gorm:"not null;default:0" json:"quantity"`
+	ExportedAt      *time.Time  `json:"exported_at,omitempty"`
+	ExportReference string      `gorm:"type:varchar(200)" json:"export_reference,omitempty"`
+	ExportError     string      `gorm:"type:text" json:"export_error,omitempty"`
+}
+
+// TableName returns the table name for UsageRecord
+func (UsageRecord) TableName() string {
+	return "usage_records"
+}
+
+// IsExported reports whether the record was reported to the billing provider
+func (r *UsageRecord) IsExported() bool {
+	return r.ExportedAt != nil
+}
+
+// IdempotencyKey identifies the record to the billing provider, so that a
+// retried export is not billed twice
+func (r *UsageRecord) IdempotencyKey() string {
+	return "kerp-usage-" + r.ID.String()
+}
+
+// PendingUsageExport is a record of a closed month waiting for export, with
+// the billing customer of its company
+type PendingUsageExport struct {
+	UsageRecord
+	BillingCustomerID string `json:"billing_customer_id"`
+}
+
+// UsageMonth is the usage of a company in a month by metric
+type UsageMonth struct {
+	Period  time.Time
+	Records map[UsageMetric]*UsageRecord
+}
+
+// GroupUsageByMonth returns the months from..to (first days of the month),
+// newest first, with the records of each month. Months without records are
+// included with no records.
+func GroupUsageByMonth(records []UsageRecord, from, to time.Time) []UsageMonth {
+	byPeriod := make(map[string]map[UsageMetric]*UsageRecord)
+	for i := range records {
+		key := records[i].Period.Format("2006-01")
+		if byPeriod[key] == nil {
+			byPeriod[key] = make(map[UsageMetric]*UsageRecord)
+		}
+		byPeriod[key][records[i].Metric] = &records[i]
+	}
+
+	var months []UsageMonth
+	for period := to; !period.Before(from); period = period.AddDate(0, -1, 0) {
+		month := UsageMonth{Period: period, Records: byPeriod[period.Format("2006-01")]}
+		if month.Records == nil {
+			month.Records = make(map[UsageMetric]*UsageRecord)
+		}
+		months = append(months, month)
+	}
+	return months
+}
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Usage Metering Tests
+// ============================================================================
+
+func TestUsagePeriodOf(t *testing.T) {
+	// 2026-09-30 20:00 UTC is already October in Korea
+	period := domain.UsagePeriodOf(time.Date(2026, 9, 30, 20, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2026-10-01", period.Format("2006-01-02"))
+	assert.Equal(t, time.Date(2026, 9, 30, 15, 0, 0, 0, time.UTC), period.UTC())
+
+	// A month parsed as UTC stays in its month
+	parsed, err := time.Parse("2006-01", "2026-03")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01", domain.UsagePeriodOf(parsed).Format("2006-01-02"))
+}
+
+func TestGroupUsageByMonth(t *testing.T) {
+	from := domain.UsagePeriodOf(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC))
+	to := domain.UsagePeriodOf(time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC))
+
+	// Records read back from the date column are at UTC midnight
+	records := []domain.UsageRecord{
+		{Period: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Metric: domain.UsageMetricVouchers, Quantity: 120},
+		{Period: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Metric: domain.UsageMetricActiveUsers, Quantity: 4},
+		{Period: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Metric: domain.UsageMetricTaxInvoices, Quantity: 9},
+	}
+
+	months := domain.GroupUsageByMonth(records, from, to)
+	require.Len(t, months, 3)
+	assert.Equal(t, "2026-09", months[0].Period.Format("2006-01"))
+	assert.Equal(t, int64(120), months[0].Records[domain.UsageMetricVouchers].Quantity)
+	assert.Equal(t, int64(4), months[0].Records[domain.UsageMetricActiveUsers].Quantity)
+	assert.Empty(t, months[1].Records)
+	assert.Equal(t, "2026-07", months[2].Period.Format("2006-01"))
+	assert.Equal(t, int64(9), months[2].Records[domain.UsageMetricTaxInvoices].Quantity)
+}
+
+func TestUsageMetric(t *testing.T) {
+	for _, metric := range domain.UsageMetrics {
+		assert.True(t, metric.IsValid())
+	}
+	assert.False(t, domain.UsageMetric("seats").IsValid())
+	assert.True(t, domain.UsageMetricActiveUsers.IsGauge())
+	assert.False(t, domain.UsageMetricVouchers.IsGauge())
+}
@@ -53,6 +53,7 @@ var (
 	ErrUserLocked            = errors.New("user account is locked")
 	ErrInvalidUserStatus     = errors.New("invalid user status")
 	ErrInvalidUserRole       = errors.New("invalid user role")
+	ErrUserLastAdmin         = errors.New("cannot remove the last active administrator")
 	ErrEmailRequired         = errors.New("email is required")
 	ErrPasswordRequired      = errors.New("password is required")
 	ErrNameRequired          = errors.New("name is required")
@@ -64,12 +65,17 @@ var (
 // User represents a user in the system
 type User struct {
 	TenantModel
-	Email        string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_company_email" json:"email"`
-	PasswordHash string     `gorm:"type:varchar(255);not null" json:"-"`
-	Name         string     `gorm:"type:varchar(100);not null" json:"name"`
-	Role         UserRole   `gorm:"type:varchar(50);default:'user'" json:"role"`
-	Status       UserStatus `gorm:"type:varchar(20);default:'active'" json:"status"`
-	LastLoginAt  *time.Time `gorm:"" json:"last_login_at,omitempty"`
+	Email              string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_company_email" json:"email"`
+	PasswordHash       string     `gorm:"type:varchar(255);not null" json:"-"`
+	SigningPINHash     string     `gorm:"type:varchar(255)" json:"-"` // PIN confirming approval signatures
+	SigningPINAttempts int        `gorm:"->" json:"-"`                 // PINs confirmed since the last correct one, counted by the repository only
+	Name               string     `gorm:"type:varchar(100);not null" json:"name"`
+	Role               UserRole   `gorm:"type:varchar(50);default:'user'" json:"role"`
+	Grade              string     `gorm:"type:varchar(50)" json:"grade,omitempty"` // 직급, e.g. for the per diem rates of the travel policy
+	Status             UserStatus `gorm:"type:varchar(20);default:'active'" json:"status"`
+	LastLoginAt        *time.Time `gorm:"" json:"last_login_at,omitempty"`
+	EmailVerifiedAt    *time.Time `gorm:"" json:"email_verified_at,omitempty"`
+	MustChangePassword bool       `gorm:"default:false" json:"must_change_password"` // set by an administrator-forced reset
 }
 
 // TableName returns the table name for User
@@ -131,17 +137,77 @@ func (u *User) SetPassword(password string) error {
 	return nil
 }
 
+// SetSigningPIN sets the PIN used to confirm voucher approval signatures
+func (u *User) SetSigningPIN(pin string) error {
+	if err := ValidateSigningPIN(pin); err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.SigningPINHash = string(hash)
+	return nil
+}
+
+// HasSigningPIN returns true if the user has set a signing PIN
+func (u *User) HasSigningPIN() bool {
+	return u.SigningPINHash != ""
+}
+
+// CheckSigningPIN verifies the signing PIN against the stored hash
+func (u *User) CheckSigningPIN(pin string) bool {
+	if !u.HasSigningPIN() {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.SigningPINHash), []byte(pin)) == nil
+}
+
+// IsSigningPINLocked returns true if signing is locked after too many incorrect PINs
+func (u *User) IsSigningPINLocked() bool {
+	return u.SigningPINAttempts >= MaxSigningPINAttempts
+}
+
 // IsActive returns true if the user account is active
 func (u *User) IsActive() bool {
 	return u.Status == UserStatusActive
 }
 
+// IsEmailVerified returns true if the user has confirmed their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// VerifyEmail marks the email address as confirmed
+func (u *User) VerifyEmail() {
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+}
+
 // UpdateLastLogin updates the last login timestamp
 func (u *User) UpdateLastLogin() {
 	now := time.Now()
 	u.LastLoginAt = &now
 }
 
+// Anonymize replaces the user's personal data for a deletion request. The row
+// is kept, as vouchers and audit entries refer to it, but can no longer log in.
+func (u *User) Anonymize() {
+	u.Email = AnonymizedEmail(u.ID)
+	u.Name = AnonymizedUserName
+	u.PasswordHash = ""
+	u.SigningPINHash = ""
+	u.Status = UserStatusInactive
+	u.LastLoginAt = nil
+	u.EmailVerifiedAt = nil
+	u.MustChangePassword = false
+}
+
+// IsAnonymized returns true if the user's personal data was deleted
+func (u *User) IsAnonymized() bool {
+	return u.Email == AnonymizedEmail(u.ID)
+}
+
 // GetRoles returns the user's roles as a string slice
 func (u *User) GetRoles() []string {
 	return []string{string(u.Role)}
@@ -150,10 +216,11 @@ func (u *User) GetRoles() []string {
 // RefreshToken represents a refresh token for JWT authentication
 type RefreshToken struct {
 	BaseModel
-	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	Token     string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
-	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
-	Revoked   bool      `gorm:"default:false" json:"revoked"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CompanyID *uuid.UUID `gorm:"type:uuid" json:"company_id,omitempty"` // company the session was switched to; nil for the home company
+	Token     string     `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	Revoked   bool       `gorm:"default:false" json:"revoked"`
 }
 
 // TableName returns the table name for RefreshToken
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Membership errors
+var (
+	ErrMembershipNotFound = errors.New("user is not a member of the company")
+	ErrMembershipInactive = errors.New("company membership is inactive")
+)
+
+// UserCompanyMembership grants a user access to a company with a company-specific role,
+// e.g. an outsourced bookkeeper serving several client companies
+type UserCompanyMembership struct {
+	TenantModel
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_user_company_memberships_user_company" json:"user_id"`
+	Role      UserRole  `gorm:"type:varchar(50);not null;default:'user'" json:"role"`
+	IsDefault bool      `gorm:"default:false" json:"is_default"` // the user's home company
+	IsActive  bool      `gorm:"default:true" json:"is_active"`
+
+	// Relations
+	Company *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// TableName returns the table name for UserCompanyMembership
+func (UserCompanyMembership) TableName() string {
+	return "kerp.user_company_memberships"
+}
+
+// NewUserCompanyMembership creates an active membership of a user in a company
+func NewUserCompanyMembership(userID, companyID uuid.UUID, role UserRole) (*UserCompanyMembership, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidUserRole
+	}
+	return &UserCompanyMembership{
+		TenantModel: TenantModel{CompanyID: companyID},
+		UserID:      userID,
+		Role:        role,
+		IsActive:    true,
+	}, nil
+}
+
+// HomeMembership returns the membership implied by the user's own company
+func (u *User) HomeMembership() UserCompanyMembership {
+	return UserCompanyMembership{
+		TenantModel: TenantModel{CompanyID: u.CompanyID},
+		UserID:      u.ID,
+		Role:        u.Role,
+		IsDefault:   true,
+		IsActive:    u.IsActive(),
+	}
+}
+
+// GetRoles returns the membership role as a string slice for token claims
+func (m *UserCompanyMembership) GetRoles() []string {
+	return []string{string(m.Role)}
+}
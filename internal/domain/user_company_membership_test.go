@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// UserCompanyMembership Tests
+// ============================================================================
+
+func TestNewUserCompanyMembership(t *testing.T) {
+	userID, companyID := uuid.New(), uuid.New()
+
+	m, err := domain.NewUserCompanyMembership(userID, companyID, domain.UserRoleViewer)
+	require.NoError(t, err)
+	assert.Equal(t, userID, m.UserID)
+	assert.Equal(t, companyID, m.CompanyID)
+	assert.True(t, m.IsActive)
+	assert.False(t, m.IsDefault)
+	assert.Equal(t, []string{"viewer"}, m.GetRoles())
+
+	_, err = domain.NewUserCompanyMembership(userID, companyID, domain.UserRole("owner"))
+	assert.ErrorIs(t, err, domain.ErrInvalidUserRole)
+}
+
+func TestUserHomeMembership(t *testing.T) {
+	user := &domain.User{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: uuid.New()},
+		Role:        domain.UserRoleAdmin,
+		Status:      domain.UserStatusLocked,
+	}
+
+	home := user.HomeMembership()
+	assert.Equal(t, user.ID, home.UserID)
+	assert.Equal(t, user.CompanyID, home.CompanyID)
+	assert.Equal(t, domain.UserRoleAdmin, home.Role)
+	assert.True(t, home.IsDefault)
+	assert.False(t, home.IsActive)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTokenPurpose identifies what a one-time user token is for
+type UserTokenPurpose string
+
+const (
+	UserTokenPurposeInvite            UserTokenPurpose = "invite"
+	UserTokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+)
+
+// Default token lifetimes
+const (
+	DefaultInviteTTL            = 7 * 24 * time.Hour
+	DefaultEmailVerificationTTL = 48 * time.Hour
+)
+
+// User token errors
+var (
+	ErrUserTokenNotFound = errors.New("token not found")
+	ErrUserTokenExpired  = errors.New("token has expired")
+	ErrUserTokenUsed     = errors.New("token has already been used")
+	ErrAlreadyMember     = errors.New("user is already a member of the company")
+	ErrEmailVerified     = errors.New("email is already verified")
+)
+
+// UserToken is a one-time token emailed to a user: an invitation to join a company
+// or a self-registration email verification. Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	TenantModel
+	Purpose   UserTokenPurpose `gorm:"type:varchar(30);not null" json:"purpose"`
+	TokenHash string           `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Email     string           `gorm:"type:varchar(255);not null" json:"email"`
+	Role      UserRole         `gorm:"type:varchar(50)" json:"role,omitempty"` // role granted on accepting an invite
+	UserID    *uuid.UUID       `gorm:"type:uuid" json:"user_id,omitempty"`     // user whose email is verified
+	CreatedBy *uuid.UUID       `gorm:"type:uuid" json:"created_by,omitempty"`
+	ExpiresAt time.Time        `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time       `json:"used_at,omitempty"`
+
+	// Relations
+	Company *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+}
+
+// TableName returns the table name for UserToken
+func (UserToken) TableName() string {
+	return "kerp.user_tokens"
+}
+
+// NewUserToken creates a token for the purpose and returns it with the raw token value,
+// which is only ever sent to the recipient
+func NewUserToken(companyID uuid.UUID, purpose UserTokenPurpose, email string, ttl time.Duration) (*UserToken, string, error) {
+	if email == "" {
+		return nil, "", ErrEmailRequired
+	}
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, "", err
+	}
+	raw := hex.EncodeToString(bytes)
+
+	return &UserToken{
+		TenantModel: TenantModel{CompanyID: companyID},
+		Purpose:     purpose,
+		TokenHash:   HashUserToken(raw),
+		Email:       email,
+		ExpiresAt:   time.Now().Add(ttl),
+	}, raw, nil
+}
+
+// HashUserToken returns the stored form of a raw token
+func HashUserToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired checks if the token is past its expiry
+func (t *UserToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsUsed checks if the token has been consumed
+func (t *UserToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// Validate checks that the token can still be used
+func (t *UserToken) Validate() error {
+	if t.IsUsed() {
+		return ErrUserTokenUsed
+	}
+	if t.IsExpired() {
+		return ErrUserTokenExpired
+	}
+	return nil
+}
+
+// MarkUsed consumes the token
+func (t *UserToken) MarkUsed() {
+	now := time.Now()
+	t.UsedAt = &now
+}
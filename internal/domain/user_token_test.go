@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// UserToken Tests
+// ============================================================================
+
+func TestNewUserToken(t *testing.T) {
+	companyID := uuid.New()
+
+	token, raw, err := domain.NewUserToken(companyID, domain.UserTokenPurposeInvite, "new@example.com", time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, raw, 64)
+	assert.Equal(t, domain.HashUserToken(raw), token.TokenHash)
+	assert.NotEqual(t, raw, token.TokenHash)
+	assert.Equal(t, companyID, token.CompanyID)
+	assert.NoError(t, token.Validate())
+
+	_, raw2, err := domain.NewUserToken(companyID, domain.UserTokenPurposeInvite, "new@example.com", time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, raw, raw2)
+
+	_, _, err = domain.NewUserToken(companyID, domain.UserTokenPurposeInvite, "", time.Hour)
+	assert.ErrorIs(t, err, domain.ErrEmailRequired)
+}
+
+func TestUserToken_Validate(t *testing.T) {
+	token, _, err := domain.NewUserToken(uuid.New(), domain.UserTokenPurposeEmailVerification, "a@example.com", -time.Minute)
+	require.NoError(t, err)
+	assert.ErrorIs(t, token.Validate(), domain.ErrUserTokenExpired)
+
+	token.ExpiresAt = time.Now().Add(time.Hour)
+	token.MarkUsed()
+	assert.True(t, token.IsUsed())
+	assert.ErrorIs(t, token.Validate(), domain.ErrUserTokenUsed)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
 )
 
 // VoucherType represents the type of voucher
@@ -140,6 +142,9 @@ type Voucher struct {
 	// Attachments
 	AttachmentCount int `gorm:"default:0" json:"attachment_count"`
 
+	// Free-form tags for ad-hoc analyses, see VoucherTag
+	Tags []string `gorm:"type:jsonb;serializer:json;default:'[]'" json:"tags,omitempty"`
+
 	// Approval workflow
 	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
 	SubmittedBy *uuid.UUID `gorm:"type:uuid" json:"submitted_by,omitempty"`
@@ -152,11 +157,27 @@ type Voucher struct {
 	// Posting
 	PostedAt *time.Time `json:"posted_at,omitempty"`
 	PostedBy *uuid.UUID `gorm:"type:uuid" json:"posted_by,omitempty"`
+	ApprovalExemptReason string `gorm:"type:varchar(200)" json:"approval_exempt_reason,omitempty"` // set when posted on submit under the approval exemption
+
+	// Scheduled posting of an approved voucher by the worker
+	ScheduledPostDate *time.Time `gorm:"type:date" json:"scheduled_post_date,omitempty"`
+	ScheduledBy       *uuid.UUID `gorm:"type:uuid" json:"scheduled_by,omitempty"`
 
 	// Reversal
 	IsReversal    bool       `gorm:"default:false" json:"is_reversal"`
 	ReversalOfID  *uuid.UUID `gorm:"type:uuid" json:"reversal_of_id,omitempty"`
-	ReversedByID  *uuid.UUID `gorm:"type:uuid" json:"reversed_by_id,omitempty"`
+	ReversedByID  *uuid.UUID `gorm:"type:uuid" json:"reversed_by_id,omitempty"` // set once the voucher is fully reversed
+	ReversedAmount float64   `gorm:"type:decimal(18,2);not null;default:0" json:"reversed_amount"`
+
+	// Correction (대체 전표): a reversed posted voucher and the voucher replacing it
+	CorrectionOfID *uuid.UUID `gorm:"type:uuid" json:"correction_of_id,omitempty"`
+	CorrectedByID  *uuid.UUID `gorm:"type:uuid" json:"corrected_by_id,omitempty"`
+
+	// Auto-reversal (accruals/deferrals reversed on the first day of the next period)
+	AutoReverse bool `gorm:"default:false" json:"auto_reverse"`
+
+	// Set once the posted voucher was scored for anomalies
+	AnomalyScoredAt *time.Time `json:"anomaly_scored_at,omitempty"`
 
 	// Audit
 	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
@@ -166,6 +187,19 @@ type Voucher struct {
 	Entries      []VoucherEntry `gorm:"foreignKey:VoucherID" json:"entries,omitempty"`
 	ReversalOf   *Voucher       `gorm:"foreignKey:ReversalOfID" json:"reversal_of,omitempty"`
 	ReversedBy   *Voucher       `gorm:"foreignKey:ReversedByID" json:"reversed_by,omitempty"`
+	CorrectionOf *Voucher       `gorm:"foreignKey:CorrectionOfID" json:"correction_of,omitempty"`
+	CorrectedBy  *Voucher       `gorm:"foreignKey:CorrectedByID" json:"corrected_by,omitempty"`
+
+	// Workflow users and timeline, loaded for the voucher detail
+	Creator       *User                 `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+	Submitter     *User                 `gorm:"foreignKey:SubmittedBy" json:"submitter,omitempty"`
+	Approver      *User                 `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+	Rejecter      *User                 `gorm:"foreignKey:RejectedBy" json:"rejecter,omitempty"`
+	Poster        *User                 `gorm:"foreignKey:PostedBy" json:"poster,omitempty"`
+	StatusHistory []VoucherStatusChange `gorm:"foreignKey:VoucherID" json:"status_history,omitempty"`
+
+	// Existing vouchers the new voucher looks like, set by creation when the duplicate check warns
+	SuspectedDuplicates []Voucher `gorm:"-" json:"-"`
 }
 
 // TableName specifies the table name for GORM
@@ -173,6 +207,14 @@ func (Voucher) TableName() string {
 	return "vouchers"
 }
 
+// VoucherCorrection is a corrected posted voucher: the original, the reversal
+// cancelling it and the corrected voucher replacing it
+type VoucherCorrection struct {
+	Original   *Voucher `json:"original"`
+	Reversal   *Voucher `json:"reversal"`
+	Correction *Voucher `json:"correction"`
+}
+
 // Validate validates the voucher data
 func (v *Voucher) Validate() error {
 	if !v.VoucherType.IsValid() {
@@ -202,6 +244,25 @@ func (v *Voucher) CalculateTotals() {
 	}
 }
 
+// FiscalYear returns the fiscal year the voucher's entries are stored under.
+// Fiscal periods follow the calendar, so this is the year of the voucher date.
+func (v *Voucher) FiscalYear() int {
+	return v.VoucherDate.Year()
+}
+
+// AccountIDs returns the accounts the voucher's entries post to, each once
+func (v *Voucher) AccountIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(v.Entries))
+	var ids []uuid.UUID
+	for _, entry := range v.Entries {
+		if !seen[entry.AccountID] {
+			seen[entry.AccountID] = true
+			ids = append(ids, entry.AccountID)
+		}
+	}
+	return ids
+}
+
 // IsBalanced returns true if debit equals credit
 func (v *Voucher) IsBalanced() bool {
 	return v.TotalDebit == v.TotalCredit
@@ -271,6 +332,52 @@ func (v *Voucher) Post(userID uuid.UUID) error {
 	return nil
 }
 
+// PostWithoutApproval posts a draft, rejected or pending voucher directly,
+// for companies whose settings do not require approval
+func (v *Voucher) PostWithoutApproval(userID uuid.UUID) error {
+	if v.Status.CanSubmit() || v.Status == VoucherStatusPending {
+		if err := v.ValidateBalance(); err != nil {
+			return err
+		}
+		if len(v.Entries) == 0 {
+			return ErrVoucherNoEntries
+		}
+		v.Status = VoucherStatusApproved
+	}
+	return v.Post(userID)
+}
+
+// PostExempt posts a pending voucher that the company's approval exemption
+// lets skip the approval queue. The voucher keeps no approver; the reason is
+// recorded with the posting.
+func (v *Voucher) PostExempt(userID uuid.UUID, reason string) error {
+	if v.Status != VoucherStatusPending {
+		return ErrVoucherCannotPost
+	}
+	v.Status = VoucherStatusApproved
+	if err := v.Post(userID); err != nil {
+		return err
+	}
+	v.ApprovalExemptReason = reason
+	return nil
+}
+
+// AutoReversalDate returns the date of the automatic reversal,
+// which is the first day of the period following the voucher date
+func (v *Voucher) AutoReversalDate() time.Time {
+	year, month, _ := v.VoucherDate.Date()
+	return time.Date(year, month+1, 1, 0, 0, 0, 0, v.VoucherDate.Location())
+}
+
+// NeedsAutoReversal returns true if the voucher is due for automatic reversal as of the given date
+func (v *Voucher) NeedsAutoReversal(asOf time.Time) bool {
+	return v.AutoReverse &&
+		v.Status == VoucherStatusPosted &&
+		!v.IsReversal &&
+		v.ReversedByID == nil &&
+		!asOf.Before(v.AutoReversalDate())
+}
+
 // Cancel cancels the voucher
 func (v *Voucher) Cancel() error {
 	if v.Status == VoucherStatusPosted {
@@ -282,42 +389,20 @@ func (v *Voucher) Cancel() error {
 
 // GetTypeLabel returns Korean label for voucher type
 func (v *Voucher) GetTypeLabel() string {
-	switch v.VoucherType {
-	case VoucherTypeGeneral:
-		return "일반전표"
-	case VoucherTypeSales:
-		return "매출전표"
-	case VoucherTypePurchase:
-		return "매입전표"
-	case VoucherTypePayment:
-		return "지급전표"
-	case VoucherTypeReceipt:
-		return "입금전표"
-	case VoucherTypeAdjustment:
-		return "수정전표"
-	case VoucherTypeClosing:
-		return "결산전표"
-	default:
-		return ""
-	}
+	return v.LocalizedTypeLabel(i18n.Korean)
+}
+
+// LocalizedTypeLabel returns the voucher type label in the locale
+func (v *Voucher) LocalizedTypeLabel(loc i18n.Locale) string {
+	return i18n.Label(loc, "voucher_type", string(v.VoucherType))
 }
 
 // GetStatusLabel returns Korean label for voucher status
 func (v *Voucher) GetStatusLabel() string {
-	switch v.Status {
-	case VoucherStatusDraft:
-		return "작성중"
-	case VoucherStatusPending:
-		return "승인대기"
-	case VoucherStatusApproved:
-		return "승인완료"
-	case VoucherStatusPosted:
-		return "전기완료"
-	case VoucherStatusRejected:
-		return "반려"
-	case VoucherStatusCancelled:
-		return "취소"
-	default:
-		return ""
-	}
+	return v.LocalizedStatusLabel(i18n.Korean)
+}
+
+// LocalizedStatusLabel returns the voucher status label in the locale
+func (v *Voucher) LocalizedStatusLabel(loc i18n.Locale) string {
+	return i18n.Label(loc, "voucher_status", string(v.Status))
 }
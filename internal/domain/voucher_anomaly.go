@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Voucher anomaly errors
+var (
+	ErrVoucherAnomalyNotFound = errors.New("anomaly flag not found")
+	ErrVoucherAnomalyReviewed = errors.New("anomaly flag is already reviewed")
+	ErrInvalidAnomalyReview   = errors.New("anomaly flags are reviewed as dismissed or confirmed")
+)
+
+// AnomalyRule identifies the check that flagged a posting
+type AnomalyRule string
+
+const (
+	// AnomalyAmountOutlier is an entry amount far above the usual amounts of its account
+	AnomalyAmountOutlier AnomalyRule = "amount_outlier"
+	// AnomalyWeekendPosting is a voucher dated or posted on a Saturday or Sunday
+	AnomalyWeekendPosting AnomalyRule = "weekend_posting"
+	// AnomalyRoundAmount is a large entry of an exact number of millions
+	AnomalyRoundAmount AnomalyRule = "round_amount"
+)
+
+// IsValid checks if the rule is valid
+func (r AnomalyRule) IsValid() bool {
+	switch r {
+	case AnomalyAmountOutlier, AnomalyWeekendPosting, AnomalyRoundAmount:
+		return true
+	}
+	return false
+}
+
+// AnomalyFlagStatus represents the review status of an anomaly flag
+type AnomalyFlagStatus string
+
+const (
+	AnomalyFlagOpen      AnomalyFlagStatus = "open"
+	AnomalyFlagDismissed AnomalyFlagStatus = "dismissed" // reviewed, the posting is fine
+	AnomalyFlagConfirmed AnomalyFlagStatus = "confirmed" // reviewed, the posting needs correcting
+)
+
+// IsValid checks if the status is valid
+func (s AnomalyFlagStatus) IsValid() bool {
+	switch s {
+	case AnomalyFlagOpen, AnomalyFlagDismissed, AnomalyFlagConfirmed:
+		return true
+	}
+	return false
+}
+
+// Scoring thresholds
+const (
+	// AnomalyStatsLookbackMonths is how far back the posted amounts of an account
+	// are taken as its usual amounts
+	AnomalyStatsLookbackMonths = 12
+
+	// anomalyZScoreThreshold is the distance in standard deviations above the
+	// mean of the account beyond which an amount is an outlier
+	anomalyZScoreThreshold = 3.0
+
+	// anomalyMinSamples is the number of postings an account needs before its
+	// amounts are scored; newer accounts have no usual amount yet
+	anomalyMinSamples = 20
+
+	// anomalyRoundUnit and anomalyRoundMinimum flag amounts such as 5,000,000
+	anomalyRoundUnit    = 1000000
+	anomalyRoundMinimum = 1000000
+)
+
+// AccountAmountStats summarizes the posted entry amounts of an account
+type AccountAmountStats struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Count     int64     `json:"count"`
+	Mean      float64   `json:"mean"`
+	StdDev    float64   `json:"std_dev"`
+}
+
+// VoucherAnomalyFlag marks a posted voucher, or one of its entries, as unusual
+// for a controller to review
+type VoucherAnomalyFlag struct {
+	TenantModel
+
+	VoucherID uuid.UUID   `gorm:"type:uuid;not null" json:"voucher_id"`
+	EntryID   *uuid.UUID  `gorm:"type:uuid" json:"entry_id,omitempty"` // nil for flags on the whole voucher
+	AccountID *uuid.UUID  `gorm:"type:uuid" json:"account_id,omitempty"`
+	Rule      AnomalyRule `gorm:"type:varchar(30);not null" json:"rule"`
+	Score     float64     `gorm:"type:decimal(8,2);not null;default:0" json:"score"` // z-score of outliers, 1 for the heuristics
+	Detail    string      `gorm:"type:varchar(300)" json:"detail,omitempty"`
+
+	Status     AnomalyFlagStatus `gorm:"type:varchar(20);not null;default:open" json:"status"`
+	ReviewedBy *uuid.UUID        `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time        `json:"reviewed_at,omitempty"`
+	ReviewNote string            `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+
+	// Relations
+	Voucher *Voucher `gorm:"foreignKey:VoucherID" json:"voucher,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (VoucherAnomalyFlag) TableName() string {
+	return "voucher_anomaly_flags"
+}
+
+// Review records the controller's decision on the flag
+func (f *VoucherAnomalyFlag) Review(userID uuid.UUID, status AnomalyFlagStatus, note string) error {
+	if status != AnomalyFlagDismissed && status != AnomalyFlagConfirmed {
+		return ErrInvalidAnomalyReview
+	}
+	if f.Status != AnomalyFlagOpen {
+		return ErrVoucherAnomalyReviewed
+	}
+
+	now := time.Now()
+	f.Status = status
+	f.ReviewedBy = &userID
+	f.ReviewedAt = &now
+	f.ReviewNote = note
+	return nil
+}
+
+// ScoreVoucherAnomalies returns the flags of a posted voucher given the usual
+// amounts of its accounts
+func ScoreVoucherAnomalies(v *Voucher, stats map[uuid.UUID]AccountAmountStats) []VoucherAnomalyFlag {
+	var flags []VoucherAnomalyFlag
+	newFlag := func(rule AnomalyRule, score float64, detail string) VoucherAnomalyFlag {
+		return VoucherAnomalyFlag{
+			TenantModel: TenantModel{CompanyID: v.CompanyID},
+			VoucherID:   v.ID,
+			Rule:        rule,
+			Score:       score,
+			Detail:      detail,
+			Status:      AnomalyFlagOpen,
+		}
+	}
+
+	if day := weekendDay(v); day != "" {
+		flags = append(flags, newFlag(AnomalyWeekendPosting, 1, day))
+	}
+
+	for i := range v.Entries {
+		e := &v.Entries[i]
+		if e.IsTaxLine {
+			continue // follows the supply line it was split from
+		}
+		amount := e.DebitAmount + e.CreditAmount
+		entryID, accountID := e.ID, e.AccountID
+
+		if s, ok := stats[e.AccountID]; ok && s.Count >= anomalyMinSamples && s.StdDev > 0 {
+			z := (amount - s.Mean) / s.StdDev
+			if z >= anomalyZScoreThreshold {
+				flag := newFlag(AnomalyAmountOutlier, math.Round(z*100)/100,
+					fmt.Sprintf("amount %.0f against an account mean of %.0f over %d postings", amount, s.Mean, s.Count))
+				flag.EntryID, flag.AccountID = &entryID, &accountID
+				flags = append(flags, flag)
+			}
+		}
+
+		if amount >= anomalyRoundMinimum && math.Mod(amount, anomalyRoundUnit) == 0 {
+			flag := newFlag(AnomalyRoundAmount, 1, fmt.Sprintf("round amount %.0f", amount))
+			flag.EntryID, flag.AccountID = &entryID, &accountID
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// weekendDay describes why the voucher counts as a weekend posting, or returns
+// an empty string
+func weekendDay(v *Voucher) string {
+	isWeekend := func(t time.Time) bool {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	}
+	if isWeekend(v.VoucherDate) {
+		return "dated " + v.VoucherDate.Weekday().String()
+	}
+	if v.PostedAt != nil && isWeekend(*v.PostedAt) {
+		return "posted " + v.PostedAt.Weekday().String()
+	}
+	return ""
+}
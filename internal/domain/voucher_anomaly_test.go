@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestScoreVoucherAnomalies(t *testing.T) {
+	expenseID, cashID := uuid.New(), uuid.New()
+	newVoucher := func(date time.Time, amount float64) *domain.Voucher {
+		v := &domain.Voucher{VoucherDate: date}
+		v.ID = uuid.New()
+		debit := domain.VoucherEntry{AccountID: expenseID}
+		debit.SetDebit(amount)
+		credit := domain.VoucherEntry{AccountID: cashID}
+		credit.SetCredit(amount)
+		v.Entries = []domain.VoucherEntry{debit, credit}
+		return v
+	}
+	stats := map[uuid.UUID]domain.AccountAmountStats{
+		expenseID: {AccountID: expenseID, Count: 50, Mean: 100000, StdDev: 20000},
+		cashID:    {AccountID: cashID, Count: 5, Mean: 100000, StdDev: 20000}, // too few postings
+	}
+	wednesday := time.Date(2026, 9, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("usual amount on a weekday is not flagged", func(t *testing.T) {
+		assert.Empty(t, domain.ScoreVoucherAnomalies(newVoucher(wednesday, 120000), stats))
+	})
+
+	t.Run("flags amount outliers of accounts with enough postings", func(t *testing.T) {
+		flags := domain.ScoreVoucherAnomalies(newVoucher(wednesday, 180000), stats)
+
+		require.Len(t, flags, 1)
+		assert.Equal(t, domain.AnomalyAmountOutlier, flags[0].Rule)
+		assert.Equal(t, 4.0, flags[0].Score)
+		assert.Equal(t, &expenseID, flags[0].AccountID)
+		assert.Equal(t, domain.AnomalyFlagOpen, flags[0].Status)
+	})
+
+	t.Run("flags weekend postings and round amounts", func(t *testing.T) {
+		saturday := wednesday.AddDate(0, 0, 3)
+		flags := domain.ScoreVoucherAnomalies(newVoucher(saturday, 3000000), nil)
+
+		rules := make([]domain.AnomalyRule, len(flags))
+		for i, f := range flags {
+			rules[i] = f.Rule
+		}
+		assert.Equal(t, []domain.AnomalyRule{
+			domain.AnomalyWeekendPosting, domain.AnomalyRoundAmount, domain.AnomalyRoundAmount,
+		}, rules)
+		assert.Nil(t, flags[0].EntryID)
+		assert.Equal(t, "dated Saturday", flags[0].Detail)
+	})
+}
+
+func TestVoucherAnomalyFlag_Review(t *testing.T) {
+	flag := &domain.VoucherAnomalyFlag{Status: domain.AnomalyFlagOpen}
+	userID := uuid.New()
+
+	assert.Equal(t, domain.ErrInvalidAnomalyReview, flag.Review(userID, domain.AnomalyFlagOpen, ""))
+	require.NoError(t, flag.Review(userID, domain.AnomalyFlagDismissed, "annual insurance premium"))
+	assert.Equal(t, &userID, flag.ReviewedBy)
+	assert.Equal(t, domain.ErrVoucherAnomalyReviewed, flag.Review(userID, domain.AnomalyFlagConfirmed, ""))
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Voucher attachment errors
+var (
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrAttachmentEmpty           = errors.New("attachment file is empty")
+	ErrAttachmentTooLarge        = errors.New("attachment file is too large")
+	ErrAttachmentInfected        = errors.New("attachment contains malware and has been quarantined")
+	ErrAttachmentScanUnavailable = errors.New("attachment could not be scanned for malware; try again later")
+	ErrAttachmentTypeNotAllowed  = errors.New("attachment file type is not allowed")
+	ErrAttachmentTypeMismatch    = errors.New("attachment type does not match its extension")
+	ErrAttachmentNoThumbnail     = errors.New("attachment has no thumbnail")
+)
+
+// AttachmentScanStatus represents the virus scan state of a stored attachment
+type AttachmentScanStatus string
+
+const (
+	AttachmentScanClean     AttachmentScanStatus = "clean"     // scanned, no malware found
+	AttachmentScanUnscanned AttachmentScanStatus = "unscanned" // stored while no scanner was configured
+)
+
+// VoucherAttachment is a supporting document (증빙) of a voucher.
+// Infected files are never stored here; they are moved to QuarantinedFile.
+// FileType is sniffed from the content rather than taken from the client, and
+// images carry a JPEG thumbnail for previews.
+type VoucherAttachment struct {
+	ID            uuid.UUID            `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	VoucherID     uuid.UUID            `gorm:"type:uuid;not null;index" json:"voucher_id"`
+	CompanyID     uuid.UUID            `gorm:"type:uuid;not null" json:"company_id"`
+	FileName      string               `gorm:"type:varchar(255);not null" json:"file_name"`
+	FileSize      int64                `gorm:"not null" json:"file_size"`
+	FileType      string               `gorm:"type:varchar(100)" json:"file_type,omitempty"`
+	FileData      []byte               `gorm:"type:bytea" json:"-"`
+	ThumbnailData []byte               `gorm:"type:bytea" json:"-"` // JPEG, images only
+	HasThumbnail  bool                 `gorm:"not null;default:false" json:"has_thumbnail"`
+	ScanStatus    AttachmentScanStatus `gorm:"type:varchar(20);not null;default:unscanned" json:"scan_status"`
+	Scanner       string               `gorm:"type:varchar(50)" json:"scanner,omitempty"`
+	ScannedAt     *time.Time           `json:"scanned_at,omitempty"`
+	UploadedAt    time.Time            `gorm:"not null;default:now()" json:"uploaded_at"`
+	UploadedBy    *uuid.UUID           `gorm:"type:uuid" json:"uploaded_by,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (VoucherAttachment) TableName() string {
+	return "voucher_attachments"
+}
+
+// MarkScanned records a clean scan verdict
+func (a *VoucherAttachment) MarkScanned(scanner string, at time.Time) {
+	a.ScanStatus = AttachmentScanClean
+	a.Scanner = scanner
+	a.ScannedAt = &at
+}
+
+// QuarantinedFile is an uploaded file in which malware was detected, kept for
+// administrator review and never served to users
+type QuarantinedFile struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID  uuid.UUID  `gorm:"type:uuid;not null" json:"company_id"`
+	VoucherID  *uuid.UUID `gorm:"type:uuid" json:"voucher_id,omitempty"`
+	FileName   string     `gorm:"type:varchar(255);not null" json:"file_name"`
+	FileSize   int64      `gorm:"not null" json:"file_size"`
+	FileType   string     `gorm:"type:varchar(100)" json:"file_type,omitempty"`
+	FileData   []byte     `gorm:"type:bytea" json:"-"`
+	Signature  string     `gorm:"type:varchar(255);not null" json:"signature"`
+	Scanner    string     `gorm:"type:varchar(50);not null" json:"scanner"`
+	UploadedBy *uuid.UUID `gorm:"type:uuid" json:"uploaded_by,omitempty"`
+	IPAddress  string     `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	DetectedAt time.Time  `gorm:"not null;default:now()" json:"detected_at"`
+}
+
+// TableName specifies the table name for GORM
+func (QuarantinedFile) TableName() string {
+	return "quarantined_files"
+}
+
+// QuarantineAttachment builds the quarantine record of an infected attachment
+func QuarantineAttachment(a *VoucherAttachment, signature, scanner, ipAddress string) *QuarantinedFile {
+	voucherID := a.VoucherID
+	return &QuarantinedFile{
+		CompanyID:  a.CompanyID,
+		VoucherID:  &voucherID,
+		FileName:   a.FileName,
+		FileSize:   a.FileSize,
+		FileType:   a.FileType,
+		FileData:   a.FileData,
+		Signature:  signature,
+		Scanner:    scanner,
+		UploadedBy: a.UploadedBy,
+		IPAddress:  ipAddress,
+		DetectedAt: time.Now(),
+	}
+}
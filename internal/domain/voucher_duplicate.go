@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDuplicateCheck is returned for an unknown mode or window
+var ErrInvalidDuplicateCheck = errors.New("duplicate check mode must be off, warn or block with a window of 0 to 90 days")
+
+// DuplicateCheckMode determines what happens when a new voucher looks like an existing one
+type DuplicateCheckMode string
+
+const (
+	DuplicateCheckOff   DuplicateCheckMode = "off"
+	DuplicateCheckWarn  DuplicateCheckMode = "warn"  // created, with the suspected duplicates in the response
+	DuplicateCheckBlock DuplicateCheckMode = "block" // rejected with the suspected duplicates
+)
+
+// defaultDuplicateCheckDays is the window of companies that have not set one
+const defaultDuplicateCheckDays = 7
+
+// maxDuplicateCheckDays bounds the window, which is searched on every creation
+const maxDuplicateCheckDays = 90
+
+// IsValid checks if the mode is valid
+func (m DuplicateCheckMode) IsValid() bool {
+	switch m {
+	case DuplicateCheckOff, DuplicateCheckWarn, DuplicateCheckBlock:
+		return true
+	}
+	return false
+}
+
+// DuplicateCheck returns the configured mode; settings saved before the check
+// existed warn
+func (s CompanySettings) DuplicateCheck() DuplicateCheckMode {
+	if s.DuplicateCheckMode == "" {
+		return DuplicateCheckWarn
+	}
+	return s.DuplicateCheckMode
+}
+
+// DuplicateCheckWindow returns how many days before and after the voucher date
+// are searched for duplicates
+func (s CompanySettings) DuplicateCheckWindow() int {
+	if s.DuplicateCheckDays == nil {
+		return defaultDuplicateCheckDays
+	}
+	return *s.DuplicateCheckDays
+}
+
+// validateDuplicateCheck checks the duplicate check settings
+func (s CompanySettings) validateDuplicateCheck() error {
+	if s.DuplicateCheckMode != "" && !s.DuplicateCheckMode.IsValid() {
+		return ErrInvalidDuplicateCheck
+	}
+	if days := s.DuplicateCheckWindow(); days < 0 || days > maxDuplicateCheckDays {
+		return ErrInvalidDuplicateCheck
+	}
+	return nil
+}
+
+// DuplicateVoucherError is returned when a voucher is blocked as a suspected duplicate
+type DuplicateVoucherError struct {
+	Duplicates []Voucher
+}
+
+func (e *DuplicateVoucherError) Error() string {
+	numbers := make([]string, len(e.Duplicates))
+	for i := range e.Duplicates {
+		numbers[i] = e.Duplicates[i].VoucherNo
+	}
+	return fmt.Sprintf("voucher duplicates %s", strings.Join(numbers, ", "))
+}
+
+// entrySignature returns the account, partner and amounts of the entries,
+// independent of their order
+func (v *Voucher) entrySignature() string {
+	lines := make([]string, len(v.Entries))
+	for i, e := range v.Entries {
+		partner := ""
+		if e.PartnerID != nil {
+			partner = e.PartnerID.String()
+		}
+		lines[i] = strings.Join([]string{
+			e.AccountID.String(),
+			partner,
+			strconv.FormatFloat(e.DebitAmount, 'f', 2, 64),
+			strconv.FormatFloat(e.CreditAmount, 'f', 2, 64),
+		}, "|")
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, ";")
+}
+
+// IsDuplicateOf returns true if the voucher books the same amounts to the same
+// accounts and partners as the other one. Dates are compared by the caller.
+func (v *Voucher) IsDuplicateOf(other *Voucher) bool {
+	if v.ID == other.ID || other.Status == VoucherStatusCancelled {
+		return false
+	}
+	if v.TotalDebit != other.TotalDebit || len(v.Entries) != len(other.Entries) {
+		return false
+	}
+	return v.entrySignature() == other.entrySignature()
+}
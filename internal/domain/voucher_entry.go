@@ -21,6 +21,10 @@ type VoucherEntry struct {
 	VoucherID uuid.UUID `gorm:"type:uuid;not null;index" json:"voucher_id"`
 	CompanyID uuid.UUID `gorm:"type:uuid;not null;index" json:"company_id"`
 
+	// FiscalYear is the year of the voucher date and the partition key of
+	// voucher_entries; it is kept in sync with the voucher by the repository
+	FiscalYear int `gorm:"not null" json:"fiscal_year"`
+
 	// Entry info
 	LineNo    int       `gorm:"not null" json:"line_no"`
 	AccountID uuid.UUID `gorm:"type:uuid;not null" json:"account_id"`
@@ -38,13 +42,25 @@ type VoucherEntry struct {
 	ProjectID    *uuid.UUID `gorm:"type:uuid" json:"project_id,omitempty"`
 	CostCenterID *uuid.UUID `gorm:"type:uuid" json:"cost_center_id,omitempty"`
 
+	// Bank account paid from or into, on entries of cash accounts with bank accounts
+	BankAccountID *uuid.UUID `gorm:"type:uuid" json:"bank_account_id,omitempty"`
+
 	// Tags for analysis
 	Tags json.RawMessage `gorm:"type:jsonb;default:'[]'" json:"tags,omitempty"`
 
+	// VAT (amount is tax-inclusive on input; the service splits off the VAT line)
+	TaxCodeID *uuid.UUID `gorm:"type:uuid" json:"tax_code_id,omitempty"`
+	TaxAmount float64    `gorm:"type:decimal(18,2);not null;default:0" json:"tax_amount"`
+	IsTaxLine bool       `gorm:"default:false" json:"is_tax_line"`
+
+	// Amount of the entry already reversed by full or partial reversals
+	ReversedAmount float64 `gorm:"type:decimal(18,2);not null;default:0" json:"reversed_amount"`
+
 	// Relations
 	Account    *Account    `gorm:"foreignKey:AccountID" json:"account,omitempty"`
 	Partner    *Partner    `gorm:"foreignKey:PartnerID" json:"partner,omitempty"`
 	Department *Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	TaxCode    *TaxCode    `gorm:"foreignKey:TaxCodeID" json:"tax_code,omitempty"`
 }
 
 // TableName specifies the table name for GORM
@@ -85,6 +101,11 @@ func (e *VoucherEntry) GetAmount() float64 {
 	return e.CreditAmount
 }
 
+// RemainingAmount returns the amount of the entry not reversed yet
+func (e *VoucherEntry) RemainingAmount() float64 {
+	return roundAmount(e.GetAmount() - e.ReversedAmount)
+}
+
 // SetDebit sets the entry as a debit entry
 func (e *VoucherEntry) SetDebit(amount float64) {
 	e.DebitAmount = amount
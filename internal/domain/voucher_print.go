@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Voucher print errors
+var (
+	ErrPrintTemplateNotFound    = errors.New("voucher print template not found")
+	ErrPrintTemplateLogoFormat  = errors.New("logo must be a JPEG or PNG image")
+	ErrPrintTemplateLogoSize    = errors.New("logo image is too large")
+	ErrPrintTemplateApprovalBox = errors.New("approval block supports 1 to 5 boxes")
+)
+
+// MaxPrintLogoSize is the maximum logo size stored with a print template
+const MaxPrintLogoSize = 512 << 10
+
+// VoucherPrintTemplate holds per-company customization of the printed voucher (전표 출력)
+type VoucherPrintTemplate struct {
+	TenantModel
+
+	// Header
+	Title           string `gorm:"type:varchar(50)" json:"title,omitempty"` // empty uses the voucher type label
+	LogoData        []byte `gorm:"type:bytea" json:"-"`
+	LogoContentType string `gorm:"type:varchar(50)" json:"logo_content_type,omitempty"`
+
+	// Approval block (결재란); the first box shows the preparer, the last the approver
+	ShowApprovalBlock bool     `gorm:"default:true" json:"show_approval_block"`
+	ApprovalLabels    []string `gorm:"type:jsonb;serializer:json" json:"approval_labels"`
+
+	// Body
+	ShowPartner bool `gorm:"default:true" json:"show_partner"`
+
+	// Footer
+	FooterText string `gorm:"type:varchar(500)" json:"footer_text,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (VoucherPrintTemplate) TableName() string {
+	return "voucher_print_templates"
+}
+
+// DefaultApprovalLabels returns the default approval block labels
+func DefaultApprovalLabels() []string {
+	return []string{"담당", "검토", "승인"}
+}
+
+// NewVoucherPrintTemplate returns the default template used until a company customizes it
+func NewVoucherPrintTemplate(companyID uuid.UUID) *VoucherPrintTemplate {
+	t := &VoucherPrintTemplate{
+		ShowApprovalBlock: true,
+		ApprovalLabels:    DefaultApprovalLabels(),
+		ShowPartner:       true,
+	}
+	t.CompanyID = companyID
+	return t
+}
+
+// HasLogo returns true if a logo image is configured
+func (t *VoucherPrintTemplate) HasLogo() bool {
+	return len(t.LogoData) > 0
+}
+
+// Validate validates the template
+func (t *VoucherPrintTemplate) Validate() error {
+	if t.ShowApprovalBlock && (len(t.ApprovalLabels) == 0 || len(t.ApprovalLabels) > 5) {
+		return ErrPrintTemplateApprovalBox
+	}
+	if t.HasLogo() {
+		if len(t.LogoData) > MaxPrintLogoSize {
+			return ErrPrintTemplateLogoSize
+		}
+		if t.LogoContentType != "image/jpeg" && t.LogoContentType != "image/png" {
+			return ErrPrintTemplateLogoFormat
+		}
+	}
+	return nil
+}
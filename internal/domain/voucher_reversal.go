@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Partial reversal errors
+var (
+	ErrVoucherOverReversal         = errors.New("reversal exceeds the amount of the entry not reversed yet")
+	ErrInvalidReversalRatio        = errors.New("reversal ratio must be greater than 0 and at most 1")
+	ErrVoucherReversalLinesMissing = errors.New("partial reversal needs a ratio or entry amounts")
+)
+
+// ReversalLine reverses Amount of an entry of a posted voucher
+type ReversalLine struct {
+	EntryID uuid.UUID `json:"entry_id"`
+	Amount  float64   `json:"amount"`
+}
+
+// IsFullyReversed returns true if every entry has been reversed in full
+func (v *Voucher) IsFullyReversed() bool {
+	for i := range v.Entries {
+		if v.Entries[i].RemainingAmount() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RemainingReversalLines returns the lines reversing what is left of every entry
+func (v *Voucher) RemainingReversalLines() []ReversalLine {
+	var lines []ReversalLine
+	for _, entry := range v.Entries {
+		if remaining := entry.RemainingAmount(); remaining > 0 {
+			lines = append(lines, ReversalLine{EntryID: entry.ID, Amount: remaining})
+		}
+	}
+	return lines
+}
+
+// RemainingEntries returns copies of the entries for what is left of each,
+// such as the entries of a voucher correcting this one
+func (v *Voucher) RemainingEntries() []VoucherEntry {
+	var entries []VoucherEntry
+	for _, entry := range v.Entries {
+		remaining := entry.RemainingAmount()
+		if remaining == 0 {
+			continue
+		}
+		copied := VoucherEntry{
+			CompanyID:     entry.CompanyID,
+			AccountID:     entry.AccountID,
+			Description:   entry.Description,
+			PartnerID:     entry.PartnerID,
+			DepartmentID:  entry.DepartmentID,
+			ProjectID:     entry.ProjectID,
+			CostCenterID:  entry.CostCenterID,
+			BankAccountID: entry.BankAccountID,
+			TaxCodeID:     entry.TaxCodeID,
+			TaxAmount:     roundAmount(entry.TaxAmount * remaining / entry.GetAmount()),
+			IsTaxLine:     entry.IsTaxLine,
+		}
+		if entry.IsDebit() {
+			copied.SetDebit(remaining)
+		} else {
+			copied.SetCredit(remaining)
+		}
+		entries = append(entries, copied)
+	}
+	return entries
+}
+
+// ProportionalReversalLines returns the lines reversing ratio of every entry,
+// such as the share of returned goods. The rounding difference between the
+// debit and credit sides goes to the largest credit line.
+func (v *Voucher) ProportionalReversalLines(ratio float64) ([]ReversalLine, error) {
+	if ratio <= 0 || ratio > 1 {
+		return nil, ErrInvalidReversalRatio
+	}
+
+	var lines []ReversalLine
+	var debit, credit float64
+	largestCredit := -1
+	for _, entry := range v.Entries {
+		amount := roundAmount(entry.GetAmount() * ratio)
+		if amount == 0 {
+			continue
+		}
+		if entry.IsDebit() {
+			debit += amount
+		} else {
+			credit += amount
+			if largestCredit < 0 || amount > lines[largestCredit].Amount {
+				largestCredit = len(lines)
+			}
+		}
+		lines = append(lines, ReversalLine{EntryID: entry.ID, Amount: amount})
+	}
+	if largestCredit >= 0 {
+		lines[largestCredit].Amount = roundAmount(lines[largestCredit].Amount + debit - credit)
+	}
+	return lines, nil
+}
+
+// ReversalEntries builds the entries reversing the lines, swapping debit and
+// credit, and adds the lines to the reversed amounts of the voucher and its
+// entries. Lines of the same entry add up; together they must stay within
+// what is left of each entry and balance.
+func (v *Voucher) ReversalEntries(lines []ReversalLine) ([]VoucherEntry, error) {
+	if len(lines) == 0 {
+		return nil, ErrVoucherReversalLinesMissing
+	}
+
+	amounts := make(map[uuid.UUID]float64, len(lines))
+	for _, line := range lines {
+		if line.Amount <= 0 {
+			return nil, ErrEntryZeroAmount
+		}
+		amounts[line.EntryID] = roundAmount(amounts[line.EntryID] + line.Amount)
+	}
+
+	var entries []VoucherEntry
+	var debit, credit float64
+	found := 0
+	for i := range v.Entries {
+		entry := &v.Entries[i]
+		amount, ok := amounts[entry.ID]
+		if !ok {
+			continue
+		}
+		found++
+		if amount > entry.RemainingAmount() {
+			return nil, ErrVoucherOverReversal
+		}
+
+		reversal := VoucherEntry{
+			CompanyID:     entry.CompanyID,
+			AccountID:     entry.AccountID,
+			Description:   entry.Description,
+			PartnerID:     entry.PartnerID,
+			DepartmentID:  entry.DepartmentID,
+			ProjectID:     entry.ProjectID,
+			CostCenterID:  entry.CostCenterID,
+			BankAccountID: entry.BankAccountID,
+			TaxCodeID:     entry.TaxCodeID,
+			TaxAmount:     roundAmount(entry.TaxAmount * amount / entry.GetAmount()),
+			IsTaxLine:     entry.IsTaxLine,
+		}
+		if entry.IsDebit() {
+			reversal.SetCredit(amount)
+			credit += amount
+		} else {
+			reversal.SetDebit(amount)
+			debit += amount
+		}
+		entries = append(entries, reversal)
+	}
+	if found < len(amounts) {
+		return nil, ErrEntryNotFound
+	}
+	if roundAmount(debit-credit) != 0 {
+		return nil, ErrVoucherUnbalanced
+	}
+
+	for i := range v.Entries {
+		if amount, ok := amounts[v.Entries[i].ID]; ok {
+			v.Entries[i].ReversedAmount = roundAmount(v.Entries[i].ReversedAmount + amount)
+		}
+	}
+	v.ReversedAmount = roundAmount(v.ReversedAmount + debit)
+	return entries, nil
+}
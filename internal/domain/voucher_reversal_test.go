@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func newSalesVoucher() *domain.Voucher {
+	entry := func(debit, credit float64, taxLine bool) domain.VoucherEntry {
+		e := domain.VoucherEntry{AccountID: uuid.New(), DebitAmount: debit, CreditAmount: credit, IsTaxLine: taxLine}
+		e.ID = uuid.New()
+		return e
+	}
+	return &domain.Voucher{
+		Status: domain.VoucherStatusPosted,
+		Entries: []domain.VoucherEntry{
+			entry(1100, 0, false), // receivable
+			entry(0, 1000, false), // sales
+			entry(0, 100, true),   // VAT
+		},
+	}
+}
+
+func TestVoucher_ProportionalReversalLines(t *testing.T) {
+	v := newSalesVoucher()
+
+	lines, err := v.ProportionalReversalLines(1.0 / 3)
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+	assert.Equal(t, 366.67, lines[0].Amount)
+	assert.Equal(t, 333.34, lines[1].Amount, "rounding difference goes to the largest credit")
+	assert.Equal(t, 33.33, lines[2].Amount)
+
+	_, err = v.ProportionalReversalLines(0)
+	assert.Equal(t, domain.ErrInvalidReversalRatio, err)
+	_, err = v.ProportionalReversalLines(1.5)
+	assert.Equal(t, domain.ErrInvalidReversalRatio, err)
+}
+
+func TestVoucher_ReversalEntries(t *testing.T) {
+	t.Run("tracks reversed amounts until fully reversed", func(t *testing.T) {
+		v := newSalesVoucher()
+
+		entries, err := v.ReversalEntries([]domain.ReversalLine{
+			{EntryID: v.Entries[0].ID, Amount: 220},
+			{EntryID: v.Entries[1].ID, Amount: 200},
+			{EntryID: v.Entries[2].ID, Amount: 20},
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, 220.0, entries[0].CreditAmount)
+		assert.Equal(t, 200.0, entries[1].DebitAmount)
+		assert.True(t, entries[2].IsTaxLine)
+		assert.Equal(t, 220.0, v.ReversedAmount)
+		assert.Equal(t, 880.0, v.Entries[0].RemainingAmount())
+		assert.False(t, v.IsFullyReversed())
+
+		remaining := v.RemainingEntries()
+		require.Len(t, remaining, 3)
+		assert.Equal(t, 800.0, remaining[1].CreditAmount)
+
+		_, err = v.ReversalEntries(v.RemainingReversalLines())
+		require.NoError(t, err)
+		assert.Equal(t, 1100.0, v.ReversedAmount)
+		assert.True(t, v.IsFullyReversed())
+		assert.Empty(t, v.RemainingReversalLines())
+	})
+
+	t.Run("blocks over-reversal", func(t *testing.T) {
+		v := newSalesVoucher()
+		v.Entries[0].ReversedAmount = 1000
+		v.Entries[1].ReversedAmount = 1000
+
+		_, err := v.ReversalEntries([]domain.ReversalLine{
+			{EntryID: v.Entries[0].ID, Amount: 200},
+			{EntryID: v.Entries[2].ID, Amount: 100},
+			{EntryID: v.Entries[2].ID, Amount: 100},
+		})
+		assert.Equal(t, domain.ErrVoucherOverReversal, err)
+		assert.Equal(t, 1000.0, v.Entries[0].ReversedAmount, "amounts are unchanged on error")
+	})
+
+	t.Run("rejects unbalanced and unknown lines", func(t *testing.T) {
+		v := newSalesVoucher()
+
+		_, err := v.ReversalEntries([]domain.ReversalLine{{EntryID: v.Entries[0].ID, Amount: 100}})
+		assert.Equal(t, domain.ErrVoucherUnbalanced, err)
+
+		_, err = v.ReversalEntries([]domain.ReversalLine{{EntryID: uuid.New(), Amount: 100}})
+		assert.Equal(t, domain.ErrEntryNotFound, err)
+
+		_, err = v.ReversalEntries(nil)
+		assert.Equal(t, domain.ErrVoucherReversalLinesMissing, err)
+		assert.Zero(t, v.ReversedAmount)
+	})
+}
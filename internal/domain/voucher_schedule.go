@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scheduled posting errors
+var (
+	ErrVoucherCannotSchedule = errors.New("only approved vouchers can be scheduled for posting")
+	ErrVoucherNotScheduled   = errors.New("voucher is not scheduled for posting")
+	ErrInvalidScheduleDate   = errors.New("scheduled posting date must be after today")
+)
+
+// IsScheduled returns true if the approved voucher waits for its scheduled posting
+func (v *Voucher) IsScheduled() bool {
+	return v.Status == VoucherStatusApproved && v.ScheduledPostDate != nil
+}
+
+// Schedule schedules the approved voucher to be posted on date, which must be
+// after today. The worker posts it on behalf of the scheduling user.
+func (v *Voucher) Schedule(userID uuid.UUID, date, today time.Time) error {
+	if v.Status != VoucherStatusApproved {
+		return ErrVoucherCannotSchedule
+	}
+	if !truncateDay(date).After(truncateDay(today)) {
+		return ErrInvalidScheduleDate
+	}
+	day := truncateDay(date)
+	v.ScheduledPostDate = &day
+	v.ScheduledBy = &userID
+	return nil
+}
+
+// Unschedule cancels the scheduled posting; the voucher stays approved
+func (v *Voucher) Unschedule() error {
+	if !v.IsScheduled() {
+		return ErrVoucherNotScheduled
+	}
+	v.ScheduledPostDate = nil
+	v.ScheduledBy = nil
+	return nil
+}
+
+// DueForPosting returns true if the scheduled posting date has arrived as of the given date
+func (v *Voucher) DueForPosting(asOf time.Time) bool {
+	return v.IsScheduled() && !truncateDay(asOf).Before(*v.ScheduledPostDate)
+}
+
+// truncateDay returns midnight of the day of t
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
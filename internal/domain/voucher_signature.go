@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Voucher signature errors
+var (
+	ErrSignatureRequired      = errors.New("signature image or PIN confirmation is required")
+	ErrSignatureImageFormat   = errors.New("signature must be a JPEG or PNG image")
+	ErrSignatureImageSize     = errors.New("signature image is too large")
+	ErrSigningPINNotSet       = errors.New("signing PIN is not set")
+	ErrSigningPINInvalid      = errors.New("signing PIN is incorrect")
+	ErrSigningPINFormat       = errors.New("signing PIN must be 4 to 8 digits")
+	ErrSigningPINLocked       = errors.New("signing is locked after too many incorrect PINs")
+	ErrInvalidSignatureAction = errors.New("invalid signature action")
+)
+
+// MaxSignatureImageSize is the maximum signature image stored with an approval record
+const MaxSignatureImageSize = 256 << 10
+
+// MaxSigningPINAttempts is the number of incorrect signing PINs after which
+// signing locks until an administrator unlocks it
+const MaxSigningPINAttempts = 5
+
+// SignatureAction represents the workflow action a signature confirms
+type SignatureAction string
+
+const (
+	SignatureActionApprove SignatureAction = "approve" // 승인
+	SignatureActionPost    SignatureAction = "post"    // 전기
+)
+
+// IsValid checks if the signature action is valid
+func (a SignatureAction) IsValid() bool {
+	return a == SignatureActionApprove || a == SignatureActionPost
+}
+
+// SignatureMethod represents how the signer confirmed the action
+type SignatureMethod string
+
+const (
+	SignatureMethodImage    SignatureMethod = "image"     // 서명 이미지
+	SignatureMethodPIN      SignatureMethod = "pin"       // PIN 확인
+	SignatureMethodImagePIN SignatureMethod = "image_pin" // 서명 이미지 + PIN 확인
+)
+
+// VoucherSignature is the audit artifact captured when a voucher is approved or posted (전자서명)
+type VoucherSignature struct {
+	TenantModel
+
+	VoucherID uuid.UUID       `gorm:"type:uuid;not null;index" json:"voucher_id"`
+	Action    SignatureAction `gorm:"type:varchar(20);not null" json:"action"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	Method    SignatureMethod `gorm:"type:varchar(20);not null" json:"method"`
+
+	// Handwritten signature image, if captured
+	ImageData        []byte `gorm:"type:bytea" json:"-"`
+	ImageContentType string `gorm:"type:varchar(50)" json:"image_content_type,omitempty"`
+
+	// SHA-256 digest of the voucher content at signing time, for tamper evidence
+	DocumentHash string `gorm:"type:varchar(64);not null" json:"document_hash"`
+
+	// Signing context
+	IPAddress string    `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent string    `gorm:"type:varchar(255)" json:"user_agent,omitempty"`
+	SignedAt  time.Time `gorm:"not null" json:"signed_at"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (VoucherSignature) TableName() string {
+	return "voucher_signatures"
+}
+
+// HasImage returns true if a signature image was captured
+func (s *VoucherSignature) HasImage() bool {
+	return len(s.ImageData) > 0
+}
+
+// Matches returns true if the voucher content is unchanged since signing
+func (s *VoucherSignature) Matches(voucher *Voucher) bool {
+	return s.DocumentHash == VoucherDigest(voucher)
+}
+
+// VoucherDigest returns a SHA-256 digest of the voucher header and entries
+func VoucherDigest(v *Voucher) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|%s|%s|%.2f|%.2f|%s\n",
+		v.ID, v.VoucherNo, v.VoucherDate.Format("2006-01-02"), v.VoucherType,
+		v.TotalDebit, v.TotalCredit, v.Description)
+	for _, e := range v.Entries {
+		fmt.Fprintf(&b, "%d|%s|%.2f|%.2f|%s\n", e.LineNo, e.AccountID, e.DebitAmount, e.CreditAmount, e.Description)
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateSigningPIN checks the signing PIN format
+func ValidateSigningPIN(pin string) error {
+	if len(pin) < 4 || len(pin) > 8 {
+		return ErrSigningPINFormat
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return ErrSigningPINFormat
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrSegregationOfDuties is returned when a user approves or posts a voucher they
+// created or submitted while the company enforces segregation of duties
+var ErrSegregationOfDuties = errors.New("vouchers cannot be approved or posted by the user who created or submitted them")
+
+// SoDRole is the part the user had in the voucher before approving or posting it
+type SoDRole string
+
+const (
+	SoDRoleCreator   SoDRole = "creator"
+	SoDRoleSubmitter SoDRole = "submitter"
+)
+
+// SoDViolation is an approval or posting of a voucher by the user who created or
+// submitted it (직무분리 위반)
+type SoDViolation struct {
+	VoucherID   uuid.UUID     `json:"voucher_id"`
+	VoucherNo   string        `json:"voucher_no"`
+	VoucherDate time.Time     `json:"voucher_date"`
+	VoucherType VoucherType   `json:"voucher_type"`
+	TotalDebit  float64       `json:"total_debit"`
+	Action      VoucherStatus `json:"action"` // approved or posted
+	UserID      uuid.UUID     `json:"user_id"`
+	UserName    string        `json:"user_name,omitempty"`
+	Role        SoDRole       `json:"role"`
+	ChangedAt   time.Time     `json:"changed_at"`
+}
+
+// CheckSegregationOfDuties returns ErrSegregationOfDuties if the user created or
+// submitted the voucher
+func (v *Voucher) CheckSegregationOfDuties(userID uuid.UUID) error {
+	if (v.CreatedBy != nil && *v.CreatedBy == userID) || (v.SubmittedBy != nil && *v.SubmittedBy == userID) {
+		return ErrSegregationOfDuties
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// VoucherStatusChange is one step of a voucher's workflow timeline.
+// Rows are written by a database trigger whenever the voucher status changes.
+type VoucherStatusChange struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	CompanyID  uuid.UUID     `gorm:"type:uuid;not null" json:"company_id"`
+	VoucherID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"voucher_id"`
+	FromStatus VoucherStatus `gorm:"type:varchar(20)" json:"from_status,omitempty"` // empty on creation
+	ToStatus   VoucherStatus `gorm:"type:varchar(20);not null" json:"to_status"`
+	ChangedBy  *uuid.UUID    `gorm:"type:uuid" json:"changed_by,omitempty"`
+	Reason     string        `gorm:"type:varchar(500)" json:"reason,omitempty"`
+	ChangedAt  time.Time     `gorm:"not null;default:now()" json:"changed_at"`
+
+	// Relations
+	User *User `gorm:"foreignKey:ChangedBy" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (VoucherStatusChange) TableName() string {
+	return "voucher_status_history"
+}
+
+// LocalizedStatusLabel returns the label of the new status in the locale
+func (c *VoucherStatusChange) LocalizedStatusLabel(loc i18n.Locale) string {
+	return i18n.Label(loc, "voucher_status", string(c.ToStatus))
+}
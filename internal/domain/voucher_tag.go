@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Voucher tag errors
+var (
+	ErrVoucherTagNotFound     = errors.New("voucher tag not found")
+	ErrVoucherTagNameRequired = errors.New("voucher tag name is required")
+	ErrInvalidVoucherTagName  = errors.New("voucher tag name must be at most 50 characters without ';'")
+	ErrVoucherTagExists       = errors.New("voucher tag already exists")
+	ErrTooManyVoucherTags     = errors.New("too many voucher tags")
+)
+
+const (
+	// MaxVoucherTags is the maximum number of tags on a voucher
+	MaxVoucherTags = 10
+	// MaxVoucherTagLength is the maximum length of a tag name in characters
+	MaxVoucherTagLength = 50
+	// VoucherTagSeparator joins the tags of a voucher in flat exports
+	VoucherTagSeparator = ";"
+)
+
+// VoucherTag is a free-form label of the company's vouchers, such as
+// "audit-adjustment". Vouchers carry their tags by name; a tag used on a
+// voucher is added to the company's tags automatically.
+type VoucherTag struct {
+	TenantModel
+
+	Name        string `gorm:"type:varchar(50);not null" json:"name"`
+	Description string `gorm:"type:varchar(200)" json:"description,omitempty"`
+
+	// VoucherCount is the number of vouchers carrying the tag, set in listings
+	VoucherCount int64 `gorm:"->;-:migration" json:"voucher_count"`
+}
+
+// TableName specifies the table name for GORM
+func (VoucherTag) TableName() string {
+	return "voucher_tags"
+}
+
+// Validate trims and validates the tag
+func (t *VoucherTag) Validate() error {
+	name, err := NormalizeVoucherTag(t.Name)
+	if err != nil {
+		return err
+	}
+	t.Name = name
+	return nil
+}
+
+// NormalizeVoucherTag trims the tag name and validates it
+func NormalizeVoucherTag(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrVoucherTagNameRequired
+	}
+	if utf8.RuneCountInString(name) > MaxVoucherTagLength || strings.Contains(name, VoucherTagSeparator) {
+		return "", ErrInvalidVoucherTagName
+	}
+	return name, nil
+}
+
+// NormalizeVoucherTags trims the tag names, drops blank ones and duplicates
+// keeping the first occurrence, and validates the rest. It never returns nil,
+// so that a voucher without tags stores an empty list.
+func NormalizeVoucherTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			continue
+		}
+		name, err := NormalizeVoucherTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	if len(normalized) > MaxVoucherTags {
+		return nil, ErrTooManyVoucherTags
+	}
+	return normalized, nil
+}
+
+// SetTags replaces the tags of the voucher with the normalized tags
+func (v *Voucher) SetTags(tags []string) error {
+	normalized, err := NormalizeVoucherTags(tags)
+	if err != nil {
+		return err
+	}
+	v.Tags = normalized
+	return nil
+}
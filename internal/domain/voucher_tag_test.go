@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ============================================================================
+// Voucher Tag Tests
+// ============================================================================
+
+func TestNormalizeVoucherTags(t *testing.T) {
+	tags, err := domain.NormalizeVoucherTags([]string{" audit-adjustment ", "", "covid-subsidy", "audit-adjustment", "  "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"audit-adjustment", "covid-subsidy"}, tags)
+
+	tags, err = domain.NormalizeVoucherTags(nil)
+	require.NoError(t, err)
+	assert.NotNil(t, tags)
+	assert.Empty(t, tags)
+
+	_, err = domain.NormalizeVoucherTags([]string{"a;b"})
+	assert.ErrorIs(t, err, domain.ErrInvalidVoucherTagName)
+
+	_, err = domain.NormalizeVoucherTags([]string{strings.Repeat("가", domain.MaxVoucherTagLength+1)})
+	assert.ErrorIs(t, err, domain.ErrInvalidVoucherTagName)
+
+	many := make([]string, domain.MaxVoucherTags+1)
+	for i := range many {
+		many[i] = strings.Repeat("t", i+1)
+	}
+	_, err = domain.NormalizeVoucherTags(many)
+	assert.ErrorIs(t, err, domain.ErrTooManyVoucherTags)
+}
+
+func TestVoucherTag_Validate(t *testing.T) {
+	tag := domain.VoucherTag{Name: "  covid-subsidy "}
+	require.NoError(t, tag.Validate())
+	assert.Equal(t, "covid-subsidy", tag.Name)
+
+	tag = domain.VoucherTag{Name: " "}
+	assert.ErrorIs(t, tag.Validate(), domain.ErrVoucherTagNameRequired)
+}
+
+func TestReportSpec_ValidateTags(t *testing.T) {
+	spec := domain.ReportSpec{
+		Tags:    []string{" audit-adjustment", "audit-adjustment"},
+		Columns: []domain.ReportAmountColumn{domain.ReportColumnClosing},
+		Period:  domain.ReportPeriodCurrentMonth,
+	}
+	require.NoError(t, spec.Validate())
+	assert.Equal(t, []string{"audit-adjustment"}, spec.Tags)
+}
@@ -695,3 +695,61 @@ func TestVoucher_GetStatusLabel(t *testing.T) {
 		})
 	}
 }
+
+// ============================================================================
+// VoucherSignature Tests
+// ============================================================================
+
+func TestVoucherSignature_Matches(t *testing.T) {
+	v := &domain.Voucher{
+		VoucherNo:   "GJ-202610-0001",
+		VoucherDate: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+		TotalDebit:  10000,
+		TotalCredit: 10000,
+		Entries: []domain.VoucherEntry{
+			{LineNo: 1, AccountID: uuid.New(), DebitAmount: 10000},
+			{LineNo: 2, AccountID: uuid.New(), CreditAmount: 10000},
+		},
+	}
+	sig := &domain.VoucherSignature{DocumentHash: domain.VoucherDigest(v)}
+	require.Len(t, sig.DocumentHash, 64)
+	assert.True(t, sig.Matches(v))
+
+	v.Entries[0].DebitAmount = 20000
+	assert.False(t, sig.Matches(v), "changed entries must invalidate the signature digest")
+}
+
+func TestValidateSigningPIN(t *testing.T) {
+	assert.NoError(t, domain.ValidateSigningPIN("1234"))
+	assert.NoError(t, domain.ValidateSigningPIN("12345678"))
+	assert.ErrorIs(t, domain.ValidateSigningPIN("123"), domain.ErrSigningPINFormat)
+	assert.ErrorIs(t, domain.ValidateSigningPIN("123456789"), domain.ErrSigningPINFormat)
+	assert.ErrorIs(t, domain.ValidateSigningPIN("12ab"), domain.ErrSigningPINFormat)
+
+	user := &domain.User{}
+	assert.False(t, user.CheckSigningPIN("1234"))
+	require.NoError(t, user.SetSigningPIN("1234"))
+	assert.True(t, user.CheckSigningPIN("1234"))
+	assert.False(t, user.CheckSigningPIN("4321"))
+}
+
+func TestVoucher_Schedule(t *testing.T) {
+	today := time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	v := &domain.Voucher{Status: domain.VoucherStatusApproved}
+	assert.Equal(t, domain.ErrInvalidScheduleDate, v.Schedule(userID, today, today))
+
+	require.NoError(t, v.Schedule(userID, time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC), today))
+	assert.True(t, v.IsScheduled())
+	assert.Equal(t, time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC), *v.ScheduledPostDate)
+	assert.False(t, v.DueForPosting(today))
+	assert.True(t, v.DueForPosting(time.Date(2025, 3, 20, 1, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, v.Unschedule())
+	assert.False(t, v.IsScheduled())
+	assert.Equal(t, domain.ErrVoucherNotScheduled, v.Unschedule())
+
+	draft := &domain.Voucher{Status: domain.VoucherStatusDraft}
+	assert.Equal(t, domain.ErrVoucherCannotSchedule, draft.Schedule(userID, today.AddDate(0, 0, 1), today))
+}
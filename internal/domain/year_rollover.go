@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Year rollover errors
+var (
+	ErrYearRolledOver                  = errors.New("fiscal year is already rolled over")
+	ErrRetainedEarningsAccountRequired = errors.New("a retained earnings account must be set in the company settings")
+	ErrInvalidRetainedEarningsAccount  = errors.New("retained earnings account must be an equity account")
+)
+
+// YearRolloverTrigger records what started a rollover
+type YearRolloverTrigger string
+
+const (
+	YearRolloverTriggerAuto   YearRolloverTrigger = "auto"   // the worker, once the new year's periods are opened
+	YearRolloverTriggerManual YearRolloverTrigger = "manual" // a user through the API
+)
+
+// YearRolloverRun is the carry-forward of a fiscal year's closing balances
+// into the opening balances of January of the next year. Balance sheet
+// accounts are carried forward; revenue and expense accounts start from zero,
+// their net income transferred to retained earnings.
+type YearRolloverRun struct {
+	TenantModel
+
+	FromYear int `gorm:"not null" json:"from_year"`
+	ToYear   int `gorm:"not null" json:"to_year"`
+
+	RetainedEarningsAccountID uuid.UUID `gorm:"type:uuid;not null" json:"retained_earnings_account_id"`
+	NetIncome                 float64   `gorm:"type:decimal(18,2);not null;default:0" json:"net_income"` // negative for a net loss
+
+	CarriedAccounts int `gorm:"not null;default:0" json:"carried_accounts"` // balance sheet accounts with an opening balance
+	ZeroedAccounts  int `gorm:"not null;default:0" json:"zeroed_accounts"`  // revenue and expense accounts closed out
+
+	Trigger   YearRolloverTrigger `gorm:"type:varchar(20);not null" json:"trigger"`
+	CreatedBy *uuid.UUID          `gorm:"type:uuid" json:"created_by,omitempty"` // nil for the worker
+}
+
+// TableName specifies the table name for GORM
+func (YearRolloverRun) TableName() string {
+	return "year_rollover_runs"
+}
+
+// isBalanceSheetAccount returns true for the account types carried across years
+func isBalanceSheetAccount(t AccountType) bool {
+	return t == AccountTypeAsset || t == AccountTypeLiability || t == AccountTypeEquity
+}
+
+// BuildYearOpeningBalances returns the January balances of the year after
+// fromYear from its December balances, which must have their Account loaded.
+// Balance sheet accounts open with their net closing balance on its natural
+// side, net income is added to the retained earnings account and revenue and
+// expense accounts open at zero. January activity already booked in current
+// is kept.
+func BuildYearOpeningBalances(companyID uuid.UUID, fromYear int, december, current []LedgerBalance, retainedEarningsAccountID uuid.UUID) ([]LedgerBalance, *YearRolloverRun) {
+	run := &YearRolloverRun{
+		TenantModel:               TenantModel{CompanyID: companyID},
+		FromYear:                  fromYear,
+		ToYear:                    fromYear + 1,
+		RetainedEarningsAccountID: retainedEarningsAccountID,
+	}
+	opening := make(map[uuid.UUID]float64) // net debit balance by account
+
+	for _, b := range december {
+		if b.Account == nil {
+			continue
+		}
+
+		net := b.GetClosingBalance()
+		switch {
+		case isBalanceSheetAccount(b.Account.AccountType):
+			opening[b.AccountID] += net
+		case b.Account.AccountType == AccountTypeRevenue, b.Account.AccountType == AccountTypeExpense:
+			run.NetIncome -= net // revenue has a credit balance, expense a debit one
+			run.ZeroedAccounts++
+		}
+	}
+	run.NetIncome = roundAmount(run.NetIncome)
+	opening[retainedEarningsAccountID] -= run.NetIncome
+
+	balances := make([]LedgerBalance, 0, len(opening)+len(current))
+	seen := make(map[uuid.UUID]bool)
+	for _, c := range current {
+		b := LedgerBalance{
+			CompanyID:    companyID,
+			AccountID:    c.AccountID,
+			FiscalYear:   run.ToYear,
+			FiscalMonth:  1,
+			PeriodDebit:  c.PeriodDebit,
+			PeriodCredit: c.PeriodCredit,
+		}
+		b.setOpening(opening[c.AccountID])
+		b.CalculateClosing()
+		balances = append(balances, b)
+		seen[c.AccountID] = true
+	}
+	for accountID, net := range opening {
+		if seen[accountID] || roundAmount(net) == 0 {
+			continue
+		}
+		b := LedgerBalance{
+			CompanyID:   companyID,
+			AccountID:   accountID,
+			FiscalYear:  run.ToYear,
+			FiscalMonth: 1,
+		}
+		b.setOpening(net)
+		b.CalculateClosing()
+		balances = append(balances, b)
+	}
+
+	for _, b := range balances {
+		if b.OpeningDebit != 0 || b.OpeningCredit != 0 {
+			run.CarriedAccounts++
+		}
+	}
+	return balances, run
+}
+
+// setOpening sets the opening balance on the debit or credit side of its sign
+func (lb *LedgerBalance) setOpening(net float64) {
+	net = roundAmount(net)
+	lb.OpeningDebit, lb.OpeningCredit = 0, 0
+	if net > 0 {
+		lb.OpeningDebit = net
+	} else if net < 0 {
+		lb.OpeningCredit = -net
+	}
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestBuildYearOpeningBalances(t *testing.T) {
+	companyID := uuid.New()
+	cash, payable, retained, sales, rent := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	balance := func(accountID uuid.UUID, accountType domain.AccountType, debit, credit float64) domain.LedgerBalance {
+		return domain.LedgerBalance{
+			CompanyID:     companyID,
+			AccountID:     accountID,
+			FiscalYear:    2024,
+			FiscalMonth:   12,
+			ClosingDebit:  debit,
+			ClosingCredit: credit,
+			Account:       &domain.Account{AccountType: accountType},
+		}
+	}
+	december := []domain.LedgerBalance{
+		balance(cash, domain.AccountTypeAsset, 9000, 2000),
+		balance(payable, domain.AccountTypeLiability, 500, 1500),
+		balance(retained, domain.AccountTypeEquity, 0, 3000),
+		balance(sales, domain.AccountTypeRevenue, 0, 5000),
+		balance(rent, domain.AccountTypeExpense, 2500, 0),
+	}
+	// January already has rent booked before the rollover
+	january := []domain.LedgerBalance{{CompanyID: companyID, AccountID: rent, FiscalYear: 2025, FiscalMonth: 1, PeriodDebit: 300}}
+
+	balances, run := domain.BuildYearOpeningBalances(companyID, 2024, december, january, retained)
+	assert.Equal(t, 2025, run.ToYear)
+	assert.Equal(t, 2500.0, run.NetIncome)
+	assert.Equal(t, 2, run.ZeroedAccounts)
+	assert.Equal(t, 3, run.CarriedAccounts)
+
+	byAccount := make(map[uuid.UUID]domain.LedgerBalance)
+	for _, b := range balances {
+		assert.Equal(t, 2025, b.FiscalYear)
+		assert.Equal(t, 1, b.FiscalMonth)
+		byAccount[b.AccountID] = b
+	}
+	require.Len(t, byAccount, 4)
+
+	assert.Equal(t, 7000.0, byAccount[cash].OpeningDebit)
+	assert.Equal(t, 0.0, byAccount[cash].OpeningCredit)
+	assert.Equal(t, 1000.0, byAccount[payable].OpeningCredit)
+	assert.Equal(t, 5500.0, byAccount[retained].OpeningCredit)
+	assert.NotContains(t, byAccount, sales)
+
+	assert.Equal(t, 0.0, byAccount[rent].OpeningDebit)
+	assert.Equal(t, 300.0, byAccount[rent].PeriodDebit)
+	assert.Equal(t, 300.0, byAccount[rent].ClosingDebit)
+}
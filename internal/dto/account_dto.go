@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
 )
 
 // CreateAccountRequest represents the request to create an account
@@ -15,9 +16,11 @@ type CreateAccountRequest struct {
 	AccountType        string `json:"account_type" binding:"required,oneof=asset liability equity revenue expense"`
 	AccountNature      string `json:"account_nature,omitempty" binding:"omitempty,oneof=debit credit"`
 	AccountCategory    string `json:"account_category,omitempty" binding:"max=50"`
+	StatementLine      string `json:"statement_line,omitempty" binding:"max=40"`
 	IsActive           *bool  `json:"is_active,omitempty"`
 	IsControlAccount   *bool  `json:"is_control_account,omitempty"`
 	AllowDirectPosting *bool  `json:"allow_direct_posting,omitempty"`
+	IsCashAccount      bool   `json:"is_cash_account,omitempty"`
 	SortOrder          int    `json:"sort_order,omitempty"`
 }
 
@@ -32,6 +35,8 @@ func (r *CreateAccountRequest) ToAccount(companyID uuid.UUID) (*domain.Account,
 		NameEn:          r.NameEn,
 		AccountType:     domain.AccountType(r.AccountType),
 		AccountCategory: r.AccountCategory,
+		StatementLine:   r.StatementLine,
+		IsCashAccount:   r.IsCashAccount,
 		SortOrder:       r.SortOrder,
 	}
 
@@ -68,21 +73,28 @@ func (r *CreateAccountRequest) ToAccount(companyID uuid.UUID) (*domain.Account,
 
 // UpdateAccountRequest represents the request to update an account
 type UpdateAccountRequest struct {
-	Code               string `json:"code" binding:"required,max=10"`
-	Name               string `json:"name" binding:"required,max=100"`
-	NameEn             string `json:"name_en,omitempty" binding:"max=100"`
-	ParentID           string `json:"parent_id,omitempty" binding:"omitempty,uuid"`
-	AccountType        string `json:"account_type" binding:"required,oneof=asset liability equity revenue expense"`
-	AccountNature      string `json:"account_nature" binding:"required,oneof=debit credit"`
-	AccountCategory    string `json:"account_category,omitempty" binding:"max=50"`
-	IsActive           *bool  `json:"is_active"`
-	IsControlAccount   *bool  `json:"is_control_account"`
-	AllowDirectPosting *bool  `json:"allow_direct_posting"`
-	SortOrder          int    `json:"sort_order,omitempty"`
+	Code               string  `json:"code" binding:"required,max=10"`
+	Name               string  `json:"name" binding:"required,max=100"`
+	NameEn             string  `json:"name_en,omitempty" binding:"max=100"`
+	ParentID           string  `json:"parent_id,omitempty" binding:"omitempty,uuid"`
+	AccountType        string  `json:"account_type" binding:"required,oneof=asset liability equity revenue expense"`
+	AccountNature      string  `json:"account_nature" binding:"required,oneof=debit credit"`
+	AccountCategory    string  `json:"account_category,omitempty" binding:"max=50"`
+	StatementLine      *string `json:"statement_line" binding:"omitempty,max=40"` // empty rolls up with the parent; nil keeps the line
+	IsActive           *bool   `json:"is_active"`
+	IsControlAccount   *bool   `json:"is_control_account"`
+	AllowDirectPosting *bool   `json:"allow_direct_posting"`
+	IsCashAccount      *bool   `json:"is_cash_account"`
+	SortOrder          int     `json:"sort_order,omitempty"`
 }
 
 // ApplyTo applies the update request to an existing account
 func (r *UpdateAccountRequest) ApplyTo(account *domain.Account) error {
+	if r.StatementLine != nil {
+		account.StatementLine = *r.StatementLine
+	} else if account.AccountType != domain.AccountType(r.AccountType) {
+		account.StatementLine = "" // lines belong to one account type
+	}
 	account.Code = r.Code
 	account.Name = r.Name
 	account.NameEn = r.NameEn
@@ -110,35 +122,41 @@ func (r *UpdateAccountRequest) ApplyTo(account *domain.Account) error {
 	if r.AllowDirectPosting != nil {
 		account.AllowDirectPosting = *r.AllowDirectPosting
 	}
+	if r.IsCashAccount != nil {
+		account.IsCashAccount = *r.IsCashAccount
+	}
 
 	return nil
 }
 
 // AccountResponse represents the response for an account
 type AccountResponse struct {
-	ID                 string             `json:"id"`
-	Code               string             `json:"code"`
-	Name               string             `json:"name"`
-	NameEn             string             `json:"name_en,omitempty"`
-	ParentID           string             `json:"parent_id,omitempty"`
-	Level              int                `json:"level"`
-	Path               string             `json:"path,omitempty"`
-	AccountType        string             `json:"account_type"`
-	AccountTypeLabel   string             `json:"account_type_label"`
-	AccountNature      string             `json:"account_nature"`
-	AccountNatureLabel string             `json:"account_nature_label"`
-	AccountCategory    string             `json:"account_category,omitempty"`
-	IsActive           bool               `json:"is_active"`
-	IsControlAccount   bool               `json:"is_control_account"`
-	AllowDirectPosting bool               `json:"allow_direct_posting"`
-	SortOrder          int                `json:"sort_order"`
-	Children           []AccountResponse  `json:"children,omitempty"`
-	CreatedAt          string             `json:"created_at"`
-	UpdatedAt          string             `json:"updated_at"`
-}
-
-// FromAccount converts domain.Account to AccountResponse
-func FromAccount(account *domain.Account) AccountResponse {
+	ID                 string            `json:"id"`
+	Code               string            `json:"code"`
+	Name               string            `json:"name"`
+	NameEn             string            `json:"name_en,omitempty"`
+	ParentID           string            `json:"parent_id,omitempty"`
+	Level              int               `json:"level"`
+	Path               string            `json:"path,omitempty"`
+	AccountType        string            `json:"account_type"`
+	AccountTypeLabel   string            `json:"account_type_label"`
+	AccountNature      string            `json:"account_nature"`
+	AccountNatureLabel string            `json:"account_nature_label"`
+	AccountCategory    string            `json:"account_category,omitempty"`
+	StatementLine      string            `json:"statement_line,omitempty"`
+	IsActive           bool              `json:"is_active"`
+	IsControlAccount   bool              `json:"is_control_account"`
+	AllowDirectPosting bool              `json:"allow_direct_posting"`
+	IsCashAccount      bool              `json:"is_cash_account"`
+	SortOrder          int               `json:"sort_order"`
+	PostingRules       *PostingRulesDTO  `json:"posting_rules,omitempty"`
+	Children           []AccountResponse `json:"children,omitempty"`
+	CreatedAt          string            `json:"created_at"`
+	UpdatedAt          string            `json:"updated_at"`
+}
+
+// FromAccount converts domain.Account to AccountResponse with labels in the locale
+func FromAccount(account *domain.Account, loc i18n.Locale) AccountResponse {
 	resp := AccountResponse{
 		ID:                 account.ID.String(),
 		Code:               account.Code,
@@ -147,13 +165,15 @@ func FromAccount(account *domain.Account) AccountResponse {
 		Level:              account.Level,
 		Path:               account.Path,
 		AccountType:        string(account.AccountType),
-		AccountTypeLabel:   account.GetTypeLabel(),
+		AccountTypeLabel:   account.LocalizedTypeLabel(loc),
 		AccountNature:      string(account.AccountNature),
-		AccountNatureLabel: account.GetNatureLabel(),
+		AccountNatureLabel: account.LocalizedNatureLabel(loc),
 		AccountCategory:    account.AccountCategory,
+		StatementLine:      account.StatementLine,
 		IsActive:           account.IsActive,
 		IsControlAccount:   account.IsControlAccount,
 		AllowDirectPosting: account.AllowDirectPosting,
+		IsCashAccount:      account.IsCashAccount,
 		SortOrder:          account.SortOrder,
 		CreatedAt:          account.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:          account.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
@@ -162,12 +182,16 @@ func FromAccount(account *domain.Account) AccountResponse {
 	if account.ParentID != nil {
 		resp.ParentID = account.ParentID.String()
 	}
+	if account.PostingRules != nil {
+		rules := FromPostingRules(account.PostingRules)
+		resp.PostingRules = &rules
+	}
 
 	// Convert children recursively
 	if len(account.Children) > 0 {
 		resp.Children = make([]AccountResponse, len(account.Children))
 		for i, child := range account.Children {
-			resp.Children[i] = FromAccount(&child)
+			resp.Children[i] = FromAccount(&child, loc)
 		}
 	}
 
@@ -175,10 +199,10 @@ func FromAccount(account *domain.Account) AccountResponse {
 }
 
 // FromAccounts converts a slice of domain.Account to []AccountResponse
-func FromAccounts(accounts []domain.Account) []AccountResponse {
+func FromAccounts(accounts []domain.Account, loc i18n.Locale) []AccountResponse {
 	responses := make([]AccountResponse, len(accounts))
 	for i, account := range accounts {
-		responses[i] = FromAccount(&account)
+		responses[i] = FromAccount(&account, loc)
 	}
 	return responses
 }
@@ -188,6 +212,7 @@ type AccountListRequest struct {
 	ParentID    string `form:"parent_id" binding:"omitempty,uuid"`
 	AccountType string `form:"account_type" binding:"omitempty,oneof=asset liability equity revenue expense"`
 	IsActive    *bool  `form:"is_active"`
+	IsCash      *bool  `form:"is_cash"`
 	Search      string `form:"search" binding:"max=100"`
 	Page        int    `form:"page" binding:"min=1"`
 	PageSize    int    `form:"page_size" binding:"min=1,max=100"`
@@ -209,6 +234,32 @@ type MoveAccountRequest struct {
 	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
 }
 
+// AccountImportRequest represents the form fields of a chart of accounts
+// workbook upload; the file itself is sent as the "file" part
+type AccountImportRequest struct {
+	DryRun *bool `form:"dry_run"` // defaults to true
+}
+
+// IsDryRun returns true unless the request explicitly asks to apply the import
+func (r *AccountImportRequest) IsDryRun() bool {
+	return r.DryRun == nil || *r.DryRun
+}
+
+// AccountTemplateApplyRequest represents the options of applying an industry template
+type AccountTemplateApplyRequest struct {
+	DryRun *bool `form:"dry_run" json:"dry_run"` // defaults to true
+}
+
+// IsDryRun returns true unless the request explicitly asks to apply the template
+func (r *AccountTemplateApplyRequest) IsDryRun() bool {
+	return r.DryRun == nil || *r.DryRun
+}
+
+// SetStatementLineRequest represents the request to map an account to a statement line
+type SetStatementLineRequest struct {
+	StatementLine string `json:"statement_line" binding:"max=40"` // empty clears the mapping
+}
+
 // UpdateSortOrderRequest represents the request to update sort orders
 type UpdateSortOrderRequest struct {
 	Orders []SortOrderItem `json:"orders" binding:"required,dive"`
@@ -219,3 +270,46 @@ type SortOrderItem struct {
 	ID        string `json:"id" binding:"required,uuid"`
 	SortOrder int    `json:"sort_order" binding:"min=0"`
 }
+
+// PostingRulesDTO represents account posting rules in requests and responses
+type PostingRulesDTO struct {
+	RequirePartner      bool     `json:"require_partner"`
+	RequireDepartment   bool     `json:"require_department"`
+	RequireProject      bool     `json:"require_project"`
+	RequireCostCenter   bool     `json:"require_cost_center"`
+	AllowedVoucherTypes []string `json:"allowed_voucher_types,omitempty" binding:"omitempty,dive,oneof=general sales purchase payment receipt adjustment closing"`
+	MinAmount           *float64 `json:"min_amount,omitempty" binding:"omitempty,min=0"`
+	MaxAmount           *float64 `json:"max_amount,omitempty" binding:"omitempty,min=0"`
+}
+
+// ToPostingRules converts PostingRulesDTO to domain.PostingRules
+func (r *PostingRulesDTO) ToPostingRules() *domain.PostingRules {
+	rules := &domain.PostingRules{
+		RequirePartner:    r.RequirePartner,
+		RequireDepartment: r.RequireDepartment,
+		RequireProject:    r.RequireProject,
+		RequireCostCenter: r.RequireCostCenter,
+		MinAmount:         r.MinAmount,
+		MaxAmount:         r.MaxAmount,
+	}
+	for _, t := range r.AllowedVoucherTypes {
+		rules.AllowedVoucherTypes = append(rules.AllowedVoucherTypes, domain.VoucherType(t))
+	}
+	return rules
+}
+
+// FromPostingRules converts domain.PostingRules to PostingRulesDTO
+func FromPostingRules(rules *domain.PostingRules) PostingRulesDTO {
+	resp := PostingRulesDTO{
+		RequirePartner:    rules.RequirePartner,
+		RequireDepartment: rules.RequireDepartment,
+		RequireProject:    rules.RequireProject,
+		RequireCostCenter: rules.RequireCostCenter,
+		MinAmount:         rules.MinAmount,
+		MaxAmount:         rules.MaxAmount,
+	}
+	for _, t := range rules.AllowedVoucherTypes {
+		resp.AllowedVoucherTypes = append(resp.AllowedVoucherTypes, string(t))
+	}
+	return resp
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// ActivityListRequest represents query parameters of the company activity feed.
+// kind may be repeated to match any of several kinds.
+type ActivityListRequest struct {
+	Kind     []string `form:"kind" binding:"omitempty,dive,oneof=status_change comment attachment edit"`
+	UserID   string   `form:"user_id" binding:"omitempty,uuid"`
+	DateFrom string   `form:"date_from" binding:"omitempty"`
+	DateTo   string   `form:"date_to" binding:"omitempty"`
+	Page     int      `form:"page" binding:"omitempty,min=1"`
+	PageSize int      `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// AddCommentRequest represents the request to comment on a voucher
+type AddCommentRequest struct {
+	Comment string `json:"comment" binding:"required,max=2000"`
+}
+
+// ActivityChangeResponse represents a field changed by an edit
+type ActivityChangeResponse struct {
+	Field    string          `json:"field"`
+	OldValue json.RawMessage `json:"old_value"`
+	NewValue json.RawMessage `json:"new_value"`
+}
+
+// ActivityResponse represents an activity feed item in API responses
+type ActivityResponse struct {
+	Kind        string                   `json:"kind"`
+	KindLabel   string                   `json:"kind_label"`
+	EntityType  string                   `json:"entity_type"`
+	EntityID    string                   `json:"entity_id"`
+	EntityLabel string                   `json:"entity_label,omitempty"`
+	User        *VoucherUserResponse     `json:"user,omitempty"`
+	FromStatus  string                   `json:"from_status,omitempty"`
+	ToStatus    string                   `json:"to_status,omitempty"`
+	StatusLabel string                   `json:"status_label,omitempty"`
+	Message     string                   `json:"message,omitempty"`
+	Changes     []ActivityChangeResponse `json:"changes,omitempty"`
+	OccurredAt  string                   `json:"occurred_at"`
+}
+
+// FromActivity converts domain.Activity to ActivityResponse with labels in the locale
+func FromActivity(a *domain.Activity, loc i18n.Locale) ActivityResponse {
+	resp := ActivityResponse{
+		Kind:        string(a.Kind),
+		KindLabel:   a.Kind.LocalizedLabel(loc),
+		EntityType:  a.EntityType,
+		EntityID:    a.EntityID.String(),
+		EntityLabel: a.EntityLabel,
+		FromStatus:  string(a.FromStatus),
+		ToStatus:    string(a.ToStatus),
+		Message:     a.Message,
+		OccurredAt:  a.OccurredAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if a.UserID != nil {
+		resp.User = &VoucherUserResponse{ID: a.UserID.String(), Name: a.UserName}
+	}
+	if a.ToStatus != "" {
+		resp.StatusLabel = i18n.Label(loc, "voucher_status", string(a.ToStatus))
+	}
+	for _, c := range a.Changes {
+		resp.Changes = append(resp.Changes, ActivityChangeResponse{Field: c.Field, OldValue: c.OldValue, NewValue: c.NewValue})
+	}
+	return resp
+}
+
+// FromActivities converts a slice of domain.Activity to responses
+func FromActivities(activities []domain.Activity, loc i18n.Locale) []ActivityResponse {
+	result := make([]ActivityResponse, len(activities))
+	for i := range activities {
+		result[i] = FromActivity(&activities[i], loc)
+	}
+	return result
+}
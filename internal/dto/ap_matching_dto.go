@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// RunAPMatchingRequest represents a request to match the purchase tax invoices
+// of a period to purchase vouchers. Omitted tolerances use the defaults.
+type RunAPMatchingRequest struct {
+	From              string `json:"from" binding:"required"`
+	To                string `json:"to" binding:"required"`
+	DateToleranceDays *int   `json:"date_tolerance_days" binding:"omitempty,min=0,max=60"`
+	AmountTolerance   *int64 `json:"amount_tolerance" binding:"omitempty,min=0,max=100000"`
+}
+
+// Tolerance returns the matching tolerance of the request
+func (r *RunAPMatchingRequest) Tolerance() domain.APMatchTolerance {
+	tolerance := domain.DefaultAPMatchTolerance()
+	if r.DateToleranceDays != nil {
+		tolerance.DateDays = *r.DateToleranceDays
+	}
+	if r.AmountTolerance != nil {
+		tolerance.Amount = *r.AmountTolerance
+	}
+	return tolerance
+}
+
+// APWorklistRequest represents query parameters for the unmatched purchase documents of a period
+type APWorklistRequest struct {
+	From string `form:"from" binding:"required"`
+	To   string `form:"to" binding:"required"`
+}
+
+// MatchTaxInvoiceRequest represents a request to match a purchase tax invoice to a voucher by hand
+type MatchTaxInvoiceRequest struct {
+	VoucherID string `json:"voucher_id" binding:"required,uuid"`
+}
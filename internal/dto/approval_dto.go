@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ApprovalActionRequest represents a one-tap approve or reject action from
+// the mobile app, confirmed by the signing PIN
+type ApprovalActionRequest struct {
+	PIN    string `json:"pin" binding:"required,numeric,min=4,max=8"`
+	Reason string `json:"reason,omitempty" binding:"max=500"` // rejection reason
+}
+
+// RegisterPushDeviceRequest represents a request to receive push notifications on a device
+type RegisterPushDeviceRequest struct {
+	Platform   string `json:"platform" binding:"required,oneof=fcm apns"`
+	Token      string `json:"token" binding:"required,max=512"`
+	DeviceName string `json:"device_name,omitempty" binding:"max=100"`
+}
+
+// ApprovalItemResponse represents a document waiting for approval in the inbox
+type ApprovalItemResponse struct {
+	Type          string  `json:"type"`
+	ID            string  `json:"id"`
+	Number        string  `json:"number"`
+	Date          string  `json:"date"`
+	Title         string  `json:"title,omitempty"`
+	Amount        float64 `json:"amount"`
+	RequesterName string  `json:"requester_name,omitempty"`
+	RequestedAt   string  `json:"requested_at,omitempty"`
+}
+
+// ApprovalInboxResponse represents the approval inbox of the user
+type ApprovalInboxResponse struct {
+	Count int                    `json:"count"`
+	Items []ApprovalItemResponse `json:"items"`
+}
+
+// PushDeviceResponse represents a registered push device
+type PushDeviceResponse struct {
+	ID         string `json:"id"`
+	Platform   string `json:"platform"`
+	DeviceName string `json:"device_name,omitempty"`
+	LastSeenAt string `json:"last_seen_at"`
+}
+
+// FromApprovalItems converts the inbox items to ApprovalInboxResponse
+func FromApprovalItems(items []domain.ApprovalItem) ApprovalInboxResponse {
+	resp := ApprovalInboxResponse{Count: len(items), Items: make([]ApprovalItemResponse, len(items))}
+	for i, item := range items {
+		resp.Items[i] = ApprovalItemResponse{
+			Type:          string(item.Type),
+			ID:            item.ID.String(),
+			Number:        item.Number,
+			Date:          item.Date.Format("2006-01-02"),
+			Title:         item.Title,
+			Amount:        item.Amount,
+			RequesterName: item.RequesterName,
+		}
+		if item.RequestedAt != nil {
+			resp.Items[i].RequestedAt = item.RequestedAt.Format(time.RFC3339)
+		}
+	}
+	return resp
+}
+
+// FromPushDevice converts domain.PushDevice to PushDeviceResponse
+func FromPushDevice(d *domain.PushDevice) PushDeviceResponse {
+	return PushDeviceResponse{
+		ID:         d.ID.String(),
+		Platform:   string(d.Platform),
+		DeviceName: d.DeviceName,
+		LastSeenAt: d.LastSeenAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// LockFiscalYearRequest represents the request to lock a fiscal year after its audit sign-off
+type LockFiscalYearRequest struct {
+	Year      int    `json:"year" binding:"required,min=2000,max=2100"`
+	Reference string `json:"reference" binding:"max=200"` // audit report or sign-off reference
+}
+
+// UnlockFiscalYearRequest represents the request to lift the audit lock of a fiscal year
+type UnlockFiscalYearRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// AuditLockResponse represents a fiscal year audit lock in API responses
+type AuditLockResponse struct {
+	ID           string `json:"id"`
+	FiscalYear   int    `json:"fiscal_year"`
+	Reference    string `json:"reference,omitempty"`
+	Active       bool   `json:"active"`
+	LockedBy     string `json:"locked_by"`
+	LockedAt     string `json:"locked_at"`
+	UnlockedBy   string `json:"unlocked_by,omitempty"`
+	UnlockedAt   string `json:"unlocked_at,omitempty"`
+	UnlockReason string `json:"unlock_reason,omitempty"`
+}
+
+// FromAuditLock converts domain.AuditLock to AuditLockResponse
+func FromAuditLock(l *domain.AuditLock) AuditLockResponse {
+	resp := AuditLockResponse{
+		ID:           l.ID.String(),
+		FiscalYear:   l.FiscalYear,
+		Reference:    l.Reference,
+		Active:       l.IsActive(),
+		LockedBy:     l.LockedBy.String(),
+		LockedAt:     l.LockedAt.Format(time.RFC3339),
+		UnlockReason: l.UnlockReason,
+	}
+	if l.UnlockedBy != nil {
+		resp.UnlockedBy = l.UnlockedBy.String()
+	}
+	if l.UnlockedAt != nil {
+		resp.UnlockedAt = l.UnlockedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// FromAuditLocks converts a slice of domain.AuditLock to responses
+func FromAuditLocks(locks []domain.AuditLock) []AuditLockResponse {
+	resp := make([]AuditLockResponse, len(locks))
+	for i := range locks {
+		resp[i] = FromAuditLock(&locks[i])
+	}
+	return resp
+}
@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// RequestBackupRestoreRequest represents the request to restore a backup into the staging database
+type RequestBackupRestoreRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"` // e.g. the support ticket
+}
+
+// BackupResponse represents a tenant backup in API responses
+type BackupResponse struct {
+	ID           string         `json:"id"`
+	CompanyID    string         `json:"company_id"`
+	Trigger      string         `json:"trigger"`
+	Status       string         `json:"status"`
+	ScheduledFor string         `json:"scheduled_for,omitempty"`
+	Size         int64          `json:"size"`
+	Checksum     string         `json:"checksum,omitempty"`
+	RowCounts    map[string]int `json:"row_counts,omitempty"`
+	Monthly      bool           `json:"monthly"`
+	Error        string         `json:"error,omitempty"`
+	RequestedBy  string         `json:"requested_by,omitempty"`
+	StartedAt    string         `json:"started_at,omitempty"`
+	CompletedAt  string         `json:"completed_at,omitempty"`
+	ExpiresAt    string         `json:"expires_at,omitempty"`
+	CreatedAt    string         `json:"created_at"`
+}
+
+// FromBackup converts domain.TenantBackup to BackupResponse
+func FromBackup(b *domain.TenantBackup) BackupResponse {
+	resp := BackupResponse{
+		ID:        b.ID.String(),
+		CompanyID: b.CompanyID.String(),
+		Trigger:   string(b.Trigger),
+		Status:    string(b.Status),
+		Size:      b.Size,
+		Checksum:  b.Checksum,
+		RowCounts: b.RowCounts,
+		Monthly:   b.Monthly,
+		Error:     b.Error,
+		CreatedAt: b.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if b.ScheduledFor != nil {
+		resp.ScheduledFor = b.ScheduledFor.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if b.RequestedBy != nil {
+		resp.RequestedBy = b.RequestedBy.String()
+	}
+	if b.StartedAt != nil {
+		resp.StartedAt = b.StartedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if b.CompletedAt != nil {
+		resp.CompletedAt = b.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if b.ExpiresAt != nil {
+		resp.ExpiresAt = b.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
+
+// FromBackups converts a slice of domain.TenantBackup to responses
+func FromBackups(backups []domain.TenantBackup) []BackupResponse {
+	result := make([]BackupResponse, len(backups))
+	for i := range backups {
+		result[i] = FromBackup(&backups[i])
+	}
+	return result
+}
+
+// BackupRestoreResponse represents a restore into the staging database in API responses
+type BackupRestoreResponse struct {
+	ID           string         `json:"id"`
+	CompanyID    string         `json:"company_id"`
+	BackupID     string         `json:"backup_id"`
+	Status       string         `json:"status"`
+	Reason       string         `json:"reason"`
+	TargetSchema string         `json:"target_schema,omitempty"`
+	RowCounts    map[string]int `json:"row_counts,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	RequestedBy  string         `json:"requested_by"`
+	StartedAt    string         `json:"started_at,omitempty"`
+	CompletedAt  string         `json:"completed_at,omitempty"`
+	CreatedAt    string         `json:"created_at"`
+}
+
+// FromBackupRestore converts domain.BackupRestore to BackupRestoreResponse
+func FromBackupRestore(r *domain.BackupRestore) BackupRestoreResponse {
+	resp := BackupRestoreResponse{
+		ID:           r.ID.String(),
+		CompanyID:    r.CompanyID.String(),
+		BackupID:     r.BackupID.String(),
+		Status:       string(r.Status),
+		Reason:       r.Reason,
+		TargetSchema: r.TargetSchema,
+		RowCounts:    r.RowCounts,
+		Error:        r.Error,
+		RequestedBy:  r.RequestedBy.String(),
+		CreatedAt:    r.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if r.StartedAt != nil {
+		resp.StartedAt = r.StartedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if r.CompletedAt != nil {
+		resp.CompletedAt = r.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
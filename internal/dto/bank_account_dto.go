@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// BankAccountRequest represents a request to register or update a bank account
+type BankAccountRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	BankCode      string `json:"bank_code" binding:"required,len=3,numeric"`
+	BankName      string `json:"bank_name" binding:"max=50"`
+	AccountNumber string `json:"account_number" binding:"required,max=30"` // dashes are stripped
+	AccountHolder string `json:"account_holder" binding:"max=100"`
+	Currency      string `json:"currency" binding:"omitempty,len=3"`
+	AccountID     string `json:"account_id" binding:"required,uuid"` // cash account of the chart
+	IsActive      *bool  `json:"is_active"`
+}
+
+// ToDomain converts the request to domain.CompanyBankAccount; identifiers are validated by binding
+func (r *BankAccountRequest) ToDomain(companyID, userID uuid.UUID) *domain.CompanyBankAccount {
+	bank := &domain.CompanyBankAccount{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		Name:          r.Name,
+		BankCode:      r.BankCode,
+		BankName:      r.BankName,
+		AccountNumber: r.AccountNumber,
+		AccountHolder: r.AccountHolder,
+		Currency:      r.Currency,
+		AccountID:     uuid.MustParse(r.AccountID),
+		IsActive:      true,
+		CreatedBy:     &userID,
+	}
+	if r.IsActive != nil {
+		bank.IsActive = *r.IsActive
+	}
+	return bank
+}
+
+// RecordBankBalanceRequest represents a balance reported for a bank account
+type RecordBankBalanceRequest struct {
+	AsOf    time.Time `json:"as_of" binding:"required"`
+	Balance float64   `json:"balance"`
+	Source  string    `json:"source" binding:"omitempty,oneof=statement_import open_banking manual"`
+}
+
+// ToDomain converts the request to domain.BankAccountBalance; a balance
+// without a source is entered by hand
+func (r *RecordBankBalanceRequest) ToDomain() *domain.BankAccountBalance {
+	balance := &domain.BankAccountBalance{
+		AsOf:    r.AsOf,
+		Balance: r.Balance,
+		Source:  domain.BankBalanceSource(r.Source),
+	}
+	if balance.Source == "" {
+		balance.Source = domain.BankBalanceManual
+	}
+	return balance
+}
+
+// BankBalancesRequest represents query parameters of the bank balances summary
+type BankBalancesRequest struct {
+	AsOf string `form:"as_of" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// BankAccountResponse represents a bank account with its number masked
+type BankAccountResponse struct {
+	ID            string                   `json:"id"`
+	Name          string                   `json:"name"`
+	BankCode      string                   `json:"bank_code"`
+	BankName      string                   `json:"bank_name,omitempty"`
+	AccountNumber string                   `json:"account_number"`
+	AccountHolder string                   `json:"account_holder,omitempty"`
+	Currency      string                   `json:"currency"`
+	AccountID     string                   `json:"account_id"`
+	Balance       float64                  `json:"balance"`
+	BalanceAsOf   *time.Time               `json:"balance_as_of,omitempty"`
+	BalanceSource domain.BankBalanceSource `json:"balance_source,omitempty"`
+	IsActive      bool                     `json:"is_active"`
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+}
+
+// FromBankAccount converts domain.CompanyBankAccount to BankAccountResponse
+func FromBankAccount(bank *domain.CompanyBankAccount) BankAccountResponse {
+	return BankAccountResponse{
+		ID:            bank.ID.String(),
+		Name:          bank.Name,
+		BankCode:      bank.BankCode,
+		BankName:      bank.BankName,
+		AccountNumber: bank.MaskedNumber(),
+		AccountHolder: bank.AccountHolder,
+		Currency:      bank.Currency,
+		AccountID:     bank.AccountID.String(),
+		Balance:       bank.Balance,
+		BalanceAsOf:   bank.BalanceAsOf,
+		BalanceSource: bank.BalanceSource,
+		IsActive:      bank.IsActive,
+		CreatedAt:     bank.CreatedAt,
+		UpdatedAt:     bank.UpdatedAt,
+	}
+}
+
+// FromBankAccounts converts bank accounts to responses
+func FromBankAccounts(banks []domain.CompanyBankAccount) []BankAccountResponse {
+	resp := make([]BankAccountResponse, len(banks))
+	for i := range banks {
+		resp[i] = FromBankAccount(&banks[i])
+	}
+	return resp
+}
+
+// BankBalanceSummaryResponse represents the bank and book balances of a cash account
+type BankBalanceSummaryResponse struct {
+	AccountID    string                `json:"account_id"`
+	AccountCode  string                `json:"account_code"`
+	AccountName  string                `json:"account_name"`
+	BookBalance  float64               `json:"book_balance"`
+	BankBalance  float64               `json:"bank_balance"`
+	Difference   float64               `json:"difference"` // bank less book
+	BankAccounts []BankAccountResponse `json:"bank_accounts"`
+}
+
+// FromBankBalanceSummaries converts bank balance summaries to responses
+func FromBankBalanceSummaries(summaries []domain.BankBalanceSummary) []BankBalanceSummaryResponse {
+	resp := make([]BankBalanceSummaryResponse, len(summaries))
+	for i, s := range summaries {
+		resp[i] = BankBalanceSummaryResponse{
+			AccountID:    s.AccountID.String(),
+			AccountCode:  s.AccountCode,
+			AccountName:  s.AccountName,
+			BookBalance:  s.BookBalance,
+			BankBalance:  s.BankBalance,
+			Difference:   s.Difference,
+			BankAccounts: FromBankAccounts(s.BankAccounts),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CashBookRequest represents query parameters for the cash book
+type CashBookRequest struct {
+	From      string `form:"from" binding:"required"`
+	To        string `form:"to" binding:"required"`
+	AccountID string `form:"account_id" binding:"omitempty,uuid"`
+}
+
+// CashBookEntryResponse represents a cash book entry in API responses
+type CashBookEntryResponse struct {
+	VoucherID      string  `json:"voucher_id"`
+	VoucherNo      string  `json:"voucher_no"`
+	VoucherType    string  `json:"voucher_type"`
+	VoucherURL     string  `json:"voucher_url"`
+	LineNo         int     `json:"line_no"`
+	Description    string  `json:"description"`
+	PartnerName    string  `json:"partner_name,omitempty"`
+	CounterAccount string  `json:"counter_account,omitempty"`
+	Receipt        float64 `json:"receipt"`
+	Payment        float64 `json:"payment"`
+	Balance        float64 `json:"balance"`
+}
+
+// CashBookDayResponse represents the entries and totals of one day
+type CashBookDayResponse struct {
+	Date     string                  `json:"date"`
+	Receipts float64                 `json:"receipts"`
+	Payments float64                 `json:"payments"`
+	Balance  float64                 `json:"balance"`
+	Entries  []CashBookEntryResponse `json:"entries"`
+}
+
+// CashBookAccountResponse represents the cash book of one cash/bank account
+type CashBookAccountResponse struct {
+	AccountID      string                `json:"account_id"`
+	AccountCode    string                `json:"account_code"`
+	AccountName    string                `json:"account_name"`
+	OpeningBalance float64               `json:"opening_balance"`
+	TotalReceipts  float64               `json:"total_receipts"`
+	TotalPayments  float64               `json:"total_payments"`
+	ClosingBalance float64               `json:"closing_balance"`
+	Days           []CashBookDayResponse `json:"days"`
+}
+
+// CashBookResponse represents the cash and bank book (현금출납장)
+type CashBookResponse struct {
+	CompanyID      string                    `json:"company_id"`
+	FromDate       string                    `json:"from_date"`
+	ToDate         string                    `json:"to_date"`
+	GeneratedAt    string                    `json:"generated_at"`
+	Accounts       []CashBookAccountResponse `json:"accounts"`
+	OpeningBalance float64                   `json:"opening_balance"`
+	TotalReceipts  float64                   `json:"total_receipts"`
+	TotalPayments  float64                   `json:"total_payments"`
+	ClosingBalance float64                   `json:"closing_balance"`
+}
+
+// FromCashBook converts the account cash books to a response with totals across accounts
+func FromCashBook(books []domain.CashBookAccount) CashBookResponse {
+	resp := CashBookResponse{Accounts: make([]CashBookAccountResponse, len(books))}
+	for i, b := range books {
+		account := CashBookAccountResponse{
+			AccountID:      b.AccountID.String(),
+			AccountCode:    b.AccountCode,
+			AccountName:    b.AccountName,
+			OpeningBalance: b.OpeningBalance,
+			TotalReceipts:  b.TotalReceipts,
+			TotalPayments:  b.TotalPayments,
+			ClosingBalance: b.ClosingBalance,
+			Days:           make([]CashBookDayResponse, len(b.Days)),
+		}
+		for j, d := range b.Days {
+			day := CashBookDayResponse{
+				Date:     d.Date.Format("2006-01-02"),
+				Receipts: d.Receipts,
+				Payments: d.Payments,
+				Balance:  d.Balance,
+				Entries:  make([]CashBookEntryResponse, len(d.Entries)),
+			}
+			for k, e := range d.Entries {
+				day.Entries[k] = CashBookEntryResponse{
+					VoucherID:      e.VoucherID.String(),
+					VoucherNo:      e.VoucherNo,
+					VoucherType:    e.VoucherType,
+					VoucherURL:     "/api/v1/vouchers/" + e.VoucherID.String(),
+					LineNo:         e.LineNo,
+					Description:    e.Description,
+					PartnerName:    e.PartnerName,
+					CounterAccount: e.CounterAccount,
+					Receipt:        e.Receipt,
+					Payment:        e.Payment,
+					Balance:        e.Balance,
+				}
+			}
+			account.Days[j] = day
+		}
+		resp.Accounts[i] = account
+
+		resp.OpeningBalance += b.OpeningBalance
+		resp.TotalReceipts += b.TotalReceipts
+		resp.TotalPayments += b.TotalPayments
+		resp.ClosingBalance += b.ClosingBalance
+	}
+	return resp
+}
@@ -0,0 +1,180 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateCloseTaskRequest represents the request to create a close checklist task
+type CreateCloseTaskRequest struct {
+	Code        string `json:"code" binding:"required,max=50"`
+	Name        string `json:"name" binding:"required,max=200"`
+	Description string `json:"description" binding:"max=500"`
+	IsMandatory *bool  `json:"is_mandatory"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// ToCloseChecklistTask converts the request to a domain.CloseChecklistTask
+func (r *CreateCloseTaskRequest) ToCloseChecklistTask(companyID uuid.UUID) *domain.CloseChecklistTask {
+	task := &domain.CloseChecklistTask{
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		IsMandatory: true,
+		IsActive:    true,
+		SortOrder:   r.SortOrder,
+	}
+	task.CompanyID = companyID
+	if r.IsMandatory != nil {
+		task.IsMandatory = *r.IsMandatory
+	}
+	return task
+}
+
+// UpdateCloseTaskRequest represents the request to update a close checklist task
+type UpdateCloseTaskRequest struct {
+	Code        *string `json:"code" binding:"omitempty,max=50"`
+	Name        *string `json:"name" binding:"omitempty,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	IsMandatory *bool   `json:"is_mandatory"`
+	IsActive    *bool   `json:"is_active"`
+	SortOrder   *int    `json:"sort_order"`
+}
+
+// ApplyTo applies the non-nil fields to an existing task
+func (r *UpdateCloseTaskRequest) ApplyTo(task *domain.CloseChecklistTask) {
+	if r.Code != nil {
+		task.Code = *r.Code
+	}
+	if r.Name != nil {
+		task.Name = *r.Name
+	}
+	if r.Description != nil {
+		task.Description = *r.Description
+	}
+	if r.IsMandatory != nil {
+		task.IsMandatory = *r.IsMandatory
+	}
+	if r.IsActive != nil {
+		task.IsActive = *r.IsActive
+	}
+	if r.SortOrder != nil {
+		task.SortOrder = *r.SortOrder
+	}
+}
+
+// UpdatePeriodTaskRequest represents the request to set a task's status for a period
+type UpdatePeriodTaskRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending completed skipped"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+// CloseTaskResponse represents a close checklist task in API responses
+type CloseTaskResponse struct {
+	ID          string `json:"id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IsMandatory bool   `json:"is_mandatory"`
+	IsActive    bool   `json:"is_active"`
+	SortOrder   int    `json:"sort_order"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// FromCloseTask converts domain.CloseChecklistTask to CloseTaskResponse
+func FromCloseTask(task *domain.CloseChecklistTask) CloseTaskResponse {
+	return CloseTaskResponse{
+		ID:          task.ID.String(),
+		Code:        task.Code,
+		Name:        task.Name,
+		Description: task.Description,
+		IsMandatory: task.IsMandatory,
+		IsActive:    task.IsActive,
+		SortOrder:   task.SortOrder,
+		CreatedAt:   task.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   task.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// FromCloseTasks converts a slice of domain.CloseChecklistTask to CloseTaskResponse slice
+func FromCloseTasks(tasks []domain.CloseChecklistTask) []CloseTaskResponse {
+	result := make([]CloseTaskResponse, len(tasks))
+	for i, t := range tasks {
+		result[i] = FromCloseTask(&t)
+	}
+	return result
+}
+
+// PeriodTaskResponse represents a checklist task with its status for a period
+type PeriodTaskResponse struct {
+	TaskID      string  `json:"task_id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	IsMandatory bool    `json:"is_mandatory"`
+	Status      string  `json:"status"`
+	Note        string  `json:"note,omitempty"`
+	CompletedBy *string `json:"completed_by,omitempty"`
+	CompletedAt *string `json:"completed_at,omitempty"`
+}
+
+// PeriodChecklistResponse represents the close checklist for a fiscal period
+type PeriodChecklistResponse struct {
+	FiscalYear       int                  `json:"fiscal_year"`
+	FiscalMonth      int                  `json:"fiscal_month"`
+	Tasks            []PeriodTaskResponse `json:"tasks"`
+	TotalCount       int                  `json:"total_count"`
+	DoneCount        int                  `json:"done_count"`
+	OutstandingCount int                  `json:"outstanding_mandatory_count"`
+	IsComplete       bool                 `json:"is_complete"`
+}
+
+// FromPeriodCloseTask converts a task and its period status to PeriodTaskResponse
+func FromPeriodCloseTask(task *domain.CloseChecklistTask, status *domain.PeriodCloseTask) PeriodTaskResponse {
+	resp := PeriodTaskResponse{
+		TaskID:      task.ID.String(),
+		Code:        task.Code,
+		Name:        task.Name,
+		Description: task.Description,
+		IsMandatory: task.IsMandatory,
+		Status:      string(domain.CloseTaskStatusPending),
+	}
+
+	if status != nil {
+		resp.Status = string(status.Status)
+		resp.Note = status.Note
+		if status.CompletedBy != nil {
+			completedBy := status.CompletedBy.String()
+			resp.CompletedBy = &completedBy
+		}
+		if status.CompletedAt != nil {
+			completedAt := status.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+			resp.CompletedAt = &completedAt
+		}
+	}
+
+	return resp
+}
+
+// FromPeriodChecklist converts domain.PeriodChecklist to PeriodChecklistResponse
+func FromPeriodChecklist(checklist *domain.PeriodChecklist) PeriodChecklistResponse {
+	resp := PeriodChecklistResponse{
+		FiscalYear:       checklist.FiscalYear,
+		FiscalMonth:      checklist.FiscalMonth,
+		Tasks:            make([]PeriodTaskResponse, len(checklist.Items)),
+		TotalCount:       len(checklist.Items),
+		OutstandingCount: len(checklist.OutstandingMandatory()),
+		IsComplete:       checklist.IsComplete(),
+	}
+
+	for i := range checklist.Items {
+		item := &checklist.Items[i]
+		resp.Tasks[i] = FromPeriodCloseTask(&item.Task, item.Status)
+		if item.IsDone() {
+			resp.DoneCount++
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CloseConfirmationResponse represents a close awaiting or past its confirmation in API responses
+type CloseConfirmationResponse struct {
+	ID                        string `json:"id"`
+	Kind                      string `json:"kind"`
+	Year                      int    `json:"year"`
+	Month                     int    `json:"month,omitempty"`
+	Override                  bool   `json:"override,omitempty"`
+	RetainedEarningsAccountID string `json:"retained_earnings_account_id,omitempty"`
+	Status                    string `json:"status"` // pending confirmations past their window are reported expired
+	InitiatedBy               string `json:"initiated_by"`
+	ExpiresAt                 string `json:"expires_at"`
+	ConfirmedBy               string `json:"confirmed_by,omitempty"`
+	ConfirmedAt               string `json:"confirmed_at,omitempty"`
+	CancelledBy               string `json:"cancelled_by,omitempty"`
+	CancelledAt               string `json:"cancelled_at,omitempty"`
+	CreatedAt                 string `json:"created_at"`
+}
+
+// FromCloseConfirmation converts domain.CloseConfirmation to CloseConfirmationResponse as of now
+func FromCloseConfirmation(c *domain.CloseConfirmation, now time.Time) CloseConfirmationResponse {
+	resp := CloseConfirmationResponse{
+		ID:          c.ID.String(),
+		Kind:        string(c.Kind),
+		Year:        c.Year,
+		Month:       c.Month,
+		Override:    c.Override,
+		Status:      string(c.StatusAt(now)),
+		InitiatedBy: c.InitiatedBy.String(),
+		ExpiresAt:   c.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.RetainedEarningsAccountID != nil {
+		resp.RetainedEarningsAccountID = c.RetainedEarningsAccountID.String()
+	}
+	if c.ConfirmedBy != nil {
+		resp.ConfirmedBy = c.ConfirmedBy.String()
+	}
+	if c.ConfirmedAt != nil {
+		resp.ConfirmedAt = c.ConfirmedAt.Format(time.RFC3339)
+	}
+	if c.CancelledBy != nil {
+		resp.CancelledBy = c.CancelledBy.String()
+	}
+	if c.CancelledAt != nil {
+		resp.CancelledAt = c.CancelledAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// FromCloseConfirmations converts a slice of domain.CloseConfirmation to responses as of now
+func FromCloseConfirmations(confirmations []domain.CloseConfirmation, now time.Time) []CloseConfirmationResponse {
+	resp := make([]CloseConfirmationResponse, len(confirmations))
+	for i := range confirmations {
+		resp[i] = FromCloseConfirmation(&confirmations[i], now)
+	}
+	return resp
+}
+
+// CloseResultResponse is the outcome of a period or year-end close. Under dual
+// control the close is pending until another user confirms it.
+type CloseResultResponse struct {
+	Closed       bool                       `json:"closed"`
+	Confirmation *CloseConfirmationResponse `json:"confirmation,omitempty"`
+	Rollover     *domain.YearRolloverRun    `json:"rollover,omitempty"`
+}
+
+// NewCloseResult builds the outcome of a close as of now
+func NewCloseResult(confirmation *domain.CloseConfirmation, run *domain.YearRolloverRun, now time.Time) CloseResultResponse {
+	resp := CloseResultResponse{Closed: confirmation == nil || !confirmation.IsPending(), Rollover: run}
+	if confirmation != nil {
+		c := FromCloseConfirmation(confirmation, now)
+		resp.Confirmation = &c
+	}
+	return resp
+}
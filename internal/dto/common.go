@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/validation"
+)
+
 // Response represents a standard API response
 type Response struct {
 	Success bool        `json:"success"`
@@ -13,6 +18,9 @@ type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+
+	// Fields lists the rejected request fields of a validation error
+	Fields []validation.FieldError `json:"fields,omitempty"`
 }
 
 // MetaInfo represents metadata for paginated responses
@@ -63,15 +71,28 @@ func ErrorResponseWithDetails(code, message, details string) Response {
 	}
 }
 
-// Common error codes
+// ValidationErrorResponse creates an error response with field-level details from a binding error
+func ValidationErrorResponse(code, message string, err error) Response {
+	return Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+			Fields:  validation.FieldErrors(err),
+		},
+	}
+}
+
+// Common error codes, aliases of the error taxonomy in internal/errors
 const (
-	ErrCodeBadRequest          = "BAD_REQUEST"
-	ErrCodeUnauthorized        = "UNAUTHORIZED"
-	ErrCodeForbidden           = "FORBIDDEN"
-	ErrCodeNotFound            = "NOT_FOUND"
-	ErrCodeConflict            = "CONFLICT"
-	ErrCodeValidation          = "VALIDATION_ERROR"
-	ErrCodeInternalServerError = "INTERNAL_SERVER_ERROR"
+	ErrCodeBadRequest          = errors.CodeInvalidInput
+	ErrCodeUnauthorized        = errors.CodeUnauthorized
+	ErrCodeForbidden           = errors.CodeForbidden
+	ErrCodeNotFound            = errors.CodeNotFound
+	ErrCodeConflict            = errors.CodeConflict
+	ErrCodeValidation          = errors.CodeValidation
+	ErrCodeInternalServerError = errors.CodeInternal
+	ErrCodePlanLimitExceeded   = errors.CodePlanLimitExceeded
 )
 
 // SimpleErrorResponse is used for simple error responses without wrapper
@@ -1,6 +1,10 @@
 package dto
 
 import (
+	"time"
+
+	"github.com/google/uuid"
+
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
 
@@ -16,6 +20,80 @@ type CompanySettingsResponse struct {
 	Timezone            string  `json:"timezone"`
 	DateFormat          string  `json:"date_format"`
 	Language            string  `json:"language"`
+
+	RoundingRule             string `json:"rounding_rule"`
+	RequireApproval          bool   `json:"require_approval"`
+	RequireApprovalSignature bool   `json:"require_approval_signature"`
+
+	ApprovalExemption ApprovalExemptionResponse `json:"approval_exemption"`
+
+	DuplicateCheckMode string `json:"duplicate_check_mode"`
+	DuplicateCheckDays int    `json:"duplicate_check_days"`
+
+	EnforceSegregationOfDuties bool `json:"enforce_segregation_of_duties"`
+
+	RetainedEarningsAccountID string `json:"retained_earnings_account_id,omitempty"`
+
+	RequireDualCloseControl bool `json:"require_dual_close_control"`
+	CloseConfirmationHours  int  `json:"close_confirmation_hours"`
+
+	PartnerCodeRule domain.PartnerCodeRule `json:"partner_code_rule"`
+}
+
+// ApprovalExemptionResponse represents the approval exemption policy in API responses
+type ApprovalExemptionResponse struct {
+	Enabled      bool     `json:"enabled"`
+	MaxAmount    float64  `json:"max_amount"`
+	VoucherTypes []string `json:"voucher_types"`
+}
+
+// FromApprovalExemption converts the policy of the settings to ApprovalExemptionResponse
+func FromApprovalExemption(settings domain.CompanySettings) ApprovalExemptionResponse {
+	resp := ApprovalExemptionResponse{VoucherTypes: []string{}}
+	if e := settings.ApprovalExemption; e != nil {
+		resp.Enabled = e.Enabled
+		resp.MaxAmount = e.MaxAmount
+		for _, t := range e.VoucherTypes {
+			resp.VoucherTypes = append(resp.VoucherTypes, string(t))
+		}
+	}
+	return resp
+}
+
+// FromCompanySettings converts domain.CompanySettings to CompanySettingsResponse
+func FromCompanySettings(settings domain.CompanySettings) CompanySettingsResponse {
+	resp := CompanySettingsResponse{
+		FiscalYearStart:     settings.FiscalYearStart,
+		DefaultCurrency:     settings.DefaultCurrency,
+		DecimalPlaces:       settings.DecimalPlaces,
+		TaxRate:             settings.TaxRate,
+		VoucherAutoNumber:   settings.VoucherAutoNumber,
+		VoucherNumberFormat: settings.VoucherNumberFormat,
+		InvoicePrefix:       settings.InvoicePrefix,
+		Timezone:            settings.Timezone,
+		DateFormat:          settings.DateFormat,
+		Language:            settings.Language,
+
+		RoundingRule:             string(settings.Rounding()),
+		RequireApproval:          settings.ApprovalRequired(),
+		RequireApprovalSignature: settings.RequireApprovalSignature,
+
+		ApprovalExemption: FromApprovalExemption(settings),
+
+		DuplicateCheckMode: string(settings.DuplicateCheck()),
+		DuplicateCheckDays: settings.DuplicateCheckWindow(),
+
+		EnforceSegregationOfDuties: settings.EnforceSegregationOfDuties,
+
+		RequireDualCloseControl: settings.RequireDualCloseControl,
+		CloseConfirmationHours:  int(settings.CloseConfirmationValidity() / time.Hour),
+
+		PartnerCodeRule: settings.PartnerCodes(),
+	}
+	if settings.RetainedEarningsAccountID != nil {
+		resp.RetainedEarningsAccountID = settings.RetainedEarningsAccountID.String()
+	}
+	return resp
 }
 
 // CompanyResponse represents a company in API responses
@@ -58,21 +136,10 @@ func FromCompany(company *domain.Company) CompanyResponse {
 		Address:        company.Address,
 		AddressDetail:  company.AddressDetail,
 		Status:         string(company.Status),
-		Settings: CompanySettingsResponse{
-			FiscalYearStart:     company.Settings.FiscalYearStart,
-			DefaultCurrency:     company.Settings.DefaultCurrency,
-			DecimalPlaces:       company.Settings.DecimalPlaces,
-			TaxRate:             company.Settings.TaxRate,
-			VoucherAutoNumber:   company.Settings.VoucherAutoNumber,
-			VoucherNumberFormat: company.Settings.VoucherNumberFormat,
-			InvoicePrefix:       company.Settings.InvoicePrefix,
-			Timezone:            company.Settings.Timezone,
-			DateFormat:          company.Settings.DateFormat,
-			Language:            company.Settings.Language,
-		},
-		Logo:      company.Logo,
-		CreatedAt: company.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: company.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Settings:       FromCompanySettings(company.Settings),
+		Logo:           company.Logo,
+		CreatedAt:      company.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:      company.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	if company.TrialEndsAt != nil {
@@ -127,38 +194,121 @@ type UpdateCompanySettingsRequest struct {
 	Timezone            string   `json:"timezone,omitempty" binding:"max=50"`
 	DateFormat          string   `json:"date_format,omitempty" binding:"max=20"`
 	Language            string   `json:"language,omitempty" binding:"max=10"`
+
+	RoundingRule             string `json:"rounding_rule,omitempty" binding:"omitempty,oneof=truncate half_up up"`
+	RequireApproval          *bool  `json:"require_approval,omitempty"`
+	RequireApprovalSignature *bool  `json:"require_approval_signature,omitempty"`
+
+	DuplicateCheckMode string `json:"duplicate_check_mode,omitempty" binding:"omitempty,oneof=off warn block"`
+	DuplicateCheckDays *int   `json:"duplicate_check_days,omitempty" binding:"omitempty,min=0,max=90"`
+
+	EnforceSegregationOfDuties *bool `json:"enforce_segregation_of_duties,omitempty"`
+
+	RetainedEarningsAccountID *string `json:"retained_earnings_account_id,omitempty" binding:"omitempty,uuid"`
+
+	RequireDualCloseControl *bool `json:"require_dual_close_control,omitempty"`
+	CloseConfirmationHours  *int  `json:"close_confirmation_hours,omitempty" binding:"omitempty,min=1,max=168"`
+
+	PartnerCodeRule *PartnerCodeRuleRequest `json:"partner_code_rule,omitempty"`
 }
 
-// ApplyTo applies the settings update to an existing company
-func (r *UpdateCompanySettingsRequest) ApplyTo(company *domain.Company) {
+// PartnerCodeRuleRequest represents the rule generating the codes of partners
+// created without one; it replaces the rule as a whole
+type PartnerCodeRuleRequest struct {
+	CustomerPrefix string `json:"customer_prefix" binding:"omitempty,max=10,alphanum"`
+	VendorPrefix   string `json:"vendor_prefix" binding:"omitempty,max=10,alphanum"`
+	BothPrefix     string `json:"both_prefix" binding:"omitempty,max=10,alphanum"`
+	Digits         int    `json:"digits" binding:"min=3,max=10"`
+}
+
+// ApplyTo applies the settings update to existing settings
+func (r *UpdateCompanySettingsRequest) ApplyTo(settings *domain.CompanySettings) {
 	if r.FiscalYearStart != nil {
-		company.Settings.FiscalYearStart = *r.FiscalYearStart
+		settings.FiscalYearStart = *r.FiscalYearStart
 	}
 	if r.DefaultCurrency != "" {
-		company.Settings.DefaultCurrency = r.DefaultCurrency
+		settings.DefaultCurrency = r.DefaultCurrency
 	}
 	if r.DecimalPlaces != nil {
-		company.Settings.DecimalPlaces = *r.DecimalPlaces
+		settings.DecimalPlaces = *r.DecimalPlaces
 	}
 	if r.TaxRate != nil {
-		company.Settings.TaxRate = *r.TaxRate
+		settings.TaxRate = *r.TaxRate
 	}
 	if r.VoucherAutoNumber != nil {
-		company.Settings.VoucherAutoNumber = *r.VoucherAutoNumber
+		settings.VoucherAutoNumber = *r.VoucherAutoNumber
 	}
 	if r.VoucherNumberFormat != "" {
-		company.Settings.VoucherNumberFormat = r.VoucherNumberFormat
+		settings.VoucherNumberFormat = r.VoucherNumberFormat
 	}
 	if r.InvoicePrefix != "" {
-		company.Settings.InvoicePrefix = r.InvoicePrefix
+		settings.InvoicePrefix = r.InvoicePrefix
 	}
 	if r.Timezone != "" {
-		company.Settings.Timezone = r.Timezone
+		settings.Timezone = r.Timezone
 	}
 	if r.DateFormat != "" {
-		company.Settings.DateFormat = r.DateFormat
+		settings.DateFormat = r.DateFormat
 	}
 	if r.Language != "" {
-		company.Settings.Language = r.Language
+		settings.Language = r.Language
+	}
+	if r.RoundingRule != "" {
+		settings.RoundingRule = domain.RoundingRule(r.RoundingRule)
+	}
+	if r.RequireApproval != nil {
+		requireApproval := *r.RequireApproval
+		settings.RequireApproval = &requireApproval
+	}
+	if r.RequireApprovalSignature != nil {
+		settings.RequireApprovalSignature = *r.RequireApprovalSignature
+	}
+	if r.DuplicateCheckMode != "" {
+		settings.DuplicateCheckMode = domain.DuplicateCheckMode(r.DuplicateCheckMode)
+	}
+	if r.DuplicateCheckDays != nil {
+		days := *r.DuplicateCheckDays
+		settings.DuplicateCheckDays = &days
+	}
+	if r.EnforceSegregationOfDuties != nil {
+		settings.EnforceSegregationOfDuties = *r.EnforceSegregationOfDuties
+	}
+	if r.RetainedEarningsAccountID != nil {
+		accountID := uuid.MustParse(*r.RetainedEarningsAccountID)
+		settings.RetainedEarningsAccountID = &accountID
+	}
+	if r.RequireDualCloseControl != nil {
+		settings.RequireDualCloseControl = *r.RequireDualCloseControl
+	}
+	if r.CloseConfirmationHours != nil {
+		hours := *r.CloseConfirmationHours
+		settings.CloseConfirmationHours = &hours
+	}
+	if r.PartnerCodeRule != nil {
+		settings.PartnerCodeRule = &domain.PartnerCodeRule{
+			CustomerPrefix: r.PartnerCodeRule.CustomerPrefix,
+			VendorPrefix:   r.PartnerCodeRule.VendorPrefix,
+			BothPrefix:     r.PartnerCodeRule.BothPrefix,
+			Digits:         r.PartnerCodeRule.Digits,
+		}
+	}
+}
+
+// UpdateApprovalExemptionRequest represents the request to replace the approval exemption policy
+type UpdateApprovalExemptionRequest struct {
+	Enabled      bool     `json:"enabled"`
+	MaxAmount    float64  `json:"max_amount" binding:"min=0"`
+	VoucherTypes []string `json:"voucher_types" binding:"dive,oneof=general sales purchase payment receipt adjustment closing"`
+}
+
+// ApplyTo replaces the approval exemption of the settings
+func (r *UpdateApprovalExemptionRequest) ApplyTo(settings *domain.CompanySettings) {
+	exemption := &domain.ApprovalExemption{
+		Enabled:   r.Enabled,
+		MaxAmount: r.MaxAmount,
+	}
+	for _, t := range r.VoucherTypes {
+		exemption.VoucherTypes = append(exemption.VoucherTypes, domain.VoucherType(t))
 	}
+	settings.ApprovalExemption = exemption
 }
@@ -0,0 +1,7 @@
+package dto
+
+// SoDViolationsRequest represents query parameters of the segregation of duties report
+type SoDViolationsRequest struct {
+	DateFrom string `form:"date_from" binding:"required,datetime=2006-01-02"`
+	DateTo   string `form:"date_to" binding:"required,datetime=2006-01-02"`
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateContractRequest represents a request to register a contract with its
+// billing milestones
+type CreateContractRequest struct {
+	ContractNo  string `json:"contract_no" binding:"required,max=50"`
+	Title       string `json:"title" binding:"required,max=200"`
+	PartnerID   string `json:"partner_id" binding:"required,uuid"`
+	StartDate   string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate     string `json:"end_date" binding:"required,datetime=2006-01-02"`
+	Amount      int64  `json:"amount" binding:"required,min=1"` // supply amount excluding VAT
+	Description string `json:"description" binding:"max=500"`
+
+	TaxCodeID           string `json:"tax_code_id" binding:"omitempty,uuid"` // output VAT of the invoices
+	RevenueAccountID    string `json:"revenue_account_id" binding:"required,uuid"`
+	ReceivableAccountID string `json:"receivable_account_id" binding:"omitempty,uuid"` // defaults to the partner's receivable
+
+	Milestones []ContractMilestoneRequest `json:"milestones" binding:"required,min=1,max=120,dive"`
+}
+
+// ContractMilestoneRequest is a billing milestone of a new contract
+type ContractMilestoneRequest struct {
+	Name    string `json:"name" binding:"required,max=200"`
+	DueDate string `json:"due_date" binding:"required,datetime=2006-01-02"`
+	Amount  int64  `json:"amount" binding:"required,min=1"` // supply amount excluding VAT
+}
+
+// ToDomain converts the request to domain.Contract; identifiers and dates are validated by binding
+func (r *CreateContractRequest) ToDomain(companyID, userID uuid.UUID) *domain.Contract {
+	startDate, _ := time.Parse("2006-01-02", r.StartDate)
+	endDate, _ := time.Parse("2006-01-02", r.EndDate)
+	contract := &domain.Contract{
+		TenantModel:      domain.TenantModel{CompanyID: companyID},
+		ContractNo:       r.ContractNo,
+		Title:            r.Title,
+		PartnerID:        uuid.MustParse(r.PartnerID),
+		StartDate:        startDate,
+		EndDate:          endDate,
+		Amount:           r.Amount,
+		Description:      r.Description,
+		RevenueAccountID: uuid.MustParse(r.RevenueAccountID),
+		CreatedBy:        &userID,
+	}
+	if r.TaxCodeID != "" {
+		taxCodeID := uuid.MustParse(r.TaxCodeID)
+		contract.TaxCodeID = &taxCodeID
+	}
+	if r.ReceivableAccountID != "" {
+		contract.ReceivableAccountID = uuid.MustParse(r.ReceivableAccountID)
+	}
+	contract.Milestones = make([]domain.ContractMilestone, len(r.Milestones))
+	for i, m := range r.Milestones {
+		dueDate, _ := time.Parse("2006-01-02", m.DueDate)
+		contract.Milestones[i] = domain.ContractMilestone{
+			Name:    m.Name,
+			DueDate: dueDate,
+			Amount:  m.Amount,
+		}
+	}
+	return contract
+}
+
+// InvoiceContractMilestoneRequest represents the invoicing of a milestone
+type InvoiceContractMilestoneRequest struct {
+	IssueDate     string `json:"issue_date" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	InvoiceNumber string `json:"invoice_number" binding:"max=50"`                    // defaults to the contract number and milestone sequence
+}
+
+// CollectContractMilestoneRequest represents a receipt of an invoiced milestone
+type CollectContractMilestoneRequest struct {
+	CollectedDate string `json:"collected_date" binding:"required,datetime=2006-01-02"`
+	Amount        int64  `json:"amount" binding:"required,min=1"`
+	BankAccountID string `json:"bank_account_id" binding:"required,uuid"`
+}
+
+// ContractBillingReportRequest holds the query of the billing report
+type ContractBillingReportRequest struct {
+	AsOf      string `form:"as_of" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	PartnerID string `form:"partner_id" binding:"omitempty,uuid"`
+	Status    string `form:"status" binding:"omitempty,oneof=active completed"`
+}
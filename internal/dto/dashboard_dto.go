@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// DashboardResponse represents the main screen summary of a company
+type DashboardResponse struct {
+	Year               int                `json:"year"`
+	Month              int                `json:"month"`
+	Revenue            float64            `json:"revenue"`
+	Expense            float64            `json:"expense"`
+	NetIncome          float64            `json:"net_income"`
+	CashBalance        float64            `json:"cash_balance"`
+	OverdueReceivables float64            `json:"overdue_receivables"`
+	OverduePayables    float64            `json:"overdue_payables"`
+	PendingApprovals   int                `json:"pending_approvals"`
+	RecentActivity     []ActivityResponse `json:"recent_activity"`
+	GeneratedAt        time.Time          `json:"generated_at"`
+}
+
+// FromDashboardSummary converts domain.DashboardSummary to DashboardResponse
+func FromDashboardSummary(d *domain.DashboardSummary, loc i18n.Locale) DashboardResponse {
+	return DashboardResponse{
+		Year:               d.Year,
+		Month:              d.Month,
+		Revenue:            d.Revenue,
+		Expense:            d.Expense,
+		NetIncome:          d.NetIncome,
+		CashBalance:        d.CashBalance,
+		OverdueReceivables: d.OverdueReceivables,
+		OverduePayables:    d.OverduePayables,
+		PendingApprovals:   d.PendingApprovals,
+		RecentActivity:     FromActivities(d.RecentActivity, loc),
+		GeneratedAt:        d.GeneratedAt,
+	}
+}
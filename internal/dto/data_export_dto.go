@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateDataExportRequest represents the request to start a full tenant data export
+type CreateDataExportRequest struct {
+	Format  string `json:"format" binding:"omitempty,oneof=csv json"`
+	MaskPII bool   `json:"mask_pii"`
+}
+
+// DataExportResponse represents a data export in API responses
+type DataExportResponse struct {
+	ID          string         `json:"id"`
+	Status      string         `json:"status"`
+	Format      string         `json:"format"`
+	MaskPII     bool           `json:"mask_pii"`
+	FileName    string         `json:"file_name,omitempty"`
+	FileSize    int            `json:"file_size"`
+	RowCounts   map[string]int `json:"row_counts,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	RequestedBy string         `json:"requested_by,omitempty"`
+	StartedAt   string         `json:"started_at,omitempty"`
+	CompletedAt string         `json:"completed_at,omitempty"`
+	ExpiresAt   string         `json:"expires_at,omitempty"`
+	CreatedAt   string         `json:"created_at"`
+
+	// Signed download link, present once the export is completed
+	DownloadURL       string `json:"download_url,omitempty"`
+	DownloadExpiresAt string `json:"download_expires_at,omitempty"`
+}
+
+// FromDataExport converts domain.DataExport to DataExportResponse
+func FromDataExport(e *domain.DataExport) DataExportResponse {
+	resp := DataExportResponse{
+		ID:        e.ID.String(),
+		Status:    string(e.Status),
+		Format:    string(e.Format),
+		MaskPII:   e.MaskPII,
+		FileName:  e.FileName,
+		FileSize:  e.FileSize,
+		RowCounts: e.RowCounts,
+		Error:     e.Error,
+		CreatedAt: e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if e.RequestedBy != nil {
+		resp.RequestedBy = e.RequestedBy.String()
+	}
+	if e.StartedAt != nil {
+		resp.StartedAt = e.StartedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if e.CompletedAt != nil {
+		resp.CompletedAt = e.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if e.ExpiresAt != nil {
+		resp.ExpiresAt = e.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
+
+// FromDataExports converts a slice of domain.DataExport to responses
+func FromDataExports(exports []domain.DataExport) []DataExportResponse {
+	result := make([]DataExportResponse, len(exports))
+	for i := range exports {
+		result[i] = FromDataExport(&exports[i])
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateDeletionRequestRequest represents a request to delete the personal data of a user or partner contact
+type CreateDeletionRequestRequest struct {
+	SubjectType string `json:"subject_type" binding:"required,oneof=user partner"`
+	SubjectID   string `json:"subject_id" binding:"required,uuid"`
+	Reason      string `json:"reason" binding:"max=1000"`
+}
+
+// RequestOwnDeletionRequest represents a user's request to delete their own account
+type RequestOwnDeletionRequest struct {
+	Reason string `json:"reason" binding:"max=1000"`
+}
+
+// RejectDeletionRequestRequest represents the refusal of a deletion request
+type RejectDeletionRequestRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"` // legal basis of the refusal
+}
+
+// ListDeletionRequestsRequest represents the query of the deletion request report
+type ListDeletionRequestsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending completed rejected all"` // pending by default
+}
+
+// DeletionRequestResponse represents a deletion request in API responses
+type DeletionRequestResponse struct {
+	ID              string `json:"id"`
+	SubjectType     string `json:"subject_type"`
+	SubjectID       string `json:"subject_id"`
+	Status          string `json:"status"`
+	Reason          string `json:"reason,omitempty"`
+	RequestedBy     string `json:"requested_by"`
+	DueAt           string `json:"due_at"`
+	DaysRemaining   int    `json:"days_remaining"` // negative once overdue
+	Overdue         bool   `json:"overdue"`
+	ProcessedBy     string `json:"processed_by,omitempty"`
+	ProcessedAt     string `json:"processed_at,omitempty"`
+	RejectionReason string `json:"rejection_reason,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// DeletionRequestReportResponse represents the deletion requests of a company with their deadlines
+type DeletionRequestReportResponse struct {
+	Requests []DeletionRequestResponse `json:"requests"`
+	Pending  int                       `json:"pending"`
+	Overdue  int                       `json:"overdue"`
+}
+
+// FromDeletionRequest converts domain.DataDeletionRequest to DeletionRequestResponse as of now
+func FromDeletionRequest(r *domain.DataDeletionRequest, now time.Time) DeletionRequestResponse {
+	resp := DeletionRequestResponse{
+		ID:              r.ID.String(),
+		SubjectType:     string(r.SubjectType),
+		SubjectID:       r.SubjectID.String(),
+		Status:          string(r.Status),
+		Reason:          r.Reason,
+		RequestedBy:     r.RequestedBy.String(),
+		DueAt:           r.DueAt.Format("2006-01-02T15:04:05Z07:00"),
+		Overdue:         r.IsOverdue(now),
+		RejectionReason: r.RejectionReason,
+		CreatedAt:       r.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if r.IsPending() {
+		resp.DaysRemaining = int(math.Ceil(r.DueAt.Sub(now).Hours() / 24))
+	}
+	if r.ProcessedBy != nil {
+		resp.ProcessedBy = r.ProcessedBy.String()
+	}
+	if r.ProcessedAt != nil {
+		resp.ProcessedAt = r.ProcessedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
+
+// FromDeletionRequestReport converts deletion requests to the report as of now
+func FromDeletionRequestReport(reqs []domain.DataDeletionRequest, now time.Time) DeletionRequestReportResponse {
+	report := DeletionRequestReportResponse{Requests: make([]DeletionRequestResponse, len(reqs))}
+	for i := range reqs {
+		report.Requests[i] = FromDeletionRequest(&reqs[i], now)
+		if reqs[i].IsPending() {
+			report.Pending++
+		}
+		if reqs[i].IsOverdue(now) {
+			report.Overdue++
+		}
+	}
+	return report
+}
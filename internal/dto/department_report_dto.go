@@ -0,0 +1,293 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DepartmentIncomeStatementRequest represents query parameters for the income statement by department
+type DepartmentIncomeStatementRequest struct {
+	DateRangeRequest
+	DepartmentID string `form:"department_id" binding:"omitempty,uuid"`
+	Rollup       bool   `form:"rollup"`
+	Allocate     bool   `form:"allocate"`
+}
+
+// DepartmentIncomeLineResponse represents an account line of a department income statement
+type DepartmentIncomeLineResponse struct {
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	Amount      float64 `json:"amount"`
+	Allocated   float64 `json:"allocated,omitempty"`
+}
+
+// DepartmentIncomeResponse represents the income statement of one department
+type DepartmentIncomeResponse struct {
+	DepartmentID   string                         `json:"department_id,omitempty"`
+	DepartmentCode string                         `json:"department_code,omitempty"`
+	DepartmentName string                         `json:"department_name"`
+	ParentID       string                         `json:"parent_id,omitempty"`
+	Level          int                            `json:"level"`
+	Revenue        []DepartmentIncomeLineResponse `json:"revenue"`
+	Expenses       []DepartmentIncomeLineResponse `json:"expenses"`
+	TotalRevenue   float64                        `json:"total_revenue"`
+	TotalExpenses  float64                        `json:"total_expenses"`
+	NetIncome      float64                        `json:"net_income"`
+}
+
+// DepartmentIncomeStatementResponse represents the income statement by department (부서별 손익계산서)
+type DepartmentIncomeStatementResponse struct {
+	CompanyID     string                     `json:"company_id"`
+	FromDate      string                     `json:"from_date"`
+	ToDate        string                     `json:"to_date"`
+	GeneratedAt   string                     `json:"generated_at"`
+	Rollup        bool                       `json:"rollup"`
+	Allocated     bool                       `json:"allocated"`
+	Departments   []DepartmentIncomeResponse `json:"departments"`
+	TotalRevenue  float64                    `json:"total_revenue"`
+	TotalExpenses float64                    `json:"total_expenses"`
+	NetIncome     float64                    `json:"net_income"`
+}
+
+// FromDepartmentIncome converts department statements to responses and computes the grand totals.
+// With roll-up, only top-level departments are totalled so descendants are not counted twice.
+func FromDepartmentIncome(statements []domain.DepartmentIncome, rollup bool) ([]DepartmentIncomeResponse, float64, float64) {
+	listed := make(map[uuid.UUID]bool)
+	for _, d := range statements {
+		if d.DepartmentID != nil {
+			listed[*d.DepartmentID] = true
+		}
+	}
+
+	result := make([]DepartmentIncomeResponse, len(statements))
+	var totalRevenue, totalExpenses float64
+	for i, d := range statements {
+		resp := DepartmentIncomeResponse{
+			DepartmentCode: d.DepartmentCode,
+			DepartmentName: d.DepartmentName,
+			Level:          d.Level,
+			Revenue:        fromDepartmentIncomeLines(d.Revenue),
+			Expenses:       fromDepartmentIncomeLines(d.Expenses),
+			TotalRevenue:   d.TotalRevenue,
+			TotalExpenses:  d.TotalExpenses,
+			NetIncome:      d.NetIncome,
+		}
+		if d.DepartmentID != nil {
+			resp.DepartmentID = d.DepartmentID.String()
+		}
+		if d.ParentID != nil {
+			resp.ParentID = d.ParentID.String()
+		}
+		result[i] = resp
+
+		if !rollup || d.ParentID == nil || !listed[*d.ParentID] {
+			totalRevenue += d.TotalRevenue
+			totalExpenses += d.TotalExpenses
+		}
+	}
+	return result, totalRevenue, totalExpenses
+}
+
+func fromDepartmentIncomeLines(lines []domain.DepartmentIncomeLine) []DepartmentIncomeLineResponse {
+	result := make([]DepartmentIncomeLineResponse, len(lines))
+	for i, l := range lines {
+		result[i] = DepartmentIncomeLineResponse{
+			AccountCode: l.AccountCode,
+			AccountName: l.AccountName,
+			Amount:      l.Amount,
+			Allocated:   l.Allocated,
+		}
+	}
+	return result
+}
+
+// AllocationTargetRequest represents a department share of an allocation rule.
+// The ratio is required for the fixed basis and the headcount for the headcount basis.
+type AllocationTargetRequest struct {
+	DepartmentID string  `json:"department_id" binding:"required,uuid"`
+	ProjectID    string  `json:"project_id" binding:"omitempty,uuid"`
+	Ratio        float64 `json:"ratio" binding:"omitempty,gt=0,max=100"`
+	Headcount    int     `json:"headcount" binding:"omitempty,min=1"`
+}
+
+// CreateAllocationRuleRequest represents the request to create a department allocation rule
+type CreateAllocationRuleRequest struct {
+	Name               string                    `json:"name" binding:"required,max=100"`
+	Basis              string                    `json:"basis" binding:"omitempty,oneof=fixed headcount revenue"`
+	SourceDepartmentID string                    `json:"source_department_id" binding:"omitempty,uuid"`
+	AccountFrom        string                    `json:"account_from" binding:"omitempty,max=10"`
+	AccountTo          string                    `json:"account_to" binding:"omitempty,max=10"`
+	Targets            []AllocationTargetRequest `json:"targets" binding:"required,min=1,dive"`
+	IsActive           *bool                     `json:"is_active"`
+}
+
+// ToAllocationRule converts the request to a domain.DepartmentAllocationRule
+func (r *CreateAllocationRuleRequest) ToAllocationRule(companyID uuid.UUID) (*domain.DepartmentAllocationRule, error) {
+	rule := &domain.DepartmentAllocationRule{
+		Name:        r.Name,
+		Basis:       domain.AllocationBasisFixed,
+		AccountFrom: r.AccountFrom,
+		AccountTo:   r.AccountTo,
+		IsActive:    true,
+	}
+	rule.CompanyID = companyID
+	if r.Basis != "" {
+		rule.Basis = domain.AllocationBasis(r.Basis)
+	}
+
+	if r.SourceDepartmentID != "" {
+		id, err := uuid.Parse(r.SourceDepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		rule.SourceDepartmentID = &id
+	}
+	targets, err := toAllocationTargets(r.Targets)
+	if err != nil {
+		return nil, err
+	}
+	rule.Targets = targets
+	if r.IsActive != nil {
+		rule.IsActive = *r.IsActive
+	}
+	return rule, nil
+}
+
+// UpdateAllocationRuleRequest represents the request to update a department allocation rule
+type UpdateAllocationRuleRequest struct {
+	Name               *string                   `json:"name" binding:"omitempty,max=100"`
+	Basis              *string                   `json:"basis" binding:"omitempty,oneof=fixed headcount revenue"`
+	SourceDepartmentID *string                   `json:"source_department_id"`
+	AccountFrom        *string                   `json:"account_from" binding:"omitempty,max=10"`
+	AccountTo          *string                   `json:"account_to" binding:"omitempty,max=10"`
+	Targets            []AllocationTargetRequest `json:"targets" binding:"omitempty,dive"`
+	IsActive           *bool                     `json:"is_active"`
+}
+
+// ApplyTo applies the non-nil fields to an existing rule.
+// An empty source_department_id allocates entries without a department.
+func (r *UpdateAllocationRuleRequest) ApplyTo(rule *domain.DepartmentAllocationRule) error {
+	if r.Name != nil {
+		rule.Name = *r.Name
+	}
+	if r.Basis != nil {
+		rule.Basis = domain.AllocationBasis(*r.Basis)
+	}
+	if r.SourceDepartmentID != nil {
+		if *r.SourceDepartmentID == "" {
+			rule.SourceDepartmentID = nil
+		} else {
+			id, err := uuid.Parse(*r.SourceDepartmentID)
+			if err != nil {
+				return err
+			}
+			rule.SourceDepartmentID = &id
+		}
+	}
+	if r.AccountFrom != nil {
+		rule.AccountFrom = *r.AccountFrom
+	}
+	if r.AccountTo != nil {
+		rule.AccountTo = *r.AccountTo
+	}
+	if r.Targets != nil {
+		targets, err := toAllocationTargets(r.Targets)
+		if err != nil {
+			return err
+		}
+		rule.Targets = targets
+	}
+	if r.IsActive != nil {
+		rule.IsActive = *r.IsActive
+	}
+	return nil
+}
+
+func toAllocationTargets(reqs []AllocationTargetRequest) ([]domain.AllocationTarget, error) {
+	targets := make([]domain.AllocationTarget, len(reqs))
+	for i, t := range reqs {
+		id, err := uuid.Parse(t.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		targets[i] = domain.AllocationTarget{DepartmentID: id, Ratio: t.Ratio, Headcount: t.Headcount}
+		if t.ProjectID != "" {
+			projectID, err := uuid.Parse(t.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			targets[i].ProjectID = &projectID
+		}
+	}
+	return targets, nil
+}
+
+// AllocationTargetResponse represents a department share in API responses
+type AllocationTargetResponse struct {
+	DepartmentID string  `json:"department_id"`
+	ProjectID    string  `json:"project_id,omitempty"`
+	Ratio        float64 `json:"ratio,omitempty"`
+	Headcount    int     `json:"headcount,omitempty"`
+}
+
+// AllocationRuleResponse represents a department allocation rule in API responses
+type AllocationRuleResponse struct {
+	ID                 string                     `json:"id"`
+	Name               string                     `json:"name"`
+	Basis              string                     `json:"basis"`
+	SourceDepartmentID string                     `json:"source_department_id,omitempty"`
+	AccountFrom        string                     `json:"account_from,omitempty"`
+	AccountTo          string                     `json:"account_to,omitempty"`
+	Targets            []AllocationTargetResponse `json:"targets"`
+	IsActive           bool                       `json:"is_active"`
+	CreatedAt          string                     `json:"created_at"`
+	UpdatedAt          string                     `json:"updated_at"`
+}
+
+// FromAllocationRule converts domain.DepartmentAllocationRule to AllocationRuleResponse
+func FromAllocationRule(rule *domain.DepartmentAllocationRule) AllocationRuleResponse {
+	resp := AllocationRuleResponse{
+		ID:          rule.ID.String(),
+		Name:        rule.Name,
+		Basis:       string(rule.Basis),
+		AccountFrom: rule.AccountFrom,
+		AccountTo:   rule.AccountTo,
+		Targets:     make([]AllocationTargetResponse, len(rule.Targets)),
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   rule.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if rule.SourceDepartmentID != nil {
+		resp.SourceDepartmentID = rule.SourceDepartmentID.String()
+	}
+	for i, t := range rule.Targets {
+		resp.Targets[i] = AllocationTargetResponse{DepartmentID: t.DepartmentID.String(), Ratio: t.Ratio, Headcount: t.Headcount}
+		if t.ProjectID != nil {
+			resp.Targets[i].ProjectID = t.ProjectID.String()
+		}
+	}
+	return resp
+}
+
+// FromAllocationRules converts a slice of domain.DepartmentAllocationRule to responses
+func FromAllocationRules(rules []domain.DepartmentAllocationRule) []AllocationRuleResponse {
+	result := make([]AllocationRuleResponse, len(rules))
+	for i := range rules {
+		result[i] = FromAllocationRule(&rules[i])
+	}
+	return result
+}
+
+// AllocationRunRequest represents the month to allocate or preview
+type AllocationRunRequest struct {
+	Year  int `json:"year" binding:"required,min=2000,max=2100"`
+	Month int `json:"month" binding:"required,min=1,max=12"`
+}
+
+// Period returns the first and last day of the month
+func (r *AllocationRunRequest) Period() (time.Time, time.Time) {
+	start := time.Date(r.Year, time.Month(r.Month), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, -1)
+}
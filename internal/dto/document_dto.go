@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DocumentFolderRequest represents a request to create, rename or move a folder
+type DocumentFolderRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"` // empty for the root
+}
+
+// Parent returns the parent folder; the ID is validated by binding
+func (r *DocumentFolderRequest) Parent() *uuid.UUID {
+	if r.ParentID == "" {
+		return nil
+	}
+	id := uuid.MustParse(r.ParentID)
+	return &id
+}
+
+// DocumentFolderPermissionRequest grants a role access to a folder
+type DocumentFolderPermissionRequest struct {
+	Role   string `json:"role" binding:"required,oneof=admin user viewer"`
+	Access string `json:"access" binding:"required,oneof=none read write manage"`
+}
+
+// SetDocumentFolderPermissionsRequest replaces the permissions of a folder;
+// an empty list makes it inherit those of its parent
+type SetDocumentFolderPermissionsRequest struct {
+	Permissions []DocumentFolderPermissionRequest `json:"permissions" binding:"max=3,dive"`
+}
+
+// ToDomain converts the permissions to domain.DocumentFolderPermission
+func (r *SetDocumentFolderPermissionsRequest) ToDomain() []domain.DocumentFolderPermission {
+	permissions := make([]domain.DocumentFolderPermission, len(r.Permissions))
+	for i, p := range r.Permissions {
+		permissions[i] = domain.DocumentFolderPermission{
+			Role:   domain.UserRole(p.Role),
+			Access: domain.DocumentAccess(p.Access),
+		}
+	}
+	return permissions
+}
+
+// UploadDocumentFileRequest holds the form fields of a new file; the content
+// is the multipart "file"
+type UploadDocumentFileRequest struct {
+	FolderID    string `form:"folder_id" binding:"required,uuid"`
+	Name        string `form:"name" binding:"max=255"` // defaults to the uploaded file name
+	Description string `form:"description" binding:"max=500"`
+	Metadata    string `form:"metadata"` // JSON object of string values
+	Comment     string `form:"comment" binding:"max=500"`
+}
+
+// UploadDocumentVersionRequest holds the form fields of a new version
+type UploadDocumentVersionRequest struct {
+	Comment string `form:"comment" binding:"max=500"`
+}
+
+// UpdateDocumentFileRequest represents a request to edit or move a file
+type UpdateDocumentFileRequest struct {
+	FolderID    string            `json:"folder_id" binding:"required,uuid"`
+	Name        string            `json:"name" binding:"required,max=255"`
+	Description string            `json:"description" binding:"max=500"`
+	Metadata    map[string]string `json:"metadata" binding:"max=30"`
+}
+
+// LinkDocumentFileRequest represents a request to link a file to a document
+type LinkDocumentFileRequest struct {
+	Document DocumentRefRequest `json:"document"`
+}
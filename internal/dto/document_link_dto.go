@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// DocumentRefRequest references a document in requests
+type DocumentRefRequest struct {
+	Type string `json:"type" binding:"required,oneof=voucher tax_invoice loan grant allocation_run"`
+	ID   string `json:"id" binding:"required,uuid"`
+}
+
+// ToDocumentRef converts the request; the ID is validated by binding
+func (r DocumentRefRequest) ToDocumentRef() domain.DocumentRef {
+	return domain.DocumentRef{Type: domain.DocumentType(r.Type), ID: uuid.MustParse(r.ID)}
+}
+
+// CreateDocumentLinkRequest represents the request to link two documents
+type CreateDocumentLinkRequest struct {
+	Document DocumentRefRequest `json:"document"`
+	LinkedTo DocumentRefRequest `json:"linked_to"`
+	Note     string             `json:"note" binding:"omitempty,max=500"`
+}
+
+// DocumentRefResponse represents a document reference in API responses
+type DocumentRefResponse struct {
+	Type      string `json:"type"`
+	TypeLabel string `json:"type_label"`
+	ID        string `json:"id"`
+	Label     string `json:"label,omitempty"`
+}
+
+// toDocumentRefResponse converts domain.DocumentRef with its type label in the locale
+func toDocumentRefResponse(ref domain.DocumentRef, label string, loc i18n.Locale) DocumentRefResponse {
+	return DocumentRefResponse{
+		Type:      string(ref.Type),
+		TypeLabel: ref.Type.LocalizedLabel(loc),
+		ID:        ref.ID.String(),
+		Label:     label,
+	}
+}
+
+// DocumentLinkResponse represents a document link in API responses
+type DocumentLinkResponse struct {
+	ID        string              `json:"id"`
+	Source    DocumentRefResponse `json:"source"`
+	Target    DocumentRefResponse `json:"target"`
+	Note      string              `json:"note,omitempty"`
+	CreatedBy string              `json:"created_by,omitempty"`
+	CreatedAt string              `json:"created_at"`
+}
+
+// FromDocumentLink converts domain.DocumentLink to DocumentLinkResponse
+func FromDocumentLink(link *domain.DocumentLink, loc i18n.Locale) DocumentLinkResponse {
+	resp := DocumentLinkResponse{
+		ID:        link.ID.String(),
+		Source:    toDocumentRefResponse(link.Source(), "", loc),
+		Target:    toDocumentRefResponse(link.Target(), "", loc),
+		Note:      link.Note,
+		CreatedAt: link.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if link.CreatedBy != nil {
+		resp.CreatedBy = link.CreatedBy.String()
+	}
+	return resp
+}
+
+// RelatedDocumentResponse represents a related document in API responses.
+// Relation is "link" for explicit links and "reference" for a voucher's reference.
+type RelatedDocumentResponse struct {
+	Document  DocumentRefResponse `json:"document"`
+	Relation  string              `json:"relation"`
+	LinkID    string              `json:"link_id,omitempty"`
+	Note      string              `json:"note,omitempty"`
+	CreatedBy string              `json:"created_by,omitempty"`
+	CreatedAt string              `json:"created_at"`
+}
+
+// FromRelatedDocuments converts related documents to responses with labels in the locale
+func FromRelatedDocuments(related []domain.RelatedDocument, loc i18n.Locale) []RelatedDocumentResponse {
+	result := make([]RelatedDocumentResponse, len(related))
+	for i, d := range related {
+		resp := RelatedDocumentResponse{
+			Document:  toDocumentRefResponse(d.Document, d.Label, loc),
+			Relation:  "link",
+			Note:      d.Note,
+			CreatedAt: d.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if d.IsReference() {
+			resp.Relation = "reference"
+		} else {
+			resp.LinkID = d.LinkID.String()
+		}
+		if d.CreatedBy != nil {
+			resp.CreatedBy = d.CreatedBy.String()
+		}
+		result[i] = resp
+	}
+	return result
+}
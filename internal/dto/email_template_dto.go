@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// SaveEmailTemplateRequest represents a request to save a new version of a template
+type SaveEmailTemplateRequest struct {
+	Subject string `json:"subject" binding:"required,max=200"`
+	Body    string `json:"body" binding:"required,max=20000"`
+}
+
+// PreviewEmailTemplateRequest represents a request to render a template with
+// sample data; omitted subject or body preview the current template
+type PreviewEmailTemplateRequest struct {
+	Subject   *string           `json:"subject" binding:"omitempty,max=200"`
+	Body      *string           `json:"body" binding:"omitempty,max=20000"`
+	Variables map[string]string `json:"variables"` // override the sample values
+}
+
+// EmailTemplateResponse represents the template of an email event in API responses
+type EmailTemplateResponse struct {
+	EventType string                         `json:"event_type"`
+	Version   int                            `json:"version"` // 0 for the system default
+	IsDefault bool                           `json:"is_default"`
+	IsActive  bool                           `json:"is_active"`
+	Subject   string                         `json:"subject"`
+	Body      string                         `json:"body"`
+	Variables []domain.EmailTemplateVariable `json:"variables"`
+	CreatedBy string                         `json:"created_by,omitempty"`
+	CreatedAt string                         `json:"created_at,omitempty"`
+}
+
+// EmailTemplatePreviewResponse represents a rendered email
+type EmailTemplatePreviewResponse struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// FromEmailTemplate converts domain.EmailTemplate to EmailTemplateResponse
+func FromEmailTemplate(t *domain.EmailTemplate) EmailTemplateResponse {
+	resp := EmailTemplateResponse{
+		EventType: string(t.EventType),
+		Version:   t.Version,
+		IsDefault: t.IsDefault(),
+		IsActive:  t.IsActive,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		Variables: t.EventType.Variables(),
+	}
+	if t.CreatedBy != nil {
+		resp.CreatedBy = t.CreatedBy.String()
+	}
+	if !t.IsDefault() {
+		resp.CreatedAt = t.CreatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// FromEmailTemplates converts a slice of domain.EmailTemplate to responses
+func FromEmailTemplates(templates []domain.EmailTemplate) []EmailTemplateResponse {
+	resp := make([]EmailTemplateResponse, len(templates))
+	for i := range templates {
+		resp[i] = FromEmailTemplate(&templates[i])
+	}
+	return resp
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateEmployeeAdvanceRequest represents a request to record an advance paid to an employee
+type CreateEmployeeAdvanceRequest struct {
+	EmployeeID    string `json:"employee_id" binding:"required,uuid"`
+	DepartmentID  string `json:"department_id" binding:"omitempty,uuid"`
+	Purpose       string `json:"purpose" binding:"required,max=200"`
+	AdvanceDate   string `json:"advance_date" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	DueDate       string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	Amount        int64  `json:"amount" binding:"required,min=1"`
+	AccountID     string `json:"account_id" binding:"required,uuid"` // advance account, e.g. 가지급금
+	BankAccountID string `json:"bank_account_id" binding:"required,uuid"`
+}
+
+// ToDomain converts the request to domain.EmployeeAdvance; identifiers and dates are validated by binding
+func (r *CreateEmployeeAdvanceRequest) ToDomain(companyID, userID uuid.UUID) *domain.EmployeeAdvance {
+	advanceDate, _ := time.Parse("2006-01-02", r.AdvanceDate)
+	advance := &domain.EmployeeAdvance{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		EmployeeID:    uuid.MustParse(r.EmployeeID),
+		Purpose:       r.Purpose,
+		AdvanceDate:   advanceDate,
+		Amount:        r.Amount,
+		AccountID:     uuid.MustParse(r.AccountID),
+		BankAccountID: uuid.MustParse(r.BankAccountID),
+		CreatedBy:     &userID,
+	}
+	if r.DepartmentID != "" {
+		departmentID := uuid.MustParse(r.DepartmentID)
+		advance.DepartmentID = &departmentID
+	}
+	if r.DueDate != "" {
+		dueDate, _ := time.Parse("2006-01-02", r.DueDate)
+		advance.DueDate = &dueDate
+	}
+	return advance
+}
+
+// AdvanceExpenseRequest represents an expense spent from an advance
+type AdvanceExpenseRequest struct {
+	AccountID   string `json:"account_id" binding:"required,uuid"` // expense account
+	Amount      int64  `json:"amount" binding:"required,min=1"`
+	Description string `json:"description" binding:"required,max=200"`
+	ReceiptNo   string `json:"receipt_no" binding:"max=50"`
+	Category    string `json:"category" binding:"omitempty,oneof=general per_diem mileage"` // defaults to general
+	Destination string `json:"destination" binding:"max=50"`                                // per diem
+	Days        int    `json:"days" binding:"min=0"`                                        // per diem
+	DistanceKm  int    `json:"distance_km" binding:"min=0"`                                 // mileage
+}
+
+// SettleEmployeeAdvanceRequest represents the settlement of an advance by expenses and a refund
+type SettleEmployeeAdvanceRequest struct {
+	SettlementDate string                  `json:"settlement_date" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	Expenses       []AdvanceExpenseRequest `json:"expenses" binding:"omitempty,max=100,dive"`
+	RefundAmount   int64                   `json:"refund_amount" binding:"min=0"`
+	BankAccountID  string                  `json:"bank_account_id" binding:"omitempty,uuid"` // defaults to the account the advance was paid from
+}
+
+// ToExpenses converts the expenses to domain.AdvanceExpense; identifiers are validated by binding
+func (r *SettleEmployeeAdvanceRequest) ToExpenses() []domain.AdvanceExpense {
+	expenses := make([]domain.AdvanceExpense, len(r.Expenses))
+	for i, e := range r.Expenses {
+		expenses[i] = domain.AdvanceExpense{
+			AccountID:   uuid.MustParse(e.AccountID),
+			Amount:      e.Amount,
+			Description: e.Description,
+			ReceiptNo:   e.ReceiptNo,
+			Category:    domain.ExpenseCategory(e.Category),
+			Destination: e.Destination,
+			Days:        e.Days,
+			DistanceKm:  e.DistanceKm,
+		}
+	}
+	return expenses
+}
+
+// OutstandingAdvancesRequest represents query parameters of the outstanding advances report
+type OutstandingAdvancesRequest struct {
+	AsOf string `form:"as_of" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+}
+
+// ReviewSettlementRequest represents the approver's review of the policy violations of a settlement
+type ReviewSettlementRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateGrantRequest represents a request to register an awarded government grant
+type CreateGrantRequest struct {
+	GrantNo           string `json:"grant_no" binding:"required,max=50"`
+	Name              string `json:"name" binding:"required,max=200"`
+	Agency            string `json:"agency" binding:"required,max=200"`
+	PartnerID         string `json:"partner_id" binding:"omitempty,uuid"`
+	AwardedAmount     int64  `json:"awarded_amount" binding:"required,min=1"`
+	ProgramStart      string `json:"program_start" binding:"required,datetime=2006-01-02"`
+	ProgramEnd        string `json:"program_end" binding:"required,datetime=2006-01-02"`
+	RecognitionMethod string `json:"recognition_method" binding:"required,oneof=expense straight_line"`
+	Description       string `json:"description" binding:"max=500"`
+
+	DeferredIncomeAccountID string `json:"deferred_income_account_id" binding:"required,uuid"`
+	IncomeAccountID         string `json:"income_account_id" binding:"required,uuid"`
+	BankAccountID           string `json:"bank_account_id" binding:"required,uuid"`
+}
+
+// ToDomain converts the request to domain.Grant; identifiers and dates are validated by binding
+func (r *CreateGrantRequest) ToDomain(companyID, userID uuid.UUID) *domain.Grant {
+	programStart, _ := time.Parse("2006-01-02", r.ProgramStart)
+	programEnd, _ := time.Parse("2006-01-02", r.ProgramEnd)
+	grant := &domain.Grant{
+		TenantModel:             domain.TenantModel{CompanyID: companyID},
+		GrantNo:                 r.GrantNo,
+		Name:                    r.Name,
+		Agency:                  r.Agency,
+		AwardedAmount:           r.AwardedAmount,
+		ProgramStart:            programStart,
+		ProgramEnd:              programEnd,
+		RecognitionMethod:       domain.GrantRecognitionMethod(r.RecognitionMethod),
+		Description:             r.Description,
+		DeferredIncomeAccountID: uuid.MustParse(r.DeferredIncomeAccountID),
+		IncomeAccountID:         uuid.MustParse(r.IncomeAccountID),
+		BankAccountID:           uuid.MustParse(r.BankAccountID),
+		CreatedBy:               &userID,
+	}
+	if r.PartnerID != "" {
+		partnerID := uuid.MustParse(r.PartnerID)
+		grant.PartnerID = &partnerID
+	}
+	return grant
+}
+
+// ReceiveGrantRequest represents grant money paid in by the agency
+type ReceiveGrantRequest struct {
+	ReceiptDate string `json:"receipt_date" binding:"required,datetime=2006-01-02"`
+	Amount      int64  `json:"amount" binding:"required,min=1"`
+}
+
+// LinkGrantExpenseRequest links an eligible expense voucher to a grant. The
+// amount defaults to the total of the voucher.
+type LinkGrantExpenseRequest struct {
+	VoucherID   string `json:"voucher_id" binding:"required,uuid"`
+	Amount      *int64 `json:"amount" binding:"omitempty,min=1"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// RecognizeGrantIncomeRequest represents a request to recognize the grant
+// income of the company at a month end
+type RecognizeGrantIncomeRequest struct {
+	PeriodEnd string `json:"period_end" binding:"required,datetime=2006-01-02"`
+}
+
+// GrantUtilizationRequest represents query parameters of the grant utilization report
+type GrantUtilizationRequest struct {
+	AsOf string `form:"as_of" binding:"omitempty,datetime=2006-01-02"`
+}
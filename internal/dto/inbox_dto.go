@@ -0,0 +1,12 @@
+package dto
+
+// ConvertInboxItemRequest represents a request to close an inbox item with the
+// voucher created from its document
+type ConvertInboxItemRequest struct {
+	VoucherID string `json:"voucher_id" binding:"required,uuid"`
+}
+
+// DiscardInboxItemRequest represents a request to close an inbox item without voucher
+type DiscardInboxItemRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PopbillCredentialRequest represents the Popbill credentials of a company
+type PopbillCredentialRequest struct {
+	LinkID    string `json:"link_id" binding:"required,max=100"`
+	SecretKey string `json:"secret_key" binding:"required,max=200"`
+	CorpNum   string `json:"corp_num" binding:"required,max=12"`
+	UserID    string `json:"user_id" binding:"omitempty,max=100"`
+	IsSandbox bool   `json:"is_sandbox"`
+}
+
+// ToDomain converts the request to domain.PopbillCredentials
+func (r *PopbillCredentialRequest) ToDomain() *domain.PopbillCredentials {
+	return &domain.PopbillCredentials{
+		LinkID:    r.LinkID,
+		SecretKey: r.SecretKey,
+		CorpNum:   r.CorpNum,
+		UserID:    r.UserID,
+		IsSandbox: r.IsSandbox,
+	}
+}
+
+// BankCredentialRequest represents the credentials of a bank account
+type BankCredentialRequest struct {
+	BankCode        string `json:"bank_code" binding:"required,len=3"`
+	AccountNumber   string `json:"account_number" binding:"required,max=30"`
+	AccountPassword string `json:"account_password" binding:"required,max=100"`
+	LoginID         string `json:"login_id" binding:"omitempty,max=100"`
+	LoginPassword   string `json:"login_password" binding:"omitempty,max=100"`
+}
+
+// ToDomain converts the request to domain.BankCredentials
+func (r *BankCredentialRequest) ToDomain() *domain.BankCredentials {
+	return &domain.BankCredentials{
+		BankCode:        r.BankCode,
+		AccountNumber:   r.AccountNumber,
+		AccountPassword: r.AccountPassword,
+		LoginID:         r.LoginID,
+		LoginPassword:   r.LoginPassword,
+	}
+}
+
+// IntegrationCredentialResponse represents a stored credential; secrets are never returned
+type IntegrationCredentialResponse struct {
+	ID                string `json:"id"`
+	Provider          string `json:"provider"`
+	Identifier        string `json:"identifier"`
+	Hint              string `json:"hint"`
+	IsActive          bool   `json:"is_active"`
+	LastTestedAt      string `json:"last_tested_at,omitempty"`
+	LastTestSucceeded *bool  `json:"last_test_succeeded,omitempty"`
+	LastTestError     string `json:"last_test_error,omitempty"`
+	UpdatedBy         string `json:"updated_by,omitempty"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// FromIntegrationCredential converts domain.IntegrationCredential to IntegrationCredentialResponse
+func FromIntegrationCredential(c *domain.IntegrationCredential) IntegrationCredentialResponse {
+	resp := IntegrationCredentialResponse{
+		ID:                c.ID.String(),
+		Provider:          string(c.Provider),
+		Identifier:        c.Identifier,
+		Hint:              c.Hint,
+		IsActive:          c.IsActive,
+		LastTestSucceeded: c.LastTestSucceeded,
+		LastTestError:     c.LastTestError,
+		UpdatedAt:         c.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if c.LastTestedAt != nil {
+		resp.LastTestedAt = c.LastTestedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if c.UpdatedBy != nil {
+		resp.UpdatedBy = c.UpdatedBy.String()
+	}
+	return resp
+}
+
+// FromIntegrationCredentials converts a slice of domain.IntegrationCredential to responses
+func FromIntegrationCredentials(credentials []domain.IntegrationCredential) []IntegrationCredentialResponse {
+	responses := make([]IntegrationCredentialResponse, len(credentials))
+	for i := range credentials {
+		responses[i] = FromIntegrationCredential(&credentials[i])
+	}
+	return responses
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "github.com/saintgo7/saas-kerp/internal/domain"
+
+// InventoryItemRequest represents a request to register or update an inventory item
+type InventoryItemRequest struct {
+	Code      string  `json:"code" binding:"required,max=50"`
+	Name      string  `json:"name" binding:"required,max=200"`
+	Unit      string  `json:"unit" binding:"max=20"` // defaults to EA
+	AccountID string  `json:"account_id" binding:"required,uuid"`
+	UnitCost  int64   `json:"unit_cost" binding:"min=0"`
+	Quantity  float64 `json:"quantity" binding:"min=0"` // opening quantity, ignored on update
+	IsActive  *bool   `json:"is_active"`
+}
+
+// OpenInventoryCountRequest represents a request to open an inventory count
+type OpenInventoryCountRequest struct {
+	CountDate     string `json:"count_date" binding:"required,datetime=2006-01-02"`
+	Description   string `json:"description" binding:"required,max=200"`
+	AccountID     string `json:"account_id" binding:"omitempty,uuid"` // items of one inventory account, all when empty
+	LossAccountID string `json:"loss_account_id" binding:"required,uuid"`
+	GainAccountID string `json:"gain_account_id" binding:"required,uuid"`
+}
+
+// RecordInventoryCountsRequest represents counted quantities of items
+type RecordInventoryCountsRequest struct {
+	Counts []InventoryCountEntryRequest `json:"counts" binding:"required,min=1,max=5000,dive"`
+}
+
+// InventoryCountEntryRequest is the counted quantity of an item
+type InventoryCountEntryRequest struct {
+	ItemCode string  `json:"item_code" binding:"required,max=50"`
+	Quantity float64 `json:"quantity" binding:"min=0"`
+	Note     string  `json:"note" binding:"max=200"`
+}
+
+// ToDomain converts the request to domain.InventoryCountEntry values
+func (r *RecordInventoryCountsRequest) ToDomain() []domain.InventoryCountEntry {
+	entries := make([]domain.InventoryCountEntry, len(r.Counts))
+	for i, count := range r.Counts {
+		entries[i] = domain.InventoryCountEntry{ItemCode: count.ItemCode, Quantity: count.Quantity, Note: count.Note}
+	}
+	return entries
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateInvitationRequest represents the request to invite a user to the company
+type CreateInvitationRequest struct {
+	Email         string `json:"email" binding:"required,email"`
+	Role          string `json:"role" binding:"required,oneof=admin user viewer"`
+	ExpiresInDays int    `json:"expires_in_days" binding:"omitempty,min=1,max=30"` // default 7
+}
+
+// ExpiresIn returns the requested invitation lifetime, zero for the default
+func (r *CreateInvitationRequest) ExpiresIn() time.Duration {
+	return time.Duration(r.ExpiresInDays) * 24 * time.Hour
+}
+
+// InvitationResponse represents a pending invitation in API responses
+type InvitationResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	InvitedBy string `json:"invited_by,omitempty"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+// FromInvitation converts an invitation token to InvitationResponse
+func FromInvitation(t *domain.UserToken) InvitationResponse {
+	resp := InvitationResponse{
+		ID:        t.ID.String(),
+		Email:     t.Email,
+		Role:      string(t.Role),
+		ExpiresAt: t.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if t.CreatedBy != nil {
+		resp.InvitedBy = t.CreatedBy.String()
+	}
+	return resp
+}
+
+// FromInvitations converts a slice of invitation tokens to responses
+func FromInvitations(tokens []domain.UserToken) []InvitationResponse {
+	result := make([]InvitationResponse, len(tokens))
+	for i := range tokens {
+		result[i] = FromInvitation(&tokens[i])
+	}
+	return result
+}
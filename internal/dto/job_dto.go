@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// JobResponse represents a background job in API responses
+type JobResponse struct {
+	ID          string          `json:"id"`
+	CompanyID   string          `json:"company_id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	RunAt       string          `json:"run_at"`
+	StartedAt   string          `json:"started_at,omitempty"`
+	FinishedAt  string          `json:"finished_at,omitempty"`
+	CreatedBy   string          `json:"created_by,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+// FromJob converts domain.Job to JobResponse
+func FromJob(j *domain.Job) JobResponse {
+	resp := JobResponse{
+		ID:          j.ID.String(),
+		CompanyID:   j.CompanyID.String(),
+		Type:        string(j.Type),
+		Payload:     j.Payload,
+		Status:      string(j.Status),
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		LastError:   j.LastError,
+		Result:      j.Result,
+		RunAt:       j.RunAt.Format(time.RFC3339),
+		CreatedAt:   j.CreatedAt.Format(time.RFC3339),
+	}
+	if j.StartedAt != nil {
+		resp.StartedAt = j.StartedAt.Format(time.RFC3339)
+	}
+	if j.FinishedAt != nil {
+		resp.FinishedAt = j.FinishedAt.Format(time.RFC3339)
+	}
+	if j.CreatedBy != nil {
+		resp.CreatedBy = j.CreatedBy.String()
+	}
+	return resp
+}
+
+// FromJobs converts a slice of domain.Job to responses
+func FromJobs(jobs []domain.Job) []JobResponse {
+	resp := make([]JobResponse, len(jobs))
+	for i := range jobs {
+		resp[i] = FromJob(&jobs[i])
+	}
+	return resp
+}
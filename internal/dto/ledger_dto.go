@@ -3,7 +3,10 @@ package dto
 import (
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
 )
 
 // LedgerBalanceResponse represents a ledger balance
@@ -134,6 +137,9 @@ type TrialBalanceItemResponse struct {
 	ClosingCredit  float64 `json:"closing_credit"`
 	IsSubTotal     bool    `json:"is_sub_total"`
 	IsTotal        bool    `json:"is_total"`
+	DimensionID    *string `json:"dimension_id,omitempty"`
+	DimensionCode  string  `json:"dimension_code,omitempty"`
+	DimensionName  string  `json:"dimension_name,omitempty"`
 }
 
 // TrialBalanceResponse represents a trial balance report
@@ -145,6 +151,7 @@ type TrialBalanceResponse struct {
 	StartDate     string                     `json:"start_date"`
 	EndDate       string                     `json:"end_date"`
 	GeneratedAt   string                     `json:"generated_at"`
+	GroupBy       string                     `json:"group_by,omitempty"`
 	Items         []TrialBalanceItemResponse `json:"items"`
 	TotalDebit    float64                    `json:"total_debit"`
 	TotalCredit   float64                    `json:"total_credit"`
@@ -169,6 +176,12 @@ func FromTrialBalance(tb *domain.TrialBalance) TrialBalanceResponse {
 			ClosingCredit: item.ClosingCredit,
 			IsSubTotal:    item.IsSubTotal,
 			IsTotal:       item.IsTotal,
+			DimensionCode: item.DimensionCode,
+			DimensionName: item.DimensionName,
+		}
+		if item.DimensionID != nil {
+			id := item.DimensionID.String()
+			items[i].DimensionID = &id
 		}
 	}
 
@@ -180,6 +193,7 @@ func FromTrialBalance(tb *domain.TrialBalance) TrialBalanceResponse {
 		StartDate:   tb.StartDate.Format("2006-01-02"),
 		EndDate:     tb.EndDate.Format("2006-01-02"),
 		GeneratedAt: tb.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
+		GroupBy:     string(tb.GroupBy),
 		Items:       items,
 		TotalDebit:  tb.TotalDebit,
 		TotalCredit: tb.TotalCredit,
@@ -227,14 +241,121 @@ func FromFiscalPeriods(periods []domain.FiscalPeriod) []FiscalPeriodResponse {
 	return responses
 }
 
+// FiscalPeriodGroupResponse represents a quarter or half-year of fiscal periods
+type FiscalPeriodGroupResponse struct {
+	Grouping      string                 `json:"grouping"`
+	FiscalYear    int                    `json:"fiscal_year"`
+	Number        int                    `json:"number"`
+	Name          string                 `json:"name"`
+	FromMonth     int                    `json:"from_month"`
+	ToMonth       int                    `json:"to_month"`
+	StartDate     string                 `json:"start_date"`
+	EndDate       string                 `json:"end_date"`
+	Status        string                 `json:"status"`
+	MissingMonths []int                  `json:"missing_months,omitempty"`
+	Periods       []FiscalPeriodResponse `json:"periods"`
+}
+
+// FromFiscalPeriodGroups converts []domain.FiscalPeriodGroup to []FiscalPeriodGroupResponse
+func FromFiscalPeriodGroups(groups []domain.FiscalPeriodGroup) []FiscalPeriodGroupResponse {
+	responses := make([]FiscalPeriodGroupResponse, len(groups))
+	for i, g := range groups {
+		responses[i] = FiscalPeriodGroupResponse{
+			Grouping:      string(g.Grouping),
+			FiscalYear:    g.FiscalYear,
+			Number:        g.Number,
+			Name:          g.Name,
+			FromMonth:     g.FromMonth,
+			ToMonth:       g.ToMonth,
+			StartDate:     g.StartDate.Format("2006-01-02"),
+			EndDate:       g.EndDate.Format("2006-01-02"),
+			Status:        string(g.Status),
+			MissingMonths: g.MissingMonths,
+			Periods:       FromFiscalPeriods(g.Periods),
+		}
+	}
+	return responses
+}
+
 // FinancialStatementItem represents a line in financial statement
 type FinancialStatementItem struct {
-	Code        string  `json:"code"`
-	Name        string  `json:"name"`
-	Amount      float64 `json:"amount"`
-	Level       int     `json:"level"`
-	IsSubTotal  bool    `json:"is_sub_total"`
-	IsTotal     bool    `json:"is_total"`
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`
+	Level        int     `json:"level"`
+	IsSubTotal   bool    `json:"is_sub_total"`
+	IsTotal      bool    `json:"is_total"`
+	AccountCount int     `json:"account_count"`
+
+	// Tree structure for expanding and collapsing rows: the code of the row
+	// this one adds up to, and whether rows below it follow
+	ParentCode  string  `json:"parent_code,omitempty"`
+	HasChildren bool    `json:"has_children"`
+	AccountID   *string `json:"account_id,omitempty"`
+}
+
+// fromFinancialStatementRows converts the rows of the account type, or all
+// rows when accountType is empty; totals are only included with all rows
+func fromFinancialStatementRows(rows []domain.FinancialStatementRow, accountType domain.AccountType, loc i18n.Locale) []FinancialStatementItem {
+	items := []FinancialStatementItem{}
+	for _, row := range rows {
+		if accountType != "" && (row.AccountType != accountType || row.IsTotal) {
+			continue
+		}
+		item := FinancialStatementItem{
+			Code:         row.Code,
+			Name:         row.LocalizedName(loc),
+			Amount:       row.Amount,
+			Level:        row.Level,
+			IsSubTotal:   row.IsSubTotal,
+			IsTotal:      row.IsTotal,
+			AccountCount: row.AccountCount,
+			ParentCode:   row.ParentCode,
+			HasChildren:  row.HasChildren,
+		}
+		if row.AccountID != nil {
+			id := row.AccountID.String()
+			item.AccountID = &id
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// FromBalanceSheet converts domain.FinancialStatement to BalanceSheetResponse
+func FromBalanceSheet(companyID uuid.UUID, fs *domain.FinancialStatement, loc i18n.Locale) BalanceSheetResponse {
+	return BalanceSheetResponse{
+		CompanyID:        companyID.String(),
+		AsOfDate:         fs.EndDate.Format("2006-01-02"),
+		GeneratedAt:      ReportGeneratedAt(),
+		Lines:            fromFinancialStatementRows(fs.Rows, "", loc),
+		Assets:           fromFinancialStatementRows(fs.Rows, domain.AccountTypeAsset, loc),
+		Liabilities:      fromFinancialStatementRows(fs.Rows, domain.AccountTypeLiability, loc),
+		Equity:           fromFinancialStatementRows(fs.Rows, domain.AccountTypeEquity, loc),
+		TotalAssets:      fs.TotalAssets,
+		TotalLiabilities: fs.TotalLiabilities,
+		TotalEquity:      fs.TotalEquity,
+		NetIncome:        fs.NetIncome,
+		IsBalanced:       fs.IsBalanced(),
+		Mismatches:       fs.ValidateRollup(),
+	}
+}
+
+// FromIncomeStatement converts domain.FinancialStatement to IncomeStatementResponse
+func FromIncomeStatement(companyID uuid.UUID, fs *domain.FinancialStatement, loc i18n.Locale) IncomeStatementResponse {
+	return IncomeStatementResponse{
+		CompanyID:     companyID.String(),
+		FromDate:      fs.StartDate.Format("2006-01-02"),
+		ToDate:        fs.EndDate.Format("2006-01-02"),
+		GeneratedAt:   ReportGeneratedAt(),
+		Lines:         fromFinancialStatementRows(fs.Rows, "", loc),
+		Revenue:       fromFinancialStatementRows(fs.Rows, domain.AccountTypeRevenue, loc),
+		Expenses:      fromFinancialStatementRows(fs.Rows, domain.AccountTypeExpense, loc),
+		TotalRevenue:  fs.TotalRevenue,
+		TotalExpenses: fs.TotalExpenses,
+		NetIncome:     fs.NetIncome,
+		Mismatches:    fs.ValidateRollup(),
+	}
 }
 
 // BalanceSheetResponse represents a balance sheet report
@@ -242,13 +363,16 @@ type BalanceSheetResponse struct {
 	CompanyID      string                   `json:"company_id"`
 	AsOfDate       string                   `json:"as_of_date"`
 	GeneratedAt    string                   `json:"generated_at"`
+	Lines          []FinancialStatementItem `json:"lines"` // the whole statement in order, with group and total rows
 	Assets         []FinancialStatementItem `json:"assets"`
 	Liabilities    []FinancialStatementItem `json:"liabilities"`
 	Equity         []FinancialStatementItem `json:"equity"`
 	TotalAssets    float64                  `json:"total_assets"`
 	TotalLiabilities float64                `json:"total_liabilities"`
 	TotalEquity    float64                  `json:"total_equity"`
+	NetIncome      float64                  `json:"net_income"` // current year, included in retained earnings
 	IsBalanced     bool                     `json:"is_balanced"`
+	Mismatches     []string                 `json:"mismatches,omitempty"` // rows that do not add up to the rows below them
 }
 
 // IncomeStatementResponse represents an income statement report
@@ -257,11 +381,13 @@ type IncomeStatementResponse struct {
 	FromDate        string                   `json:"from_date"`
 	ToDate          string                   `json:"to_date"`
 	GeneratedAt     string                   `json:"generated_at"`
+	Lines           []FinancialStatementItem `json:"lines"` // the whole statement in order, with profit subtotals
 	Revenue         []FinancialStatementItem `json:"revenue"`
 	Expenses        []FinancialStatementItem `json:"expenses"`
 	TotalRevenue    float64                  `json:"total_revenue"`
 	TotalExpenses   float64                  `json:"total_expenses"`
 	NetIncome       float64                  `json:"net_income"`
+	Mismatches      []string                 `json:"mismatches,omitempty"` // rows that do not add up to the rows below them
 }
 
 // AccountLedgerRequest represents query parameters for account ledger
@@ -277,6 +403,13 @@ type PeriodRequest struct {
 	Month int `form:"month" binding:"required,min=1,max=12"`
 }
 
+// TrialBalanceRequest represents query parameters for the trial balance;
+// GroupBy breaks the accounts down by a voucher entry dimension
+type TrialBalanceRequest struct {
+	PeriodRequest
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=department project partner"`
+}
+
 // DateRangeRequest represents query parameters for date range reports
 type DateRangeRequest struct {
 	FromYear  int `form:"from_year" binding:"required,min=2000,max=2100"`
@@ -285,10 +418,53 @@ type DateRangeRequest struct {
 	ToMonth   int `form:"to_month" binding:"required,min=1,max=12"`
 }
 
+// BalanceExceptionRequest represents query parameters for the balance
+// exceptions of the months ending at Year/Month
+type BalanceExceptionRequest struct {
+	PeriodRequest
+	Months int `form:"months" binding:"omitempty,min=1,max=12"`
+}
+
+// PeriodMonths returns the number of months to check, the default when omitted
+func (r BalanceExceptionRequest) PeriodMonths() int {
+	if r.Months == 0 {
+		return domain.BalanceExceptionDefaultMonths
+	}
+	return r.Months
+}
+
+// StatementDepthRequest is the number of account levels rolled up below each
+// financial statement line; every level when omitted, lines only with 0
+type StatementDepthRequest struct {
+	Depth *int `form:"depth" binding:"omitempty,min=0,max=10"`
+}
+
+// AccountDepth returns the depth for the service
+func (r StatementDepthRequest) AccountDepth() int {
+	if r.Depth == nil {
+		return domain.StatementDepthAll
+	}
+	return *r.Depth
+}
+
+// BalanceSheetRequest represents query parameters for the balance sheet
+type BalanceSheetRequest struct {
+	PeriodRequest
+	StatementDepthRequest
+}
+
+// IncomeStatementRequest represents query parameters for the income statement
+type IncomeStatementRequest struct {
+	DateRangeRequest
+	StatementDepthRequest
+}
+
 // ClosePeriodRequest represents the request to close a period
 type ClosePeriodRequest struct {
 	Year  int `json:"year" binding:"required,min=2000,max=2100"`
 	Month int `json:"month" binding:"required,min=1,max=12"`
+	// Override closes the period even if mandatory checklist tasks are incomplete (admin only)
+	Override bool `json:"override"`
 }
 
 // YearEndCloseRequest represents the request for year-end closing
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+)
+
+// LegacyImportRequest represents the form fields of a legacy import upload;
+// the file itself is sent as the "file" part
+type LegacyImportRequest struct {
+	Format          string `form:"format" binding:"required,oneof=douzone semusarang"`
+	Dataset         string `form:"dataset" binding:"required,oneof=accounts partners opening_balances vouchers"`
+	FiscalYear      int    `form:"fiscal_year" binding:"omitempty,min=1900,max=2999"`
+	CashAccountCode string `form:"cash_account_code" binding:"omitempty,max=10"`
+	DryRun          *bool  `form:"dry_run"` // defaults to true
+}
+
+// IsDryRun returns true unless the request explicitly asks to apply the import
+func (r *LegacyImportRequest) IsDryRun() bool {
+	return r.DryRun == nil || *r.DryRun
+}
+
+// Options converts the request into parsing options
+func (r *LegacyImportRequest) Options() migrate.Options {
+	return migrate.Options{Year: r.FiscalYear, CashAccountCode: r.CashAccountCode}
+}
+
+// LegacyImportFormatResponse describes a supported legacy package
+type LegacyImportFormatResponse struct {
+	Format   string                        `json:"format"`
+	Name     string                        `json:"name"`
+	Datasets []LegacyImportDatasetResponse `json:"datasets"`
+}
+
+// LegacyImportDatasetResponse lists the columns recognized in a dataset file
+type LegacyImportDatasetResponse struct {
+	Dataset string           `json:"dataset"`
+	Columns []migrate.Column `json:"columns"`
+}
+
+// FromLegacyImportFormats converts adapters to responses, with datasets in import order
+func FromLegacyImportFormats(adapters []migrate.Adapter) []LegacyImportFormatResponse {
+	result := make([]LegacyImportFormatResponse, len(adapters))
+	for i, a := range adapters {
+		result[i] = LegacyImportFormatResponse{Format: string(a.Format()), Name: a.Name()}
+		for _, dataset := range migrate.Datasets {
+			result[i].Datasets = append(result[i].Datasets, LegacyImportDatasetResponse{
+				Dataset: string(dataset),
+				Columns: a.Columns(dataset),
+			})
+		}
+	}
+	return result
+}
+
+// LegacyImportResultResponse represents the outcome of a legacy import or dry run
+type LegacyImportResultResponse struct {
+	Format   string          `json:"format"`
+	Dataset  string          `json:"dataset"`
+	DryRun   bool            `json:"dry_run"`
+	Applied  bool            `json:"applied"`
+	Rows     int             `json:"rows"`
+	Records  int             `json:"records"`
+	Created  int             `json:"created"`
+	Updated  int             `json:"updated"`
+	Skipped  int             `json:"skipped"`
+	Errors   int             `json:"errors"`
+	Warnings int             `json:"warnings"`
+	Issues   []migrate.Issue `json:"issues"`
+}
+
+// FromLegacyImportResult converts migrate.Result to LegacyImportResultResponse
+func FromLegacyImportResult(r *migrate.Result) LegacyImportResultResponse {
+	resp := LegacyImportResultResponse{
+		Format:  string(r.Format),
+		Dataset: string(r.Dataset),
+		DryRun:  r.DryRun,
+		Applied: r.Applied,
+		Rows:    r.Rows,
+		Records: r.Records,
+		Created: r.Created,
+		Updated: r.Updated,
+		Skipped: r.Skipped,
+		Issues:  r.Issues,
+	}
+	if resp.Issues == nil {
+		resp.Issues = []migrate.Issue{}
+	}
+	for _, issue := range r.Issues {
+		if issue.Severity == migrate.SeverityError {
+			resp.Errors++
+		} else {
+			resp.Warnings++
+		}
+	}
+	return resp
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateLoanRequest represents a request to register a loan
+type CreateLoanRequest struct {
+	LoanNo          string  `json:"loan_no" binding:"required,max=50"`
+	Lender          string  `json:"lender" binding:"required,max=200"`
+	PartnerID       string  `json:"partner_id" binding:"omitempty,uuid"`
+	Principal       int64   `json:"principal" binding:"required,min=1"`
+	InterestRate    float64 `json:"interest_rate" binding:"min=0,lt=100"` // annual, in percent
+	StartDate       string  `json:"start_date" binding:"required,datetime=2006-01-02"`
+	MaturityDate    string  `json:"maturity_date" binding:"required,datetime=2006-01-02"`
+	RepaymentMethod string  `json:"repayment_method" binding:"required,oneof=bullet equal_principal equal_payment"`
+	Description     string  `json:"description" binding:"max=500"`
+
+	LoanAccountID            string `json:"loan_account_id" binding:"required,uuid"`
+	InterestExpenseAccountID string `json:"interest_expense_account_id" binding:"required,uuid"`
+	AccruedInterestAccountID string `json:"accrued_interest_account_id" binding:"required,uuid"`
+	SettlementAccountID      string `json:"settlement_account_id" binding:"required,uuid"`
+}
+
+// ToDomain converts the request to domain.Loan; identifiers and dates are validated by binding
+func (r *CreateLoanRequest) ToDomain(companyID, userID uuid.UUID) *domain.Loan {
+	startDate, _ := time.Parse("2006-01-02", r.StartDate)
+	maturityDate, _ := time.Parse("2006-01-02", r.MaturityDate)
+	loan := &domain.Loan{
+		TenantModel:              domain.TenantModel{CompanyID: companyID},
+		LoanNo:                   r.LoanNo,
+		Lender:                   r.Lender,
+		Principal:                r.Principal,
+		InterestRate:             r.InterestRate,
+		StartDate:                startDate,
+		MaturityDate:             maturityDate,
+		RepaymentMethod:          domain.LoanRepaymentMethod(r.RepaymentMethod),
+		Description:              r.Description,
+		LoanAccountID:            uuid.MustParse(r.LoanAccountID),
+		InterestExpenseAccountID: uuid.MustParse(r.InterestExpenseAccountID),
+		AccruedInterestAccountID: uuid.MustParse(r.AccruedInterestAccountID),
+		SettlementAccountID:      uuid.MustParse(r.SettlementAccountID),
+		CreatedBy:                &userID,
+	}
+	if r.PartnerID != "" {
+		partnerID := uuid.MustParse(r.PartnerID)
+		loan.PartnerID = &partnerID
+	}
+	return loan
+}
+
+// RepayLoanRequest represents a repayment of a loan. Omitted amounts default to
+// the scheduled principal and the interest since the last repayment.
+type RepayLoanRequest struct {
+	RepaymentDate    string `json:"repayment_date" binding:"required,datetime=2006-01-02"`
+	Principal        *int64 `json:"principal" binding:"omitempty,min=0"`
+	Interest         *int64 `json:"interest" binding:"omitempty,min=0"`
+	PaymentAccountID string `json:"payment_account_id" binding:"omitempty,uuid"`
+}
+
+// AccrueLoanInterestRequest represents a request to accrue the interest of the
+// company's loans at a month end
+type AccrueLoanInterestRequest struct {
+	PeriodEnd string `json:"period_end" binding:"required,datetime=2006-01-02"`
+}
+
+// LoansOutstandingRequest represents query parameters of the loans outstanding report
+type LoansOutstandingRequest struct {
+	AsOf string `form:"as_of" binding:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// RegisterNoteRequest represents a request to register a received or issued note
+type RegisterNoteRequest struct {
+	NoteNo       string `json:"note_no" binding:"required,max=50"`
+	Direction    string `json:"direction" binding:"required,oneof=received issued"`
+	Kind         string `json:"kind" binding:"required,oneof=promissory electronic check"`
+	PartnerID    string `json:"partner_id" binding:"required,uuid"`
+	Drawer       string `json:"drawer" binding:"max=100"`
+	Amount       int64  `json:"amount" binding:"required,min=1"`
+	IssueDate    string `json:"issue_date" binding:"required,datetime=2006-01-02"`
+	MaturityDate string `json:"maturity_date" binding:"required,datetime=2006-01-02"`
+	BookedDate   string `json:"booked_date" binding:"omitempty,datetime=2006-01-02"` // defaults to the issue date
+	PayingBank   string `json:"paying_bank" binding:"max=100"`
+	Description  string `json:"description" binding:"max=500"`
+
+	NoteAccountID    string `json:"note_account_id" binding:"required,uuid"`
+	CounterAccountID string `json:"counter_account_id" binding:"required,uuid"`
+}
+
+// ToDomain converts the request to domain.Note; identifiers and dates are validated by binding
+func (r *RegisterNoteRequest) ToDomain(companyID, userID uuid.UUID) *domain.Note {
+	issueDate, _ := time.Parse("2006-01-02", r.IssueDate)
+	maturityDate, _ := time.Parse("2006-01-02", r.MaturityDate)
+	note := &domain.Note{
+		TenantModel:      domain.TenantModel{CompanyID: companyID},
+		NoteNo:           r.NoteNo,
+		Direction:        domain.NoteDirection(r.Direction),
+		Kind:             domain.NoteKind(r.Kind),
+		PartnerID:        uuid.MustParse(r.PartnerID),
+		Drawer:           r.Drawer,
+		Amount:           r.Amount,
+		IssueDate:        issueDate,
+		MaturityDate:     maturityDate,
+		PayingBank:       r.PayingBank,
+		Description:      r.Description,
+		NoteAccountID:    uuid.MustParse(r.NoteAccountID),
+		CounterAccountID: uuid.MustParse(r.CounterAccountID),
+		CreatedBy:        &userID,
+	}
+	if r.BookedDate != "" {
+		note.BookedDate, _ = time.Parse("2006-01-02", r.BookedDate)
+	}
+	return note
+}
+
+// DiscountNoteRequest represents the discounting of a received note at a bank
+type DiscountNoteRequest struct {
+	DiscountDate    string `json:"discount_date" binding:"required,datetime=2006-01-02"`
+	Charge          int64  `json:"charge" binding:"min=0"` // 할인료
+	BankAccountID   string `json:"bank_account_id" binding:"required,uuid"`
+	ChargeAccountID string `json:"charge_account_id" binding:"omitempty,uuid"` // required with a charge
+}
+
+// SettleNoteRequest represents the collection or payment of a note at maturity
+type SettleNoteRequest struct {
+	SettledDate   string `json:"settled_date" binding:"required,datetime=2006-01-02"`
+	BankAccountID string `json:"bank_account_id" binding:"required,uuid"`
+}
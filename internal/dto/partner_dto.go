@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"github.com/google/uuid"
+
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
 
@@ -24,8 +26,18 @@ type PartnerResponse struct {
 	CreditLimit      float64 `json:"credit_limit"`
 	ARAccountID      string  `json:"ar_account_id,omitempty"`
 	APAccountID      string  `json:"ap_account_id,omitempty"`
+	BankCode          string `json:"bank_code,omitempty"`
+	BankAccountNumber string `json:"bank_account_number,omitempty"`
+	BankAccountHolder string `json:"bank_account_holder,omitempty"`
 	IsActive         bool    `json:"is_active"`
 	CreatedAt        string  `json:"created_at"`
+
+	// NTS registration status of the business number
+	BusinessStatus         string `json:"business_status,omitempty"`
+	BusinessTaxType        string `json:"business_tax_type,omitempty"`
+	BusinessClosedOn       string `json:"business_closed_on,omitempty"`
+	RepresentativeVerified *bool  `json:"representative_verified,omitempty"`
+	BusinessVerifiedAt     string `json:"business_verified_at,omitempty"`
 	UpdatedAt        string  `json:"updated_at"`
 }
 
@@ -48,6 +60,9 @@ func FromPartner(partner *domain.Partner) PartnerResponse {
 		AddressDetail:   partner.AddressDetail,
 		PaymentTermDays: partner.PaymentTermDays,
 		CreditLimit:     partner.CreditLimit,
+		BankCode:          partner.BankCode,
+		BankAccountNumber: partner.BankAccountNumber,
+		BankAccountHolder: partner.BankAccountHolder,
 		IsActive:        partner.IsActive,
 		CreatedAt:       partner.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       partner.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
@@ -60,6 +75,16 @@ func FromPartner(partner *domain.Partner) PartnerResponse {
 		resp.APAccountID = partner.APAccountID.String()
 	}
 
+	resp.BusinessStatus = string(partner.BusinessStatus)
+	resp.BusinessTaxType = partner.BusinessTaxType
+	resp.RepresentativeVerified = partner.RepresentativeVerified
+	if partner.BusinessClosedOn != nil {
+		resp.BusinessClosedOn = partner.BusinessClosedOn.Format("2006-01-02")
+	}
+	if partner.BusinessVerifiedAt != nil {
+		resp.BusinessVerifiedAt = partner.BusinessVerifiedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
 	return resp
 }
 
@@ -74,7 +99,7 @@ func FromPartners(partners []domain.Partner) []PartnerResponse {
 
 // CreatePartnerRequest represents the request to create a partner
 type CreatePartnerRequest struct {
-	Code            string  `json:"code" binding:"required,max=20"`
+	Code            string  `json:"code,omitempty" binding:"max=20"` // generated by the company's code rule when empty
 	Name            string  `json:"name" binding:"required,max=100"`
 	NameEn          string  `json:"name_en,omitempty" binding:"max=100"`
 	BusinessNumber  string  `json:"business_number,omitempty" binding:"max=12"`
@@ -91,6 +116,10 @@ type CreatePartnerRequest struct {
 	CreditLimit     float64 `json:"credit_limit,omitempty"`
 	ARAccountID     string  `json:"ar_account_id,omitempty" binding:"omitempty,uuid"`
 	APAccountID     string  `json:"ap_account_id,omitempty" binding:"omitempty,uuid"`
+	// Bank account payables are transferred to
+	BankCode          string `json:"bank_code,omitempty" binding:"omitempty,len=3,numeric"`
+	BankAccountNumber string `json:"bank_account_number,omitempty" binding:"max=30"`
+	BankAccountHolder string `json:"bank_account_holder,omitempty" binding:"max=100"`
 	IsActive        *bool   `json:"is_active,omitempty"`
 }
 
@@ -113,6 +142,10 @@ type UpdatePartnerRequest struct {
 	CreditLimit     float64 `json:"credit_limit,omitempty"`
 	ARAccountID     string  `json:"ar_account_id,omitempty" binding:"omitempty,uuid"`
 	APAccountID     string  `json:"ap_account_id,omitempty" binding:"omitempty,uuid"`
+	// Bank account payables are transferred to
+	BankCode          string `json:"bank_code,omitempty" binding:"omitempty,len=3,numeric"`
+	BankAccountNumber string `json:"bank_account_number,omitempty" binding:"max=30"`
+	BankAccountHolder string `json:"bank_account_holder,omitempty" binding:"max=100"`
 	IsActive        *bool   `json:"is_active,omitempty"`
 }
 
@@ -129,3 +162,42 @@ type PartnerStatsResponse struct {
 	ActiveCount   int64 `json:"active_count"`
 	InactiveCount int64 `json:"inactive_count"`
 }
+
+// MergePartnersRequest represents the request to merge a duplicate partner,
+// the source, into the partner kept, the target
+type MergePartnersRequest struct {
+	SourceID string `json:"source_id" binding:"required,uuid"`
+	TargetID string `json:"target_id" binding:"required,uuid"`
+}
+
+// VerifyPartnersRequest represents a request to verify business numbers against NTS.
+// Each business is a registered partner or a business number, e.g. of a buyer.
+type VerifyPartnersRequest struct {
+	Businesses []VerifyBusinessRequest `json:"businesses" binding:"required,min=1,max=100,dive"`
+}
+
+// VerifyBusinessRequest represents one business to verify
+type VerifyBusinessRequest struct {
+	PartnerID      string `json:"partner_id,omitempty" binding:"required_without=BusinessNumber,omitempty,uuid"`
+	BusinessNumber string `json:"business_number,omitempty" binding:"max=12"`
+	// Representative is checked only with the opening date (개업일자)
+	Representative string `json:"representative,omitempty" binding:"max=50"`
+	StartDate      string `json:"start_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ToDomain converts the request to domain.BusinessVerificationRequest values; ids and dates are validated by binding
+func (r *VerifyPartnersRequest) ToDomain() []domain.BusinessVerificationRequest {
+	reqs := make([]domain.BusinessVerificationRequest, len(r.Businesses))
+	for i, b := range r.Businesses {
+		reqs[i] = domain.BusinessVerificationRequest{
+			BusinessNumber: b.BusinessNumber,
+			Representative: b.Representative,
+			StartDate:      optionalDate(b.StartDate),
+		}
+		if b.PartnerID != "" {
+			id := uuid.MustParse(b.PartnerID)
+			reqs[i].PartnerID = &id
+		}
+	}
+	return reqs
+}
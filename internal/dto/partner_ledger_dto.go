@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PartnerLedgerRequest represents query parameters for a partner statement of account
+type PartnerLedgerRequest struct {
+	PartnerID string `form:"partner_id" binding:"required,uuid"`
+	From      string `form:"from" binding:"required"`
+	To        string `form:"to" binding:"required"`
+}
+
+// PartnerStatementsRequest represents query parameters for bulk partner statements
+type PartnerStatementsRequest struct {
+	From         string `form:"from" binding:"required"`
+	To           string `form:"to" binding:"required"`
+	PartnerType  string `form:"partner_type" binding:"omitempty,oneof=customer vendor"`
+	IncludeEmpty bool   `form:"include_empty"`
+}
+
+// SendPartnerStatementsRequest represents the request to mail monthly partner statements
+type SendPartnerStatementsRequest struct {
+	Year        int    `json:"year" binding:"required,min=2000,max=2100"`
+	Month       int    `json:"month" binding:"required,min=1,max=12"`
+	PartnerType string `json:"partner_type" binding:"omitempty,oneof=customer vendor"`
+}
+
+// PartnerLedgerEntryResponse represents a partner ledger entry in API responses
+type PartnerLedgerEntryResponse struct {
+	VoucherID    string  `json:"voucher_id"`
+	VoucherNo    string  `json:"voucher_no"`
+	VoucherDate  string  `json:"voucher_date"`
+	VoucherType  string  `json:"voucher_type"`
+	LineNo       int     `json:"line_no"`
+	AccountCode  string  `json:"account_code"`
+	AccountName  string  `json:"account_name"`
+	Description  string  `json:"description"`
+	DebitAmount  float64 `json:"debit_amount"`
+	CreditAmount float64 `json:"credit_amount"`
+	Balance      float64 `json:"balance"`
+}
+
+// PartnerLedgerResponse represents a partner statement of account (거래처원장)
+type PartnerLedgerResponse struct {
+	PartnerID      string                       `json:"partner_id"`
+	PartnerCode    string                       `json:"partner_code"`
+	PartnerName    string                       `json:"partner_name"`
+	PartnerType    string                       `json:"partner_type"`
+	Email          string                       `json:"email,omitempty"`
+	FromDate       string                       `json:"from_date"`
+	ToDate         string                       `json:"to_date"`
+	OpeningBalance float64                      `json:"opening_balance"`
+	TotalDebit     float64                      `json:"total_debit"`
+	TotalCredit    float64                      `json:"total_credit"`
+	ClosingBalance float64                      `json:"closing_balance"`
+	Entries        []PartnerLedgerEntryResponse `json:"entries"`
+}
+
+// FromPartnerLedger converts domain.PartnerLedger to PartnerLedgerResponse
+func FromPartnerLedger(ledger *domain.PartnerLedger) PartnerLedgerResponse {
+	resp := PartnerLedgerResponse{
+		PartnerID:      ledger.Partner.ID.String(),
+		PartnerCode:    ledger.Partner.Code,
+		PartnerName:    ledger.Partner.Name,
+		PartnerType:    ledger.Partner.PartnerType,
+		Email:          ledger.Partner.Email,
+		FromDate:       ledger.FromDate.Format("2006-01-02"),
+		ToDate:         ledger.ToDate.Format("2006-01-02"),
+		OpeningBalance: ledger.OpeningBalance,
+		TotalDebit:     ledger.TotalDebit,
+		TotalCredit:    ledger.TotalCredit,
+		ClosingBalance: ledger.ClosingBalance,
+		Entries:        make([]PartnerLedgerEntryResponse, len(ledger.Entries)),
+	}
+	for i, e := range ledger.Entries {
+		resp.Entries[i] = PartnerLedgerEntryResponse{
+			VoucherID:    e.VoucherID.String(),
+			VoucherNo:    e.VoucherNo,
+			VoucherDate:  e.VoucherDate.Format("2006-01-02"),
+			VoucherType:  e.VoucherType,
+			LineNo:       e.LineNo,
+			AccountCode:  e.AccountCode,
+			AccountName:  e.AccountName,
+			Description:  e.Description,
+			DebitAmount:  e.DebitAmount,
+			CreditAmount: e.CreditAmount,
+			Balance:      e.Balance,
+		}
+	}
+	return resp
+}
+
+// FromPartnerLedgers converts a slice of domain.PartnerLedger to responses
+func FromPartnerLedgers(ledgers []domain.PartnerLedger) []PartnerLedgerResponse {
+	result := make([]PartnerLedgerResponse, len(ledgers))
+	for i := range ledgers {
+		result[i] = FromPartnerLedger(&ledgers[i])
+	}
+	return result
+}
+
+// PartnerStatementFailureResponse represents a statement that could not be mailed
+type PartnerStatementFailureResponse struct {
+	PartnerID   string `json:"partner_id"`
+	PartnerName string `json:"partner_name"`
+	Error       string `json:"error"`
+}
+
+// PartnerStatementMailingResponse represents the result of a statement mailing
+type PartnerStatementMailingResponse struct {
+	FromDate  string                            `json:"from_date"`
+	ToDate    string                            `json:"to_date"`
+	Generated int                               `json:"generated"`
+	Sent      int                               `json:"sent"`
+	Skipped   int                               `json:"skipped"`
+	Failures  []PartnerStatementFailureResponse `json:"failures"`
+}
+
+// FromPartnerStatementMailing converts domain.PartnerStatementMailing to PartnerStatementMailingResponse
+func FromPartnerStatementMailing(m *domain.PartnerStatementMailing, from, to string) PartnerStatementMailingResponse {
+	resp := PartnerStatementMailingResponse{
+		FromDate:  from,
+		ToDate:    to,
+		Generated: m.Generated,
+		Sent:      m.Sent,
+		Skipped:   m.Skipped,
+		Failures:  make([]PartnerStatementFailureResponse, len(m.Failures)),
+	}
+	for i, f := range m.Failures {
+		resp.Failures[i] = PartnerStatementFailureResponse{
+			PartnerID:   f.PartnerID.String(),
+			PartnerName: f.PartnerName,
+			Error:       f.Error,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,24 @@
+package dto
+
+// PayablesRequest represents query parameters of the payables due
+type PayablesRequest struct {
+	DueBy     string `form:"due_by" binding:"omitempty,datetime=2006-01-02"`
+	PartnerID string `form:"partner_id" binding:"omitempty,uuid"`
+}
+
+// CreatePaymentBatchRequest represents a request to generate a bulk transfer
+// of the payables due. Without partners or entries all payables due are taken.
+type CreatePaymentBatchRequest struct {
+	BankAccountID string   `json:"bank_account_id" binding:"required,uuid"` // paying account
+	TransferDate  string   `json:"transfer_date" binding:"required,datetime=2006-01-02"`
+	DueBy         string   `json:"due_by" binding:"omitempty,datetime=2006-01-02"` // defaults to the transfer date
+	PartnerIDs    []string `json:"partner_ids" binding:"omitempty,dive,uuid"`
+	EntryIDs      []string `json:"entry_ids" binding:"omitempty,dive,uuid"`
+}
+
+// ExecutePaymentBatchRequest represents the outcome of a bulk transfer
+// reported by the bank; items not listed as failed are paid
+type ExecutePaymentBatchRequest struct {
+	ExecutedOn    string   `json:"executed_on" binding:"required,datetime=2006-01-02"`
+	FailedItemIDs []string `json:"failed_item_ids" binding:"omitempty,dive,uuid"`
+}
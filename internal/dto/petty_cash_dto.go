@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreatePettyCashFundRequest represents a request to establish a petty cash fund
+type CreatePettyCashFundRequest struct {
+	Name            string `json:"name" binding:"required,max=100"`
+	DepartmentID    string `json:"department_id" binding:"required,uuid"`
+	CustodianID     string `json:"custodian_id" binding:"omitempty,uuid"`
+	FloatAmount     int64  `json:"float_amount" binding:"required,min=1"`
+	EstablishedDate string `json:"established_date" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	AccountID       string `json:"account_id" binding:"required,uuid"`                       // cash account of the fund
+	BankAccountID   string `json:"bank_account_id" binding:"required,uuid"`
+}
+
+// ToDomain converts the request to domain.PettyCashFund; identifiers and dates are validated by binding
+func (r *CreatePettyCashFundRequest) ToDomain(companyID, userID uuid.UUID) *domain.PettyCashFund {
+	establishedDate, _ := time.Parse("2006-01-02", r.EstablishedDate)
+	fund := &domain.PettyCashFund{
+		TenantModel:     domain.TenantModel{CompanyID: companyID},
+		Name:            r.Name,
+		DepartmentID:    uuid.MustParse(r.DepartmentID),
+		FloatAmount:     r.FloatAmount,
+		EstablishedDate: establishedDate,
+		AccountID:       uuid.MustParse(r.AccountID),
+		BankAccountID:   uuid.MustParse(r.BankAccountID),
+		CreatedBy:       &userID,
+	}
+	if r.CustodianID != "" {
+		custodianID := uuid.MustParse(r.CustodianID)
+		fund.CustodianID = &custodianID
+	}
+	return fund
+}
+
+// UpdatePettyCashFundRequest represents a request to update a petty cash fund
+type UpdatePettyCashFundRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	CustodianID   string `json:"custodian_id" binding:"omitempty,uuid"`
+	BankAccountID string `json:"bank_account_id" binding:"required,uuid"`
+	IsActive      *bool  `json:"is_active" binding:"required"`
+}
+
+// PettyCashClaimRequest represents an expense paid from a petty cash fund
+type PettyCashClaimRequest struct {
+	TransactionDate string `json:"transaction_date" binding:"required,datetime=2006-01-02"`
+	AccountID       string `json:"account_id" binding:"required,uuid"` // expense account
+	Amount          int64  `json:"amount" binding:"required,min=1"`
+	Payee           string `json:"payee" binding:"max=100"`
+	Description     string `json:"description" binding:"required,max=200"`
+	ReceiptNo       string `json:"receipt_no" binding:"max=50"`
+}
+
+// ToDomain converts the request to domain.PettyCashTransaction; identifiers and dates are validated by binding
+func (r *PettyCashClaimRequest) ToDomain(companyID, fundID, userID uuid.UUID) *domain.PettyCashTransaction {
+	date, _ := time.Parse("2006-01-02", r.TransactionDate)
+	return &domain.PettyCashTransaction{
+		TenantModel:     domain.TenantModel{CompanyID: companyID},
+		FundID:          fundID,
+		TransactionDate: date,
+		AccountID:       uuid.MustParse(r.AccountID),
+		Amount:          r.Amount,
+		Payee:           r.Payee,
+		Description:     r.Description,
+		ReceiptNo:       r.ReceiptNo,
+		CreatedBy:       &userID,
+	}
+}
+
+// ReplenishPettyCashRequest represents a replenishment request of a petty cash fund
+type ReplenishPettyCashRequest struct {
+	RequestDate   string `json:"request_date" binding:"required,datetime=2006-01-02"`
+	ClaimsThrough string `json:"claims_through" binding:"omitempty,datetime=2006-01-02"` // defaults to the request date
+}
+
+// PettyCashReconciliationRequest represents query parameters of the fund reconciliation
+type PettyCashReconciliationRequest struct {
+	AsOf        string `form:"as_of" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	CountedCash *int64 `form:"counted_cash" binding:"omitempty,min=0"`
+}
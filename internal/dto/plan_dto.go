@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// PlanResponse is a subscription plan
+type PlanResponse struct {
+	Code                string  `json:"code"`
+	Name                string  `json:"name"`
+	MaxUsers            *int64  `json:"max_users"`
+	MaxVouchersPerMonth *int64  `json:"max_vouchers_per_month"`
+	MaxStorageBytes     *int64  `json:"max_storage_bytes"`
+	UpgradeTo           *string `json:"upgrade_to,omitempty"`
+}
+
+// FromPlan converts a plan to a response
+func FromPlan(p *domain.Plan) PlanResponse {
+	return PlanResponse{
+		Code:                p.Code,
+		Name:                p.Name,
+		MaxUsers:            p.MaxUsers,
+		MaxVouchersPerMonth: p.MaxVouchersPerMonth,
+		MaxStorageBytes:     p.MaxStorageBytes,
+		UpgradeTo:           p.UpgradeTo,
+	}
+}
+
+// FromPlans converts plans to responses
+func FromPlans(plans []domain.Plan) []PlanResponse {
+	result := make([]PlanResponse, len(plans))
+	for i := range plans {
+		result[i] = FromPlan(&plans[i])
+	}
+	return result
+}
+
+// PlanUsageResponse is the company's usage of a limited resource
+type PlanUsageResponse struct {
+	Limit   domain.PlanLimit `json:"limit"`
+	Label   string           `json:"label"`
+	Current int64            `json:"current"`
+	Max     *int64           `json:"max"` // null when unlimited
+}
+
+// SubscriptionResponse is the company's plan with its usage
+type SubscriptionResponse struct {
+	Plan          PlanResponse        `json:"plan"`
+	PreviousPlan  *PlanResponse       `json:"previous_plan,omitempty"`
+	PlanChangedAt *time.Time          `json:"plan_changed_at,omitempty"`
+	Usage         []PlanUsageResponse `json:"usage"`
+}
+
+// FromSubscription converts the company's plan and usage to a response
+func FromSubscription(plan *domain.CompanyPlan, usage []domain.PlanUsage, loc i18n.Locale) SubscriptionResponse {
+	resp := SubscriptionResponse{
+		Plan:          FromPlan(plan.Plan),
+		PlanChangedAt: plan.PlanChangedAt,
+		Usage:         make([]PlanUsageResponse, len(usage)),
+	}
+	if plan.PreviousPlan != nil {
+		previous := FromPlan(plan.PreviousPlan)
+		resp.PreviousPlan = &previous
+	}
+	for i, u := range usage {
+		resp.Usage[i] = PlanUsageResponse{Limit: u.Limit, Label: u.Limit.LocalizedLabel(loc), Current: u.Current, Max: u.Max}
+	}
+	return resp
+}
+
+// PlanLimitResponse describes the limit an operation was refused for
+type PlanLimitResponse struct {
+	Limit     domain.PlanLimit `json:"limit"`
+	Label     string           `json:"label"`
+	Plan      string           `json:"plan"`
+	Max       int64            `json:"max"`
+	Current   int64            `json:"current"`
+	UpgradeTo *PlanResponse    `json:"upgrade_to,omitempty"`
+}
+
+// FromPlanLimitError converts a plan limit error to a response
+func FromPlanLimitError(err *domain.PlanLimitError, loc i18n.Locale) PlanLimitResponse {
+	resp := PlanLimitResponse{
+		Limit:   err.Limit,
+		Label:   err.Limit.LocalizedLabel(loc),
+		Plan:    err.Plan,
+		Max:     err.Max,
+		Current: err.Current,
+	}
+	if err.UpgradeTo != nil {
+		upgrade := FromPlan(err.UpgradeTo)
+		resp.UpgradeTo = &upgrade
+	}
+	return resp
+}
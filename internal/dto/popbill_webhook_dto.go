@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PopbillWebhookEventResponse represents a received Popbill callback
+type PopbillWebhookEventResponse struct {
+	ID           string          `json:"id"`
+	EventType    string          `json:"event_type"`
+	CorpNum      string          `json:"corp_num"`
+	ItemKey      string          `json:"item_key"`
+	StateCode    int             `json:"state_code"`
+	Payload      json.RawMessage `json:"payload"`
+	OccurredAt   string          `json:"occurred_at,omitempty"`
+	Status       string          `json:"status"`
+	Attempts     int             `json:"attempts"`
+	Error        string          `json:"error,omitempty"`
+	TaxInvoiceID string          `json:"tax_invoice_id,omitempty"`
+	RemoteAddr   string          `json:"remote_addr"`
+	ReceivedAt   string          `json:"received_at"`
+	ProcessedAt  string          `json:"processed_at,omitempty"`
+}
+
+// FromPopbillWebhookEvent converts domain.PopbillWebhookEvent to PopbillWebhookEventResponse
+func FromPopbillWebhookEvent(e *domain.PopbillWebhookEvent) PopbillWebhookEventResponse {
+	resp := PopbillWebhookEventResponse{
+		ID:         e.ID.String(),
+		EventType:  e.EventType,
+		CorpNum:    e.CorpNum,
+		ItemKey:    e.ItemKey,
+		StateCode:  e.StateCode,
+		Payload:    json.RawMessage(e.Payload),
+		Status:     string(e.Status),
+		Attempts:   e.Attempts,
+		Error:      e.Error,
+		RemoteAddr: e.RemoteAddr,
+		ReceivedAt: e.ReceivedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if e.OccurredAt != nil {
+		resp.OccurredAt = e.OccurredAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if e.TaxInvoiceID != nil {
+		resp.TaxInvoiceID = e.TaxInvoiceID.String()
+	}
+	if e.ProcessedAt != nil {
+		resp.ProcessedAt = e.ProcessedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
+
+// FromPopbillWebhookEvents converts a slice of domain.PopbillWebhookEvent to responses
+func FromPopbillWebhookEvents(events []domain.PopbillWebhookEvent) []PopbillWebhookEventResponse {
+	responses := make([]PopbillWebhookEventResponse, len(events))
+	for i := range events {
+		responses[i] = FromPopbillWebhookEvent(&events[i])
+	}
+	return responses
+}
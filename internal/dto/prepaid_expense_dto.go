@@ -0,0 +1,25 @@
+package dto
+
+// CreatePrepaidExpenseRequest represents a request to mark the expense of a
+// posted voucher as prepaid over a period
+type CreatePrepaidExpenseRequest struct {
+	VoucherID        string `json:"voucher_id" binding:"required,uuid"`
+	ExpenseAccountID string `json:"expense_account_id" binding:"required,uuid"`
+	PrepaidAccountID string `json:"prepaid_account_id" binding:"required,uuid"`
+	PeriodStart      string `json:"period_start" binding:"required,datetime=2006-01-02"`
+	PeriodEnd        string `json:"period_end" binding:"required,datetime=2006-01-02"`
+	Amount           *int64 `json:"amount" binding:"omitempty,min=1"` // defaults to the expense debited by the voucher
+	Description      string `json:"description" binding:"max=500"`
+}
+
+// AmortizePrepaidExpensesRequest represents a request to post the prepaid
+// expense amortizations of the company due by a month end
+type AmortizePrepaidExpensesRequest struct {
+	PeriodEnd string `json:"period_end" binding:"required,datetime=2006-01-02"`
+}
+
+// PrepaidExpenseBalanceRequest holds the query of the prepaid expense balance report
+type PrepaidExpenseBalanceRequest struct {
+	AsOf      string `form:"as_of" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	AccountID string `form:"account_id" binding:"omitempty,uuid"`           // prepaid account
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"net/http"
+
+	"github.com/saintgo7/saas-kerp/internal/validation"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix makes the type URI of a problem from its error code
+const problemTypePrefix = "urn:kerp:error:"
+
+// Problem is an RFC 7807 problem details object. Besides the standard members
+// it carries what the error envelope does: the error code, the details and
+// rejected fields, the data of errors that have any (the suggested plan of an
+// exceeded limit) and the request ID.
+type Problem struct {
+	Type      string                  `json:"type"`
+	Title     string                  `json:"title"`
+	Status    int                     `json:"status"`
+	Detail    string                  `json:"detail"`
+	Instance  string                  `json:"instance,omitempty"`
+	Code      string                  `json:"code"`
+	Details   string                  `json:"details,omitempty"`
+	Fields    []validation.FieldError `json:"fields,omitempty"`
+	Data      interface{}             `json:"data,omitempty"`
+	RequestID string                  `json:"request_id,omitempty"`
+}
+
+// NewProblem creates the problem details of an error response. The type is
+// the URN of the error code, e.g. urn:kerp:error:RES_001.
+func NewProblem(status int, info *ErrorInfo, instance string) *Problem {
+	return &Problem{
+		Type:     problemTypePrefix + info.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   info.Message,
+		Instance: instance,
+		Code:     info.Code,
+		Details:  info.Details,
+		Fields:   info.Fields,
+	}
+}
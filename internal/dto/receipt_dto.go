@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// SuggestFromReceiptRequest represents the form fields sent with a receipt image
+type SuggestFromReceiptRequest struct {
+	ExpenseAccountID string `form:"expense_account_id" binding:"required,uuid"`
+	PaymentAccountID string `form:"payment_account_id" binding:"required,uuid"`
+	TaxCodeID        string `form:"tax_code_id" binding:"omitempty,uuid"`
+}
+
+// ReceiptSuggestionResponse represents the recognized receipt and the suggested voucher.
+// Voucher can be submitted unchanged to POST /vouchers.
+type ReceiptSuggestionResponse struct {
+	TransactionDate      string               `json:"transaction_date,omitempty"`
+	VendorName           string               `json:"vendor_name,omitempty"`
+	VendorBusinessNumber string               `json:"vendor_business_number,omitempty"`
+	TotalAmount          float64              `json:"total_amount"`
+	SupplyAmount         float64              `json:"supply_amount"`
+	TaxAmount            float64              `json:"tax_amount"`
+	Confidence           float64              `json:"confidence"`
+	Voucher              CreateVoucherRequest `json:"voucher"`
+	Warnings             []string             `json:"warnings,omitempty"`
+}
+
+// FromReceiptSuggestion converts domain.ReceiptSuggestion to ReceiptSuggestionResponse
+func FromReceiptSuggestion(s *domain.ReceiptSuggestion) ReceiptSuggestionResponse {
+	resp := ReceiptSuggestionResponse{
+		VendorName:           s.VendorName,
+		VendorBusinessNumber: s.VendorBusinessNumber,
+		TotalAmount:          s.TotalAmount,
+		SupplyAmount:         s.SupplyAmount,
+		TaxAmount:            s.TaxAmount,
+		Confidence:           s.Confidence,
+		Voucher: CreateVoucherRequest{
+			VoucherDate: s.VoucherDate.Format("2006-01-02"),
+			VoucherType: string(s.VoucherType),
+			Description: s.Description,
+		},
+		Warnings: s.Warnings,
+	}
+	if s.TransactionDate != nil {
+		resp.TransactionDate = s.TransactionDate.Format("2006-01-02")
+	}
+
+	for _, entry := range s.Entries {
+		req := CreateVoucherEntryRequest{
+			AccountID:    entry.AccountID.String(),
+			DebitAmount:  entry.DebitAmount,
+			CreditAmount: entry.CreditAmount,
+			Description:  entry.Description,
+		}
+		if entry.PartnerID != nil {
+			req.PartnerID = entry.PartnerID.String()
+		}
+		if entry.TaxCodeID != nil {
+			req.TaxCodeID = entry.TaxCodeID.String()
+		}
+		resp.Voucher.Entries = append(resp.Voucher.Entries, req)
+	}
+
+	return resp
+}
@@ -0,0 +1,232 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// AccountCodeRangeRequest represents an inclusive account code range
+type AccountCodeRangeRequest struct {
+	From string `json:"from" binding:"required,max=10"`
+	To   string `json:"to" binding:"required,max=10"`
+}
+
+// ReportSpecRequest represents the definition of a custom report
+type ReportSpecRequest struct {
+	AccountRanges []AccountCodeRangeRequest `json:"account_ranges" binding:"omitempty,dive"`
+	AccountTypes  []string                  `json:"account_types" binding:"omitempty,dive,oneof=asset liability equity revenue expense"`
+	Tags          []string                  `json:"tags" binding:"omitempty,max=10,dive,max=50"`
+	Columns       []string                  `json:"columns" binding:"required,min=1,dive,oneof=opening debit credit closing"`
+	GroupBy       []string                  `json:"group_by" binding:"omitempty,max=3,dive,oneof=department partner project"`
+	Period        string                    `json:"period" binding:"omitempty,oneof=current_month previous_month previous_quarter year_to_date previous_year"`
+	NaturalSign   bool                      `json:"natural_sign"`
+	IncludeZero   bool                      `json:"include_zero"`
+}
+
+// ToReportSpec converts the request to a domain.ReportSpec
+func (r *ReportSpecRequest) ToReportSpec() domain.ReportSpec {
+	spec := domain.ReportSpec{
+		Period:      domain.ReportPeriodCurrentMonth,
+		NaturalSign: r.NaturalSign,
+		IncludeZero: r.IncludeZero,
+		Tags:        r.Tags,
+	}
+	for _, rng := range r.AccountRanges {
+		spec.AccountRanges = append(spec.AccountRanges, domain.AccountCodeRange{From: rng.From, To: rng.To})
+	}
+	for _, t := range r.AccountTypes {
+		spec.AccountTypes = append(spec.AccountTypes, domain.AccountType(t))
+	}
+	for _, c := range r.Columns {
+		spec.Columns = append(spec.Columns, domain.ReportAmountColumn(c))
+	}
+	for _, d := range r.GroupBy {
+		spec.GroupBy = append(spec.GroupBy, domain.ReportDimension(d))
+	}
+	if r.Period != "" {
+		spec.Period = domain.ReportPeriod(r.Period)
+	}
+	return spec
+}
+
+// CreateReportDefinitionRequest represents the request to save a custom report
+type CreateReportDefinitionRequest struct {
+	Name        string            `json:"name" binding:"required,max=100"`
+	Description string            `json:"description" binding:"omitempty,max=500"`
+	Spec        ReportSpecRequest `json:"spec" binding:"required"`
+}
+
+// ToReportDefinition converts the request to a domain.ReportDefinition
+func (r *CreateReportDefinitionRequest) ToReportDefinition(companyID, userID uuid.UUID) *domain.ReportDefinition {
+	def := &domain.ReportDefinition{
+		Name:        r.Name,
+		Description: r.Description,
+		Spec:        r.Spec.ToReportSpec(),
+	}
+	def.CompanyID = companyID
+	if userID != uuid.Nil {
+		def.CreatedBy = &userID
+	}
+	return def
+}
+
+// UpdateReportDefinitionRequest represents the request to update a custom report
+type UpdateReportDefinitionRequest struct {
+	Name        *string            `json:"name" binding:"omitempty,max=100"`
+	Description *string            `json:"description" binding:"omitempty,max=500"`
+	Spec        *ReportSpecRequest `json:"spec"`
+}
+
+// ApplyTo applies the non-nil fields to an existing definition
+func (r *UpdateReportDefinitionRequest) ApplyTo(def *domain.ReportDefinition) {
+	if r.Name != nil {
+		def.Name = *r.Name
+	}
+	if r.Description != nil {
+		def.Description = *r.Description
+	}
+	if r.Spec != nil {
+		def.Spec = r.Spec.ToReportSpec()
+	}
+}
+
+// RunReportRequest represents the query parameters of a report run or export.
+// Both dates must be given to override the definition's period.
+type RunReportRequest struct {
+	FromDate string `form:"from_date" json:"from_date"`
+	ToDate   string `form:"to_date" json:"to_date"`
+	Format   string `form:"format" json:"format" binding:"omitempty,oneof=csv pdf"`
+}
+
+// DateRange parses the optional date range
+func (r *RunReportRequest) DateRange() (*time.Time, *time.Time, error) {
+	if r.FromDate == "" && r.ToDate == "" {
+		return nil, nil, nil
+	}
+	from, err := time.Parse("2006-01-02", r.FromDate)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidReportDateRange
+	}
+	to, err := time.Parse("2006-01-02", r.ToDate)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidReportDateRange
+	}
+	return &from, &to, nil
+}
+
+// PreviewReportRequest represents the request to run an unsaved custom report
+type PreviewReportRequest struct {
+	RunReportRequest
+	Spec ReportSpecRequest `json:"spec" binding:"required"`
+}
+
+// AccountCodeRangeResponse represents an account code range in API responses
+type AccountCodeRangeResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ReportSpecResponse represents a custom report spec in API responses
+type ReportSpecResponse struct {
+	AccountRanges []AccountCodeRangeResponse `json:"account_ranges"`
+	AccountTypes  []string                   `json:"account_types"`
+	Tags          []string                   `json:"tags"`
+	Columns       []string                   `json:"columns"`
+	GroupBy       []string                   `json:"group_by"`
+	Period        string                     `json:"period"`
+	NaturalSign   bool                       `json:"natural_sign"`
+	IncludeZero   bool                       `json:"include_zero"`
+}
+
+// ReportDefinitionResponse represents a saved custom report in API responses
+type ReportDefinitionResponse struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Spec        ReportSpecResponse `json:"spec"`
+	CreatedBy   string             `json:"created_by,omitempty"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+}
+
+// FromReportDefinition converts domain.ReportDefinition to ReportDefinitionResponse
+func FromReportDefinition(def *domain.ReportDefinition) ReportDefinitionResponse {
+	spec := ReportSpecResponse{
+		AccountRanges: make([]AccountCodeRangeResponse, len(def.Spec.AccountRanges)),
+		AccountTypes:  make([]string, len(def.Spec.AccountTypes)),
+		Tags:          append([]string{}, def.Spec.Tags...),
+		Columns:       make([]string, len(def.Spec.Columns)),
+		GroupBy:       make([]string, len(def.Spec.GroupBy)),
+		Period:        string(def.Spec.Period),
+		NaturalSign:   def.Spec.NaturalSign,
+		IncludeZero:   def.Spec.IncludeZero,
+	}
+	for i, rng := range def.Spec.AccountRanges {
+		spec.AccountRanges[i] = AccountCodeRangeResponse{From: rng.From, To: rng.To}
+	}
+	for i, t := range def.Spec.AccountTypes {
+		spec.AccountTypes[i] = string(t)
+	}
+	for i, c := range def.Spec.Columns {
+		spec.Columns[i] = string(c)
+	}
+	for i, d := range def.Spec.GroupBy {
+		spec.GroupBy[i] = string(d)
+	}
+
+	resp := ReportDefinitionResponse{
+		ID:          def.ID.String(),
+		Name:        def.Name,
+		Description: def.Description,
+		Spec:        spec,
+		CreatedAt:   def.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   def.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if def.CreatedBy != nil {
+		resp.CreatedBy = def.CreatedBy.String()
+	}
+	return resp
+}
+
+// FromReportDefinitions converts a slice of domain.ReportDefinition to responses
+func FromReportDefinitions(defs []domain.ReportDefinition) []ReportDefinitionResponse {
+	result := make([]ReportDefinitionResponse, len(defs))
+	for i := range defs {
+		result[i] = FromReportDefinition(&defs[i])
+	}
+	return result
+}
+
+// ReportColumnResponse represents a report table column in API responses
+type ReportColumnResponse struct {
+	Label   string `json:"label"`
+	Numeric bool   `json:"numeric"`
+}
+
+// ReportTableResponse represents a generated report in API responses.
+// Numeric cells are plain numbers formatted as strings.
+type ReportTableResponse struct {
+	Title    string                 `json:"title"`
+	Subtitle string                 `json:"subtitle,omitempty"`
+	Columns  []ReportColumnResponse `json:"columns"`
+	Rows     [][]string             `json:"rows"`
+}
+
+// FromReportTable converts domain.ReportTable to ReportTableResponse
+func FromReportTable(table *domain.ReportTable) ReportTableResponse {
+	resp := ReportTableResponse{
+		Title:    table.Title,
+		Subtitle: table.Subtitle,
+		Columns:  make([]ReportColumnResponse, len(table.Columns)),
+		Rows:     table.Rows,
+	}
+	for i, c := range table.Columns {
+		resp.Columns[i] = ReportColumnResponse{Label: c.Label, Numeric: c.Numeric}
+	}
+	if resp.Rows == nil {
+		resp.Rows = [][]string{}
+	}
+	return resp
+}
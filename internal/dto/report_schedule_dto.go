@@ -0,0 +1,203 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// CreateReportScheduleRequest represents the request to create a report schedule
+type CreateReportScheduleRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	ReportType string   `json:"report_type" binding:"required,oneof=trial_balance balance_sheet income_statement vat_return"`
+	Period     string   `json:"period" binding:"required,oneof=current_month previous_month previous_quarter year_to_date previous_year"`
+	Format     string   `json:"format" binding:"omitempty,oneof=csv pdf"`
+	CronExpr   string   `json:"cron_expr" binding:"required,max=100"`
+	Timezone   string   `json:"timezone" binding:"omitempty,max=50"`
+	Recipients []string `json:"recipients" binding:"required,min=1,dive,email"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// ToReportSchedule converts the request to a domain.ReportSchedule
+func (r *CreateReportScheduleRequest) ToReportSchedule(companyID, userID uuid.UUID) *domain.ReportSchedule {
+	schedule := &domain.ReportSchedule{
+		Name:       r.Name,
+		ReportType: domain.ReportType(r.ReportType),
+		Params:     domain.ReportParams{Period: domain.ReportPeriod(r.Period)},
+		Format:     domain.ReportFormatPDF,
+		CronExpr:   r.CronExpr,
+		Timezone:   "Asia/Seoul",
+		Recipients: r.Recipients,
+		IsActive:   true,
+	}
+	schedule.CompanyID = companyID
+	if userID != uuid.Nil {
+		schedule.CreatedBy = &userID
+	}
+
+	if r.Format != "" {
+		schedule.Format = domain.ReportFormat(r.Format)
+	}
+	if r.Timezone != "" {
+		schedule.Timezone = r.Timezone
+	}
+	if r.IsActive != nil {
+		schedule.IsActive = *r.IsActive
+	}
+	return schedule
+}
+
+// UpdateReportScheduleRequest represents the request to update a report schedule
+type UpdateReportScheduleRequest struct {
+	Name       *string  `json:"name" binding:"omitempty,max=100"`
+	ReportType *string  `json:"report_type" binding:"omitempty,oneof=trial_balance balance_sheet income_statement vat_return"`
+	Period     *string  `json:"period" binding:"omitempty,oneof=current_month previous_month previous_quarter year_to_date previous_year"`
+	Format     *string  `json:"format" binding:"omitempty,oneof=csv pdf"`
+	CronExpr   *string  `json:"cron_expr" binding:"omitempty,max=100"`
+	Timezone   *string  `json:"timezone" binding:"omitempty,max=50"`
+	Recipients []string `json:"recipients" binding:"omitempty,dive,email"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// ApplyTo applies the non-nil fields to an existing schedule.
+// Re-activating a schedule clears its failure count.
+func (r *UpdateReportScheduleRequest) ApplyTo(schedule *domain.ReportSchedule) {
+	if r.Name != nil {
+		schedule.Name = *r.Name
+	}
+	if r.ReportType != nil {
+		schedule.ReportType = domain.ReportType(*r.ReportType)
+	}
+	if r.Period != nil {
+		schedule.Params.Period = domain.ReportPeriod(*r.Period)
+	}
+	if r.Format != nil {
+		schedule.Format = domain.ReportFormat(*r.Format)
+	}
+	if r.CronExpr != nil {
+		schedule.CronExpr = *r.CronExpr
+	}
+	if r.Timezone != nil {
+		schedule.Timezone = *r.Timezone
+	}
+	if r.Recipients != nil {
+		schedule.Recipients = r.Recipients
+	}
+	if r.IsActive != nil {
+		if *r.IsActive && !schedule.IsActive {
+			schedule.FailureCount = 0
+		}
+		schedule.IsActive = *r.IsActive
+	}
+}
+
+// ReportScheduleResponse represents a report schedule in API responses
+type ReportScheduleResponse struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	ReportType   string   `json:"report_type"`
+	ReportName   string   `json:"report_name"`
+	Period       string   `json:"period"`
+	Format       string   `json:"format"`
+	CronExpr     string   `json:"cron_expr"`
+	Timezone     string   `json:"timezone"`
+	Recipients   []string `json:"recipients"`
+	IsActive     bool     `json:"is_active"`
+	NextRunAt    string   `json:"next_run_at,omitempty"`
+	LastRunAt    string   `json:"last_run_at,omitempty"`
+	LastStatus   string   `json:"last_status,omitempty"`
+	FailureCount int      `json:"failure_count"`
+	CreatedBy    string   `json:"created_by,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+// FromReportSchedule converts domain.ReportSchedule to ReportScheduleResponse with the report name in the locale
+func FromReportSchedule(s *domain.ReportSchedule, loc i18n.Locale) ReportScheduleResponse {
+	resp := ReportScheduleResponse{
+		ID:           s.ID.String(),
+		Name:         s.Name,
+		ReportType:   string(s.ReportType),
+		ReportName:   s.ReportType.LocalizedLabel(loc),
+		Period:       string(s.Params.Period),
+		Format:       string(s.Format),
+		CronExpr:     s.CronExpr,
+		Timezone:     s.Timezone,
+		Recipients:   s.Recipients,
+		IsActive:     s.IsActive,
+		LastStatus:   s.LastStatus,
+		FailureCount: s.FailureCount,
+		CreatedAt:    s.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    s.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if resp.Recipients == nil {
+		resp.Recipients = []string{}
+	}
+	if s.NextRunAt != nil {
+		resp.NextRunAt = s.NextRunAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if s.LastRunAt != nil {
+		resp.LastRunAt = s.LastRunAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if s.CreatedBy != nil {
+		resp.CreatedBy = s.CreatedBy.String()
+	}
+	return resp
+}
+
+// FromReportSchedules converts a slice of domain.ReportSchedule to responses
+func FromReportSchedules(schedules []domain.ReportSchedule, loc i18n.Locale) []ReportScheduleResponse {
+	result := make([]ReportScheduleResponse, len(schedules))
+	for i := range schedules {
+		result[i] = FromReportSchedule(&schedules[i], loc)
+	}
+	return result
+}
+
+// ReportScheduleRunResponse represents a schedule run in API responses
+type ReportScheduleRunResponse struct {
+	ID             string `json:"id"`
+	ScheduleID     string `json:"schedule_id"`
+	Status         string `json:"status"`
+	Manual         bool   `json:"manual"`
+	PeriodFrom     string `json:"period_from"`
+	PeriodTo       string `json:"period_to"`
+	FileName       string `json:"file_name,omitempty"`
+	FileSize       int    `json:"file_size"`
+	RecipientCount int    `json:"recipient_count"`
+	Error          string `json:"error,omitempty"`
+	StartedAt      string `json:"started_at"`
+	FinishedAt     string `json:"finished_at,omitempty"`
+	DurationMs     int64  `json:"duration_ms,omitempty"`
+}
+
+// FromReportScheduleRun converts domain.ReportScheduleRun to ReportScheduleRunResponse
+func FromReportScheduleRun(run *domain.ReportScheduleRun) ReportScheduleRunResponse {
+	resp := ReportScheduleRunResponse{
+		ID:             run.ID.String(),
+		ScheduleID:     run.ScheduleID.String(),
+		Status:         string(run.Status),
+		Manual:         run.Manual,
+		PeriodFrom:     run.PeriodFrom.Format("2006-01-02"),
+		PeriodTo:       run.PeriodTo.Format("2006-01-02"),
+		FileName:       run.FileName,
+		FileSize:       run.FileSize,
+		RecipientCount: run.RecipientCount,
+		Error:          run.Error,
+		StartedAt:      run.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if run.FinishedAt != nil {
+		resp.FinishedAt = run.FinishedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.DurationMs = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+	}
+	return resp
+}
+
+// FromReportScheduleRuns converts a slice of domain.ReportScheduleRun to responses
+func FromReportScheduleRuns(runs []domain.ReportScheduleRun) []ReportScheduleRunResponse {
+	result := make([]ReportScheduleRunResponse, len(runs))
+	for i := range runs {
+		result[i] = FromReportScheduleRun(&runs[i])
+	}
+	return result
+}
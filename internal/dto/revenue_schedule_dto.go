@@ -0,0 +1,25 @@
+package dto
+
+// CreateRevenueScheduleRequest represents a request to defer the revenue of a
+// sales tax invoice over its service period
+type CreateRevenueScheduleRequest struct {
+	TaxInvoiceID             string `json:"tax_invoice_id" binding:"required,uuid"`
+	ServiceStart             string `json:"service_start" binding:"required,datetime=2006-01-02"`
+	ServiceEnd               string `json:"service_end" binding:"required,datetime=2006-01-02"`
+	RevenueAccountID         string `json:"revenue_account_id" binding:"required,uuid"`
+	DeferredRevenueAccountID string `json:"deferred_revenue_account_id" binding:"required,uuid"`
+	Amount                   *int64 `json:"amount" binding:"omitempty,min=1"` // defaults to the supply amount of the invoice
+	Description              string `json:"description" binding:"max=500"`
+}
+
+// RecognizeRevenueRequest represents a request to post the revenue
+// recognitions of the company due by a month end
+type RecognizeRevenueRequest struct {
+	PeriodEnd string `json:"period_end" binding:"required,datetime=2006-01-02"`
+}
+
+// DeferredRevenueWaterfallRequest holds the query of the deferred revenue waterfall
+type DeferredRevenueWaterfallRequest struct {
+	AsOf   string `form:"as_of" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	Months int    `form:"months" binding:"omitempty,min=1,max=36"`       // defaults to 12
+}
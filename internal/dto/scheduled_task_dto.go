@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// UpdateScheduledTaskRequest represents a request to change the schedule of a task
+type UpdateScheduledTaskRequest struct {
+	CronExpr *string `json:"cron_expr" binding:"omitempty,max=100"`
+	Timezone *string `json:"timezone" binding:"omitempty,max=50"`
+	CatchUp  *string `json:"catch_up" binding:"omitempty,oneof=skip once all"`
+	Enabled  *bool   `json:"enabled"`
+}
+
+// ScheduledTaskResponse represents a recurring worker task in API responses
+type ScheduledTaskResponse struct {
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	CronExpr        string `json:"cron_expr"`
+	Timezone        string `json:"timezone"`
+	CatchUp         string `json:"catch_up"`
+	Enabled         bool   `json:"enabled"`
+	NextRunAt       string `json:"next_run_at"`
+	LockedBy        string `json:"locked_by,omitempty"`
+	LockedUntil     string `json:"locked_until,omitempty"`
+	LastScheduledAt string `json:"last_scheduled_at,omitempty"`
+	LastStartedAt   string `json:"last_started_at,omitempty"`
+	LastFinishedAt  string `json:"last_finished_at,omitempty"`
+	LastStatus      string `json:"last_status,omitempty"`
+	LastError       string `json:"last_error,omitempty"`
+}
+
+// FromScheduledTask converts domain.ScheduledTask to ScheduledTaskResponse
+func FromScheduledTask(t *domain.ScheduledTask) ScheduledTaskResponse {
+	resp := ScheduledTaskResponse{
+		Name:        t.Name,
+		Description: t.Description,
+		CronExpr:    t.CronExpr,
+		Timezone:    t.Timezone,
+		CatchUp:     string(t.CatchUp),
+		Enabled:     t.Enabled,
+		NextRunAt:   t.NextRunAt.Format(time.RFC3339),
+		LockedBy:    t.LockedBy,
+		LastStatus:  t.LastStatus,
+		LastError:   t.LastError,
+	}
+	if t.LockedUntil != nil {
+		resp.LockedUntil = t.LockedUntil.Format(time.RFC3339)
+	}
+	if t.LastScheduledAt != nil {
+		resp.LastScheduledAt = t.LastScheduledAt.Format(time.RFC3339)
+	}
+	if t.LastStartedAt != nil {
+		resp.LastStartedAt = t.LastStartedAt.Format(time.RFC3339)
+	}
+	if t.LastFinishedAt != nil {
+		resp.LastFinishedAt = t.LastFinishedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// FromScheduledTasks converts a slice of domain.ScheduledTask to responses
+func FromScheduledTasks(tasks []domain.ScheduledTask) []ScheduledTaskResponse {
+	resp := make([]ScheduledTaskResponse, len(tasks))
+	for i := range tasks {
+		resp[i] = FromScheduledTask(&tasks[i])
+	}
+	return resp
+}
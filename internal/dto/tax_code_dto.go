@@ -0,0 +1,186 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateTaxCodeRequest represents the request to create a tax code
+type CreateTaxCodeRequest struct {
+	Code         string  `json:"code" binding:"required,max=20"`
+	Name         string  `json:"name" binding:"required,max=100"`
+	TaxType      string  `json:"tax_type" binding:"required,oneof=output input"`
+	TaxCategory  string  `json:"tax_category" binding:"omitempty,oneof=taxable zero_rated exempt"`
+	Rate         float64 `json:"rate" binding:"min=0,max=100"`
+	IsDeductible *bool   `json:"is_deductible"`
+	VATAccountID string  `json:"vat_account_id" binding:"omitempty,uuid"`
+}
+
+// ToTaxCode converts the request to a domain.TaxCode
+func (r *CreateTaxCodeRequest) ToTaxCode(companyID uuid.UUID) (*domain.TaxCode, error) {
+	taxCode := &domain.TaxCode{
+		Code:         r.Code,
+		Name:         r.Name,
+		TaxType:      domain.TaxType(r.TaxType),
+		TaxCategory:  domain.TaxCategoryTaxable,
+		Rate:         r.Rate,
+		IsDeductible: true,
+		IsActive:     true,
+	}
+	taxCode.CompanyID = companyID
+
+	if r.TaxCategory != "" {
+		taxCode.TaxCategory = domain.TaxCategory(r.TaxCategory)
+	}
+	if r.IsDeductible != nil {
+		taxCode.IsDeductible = *r.IsDeductible
+	}
+	if r.VATAccountID != "" {
+		accountID, err := uuid.Parse(r.VATAccountID)
+		if err != nil {
+			return nil, err
+		}
+		taxCode.VATAccountID = &accountID
+	}
+
+	return taxCode, nil
+}
+
+// UpdateTaxCodeRequest represents the request to update a tax code
+type UpdateTaxCodeRequest struct {
+	Code         *string  `json:"code" binding:"omitempty,max=20"`
+	Name         *string  `json:"name" binding:"omitempty,max=100"`
+	TaxType      *string  `json:"tax_type" binding:"omitempty,oneof=output input"`
+	TaxCategory  *string  `json:"tax_category" binding:"omitempty,oneof=taxable zero_rated exempt"`
+	Rate         *float64 `json:"rate" binding:"omitempty,min=0,max=100"`
+	IsDeductible *bool    `json:"is_deductible"`
+	VATAccountID *string  `json:"vat_account_id" binding:"omitempty"`
+	IsActive     *bool    `json:"is_active"`
+}
+
+// ApplyTo applies the non-nil fields to an existing tax code.
+// An empty vat_account_id clears the VAT account.
+func (r *UpdateTaxCodeRequest) ApplyTo(taxCode *domain.TaxCode) error {
+	if r.Code != nil {
+		taxCode.Code = *r.Code
+	}
+	if r.Name != nil {
+		taxCode.Name = *r.Name
+	}
+	if r.TaxType != nil {
+		taxCode.TaxType = domain.TaxType(*r.TaxType)
+	}
+	if r.TaxCategory != nil {
+		taxCode.TaxCategory = domain.TaxCategory(*r.TaxCategory)
+	}
+	if r.Rate != nil {
+		taxCode.Rate = *r.Rate
+	}
+	if r.IsDeductible != nil {
+		taxCode.IsDeductible = *r.IsDeductible
+	}
+	if r.VATAccountID != nil {
+		if *r.VATAccountID == "" {
+			taxCode.VATAccountID = nil
+		} else {
+			accountID, err := uuid.Parse(*r.VATAccountID)
+			if err != nil {
+				return err
+			}
+			taxCode.VATAccountID = &accountID
+		}
+		taxCode.VATAccount = nil
+	}
+	if r.IsActive != nil {
+		taxCode.IsActive = *r.IsActive
+	}
+	return nil
+}
+
+// TaxCodeResponse represents a tax code in API responses
+type TaxCodeResponse struct {
+	ID             string  `json:"id"`
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	TaxType        string  `json:"tax_type"`
+	TaxCategory    string  `json:"tax_category"`
+	Rate           float64 `json:"rate"`
+	IsDeductible   bool    `json:"is_deductible"`
+	VATAccountID   string  `json:"vat_account_id,omitempty"`
+	VATAccountCode string  `json:"vat_account_code,omitempty"`
+	VATAccountName string  `json:"vat_account_name,omitempty"`
+	IsActive       bool    `json:"is_active"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+// FromTaxCode converts domain.TaxCode to TaxCodeResponse
+func FromTaxCode(taxCode *domain.TaxCode) TaxCodeResponse {
+	resp := TaxCodeResponse{
+		ID:           taxCode.ID.String(),
+		Code:         taxCode.Code,
+		Name:         taxCode.Name,
+		TaxType:      string(taxCode.TaxType),
+		TaxCategory:  string(taxCode.TaxCategory),
+		Rate:         taxCode.Rate,
+		IsDeductible: taxCode.IsDeductible,
+		IsActive:     taxCode.IsActive,
+		CreatedAt:    taxCode.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    taxCode.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if taxCode.VATAccountID != nil {
+		resp.VATAccountID = taxCode.VATAccountID.String()
+		if taxCode.VATAccount != nil {
+			resp.VATAccountCode = taxCode.VATAccount.Code
+			resp.VATAccountName = taxCode.VATAccount.Name
+		}
+	}
+	return resp
+}
+
+// FromTaxCodes converts a slice of domain.TaxCode to []TaxCodeResponse
+func FromTaxCodes(taxCodes []domain.TaxCode) []TaxCodeResponse {
+	responses := make([]TaxCodeResponse, len(taxCodes))
+	for i := range taxCodes {
+		responses[i] = FromTaxCode(&taxCodes[i])
+	}
+	return responses
+}
+
+// VATReturnRequest represents the query for the VAT return report
+type VATReturnRequest struct {
+	FromDate string `form:"from_date" binding:"required"`
+	ToDate   string `form:"to_date" binding:"required"`
+}
+
+// VATReturnResponse represents the VAT return report
+type VATReturnResponse struct {
+	FromDate                 string                  `json:"from_date"`
+	ToDate                   string                  `json:"to_date"`
+	Items                    []domain.VATSummaryItem `json:"items"`
+	OutputSupplyAmount       float64                 `json:"output_supply_amount"`
+	OutputTaxAmount          float64                 `json:"output_tax_amount"`
+	InputSupplyAmount        float64                 `json:"input_supply_amount"`
+	DeductibleInputTaxAmount float64                 `json:"deductible_input_tax_amount"`
+	NonDeductibleInputTax    float64                 `json:"non_deductible_input_tax"`
+	PayableTaxAmount         float64                 `json:"payable_tax_amount"`
+}
+
+// FromVATReturn converts domain.VATReturn to VATReturnResponse
+func FromVATReturn(r *domain.VATReturn) VATReturnResponse {
+	items := r.Items
+	if items == nil {
+		items = []domain.VATSummaryItem{}
+	}
+	return VATReturnResponse{
+		FromDate:                 r.FromDate.Format("2006-01-02"),
+		ToDate:                   r.ToDate.Format("2006-01-02"),
+		Items:                    items,
+		OutputSupplyAmount:       r.OutputSupplyAmount,
+		OutputTaxAmount:          r.OutputTaxAmount,
+		InputSupplyAmount:        r.InputSupplyAmount,
+		DeductibleInputTaxAmount: r.DeductibleInputTaxAmount,
+		NonDeductibleInputTax:    r.NonDeductibleInputTax,
+		PayableTaxAmount:         r.PayableTaxAmount,
+	}
+}
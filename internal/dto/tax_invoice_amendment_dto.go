@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// AmendTaxInvoiceRequest represents a request to amend an issued tax invoice
+type AmendTaxInvoiceRequest struct {
+	// ReasonCode is the NTS amendment reason (1-6)
+	ReasonCode   int                          `json:"reason_code" binding:"required,min=1,max=6"`
+	IssueDate    string                       `json:"issue_date" binding:"omitempty,datetime=2006-01-02"`
+	SupplyAmount int64                        `json:"supply_amount"`
+	TaxAmount    *int64                       `json:"tax_amount"`
+	Correction   *TaxInvoiceCorrectionRequest `json:"correction"`
+	Remarks      string                       `json:"remarks" binding:"max=500"`
+}
+
+// TaxInvoiceCorrectionRequest represents the corrected values of an entry error
+type TaxInvoiceCorrectionRequest struct {
+	IssueDate           string `json:"issue_date" binding:"omitempty,datetime=2006-01-02"`
+	BuyerBusinessNumber string `json:"buyer_business_number" binding:"omitempty,len=10,numeric"`
+	BuyerName           string `json:"buyer_name" binding:"max=200"`
+	BuyerCEOName        string `json:"buyer_ceo_name" binding:"max=100"`
+	BuyerAddress        string `json:"buyer_address" binding:"max=500"`
+	BuyerEmail          string `json:"buyer_email" binding:"omitempty,email"`
+	SupplyAmount        *int64 `json:"supply_amount"`
+	TaxAmount           *int64 `json:"tax_amount"`
+}
+
+// ToDomain converts the request to domain.TaxInvoiceAmendment; dates are validated by binding
+func (r *AmendTaxInvoiceRequest) ToDomain() *domain.TaxInvoiceAmendment {
+	amendment := &domain.TaxInvoiceAmendment{
+		Reason:       domain.TaxInvoiceAmendmentReason(r.ReasonCode),
+		IssueDate:    optionalDate(r.IssueDate),
+		SupplyAmount: r.SupplyAmount,
+		TaxAmount:    r.TaxAmount,
+		Remarks:      r.Remarks,
+	}
+	if c := r.Correction; c != nil {
+		amendment.Correction = &domain.TaxInvoiceCorrection{
+			IssueDate:           optionalDate(c.IssueDate),
+			BuyerBusinessNumber: c.BuyerBusinessNumber,
+			BuyerName:           c.BuyerName,
+			BuyerCEOName:        c.BuyerCEOName,
+			BuyerAddress:        c.BuyerAddress,
+			BuyerEmail:          c.BuyerEmail,
+			SupplyAmount:        c.SupplyAmount,
+			TaxAmount:           c.TaxAmount,
+		}
+	}
+	return amendment
+}
+
+func optionalDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// BulkIssueTaxInvoicesRequest represents a request to issue many draft invoices
+type BulkIssueTaxInvoicesRequest struct {
+	InvoiceIDs []string `json:"invoice_ids" binding:"required,min=1,dive,uuid"`
+}
+
+// TaxInvoiceBulkIssueResponse represents a bulk issuance and the result of each invoice
+type TaxInvoiceBulkIssueResponse struct {
+	ID             string                            `json:"id"`
+	Status         string                            `json:"status"`
+	TotalCount     int                               `json:"total_count"`
+	SucceededCount int                               `json:"succeeded_count"`
+	FailedCount    int                               `json:"failed_count"`
+	Attempts       int                               `json:"attempts"`
+	RequestedBy    string                            `json:"requested_by,omitempty"`
+	CreatedAt      string                            `json:"created_at"`
+	CompletedAt    string                            `json:"completed_at,omitempty"`
+	Items          []TaxInvoiceBulkIssueItemResponse `json:"items"`
+}
+
+// TaxInvoiceBulkIssueItemResponse represents the result of one invoice
+type TaxInvoiceBulkIssueItemResponse struct {
+	TaxInvoiceID     string `json:"tax_invoice_id"`
+	LineNo           int    `json:"line_no"`
+	Status           string `json:"status"`
+	NTSConfirmNumber string `json:"nts_confirm_number,omitempty"`
+	ASPInvoiceID     string `json:"asp_invoice_id,omitempty"`
+	ErrorCode        string `json:"error_code,omitempty"`
+	ErrorMessage     string `json:"error_message,omitempty"`
+	Attempts         int    `json:"attempts"`
+}
+
+// FromTaxInvoiceBulkIssue converts domain.TaxInvoiceBulkIssue to TaxInvoiceBulkIssueResponse
+func FromTaxInvoiceBulkIssue(b *domain.TaxInvoiceBulkIssue) TaxInvoiceBulkIssueResponse {
+	resp := TaxInvoiceBulkIssueResponse{
+		ID:             b.ID.String(),
+		Status:         string(b.Status),
+		TotalCount:     b.TotalCount,
+		SucceededCount: b.SucceededCount,
+		FailedCount:    b.FailedCount,
+		Attempts:       b.Attempts,
+		CreatedAt:      b.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Items:          make([]TaxInvoiceBulkIssueItemResponse, len(b.Items)),
+	}
+	if b.RequestedBy != nil {
+		resp.RequestedBy = b.RequestedBy.String()
+	}
+	if b.CompletedAt != nil {
+		resp.CompletedAt = b.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	for i, item := range b.Items {
+		resp.Items[i] = TaxInvoiceBulkIssueItemResponse{
+			TaxInvoiceID:     item.TaxInvoiceID.String(),
+			LineNo:           item.LineNo,
+			Status:           string(item.Status),
+			NTSConfirmNumber: item.NTSConfirmNumber,
+			ASPInvoiceID:     item.ASPInvoiceID,
+			ErrorCode:        item.ErrorCode,
+			ErrorMessage:     item.ErrorMessage,
+			Attempts:         item.Attempts,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// SendTaxInvoiceRequest represents a request to email a tax invoice to the buyer
+type SendTaxInvoiceRequest struct {
+	// To overrides the buyer email of the invoice
+	To      string   `json:"to" binding:"omitempty,email,max=255"`
+	Cc      []string `json:"cc" binding:"max=10,dive,email,max=255"`
+	Message string   `json:"message" binding:"max=1000"`
+}
+
+// EmailBounceRequest represents a bounce notice from the mail provider
+type EmailBounceRequest struct {
+	MessageID string     `json:"message_id" binding:"required,max=255"`
+	Recipient string     `json:"recipient" binding:"max=255"`
+	Reason    string     `json:"reason" binding:"max=1000"`
+	BouncedAt *time.Time `json:"bounced_at"`
+}
+
+// ToDomain converts the request to domain.EmailBounce
+func (r *EmailBounceRequest) ToDomain() *domain.EmailBounce {
+	bounce := &domain.EmailBounce{
+		MessageID: r.MessageID,
+		Recipient: r.Recipient,
+		Reason:    r.Reason,
+	}
+	if r.BouncedAt != nil {
+		bounce.BouncedAt = *r.BouncedAt
+	}
+	return bounce
+}
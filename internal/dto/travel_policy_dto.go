@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PerDiemRateRequest represents a per diem rate; an empty destination or grade matches any
+type PerDiemRateRequest struct {
+	Destination string `json:"destination" binding:"max=50"`
+	Grade       string `json:"grade" binding:"max=50"`
+	DailyAmount int64  `json:"daily_amount" binding:"required,min=1"`
+}
+
+// TravelPolicyRequest holds the rules of a travel policy
+type TravelPolicyRequest struct {
+	Name             string               `json:"name" binding:"required,max=100"`
+	PerDiemRates     []PerDiemRateRequest `json:"per_diem_rates" binding:"omitempty,max=100,dive"`
+	ReceiptThreshold int64                `json:"receipt_threshold" binding:"min=0"` // 0 for no receipt requirement
+	MileageRate      int64                `json:"mileage_rate" binding:"min=0"`      // per km, 0 when mileage is not paid
+}
+
+// Rates converts the per diem rates to domain.PerDiemRate
+func (r *TravelPolicyRequest) Rates() []domain.PerDiemRate {
+	rates := make([]domain.PerDiemRate, len(r.PerDiemRates))
+	for i, rate := range r.PerDiemRates {
+		rates[i] = domain.PerDiemRate{Destination: rate.Destination, Grade: rate.Grade, DailyAmount: rate.DailyAmount}
+	}
+	return rates
+}
+
+// CreateTravelPolicyRequest represents a request to create a travel policy
+type CreateTravelPolicyRequest struct {
+	TravelPolicyRequest
+	DepartmentID string `json:"department_id" binding:"omitempty,uuid"` // empty for the whole company
+}
+
+// ToDomain converts the request to domain.TravelPolicy; identifiers are validated by binding
+func (r *CreateTravelPolicyRequest) ToDomain(companyID, userID uuid.UUID) *domain.TravelPolicy {
+	policy := &domain.TravelPolicy{
+		TenantModel:      domain.TenantModel{CompanyID: companyID},
+		Name:             r.Name,
+		PerDiemRates:     r.Rates(),
+		ReceiptThreshold: r.ReceiptThreshold,
+		MileageRate:      r.MileageRate,
+		CreatedBy:        &userID,
+	}
+	if r.DepartmentID != "" {
+		departmentID := uuid.MustParse(r.DepartmentID)
+		policy.DepartmentID = &departmentID
+	}
+	return policy
+}
+
+// UpdateTravelPolicyRequest represents a request to update a travel policy
+type UpdateTravelPolicyRequest struct {
+	TravelPolicyRequest
+	IsActive *bool `json:"is_active" binding:"required"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// UsageMetricResponse is the usage of a metric in a month
+type UsageMetricResponse struct {
+	Metric       domain.UsageMetric `json:"metric"`
+	Label        string             `json:"label"`
+	Quantity     int64              `json:"quantity"`
+	AggregatedAt *time.Time         `json:"aggregated_at,omitempty"`
+	ExportedAt   *time.Time         `json:"exported_at,omitempty"`
+}
+
+// UsageMonthResponse is the usage of the company in a month
+type UsageMonthResponse struct {
+	Period  string                `json:"period"` // YYYY-MM
+	Metrics []UsageMetricResponse `json:"metrics"`
+}
+
+// FromUsageMonths converts monthly usage to responses, with every metric
+// listed and zero for metrics without usage
+func FromUsageMonths(months []domain.UsageMonth, loc i18n.Locale) []UsageMonthResponse {
+	result := make([]UsageMonthResponse, len(months))
+	for i, month := range months {
+		resp := UsageMonthResponse{
+			Period:  month.Period.Format("2006-01"),
+			Metrics: make([]UsageMetricResponse, 0, len(domain.UsageMetrics)),
+		}
+		for _, metric := range domain.UsageMetrics {
+			m := UsageMetricResponse{Metric: metric, Label: metric.LocalizedLabel(loc)}
+			if record, ok := month.Records[metric]; ok {
+				m.Quantity = record.Quantity
+				m.AggregatedAt = &record.UpdatedAt
+				m.ExportedAt = record.ExportedAt
+			}
+			resp.Metrics = append(resp.Metrics, m)
+		}
+		result[i] = resp
+	}
+	return result
+}
@@ -1,32 +1,44 @@
 package dto
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
 
 // UserResponse represents a user in API responses
 type UserResponse struct {
-	ID          string  `json:"id"`
-	Email       string  `json:"email"`
-	Name        string  `json:"name"`
-	Role        string  `json:"role"`
-	Status      string  `json:"status"`
-	LastLoginAt *string `json:"last_login_at,omitempty"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID                 string  `json:"id"`
+	Email              string  `json:"email"`
+	Name               string  `json:"name"`
+	Role               string  `json:"role"`
+	Grade              string  `json:"grade,omitempty"`
+	Status             string  `json:"status"`
+	LastLoginAt        *string `json:"last_login_at"`
+	EmailVerified      bool    `json:"email_verified"`
+	MustChangePassword bool    `json:"must_change_password"`
+	HasSigningPIN      bool    `json:"has_signing_pin"`
+	SigningPINLocked   bool    `json:"signing_pin_locked"`
+	CreatedAt          string  `json:"created_at"`
+	UpdatedAt          string  `json:"updated_at"`
 }
 
 // FromUser converts domain.User to UserResponse
 func FromUser(user *domain.User) UserResponse {
 	resp := UserResponse{
-		ID:        user.ID.String(),
-		Email:     user.Email,
-		Name:      user.Name,
-		Role:      string(user.Role),
-		Status:    string(user.Status),
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:                 user.ID.String(),
+		Email:              user.Email,
+		Name:               user.Name,
+		Role:               string(user.Role),
+		Grade:              user.Grade,
+		Status:             string(user.Status),
+		EmailVerified:      user.IsEmailVerified(),
+		MustChangePassword: user.MustChangePassword,
+		HasSigningPIN:      user.HasSigningPIN(),
+		SigningPINLocked:   user.IsSigningPINLocked(),
+		CreatedAt:          user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:          user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	if user.LastLoginAt != nil {
@@ -52,6 +64,7 @@ type CreateUserRequest struct {
 	Password string `json:"password" binding:"required,min=8,max=100"`
 	Name     string `json:"name" binding:"required,max=100"`
 	Role     string `json:"role" binding:"omitempty,oneof=admin user viewer"`
+	Grade    string `json:"grade" binding:"max=50"`
 }
 
 // ToUser converts CreateUserRequest to domain.User
@@ -60,14 +73,20 @@ func (r *CreateUserRequest) ToUser(companyID uuid.UUID) (*domain.User, error) {
 	if !role.IsValid() {
 		role = domain.UserRoleUser
 	}
-	return domain.NewUser(companyID, r.Email, r.Password, r.Name, role)
+	user, err := domain.NewUser(companyID, r.Email, r.Password, r.Name, role)
+	if err != nil {
+		return nil, err
+	}
+	user.Grade = strings.TrimSpace(r.Grade)
+	return user, nil
 }
 
 // UpdateUserRequest represents the request to update a user
 type UpdateUserRequest struct {
-	Email string `json:"email" binding:"required,email,max=255"`
-	Name  string `json:"name" binding:"required,max=100"`
-	Role  string `json:"role" binding:"omitempty,oneof=admin user viewer"`
+	Email string  `json:"email" binding:"required,email,max=255"`
+	Name  string  `json:"name" binding:"required,max=100"`
+	Role  string  `json:"role" binding:"omitempty,oneof=admin user viewer"`
+	Grade *string `json:"grade" binding:"omitempty,max=50"` // nil leaves the grade unchanged, empty clears it
 }
 
 // ApplyTo applies the update to an existing user
@@ -80,6 +99,21 @@ func (r *UpdateUserRequest) ApplyTo(user *domain.User) {
 			user.Role = role
 		}
 	}
+	if r.Grade != nil {
+		user.Grade = strings.TrimSpace(*r.Grade)
+	}
+}
+
+// AssignRoleRequest represents the request to change a user's role
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin user viewer"`
+}
+
+// PasswordResetResponse carries the temporary password of a forced reset.
+// It is shown once to the administrator to hand over to the user.
+type PasswordResetResponse struct {
+	TemporaryPassword  string `json:"temporary_password"`
+	MustChangePassword bool   `json:"must_change_password"`
 }
 
 // ChangePasswordRequest represents the request to change a user's password
@@ -88,6 +122,12 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required,min=8,max=100"`
 }
 
+// SetSigningPINRequest represents the request to set the PIN confirming approval signatures
+type SetSigningPINRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	PIN             string `json:"pin" binding:"required,numeric,min=4,max=8"`
+}
+
 // UserStatsResponse represents user statistics
 type UserStatsResponse struct {
 	TotalCount    int64 `json:"total_count"`
@@ -0,0 +1,7 @@
+package dto
+
+// ReviewAnomalyFlagRequest represents a controller's review of an anomaly flag
+type ReviewAnomalyFlagRequest struct {
+	Status string `json:"status" binding:"required,oneof=dismissed confirmed"`
+	Note   string `json:"note,omitempty" binding:"max=500"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// VoucherAttachmentResponse represents a voucher attachment without its content.
+// Attachments with a thumbnail have a preview at .../attachments/{id}/thumbnail.
+type VoucherAttachmentResponse struct {
+	ID           string `json:"id"`
+	VoucherID    string `json:"voucher_id"`
+	FileName     string `json:"file_name"`
+	FileSize     int64  `json:"file_size"`
+	FileType     string `json:"file_type,omitempty"`
+	HasThumbnail bool   `json:"has_thumbnail"`
+	ScanStatus   string `json:"scan_status"`
+	ScannedAt    string `json:"scanned_at,omitempty"`
+	UploadedAt   string `json:"uploaded_at"`
+	UploadedBy   string `json:"uploaded_by,omitempty"`
+}
+
+// FromVoucherAttachment converts domain.VoucherAttachment to VoucherAttachmentResponse
+func FromVoucherAttachment(a *domain.VoucherAttachment) VoucherAttachmentResponse {
+	resp := VoucherAttachmentResponse{
+		ID:           a.ID.String(),
+		VoucherID:    a.VoucherID.String(),
+		FileName:     a.FileName,
+		FileSize:     a.FileSize,
+		FileType:     a.FileType,
+		HasThumbnail: a.HasThumbnail,
+		ScanStatus:   string(a.ScanStatus),
+		UploadedAt:   a.UploadedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if a.ScannedAt != nil {
+		resp.ScannedAt = a.ScannedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if a.UploadedBy != nil {
+		resp.UploadedBy = a.UploadedBy.String()
+	}
+	return resp
+}
+
+// FromVoucherAttachments converts a slice of domain.VoucherAttachment
+func FromVoucherAttachments(attachments []domain.VoucherAttachment) []VoucherAttachmentResponse {
+	responses := make([]VoucherAttachmentResponse, len(attachments))
+	for i := range attachments {
+		responses[i] = FromVoucherAttachment(&attachments[i])
+	}
+	return responses
+}
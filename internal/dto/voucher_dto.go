@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
 )
 
 // CreateVoucherRequest represents the request to create a voucher
@@ -15,6 +16,8 @@ type CreateVoucherRequest struct {
 	Description   string                      `json:"description,omitempty" binding:"max=500"`
 	ReferenceType string                      `json:"reference_type,omitempty" binding:"max=50"`
 	ReferenceID   string                      `json:"reference_id,omitempty" binding:"omitempty,uuid"`
+	AutoReverse   bool                        `json:"auto_reverse,omitempty"`
+	Tags          []string                    `json:"tags,omitempty" binding:"max=10,dive,max=50"`
 	Entries       []CreateVoucherEntryRequest `json:"entries" binding:"required,min=1,dive"`
 }
 
@@ -28,6 +31,10 @@ type CreateVoucherEntryRequest struct {
 	DepartmentID string  `json:"department_id,omitempty" binding:"omitempty,uuid"`
 	ProjectID    string  `json:"project_id,omitempty" binding:"omitempty,uuid"`
 	CostCenterID string  `json:"cost_center_id,omitempty" binding:"omitempty,uuid"`
+	// Bank account paid from, on the credit entry of a payment from bank
+	BankAccountID string `json:"bank_account_id,omitempty" binding:"omitempty,uuid"`
+	// Amount of an entry with a tax code is VAT-inclusive
+	TaxCodeID string `json:"tax_code_id,omitempty" binding:"omitempty,uuid"`
 }
 
 // ToVoucher converts CreateVoucherRequest to domain.Voucher
@@ -45,6 +52,8 @@ func (r *CreateVoucherRequest) ToVoucher(companyID, userID uuid.UUID) (*domain.V
 		VoucherType:   domain.VoucherType(r.VoucherType),
 		Description:   r.Description,
 		ReferenceType: r.ReferenceType,
+		AutoReverse:   r.AutoReverse,
+		Tags:          r.Tags,
 		CreatedBy:     &userID,
 	}
 
@@ -115,15 +124,83 @@ func (r *CreateVoucherEntryRequest) ToEntry(companyID uuid.UUID) (*domain.Vouche
 		entry.CostCenterID = &ccID
 	}
 
+	if r.BankAccountID != "" {
+		bankID, err := uuid.Parse(r.BankAccountID)
+		if err != nil {
+			return nil, err
+		}
+		entry.BankAccountID = &bankID
+	}
+
+	if r.TaxCodeID != "" {
+		taxCodeID, err := uuid.Parse(r.TaxCodeID)
+		if err != nil {
+			return nil, err
+		}
+		entry.TaxCodeID = &taxCodeID
+	}
+
 	return entry, nil
 }
 
+// QuickVoucherRequest represents the request to create a voucher from entry
+// rows as typed on the keyboard, with codes in place of IDs
+type QuickVoucherRequest struct {
+	VoucherDate string                     `json:"voucher_date" binding:"required"`
+	VoucherType string                     `json:"voucher_type" binding:"required,oneof=general sales purchase payment receipt adjustment closing"`
+	Description string                     `json:"description,omitempty" binding:"max=500"`
+	Tags        []string                   `json:"tags,omitempty" binding:"max=10,dive,max=50"`
+	Entries     []QuickVoucherEntryRequest `json:"entries" binding:"required,min=1,dive"`
+}
+
+// QuickVoucherEntryRequest represents an entry row of a quick voucher
+type QuickVoucherEntryRequest struct {
+	AccountCode string `json:"account_code" binding:"required,max=50"`
+	Side        string `json:"side" binding:"required,oneof=debit credit"`
+	// Amount left out on one row is the difference that balances the voucher;
+	// with a tax code it is VAT-inclusive
+	Amount         float64 `json:"amount,omitempty" binding:"min=0"`
+	Description    string  `json:"description,omitempty" binding:"max=200"`
+	PartnerCode    string  `json:"partner_code,omitempty" binding:"max=50"`
+	DepartmentCode string  `json:"department_code,omitempty" binding:"max=50"`
+	ProjectCode    string  `json:"project_code,omitempty" binding:"max=50"`
+	TaxCode        string  `json:"tax_code,omitempty" binding:"max=50"`
+}
+
+// ToVoucher converts QuickVoucherRequest to a domain.Voucher without entries
+// and the entry rows
+func (r *QuickVoucherRequest) ToVoucher(companyID, userID uuid.UUID) (*domain.Voucher, []domain.QuickEntry, error) {
+	header := CreateVoucherRequest{VoucherDate: r.VoucherDate, VoucherType: r.VoucherType, Description: r.Description, Tags: r.Tags}
+	voucher, err := header.ToVoucher(companyID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]domain.QuickEntry, len(r.Entries))
+	for i, e := range r.Entries {
+		entries[i] = domain.QuickEntry{
+			AccountCode:    e.AccountCode,
+			Side:           domain.AccountNature(e.Side),
+			Amount:         e.Amount,
+			Description:    e.Description,
+			PartnerCode:    e.PartnerCode,
+			DepartmentCode: e.DepartmentCode,
+			ProjectCode:    e.ProjectCode,
+			TaxCode:        e.TaxCode,
+		}
+	}
+	return voucher, entries, nil
+}
+
 // UpdateVoucherRequest represents the request to update a voucher
 type UpdateVoucherRequest struct {
 	VoucherDate   string                      `json:"voucher_date" binding:"required"`
 	Description   string                      `json:"description,omitempty" binding:"max=500"`
 	ReferenceType string                      `json:"reference_type,omitempty" binding:"max=50"`
 	ReferenceID   string                      `json:"reference_id,omitempty" binding:"omitempty,uuid"`
+	AutoReverse   bool                        `json:"auto_reverse,omitempty"`
+	// Tags replace the voucher's tags when given
+	Tags          []string                    `json:"tags" binding:"omitempty,max=10,dive,max=50"`
 	Entries       []CreateVoucherEntryRequest `json:"entries" binding:"required,min=1,dive"`
 }
 
@@ -142,15 +219,67 @@ type VoucherResponse struct {
 	ReferenceType   string                 `json:"reference_type,omitempty"`
 	ReferenceID     string                 `json:"reference_id,omitempty"`
 	AttachmentCount int                    `json:"attachment_count"`
+	Tags            []string               `json:"tags"`
 	IsReversal      bool                   `json:"is_reversal"`
 	ReversalOfID    string                 `json:"reversal_of_id,omitempty"`
 	ReversedByID    string                 `json:"reversed_by_id,omitempty"`
+	ReversedAmount  float64                `json:"reversed_amount,omitempty"`
+	CorrectionOfID  string                 `json:"correction_of_id,omitempty"`
+	CorrectedByID   string                 `json:"corrected_by_id,omitempty"`
+	AutoReverse     bool                   `json:"auto_reverse"`
 	SubmittedAt     string                 `json:"submitted_at,omitempty"`
 	ApprovedAt      string                 `json:"approved_at,omitempty"`
 	PostedAt        string                 `json:"posted_at,omitempty"`
+	RejectedAt      string                 `json:"rejected_at,omitempty"`
+	RejectionReason string                 `json:"rejection_reason,omitempty"`
+	ApprovalExemptReason string            `json:"approval_exempt_reason,omitempty"`
+	ScheduledPostDate string               `json:"scheduled_post_date,omitempty"`
 	Entries         []VoucherEntryResponse `json:"entries,omitempty"`
 	CreatedAt       string                 `json:"created_at"`
 	UpdatedAt       string                 `json:"updated_at"`
+
+	// Workflow users; names are set in the voucher detail
+	CreatedBy   *VoucherUserResponse `json:"created_by,omitempty"`
+	SubmittedBy *VoucherUserResponse `json:"submitted_by,omitempty"`
+	ApprovedBy  *VoucherUserResponse `json:"approved_by,omitempty"`
+	RejectedBy  *VoucherUserResponse `json:"rejected_by,omitempty"`
+	PostedBy    *VoucherUserResponse `json:"posted_by,omitempty"`
+
+	// Voucher detail only
+	ReversalOf    *VoucherSummaryResponse       `json:"reversal_of,omitempty"`
+	ReversedBy    *VoucherSummaryResponse       `json:"reversed_by,omitempty"`
+	CorrectionOf  *VoucherSummaryResponse       `json:"correction_of,omitempty"`
+	CorrectedBy   *VoucherSummaryResponse       `json:"corrected_by,omitempty"`
+	StatusHistory []VoucherStatusChangeResponse `json:"status_history,omitempty"`
+
+	// Creation only, when the duplicate check warns
+	SuspectedDuplicates []VoucherSummaryResponse `json:"suspected_duplicates,omitempty"`
+}
+
+// VoucherUserResponse identifies the user of a workflow step
+type VoucherUserResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// VoucherSummaryResponse is a linked voucher in the voucher detail
+type VoucherSummaryResponse struct {
+	ID          string  `json:"id"`
+	VoucherNo   string  `json:"voucher_no"`
+	VoucherDate string  `json:"voucher_date"`
+	Status      string  `json:"status"`
+	StatusLabel string  `json:"status_label"`
+	TotalDebit  float64 `json:"total_debit"`
+}
+
+// VoucherStatusChangeResponse is one step of the voucher status timeline
+type VoucherStatusChangeResponse struct {
+	FromStatus  string               `json:"from_status,omitempty"`
+	ToStatus    string               `json:"to_status"`
+	StatusLabel string               `json:"status_label"`
+	ChangedBy   *VoucherUserResponse `json:"changed_by,omitempty"`
+	Reason      string               `json:"reason,omitempty"`
+	ChangedAt   string               `json:"changed_at"`
 }
 
 // VoucherEntryResponse represents the response for a voucher entry
@@ -169,28 +298,39 @@ type VoucherEntryResponse struct {
 	DepartmentName string         `json:"department_name,omitempty"`
 	ProjectID    string           `json:"project_id,omitempty"`
 	CostCenterID string           `json:"cost_center_id,omitempty"`
+	BankAccountID string          `json:"bank_account_id,omitempty"`
+	TaxCodeID    string           `json:"tax_code_id,omitempty"`
+	TaxAmount    float64          `json:"tax_amount,omitempty"`
+	IsTaxLine    bool             `json:"is_tax_line,omitempty"`
+	ReversedAmount float64        `json:"reversed_amount,omitempty"`
 }
 
-// FromVoucher converts domain.Voucher to VoucherResponse
-func FromVoucher(voucher *domain.Voucher) VoucherResponse {
+// FromVoucher converts domain.Voucher to VoucherResponse with labels in the locale
+func FromVoucher(voucher *domain.Voucher, loc i18n.Locale) VoucherResponse {
 	resp := VoucherResponse{
 		ID:              voucher.ID.String(),
 		VoucherNo:       voucher.VoucherNo,
 		VoucherDate:     voucher.VoucherDate.Format("2006-01-02"),
 		VoucherType:     string(voucher.VoucherType),
-		VoucherTypeLabel: voucher.GetTypeLabel(),
+		VoucherTypeLabel: voucher.LocalizedTypeLabel(loc),
 		Status:          string(voucher.Status),
-		StatusLabel:     voucher.GetStatusLabel(),
+		StatusLabel:     voucher.LocalizedStatusLabel(loc),
 		TotalDebit:      voucher.TotalDebit,
 		TotalCredit:     voucher.TotalCredit,
 		Description:     voucher.Description,
 		ReferenceType:   voucher.ReferenceType,
 		AttachmentCount: voucher.AttachmentCount,
+		Tags:            voucher.Tags,
 		IsReversal:      voucher.IsReversal,
+		ReversedAmount:  voucher.ReversedAmount,
+		AutoReverse:     voucher.AutoReverse,
 		CreatedAt:       voucher.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       voucher.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
+	if resp.Tags == nil {
+		resp.Tags = []string{}
+	}
 	if voucher.ReferenceID != nil {
 		resp.ReferenceID = voucher.ReferenceID.String()
 	}
@@ -200,6 +340,12 @@ func FromVoucher(voucher *domain.Voucher) VoucherResponse {
 	if voucher.ReversedByID != nil {
 		resp.ReversedByID = voucher.ReversedByID.String()
 	}
+	if voucher.CorrectionOfID != nil {
+		resp.CorrectionOfID = voucher.CorrectionOfID.String()
+	}
+	if voucher.CorrectedByID != nil {
+		resp.CorrectedByID = voucher.CorrectedByID.String()
+	}
 	if voucher.SubmittedAt != nil {
 		resp.SubmittedAt = voucher.SubmittedAt.Format("2006-01-02T15:04:05Z07:00")
 	}
@@ -208,6 +354,48 @@ func FromVoucher(voucher *domain.Voucher) VoucherResponse {
 	}
 	if voucher.PostedAt != nil {
 		resp.PostedAt = voucher.PostedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.ApprovalExemptReason = voucher.ApprovalExemptReason
+	}
+	if voucher.IsScheduled() {
+		resp.ScheduledPostDate = voucher.ScheduledPostDate.Format("2006-01-02")
+	}
+	if voucher.RejectedAt != nil {
+		resp.RejectedAt = voucher.RejectedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.RejectionReason = voucher.RejectionReason
+	}
+
+	resp.CreatedBy = fromVoucherUser(voucher.CreatedBy, voucher.Creator)
+	resp.SubmittedBy = fromVoucherUser(voucher.SubmittedBy, voucher.Submitter)
+	resp.ApprovedBy = fromVoucherUser(voucher.ApprovedBy, voucher.Approver)
+	resp.RejectedBy = fromVoucherUser(voucher.RejectedBy, voucher.Rejecter)
+	resp.PostedBy = fromVoucherUser(voucher.PostedBy, voucher.Poster)
+
+	if voucher.ReversalOf != nil {
+		resp.ReversalOf = fromVoucherSummary(voucher.ReversalOf, loc)
+	}
+	if voucher.ReversedBy != nil {
+		resp.ReversedBy = fromVoucherSummary(voucher.ReversedBy, loc)
+	}
+	if voucher.CorrectionOf != nil {
+		resp.CorrectionOf = fromVoucherSummary(voucher.CorrectionOf, loc)
+	}
+	if voucher.CorrectedBy != nil {
+		resp.CorrectedBy = fromVoucherSummary(voucher.CorrectedBy, loc)
+	}
+	for i := range voucher.StatusHistory {
+		change := &voucher.StatusHistory[i]
+		resp.StatusHistory = append(resp.StatusHistory, VoucherStatusChangeResponse{
+			FromStatus:  string(change.FromStatus),
+			ToStatus:    string(change.ToStatus),
+			StatusLabel: change.LocalizedStatusLabel(loc),
+			ChangedBy:   fromVoucherUser(change.ChangedBy, change.User),
+			Reason:      change.Reason,
+			ChangedAt:   change.ChangedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	if len(voucher.SuspectedDuplicates) > 0 {
+		resp.SuspectedDuplicates = FromVoucherSummaries(voucher.SuspectedDuplicates, loc)
 	}
 
 	// Convert entries
@@ -218,6 +406,38 @@ func FromVoucher(voucher *domain.Voucher) VoucherResponse {
 	return resp
 }
 
+// FromVoucherSummaries converts vouchers to VoucherSummaryResponses
+func FromVoucherSummaries(vouchers []domain.Voucher, loc i18n.Locale) []VoucherSummaryResponse {
+	resp := make([]VoucherSummaryResponse, len(vouchers))
+	for i := range vouchers {
+		resp[i] = *fromVoucherSummary(&vouchers[i], loc)
+	}
+	return resp
+}
+
+// fromVoucherUser returns the user of a workflow step, named if the user was loaded
+func fromVoucherUser(id *uuid.UUID, user *domain.User) *VoucherUserResponse {
+	if id == nil {
+		return nil
+	}
+	resp := &VoucherUserResponse{ID: id.String()}
+	if user != nil {
+		resp.Name = user.Name
+	}
+	return resp
+}
+
+func fromVoucherSummary(voucher *domain.Voucher, loc i18n.Locale) *VoucherSummaryResponse {
+	return &VoucherSummaryResponse{
+		ID:          voucher.ID.String(),
+		VoucherNo:   voucher.VoucherNo,
+		VoucherDate: voucher.VoucherDate.Format("2006-01-02"),
+		Status:      string(voucher.Status),
+		StatusLabel: voucher.LocalizedStatusLabel(loc),
+		TotalDebit:  voucher.TotalDebit,
+	}
+}
+
 // FromVoucherEntry converts domain.VoucherEntry to VoucherEntryResponse
 func FromVoucherEntry(entry *domain.VoucherEntry) VoucherEntryResponse {
 	resp := VoucherEntryResponse{
@@ -227,6 +447,9 @@ func FromVoucherEntry(entry *domain.VoucherEntry) VoucherEntryResponse {
 		DebitAmount:  entry.DebitAmount,
 		CreditAmount: entry.CreditAmount,
 		Description:  entry.Description,
+		TaxAmount:    entry.TaxAmount,
+		IsTaxLine:    entry.IsTaxLine,
+		ReversedAmount: entry.ReversedAmount,
 	}
 
 	if entry.Account != nil {
@@ -251,34 +474,63 @@ func FromVoucherEntry(entry *domain.VoucherEntry) VoucherEntryResponse {
 	if entry.CostCenterID != nil {
 		resp.CostCenterID = entry.CostCenterID.String()
 	}
+	if entry.BankAccountID != nil {
+		resp.BankAccountID = entry.BankAccountID.String()
+	}
+	if entry.TaxCodeID != nil {
+		resp.TaxCodeID = entry.TaxCodeID.String()
+	}
 
 	return resp
 }
 
 // FromVouchers converts a slice of domain.Voucher to []VoucherResponse
-func FromVouchers(vouchers []domain.Voucher) []VoucherResponse {
+func FromVouchers(vouchers []domain.Voucher, loc i18n.Locale) []VoucherResponse {
 	responses := make([]VoucherResponse, len(vouchers))
 	for i, voucher := range vouchers {
-		responses[i] = FromVoucher(&voucher)
+		responses[i] = FromVoucher(&voucher, loc)
 	}
 	return responses
 }
 
-// VoucherListRequest represents query parameters for listing vouchers
+// VoucherListRequest represents query parameters for listing vouchers.
+// voucher_type and status may be repeated to match any of several values;
+// tags may be repeated to match vouchers carrying all of them.
 type VoucherListRequest struct {
-	VoucherType  string `form:"voucher_type" binding:"omitempty,oneof=general sales purchase payment receipt adjustment closing"`
-	Status       string `form:"status" binding:"omitempty,oneof=draft pending approved posted rejected cancelled"`
-	DateFrom     string `form:"date_from" binding:"omitempty"`
-	DateTo       string `form:"date_to" binding:"omitempty"`
-	AccountID    string `form:"account_id" binding:"omitempty,uuid"`
-	PartnerID    string `form:"partner_id" binding:"omitempty,uuid"`
-	DepartmentID string `form:"department_id" binding:"omitempty,uuid"`
-	Search       string `form:"search" binding:"max=100"`
-	IncludeEntries bool `form:"include_entries"`
-	Page         int    `form:"page" binding:"omitempty,min=1"`
-	PageSize     int    `form:"page_size" binding:"omitempty,min=1,max=100"`
-	SortBy       string `form:"sort_by"`
-	SortDesc     bool   `form:"sort_desc"`
+	VoucherType     []string `form:"voucher_type" binding:"omitempty,dive,oneof=general sales purchase payment receipt adjustment closing"`
+	Status          []string `form:"status" binding:"omitempty,dive,oneof=draft pending approved posted rejected cancelled"`
+	DateFrom        string   `form:"date_from" binding:"omitempty"`
+	DateTo          string   `form:"date_to" binding:"omitempty"`
+	MinAmount       *float64 `form:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount       *float64 `form:"max_amount" binding:"omitempty,min=0"`
+	CreatedBy       string   `form:"created_by" binding:"omitempty,uuid"`
+	ApprovedBy      string   `form:"approved_by" binding:"omitempty,uuid"`
+	VoucherNoPrefix string   `form:"voucher_no_prefix" binding:"max=50"`
+	AccountID       string   `form:"account_id" binding:"omitempty,uuid"`
+	PartnerID       string   `form:"partner_id" binding:"omitempty,uuid"`
+	DepartmentID    string   `form:"department_id" binding:"omitempty,uuid"`
+	Tags            []string `form:"tags" binding:"omitempty,max=10,dive,max=50"`
+	Search          string   `form:"search" binding:"max=100"`
+	IncludeEntries  bool     `form:"include_entries"`
+	Page            int      `form:"page" binding:"omitempty,min=1"`
+	PageSize        int      `form:"page_size" binding:"omitempty,min=1,max=100"`
+	SortBy          string   `form:"sort_by"`
+	SortDesc        bool     `form:"sort_desc"`
+}
+
+// Voucher export formats
+const (
+	VoucherExportFormatCSV    = "csv"
+	VoucherExportFormatNDJSON = "ndjson"
+)
+
+// VoucherExportRequest represents query parameters for exporting vouchers.
+// The list filters apply; pagination and sorting are ignored.
+type VoucherExportRequest struct {
+	VoucherListRequest
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	// MaskPII masks personal data in descriptions; always on for non-admins
+	MaskPII bool `form:"mask_pii"`
 }
 
 // WorkflowActionRequest represents a workflow action request
@@ -286,8 +538,64 @@ type WorkflowActionRequest struct {
 	Reason string `json:"reason,omitempty" binding:"max=500"`
 }
 
+// ScheduleVoucherRequest represents the request to schedule the posting of an
+// approved voucher, with the posting signature when the company requires one
+type ScheduleVoucherRequest struct {
+	PostDate string `json:"post_date" binding:"required"`
+	SignatureRequest
+}
+
 // ReverseVoucherRequest represents the request to reverse a voucher
 type ReverseVoucherRequest struct {
 	ReversalDate string `json:"reversal_date" binding:"required"`
 	Description  string `json:"description,omitempty" binding:"max=500"`
 }
+
+// PartialReverseVoucherRequest represents the request to reverse a share of a
+// voucher: Ratio of every entry, or the amounts of the lines
+type PartialReverseVoucherRequest struct {
+	ReversalDate string                       `json:"reversal_date" binding:"required"`
+	Description  string                       `json:"description,omitempty" binding:"max=500"`
+	Ratio        float64                      `json:"ratio,omitempty" binding:"omitempty,gt=0,lte=1"`
+	Lines        []PartialReversalLineRequest `json:"lines,omitempty" binding:"required_without=Ratio,dive"`
+}
+
+// PartialReversalLineRequest is the amount of an entry to reverse
+type PartialReversalLineRequest struct {
+	EntryID string  `json:"entry_id" binding:"required,uuid"`
+	Amount  float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// ToLines converts the request lines to domain.ReversalLine
+func (r *PartialReverseVoucherRequest) ToLines() []domain.ReversalLine {
+	lines := make([]domain.ReversalLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, domain.ReversalLine{EntryID: uuid.MustParse(line.EntryID), Amount: line.Amount})
+	}
+	return lines
+}
+
+// CorrectVoucherRequest represents the request to correct a posted voucher.
+// Without entries the corrected voucher copies the original's; without a
+// description it keeps the original's.
+type CorrectVoucherRequest struct {
+	CorrectionDate string                      `json:"correction_date" binding:"required"`
+	Description    string                      `json:"description,omitempty" binding:"max=500"`
+	Entries        []CreateVoucherEntryRequest `json:"entries,omitempty" binding:"omitempty,dive"`
+}
+
+// VoucherCorrectionResponse represents the vouchers of a correction
+type VoucherCorrectionResponse struct {
+	Original   VoucherResponse `json:"original"`
+	Reversal   VoucherResponse `json:"reversal"`
+	Correction VoucherResponse `json:"correction"`
+}
+
+// FromVoucherCorrection converts domain.VoucherCorrection to VoucherCorrectionResponse
+func FromVoucherCorrection(correction *domain.VoucherCorrection, loc i18n.Locale) VoucherCorrectionResponse {
+	return VoucherCorrectionResponse{
+		Original:   FromVoucher(correction.Original, loc),
+		Reversal:   FromVoucher(correction.Reversal, loc),
+		Correction: FromVoucher(correction.Correction, loc),
+	}
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// UpdateVoucherPrintTemplateRequest represents the request to customize the printed voucher
+type UpdateVoucherPrintTemplateRequest struct {
+	Title             *string  `json:"title" binding:"omitempty,max=50"`
+	Logo              *string  `json:"logo"` // base64-encoded JPEG/PNG; empty string removes the logo
+	ShowApprovalBlock *bool    `json:"show_approval_block"`
+	ApprovalLabels    []string `json:"approval_labels" binding:"omitempty,max=5,dive,max=10"`
+	ShowPartner       *bool    `json:"show_partner"`
+	FooterText        *string  `json:"footer_text" binding:"omitempty,max=500"`
+}
+
+// ApplyTo applies the non-nil fields to the template
+func (r *UpdateVoucherPrintTemplateRequest) ApplyTo(t *domain.VoucherPrintTemplate) error {
+	if r.Title != nil {
+		t.Title = *r.Title
+	}
+	if r.Logo != nil {
+		if *r.Logo == "" {
+			t.LogoData = nil
+			t.LogoContentType = ""
+		} else {
+			data, err := base64.StdEncoding.DecodeString(*r.Logo)
+			if err != nil {
+				return err
+			}
+			t.LogoData = data
+			t.LogoContentType = http.DetectContentType(data)
+		}
+	}
+	if r.ShowApprovalBlock != nil {
+		t.ShowApprovalBlock = *r.ShowApprovalBlock
+	}
+	if r.ApprovalLabels != nil {
+		t.ApprovalLabels = r.ApprovalLabels
+	}
+	if r.ShowPartner != nil {
+		t.ShowPartner = *r.ShowPartner
+	}
+	if r.FooterText != nil {
+		t.FooterText = *r.FooterText
+	}
+	return nil
+}
+
+// VoucherPrintTemplateResponse represents the voucher print template in API responses
+type VoucherPrintTemplateResponse struct {
+	Title             string   `json:"title,omitempty"`
+	HasLogo           bool     `json:"has_logo"`
+	LogoContentType   string   `json:"logo_content_type,omitempty"`
+	ShowApprovalBlock bool     `json:"show_approval_block"`
+	ApprovalLabels    []string `json:"approval_labels"`
+	ShowPartner       bool     `json:"show_partner"`
+	FooterText        string   `json:"footer_text,omitempty"`
+}
+
+// FromVoucherPrintTemplate converts domain.VoucherPrintTemplate to VoucherPrintTemplateResponse
+func FromVoucherPrintTemplate(t *domain.VoucherPrintTemplate) VoucherPrintTemplateResponse {
+	return VoucherPrintTemplateResponse{
+		Title:             t.Title,
+		HasLogo:           t.HasLogo(),
+		LogoContentType:   t.LogoContentType,
+		ShowApprovalBlock: t.ShowApprovalBlock,
+		ApprovalLabels:    t.ApprovalLabels,
+		ShowPartner:       t.ShowPartner,
+		FooterText:        t.FooterText,
+	}
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// SignatureRequest carries the signature captured on approve/post actions (전자서명)
+type SignatureRequest struct {
+	SignatureImage string `json:"signature_image,omitempty"` // base64-encoded JPEG/PNG, optionally as a data URL
+	PIN            string `json:"pin,omitempty" binding:"omitempty,numeric,min=4,max=8"`
+}
+
+// DecodeImage returns the decoded signature image, or nil if none was submitted
+func (r *SignatureRequest) DecodeImage() ([]byte, error) {
+	if r.SignatureImage == "" {
+		return nil, nil
+	}
+	data := r.SignatureImage
+	// Accept canvas.toDataURL() output (data:image/png;base64,...)
+	if strings.HasPrefix(data, "data:") {
+		if i := strings.Index(data, ","); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return base64.StdEncoding.DecodeString(data)
+}
+
+// VoucherSignatureResponse represents an approval signature in API responses
+type VoucherSignatureResponse struct {
+	ID               string `json:"id"`
+	VoucherID        string `json:"voucher_id"`
+	Action           string `json:"action"`
+	UserID           string `json:"user_id"`
+	UserName         string `json:"user_name,omitempty"`
+	Method           string `json:"method"`
+	SignatureImage   string `json:"signature_image,omitempty"` // base64-encoded
+	ImageContentType string `json:"image_content_type,omitempty"`
+	DocumentHash     string `json:"document_hash"`
+	DocumentMatches  bool   `json:"document_matches"` // false if the voucher changed after signing
+	IPAddress        string `json:"ip_address,omitempty"`
+	SignedAt         string `json:"signed_at"`
+}
+
+// FromVoucherSignature converts domain.VoucherSignature to VoucherSignatureResponse
+func FromVoucherSignature(s *domain.VoucherSignature, voucher *domain.Voucher) VoucherSignatureResponse {
+	resp := VoucherSignatureResponse{
+		ID:               s.ID.String(),
+		VoucherID:        s.VoucherID.String(),
+		Action:           string(s.Action),
+		UserID:           s.UserID.String(),
+		Method:           string(s.Method),
+		ImageContentType: s.ImageContentType,
+		DocumentHash:     s.DocumentHash,
+		DocumentMatches:  s.Matches(voucher),
+		IPAddress:        s.IPAddress,
+		SignedAt:         s.SignedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if s.User != nil {
+		resp.UserName = s.User.Name
+	}
+	if s.HasImage() {
+		resp.SignatureImage = base64.StdEncoding.EncodeToString(s.ImageData)
+	}
+	return resp
+}
+
+// FromVoucherSignatures converts a slice of signatures to responses
+func FromVoucherSignatures(signatures []domain.VoucherSignature, voucher *domain.Voucher) []VoucherSignatureResponse {
+	result := make([]VoucherSignatureResponse, len(signatures))
+	for i := range signatures {
+		result[i] = FromVoucherSignature(&signatures[i], voucher)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CreateVoucherTagRequest represents the request to add a voucher tag
+type CreateVoucherTagRequest struct {
+	Name        string `json:"name" binding:"required,max=50"`
+	Description string `json:"description" binding:"omitempty,max=200"`
+}
+
+// ToVoucherTag converts the request to a domain.VoucherTag
+func (r *CreateVoucherTagRequest) ToVoucherTag(companyID uuid.UUID) *domain.VoucherTag {
+	tag := &domain.VoucherTag{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+	tag.CompanyID = companyID
+	return tag
+}
+
+// UpdateVoucherTagRequest represents the request to update or rename a voucher tag
+type UpdateVoucherTagRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=50"`
+	Description *string `json:"description" binding:"omitempty,max=200"`
+}
+
+// ApplyTo applies the non-nil fields to an existing tag
+func (r *UpdateVoucherTagRequest) ApplyTo(tag *domain.VoucherTag) {
+	if r.Name != nil {
+		tag.Name = *r.Name
+	}
+	if r.Description != nil {
+		tag.Description = *r.Description
+	}
+}
+
+// SetVoucherTagsRequest represents the request to replace the tags of a voucher
+type SetVoucherTagsRequest struct {
+	Tags []string `json:"tags" binding:"max=10,dive,max=50"`
+}
+
+// VoucherTagResponse represents a voucher tag in API responses
+type VoucherTagResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	VoucherCount int64  `json:"voucher_count"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// FromVoucherTag converts domain.VoucherTag to VoucherTagResponse
+func FromVoucherTag(tag *domain.VoucherTag) VoucherTagResponse {
+	return VoucherTagResponse{
+		ID:           tag.ID.String(),
+		Name:         tag.Name,
+		Description:  tag.Description,
+		VoucherCount: tag.VoucherCount,
+		CreatedAt:    tag.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    tag.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// FromVoucherTags converts a slice of domain.VoucherTag to responses
+func FromVoucherTags(tags []domain.VoucherTag) []VoucherTagResponse {
+	result := make([]VoucherTagResponse, len(tags))
+	for i := range tags {
+		result[i] = FromVoucherTag(&tags[i])
+	}
+	return result
+}
@@ -7,6 +7,8 @@ package errors
 //   RES  - Resource
 //   PERM - Permission
 //   SRV  - Server/Internal
+//   BIZ  - Business rules
+//   RATE - Rate limiting
 
 const (
 	// Authentication errors (AUTH_)
@@ -26,6 +28,8 @@ const (
 	CodeMissingField  = "VAL_003"
 	CodeInvalidFormat = "VAL_004"
 	CodeOutOfRange    = "VAL_005"
+	CodePayloadTooLarge = "VAL_006"
+	CodePayloadTooComplex = "VAL_007"
 
 	// Resource errors (RES_)
 	CodeNotFound      = "RES_001"
@@ -33,6 +37,7 @@ const (
 	CodeConflict      = "RES_003"
 	CodeEmailExists   = "RES_004"
 	CodeBusinessNumberExists = "RES_005"
+	CodeGone                 = "RES_006"
 
 	// Permission errors (PERM_)
 	CodeForbidden        = "PERM_001"
@@ -51,6 +56,11 @@ const (
 	CodePeriodClosed        = "BIZ_002"
 	CodeInsufficientBalance = "BIZ_003"
 	CodeInvalidTransaction  = "BIZ_004"
+	CodeBusinessRule        = "BIZ_005"
+	CodePlanLimitExceeded   = "BIZ_006"
+
+	// Rate limiting errors (RATE_)
+	CodeRateLimited = "RATE_001"
 )
 
 // HTTP status code mapping
@@ -70,12 +80,15 @@ var HTTPStatusCodes = map[string]int{
 	CodeMissingField:  400,
 	CodeInvalidFormat: 400,
 	CodeOutOfRange:    400,
+	CodePayloadTooLarge: 413,
+	CodePayloadTooComplex: 422,
 
 	CodeNotFound:      404,
 	CodeAlreadyExists: 409,
 	CodeConflict:      409,
 	CodeEmailExists:   409,
 	CodeBusinessNumberExists: 409,
+	CodeGone:                 410,
 
 	CodeForbidden:        403,
 	CodeInsufficientRole: 403,
@@ -91,6 +104,10 @@ var HTTPStatusCodes = map[string]int{
 	CodePeriodClosed:        422,
 	CodeInsufficientBalance: 422,
 	CodeInvalidTransaction:  422,
+	CodeBusinessRule:        422,
+	CodePlanLimitExceeded:   402,
+
+	CodeRateLimited: 429,
 }
 
 // GetHTTPStatus returns the HTTP status code for an error code
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mapper maps the errors returned by services to application errors, so that
+// every handler answers the same error with the same code, status and message.
+// Sentinel errors are registered once with their code; the HTTP status follows
+// from the code.
+type Mapper struct {
+	parent *Mapper
+	rules  []mapping
+}
+
+type mapping struct {
+	target error
+	code   string
+}
+
+// NewMapper creates an empty mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// Register maps the errors matching any of targets (as errors.Is does) to code
+func (m *Mapper) Register(code string, targets ...error) *Mapper {
+	for _, target := range targets {
+		m.rules = append(m.rules, mapping{target: target, code: code})
+	}
+	return m
+}
+
+// Extend returns a mapper whose registrations take precedence over those of m,
+// for the few errors that mean something else where they are returned (a
+// missing account referenced by a voucher entry is invalid input, not a
+// missing resource)
+func (m *Mapper) Extend() *Mapper {
+	return &Mapper{parent: m}
+}
+
+// code returns the registered code of err
+func (m *Mapper) code(err error) (string, bool) {
+	for mm := m; mm != nil; mm = mm.parent {
+		for _, rule := range mm.rules {
+			if errors.Is(err, rule.target) {
+				return rule.code, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Map returns the application error of err and whether err is known. Known
+// errors keep their message, capitalized; application errors are returned as
+// they are. Unknown errors map to an internal error, whose message the caller
+// is expected to replace since it must not leak to clients.
+func (m *Mapper) Map(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	if code, ok := m.code(err); ok {
+		return Wrap(code, capitalize(err.Error()), err), true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Wrap(CodeTimeout, "Request timed out", err), true
+	}
+	return Wrap(CodeInternal, "Internal server error", err), false
+}
+
+// capitalize upper-cases the first letter of an error message, the form the
+// message catalog translates
+func capitalize(message string) string {
+	r, size := utf8.DecodeRuneInString(message)
+	if r == utf8.RuneError {
+		return message
+	}
+	return string(unicode.ToUpper(r)) + message[size:]
+}
@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errWidgetNotFound = errors.New("widget not found")
+	errWidgetInUse    = errors.New("widget is in use")
+)
+
+func TestMapper_Map(t *testing.T) {
+	mapper := NewMapper().
+		Register(CodeNotFound, errWidgetNotFound).
+		Register(CodeConflict, errWidgetInUse)
+
+	tests := []struct {
+		name    string
+		err     error
+		code    string
+		message string
+		status  int
+		known   bool
+	}{
+		{"registered", errWidgetNotFound, CodeNotFound, "Widget not found", http.StatusNotFound, true},
+		{"wrapped", fmt.Errorf("delete: %w", errWidgetInUse), CodeConflict, "Delete: widget is in use", http.StatusConflict, true},
+		{"app error", New(CodePeriodClosed, "Period is closed"), CodePeriodClosed, "Period is closed", http.StatusUnprocessableEntity, true},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), CodeTimeout, "Request timed out", http.StatusGatewayTimeout, true},
+		{"unknown", errors.New("connection reset"), CodeInternal, "Internal server error", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr, known := mapper.Map(tt.err)
+			assert.Equal(t, tt.known, known)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, tt.message, appErr.Message)
+			assert.Equal(t, tt.status, appErr.HTTPStatus())
+		})
+	}
+}
+
+func TestMapper_Extend(t *testing.T) {
+	base := NewMapper().Register(CodeNotFound, errWidgetNotFound, errWidgetInUse)
+	extended := base.Extend().Register(CodeInvalidInput, errWidgetNotFound)
+
+	appErr, _ := extended.Map(errWidgetNotFound)
+	assert.Equal(t, CodeInvalidInput, appErr.Code)
+
+	appErr, _ = extended.Map(errWidgetInUse)
+	assert.Equal(t, CodeNotFound, appErr.Code, "registrations of the parent apply")
+
+	appErr, _ = base.Map(errWidgetNotFound)
+	assert.Equal(t, CodeNotFound, appErr.Code, "the parent is not changed")
+}
@@ -0,0 +1,246 @@
+// Package nts provides a client for the NTS business registration status API
+// (국세청 사업자등록정보 진위확인 및 상태조회 서비스), published on the public data portal.
+package nts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// ProductionURL is the base URL of the API on the public data portal
+	ProductionURL = "https://api.odcloud.kr/api/nts-businessman/v1"
+
+	// MaxBatchSize is the largest number of business numbers per request
+	MaxBatchSize = 100
+)
+
+// Business status codes (b_stt_cd)
+const (
+	StatusCodeActive    = "01" // 계속사업자
+	StatusCodeSuspended = "02" // 휴업자
+	StatusCodeClosed    = "03" // 폐업자
+)
+
+// Validation results (valid)
+const (
+	ValidMatched    = "01"
+	ValidNotMatched = "02"
+)
+
+// ErrNotConfigured is returned when no service key is configured
+var ErrNotConfigured = errors.New("NTS business status API is not configured")
+
+// Config holds NTS API configuration
+type Config struct {
+	BaseURL    string // defaults to ProductionURL
+	ServiceKey string // public data portal service key (decoded)
+	Timeout    time.Duration
+}
+
+// Client provides methods for the NTS business registration status API
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new NTS API client
+func NewClient(config Config) *Client {
+	if config.BaseURL == "" {
+		config.BaseURL = ProductionURL
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// IsConfigured returns true if a service key is configured
+func (c *Client) IsConfigured() bool {
+	return c.config.ServiceKey != ""
+}
+
+// BusinessStatus is the registration status of one business number
+type BusinessStatus struct {
+	BusinessNumber string `json:"b_no"`
+	Status         string `json:"b_stt"`       // e.g. 계속사업자; empty when not registered
+	StatusCode     string `json:"b_stt_cd"`    // 01, 02, 03; empty when not registered
+	TaxType        string `json:"tax_type"`    // e.g. 부가가치세 일반과세자, or the not registered message
+	TaxTypeCode    string `json:"tax_type_cd"` // empty when not registered
+	EndDate        string `json:"end_dt"`      // 폐업일 (YYYYMMDD)
+}
+
+// IsRegistered returns true if NTS knows the business number
+func (s *BusinessStatus) IsRegistered() bool {
+	return s.StatusCode != ""
+}
+
+// ClosedOn returns the closing date of a closed business, or nil
+func (s *BusinessStatus) ClosedOn() *time.Time {
+	if s.EndDate == "" {
+		return nil
+	}
+	t, err := time.Parse("20060102", s.EndDate)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ValidateRequest is a business number with the details to check against NTS.
+// The opening date and representative name are required by NTS.
+type ValidateRequest struct {
+	BusinessNumber  string `json:"b_no"`
+	StartDate       string `json:"start_dt"` // 개업일자 (YYYYMMDD)
+	Representative  string `json:"p_nm"`
+	Representative2 string `json:"p_nm2"`
+	BusinessName    string `json:"b_nm"`
+	CorpNumber      string `json:"corp_no"`
+	BusinessSector  string `json:"b_sector"`
+	BusinessType    string `json:"b_type"`
+}
+
+// ValidateResult is the result of checking one business
+type ValidateResult struct {
+	BusinessNumber string          `json:"b_no"`
+	Valid          string          `json:"valid"` // 01 matched, 02 not matched
+	Message        string          `json:"valid_msg"`
+	Status         *BusinessStatus `json:"status"`
+}
+
+// Matched returns true if the details matched the NTS registration
+func (r *ValidateResult) Matched() bool {
+	return r.Valid == ValidMatched
+}
+
+// APIError is an error response of the API
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"status_code"`
+	Message    string `json:"message"`
+	Body       string `json:"-"`
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("NTS API error %d: %s %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("NTS API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Status looks up the registration status of the business numbers, in
+// batches of MaxBatchSize. The result is in the order of numbers.
+func (c *Client) Status(ctx context.Context, numbers []string) ([]BusinessStatus, error) {
+	var statuses []BusinessStatus
+	for start := 0; start < len(numbers); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(numbers) {
+			end = len(numbers)
+		}
+
+		var resp struct {
+			Data []BusinessStatus `json:"data"`
+		}
+		if err := c.post(ctx, "/status", map[string][]string{"b_no": numbers[start:end]}, &resp); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, orderStatuses(numbers[start:end], resp.Data)...)
+	}
+	return statuses, nil
+}
+
+// Validate checks the businesses against their registration, in batches of
+// MaxBatchSize. The result is in the order of businesses.
+func (c *Client) Validate(ctx context.Context, businesses []ValidateRequest) ([]ValidateResult, error) {
+	var results []ValidateResult
+	for start := 0; start < len(businesses); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(businesses) {
+			end = len(businesses)
+		}
+
+		var resp struct {
+			Data []ValidateResult `json:"data"`
+		}
+		if err := c.post(ctx, "/validate", map[string][]ValidateRequest{"businesses": businesses[start:end]}, &resp); err != nil {
+			return nil, err
+		}
+		if len(resp.Data) != end-start {
+			return nil, fmt.Errorf("NTS API returned %d results for %d businesses", len(resp.Data), end-start)
+		}
+		results = append(results, resp.Data...)
+	}
+	return results, nil
+}
+
+// orderStatuses returns the statuses in the order of numbers. A number missing
+// from the response is reported as not registered.
+func orderStatuses(numbers []string, data []BusinessStatus) []BusinessStatus {
+	byNumber := make(map[string]BusinessStatus, len(data))
+	for _, s := range data {
+		byNumber[s.BusinessNumber] = s
+	}
+	statuses := make([]BusinessStatus, len(numbers))
+	for i, number := range numbers {
+		s, ok := byNumber[number]
+		if !ok {
+			s = BusinessStatus{BusinessNumber: number}
+		}
+		statuses[i] = s
+	}
+	return statuses
+}
+
+// post sends a JSON request and decodes the response into out
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	if !c.IsConfigured() {
+		return ErrNotConfigured
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	query := url.Values{"serviceKey": {c.config.ServiceKey}, "returnType": {"JSON"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+path+"?"+query.Encode(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+		_ = json.Unmarshal(respBody, apiErr)
+		return apiErr
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package nts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Status(t *testing.T) {
+	var requests [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/status", r.URL.Path)
+		assert.Equal(t, "service-key", r.URL.Query().Get("serviceKey"))
+
+		var body struct {
+			Numbers []string `json:"b_no"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body.Numbers)
+
+		// The response is in a different order and leaves one number out
+		w.Write([]byte(`{"status_code":"OK","data":[
+			{"b_no":"2208100001","b_stt":"폐업자","b_stt_cd":"03","tax_type":"부가가치세 일반과세자","end_dt":"20240131"},
+			{"b_no":"1234567891","b_stt":"계속사업자","b_stt_cd":"01","tax_type":"부가가치세 일반과세자","end_dt":""}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, ServiceKey: "service-key"})
+	statuses, err := client.Status(context.Background(), []string{"1234567891", "1048123454", "2208100001"})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+
+	require.Len(t, statuses, 3)
+	assert.Equal(t, StatusCodeActive, statuses[0].StatusCode)
+	assert.Nil(t, statuses[0].ClosedOn())
+	assert.Equal(t, "1048123454", statuses[1].BusinessNumber)
+	assert.False(t, statuses[1].IsRegistered())
+	assert.Equal(t, StatusCodeClosed, statuses[2].StatusCode)
+	require.NotNil(t, statuses[2].ClosedOn())
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), *statuses[2].ClosedOn())
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status_code":"BAD_JSON_REQUEST","message":"JSON format error"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(Config{BaseURL: server.URL}).Status(context.Background(), []string{"1234567891"})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	_, err = NewClient(Config{BaseURL: server.URL, ServiceKey: "service-key"}).Validate(context.Background(), []ValidateRequest{{BusinessNumber: "1234567891"}})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "BAD_JSON_REQUEST", apiErr.Code)
+}
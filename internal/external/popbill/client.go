@@ -5,14 +5,13 @@ package popbill
 import (
 	"bytes"
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -40,7 +39,10 @@ type Client struct {
 	config     *Config
 	httpClient *http.Client
 	baseURL    string
-	token      *accessToken
+	options    ClientOptions
+
+	mu    sync.Mutex
+	token *accessToken
 }
 
 // accessToken represents Popbill API access token.
@@ -52,8 +54,20 @@ type accessToken struct {
 	ExpiresAt    time.Time `json:"expires_at"`
 }
 
+// valid returns true if the token can still be used for a request
+func (t *accessToken) valid() bool {
+	return t != nil && time.Now().Before(t.ExpiresAt.Add(-5*time.Minute))
+}
+
 // NewClient creates a new Popbill API client.
 func NewClient(config *Config) *Client {
+	return NewClientWithOptions(config, nil)
+}
+
+// NewClientWithOptions creates a Popbill API client with retries, a circuit
+// breaker and a shared token cache. Clients of different companies should
+// share the options so that they share the circuit breaker.
+func NewClientWithOptions(config *Config, options *ClientOptions) *Client {
 	baseURL := ProductionURL
 	if config.IsSandbox {
 		baseURL = SandboxURL
@@ -64,56 +78,107 @@ func NewClient(config *Config) *Client {
 		timeout = 30 * time.Second
 	}
 
-	return &Client{
+	client := &Client{
 		config: config,
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
 		baseURL: baseURL,
 	}
+	if options != nil {
+		client.options = *options
+		if options.BaseURL != "" {
+			client.baseURL = options.BaseURL
+		}
+	}
+	return client
 }
 
-// getToken retrieves or refreshes the API access token.
+// getToken retrieves or refreshes the API access token. With a token cache,
+// a token minted by any API instance is reused, and only one instance mints
+// a new token when it expires.
 func (c *Client) getToken(ctx context.Context) (*accessToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Check if existing token is valid
-	if c.token != nil && time.Now().Before(c.token.ExpiresAt.Add(-5*time.Minute)) {
+	if c.token.valid() {
 		return c.token, nil
 	}
 
-	// Generate authentication data
-	authData := c.generateAuthData()
+	cache := c.options.TokenCache
+	if cache == nil {
+		return c.storeToken(c.mintToken(ctx))
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST",
-		fmt.Sprintf("%s/TAXINVOICE/Token", c.baseURL), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create token request: %w", err)
+	key := tokenCacheKey(c.config, c.baseURL)
+	if token := c.cachedToken(ctx, key); token != nil {
+		c.token = token
+		return token, nil
 	}
 
-	req.Header.Set("x-lh-date", authData.timestamp)
-	req.Header.Set("x-lh-version", "2.0")
-	req.Header.Set("Authorization", authData.signature)
-	req.Header.Set("Content-Type", "application/json")
+	acquired, err := cache.Acquire(ctx, key, tokenLockTTL)
+	if err == nil && !acquired {
+		// Another instance is minting the token
+		if token := c.awaitCachedToken(ctx, key); token != nil {
+			c.token = token
+			return token, nil
+		}
+	}
 
-	resp, err := c.httpClient.Do(req)
+	token, err := c.storeToken(c.mintToken(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("token request failed: %w", err)
+		if acquired {
+			cache.Release(ctx, key)
+		}
+		return nil, err
 	}
-	defer resp.Body.Close()
+	if data, err := json.Marshal(token); err == nil {
+		cache.Set(ctx, key, data, time.Until(token.ExpiresAt.Add(-5*time.Minute)))
+	}
+	if acquired {
+		cache.Release(ctx, key)
+	}
+	return token, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+func (c *Client) storeToken(token *accessToken, err error) (*accessToken, error) {
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	return token, nil
+}
+
+// mintToken requests a new access token.
+func (c *Client) mintToken(ctx context.Context) (*accessToken, error) {
+	body, err := c.execute(ctx, http.MethodPost, func() (*http.Request, error) {
+		// Generate authentication data
+		authData := c.generateAuthData()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			fmt.Sprintf("%s/TAXINVOICE/Token", c.baseURL), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create token request: %w", err)
+		}
+
+		req.Header.Set("x-lh-date", authData.timestamp)
+		req.Header.Set("x-lh-version", "2.0")
+		req.Header.Set("Authorization", authData.signature)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, true)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
 	}
 
 	var token accessToken
-	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+	if err := json.Unmarshal(body, &token); err != nil {
 		return nil, fmt.Errorf("failed to decode token response: %w", err)
 	}
 
 	// Set expiration (typically 1 hour)
 	token.ExpiresAt = time.Now().Add(50 * time.Minute)
-	c.token = &token
-
 	return &token, nil
 }
 
@@ -145,23 +210,62 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return nil, err
 	}
 
-	var bodyReader io.Reader
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	return c.execute(ctx, method, func() (*http.Request, error) {
+		var bodyReader io.Reader
+		if bodyBytes != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token.SessionToken)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-pb-userid", c.config.UserID)
+		return req, nil
+	}, method == http.MethodGet)
+}
+
+// execute sends the request built by newRequest and returns the response
+// body, retrying transient failures under the retry policy. idempotent
+// requests are retried after any transient failure; others only when they
+// cannot have reached Popbill, so that a tax invoice is never issued twice.
+func (c *Client) execute(ctx context.Context, method string, newRequest func() (*http.Request, error), idempotent bool) ([]byte, error) {
+	breaker := c.options.Breaker
+	for attempt := 1; ; attempt++ {
+		if err := breaker.Allow(); err != nil {
+			return nil, err
+		}
+
+		body, err := c.send(newRequest)
+		// Failures caused by the caller's deadline do not count against Popbill
+		breaker.Record(isOutage(err) && ctx.Err() == nil)
+
+		if err == nil || attempt >= c.options.Retry.attempts() || !isRetryable(err, idempotent) {
+			return body, err
+		}
+		if err := sleepContext(ctx, c.options.Retry.backoff(attempt)); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	req.Header.Set("Authorization", "Bearer "+token.SessionToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-pb-userid", c.config.UserID)
+// send performs one attempt of a request
+func (c *Client) send(newRequest func() (*http.Request, error)) ([]byte, error) {
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -176,10 +280,11 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 	if resp.StatusCode >= 400 {
 		var errResp PopbillError
-		if json.Unmarshal(respBody, &errResp) == nil {
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Code != 0 {
+			errResp.StatusCode = resp.StatusCode
 			return nil, &errResp
 		}
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	return respBody, nil
@@ -187,8 +292,9 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 // PopbillError represents a Popbill API error.
 type PopbillError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"-"`
 }
 
 // Error implements the error interface.
@@ -196,6 +302,17 @@ func (e *PopbillError) Error() string {
 	return fmt.Sprintf("Popbill error %d: %s", e.Code, e.Message)
 }
 
+// StatusError is an HTTP error response without a Popbill error body.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
 // TaxInvoice represents a tax invoice for Popbill API.
 type TaxInvoice struct {
 	// Basic info
@@ -204,6 +321,7 @@ type TaxInvoice struct {
 	IssueType              string `json:"issueType"`              // 발행형태 (정발행/역발행/위수탁)
 	TaxType                string `json:"taxType"`                // 과세형태 (과세/면세/영세)
 	PurposeType            string `json:"purposeType"`            // 영수/청구
+	InvoicerMgtKey         string `json:"invoicerMgtKey"`         // 공급자 문서번호; Popbill rejects a reused one
 
 	// Supplier info
 	InvoicerCorpNum        string `json:"invoicerCorpNum"`        // 공급자 사업자번호
@@ -231,6 +349,10 @@ type TaxInvoice struct {
 	TaxTotal               string `json:"taxTotal"`               // 세액 합계
 	TotalAmount            string `json:"totalAmount"`            // 합계금액
 
+	// Amendment (수정세금계산서)
+	ModifyCode             int    `json:"modifyCode,omitempty"`       // 수정사유코드 (1-6)
+	OrgNTSConfirmNum       string `json:"orgNTSConfirmNum,omitempty"` // 당초 국세청 승인번호
+
 	// Items
 	DetailList             []TaxInvoiceDetail `json:"detailList"` // 품목 리스트
 
@@ -365,46 +487,3 @@ func (c *Client) GetBalance(ctx context.Context) (float64, error) {
 
 	return result.Balance, nil
 }
-
-// encryptSecretKey encrypts the secret key for storage using AES.
-func encryptSecretKey(key []byte, plaintext string) ([]byte, error) {
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, err
-	}
-
-	nonce := make([]byte, gcm.NonceSize())
-	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
-	return ciphertext, nil
-}
-
-// decryptSecretKey decrypts the secret key from storage.
-func decryptSecretKey(key []byte, ciphertext []byte) (string, error) {
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
-	}
-
-	nonceSize := gcm.NonceSize()
-	if len(ciphertext) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
-	}
-
-	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
-	if err != nil {
-		return "", err
-	}
-
-	return string(plaintext), nil
-}
@@ -0,0 +1,184 @@
+package popbill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePopbill serves tokens and balances; balance responses follow statuses, then succeed
+type fakePopbill struct {
+	tokens   int32
+	balances int32
+	statuses []int
+}
+
+func (f *fakePopbill) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/TAXINVOICE/Token":
+		atomic.AddInt32(&f.tokens, 1)
+		w.Write([]byte(`{"session_token":"token-1"}`))
+	default:
+		n := int(atomic.AddInt32(&f.balances, 1))
+		if n <= len(f.statuses) && f.statuses[n-1] != http.StatusOK {
+			w.WriteHeader(f.statuses[n-1])
+			w.Write([]byte(`{"code":-99999999,"message":"temporary failure"}`))
+			return
+		}
+		w.Write([]byte(`{"balance":1500}`))
+	}
+}
+
+func newTestClient(t *testing.T, fake *fakePopbill, options ClientOptions) *Client {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	options.BaseURL = server.URL
+	return NewClientWithOptions(&Config{LinkID: "KERP", SecretKey: "secret", CorpNum: "1234567890"}, &options)
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	fake := &fakePopbill{statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable}}
+	client := newTestClient(t, fake, ClientOptions{Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}})
+
+	balance, err := client.GetBalance(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, balance)
+	assert.EqualValues(t, 3, fake.balances)
+}
+
+func TestClient_DoesNotRetryRejectedRequests(t *testing.T) {
+	fake := &fakePopbill{statuses: []int{http.StatusBadRequest}}
+	client := newTestClient(t, fake, ClientOptions{Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}})
+
+	_, err := client.GetBalance(context.Background())
+
+	var pbErr *PopbillError
+	require.True(t, errors.As(err, &pbErr))
+	assert.Equal(t, http.StatusBadRequest, pbErr.StatusCode)
+	assert.EqualValues(t, 1, fake.balances)
+}
+
+func TestIsRetryable_NonIdempotent(t *testing.T) {
+	assert.False(t, isRetryable(&PopbillError{Code: -1, StatusCode: http.StatusInternalServerError}, false), "an issue request may have been processed")
+	assert.True(t, isRetryable(&PopbillError{Code: -1, StatusCode: http.StatusServiceUnavailable}, false))
+	assert.True(t, isRetryable(&StatusError{StatusCode: http.StatusTooManyRequests}, false))
+	assert.True(t, isRetryable(&StatusError{StatusCode: http.StatusInternalServerError}, true))
+	assert.False(t, isRetryable(ErrCircuitOpen, true))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	require.NoError(t, breaker.Allow())
+	breaker.Record(true)
+	require.NoError(t, breaker.Allow())
+	breaker.Record(true)
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// One trial call after the open timeout
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+	breaker.Record(true)
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	breaker.Record(false)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.NoError(t, breaker.Allow())
+}
+
+func TestClient_CircuitBreakerShortCircuits(t *testing.T) {
+	fake := &fakePopbill{statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError}}
+	client := newTestClient(t, fake, ClientOptions{Breaker: NewCircuitBreaker(2, time.Hour)})
+
+	_, err := client.GetBalance(context.Background())
+	require.Error(t, err)
+	_, err = client.GetBalance(context.Background())
+	require.Error(t, err)
+
+	_, err = client.GetBalance(context.Background())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.EqualValues(t, 2, fake.balances)
+}
+
+// memoryTokenCache is an in-process TokenCache
+type memoryTokenCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	locks  map[string]bool
+}
+
+func newMemoryTokenCache() *memoryTokenCache {
+	return &memoryTokenCache{values: map[string][]byte{}, locks: map[string]bool{}}
+}
+
+func (c *memoryTokenCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	return v, ok, nil
+}
+
+func (c *memoryTokenCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *memoryTokenCache) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.locks[key] {
+		return false, nil
+	}
+	c.locks[key] = true
+	return true, nil
+}
+
+func (c *memoryTokenCache) Release(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.locks, key)
+	return nil
+}
+
+func TestClient_SharesTokensThroughCache(t *testing.T) {
+	fake := &fakePopbill{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cache := newMemoryTokenCache()
+	options := &ClientOptions{TokenCache: cache, BaseURL: server.URL}
+	config := Config{LinkID: "KERP", SecretKey: "secret", CorpNum: "1234567890"}
+
+	// Two clients stand in for two API instances
+	first, second := NewClientWithOptions(&config, options), NewClientWithOptions(&config, options)
+	_, err := first.GetBalance(context.Background())
+	require.NoError(t, err)
+	_, err = second.GetBalance(context.Background())
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, fake.tokens)
+
+	// Rotated credentials get their own token
+	rotated := config
+	rotated.SecretKey = "rotated"
+	_, err = NewClientWithOptions(&rotated, options).GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, fake.tokens)
+}
@@ -0,0 +1,271 @@
+package popbill_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill/popbilltest"
+)
+
+var contractConfig = popbill.Config{LinkID: "KERP", SecretKey: "secret", CorpNum: "1234567890", UserID: "kerpuser"}
+
+// newContractService returns a service talking to a mock Popbill with up to
+// three attempts per request
+func newContractService(t *testing.T) (*popbill.Service, *popbilltest.Server) {
+	server := popbilltest.NewServer(t)
+	options := server.Options()
+	options.Retry = popbill.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	config := contractConfig
+	return popbill.NewServiceWithOptions(&config, options), server
+}
+
+func contractInvoice() *domain.TaxInvoice {
+	supplyDate := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	return &domain.TaxInvoice{
+		InvoiceNumber:          "INV-2024-0001",
+		IssueDate:              supplyDate,
+		SupplierBusinessNumber: "1234567890",
+		SupplierName:           "케이이알피 주식회사",
+		BuyerBusinessNumber:    "8888888888",
+		BuyerName:              "거래처 주식회사",
+		SupplyAmount:           1000000,
+		TaxAmount:              100000,
+		TotalAmount:            1100000,
+		Status:                 domain.TaxInvoiceStatusDraft,
+		Items: []domain.TaxInvoiceItem{{
+			SupplyDate:  &supplyDate,
+			Description: "ERP 구독료",
+			Quantity:    1,
+			UnitPrice:   1000000,
+			Amount:      1000000,
+			TaxAmount:   100000,
+		}},
+	}
+}
+
+func TestContract_Authentication(t *testing.T) {
+	svc, server := newContractService(t)
+
+	_, err := svc.GetBalance(context.Background())
+	require.NoError(t, err)
+	_, err = svc.GetBalance(context.Background())
+	require.NoError(t, err)
+
+	// One token serves every request until it expires
+	tokens := server.Requests(popbilltest.RouteToken)
+	require.Len(t, tokens, 1)
+	assert.True(t, strings.HasPrefix(tokens[0].Header.Get("Authorization"), "LINKHUB KERP "))
+	assert.Len(t, tokens[0].Header.Get("x-lh-date"), len("20060102150405"))
+	assert.Equal(t, "2.0", tokens[0].Header.Get("x-lh-version"))
+
+	for _, r := range server.Requests(popbilltest.RouteBalance) {
+		assert.Equal(t, "Bearer "+popbilltest.SessionToken(), r.Header.Get("Authorization"))
+		assert.Equal(t, "kerpuser", r.Header.Get("x-pb-userid"))
+		assert.Equal(t, "/TAXINVOICE/1234567890/Balance", r.Path)
+	}
+}
+
+func TestContract_IssueTaxInvoice(t *testing.T) {
+	svc, server := newContractService(t)
+
+	invoice, err := svc.IssueTaxInvoice(context.Background(), contractInvoice())
+	require.NoError(t, err)
+
+	assert.Equal(t, "20240102-41000000-00000001", invoice.NTSConfirmNumber)
+	assert.Equal(t, "024010210000000001", invoice.ASPInvoiceID)
+	assert.Equal(t, popbill.ASPProvider, invoice.ASPProvider)
+	assert.Equal(t, domain.TaxInvoiceStatusTransmitted, invoice.Status)
+	assert.NotNil(t, invoice.NTSTransmittedAt)
+
+	requests := server.Requests(popbilltest.RouteIssue)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/TAXINVOICE/1234567890", requests[0].Path)
+
+	var sent popbill.TaxInvoice
+	require.NoError(t, requests[0].Decode(&sent))
+	assert.Equal(t, "INV-2024-0001", sent.InvoicerMgtKey)
+	assert.Equal(t, "20240102", sent.WriteDate)
+	assert.Equal(t, "정발행", sent.IssueType)
+	assert.Equal(t, "1000000", sent.SupplyCostTotal)
+	assert.Equal(t, "100000", sent.TaxTotal)
+	assert.Equal(t, "1100000", sent.TotalAmount)
+	require.Len(t, sent.DetailList, 1)
+	assert.Equal(t, 1, sent.DetailList[0].SerialNum)
+	assert.Equal(t, "20240102", sent.DetailList[0].PurchaseDT)
+	assert.Equal(t, "1.00", sent.DetailList[0].Qty)
+	assert.Equal(t, "1000000", sent.DetailList[0].UnitCost)
+}
+
+func TestContract_GetTaxInvoice(t *testing.T) {
+	svc, server := newContractService(t)
+
+	invoice, err := svc.GetTaxInvoice(context.Background(), "024010210000000001")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), invoice.IssueDate)
+	assert.Equal(t, "케이이알피 주식회사", invoice.SupplierName)
+	assert.Equal(t, "8888888888", invoice.BuyerBusinessNumber)
+	assert.Equal(t, "buyer@partner.example", invoice.BuyerEmail)
+	assert.EqualValues(t, 1100000, invoice.TotalAmount)
+	assert.Equal(t, "20240102-41000000-00000001", invoice.NTSConfirmNumber)
+	assert.Equal(t, "/TAXINVOICE/1234567890/024010210000000001", server.Requests(popbilltest.RouteGet)[0].Path)
+}
+
+func TestContract_SearchTaxInvoices(t *testing.T) {
+	svc, server := newContractService(t)
+
+	from, to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	invoices, total, err := svc.SearchTaxInvoices(context.Background(), from, to, 1, 50)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, total)
+	require.Len(t, invoices, 2)
+	assert.Equal(t, "두번째 거래처", invoices[1].BuyerName)
+	assert.EqualValues(t, 25000, invoices[1].TaxAmount)
+	assert.Equal(t, domain.TaxInvoiceStatusConfirmed, invoices[1].Status)
+
+	var sent popbill.SearchRequest
+	require.NoError(t, server.Requests(popbilltest.RouteSearch)[0].Decode(&sent))
+	assert.Equal(t, "20240101", sent.SDate)
+	assert.Equal(t, "20240131", sent.EDate)
+	assert.Equal(t, 1, sent.Page)
+	assert.Equal(t, 50, sent.PerPage)
+}
+
+func TestContract_CancelTaxInvoice(t *testing.T) {
+	svc, server := newContractService(t)
+
+	require.NoError(t, svc.CancelTaxInvoice(context.Background(), "024010210000000001", "발행 착오"))
+
+	requests := server.Requests(popbilltest.RouteCancel)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/TAXINVOICE/1234567890/024010210000000001/Cancel", requests[0].Path)
+	var sent map[string]string
+	require.NoError(t, requests[0].Decode(&sent))
+	assert.Equal(t, "발행 착오", sent["memo"])
+}
+
+func TestContract_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		route      popbilltest.Route
+		recordings []string
+		call       func(context.Context, *popbill.Service) error
+		code       int // the expected Popbill error code, 0 for a StatusError
+		status     int
+		attempts   int
+	}{
+		{
+			name:       "duplicate management key is not retried",
+			route:      popbilltest.RouteIssue,
+			recordings: []string{popbilltest.ErrDuplicateMgtKey},
+			call:       issue,
+			code:       popbilltest.CodeDuplicateMgtKey,
+			status:     http.StatusBadRequest,
+			attempts:   1,
+		},
+		{
+			name:       "insufficient balance",
+			route:      popbilltest.RouteIssue,
+			recordings: []string{popbilltest.ErrInsufficientBalance},
+			call:       issue,
+			code:       popbilltest.CodeInsufficientBalance,
+			status:     http.StatusBadRequest,
+			attempts:   1,
+		},
+		{
+			name:       "internal error on issue is not retried, the invoice may have been issued",
+			route:      popbilltest.RouteIssue,
+			recordings: []string{popbilltest.ErrInternal, popbilltest.ErrInternal},
+			call:       issue,
+			code:       popbilltest.CodeInternal,
+			status:     http.StatusInternalServerError,
+			attempts:   1,
+		},
+		{
+			name:       "internal errors on reads are retried",
+			route:      popbilltest.RouteGet,
+			recordings: []string{popbilltest.ErrInternal, popbilltest.ErrInternal, popbilltest.ErrInternal},
+			call:       get,
+			code:       popbilltest.CodeInternal,
+			status:     http.StatusInternalServerError,
+			attempts:   3,
+		},
+		{
+			name:       "unavailable gateway without a Popbill body",
+			route:      popbilltest.RouteBalance,
+			recordings: []string{popbilltest.ErrUnavailable, popbilltest.ErrUnavailable, popbilltest.ErrUnavailable},
+			call:       balance,
+			status:     http.StatusServiceUnavailable,
+			attempts:   3,
+		},
+		{
+			name:       "rejected credentials",
+			route:      popbilltest.RouteToken,
+			recordings: []string{popbilltest.ErrAuthFailed},
+			call:       balance,
+			code:       popbilltest.CodeAuthFailed,
+			status:     http.StatusUnauthorized,
+			attempts:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, server := newContractService(t)
+			server.Queue(tt.route, tt.recordings...)
+
+			err := tt.call(context.Background(), svc)
+			require.Error(t, err)
+
+			if tt.code != 0 {
+				var pbErr *popbill.PopbillError
+				require.True(t, errors.As(err, &pbErr), "got %v", err)
+				assert.Equal(t, tt.code, pbErr.Code)
+				assert.Equal(t, tt.status, pbErr.StatusCode)
+				assert.NotEmpty(t, pbErr.Message)
+			} else {
+				var statusErr *popbill.StatusError
+				require.True(t, errors.As(err, &statusErr), "got %v", err)
+				assert.Equal(t, tt.status, statusErr.StatusCode)
+			}
+			assert.Len(t, server.Requests(tt.route), tt.attempts)
+		})
+	}
+}
+
+func TestContract_RecoversFromUnavailability(t *testing.T) {
+	svc, server := newContractService(t)
+	// A 503 means Popbill did not process the request, so even an issue is sent again
+	server.Queue(popbilltest.RouteIssue, popbilltest.ErrUnavailable)
+
+	invoice, err := svc.IssueTaxInvoice(context.Background(), contractInvoice())
+	require.NoError(t, err)
+
+	assert.Equal(t, "024010210000000001", invoice.ASPInvoiceID)
+	assert.Len(t, server.Requests(popbilltest.RouteIssue), 2)
+}
+
+func issue(ctx context.Context, svc *popbill.Service) error {
+	_, err := svc.IssueTaxInvoice(ctx, contractInvoice())
+	return err
+}
+
+func get(ctx context.Context, svc *popbill.Service) error {
+	_, err := svc.GetTaxInvoice(ctx, "024010210000000001")
+	return err
+}
+
+func balance(ctx context.Context, svc *popbill.Service) error {
+	_, err := svc.GetBalance(ctx)
+	return err
+}
@@ -0,0 +1,60 @@
+package popbill
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CredentialSource resolves the Popbill credentials of a company. The returned
+// Config holds the decrypted secret key and must not be retained by callers.
+type CredentialSource interface {
+	PopbillConfig(ctx context.Context, companyID uuid.UUID) (*Config, error)
+}
+
+// Services provides a Service per company, built from the credentials the
+// company has registered. Credentials are resolved on every call so that
+// updates take effect immediately; a company's client, and with it its access
+// token, is reused while its credentials are unchanged.
+type Services struct {
+	source  CredentialSource
+	timeout time.Duration
+	options *ClientOptions
+
+	mu       sync.Mutex
+	services map[uuid.UUID]*Service
+}
+
+// NewServices creates a per-company Popbill service provider. The clients of
+// all companies share options, and with them the circuit breaker and token cache.
+func NewServices(source CredentialSource, timeout time.Duration, options *ClientOptions) *Services {
+	return &Services{
+		source:   source,
+		timeout:  timeout,
+		options:  options,
+		services: make(map[uuid.UUID]*Service),
+	}
+}
+
+// ForCompany returns the Popbill service of a company
+func (s *Services) ForCompany(ctx context.Context, companyID uuid.UUID) (*Service, error) {
+	config, err := s.source.PopbillConfig(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if config.Timeout == 0 {
+		config.Timeout = s.timeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if svc, ok := s.services[companyID]; ok && *svc.client.config == *config {
+		return svc, nil
+	}
+	svc := NewServiceWithOptions(config, s.options)
+	s.services[companyID] = svc
+	return svc, nil
+}
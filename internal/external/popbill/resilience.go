@@ -0,0 +1,199 @@
+package popbill
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling Popbill while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("popbill is unavailable: circuit breaker is open")
+
+// ClientOptions configures the resilience of Popbill clients.
+type ClientOptions struct {
+	Retry      RetryPolicy
+	Breaker    *CircuitBreaker // shared by all clients; nil disables it
+	TokenCache TokenCache      // shares access tokens between API instances; nil keeps them per client
+	BaseURL    string          // overrides the production/sandbox endpoint, for tests
+}
+
+// RetryPolicy retries transient failures with exponential backoff and full jitter.
+type RetryPolicy struct {
+	MaxAttempts int           // including the first; 0 or 1 disables retries
+	BaseDelay   time.Duration // backoff ceiling of the first retry, doubled on each retry
+	MaxDelay    time.Duration
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns a random delay up to the exponential ceiling of the attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.BaseDelay << uint(attempt-1)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// isRetryable reports whether a failed request may be sent again. Requests
+// that are not idempotent are only retried when Popbill cannot have processed
+// them: the connection was never established, or Popbill rejected the request
+// as rate limited or temporarily unavailable.
+func isRetryable(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	status := statusCode(err)
+	switch {
+	case status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable:
+		return true
+	case status >= 500:
+		return idempotent
+	case status != 0:
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var netErr net.Error
+	return idempotent && errors.As(err, &netErr)
+}
+
+// isOutage reports whether a failure indicates that Popbill is down, as
+// opposed to rejecting the request
+func isOutage(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if status := statusCode(err); status != 0 {
+		return status >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func statusCode(err error) int {
+	var pbErr *PopbillError
+	if errors.As(err, &pbErr) {
+		return pbErr.StatusCode
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// CircuitBreaker stops calls to Popbill after consecutive outage failures.
+// Once OpenTimeout has passed a single trial call is let through; the circuit
+// closes again if it succeeds.
+type CircuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a circuit breaker opening after threshold consecutive failures
+func NewCircuitBreaker(threshold int, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+		state:       CircuitClosed,
+	}
+}
+
+// State returns the current state of the circuit
+func (b *CircuitBreaker) State() string {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow returns ErrCircuitOpen if the call must not be made
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record records the outcome of an allowed call
+func (b *CircuitBreaker) Record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.state = CircuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+	}
+}
@@ -17,8 +17,13 @@ type Service struct {
 
 // NewService creates a new Popbill service.
 func NewService(config *Config) *Service {
+	return NewServiceWithOptions(config, nil)
+}
+
+// NewServiceWithOptions creates a Popbill service whose client uses the resilience options.
+func NewServiceWithOptions(config *Config, options *ClientOptions) *Service {
 	return &Service{
-		client: NewClient(config),
+		client: NewClientWithOptions(config, options),
 	}
 }
 
@@ -31,6 +36,7 @@ func (s *Service) IssueTaxInvoice(ctx context.Context, invoice *domain.TaxInvoic
 		IssueType:           "정발행",
 		TaxType:             "과세",
 		PurposeType:         "영수",
+		InvoicerMgtKey:      invoice.InvoiceNumber,
 
 		InvoicerCorpNum:     invoice.SupplierBusinessNumber,
 		InvoicerCorpName:    invoice.SupplierName,
@@ -54,6 +60,9 @@ func (s *Service) IssueTaxInvoice(ctx context.Context, invoice *domain.TaxInvoic
 		TotalAmount:         strconv.FormatInt(invoice.TotalAmount, 10),
 
 		Remark1:             invoice.Remarks,
+
+		ModifyCode:          int(invoice.AmendmentReason),
+		OrgNTSConfirmNum:    invoice.OriginalNTSConfirmNumber,
 	}
 
 	// Convert items
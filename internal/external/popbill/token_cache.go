@@ -0,0 +1,103 @@
+package popbill
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// tokenLockTTL bounds how long other instances wait for the instance minting a token
+	tokenLockTTL = 5 * time.Second
+	// tokenPollInterval is how often waiting instances look for the minted token
+	tokenPollInterval = 100 * time.Millisecond
+)
+
+// TokenCache stores access tokens shared by all API instances.
+type TokenCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Acquire takes the lock of a key for ttl; false if another instance holds it
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key string) error
+}
+
+// tokenCacheKey identifies the token of a set of credentials. The secret key
+// is hashed in so that a rotated key does not reuse the previous token.
+func tokenCacheKey(config *Config, baseURL string) string {
+	sum := sha256.Sum256([]byte(baseURL + "\x00" + config.LinkID + "\x00" + config.SecretKey + "\x00" + config.CorpNum + "\x00" + config.UserID))
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedToken returns the valid cached token of key, if any
+func (c *Client) cachedToken(ctx context.Context, key string) *accessToken {
+	data, ok, err := c.options.TokenCache.Get(ctx, key)
+	if err != nil || !ok {
+		return nil
+	}
+	var token accessToken
+	if json.Unmarshal(data, &token) != nil || !token.valid() {
+		return nil
+	}
+	return &token
+}
+
+// awaitCachedToken waits for another instance to store the token of key
+func (c *Client) awaitCachedToken(ctx context.Context, key string) *accessToken {
+	deadline := time.Now().Add(tokenLockTTL)
+	for time.Now().Before(deadline) {
+		if sleepContext(ctx, tokenPollInterval) != nil {
+			return nil
+		}
+		if token := c.cachedToken(ctx, key); token != nil {
+			return token
+		}
+	}
+	return nil
+}
+
+// RedisTokenCache is a TokenCache backed by Redis.
+type RedisTokenCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisTokenCache creates a Redis token cache with keys under prefix
+func NewRedisTokenCache(client *redis.Client, prefix string) *RedisTokenCache {
+	return &RedisTokenCache{client: client, prefix: prefix}
+}
+
+// Get returns the value of key
+func (c *RedisTokenCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Set stores the value of key
+func (c *RedisTokenCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+// Acquire takes the lock of key
+func (c *RedisTokenCache) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return c.client.SetNX(ctx, c.prefix+key+":lock", 1, ttl).Result()
+}
+
+// Release releases the lock of key
+func (c *RedisTokenCache) Release(ctx context.Context, key string) error {
+	return c.client.Del(ctx, c.prefix+key+":lock").Err()
+}
@@ -0,0 +1,77 @@
+package popbill
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	secret := []byte("webhook-secret")
+	body := []byte(`{"itemKey":"024010100000000001","stateCode":304}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := SignWebhook(secret, timestamp, body)
+
+	assert.NoError(t, VerifyWebhook(secret, timestamp, signature, body, 5*time.Minute, now))
+
+	tampered := []byte(`{"itemKey":"024010100000000001","stateCode":600}`)
+	assert.ErrorIs(t, VerifyWebhook(secret, timestamp, signature, tampered, 5*time.Minute, now), ErrInvalidWebhookSignature)
+	assert.ErrorIs(t, VerifyWebhook([]byte("other"), timestamp, signature, body, 5*time.Minute, now), ErrInvalidWebhookSignature)
+
+	// A captured request replayed later still has a valid signature but an old timestamp
+	assert.ErrorIs(t, VerifyWebhook(secret, timestamp, signature, body, 5*time.Minute, now.Add(10*time.Minute)), ErrStaleWebhook)
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(`{
+		"eventID": "evt-1", "eventType": "NTS", "eventDT": "20240102093000",
+		"corpNum": "1234567890", "itemKey": "024010100000000001", "stateCode": 304,
+		"ntsconfirmNum": "20240102-41000000-00000001", "ntssendDT": "20240102090000"
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "id:evt-1", event.Key(nil))
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC), event.OccurredAt().UTC())
+	assert.NotNil(t, event.NTSSentAt())
+	assert.Nil(t, event.NTSResultAt())
+
+	_, err = ParseWebhookEvent([]byte(`{"stateCode": 304}`))
+	assert.ErrorIs(t, err, ErrInvalidWebhookPayload)
+	_, err = ParseWebhookEvent([]byte(`not json`))
+	assert.ErrorIs(t, err, ErrInvalidWebhookPayload)
+}
+
+func TestWebhookEvent_KeyWithoutEventID(t *testing.T) {
+	event := &WebhookEvent{ItemKey: "024010100000000001"}
+	first := event.Key([]byte(`{"itemKey":"024010100000000001","stateCode":300}`))
+	assert.Equal(t, first, event.Key([]byte(`{"itemKey":"024010100000000001","stateCode":300}`)))
+	assert.NotEqual(t, first, event.Key([]byte(`{"itemKey":"024010100000000001","stateCode":304}`)))
+}
+
+func TestWebhookEvent_InvoiceStatus(t *testing.T) {
+	tests := []struct {
+		stateCode int
+		status    domain.TaxInvoiceStatus
+		ok        bool
+	}{
+		{100, "", false},
+		{200, "", false},
+		{300, domain.TaxInvoiceStatusIssued, true},
+		{302, domain.TaxInvoiceStatusTransmitted, true},
+		{304, domain.TaxInvoiceStatusConfirmed, true},
+		{305, domain.TaxInvoiceStatusRejected, true},
+		{400, domain.TaxInvoiceStatusRejected, true},
+		{600, domain.TaxInvoiceStatusCancelled, true},
+	}
+	for _, tt := range tests {
+		status, ok := (&WebhookEvent{StateCode: tt.stateCode}).InvoiceStatus()
+		assert.Equal(t, tt.ok, ok, "state %d", tt.stateCode)
+		assert.Equal(t, tt.status, status, "state %d", tt.stateCode)
+	}
+}
@@ -0,0 +1,219 @@
+// Package sentry sends error events to Sentry through its envelope API.
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Config holds Sentry configuration
+type Config struct {
+	DSN         string // https://<public key>@<host>/<project id>
+	Environment string
+	Release     string
+	Timeout     time.Duration
+}
+
+// Client sends events to the Sentry project of a DSN
+type Client struct {
+	config      *Config
+	client      *http.Client
+	envelopeURL string
+	publicKey   string
+}
+
+// NewClient creates a Sentry client; the DSN must be valid
+func NewClient(config *Config) (*Client, error) {
+	dsn, err := url.Parse(config.DSN)
+	if err != nil || dsn.User == nil || dsn.User.Username() == "" || dsn.Host == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN")
+	}
+	path := strings.Trim(dsn.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	project := path[slash+1:]
+	if project == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: no project")
+	}
+	prefix := ""
+	if slash >= 0 {
+		prefix = "/" + path[:slash]
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	return &Client{
+		config:      config,
+		client:      &http.Client{Timeout: timeout},
+		envelopeURL: fmt.Sprintf("%s://%s%s/api/%s/envelope/", dsn.Scheme, dsn.Host, prefix, project),
+		publicKey:   dsn.User.Username(),
+	}, nil
+}
+
+// Event is an error event
+type Event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Exception   *Exceptions       `json:"exception,omitempty"`
+	Request     *Request          `json:"request,omitempty"`
+	User        *User             `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// Exceptions holds the exceptions of an event
+type Exceptions struct {
+	Values []Exception `json:"values"`
+}
+
+// Exception is an error with the stack it was raised on
+type Exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+// Stacktrace lists frames from the outermost call to the innermost
+type Stacktrace struct {
+	Frames []Frame `json:"frames"`
+}
+
+// Frame is a stack frame
+type Frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// Request is the HTTP request an event occurred in
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// User is the user an event occurred for
+type User struct {
+	ID string `json:"id"`
+}
+
+// inAppModule prefixes the packages of this application
+const inAppModule = "github.com/saintgo7/saas-kerp/"
+
+// NewStacktrace converts runtime frames, innermost first, to a Sentry stack trace
+func NewStacktrace(frames []runtime.Frame) *Stacktrace {
+	trace := &Stacktrace{Frames: make([]Frame, 0, len(frames))}
+	for i := len(frames) - 1; i >= 0; i-- {
+		f := frames[i]
+		module, function := splitFunction(f.Function)
+		trace.Frames = append(trace.Frames, Frame{
+			Function: function,
+			Module:   module,
+			Filename: shortFile(f.File),
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    strings.HasPrefix(f.Function, inAppModule),
+		})
+	}
+	return trace
+}
+
+// splitFunction splits "pkg/path.Type.Method" into the package path and the function
+func splitFunction(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+// shortFile returns the last two elements of a path
+func shortFile(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	return strings.Join(parts, "/")
+}
+
+// Capture sends an event, filling in its ID, timestamp and environment, and
+// returns the event ID
+func (c *Client) Capture(ctx context.Context, event *Event) (string, error) {
+	if event.EventID == "" {
+		event.EventID = newEventID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	if event.Platform == "" {
+		event.Platform = "go"
+	}
+	if event.Level == "" {
+		event.Level = "error"
+	}
+	if event.Environment == "" {
+		event.Environment = c.config.Environment
+	}
+	if event.Release == "" {
+		event.Release = c.config.Release
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode event: %w", err)
+	}
+	header, _ := json.Marshal(map[string]string{
+		"event_id": event.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339),
+	})
+	var body bytes.Buffer
+	body.Write(header)
+	body.WriteString("\n")
+	fmt.Fprintf(&body, `{"type":"event","length":%d}`, len(payload))
+	body.WriteString("\n")
+	body.Write(payload)
+	body.WriteString("\n")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.envelopeURL, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=kerp/1.0, sentry_key=%s", c.publicKey))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("sentry returned status %d: %s", resp.StatusCode, respBody)
+	}
+	return event.EventID, nil
+}
+
+// newEventID returns a random 32 character hex ID
+func newEventID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,83 @@
+package sentry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_DSN(t *testing.T) {
+	client, err := NewClient(&Config{DSN: "https://key@sentry.example.com/prefix/42"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sentry.example.com/prefix/api/42/envelope/", client.envelopeURL)
+	assert.Equal(t, "key", client.publicKey)
+
+	for _, dsn := range []string{"", "https://sentry.example.com/42", "https://key@sentry.example.com/"} {
+		_, err := NewClient(&Config{DSN: dsn})
+		assert.Error(t, err, dsn)
+	}
+}
+
+func TestClient_Capture(t *testing.T) {
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/7/envelope/", r.URL.Path)
+		assert.Contains(t, r.Header.Get("X-Sentry-Auth"), "sentry_key=public")
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		DSN:         strings.Replace(server.URL, "http://", "http://public@", 1) + "/7",
+		Environment: "production",
+		Release:     "1.2.3",
+	})
+	require.NoError(t, err)
+
+	pcs := make([]uintptr, 8)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	frame, _ := frames.Next()
+	id, err := client.Capture(context.Background(), &Event{
+		Level: "fatal",
+		Exception: &Exceptions{Values: []Exception{{
+			Type: "panic", Value: "boom", Stacktrace: NewStacktrace([]runtime.Frame{frame}),
+		}}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, id, 32)
+
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], id)
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &event))
+	assert.Equal(t, "production", event.Environment)
+	assert.Equal(t, "1.2.3", event.Release)
+	assert.Equal(t, "go", event.Platform)
+	got := event.Exception.Values[0].Stacktrace.Frames[0]
+	assert.Equal(t, "github.com/saintgo7/saas-kerp/internal/external/sentry", got.Module)
+	assert.Equal(t, "TestClient_Capture", got.Function)
+	assert.True(t, got.InApp)
+}
+
+func TestClient_CaptureRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{DSN: strings.Replace(server.URL, "http://", "http://public@", 1) + "/7"})
+	require.NoError(t, err)
+	_, err = client.Capture(context.Background(), &Event{Message: "test"})
+	assert.ErrorContains(t, err, "429")
+}
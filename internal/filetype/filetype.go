@@ -0,0 +1,199 @@
+// Package filetype identifies uploaded files by their content. The declared
+// content type and the extension of an upload are chosen by the client; only the
+// leading bytes of the file tell what it really is.
+package filetype
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// File type errors
+var (
+	ErrNotAllowed        = errors.New("file type is not allowed")
+	ErrExtensionMismatch = errors.New("file extension does not match its content")
+)
+
+// MIME types of the accepted files
+const (
+	PDF  = "application/pdf"
+	JPEG = "image/jpeg"
+	PNG  = "image/png"
+	GIF  = "image/gif"
+	WebP = "image/webp"
+	TIFF = "image/tiff"
+	BMP  = "image/bmp"
+	HEIC = "image/heic"
+	Text = "text/plain"
+	XML  = "text/xml"
+	XLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	DOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	PPTX = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	HWPX = "application/hwp+zip"
+	HWP  = "application/x-hwp"
+	DOC  = "application/msword"
+	XLS  = "application/vnd.ms-excel"
+	PPT  = "application/vnd.ms-powerpoint"
+)
+
+// extensions lists the extensions a file of each accepted type may carry.
+// Markup that browsers execute (HTML, SVG), archives and executables are not
+// accepted at all.
+var extensions = map[string][]string{
+	PDF:  {".pdf"},
+	JPEG: {".jpg", ".jpeg"},
+	PNG:  {".png"},
+	GIF:  {".gif"},
+	WebP: {".webp"},
+	TIFF: {".tif", ".tiff"},
+	BMP:  {".bmp"},
+	HEIC: {".heic", ".heif"},
+	Text: {".txt", ".csv"},
+	XML:  {".xml"}, // 전자세금계산서
+	XLSX: {".xlsx"},
+	DOCX: {".docx"},
+	PPTX: {".pptx"},
+	HWPX: {".hwpx"},
+	HWP:  {".hwp"},
+	DOC:  {".doc"},
+	XLS:  {".xls"},
+	PPT:  {".ppt"},
+}
+
+// oleTypes are the legacy Office and Hangul formats, which share the compound
+// file container and are told apart by their extension
+var oleTypes = map[string]string{".hwp": HWP, ".doc": DOC, ".xls": XLS, ".ppt": PPT}
+
+// heifBrands are the ftyp brands of HEIF images written by phone cameras
+var heifBrands = []string{"heic", "heix", "heim", "heis", "hevc", "hevx", "mif1", "msf1"}
+
+// Detect returns the MIME type of a file from its content, checking that it is
+// an accepted type and that the extension of name, if any, belongs to it.
+func Detect(name string, data []byte) (string, error) {
+	ext := strings.ToLower(filepath.Ext(name))
+
+	mimeType := sniff(data)
+	if mimeType == "ole" {
+		oleType, ok := oleTypes[ext]
+		switch {
+		case ok:
+			return oleType, nil
+		case ext == "":
+			return "", ErrNotAllowed // no way to tell which document it is
+		default:
+			return "", ErrExtensionMismatch
+		}
+	}
+
+	allowed, ok := extensions[mimeType]
+	if !ok {
+		return "", ErrNotAllowed
+	}
+	if ext == "" {
+		return mimeType, nil
+	}
+	for _, e := range allowed {
+		if e == ext {
+			return mimeType, nil
+		}
+	}
+	return "", ErrExtensionMismatch
+}
+
+// IsImage reports whether a MIME type is an image
+func IsImage(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "image/")
+}
+
+// sniff identifies a file by its signature. Compound files are returned as
+// "ole"; unknown content falls back to http.DetectContentType, which tells
+// text from markup and binary data.
+func sniff(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return PDF
+	case bytes.HasPrefix(data, []byte("\xFF\xD8\xFF")):
+		return JPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1A\n")):
+		return PNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return GIF
+	case len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && string(data[8:12]) == "WEBP":
+		return WebP
+	case bytes.HasPrefix(data, []byte("II*\x00")), bytes.HasPrefix(data, []byte("MM\x00*")):
+		return TIFF
+	case bytes.HasPrefix(data, []byte("BM")) && len(data) >= 14:
+		return BMP
+	case isHEIF(data):
+		return HEIC
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return sniffZip(data)
+	case bytes.HasPrefix(data, []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")):
+		return "ole"
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return mimeType
+}
+
+// isHEIF reports whether data starts with the ftyp box of a HEIF image
+func isHEIF(data []byte) bool {
+	if len(data) < 12 || string(data[4:8]) != "ftyp" {
+		return false
+	}
+	brand := string(data[8:12])
+	for _, b := range heifBrands {
+		if brand == b {
+			return true
+		}
+	}
+	return false
+}
+
+// sniffZip identifies the Office Open XML and HWPX documents, which are zip
+// archives; other archives are reported as application/zip.
+func sniffZip(data []byte) string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "application/zip"
+	}
+
+	var contentTypes bool
+	var part string
+	for _, f := range archive.File {
+		switch {
+		case f.Name == "mimetype":
+			if readSmall(f) == HWPX {
+				return HWPX
+			}
+		case f.Name == "[Content_Types].xml":
+			contentTypes = true
+		case part == "" && strings.HasPrefix(f.Name, "word/"):
+			part = DOCX
+		case part == "" && strings.HasPrefix(f.Name, "xl/"):
+			part = XLSX
+		case part == "" && strings.HasPrefix(f.Name, "ppt/"):
+			part = PPTX
+		}
+	}
+	if contentTypes && part != "" {
+		return part
+	}
+	return "application/zip"
+}
+
+// readSmall reads the first bytes of an archive entry
+func readSmall(f *zip.File) string {
+	r, err := f.Open()
+	if err != nil {
+		return ""
+	}
+	defer r.Close()
+	data, _ := io.ReadAll(io.LimitReader(r, 64))
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,69 @@
+package filetype
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func zipWith(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetect(t *testing.T) {
+	ole := []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1\x00\x00")
+	heic := []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic")
+	xlsx := zipWith(t, map[string]string{"[Content_Types].xml": "<Types/>", "xl/workbook.xml": "<workbook/>"})
+	hwpx := zipWith(t, map[string]string{"mimetype": "application/hwp+zip", "Contents/section0.xml": "<sec/>"})
+
+	tests := []struct {
+		name     string
+		fileName string
+		data     []byte
+		want     string
+		err      error
+	}{
+		{"pdf", "invoice.pdf", []byte("%PDF-1.7\n"), PDF, nil},
+		{"jpeg upper case extension", "RECEIPT.JPG", []byte("\xFF\xD8\xFF\xE0\x00\x10JFIF"), JPEG, nil},
+		{"png", "scan.png", []byte("\x89PNG\r\n\x1A\n\x00\x00"), PNG, nil},
+		{"heic", "IMG_0001.HEIC", heic, HEIC, nil},
+		{"csv", "bank.csv", []byte("date,amount\n2026-01-02,1000\n"), Text, nil},
+		{"tax invoice xml", "taxinvoice.xml", []byte(`<?xml version="1.0"?><TaxInvoice/>`), XML, nil},
+		{"xlsx", "ledger.xlsx", xlsx, XLSX, nil},
+		{"hwpx", "report.hwpx", hwpx, HWPX, nil},
+		{"hwp", "report.hwp", ole, HWP, nil},
+		{"no extension", "scan", []byte("%PDF-1.4"), PDF, nil},
+		{"executable renamed", "invoice.pdf", []byte("MZ\x90\x00\x03\x00\x00\x00"), "", ErrNotAllowed},
+		{"html", "invoice.html", []byte("<html><script>alert(1)</script></html>"), "", ErrNotAllowed},
+		{"plain zip", "files.zip", zipWith(t, map[string]string{"a.exe": "MZ"}), "", ErrNotAllowed},
+		{"html named pdf", "invoice.pdf", []byte("<!DOCTYPE html><html></html>"), "", ErrNotAllowed},
+		{"png named jpg", "photo.jpg", []byte("\x89PNG\r\n\x1A\n\x00\x00"), "", ErrExtensionMismatch},
+		{"pdf named txt", "notes.txt", []byte("%PDF-1.7"), "", ErrExtensionMismatch},
+		{"svg", "logo.svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script/></svg>`), "", ErrExtensionMismatch},
+		{"compound file named pdf", "invoice.pdf", ole, "", ErrExtensionMismatch},
+		{"compound file without name", "document", ole, "", ErrNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.fileName, tt.data)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -11,6 +13,7 @@ import (
 	"github.com/saintgo7/saas-kerp/internal/dto"
 	"github.com/saintgo7/saas-kerp/internal/repository"
 	"github.com/saintgo7/saas-kerp/internal/service"
+	"github.com/saintgo7/saas-kerp/internal/xlsx"
 )
 
 // AccountHandler handles HTTP requests for chart of accounts
@@ -29,6 +32,13 @@ func (h *AccountHandler) RegisterRoutes(r *gin.RouterGroup) {
 	{
 		accounts.GET("", h.List)
 		accounts.GET("/tree", h.GetTree)
+		accounts.GET("/lookup", h.Lookup)
+		accounts.GET("/export", h.ExportExcel)
+		accounts.POST("/import", h.ImportExcel)
+		accounts.GET("/templates", h.ListTemplates)
+		accounts.GET("/templates/:industry", h.GetTemplate)
+		accounts.POST("/templates/:industry/apply", h.ApplyTemplate)
+		accounts.GET("/statement-lines", h.ListStatementLines)
 		accounts.GET("/:id", h.GetByID)
 		accounts.GET("/code/:code", h.GetByCode)
 		accounts.POST("", h.Create)
@@ -37,6 +47,10 @@ func (h *AccountHandler) RegisterRoutes(r *gin.RouterGroup) {
 		accounts.GET("/:id/children", h.GetChildren)
 		accounts.GET("/:id/can-delete", h.CanDelete)
 		accounts.PUT("/:id/move", h.Move)
+		accounts.GET("/:id/posting-rules", h.GetPostingRules)
+		accounts.PUT("/:id/posting-rules", h.UpdatePostingRules)
+		accounts.DELETE("/:id/posting-rules", h.DeletePostingRules)
+		accounts.PUT("/:id/statement-line", h.SetStatementLine)
 	}
 }
 
@@ -70,15 +84,19 @@ func (h *AccountHandler) List(c *gin.Context) {
 		active := isActive == "true"
 		filter.IsActive = &active
 	}
+	if isCash := c.Query("is_cash"); isCash != "" {
+		cash := isCash == "true"
+		filter.IsCash = &cash
+	}
 
 	accounts, total, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to list accounts")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessWithMeta(
-		dto.FromAccounts(accounts),
+		dto.FromAccounts(accounts, appctx.GetLocale(c)),
 		&dto.MetaInfo{
 			Total:      total,
 			Page:       filter.Page,
@@ -94,11 +112,138 @@ func (h *AccountHandler) GetTree(c *gin.Context) {
 
 	accounts, err := h.service.GetTree(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get account tree")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccounts(accounts, appctx.GetLocale(c))))
+}
+
+// ExportExcel handles GET /accounts/export
+// @Summary Export the chart of accounts
+// @Description Download the chart of accounts as an .xlsx workbook with the columns
+// @Description code, name, name_en, parent_code, account_type, account_nature, account_category,
+// @Description is_active, is_control_account, allow_direct_posting, is_cash_account (Y/N) and sort_order.
+// @Tags accounts
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} file
+// @Router /accounts/export [get]
+func (h *AccountHandler) ExportExcel(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	data, err := h.service.ExportExcel(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to export accounts")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "accounts-"+time.Now().Format("20060102")+".xlsx"))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, xlsx.ContentType, data)
+}
+
+// ImportExcel handles POST /accounts/import
+// @Summary Import the chart of accounts
+// @Description Validate (dry_run, the default) or apply an .xlsx workbook in the export layout.
+// @Description New codes are created and existing codes updated; parent_code must be in the file
+// @Description or the chart of accounts, and files with any error are not applied.
+// @Tags accounts
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true ".xlsx workbook with a header row; Korean headers are accepted"
+// @Param dry_run formData bool false "Validate without saving (default true)"
+// @Success 200 {object} dto.Response{data=dto.LegacyImportResultResponse}
+// @Failure 400 {object} dto.Response
+// @Router /accounts/import [post]
+func (h *AccountHandler) ImportExcel(c *gin.Context) {
+	var req dto.AccountImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request", err))
+		return
+	}
+
+	data, err := readUploadedFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Import file is required", err.Error()))
+		return
+	}
+
+	result, err := h.service.ImportExcel(c.Request.Context(), appctx.GetCompanyID(c), data, req.IsDryRun())
+	if err != nil {
+		respondError(c, err, "Failed to import accounts")
+		return
+	}
+
+	// Files with errors are reported with applied=false and their issues
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromLegacyImportResult(result)))
+}
+
+// ListTemplates handles GET /accounts/templates
+// @Summary List chart of accounts templates
+// @Description List the industry templates of the chart of accounts
+// @Tags accounts
+// @Produce json
+// @Success 200 {object} dto.Response{data=[]domain.AccountTemplate}
+// @Router /accounts/templates [get]
+func (h *AccountHandler) ListTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse(h.service.ListTemplates()))
+}
+
+// GetTemplate handles GET /accounts/templates/:industry
+// @Summary Get a chart of accounts template
+// @Description Get the accounts of an industry template with their statement lines
+// @Tags accounts
+// @Produce json
+// @Param industry path string true "general, manufacturing, retail, construction or service"
+// @Success 200 {object} dto.Response{data=domain.AccountTemplate}
+// @Failure 404 {object} dto.Response
+// @Router /accounts/templates/{industry} [get]
+func (h *AccountHandler) GetTemplate(c *gin.Context) {
+	template, err := h.service.GetTemplate(domain.Industry(c.Param("industry")))
+	if err != nil {
+		respondError(c, err, "Failed to get account template")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(template))
+}
+
+// ApplyTemplate handles POST /accounts/templates/:industry/apply
+// @Summary Apply a chart of accounts template
+// @Description Validate (dry_run, the default) or add the template's accounts missing from the company.
+// @Description Existing accounts keep their settings; those without a statement line get the template's.
+// @Tags accounts
+// @Produce json
+// @Param industry path string true "general, manufacturing, retail, construction or service"
+// @Param dry_run query bool false "Report without saving (default true)"
+// @Success 200 {object} dto.Response{data=dto.LegacyImportResultResponse}
+// @Failure 404 {object} dto.Response
+// @Router /accounts/templates/{industry}/apply [post]
+func (h *AccountHandler) ApplyTemplate(c *gin.Context) {
+	var req dto.AccountTemplateApplyRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request", err))
+		return
+	}
+
+	result, err := h.service.ApplyTemplate(c.Request.Context(), appctx.GetCompanyID(c), domain.Industry(c.Param("industry")), req.IsDryRun())
+	if err != nil {
+		respondError(c, err, "Failed to apply account template")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccounts(accounts)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromLegacyImportResult(result)))
+}
+
+// ListStatementLines handles GET /accounts/statement-lines
+// @Summary List financial statement lines
+// @Description List the balance sheet and income statement lines accounts can be mapped to
+// @Tags accounts
+// @Produce json
+// @Success 200 {object} dto.Response{data=[]domain.StatementLine}
+// @Router /accounts/statement-lines [get]
+func (h *AccountHandler) ListStatementLines(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse(h.service.StatementLines()))
 }
 
 // GetByID handles GET /accounts/:id
@@ -112,11 +257,30 @@ func (h *AccountHandler) GetByID(c *gin.Context) {
 
 	account, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Account not found"))
+		respondError(c, err, "Failed to get account")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account, appctx.GetLocale(c))))
+}
+
+// Lookup handles GET /accounts/lookup, the type-ahead of account fields
+// matching q to the start of the codes and names of active accounts
+func (h *AccountHandler) Lookup(c *gin.Context) {
+	limit := domain.AccountLookupLimit
+	if value := c.Query("limit"); value != "" {
+		if l, err := parseInt(value); err == nil {
+			limit = l
+		}
+	}
+
+	accounts, err := h.service.Lookup(c.Request.Context(), appctx.GetCompanyID(c), c.Query("q"), limit)
+	if err != nil {
+		respondError(c, err, "Failed to look up accounts")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(accounts))
 }
 
 // GetByCode handles GET /accounts/code/:code
@@ -126,18 +290,18 @@ func (h *AccountHandler) GetByCode(c *gin.Context) {
 
 	account, err := h.service.GetByCode(c.Request.Context(), companyID, code)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Account not found"))
+		respondError(c, err, "Failed to get account")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account, appctx.GetLocale(c))))
 }
 
 // Create handles POST /accounts
 func (h *AccountHandler) Create(c *gin.Context) {
 	var req dto.CreateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -150,18 +314,11 @@ func (h *AccountHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.service.Create(c.Request.Context(), account); err != nil {
-		switch err {
-		case domain.ErrAccountCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Account code already exists"))
-		case domain.ErrParentNotFound:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_002", "Parent account not found"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to create account")
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromAccount(account)))
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromAccount(account, appctx.GetLocale(c))))
 }
 
 // Update handles PUT /accounts/:id
@@ -175,13 +332,13 @@ func (h *AccountHandler) Update(c *gin.Context) {
 
 	var req dto.UpdateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	account, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Account not found"))
+		respondError(c, err, "Failed to get account")
 		return
 	}
 
@@ -192,16 +349,11 @@ func (h *AccountHandler) Update(c *gin.Context) {
 	}
 
 	if err := h.service.Update(c.Request.Context(), account); err != nil {
-		switch err {
-		case domain.ErrAccountCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Account code already exists"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to update account")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account, appctx.GetLocale(c))))
 }
 
 // Delete handles DELETE /accounts/:id
@@ -214,7 +366,7 @@ func (h *AccountHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_004", err.Error()))
+		respondError(c, err, "Failed to delete account")
 		return
 	}
 
@@ -232,11 +384,11 @@ func (h *AccountHandler) GetChildren(c *gin.Context) {
 
 	children, err := h.service.GetChildren(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get child accounts")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccounts(children)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccounts(children, appctx.GetLocale(c))))
 }
 
 // CanDelete handles GET /accounts/:id/can-delete
@@ -250,7 +402,7 @@ func (h *AccountHandler) CanDelete(c *gin.Context) {
 
 	canDelete, reason, err := h.service.CanDelete(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Account not found"))
+		respondError(c, err, "Failed to check account deletion")
 		return
 	}
 
@@ -271,7 +423,7 @@ func (h *AccountHandler) Move(c *gin.Context) {
 
 	var req dto.MoveAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -286,9 +438,108 @@ func (h *AccountHandler) Move(c *gin.Context) {
 	}
 
 	if err := h.service.Move(c.Request.Context(), companyID, id, newParentID); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_005", err.Error()))
+		respondError(c, err, "Failed to move account")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"moved": true}))
 }
+
+// GetPostingRules handles GET /accounts/:id/posting-rules
+func (h *AccountHandler) GetPostingRules(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid account ID"))
+		return
+	}
+
+	rules, err := h.service.GetPostingRules(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get posting rules")
+		return
+	}
+
+	resp := dto.PostingRulesDTO{}
+	if rules != nil {
+		resp = dto.FromPostingRules(rules)
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
+
+// UpdatePostingRules handles PUT /accounts/:id/posting-rules
+func (h *AccountHandler) UpdatePostingRules(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid account ID"))
+		return
+	}
+
+	var req dto.PostingRulesDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	rules := req.ToPostingRules()
+	if err := h.service.UpdatePostingRules(c.Request.Context(), companyID, id, rules); err != nil {
+		respondError(c, err, "Failed to update posting rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPostingRules(rules)))
+}
+
+// DeletePostingRules handles DELETE /accounts/:id/posting-rules
+func (h *AccountHandler) DeletePostingRules(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid account ID"))
+		return
+	}
+
+	if err := h.service.UpdatePostingRules(c.Request.Context(), companyID, id, nil); err != nil {
+		respondError(c, err, "Failed to delete posting rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Posting rules removed successfully"}))
+}
+
+// SetStatementLine handles PUT /accounts/:id/statement-line
+// @Summary Map an account to a statement line
+// @Description Set the financial statement line of an account, or clear it to roll up with the parent
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Account ID"
+// @Param request body dto.SetStatementLineRequest true "Statement line"
+// @Success 200 {object} dto.Response{data=dto.AccountResponse}
+// @Failure 404 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Router /accounts/{id}/statement-line [put]
+func (h *AccountHandler) SetStatementLine(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid account ID"))
+		return
+	}
+
+	var req dto.SetStatementLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	account, err := h.service.SetStatementLine(c.Request.Context(), companyID, id, req.StatementLine)
+	if err != nil {
+		respondError(c, err, "Failed to set statement line")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAccount(account, appctx.GetLocale(c))))
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ActivityHandler handles HTTP requests for activity feeds and voucher comments
+type ActivityHandler struct {
+	service service.ActivityService
+}
+
+// NewActivityHandler creates a new ActivityHandler
+func NewActivityHandler(svc service.ActivityService) *ActivityHandler {
+	return &ActivityHandler{service: svc}
+}
+
+// RegisterRoutes registers activity routes
+func (h *ActivityHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/activity", h.List)
+
+	voucher := r.Group("/vouchers/:id")
+	{
+		voucher.GET("/activity", h.VoucherActivity)
+		voucher.POST("/comments", h.AddComment)
+	}
+}
+
+// List handles GET /activity, the company feed of status changes, comments,
+// attachment uploads and edits
+func (h *ActivityHandler) List(c *gin.Context) {
+	var req dto.ActivityListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PageSize == 0 {
+		req.PageSize = 50
+	}
+
+	filter := repository.ActivityFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}
+	for _, kind := range req.Kind {
+		filter.Kinds = append(filter.Kinds, domain.ActivityKind(kind))
+	}
+	if req.UserID != "" {
+		userID := uuid.MustParse(req.UserID)
+		filter.UserID = &userID
+	}
+	if req.DateFrom != "" {
+		from, err := time.Parse("2006-01-02", req.DateFrom)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid date_from"))
+			return
+		}
+		filter.DateFrom = &from
+	}
+	if req.DateTo != "" {
+		to, err := time.Parse("2006-01-02", req.DateTo)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid date_to"))
+			return
+		}
+		filter.DateTo = &to
+	}
+
+	activities, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve activity")
+		return
+	}
+
+	totalPages := int(total) / req.PageSize
+	if int(total)%req.PageSize > 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		dto.FromActivities(activities, appctx.GetLocale(c)),
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalPages: totalPages,
+		},
+	))
+}
+
+// VoucherActivity handles GET /vouchers/:id/activity
+func (h *ActivityHandler) VoucherActivity(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	activities, err := h.service.VoucherActivity(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve voucher activity")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromActivities(activities, appctx.GetLocale(c))))
+}
+
+// AddComment handles POST /vouchers/:id/comments
+func (h *ActivityHandler) AddComment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	var req dto.AddCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	activity, err := h.service.AddComment(c.Request.Context(), appctx.GetCompanyID(c), id, appctx.GetUserID(c),
+		req.Comment, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondError(c, err, "Failed to add comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromActivity(activity, appctx.GetLocale(c))))
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// AllocationRunHandler handles period-end cost allocation runs
+type AllocationRunHandler struct {
+	service service.AllocationRunService
+}
+
+// NewAllocationRunHandler creates a new AllocationRunHandler
+func NewAllocationRunHandler(svc service.AllocationRunService) *AllocationRunHandler {
+	return &AllocationRunHandler{service: svc}
+}
+
+// RegisterRoutes registers allocation run routes
+func (h *AllocationRunHandler) RegisterRoutes(r *gin.RouterGroup) {
+	runs := r.Group("/allocation-runs")
+	{
+		runs.GET("", h.List)
+		runs.POST("", h.Run)
+		runs.POST("/preview", h.Preview)
+		runs.GET("/:id", h.Get)
+		runs.POST("/:id/reverse", h.Reverse)
+	}
+}
+
+// Preview handles POST /allocation-runs/preview
+func (h *AllocationRunHandler) Preview(c *gin.Context) {
+	var req dto.AllocationRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodStart, periodEnd := req.Period()
+
+	run, err := h.service.Preview(c.Request.Context(), appctx.GetCompanyID(c), periodStart, periodEnd)
+	if err != nil {
+		respondError(c, err, "Failed to preview allocation")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Run handles POST /allocation-runs
+func (h *AllocationRunHandler) Run(c *gin.Context) {
+	var req dto.AllocationRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodStart, periodEnd := req.Period()
+
+	run, err := h.service.Run(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), periodStart, periodEnd)
+	if err != nil {
+		respondError(c, err, "Failed to run allocation")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(run))
+}
+
+// List handles GET /allocation-runs
+func (h *AllocationRunHandler) List(c *gin.Context) {
+	filter := repository.AllocationRunFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.AllocationRunStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid allocation run status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	runs, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to list allocation runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		runs,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /allocation-runs/:id
+func (h *AllocationRunHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid allocation run ID")
+	if !ok {
+		return
+	}
+
+	run, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get allocation run")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Reverse handles POST /allocation-runs/:id/reverse
+func (h *AllocationRunHandler) Reverse(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid allocation run ID")
+	if !ok {
+		return
+	}
+
+	run, err := h.service.Reverse(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to reverse allocation run")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
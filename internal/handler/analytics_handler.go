@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// AnalyticsHandler handles HTTP requests for the KPI series of dashboard charts
+type AnalyticsHandler struct {
+	service service.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler
+func NewAnalyticsHandler(svc service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: svc}
+}
+
+// RegisterRoutes registers analytics routes
+func (h *AnalyticsHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/analytics/timeseries", h.TimeSeries)
+}
+
+// TimeSeries returns a KPI series
+// @Summary Get KPI time series
+// @Description Revenue or expense per period, or the cash balance at the end of each period, computed from the ledger balances (최대 60개월)
+// @Tags analytics
+// @Produce json
+// @Param metric query string true "revenue, expense or cash"
+// @Param granularity query string false "month (default), quarter or year"
+// @Param from query string false "First month (YYYY-MM, default: 11 months before to)"
+// @Param to query string false "Last month (YYYY-MM, default: the current month)"
+// @Param department_id query string false "Department ID (revenue and expense only)"
+// @Param project_id query string false "Project ID (revenue and expense only)"
+// @Success 200 {object} dto.Response{data=domain.KPISeries}
+// @Router /api/v1/analytics/timeseries [get]
+func (h *AnalyticsHandler) TimeSeries(c *gin.Context) {
+	q := domain.KPISeriesQuery{
+		Metric:      domain.KPIMetric(c.Query("metric")),
+		Granularity: domain.KPIGranularity(c.DefaultQuery("granularity", string(domain.KPIGranularityMonth))),
+	}
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := time.Time{}
+	for name, month := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid "+name+" month, expected YYYY-MM"))
+			return
+		}
+		*month = parsed
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 1-domain.KPISeriesDefaultMonths, 0)
+	}
+	q.FromYear, q.FromMonth = from.Year(), int(from.Month())
+	q.ToYear, q.ToMonth = to.Year(), int(to.Month())
+
+	for name, id := range map[string]**uuid.UUID{"department_id": &q.DepartmentID, "project_id": &q.ProjectID} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid "+name))
+			return
+		}
+		*id = &parsed
+	}
+
+	series, err := h.service.TimeSeries(c.Request.Context(), appctx.GetCompanyID(c), q)
+	if err != nil {
+		respondError(c, err, "Failed to get time series")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(series))
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// APMatchingHandler handles matching of purchase tax invoices to purchase vouchers
+type APMatchingHandler struct {
+	service service.APMatchingService
+}
+
+// NewAPMatchingHandler creates a new APMatchingHandler
+func NewAPMatchingHandler(svc service.APMatchingService) *APMatchingHandler {
+	return &APMatchingHandler{service: svc}
+}
+
+// RegisterRoutes registers AP matching routes
+func (h *APMatchingHandler) RegisterRoutes(r *gin.RouterGroup) {
+	matching := r.Group("/ap-matching")
+	{
+		matching.POST("/run", h.Run)
+		matching.GET("/worklist", h.Worklist)
+	}
+
+	tax := r.Group("/tax-invoices")
+	{
+		tax.POST("/:id/match", h.Match)
+		tax.DELETE("/:id/match", h.Unmatch)
+	}
+}
+
+// Run handles POST /ap-matching/run
+func (h *APMatchingHandler) Run(c *gin.Context) {
+	var req dto.RunAPMatchingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	from, to, ok := parseDateRangeParams(c, req.From, req.To)
+	if !ok {
+		return
+	}
+
+	run, err := h.service.AutoMatch(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), from, to, req.Tolerance())
+	if err != nil {
+		respondError(c, err, "Failed to match tax invoices")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Worklist handles GET /ap-matching/worklist
+func (h *APMatchingHandler) Worklist(c *gin.Context) {
+	var req dto.APWorklistRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+
+	from, to, ok := parseDateRangeParams(c, req.From, req.To)
+	if !ok {
+		return
+	}
+
+	worklist, err := h.service.Worklist(c.Request.Context(), appctx.GetCompanyID(c), from, to)
+	if err != nil {
+		respondError(c, err, "Failed to get matching worklist")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(worklist))
+}
+
+// Match handles POST /tax-invoices/:id/match
+func (h *APMatchingHandler) Match(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax invoice ID"))
+		return
+	}
+
+	var req dto.MatchTaxInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	match, err := h.service.Match(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, uuid.MustParse(req.VoucherID))
+	if err != nil {
+		respondError(c, err, "Failed to match tax invoice")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(match))
+}
+
+// Unmatch handles DELETE /tax-invoices/:id/match
+func (h *APMatchingHandler) Unmatch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax invoice ID"))
+		return
+	}
+
+	if err := h.service.Unmatch(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id); err != nil {
+		respondError(c, err, "Failed to unmatch tax invoice")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ApprovalHandler handles the approval inbox of the mobile app
+type ApprovalHandler struct {
+	service service.ApprovalService
+}
+
+// NewApprovalHandler creates a new ApprovalHandler
+func NewApprovalHandler(svc service.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{service: svc}
+}
+
+// RegisterRoutes registers approval routes
+func (h *ApprovalHandler) RegisterRoutes(r *gin.RouterGroup) {
+	approvals := r.Group("/approvals")
+	{
+		approvals.GET("/inbox", h.Inbox)
+		approvals.POST("/vouchers/:id/approve", h.ApproveVoucher)
+		approvals.POST("/vouchers/:id/reject", h.RejectVoucher)
+		approvals.POST("/devices", h.RegisterDevice)
+		approvals.DELETE("/devices/:token", h.UnregisterDevice)
+	}
+}
+
+// Inbox handles GET /approvals/inbox
+// @Summary Get the approval inbox
+// @Description Documents waiting for the approval of the current user, oldest request first
+// @Tags approvals
+// @Produce json
+// @Success 200 {object} dto.Response{data=dto.ApprovalInboxResponse}
+// @Router /api/v1/approvals/inbox [get]
+func (h *ApprovalHandler) Inbox(c *gin.Context) {
+	items, err := h.service.Inbox(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to get approval inbox")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromApprovalItems(items)))
+}
+
+// ApproveVoucher handles POST /approvals/vouchers/:id/approve
+// @Summary Approve a voucher with the signing PIN
+// @Description One-tap approval; the PIN confirmation is recorded as the approval signature
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param body body dto.ApprovalActionRequest true "Signing PIN"
+// @Success 200 {object} dto.Response{data=dto.VoucherResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/approvals/vouchers/{id}/approve [post]
+func (h *ApprovalHandler) ApproveVoucher(c *gin.Context) {
+	h.act(c, h.service.ApproveVoucher, "Failed to approve voucher")
+}
+
+// RejectVoucher handles POST /approvals/vouchers/:id/reject
+// @Summary Reject a voucher with the signing PIN
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param body body dto.ApprovalActionRequest true "Signing PIN and rejection reason"
+// @Success 200 {object} dto.Response{data=dto.VoucherResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/approvals/vouchers/{id}/reject [post]
+func (h *ApprovalHandler) RejectVoucher(c *gin.Context) {
+	h.act(c, h.service.RejectVoucher, "Failed to reject voucher")
+}
+
+// voucherAction is a one-tap action of the approval service on a voucher
+type voucherAction func(ctx context.Context, companyID, userID, voucherID uuid.UUID, req service.ApprovalActionRequest) (*domain.Voucher, error)
+
+// act binds a one-tap action on a voucher and runs it
+func (h *ApprovalHandler) act(c *gin.Context, action voucherAction, msg string) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	var req dto.ApprovalActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	voucher, err := action(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, service.ApprovalActionRequest{
+		PIN:       req.PIN,
+		Reason:    req.Reason,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		respondError(c, err, msg)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
+}
+
+// RegisterDevice handles POST /approvals/devices
+// @Summary Register a device for push notifications
+// @Description Register the FCM or APNs token of the app to be notified of approval requests
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Param body body dto.RegisterPushDeviceRequest true "Device"
+// @Success 200 {object} dto.Response{data=dto.PushDeviceResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/approvals/devices [post]
+func (h *ApprovalHandler) RegisterDevice(c *gin.Context) {
+	var req dto.RegisterPushDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	device, err := h.service.RegisterDevice(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c),
+		domain.PushPlatform(req.Platform), req.Token, req.DeviceName)
+	if err != nil {
+		respondError(c, err, "Failed to register device")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPushDevice(device)))
+}
+
+// UnregisterDevice handles DELETE /approvals/devices/:token, e.g. on sign-out
+func (h *ApprovalHandler) UnregisterDevice(c *gin.Context) {
+	if err := h.service.UnregisterDevice(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), c.Param("token")); err != nil {
+		respondError(c, err, "Failed to unregister device")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
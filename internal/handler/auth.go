@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -11,7 +13,9 @@ import (
 	"github.com/saintgo7/saas-kerp/internal/auth"
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/domain"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
 	"github.com/saintgo7/saas-kerp/internal/handler/response"
+	"github.com/saintgo7/saas-kerp/internal/provider"
 	"github.com/saintgo7/saas-kerp/internal/repository"
 	"github.com/saintgo7/saas-kerp/internal/service"
 )
@@ -21,21 +25,25 @@ type AuthHandler struct {
 	*BaseHandler
 	jwtService  *auth.JWTService
 	authService *service.AuthService
+	onboarding  service.OnboardingService
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(db *gorm.DB, redis *redis.Client, logger *zap.Logger, jwtService *auth.JWTService) *AuthHandler {
+func NewAuthHandler(db *gorm.DB, redis *redis.Client, logger *zap.Logger, jwtService *auth.JWTService, onboarding service.OnboardingService) *AuthHandler {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	membershipRepo := repository.NewUserCompanyMembershipRepository(db)
+	companyRepo := repository.NewCompanyRepository(db)
 
 	// Initialize auth service
-	authService := service.NewAuthService(userRepo, refreshTokenRepo, jwtService, logger)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, membershipRepo, companyRepo, jwtService, logger)
 
 	return &AuthHandler{
 		BaseHandler: NewBaseHandler(db, redis, logger),
 		jwtService:  jwtService,
 		authService: authService,
+		onboarding:  onboarding,
 	}
 }
 
@@ -67,17 +75,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		Password: req.Password,
 	})
 	if err != nil {
-		switch err {
-		case domain.ErrInvalidCredentials:
-			response.Unauthorized(c, "Invalid email or password")
-		case domain.ErrUserInactive:
-			response.Forbidden(c, "User account is inactive")
-		case domain.ErrUserLocked:
-			response.Forbidden(c, "User account is locked")
-		default:
-			h.Logger.Error("login failed", zap.Error(err))
-			response.InternalError(c, "Login failed")
-		}
+		h.respondAuthError(c, err, "Login failed")
 		return
 	}
 
@@ -101,15 +99,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		RefreshToken: req.RefreshToken,
 	})
 	if err != nil {
-		switch err {
-		case domain.ErrRefreshTokenNotFound:
-			response.Unauthorized(c, "Invalid refresh token")
-		case domain.ErrRefreshTokenExpired:
-			response.Unauthorized(c, "Refresh token expired")
-		default:
-			h.Logger.Error("refresh failed", zap.Error(err))
-			response.InternalError(c, "Token refresh failed")
-		}
+		h.respondAuthError(c, err, "Token refresh failed")
 		return
 	}
 
@@ -149,6 +139,51 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	})
 }
 
+// JWKS publishes the public keys access tokens are verified with.
+// The key set is returned unwrapped, as JWKS clients expect.
+// GET /.well-known/jwks.json
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtService.JWKS())
+}
+
+// Companies lists the companies the current user can switch to
+// GET /api/v1/auth/companies
+func (h *AuthHandler) Companies(c *gin.Context) {
+	result, err := h.authService.ListCompanies(c.Request.Context(), appctx.GetUserID(c), appctx.GetCompanyID(c))
+	if err != nil {
+		h.respondAuthError(c, err, "Failed to list companies")
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// SwitchCompanyRequest represents a company switch request
+type SwitchCompanyRequest struct {
+	CompanyID string `json:"company_id" binding:"required,uuid"`
+}
+
+// SwitchCompany issues a new token pair scoped to another company of the user
+// POST /api/v1/auth/switch-company
+func (h *AuthHandler) SwitchCompany(c *gin.Context) {
+	var req SwitchCompanyRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.authService.SwitchCompany(c.Request.Context(), service.SwitchCompanyInput{
+		UserID:    appctx.GetUserID(c),
+		CompanyID: uuid.MustParse(req.CompanyID),
+	})
+	if err != nil {
+		h.respondAuthError(c, err, "Company switch failed")
+		return
+	}
+
+	response.OK(c, result)
+}
+
 // ChangePasswordRequest represents a password change request
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password" binding:"required"`
@@ -242,25 +277,161 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Phone:          req.Phone,
 	})
 	if err != nil {
+		h.respondAuthError(c, err, "Registration failed")
+		return
+	}
+
+	// Send the email verification link; registration succeeds regardless
+	if err := h.onboarding.SendEmailVerification(c.Request.Context(), result.User.ID); err != nil && err != provider.ErrProviderUnavailable {
+		h.Logger.Warn("failed to send verification email", zap.Error(err))
+	}
+
+	response.Created(c, result)
+}
+
+// GetInvitation returns the invitation behind a token so the invitee can review it
+// GET /api/v1/auth/invitations/:token
+func (h *AuthHandler) GetInvitation(c *gin.Context) {
+	invite, err := h.onboarding.GetInvitation(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.respondUserTokenError(c, err, "Invitation")
+		return
+	}
+
+	data := gin.H{
+		"email":      invite.Email,
+		"role":       invite.Role,
+		"expires_at": invite.ExpiresAt,
+	}
+	if invite.Company != nil {
+		data["company_name"] = invite.Company.Name
+	}
+	response.OK(c, data)
+}
+
+// AcceptInvitationRequest represents an invitation acceptance request.
+// Invitees who already have an account enter its password; name is then not required.
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// AcceptInvitation creates the invitee's account or company membership
+// POST /api/v1/auth/invitations/accept
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.onboarding.AcceptInvitation(c.Request.Context(), service.AcceptInvitationInput{
+		Token:    req.Token,
+		Name:     req.Name,
+		Password: req.Password,
+	})
+	if err != nil {
+		if respondPlanLimitExceeded(c, err) {
+			return
+		}
 		switch err {
-		case domain.ErrUserEmailExists:
-			response.Conflict(c, "Email already registered")
-		case domain.ErrEmailRequired:
-			response.BadRequest(c, "Email is required")
-		case domain.ErrPasswordRequired:
-			response.BadRequest(c, "Password is required")
+		case domain.ErrInvalidCredentials:
+			response.Unauthorized(c, "Password does not match the existing account")
+		case domain.ErrAlreadyMember:
+			response.Conflict(c, "User is already a member of the company")
 		case domain.ErrNameRequired:
 			response.BadRequest(c, "Name is required")
 		case domain.ErrPasswordTooShort:
 			response.BadRequest(c, "Password must be at least 8 characters")
 		default:
-			h.Logger.Error("registration failed", zap.Error(err))
-			response.InternalError(c, "Registration failed")
+			h.respondUserTokenError(c, err, "Invitation")
 		}
 		return
 	}
 
-	response.Created(c, result)
+	response.OK(c, gin.H{
+		"user_id":    user.ID,
+		"company_id": user.CompanyID,
+		"email":      user.Email,
+		"message":    "Invitation accepted",
+	})
+}
+
+// VerifyEmailRequest represents an email verification request
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// VerifyEmail confirms a self-registered user's email address
+// POST /api/v1/auth/verify-email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.onboarding.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		h.respondUserTokenError(c, err, "Verification link")
+		return
+	}
+
+	response.OK(c, gin.H{
+		"message": "Email verified",
+	})
+}
+
+// ResendVerification sends a new email verification link to the current user
+// POST /api/v1/auth/verify-email/resend
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	if err := h.onboarding.SendEmailVerification(c.Request.Context(), appctx.GetUserID(c)); err != nil {
+		switch err {
+		case domain.ErrEmailVerified:
+			response.Conflict(c, "Email is already verified")
+		case domain.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		case provider.ErrProviderUnavailable:
+			response.ServiceUnavailable(c, "Email delivery is not configured")
+		default:
+			h.Logger.Error("resend verification failed", zap.Error(err))
+			response.InternalError(c, "Failed to send verification email")
+		}
+		return
+	}
+
+	response.OK(c, gin.H{
+		"message": "Verification email sent",
+	})
+}
+
+// authErrors maps the errors of the auth service. A membership that does not
+// exist denies access to the company rather than being a missing resource.
+var authErrors = errorMap.Extend().
+	Register(apperrors.CodeForbidden, domain.ErrMembershipNotFound)
+
+// respondAuthError writes the response of an auth service error as authErrors
+// maps it, in the envelope of the auth endpoints
+func (h *AuthHandler) respondAuthError(c *gin.Context, err error, message string) {
+	appErr, known := authErrors.Map(err)
+	if !known {
+		h.Logger.Error(message, zap.Error(err))
+		appErr = apperrors.Wrap(apperrors.CodeInternal, message, err)
+	}
+	response.FromError(c, appErr)
+}
+
+// respondUserTokenError maps invitation/verification token errors to responses
+func (h *AuthHandler) respondUserTokenError(c *gin.Context, err error, subject string) {
+	switch err {
+	case domain.ErrUserTokenNotFound:
+		response.NotFound(c, subject+" not found")
+	case domain.ErrUserTokenExpired:
+		response.Error(c, http.StatusGone, apperrors.CodeGone, subject+" has expired")
+	case domain.ErrUserTokenUsed:
+		response.Error(c, http.StatusGone, apperrors.CodeGone, subject+" has already been used")
+	default:
+		h.Logger.Error("user token request failed", zap.Error(err))
+		response.InternalError(c, "Request failed")
+	}
 }
 
 // ForgotPasswordRequest represents a forgot password request
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// BackupHandler handles HTTP requests for tenant backups and their restores
+// into the staging database. It is served to super admins only.
+type BackupHandler struct {
+	service service.BackupService
+}
+
+// NewBackupHandler creates a new BackupHandler
+func NewBackupHandler(svc service.BackupService) *BackupHandler {
+	return &BackupHandler{service: svc}
+}
+
+// RegisterRoutes registers backup routes
+func (h *BackupHandler) RegisterRoutes(r *gin.RouterGroup) {
+	companies := r.Group("/admin/companies/:company_id/backups")
+	{
+		companies.GET("", h.List)
+		companies.POST("", h.Request)
+	}
+
+	backups := r.Group("/admin/backups")
+	{
+		backups.GET("/:id", h.GetByID)
+		backups.POST("/:id/restores", h.RequestRestore)
+		backups.GET("/:id/restores/:restore_id", h.GetRestore)
+	}
+}
+
+// List handles GET /admin/companies/:company_id/backups
+func (h *BackupHandler) List(c *gin.Context) {
+	companyID, err := uuid.Parse(c.Param("company_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid company ID"))
+		return
+	}
+
+	backups, err := h.service.List(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to list backups")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBackups(backups)))
+}
+
+// Request handles POST /admin/companies/:company_id/backups
+func (h *BackupHandler) Request(c *gin.Context) {
+	companyID, err := uuid.Parse(c.Param("company_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid company ID"))
+		return
+	}
+
+	backup, err := h.service.Request(c.Request.Context(), companyID, appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to request backup")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse(dto.FromBackup(backup)))
+}
+
+// GetByID handles GET /admin/backups/:id
+func (h *BackupHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid backup ID"))
+		return
+	}
+
+	backup, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to get backup")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBackup(backup)))
+}
+
+// RequestRestore handles POST /admin/backups/:id/restores
+func (h *BackupHandler) RequestRestore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid backup ID"))
+		return
+	}
+
+	var req dto.RequestBackupRestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	restore, err := h.service.RequestRestore(c.Request.Context(), id, appctx.GetUserID(c), req.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to request backup restore")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse(dto.FromBackupRestore(restore)))
+}
+
+// GetRestore handles GET /admin/backups/:id/restores/:restore_id
+func (h *BackupHandler) GetRestore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid backup ID"))
+		return
+	}
+	restoreID, err := uuid.Parse(c.Param("restore_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid restore ID"))
+		return
+	}
+
+	restore, err := h.service.GetRestore(c.Request.Context(), id, restoreID)
+	if err != nil {
+		respondError(c, err, "Failed to get backup restore")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBackupRestore(restore)))
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// BankAccountHandler handles the bank accounts of the company and their balances
+type BankAccountHandler struct {
+	service service.BankAccountService
+}
+
+// NewBankAccountHandler creates a new BankAccountHandler
+func NewBankAccountHandler(svc service.BankAccountService) *BankAccountHandler {
+	return &BankAccountHandler{service: svc}
+}
+
+// RegisterRoutes registers bank account routes
+func (h *BankAccountHandler) RegisterRoutes(r *gin.RouterGroup) {
+	banks := r.Group("/bank-accounts")
+	{
+		banks.GET("", h.List)
+		banks.POST("", h.Create)
+		banks.GET("/balances", h.Balances)
+		banks.GET("/:id", h.Get)
+		banks.PUT("/:id", h.Update)
+		banks.DELETE("/:id", h.Delete)
+		banks.GET("/:id/balances", h.ListBalances)
+		banks.POST("/:id/balances", h.RecordBalance)
+	}
+}
+
+// Create handles POST /bank-accounts
+func (h *BankAccountHandler) Create(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.BankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	bank := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), bank); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create bank account")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromBankAccount(bank)))
+}
+
+// List handles GET /bank-accounts
+func (h *BankAccountHandler) List(c *gin.Context) {
+	activeOnly := c.Query("active") == "true"
+	banks, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c), activeOnly)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list bank accounts")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBankAccounts(banks)))
+}
+
+// Get handles GET /bank-accounts/:id
+func (h *BankAccountHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid bank account ID")
+	if !ok {
+		return
+	}
+
+	bank, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get bank account")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBankAccount(bank)))
+}
+
+// Update handles PUT /bank-accounts/:id
+func (h *BankAccountHandler) Update(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseUUIDParam(c, "id", "Invalid bank account ID")
+	if !ok {
+		return
+	}
+
+	var req dto.BankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	bank := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	bank.ID = id
+	if err := h.service.Update(c.Request.Context(), bank); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to update bank account")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBankAccount(bank)))
+}
+
+// Delete handles DELETE /bank-accounts/:id
+func (h *BankAccountHandler) Delete(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseUUIDParam(c, "id", "Invalid bank account ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to delete bank account")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// ListBalances handles GET /bank-accounts/:id/balances
+func (h *BankAccountHandler) ListBalances(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid bank account ID")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit > 366 {
+		limit = 366
+	}
+
+	balances, err := h.service.ListBalances(c.Request.Context(), appctx.GetCompanyID(c), id, limit)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list bank balances")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(balances))
+}
+
+// RecordBalance handles POST /bank-accounts/:id/balances.
+// Statement imports and account inquiries report their balances here too.
+func (h *BankAccountHandler) RecordBalance(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid bank account ID")
+	if !ok {
+		return
+	}
+
+	var req dto.RecordBankBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	bank, err := h.service.RecordBalance(c.Request.Context(), appctx.GetCompanyID(c), id, appctx.GetUserID(c), req.ToDomain())
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record bank balance")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromBankAccount(bank)))
+}
+
+// Balances handles GET /bank-accounts/balances
+func (h *BankAccountHandler) Balances(c *gin.Context) {
+	var req dto.BankBalancesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	summaries, err := h.service.Balances(c.Request.Context(), appctx.GetCompanyID(c), asOf)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get bank balances")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBankBalanceSummaries(summaries)))
+}
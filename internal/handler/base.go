@@ -10,6 +10,7 @@ import (
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/database"
 	"github.com/saintgo7/saas-kerp/internal/handler/response"
+	"github.com/saintgo7/saas-kerp/internal/validation"
 )
 
 // BaseHandler provides common functionality for all handlers
@@ -51,7 +52,7 @@ func (h *BaseHandler) ScopedDB(c *gin.Context) *gorm.DB {
 // BindJSON binds JSON body to the given struct and handles errors
 func (h *BaseHandler) BindJSON(c *gin.Context, obj interface{}) bool {
 	if err := c.ShouldBindJSON(obj); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ValidationError(c, validation.FieldErrors(err))
 		return false
 	}
 	return true
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// CashBookHandler handles HTTP requests for the cash and bank book
+type CashBookHandler struct {
+	service service.CashBookService
+}
+
+// NewCashBookHandler creates a new CashBookHandler
+func NewCashBookHandler(svc service.CashBookService) *CashBookHandler {
+	return &CashBookHandler{service: svc}
+}
+
+// RegisterRoutes registers cash book routes
+func (h *CashBookHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/reports/cash-book", h.GetCashBook)
+}
+
+// GetCashBook returns the cash and bank book
+// @Summary Get cash book
+// @Description Chronological register of designated cash/bank accounts with running balance and daily totals (현금출납장)
+// @Tags reports
+// @Produce json
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Param account_id query string false "Cash account ID (default: all designated cash accounts)"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/reports/cash-book [get]
+func (h *CashBookHandler) GetCashBook(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	var req dto.CashBookRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+
+	from, to, ok := parseDateRangeParams(c, req.From, req.To)
+	if !ok {
+		return
+	}
+
+	var accountID *uuid.UUID
+	if req.AccountID != "" {
+		id := uuid.MustParse(req.AccountID)
+		accountID = &id
+	}
+
+	books, err := h.service.GetCashBook(c.Request.Context(), companyID, accountID, from, to)
+	if err != nil {
+		respondError(c, err, "Failed to generate cash book")
+		return
+	}
+
+	resp := dto.FromCashBook(books)
+	resp.CompanyID = companyID.String()
+	resp.FromDate = from.Format("2006-01-02")
+	resp.ToDate = to.Format("2006-01-02")
+	resp.GeneratedAt = dto.ReportGeneratedAt()
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// CloseChecklistHandler handles HTTP requests for the month-end close checklist
+type CloseChecklistHandler struct {
+	service service.CloseChecklistService
+}
+
+// NewCloseChecklistHandler creates a new CloseChecklistHandler
+func NewCloseChecklistHandler(svc service.CloseChecklistService) *CloseChecklistHandler {
+	return &CloseChecklistHandler{service: svc}
+}
+
+// RegisterRoutes registers close checklist routes
+func (h *CloseChecklistHandler) RegisterRoutes(r *gin.RouterGroup) {
+	checklist := r.Group("/close-checklist")
+	{
+		// Task configuration
+		checklist.GET("/tasks", h.ListTasks)
+		checklist.POST("/tasks", h.CreateTask)
+		checklist.GET("/tasks/:id", h.GetTask)
+		checklist.PUT("/tasks/:id", h.UpdateTask)
+		checklist.DELETE("/tasks/:id", h.DeleteTask)
+
+		// Per-period status
+		checklist.GET("/periods/:year/:month", h.GetPeriodChecklist)
+		checklist.PUT("/periods/:year/:month/tasks/:task_id", h.UpdatePeriodTask)
+	}
+}
+
+// ListTasks handles GET /close-checklist/tasks
+func (h *CloseChecklistHandler) ListTasks(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	activeOnly := c.Query("active_only") == "true"
+
+	tasks, err := h.service.ListTasks(c.Request.Context(), companyID, activeOnly)
+	if err != nil {
+		respondError(c, err, "Failed to list checklist tasks")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCloseTasks(tasks)))
+}
+
+// CreateTask handles POST /close-checklist/tasks
+func (h *CloseChecklistHandler) CreateTask(c *gin.Context) {
+	var req dto.CreateCloseTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	task := req.ToCloseChecklistTask(appctx.GetCompanyID(c))
+
+	if err := h.service.CreateTask(c.Request.Context(), task); err != nil {
+		respondError(c, err, "Failed to create checklist task")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromCloseTask(task)))
+}
+
+// GetTask handles GET /close-checklist/tasks/:id
+func (h *CloseChecklistHandler) GetTask(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_005", "Invalid task ID"))
+		return
+	}
+
+	task, err := h.service.GetTask(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get checklist task")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCloseTask(task)))
+}
+
+// UpdateTask handles PUT /close-checklist/tasks/:id
+func (h *CloseChecklistHandler) UpdateTask(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_005", "Invalid task ID"))
+		return
+	}
+
+	var req dto.UpdateCloseTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	task, err := h.service.GetTask(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get checklist task")
+		return
+	}
+
+	req.ApplyTo(task)
+
+	if err := h.service.UpdateTask(c.Request.Context(), task); err != nil {
+		respondError(c, err, "Failed to update checklist task")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCloseTask(task)))
+}
+
+// DeleteTask handles DELETE /close-checklist/tasks/:id
+func (h *CloseChecklistHandler) DeleteTask(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_005", "Invalid task ID"))
+		return
+	}
+
+	if err := h.service.DeleteTask(c.Request.Context(), companyID, id); err != nil {
+		respondError(c, err, "Failed to delete checklist task")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Checklist task deleted successfully"}))
+}
+
+// GetPeriodChecklist handles GET /close-checklist/periods/:year/:month
+func (h *CloseChecklistHandler) GetPeriodChecklist(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	year, month, ok := parsePeriodParams(c)
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.GetPeriodChecklist(c.Request.Context(), companyID, year, month)
+	if err != nil {
+		respondError(c, err, "Failed to get period checklist")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPeriodChecklist(checklist)))
+}
+
+// UpdatePeriodTask handles PUT /close-checklist/periods/:year/:month/tasks/:task_id
+func (h *CloseChecklistHandler) UpdatePeriodTask(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	userID := appctx.GetUserID(c)
+	year, month, ok := parsePeriodParams(c)
+	if !ok {
+		return
+	}
+	taskID, err := uuid.Parse(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_005", "Invalid task ID"))
+		return
+	}
+
+	var req dto.UpdatePeriodTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	periodTask, err := h.service.UpdatePeriodTask(c.Request.Context(), companyID, taskID, year, month, domain.CloseTaskStatus(req.Status), req.Note, userID)
+	if err != nil {
+		respondError(c, err, "Failed to update period checklist task")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPeriodCloseTask(periodTask.Task, periodTask)))
+}
+
+// parsePeriodParams parses :year and :month path parameters
+func parsePeriodParams(c *gin.Context) (int, int, bool) {
+	year, ok := parseFiscalYear(c, c.Param("year"))
+	if !ok {
+		return 0, 0, false
+	}
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil || month < 1 || month > 12 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid fiscal month"))
+		return 0, 0, false
+	}
+	return year, month, true
+}
+
+// parseFiscalYear parses a fiscal year from a path or query parameter
+func parseFiscalYear(c *gin.Context, value string) (int, bool) {
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 2000 || year > 2100 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid fiscal year"))
+		return 0, false
+	}
+	return year, true
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/gin-gonic/gin"
 
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
-	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/dto"
 	"github.com/saintgo7/saas-kerp/internal/service"
 )
@@ -27,8 +26,6 @@ func (h *CompanyHandler) RegisterRoutes(r *gin.RouterGroup) {
 	{
 		company.GET("", h.Get)
 		company.PUT("", h.Update)
-		company.GET("/settings", h.GetSettings)
-		company.PUT("/settings", h.UpdateSettings)
 	}
 }
 
@@ -38,11 +35,7 @@ func (h *CompanyHandler) Get(c *gin.Context) {
 
 	company, err := h.service.GetByID(c.Request.Context(), companyID)
 	if err != nil {
-		if err == domain.ErrCompanyNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Company not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get company")
 		return
 	}
 
@@ -55,18 +48,14 @@ func (h *CompanyHandler) Update(c *gin.Context) {
 
 	var req dto.UpdateCompanyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	// Get existing company
 	company, err := h.service.GetByID(c.Request.Context(), companyID)
 	if err != nil {
-		if err == domain.ErrCompanyNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Company not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get company")
 		return
 	}
 
@@ -74,80 +63,9 @@ func (h *CompanyHandler) Update(c *gin.Context) {
 	req.ApplyTo(company)
 
 	if err := h.service.Update(c.Request.Context(), company); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to update company")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCompany(company)))
 }
-
-// GetSettings handles GET /company/settings
-func (h *CompanyHandler) GetSettings(c *gin.Context) {
-	companyID := appctx.GetCompanyID(c)
-
-	company, err := h.service.GetByID(c.Request.Context(), companyID)
-	if err != nil {
-		if err == domain.ErrCompanyNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Company not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		return
-	}
-
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.CompanySettingsResponse{
-		FiscalYearStart:     company.Settings.FiscalYearStart,
-		DefaultCurrency:     company.Settings.DefaultCurrency,
-		DecimalPlaces:       company.Settings.DecimalPlaces,
-		TaxRate:             company.Settings.TaxRate,
-		VoucherAutoNumber:   company.Settings.VoucherAutoNumber,
-		VoucherNumberFormat: company.Settings.VoucherNumberFormat,
-		InvoicePrefix:       company.Settings.InvoicePrefix,
-		Timezone:            company.Settings.Timezone,
-		DateFormat:          company.Settings.DateFormat,
-		Language:            company.Settings.Language,
-	}))
-}
-
-// UpdateSettings handles PUT /company/settings
-func (h *CompanyHandler) UpdateSettings(c *gin.Context) {
-	companyID := appctx.GetCompanyID(c)
-
-	var req dto.UpdateCompanySettingsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
-		return
-	}
-
-	// Get existing company
-	company, err := h.service.GetByID(c.Request.Context(), companyID)
-	if err != nil {
-		if err == domain.ErrCompanyNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Company not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		return
-	}
-
-	// Apply settings updates
-	req.ApplyTo(company)
-
-	if err := h.service.UpdateSettings(c.Request.Context(), company); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		return
-	}
-
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.CompanySettingsResponse{
-		FiscalYearStart:     company.Settings.FiscalYearStart,
-		DefaultCurrency:     company.Settings.DefaultCurrency,
-		DecimalPlaces:       company.Settings.DecimalPlaces,
-		TaxRate:             company.Settings.TaxRate,
-		VoucherAutoNumber:   company.Settings.VoucherAutoNumber,
-		VoucherNumberFormat: company.Settings.VoucherNumberFormat,
-		InvoicePrefix:       company.Settings.InvoicePrefix,
-		Timezone:            company.Settings.Timezone,
-		DateFormat:          company.Settings.DateFormat,
-		Language:            company.Settings.Language,
-	}))
-}
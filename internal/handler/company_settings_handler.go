@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// CompanySettingsHandler handles HTTP requests for company settings
+type CompanySettingsHandler struct {
+	service service.CompanySettingsService
+}
+
+// NewCompanySettingsHandler creates a new CompanySettingsHandler
+func NewCompanySettingsHandler(svc service.CompanySettingsService) *CompanySettingsHandler {
+	return &CompanySettingsHandler{service: svc}
+}
+
+// RegisterRoutes registers company settings routes
+func (h *CompanySettingsHandler) RegisterRoutes(r *gin.RouterGroup) {
+	settings := r.Group("/company/settings")
+	{
+		settings.GET("", h.Get)
+		settings.PUT("", h.Update)
+		settings.GET("/approval-exemption", h.GetApprovalExemption)
+		settings.PUT("/approval-exemption", h.UpdateApprovalExemption)
+	}
+}
+
+// Get handles GET /company/settings
+func (h *CompanySettingsHandler) Get(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	settings, err := h.service.Get(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to process company settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCompanySettings(settings)))
+}
+
+// Update handles PUT /company/settings. The settings hold the company's
+// approval and close controls, so only admins may change them.
+func (h *CompanySettingsHandler) Update(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	companyID := appctx.GetCompanyID(c)
+
+	var req dto.UpdateCompanySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	settings, err := h.service.Update(c.Request.Context(), companyID, req.ApplyTo)
+	if err != nil {
+		respondError(c, err, "Failed to process company settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCompanySettings(settings)))
+}
+
+// GetApprovalExemption handles GET /company/settings/approval-exemption
+func (h *CompanySettingsHandler) GetApprovalExemption(c *gin.Context) {
+	settings, err := h.service.Get(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to process company settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromApprovalExemption(settings)))
+}
+
+// UpdateApprovalExemption handles PUT /company/settings/approval-exemption.
+// The policy is replaced as a whole; only admins may change it.
+func (h *CompanySettingsHandler) UpdateApprovalExemption(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	var req dto.UpdateApprovalExemptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	settings, err := h.service.Update(c.Request.Context(), appctx.GetCompanyID(c), req.ApplyTo)
+	if err != nil {
+		respondError(c, err, "Failed to process company settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromApprovalExemption(settings)))
+}
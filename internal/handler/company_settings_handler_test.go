@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+)
+
+// newCompanySettingsRouter serves the settings routes to a user with the given role
+func newCompanySettingsRouter(svc *mocks.MockCompanySettingsService, companyID uuid.UUID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		appctx.SetCompanyID(c, companyID)
+		appctx.SetUserID(c, uuid.New())
+		appctx.SetRoles(c, []string{role})
+		c.Next()
+	})
+	NewCompanySettingsHandler(svc).RegisterRoutes(router.Group("/api/v1"))
+	return router
+}
+
+func TestCompanySettingsHandler_UpdateRequiresAdmin(t *testing.T) {
+	routes := []struct {
+		path string
+		body string
+	}{
+		{"/api/v1/company/settings", `{"require_approval": false}`},
+		{"/api/v1/company/settings/approval-exemption", `{"enabled": false}`},
+	}
+
+	for _, route := range routes {
+		t.Run(route.path+" refuses a non-admin", func(t *testing.T) {
+			svc := new(mocks.MockCompanySettingsService)
+			router := newCompanySettingsRouter(svc, uuid.New(), string(domain.UserRoleUser))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, route.path, strings.NewReader(route.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			svc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		t.Run(route.path+" updates for an admin", func(t *testing.T) {
+			svc := new(mocks.MockCompanySettingsService)
+			companyID := uuid.New()
+			router := newCompanySettingsRouter(svc, companyID, string(domain.UserRoleAdmin))
+			svc.On("Update", mock.Anything, companyID, mock.Anything).Return(domain.DefaultCompanySettings(), nil).Once()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, route.path, strings.NewReader(route.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+}
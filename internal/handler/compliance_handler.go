@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ComplianceHandler handles internal control reports
+type ComplianceHandler struct {
+	voucherService service.VoucherService
+}
+
+// NewComplianceHandler creates a new ComplianceHandler
+func NewComplianceHandler(voucherService service.VoucherService) *ComplianceHandler {
+	return &ComplianceHandler{voucherService: voucherService}
+}
+
+// RegisterRoutes registers compliance routes
+func (h *ComplianceHandler) RegisterRoutes(r *gin.RouterGroup) {
+	compliance := r.Group("/compliance")
+	{
+		compliance.GET("/sod-violations", h.SoDViolations)
+	}
+}
+
+// SoDViolations handles GET /compliance/sod-violations.
+// Violations are listed by the date of the approval or posting, both dates inclusive.
+func (h *ComplianceHandler) SoDViolations(c *gin.Context) {
+	var req dto.SoDViolationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	from, _ := time.Parse("2006-01-02", req.DateFrom)
+	to, _ := time.Parse("2006-01-02", req.DateTo)
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "date_to must not be before date_from"))
+		return
+	}
+
+	violations, err := h.voucherService.SoDViolations(c.Request.Context(), appctx.GetCompanyID(c), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		respondError(c, err, "Failed to list segregation of duties violations")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(violations))
+}
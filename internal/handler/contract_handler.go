@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ContractHandler handles the contract register and milestone billing
+type ContractHandler struct {
+	service service.ContractService
+}
+
+// NewContractHandler creates a new ContractHandler
+func NewContractHandler(svc service.ContractService) *ContractHandler {
+	return &ContractHandler{service: svc}
+}
+
+// RegisterRoutes registers contract routes
+func (h *ContractHandler) RegisterRoutes(r *gin.RouterGroup) {
+	contracts := r.Group("/contracts")
+	{
+		contracts.GET("", h.List)
+		contracts.POST("", h.Create)
+		contracts.GET("/billing-report", h.BillingReport)
+		contracts.GET("/:id", h.Get)
+		contracts.GET("/:id/collections", h.ListCollections)
+		contracts.POST("/:id/milestones/:milestone_id/invoice", h.Invoice)
+		contracts.POST("/:id/milestones/:milestone_id/collections", h.Collect)
+	}
+}
+
+// Create handles POST /contracts
+func (h *ContractHandler) Create(c *gin.Context) {
+	var req dto.CreateContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	contract := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), contract); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create contract")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(contract))
+}
+
+// List handles GET /contracts
+func (h *ContractHandler) List(c *gin.Context) {
+	filter := repository.ContractFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.ContractStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid contract status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if partnerID := c.Query("partner_id"); partnerID != "" {
+		id, err := uuid.Parse(partnerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid partner ID"))
+			return
+		}
+		filter.PartnerID = &id
+	}
+
+	contracts, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list contracts")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		contracts,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /contracts/:id
+func (h *ContractHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid contract ID")
+	if !ok {
+		return
+	}
+
+	contract, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get contract")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(contract))
+}
+
+// ListCollections handles GET /contracts/:id/collections
+func (h *ContractHandler) ListCollections(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid contract ID")
+	if !ok {
+		return
+	}
+
+	collections, err := h.service.ListCollections(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list contract collections")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(collections))
+}
+
+// Invoice handles POST /contracts/:id/milestones/:milestone_id/invoice
+func (h *ContractHandler) Invoice(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid contract ID")
+	if !ok {
+		return
+	}
+	milestoneID, ok := parseUUIDParam(c, "milestone_id", "Invalid milestone ID")
+	if !ok {
+		return
+	}
+
+	var req dto.InvoiceContractMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	if req.IssueDate == "" {
+		req.IssueDate = time.Now().Format("2006-01-02")
+	}
+	issueDate, _ := time.Parse("2006-01-02", req.IssueDate)
+
+	milestone, err := h.service.Invoice(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, milestoneID, service.ContractInvoiceInput{
+		IssueDate:     issueDate,
+		InvoiceNumber: req.InvoiceNumber,
+	})
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to invoice contract milestone")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(milestone))
+}
+
+// Collect handles POST /contracts/:id/milestones/:milestone_id/collections
+func (h *ContractHandler) Collect(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid contract ID")
+	if !ok {
+		return
+	}
+	milestoneID, ok := parseUUIDParam(c, "milestone_id", "Invalid milestone ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CollectContractMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	collectedDate, _ := time.Parse("2006-01-02", req.CollectedDate)
+
+	collection, err := h.service.Collect(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, milestoneID, service.ContractCollectionInput{
+		CollectedDate: collectedDate,
+		Amount:        req.Amount,
+		BankAccountID: uuid.MustParse(req.BankAccountID),
+	})
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record contract collection")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(collection))
+}
+
+// BillingReport handles GET /contracts/billing-report
+func (h *ContractHandler) BillingReport(c *gin.Context) {
+	var req dto.ContractBillingReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	filter := repository.ContractFilter{CompanyID: appctx.GetCompanyID(c)}
+	if req.PartnerID != "" {
+		partnerID := uuid.MustParse(req.PartnerID)
+		filter.PartnerID = &partnerID
+	}
+	if req.Status != "" {
+		status := domain.ContractStatus(req.Status)
+		filter.Status = &status
+	}
+
+	report, err := h.service.BillingReport(c.Request.Context(), filter, asOf)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to report contract billing")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(report))
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// DashboardHandler handles HTTP requests for the main screen summary
+type DashboardHandler struct {
+	service service.DashboardService
+}
+
+// NewDashboardHandler creates a new DashboardHandler
+func NewDashboardHandler(svc service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{service: svc}
+}
+
+// RegisterRoutes registers dashboard routes
+func (h *DashboardHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/dashboard", h.Get)
+}
+
+// Get returns the dashboard summary
+// @Summary Get dashboard summary
+// @Description Current month revenue, expense and net income, cash balance, overdue AR/AP, the user's pending approvals and recent activity in one call. Company figures are cached for a minute.
+// @Tags dashboard
+// @Produce json
+// @Success 200 {object} dto.Response{data=dto.DashboardResponse}
+// @Router /api/v1/dashboard [get]
+func (h *DashboardHandler) Get(c *gin.Context) {
+	summary, err := h.service.Summary(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to get dashboard")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromDashboardSummary(summary, appctx.GetLocale(c))))
+}
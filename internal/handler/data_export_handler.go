@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// DataExportHandler handles HTTP requests for tenant data exports
+type DataExportHandler struct {
+	service service.DataExportService
+}
+
+// NewDataExportHandler creates a new DataExportHandler
+func NewDataExportHandler(svc service.DataExportService) *DataExportHandler {
+	return &DataExportHandler{service: svc}
+}
+
+// RegisterRoutes registers data export routes.
+// The download route is public and registered separately, as it is authorized by the link signature.
+func (h *DataExportHandler) RegisterRoutes(r *gin.RouterGroup) {
+	exports := r.Group("/exports")
+	{
+		exports.POST("/full", h.RequestFull)
+		exports.GET("", h.List)
+		exports.GET("/:id", h.GetByID)
+	}
+}
+
+// RequestFull handles POST /exports/full
+func (h *DataExportHandler) RequestFull(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	userID := appctx.GetUserID(c)
+
+	var req dto.CreateDataExportRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+			return
+		}
+	}
+
+	export, err := h.service.Request(c.Request.Context(), companyID, userID, domain.DataExportFormat(req.Format), req.MaskPII)
+	if err != nil {
+		respondError(c, err, "Failed to request data export")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse(dto.FromDataExport(export)))
+}
+
+// List handles GET /exports
+func (h *DataExportHandler) List(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	exports, err := h.service.List(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to list data exports")
+		return
+	}
+
+	resp := dto.FromDataExports(exports)
+	for i := range exports {
+		h.attachLink(&resp[i], &exports[i])
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
+
+// GetByID handles GET /exports/:id
+func (h *DataExportHandler) GetByID(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid data export ID"))
+		return
+	}
+
+	export, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get data export")
+		return
+	}
+
+	resp := dto.FromDataExport(export)
+	h.attachLink(&resp, export)
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
+
+// Download handles GET /exports/:id/download?expires=&signature=
+func (h *DataExportHandler) Download(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid data export ID"))
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse(dto.ErrCodeForbidden, domain.ErrInvalidDataExportLink.Error()))
+		return
+	}
+
+	export, err := h.service.OpenDownload(c.Request.Context(), id, expires, c.Query("signature"))
+	if err != nil {
+		respondError(c, err, "Failed to download data export")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", export.FileData)
+}
+
+// attachLink adds a signed download link to a completed export; exports that
+// cannot be downloaded are returned without one
+func (h *DataExportHandler) attachLink(resp *dto.DataExportResponse, export *domain.DataExport) {
+	link, err := h.service.DownloadLink(export)
+	if err != nil {
+		return
+	}
+	resp.DownloadURL = link.URL
+	resp.DownloadExpiresAt = link.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// DataRetentionHandler handles the retention policy and the deletion requests
+// of personal data. Any user can request the deletion of their own account;
+// everything else is for company admins.
+type DataRetentionHandler struct {
+	service service.DataRetentionService
+}
+
+// NewDataRetentionHandler creates a new DataRetentionHandler
+func NewDataRetentionHandler(svc service.DataRetentionService) *DataRetentionHandler {
+	return &DataRetentionHandler{service: svc}
+}
+
+// RegisterRoutes registers data retention routes
+func (h *DataRetentionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	privacy := r.Group("/privacy")
+	{
+		privacy.GET("/retention-policy", h.Policy)
+		privacy.GET("/deletion-requests", h.List)
+		privacy.POST("/deletion-requests", h.Create)
+		privacy.POST("/deletion-requests/me", h.RequestOwn)
+		privacy.GET("/deletion-requests/:id", h.GetByID)
+		privacy.POST("/deletion-requests/:id/complete", h.Complete)
+		privacy.POST("/deletion-requests/:id/reject", h.Reject)
+	}
+}
+
+// Policy handles GET /privacy/retention-policy
+func (h *DataRetentionHandler) Policy(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(h.service.Policy()))
+}
+
+// List handles GET /privacy/deletion-requests, the report of deletion
+// requests and their deadlines. Pending requests are listed by default.
+func (h *DataRetentionHandler) List(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.ListDeletionRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	var status *domain.DeletionRequestStatus
+	switch req.Status {
+	case "":
+		pending := domain.DeletionRequestPending
+		status = &pending
+	case "all":
+	default:
+		s := domain.DeletionRequestStatus(req.Status)
+		status = &s
+	}
+
+	reqs, err := h.service.ListDeletionRequests(c.Request.Context(), appctx.GetCompanyID(c), status)
+	if err != nil {
+		respondError(c, err, "Failed to list deletion requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromDeletionRequestReport(reqs, time.Now())))
+}
+
+// Create handles POST /privacy/deletion-requests, filing a request received
+// from a user or a partner's contact person
+func (h *DataRetentionHandler) Create(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.CreateDeletionRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	subjectID, _ := uuid.Parse(req.SubjectID)
+
+	created, err := h.service.RequestDeletion(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c),
+		domain.DeletionSubjectType(req.SubjectType), subjectID, req.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to create deletion request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromDeletionRequest(created, time.Now())))
+}
+
+// RequestOwn handles POST /privacy/deletion-requests/me
+func (h *DataRetentionHandler) RequestOwn(c *gin.Context) {
+	var req dto.RequestOwnDeletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	userID := appctx.GetUserID(c)
+	created, err := h.service.RequestDeletion(c.Request.Context(), appctx.GetCompanyID(c), userID,
+		domain.DeletionSubjectUser, userID, req.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to create deletion request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromDeletionRequest(created, time.Now())))
+}
+
+// GetByID handles GET /privacy/deletion-requests/:id; users may see the requests they filed
+func (h *DataRetentionHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid deletion request ID"))
+		return
+	}
+
+	req, err := h.service.GetDeletionRequest(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get deletion request")
+		return
+	}
+	if req.RequestedBy != appctx.GetUserID(c) && !requireAdmin(c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromDeletionRequest(req, time.Now())))
+}
+
+// Complete handles POST /privacy/deletion-requests/:id/complete
+func (h *DataRetentionHandler) Complete(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid deletion request ID"))
+		return
+	}
+
+	req, err := h.service.CompleteDeletion(c.Request.Context(), appctx.GetCompanyID(c), id, appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to complete deletion request")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromDeletionRequest(req, time.Now())))
+}
+
+// Reject handles POST /privacy/deletion-requests/:id/reject
+func (h *DataRetentionHandler) Reject(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid deletion request ID"))
+		return
+	}
+
+	var body dto.RejectDeletionRequestRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	req, err := h.service.RejectDeletion(c.Request.Context(), appctx.GetCompanyID(c), id, appctx.GetUserID(c), body.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to reject deletion request")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromDeletionRequest(req, time.Now())))
+}
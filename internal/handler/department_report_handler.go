@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// DepartmentReportHandler handles HTTP requests for department-level reports and cost allocation rules
+type DepartmentReportHandler struct {
+	service service.DepartmentReportService
+}
+
+// NewDepartmentReportHandler creates a new DepartmentReportHandler
+func NewDepartmentReportHandler(svc service.DepartmentReportService) *DepartmentReportHandler {
+	return &DepartmentReportHandler{service: svc}
+}
+
+// RegisterRoutes registers department report routes
+func (h *DepartmentReportHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/reports/income-statement/by-department", h.GetIncomeStatement)
+
+	rules := r.Group("/department-allocation-rules")
+	{
+		rules.GET("", h.ListRules)
+		rules.POST("", h.CreateRule)
+		rules.GET("/:id", h.GetRule)
+		rules.PUT("/:id", h.UpdateRule)
+		rules.DELETE("/:id", h.DeleteRule)
+	}
+}
+
+// GetIncomeStatement returns the income statement by department
+// @Summary Get income statement by department
+// @Description Posted revenue and expenses by department, with optional shared-cost allocation and hierarchy roll-up
+// @Tags reports
+// @Produce json
+// @Param from_year query int true "From year"
+// @Param from_month query int true "From month"
+// @Param to_year query int true "To year"
+// @Param to_month query int true "To month"
+// @Param department_id query string false "Limit to this department and its descendants"
+// @Param rollup query bool false "Include descendants in each department's figures"
+// @Param allocate query bool false "Apply active allocation rules"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/reports/income-statement/by-department [get]
+func (h *DepartmentReportHandler) GetIncomeStatement(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	var req dto.DepartmentIncomeStatementRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+
+	query := service.DepartmentIncomeQuery{
+		FromDate: time.Date(req.FromYear, time.Month(req.FromMonth), 1, 0, 0, 0, 0, time.UTC),
+		ToDate:   time.Date(req.ToYear, time.Month(req.ToMonth)+1, 0, 0, 0, 0, 0, time.UTC),
+		Rollup:   req.Rollup,
+		Allocate: req.Allocate,
+	}
+	if query.ToDate.Before(query.FromDate) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "to period must not be before from period"))
+		return
+	}
+	if req.DepartmentID != "" {
+		id, _ := uuid.Parse(req.DepartmentID)
+		query.DepartmentID = &id
+	}
+
+	statements, err := h.service.GetIncomeStatement(c.Request.Context(), companyID, query)
+	if err != nil {
+		respondError(c, err, "Failed to generate income statement by department")
+		return
+	}
+
+	departments, totalRevenue, totalExpenses := dto.FromDepartmentIncome(statements, req.Rollup)
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.DepartmentIncomeStatementResponse{
+		CompanyID:     companyID.String(),
+		FromDate:      query.FromDate.Format("2006-01-02"),
+		ToDate:        query.ToDate.Format("2006-01-02"),
+		GeneratedAt:   dto.ReportGeneratedAt(),
+		Rollup:        req.Rollup,
+		Allocated:     req.Allocate,
+		Departments:   departments,
+		TotalRevenue:  totalRevenue,
+		TotalExpenses: totalExpenses,
+		NetIncome:     totalRevenue - totalExpenses,
+	}))
+}
+
+// ListRules handles GET /department-allocation-rules
+func (h *DepartmentReportHandler) ListRules(c *gin.Context) {
+	rules, err := h.service.ListRules(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list allocation rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAllocationRules(rules)))
+}
+
+// CreateRule handles POST /department-allocation-rules
+func (h *DepartmentReportHandler) CreateRule(c *gin.Context) {
+	var req dto.CreateAllocationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	rule, err := req.ToAllocationRule(appctx.GetCompanyID(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid allocation rule data", err.Error()))
+		return
+	}
+
+	if err := h.service.CreateRule(c.Request.Context(), rule); err != nil {
+		respondError(c, err, "Failed to create allocation rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromAllocationRule(rule)))
+}
+
+// GetRule handles GET /department-allocation-rules/:id
+func (h *DepartmentReportHandler) GetRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid allocation rule ID"))
+		return
+	}
+
+	rule, err := h.service.GetRule(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get allocation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAllocationRule(rule)))
+}
+
+// UpdateRule handles PUT /department-allocation-rules/:id
+func (h *DepartmentReportHandler) UpdateRule(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid allocation rule ID"))
+		return
+	}
+
+	var req dto.UpdateAllocationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	rule, err := h.service.GetRule(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get allocation rule")
+		return
+	}
+
+	if err := req.ApplyTo(rule); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid allocation rule data", err.Error()))
+		return
+	}
+
+	if err := h.service.UpdateRule(c.Request.Context(), rule); err != nil {
+		respondError(c, err, "Failed to update allocation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAllocationRule(rule)))
+}
+
+// DeleteRule handles DELETE /department-allocation-rules/:id
+func (h *DepartmentReportHandler) DeleteRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid allocation rule ID"))
+		return
+	}
+
+	if err := h.service.DeleteRule(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to delete allocation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
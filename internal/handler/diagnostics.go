@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/database"
+	"github.com/saintgo7/saas-kerp/internal/handler/response"
+	"github.com/saintgo7/saas-kerp/internal/middleware"
+)
+
+// DiagnosticsHandler summarizes the runtime state of the instance for
+// production debugging. It is served to super admins only; profiles are
+// served by the diagnostics listener (see router.NewDiagnosticsServer).
+type DiagnosticsHandler struct {
+	*BaseHandler
+	redisResilience *database.RedisResilience
+	nats            *nats.Conn
+	version         string
+	started         time.Time
+}
+
+// NewDiagnosticsHandler creates a new diagnostics handler. nc may be nil
+// when NATS is not connected.
+func NewDiagnosticsHandler(db *gorm.DB, redis *redis.Client, redisResilience *database.RedisResilience, nc *nats.Conn, logger *zap.Logger, version string) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		BaseHandler:     NewBaseHandler(db, redis, logger),
+		redisResilience: redisResilience,
+		nats:            nc,
+		version:         version,
+		started:         time.Now(),
+	}
+}
+
+// RegisterRoutes registers the diagnostics routes
+func (h *DiagnosticsHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/diagnostics", h.Summary)
+}
+
+// Diagnostics is the runtime state of an instance
+type Diagnostics struct {
+	Version   string              `json:"version"`
+	Timestamp time.Time           `json:"timestamp"`
+	Uptime    string              `json:"uptime"`
+	Runtime   RuntimeDiagnostics  `json:"runtime"`
+	Database  DatabaseDiagnostics `json:"database"`
+	Redis     RedisDiagnostics    `json:"redis"`
+	NATS      NATSDiagnostics     `json:"nats"`
+}
+
+// RuntimeDiagnostics is the state of the Go runtime
+type RuntimeDiagnostics struct {
+	GoVersion      string  `json:"go_version"`
+	Goroutines     int     `json:"goroutines"`
+	GOMAXPROCS     int     `json:"gomaxprocs"`
+	HeapAllocBytes uint64  `json:"heap_alloc_bytes"`
+	HeapObjects    uint64  `json:"heap_objects"`
+	SysBytes       uint64  `json:"sys_bytes"`
+	NumGC          uint32  `json:"num_gc"`
+	LastGCPauseMs  float64 `json:"last_gc_pause_ms"`
+	Panics         int64   `json:"panics"` // recovered from requests since the start
+}
+
+// DatabaseDiagnostics is the connection pool of the primary database
+type DatabaseDiagnostics struct {
+	Status            string  `json:"status"`
+	Error             string  `json:"error,omitempty"`
+	MaxOpen           int     `json:"max_open"`
+	Open              int     `json:"open"`
+	InUse             int     `json:"in_use"`
+	Idle              int     `json:"idle"`
+	WaitCount         int64   `json:"wait_count"`
+	WaitDurationMs    float64 `json:"wait_duration_ms"`
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+// RedisDiagnostics is the latency of a ping, the connection pool and the degradation of Redis
+type RedisDiagnostics struct {
+	Status     string                        `json:"status"`
+	Error      string                        `json:"error,omitempty"`
+	LatencyMs  float64                       `json:"latency_ms"`
+	TotalConns uint32                        `json:"total_conns"`
+	IdleConns  uint32                        `json:"idle_conns"`
+	Timeouts   uint32                        `json:"timeouts"`
+	Resilience database.RedisResilienceStats `json:"resilience"`
+}
+
+// NATSDiagnostics is the connection and the consumer lag of every JetStream stream
+type NATSDiagnostics struct {
+	Status       string              `json:"status"`
+	Error        string              `json:"error,omitempty"`
+	ConnectedURL string              `json:"connected_url,omitempty"`
+	Reconnects   uint64              `json:"reconnects"`
+	Streams      []StreamDiagnostics `json:"streams"`
+}
+
+// StreamDiagnostics is a JetStream stream with its consumers
+type StreamDiagnostics struct {
+	Name      string                `json:"name"`
+	Messages  uint64                `json:"messages"`
+	Consumers []ConsumerDiagnostics `json:"consumers"`
+}
+
+// ConsumerDiagnostics is the lag of a JetStream consumer
+type ConsumerDiagnostics struct {
+	Name        string `json:"name"`
+	Pending     uint64 `json:"pending"`     // messages not yet delivered
+	AckPending  int    `json:"ack_pending"` // delivered, not yet acknowledged
+	Redelivered int    `json:"redelivered"`
+	Waiting     int    `json:"waiting"` // pull requests waiting for messages
+}
+
+// Summary returns the runtime state of the instance
+func (h *DiagnosticsHandler) Summary(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	response.OK(c, Diagnostics{
+		Version:   h.version,
+		Timestamp: time.Now().UTC(),
+		Uptime:    time.Since(h.started).Round(time.Second).String(),
+		Runtime:   runtimeDiagnostics(),
+		Database:  h.database(),
+		Redis:     h.redis(ctx),
+		NATS:      h.jetStream(),
+	})
+}
+
+func runtimeDiagnostics() RuntimeDiagnostics {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return RuntimeDiagnostics{
+		GoVersion:      runtime.Version(),
+		Goroutines:     runtime.NumGoroutine(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapObjects:    mem.HeapObjects,
+		SysBytes:       mem.Sys,
+		NumGC:          mem.NumGC,
+		LastGCPauseMs:  float64(mem.PauseNs[(mem.NumGC+255)%256]) / float64(time.Millisecond),
+		Panics:         middleware.PanicCount(),
+	}
+}
+
+func (h *DiagnosticsHandler) database() DatabaseDiagnostics {
+	sqlDB, err := h.DB.DB()
+	if err != nil {
+		return DatabaseDiagnostics{Status: "unavailable", Error: err.Error()}
+	}
+	stats := sqlDB.Stats()
+	return DatabaseDiagnostics{
+		Status:            "connected",
+		MaxOpen:           stats.MaxOpenConnections,
+		Open:              stats.OpenConnections,
+		InUse:             stats.InUse,
+		Idle:              stats.Idle,
+		WaitCount:         stats.WaitCount,
+		WaitDurationMs:    float64(stats.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:     stats.MaxIdleClosed,
+		MaxLifetimeClosed: stats.MaxLifetimeClosed,
+	}
+}
+
+func (h *DiagnosticsHandler) redis(ctx context.Context) RedisDiagnostics {
+	diag := RedisDiagnostics{Status: "disabled", Resilience: h.redisResilience.Stats()}
+	if h.Redis == nil {
+		return diag
+	}
+
+	start := time.Now()
+	err := h.Redis.Ping(ctx).Err()
+	diag.LatencyMs = float64(time.Since(start)) / float64(time.Millisecond)
+	diag.Status = "healthy"
+	if err != nil {
+		diag.Status, diag.Error = "unavailable", err.Error()
+	}
+
+	pool := h.Redis.PoolStats()
+	diag.TotalConns, diag.IdleConns, diag.Timeouts = pool.TotalConns, pool.IdleConns, pool.Timeouts
+	return diag
+}
+
+// jetStream lists the consumers of every stream; their pending messages are the lag
+func (h *DiagnosticsHandler) jetStream() NATSDiagnostics {
+	diag := NATSDiagnostics{Status: "disconnected", Streams: []StreamDiagnostics{}}
+	if h.nats == nil {
+		return diag
+	}
+	diag.Status = h.nats.Status().String()
+	diag.ConnectedURL = h.nats.ConnectedUrlRedacted()
+	diag.Reconnects = h.nats.Stats().Reconnects
+	if !h.nats.IsConnected() {
+		return diag
+	}
+
+	js, err := h.nats.JetStream()
+	if err != nil {
+		diag.Error = err.Error()
+		return diag
+	}
+	for stream := range js.Streams() {
+		s := StreamDiagnostics{Name: stream.Config.Name, Messages: stream.State.Msgs, Consumers: []ConsumerDiagnostics{}}
+		for consumer := range js.Consumers(stream.Config.Name) {
+			s.Consumers = append(s.Consumers, ConsumerDiagnostics{
+				Name:        consumer.Name,
+				Pending:     consumer.NumPending,
+				AckPending:  consumer.NumAckPending,
+				Redelivered: consumer.NumRedelivered,
+				Waiting:     consumer.NumWaiting,
+			})
+		}
+		diag.Streams = append(diag.Streams, s)
+	}
+	sort.Slice(diag.Streams, func(i, j int) bool { return diag.Streams[i].Name < diag.Streams[j].Name })
+	return diag
+}
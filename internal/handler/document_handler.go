@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// DocumentHandler handles the document library: folders, files, their
+// versions and links to business documents
+type DocumentHandler struct {
+	service     service.DocumentService
+	maxFileSize int64
+}
+
+// NewDocumentHandler creates a new DocumentHandler
+func NewDocumentHandler(svc service.DocumentService, maxFileSize int64) *DocumentHandler {
+	return &DocumentHandler{service: svc, maxFileSize: maxFileSize}
+}
+
+// RegisterRoutes registers document library routes
+func (h *DocumentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	documents := r.Group("/documents")
+	{
+		documents.GET("/folders", h.ListFolders)
+		documents.POST("/folders", h.CreateFolder)
+		documents.PUT("/folders/:id", h.UpdateFolder)
+		documents.DELETE("/folders/:id", h.DeleteFolder)
+		documents.GET("/folders/:id/permissions", h.FolderPermissions)
+		documents.PUT("/folders/:id/permissions", h.SetFolderPermissions)
+
+		documents.GET("/files", h.Search)
+		documents.POST("/files", h.Upload)
+		documents.GET("/files/:id", h.GetFile)
+		documents.PUT("/files/:id", h.UpdateFile)
+		documents.DELETE("/files/:id", h.DeleteFile)
+		documents.POST("/files/:id/versions", h.AddVersion)
+		documents.GET("/files/:id/download", h.Download)
+		documents.POST("/files/:id/links", h.Link)
+		documents.DELETE("/files/:id/links/:link_id", h.Unlink)
+	}
+}
+
+// ListFolders handles GET /documents/folders
+func (h *DocumentHandler) ListFolders(c *gin.Context) {
+	folders, err := h.service.ListFolders(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list document folders")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(folders))
+}
+
+// CreateFolder handles POST /documents/folders
+func (h *DocumentHandler) CreateFolder(c *gin.Context) {
+	var req dto.DocumentFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	folder := &domain.DocumentFolder{
+		TenantModel: domain.TenantModel{CompanyID: appctx.GetCompanyID(c)},
+		ParentID:    req.Parent(),
+		Name:        req.Name,
+	}
+	if err := h.service.CreateFolder(c.Request.Context(), appctx.GetUserID(c), folder); err != nil {
+		respondError(c, err, "Failed to create document folder")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(folder))
+}
+
+// UpdateFolder handles PUT /documents/folders/:id
+func (h *DocumentHandler) UpdateFolder(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document folder ID")
+	if !ok {
+		return
+	}
+
+	var req dto.DocumentFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	folder, err := h.service.UpdateFolder(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.Name, req.Parent())
+	if err != nil {
+		respondError(c, err, "Failed to update document folder")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(folder))
+}
+
+// DeleteFolder handles DELETE /documents/folders/:id
+func (h *DocumentHandler) DeleteFolder(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document folder ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFolder(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id); err != nil {
+		respondError(c, err, "Failed to delete document folder")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// FolderPermissions handles GET /documents/folders/:id/permissions
+func (h *DocumentHandler) FolderPermissions(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document folder ID")
+	if !ok {
+		return
+	}
+
+	permissions, err := h.service.FolderPermissions(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get document folder permissions")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(permissions))
+}
+
+// SetFolderPermissions handles PUT /documents/folders/:id/permissions
+func (h *DocumentHandler) SetFolderPermissions(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document folder ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SetDocumentFolderPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	permissions, err := h.service.SetFolderPermissions(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.ToDomain())
+	if err != nil {
+		respondError(c, err, "Failed to set document folder permissions")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(permissions))
+}
+
+// Search handles GET /documents/files. Files are searched by q in their name
+// and description, by metadata[key]=value, and by the document they are
+// linked to with entity_type and entity_id.
+func (h *DocumentHandler) Search(c *gin.Context) {
+	filter := repository.DocumentFileFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Query:     c.Query("q"),
+		Metadata:  c.QueryMap("metadata"),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if folder := c.Query("folder_id"); folder != "" {
+		id, err := uuid.Parse(folder)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid document folder ID"))
+			return
+		}
+		filter.FolderID = &id
+	}
+	if entityType := c.Query("entity_type"); entityType != "" {
+		id, err := uuid.Parse(c.Query("entity_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid entity ID"))
+			return
+		}
+		filter.Entity = &domain.DocumentRef{Type: domain.DocumentType(entityType), ID: id}
+	}
+
+	files, total, err := h.service.Search(c.Request.Context(), appctx.GetUserID(c), filter)
+	if err != nil {
+		respondError(c, err, "Failed to search documents")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		files,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Upload handles POST /documents/files; the content is the multipart "file"
+func (h *DocumentHandler) Upload(c *gin.Context) {
+	var req dto.UploadDocumentFileRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	var metadata map[string]string
+	if req.Metadata != "" {
+		if err := json.Unmarshal([]byte(req.Metadata), &metadata); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid document metadata", err.Error()))
+			return
+		}
+	}
+	upload, ok := h.readFile(c, req.Comment)
+	if !ok {
+		return
+	}
+
+	file := &domain.DocumentFile{
+		FolderID:    uuid.MustParse(req.FolderID),
+		Name:        req.Name,
+		Description: req.Description,
+		Metadata:    metadata,
+	}
+	file, err := h.service.Upload(c.Request.Context(), file, upload)
+	if err != nil {
+		respondError(c, err, "Failed to upload document")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(file))
+}
+
+// GetFile handles GET /documents/files/:id
+func (h *DocumentHandler) GetFile(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+
+	file, err := h.service.GetFile(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get document")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(file))
+}
+
+// UpdateFile handles PUT /documents/files/:id
+func (h *DocumentHandler) UpdateFile(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateDocumentFileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	file, err := h.service.UpdateFile(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, service.DocumentFileUpdate{
+		FolderID:    uuid.MustParse(req.FolderID),
+		Name:        req.Name,
+		Description: req.Description,
+		Metadata:    req.Metadata,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update document")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(file))
+}
+
+// DeleteFile handles DELETE /documents/files/:id
+func (h *DocumentHandler) DeleteFile(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFile(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id); err != nil {
+		respondError(c, err, "Failed to delete document")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddVersion handles POST /documents/files/:id/versions
+func (h *DocumentHandler) AddVersion(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+
+	var req dto.UploadDocumentVersionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	upload, ok := h.readFile(c, req.Comment)
+	if !ok {
+		return
+	}
+
+	file, err := h.service.AddVersion(c.Request.Context(), id, upload)
+	if err != nil {
+		respondError(c, err, "Failed to upload document version")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(file))
+}
+
+// Download handles GET /documents/files/:id/download; version selects an
+// earlier version than the current one
+func (h *DocumentHandler) Download(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+	version := 0
+	if v := c.Query("version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid document version"))
+			return
+		}
+		version = n
+	}
+
+	v, err := h.service.Download(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, version, c.ClientIP())
+	if err != nil {
+		respondError(c, err, "Failed to download document")
+		return
+	}
+
+	contentType := v.FileType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", v.FileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, v.FileData)
+}
+
+// Link handles POST /documents/files/:id/links
+func (h *DocumentHandler) Link(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+
+	var req dto.LinkDocumentFileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	link, err := h.service.Link(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.Document.ToDocumentRef())
+	if err != nil {
+		respondError(c, err, "Failed to link document")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(link))
+}
+
+// Unlink handles DELETE /documents/files/:id/links/:link_id
+func (h *DocumentHandler) Unlink(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid document file ID")
+	if !ok {
+		return
+	}
+	linkID, ok := parseUUIDParam(c, "link_id", "Invalid document file link ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unlink(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, linkID); err != nil {
+		respondError(c, err, "Failed to unlink document")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// readFile reads the uploaded content, responding 400 or 413 when it fails
+func (h *DocumentHandler) readFile(c *gin.Context, comment string) (*service.DocumentUpload, bool) {
+	fileHeader, data, err := readUpload(c, h.maxFileSize)
+	if err != nil {
+		if err == domain.ErrAttachmentTooLarge {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse(dto.ErrCodeValidation, err.Error()))
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Document file is required", err.Error()))
+		return nil, false
+	}
+	return &service.DocumentUpload{
+		CompanyID: appctx.GetCompanyID(c),
+		UserID:    appctx.GetUserID(c),
+		FileName:  fileHeader.Filename,
+		Data:      data,
+		Comment:   comment,
+		IPAddress: c.ClientIP(),
+	}, true
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// DocumentLinkHandler handles HTTP requests for links between documents
+type DocumentLinkHandler struct {
+	service service.DocumentLinkService
+}
+
+// NewDocumentLinkHandler creates a new DocumentLinkHandler
+func NewDocumentLinkHandler(svc service.DocumentLinkService) *DocumentLinkHandler {
+	return &DocumentLinkHandler{service: svc}
+}
+
+// RegisterRoutes registers document link routes
+func (h *DocumentLinkHandler) RegisterRoutes(r *gin.RouterGroup) {
+	links := r.Group("/document-links")
+	{
+		links.POST("", h.Create)
+		links.DELETE("/:id", h.Delete)
+	}
+
+	r.GET("/related-documents/:type/:id", h.Related)
+}
+
+// Create handles POST /document-links
+func (h *DocumentLinkHandler) Create(c *gin.Context) {
+	var req dto.CreateDocumentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	link, err := h.service.Link(c.Request.Context(), appctx.GetCompanyID(c),
+		req.Document.ToDocumentRef(), req.LinkedTo.ToDocumentRef(), req.Note, appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to link documents")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromDocumentLink(link, appctx.GetLocale(c))))
+}
+
+// Delete handles DELETE /document-links/:id
+func (h *DocumentLinkHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid document link ID"))
+		return
+	}
+
+	if err := h.service.Unlink(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to delete document link")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// Related handles GET /related-documents/:type/:id, the documents linked to
+// any document or related to it by a voucher's reference
+func (h *DocumentLinkHandler) Related(c *gin.Context) {
+	docType := domain.DocumentType(c.Param("type"))
+	if !docType.IsValid() {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, domain.ErrInvalidDocumentType.Error()))
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid document ID"))
+		return
+	}
+
+	related, err := h.service.Related(c.Request.Context(), appctx.GetCompanyID(c), domain.DocumentRef{Type: docType, ID: id})
+	if err != nil {
+		respondError(c, err, "Failed to retrieve related documents")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromRelatedDocuments(related, appctx.GetLocale(c))))
+}
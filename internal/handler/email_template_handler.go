@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// EmailTemplateHandler handles the email templates of the company. Any user
+// can read and preview them; changing them is for company admins.
+type EmailTemplateHandler struct {
+	service service.EmailTemplateService
+}
+
+// NewEmailTemplateHandler creates a new EmailTemplateHandler
+func NewEmailTemplateHandler(svc service.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{service: svc}
+}
+
+// RegisterRoutes registers email template routes
+func (h *EmailTemplateHandler) RegisterRoutes(r *gin.RouterGroup) {
+	templates := r.Group("/email-templates")
+	{
+		templates.GET("", h.List)
+		templates.GET("/:event_type", h.Get)
+		templates.PUT("/:event_type", h.Save)
+		templates.DELETE("/:event_type", h.Reset)
+		templates.POST("/:event_type/preview", h.Preview)
+		templates.GET("/:event_type/versions", h.Versions)
+		templates.POST("/:event_type/versions/:version/restore", h.Restore)
+	}
+}
+
+// List handles GET /email-templates, the current template of every event
+func (h *EmailTemplateHandler) List(c *gin.Context) {
+	templates, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list email templates")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromEmailTemplates(templates)))
+}
+
+// Get handles GET /email-templates/:event_type
+func (h *EmailTemplateHandler) Get(c *gin.Context) {
+	template, err := h.service.Get(c.Request.Context(), appctx.GetCompanyID(c), domain.EmailEventType(c.Param("event_type")))
+	if err != nil {
+		respondError(c, err, "Failed to get email template")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromEmailTemplate(template)))
+}
+
+// Save handles PUT /email-templates/:event_type
+// @Summary Save an email template
+// @Description Save a new version of the company's template for an event and make it active.
+// @Description Placeholders are written {{variable}}; only the variables of the event are allowed.
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Param event_type path string true "invitation, email_verification, report_delivery, report_failure or partner_statement"
+// @Param body body dto.SaveEmailTemplateRequest true "Template"
+// @Success 200 {object} dto.Response{data=dto.EmailTemplateResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/email-templates/{event_type} [put]
+func (h *EmailTemplateHandler) Save(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.SaveEmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	template, err := h.service.Save(c.Request.Context(), appctx.GetCompanyID(c),
+		domain.EmailEventType(c.Param("event_type")), req.Subject, req.Body, appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to save email template")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromEmailTemplate(template)))
+}
+
+// Reset handles DELETE /email-templates/:event_type, going back to the system
+// default; the saved versions are kept and can be restored
+func (h *EmailTemplateHandler) Reset(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	template, err := h.service.Reset(c.Request.Context(), appctx.GetCompanyID(c), domain.EmailEventType(c.Param("event_type")))
+	if err != nil {
+		respondError(c, err, "Failed to reset email template")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromEmailTemplate(template)))
+}
+
+// Preview handles POST /email-templates/:event_type/preview
+// @Summary Preview an email template
+// @Description Render a draft, or the current template, with the sample data of the event
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Param event_type path string true "Event type"
+// @Param body body dto.PreviewEmailTemplateRequest false "Draft"
+// @Success 200 {object} dto.Response{data=dto.EmailTemplatePreviewResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/email-templates/{event_type}/preview [post]
+func (h *EmailTemplateHandler) Preview(c *gin.Context) {
+	var req dto.PreviewEmailTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+			return
+		}
+	}
+
+	subject, body, err := h.service.Preview(c.Request.Context(), appctx.GetCompanyID(c),
+		domain.EmailEventType(c.Param("event_type")), service.EmailTemplatePreviewRequest{
+			Subject:   req.Subject,
+			Body:      req.Body,
+			Variables: req.Variables,
+		})
+	if err != nil {
+		respondError(c, err, "Failed to preview email template")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.EmailTemplatePreviewResponse{Subject: subject, Body: body}))
+}
+
+// Versions handles GET /email-templates/:event_type/versions, newest first
+func (h *EmailTemplateHandler) Versions(c *gin.Context) {
+	templates, err := h.service.Versions(c.Request.Context(), appctx.GetCompanyID(c), domain.EmailEventType(c.Param("event_type")))
+	if err != nil {
+		respondError(c, err, "Failed to list email template versions")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromEmailTemplates(templates)))
+}
+
+// Restore handles POST /email-templates/:event_type/versions/:version/restore,
+// saving a copy of the version as the next one
+func (h *EmailTemplateHandler) Restore(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid template version"))
+		return
+	}
+
+	template, err := h.service.Restore(c.Request.Context(), appctx.GetCompanyID(c),
+		domain.EmailEventType(c.Param("event_type")), version, appctx.GetUserID(c))
+	if err != nil {
+		respondError(c, err, "Failed to restore email template")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromEmailTemplate(template)))
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// EmployeeAdvanceHandler handles employee advances and their settlements
+type EmployeeAdvanceHandler struct {
+	service service.EmployeeAdvanceService
+}
+
+// NewEmployeeAdvanceHandler creates a new EmployeeAdvanceHandler
+func NewEmployeeAdvanceHandler(svc service.EmployeeAdvanceService) *EmployeeAdvanceHandler {
+	return &EmployeeAdvanceHandler{service: svc}
+}
+
+// RegisterRoutes registers employee advance routes
+func (h *EmployeeAdvanceHandler) RegisterRoutes(r *gin.RouterGroup) {
+	advances := r.Group("/employee-advances")
+	{
+		advances.GET("", h.List)
+		advances.POST("", h.Create)
+		advances.GET("/outstanding", h.Outstanding)
+		advances.GET("/settlements", h.ListSettlements)
+		advances.POST("/settlements/:id/review", h.ReviewSettlement)
+		advances.GET("/:id", h.Get)
+		advances.POST("/:id/settlements", h.Settle)
+	}
+}
+
+// Create handles POST /employee-advances
+func (h *EmployeeAdvanceHandler) Create(c *gin.Context) {
+	var req dto.CreateEmployeeAdvanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	if req.AdvanceDate == "" {
+		req.AdvanceDate = time.Now().Format("2006-01-02")
+	}
+
+	advance := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), advance); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record employee advance")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(advance))
+}
+
+// List handles GET /employee-advances
+func (h *EmployeeAdvanceHandler) List(c *gin.Context) {
+	filter := repository.EmployeeAdvanceFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if employee := c.Query("employee_id"); employee != "" {
+		id, err := uuid.Parse(employee)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid employee ID"))
+			return
+		}
+		filter.EmployeeID = &id
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.EmployeeAdvanceStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid employee advance status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	advances, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list employee advances")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		advances,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /employee-advances/:id
+func (h *EmployeeAdvanceHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid employee advance ID")
+	if !ok {
+		return
+	}
+
+	advance, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get employee advance")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(advance))
+}
+
+// Settle handles POST /employee-advances/:id/settlements
+func (h *EmployeeAdvanceHandler) Settle(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid employee advance ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SettleEmployeeAdvanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	if req.SettlementDate == "" {
+		req.SettlementDate = time.Now().Format("2006-01-02")
+	}
+	date, _ := time.Parse("2006-01-02", req.SettlementDate)
+	input := service.AdvanceSettlementInput{
+		SettlementDate: date,
+		Expenses:       req.ToExpenses(),
+		Refund:         req.RefundAmount,
+	}
+	if req.BankAccountID != "" {
+		bankAccountID := uuid.MustParse(req.BankAccountID)
+		input.BankAccountID = &bankAccountID
+	}
+
+	settlement, err := h.service.Settle(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, input)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to settle employee advance")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(settlement))
+}
+
+// ListSettlements handles GET /employee-advances/settlements; review_status=pending
+// lists the settlements whose policy violations wait for the approver
+func (h *EmployeeAdvanceHandler) ListSettlements(c *gin.Context) {
+	filter := repository.AdvanceSettlementFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("review_status"); status != "" {
+		s := domain.SettlementReviewStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid review status"))
+			return
+		}
+		filter.ReviewStatus = &s
+	}
+
+	settlements, total, err := h.service.ListSettlements(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list advance settlements")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		settlements,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// ReviewSettlement handles POST /employee-advances/settlements/:id/review
+func (h *EmployeeAdvanceHandler) ReviewSettlement(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid advance settlement ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewSettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	settlement, err := h.service.ReviewSettlement(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.Note)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to review advance settlement")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(settlement))
+}
+
+// Outstanding handles GET /employee-advances/outstanding
+func (h *EmployeeAdvanceHandler) Outstanding(c *gin.Context) {
+	var req dto.OutstandingAdvancesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	summaries, err := h.service.Outstanding(c.Request.Context(), appctx.GetCompanyID(c), asOf)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to report outstanding employee advances")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(summaries))
+}
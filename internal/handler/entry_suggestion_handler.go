@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// EntrySuggestionHandler handles HTTP requests for voucher entry autocomplete
+type EntrySuggestionHandler struct {
+	service service.EntrySuggestionService
+}
+
+// NewEntrySuggestionHandler creates a new EntrySuggestionHandler
+func NewEntrySuggestionHandler(svc service.EntrySuggestionService) *EntrySuggestionHandler {
+	return &EntrySuggestionHandler{service: svc}
+}
+
+// RegisterRoutes registers entry suggestion routes
+func (h *EntrySuggestionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/vouchers/suggestions", h.Suggest)
+}
+
+// Suggest returns entry suggestions
+// @Summary Suggest voucher entries
+// @Description Account, department and tax code combinations most used on posted entries of the last 24 months with a similar description or the same partner
+// @Tags vouchers
+// @Produce json
+// @Param description query string false "Entry description typed so far"
+// @Param partner_id query string false "Partner ID"
+// @Success 200 {object} dto.Response{data=[]domain.EntrySuggestion}
+// @Router /api/v1/vouchers/suggestions [get]
+func (h *EntrySuggestionHandler) Suggest(c *gin.Context) {
+	var partnerID *uuid.UUID
+	if value := c.Query("partner_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid partner ID"))
+			return
+		}
+		partnerID = &id
+	}
+
+	suggestions, err := h.service.Suggest(c.Request.Context(), appctx.GetCompanyID(c), c.Query("description"), partnerID)
+	if err != nil {
+		respondError(c, err, "Failed to get entry suggestions")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(suggestions))
+}
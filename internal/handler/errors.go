@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/banktransfer"
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/mailtemplate"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/service"
+	"github.com/saintgo7/saas-kerp/internal/xlsx"
+)
+
+// errorMap maps the errors of the services to the codes of the error taxonomy;
+// the HTTP status follows from the code (see errors.HTTPStatusCodes)
+var errorMap = apperrors.NewMapper().
+	Register(apperrors.CodeNotFound,
+		domain.ErrAccountNotFound, domain.ErrAccountTemplateNotFound, domain.ErrAllocationRuleNotFound,
+		domain.ErrAllocationRunNotFound, domain.ErrAttachmentNoThumbnail, domain.ErrAttachmentNotFound,
+		domain.ErrAuditLockNotFound, domain.ErrBackupNotFound, domain.ErrBankAccountNotFound, domain.ErrBackupRestoreNotFound, domain.ErrCloseConfirmationNotFound,
+		domain.ErrCloseTaskNotFound,
+		domain.ErrCompanyNotFound, domain.ErrContractMilestoneNotFound, domain.ErrContractNotFound, domain.ErrDataExportNotFound, domain.ErrDeletionRequestNotFound,
+		domain.ErrDocumentFileLinkNotFound, domain.ErrDocumentFileNotFound, domain.ErrDocumentFolderNotFound, domain.ErrDocumentLinkNotFound,
+		domain.ErrDocumentVersionNotFound,
+		domain.ErrAdvanceSettlementNotFound, domain.ErrDocumentNotFound, domain.ErrEmailTemplateNotFound, domain.ErrEmployeeAdvanceNotFound, domain.ErrFiscalPeriodNotFound, domain.ErrGrantExpenseNotFound,
+		domain.ErrGrantNotFound, domain.ErrInboxAliasNotFound, domain.ErrInboxItemNotFound,
+		domain.ErrIntegrationCredentialNotFound, domain.ErrInventoryCountNotFound, domain.ErrInventoryItemNotFound, domain.ErrJobNotFound, domain.ErrLedgerBalanceNotFound, domain.ErrLoanNotFound,
+		domain.ErrMembershipNotFound, domain.ErrNoteNotFound, domain.ErrPartnerNotFound, domain.ErrPaymentBatchNotFound, domain.ErrPettyCashClaimNotFound, domain.ErrPettyCashFundNotFound, domain.ErrPlanNotFound, domain.ErrPrepaidExpenseNotFound,
+		domain.ErrPrintTemplateNotFound, domain.ErrProjectNotFound, domain.ErrReportDefinitionNotFound,
+		domain.ErrReportScheduleNotFound, domain.ErrRevenueScheduleNotFound, domain.ErrRoleNotFound, domain.ErrScheduledTaskNotFound, domain.ErrTaxCodeNotFound,
+		domain.ErrTaxInvoiceBulkIssueNotFound, domain.ErrTaxInvoiceDeliveryNotFound, domain.ErrTaxInvoiceNotFound, domain.ErrTravelPolicyNotFound,
+		domain.ErrUserNotFound, domain.ErrUserTokenNotFound, domain.ErrVoucherAnomalyNotFound,
+		domain.ErrVoucherNotFound, domain.ErrVoucherTagNotFound, gorm.ErrRecordNotFound,
+		service.ErrDepartmentNotFound, service.ErrPartnerNotFound,
+		// Webhooks of integrations that are not configured do not exist
+		domain.ErrEmailBounceWebhookDisabled, domain.ErrInboxWebhookDisabled, domain.ErrPopbillWebhookDisabled).
+	Register(apperrors.CodeAlreadyExists,
+		domain.ErrAccountCodeExists, domain.ErrAllocationRunExists, domain.ErrAlreadyMember, domain.ErrBankAccountExists,
+		domain.ErrCloseTaskCodeExists, domain.ErrCompanyCodeExists, domain.ErrContractInvoiceNoExists, domain.ErrContractNoExists,
+		domain.ErrDepartmentCodeExists,
+		domain.ErrDocumentFileExists, domain.ErrDocumentFileLinkExists, domain.ErrDocumentFolderExists,
+		domain.ErrDocumentLinkExists, domain.ErrGrantNoExists, domain.ErrInventoryItemCodeExists, domain.ErrLoanNoExists, domain.ErrNoteNoExists, domain.ErrPartnerCodeExists, domain.ErrPettyCashFundExists, domain.ErrPrepaidExpenseExists,
+		domain.ErrProjectCodeExists, domain.ErrRevenueScheduleExists, domain.ErrRoleCodeExists, domain.ErrRoleNameExists, domain.ErrTaxCodeExists, domain.ErrTravelPolicyExists,
+		domain.ErrVoucherTagExists, service.ErrDepartmentCodeExists, service.ErrPartnerCodeExists).
+	Register(apperrors.CodeEmailExists, domain.ErrUserEmailExists, service.ErrUserEmailExists).
+	Register(apperrors.CodeBusinessNumberExists, service.ErrPartnerBizNoExists).
+	Register(apperrors.CodeConflict,
+		domain.ErrAccountHasChildren, domain.ErrAccountHasEntries, domain.ErrAllocationRunReversed,
+		domain.ErrAuditLockAlreadyUnlocked, domain.ErrAuditLockOpenPeriods, domain.ErrBackupInProgress, domain.ErrBackupNotRestorable,
+		domain.ErrBankAccountInUse,
+		domain.ErrCloseChecklistIncomplete, domain.ErrCloseConfirmationClosed, domain.ErrCloseConfirmationMismatch,
+		domain.ErrContractMilestoneChanged, domain.ErrContractMilestoneInvoiced, domain.ErrContractMilestoneScheduled, domain.ErrDataExportInProgress, domain.ErrDataExportNotReady,
+		domain.ErrDeletionRequestClosed, domain.ErrDeletionRequestExists,
+		domain.ErrDepartmentHasChildren, domain.ErrDocumentFileChanged, domain.ErrDocumentFolderNotEmpty, domain.ErrEmailVerified, domain.ErrEmployeeAdvanceChanged, domain.ErrEmployeeAdvanceSettled,
+		domain.ErrGrantExpenseLinked,
+		domain.ErrGrantExpenseRecognized, domain.ErrInboxItemClosed, domain.ErrInventoryCountChanged, domain.ErrInventoryCountInProgress,
+		domain.ErrInventoryCountNotOpen, domain.ErrInventoryCountNotSubmitted, domain.ErrJobNotCancellable,
+		domain.ErrJobNotRetryable, domain.ErrLoanRepaid, domain.ErrNoteNotOutstanding, domain.ErrPayablesChanged, domain.ErrPaymentBatchNotOpen,
+		domain.ErrPettyCashClaimReplenished, domain.ErrPettyCashClaimsChanged,
+		domain.ErrPopbillWebhookDuplicate, domain.ErrPrepaidExpenseLineChanged, domain.ErrProjectInUse, domain.ErrRevenueScheduleLineChanged, domain.ErrRoleInUse, domain.ErrSettlementReviewNotPending, domain.ErrTaxCodeInUse,
+		domain.ErrTaxInvoiceAlreadyAmended, domain.ErrTaxInvoiceAlreadyMatched, domain.ErrTaxInvoiceNotAmendable,
+		domain.ErrTaxInvoiceNotIssuable, domain.ErrTaxInvoiceNotMatched, domain.ErrTaxInvoiceNotSendable, domain.ErrTravelPolicyScopeExists,
+		domain.ErrVoucherAlreadyReferenced, domain.ErrVoucherAlreadyReversed, domain.ErrVoucherAnomalyReviewed,
+		domain.ErrVoucherCannotApprove, domain.ErrVoucherCannotCancel, domain.ErrVoucherCannotEdit,
+		domain.ErrVoucherCannotPost, domain.ErrVoucherCannotReject, domain.ErrVoucherCannotReverse,
+		domain.ErrVoucherCannotSchedule, domain.ErrVoucherCannotSubmit, domain.ErrVoucherNotScheduled,
+		domain.ErrVoucherOverReversal, domain.ErrYearRolledOver, service.ErrDepartmentHasChildren,
+		service.ErrDepartmentHasTransactions, service.ErrPartnerHasTransactions).
+	Register(apperrors.CodeGone,
+		domain.ErrCloseConfirmationExpired, domain.ErrDataExportExpired, domain.ErrUserTokenExpired,
+		domain.ErrUserTokenUsed).
+	Register(apperrors.CodeInvalidInput,
+		domain.ErrAccountCodeRequired, domain.ErrAccountNameRequired, domain.ErrAdvanceRefundTooLarge,
+		domain.ErrAdvanceSettlementNoExpense, domain.ErrAllocationRatioSum,
+		domain.ErrAllocationRuleNameRequired, domain.ErrAllocationTargetIsSource,
+		domain.ErrAllocationTargetsRequired, domain.ErrApprovalPINRequired, domain.ErrAttachmentEmpty, domain.ErrAttachmentTypeMismatch,
+		domain.ErrAttachmentTypeNotAllowed, domain.ErrAuditUnlockReason, domain.ErrBackupRestoreReasonRequired,
+		domain.ErrBackupRestoreReasonTooLong, domain.ErrBalancingEntryCount, domain.ErrBalancingEntrySide,
+		domain.ErrBankAccountMismatch,
+		domain.ErrCircularReference,
+		domain.ErrCloseTaskCodeEmpty, domain.ErrCloseTaskNameEmpty, domain.ErrCommentRequired,
+		domain.ErrCommentTooLong, domain.ErrCompanyNameEmpty, domain.ErrContractInvoiceIncomplete, domain.ErrDeletionReasonTooLong,
+		domain.ErrDeletionRejectReasonRequired, domain.ErrDepartmentNotFound,
+		domain.ErrDocumentFolderCycle, domain.ErrDocumentLinkNoteLength, domain.ErrDocumentLinkToItself, domain.ErrDuplicateAllocationTarget,
+		domain.ErrDuplicateReportDimension, domain.ErrEmailRequired, domain.ErrEmailTemplateBodyRequired,
+		domain.ErrEmailTemplateSubjectRequired, domain.ErrEntryAccountInvalid,
+		domain.ErrEntryInvalidAmount, domain.ErrEntryNotFound, domain.ErrEntryZeroAmount,
+		domain.ErrGrantAmountExceeded, domain.ErrInvalidAPMatchTolerance, domain.ErrInvalidAccountNature,
+		domain.ErrInvalidAccountRange, domain.ErrInvalidAccountType, domain.ErrInvalidAdvanceSettlement, domain.ErrInvalidAllocationAccountRange,
+		domain.ErrInvalidAllocationBasis, domain.ErrInvalidAllocationHeadcount, domain.ErrInvalidAllocationRatio,
+		domain.ErrInvalidAnomalyReview, domain.ErrInvalidApprovalExemption, domain.ErrInvalidBankAccount, domain.ErrInvalidBankBalance, domain.ErrInvalidBusinessNumber,
+		domain.ErrInvalidBusinessVerification, domain.ErrInvalidCatchUpPolicy, domain.ErrInvalidCloseConfirmationHours,
+		domain.ErrInvalidCloseTaskStatus, domain.ErrInvalidContract, domain.ErrInvalidContractCollection, domain.ErrInvalidCronExpression,
+		domain.ErrInvalidDataExportFormat, domain.ErrInvalidDecimalPlaces, domain.ErrInvalidDefaultTaxRate,
+		domain.ErrInvalidDeletionSubject,
+		domain.ErrInvalidDocumentAccess, domain.ErrInvalidDocumentFile, domain.ErrInvalidDocumentFolder,
+		domain.ErrInvalidDocumentType, domain.ErrInvalidDuplicateCheck, domain.ErrInvalidEmailBounceNotice,
+		domain.ErrInvalidEmailEventType, domain.ErrInvalidEmployeeAdvance,
+		domain.ErrInvalidFiscalYearStart,
+		domain.ErrInvalidGrant, domain.ErrInvalidInboundEmail, domain.ErrInvalidIntegrationCredential,
+		domain.ErrInvalidInventoryCount, domain.ErrInvalidInventoryItem, domain.ErrInventoryCountItemNotInCount, domain.ErrInventoryCountSheetColumnMissing,
+		domain.ErrInvalidJobStatus, domain.ErrInvalidJobType, domain.ErrInvalidKPIGranularity, domain.ErrInvalidKPIMetric,
+		domain.ErrInvalidKPIRange,
+		domain.ErrInvalidLoan, domain.ErrInvalidLoanRepayment, domain.ErrInvalidNote, domain.ErrInvalidNoteDiscount, domain.ErrInvalidPartnerBankAccount, domain.ErrInvalidPartnerCodeRule,
+		domain.ErrInvalidPaymentBatch, domain.ErrInvalidPettyCashClaim, domain.ErrInvalidPettyCashFund,
+		domain.ErrInvalidPettyCashReplenishment, domain.ErrInvalidPushPlatform,
+		domain.ErrInvalidPrepaidExpense, domain.ErrInvalidReportColumn,
+		domain.ErrInvalidReportDateRange, domain.ErrInvalidReportDimension, domain.ErrInvalidReportFormat,
+		domain.ErrInvalidReportPeriod, domain.ErrInvalidReportRecipient, domain.ErrInvalidReportType,
+		domain.ErrInvalidReversalRatio, domain.ErrInvalidRevenueSchedule, domain.ErrInvalidRoundingRule, domain.ErrInvalidScheduleDate,
+		domain.ErrInvalidShortcutKind,
+		domain.ErrInvalidSignatureAction, domain.ErrInvalidTaxCategory, domain.ErrInvalidTaxInvoiceAmendment,
+		domain.ErrInvalidTaxInvoiceBulkIssue, domain.ErrInvalidTaxRate, domain.ErrInvalidTaxType,
+		domain.ErrInvalidTimezone, domain.ErrInvalidTravelPolicy, domain.ErrInvalidUsagePeriod, domain.ErrInvalidUserRole,
+		domain.ErrInvalidUserStatus, domain.ErrInvalidVoucherDate, domain.ErrInvalidVoucherTagName,
+		domain.ErrInvalidVoucherType, domain.ErrKPIDimensionNotSupported, domain.ErrLoanRepaymentTooLarge,
+		domain.ErrNameRequired,
+		domain.ErrNotCashAccount, domain.ErrParentNotFound, domain.ErrPartnerMergeSelf, domain.ErrPasswordRequired, domain.ErrPaymentItemNotInBatch,
+		domain.ErrPasswordTooShort,
+		domain.ErrPostingRuleAmountAboveMax, domain.ErrPostingRuleAmountBelowMin,
+		domain.ErrPostingRuleCostCenterRequired, domain.ErrPostingRuleDepartmentRequired,
+		domain.ErrPostingRuleInvalidRange, domain.ErrPostingRulePartnerRequired,
+		domain.ErrPostingRuleProjectRequired, domain.ErrPostingRuleVoucherType, domain.ErrPrintTemplateApprovalBox,
+		domain.ErrPrepaidExpenseVoucher, domain.ErrPrintTemplateLogoFormat, domain.ErrPrintTemplateLogoSize, domain.ErrProjectCodeEmpty,
+		domain.ErrProjectNameEmpty, domain.ErrPushTokenRequired, domain.ErrReceiptTaxCodeType, domain.ErrReportColumnsRequired,
+		domain.ErrReportDefinitionNameRequired, domain.ErrReportRecipientsRequired,
+		domain.ErrReportScheduleNameRequired, domain.ErrRevenueScheduleInvoice, domain.ErrRoleCodeEmpty, domain.ErrRoleNameEmpty,
+		domain.ErrSignatureImageFormat, domain.ErrSignatureImageSize, domain.ErrSignatureRequired,
+		domain.ErrSuggestionCriteriaRequired,
+		domain.ErrSigningPINFormat, domain.ErrSigningPINNotSet, domain.ErrTaxCodeInactive,
+		domain.ErrTaxCodeNameRequired, domain.ErrTaxCodeRequired, domain.ErrTaxCodeVATAccount,
+		domain.ErrTooManyReportDimensions, domain.ErrTooManyReportRecipients, domain.ErrTooManyVoucherTags,
+		domain.ErrUnknownEntryCode,
+		domain.ErrVoucherInvalidStatus, domain.ErrVoucherNoEntries, domain.ErrVoucherReversalLinesMissing,
+		domain.ErrVoucherTagNameRequired, banktransfer.ErrInvalidTransfer, mailtemplate.ErrSyntax, mailtemplate.ErrUnknownVariable,
+		migrate.ErrEmptyFile, migrate.ErrMissingColumn, migrate.ErrUnknownDataset,
+		migrate.ErrUnknownFormat, popbill.ErrInvalidWebhookPayload, provider.ErrOCRUnsupportedFormat,
+		service.ErrDepartmentCircularRef, service.ErrInvalidCurrentPassword, service.ErrPartnerInvalidType,
+		xlsx.ErrInvalidWorkbook).
+	Register(apperrors.CodePayloadTooLarge, domain.ErrAttachmentTooLarge, domain.ErrReceiptImageTooLarge).
+	Register(apperrors.CodeVoucherUnbalanced, domain.ErrVoucherUnbalanced).
+	Register(apperrors.CodePeriodClosed, domain.ErrFiscalPeriodClosed, domain.ErrFiscalYearAuditLocked, domain.ErrPeriodClosed).
+	Register(apperrors.CodeBusinessRule,
+		domain.ErrAttachmentInfected, domain.ErrBankAccountInactive, domain.ErrBankAccountRequired, domain.ErrControlAccountPosting, domain.ErrCredentialTestFailed,
+		domain.ErrGrantExpenseOutOfPeriod, domain.ErrGrantVoucherNotLinkable, domain.ErrInventoryCountIncomplete,
+		domain.ErrInvalidRetainedEarningsAccount, domain.ErrInvalidStatementLine, domain.ErrNoInventoryItems, domain.ErrNoPayablesDue, domain.ErrNoPettyCashClaims, domain.ErrNoteNotDiscountable, domain.ErrNothingToAllocate,
+		domain.ErrPartnerCodeSequenceExhausted, domain.ErrPartnerMergeBusinessNumber,
+		domain.ErrPettyCashFundInactive, domain.ErrPettyCashInsufficient,
+		domain.ErrReceiptAmountMissing, domain.ErrRetainedEarningsAccountRequired,
+		domain.ErrStatementLineTypeMismatch, domain.ErrTaxInvoiceNoRecipient, domain.ErrTaxInvoiceNotMatchable,
+		domain.ErrTooManyFavorites,
+		domain.ErrVoucherNotMatchable, banktransfer.ErrUnsupportedBank, pdf.ErrUnsupportedImage, provider.ErrOCRRecognitionFailed,
+		service.ErrUserCannotDeactivateSelf, service.ErrUserCannotDeleteSelf, service.ErrUserLastAdmin).
+	Register(apperrors.CodeForbidden,
+		domain.ErrCloseOverrideForbidden, domain.ErrCloseSelfConfirmation, domain.ErrDocumentAccessDenied, domain.ErrInvalidDataExportLink, domain.ErrInventoryCountApprovalForbidden, domain.ErrMembershipInactive,
+		domain.ErrSegregationOfDuties, domain.ErrSettlementReviewForbidden,
+		domain.ErrSigningPINInvalid).
+	Register(apperrors.CodeTokenInvalid,
+		domain.ErrInvalidEmailBounceToken, domain.ErrInvalidInboxToken, popbill.ErrInvalidWebhookSignature,
+		popbill.ErrStaleWebhook).
+	Register(apperrors.CodeExternalService, domain.ErrBusinessVerificationFailed).
+	Register(apperrors.CodeUnavailable,
+		domain.ErrAttachmentScanUnavailable, domain.ErrBackupRestoreDisabled, domain.ErrBackupsDisabled,
+		domain.ErrBusinessVerificationDisabled,
+		domain.ErrCredentialEncryptionDisabled, domain.ErrDataExportSigningDisabled, domain.ErrShortcutsDisabled,
+		provider.ErrProviderUnavailable).
+	Register(apperrors.CodeTimeout, provider.ErrProviderTimeout).
+	Register(apperrors.CodePlanLimitExceeded, domain.ErrPlanLimitExceeded).
+	Register(apperrors.CodeInvalidCredentials, domain.ErrInvalidCredentials).
+	Register(apperrors.CodeAccountInactive, domain.ErrUserInactive).
+	Register(apperrors.CodeAccountLocked, domain.ErrSigningPINLocked, domain.ErrUserLocked).
+	Register(apperrors.CodeRefreshTokenInvalid, domain.ErrRefreshTokenExpired, domain.ErrRefreshTokenNotFound)
+
+// referenceErrors maps the accounts and tax codes a request refers to, as
+// opposed to the resource it addresses, to invalid input when they are missing
+var referenceErrors = errorMap.Extend().
+	Register(apperrors.CodeInvalidInput, domain.ErrAccountNotFound, domain.ErrTaxCodeNotFound)
+
+// respondError writes the error response of err as errorMap maps it. Errors it
+// does not know are logged and answered with a 500 carrying message, so that
+// their details do not reach the client.
+func respondError(c *gin.Context, err error, message string) {
+	respondMappedError(c, errorMap, err, message)
+}
+
+// respondMappedError is respondError with the mapper of a handler whose
+// errors mean something else than errorMap says
+func respondMappedError(c *gin.Context, mapper *apperrors.Mapper, err error, message string) {
+	if respondPlanLimitExceeded(c, err) || respondDuplicateVoucher(c, err) || respondPostingRuleViolation(c, err) {
+		return
+	}
+
+	appErr, known := mapper.Map(err)
+	if !known {
+		if logger := appctx.GetLogger(c); logger != nil {
+			logger.Error(message, zap.Error(err))
+		}
+		appErr = apperrors.Wrap(apperrors.CodeInternal, message, err)
+	}
+	c.JSON(appErr.HTTPStatus(), dto.ErrorResponse(appErr.Code, appErr.Message))
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// GrantHandler handles government grants
+type GrantHandler struct {
+	service service.GrantService
+}
+
+// NewGrantHandler creates a new GrantHandler
+func NewGrantHandler(svc service.GrantService) *GrantHandler {
+	return &GrantHandler{service: svc}
+}
+
+// RegisterRoutes registers grant routes
+func (h *GrantHandler) RegisterRoutes(r *gin.RouterGroup) {
+	grants := r.Group("/grants")
+	{
+		grants.GET("", h.List)
+		grants.POST("", h.Create)
+		grants.GET("/utilization", h.Utilization)
+		grants.POST("/recognitions", h.Recognize)
+		grants.GET("/:id", h.Get)
+		grants.GET("/:id/receipts", h.ListReceipts)
+		grants.POST("/:id/receipts", h.Receive)
+		grants.GET("/:id/expenses", h.ListExpenses)
+		grants.POST("/:id/expenses", h.LinkExpense)
+		grants.DELETE("/:id/expenses/:expense_id", h.UnlinkExpense)
+		grants.GET("/:id/recognitions", h.ListRecognitions)
+	}
+}
+
+// Create handles POST /grants
+func (h *GrantHandler) Create(c *gin.Context) {
+	var req dto.CreateGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	grant := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), grant); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create grant")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(grant))
+}
+
+// List handles GET /grants
+func (h *GrantHandler) List(c *gin.Context) {
+	filter := repository.GrantFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Search:    c.Query("search"),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if method := c.Query("recognition_method"); method != "" {
+		m := domain.GrantRecognitionMethod(method)
+		if !m.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid recognition method"))
+			return
+		}
+		filter.Method = &m
+	}
+
+	grants, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list grants")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		grants,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /grants/:id
+func (h *GrantHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+
+	grant, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get grant")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(grant))
+}
+
+// ListReceipts handles GET /grants/:id/receipts
+func (h *GrantHandler) ListReceipts(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+
+	receipts, err := h.service.ListReceipts(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list grant receipts")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(receipts))
+}
+
+// Receive handles POST /grants/:id/receipts
+func (h *GrantHandler) Receive(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ReceiveGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	receiptDate, _ := time.Parse("2006-01-02", req.ReceiptDate)
+
+	receipt, err := h.service.Receive(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, receiptDate, req.Amount)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record grant receipt")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(receipt))
+}
+
+// ListExpenses handles GET /grants/:id/expenses
+func (h *GrantHandler) ListExpenses(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+
+	expenses, err := h.service.ListExpenses(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list grant expenses")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(expenses))
+}
+
+// LinkExpense handles POST /grants/:id/expenses
+func (h *GrantHandler) LinkExpense(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+
+	var req dto.LinkGrantExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	input := service.GrantExpenseInput{
+		VoucherID:   uuid.MustParse(req.VoucherID),
+		Amount:      req.Amount,
+		Description: req.Description,
+	}
+
+	expense, err := h.service.LinkExpense(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, input)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to link grant expense")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(expense))
+}
+
+// UnlinkExpense handles DELETE /grants/:id/expenses/:expense_id
+func (h *GrantHandler) UnlinkExpense(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+	expenseID, ok := parseUUIDParam(c, "expense_id", "Invalid grant expense ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnlinkExpense(c.Request.Context(), appctx.GetCompanyID(c), id, expenseID); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to unlink grant expense")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListRecognitions handles GET /grants/:id/recognitions
+func (h *GrantHandler) ListRecognitions(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid grant ID")
+	if !ok {
+		return
+	}
+
+	recognitions, err := h.service.ListRecognitions(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list grant recognitions")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(recognitions))
+}
+
+// Recognize handles POST /grants/recognitions.
+// The worker recognizes every month end; this catches up a month for the company.
+func (h *GrantHandler) Recognize(c *gin.Context) {
+	var req dto.RecognizeGrantIncomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodEnd, _ := time.Parse("2006-01-02", req.PeriodEnd)
+	if periodEnd.AddDate(0, 0, 1).Day() != 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "period_end must be the last day of a month"))
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	run, err := h.service.RecognizeIncome(c.Request.Context(), &companyID, periodEnd)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to recognize grant income")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Utilization handles GET /grants/utilization
+func (h *GrantHandler) Utilization(c *gin.Context) {
+	var req dto.GrantUtilizationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	report, err := h.service.Utilization(c.Request.Context(), appctx.GetCompanyID(c), asOf)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get grant utilization")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(report))
+}
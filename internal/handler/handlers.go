@@ -1,61 +1,468 @@
 package handler
 
 import (
+	"time"
+
+	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/saintgo7/saas-kerp/internal/auth"
+	"github.com/saintgo7/saas-kerp/internal/config"
+	"github.com/saintgo7/saas-kerp/internal/cron"
+	"github.com/saintgo7/saas-kerp/internal/database"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/nts"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/provider"
 	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/secrets"
 	"github.com/saintgo7/saas-kerp/internal/service"
 )
 
 // Handlers holds all HTTP handlers
 type Handlers struct {
-	Health  *HealthHandler
-	Auth    *AuthHandler
-	Partner *PartnerHandler
-	Voucher *VoucherHandler
-	Ledger  *LedgerHandler
-	Account *AccountHandler
-	User    *UserHandler
-	Role    *RoleHandler
-	Company *CompanyHandler
-	Project *ProjectHandler
+	Health          *HealthHandler
+	Auth            *AuthHandler
+	Partner         *PartnerHandler
+	Voucher         *VoucherHandler
+	VoucherTag      *VoucherTagHandler
+	Activity        *ActivityHandler
+	DocumentLink    *DocumentLinkHandler
+	Ledger          *LedgerHandler
+	Account         *AccountHandler
+	User            *UserHandler
+	Role            *RoleHandler
+	Company         *CompanyHandler
+	CompanySettings *CompanySettingsHandler
+	Project         *ProjectHandler
+	CloseChecklist  *CloseChecklistHandler
+	TaxCode         *TaxCodeHandler
+	Receipt         *ReceiptHandler
+	VoucherPrint    *VoucherPrintHandler
+	ReportSchedule  *ReportScheduleHandler
+	ReportDef       *ReportDefinitionHandler
+	DepartmentPL    *DepartmentReportHandler
+	PartnerLedger   *PartnerLedgerHandler
+	CashBook        *CashBookHandler
+	Invitation      *InvitationHandler
+	DataExport      *DataExportHandler
+	LegacyImport    *LegacyImportHandler
+	Attachment      *VoucherAttachmentHandler
+	Credential      *IntegrationCredentialHandler
+	PopbillWebhook  *PopbillWebhookHandler
+	TaxInvoiceBulk  *TaxInvoiceBulkIssueHandler
+	TaxInvoiceAmend *TaxInvoiceAmendmentHandler
+	TaxInvoiceSend  *TaxInvoiceDeliveryHandler
+	PartnerVerify   *PartnerVerificationHandler
+	APMatching      *APMatchingHandler
+	Inbox           *InboxHandler
+	Loan            *LoanHandler
+	Grant           *GrantHandler
+	AllocationRun   *AllocationRunHandler
+	VoucherAnomaly  *VoucherAnomalyHandler
+	Compliance      *ComplianceHandler
+	Usage           *UsageHandler
+	Plan            *PlanHandler
+	Diagnostics     *DiagnosticsHandler
+	Backup          *BackupHandler
+	DataRetention   *DataRetentionHandler
+	Job             *JobHandler
+	ScheduledTask   *ScheduledTaskHandler
+	EmailTemplate   *EmailTemplateHandler
+	Approval        *ApprovalHandler
+	Dashboard       *DashboardHandler
+	Analytics       *AnalyticsHandler
+	EntrySuggestion *EntrySuggestionHandler
+	Shortcut        *ShortcutHandler
+	QuickVoucher    *QuickVoucherHandler
+	BankAccount     *BankAccountHandler
+	PaymentBatch    *PaymentBatchHandler
+	Note            *NoteHandler
+	PettyCash       *PettyCashHandler
+	EmployeeAdvance *EmployeeAdvanceHandler
+	TravelPolicy    *TravelPolicyHandler
+	Document        *DocumentHandler
+	Contract        *ContractHandler
+	RevenueSchedule *RevenueScheduleHandler
+	PrepaidExpense  *PrepaidExpenseHandler
+	InventoryCount  *InventoryCountHandler
 }
 
 // NewHandlers creates all handlers
-func NewHandlers(db *gorm.DB, redis *redis.Client, logger *zap.Logger, jwtService *auth.JWTService, version string) *Handlers {
+func NewHandlers(db *gorm.DB, redis *redis.Client, redisResilience *database.RedisResilience, nc *nats.Conn, logger *zap.Logger, jwtService *auth.JWTService, ocrCfg *config.OCRConfig, emailCfg *config.EmailConfig, exportCfg *config.ExportConfig, attachmentCfg *config.AttachmentConfig, credentialsCfg *config.CredentialsConfig, popbillCfg *config.PopbillConfig, ntsCfg *config.NTSConfig, inboxCfg *config.InboxConfig, planCfg *config.PlanConfig, backupCfg *config.BackupConfig, retentionCfg *config.RetentionConfig, version string) *Handlers {
 	// Initialize repositories
 	partnerRepo := repository.NewPartnerRepositoryGorm(db)
 	voucherRepo := repository.NewVoucherRepository(db)
+	voucherTagRepo := repository.NewVoucherTagRepository(db)
+	activityRepo := repository.NewActivityRepository(db)
+	documentLinkRepo := repository.NewDocumentLinkRepository(db)
+	usageRepo := repository.NewUsageRepository(db)
+	planRepo := repository.NewPlanRepository(db)
 	accountRepo := repository.NewAccountRepository(db)
 	ledgerRepo := repository.NewLedgerRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
 	roleRepo := repository.NewRoleRepository(db)
 	companyRepo := repository.NewCompanyRepository(db)
 	projectRepo := repository.NewProjectRepository(db)
+	closeChecklistRepo := repository.NewCloseChecklistRepository(db)
+	taxCodeRepo := repository.NewTaxCodeRepository(db)
+	printTemplateRepo := repository.NewVoucherPrintTemplateRepository(db)
+	signatureRepo := repository.NewVoucherSignatureRepository(db)
+	reportScheduleRepo := repository.NewReportScheduleRepository(db)
+	reportDefinitionRepo := repository.NewReportDefinitionRepository(db)
+	departmentRepo := repository.NewDepartmentRepositoryGorm(db)
+	allocationRuleRepo := repository.NewDepartmentAllocationRuleRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	membershipRepo := repository.NewUserCompanyMembershipRepository(db)
+	dataExportRepo := repository.NewDataExportRepository(db)
+	attachmentRepo := repository.NewVoucherAttachmentRepository(db)
+	credentialRepo := repository.NewIntegrationCredentialRepository(db)
+	popbillWebhookRepo := repository.NewPopbillWebhookRepository(db)
+	taxInvoiceRepo := repository.NewTaxInvoiceRepositoryGorm(db)
+	bulkIssueRepo := repository.NewTaxInvoiceBulkIssueRepository(db)
+	deliveryRepo := repository.NewTaxInvoiceDeliveryRepository(db)
+	inboxRepo := repository.NewInboxRepository(db)
+	loanRepo := repository.NewLoanRepository(db)
+	grantRepo := repository.NewGrantRepository(db)
+	allocationRunRepo := repository.NewAllocationRunRepository(db)
+	voucherAnomalyRepo := repository.NewVoucherAnomalyRepository(db)
+	yearRolloverRepo := repository.NewYearRolloverRepository(db)
+	closeConfirmationRepo := repository.NewCloseConfirmationRepository(db)
+	auditLockRepo := repository.NewAuditLockRepository(db)
+	backupRepo := repository.NewBackupRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	scheduledTaskRepo := repository.NewScheduledTaskRepository(db)
+	retentionRepo := repository.NewDataRetentionRepository(db)
+	emailTemplateRepo := repository.NewEmailTemplateRepository(db)
+	pushDeviceRepo := repository.NewPushDeviceRepository(db)
+	bankAccountRepo := repository.NewBankAccountRepository(db)
+	paymentBatchRepo := repository.NewPaymentBatchRepository(db)
+	noteRepo := repository.NewNoteRepository(db)
+	pettyCashRepo := repository.NewPettyCashRepository(db)
+	employeeAdvanceRepo := repository.NewEmployeeAdvanceRepository(db)
+	travelPolicyRepo := repository.NewTravelPolicyRepository(db)
+	documentRepo := repository.NewDocumentRepository(db)
+	contractRepo := repository.NewContractRepository(db)
+	revenueScheduleRepo := repository.NewRevenueScheduleRepository(db)
+	prepaidExpenseRepo := repository.NewPrepaidExpenseRepository(db)
+	inventoryCountRepo := repository.NewInventoryCountRepository(db)
 
 	// Initialize services
-	partnerService := service.NewPartnerService(partnerRepo)
+	planService := service.NewPlanService(planRepo, service.PlanOptions{
+		Enforcement:  planCfg.Enforcement,
+		GracePercent: planCfg.GracePercent,
+		GracePeriod:  planCfg.GracePeriod,
+	}, logger)
 	accountService := service.NewAccountService(accountRepo)
-	voucherService := service.NewVoucherService(voucherRepo, accountRepo)
-	ledgerService := service.NewLedgerService(ledgerRepo, accountRepo)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, refreshTokenRepo, planService)
 	roleService := service.NewRoleService(roleRepo)
 	companyService := service.NewCompanyService(companyRepo)
+	companySettingsService := service.NewCompanySettingsService(companyRepo)
+	partnerService := service.NewPartnerService(partnerRepo, companySettingsService, auditLockRepo)
+	var reportCache service.ReportCache
+	if redis != nil {
+		reportCache = database.NewRedisCache(redis, redisResilience, database.RedisFeatureReportCache)
+	}
+	ledgerService := service.NewLedgerService(ledgerRepo, accountRepo, closeChecklistRepo, yearRolloverRepo, closeConfirmationRepo, auditLockRepo, companySettingsService, reportCache)
+	voucherService := service.NewVoucherService(voucherRepo, accountRepo, taxCodeRepo, companySettingsService, ledgerRepo, planService, bankAccountRepo)
+	voucherTagService := service.NewVoucherTagService(voucherTagRepo)
+	activityService := service.NewActivityService(activityRepo, voucherRepo)
+	documentLinkService := service.NewDocumentLinkService(documentLinkRepo)
 	projectService := service.NewProjectService(projectRepo)
+	closeChecklistService := service.NewCloseChecklistService(closeChecklistRepo, ledgerRepo)
+	taxCodeService := service.NewTaxCodeService(taxCodeRepo, accountRepo)
+	receiptService := service.NewReceiptService(newReceiptOCRProvider(ocrCfg), partnerRepo, taxCodeRepo, companySettingsService)
+	voucherSignatureService := service.NewVoucherSignatureService(signatureRepo, userRepo, companyRepo)
+	voucherPrintService := service.NewVoucherPrintService(voucherRepo, companyRepo, userRepo, printTemplateRepo, signatureRepo)
+	notificationService := service.NewNotificationService(newEmailProvider(emailCfg))
+	reportService := service.NewReportService(ledgerRepo, accountRepo, taxCodeRepo, companyRepo)
+	emailTemplateService := service.NewEmailTemplateService(emailTemplateRepo)
+	jobService := service.NewJobService(jobRepo) // jobs are run by the worker
+	// The API only lists and reschedules the tasks; they are run by the worker
+	schedulerService := service.NewSchedulerService(scheduledTaskRepo, "")
+	// Approval pushes are queued as jobs and sent by the worker
+	approvalService := service.NewApprovalService(pushDeviceRepo, userRepo, voucherService, companySettingsService, voucherSignatureService, notificationService, jobService, logger)
+	dashboardService := service.NewDashboardService(ledgerRepo, accountRepo, partnerRepo, approvalService, activityService, reportCache)
+	analyticsService := service.NewAnalyticsService(ledgerRepo, accountRepo, reportCache)
+	entrySuggestionService := service.NewEntrySuggestionService(voucherRepo)
+	var shortcutStore service.ShortcutStore
+	if redis != nil {
+		shortcutStore = database.NewRedisCache(redis, redisResilience, database.RedisFeatureShortcuts)
+	}
+	shortcutService := service.NewShortcutService(shortcutStore, accountRepo, partnerRepo)
+	quickVoucherService := service.NewQuickVoucherService(voucherService, accountRepo, partnerRepo, departmentRepo, projectRepo, taxCodeRepo)
+	reportScheduleService := service.NewReportScheduleService(reportScheduleRepo, userRepo, reportService, notificationService, emailTemplateService, jobService)
+	reportDefinitionService := service.NewReportDefinitionService(reportDefinitionRepo, companyRepo)
+	departmentReportService := service.NewDepartmentReportService(ledgerRepo, departmentRepo, allocationRuleRepo)
+	partnerLedgerService := service.NewPartnerLedgerService(ledgerRepo, partnerRepo, companyRepo, reportService, notificationService, emailTemplateService)
+	cashBookService := service.NewCashBookService(ledgerRepo, accountRepo)
+	onboardingService := service.NewOnboardingService(userTokenRepo, userRepo, membershipRepo, companyRepo, notificationService, emailTemplateService, planService, emailCfg.LinkBaseURL)
+	legacyImportService := service.NewLegacyImportService(accountRepo, partnerRepo, voucherRepo, ledgerRepo, accountService, partnerService, voucherService, companySettingsService)
+	dataExportService := service.NewDataExportService(dataExportRepo, companyRepo, exportCfg.SigningSecret, exportCfg.LinkTTL, exportCfg.Retention)
+	attachmentService := service.NewVoucherAttachmentService(attachmentRepo, voucherRepo, userRepo, newVirusScanner(attachmentCfg), newImageConverter(attachmentCfg), notificationService, logger, attachmentCfg.MaxFileSize, attachmentCfg.ThumbnailSize, planService)
+	popbillOptions := newPopbillOptions(popbillCfg, redis)
+	keyManager := newKeyManager(credentialsCfg, logger)
+	credentialService := service.NewIntegrationCredentialService(credentialRepo, keyManager, popbillOptions, credentialsCfg.TestTimeout)
+	popbillServices := popbill.NewServices(credentialService, popbillCfg.Timeout, popbillOptions)
+	popbillWebhookService := service.NewPopbillWebhookService(popbillWebhookRepo, taxInvoiceRepo, popbillCfg.WebhookSecret, popbillCfg.WebhookTolerance)
+	bulkIssueService := service.NewTaxInvoiceBulkIssueService(bulkIssueRepo, taxInvoiceRepo, popbillServices, popbillCfg.BulkIssueConcurrency, popbillCfg.BulkIssueMaxInvoices)
+	amendmentService := service.NewTaxInvoiceAmendmentService(taxInvoiceRepo, voucherRepo, voucherService, taxCodeRepo)
+	deliveryService := service.NewTaxInvoiceDeliveryService(deliveryRepo, taxInvoiceRepo, notificationService, emailCfg.From, emailCfg.BounceWebhookSecret)
+	apMatchingService := service.NewAPMatchingService(taxInvoiceRepo, voucherRepo, partnerRepo)
+	businessVerificationService := service.NewBusinessVerificationService(partnerRepo, nts.NewClient(nts.Config{
+		BaseURL:    ntsCfg.BaseURL,
+		ServiceKey: ntsCfg.ServiceKey,
+		Timeout:    ntsCfg.Timeout,
+	}))
+	inboxService := service.NewInboxService(inboxRepo, attachmentService, service.InboxOptions{
+		Domain:            inboxCfg.Domain,
+		WebhookSecret:     inboxCfg.WebhookSecret,
+		MaxAttachmentSize: inboxCfg.MaxAttachmentSize,
+	})
+	loanService := service.NewLoanService(loanRepo, accountRepo, voucherService)
+	grantService := service.NewGrantService(grantRepo, accountRepo, voucherRepo, voucherService)
+	allocationRunService := service.NewAllocationRunService(allocationRunRepo, allocationRuleRepo, ledgerRepo, voucherService)
+	voucherAnomalyService := service.NewVoucherAnomalyService(voucherAnomalyRepo)
+	meteringService := service.NewMeteringService(usageRepo, nil) // usage is exported by the worker
+	backupService := service.NewBackupService(backupRepo, dataExportRepo, companyRepo, newBackupStorage(backupCfg), keyManager,
+		newBackupStaging(backupCfg, logger), newBackupOptions(backupCfg)) // backups are taken by the worker
+	bankAccountService := service.NewBankAccountService(bankAccountRepo, accountRepo, ledgerRepo)
+	paymentBatchService := service.NewPaymentBatchService(paymentBatchRepo, bankAccountRepo, voucherService)
+	noteService := service.NewNoteService(noteRepo, partnerRepo, accountRepo, bankAccountRepo, companyRepo, userRepo, voucherService, notificationService, emailTemplateService)
+	pettyCashService := service.NewPettyCashService(pettyCashRepo, accountRepo, departmentRepo, userRepo, bankAccountRepo, ledgerRepo, voucherService)
+	travelPolicyService := service.NewTravelPolicyService(travelPolicyRepo, departmentRepo)
+	employeeAdvanceService := service.NewEmployeeAdvanceService(employeeAdvanceRepo, travelPolicyRepo, userRepo, departmentRepo, accountRepo, bankAccountRepo, voucherService)
+	documentService := service.NewDocumentService(documentRepo, attachmentRepo, documentLinkRepo, userRepo, newVirusScanner(attachmentCfg), notificationService, logger, attachmentCfg.MaxFileSize, planService)
+	contractService := service.NewContractService(contractRepo, partnerRepo, accountRepo, taxCodeRepo, bankAccountRepo, companyRepo, userRepo, taxInvoiceRepo, voucherService, notificationService, emailTemplateService)
+	revenueScheduleService := service.NewRevenueScheduleService(revenueScheduleRepo, taxInvoiceRepo, partnerRepo, accountRepo, voucherRepo, voucherService)
+	prepaidExpenseService := service.NewPrepaidExpenseService(prepaidExpenseRepo, accountRepo, voucherRepo, voucherService)
+	inventoryCountService := service.NewInventoryCountService(inventoryCountRepo, accountRepo, userRepo, voucherService)
+	dataRetentionService := service.NewDataRetentionService(retentionRepo, userRepo, partnerRepo, newRetentionPolicy(retentionCfg))
 
 	return &Handlers{
-		Health:  NewHealthHandler(db, redis, logger, version),
-		Auth:    NewAuthHandler(db, redis, logger, jwtService),
-		Partner: NewPartnerHandler(partnerService),
-		Voucher: NewVoucherHandler(voucherService),
-		Ledger:  NewLedgerHandler(ledgerService, accountService),
-		Account: NewAccountHandler(accountService),
-		User:    NewUserHandler(userService),
-		Role:    NewRoleHandler(roleService),
-		Company: NewCompanyHandler(companyService),
-		Project: NewProjectHandler(projectService),
+		Health:          NewHealthHandler(db, redis, redisResilience, logger, version),
+		Auth:            NewAuthHandler(db, redis, logger, jwtService, onboardingService),
+		Partner:         NewPartnerHandler(partnerService),
+		Voucher:         NewVoucherHandler(voucherService, voucherSignatureService, approvalService, shortcutService),
+		VoucherTag:      NewVoucherTagHandler(voucherTagService),
+		Activity:        NewActivityHandler(activityService),
+		DocumentLink:    NewDocumentLinkHandler(documentLinkService),
+		Ledger:          NewLedgerHandler(ledgerService, accountService, jobService),
+		Account:         NewAccountHandler(accountService),
+		User:            NewUserHandler(userService),
+		Role:            NewRoleHandler(roleService),
+		Company:         NewCompanyHandler(companyService),
+		CompanySettings: NewCompanySettingsHandler(companySettingsService),
+		Project:         NewProjectHandler(projectService),
+		CloseChecklist:  NewCloseChecklistHandler(closeChecklistService),
+		TaxCode:         NewTaxCodeHandler(taxCodeService),
+		Receipt:         NewReceiptHandler(receiptService, ocrCfg.MaxImageSize),
+		VoucherPrint:    NewVoucherPrintHandler(voucherPrintService),
+		ReportSchedule:  NewReportScheduleHandler(reportScheduleService),
+		ReportDef:       NewReportDefinitionHandler(reportDefinitionService, reportService),
+		DepartmentPL:    NewDepartmentReportHandler(departmentReportService),
+		PartnerLedger:   NewPartnerLedgerHandler(partnerLedgerService),
+		CashBook:        NewCashBookHandler(cashBookService),
+		Invitation:      NewInvitationHandler(onboardingService),
+		DataExport:      NewDataExportHandler(dataExportService),
+		LegacyImport:    NewLegacyImportHandler(legacyImportService, jobService),
+		Attachment:      NewVoucherAttachmentHandler(attachmentService, attachmentCfg.MaxFileSize),
+		Credential:      NewIntegrationCredentialHandler(credentialService),
+		PopbillWebhook:  NewPopbillWebhookHandler(popbillWebhookService),
+		TaxInvoiceBulk:  NewTaxInvoiceBulkIssueHandler(bulkIssueService),
+		TaxInvoiceAmend: NewTaxInvoiceAmendmentHandler(amendmentService),
+		TaxInvoiceSend:  NewTaxInvoiceDeliveryHandler(deliveryService),
+		PartnerVerify:   NewPartnerVerificationHandler(businessVerificationService),
+		APMatching:      NewAPMatchingHandler(apMatchingService),
+		Inbox:           NewInboxHandler(inboxService, inboxCfg.MaxMessageSize),
+		Loan:            NewLoanHandler(loanService),
+		Grant:           NewGrantHandler(grantService),
+		AllocationRun:   NewAllocationRunHandler(allocationRunService),
+		VoucherAnomaly:  NewVoucherAnomalyHandler(voucherAnomalyService),
+		Compliance:      NewComplianceHandler(voucherService),
+		Usage:           NewUsageHandler(meteringService),
+		Plan:            NewPlanHandler(planService),
+		Diagnostics:     NewDiagnosticsHandler(db, redis, redisResilience, nc, logger, version),
+		Backup:          NewBackupHandler(backupService),
+		DataRetention:   NewDataRetentionHandler(dataRetentionService),
+		Job:             NewJobHandler(jobService),
+		ScheduledTask:   NewScheduledTaskHandler(schedulerService),
+		EmailTemplate:   NewEmailTemplateHandler(emailTemplateService),
+		Approval:        NewApprovalHandler(approvalService),
+		Dashboard:       NewDashboardHandler(dashboardService),
+		Analytics:       NewAnalyticsHandler(analyticsService),
+		EntrySuggestion: NewEntrySuggestionHandler(entrySuggestionService),
+		Shortcut:        NewShortcutHandler(shortcutService),
+		QuickVoucher:    NewQuickVoucherHandler(quickVoucherService, shortcutService),
+		BankAccount:     NewBankAccountHandler(bankAccountService),
+		PaymentBatch:    NewPaymentBatchHandler(paymentBatchService),
+		Note:            NewNoteHandler(noteService),
+		PettyCash:       NewPettyCashHandler(pettyCashService),
+		EmployeeAdvance: NewEmployeeAdvanceHandler(employeeAdvanceService),
+		TravelPolicy:    NewTravelPolicyHandler(travelPolicyService),
+		Document:        NewDocumentHandler(documentService, attachmentCfg.MaxFileSize),
+		Contract:        NewContractHandler(contractService),
+		RevenueSchedule: NewRevenueScheduleHandler(revenueScheduleService),
+		PrepaidExpense:  NewPrepaidExpenseHandler(prepaidExpenseService),
+		InventoryCount:  NewInventoryCountHandler(inventoryCountService),
+	}
+}
+
+// newReceiptOCRProvider creates the configured receipt OCR provider, or nil if OCR is disabled
+func newReceiptOCRProvider(cfg *config.OCRConfig) provider.ReceiptOCRProvider {
+	switch cfg.Provider {
+	case string(provider.ProviderTypeClova):
+		return provider.NewClovaOCRProvider(&provider.ClovaOCRConfig{
+			InvokeURL: cfg.InvokeURL,
+			SecretKey: cfg.SecretKey,
+			Timeout:   cfg.Timeout,
+		})
+	}
+	return nil
+}
+
+// newEmailProvider creates the configured outgoing email provider, or nil if email is disabled
+func newEmailProvider(cfg *config.EmailConfig) provider.EmailProvider {
+	switch cfg.Provider {
+	case string(provider.ProviderTypeSMTP):
+		return provider.NewSMTPEmailProvider(&provider.SMTPConfig{
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			Username: cfg.Username,
+			Password: cfg.Password,
+			From:     cfg.From,
+			FromName: cfg.FromName,
+			Timeout:  cfg.Timeout,
+		})
+	}
+	return nil
+}
+
+// newVirusScanner creates the configured attachment virus scanner, or nil if scanning is disabled
+func newVirusScanner(cfg *config.AttachmentConfig) provider.VirusScanner {
+	switch cfg.Scanner {
+	case string(provider.ProviderTypeClamAV):
+		return provider.NewClamAVScanner(&provider.ClamAVConfig{
+			Address: cfg.ClamAVAddress,
+			Timeout: cfg.ScanTimeout,
+		})
+	}
+	return nil
+}
+
+// newImageConverter creates the configured HEIC converter, or nil if HEIC photos are stored as uploaded
+func newImageConverter(cfg *config.AttachmentConfig) provider.ImageConverter {
+	switch cfg.HEICConverter {
+	case string(provider.ProviderTypeMagick):
+		return provider.NewImageMagickConverter(&provider.ImageMagickConfig{
+			Path:    cfg.ImageMagickPath,
+			Timeout: cfg.ConvertTimeout,
+		})
+	}
+	return nil
+}
+
+// newKeyManager creates the master key manager of stored credentials, or nil if
+// credential encryption is not configured
+func newKeyManager(cfg *config.CredentialsConfig, logger *zap.Logger) secrets.KeyManager {
+	switch cfg.KeyProvider {
+	case "local":
+		keys := map[string]string{cfg.MasterKeyID: cfg.MasterKey}
+		for _, k := range cfg.RetiredKeys {
+			keys[k.ID] = k.Key
+		}
+		km, err := secrets.NewLocalKeyManager(cfg.MasterKeyID, keys)
+		if err != nil {
+			logger.Error("credential encryption disabled", zap.Error(err))
+			return nil
+		}
+		return km
+	case "vault":
+		return secrets.NewVaultTransitKeyManager(&secrets.VaultTransitConfig{
+			Address: cfg.VaultAddress,
+			Token:   cfg.VaultToken,
+			KeyName: cfg.VaultTransitKey,
+			Timeout: cfg.VaultTimeout,
+		})
+	}
+	return nil
+}
+
+// newBackupStorage creates the configured backup object storage, or nil if backups are disabled
+func newBackupStorage(cfg *config.BackupConfig) provider.ObjectStorage {
+	switch cfg.Storage {
+	case string(provider.ProviderTypeS3):
+		return provider.NewS3Storage(&provider.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+			Timeout:         cfg.StorageTimeout,
+		})
+	case string(provider.ProviderTypeFile):
+		return provider.NewFileStorage(cfg.FileDir)
+	}
+	return nil
+}
+
+// newBackupStaging creates the repository of the staging database restores
+// are loaded into, or nil if restores are disabled
+func newBackupStaging(cfg *config.BackupConfig, logger *zap.Logger) repository.BackupStagingRepository {
+	if cfg.StagingDSN == "" {
+		return nil
+	}
+	db, err := database.NewStagingDB(cfg.StagingDSN)
+	if err != nil {
+		logger.Error("backup restores disabled", zap.Error(err))
+		return nil
+	}
+	return repository.NewBackupStagingRepository(db)
+}
+
+// newBackupOptions creates the schedule, retention and storage layout of
+// backups; Validate has checked the schedule and time zone
+func newBackupOptions(cfg *config.BackupConfig) service.BackupOptions {
+	options := service.BackupOptions{
+		Retention: domain.BackupRetention{Days: cfg.RetentionDays, MonthlyMonths: cfg.MonthlyRetentionMonths},
+	}
+	if cfg.Storage == string(provider.ProviderTypeS3) {
+		options.StoragePrefix = cfg.S3Prefix
+	}
+	options.Schedule, _ = cron.Parse(cfg.Schedule)
+	options.Location, _ = time.LoadLocation(cfg.Timezone)
+	return options
+}
+
+// newRetentionPolicy creates the retention policy of the data classes
+func newRetentionPolicy(cfg *config.RetentionConfig) domain.RetentionPolicy {
+	return domain.RetentionPolicy{
+		AccountingYears:      cfg.AccountingYears,
+		TaxYears:             cfg.TaxYears,
+		AuditLogDays:         cfg.AuditLogDays,
+		SessionDays:          cfg.SessionDays,
+		DeletionDeadlineDays: cfg.DeletionDeadlineDays,
+	}
+}
+
+// newPopbillOptions creates the retry policy, circuit breaker and token cache shared by all Popbill clients
+func newPopbillOptions(cfg *config.PopbillConfig, rdb *redis.Client) *popbill.ClientOptions {
+	options := &popbill.ClientOptions{
+		Retry: popbill.RetryPolicy{
+			MaxAttempts: cfg.RetryMaxAttempts,
+			BaseDelay:   cfg.RetryBaseDelay,
+			MaxDelay:    cfg.RetryMaxDelay,
+		},
+	}
+	if cfg.BreakerThreshold > 0 {
+		options.Breaker = popbill.NewCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerOpenTimeout)
+	}
+	if cfg.ShareTokens && rdb != nil {
+		options.TokenCache = popbill.NewRedisTokenCache(rdb, "kerp:popbill:token:")
 	}
+	return options
 }
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// Inbound email webhook authorization
+const (
+	inboxTokenHeader      = "X-Inbox-Token"
+	inboxTokenQuery       = "token" // SNS subscriptions cannot set headers
+	snsMessageTypeHeader  = "X-Amz-Sns-Message-Type"
+	inboxEnvelopeToHeader = "X-Envelope-To"
+)
+
+// InboxHandler handles the inbound email inbox of supplier invoices
+type InboxHandler struct {
+	service        service.InboxService
+	maxMessageSize int64
+}
+
+// NewInboxHandler creates a new InboxHandler
+func NewInboxHandler(svc service.InboxService, maxMessageSize int64) *InboxHandler {
+	return &InboxHandler{service: svc, maxMessageSize: maxMessageSize}
+}
+
+// RegisterRoutes registers inbox routes.
+// The inbound email webhook is public and registered separately.
+func (h *InboxHandler) RegisterRoutes(r *gin.RouterGroup) {
+	inbox := r.Group("/inbox")
+	{
+		inbox.GET("/address", h.Address)
+		inbox.POST("/address/rotate", h.RotateAddress)
+		inbox.GET("/items", h.List)
+		inbox.GET("/items/:id", h.Get)
+		inbox.GET("/items/:id/document", h.Document)
+		inbox.POST("/items/:id/convert", h.Convert)
+		inbox.POST("/items/:id/discard", h.Discard)
+	}
+}
+
+// Receive handles POST /webhooks/email/inbound.
+// The body is a raw RFC 5322 message, or an SNS message of an SES receipt rule
+// when the X-Amz-Sns-Message-Type header is present. Envelope recipients of a
+// raw message may be passed in X-Envelope-To headers.
+func (h *InboxHandler) Receive(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, h.maxMessageSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Failed to read request body"))
+		return
+	}
+	if int64(len(body)) > h.maxMessageSize {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse(dto.ErrCodeValidation, "Message is too large"))
+		return
+	}
+
+	token := c.GetHeader(inboxTokenHeader)
+	if token == "" {
+		token = c.Query(inboxTokenQuery)
+	}
+
+	var receipt *service.InboxReceipt
+	if c.GetHeader(snsMessageTypeHeader) != "" {
+		receipt, err = h.service.ReceiveSNS(c.Request.Context(), token, body)
+	} else {
+		receipt, err = h.service.Receive(c.Request.Context(), token, body, c.Request.Header.Values(inboxEnvelopeToHeader))
+	}
+	if err != nil {
+		respondError(c, err, "Failed to receive email")
+		return
+	}
+	if receipt == nil {
+		receipt = &service.InboxReceipt{}
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(receipt))
+}
+
+// Address handles GET /inbox/address
+func (h *InboxHandler) Address(c *gin.Context) {
+	address, err := h.service.Address(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondInboxError(c, err, "Failed to get inbox address")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"address": address}))
+}
+
+// RotateAddress handles POST /inbox/address/rotate
+func (h *InboxHandler) RotateAddress(c *gin.Context) {
+	address, err := h.service.RotateAddress(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondInboxError(c, err, "Failed to rotate inbox address")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"address": address}))
+}
+
+// List handles GET /inbox/items
+func (h *InboxHandler) List(c *gin.Context) {
+	filter := repository.InboxItemFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.InboxItemStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid inbox item status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	items, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondInboxError(c, err, "Failed to list inbox items")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		items,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /inbox/items/:id
+func (h *InboxHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inbox item ID")
+	if !ok {
+		return
+	}
+
+	item, err := h.service.Get(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondInboxError(c, err, "Failed to get inbox item")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(item))
+}
+
+// Document handles GET /inbox/items/:id/document
+func (h *InboxHandler) Document(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inbox item ID")
+	if !ok {
+		return
+	}
+
+	document, err := h.service.Document(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondInboxError(c, err, "Failed to get inbox document")
+		return
+	}
+
+	disposition := "attachment"
+	if c.Query("inline") == "true" {
+		disposition = "inline"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, document.FileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, document.ContentType, document.Data)
+}
+
+// Convert handles POST /inbox/items/:id/convert
+func (h *InboxHandler) Convert(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inbox item ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ConvertInboxItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	voucherID, _ := uuid.Parse(req.VoucherID)
+
+	item, err := h.service.Convert(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, voucherID, c.ClientIP())
+	if err != nil {
+		respondInboxError(c, err, "Failed to convert inbox item")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(item))
+}
+
+// Discard handles POST /inbox/items/:id/discard
+func (h *InboxHandler) Discard(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inbox item ID")
+	if !ok {
+		return
+	}
+
+	var req dto.DiscardInboxItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	item, err := h.service.Discard(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.Note)
+	if err != nil {
+		respondInboxError(c, err, "Failed to discard inbox item")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(item))
+}
+
+// respondInboxError writes the response of an inbox error. Inbound email that
+// is not configured is unavailable here, not a missing webhook.
+func respondInboxError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, domain.ErrInboxWebhookDisabled) {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse(apperrors.CodeUnavailable, "Inbound email is not configured"))
+		return
+	}
+	respondError(c, err, fallback)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// IntegrationCredentialHandler handles HTTP requests for integration credentials
+type IntegrationCredentialHandler struct {
+	service service.IntegrationCredentialService
+}
+
+// NewIntegrationCredentialHandler creates a new IntegrationCredentialHandler
+func NewIntegrationCredentialHandler(svc service.IntegrationCredentialService) *IntegrationCredentialHandler {
+	return &IntegrationCredentialHandler{service: svc}
+}
+
+// RegisterRoutes registers integration credential routes
+func (h *IntegrationCredentialHandler) RegisterRoutes(r *gin.RouterGroup) {
+	credentials := r.Group("/integrations/credentials")
+	{
+		credentials.GET("", h.List)
+		credentials.PUT("/popbill", h.SavePopbill)
+		credentials.POST("/popbill/test", h.TestPopbill)
+		credentials.PUT("/bank", h.SaveBank)
+		credentials.POST("/:id/test", h.Test)
+		credentials.DELETE("/:id", h.Delete)
+	}
+}
+
+// List handles GET /integrations/credentials
+func (h *IntegrationCredentialHandler) List(c *gin.Context) {
+	credentials, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list credentials")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromIntegrationCredentials(credentials)))
+}
+
+// SavePopbill handles PUT /integrations/credentials/popbill
+func (h *IntegrationCredentialHandler) SavePopbill(c *gin.Context) {
+	var req dto.PopbillCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	credential, err := h.service.SavePopbill(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), req.ToDomain())
+	if err != nil {
+		respondError(c, err, "Failed to save Popbill credentials")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromIntegrationCredential(credential)))
+}
+
+// TestPopbill handles POST /integrations/credentials/popbill/test.
+// The credentials in the body are tested without being stored.
+func (h *IntegrationCredentialHandler) TestPopbill(c *gin.Context) {
+	var req dto.PopbillCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	if err := h.service.TestPopbill(c.Request.Context(), req.ToDomain()); err != nil {
+		respondError(c, err, "Failed to test Popbill credentials")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"succeeded": true}))
+}
+
+// SaveBank handles PUT /integrations/credentials/bank
+func (h *IntegrationCredentialHandler) SaveBank(c *gin.Context) {
+	var req dto.BankCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	credential, err := h.service.SaveBank(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), req.ToDomain())
+	if err != nil {
+		respondError(c, err, "Failed to save bank credentials")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromIntegrationCredential(credential)))
+}
+
+// Test handles POST /integrations/credentials/:id/test.
+// The outcome is recorded on the credential and returned in the response.
+func (h *IntegrationCredentialHandler) Test(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid credential ID"))
+		return
+	}
+
+	credential, err := h.service.Test(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to test credential")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromIntegrationCredential(credential)))
+}
+
+// Delete handles DELETE /integrations/credentials/:id
+func (h *IntegrationCredentialHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid credential ID"))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to delete credential")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// InventoryCountHandler handles inventory items and stocktakes
+type InventoryCountHandler struct {
+	service service.InventoryCountService
+}
+
+// NewInventoryCountHandler creates a new InventoryCountHandler
+func NewInventoryCountHandler(svc service.InventoryCountService) *InventoryCountHandler {
+	return &InventoryCountHandler{service: svc}
+}
+
+// RegisterRoutes registers inventory item and count routes
+func (h *InventoryCountHandler) RegisterRoutes(r *gin.RouterGroup) {
+	items := r.Group("/inventory-items")
+	{
+		items.GET("", h.ListItems)
+		items.POST("", h.CreateItem)
+		items.GET("/:id", h.GetItem)
+		items.PUT("/:id", h.UpdateItem)
+	}
+
+	counts := r.Group("/inventory-counts")
+	{
+		counts.GET("", h.List)
+		counts.POST("", h.Open)
+		counts.GET("/:id", h.Get)
+		counts.GET("/:id/variances", h.Variances)
+		counts.POST("/:id/counts", h.RecordCounts)
+		counts.POST("/:id/counts/import", h.ImportCounts)
+		counts.POST("/:id/submit", h.Submit)
+		counts.POST("/:id/reopen", h.Reopen)
+		counts.POST("/:id/approve", h.Approve)
+		counts.POST("/:id/cancel", h.Cancel)
+	}
+}
+
+// CreateItem handles POST /inventory-items
+func (h *InventoryCountHandler) CreateItem(c *gin.Context) {
+	var req dto.InventoryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	item, err := h.service.CreateItem(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), inventoryItemInput(&req))
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create inventory item")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(item))
+}
+
+// UpdateItem handles PUT /inventory-items/:id
+func (h *InventoryCountHandler) UpdateItem(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory item ID")
+	if !ok {
+		return
+	}
+	var req dto.InventoryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	item, err := h.service.UpdateItem(c.Request.Context(), appctx.GetCompanyID(c), id, inventoryItemInput(&req))
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to update inventory item")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(item))
+}
+
+// inventoryItemInput converts the request; the account is validated by binding
+func inventoryItemInput(req *dto.InventoryItemRequest) service.InventoryItemInput {
+	return service.InventoryItemInput{
+		Code:      req.Code,
+		Name:      req.Name,
+		Unit:      req.Unit,
+		AccountID: uuid.MustParse(req.AccountID),
+		UnitCost:  req.UnitCost,
+		Quantity:  req.Quantity,
+		IsActive:  req.IsActive,
+	}
+}
+
+// GetItem handles GET /inventory-items/:id
+func (h *InventoryCountHandler) GetItem(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory item ID")
+	if !ok {
+		return
+	}
+
+	item, err := h.service.GetItem(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get inventory item")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(item))
+}
+
+// ListItems handles GET /inventory-items
+func (h *InventoryCountHandler) ListItems(c *gin.Context) {
+	filter := repository.InventoryItemFilter{
+		CompanyID:  appctx.GetCompanyID(c),
+		Search:     strings.TrimSpace(c.Query("search")),
+		ActiveOnly: c.Query("active") == "true",
+		Page:       1,
+		PageSize:   50,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 200 {
+		filter.PageSize = pageSize
+	}
+	if accountID := c.Query("account_id"); accountID != "" {
+		id, err := uuid.Parse(accountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid account ID"))
+			return
+		}
+		filter.AccountID = &id
+	}
+
+	items, total, err := h.service.ListItems(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list inventory items")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		items,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Open handles POST /inventory-counts
+func (h *InventoryCountHandler) Open(c *gin.Context) {
+	var req dto.OpenInventoryCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	countDate, _ := time.Parse("2006-01-02", req.CountDate)
+	input := service.InventoryCountInput{
+		CountDate:     countDate,
+		Description:   req.Description,
+		LossAccountID: uuid.MustParse(req.LossAccountID),
+		GainAccountID: uuid.MustParse(req.GainAccountID),
+	}
+	if req.AccountID != "" {
+		accountID := uuid.MustParse(req.AccountID)
+		input.AccountID = &accountID
+	}
+
+	count, err := h.service.Open(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), input)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to open inventory count")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(count))
+}
+
+// List handles GET /inventory-counts
+func (h *InventoryCountHandler) List(c *gin.Context) {
+	filter := repository.InventoryCountFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.InventoryCountStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid inventory count status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	counts, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list inventory counts")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		counts,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /inventory-counts/:id
+func (h *InventoryCountHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+
+	count, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get inventory count")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
+
+// Variances handles GET /inventory-counts/:id/variances
+func (h *InventoryCountHandler) Variances(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.Summary(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get inventory count variances")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(summary))
+}
+
+// RecordCounts handles POST /inventory-counts/:id/counts
+func (h *InventoryCountHandler) RecordCounts(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+	var req dto.RecordInventoryCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	count, err := h.service.RecordCounts(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.ToDomain())
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record inventory counts")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
+
+// ImportCounts handles POST /inventory-counts/:id/counts/import, a CSV or tab
+// separated count sheet in the "file" part with item code and counted
+// quantity columns
+func (h *InventoryCountHandler) ImportCounts(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+	data, err := readUploadedFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Count sheet is required", err.Error()))
+		return
+	}
+
+	count, err := h.service.ImportCountSheet(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, data)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to import inventory counts")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
+
+// Submit handles POST /inventory-counts/:id/submit
+func (h *InventoryCountHandler) Submit(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+
+	count, err := h.service.Submit(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to submit inventory count")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
+
+// Reopen handles POST /inventory-counts/:id/reopen
+func (h *InventoryCountHandler) Reopen(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+
+	count, err := h.service.Reopen(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to reopen inventory count")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
+
+// Approve handles POST /inventory-counts/:id/approve
+func (h *InventoryCountHandler) Approve(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+
+	count, err := h.service.Approve(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to approve inventory count")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
+
+// Cancel handles POST /inventory-counts/:id/cancel
+func (h *InventoryCountHandler) Cancel(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid inventory count ID")
+	if !ok {
+		return
+	}
+
+	count, err := h.service.Cancel(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to cancel inventory count")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(count))
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// InvitationHandler handles HTTP requests for user invitations
+type InvitationHandler struct {
+	service service.OnboardingService
+}
+
+// NewInvitationHandler creates a new InvitationHandler
+func NewInvitationHandler(svc service.OnboardingService) *InvitationHandler {
+	return &InvitationHandler{service: svc}
+}
+
+// RegisterRoutes registers invitation routes
+func (h *InvitationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	invitations := r.Group("/invitations")
+	{
+		invitations.GET("", h.List)
+		invitations.POST("", h.Create)
+		invitations.DELETE("/:id", h.Cancel)
+	}
+}
+
+// Create invites a user to the company
+// @Summary Invite user
+// @Description Email an invitation to join the company with the given role
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateInvitationRequest true "Invitation"
+// @Success 201 {object} dto.Response
+// @Router /api/v1/invitations [post]
+func (h *InvitationHandler) Create(c *gin.Context) {
+	var req dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	invite, err := h.service.Invite(c.Request.Context(), service.InviteInput{
+		CompanyID: appctx.GetCompanyID(c),
+		InvitedBy: appctx.GetUserID(c),
+		Email:     req.Email,
+		Role:      domain.UserRole(req.Role),
+		ExpiresIn: req.ExpiresIn(),
+	})
+	if err != nil {
+		respondDeliveryError(c, err, "Failed to send invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromInvitation(invite)))
+}
+
+// List returns the pending invitations of the company
+// @Summary List invitations
+// @Description Invitations not yet accepted, cancelled or expired
+// @Tags users
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /api/v1/invitations [get]
+func (h *InvitationHandler) List(c *gin.Context) {
+	invites, err := h.service.ListInvitations(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromInvitations(invites)))
+}
+
+// Cancel revokes a pending invitation
+// @Summary Cancel invitation
+// @Tags users
+// @Param id path string true "Invitation ID"
+// @Success 204
+// @Router /api/v1/invitations/{id} [delete]
+func (h *InvitationHandler) Cancel(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid invitation ID"))
+		return
+	}
+
+	if err := h.service.CancelInvitation(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to cancel invitation")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
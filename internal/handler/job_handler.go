@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// JobHandler handles background jobs. Tenant users poll the jobs of their
+// company; the list, retry and cancel of jobs of any company are for platform
+// operators.
+type JobHandler struct {
+	service service.JobService
+}
+
+// NewJobHandler creates a new JobHandler
+func NewJobHandler(svc service.JobService) *JobHandler {
+	return &JobHandler{service: svc}
+}
+
+// RegisterRoutes registers the tenant job routes
+func (h *JobHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/jobs/:id", h.Get)
+}
+
+// RegisterOperatorRoutes registers the routes reserved to platform operators
+func (h *JobHandler) RegisterOperatorRoutes(r *gin.RouterGroup) {
+	jobs := r.Group("/admin/jobs")
+	{
+		jobs.GET("", h.List)
+		jobs.GET("/:id", h.GetAny)
+		jobs.POST("/:id/retry", h.Retry)
+		jobs.POST("/:id/cancel", h.Cancel)
+	}
+}
+
+// Get handles GET /jobs/:id, a job of the user's company
+func (h *JobHandler) Get(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.GetForCompany(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get job")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromJob(job)))
+}
+
+// List handles GET /admin/jobs
+// @Summary List background jobs
+// @Description Jobs of all companies, newest first, filtered by company, type, status and creation date
+// @Tags admin
+// @Produce json
+// @Param company_id query string false "Company ID"
+// @Param type query string false "ledger_recalculation, legacy_import, report_delivery or approval_push"
+// @Param status query string false "queued, running, succeeded, failed or cancelled"
+// @Param from query string false "Created on or after (YYYY-MM-DD)"
+// @Param to query string false "Created on or before (YYYY-MM-DD)"
+// @Param page query int false "Page (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} dto.Response{data=[]dto.JobResponse}
+// @Router /api/v1/admin/jobs [get]
+func (h *JobHandler) List(c *gin.Context) {
+	filter := repository.JobFilter{Page: 1, PageSize: 20}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if companyID := c.Query("company_id"); companyID != "" {
+		id, err := uuid.Parse(companyID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid company ID"))
+			return
+		}
+		filter.CompanyID = &id
+	}
+	if jobType := c.Query("type"); jobType != "" {
+		t := domain.JobType(jobType)
+		if !t.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid job type"))
+			return
+		}
+		filter.Type = &t
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.JobStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid job status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if from := c.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid from date, expected YYYY-MM-DD"))
+			return
+		}
+		filter.CreatedFrom = &date
+	}
+	if to := c.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid to date, expected YYYY-MM-DD"))
+			return
+		}
+		// The whole day is included
+		end := date.AddDate(0, 0, 1)
+		filter.CreatedTo = &end
+	}
+
+	jobs, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to list jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		dto.FromJobs(jobs),
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// GetAny handles GET /admin/jobs/:id, a job of any company
+func (h *JobHandler) GetAny(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to get job")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromJob(job)))
+}
+
+// Retry handles POST /admin/jobs/:id/retry
+// @Summary Retry a background job
+// @Description Queue a failed or cancelled job again with all its attempts
+// @Tags admin
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.Response{data=dto.JobResponse}
+// @Failure 409 {object} dto.Response
+// @Router /api/v1/admin/jobs/{id}/retry [post]
+func (h *JobHandler) Retry(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.Retry(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to retry job")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromJob(job)))
+}
+
+// Cancel handles POST /admin/jobs/:id/cancel
+// @Summary Cancel a background job
+// @Description Stop a queued or running job; what a running job already did stands
+// @Tags admin
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.Response{data=dto.JobResponse}
+// @Failure 409 {object} dto.Response
+// @Router /api/v1/admin/jobs/{id}/cancel [post]
+func (h *JobHandler) Cancel(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.Cancel(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to cancel job")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromJob(job)))
+}
+
+// parseJobID parses the job ID path parameter, responding with 400 if invalid
+func parseJobID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid job ID"))
+		return uuid.Nil, false
+	}
+	return id, true
+}
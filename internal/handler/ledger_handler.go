@@ -2,13 +2,16 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
 	"github.com/saintgo7/saas-kerp/internal/service"
 )
 
@@ -16,13 +19,15 @@ import (
 type LedgerHandler struct {
 	ledgerService  service.LedgerService
 	accountService service.AccountService
+	jobs           service.JobQueue
 }
 
 // NewLedgerHandler creates a new LedgerHandler
-func NewLedgerHandler(ledgerService service.LedgerService, accountService service.AccountService) *LedgerHandler {
+func NewLedgerHandler(ledgerService service.LedgerService, accountService service.AccountService, jobs service.JobQueue) *LedgerHandler {
 	return &LedgerHandler{
 		ledgerService:  ledgerService,
 		accountService: accountService,
+		jobs:           jobs,
 	}
 }
 
@@ -36,6 +41,8 @@ func (h *LedgerHandler) RegisterRoutes(r *gin.RouterGroup) {
 		ledger.POST("/recalculate", h.RecalculateBalances)
 	}
 
+	r.GET("/accounts/:id/activity", h.GetAccountActivity)
+
 	// Report routes
 	reports := r.Group("/reports")
 	{
@@ -43,6 +50,7 @@ func (h *LedgerHandler) RegisterRoutes(r *gin.RouterGroup) {
 		reports.GET("/trial-balance/range", h.GetTrialBalanceRange)
 		reports.GET("/balance-sheet", h.GetBalanceSheet)
 		reports.GET("/income-statement", h.GetIncomeStatement)
+		reports.GET("/balance-exceptions", h.GetBalanceExceptions)
 	}
 
 	// Fiscal period routes
@@ -54,9 +62,21 @@ func (h *LedgerHandler) RegisterRoutes(r *gin.RouterGroup) {
 		periods.POST("/close", h.ClosePeriod)
 		periods.POST("/reopen", h.ReopenPeriod)
 		periods.POST("/year-end-close", h.YearEndClose)
+		periods.GET("/year-rollovers", h.ListYearRollovers)
+		periods.GET("/close-confirmations", h.ListCloseConfirmations)
+		periods.GET("/close-confirmations/:id", h.GetCloseConfirmation)
+		periods.POST("/close-confirmations/:id/confirm", h.ConfirmClose)
+		periods.POST("/close-confirmations/:id/cancel", h.CancelClose)
+		periods.GET("/audit-locks", h.ListAuditLocks)
+		periods.POST("/audit-locks", h.LockFiscalYear)
 	}
 }
 
+// RegisterOperatorRoutes registers the routes reserved to platform operators
+func (h *LedgerHandler) RegisterOperatorRoutes(r *gin.RouterGroup) {
+	r.POST("/admin/companies/:company_id/audit-locks/:year/unlock", h.UnlockFiscalYear)
+}
+
 // getCompanyID extracts company_id from context
 func (h *LedgerHandler) getCompanyID(c *gin.Context) (uuid.UUID, bool) {
 	companyIDVal, exists := c.Get("company_id")
@@ -105,13 +125,13 @@ func (h *LedgerHandler) GetPeriodBalances(c *gin.Context) {
 
 	var req dto.PeriodRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
 		return
 	}
 
 	balances, err := h.ledgerService.GetPeriodBalances(c.Request.Context(), companyID, req.Year, req.Month)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve balances"))
+		respondError(c, err, "Failed to retrieve balances")
 		return
 	}
 
@@ -137,7 +157,7 @@ func (h *LedgerHandler) GetAccountLedger(c *gin.Context) {
 
 	var req dto.AccountLedgerRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
 		return
 	}
 
@@ -162,18 +182,14 @@ func (h *LedgerHandler) GetAccountLedger(c *gin.Context) {
 	// Get account info
 	account, err := h.accountService.GetByID(c.Request.Context(), companyID, accountID)
 	if err != nil {
-		if err == domain.ErrAccountNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Account not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve account"))
+		respondError(c, err, "Failed to retrieve account")
 		return
 	}
 
 	// Get ledger entries
 	entries, openingBalance, err := h.ledgerService.GetAccountLedger(c.Request.Context(), companyID, accountID, fromDate, toDate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve ledger"))
+		respondError(c, err, "Failed to retrieve ledger")
 		return
 	}
 
@@ -204,14 +220,15 @@ func (h *LedgerHandler) GetAccountLedger(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.SuccessResponse(response))
 }
 
-// RecalculateBalances recalculates ledger balances from posted vouchers
+// RecalculateBalances queues the recalculation of ledger balances from posted
+// vouchers; its progress is polled with GET /jobs/:id
 // @Summary Recalculate balances
-// @Description Recalculate ledger balances from posted vouchers
+// @Description Queue the recalculation of ledger balances from posted vouchers
 // @Tags ledger
 // @Accept json
 // @Produce json
 // @Param body body dto.PeriodRequest true "Period"
-// @Success 200 {object} dto.Response
+// @Success 202 {object} dto.Response{data=dto.JobResponse}
 // @Router /api/v1/ledger/recalculate [post]
 func (h *LedgerHandler) RecalculateBalances(c *gin.Context) {
 	companyID, ok := h.getCompanyID(c)
@@ -221,16 +238,57 @@ func (h *LedgerHandler) RecalculateBalances(c *gin.Context) {
 
 	var req dto.PeriodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	userID := appctx.GetUserID(c)
+	payload := domain.LedgerRecalculationPayload{Year: req.Year, Month: req.Month}
+	job, err := h.jobs.Enqueue(c.Request.Context(), companyID, domain.JobTypeLedgerRecalculation, payload, nil, &userID)
+	if err != nil {
+		respondError(c, err, "Failed to queue balance recalculation")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse(dto.FromJob(job)))
+}
+
+// GetAccountActivity returns the monthly activity series of an account
+// @Summary Get account activity
+// @Description Monthly debit, credit and closing balance of an account for dashboard sparklines
+// @Tags ledger
+// @Produce json
+// @Param id path string true "Account ID"
+// @Param months query int false "Number of months up to the current one (default 12, max 36)"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/accounts/{id}/activity [get]
+func (h *LedgerHandler) GetAccountActivity(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	accountID, ok := parseUUIDParam(c, "id", "Invalid account ID")
+	if !ok {
 		return
 	}
 
-	if err := h.ledgerService.RecalculateBalances(c.Request.Context(), companyID, req.Year, req.Month); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to recalculate balances"))
+	months := domain.AccountActivityDefaultMonths
+	if value := c.Query("months"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > domain.AccountActivityMaxMonths {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "months must be between 1 and 36"))
+			return
+		}
+		months = n
+	}
+
+	activity, err := h.ledgerService.GetAccountActivity(c.Request.Context(), companyID, accountID, months)
+	if err != nil {
+		respondError(c, err, "Failed to get account activity")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Balances recalculated successfully"}))
+	c.JSON(http.StatusOK, dto.SuccessResponse(activity))
 }
 
 // GetTrialBalance generates a trial balance report
@@ -241,6 +299,7 @@ func (h *LedgerHandler) RecalculateBalances(c *gin.Context) {
 // @Produce json
 // @Param year query int true "Fiscal year"
 // @Param month query int true "Fiscal month"
+// @Param group_by query string false "Break down by department, project or partner"
 // @Success 200 {object} dto.Response
 // @Router /api/v1/reports/trial-balance [get]
 func (h *LedgerHandler) GetTrialBalance(c *gin.Context) {
@@ -249,13 +308,19 @@ func (h *LedgerHandler) GetTrialBalance(c *gin.Context) {
 		return
 	}
 
-	var req dto.PeriodRequest
+	var req dto.TrialBalanceRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
 		return
 	}
 
-	tb, err := h.ledgerService.GetTrialBalance(c.Request.Context(), companyID, req.Year, req.Month)
+	var tb *domain.TrialBalance
+	var err error
+	if req.GroupBy != "" {
+		tb, err = h.ledgerService.GetTrialBalanceByDimension(c.Request.Context(), companyID, req.Year, req.Month, domain.TrialBalanceDimension(req.GroupBy))
+	} else {
+		tb, err = h.ledgerService.GetTrialBalance(c.Request.Context(), companyID, req.Year, req.Month)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to generate trial balance"))
 		return
@@ -284,94 +349,85 @@ func (h *LedgerHandler) GetTrialBalanceRange(c *gin.Context) {
 
 	var req dto.DateRangeRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
 		return
 	}
 
 	tb, err := h.ledgerService.GetTrialBalanceRange(c.Request.Context(), companyID, req.FromYear, req.FromMonth, req.ToYear, req.ToMonth)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to generate trial balance"))
+		respondError(c, err, "Failed to generate trial balance")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTrialBalance(tb)))
 }
 
-// GetBalanceSheet generates a balance sheet report
-// @Summary Get balance sheet
-// @Description Generate a balance sheet report
+// GetBalanceExceptions lists the accounts whose closing balance contradicts their nature
+// @Summary Get balance exceptions
+// @Description List the accounts closing on the side opposite to their nature, such as a credit balance on cash, in each of the months ending at year/month
 // @Tags reports
-// @Accept json
 // @Produce json
 // @Param year query int true "Fiscal year"
 // @Param month query int true "Fiscal month"
+// @Param months query int false "Number of months up to year/month (default 1, max 12)"
 // @Success 200 {object} dto.Response
-// @Router /api/v1/reports/balance-sheet [get]
-func (h *LedgerHandler) GetBalanceSheet(c *gin.Context) {
+// @Router /api/v1/reports/balance-exceptions [get]
+func (h *LedgerHandler) GetBalanceExceptions(c *gin.Context) {
 	companyID, ok := h.getCompanyID(c)
 	if !ok {
 		return
 	}
 
-	var req dto.PeriodRequest
+	var req dto.BalanceExceptionRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
 		return
 	}
 
-	// Get trial balance
-	tb, err := h.ledgerService.GetTrialBalance(c.Request.Context(), companyID, req.Year, req.Month)
+	report, err := h.ledgerService.GetBalanceExceptions(c.Request.Context(), companyID, req.Year, req.Month, req.PeriodMonths())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to generate balance sheet"))
+		respondError(c, err, "Failed to check balances")
 		return
 	}
 
-	// Build balance sheet from trial balance
-	var assets, liabilities, equity []dto.FinancialStatementItem
-	var totalAssets, totalLiabilities, totalEquity float64
+	c.JSON(http.StatusOK, dto.SuccessResponse(report))
+}
 
-	for _, item := range tb.Items {
-		fsItem := dto.FinancialStatementItem{
-			Code:   item.AccountCode,
-			Name:   item.AccountName,
-			Amount: item.ClosingDebit - item.ClosingCredit,
-			Level:  item.AccountLevel,
-		}
+// GetBalanceSheet generates a balance sheet report
+// @Summary Get balance sheet
+// @Description Generate a balance sheet with the accounts rolled up to their statement lines
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param year query int true "Fiscal year"
+// @Param month query int true "Fiscal month"
+// @Param depth query int false "Account levels below each statement line, all when omitted"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/reports/balance-sheet [get]
+func (h *LedgerHandler) GetBalanceSheet(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
 
-		switch item.AccountType {
-		case "asset":
-			assets = append(assets, fsItem)
-			totalAssets += fsItem.Amount
-		case "liability":
-			fsItem.Amount = item.ClosingCredit - item.ClosingDebit // Liability is credit balance
-			liabilities = append(liabilities, fsItem)
-			totalLiabilities += fsItem.Amount
-		case "equity":
-			fsItem.Amount = item.ClosingCredit - item.ClosingDebit // Equity is credit balance
-			equity = append(equity, fsItem)
-			totalEquity += fsItem.Amount
-		}
+	var req dto.BalanceSheetRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
 	}
 
-	response := dto.BalanceSheetResponse{
-		CompanyID:        companyID.String(),
-		AsOfDate:         tb.EndDate.Format("2006-01-02"),
-		GeneratedAt:      dto.ReportGeneratedAt(),
-		Assets:           assets,
-		Liabilities:      liabilities,
-		Equity:           equity,
-		TotalAssets:      totalAssets,
-		TotalLiabilities: totalLiabilities,
-		TotalEquity:      totalEquity,
-		IsBalanced:       totalAssets == (totalLiabilities + totalEquity),
+	fs, err := h.ledgerService.GetBalanceSheet(c.Request.Context(), companyID, req.Year, req.Month, req.AccountDepth())
+	if err != nil {
+		respondError(c, err, "Failed to generate balance sheet")
+		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(response))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromBalanceSheet(companyID, fs, appctx.GetLocale(c))))
 }
 
 // GetIncomeStatement generates an income statement report
 // @Summary Get income statement
-// @Description Generate an income statement report
+// @Description Generate an income statement with the accounts rolled up to their statement lines
 // @Tags reports
 // @Accept json
 // @Produce json
@@ -379,6 +435,7 @@ func (h *LedgerHandler) GetBalanceSheet(c *gin.Context) {
 // @Param from_month query int true "From month"
 // @Param to_year query int true "To year"
 // @Param to_month query int true "To month"
+// @Param depth query int false "Account levels below each statement line, all when omitted"
 // @Success 200 {object} dto.Response
 // @Router /api/v1/reports/income-statement [get]
 func (h *LedgerHandler) GetIncomeStatement(c *gin.Context) {
@@ -387,55 +444,19 @@ func (h *LedgerHandler) GetIncomeStatement(c *gin.Context) {
 		return
 	}
 
-	var req dto.DateRangeRequest
+	var req dto.IncomeStatementRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
 		return
 	}
 
-	// Get trial balance for the range
-	tb, err := h.ledgerService.GetTrialBalanceRange(c.Request.Context(), companyID, req.FromYear, req.FromMonth, req.ToYear, req.ToMonth)
+	fs, err := h.ledgerService.GetIncomeStatement(c.Request.Context(), companyID, req.FromYear, req.FromMonth, req.ToYear, req.ToMonth, req.AccountDepth())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to generate income statement"))
+		respondError(c, err, "Failed to generate income statement")
 		return
 	}
 
-	// Build income statement from trial balance
-	var revenue, expenses []dto.FinancialStatementItem
-	var totalRevenue, totalExpenses float64
-
-	for _, item := range tb.Items {
-		fsItem := dto.FinancialStatementItem{
-			Code:   item.AccountCode,
-			Name:   item.AccountName,
-			Level:  item.AccountLevel,
-		}
-
-		switch item.AccountType {
-		case "revenue":
-			fsItem.Amount = item.ClosingCredit - item.ClosingDebit // Revenue is credit balance
-			revenue = append(revenue, fsItem)
-			totalRevenue += fsItem.Amount
-		case "expense":
-			fsItem.Amount = item.ClosingDebit - item.ClosingCredit // Expense is debit balance
-			expenses = append(expenses, fsItem)
-			totalExpenses += fsItem.Amount
-		}
-	}
-
-	response := dto.IncomeStatementResponse{
-		CompanyID:     companyID.String(),
-		FromDate:      tb.StartDate.Format("2006-01-02"),
-		ToDate:        tb.EndDate.Format("2006-01-02"),
-		GeneratedAt:   dto.ReportGeneratedAt(),
-		Revenue:       revenue,
-		Expenses:      expenses,
-		TotalRevenue:  totalRevenue,
-		TotalExpenses: totalExpenses,
-		NetIncome:     totalRevenue - totalExpenses,
-	}
-
-	c.JSON(http.StatusOK, dto.SuccessResponse(response))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromIncomeStatement(companyID, fs, appctx.GetLocale(c))))
 }
 
 // GetFiscalPeriods returns all fiscal periods for a year
@@ -444,7 +465,8 @@ func (h *LedgerHandler) GetIncomeStatement(c *gin.Context) {
 // @Tags fiscal-periods
 // @Accept json
 // @Produce json
-// @Param year query int true "Fiscal year"
+// @Param year query int false "Fiscal year, the current year by default"
+// @Param group_by query string false "month (default), quarter or half"
 // @Success 200 {object} dto.Response
 // @Router /api/v1/fiscal-periods [get]
 func (h *LedgerHandler) GetFiscalPeriods(c *gin.Context) {
@@ -453,25 +475,32 @@ func (h *LedgerHandler) GetFiscalPeriods(c *gin.Context) {
 		return
 	}
 
-	year := c.Query("year")
-	if year == "" {
-		year = time.Now().Format("2006")
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		if year, ok = parseFiscalYear(c, value); !ok {
+			return
+		}
 	}
-
-	var yearInt int
-	if _, err := time.Parse("2006", year); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid year"))
-		return
+	grouping := domain.PeriodGroupingMonth
+	if value := c.Query("group_by"); value != "" {
+		grouping = domain.PeriodGrouping(value)
+		if !grouping.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid period grouping"))
+			return
+		}
 	}
-	yearInt = time.Now().Year() // Default to current year if parsing issue
 
-	periods, err := h.ledgerService.GetFiscalPeriods(c.Request.Context(), companyID, yearInt)
+	periods, err := h.ledgerService.GetFiscalPeriods(c.Request.Context(), companyID, year)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve fiscal periods"))
+		respondError(c, err, "Failed to retrieve fiscal periods")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromFiscalPeriods(periods)))
+	if grouping == domain.PeriodGroupingMonth {
+		c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromFiscalPeriods(periods)))
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromFiscalPeriodGroups(domain.GroupFiscalPeriods(year, periods, grouping))))
 }
 
 // GetFiscalPeriod returns a specific fiscal period
@@ -490,20 +519,14 @@ func (h *LedgerHandler) GetFiscalPeriod(c *gin.Context) {
 		return
 	}
 
-	// Parse year and month from path
-	var year, month int
-	if _, err := c.Params.Get("year"); err {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid year"))
+	year, month, ok := parsePeriodParams(c)
+	if !ok {
 		return
 	}
 
 	period, err := h.ledgerService.GetFiscalPeriod(c.Request.Context(), companyID, year, month)
 	if err != nil {
-		if err == domain.ErrFiscalPeriodNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Fiscal period not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve fiscal period"))
+		respondError(c, err, "Failed to retrieve fiscal period")
 		return
 	}
 
@@ -525,12 +548,14 @@ func (h *LedgerHandler) CreateFiscalPeriods(c *gin.Context) {
 		return
 	}
 
-	// Parse year from path - simplified
-	year := time.Now().Year()
+	year, ok := parseFiscalYear(c, c.Param("year"))
+	if !ok {
+		return
+	}
 
 	periods, err := h.ledgerService.CreateFiscalPeriods(c.Request.Context(), companyID, year)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to create fiscal periods"))
+		respondError(c, err, "Failed to create fiscal periods")
 		return
 	}
 
@@ -539,12 +564,14 @@ func (h *LedgerHandler) CreateFiscalPeriods(c *gin.Context) {
 
 // ClosePeriod closes a fiscal period
 // @Summary Close fiscal period
-// @Description Close a fiscal period
+// @Description Close a fiscal period. All mandatory close checklist tasks must be completed unless an admin sets override.
+// @Description Under dual control the close is initiated and performed once another user confirms it (202 Accepted).
 // @Tags fiscal-periods
 // @Accept json
 // @Produce json
 // @Param body body dto.ClosePeriodRequest true "Period to close"
 // @Success 200 {object} dto.Response
+// @Success 202 {object} dto.Response
 // @Router /api/v1/fiscal-periods/close [post]
 func (h *LedgerHandler) ClosePeriod(c *gin.Context) {
 	companyID, ok := h.getCompanyID(c)
@@ -555,27 +582,33 @@ func (h *LedgerHandler) ClosePeriod(c *gin.Context) {
 	if !ok {
 		return
 	}
+	if !requireClosePermission(c) {
+		return
+	}
 
 	var req dto.ClosePeriodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
-	if err := h.ledgerService.ClosePeriod(c.Request.Context(), companyID, req.Year, req.Month, userID); err != nil {
-		if err == domain.ErrFiscalPeriodNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Fiscal period not found"))
-			return
-		}
-		if err == domain.ErrFiscalPeriodClosed {
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Fiscal period is already closed"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to close fiscal period"))
+	// Only admins may close a period with an incomplete checklist
+	if req.Override && !appctx.HasRole(c, string(domain.UserRoleAdmin)) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse(dto.ErrCodeForbidden, "Checklist override requires admin role"))
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Fiscal period closed successfully"}))
+	confirmation, err := h.ledgerService.ClosePeriod(c.Request.Context(), companyID, req.Year, req.Month, userID, req.Override)
+	if err != nil {
+		respondError(c, err, "Failed to close fiscal period")
+		return
+	}
+	if confirmation == nil {
+		c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Fiscal period closed successfully"}))
+		return
+	}
+
+	respondCloseResult(c, confirmation, nil)
 }
 
 // ReopenPeriod reopens a closed fiscal period
@@ -595,20 +628,12 @@ func (h *LedgerHandler) ReopenPeriod(c *gin.Context) {
 
 	var req dto.ClosePeriodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
 	if err := h.ledgerService.ReopenPeriod(c.Request.Context(), companyID, req.Year, req.Month); err != nil {
-		if err == domain.ErrFiscalPeriodNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Fiscal period not found"))
-			return
-		}
-		if err == domain.ErrFiscalPeriodClosed {
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Fiscal period is locked and cannot be reopened"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to reopen fiscal period"))
+		respondError(c, err, "Failed to reopen fiscal period")
 		return
 	}
 
@@ -617,12 +642,13 @@ func (h *LedgerHandler) ReopenPeriod(c *gin.Context) {
 
 // YearEndClose performs year-end closing
 // @Summary Year-end close
-// @Description Perform year-end closing
+// @Description Perform year-end closing. Under dual control the close is initiated and performed once another user confirms it (202 Accepted).
 // @Tags fiscal-periods
 // @Accept json
 // @Produce json
 // @Param body body dto.YearEndCloseRequest true "Year-end close request"
 // @Success 200 {object} dto.Response
+// @Success 202 {object} dto.Response
 // @Router /api/v1/fiscal-periods/year-end-close [post]
 func (h *LedgerHandler) YearEndClose(c *gin.Context) {
 	companyID, ok := h.getCompanyID(c)
@@ -633,10 +659,13 @@ func (h *LedgerHandler) YearEndClose(c *gin.Context) {
 	if !ok {
 		return
 	}
+	if !requireClosePermission(c) {
+		return
+	}
 
 	var req dto.YearEndCloseRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
@@ -646,10 +675,282 @@ func (h *LedgerHandler) YearEndClose(c *gin.Context) {
 		return
 	}
 
-	if err := h.ledgerService.PerformYearEndClose(c.Request.Context(), companyID, req.Year, retainedEarningsAccountID, userID); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to perform year-end close"))
+	run, confirmation, err := h.ledgerService.PerformYearEndClose(c.Request.Context(), companyID, req.Year, retainedEarningsAccountID, userID)
+	if err != nil {
+		respondError(c, err, "Failed to perform year-end close")
 		return
 	}
+	if confirmation == nil {
+		c.JSON(http.StatusOK, dto.SuccessResponse(run))
+		return
+	}
+
+	respondCloseResult(c, confirmation, run)
+}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Year-end close completed successfully"}))
+// ListYearRollovers lists the year rollovers of the company
+// @Summary List year rollovers
+// @Description List the carry-forwards of closing balances into the next fiscal year
+// @Tags fiscal-periods
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /api/v1/fiscal-periods/year-rollovers [get]
+func (h *LedgerHandler) ListYearRollovers(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	runs, err := h.ledgerService.ListYearRollovers(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to list year rollovers")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(runs))
+}
+
+// ListCloseConfirmations lists the closes initiated under dual control
+// @Summary List close confirmations
+// @Description List the period and year-end closes initiated under dual control, latest first
+// @Tags fiscal-periods
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /api/v1/fiscal-periods/close-confirmations [get]
+func (h *LedgerHandler) ListCloseConfirmations(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	confirmations, err := h.ledgerService.ListCloseConfirmations(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to list close confirmations")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCloseConfirmations(confirmations, time.Now())))
+}
+
+// GetCloseConfirmation returns a close initiated under dual control
+// @Summary Get close confirmation
+// @Tags fiscal-periods
+// @Produce json
+// @Param id path string true "Close confirmation ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/fiscal-periods/close-confirmations/{id} [get]
+func (h *LedgerHandler) GetCloseConfirmation(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid close confirmation ID"))
+		return
+	}
+
+	confirmation, err := h.ledgerService.GetCloseConfirmation(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get close confirmation")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCloseConfirmation(confirmation, time.Now())))
+}
+
+// ConfirmClose performs a close initiated by another user
+// @Summary Confirm close
+// @Description Confirm and perform a pending period or year-end close initiated by another user
+// @Description A close overriding the close checklist can only be confirmed by an admin.
+// @Tags fiscal-periods
+// @Produce json
+// @Param id path string true "Close confirmation ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/fiscal-periods/close-confirmations/{id}/confirm [post]
+func (h *LedgerHandler) ConfirmClose(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+	if !requireClosePermission(c) {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid close confirmation ID"))
+		return
+	}
+
+	// Only admins may confirm a close overriding the checklist
+	confirmation, run, err := h.ledgerService.ConfirmClose(c.Request.Context(), companyID, id, userID, appctx.HasRole(c, string(domain.UserRoleAdmin)))
+	if err != nil {
+		respondError(c, err, "Failed to confirm close")
+		return
+	}
+
+	respondCloseResult(c, confirmation, run)
+}
+
+// CancelClose withdraws a pending close
+// @Summary Cancel close
+// @Description Withdraw a pending close; only its initiator and admins may cancel it
+// @Tags fiscal-periods
+// @Produce json
+// @Param id path string true "Close confirmation ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/fiscal-periods/close-confirmations/{id}/cancel [post]
+func (h *LedgerHandler) CancelClose(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid close confirmation ID"))
+		return
+	}
+
+	confirmation, err := h.ledgerService.GetCloseConfirmation(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to cancel close")
+		return
+	}
+	if confirmation.InitiatedBy != userID && !requireAdmin(c) {
+		return
+	}
+
+	confirmation, err = h.ledgerService.CancelClose(c.Request.Context(), companyID, id, userID)
+	if err != nil {
+		respondError(c, err, "Failed to cancel close")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromCloseConfirmation(confirmation, time.Now())))
+}
+
+// ListAuditLocks lists the audit locks of the company's fiscal years
+// @Summary List audit locks
+// @Description List the fiscal year locks set after audit sign-off, lifted ones included
+// @Tags fiscal-periods
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /api/v1/fiscal-periods/audit-locks [get]
+func (h *LedgerHandler) ListAuditLocks(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	locks, err := h.ledgerService.ListAuditLocks(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to list audit locks")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAuditLocks(locks)))
+}
+
+// LockFiscalYear locks a fiscal year after its audit sign-off
+// @Summary Lock fiscal year
+// @Description Lock a closed fiscal year after its external audit sign-off: its periods can no longer be reopened and no voucher can be reversed into it (admin only)
+// @Tags fiscal-periods
+// @Accept json
+// @Produce json
+// @Param body body dto.LockFiscalYearRequest true "Fiscal year to lock"
+// @Success 201 {object} dto.Response
+// @Router /api/v1/fiscal-periods/audit-locks [post]
+func (h *LedgerHandler) LockFiscalYear(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.LockFiscalYearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	lock, err := h.ledgerService.LockFiscalYear(c.Request.Context(), companyID, req.Year, req.Reference, userID)
+	if err != nil {
+		respondError(c, err, "Failed to lock fiscal year")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromAuditLock(lock)))
+}
+
+// UnlockFiscalYear lifts the audit lock of a company's fiscal year. It is
+// served to platform operators only.
+// @Summary Unlock audited fiscal year
+// @Description Lift the audit lock of a fiscal year (super admin only). The reason, the operator and the client are recorded in the audit trail.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param company_id path string true "Company ID"
+// @Param year path int true "Fiscal year"
+// @Param body body dto.UnlockFiscalYearRequest true "Unlock reason"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/admin/companies/{company_id}/audit-locks/{year}/unlock [post]
+func (h *LedgerHandler) UnlockFiscalYear(c *gin.Context) {
+	companyID, err := uuid.Parse(c.Param("company_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid company ID"))
+		return
+	}
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid fiscal year"))
+		return
+	}
+
+	var req dto.UnlockFiscalYearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	lock, err := h.ledgerService.UnlockFiscalYear(c.Request.Context(), companyID, year, req.Reason,
+		appctx.GetUserID(c), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondError(c, err, "Failed to unlock fiscal year")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromAuditLock(lock)))
+}
+
+// requireClosePermission responds with 403 unless the user may close periods,
+// which viewers may not
+func requireClosePermission(c *gin.Context) bool {
+	if !appctx.HasAnyRole(c, string(domain.UserRoleAdmin), string(domain.UserRoleUser)) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse(apperrors.CodeInsufficientRole, "Close permission required"))
+		return false
+	}
+	return true
+}
+
+// respondCloseResult writes the outcome of a close: 202 while it awaits confirmation
+func respondCloseResult(c *gin.Context, confirmation *domain.CloseConfirmation, run *domain.YearRolloverRun) {
+	status := http.StatusOK
+	if confirmation != nil && confirmation.IsPending() {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, dto.SuccessResponse(dto.NewCloseResult(confirmation, run, time.Now())))
 }
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// LegacyImportHandler handles the import wizard for Douzone and SemusaRang data
+type LegacyImportHandler struct {
+	service service.LegacyImportService
+	jobs    service.JobQueue
+}
+
+// NewLegacyImportHandler creates a new LegacyImportHandler
+func NewLegacyImportHandler(svc service.LegacyImportService, jobs service.JobQueue) *LegacyImportHandler {
+	return &LegacyImportHandler{service: svc, jobs: jobs}
+}
+
+// RegisterRoutes registers legacy import routes
+func (h *LegacyImportHandler) RegisterRoutes(r *gin.RouterGroup) {
+	imports := r.Group("/imports/legacy")
+	{
+		imports.GET("/formats", h.Formats)
+		imports.POST("", h.Import)
+	}
+}
+
+// Formats handles GET /imports/legacy/formats
+func (h *LegacyImportHandler) Formats(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromLegacyImportFormats(h.service.Formats())))
+}
+
+// Import handles POST /imports/legacy
+// @Summary Import legacy accounting data
+// @Description Validate (dry_run, the default) or import one Douzone/SemusaRang export file.
+// @Description Import accounts, partners, opening balances and vouchers in that order.
+// @Description Dry runs are answered directly; imports are queued as a job polled with GET /jobs/{id}.
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or tab-separated export file (UTF-8 or CP949)"
+// @Param format formData string true "douzone or semusarang"
+// @Param dataset formData string true "accounts, partners, opening_balances or vouchers"
+// @Param fiscal_year formData int false "Fiscal year of opening balances; year of vouchers dated by month and day"
+// @Param cash_account_code formData string false "Counter account of 출금/입금 lines (default 101)"
+// @Param dry_run formData bool false "Validate without saving (default true)"
+// @Success 200 {object} dto.Response{data=dto.LegacyImportResultResponse}
+// @Success 202 {object} dto.Response{data=dto.JobResponse}
+// @Failure 400 {object} dto.Response
+// @Router /imports/legacy [post]
+func (h *LegacyImportHandler) Import(c *gin.Context) {
+	var req dto.LegacyImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request", err))
+		return
+	}
+
+	data, err := readUploadedFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Import file is required", err.Error()))
+		return
+	}
+
+	companyID, userID := appctx.GetCompanyID(c), appctx.GetUserID(c)
+	if !req.IsDryRun() {
+		payload := domain.LegacyImportPayload{
+			Format:          req.Format,
+			Dataset:         req.Dataset,
+			FiscalYear:      req.FiscalYear,
+			CashAccountCode: req.CashAccountCode,
+			UserID:          userID,
+		}
+		job, err := h.jobs.Enqueue(c.Request.Context(), companyID, domain.JobTypeLegacyImport, payload, data, &userID)
+		if err != nil {
+			respondError(c, err, "Failed to queue legacy import")
+			return
+		}
+		c.JSON(http.StatusAccepted, dto.SuccessResponse(dto.FromJob(job)))
+		return
+	}
+
+	result, err := h.service.Import(c.Request.Context(), service.LegacyImportRequest{
+		CompanyID: companyID,
+		UserID:    userID,
+		Format:    migrate.Format(req.Format),
+		Dataset:   migrate.Dataset(req.Dataset),
+		Data:      data,
+		Options:   req.Options(),
+		DryRun:    true,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to import legacy data")
+		return
+	}
+
+	// Files with errors are reported with applied=false and their issues
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromLegacyImportResult(result)))
+}
+
+// readUploadedFile reads the "file" part of a multipart request; its size is
+// capped by the upload body limit
+func readUploadedFile(c *gin.Context) ([]byte, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// LoanHandler handles loans and borrowings
+type LoanHandler struct {
+	service service.LoanService
+}
+
+// NewLoanHandler creates a new LoanHandler
+func NewLoanHandler(svc service.LoanService) *LoanHandler {
+	return &LoanHandler{service: svc}
+}
+
+// RegisterRoutes registers loan routes
+func (h *LoanHandler) RegisterRoutes(r *gin.RouterGroup) {
+	loans := r.Group("/loans")
+	{
+		loans.GET("", h.List)
+		loans.POST("", h.Create)
+		loans.GET("/outstanding", h.Outstanding)
+		loans.POST("/accruals", h.Accrue)
+		loans.GET("/:id", h.Get)
+		loans.GET("/:id/repayments", h.ListRepayments)
+		loans.POST("/:id/repayments", h.Repay)
+	}
+}
+
+// Create handles POST /loans
+func (h *LoanHandler) Create(c *gin.Context) {
+	var req dto.CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	loan := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), loan); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create loan")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(loan))
+}
+
+// List handles GET /loans
+func (h *LoanHandler) List(c *gin.Context) {
+	filter := repository.LoanFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.LoanStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid loan status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	loans, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list loans")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		loans,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /loans/:id
+func (h *LoanHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid loan ID")
+	if !ok {
+		return
+	}
+
+	loan, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get loan")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(loan))
+}
+
+// ListRepayments handles GET /loans/:id/repayments
+func (h *LoanHandler) ListRepayments(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid loan ID")
+	if !ok {
+		return
+	}
+
+	repayments, err := h.service.ListRepayments(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list repayments")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(repayments))
+}
+
+// Repay handles POST /loans/:id/repayments
+func (h *LoanHandler) Repay(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid loan ID")
+	if !ok {
+		return
+	}
+
+	var req dto.RepayLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	repaymentDate, _ := time.Parse("2006-01-02", req.RepaymentDate)
+	input := service.LoanRepaymentInput{
+		RepaymentDate: repaymentDate,
+		Principal:     req.Principal,
+		Interest:      req.Interest,
+	}
+	if req.PaymentAccountID != "" {
+		accountID := uuid.MustParse(req.PaymentAccountID)
+		input.PaymentAccountID = &accountID
+	}
+
+	repayment, err := h.service.Repay(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, input)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record repayment")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(repayment))
+}
+
+// Accrue handles POST /loans/accruals.
+// The worker accrues every month end; this catches up a month for the company.
+func (h *LoanHandler) Accrue(c *gin.Context) {
+	var req dto.AccrueLoanInterestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodEnd, _ := time.Parse("2006-01-02", req.PeriodEnd)
+	if periodEnd.AddDate(0, 0, 1).Day() != 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "period_end must be the last day of a month"))
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	run, err := h.service.AccrueInterest(c.Request.Context(), &companyID, periodEnd)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to accrue loan interest")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Outstanding handles GET /loans/outstanding
+func (h *LoanHandler) Outstanding(c *gin.Context) {
+	var req dto.LoansOutstandingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	report, err := h.service.Outstanding(c.Request.Context(), appctx.GetCompanyID(c), asOf)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get loans outstanding")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(report))
+}
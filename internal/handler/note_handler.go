@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// NoteHandler handles the register of notes and checks
+type NoteHandler struct {
+	service service.NoteService
+}
+
+// NewNoteHandler creates a new NoteHandler
+func NewNoteHandler(svc service.NoteService) *NoteHandler {
+	return &NoteHandler{service: svc}
+}
+
+// RegisterRoutes registers note routes
+func (h *NoteHandler) RegisterRoutes(r *gin.RouterGroup) {
+	notes := r.Group("/notes")
+	{
+		notes.GET("", h.List)
+		notes.POST("", h.Register)
+		notes.GET("/:id", h.Get)
+		notes.POST("/:id/discount", h.Discount)
+		notes.POST("/:id/settle", h.Settle)
+	}
+}
+
+// Register handles POST /notes
+func (h *NoteHandler) Register(c *gin.Context) {
+	var req dto.RegisterNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	note := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Register(c.Request.Context(), note); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to register note")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(note))
+}
+
+// List handles GET /notes
+func (h *NoteHandler) List(c *gin.Context) {
+	filter := repository.NoteFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if direction := c.Query("direction"); direction != "" {
+		d := domain.NoteDirection(direction)
+		if !d.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid note direction"))
+			return
+		}
+		filter.Direction = &d
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.NoteStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid note status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if partnerID := c.Query("partner_id"); partnerID != "" {
+		id, err := uuid.Parse(partnerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid partner ID"))
+			return
+		}
+		filter.PartnerID = &id
+	}
+	if maturityTo := c.Query("maturity_to"); maturityTo != "" {
+		date, err := time.Parse("2006-01-02", maturityTo)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid maturity date"))
+			return
+		}
+		filter.MaturityTo = &date
+	}
+
+	notes, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list notes")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		notes,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /notes/:id
+func (h *NoteHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid note ID")
+	if !ok {
+		return
+	}
+
+	note, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get note")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(note))
+}
+
+// Discount handles POST /notes/:id/discount
+func (h *NoteHandler) Discount(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid note ID")
+	if !ok {
+		return
+	}
+
+	var req dto.DiscountNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	discountDate, _ := time.Parse("2006-01-02", req.DiscountDate)
+	input := service.NoteDiscountInput{
+		DiscountDate:  discountDate,
+		Charge:        req.Charge,
+		BankAccountID: uuid.MustParse(req.BankAccountID),
+	}
+	if req.ChargeAccountID != "" {
+		chargeAccountID := uuid.MustParse(req.ChargeAccountID)
+		input.ChargeAccountID = &chargeAccountID
+	}
+
+	note, err := h.service.Discount(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, input)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to discount note")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(note))
+}
+
+// Settle handles POST /notes/:id/settle
+func (h *NoteHandler) Settle(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid note ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SettleNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	settledDate, _ := time.Parse("2006-01-02", req.SettledDate)
+
+	note, err := h.service.Settle(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, settledDate, uuid.MustParse(req.BankAccountID))
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to settle note")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(note))
+}
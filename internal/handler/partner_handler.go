@@ -29,6 +29,8 @@ func (h *PartnerHandler) RegisterRoutes(r *gin.RouterGroup) {
 		partners.POST("", h.Create)
 		partners.GET("", h.List)
 		partners.GET("/stats", h.GetStats)
+		partners.GET("/duplicates", h.Duplicates)
+		partners.POST("/merge", h.Merge)
 		partners.GET("/:id", h.GetByID)
 		partners.PUT("/:id", h.Update)
 		partners.DELETE("/:id", h.Delete)
@@ -44,7 +46,7 @@ func (h *PartnerHandler) RegisterRoutes(r *gin.RouterGroup) {
 func (h *PartnerHandler) Create(c *gin.Context) {
 	var req dto.CreatePartnerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -54,22 +56,25 @@ func (h *PartnerHandler) Create(c *gin.Context) {
 		TenantModel: domain.TenantModel{
 			CompanyID: companyID,
 		},
-		Code:            req.Code,
-		Name:            req.Name,
-		NameEn:          req.NameEn,
-		BusinessNumber:  req.BusinessNumber,
-		PartnerType:     req.PartnerType,
-		Representative:  req.Representative,
-		Phone:           req.Phone,
-		Fax:             req.Fax,
-		Email:           req.Email,
-		Website:         req.Website,
-		ZipCode:         req.ZipCode,
-		Address:         req.Address,
-		AddressDetail:   req.AddressDetail,
-		PaymentTermDays: req.PaymentTermDays,
-		CreditLimit:     req.CreditLimit,
-		IsActive:        true,
+		Code:              req.Code,
+		Name:              req.Name,
+		NameEn:            req.NameEn,
+		BusinessNumber:    req.BusinessNumber,
+		PartnerType:       req.PartnerType,
+		Representative:    req.Representative,
+		Phone:             req.Phone,
+		Fax:               req.Fax,
+		Email:             req.Email,
+		Website:           req.Website,
+		ZipCode:           req.ZipCode,
+		Address:           req.Address,
+		AddressDetail:     req.AddressDetail,
+		PaymentTermDays:   req.PaymentTermDays,
+		CreditLimit:       req.CreditLimit,
+		BankCode:          req.BankCode,
+		BankAccountNumber: req.BankAccountNumber,
+		BankAccountHolder: req.BankAccountHolder,
+		IsActive:          true,
 	}
 
 	if req.IsActive != nil {
@@ -86,16 +91,7 @@ func (h *PartnerHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.service.Create(c.Request.Context(), partner); err != nil {
-		switch err {
-		case service.ErrPartnerCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Partner code already exists"))
-		case service.ErrPartnerBizNoExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_002", "Business number already exists"))
-		case service.ErrPartnerInvalidType:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Invalid partner type"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to create partner")
 		return
 	}
 
@@ -107,11 +103,12 @@ func (h *PartnerHandler) List(c *gin.Context) {
 	companyID := appctx.GetCompanyID(c)
 
 	filter := &service.PartnerFilter{
-		CompanyID:   companyID,
-		PartnerType: c.Query("type"),
-		SearchTerm:  c.Query("search"),
-		Page:        1,
-		PageSize:    20,
+		CompanyID:      companyID,
+		PartnerType:    c.Query("type"),
+		SearchTerm:     c.Query("search"),
+		BusinessStatus: domain.BusinessStatus(c.Query("business_status")),
+		Page:           1,
+		PageSize:       20,
 	}
 
 	if page := c.Query("page"); page != "" {
@@ -131,7 +128,7 @@ func (h *PartnerHandler) List(c *gin.Context) {
 
 	partners, total, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to list partners")
 		return
 	}
 
@@ -157,7 +154,7 @@ func (h *PartnerHandler) GetByID(c *gin.Context) {
 
 	partner, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Partner not found"))
+		respondError(c, err, "Failed to get partner")
 		return
 	}
 
@@ -171,7 +168,7 @@ func (h *PartnerHandler) GetByCode(c *gin.Context) {
 
 	partner, err := h.service.GetByCode(c.Request.Context(), companyID, code)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Partner not found"))
+		respondError(c, err, "Failed to get partner")
 		return
 	}
 
@@ -185,7 +182,7 @@ func (h *PartnerHandler) GetByBusinessNumber(c *gin.Context) {
 
 	partner, err := h.service.GetByBusinessNumber(c.Request.Context(), companyID, bizNo)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Partner not found"))
+		respondError(c, err, "Failed to get partner")
 		return
 	}
 
@@ -203,14 +200,14 @@ func (h *PartnerHandler) Update(c *gin.Context) {
 
 	var req dto.UpdatePartnerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	// Get existing partner
 	partner, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Partner not found"))
+		respondError(c, err, "Failed to get partner")
 		return
 	}
 
@@ -230,6 +227,9 @@ func (h *PartnerHandler) Update(c *gin.Context) {
 	partner.AddressDetail = req.AddressDetail
 	partner.PaymentTermDays = req.PaymentTermDays
 	partner.CreditLimit = req.CreditLimit
+	partner.BankCode = req.BankCode
+	partner.BankAccountNumber = req.BankAccountNumber
+	partner.BankAccountHolder = req.BankAccountHolder
 
 	if req.IsActive != nil {
 		partner.IsActive = *req.IsActive
@@ -249,16 +249,7 @@ func (h *PartnerHandler) Update(c *gin.Context) {
 	}
 
 	if err := h.service.Update(c.Request.Context(), partner); err != nil {
-		switch err {
-		case service.ErrPartnerCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Partner code already exists"))
-		case service.ErrPartnerBizNoExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_002", "Business number already exists"))
-		case service.ErrPartnerInvalidType:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Invalid partner type"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to update partner")
 		return
 	}
 
@@ -275,7 +266,7 @@ func (h *PartnerHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_004", err.Error()))
+		respondError(c, err, "Failed to delete partner")
 		return
 	}
 
@@ -293,7 +284,7 @@ func (h *PartnerHandler) CanDelete(c *gin.Context) {
 
 	canDelete, reason, err := h.service.CanDelete(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Partner not found"))
+		respondError(c, err, "Failed to check partner deletion")
 		return
 	}
 
@@ -309,7 +300,7 @@ func (h *PartnerHandler) Activate(c *gin.Context) {
 
 	var req dto.BulkStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -324,7 +315,7 @@ func (h *PartnerHandler) Activate(c *gin.Context) {
 	}
 
 	if err := h.service.Activate(c.Request.Context(), companyID, ids); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to activate partner")
 		return
 	}
 
@@ -337,7 +328,7 @@ func (h *PartnerHandler) Deactivate(c *gin.Context) {
 
 	var req dto.BulkStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -352,7 +343,7 @@ func (h *PartnerHandler) Deactivate(c *gin.Context) {
 	}
 
 	if err := h.service.Deactivate(c.Request.Context(), companyID, ids); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to deactivate partner")
 		return
 	}
 
@@ -365,7 +356,7 @@ func (h *PartnerHandler) GetStats(c *gin.Context) {
 
 	stats, err := h.service.GetStats(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get partner statistics")
 		return
 	}
 
@@ -377,3 +368,37 @@ func (h *PartnerHandler) GetStats(c *gin.Context) {
 		InactiveCount: stats.InactiveCount,
 	}))
 }
+
+// Duplicates handles GET /partners/duplicates
+func (h *PartnerHandler) Duplicates(c *gin.Context) {
+	duplicates, err := h.service.Duplicates(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to find duplicate partners")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(duplicates))
+}
+
+// Merge handles POST /partners/merge; only administrators may merge partners
+func (h *PartnerHandler) Merge(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.MergePartnersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	merge, err := h.service.Merge(c.Request.Context(), appctx.GetCompanyID(c),
+		uuid.MustParse(req.SourceID), uuid.MustParse(req.TargetID),
+		appctx.GetUserID(c), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondError(c, err, "Failed to merge partners")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(merge))
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// PartnerLedgerHandler handles HTTP requests for partner statements of account
+type PartnerLedgerHandler struct {
+	service service.PartnerLedgerService
+}
+
+// NewPartnerLedgerHandler creates a new PartnerLedgerHandler
+func NewPartnerLedgerHandler(svc service.PartnerLedgerService) *PartnerLedgerHandler {
+	return &PartnerLedgerHandler{service: svc}
+}
+
+// RegisterRoutes registers partner ledger routes
+func (h *PartnerLedgerHandler) RegisterRoutes(r *gin.RouterGroup) {
+	ledger := r.Group("/reports/partner-ledger")
+	{
+		ledger.GET("", h.GetLedger)
+		ledger.GET("/bulk", h.GetStatements)
+		ledger.POST("/bulk/send", h.SendStatements)
+	}
+}
+
+// GetLedger returns the statement of account of a partner
+// @Summary Get partner ledger
+// @Description Posted AR/AP entries of a partner with running balance (거래처원장)
+// @Tags reports
+// @Produce json
+// @Param partner_id query string true "Partner ID"
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/reports/partner-ledger [get]
+func (h *PartnerLedgerHandler) GetLedger(c *gin.Context) {
+	var req dto.PartnerLedgerRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+
+	from, to, ok := parseDateRangeParams(c, req.From, req.To)
+	if !ok {
+		return
+	}
+
+	ledger, err := h.service.GetLedger(c.Request.Context(), appctx.GetCompanyID(c), uuid.MustParse(req.PartnerID), from, to)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve partner ledger")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPartnerLedger(ledger)))
+}
+
+// GetStatements returns the statements of all partners
+// @Summary Get partner statements in bulk
+// @Description Statements of account for all partners with activity or balance in the range
+// @Tags reports
+// @Produce json
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Param partner_type query string false "customer or vendor"
+// @Param include_empty query bool false "Include partners without activity or balance"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/reports/partner-ledger/bulk [get]
+func (h *PartnerLedgerHandler) GetStatements(c *gin.Context) {
+	var req dto.PartnerStatementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+
+	from, to, ok := parseDateRangeParams(c, req.From, req.To)
+	if !ok {
+		return
+	}
+
+	filter := service.PartnerStatementFilter{PartnerType: req.PartnerType, IncludeEmpty: req.IncludeEmpty}
+	statements, err := h.service.GetStatements(c.Request.Context(), appctx.GetCompanyID(c), from, to, filter)
+	if err != nil {
+		respondError(c, err, "Failed to generate partner statements")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPartnerLedgers(statements)))
+}
+
+// SendStatements emails the monthly statements to all partners
+// @Summary Mail partner statements
+// @Description Email each partner its statement for the month as a PDF attachment
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param body body dto.SendPartnerStatementsRequest true "Month to mail"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/reports/partner-ledger/bulk/send [post]
+func (h *PartnerLedgerHandler) SendStatements(c *gin.Context) {
+	var req dto.SendPartnerStatementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	from := time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	filter := service.PartnerStatementFilter{PartnerType: req.PartnerType}
+	result, err := h.service.SendStatements(c.Request.Context(), appctx.GetCompanyID(c), from, to, filter)
+	if err != nil {
+		respondError(c, err, "Failed to send partner statements")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPartnerStatementMailing(result, from.Format("2006-01-02"), to.Format("2006-01-02"))))
+}
+
+// parseDateRangeParams parses the from/to dates, writing a 400 response on failure
+func parseDateRangeParams(c *gin.Context, fromStr, toStr string) (time.Time, time.Time, bool) {
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid from date format"))
+		return time.Time{}, time.Time{}, false
+	}
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid to date format"))
+		return time.Time{}, time.Time{}, false
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "to date must not be before from date"))
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// PartnerVerificationHandler handles HTTP requests for verifying business
+// numbers against NTS
+type PartnerVerificationHandler struct {
+	service service.BusinessVerificationService
+}
+
+// NewPartnerVerificationHandler creates a new PartnerVerificationHandler
+func NewPartnerVerificationHandler(svc service.BusinessVerificationService) *PartnerVerificationHandler {
+	return &PartnerVerificationHandler{service: svc}
+}
+
+// RegisterRoutes registers business verification routes
+func (h *PartnerVerificationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/partners/verify", h.Verify)
+}
+
+// Verify handles POST /partners/verify
+func (h *PartnerVerificationHandler) Verify(c *gin.Context) {
+	var req dto.VerifyPartnersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	verifications, err := h.service.Verify(c.Request.Context(), appctx.GetCompanyID(c), req.ToDomain())
+	if err != nil {
+		respondError(c, err, "Failed to verify business numbers")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(verifications))
+}
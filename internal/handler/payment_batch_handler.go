@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/banktransfer"
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// PaymentBatchHandler handles bulk transfers of payables
+type PaymentBatchHandler struct {
+	service service.PaymentBatchService
+}
+
+// NewPaymentBatchHandler creates a new PaymentBatchHandler
+func NewPaymentBatchHandler(svc service.PaymentBatchService) *PaymentBatchHandler {
+	return &PaymentBatchHandler{service: svc}
+}
+
+// RegisterRoutes registers payment batch routes
+func (h *PaymentBatchHandler) RegisterRoutes(r *gin.RouterGroup) {
+	payments := r.Group("/payments")
+	{
+		payments.GET("/payables", h.Payables)
+		payments.GET("/batches", h.List)
+		payments.POST("/batches", h.Create)
+		payments.GET("/batches/:id", h.Get)
+		payments.GET("/batches/:id/file", h.File)
+		payments.POST("/batches/:id/execute", h.Execute)
+		payments.POST("/batches/:id/cancel", h.Cancel)
+	}
+}
+
+// Payables handles GET /payments/payables
+func (h *PaymentBatchHandler) Payables(c *gin.Context) {
+	var req dto.PayablesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.DueBy == "" {
+		req.DueBy = time.Now().Format("2006-01-02")
+	}
+	dueBy, _ := time.Parse("2006-01-02", req.DueBy)
+	var partnerIDs []uuid.UUID
+	if req.PartnerID != "" {
+		partnerIDs = []uuid.UUID{uuid.MustParse(req.PartnerID)}
+	}
+
+	payables, err := h.service.Payables(c.Request.Context(), appctx.GetCompanyID(c), dueBy, partnerIDs)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list payables")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(payables))
+}
+
+// Create handles POST /payments/batches
+func (h *PaymentBatchHandler) Create(c *gin.Context) {
+	var req dto.CreatePaymentBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	transferDate, _ := time.Parse("2006-01-02", req.TransferDate)
+	input := service.PaymentBatchInput{
+		BankAccountID: uuid.MustParse(req.BankAccountID),
+		TransferDate:  transferDate,
+		PartnerIDs:    parseUUIDs(req.PartnerIDs),
+		EntryIDs:      parseUUIDs(req.EntryIDs),
+		CreatedBy:     appctx.GetUserID(c),
+	}
+	if req.DueBy != "" {
+		dueBy, _ := time.Parse("2006-01-02", req.DueBy)
+		input.DueBy = &dueBy
+	}
+
+	creation, err := h.service.CreateBatch(c.Request.Context(), appctx.GetCompanyID(c), input)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create payment batch")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(creation))
+}
+
+// List handles GET /payments/batches
+func (h *PaymentBatchHandler) List(c *gin.Context) {
+	filter := repository.PaymentBatchFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.PaymentBatchStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid payment batch status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	batches, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list payment batches")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		batches,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /payments/batches/:id
+func (h *PaymentBatchHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid payment batch ID")
+	if !ok {
+		return
+	}
+
+	batch, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get payment batch")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(batch))
+}
+
+// File handles GET /payments/batches/:id/file, the file uploaded to internet banking
+func (h *PaymentBatchHandler) File(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid payment batch ID")
+	if !ok {
+		return
+	}
+
+	file, err := h.service.File(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get payment batch file")
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Data(http.StatusOK, banktransfer.ContentType, file.Content)
+}
+
+// Execute handles POST /payments/batches/:id/execute
+func (h *PaymentBatchHandler) Execute(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid payment batch ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ExecutePaymentBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	executedOn, _ := time.Parse("2006-01-02", req.ExecutedOn)
+
+	batch, err := h.service.Execute(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, executedOn, parseUUIDs(req.FailedItemIDs))
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to execute payment batch")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(batch))
+}
+
+// Cancel handles POST /payments/batches/:id/cancel
+func (h *PaymentBatchHandler) Cancel(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid payment batch ID")
+	if !ok {
+		return
+	}
+
+	batch, err := h.service.Cancel(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to cancel payment batch")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(batch))
+}
+
+// parseUUIDs parses identifiers validated by binding
+func parseUUIDs(ids []string) []uuid.UUID {
+	if len(ids) == 0 {
+		return nil
+	}
+	parsed := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		parsed[i] = uuid.MustParse(id)
+	}
+	return parsed
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// PettyCashHandler handles petty cash funds, their claims and replenishments
+type PettyCashHandler struct {
+	service service.PettyCashService
+}
+
+// NewPettyCashHandler creates a new PettyCashHandler
+func NewPettyCashHandler(svc service.PettyCashService) *PettyCashHandler {
+	return &PettyCashHandler{service: svc}
+}
+
+// RegisterRoutes registers petty cash routes
+func (h *PettyCashHandler) RegisterRoutes(r *gin.RouterGroup) {
+	pettyCash := r.Group("/petty-cash")
+	{
+		pettyCash.GET("/funds", h.ListFunds)
+		pettyCash.POST("/funds", h.CreateFund)
+		pettyCash.GET("/funds/:id", h.GetFund)
+		pettyCash.PUT("/funds/:id", h.UpdateFund)
+		pettyCash.GET("/funds/:id/claims", h.ListClaims)
+		pettyCash.POST("/funds/:id/claims", h.Claim)
+		pettyCash.GET("/funds/:id/replenishments", h.ListReplenishments)
+		pettyCash.POST("/funds/:id/replenishments", h.Replenish)
+		pettyCash.GET("/funds/:id/reconciliation", h.Reconcile)
+		pettyCash.DELETE("/claims/:id", h.DeleteClaim)
+	}
+}
+
+// CreateFund handles POST /petty-cash/funds
+func (h *PettyCashHandler) CreateFund(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.CreatePettyCashFundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	if req.EstablishedDate == "" {
+		req.EstablishedDate = time.Now().Format("2006-01-02")
+	}
+
+	fund := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.CreateFund(c.Request.Context(), fund); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create petty cash fund")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(fund))
+}
+
+// ListFunds handles GET /petty-cash/funds
+func (h *PettyCashHandler) ListFunds(c *gin.Context) {
+	var departmentID *uuid.UUID
+	if department := c.Query("department_id"); department != "" {
+		id, err := uuid.Parse(department)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid department ID"))
+			return
+		}
+		departmentID = &id
+	}
+
+	funds, err := h.service.ListFunds(c.Request.Context(), appctx.GetCompanyID(c), departmentID)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list petty cash funds")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(funds))
+}
+
+// GetFund handles GET /petty-cash/funds/:id
+func (h *PettyCashHandler) GetFund(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	fund, err := h.service.GetFund(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get petty cash fund")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(fund))
+}
+
+// UpdateFund handles PUT /petty-cash/funds/:id
+func (h *PettyCashHandler) UpdateFund(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdatePettyCashFundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	update := service.PettyCashFundUpdate{
+		Name:          req.Name,
+		BankAccountID: uuid.MustParse(req.BankAccountID),
+		IsActive:      *req.IsActive,
+	}
+	if req.CustodianID != "" {
+		custodianID := uuid.MustParse(req.CustodianID)
+		update.CustodianID = &custodianID
+	}
+
+	fund, err := h.service.UpdateFund(c.Request.Context(), appctx.GetCompanyID(c), id, update)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to update petty cash fund")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(fund))
+}
+
+// Claim handles POST /petty-cash/funds/:id/claims
+func (h *PettyCashHandler) Claim(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	var req dto.PettyCashClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	claim := req.ToDomain(appctx.GetCompanyID(c), id, appctx.GetUserID(c))
+	if err := h.service.Claim(c.Request.Context(), claim); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to record petty cash claim")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(claim))
+}
+
+// ListClaims handles GET /petty-cash/funds/:id/claims
+func (h *PettyCashHandler) ListClaims(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	filter := repository.PettyCashClaimFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		FundID:    id,
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.PettyCashClaimStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid petty cash claim status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if from := c.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid from date"))
+			return
+		}
+		filter.From = &date
+	}
+	if to := c.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid to date"))
+			return
+		}
+		filter.To = &date
+	}
+
+	claims, total, err := h.service.ListClaims(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list petty cash claims")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		claims,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// DeleteClaim handles DELETE /petty-cash/claims/:id
+func (h *PettyCashHandler) DeleteClaim(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash claim ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteClaim(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to delete petty cash claim")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// Replenish handles POST /petty-cash/funds/:id/replenishments
+func (h *PettyCashHandler) Replenish(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ReplenishPettyCashRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	if req.ClaimsThrough == "" {
+		req.ClaimsThrough = req.RequestDate
+	}
+	requestDate, _ := time.Parse("2006-01-02", req.RequestDate)
+	through, _ := time.Parse("2006-01-02", req.ClaimsThrough)
+
+	replenishment, err := h.service.Replenish(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, requestDate, through)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to replenish petty cash fund")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(replenishment))
+}
+
+// ListReplenishments handles GET /petty-cash/funds/:id/replenishments
+func (h *PettyCashHandler) ListReplenishments(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	replenishments, err := h.service.ListReplenishments(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list petty cash replenishments")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(replenishments))
+}
+
+// Reconcile handles GET /petty-cash/funds/:id/reconciliation
+func (h *PettyCashHandler) Reconcile(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid petty cash fund ID")
+	if !ok {
+		return
+	}
+
+	var req dto.PettyCashReconciliationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	reconciliation, err := h.service.Reconcile(c.Request.Context(), appctx.GetCompanyID(c), id, asOf, req.CountedCash)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to reconcile petty cash fund")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(reconciliation))
+}
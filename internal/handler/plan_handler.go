@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// PlanHandler handles subscription plans
+type PlanHandler struct {
+	service service.PlanService
+}
+
+// NewPlanHandler creates a new PlanHandler
+func NewPlanHandler(svc service.PlanService) *PlanHandler {
+	return &PlanHandler{service: svc}
+}
+
+// RegisterRoutes registers plan routes
+func (h *PlanHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/plans", h.List)
+	r.GET("/plan", h.Get)
+}
+
+// List handles GET /plans
+func (h *PlanHandler) List(c *gin.Context) {
+	plans, err := h.service.List(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to list plans")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromPlans(plans)))
+}
+
+// Get handles GET /plan, the company's plan and its usage of the limits
+func (h *PlanHandler) Get(c *gin.Context) {
+	plan, usage, err := h.service.Subscription(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to get plan")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromSubscription(plan, usage, appctx.GetLocale(c))))
+}
+
+// respondPlanLimitExceeded responds with the limit and the suggested upgrade
+// if the operation was refused by the company's plan
+func respondPlanLimitExceeded(c *gin.Context, err error) bool {
+	var limitErr *domain.PlanLimitError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+	resp := dto.ErrorResponseWithDetails(dto.ErrCodePlanLimitExceeded, "Subscription plan limit exceeded", limitErr.Error())
+	resp.Data = dto.FromPlanLimitError(limitErr, appctx.GetLocale(c))
+	c.JSON(http.StatusPaymentRequired, resp)
+	return true
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// popbillWebhookMaxBody is the largest callback body accepted
+const popbillWebhookMaxBody = 64 << 10
+
+// PopbillWebhookHandler handles Popbill tax invoice callbacks
+type PopbillWebhookHandler struct {
+	service service.PopbillWebhookService
+}
+
+// NewPopbillWebhookHandler creates a new PopbillWebhookHandler
+func NewPopbillWebhookHandler(svc service.PopbillWebhookService) *PopbillWebhookHandler {
+	return &PopbillWebhookHandler{service: svc}
+}
+
+// RotateSecret applies a reloaded callback signing secret
+func (h *PopbillWebhookHandler) RotateSecret(secret string) {
+	h.service.RotateSecret(secret)
+}
+
+// RegisterRoutes registers the administrator view of received callbacks.
+// The callback endpoint itself is public and registered separately.
+func (h *PopbillWebhookHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/integrations/popbill/webhooks", h.List)
+}
+
+// Receive handles POST /webhooks/popbill.
+// Callbacks are authorized by their signature and only stored here; a replayed
+// callback is acknowledged so that Popbill stops delivering it.
+func (h *PopbillWebhookHandler) Receive(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, popbillWebhookMaxBody+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Failed to read request body"))
+		return
+	}
+	if len(body) > popbillWebhookMaxBody {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse(dto.ErrCodeValidation, "Request body is too large"))
+		return
+	}
+
+	_, err = h.service.Receive(c.Request.Context(), body,
+		c.GetHeader(popbill.WebhookTimestampHeader), c.GetHeader(popbill.WebhookSignatureHeader), c.ClientIP())
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"received": true}))
+	case errors.Is(err, domain.ErrPopbillWebhookDuplicate):
+		c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"received": true, "duplicate": true}))
+	default:
+		respondError(c, err, "Failed to receive webhook")
+	}
+}
+
+// List handles GET /integrations/popbill/webhooks
+func (h *PopbillWebhookHandler) List(c *gin.Context) {
+	filter := repository.PopbillWebhookFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		ItemKey:   c.Query("item_key"),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.PopbillWebhookStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid webhook status"))
+			return
+		}
+		filter.Status = &s
+	}
+
+	events, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to list webhooks")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		dto.FromPopbillWebhookEvents(events),
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
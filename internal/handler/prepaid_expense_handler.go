@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// PrepaidExpenseHandler handles amortization schedules of prepaid expenses
+type PrepaidExpenseHandler struct {
+	service service.PrepaidExpenseService
+}
+
+// NewPrepaidExpenseHandler creates a new PrepaidExpenseHandler
+func NewPrepaidExpenseHandler(svc service.PrepaidExpenseService) *PrepaidExpenseHandler {
+	return &PrepaidExpenseHandler{service: svc}
+}
+
+// RegisterRoutes registers prepaid expense routes
+func (h *PrepaidExpenseHandler) RegisterRoutes(r *gin.RouterGroup) {
+	expenses := r.Group("/prepaid-expenses")
+	{
+		expenses.GET("", h.List)
+		expenses.POST("", h.Create)
+		expenses.GET("/balances", h.Balances)
+		expenses.POST("/amortizations", h.Amortize)
+		expenses.GET("/:id", h.Get)
+	}
+}
+
+// Create handles POST /prepaid-expenses
+func (h *PrepaidExpenseHandler) Create(c *gin.Context) {
+	var req dto.CreatePrepaidExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodStart, _ := time.Parse("2006-01-02", req.PeriodStart)
+	periodEnd, _ := time.Parse("2006-01-02", req.PeriodEnd)
+
+	expense, err := h.service.Create(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), service.PrepaidExpenseInput{
+		VoucherID:        uuid.MustParse(req.VoucherID),
+		ExpenseAccountID: uuid.MustParse(req.ExpenseAccountID),
+		PrepaidAccountID: uuid.MustParse(req.PrepaidAccountID),
+		PeriodStart:      periodStart,
+		PeriodEnd:        periodEnd,
+		Amount:           req.Amount,
+		Description:      req.Description,
+	})
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create prepaid expense")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(expense))
+}
+
+// List handles GET /prepaid-expenses
+func (h *PrepaidExpenseHandler) List(c *gin.Context) {
+	filter := repository.PrepaidExpenseFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.PrepaidExpenseStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid prepaid expense status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if partnerID := c.Query("partner_id"); partnerID != "" {
+		id, err := uuid.Parse(partnerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid partner ID"))
+			return
+		}
+		filter.PartnerID = &id
+	}
+	if accountID := c.Query("account_id"); accountID != "" {
+		id, err := uuid.Parse(accountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid account ID"))
+			return
+		}
+		filter.AccountID = &id
+	}
+
+	expenses, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list prepaid expenses")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		expenses,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /prepaid-expenses/:id
+func (h *PrepaidExpenseHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid prepaid expense ID")
+	if !ok {
+		return
+	}
+
+	expense, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get prepaid expense")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(expense))
+}
+
+// Amortize handles POST /prepaid-expenses/amortizations.
+// The worker amortizes every month end; this catches up a month for the company.
+func (h *PrepaidExpenseHandler) Amortize(c *gin.Context) {
+	var req dto.AmortizePrepaidExpensesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodEnd, _ := time.Parse("2006-01-02", req.PeriodEnd)
+	if periodEnd.AddDate(0, 0, 1).Day() != 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "period_end must be the last day of a month"))
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	run, err := h.service.Amortize(c.Request.Context(), &companyID, periodEnd)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to amortize prepaid expenses")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Balances handles GET /prepaid-expenses/balances
+func (h *PrepaidExpenseHandler) Balances(c *gin.Context) {
+	var req dto.PrepaidExpenseBalanceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+	var accountID *uuid.UUID
+	if req.AccountID != "" {
+		id := uuid.MustParse(req.AccountID)
+		accountID = &id
+	}
+
+	report, err := h.service.BalanceReport(c.Request.Context(), appctx.GetCompanyID(c), asOf, accountID)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to report prepaid expense balances")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(report))
+}
@@ -43,7 +43,7 @@ func (h *ProjectHandler) RegisterRoutes(r *gin.RouterGroup) {
 func (h *ProjectHandler) Create(c *gin.Context) {
 	var req dto.CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -56,16 +56,7 @@ func (h *ProjectHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.service.Create(c.Request.Context(), project); err != nil {
-		switch err {
-		case domain.ErrProjectCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Project code already exists"))
-		case domain.ErrProjectCodeEmpty:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Project code is required"))
-		case domain.ErrProjectNameEmpty:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Project name is required"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to create project")
 		return
 	}
 
@@ -111,7 +102,7 @@ func (h *ProjectHandler) List(c *gin.Context) {
 
 	projects, total, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to list projects")
 		return
 	}
 
@@ -137,11 +128,7 @@ func (h *ProjectHandler) GetByID(c *gin.Context) {
 
 	project, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrProjectNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Project not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get project")
 		return
 	}
 
@@ -155,11 +142,7 @@ func (h *ProjectHandler) GetByCode(c *gin.Context) {
 
 	project, err := h.service.GetByCode(c.Request.Context(), companyID, code)
 	if err != nil {
-		if err == domain.ErrProjectNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Project not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get project")
 		return
 	}
 
@@ -177,18 +160,14 @@ func (h *ProjectHandler) Update(c *gin.Context) {
 
 	var req dto.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	// Get existing project
 	project, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrProjectNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Project not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get project")
 		return
 	}
 
@@ -196,12 +175,7 @@ func (h *ProjectHandler) Update(c *gin.Context) {
 	req.ApplyTo(project)
 
 	if err := h.service.Update(c.Request.Context(), project); err != nil {
-		switch err {
-		case domain.ErrProjectCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Project code already exists"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to update project")
 		return
 	}
 
@@ -218,14 +192,7 @@ func (h *ProjectHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		switch err {
-		case domain.ErrProjectNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Project not found"))
-		case domain.ErrProjectInUse:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_002", "Project is in use and cannot be deleted"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to delete project")
 		return
 	}
 
@@ -243,11 +210,7 @@ func (h *ProjectHandler) CanDelete(c *gin.Context) {
 
 	canDelete, reason, err := h.service.CanDelete(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrProjectNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Project not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to check project deletion")
 		return
 	}
 
@@ -263,7 +226,7 @@ func (h *ProjectHandler) GetStats(c *gin.Context) {
 
 	stats, err := h.service.GetStats(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get project statistics")
 		return
 	}
 
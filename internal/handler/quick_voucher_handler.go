@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// QuickVoucherHandler handles HTTP requests for vouchers typed as shorthand
+// entry rows
+type QuickVoucherHandler struct {
+	service   service.QuickVoucherService
+	shortcuts service.ShortcutRecorder // may be nil
+}
+
+// NewQuickVoucherHandler creates a new QuickVoucherHandler; shortcuts records
+// the accounts and partners users enter
+func NewQuickVoucherHandler(svc service.QuickVoucherService, shortcuts service.ShortcutRecorder) *QuickVoucherHandler {
+	return &QuickVoucherHandler{service: svc, shortcuts: shortcuts}
+}
+
+// RegisterRoutes registers quick voucher routes
+func (h *QuickVoucherHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/vouchers/quick", h.Create)
+}
+
+// Create creates a voucher from entry rows
+// @Summary Create voucher from entry rows
+// @Description Create a voucher from rows with account, partner, department, project and tax codes in place of IDs and the amount on one side. One row may leave out its amount to balance the voucher. Unknown codes are all reported with their line.
+// @Tags vouchers
+// @Accept json
+// @Produce json
+// @Param request body dto.QuickVoucherRequest true "Voucher and entry rows"
+// @Success 201 {object} dto.Response{data=dto.VoucherResponse}
+// @Router /api/v1/vouchers/quick [post]
+func (h *QuickVoucherHandler) Create(c *gin.Context) {
+	var req dto.QuickVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	userID := appctx.GetUserID(c)
+	voucher, entries, err := req.ToVoucher(appctx.GetCompanyID(c), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid voucher data", err.Error()))
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), voucher, entries); err != nil {
+		var unknown *domain.UnknownCodesError
+		if errors.As(err, &unknown) {
+			resp := dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Unknown codes in entries", unknown.Error())
+			resp.Data = unknown.Codes
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+		respondMappedError(c, referenceErrors, err, "Failed to create voucher")
+		return
+	}
+	if h.shortcuts != nil {
+		h.shortcuts.RecordVoucher(c.Request.Context(), userID, voucher)
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
+}
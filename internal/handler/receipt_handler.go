@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ReceiptHandler handles HTTP requests for receipt OCR voucher suggestions
+type ReceiptHandler struct {
+	service      service.ReceiptService
+	maxImageSize int64
+}
+
+// NewReceiptHandler creates a new ReceiptHandler
+func NewReceiptHandler(svc service.ReceiptService, maxImageSize int64) *ReceiptHandler {
+	return &ReceiptHandler{service: svc, maxImageSize: maxImageSize}
+}
+
+// RegisterRoutes registers receipt routes
+func (h *ReceiptHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/vouchers/suggest-from-receipt", h.SuggestFromReceipt)
+}
+
+// SuggestFromReceipt recognizes an uploaded receipt and returns a suggested voucher
+// @Summary Suggest voucher from receipt
+// @Description Extract date, vendor, amount and VAT from a receipt image and suggest voucher entries
+// @Tags vouchers
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Receipt image (jpg, png, pdf, tiff)"
+// @Param expense_account_id formData string true "Debit account ID"
+// @Param payment_account_id formData string true "Credit account ID"
+// @Param tax_code_id formData string false "Input tax code ID"
+// @Success 200 {object} dto.Response{data=dto.ReceiptSuggestionResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 413 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 503 {object} dto.Response
+// @Router /vouchers/suggest-from-receipt [post]
+func (h *ReceiptHandler) SuggestFromReceipt(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	var req dto.SuggestFromReceiptRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request", err))
+		return
+	}
+
+	input := service.ReceiptSuggestionInput{
+		ExpenseAccountID: uuid.MustParse(req.ExpenseAccountID),
+		PaymentAccountID: uuid.MustParse(req.PaymentAccountID),
+	}
+	if req.TaxCodeID != "" {
+		taxCodeID := uuid.MustParse(req.TaxCodeID)
+		input.TaxCodeID = &taxCodeID
+	}
+
+	image, err := h.readImage(c)
+	if err != nil {
+		if err == domain.ErrReceiptImageTooLarge {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse(dto.ErrCodeValidation, "Receipt image is too large"))
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Receipt image is required", err.Error()))
+		return
+	}
+
+	suggestion, err := h.service.SuggestFromReceipt(c.Request.Context(), companyID, image, input)
+	if err != nil {
+		switch {
+		// Whatever fails at the OCR provider, receipts cannot be recognized now
+		case errors.Is(err, provider.ErrProviderUnavailable), errors.Is(err, provider.ErrProviderTimeout),
+			errors.Is(err, provider.ErrQuotaExceeded), errors.Is(err, provider.ErrInvalidCredentials):
+			c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse(apperrors.CodeUnavailable, "Receipt OCR is unavailable"))
+		default:
+			respondMappedError(c, referenceErrors, err, "Failed to recognize receipt")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReceiptSuggestion(suggestion)))
+}
+
+// readImage reads the uploaded receipt file, enforcing the size limit
+func (h *ReceiptHandler) readImage(c *gin.Context) (*provider.ReceiptImage, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	if fileHeader.Size > h.maxImageSize {
+		return nil, domain.ErrReceiptImageTooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > h.maxImageSize {
+		return nil, domain.ErrReceiptImageTooLarge
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(data)
+	}
+
+	return &provider.ReceiptImage{
+		Name:        fileHeader.Filename,
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/pii"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ReportDefinitionHandler handles HTTP requests for saved custom reports
+type ReportDefinitionHandler struct {
+	service service.ReportDefinitionService
+	reports service.ReportService
+}
+
+// NewReportDefinitionHandler creates a new ReportDefinitionHandler
+func NewReportDefinitionHandler(svc service.ReportDefinitionService, reports service.ReportService) *ReportDefinitionHandler {
+	return &ReportDefinitionHandler{service: svc, reports: reports}
+}
+
+// RegisterRoutes registers report definition routes
+func (h *ReportDefinitionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	defs := r.Group("/report-definitions")
+	{
+		defs.GET("", h.List)
+		defs.POST("", h.Create)
+		defs.POST("/preview", h.Preview)
+		defs.GET("/:id", h.Get)
+		defs.PUT("/:id", h.Update)
+		defs.DELETE("/:id", h.Delete)
+		defs.GET("/:id/run", h.Run)
+		defs.GET("/:id/export", h.Export)
+	}
+}
+
+// List handles GET /report-definitions
+func (h *ReportDefinitionHandler) List(c *gin.Context) {
+	defs, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list report definitions")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportDefinitions(defs)))
+}
+
+// Create handles POST /report-definitions
+func (h *ReportDefinitionHandler) Create(c *gin.Context) {
+	var req dto.CreateReportDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	def := req.ToReportDefinition(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), def); err != nil {
+		respondError(c, err, "Failed to create report definition")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromReportDefinition(def)))
+}
+
+// Preview handles POST /report-definitions/preview
+func (h *ReportDefinitionHandler) Preview(c *gin.Context) {
+	var req dto.PreviewReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	from, to, err := req.DateRange()
+	if err != nil {
+		respondError(c, err, "")
+		return
+	}
+
+	table, err := h.service.Preview(c.Request.Context(), appctx.GetCompanyID(c), req.Spec.ToReportSpec(), from, to)
+	if err != nil {
+		respondError(c, err, "Failed to run report")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportTable(table)))
+}
+
+// Get handles GET /report-definitions/:id
+func (h *ReportDefinitionHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report definition ID"))
+		return
+	}
+
+	def, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get report definition")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportDefinition(def)))
+}
+
+// Update handles PUT /report-definitions/:id
+func (h *ReportDefinitionHandler) Update(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report definition ID"))
+		return
+	}
+
+	var req dto.UpdateReportDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	def, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get report definition")
+		return
+	}
+
+	req.ApplyTo(def)
+	if err := h.service.Update(c.Request.Context(), def); err != nil {
+		respondError(c, err, "Failed to update report definition")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportDefinition(def)))
+}
+
+// Delete handles DELETE /report-definitions/:id
+func (h *ReportDefinitionHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report definition ID"))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to delete report definition")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// Run handles GET /report-definitions/:id/run
+func (h *ReportDefinitionHandler) Run(c *gin.Context) {
+	table, ok := h.runSaved(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportTable(table)))
+}
+
+// Export handles GET /report-definitions/:id/export?format=csv|pdf. Personal
+// data in text cells is masked for non-admins, and with mask_pii=true.
+func (h *ReportDefinitionHandler) Export(c *gin.Context) {
+	format := domain.ReportFormat(c.DefaultQuery("format", string(domain.ReportFormatCSV)))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "format must be csv or pdf"))
+		return
+	}
+
+	table, ok := h.runSaved(c)
+	if !ok {
+		return
+	}
+	if requested, _ := strconv.ParseBool(c.Query("mask_pii")); maskExportPII(c, requested) {
+		maskReportTablePII(table)
+	}
+
+	data, err := h.reports.Export(table, format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to export report"))
+		return
+	}
+
+	fileName := fmt.Sprintf("report_%s.%s", time.Now().Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(http.StatusOK, format.ContentType(), data)
+}
+
+// runSaved runs the definition named in the path; it writes the error response and returns false on failure
+func (h *ReportDefinitionHandler) runSaved(c *gin.Context) (*domain.ReportTable, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report definition ID"))
+		return nil, false
+	}
+
+	var req dto.RunReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return nil, false
+	}
+	from, to, err := req.DateRange()
+	if err != nil {
+		respondError(c, err, "")
+		return nil, false
+	}
+
+	table, err := h.service.Run(c.Request.Context(), appctx.GetCompanyID(c), id, from, to)
+	if err != nil {
+		respondError(c, err, "Failed to run report")
+		return nil, false
+	}
+	return table, true
+}
+
+// maskReportTablePII masks the personal data in the text cells of a report
+func maskReportTablePII(table *domain.ReportTable) {
+	for _, row := range table.Rows {
+		for i := range row {
+			if i < len(table.Columns) && table.Columns[i].Numeric {
+				continue
+			}
+			row[i] = pii.MaskText(row[i])
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ReportScheduleHandler handles HTTP requests for scheduled report delivery
+type ReportScheduleHandler struct {
+	service service.ReportScheduleService
+}
+
+// NewReportScheduleHandler creates a new ReportScheduleHandler
+func NewReportScheduleHandler(svc service.ReportScheduleService) *ReportScheduleHandler {
+	return &ReportScheduleHandler{service: svc}
+}
+
+// RegisterRoutes registers report schedule routes
+func (h *ReportScheduleHandler) RegisterRoutes(r *gin.RouterGroup) {
+	schedules := r.Group("/report-schedules")
+	{
+		schedules.GET("", h.List)
+		schedules.POST("", h.Create)
+		schedules.GET("/:id", h.Get)
+		schedules.PUT("/:id", h.Update)
+		schedules.DELETE("/:id", h.Delete)
+		schedules.POST("/:id/run", h.Run)
+		schedules.GET("/:id/runs", h.ListRuns)
+	}
+}
+
+// List handles GET /report-schedules
+func (h *ReportScheduleHandler) List(c *gin.Context) {
+	schedules, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list report schedules")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportSchedules(schedules, appctx.GetLocale(c))))
+}
+
+// Create handles POST /report-schedules
+func (h *ReportScheduleHandler) Create(c *gin.Context) {
+	var req dto.CreateReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	schedule := req.ToReportSchedule(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), schedule); err != nil {
+		respondError(c, err, "Failed to create report schedule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromReportSchedule(schedule, appctx.GetLocale(c))))
+}
+
+// Get handles GET /report-schedules/:id
+func (h *ReportScheduleHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report schedule ID"))
+		return
+	}
+
+	schedule, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get report schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportSchedule(schedule, appctx.GetLocale(c))))
+}
+
+// Update handles PUT /report-schedules/:id
+func (h *ReportScheduleHandler) Update(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report schedule ID"))
+		return
+	}
+
+	var req dto.UpdateReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	schedule, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get report schedule")
+		return
+	}
+
+	req.ApplyTo(schedule)
+	if err := h.service.Update(c.Request.Context(), schedule); err != nil {
+		respondError(c, err, "Failed to update report schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportSchedule(schedule, appctx.GetLocale(c))))
+}
+
+// Delete handles DELETE /report-schedules/:id
+func (h *ReportScheduleHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report schedule ID"))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to delete report schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// Run handles POST /report-schedules/:id/run.
+// The report is generated and sent immediately; a failed delivery is reported in the returned run.
+func (h *ReportScheduleHandler) Run(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report schedule ID"))
+		return
+	}
+
+	run, err := h.service.RunNow(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to run report schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportScheduleRun(run)))
+}
+
+// ListRuns handles GET /report-schedules/:id/runs
+func (h *ReportScheduleHandler) ListRuns(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid report schedule ID"))
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	runs, err := h.service.GetRuns(c.Request.Context(), appctx.GetCompanyID(c), id, limit)
+	if err != nil {
+		respondError(c, err, "Failed to list report schedule runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromReportScheduleRuns(runs)))
+}
@@ -8,6 +8,7 @@ import (
 
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/validation"
 )
 
 // Response is the standard API response structure
@@ -26,10 +27,7 @@ type ErrorBody struct {
 }
 
 // FieldError represents a validation error on a specific field
-type FieldError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
-}
+type FieldError = validation.FieldError
 
 // Meta contains metadata about the response
 type Meta struct {
@@ -187,6 +185,11 @@ func InternalError(c *gin.Context, message string) {
 	Error(c, http.StatusInternalServerError, errors.CodeInternal, message)
 }
 
+// ServiceUnavailable sends a 503 response
+func ServiceUnavailable(c *gin.Context, message string) {
+	Error(c, http.StatusServiceUnavailable, errors.CodeUnavailable, message)
+}
+
 // ValidationError sends a 400 response with validation details
 func ValidationError(c *gin.Context, details []FieldError) {
 	ErrorWithDetails(c, http.StatusBadRequest, errors.CodeValidation, "Validation failed", details)
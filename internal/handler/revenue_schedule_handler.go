@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// RevenueScheduleHandler handles revenue recognition schedules of deferred revenue
+type RevenueScheduleHandler struct {
+	service service.RevenueScheduleService
+}
+
+// NewRevenueScheduleHandler creates a new RevenueScheduleHandler
+func NewRevenueScheduleHandler(svc service.RevenueScheduleService) *RevenueScheduleHandler {
+	return &RevenueScheduleHandler{service: svc}
+}
+
+// RegisterRoutes registers revenue schedule routes
+func (h *RevenueScheduleHandler) RegisterRoutes(r *gin.RouterGroup) {
+	schedules := r.Group("/revenue-schedules")
+	{
+		schedules.GET("", h.List)
+		schedules.POST("", h.Create)
+		schedules.GET("/waterfall", h.Waterfall)
+		schedules.POST("/recognitions", h.Recognize)
+		schedules.GET("/:id", h.Get)
+	}
+}
+
+// Create handles POST /revenue-schedules
+func (h *RevenueScheduleHandler) Create(c *gin.Context) {
+	var req dto.CreateRevenueScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	serviceStart, _ := time.Parse("2006-01-02", req.ServiceStart)
+	serviceEnd, _ := time.Parse("2006-01-02", req.ServiceEnd)
+
+	schedule, err := h.service.Create(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), service.RevenueScheduleInput{
+		TaxInvoiceID:             uuid.MustParse(req.TaxInvoiceID),
+		ServiceStart:             serviceStart,
+		ServiceEnd:               serviceEnd,
+		RevenueAccountID:         uuid.MustParse(req.RevenueAccountID),
+		DeferredRevenueAccountID: uuid.MustParse(req.DeferredRevenueAccountID),
+		Amount:                   req.Amount,
+		Description:              req.Description,
+	})
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create revenue schedule")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(schedule))
+}
+
+// List handles GET /revenue-schedules
+func (h *RevenueScheduleHandler) List(c *gin.Context) {
+	filter := repository.RevenueScheduleFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	if status := c.Query("status"); status != "" {
+		s := domain.RevenueScheduleStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid revenue schedule status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if partnerID := c.Query("partner_id"); partnerID != "" {
+		id, err := uuid.Parse(partnerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid partner ID"))
+			return
+		}
+		filter.PartnerID = &id
+	}
+
+	schedules, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list revenue schedules")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		schedules,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /revenue-schedules/:id
+func (h *RevenueScheduleHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid revenue schedule ID")
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get revenue schedule")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(schedule))
+}
+
+// Recognize handles POST /revenue-schedules/recognitions.
+// The worker recognizes every month end; this catches up a month for the company.
+func (h *RevenueScheduleHandler) Recognize(c *gin.Context) {
+	var req dto.RecognizeRevenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	periodEnd, _ := time.Parse("2006-01-02", req.PeriodEnd)
+	if periodEnd.AddDate(0, 0, 1).Day() != 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "period_end must be the last day of a month"))
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	run, err := h.service.RecognizeRevenue(c.Request.Context(), &companyID, periodEnd)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to recognize revenue")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(run))
+}
+
+// Waterfall handles GET /revenue-schedules/waterfall
+func (h *RevenueScheduleHandler) Waterfall(c *gin.Context) {
+	var req dto.DeferredRevenueWaterfallRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if req.AsOf == "" {
+		req.AsOf = time.Now().Format("2006-01-02")
+	}
+	if req.Months == 0 {
+		req.Months = 12
+	}
+	asOf, _ := time.Parse("2006-01-02", req.AsOf)
+
+	waterfall, err := h.service.Waterfall(c.Request.Context(), appctx.GetCompanyID(c), asOf, req.Months)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to report deferred revenue waterfall")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(waterfall))
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/google/uuid"
 
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
-	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/dto"
 	"github.com/saintgo7/saas-kerp/internal/repository"
 	"github.com/saintgo7/saas-kerp/internal/service"
@@ -42,7 +41,7 @@ func (h *RoleHandler) RegisterRoutes(r *gin.RouterGroup) {
 func (h *RoleHandler) Create(c *gin.Context) {
 	var req dto.CreateRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -55,16 +54,7 @@ func (h *RoleHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.service.Create(c.Request.Context(), role); err != nil {
-		switch err {
-		case domain.ErrRoleCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Role code already exists"))
-		case domain.ErrRoleCodeEmpty:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Role code is required"))
-		case domain.ErrRoleNameEmpty:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Role name is required"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to create role")
 		return
 	}
 
@@ -99,7 +89,7 @@ func (h *RoleHandler) List(c *gin.Context) {
 
 	roles, total, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to list roles")
 		return
 	}
 
@@ -125,11 +115,7 @@ func (h *RoleHandler) GetByID(c *gin.Context) {
 
 	role, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrRoleNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Role not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get role")
 		return
 	}
 
@@ -147,18 +133,14 @@ func (h *RoleHandler) Update(c *gin.Context) {
 
 	var req dto.UpdateRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	// Get existing role
 	role, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrRoleNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Role not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get role")
 		return
 	}
 
@@ -166,12 +148,7 @@ func (h *RoleHandler) Update(c *gin.Context) {
 	req.ApplyTo(role)
 
 	if err := h.service.Update(c.Request.Context(), role); err != nil {
-		switch err {
-		case domain.ErrRoleCodeExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Role code already exists"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to update role")
 		return
 	}
 
@@ -188,14 +165,7 @@ func (h *RoleHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		switch err {
-		case domain.ErrRoleNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Role not found"))
-		case domain.ErrRoleInUse:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_002", "Role is in use and cannot be deleted"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to delete role")
 		return
 	}
 
@@ -213,25 +183,21 @@ func (h *RoleHandler) SetPermissions(c *gin.Context) {
 
 	var req dto.SetPermissionsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	permissions := req.ToPermissions()
 
 	if err := h.service.SetPermissions(c.Request.Context(), companyID, id, permissions); err != nil {
-		if err == domain.ErrRoleNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Role not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to set role permissions")
 		return
 	}
 
 	// Get updated role
 	role, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get role")
 		return
 	}
 
@@ -249,11 +215,7 @@ func (h *RoleHandler) CanDelete(c *gin.Context) {
 
 	canDelete, reason, err := h.service.CanDelete(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrRoleNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Role not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to check role deletion")
 		return
 	}
 
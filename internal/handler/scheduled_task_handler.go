@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ScheduledTaskHandler handles the recurring tasks of the worker, for platform operators
+type ScheduledTaskHandler struct {
+	service service.SchedulerService
+}
+
+// NewScheduledTaskHandler creates a new ScheduledTaskHandler
+func NewScheduledTaskHandler(svc service.SchedulerService) *ScheduledTaskHandler {
+	return &ScheduledTaskHandler{service: svc}
+}
+
+// RegisterOperatorRoutes registers the routes reserved to platform operators
+func (h *ScheduledTaskHandler) RegisterOperatorRoutes(r *gin.RouterGroup) {
+	tasks := r.Group("/admin/scheduled-tasks")
+	{
+		tasks.GET("", h.List)
+		tasks.GET("/:name", h.Get)
+		tasks.PUT("/:name", h.Update)
+	}
+}
+
+// List handles GET /admin/scheduled-tasks
+func (h *ScheduledTaskHandler) List(c *gin.Context) {
+	tasks, err := h.service.List(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to list scheduled tasks")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromScheduledTasks(tasks)))
+}
+
+// Get handles GET /admin/scheduled-tasks/:name
+func (h *ScheduledTaskHandler) Get(c *gin.Context) {
+	task, err := h.service.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		respondError(c, err, "Failed to get scheduled task")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromScheduledTask(task)))
+}
+
+// Update handles PUT /admin/scheduled-tasks/:name
+// @Summary Update a scheduled task
+// @Description Change the cron expression, time zone, catch-up policy or enabled state of a worker task.
+// @Description The next run is computed again from now; runs missed under the old schedule are not made up.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param name path string true "Task name"
+// @Param body body dto.UpdateScheduledTaskRequest true "Schedule"
+// @Success 200 {object} dto.Response{data=dto.ScheduledTaskResponse}
+// @Failure 400 {object} dto.Response
+// @Router /api/v1/admin/scheduled-tasks/{name} [put]
+func (h *ScheduledTaskHandler) Update(c *gin.Context) {
+	var req dto.UpdateScheduledTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	update := service.UpdateScheduledTaskRequest{
+		CronExpr: req.CronExpr,
+		Timezone: req.Timezone,
+		Enabled:  req.Enabled,
+	}
+	if req.CatchUp != nil {
+		policy := domain.CatchUpPolicy(*req.CatchUp)
+		update.CatchUp = &policy
+	}
+
+	task, err := h.service.Update(c.Request.Context(), c.Param("name"), update)
+	if err != nil {
+		respondError(c, err, "Failed to update scheduled task")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromScheduledTask(task)))
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// ShortcutHandler handles HTTP requests for the favorite and recent accounts
+// and partners of the current user
+type ShortcutHandler struct {
+	service service.ShortcutService
+}
+
+// NewShortcutHandler creates a new ShortcutHandler
+func NewShortcutHandler(svc service.ShortcutService) *ShortcutHandler {
+	return &ShortcutHandler{service: svc}
+}
+
+// RegisterRoutes registers shortcut routes
+func (h *ShortcutHandler) RegisterRoutes(r *gin.RouterGroup) {
+	shortcuts := r.Group("/shortcuts/:kind")
+	{
+		shortcuts.GET("", h.List)
+		shortcuts.PUT("/favorites/:id", h.AddFavorite)
+		shortcuts.DELETE("/favorites/:id", h.RemoveFavorite)
+	}
+}
+
+// List returns the user's shortcuts
+// @Summary List shortcuts
+// @Description Up to 20 accounts or partners for entry forms: the user's favorites first, then the ones they used most recently
+// @Tags shortcuts
+// @Produce json
+// @Param kind path string true "accounts or partners"
+// @Success 200 {object} dto.Response{data=[]domain.Shortcut}
+// @Router /api/v1/shortcuts/{kind} [get]
+func (h *ShortcutHandler) List(c *gin.Context) {
+	shortcuts, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), domain.ShortcutKind(c.Param("kind")))
+	if err != nil {
+		respondError(c, err, "Failed to list shortcuts")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(shortcuts))
+}
+
+// AddFavorite adds an account or partner to the user's favorites
+// @Summary Add favorite
+// @Description Add an account or partner to the user's favorites, at most 20 of each
+// @Tags shortcuts
+// @Produce json
+// @Param kind path string true "accounts or partners"
+// @Param id path string true "Account or partner ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/shortcuts/{kind}/favorites/{id} [put]
+func (h *ShortcutHandler) AddFavorite(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid ID"))
+		return
+	}
+
+	if err := h.service.AddFavorite(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), domain.ShortcutKind(c.Param("kind")), id); err != nil {
+		respondError(c, err, "Failed to add favorite")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// RemoveFavorite removes an account or partner from the user's favorites
+// @Summary Remove favorite
+// @Description Remove an account or partner from the user's favorites
+// @Tags shortcuts
+// @Produce json
+// @Param kind path string true "accounts or partners"
+// @Param id path string true "Account or partner ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/shortcuts/{kind}/favorites/{id} [delete]
+func (h *ShortcutHandler) RemoveFavorite(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid ID"))
+		return
+	}
+
+	if err := h.service.RemoveFavorite(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), domain.ShortcutKind(c.Param("kind")), id); err != nil {
+		respondError(c, err, "Failed to remove favorite")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// TaxCodeHandler handles HTTP requests for tax codes and the VAT return report
+type TaxCodeHandler struct {
+	service service.TaxCodeService
+}
+
+// NewTaxCodeHandler creates a new TaxCodeHandler
+func NewTaxCodeHandler(svc service.TaxCodeService) *TaxCodeHandler {
+	return &TaxCodeHandler{service: svc}
+}
+
+// RegisterRoutes registers tax code routes
+func (h *TaxCodeHandler) RegisterRoutes(r *gin.RouterGroup) {
+	taxCodes := r.Group("/tax-codes")
+	{
+		taxCodes.GET("", h.List)
+		taxCodes.POST("", h.Create)
+		taxCodes.GET("/:id", h.Get)
+		taxCodes.PUT("/:id", h.Update)
+		taxCodes.DELETE("/:id", h.Delete)
+	}
+
+	r.GET("/reports/vat-return", h.GetVATReturn)
+}
+
+// List handles GET /tax-codes
+func (h *TaxCodeHandler) List(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	activeOnly := c.Query("active_only") == "true"
+
+	taxCodes, err := h.service.List(c.Request.Context(), companyID, activeOnly)
+	if err != nil {
+		respondError(c, err, "Failed to list tax codes")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTaxCodes(taxCodes)))
+}
+
+// Create handles POST /tax-codes
+func (h *TaxCodeHandler) Create(c *gin.Context) {
+	var req dto.CreateTaxCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	taxCode, err := req.ToTaxCode(appctx.GetCompanyID(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid tax code data", err.Error()))
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), taxCode); err != nil {
+		respondTaxCodeError(c, err, "Failed to create tax code")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromTaxCode(taxCode)))
+}
+
+// Get handles GET /tax-codes/:id
+func (h *TaxCodeHandler) Get(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax code ID"))
+		return
+	}
+
+	taxCode, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondTaxCodeError(c, err, "Failed to get tax code")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTaxCode(taxCode)))
+}
+
+// Update handles PUT /tax-codes/:id
+func (h *TaxCodeHandler) Update(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax code ID"))
+		return
+	}
+
+	var req dto.UpdateTaxCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	taxCode, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondTaxCodeError(c, err, "Failed to get tax code")
+		return
+	}
+
+	if err := req.ApplyTo(taxCode); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid tax code data", err.Error()))
+		return
+	}
+
+	if err := h.service.Update(c.Request.Context(), taxCode); err != nil {
+		respondTaxCodeError(c, err, "Failed to update tax code")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTaxCode(taxCode)))
+}
+
+// Delete handles DELETE /tax-codes/:id
+func (h *TaxCodeHandler) Delete(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax code ID"))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
+		respondTaxCodeError(c, err, "Failed to delete tax code")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
+
+// GetVATReturn handles GET /reports/vat-return
+func (h *TaxCodeHandler) GetVATReturn(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+
+	var req dto.VATReturnRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+
+	fromDate, err := time.Parse("2006-01-02", req.FromDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid from_date format"))
+		return
+	}
+	toDate, err := time.Parse("2006-01-02", req.ToDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid to_date format"))
+		return
+	}
+	if toDate.Before(fromDate) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "to_date must not be before from_date"))
+		return
+	}
+
+	report, err := h.service.GetVATReturn(c.Request.Context(), companyID, fromDate, toDate)
+	if err != nil {
+		respondError(c, err, "Failed to generate VAT return")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVATReturn(report)))
+}
+
+// respondTaxCodeError writes the response of a tax code error; the accounts a
+// tax code refers to are its VAT accounts
+func respondTaxCodeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrAccountNotFound):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(apperrors.CodeInvalidInput, "VAT account not found"))
+	case errors.Is(err, domain.ErrControlAccountPosting):
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse(apperrors.CodeBusinessRule, "VAT account cannot be a control account"))
+	default:
+		respondError(c, err, fallback)
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// TaxInvoiceAmendmentHandler handles amended tax invoices (수정세금계산서)
+type TaxInvoiceAmendmentHandler struct {
+	service service.TaxInvoiceAmendmentService
+}
+
+// NewTaxInvoiceAmendmentHandler creates a new TaxInvoiceAmendmentHandler
+func NewTaxInvoiceAmendmentHandler(svc service.TaxInvoiceAmendmentService) *TaxInvoiceAmendmentHandler {
+	return &TaxInvoiceAmendmentHandler{service: svc}
+}
+
+// RegisterRoutes registers amendment routes
+func (h *TaxInvoiceAmendmentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	tax := r.Group("/tax-invoices")
+	{
+		tax.POST("/:id/amend", h.Amend)
+		tax.GET("/:id/amendments", h.ListAmendments)
+	}
+}
+
+// Amend handles POST /tax-invoices/:id/amend.
+// The amended invoices are created as drafts and issued like any other invoice.
+func (h *TaxInvoiceAmendmentHandler) Amend(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax invoice ID"))
+		return
+	}
+
+	var req dto.AmendTaxInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	invoices, err := h.service.Amend(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, req.ToDomain())
+	if err != nil {
+		respondAmendmentError(c, err, "Failed to amend tax invoice")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(invoices))
+}
+
+// ListAmendments handles GET /tax-invoices/:id/amendments
+func (h *TaxInvoiceAmendmentHandler) ListAmendments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax invoice ID"))
+		return
+	}
+
+	invoices, err := h.service.ListAmendments(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondAmendmentError(c, err, "Failed to list amendments")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(invoices))
+}
+
+// respondAmendmentError writes the response of an amendment error; the voucher
+// is that of the original invoice, which cannot be amended without it
+func respondAmendmentError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, domain.ErrVoucherNotFound) {
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse(apperrors.CodeBusinessRule, "Voucher of the original invoice not found"))
+		return
+	}
+	respondError(c, err, fallback)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// TaxInvoiceBulkIssueHandler handles bulk issuance of tax invoices through Popbill
+type TaxInvoiceBulkIssueHandler struct {
+	service service.TaxInvoiceBulkIssueService
+}
+
+// NewTaxInvoiceBulkIssueHandler creates a new TaxInvoiceBulkIssueHandler
+func NewTaxInvoiceBulkIssueHandler(svc service.TaxInvoiceBulkIssueService) *TaxInvoiceBulkIssueHandler {
+	return &TaxInvoiceBulkIssueHandler{service: svc}
+}
+
+// RegisterRoutes registers bulk issuance routes
+func (h *TaxInvoiceBulkIssueHandler) RegisterRoutes(r *gin.RouterGroup) {
+	bulk := r.Group("/tax-invoices/bulk-issue")
+	{
+		bulk.POST("", h.Issue)
+		bulk.GET("/:id", h.GetByID)
+		bulk.POST("/:id/retry", h.Retry)
+	}
+}
+
+// Issue handles POST /tax-invoices/bulk-issue.
+// The response is 200 even when some invoices failed; each carries its own result.
+func (h *TaxInvoiceBulkIssueHandler) Issue(c *gin.Context) {
+	var req dto.BulkIssueTaxInvoicesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	invoiceIDs := make([]uuid.UUID, len(req.InvoiceIDs))
+	for i, id := range req.InvoiceIDs {
+		invoiceIDs[i] = uuid.MustParse(id) // validated by binding
+	}
+
+	issue, err := h.service.Issue(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), invoiceIDs)
+	if err != nil {
+		respondBulkIssueError(c, err, "Failed to issue tax invoices")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTaxInvoiceBulkIssue(issue)))
+}
+
+// GetByID handles GET /tax-invoices/bulk-issue/:id
+func (h *TaxInvoiceBulkIssueHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid bulk issue ID"))
+		return
+	}
+
+	issue, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondBulkIssueError(c, err, "Failed to get bulk issue")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTaxInvoiceBulkIssue(issue)))
+}
+
+// Retry handles POST /tax-invoices/bulk-issue/:id/retry
+func (h *TaxInvoiceBulkIssueHandler) Retry(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid bulk issue ID"))
+		return
+	}
+
+	issue, err := h.service.Retry(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id)
+	if err != nil {
+		respondBulkIssueError(c, err, "Failed to retry bulk issue")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromTaxInvoiceBulkIssue(issue)))
+}
+
+// respondBulkIssueError writes the response of a bulk issue error; invoices
+// cannot be issued before the Popbill credentials are registered
+func respondBulkIssueError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, domain.ErrIntegrationCredentialNotFound) {
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse(apperrors.CodeBusinessRule, "Popbill credentials are not registered"))
+		return
+	}
+	respondError(c, err, fallback)
+}
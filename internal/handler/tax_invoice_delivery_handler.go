@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// EmailBounceTokenHeader carries the shared secret of bounce notices
+const EmailBounceTokenHeader = "X-Bounce-Token"
+
+// TaxInvoiceDeliveryHandler handles emailing tax invoices to the buyer
+type TaxInvoiceDeliveryHandler struct {
+	service service.TaxInvoiceDeliveryService
+}
+
+// NewTaxInvoiceDeliveryHandler creates a new TaxInvoiceDeliveryHandler
+func NewTaxInvoiceDeliveryHandler(svc service.TaxInvoiceDeliveryService) *TaxInvoiceDeliveryHandler {
+	return &TaxInvoiceDeliveryHandler{service: svc}
+}
+
+// RegisterRoutes registers delivery routes.
+// The bounce endpoint is public and registered separately.
+func (h *TaxInvoiceDeliveryHandler) RegisterRoutes(r *gin.RouterGroup) {
+	tax := r.Group("/tax-invoices")
+	{
+		tax.POST("/:id/send", h.Send)
+		tax.GET("/:id/deliveries", h.ListDeliveries)
+	}
+}
+
+// Send handles POST /tax-invoices/:id/send.
+// A failed send is recorded and returned as 502 with the delivery.
+func (h *TaxInvoiceDeliveryHandler) Send(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax invoice ID"))
+		return
+	}
+
+	var req dto.SendTaxInvoiceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+			return
+		}
+	}
+
+	opts := service.TaxInvoiceSendOptions{To: req.To, Cc: req.Cc, Message: req.Message}
+	delivery, err := h.service.Send(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id, opts)
+	if err != nil {
+		if delivery != nil {
+			resp := dto.ErrorResponseWithDetails(dto.ErrCodeInternalServerError, "Failed to send tax invoice email", delivery.Error)
+			resp.Data = delivery
+			c.JSON(http.StatusBadGateway, resp)
+			return
+		}
+		respondDeliveryError(c, err, "Failed to send tax invoice email")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(delivery))
+}
+
+// ListDeliveries handles GET /tax-invoices/:id/deliveries
+func (h *TaxInvoiceDeliveryHandler) ListDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid tax invoice ID"))
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondDeliveryError(c, err, "Failed to list deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(deliveries))
+}
+
+// ReceiveBounce handles POST /webhooks/email/bounces.
+// Notices are authorized by the shared token; bounces of emails other than tax
+// invoices are acknowledged so that the provider stops delivering them.
+func (h *TaxInvoiceDeliveryHandler) ReceiveBounce(c *gin.Context) {
+	var req dto.EmailBounceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	_, err := h.service.RecordBounce(c.Request.Context(), c.GetHeader(EmailBounceTokenHeader), req.ToDomain())
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"received": true, "matched": true}))
+	case errors.Is(err, domain.ErrTaxInvoiceDeliveryNotFound):
+		c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"received": true, "matched": false}))
+	default:
+		respondError(c, err, "Failed to record bounce")
+	}
+}
+
+// respondDeliveryError writes the response of a delivery error; the provider
+// that is unavailable is the email provider
+func respondDeliveryError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, provider.ErrProviderUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse(apperrors.CodeUnavailable, "Email delivery is not configured"))
+		return
+	}
+	respondError(c, err, fallback)
+}
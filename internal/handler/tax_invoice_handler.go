@@ -77,7 +77,7 @@ type CreateTaxInvoiceItemRequest struct {
 func (h *TaxInvoiceHandler) Create(c *gin.Context) {
 	var req CreateTaxInvoiceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -127,7 +127,7 @@ func (h *TaxInvoiceHandler) Create(c *gin.Context) {
 
 	invoice, err := h.service.Create(c.Request.Context(), companyID, input, &userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to create tax invoice")
 		return
 	}
 
@@ -176,7 +176,7 @@ func (h *TaxInvoiceHandler) List(c *gin.Context) {
 
 	invoices, total, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to list tax invoices")
 		return
 	}
 
@@ -200,7 +200,7 @@ func (h *TaxInvoiceHandler) GetByID(c *gin.Context) {
 
 	invoice, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "Invoice not found"))
+		respondError(c, err, "Failed to get tax invoice")
 		return
 	}
 
@@ -222,7 +222,7 @@ func (h *TaxInvoiceHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_004", err.Error()))
+		respondError(c, err, "Failed to delete tax invoice")
 		return
 	}
 
@@ -241,7 +241,7 @@ func (h *TaxInvoiceHandler) Issue(c *gin.Context) {
 
 	invoice, err := h.service.Issue(c.Request.Context(), companyID, id, &userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_004", err.Error()))
+		respondError(c, err, "Failed to issue tax invoice")
 		return
 	}
 
@@ -265,13 +265,13 @@ func (h *TaxInvoiceHandler) TransmitToNTS(c *gin.Context) {
 
 	var req TransmitRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	invoice, err := h.service.TransmitToNTS(c.Request.Context(), companyID, id, req.SessionID, &userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("SRV_003", err.Error()))
+		respondError(c, err, "Failed to transmit tax invoice to NTS")
 		return
 	}
 
@@ -295,13 +295,13 @@ func (h *TaxInvoiceHandler) Cancel(c *gin.Context) {
 
 	var req CancelRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	invoice, err := h.service.Cancel(c.Request.Context(), companyID, id, req.Reason, &userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_004", err.Error()))
+		respondError(c, err, "Failed to cancel tax invoice")
 		return
 	}
 
@@ -326,7 +326,7 @@ func (h *TaxInvoiceHandler) GetSummary(c *gin.Context) {
 
 	summary, err := h.service.GetSummary(c.Request.Context(), companyID, startDate, endDate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get tax invoice summary")
 		return
 	}
 
@@ -347,13 +347,13 @@ func (h *TaxInvoiceHandler) SyncFromHometax(c *gin.Context) {
 
 	var req SyncRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	count, err := h.service.SyncFromHometax(c.Request.Context(), companyID, req.SessionID, req.StartDate, req.EndDate, &userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("SRV_003", err.Error()))
+		respondError(c, err, "Failed to sync tax invoices from Hometax")
 		return
 	}
 
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// TravelPolicyHandler handles travel expense policies
+type TravelPolicyHandler struct {
+	service service.TravelPolicyService
+}
+
+// NewTravelPolicyHandler creates a new TravelPolicyHandler
+func NewTravelPolicyHandler(svc service.TravelPolicyService) *TravelPolicyHandler {
+	return &TravelPolicyHandler{service: svc}
+}
+
+// RegisterRoutes registers travel policy routes
+func (h *TravelPolicyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	policies := r.Group("/travel-policies")
+	{
+		policies.GET("", h.List)
+		policies.POST("", h.Create)
+		policies.GET("/:id", h.Get)
+		policies.PUT("/:id", h.Update)
+		policies.DELETE("/:id", h.Delete)
+	}
+}
+
+// Create handles POST /travel-policies
+func (h *TravelPolicyHandler) Create(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req dto.CreateTravelPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	policy := req.ToDomain(appctx.GetCompanyID(c), appctx.GetUserID(c))
+	if err := h.service.Create(c.Request.Context(), policy); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to create travel policy")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(policy))
+}
+
+// List handles GET /travel-policies
+func (h *TravelPolicyHandler) List(c *gin.Context) {
+	policies, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to list travel policies")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(policies))
+}
+
+// Get handles GET /travel-policies/:id
+func (h *TravelPolicyHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid travel policy ID")
+	if !ok {
+		return
+	}
+
+	policy, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to get travel policy")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(policy))
+}
+
+// Update handles PUT /travel-policies/:id
+func (h *TravelPolicyHandler) Update(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseUUIDParam(c, "id", "Invalid travel policy ID")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTravelPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+	update := service.TravelPolicyUpdate{
+		Name:             req.Name,
+		PerDiemRates:     req.Rates(),
+		ReceiptThreshold: req.ReceiptThreshold,
+		MileageRate:      req.MileageRate,
+		IsActive:         *req.IsActive,
+	}
+
+	policy, err := h.service.Update(c.Request.Context(), appctx.GetCompanyID(c), id, update)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to update travel policy")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(policy))
+}
+
+// Delete handles DELETE /travel-policies/:id
+func (h *TravelPolicyHandler) Delete(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseUUIDParam(c, "id", "Invalid travel policy ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to delete travel policy")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// UsageHandler handles the billable usage of the company
+type UsageHandler struct {
+	service service.MeteringService
+}
+
+// NewUsageHandler creates a new UsageHandler
+func NewUsageHandler(svc service.MeteringService) *UsageHandler {
+	return &UsageHandler{service: svc}
+}
+
+// RegisterRoutes registers usage routes
+func (h *UsageHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/usage", h.Get)
+}
+
+// Get handles GET /admin/usage.
+// from and to are months (YYYY-MM); the last 12 months are returned by default.
+func (h *UsageHandler) Get(c *gin.Context) {
+	to := domain.UsagePeriodOf(time.Now())
+	from := to.AddDate(0, -11, 0)
+	for name, month := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid "+name+" month, expected YYYY-MM"))
+			return
+		}
+		*month = domain.UsagePeriodOf(parsed)
+	}
+
+	months, err := h.service.Usage(c.Request.Context(), appctx.GetCompanyID(c), from, to)
+	if err != nil {
+		respondError(c, err, "Failed to get usage")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromUsageMonths(months, appctx.GetLocale(c))))
+}
@@ -10,6 +10,7 @@ import (
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/dto"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
 	"github.com/saintgo7/saas-kerp/internal/repository"
 	"github.com/saintgo7/saas-kerp/internal/service"
 )
@@ -35,16 +36,43 @@ func (h *UserHandler) RegisterRoutes(r *gin.RouterGroup) {
 		users.PUT("/:id", h.Update)
 		users.DELETE("/:id", h.Delete)
 		users.PUT("/:id/password", h.ChangePassword)
+		users.PUT("/:id/signing-pin", h.SetSigningPIN)
+		users.POST("/:id/signing-pin/unlock", h.UnlockSigningPIN)
+		users.PUT("/:id/role", h.AssignRole)
+		users.POST("/:id/reset-password", h.ForcePasswordReset)
 		users.POST("/:id/activate", h.Activate)
 		users.POST("/:id/deactivate", h.Deactivate)
+		users.POST("/:id/lock", h.Lock)
+		users.POST("/:id/unlock", h.Unlock)
 	}
 }
 
+// userSortColumns are the columns the user list may be sorted by
+var userSortColumns = map[string]bool{
+	"name":          true,
+	"email":         true,
+	"created_at":    true,
+	"last_login_at": true,
+}
+
+// requireAdmin writes a 403 response unless the current user is a company admin
+func requireAdmin(c *gin.Context) bool {
+	if !appctx.HasRole(c, "admin") {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse(apperrors.CodeInsufficientRole, "Administrator role required"))
+		return false
+	}
+	return true
+}
+
 // Create handles POST /users
 func (h *UserHandler) Create(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
 	var req dto.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
@@ -57,14 +85,7 @@ func (h *UserHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.service.Create(c.Request.Context(), user); err != nil {
-		switch err {
-		case service.ErrUserEmailExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Email already exists"))
-		case domain.ErrPasswordTooShort:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Password must be at least 8 characters"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to create user")
 		return
 	}
 
@@ -104,10 +125,14 @@ func (h *UserHandler) List(c *gin.Context) {
 			filter.Role = &r
 		}
 	}
+	if sortBy := c.Query("sort_by"); userSortColumns[sortBy] {
+		filter.SortBy = sortBy
+		filter.SortDesc = c.Query("sort_desc") == "true"
+	}
 
 	users, total, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to list users")
 		return
 	}
 
@@ -133,11 +158,7 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 
 	user, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get user")
 		return
 	}
 
@@ -146,6 +167,10 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 
 // Update handles PUT /users/:id
 func (h *UserHandler) Update(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
 	companyID := appctx.GetCompanyID(c)
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -155,18 +180,14 @@ func (h *UserHandler) Update(c *gin.Context) {
 
 	var req dto.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	// Get existing user
 	user, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get user")
 		return
 	}
 
@@ -174,12 +195,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 	req.ApplyTo(user)
 
 	if err := h.service.Update(c.Request.Context(), user); err != nil {
-		switch err {
-		case service.ErrUserEmailExists:
-			c.JSON(http.StatusConflict, dto.ErrorResponse("BIZ_001", "Email already exists"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
-		}
+		respondError(c, err, "Failed to update user")
 		return
 	}
 
@@ -188,6 +204,10 @@ func (h *UserHandler) Update(c *gin.Context) {
 
 // Delete handles DELETE /users/:id
 func (h *UserHandler) Delete(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
 	companyID := appctx.GetCompanyID(c)
 	currentUserID := appctx.GetUserID(c)
 	id, err := uuid.Parse(c.Param("id"))
@@ -198,16 +218,12 @@ func (h *UserHandler) Delete(c *gin.Context) {
 
 	// Prevent self-deletion
 	if id == currentUserID {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_002", "Cannot delete your own account"))
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse(apperrors.CodeBusinessRule, "Cannot delete your own account"))
 		return
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		if err == domain.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to delete user")
 		return
 	}
 
@@ -226,46 +242,26 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 
 	var req dto.ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_001", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
 		return
 	}
 
 	// Check if user is changing their own password or is an admin
 	isAdmin := appctx.HasRole(c, "admin")
 	if id != currentUserID && !isAdmin {
-		c.JSON(http.StatusForbidden, dto.ErrorResponse("AUTH_001", "Not authorized to change this user's password"))
+		c.JSON(http.StatusForbidden, dto.ErrorResponse(apperrors.CodeForbidden, "Not authorized to change this user's password"))
 		return
 	}
 
 	// If admin is changing someone else's password, skip current password check
 	if id != currentUserID && isAdmin {
 		if err := h.service.ResetPassword(c.Request.Context(), companyID, id, req.NewPassword); err != nil {
-			if err == domain.ErrUserNotFound {
-				c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-				return
-			}
-			if err == domain.ErrPasswordTooShort {
-				c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Password must be at least 8 characters"))
-				return
-			}
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+			respondError(c, err, "Failed to reset password")
 			return
 		}
 	} else {
 		if err := h.service.ChangePassword(c.Request.Context(), companyID, id, req.CurrentPassword, req.NewPassword); err != nil {
-			if err == domain.ErrUserNotFound {
-				c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-				return
-			}
-			if err == service.ErrInvalidCurrentPassword {
-				c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_005", "Invalid current password"))
-				return
-			}
-			if err == domain.ErrPasswordTooShort {
-				c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_003", "Password must be at least 8 characters"))
-				return
-			}
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+			respondError(c, err, "Failed to change password")
 			return
 		}
 	}
@@ -273,8 +269,63 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Password changed successfully"}))
 }
 
+// SetSigningPIN handles PUT /users/:id/signing-pin
+func (h *UserHandler) SetSigningPIN(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	currentUserID := appctx.GetUserID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid user ID"))
+		return
+	}
+
+	var req dto.SetSigningPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	// A signing PIN attests the signer's identity, so only the user can set it
+	if id != currentUserID {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse(apperrors.CodeForbidden, "Not authorized to set this user's signing PIN"))
+		return
+	}
+
+	if err := h.service.SetSigningPIN(c.Request.Context(), companyID, id, req.CurrentPassword, req.PIN); err != nil {
+		respondError(c, err, "Failed to set signing PIN")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"message": "Signing PIN set successfully"}))
+}
+
+// UnlockSigningPIN handles POST /users/:id/signing-pin/unlock
+func (h *UserHandler) UnlockSigningPIN(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid user ID"))
+		return
+	}
+
+	if err := h.service.UnlockSigningPIN(c.Request.Context(), companyID, id); err != nil {
+		respondError(c, err, "Failed to unlock signing PIN")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"signing_pin_locked": false}))
+}
+
 // Activate handles POST /users/:id/activate
 func (h *UserHandler) Activate(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
 	companyID := appctx.GetCompanyID(c)
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -283,11 +334,7 @@ func (h *UserHandler) Activate(c *gin.Context) {
 	}
 
 	if err := h.service.Activate(c.Request.Context(), companyID, id); err != nil {
-		if err == domain.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to activate user")
 		return
 	}
 
@@ -296,6 +343,10 @@ func (h *UserHandler) Activate(c *gin.Context) {
 
 // Deactivate handles POST /users/:id/deactivate
 func (h *UserHandler) Deactivate(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
 	companyID := appctx.GetCompanyID(c)
 	currentUserID := appctx.GetUserID(c)
 	id, err := uuid.Parse(c.Param("id"))
@@ -306,29 +357,132 @@ func (h *UserHandler) Deactivate(c *gin.Context) {
 
 	// Prevent self-deactivation
 	if id == currentUserID {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse("BIZ_003", "Cannot deactivate your own account"))
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse(apperrors.CodeBusinessRule, "Cannot deactivate your own account"))
 		return
 	}
 
 	if err := h.service.Deactivate(c.Request.Context(), companyID, id); err != nil {
-		if err == domain.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("RES_001", "User not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to deactivate user")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"deactivated": true}))
 }
 
+// Lock handles POST /users/:id/lock
+func (h *UserHandler) Lock(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	currentUserID := appctx.GetUserID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid user ID"))
+		return
+	}
+
+	// Prevent self-lockout
+	if id == currentUserID {
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse(apperrors.CodeBusinessRule, "Cannot lock your own account"))
+		return
+	}
+
+	if err := h.service.Lock(c.Request.Context(), companyID, id); err != nil {
+		respondError(c, err, "Failed to lock user")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"locked": true}))
+}
+
+// Unlock handles POST /users/:id/unlock
+func (h *UserHandler) Unlock(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid user ID"))
+		return
+	}
+
+	if err := h.service.Unlock(c.Request.Context(), companyID, id); err != nil {
+		respondError(c, err, "Failed to unlock user")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"locked": false}))
+}
+
+// AssignRole handles PUT /users/:id/role
+func (h *UserHandler) AssignRole(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid user ID"))
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse("VAL_001", "Invalid request body", err))
+		return
+	}
+
+	if err := h.service.AssignRole(c.Request.Context(), companyID, id, domain.UserRole(req.Role)); err != nil {
+		respondError(c, err, "Failed to assign role")
+		return
+	}
+
+	user, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromUser(user)))
+}
+
+// ForcePasswordReset handles POST /users/:id/reset-password
+func (h *UserHandler) ForcePasswordReset(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VAL_004", "Invalid user ID"))
+		return
+	}
+
+	temporary, err := h.service.ForcePasswordReset(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to reset password")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.PasswordResetResponse{
+		TemporaryPassword:  temporary,
+		MustChangePassword: true,
+	}))
+}
+
 // GetStats handles GET /users/stats
 func (h *UserHandler) GetStats(c *gin.Context) {
 	companyID := appctx.GetCompanyID(c)
 
 	stats, err := h.service.GetStats(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SRV_001", err.Error()))
+		respondError(c, err, "Failed to get user statistics")
 		return
 	}
 
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// VoucherAnomalyHandler handles the review worklist of anomaly flags
+type VoucherAnomalyHandler struct {
+	service service.VoucherAnomalyService
+}
+
+// NewVoucherAnomalyHandler creates a new VoucherAnomalyHandler
+func NewVoucherAnomalyHandler(svc service.VoucherAnomalyService) *VoucherAnomalyHandler {
+	return &VoucherAnomalyHandler{service: svc}
+}
+
+// RegisterRoutes registers anomaly flag routes
+func (h *VoucherAnomalyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	flags := r.Group("/anomaly-flags")
+	{
+		flags.GET("", h.List)
+		flags.GET("/:id", h.Get)
+		flags.POST("/:id/review", h.Review)
+	}
+}
+
+// List handles GET /anomaly-flags.
+// Open flags are listed unless another status, or "all", is asked for.
+func (h *VoucherAnomalyHandler) List(c *gin.Context) {
+	open := domain.AnomalyFlagOpen
+	filter := repository.VoucherAnomalyFilter{
+		CompanyID: appctx.GetCompanyID(c),
+		Status:    &open,
+		Page:      1,
+		PageSize:  20,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("page_size")); err == nil && pageSize > 0 && pageSize <= 100 {
+		filter.PageSize = pageSize
+	}
+	switch status := c.Query("status"); status {
+	case "":
+	case "all":
+		filter.Status = nil
+	default:
+		s := domain.AnomalyFlagStatus(status)
+		if !s.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid anomaly flag status"))
+			return
+		}
+		filter.Status = &s
+	}
+	if rule := c.Query("rule"); rule != "" {
+		r := domain.AnomalyRule(rule)
+		if !r.IsValid() {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid anomaly rule"))
+			return
+		}
+		filter.Rule = &r
+	}
+	if accountID := c.Query("account_id"); accountID != "" {
+		id, err := uuid.Parse(accountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid account ID"))
+			return
+		}
+		filter.AccountID = &id
+	}
+
+	flags, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to list anomaly flags")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		flags,
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       filter.Page,
+			PageSize:   filter.PageSize,
+			TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+		},
+	))
+}
+
+// Get handles GET /anomaly-flags/:id
+func (h *VoucherAnomalyHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid anomaly flag ID")
+	if !ok {
+		return
+	}
+
+	flag, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get anomaly flag")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(flag))
+}
+
+// Review handles POST /anomaly-flags/:id/review
+func (h *VoucherAnomalyHandler) Review(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id", "Invalid anomaly flag ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewAnomalyFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	flag, err := h.service.Review(c.Request.Context(), appctx.GetCompanyID(c), appctx.GetUserID(c), id,
+		domain.AnomalyFlagStatus(req.Status), req.Note)
+	if err != nil {
+		respondError(c, err, "Failed to review anomaly flag")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(flag))
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// VoucherAttachmentHandler handles HTTP requests for voucher attachments
+type VoucherAttachmentHandler struct {
+	service     service.VoucherAttachmentService
+	maxFileSize int64
+}
+
+// NewVoucherAttachmentHandler creates a new VoucherAttachmentHandler
+func NewVoucherAttachmentHandler(svc service.VoucherAttachmentService, maxFileSize int64) *VoucherAttachmentHandler {
+	return &VoucherAttachmentHandler{service: svc, maxFileSize: maxFileSize}
+}
+
+// RegisterRoutes registers voucher attachment routes
+func (h *VoucherAttachmentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	attachments := r.Group("/vouchers/:id/attachments")
+	{
+		attachments.GET("", h.List)
+		attachments.POST("", h.Upload)
+		attachments.GET("/:attachment_id", h.Download)
+		attachments.GET("/:attachment_id/thumbnail", h.Thumbnail)
+		attachments.DELETE("/:attachment_id", h.Delete)
+	}
+}
+
+// List returns the attachments of a voucher
+// @Summary List voucher attachments
+// @Tags vouchers
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Success 200 {object} dto.Response{data=[]dto.VoucherAttachmentResponse}
+// @Router /vouchers/{id}/attachments [get]
+func (h *VoucherAttachmentHandler) List(c *gin.Context) {
+	voucherID, ok := parseUUIDParam(c, "id", "Invalid voucher ID")
+	if !ok {
+		return
+	}
+
+	attachments, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c), voucherID)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve attachments")
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherAttachments(attachments)))
+}
+
+// Upload attaches a file to a voucher after scanning it for malware
+// @Summary Upload voucher attachment
+// @Description Upload a supporting document: PDF, image, text, XML, Office or Hangul file. The type is
+// @Description checked against the content; files containing malware are quarantined and rejected.
+// @Description HEIC photos are stored as JPEG when a converter is configured, and images get a thumbnail.
+// @Tags vouchers
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param file formData file true "Attachment"
+// @Success 201 {object} dto.Response{data=dto.VoucherAttachmentResponse}
+// @Failure 400 {object} dto.Response
+// @Failure 413 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 503 {object} dto.Response
+// @Router /vouchers/{id}/attachments [post]
+func (h *VoucherAttachmentHandler) Upload(c *gin.Context) {
+	voucherID, ok := parseUUIDParam(c, "id", "Invalid voucher ID")
+	if !ok {
+		return
+	}
+
+	upload, err := h.readFile(c)
+	if err != nil {
+		if err == domain.ErrAttachmentTooLarge {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse(dto.ErrCodeValidation, err.Error()))
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Attachment file is required", err.Error()))
+		return
+	}
+	upload.CompanyID = appctx.GetCompanyID(c)
+	upload.VoucherID = voucherID
+	upload.UserID = appctx.GetUserID(c)
+	upload.IPAddress = c.ClientIP()
+
+	attachment, err := h.service.Upload(c.Request.Context(), upload)
+	if err != nil {
+		if respondPlanLimitExceeded(c, err) {
+			return
+		}
+		respondError(c, err, "Failed to upload attachment")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucherAttachment(attachment)))
+}
+
+// Download sends the content of an attachment
+// @Summary Download voucher attachment
+// @Tags vouchers
+// @Produce octet-stream
+// @Param id path string true "Voucher ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Router /vouchers/{id}/attachments/{attachment_id} [get]
+func (h *VoucherAttachmentHandler) Download(c *gin.Context) {
+	voucherID, ok := parseUUIDParam(c, "id", "Invalid voucher ID")
+	if !ok {
+		return
+	}
+	id, ok := parseUUIDParam(c, "attachment_id", "Invalid attachment ID")
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.Download(c.Request.Context(), appctx.GetCompanyID(c), voucherID, id, c.ClientIP())
+	if err != nil {
+		respondError(c, err, "Failed to download attachment")
+		return
+	}
+
+	contentType := attachment.FileType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, attachment.FileData)
+}
+
+// Thumbnail sends the JPEG preview of an image attachment
+// @Summary Voucher attachment thumbnail
+// @Tags vouchers
+// @Produce jpeg
+// @Param id path string true "Voucher ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.Response
+// @Router /vouchers/{id}/attachments/{attachment_id}/thumbnail [get]
+func (h *VoucherAttachmentHandler) Thumbnail(c *gin.Context) {
+	voucherID, ok := parseUUIDParam(c, "id", "Invalid voucher ID")
+	if !ok {
+		return
+	}
+	id, ok := parseUUIDParam(c, "attachment_id", "Invalid attachment ID")
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.Thumbnail(c.Request.Context(), appctx.GetCompanyID(c), voucherID, id)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve thumbnail")
+		return
+	}
+
+	// Attachments never change, so previews can be cached by the browser
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, "image/jpeg", attachment.ThumbnailData)
+}
+
+// Delete removes an attachment
+// @Summary Delete voucher attachment
+// @Tags vouchers
+// @Param id path string true "Voucher ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Success 204
+// @Router /vouchers/{id}/attachments/{attachment_id} [delete]
+func (h *VoucherAttachmentHandler) Delete(c *gin.Context) {
+	voucherID, ok := parseUUIDParam(c, "id", "Invalid voucher ID")
+	if !ok {
+		return
+	}
+	id, ok := parseUUIDParam(c, "attachment_id", "Invalid attachment ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), voucherID, id); err != nil {
+		respondError(c, err, "Failed to delete attachment")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// readFile reads the uploaded file, enforcing the size limit
+func (h *VoucherAttachmentHandler) readFile(c *gin.Context) (*service.AttachmentUpload, error) {
+	fileHeader, data, err := readUpload(c, h.maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	return &service.AttachmentUpload{
+		FileName:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Data:        data,
+	}, nil
+}
+
+// readUpload reads the multipart "file" of the request; files over
+// maxFileSize fail with domain.ErrAttachmentTooLarge
+func readUpload(c *gin.Context, maxFileSize int64) (*multipart.FileHeader, []byte, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, nil, err
+	}
+	if fileHeader.Size > maxFileSize {
+		return nil, nil, domain.ErrAttachmentTooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxFileSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(data)) > maxFileSize {
+		return nil, nil, domain.ErrAttachmentTooLarge
+	}
+	return fileHeader, data, nil
+}
+
+// parseUUIDParam parses a path parameter, responding 400 if it is not a UUID
+func parseUUIDParam(c *gin.Context, name, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, message))
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+	"github.com/saintgo7/saas-kerp/internal/pii"
+)
+
+// voucherExportFlushInterval is the number of vouchers written between flushes of the response
+const voucherExportFlushInterval = 100
+
+// voucherExportColumns are the CSV columns; each row is one entry with its voucher's fields
+var voucherExportColumns = []string{
+	"voucher_no", "voucher_date", "voucher_type", "status", "voucher_description",
+	"line_no", "account_code", "account_name", "debit_amount", "credit_amount", "description",
+	"partner_id", "department_id", "project_id", "cost_center_id", "tax_code_id", "tax_amount",
+	"voucher_tags",
+}
+
+// voucherExportWriter writes exported vouchers to the response body
+type voucherExportWriter interface {
+	ContentType() string
+	Write(voucher *domain.Voucher) error
+	// Flush writes buffered output to the underlying writer
+	Flush() error
+}
+
+func newVoucherExportWriter(format string, w io.Writer, loc i18n.Locale) voucherExportWriter {
+	if format == dto.VoucherExportFormatNDJSON {
+		return &ndjsonVoucherExportWriter{enc: json.NewEncoder(w), loc: loc}
+	}
+	return &csvVoucherExportWriter{out: w, w: csv.NewWriter(w), record: make([]string, len(voucherExportColumns))}
+}
+
+// csvVoucherExportWriter writes one row per entry. The UTF-8 BOM and header row
+// are written with the first voucher so that nothing is sent before the first
+// query has succeeded.
+type csvVoucherExportWriter struct {
+	out     io.Writer
+	w       *csv.Writer
+	started bool
+	record  []string
+}
+
+func (e *csvVoucherExportWriter) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (e *csvVoucherExportWriter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	if _, err := io.WriteString(e.out, "\ufeff"); err != nil {
+		return err
+	}
+	return e.w.Write(voucherExportColumns)
+}
+
+func (e *csvVoucherExportWriter) Write(voucher *domain.Voucher) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	e.record[0] = voucher.VoucherNo
+	e.record[1] = voucher.VoucherDate.Format("2006-01-02")
+	e.record[2] = string(voucher.VoucherType)
+	e.record[3] = string(voucher.Status)
+	e.record[4] = voucher.Description
+	e.record[17] = strings.Join(voucher.Tags, domain.VoucherTagSeparator)
+	for i := range voucher.Entries {
+		entry := &voucher.Entries[i]
+		e.record[5] = strconv.Itoa(entry.LineNo)
+		e.record[6], e.record[7] = "", ""
+		if entry.Account != nil {
+			e.record[6], e.record[7] = entry.Account.Code, entry.Account.Name
+		}
+		e.record[8] = formatExportAmount(entry.DebitAmount)
+		e.record[9] = formatExportAmount(entry.CreditAmount)
+		e.record[10] = entry.Description
+		e.record[11] = formatExportID(entry.PartnerID)
+		e.record[12] = formatExportID(entry.DepartmentID)
+		e.record[13] = formatExportID(entry.ProjectID)
+		e.record[14] = formatExportID(entry.CostCenterID)
+		e.record[15] = formatExportID(entry.TaxCodeID)
+		e.record[16] = formatExportAmount(entry.TaxAmount)
+		if err := e.w.Write(e.record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvVoucherExportWriter) Flush() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonVoucherExportWriter writes one voucher with its entries per line
+type ndjsonVoucherExportWriter struct {
+	enc *json.Encoder
+	loc i18n.Locale
+}
+
+func (e *ndjsonVoucherExportWriter) ContentType() string { return "application/x-ndjson" }
+
+func (e *ndjsonVoucherExportWriter) Write(voucher *domain.Voucher) error {
+	return e.enc.Encode(dto.FromVoucher(voucher, e.loc))
+}
+
+func (e *ndjsonVoucherExportWriter) Flush() error { return nil }
+
+// maskExportPII tells whether an export masks personal data: always for
+// non-admins, and for admins who ask for it
+func maskExportPII(c *gin.Context, requested bool) bool {
+	return requested || !appctx.HasRole(c, "admin")
+}
+
+// maskVoucherPII masks the personal data in the descriptions of a voucher and its entries
+func maskVoucherPII(voucher *domain.Voucher) {
+	voucher.Description = pii.MaskText(voucher.Description)
+	for i := range voucher.Entries {
+		voucher.Entries[i].Description = pii.MaskText(voucher.Entries[i].Description)
+	}
+}
+
+func formatExportAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+func formatExportID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
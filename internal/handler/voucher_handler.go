@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/dto"
 	"github.com/saintgo7/saas-kerp/internal/repository"
@@ -15,12 +19,17 @@ import (
 
 // VoucherHandler handles HTTP requests for vouchers
 type VoucherHandler struct {
-	service service.VoucherService
+	service    service.VoucherService
+	signatures service.VoucherSignatureService
+	approvals  service.ApprovalNotifier // may be nil
+	shortcuts  service.ShortcutRecorder // may be nil
 }
 
-// NewVoucherHandler creates a new VoucherHandler
-func NewVoucherHandler(service service.VoucherService) *VoucherHandler {
-	return &VoucherHandler{service: service}
+// NewVoucherHandler creates a new VoucherHandler; approvals notifies the
+// approvers of submitted vouchers and shortcuts records the accounts and
+// partners users enter
+func NewVoucherHandler(service service.VoucherService, signatures service.VoucherSignatureService, approvals service.ApprovalNotifier, shortcuts service.ShortcutRecorder) *VoucherHandler {
+	return &VoucherHandler{service: service, signatures: signatures, approvals: approvals, shortcuts: shortcuts}
 }
 
 // RegisterRoutes registers voucher routes
@@ -29,6 +38,8 @@ func (h *VoucherHandler) RegisterRoutes(r *gin.RouterGroup) {
 	{
 		vouchers.GET("", h.List)
 		vouchers.GET("/pending", h.GetPending)
+		vouchers.GET("/scheduled", h.GetScheduled)
+		vouchers.GET("/export", h.Export)
 		vouchers.GET("/:id", h.GetByID)
 		vouchers.GET("/no/:voucher_no", h.GetByNo)
 		vouchers.POST("", h.Create)
@@ -43,8 +54,16 @@ func (h *VoucherHandler) RegisterRoutes(r *gin.RouterGroup) {
 		vouchers.POST("/:id/approve", h.Approve)
 		vouchers.POST("/:id/reject", h.Reject)
 		vouchers.POST("/:id/post", h.Post)
+		vouchers.POST("/:id/schedule", h.Schedule)
+		vouchers.DELETE("/:id/schedule", h.Unschedule)
 		vouchers.POST("/:id/cancel", h.Cancel)
 		vouchers.POST("/:id/reverse", h.Reverse)
+		vouchers.POST("/:id/reverse-partial", h.PartialReverse)
+		vouchers.POST("/:id/correct", h.Correct)
+		vouchers.PUT("/:id/tags", h.SetTags)
+
+		// Approval signatures
+		vouchers.GET("/:id/signatures", h.GetSignatures)
 	}
 }
 
@@ -94,7 +113,10 @@ func (h *VoucherHandler) List(c *gin.Context) {
 
 	var req dto.VoucherListRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid query parameters", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if !checkVoucherListRequest(c, &req) {
 		return
 	}
 
@@ -106,7 +128,117 @@ func (h *VoucherHandler) List(c *gin.Context) {
 		req.PageSize = 20
 	}
 
-	// Build filter
+	filter := voucherFilterFromRequest(companyID, &req)
+
+	vouchers, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve vouchers")
+		return
+	}
+
+	totalPages := int(total) / req.PageSize
+	if int(total)%req.PageSize > 0 {
+		totalPages++
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(
+		dto.FromVouchers(vouchers, appctx.GetLocale(c)),
+		&dto.MetaInfo{
+			Total:      total,
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalPages: totalPages,
+		},
+	))
+}
+
+// Export streams vouchers matching the list filters
+// @Summary Export vouchers
+// @Description Stream vouchers with their entries as CSV (one row per entry) or NDJSON (one voucher per line)
+// @Tags vouchers
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default) or ndjson"
+// @Param mask_pii query bool false "Mask emails, phone numbers and business numbers in descriptions; always on for non-admins"
+// @Success 200 {file} file
+// @Router /api/v1/vouchers/export [get]
+func (h *VoucherHandler) Export(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.VoucherExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid query parameters", err))
+		return
+	}
+	if !checkVoucherListRequest(c, &req.VoucherListRequest) {
+		return
+	}
+	format := req.Format
+	if format == "" {
+		format = dto.VoucherExportFormatCSV
+	}
+
+	filter := voucherFilterFromRequest(companyID, &req.VoucherListRequest)
+	filter.IncludeEntries = true
+	mask := maskExportPII(c, req.MaskPII)
+
+	w := newVoucherExportWriter(format, c.Writer, appctx.GetLocale(c))
+	c.Header("Content-Type", w.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "vouchers-"+time.Now().Format("20060102")+"."+format))
+	c.Header("Cache-Control", "no-store")
+	// Keep reverse proxies from buffering the whole export
+	c.Header("X-Accel-Buffering", "no")
+
+	written := 0
+	err := h.service.Export(c.Request.Context(), filter, func(voucher *domain.Voucher) error {
+		if mask {
+			maskVoucherPII(voucher)
+		}
+		if err := w.Write(voucher); err != nil {
+			return err
+		}
+		written++
+		if written%voucherExportFlushInterval == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to export vouchers"))
+		return
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		// The status line has been sent; the client gets a truncated body
+		_ = c.Error(err)
+		return
+	}
+	c.Writer.Flush()
+}
+
+// checkVoucherListRequest validates list query parameters that binding cannot
+// and responds with an error if they are invalid
+func checkVoucherListRequest(c *gin.Context, req *dto.VoucherListRequest) bool {
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "min_amount must not be greater than max_amount"))
+		return false
+	}
+	return true
+}
+
+// voucherFilterFromRequest builds the repository filter of list query parameters;
+// malformed dates and IDs are ignored
+func voucherFilterFromRequest(companyID uuid.UUID, req *dto.VoucherListRequest) repository.VoucherFilter {
 	filter := repository.VoucherFilter{
 		CompanyID:      companyID,
 		SearchTerm:     req.Search,
@@ -117,13 +249,11 @@ func (h *VoucherHandler) List(c *gin.Context) {
 		SortDesc:       req.SortDesc,
 	}
 
-	if req.VoucherType != "" {
-		voucherType := domain.VoucherType(req.VoucherType)
-		filter.VoucherType = &voucherType
+	for _, voucherType := range req.VoucherType {
+		filter.VoucherTypes = append(filter.VoucherTypes, domain.VoucherType(voucherType))
 	}
-	if req.Status != "" {
-		status := domain.VoucherStatus(req.Status)
-		filter.Status = &status
+	for _, status := range req.Status {
+		filter.Statuses = append(filter.Statuses, domain.VoucherStatus(status))
 	}
 	if req.DateFrom != "" {
 		dateFrom, err := time.Parse("2006-01-02", req.DateFrom)
@@ -137,6 +267,21 @@ func (h *VoucherHandler) List(c *gin.Context) {
 			filter.DateTo = &dateTo
 		}
 	}
+	filter.MinAmount = req.MinAmount
+	filter.MaxAmount = req.MaxAmount
+	if req.CreatedBy != "" {
+		createdBy, err := uuid.Parse(req.CreatedBy)
+		if err == nil {
+			filter.CreatedBy = &createdBy
+		}
+	}
+	if req.ApprovedBy != "" {
+		approvedBy, err := uuid.Parse(req.ApprovedBy)
+		if err == nil {
+			filter.ApprovedBy = &approvedBy
+		}
+	}
+	filter.VoucherNoPrefix = req.VoucherNoPrefix
 	if req.AccountID != "" {
 		accountID, err := uuid.Parse(req.AccountID)
 		if err == nil {
@@ -155,27 +300,9 @@ func (h *VoucherHandler) List(c *gin.Context) {
 			filter.DepartmentID = &deptID
 		}
 	}
+	filter.Tags = req.Tags
 
-	vouchers, total, err := h.service.List(c.Request.Context(), filter)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve vouchers"))
-		return
-	}
-
-	totalPages := int(total) / req.PageSize
-	if int(total)%req.PageSize > 0 {
-		totalPages++
-	}
-
-	c.JSON(http.StatusOK, dto.SuccessWithMeta(
-		dto.FromVouchers(vouchers),
-		&dto.MetaInfo{
-			Total:      total,
-			Page:       req.Page,
-			PageSize:   req.PageSize,
-			TotalPages: totalPages,
-		},
-	))
+	return filter
 }
 
 // GetPending returns vouchers pending approval
@@ -194,16 +321,38 @@ func (h *VoucherHandler) GetPending(c *gin.Context) {
 
 	vouchers, err := h.service.GetPending(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve pending vouchers"))
+		respondError(c, err, "Failed to retrieve pending vouchers")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVouchers(vouchers)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVouchers(vouchers, appctx.GetLocale(c))))
+}
+
+// GetScheduled returns the vouchers scheduled for posting
+// @Summary Get scheduled vouchers
+// @Description Get the approved vouchers waiting for their scheduled posting, next due first
+// @Tags vouchers
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /api/v1/vouchers/scheduled [get]
+func (h *VoucherHandler) GetScheduled(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	vouchers, err := h.service.GetScheduled(c.Request.Context(), companyID)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve scheduled vouchers")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVouchers(vouchers, appctx.GetLocale(c))))
 }
 
 // GetByID returns a voucher by ID
 // @Summary Get voucher by ID
-// @Description Get a single voucher by its ID
+// @Description Get a single voucher by its ID with its reversal links, workflow users and status history
 // @Tags vouchers
 // @Accept json
 // @Produce json
@@ -222,17 +371,13 @@ func (h *VoucherHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	voucher, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	voucher, err := h.service.GetDetail(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrVoucherNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve voucher"))
+		respondError(c, err, "Failed to retrieve voucher")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // GetByNo returns a voucher by voucher number
@@ -258,25 +403,22 @@ func (h *VoucherHandler) GetByNo(c *gin.Context) {
 
 	voucher, err := h.service.GetByNo(c.Request.Context(), companyID, voucherNo)
 	if err != nil {
-		if err == domain.ErrVoucherNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve voucher"))
+		respondError(c, err, "Failed to retrieve voucher")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Create creates a new voucher
 // @Summary Create voucher
-// @Description Create a new voucher with entries
+// @Description Create a new voucher with entries. Suspected duplicates are listed in the response, or block the voucher when the company's duplicate check is set to block.
 // @Tags vouchers
 // @Accept json
 // @Produce json
 // @Param voucher body dto.CreateVoucherRequest true "Voucher data"
 // @Success 201 {object} dto.Response
+// @Failure 409 {object} dto.Response
 // @Router /api/v1/vouchers [post]
 func (h *VoucherHandler) Create(c *gin.Context) {
 	companyID, ok := h.getCompanyID(c)
@@ -290,7 +432,7 @@ func (h *VoucherHandler) Create(c *gin.Context) {
 
 	var req dto.CreateVoucherRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
@@ -301,26 +443,14 @@ func (h *VoucherHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.service.Create(c.Request.Context(), voucher); err != nil {
-		switch err {
-		case domain.ErrVoucherUnbalanced:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Debit and credit must be equal"))
-		case domain.ErrVoucherNoEntries:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Voucher must have at least one entry"))
-		case domain.ErrEntryInvalidAmount:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Entry must have either debit or credit, not both"))
-		case domain.ErrEntryZeroAmount:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Entry amount must be greater than zero"))
-		case domain.ErrControlAccountPosting:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Cannot post to control account"))
-		case domain.ErrAccountNotFound:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Account not found"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to create voucher"))
-		}
+		respondMappedError(c, referenceErrors, err, "Failed to create voucher")
 		return
 	}
+	if h.shortcuts != nil {
+		h.shortcuts.RecordVoucher(c.Request.Context(), userID, voucher)
+	}
 
-	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Update updates an existing voucher
@@ -351,18 +481,14 @@ func (h *VoucherHandler) Update(c *gin.Context) {
 
 	var req dto.UpdateVoucherRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
 	// Get existing voucher
 	voucher, err := h.service.GetByID(c.Request.Context(), companyID, id)
 	if err != nil {
-		if err == domain.ErrVoucherNotFound {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to retrieve voucher"))
+		respondError(c, err, "Failed to retrieve voucher")
 		return
 	}
 
@@ -375,6 +501,10 @@ func (h *VoucherHandler) Update(c *gin.Context) {
 	voucher.VoucherDate = voucherDate
 	voucher.Description = req.Description
 	voucher.ReferenceType = req.ReferenceType
+	voucher.AutoReverse = req.AutoReverse
+	if req.Tags != nil {
+		voucher.Tags = req.Tags
+	}
 	voucher.UpdatedBy = &userID
 
 	if req.ReferenceID != "" {
@@ -389,11 +519,7 @@ func (h *VoucherHandler) Update(c *gin.Context) {
 	}
 
 	if err := h.service.Update(c.Request.Context(), voucher); err != nil {
-		if err == domain.ErrVoucherCannotEdit {
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be edited in current status"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to update voucher"))
+		respondError(c, err, "Failed to update voucher")
 		return
 	}
 
@@ -410,21 +536,17 @@ func (h *VoucherHandler) Update(c *gin.Context) {
 		}
 
 		if err := h.service.ReplaceEntries(c.Request.Context(), id, entries); err != nil {
-			switch err {
-			case domain.ErrVoucherUnbalanced:
-				c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Debit and credit must be equal"))
-			case domain.ErrVoucherCannotEdit:
-				c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be edited in current status"))
-			default:
-				c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to update entries"))
-			}
+			respondMappedError(c, referenceErrors, err, "Failed to update entries")
 			return
 		}
 	}
 
 	// Reload voucher
 	voucher, _ = h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	if h.shortcuts != nil && len(req.Entries) > 0 {
+		h.shortcuts.RecordVoucher(c.Request.Context(), userID, voucher)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Delete removes a voucher
@@ -449,14 +571,7 @@ func (h *VoucherHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(c.Request.Context(), companyID, id); err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotEdit:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be deleted in current status"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to delete voucher"))
-		}
+		respondError(c, err, "Failed to delete voucher")
 		return
 	}
 
@@ -487,7 +602,7 @@ func (h *VoucherHandler) ReplaceEntries(c *gin.Context) {
 
 	var req []dto.CreateVoucherEntryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
@@ -502,26 +617,20 @@ func (h *VoucherHandler) ReplaceEntries(c *gin.Context) {
 	}
 
 	if err := h.service.ReplaceEntries(c.Request.Context(), id, entries); err != nil {
-		switch err {
-		case domain.ErrVoucherUnbalanced:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Debit and credit must be equal"))
-		case domain.ErrVoucherCannotEdit:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be edited in current status"))
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to replace entries"))
-		}
+		respondMappedError(c, referenceErrors, err, "Failed to replace entries")
 		return
 	}
 
 	voucher, _ := h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	if h.shortcuts != nil {
+		h.shortcuts.RecordVoucher(c.Request.Context(), appctx.GetUserID(c), voucher)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Submit submits a voucher for approval
 // @Summary Submit voucher for approval
-// @Description Submit a voucher for approval
+// @Description Submit a voucher for approval; vouchers covered by the approval exemption are posted
 // @Tags vouchers
 // @Accept json
 // @Produce json
@@ -545,23 +654,15 @@ func (h *VoucherHandler) Submit(c *gin.Context) {
 	}
 
 	if err := h.service.Submit(c.Request.Context(), companyID, id, userID); err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotSubmit:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be submitted in current status"))
-		case domain.ErrVoucherUnbalanced:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Voucher is not balanced"))
-		case domain.ErrVoucherNoEntries:
-			c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Voucher has no entries"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to submit voucher"))
-		}
+		respondError(c, err, "Failed to submit voucher")
 		return
 	}
 
 	voucher, _ := h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	if h.approvals != nil {
+		h.approvals.NotifySubmitted(c.Request.Context(), voucher)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Approve approves a voucher
@@ -571,6 +672,7 @@ func (h *VoucherHandler) Submit(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Voucher ID"
+// @Param body body dto.SignatureRequest false "Signature image and/or PIN confirmation"
 // @Success 200 {object} dto.Response
 // @Router /api/v1/vouchers/{id}/approve [post]
 func (h *VoucherHandler) Approve(c *gin.Context) {
@@ -589,20 +691,21 @@ func (h *VoucherHandler) Approve(c *gin.Context) {
 		return
 	}
 
+	signature, ok := h.verifySignature(c, companyID, userID, domain.SignatureActionApprove)
+	if !ok {
+		return
+	}
+
 	if err := h.service.Approve(c.Request.Context(), companyID, id, userID); err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotApprove:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be approved in current status"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to approve voucher"))
-		}
+		respondError(c, err, "Failed to approve voucher")
 		return
 	}
 
 	voucher, _ := h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	if !h.recordSignature(c, voucher, signature) {
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Reject rejects a voucher
@@ -633,24 +736,17 @@ func (h *VoucherHandler) Reject(c *gin.Context) {
 
 	var req dto.WorkflowActionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
 	if err := h.service.Reject(c.Request.Context(), companyID, id, userID, req.Reason); err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotReject:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be rejected in current status"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to reject voucher"))
-		}
+		respondError(c, err, "Failed to reject voucher")
 		return
 	}
 
 	voucher, _ := h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Post posts a voucher to the ledger
@@ -660,6 +756,7 @@ func (h *VoucherHandler) Reject(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Voucher ID"
+// @Param body body dto.SignatureRequest false "Signature image and/or PIN confirmation"
 // @Success 200 {object} dto.Response
 // @Router /api/v1/vouchers/{id}/post [post]
 func (h *VoucherHandler) Post(c *gin.Context) {
@@ -678,20 +775,224 @@ func (h *VoucherHandler) Post(c *gin.Context) {
 		return
 	}
 
+	signature, ok := h.verifySignature(c, companyID, userID, domain.SignatureActionPost)
+	if !ok {
+		return
+	}
+
 	if err := h.service.Post(c.Request.Context(), companyID, id, userID); err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotPost:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher cannot be posted in current status"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to post voucher"))
-		}
+		respondError(c, err, "Failed to post voucher")
 		return
 	}
 
 	voucher, _ := h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	if !h.recordSignature(c, voucher, signature) {
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
+}
+
+// Schedule schedules an approved voucher for posting on a future date
+// @Summary Schedule voucher posting
+// @Description Schedule an approved voucher to be posted by the worker on a future date, once its period is open; the posting signature is captured now
+// @Tags vouchers
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param body body dto.ScheduleVoucherRequest true "Posting date and signature"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/vouchers/{id}/schedule [post]
+func (h *VoucherHandler) Schedule(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	var req dto.ScheduleVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	postDate, err := time.Parse("2006-01-02", req.PostDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid posting date"))
+		return
+	}
+
+	signature, ok := h.verifySignatureRequest(c, companyID, userID, domain.SignatureActionPost, &req.SignatureRequest)
+	if !ok {
+		return
+	}
+
+	voucher, err := h.service.Schedule(c.Request.Context(), companyID, id, userID, postDate)
+	if err != nil {
+		respondError(c, err, "Failed to schedule voucher posting")
+		return
+	}
+
+	if !h.recordSignature(c, voucher, signature) {
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
+}
+
+// Unschedule cancels the scheduled posting of a voucher
+// @Summary Cancel scheduled posting
+// @Description Cancel the scheduled posting of a voucher before it is posted; the voucher stays approved
+// @Tags vouchers
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/vouchers/{id}/schedule [delete]
+func (h *VoucherHandler) Unschedule(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	voucher, err := h.service.Unschedule(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to cancel scheduled posting")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
+}
+
+// SetTags replaces the tags of a voucher
+// @Summary Set voucher tags
+// @Description Replace the tags of a voucher in any status; new tags are added to the company's tags
+// @Tags vouchers
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param request body dto.SetVoucherTagsRequest true "Tags"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/vouchers/{id}/tags [put]
+func (h *VoucherHandler) SetTags(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	var req dto.SetVoucherTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	voucher, err := h.service.SetTags(c.Request.Context(), companyID, id, userID, req.Tags)
+	if err != nil {
+		respondError(c, err, "Failed to update voucher tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
+}
+
+// GetSignatures returns the approval signatures captured on a voucher
+// @Summary Get voucher signatures
+// @Description Get the signature artifacts captured when the voucher was approved and posted
+// @Tags vouchers
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Success 200 {object} dto.Response
+// @Router /api/v1/vouchers/{id}/signatures [get]
+func (h *VoucherHandler) GetSignatures(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	voucher, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get voucher")
+		return
+	}
+
+	signatures, err := h.signatures.GetByVoucher(c.Request.Context(), companyID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to get voucher signatures"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherSignatures(signatures, voucher)))
+}
+
+// verifySignature binds the optional signature body and verifies it before an approve/post action
+func (h *VoucherHandler) verifySignature(c *gin.Context, companyID, userID uuid.UUID, action domain.SignatureAction) (*domain.VoucherSignature, bool) {
+	var req dto.SignatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return nil, false
+	}
+	return h.verifySignatureRequest(c, companyID, userID, action, &req)
+}
+
+// verifySignatureRequest verifies a signature bound with the rest of the request body
+func (h *VoucherHandler) verifySignatureRequest(c *gin.Context, companyID, userID uuid.UUID, action domain.SignatureAction, req *dto.SignatureRequest) (*domain.VoucherSignature, bool) {
+	image, err := req.DecodeImage()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Signature image must be base64-encoded"))
+		return nil, false
+	}
+
+	signature, err := h.signatures.Verify(c.Request.Context(), companyID, userID, action, &service.SignatureInput{
+		ImageData: image,
+		PIN:       req.PIN,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		respondError(c, err, "Failed to verify signature")
+		return nil, false
+	}
+	return signature, true
+}
+
+// recordSignature stores a verified signature once the action has succeeded
+func (h *VoucherHandler) recordSignature(c *gin.Context, voucher *domain.Voucher, signature *domain.VoucherSignature) bool {
+	if signature == nil || voucher == nil {
+		return true
+	}
+	if err := h.signatures.Record(c.Request.Context(), voucher, signature); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to store signature"))
+		return false
+	}
+	return true
 }
 
 // Cancel cancels a voucher
@@ -716,19 +1017,12 @@ func (h *VoucherHandler) Cancel(c *gin.Context) {
 	}
 
 	if err := h.service.Cancel(c.Request.Context(), companyID, id); err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotCancel:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Posted vouchers cannot be cancelled, use reversal instead"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to cancel voucher"))
-		}
+		respondError(c, err, "Failed to cancel voucher")
 		return
 	}
 
 	voucher, _ := h.service.GetByID(c.Request.Context(), companyID, id)
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher)))
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucher(voucher, appctx.GetLocale(c))))
 }
 
 // Reverse creates a reversal voucher
@@ -759,7 +1053,7 @@ func (h *VoucherHandler) Reverse(c *gin.Context) {
 
 	var req dto.ReverseVoucherRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid request body", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
 		return
 	}
 
@@ -771,18 +1065,136 @@ func (h *VoucherHandler) Reverse(c *gin.Context) {
 
 	reversal, err := h.service.Reverse(c.Request.Context(), companyID, id, userID, reversalDate, req.Description)
 	if err != nil {
-		switch err {
-		case domain.ErrVoucherNotFound:
-			c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
-		case domain.ErrVoucherCannotReverse:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Only posted vouchers can be reversed"))
-		case domain.ErrVoucherAlreadyReversed:
-			c.JSON(http.StatusConflict, dto.ErrorResponse(dto.ErrCodeConflict, "Voucher has already been reversed"))
-		default:
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(dto.ErrCodeInternalServerError, "Failed to create reversal voucher"))
+		respondError(c, err, "Failed to create reversal voucher")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucher(reversal, appctx.GetLocale(c))))
+}
+
+// PartialReverse creates a reversal of a share of a posted voucher
+// @Summary Partially reverse voucher
+// @Description Create a reversal of a ratio of every entry or of entry amounts of a posted voucher, such as returned goods; a ratio takes precedence over lines
+// @Tags vouchers
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param body body dto.PartialReverseVoucherRequest true "Partial reversal details"
+// @Success 201 {object} dto.Response
+// @Router /api/v1/vouchers/{id}/reverse-partial [post]
+func (h *VoucherHandler) PartialReverse(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	var req dto.PartialReverseVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	reversalDate, err := time.Parse("2006-01-02", req.ReversalDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid reversal date"))
+		return
+	}
+
+	reversal, err := h.service.PartialReverse(c.Request.Context(), companyID, id, userID, reversalDate, req.Description, req.Ratio, req.ToLines())
+	if err != nil {
+		respondError(c, err, "Failed to create reversal voucher")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucher(reversal, appctx.GetLocale(c))))
+}
+
+// Correct reverses a posted voucher and creates the corrected voucher replacing it
+// @Summary Correct voucher
+// @Description Reverse a posted voucher and create a draft replacing it (대체 전표) in one step; without entries the draft copies the original's
+// @Tags vouchers
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param body body dto.CorrectVoucherRequest true "Correction details"
+// @Success 201 {object} dto.Response
+// @Router /api/v1/vouchers/{id}/correct [post]
+func (h *VoucherHandler) Correct(c *gin.Context) {
+	companyID, ok := h.getCompanyID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid voucher ID"))
+		return
+	}
+
+	var req dto.CorrectVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	correctionDate, err := time.Parse("2006-01-02", req.CorrectionDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Invalid correction date"))
+		return
+	}
+
+	var entries []domain.VoucherEntry
+	for _, entryReq := range req.Entries {
+		entry, err := entryReq.ToEntry(companyID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Invalid entry data", err.Error()))
+			return
 		}
+		entries = append(entries, *entry)
+	}
+
+	correction, err := h.service.Correct(c.Request.Context(), companyID, id, userID, correctionDate, req.Description, entries)
+	if err != nil {
+		respondMappedError(c, referenceErrors, err, "Failed to correct voucher")
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucher(reversal)))
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucherCorrection(correction, appctx.GetLocale(c))))
+}
+
+// respondPostingRuleViolation writes a validation error if err is an account posting rule violation
+func respondPostingRuleViolation(c *gin.Context, err error) bool {
+	var violation *domain.PostingRuleViolation
+	if !errors.As(err, &violation) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, dto.ErrorResponseWithDetails(dto.ErrCodeValidation, "Entry violates account posting rule", violation.Error()))
+	return true
+}
+
+// respondDuplicateVoucher responds with the suspected duplicates if the voucher
+// was blocked by the duplicate check
+func respondDuplicateVoucher(c *gin.Context, err error) bool {
+	var duplicate *domain.DuplicateVoucherError
+	if !errors.As(err, &duplicate) {
+		return false
+	}
+	resp := dto.ErrorResponseWithDetails(dto.ErrCodeConflict, "Voucher duplicates an existing voucher", duplicate.Error())
+	resp.Data = dto.FromVoucherSummaries(duplicate.Duplicates, appctx.GetLocale(c))
+	c.JSON(http.StatusConflict, resp)
+	return true
 }
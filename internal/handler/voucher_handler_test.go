@@ -2,9 +2,12 @@ package handler
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +20,8 @@ import (
 	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/dto"
 	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
 )
 
 type VoucherHandlerTestSuite struct {
@@ -24,6 +29,7 @@ type VoucherHandlerTestSuite struct {
 	router    *gin.Engine
 	handler   *VoucherHandler
 	mockSvc   *mocks.MockVoucherService
+	mockSig   *mocks.MockVoucherSignatureService
 	companyID uuid.UUID
 	userID    uuid.UUID
 }
@@ -36,7 +42,8 @@ func (s *VoucherHandlerTestSuite) SetupTest() {
 	gin.SetMode(gin.TestMode)
 
 	s.mockSvc = new(mocks.MockVoucherService)
-	s.handler = NewVoucherHandler(s.mockSvc)
+	s.mockSig = new(mocks.MockVoucherSignatureService)
+	s.handler = NewVoucherHandler(s.mockSvc, s.mockSig, nil, nil)
 	s.companyID = uuid.New()
 	s.userID = uuid.New()
 
@@ -130,6 +137,41 @@ func (s *VoucherHandlerTestSuite) TestList_WithFilters() {
 	assert.Equal(s.T(), http.StatusOK, w.Code)
 }
 
+func (s *VoucherHandlerTestSuite) TestList_WithExtendedFilters() {
+	creatorID := uuid.New()
+
+	s.mockSvc.On("List", mock.Anything, mock.MatchedBy(func(f repository.VoucherFilter) bool {
+		return len(f.Statuses) == 2 && f.Statuses[0] == domain.VoucherStatusApproved && f.Statuses[1] == domain.VoucherStatusPosted &&
+			len(f.VoucherTypes) == 1 && f.VoucherTypes[0] == domain.VoucherTypeSales &&
+			f.MinAmount != nil && *f.MinAmount == 1000 && f.MaxAmount != nil && *f.MaxAmount == 50000 &&
+			f.CreatedBy != nil && *f.CreatedBy == creatorID &&
+			f.VoucherNoPrefix == "SAL-2024"
+	})).Return([]domain.Voucher{}, int64(0), nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/v1/vouchers?status=approved&status=posted&voucher_type=sales"+
+		"&min_amount=1000&max_amount=50000&created_by="+creatorID.String()+"&voucher_no_prefix=SAL-2024", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	s.mockSvc.AssertExpectations(s.T())
+}
+
+func (s *VoucherHandlerTestSuite) TestList_InvalidFilters() {
+	for _, query := range []string{
+		"status=draft&status=unknown",
+		"min_amount=5000&max_amount=1000",
+		"min_amount=-1",
+		"created_by=not-a-uuid",
+	} {
+		req := httptest.NewRequest("GET", "/api/v1/vouchers?"+query, nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(s.T(), http.StatusBadRequest, w.Code, query)
+	}
+}
+
 func (s *VoucherHandlerTestSuite) TestList_NoCompanyID() {
 	// Create router without company_id
 	router := gin.New()
@@ -164,6 +206,105 @@ func (s *VoucherHandlerTestSuite) TestGetPending_Success() {
 	assert.Equal(s.T(), http.StatusOK, w.Code)
 }
 
+// =============================================================================
+// GET /vouchers/export Tests
+// =============================================================================
+
+// exportVouchers makes the mocked Export call fn with the vouchers
+func exportVouchers(vouchers ...*domain.Voucher) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(2).(func(*domain.Voucher) error)
+		for _, v := range vouchers {
+			if err := fn(v); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *VoucherHandlerTestSuite) TestExport_CSV() {
+	voucher := s.newTestVoucher()
+	voucher.Tags = []string{"audit-adjustment", "covid-subsidy"}
+
+	s.mockSvc.On("Export", mock.Anything, mock.MatchedBy(func(f repository.VoucherFilter) bool {
+		return f.CompanyID == s.companyID && f.IncludeEntries &&
+			len(f.Statuses) == 1 && f.Statuses[0] == domain.VoucherStatusPosted &&
+			len(f.Tags) == 1 && f.Tags[0] == "audit-adjustment"
+	}), mock.Anything).Run(exportVouchers(voucher)).Return(nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/v1/vouchers/export?status=posted&tags=audit-adjustment", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(s.T(), w.Header().Get("Content-Disposition"), ".csv")
+
+	body := w.Body.String()
+	assert.True(s.T(), strings.HasPrefix(body, "\ufeffvoucher_no,voucher_date,"))
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(body, "\ufeff"))).ReadAll()
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), records, 3) // header and one row per entry
+	assert.Equal(s.T(), "GEN-2024-0001", records[1][0])
+	assert.Equal(s.T(), "1000", records[1][8])
+	assert.Equal(s.T(), "1000", records[2][9])
+	assert.Equal(s.T(), "audit-adjustment;covid-subsidy", records[1][17])
+	s.mockSvc.AssertExpectations(s.T())
+}
+
+func (s *VoucherHandlerTestSuite) TestExport_NDJSON() {
+	first := s.newTestVoucher()
+	second := s.newTestVoucher()
+	second.VoucherNo = "GEN-2024-0002"
+
+	s.mockSvc.On("Export", mock.Anything, mock.Anything, mock.Anything).Run(exportVouchers(first, second)).Return(nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/v1/vouchers/export?format=ndjson", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	assert.Len(s.T(), lines, 2)
+	var resp dto.VoucherResponse
+	assert.NoError(s.T(), json.Unmarshal([]byte(lines[1]), &resp))
+	assert.Equal(s.T(), "GEN-2024-0002", resp.VoucherNo)
+	assert.Len(s.T(), resp.Entries, 2)
+}
+
+func (s *VoucherHandlerTestSuite) TestExport_EmptyCSVHasHeader() {
+	s.mockSvc.On("Export", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/v1/vouchers/export", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.True(s.T(), strings.HasPrefix(w.Body.String(), "\ufeffvoucher_no,"))
+}
+
+func (s *VoucherHandlerTestSuite) TestExport_InvalidFormat() {
+	req := httptest.NewRequest("GET", "/api/v1/vouchers/export?format=xml", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *VoucherHandlerTestSuite) TestExport_ErrorBeforeFirstVoucher() {
+	s.mockSvc.On("Export", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+
+	req := httptest.NewRequest("GET", "/api/v1/vouchers/export", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "application/json")
+	assert.Empty(s.T(), w.Header().Get("Content-Disposition"))
+}
+
 // =============================================================================
 // GET /vouchers/:id Tests
 // =============================================================================
@@ -171,7 +312,7 @@ func (s *VoucherHandlerTestSuite) TestGetPending_Success() {
 func (s *VoucherHandlerTestSuite) TestGetByID_Success() {
 	voucher := s.newTestVoucher()
 
-	s.mockSvc.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(voucher, nil)
+	s.mockSvc.On("GetDetail", mock.Anything, mock.Anything, mock.Anything).Return(voucher, nil)
 
 	req := httptest.NewRequest("GET", "/api/v1/vouchers/"+voucher.ID.String(), nil)
 	w := httptest.NewRecorder()
@@ -185,10 +326,52 @@ func (s *VoucherHandlerTestSuite) TestGetByID_Success() {
 	assert.True(s.T(), resp.Success)
 }
 
+func (s *VoucherHandlerTestSuite) TestGetByID_WithLinksAndHistory() {
+	voucher := s.newTestVoucher()
+	voucher.Status = domain.VoucherStatusPosted
+	approvedAt := time.Now()
+	voucher.ApprovedAt = &approvedAt
+	voucher.ApprovedBy = &s.userID
+	voucher.Approver = &domain.User{Name: "김승인"}
+	reversal := s.newTestVoucher()
+	reversal.VoucherNo = "GEN-2024-0002"
+	voucher.ReversedByID = &reversal.ID
+	voucher.ReversedBy = reversal
+	voucher.StatusHistory = []domain.VoucherStatusChange{
+		{ToStatus: domain.VoucherStatusDraft, ChangedAt: voucher.CreatedAt},
+		{FromStatus: domain.VoucherStatusPending, ToStatus: domain.VoucherStatusApproved, ChangedBy: &s.userID,
+			User: voucher.Approver, ChangedAt: approvedAt},
+	}
+
+	s.mockSvc.On("GetDetail", mock.Anything, s.companyID, voucher.ID).Return(voucher, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/vouchers/"+voucher.ID.String(), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var resp struct {
+		Data dto.VoucherResponse `json:"data"`
+	}
+	assert.NoError(s.T(), json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.NotNil(s.T(), resp.Data.ApprovedBy) {
+		assert.Equal(s.T(), "김승인", resp.Data.ApprovedBy.Name)
+	}
+	if assert.NotNil(s.T(), resp.Data.ReversedBy) {
+		assert.Equal(s.T(), "GEN-2024-0002", resp.Data.ReversedBy.VoucherNo)
+	}
+	assert.Nil(s.T(), resp.Data.ReversalOf)
+	if assert.Len(s.T(), resp.Data.StatusHistory, 2) {
+		assert.Equal(s.T(), "approved", resp.Data.StatusHistory[1].ToStatus)
+		assert.Equal(s.T(), "김승인", resp.Data.StatusHistory[1].ChangedBy.Name)
+	}
+}
+
 func (s *VoucherHandlerTestSuite) TestGetByID_NotFound() {
 	voucherID := uuid.New()
 
-	s.mockSvc.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrVoucherNotFound)
+	s.mockSvc.On("GetDetail", mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrVoucherNotFound)
 
 	req := httptest.NewRequest("GET", "/api/v1/vouchers/"+voucherID.String(), nil)
 	w := httptest.NewRecorder()
@@ -286,7 +469,7 @@ func (s *VoucherHandlerTestSuite) TestCreate_ValidationError_Unbalanced() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
 }
 
 func (s *VoucherHandlerTestSuite) TestCreate_InvalidJSON() {
@@ -475,6 +658,7 @@ func (s *VoucherHandlerTestSuite) TestApprove_Success() {
 	voucher := s.newTestVoucher()
 	voucher.Status = domain.VoucherStatusPending
 
+	s.mockSig.On("Verify", mock.Anything, mock.Anything, mock.Anything, domain.SignatureActionApprove, mock.Anything).Return(nil, nil)
 	s.mockSvc.On("Approve", mock.Anything, mock.Anything, voucher.ID, mock.Anything).Return(nil)
 	s.mockSvc.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(voucher, nil)
 
@@ -488,6 +672,7 @@ func (s *VoucherHandlerTestSuite) TestApprove_Success() {
 func (s *VoucherHandlerTestSuite) TestApprove_CannotApprove() {
 	voucherID := uuid.New()
 
+	s.mockSig.On("Verify", mock.Anything, mock.Anything, mock.Anything, domain.SignatureActionApprove, mock.Anything).Return(nil, nil)
 	s.mockSvc.On("Approve", mock.Anything, mock.Anything, voucherID, mock.Anything).Return(domain.ErrVoucherCannotApprove)
 
 	req := httptest.NewRequest("POST", "/api/v1/vouchers/"+voucherID.String()+"/approve", nil)
@@ -497,6 +682,43 @@ func (s *VoucherHandlerTestSuite) TestApprove_CannotApprove() {
 	assert.Equal(s.T(), http.StatusConflict, w.Code)
 }
 
+func (s *VoucherHandlerTestSuite) TestApprove_RecordsSignature() {
+	voucher := s.newTestVoucher()
+	voucher.Status = domain.VoucherStatusPending
+	signature := &domain.VoucherSignature{Action: domain.SignatureActionApprove, Method: domain.SignatureMethodPIN}
+
+	s.mockSig.On("Verify", mock.Anything, s.companyID, s.userID, domain.SignatureActionApprove,
+		mock.MatchedBy(func(in *service.SignatureInput) bool { return in.PIN == "123456" })).Return(signature, nil)
+	s.mockSvc.On("Approve", mock.Anything, mock.Anything, voucher.ID, mock.Anything).Return(nil)
+	s.mockSvc.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(voucher, nil)
+	s.mockSig.On("Record", mock.Anything, voucher, signature).Return(nil)
+
+	body, _ := json.Marshal(dto.SignatureRequest{PIN: "123456"})
+	req := httptest.NewRequest("POST", "/api/v1/vouchers/"+voucher.ID.String()+"/approve", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	s.mockSig.AssertExpectations(s.T())
+}
+
+func (s *VoucherHandlerTestSuite) TestApprove_InvalidPIN() {
+	voucherID := uuid.New()
+
+	s.mockSig.On("Verify", mock.Anything, mock.Anything, mock.Anything, domain.SignatureActionApprove, mock.Anything).
+		Return(nil, domain.ErrSigningPINInvalid)
+
+	body, _ := json.Marshal(dto.SignatureRequest{PIN: "0000"})
+	req := httptest.NewRequest("POST", "/api/v1/vouchers/"+voucherID.String()+"/approve", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+	s.mockSvc.AssertNotCalled(s.T(), "Approve", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // =============================================================================
 // POST /vouchers/:id/reject Tests
 // =============================================================================
@@ -526,6 +748,7 @@ func (s *VoucherHandlerTestSuite) TestPost_Success() {
 	voucher := s.newTestVoucher()
 	voucher.Status = domain.VoucherStatusApproved
 
+	s.mockSig.On("Verify", mock.Anything, mock.Anything, mock.Anything, domain.SignatureActionPost, mock.Anything).Return(nil, nil)
 	s.mockSvc.On("Post", mock.Anything, mock.Anything, voucher.ID, mock.Anything).Return(nil)
 	s.mockSvc.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(voucher, nil)
 
@@ -539,6 +762,7 @@ func (s *VoucherHandlerTestSuite) TestPost_Success() {
 func (s *VoucherHandlerTestSuite) TestPost_CannotPost() {
 	voucherID := uuid.New()
 
+	s.mockSig.On("Verify", mock.Anything, mock.Anything, mock.Anything, domain.SignatureActionPost, mock.Anything).Return(nil, nil)
 	s.mockSvc.On("Post", mock.Anything, mock.Anything, voucherID, mock.Anything).Return(domain.ErrVoucherCannotPost)
 
 	req := httptest.NewRequest("POST", "/api/v1/vouchers/"+voucherID.String()+"/post", nil)
@@ -705,5 +929,5 @@ func (s *VoucherHandlerTestSuite) TestReplaceEntries_Unbalanced() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
 }
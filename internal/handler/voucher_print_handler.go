@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// VoucherPrintHandler handles HTTP requests for printable vouchers
+type VoucherPrintHandler struct {
+	service service.VoucherPrintService
+}
+
+// NewVoucherPrintHandler creates a new VoucherPrintHandler
+func NewVoucherPrintHandler(svc service.VoucherPrintService) *VoucherPrintHandler {
+	return &VoucherPrintHandler{service: svc}
+}
+
+// RegisterRoutes registers voucher print routes
+func (h *VoucherPrintHandler) RegisterRoutes(r *gin.RouterGroup) {
+	vouchers := r.Group("/vouchers")
+	{
+		vouchers.GET("/print-template", h.GetTemplate)
+		vouchers.PUT("/print-template", h.UpdateTemplate)
+		vouchers.GET("/:id/print", h.Print)
+	}
+}
+
+// Print renders a voucher as PDF
+// @Summary Print voucher
+// @Description Render a voucher as PDF with company header, entries and approval block
+// @Tags vouchers
+// @Produce application/pdf
+// @Param id path string true "Voucher ID"
+// @Param download query bool false "Send as attachment instead of inline"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.Response
+// @Router /vouchers/{id}/print [get]
+func (h *VoucherPrintHandler) Print(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid voucher ID"))
+		return
+	}
+
+	voucher, data, err := h.service.PrintVoucher(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to print voucher")
+		return
+	}
+
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, voucher.VoucherNo+".pdf"))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// GetTemplate handles GET /vouchers/print-template
+func (h *VoucherPrintHandler) GetTemplate(c *gin.Context) {
+	template, err := h.service.GetTemplate(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to get print template")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherPrintTemplate(template)))
+}
+
+// UpdateTemplate handles PUT /vouchers/print-template
+func (h *VoucherPrintHandler) UpdateTemplate(c *gin.Context) {
+	var req dto.UpdateVoucherPrintTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to get print template")
+		return
+	}
+
+	if err := req.ApplyTo(template); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeValidation, "Logo must be base64 encoded"))
+		return
+	}
+
+	if err := h.service.UpdateTemplate(c.Request.Context(), template); err != nil {
+		respondError(c, err, "Failed to update print template")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherPrintTemplate(template)))
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// VoucherTagHandler handles HTTP requests for the company's voucher tags
+type VoucherTagHandler struct {
+	service service.VoucherTagService
+}
+
+// NewVoucherTagHandler creates a new VoucherTagHandler
+func NewVoucherTagHandler(svc service.VoucherTagService) *VoucherTagHandler {
+	return &VoucherTagHandler{service: svc}
+}
+
+// RegisterRoutes registers voucher tag routes
+func (h *VoucherTagHandler) RegisterRoutes(r *gin.RouterGroup) {
+	tags := r.Group("/voucher-tags")
+	{
+		tags.GET("", h.List)
+		tags.POST("", h.Create)
+		tags.GET("/:id", h.Get)
+		tags.PUT("/:id", h.Update)
+		tags.DELETE("/:id", h.Delete)
+	}
+}
+
+// List handles GET /voucher-tags
+func (h *VoucherTagHandler) List(c *gin.Context) {
+	tags, err := h.service.List(c.Request.Context(), appctx.GetCompanyID(c))
+	if err != nil {
+		respondError(c, err, "Failed to list voucher tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherTags(tags)))
+}
+
+// Create handles POST /voucher-tags
+func (h *VoucherTagHandler) Create(c *gin.Context) {
+	var req dto.CreateVoucherTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	tag := req.ToVoucherTag(appctx.GetCompanyID(c))
+	if err := h.service.Create(c.Request.Context(), tag); err != nil {
+		respondError(c, err, "Failed to create voucher tag")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.FromVoucherTag(tag)))
+}
+
+// Get handles GET /voucher-tags/:id
+func (h *VoucherTagHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid voucher tag ID"))
+		return
+	}
+
+	tag, err := h.service.GetByID(c.Request.Context(), appctx.GetCompanyID(c), id)
+	if err != nil {
+		respondError(c, err, "Failed to get voucher tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherTag(tag)))
+}
+
+// Update handles PUT /voucher-tags/:id; a new name is applied to the tagged vouchers
+func (h *VoucherTagHandler) Update(c *gin.Context) {
+	companyID := appctx.GetCompanyID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid voucher tag ID"))
+		return
+	}
+
+	var req dto.UpdateVoucherTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(dto.ErrCodeValidation, "Invalid request body", err))
+		return
+	}
+
+	tag, err := h.service.GetByID(c.Request.Context(), companyID, id)
+	if err != nil {
+		respondError(c, err, "Failed to get voucher tag")
+		return
+	}
+
+	req.ApplyTo(tag)
+	if err := h.service.Update(c.Request.Context(), tag); err != nil {
+		respondError(c, err, "Failed to update voucher tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromVoucherTag(tag)))
+}
+
+// Delete handles DELETE /voucher-tags/:id; the tag is removed from the tagged vouchers
+func (h *VoucherTagHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse(dto.ErrCodeBadRequest, "Invalid voucher tag ID"))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), appctx.GetCompanyID(c), id); err != nil {
+		respondError(c, err, "Failed to delete voucher tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
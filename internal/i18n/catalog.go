@@ -0,0 +1,399 @@
+package i18n
+
+// catalogs holds the messages of each locale. Keys are grouped by prefix:
+//   - enum labels: "<enum>.<value>", looked up with Label
+//   - report headers: "report.*"
+//   - generic error messages by error code: "error.<code>"
+//   - translations of specific English error messages: "msg.<message>"
+var catalogs = map[Locale]map[string]string{
+	Korean: {
+		// Account type and nature
+		"account_type.asset":     "자산",
+		"account_type.liability": "부채",
+		"account_type.equity":    "자본",
+		"account_type.revenue":   "수익",
+		"account_type.expense":   "비용",
+		"account_nature.debit":   "차변",
+		"account_nature.credit":  "대변",
+
+		// Voucher type and status
+		"voucher_type.general":     "일반전표",
+		"voucher_type.sales":       "매출전표",
+		"voucher_type.purchase":    "매입전표",
+		"voucher_type.payment":     "지급전표",
+		"voucher_type.receipt":     "입금전표",
+		"voucher_type.adjustment":  "수정전표",
+		"voucher_type.closing":     "결산전표",
+		"voucher_status.draft":     "작성중",
+		"voucher_status.pending":   "승인대기",
+		"voucher_status.approved":  "승인완료",
+		"voucher_status.posted":    "전기완료",
+		"voucher_status.rejected":  "반려",
+		"voucher_status.cancelled": "취소",
+
+		// Activity feed
+		"activity_kind.status_change": "상태 변경",
+		"activity_kind.comment":       "댓글",
+		"activity_kind.attachment":    "증빙 첨부",
+		"activity_kind.edit":          "수정",
+
+		// Document links
+		"document_type.voucher":        "전표",
+		"document_type.tax_invoice":    "세금계산서",
+		"document_type.loan":           "차입금",
+		"document_type.grant":          "보조금",
+		"document_type.allocation_run": "배부 실행",
+
+		// Usage metering
+		"usage_metric.active_users": "활성 사용자",
+		"usage_metric.vouchers":     "전표",
+		"usage_metric.tax_invoices": "발행 세금계산서",
+
+		// Subscription plans
+		"plan_limit.users":    "사용자 수",
+		"plan_limit.vouchers": "월 전표 수",
+		"plan_limit.storage":  "첨부파일 용량",
+
+		// Reports
+		"report_type.trial_balance":    "합계잔액시산표",
+		"report_type.balance_sheet":    "재무상태표",
+		"report_type.income_statement": "손익계산서",
+		"report_type.vat_return":       "부가가치세 신고서",
+		"report_column.opening":        "기초잔액",
+		"report_column.debit":          "차변",
+		"report_column.credit":         "대변",
+		"report_column.closing":        "기말잔액",
+		"report_dimension.department":  "부서",
+		"report_dimension.partner":     "거래처",
+		"report_dimension.project":     "프로젝트",
+
+		// Report headers
+		"report.account_code":  "계정코드",
+		"report.account_name":  "계정과목",
+		"report.section":       "구분",
+		"report.amount":        "금액",
+		"report.tax_code":      "세금코드",
+		"report.tax_name":      "명칭",
+		"report.count":         "건수",
+		"report.supply_amount": "공급가액",
+		"report.tax_amount":    "세액",
+		"report.total":         "합계",
+		"report.as_of":         "%s 현재",
+		"report.sales":         "매출",
+		"report.purchase":      "매입",
+		"report.output_tax":    "매출세액 합계",
+		"report.input_tax":     "공제 매입세액",
+		"report.payable_tax":   "납부(환급)세액",
+		"report.unassigned":    "(미지정)",
+		"report.currency":      "(단위: %s)",
+
+		// Errors by code
+		"error.AUTH_001": "인증이 필요합니다",
+		"error.AUTH_002": "토큰이 만료되었습니다",
+		"error.AUTH_003": "이메일 또는 비밀번호가 올바르지 않습니다",
+		"error.AUTH_004": "잠긴 계정입니다",
+		"error.AUTH_005": "비활성화된 계정입니다",
+		"error.AUTH_006": "유효하지 않은 토큰입니다",
+		"error.AUTH_007": "유효하지 않은 리프레시 토큰입니다",
+		"error.AUTH_008": "추가 인증(MFA)이 필요합니다",
+		"error.AUTH_009": "추가 인증 코드가 올바르지 않습니다",
+		"error.VAL_001":  "요청 값이 올바르지 않습니다",
+		"error.VAL_002":  "입력 값이 올바르지 않습니다",
+		"error.VAL_003":  "필수 항목이 누락되었습니다",
+		"error.VAL_004":  "형식이 올바르지 않습니다",
+		"error.VAL_005":  "허용 범위를 벗어난 값입니다",
+		"error.VAL_006":  "요청 본문이 너무 큽니다",
+		"error.VAL_007":  "요청 본문의 구조가 허용 범위를 벗어났습니다",
+		"error.RES_001":  "요청한 리소스를 찾을 수 없습니다",
+		"error.RES_002":  "이미 존재합니다",
+		"error.RES_003":  "현재 상태와 충돌하는 요청입니다",
+		"error.RES_004":  "이미 사용 중인 이메일입니다",
+		"error.RES_005":  "이미 등록된 사업자등록번호입니다",
+		"error.RES_006":  "더 이상 유효하지 않습니다",
+		"error.PERM_001": "접근 권한이 없습니다",
+		"error.PERM_002": "역할 권한이 부족합니다",
+		"error.PERM_003": "다른 회사의 데이터에 접근할 수 없습니다",
+		"error.SRV_001":  "서버 오류가 발생했습니다",
+		"error.SRV_002":  "데이터베이스 오류가 발생했습니다",
+		"error.SRV_003":  "외부 서비스 오류가 발생했습니다",
+		"error.SRV_004":  "요청 시간이 초과되었습니다",
+		"error.SRV_005":  "서비스를 일시적으로 사용할 수 없습니다",
+		"error.BIZ_001":  "차변과 대변 금액이 일치하지 않습니다",
+		"error.BIZ_002":  "마감된 회계기간입니다",
+		"error.BIZ_003":  "업무 규칙에 맞지 않는 요청입니다",
+		"error.BIZ_004":  "업무 규칙에 맞지 않는 요청입니다",
+		"error.BIZ_005":  "업무 규칙에 맞지 않는 요청입니다",
+		"error.BIZ_006":  "구독 플랜의 한도를 초과했습니다",
+		"error.RATE_001": "요청이 너무 많습니다. 잠시 후 다시 시도해 주십시오",
+
+		// Specific error messages
+		"msg.Invalid request body":                         "요청 본문이 올바르지 않습니다",
+		"msg.Invalid query parameters":                     "조회 조건이 올바르지 않습니다",
+		"msg.Validation failed":                            "입력 값 검증에 실패했습니다",
+		"msg.Voucher not found":                            "전표를 찾을 수 없습니다",
+		"msg.Account not found":                            "계정과목을 찾을 수 없습니다",
+		"msg.User not found":                               "사용자를 찾을 수 없습니다",
+		"msg.Partner not found":                            "거래처를 찾을 수 없습니다",
+		"msg.Role not found":                               "역할을 찾을 수 없습니다",
+		"msg.Project not found":                            "프로젝트를 찾을 수 없습니다",
+		"msg.Company not found":                            "회사를 찾을 수 없습니다",
+		"msg.Department not found":                         "부서를 찾을 수 없습니다",
+		"msg.Tax code not found":                           "세금코드를 찾을 수 없습니다",
+		"msg.Fiscal period not found":                      "회계기간을 찾을 수 없습니다",
+		"msg.Close checklist task not found":               "결산 체크리스트 항목을 찾을 수 없습니다",
+		"msg.Invalid user ID":                              "사용자 ID가 올바르지 않습니다",
+		"msg.Invalid voucher ID":                           "전표 ID가 올바르지 않습니다",
+		"msg.Invalid account ID":                           "계정과목 ID가 올바르지 않습니다",
+		"msg.Invalid partner ID":                           "거래처 ID가 올바르지 않습니다",
+		"msg.Invalid project ID":                           "프로젝트 ID가 올바르지 않습니다",
+		"msg.Invalid role ID":                              "역할 ID가 올바르지 않습니다",
+		"msg.Voucher cannot be edited in current status":   "현재 상태에서는 전표를 수정할 수 없습니다",
+		"msg.Voucher debit and credit must be equal":       "차변과 대변 금액이 일치해야 합니다",
+		"msg.Voucher cannot be approved in current status": "현재 상태에서는 전표를 승인할 수 없습니다",
+		"msg.Voucher cannot be posted in current status":   "현재 상태에서는 전표를 전기할 수 없습니다",
+		"msg.Cannot post directly to control account":      "통제계정에는 직접 전기할 수 없습니다",
+		"msg.Voucher must have at least one entry":         "전표에는 하나 이상의 분개가 있어야 합니다",
+		"msg.Voucher has already been reversed":            "이미 역분개된 전표입니다",
+		"msg.Fiscal period is closed":                      "마감된 회계기간입니다",
+		"msg.Password must be at least 8 characters":       "비밀번호는 8자 이상이어야 합니다",
+		"msg.Invalid current password":                     "현재 비밀번호가 올바르지 않습니다",
+		"msg.Cannot remove the last active administrator":  "활성 관리자가 한 명 이상 있어야 합니다",
+		"msg.Email already exists":                         "이미 사용 중인 이메일입니다",
+		"msg.Account code already exists":                  "이미 존재하는 계정코드입니다",
+		"msg.Partner code already exists":                  "이미 존재하는 거래처코드입니다",
+		"msg.Project code already exists":                  "이미 존재하는 프로젝트코드입니다",
+		"msg.Role code already exists":                     "이미 존재하는 역할코드입니다",
+		"msg.Business number already exists":               "이미 등록된 사업자등록번호입니다",
+		"msg.Email delivery is not configured":             "이메일 발송이 설정되어 있지 않습니다",
+		"msg.Inbound email is not configured":              "수신 이메일이 설정되어 있지 않습니다",
+		"msg.Receipt OCR is unavailable":                   "영수증 인식을 사용할 수 없습니다",
+		"msg.Request timed out":                            "요청 시간이 초과되었습니다",
+		"msg.Rate limit exceeded":                          "요청이 너무 많습니다. 잠시 후 다시 시도해 주십시오",
+		"msg.Request body too large":                       "요청 본문이 너무 큽니다",
+		"msg.Attachment file type is not allowed":          "첨부할 수 없는 파일 형식입니다",
+		"msg.Attachment type does not match its extension": "파일 확장자와 실제 파일 형식이 다릅니다",
+		"msg.Attachment has no thumbnail":                  "미리보기 이미지가 없습니다",
+		"msg.Backup not found":                             "백업을 찾을 수 없습니다",
+		"msg.Backups are not configured":                   "백업이 설정되어 있지 않습니다",
+		"msg.Restores into staging are not configured":     "스테이징 복원이 설정되어 있지 않습니다",
+		"msg.A reason is required to restore a backup":     "백업을 복원하려면 사유를 입력해야 합니다",
+		"msg.Backup is not completed or has expired":       "완료되지 않았거나 보관 기간이 지난 백업입니다",
+		"msg.Close confirmation not found":                 "마감 승인 요청을 찾을 수 없습니다",
+		"msg.Close confirmation is already closed":         "이미 처리된 마감 승인 요청입니다",
+		"msg.Close confirmation has expired":               "마감 승인 요청의 유효 기간이 지났습니다",
+		"msg.A close must be confirmed by another user":    "마감은 다른 사용자가 승인해야 합니다",
+		"msg.Fiscal year is locked after audit sign-off":   "감사 확정으로 잠긴 회계연도입니다",
+		"msg.Audit lock not found":                         "감사 잠금을 찾을 수 없습니다",
+		"msg.Audit lock is already unlocked":               "이미 해제된 감사 잠금입니다",
+		"msg.A reason is required to unlock a fiscal year": "감사 잠금을 해제하려면 사유를 입력해야 합니다",
+		"msg.Job not found":                                "작업을 찾을 수 없습니다",
+		"msg.Only failed or cancelled jobs can be retried": "실패하거나 취소된 작업만 재시도할 수 있습니다",
+		"msg.Job is already finished":                      "이미 완료된 작업입니다",
+		"msg.Invalid job type":                             "유효하지 않은 작업 유형입니다",
+		"msg.Invalid job status":                           "유효하지 않은 작업 상태입니다",
+		"msg.Scheduled task not found":                     "예약 작업을 찾을 수 없습니다",
+		"msg.Invalid catch-up policy":                      "유효하지 않은 누락 실행 정책입니다",
+		"msg.Email template not found":                     "이메일 템플릿을 찾을 수 없습니다",
+		"msg.Invalid email event type":                     "유효하지 않은 이메일 이벤트입니다",
+		"msg.Email template subject is required":           "이메일 제목을 입력하세요",
+		"msg.Email template body is required":              "이메일 본문을 입력하세요",
+		"msg.Invalid template syntax":                      "템플릿 문법이 올바르지 않습니다",
+		"msg.Unknown template variable":                    "사용할 수 없는 템플릿 변수입니다",
+		"msg.Invalid template version":                     "유효하지 않은 템플릿 버전입니다",
+		"msg.PIN confirmation is required":                 "PIN 확인이 필요합니다",
+		"msg.Invalid push platform":                        "유효하지 않은 푸시 플랫폼입니다",
+		"msg.Push token is required":                       "푸시 토큰을 입력하세요",
+		"msg.Invalid metric":                               "유효하지 않은 지표입니다",
+		"msg.Invalid granularity":                          "유효하지 않은 집계 단위입니다",
+		"msg.Invalid period range":                         "유효하지 않은 기간입니다",
+		"msg.Cash series take no filters":                  "현금 추이는 부서나 프로젝트로 조회할 수 없습니다",
+		"msg.Description or partner is required":           "적요 또는 거래처를 입력하세요",
+		"msg.Invalid shortcut kind":                        "바로가기 종류가 올바르지 않습니다",
+		"msg.Too many favorites":                           "즐겨찾기는 20개까지 등록할 수 있습니다",
+		"msg.Shortcuts are disabled":                       "즐겨찾기 기능을 사용할 수 없습니다",
+		"msg.Only one entry may omit its amount":           "금액을 비워 둘 수 있는 분개는 하나뿐입니다",
+		"msg.Balancing entry is on the wrong side":         "금액을 비운 분개의 차대 구분이 차액과 맞지 않습니다",
+		"msg.Unknown codes in entries":                     "분개에 등록되지 않은 코드가 있습니다",
+		"msg.Unknown code":                                 "등록되지 않은 코드입니다",
+		"msg.Invalid partner code rule":                    "거래처 코드 규칙이 올바르지 않습니다",
+		"msg.Partner code sequence is exhausted":           "거래처 코드 번호가 모두 사용되었습니다. 코드 자릿수를 늘리세요",
+		"msg.A partner cannot be merged into itself":       "같은 거래처끼리는 병합할 수 없습니다",
+		"msg.Tax invoices tie partner to business number":  "세금계산서가 있는 거래처는 사업자번호가 다른 거래처로 병합할 수 없습니다",
+		"msg.Bank account not found":                       "계좌를 찾을 수 없습니다",
+		"msg.Bank account already registered":              "이미 등록된 계좌입니다",
+		"msg.Bank account has voucher entries":             "전표에 사용된 계좌입니다. 삭제 대신 사용 중지하세요",
+		"msg.Invalid bank account":                         "계좌 정보가 올바르지 않습니다",
+		"msg.Invalid bank balance":                         "계좌 잔액 정보가 올바르지 않습니다",
+		"msg.Payment from bank needs the bank account":     "예금 출금 전표에는 출금 계좌를 지정하세요",
+		"msg.Bank account belongs to another account":      "다른 계정과목에 연결된 계좌입니다",
+		"msg.Bank account is inactive":                     "사용 중지된 계좌입니다",
+		"msg.Invalid partner bank account":                 "거래처 계좌 정보가 올바르지 않습니다",
+		"msg.Payment batch not found":                      "이체 묶음을 찾을 수 없습니다",
+		"msg.No approved payables are due":                 "지급 기한이 된 승인 채무가 없습니다",
+		"msg.Payment batch is executed or cancelled":       "이미 실행되었거나 취소된 이체 묶음입니다",
+		"msg.Invalid payment batch":                        "이체 묶음 정보가 올바르지 않습니다",
+		"msg.Payment item is not in the batch":             "이체 묶음에 없는 지급 항목입니다",
+		"msg.Payables changed while generating the batch":  "이체 묶음 생성 중 채무가 변경되었습니다. 다시 시도하세요",
+		"msg.Bank has no bulk transfer file layout":        "대량이체 파일을 지원하지 않는 은행입니다",
+		"msg.Invalid bulk transfer":                        "대량이체 정보가 올바르지 않습니다",
+		"msg.Note not found":                               "어음을 찾을 수 없습니다",
+		"msg.Note number already exists":                   "이미 등록된 어음 번호입니다",
+		"msg.Invalid note":                                 "어음 정보가 올바르지 않습니다",
+		"msg.Invalid note discount":                        "어음 할인 정보가 올바르지 않습니다",
+		"msg.Note is already discounted or settled":        "이미 할인되었거나 결제된 어음입니다",
+		"msg.Only received notes can be discounted":        "받을어음만 할인할 수 있습니다",
+		"msg.Petty cash fund not found":                    "소액현금을 찾을 수 없습니다",
+		"msg.Petty cash fund already exists":               "이미 등록된 소액현금 이름입니다",
+		"msg.Invalid petty cash fund":                      "소액현금 정보가 올바르지 않습니다",
+		"msg.Petty cash fund is inactive":                  "사용 중지된 소액현금입니다",
+		"msg.Petty cash claim not found":                   "소액현금 지출을 찾을 수 없습니다",
+		"msg.Invalid petty cash claim":                     "소액현금 지출 정보가 올바르지 않습니다",
+		"msg.Petty cash on hand is insufficient":           "소액현금 잔액이 부족합니다",
+		"msg.Petty cash claim is replenished":              "이미 보충 처리된 소액현금 지출입니다",
+		"msg.No petty cash claims to replenish":            "보충할 소액현금 지출이 없습니다",
+		"msg.Invalid petty cash replenishment":             "소액현금 보충 정보가 올바르지 않습니다",
+		"msg.Claims changed while replenishing":            "보충 중 지출 내역이 변경되었습니다. 다시 시도하세요",
+		"msg.Employee advance not found":                   "가지급금을 찾을 수 없습니다",
+		"msg.Invalid employee advance":                     "가지급금 정보가 올바르지 않습니다",
+		"msg.Invalid advance settlement":                   "가지급금 정산 내용이 올바르지 않습니다",
+		"msg.Employee advance is already settled":          "이미 정산이 완료된 가지급금입니다",
+		"msg.Refund exceeds the advance balance":           "반환액이 가지급금 잔액을 초과합니다",
+		"msg.Advance changed while settling":               "정산 중 가지급금이 변경되었습니다. 다시 시도하세요",
+		"msg.Settlement needs expenses or a refund":        "정산할 경비 또는 반환액을 입력하세요",
+		"msg.Advance settlement not found":                 "가지급금 정산 내역을 찾을 수 없습니다",
+		"msg.Travel policy not found":                      "여비규정을 찾을 수 없습니다",
+		"msg.Invalid travel policy":                        "여비규정 내용이 올바르지 않습니다",
+		"msg.Travel policy name already exists":            "이미 사용 중인 여비규정 이름입니다",
+		"msg.Department has an active travel policy":       "해당 부서에 이미 사용 중인 여비규정이 있습니다",
+		"msg.Settlement has no violations pending review":  "검토할 규정 위반이 없는 정산입니다",
+		"msg.User may not review the policy violations":    "규정 위반을 검토할 권한이 없습니다",
+		"msg.Document folder not found":                    "문서함 폴더를 찾을 수 없습니다",
+		"msg.Invalid document folder":                      "문서함 폴더 정보가 올바르지 않습니다",
+		"msg.Folder name already exists":                   "같은 위치에 이미 있는 폴더 이름입니다",
+		"msg.Document folder is not empty":                 "하위 폴더나 파일이 있는 폴더는 삭제할 수 없습니다",
+		"msg.Folder cannot move into its own subfolder":    "폴더를 자신의 하위 폴더로 옮길 수 없습니다",
+		"msg.Document file not found":                      "문서 파일을 찾을 수 없습니다",
+		"msg.Invalid document file":                        "문서 파일 정보가 올바르지 않습니다",
+		"msg.File name already exists in the folder":       "폴더에 이미 있는 파일 이름입니다",
+		"msg.Document version not found":                   "문서 버전을 찾을 수 없습니다",
+		"msg.File changed while uploading":                 "업로드 중 파일이 변경되었습니다. 다시 시도하세요",
+		"msg.File is already linked to the document":       "이미 연결된 문서입니다",
+		"msg.Document file link not found":                 "문서 연결을 찾을 수 없습니다",
+		"msg.Invalid document access":                      "문서함 권한 설정이 올바르지 않습니다",
+		"msg.No access to the document folder":             "문서함 폴더에 대한 권한이 없습니다",
+		"msg.Contract not found":                           "계약을 찾을 수 없습니다",
+		"msg.Contract number already exists":               "이미 등록된 계약 번호입니다",
+		"msg.Invalid contract":                             "계약 정보가 올바르지 않습니다",
+		"msg.Contract milestone not found":                 "청구 일정을 찾을 수 없습니다",
+		"msg.Contract milestone is already invoiced":       "이미 세금계산서가 작성된 청구 일정입니다",
+		"msg.Contract milestone is not invoiced yet":       "아직 청구되지 않은 청구 일정입니다",
+		"msg.Contract milestone was changed meanwhile":     "청구 일정이 변경되었습니다. 다시 시도하세요",
+		"msg.Invalid contract collection":                  "수금 정보가 올바르지 않습니다",
+		"msg.Tax invoice number already exists":            "이미 사용 중인 세금계산서 번호입니다",
+		"msg.Revenue schedule not found":                   "수익인식 일정을 찾을 수 없습니다",
+		"msg.Tax invoice already has a revenue schedule":   "이미 수익인식 일정이 등록된 세금계산서입니다",
+		"msg.Invalid revenue schedule":                     "수익인식 일정 정보가 올바르지 않습니다",
+		"msg.Only sales tax invoices can be deferred":      "매출 세금계산서만 선수수익으로 이연할 수 있습니다",
+		"msg.Revenue recognition was posted meanwhile":     "수익인식 전표가 이미 기표되었습니다. 다시 시도하세요",
+		"msg.Prepaid expense not found":                    "선급비용을 찾을 수 없습니다",
+		"msg.Expense of the voucher is already prepaid":    "이미 선급비용으로 등록된 전표의 비용입니다",
+		"msg.Invalid prepaid expense":                      "선급비용 정보가 올바르지 않습니다",
+		"msg.Only posted expense vouchers can be prepaid":  "기표된 비용 전표만 선급비용으로 등록할 수 있습니다",
+		"msg.Prepaid amortization was posted meanwhile":    "선급비용 상각 전표가 이미 기표되었습니다. 다시 시도하세요",
+		"msg.Inventory item not found":                     "품목을 찾을 수 없습니다",
+		"msg.Inventory item code already exists":           "이미 등록된 품목 코드입니다",
+		"msg.Invalid inventory item":                       "품목 정보가 올바르지 않습니다",
+		"msg.Inventory count not found":                    "재고실사를 찾을 수 없습니다",
+		"msg.Invalid inventory count":                      "재고실사 정보가 올바르지 않습니다",
+		"msg.No inventory items to count":                  "실사할 품목이 없습니다",
+		"msg.Another inventory count is in progress":       "진행 중인 재고실사가 있습니다",
+		"msg.Inventory count is not open":                  "실사 중인 재고실사가 아닙니다",
+		"msg.Inventory count is not submitted":             "승인 요청된 재고실사가 아닙니다",
+		"msg.Inventory count has items not counted":        "실사 수량이 입력되지 않은 품목이 있습니다",
+		"msg.Inventory count changed meanwhile":            "재고실사가 변경되었습니다. 다시 시도하세요",
+		"msg.User may not approve the inventory count":     "재고실사를 승인할 권한이 없습니다",
+		"msg.Item is not in the inventory count":           "재고실사 대상이 아닌 품목입니다",
+		"msg.Count sheet needs item code and quantity":     "실사표에 품목코드와 실사수량 열이 필요합니다",
+		"msg.Fiscal year has open periods":                 "마감되지 않은 회계기간이 있는 회계연도입니다",
+		"msg.Deletion request not found":                   "삭제 요청을 찾을 수 없습니다",
+		"msg.A deletion request is already pending":        "이미 처리 대기 중인 삭제 요청이 있습니다",
+		"msg.Deletion request is already closed":           "이미 처리된 삭제 요청입니다",
+		"msg.A reason is required to reject the request":   "삭제 요청을 거부하려면 사유를 입력해야 합니다",
+		"msg.JSON is nested too deeply":                    "요청 본문의 중첩이 너무 깊습니다",
+		"msg.JSON array is too long":                       "요청 본문의 배열 항목이 너무 많습니다",
+		"msg.Internal server error":                        "서버 오류가 발생했습니다",
+	},
+	English: {
+		"account_type.asset":     "Assets",
+		"account_type.liability": "Liabilities",
+		"account_type.equity":    "Equity",
+		"account_type.revenue":   "Revenue",
+		"account_type.expense":   "Expenses",
+		"account_nature.debit":   "Debit",
+		"account_nature.credit":  "Credit",
+
+		"voucher_type.general":     "General voucher",
+		"voucher_type.sales":       "Sales voucher",
+		"voucher_type.purchase":    "Purchase voucher",
+		"voucher_type.payment":     "Payment voucher",
+		"voucher_type.receipt":     "Receipt voucher",
+		"voucher_type.adjustment":  "Adjustment voucher",
+		"voucher_type.closing":     "Closing voucher",
+		"voucher_status.draft":     "Draft",
+		"voucher_status.pending":   "Pending approval",
+		"voucher_status.approved":  "Approved",
+		"voucher_status.posted":    "Posted",
+		"voucher_status.rejected":  "Rejected",
+		"voucher_status.cancelled": "Cancelled",
+
+		"activity_kind.status_change": "Status change",
+		"activity_kind.comment":       "Comment",
+		"activity_kind.attachment":    "Attachment uploaded",
+		"activity_kind.edit":          "Edit",
+
+		"document_type.voucher":        "Voucher",
+		"document_type.tax_invoice":    "Tax invoice",
+		"document_type.loan":           "Loan",
+		"document_type.grant":          "Grant",
+		"document_type.allocation_run": "Allocation run",
+
+		"usage_metric.active_users": "Active users",
+		"usage_metric.vouchers":     "Vouchers",
+		"usage_metric.tax_invoices": "Issued tax invoices",
+
+		"plan_limit.users":    "Users",
+		"plan_limit.vouchers": "Vouchers per month",
+		"plan_limit.storage":  "Attachment storage",
+
+		"report_type.trial_balance":    "Trial Balance",
+		"report_type.balance_sheet":    "Balance Sheet",
+		"report_type.income_statement": "Income Statement",
+		"report_type.vat_return":       "VAT Return",
+		"report_column.opening":        "Opening Balance",
+		"report_column.debit":          "Debit",
+		"report_column.credit":         "Credit",
+		"report_column.closing":        "Closing Balance",
+		"report_dimension.department":  "Department",
+		"report_dimension.partner":     "Partner",
+		"report_dimension.project":     "Project",
+
+		"report.account_code":  "Account Code",
+		"report.account_name":  "Account",
+		"report.section":       "Section",
+		"report.amount":        "Amount",
+		"report.tax_code":      "Tax Code",
+		"report.tax_name":      "Name",
+		"report.count":         "Count",
+		"report.supply_amount": "Supply Amount",
+		"report.tax_amount":    "Tax Amount",
+		"report.total":         "Total",
+		"report.as_of":         "As of %s",
+		"report.sales":         "Sales",
+		"report.purchase":      "Purchases",
+		"report.output_tax":    "Total Output Tax",
+		"report.input_tax":     "Deductible Input Tax",
+		"report.payable_tax":   "Tax Payable (Refundable)",
+		"report.unassigned":    "(Unassigned)",
+		"report.currency":      "(Currency: %s)",
+	},
+}
@@ -0,0 +1,124 @@
+// Package i18n provides locale negotiation and message catalogs for API messages,
+// enum labels and report headers
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a supported language
+type Locale string
+
+const (
+	Korean  Locale = "ko"
+	English Locale = "en"
+
+	// DefaultLocale is used when the client does not ask for a supported language
+	DefaultLocale = Korean
+
+	// sourceLocale is the language API error messages are written in
+	sourceLocale = English
+)
+
+// Parse returns the supported locale for a language tag such as "en-US"
+func Parse(tag string) (Locale, bool) {
+	lang := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch Locale(lang) {
+	case Korean, English:
+		return Locale(lang), true
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage picks the preferred supported locale from an Accept-Language
+// header, e.g. "en-US,en;q=0.9,ko;q=0.8"
+func ParseAcceptLanguage(header string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if loc, ok := Parse(tag); ok && q > 0 {
+			candidates = append(candidates, candidate{loc, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+// T returns the message for key, falling back to the default locale and then the key itself
+func T(loc Locale, key string) string {
+	if msg, ok := catalogs[loc][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// Tf formats the message for key with args
+func Tf(loc Locale, key string, args ...interface{}) string {
+	return fmt.Sprintf(T(loc, key), args...)
+}
+
+// Label returns the label of an enum value, e.g. Label(loc, "voucher_status", "draft").
+// Unknown values yield an empty string.
+func Label(loc Locale, enum, value string) string {
+	key := enum + "." + value
+	if msg, ok := catalogs[loc][key]; ok {
+		return msg
+	}
+	return catalogs[DefaultLocale][key]
+}
+
+// ErrorMessage localizes an API error message. A translation of the exact message is
+// preferred; otherwise the generic message for the error code is used. Messages without
+// any translation are returned unchanged.
+func ErrorMessage(loc Locale, code, message string) string {
+	if loc == sourceLocale {
+		return message
+	}
+	if msg, ok := catalogs[loc]["msg."+message]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[loc]["error."+code]; ok {
+		return msg
+	}
+	return message
+}
+
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx carrying the locale
+func WithLocale(ctx context.Context, loc Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, loc)
+}
+
+// FromContext returns the locale carried by ctx, or the default locale
+func FromContext(ctx context.Context) Locale {
+	if loc, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return loc
+	}
+	return DefaultLocale
+}
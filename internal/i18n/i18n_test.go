@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected Locale
+	}{
+		{"", DefaultLocale},
+		{"en-US,en;q=0.9,ko;q=0.8", English},
+		{"ko-KR,ko;q=0.9,en-US;q=0.8", Korean},
+		{"ja,en;q=0.5", English},
+		{"en;q=0.3,ko;q=0.7", Korean},
+		{"fr, de", DefaultLocale},
+		{"en;q=0", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestT_Fallback(t *testing.T) {
+	assert.Equal(t, "Trial Balance", T(English, "report_type.trial_balance"))
+	assert.Equal(t, "합계잔액시산표", T(Korean, "report_type.trial_balance"))
+	// Missing English entries fall back to the default locale, then the key
+	assert.Equal(t, "인증이 필요합니다", T(English, "error.AUTH_001"))
+	assert.Equal(t, "no.such.key", T(English, "no.such.key"))
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "Posted", Label(English, "voucher_status", "posted"))
+	assert.Equal(t, "전기완료", Label(Korean, "voucher_status", "posted"))
+	assert.Equal(t, "", Label(Korean, "voucher_status", "unknown"))
+}
+
+func TestErrorMessage(t *testing.T) {
+	// Exact message translation wins over the code's generic message
+	assert.Equal(t, "전표를 찾을 수 없습니다", ErrorMessage(Korean, "RES_001", "Voucher not found"))
+	assert.Equal(t, "요청한 리소스를 찾을 수 없습니다", ErrorMessage(Korean, "RES_001", "Widget not found"))
+	assert.Equal(t, "Custom failure", ErrorMessage(Korean, "UNKNOWN", "Custom failure"))
+	// English is the source language
+	assert.Equal(t, "Voucher not found", ErrorMessage(English, "RES_001", "Voucher not found"))
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, DefaultLocale, FromContext(context.Background()))
+	assert.Equal(t, English, FromContext(WithLocale(context.Background(), English)))
+}
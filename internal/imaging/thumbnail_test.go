@@ -0,0 +1,94 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment with the orientation tag after the SOI marker
+func withOrientation(jpg []byte, orientation byte) []byte {
+	tiff := []byte("MM\x00\x2A\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00")
+	tiff[19] = orientation
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	length := len(segment) + 2
+	app1 := append([]byte{0xFF, 0xE1, byte(length >> 8), byte(length)}, segment...)
+	return append(append(append([]byte{}, jpg[:2]...), app1...), jpg[2:]...)
+}
+
+func TestThumbnail_FitsBox(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	data, err := Thumbnail(encodePNG(t, src), 200)
+	require.NoError(t, err)
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 200, cfg.Width)
+	assert.Equal(t, 100, cfg.Height)
+}
+
+func TestThumbnail_KeepsSmallImages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	data, err := Thumbnail(encodePNG(t, src), 200)
+	require.NoError(t, err)
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 40, cfg.Width)
+	assert.Equal(t, 30, cfg.Height)
+}
+
+func TestThumbnail_FlattensTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 10, 10)) // fully transparent
+	data, err := Thumbnail(encodePNG(t, src), 200)
+	require.NoError(t, err)
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	r, g, b, _ := img.At(5, 5).RGBA()
+	assert.Greater(t, r>>8, uint32(0xF0))
+	assert.Greater(t, g>>8, uint32(0xF0))
+	assert.Greater(t, b>>8, uint32(0xF0))
+}
+
+func TestThumbnail_AppliesEXIFOrientation(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 60, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 60; x++ {
+			src.Set(x, y, color.White)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, src, nil))
+
+	data, err := Thumbnail(withOrientation(buf.Bytes(), 6), 200)
+	require.NoError(t, err)
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 20, cfg.Width, "rotated 90° clockwise")
+	assert.Equal(t, 60, cfg.Height)
+}
+
+func TestThumbnail_Rejects(t *testing.T) {
+	_, err := Thumbnail([]byte("%PDF-1.7"), 200)
+	assert.ErrorIs(t, err, ErrUnsupportedImage)
+
+	// A GIF header claiming 65535 x 65535 pixels
+	_, err = Thumbnail([]byte("GIF89a\xFF\xFF\xFF\xFF\x00\x00\x00"), 200)
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+}
@@ -0,0 +1,134 @@
+// Package mailtemplate implements the placeholder language of email templates.
+//
+// A template is plain text in which {{name}} is replaced by the value of the
+// variable name; spaces inside the braces are ignored and names are made of
+// lowercase letters, digits and underscores. {{{{ stands for a literal {{.
+// There are no expressions, functions or loops, so a template edited by a
+// company can only place the values it is given.
+package mailtemplate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrSyntax is returned for templates with an unclosed or malformed placeholder
+	ErrSyntax = errors.New("invalid template syntax")
+	// ErrUnknownVariable is returned for placeholders of variables that are not allowed
+	ErrUnknownVariable = errors.New("unknown template variable")
+)
+
+// MaxLength is the maximum length of a template in bytes
+const MaxLength = 20000
+
+// segment is literal text, or a placeholder when variable is set
+type segment struct {
+	text     string
+	variable string
+}
+
+// Template is a parsed template
+type Template struct {
+	segments []segment
+}
+
+// Parse parses a template
+func Parse(src string) (*Template, error) {
+	if len(src) > MaxLength {
+		return nil, fmt.Errorf("%w: longer than %d bytes", ErrSyntax, MaxLength)
+	}
+
+	t := &Template{}
+	var text strings.Builder
+	for {
+		i := strings.Index(src, "{{")
+		if i < 0 {
+			text.WriteString(src)
+			break
+		}
+		text.WriteString(src[:i])
+		src = src[i+2:]
+		if strings.HasPrefix(src, "{{") {
+			text.WriteString("{{")
+			src = src[2:]
+			continue
+		}
+
+		end := strings.Index(src, "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed placeholder", ErrSyntax)
+		}
+		name := strings.TrimSpace(src[:end])
+		if !isName(name) {
+			return nil, fmt.Errorf("%w: placeholder {{%s}}", ErrSyntax, src[:end])
+		}
+		if text.Len() > 0 {
+			t.segments = append(t.segments, segment{text: text.String()})
+			text.Reset()
+		}
+		t.segments = append(t.segments, segment{variable: name})
+		src = src[end+2:]
+	}
+	if text.Len() > 0 {
+		t.segments = append(t.segments, segment{text: text.String()})
+	}
+	return t, nil
+}
+
+// isName reports whether s is a valid variable name
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Variables returns the names of the variables used, in order of first use
+func (t *Template) Variables() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, s := range t.segments {
+		if s.variable != "" && !seen[s.variable] {
+			seen[s.variable] = true
+			names = append(names, s.variable)
+		}
+	}
+	return names
+}
+
+// Check returns ErrUnknownVariable if the template uses a variable not in allowed
+func (t *Template) Check(allowed []string) error {
+	for _, name := range t.Variables() {
+		found := false
+		for _, a := range allowed {
+			if a == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %s", ErrUnknownVariable, name)
+		}
+	}
+	return nil
+}
+
+// Render substitutes the variables; variables without a value are left empty
+func (t *Template) Render(vars map[string]string) string {
+	var b strings.Builder
+	for _, s := range t.segments {
+		if s.variable != "" {
+			b.WriteString(vars[s.variable])
+			continue
+		}
+		b.WriteString(s.text)
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package mailtemplate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/mailtemplate"
+)
+
+func TestParse_Render(t *testing.T) {
+	tmpl, err := mailtemplate.Parse("{{ user_name }} 님, {{company_name}}에 초대합니다. {{{{literal}} {{user_name}}")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user_name", "company_name"}, tmpl.Variables())
+	assert.Equal(t, "홍길동 님, 케이랩에 초대합니다. {{literal}} 홍길동",
+		tmpl.Render(map[string]string{"user_name": "홍길동", "company_name": "케이랩"}))
+	assert.Equal(t, " 님, 에 초대합니다. {{literal}} ", tmpl.Render(nil))
+
+	// Values are never interpreted as templates
+	assert.Equal(t, "{{company_name}} 님, 에 초대합니다. {{literal}} {{company_name}}",
+		tmpl.Render(map[string]string{"user_name": "{{company_name}}"}))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, src := range []string{"{{name", "{{}}", "{{ .Name }}", "{{name | upper}}", "{{Name}}"} {
+		t.Run(src, func(t *testing.T) {
+			_, err := mailtemplate.Parse(src)
+			assert.ErrorIs(t, err, mailtemplate.ErrSyntax)
+		})
+	}
+}
+
+func TestTemplate_Check(t *testing.T) {
+	tmpl, err := mailtemplate.Parse("{{invite_link}} {{password}}")
+	require.NoError(t, err)
+	assert.ErrorIs(t, tmpl.Check([]string{"invite_link"}), mailtemplate.ErrUnknownVariable)
+	assert.NoError(t, tmpl.Check([]string{"invite_link", "password"}))
+}
@@ -111,6 +111,12 @@ func RequireAdmin() gin.HandlerFunc {
 	return RequireRoles("admin")
 }
 
+// RequireSuperAdmin middleware checks if the user is a platform operator.
+// Company admins are not super admins.
+func RequireSuperAdmin() gin.HandlerFunc {
+	return RequireRoles("super_admin")
+}
+
 // abortWithError is a helper to abort with a standardized error response
 func abortWithError(c *gin.Context, status int, code, message string) {
 	c.AbortWithStatusJSON(status, gin.H{
@@ -412,6 +412,30 @@ func TestRequireAdmin_NotAdmin(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, w.Code)
 }
 
+func TestRequireSuperAdmin(t *testing.T) {
+	for roles, status := range map[string]int{
+		"super_admin": http.StatusOK,
+		"admin":       http.StatusForbidden,
+	} {
+		roles, status := roles, status
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			appctx.SetRoles(c, []string{"user", roles})
+			c.Next()
+		})
+		router.Use(RequireSuperAdmin())
+		router.GET("/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, roles)
+	}
+}
+
 // =============================================================================
 // Integration Tests - Full Auth Flow
 // =============================================================================
@@ -26,7 +26,10 @@ func CORS(cfg *config.CORSConfig) gin.HandlerFunc {
 	// Pre-compute header values
 	methodsHeader := strings.Join(cfg.AllowedMethods, ", ")
 	headersHeader := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeader := strings.Join(cfg.ExposedHeaders, ", ")
 	maxAgeHeader := strconv.Itoa(cfg.MaxAge)
+	// Browsers reject credentials for the wildcard origin
+	allowCredentials := cfg.AllowCredentials && !allowAll
 
 	return func(c *gin.Context) {
 		origin := c.GetHeader("Origin")
@@ -36,14 +39,19 @@ func CORS(cfg *config.CORSConfig) gin.HandlerFunc {
 			c.Header("Access-Control-Allow-Origin", "*")
 		} else if allowedOriginsSet[origin] {
 			c.Header("Access-Control-Allow-Origin", origin)
-			c.Header("Vary", "Origin")
+			c.Writer.Header().Add("Vary", "Origin")
 		}
 
 		// Set CORS headers
 		c.Header("Access-Control-Allow-Methods", methodsHeader)
 		c.Header("Access-Control-Allow-Headers", headersHeader)
 		c.Header("Access-Control-Max-Age", maxAgeHeader)
-		c.Header("Access-Control-Allow-Credentials", "true")
+		if exposedHeader != "" {
+			c.Header("Access-Control-Expose-Headers", exposedHeader)
+		}
+		if allowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
 
 		// Handle preflight requests
 		if c.Request.Method == http.MethodOptions {
@@ -60,7 +68,10 @@ func DefaultCORSConfig() *config.CORSConfig {
 	return &config.CORSConfig{
 		AllowedOrigins: []string{"http://localhost:3000", "http://localhost:5173"},
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowedHeaders: []string{"Authorization", "Content-Type", "X-Request-ID"},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "X-Request-ID", HeaderReadPrimary},
 		MaxAge:         86400,
+
+		AllowCredentials: true,
+		ExposedHeaders:   []string{"X-Request-ID", "Content-Disposition"},
 	}
 }
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saintgo7/saas-kerp/internal/database"
+)
+
+// HeaderReadPrimary asks for the reads of a GET request to be served by the primary
+// database, e.g. when a client reloads a record right after changing it
+const HeaderReadPrimary = "X-Read-Primary"
+
+// DBRouting sends every query of a write request (POST, PUT, PATCH, DELETE) to the
+// primary database so that workflow actions check the state they are about to
+// change against the latest data. Reads of other requests may be served by a
+// read replica unless the X-Read-Primary header is true.
+func DBRouting() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if usesPrimary(c) {
+			c.Request = c.Request.WithContext(database.WithPrimary(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+func usesPrimary(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		primary, _ := strconv.ParseBool(c.GetHeader(HeaderReadPrimary))
+		return primary
+	}
+	return true
+}
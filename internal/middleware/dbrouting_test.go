@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/database"
+)
+
+// =============================================================================
+// DB Routing Middleware Tests
+// =============================================================================
+
+func TestDBRouting(t *testing.T) {
+	router := gin.New()
+	router.Use(DBRouting())
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"primary": database.UsesPrimary(c.Request.Context())})
+	}
+	router.GET("/vouchers", handler)
+	router.POST("/vouchers/:id/approve", handler)
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		header   string
+		expected string
+	}{
+		{"list reads from replica", http.MethodGet, "/vouchers", "", `{"primary":false}`},
+		{"read primary header", http.MethodGet, "/vouchers", "true", `{"primary":true}`},
+		{"invalid header ignored", http.MethodGet, "/vouchers", "yes please", `{"primary":false}`},
+		{"workflow action uses primary", http.MethodPost, "/vouchers/1/approve", "", `{"primary":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderReadPrimary, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expected, w.Body.String())
+		})
+	}
+}
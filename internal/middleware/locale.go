@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// Locale middleware negotiates the response locale from Accept-Language (or a
+// ?lang= override), stores it in the gin and request contexts, and localizes the
+// message of JSON error responses.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		loc, ok := i18n.Parse(c.Query("lang"))
+		if !ok {
+			loc = i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+		}
+		appctx.SetLocale(c, loc)
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), loc))
+		c.Header("Content-Language", string(loc))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		w := &localizingWriter{ResponseWriter: c.Writer, locale: loc}
+		c.Writer = w
+		c.Next()
+		w.flush()
+	}
+}
+
+// localizingWriter holds back JSON error bodies so their message can be translated
+type localizingWriter struct {
+	gin.ResponseWriter
+	locale i18n.Locale
+	body   bytes.Buffer
+}
+
+// buffering reports whether the response is a JSON error body
+func (w *localizingWriter) buffering() bool {
+	return w.Status() >= http.StatusBadRequest &&
+		strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+}
+
+func (w *localizingWriter) Write(data []byte) (int, error) {
+	if w.buffering() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *localizingWriter) WriteString(s string) (int, error) {
+	if w.buffering() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// flush writes the held back error body with its message localized
+func (w *localizingWriter) flush() {
+	if w.body.Len() == 0 {
+		return
+	}
+	_, _ = w.ResponseWriter.Write(localizeErrorBody(w.body.Bytes(), w.locale))
+}
+
+// localizeErrorBody translates error.message of a {"error": {"code", "message"}} body.
+// Bodies of any other shape are returned unchanged.
+func localizeErrorBody(data []byte, loc i18n.Locale) []byte {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return data
+	}
+	var errBody map[string]json.RawMessage
+	if err := json.Unmarshal(body["error"], &errBody); err != nil {
+		return data
+	}
+	var code, message string
+	if json.Unmarshal(errBody["code"], &code) != nil || json.Unmarshal(errBody["message"], &message) != nil {
+		return data
+	}
+
+	localized := i18n.ErrorMessage(loc, code, message)
+	if localized == message {
+		return data
+	}
+	errBody["message"], _ = json.Marshal(localized)
+	body["error"], _ = json.Marshal(errBody)
+	out, err := json.Marshal(body)
+	if err != nil {
+		return data
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+)
+
+// =============================================================================
+// Locale Middleware Tests
+// =============================================================================
+
+func TestLocale_Negotiation(t *testing.T) {
+	router := gin.New()
+	router.Use(Locale())
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"locale":  appctx.GetLocale(c),
+			"request": i18n.FromContext(c.Request.Context()),
+		})
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		header   string
+		expected string
+	}{
+		{"default", "/test", "", "ko"},
+		{"accept-language", "/test", "en-US,en;q=0.9", "en"},
+		{"query override", "/test?lang=ko", "en-US", "ko"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.expected, body["locale"])
+			assert.Equal(t, tt.expected, body["request"])
+			assert.Equal(t, tt.expected, w.Header().Get("Content-Language"))
+		})
+	}
+}
+
+func TestLocale_ErrorMessages(t *testing.T) {
+	router := gin.New()
+	router.Use(Locale())
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   gin.H{"code": "RES_001", "message": "Voucher not found"},
+		})
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Language", "ko-KR")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"success":false,"error":{"code":"RES_001","message":"전표를 찾을 수 없습니다"}}`, w.Body.String())
+
+	req = httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Language", "en")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.JSONEq(t, `{"success":false,"error":{"code":"RES_001","message":"Voucher not found"}}`, w.Body.String())
+}
@@ -76,6 +76,7 @@ func SkipLogging(path string) bool {
 		"/health":       true,
 		"/health/ready": true,
 		"/health/live":  true,
+		"/health/redis": true,
 		"/metrics":      true,
 		"/favicon.ico":  true,
 	}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	"github.com/saintgo7/saas-kerp/internal/dto"
+	"github.com/saintgo7/saas-kerp/internal/validation"
+)
+
+// ProblemDetails middleware answers errors as RFC 7807 problem details
+// (application/problem+json) to clients that accept them, converting the
+// error envelope of handlers and middleware. Other clients keep receiving the
+// envelope. It must run before Locale so that it converts localized messages.
+func ProblemDetails() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept")
+		if !acceptsProblem(c.GetHeader("Accept")) {
+			c.Next()
+			return
+		}
+
+		w := &problemWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		w.flush(c)
+	}
+}
+
+// acceptsProblem reports whether an Accept header lists problem details
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == dto.ProblemContentType && params["q"] != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// problemWriter holds back JSON error bodies so they can be converted
+type problemWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// buffering reports whether the response is a JSON error body
+func (w *problemWriter) buffering() bool {
+	return w.Status() >= http.StatusBadRequest &&
+		strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+}
+
+func (w *problemWriter) Write(data []byte) (int, error) {
+	if w.buffering() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *problemWriter) WriteString(s string) (int, error) {
+	if w.buffering() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// flush writes the held back error body as problem details, or unchanged if
+// it is not an error envelope
+func (w *problemWriter) flush(c *gin.Context) {
+	if w.body.Len() == 0 {
+		return
+	}
+	problem, ok := envelopeProblem(w.body.Bytes(), w.Status(), c.Request.URL.Path)
+	if !ok {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+	problem.RequestID = appctx.GetRequestID(c)
+	data, err := json.Marshal(problem)
+	if err != nil {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+	w.Header().Set("Content-Type", dto.ProblemContentType)
+	_, _ = w.ResponseWriter.Write(data)
+}
+
+// envelopeProblem converts a {"error": {"code", "message", ...}, "data"} body
+// to problem details. The details of an error are a string in dto.Response and
+// a list of fields in response.Response; both are taken.
+func envelopeProblem(data []byte, status int, instance string) (*dto.Problem, bool) {
+	var body struct {
+		Error *struct {
+			Code    string                  `json:"code"`
+			Message string                  `json:"message"`
+			Details json.RawMessage         `json:"details"`
+			Fields  []validation.FieldError `json:"fields"`
+		} `json:"error"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Error == nil || body.Error.Code == "" {
+		return nil, false
+	}
+
+	info := &dto.ErrorInfo{Code: body.Error.Code, Message: body.Error.Message, Fields: body.Error.Fields}
+	if len(body.Error.Details) > 0 {
+		var fields []validation.FieldError
+		if json.Unmarshal(body.Error.Details, &info.Details) != nil && json.Unmarshal(body.Error.Details, &fields) == nil {
+			info.Fields = append(info.Fields, fields...)
+		}
+	}
+	problem := dto.NewProblem(status, info, instance)
+	if len(body.Data) > 0 && string(body.Data) != "null" {
+		problem.Data = body.Data
+	}
+	return problem, true
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/dto"
+)
+
+// =============================================================================
+// Problem Details Middleware Tests
+// =============================================================================
+
+func newProblemRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(ProblemDetails(), Locale())
+	router.GET("/vouchers/:id", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse(dto.ErrCodeNotFound, "Voucher not found"))
+	})
+	router.GET("/fields", func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "VAL_001",
+				"message": "Validation failed",
+				"details": []gin.H{{"field": "password", "message": "too short"}},
+			},
+		})
+	})
+	router.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{"id": 1}))
+	})
+	router.GET("/text", func(c *gin.Context) {
+		c.String(http.StatusBadRequest, "bad request")
+	})
+	return router
+}
+
+func TestProblemDetails_Negotiation(t *testing.T) {
+	router := newProblemRouter()
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"no accept", "", "application/json; charset=utf-8"},
+		{"json", "application/json", "application/json; charset=utf-8"},
+		{"problem", "application/problem+json", dto.ProblemContentType},
+		{"problem among others", "application/json;q=0.5, application/problem+json", dto.ProblemContentType},
+		{"problem refused", "application/problem+json;q=0", "application/json; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/vouchers/1", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Values("Vary"), "Accept")
+		})
+	}
+}
+
+func TestProblemDetails_ConvertsLocalizedErrors(t *testing.T) {
+	router := newProblemRouter()
+
+	req := httptest.NewRequest("GET", "/vouchers/1", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	req.Header.Set("Accept-Language", "ko-KR")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{
+		"type": "urn:kerp:error:RES_001",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "전표를 찾을 수 없습니다",
+		"instance": "/vouchers/1",
+		"code": "RES_001"
+	}`, w.Body.String())
+}
+
+func TestProblemDetails_FieldDetails(t *testing.T) {
+	router := newProblemRouter()
+
+	req := httptest.NewRequest("GET", "/fields", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	req.Header.Set("Accept-Language", "en")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"type": "urn:kerp:error:VAL_001",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "Validation failed",
+		"instance": "/fields",
+		"code": "VAL_001",
+		"fields": [{"field": "password", "message": "too short"}]
+	}`, w.Body.String())
+}
+
+func TestProblemDetails_PassesThroughOtherResponses(t *testing.T) {
+	router := newProblemRouter()
+
+	req := httptest.NewRequest("GET", "/ok", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"success":true,"data":{"id":1}}`, w.Body.String())
+
+	req = httptest.NewRequest("GET", "/text", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "bad request", w.Body.String())
+}
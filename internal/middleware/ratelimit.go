@@ -3,12 +3,14 @@ package middleware
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
 	"github.com/saintgo7/saas-kerp/internal/config"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
 )
 
 // RateLimiter implements a simple in-memory rate limiter using token bucket algorithm
@@ -37,6 +39,20 @@ func NewRateLimiter(rate, burst int) *RateLimiter {
 	}
 }
 
+// SetLimits changes the rate and burst. Clients keep their tokens, up to the new burst.
+func (rl *RateLimiter) SetLimits(rate, burst int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.rate = rate
+	rl.burst = burst
+	for _, b := range rl.buckets {
+		if b.tokens > float64(burst) {
+			b.tokens = float64(burst)
+		}
+	}
+}
+
 // Allow checks if a request from the given key should be allowed
 func (rl *RateLimiter) Allow(key string) bool {
 	rl.mu.Lock()
@@ -86,12 +102,30 @@ func (rl *RateLimiter) cleanupOldBuckets() {
 	}
 }
 
-// RateLimit middleware applies rate limiting based on client IP
-func RateLimit(cfg *config.RateLimitConfig) gin.HandlerFunc {
-	limiter := NewRateLimiter(cfg.RequestsPerSecond, cfg.Burst)
+// RateLimitSettings holds rate limiting that can be changed while serving,
+// for configuration reloads
+type RateLimitSettings struct {
+	enabled atomic.Bool
+	limiter *RateLimiter
+}
 
+// NewRateLimitSettings creates rate limit settings from the configuration
+func NewRateLimitSettings(cfg *config.RateLimitConfig) *RateLimitSettings {
+	s := &RateLimitSettings{limiter: NewRateLimiter(cfg.RequestsPerSecond, cfg.Burst)}
+	s.enabled.Store(cfg.Enabled)
+	return s
+}
+
+// Apply switches to the settings of the configuration
+func (s *RateLimitSettings) Apply(cfg *config.RateLimitConfig) {
+	s.limiter.SetLimits(cfg.RequestsPerSecond, cfg.Burst)
+	s.enabled.Store(cfg.Enabled)
+}
+
+// Middleware applies rate limiting based on client IP, or the user when authenticated
+func (s *RateLimitSettings) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if !cfg.Enabled {
+		if !s.enabled.Load() {
 			c.Next()
 			return
 		}
@@ -104,18 +138,8 @@ func RateLimit(cfg *config.RateLimitConfig) gin.HandlerFunc {
 			key = userID.String()
 		}
 
-		if !limiter.Allow(key) {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "RATE_001",
-					"message": "Rate limit exceeded",
-				},
-				"meta": gin.H{
-					"request_id": appctx.GetRequestID(c),
-					"retry_after": 1,
-				},
-			})
+		if !s.limiter.Allow(key) {
+			abortRateLimited(c)
 			return
 		}
 
@@ -123,6 +147,11 @@ func RateLimit(cfg *config.RateLimitConfig) gin.HandlerFunc {
 	}
 }
 
+// RateLimit middleware applies rate limiting based on client IP
+func RateLimit(cfg *config.RateLimitConfig) gin.HandlerFunc {
+	return NewRateLimitSettings(cfg).Middleware()
+}
+
 // RateLimitByKey middleware applies rate limiting with a custom key function
 func RateLimitByKey(cfg *config.RateLimitConfig, keyFunc func(*gin.Context) string) gin.HandlerFunc {
 	limiter := NewRateLimiter(cfg.RequestsPerSecond, cfg.Burst)
@@ -135,20 +164,25 @@ func RateLimitByKey(cfg *config.RateLimitConfig, keyFunc func(*gin.Context) stri
 
 		key := keyFunc(c)
 		if !limiter.Allow(key) {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "RATE_001",
-					"message": "Rate limit exceeded",
-				},
-				"meta": gin.H{
-					"request_id": appctx.GetRequestID(c),
-					"retry_after": 1,
-				},
-			})
+			abortRateLimited(c)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// abortRateLimited responds 429 Too Many Requests
+func abortRateLimited(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    apperrors.CodeRateLimited,
+			"message": "Rate limit exceeded",
+		},
+		"meta": gin.H{
+			"request_id":  appctx.GetRequestID(c),
+			"retry_after": 1,
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+// =============================================================================
+// RateLimitSettings Tests
+// =============================================================================
+
+func TestRateLimitSettings_Apply(t *testing.T) {
+	settings := NewRateLimitSettings(&config.RateLimitConfig{Enabled: false, RequestsPerSecond: 1, Burst: 1})
+	router := gin.New()
+	router.Use(settings.Middleware())
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/test", nil))
+		return w.Code
+	}
+
+	// Disabled: nothing is limited
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, request())
+	}
+
+	// Enabled by a reload
+	settings.Apply(&config.RateLimitConfig{Enabled: true, RequestsPerSecond: 1, Burst: 2})
+	assert.Equal(t, http.StatusOK, request())
+	assert.Equal(t, http.StatusOK, request())
+	assert.Equal(t, http.StatusTooManyRequests, request())
+
+	// Disabled again
+	settings.Apply(&config.RateLimitConfig{Enabled: false, RequestsPerSecond: 1, Burst: 2})
+	assert.Equal(t, http.StatusOK, request())
+}
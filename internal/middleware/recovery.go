@@ -1,52 +1,180 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"expvar"
+	"net"
 	"net/http"
+	"os"
+	"runtime"
 	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
 )
 
-// Recovery middleware recovers from panics and logs the error
-func Recovery(logger *zap.Logger) gin.HandlerFunc {
+// panics counts recovered panics by route; served as http_panics on /debug/vars
+var panics = expvar.NewMap("http_panics")
+
+// PanicCount returns the number of panics recovered since the instance started
+func PanicCount() int64 {
+	var total int64
+	panics.Do(func(kv expvar.KeyValue) {
+		if counter, ok := kv.Value.(*expvar.Int); ok {
+			total += counter.Value()
+		}
+	})
+	return total
+}
+
+// PanicReport describes a panic recovered from a request
+type PanicReport struct {
+	Value         interface{}
+	Stack         []byte          // as printed by runtime/debug.Stack
+	Frames        []runtime.Frame // innermost first, starting at the panic
+	CorrelationID string          // the request ID, returned to the client
+	Method        string
+	Route         string // the route pattern, e.g. /api/v1/vouchers/:id
+	URL           string
+	UserID        string
+	CompanyID     string
+	Time          time.Time
+}
+
+// PanicReporter sends recovered panics to an error tracker. ReportPanic runs
+// on a goroutine of its own with a context detached from the request, so that
+// it does not delay the response; it must bound its own duration.
+type PanicReporter interface {
+	ReportPanic(ctx context.Context, report *PanicReport)
+}
+
+// Recovery middleware recovers from panics, logs them with their stack,
+// reports them to the reporter (nil reports nowhere) and responds with a 500
+// carrying the request ID so that support can correlate the failure. It
+// replaces gin's default recovery.
+func Recovery(logger *zap.Logger, reporter PanicReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
-			if err := recover(); err != nil {
-				// Get stack trace
-				stack := debug.Stack()
-
-				// Get request-scoped logger or use default
-				reqLogger := appctx.GetLogger(c)
-				if reqLogger == nil {
-					reqLogger = logger
-				}
-
-				// Log the panic
-				reqLogger.Error("panic recovered",
-					zap.Any("error", err),
-					zap.String("stack", string(stack)),
-					zap.String("request_id", appctx.GetRequestID(c)),
-					zap.String("path", c.Request.URL.Path),
-					zap.String("method", c.Request.Method),
-				)
-
-				// Return 500 error
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"error": gin.H{
-						"code":    "SRV_001",
-						"message": "Internal server error",
-					},
-					"meta": gin.H{
-						"request_id": appctx.GetRequestID(c),
-					},
-				})
+			err := recover()
+			if err == nil {
+				return
+			}
+			// net/http aborts the response silently for this one
+			if err == http.ErrAbortHandler {
+				panic(err)
 			}
+
+			stack := debug.Stack()
+			report := &PanicReport{
+				Value:         err,
+				Stack:         stack,
+				Frames:        panicFrames(),
+				CorrelationID: appctx.GetRequestID(c),
+				Method:        c.Request.Method,
+				Route:         c.FullPath(),
+				URL:           c.Request.URL.String(),
+				Time:          time.Now().UTC(),
+			}
+			if userID := appctx.GetUserID(c); userID != uuid.Nil {
+				report.UserID = userID.String()
+			}
+			if companyID := appctx.GetCompanyID(c); companyID != uuid.Nil {
+				report.CompanyID = companyID.String()
+			}
+			route := report.Route
+			if route == "" {
+				route = "unmatched"
+			}
+			panics.Add(route, 1)
+
+			// Get request-scoped logger or use default
+			reqLogger := appctx.GetLogger(c)
+			if reqLogger == nil {
+				reqLogger = logger
+			}
+
+			// Log the panic
+			reqLogger.Error("panic recovered",
+				zap.Any("error", err),
+				zap.String("stack", string(stack)),
+				zap.String("request_id", report.CorrelationID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("route", report.Route),
+				zap.String("method", c.Request.Method),
+			)
+
+			if reporter != nil {
+				go reporter.ReportPanic(context.Background(), report)
+			}
+
+			// The client is gone; there is no one to respond to
+			if brokenPipe(err) {
+				c.Abort()
+				return
+			}
+
+			// Return 500 error
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    apperrors.CodeInternal,
+					"message": "Internal server error",
+				},
+				"meta": gin.H{
+					"request_id": report.CorrelationID,
+				},
+			})
 		}()
 
 		c.Next()
 	}
 }
+
+// panicFrames returns the stack of the panicking goroutine from the function
+// that panicked, skipping the runtime's panic handling and this middleware
+func panicFrames() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var all []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		all = append(all, frame)
+		if !more {
+			break
+		}
+	}
+	// The frame after runtime.gopanic is the one that panicked
+	for i, frame := range all {
+		if frame.Function == "runtime.gopanic" {
+			return all[i+1:]
+		}
+	}
+	return all
+}
+
+// brokenPipe reports whether the panic is a write to a closed client connection
+func brokenPipe(err interface{}) bool {
+	e, ok := err.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !errors.As(e, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if errors.As(opErr, &syscallErr) {
+		msg := strings.ToLower(syscallErr.Error())
+		return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
+	}
+	return false
+}
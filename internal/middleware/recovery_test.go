@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingReporter struct {
+	reports chan *PanicReport
+}
+
+func (r *recordingReporter) ReportPanic(ctx context.Context, report *PanicReport) {
+	r.reports <- report
+}
+
+func TestRecovery_ReportsPanic(t *testing.T) {
+	reporter := &recordingReporter{reports: make(chan *PanicReport, 1)}
+	router := gin.New()
+	router.Use(RequestID(), Recovery(zap.NewNop(), reporter))
+	router.GET("/vouchers/:id", func(c *gin.Context) {
+		panic("boom")
+	})
+	before := panics.Get("/vouchers/:id")
+
+	req := httptest.NewRequest(http.MethodGet, "/vouchers/1", nil)
+	req.Header.Set(HeaderRequestID, "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+		Meta struct {
+			RequestID string `json:"request_id"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "SRV_001", body.Error.Code)
+	assert.Equal(t, "req-1", body.Meta.RequestID)
+
+	select {
+	case report := <-reporter.reports:
+		assert.Equal(t, "boom", report.Value)
+		assert.Equal(t, "req-1", report.CorrelationID)
+		assert.Equal(t, "/vouchers/:id", report.Route)
+		require.NotEmpty(t, report.Frames)
+		// The stack starts at the handler that panicked
+		assert.True(t, strings.HasSuffix(report.Frames[0].Function, "TestRecovery_ReportsPanic.func1"), report.Frames[0].Function)
+	case <-time.After(time.Second):
+		t.Fatal("panic was not reported")
+	}
+
+	if before == nil {
+		assert.Equal(t, "1", panics.Get("/vouchers/:id").String())
+	}
+	assert.Positive(t, PanicCount())
+}
+
+func TestRecovery_NoReporter(t *testing.T) {
+	router := gin.New()
+	router.Use(Recovery(zap.NewNop(), nil))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+	appctx "github.com/saintgo7/saas-kerp/internal/context"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+)
+
+// SecurityHeaders middleware sets standard HTTP security headers
+func SecurityHeaders(cfg *config.SecurityConfig) gin.HandlerFunc {
+	// Pre-compute header values
+	hstsHeader := ""
+	if cfg.HSTSMaxAge > 0 {
+		hstsHeader = "max-age=" + strconv.Itoa(cfg.HSTSMaxAge)
+		if cfg.HSTSIncludeSubdomains {
+			hstsHeader += "; includeSubDomains"
+		}
+	}
+
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		if cfg.FrameOptions != "" {
+			c.Header("X-Frame-Options", cfg.FrameOptions)
+		}
+		if cfg.ContentSecurityPolicy != "" {
+			c.Header("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		if cfg.ReferrerPolicy != "" {
+			c.Header("Referrer-Policy", cfg.ReferrerPolicy)
+		}
+
+		// HSTS is ignored over plain HTTP; behind a proxy trust its forwarded scheme
+		if hstsHeader != "" && (c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https") {
+			c.Header("Strict-Transport-Security", hstsHeader)
+		}
+
+		c.Next()
+	}
+}
+
+// BodyLimit middleware caps the request body size. Multipart uploads get the
+// larger upload limit; reads past the limit fail inside the handler's binding.
+func BodyLimit(cfg *config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		limit := cfg.MaxBodySize
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			limit = cfg.MaxUploadSize
+		}
+
+		// Reject declared oversize bodies before reading them
+		if c.Request.ContentLength > limit {
+			abortPayloadTooLarge(c, limit)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+func abortPayloadTooLarge(c *gin.Context, limit int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    apperrors.CodePayloadTooLarge,
+			"message": "Request body too large",
+		},
+		"meta": gin.H{
+			"request_id": appctx.GetRequestID(c),
+			"max_bytes":  limit,
+		},
+	})
+}
+
+// ArrayLimit caps the items of an array of the JSON bodies of a route: a member
+// of the body object, or the body itself when Field is empty
+type ArrayLimit struct {
+	Field string
+	Max   int
+}
+
+// JSONLimits middleware rejects JSON bodies nested deeper than the configured
+// depth or holding longer arrays than allowed, before handlers decode them.
+// limits are keyed by method and route, e.g. "POST /api/v1/vouchers". It reads
+// the body, so it must run after BodyLimit; bodies cut off at the size limit
+// are answered 413 here rather than failing the handler's binding.
+func JSONLimits(cfg *config.SecurityConfig, limits map[string]ArrayLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody || !isJSON(c.ContentType()) {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				abortPayloadTooLarge(c, tooLarge.Limit)
+				return
+			}
+			// Leave read errors to the handler's binding
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var limit *ArrayLimit
+		if l, ok := limits[c.Request.Method+" "+c.FullPath()]; ok {
+			limit = &l
+		}
+		if violation := checkJSONShape(body, cfg.MaxJSONDepth, cfg.MaxJSONArrayLength, limit); violation != nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    apperrors.CodePayloadTooComplex,
+					"message": violation.message,
+				},
+				"meta": gin.H{
+					"request_id": appctx.GetRequestID(c),
+					"limit":      violation.limit,
+				},
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// isJSON reports whether a media type is JSON, including +json types
+func isJSON(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+// shapeViolation is a limit a JSON body exceeds
+type shapeViolation struct {
+	message string
+	limit   int
+}
+
+// jsonContainer is an object or array being walked by checkJSONShape
+type jsonContainer struct {
+	array   bool
+	items   int
+	key     string // last member name read, in objects
+	wantKey bool   // the next token is a member name, in objects
+	field   string // member of the body object holding the container, or "" for the body
+	limited bool   // limit applies to the container
+}
+
+// checkJSONShape walks the tokens of a JSON body and returns the first limit
+// it exceeds. Malformed JSON is not a violation; the handler's binding rejects
+// it with the decoding error.
+func checkJSONShape(body []byte, maxDepth, maxArrayLength int, limit *ArrayLimit) *shapeViolation {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var stack []*jsonContainer
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+
+		delim, isDelim := tok.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		var parent *jsonContainer
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		if parent != nil && parent.wantKey {
+			parent.key, parent.wantKey = tok.(string), false
+			continue
+		}
+		if parent != nil {
+			if parent.array {
+				parent.items++
+				if parent.limited && parent.items > limit.Max {
+					name := parent.field
+					if name == "" {
+						name = "request body"
+					}
+					return &shapeViolation{message: "Too many items in " + name, limit: limit.Max}
+				}
+				if parent.items > maxArrayLength {
+					return &shapeViolation{message: "JSON array is too long", limit: maxArrayLength}
+				}
+			} else {
+				parent.wantKey = true
+			}
+		}
+
+		if isDelim {
+			container := &jsonContainer{array: delim == '[', wantKey: delim == '{'}
+			switch {
+			case parent == nil:
+				container.limited = limit != nil && limit.Field == ""
+			case len(stack) == 1 && !parent.array:
+				container.field = parent.key
+				container.limited = limit != nil && limit.Field == parent.key
+			}
+			stack = append(stack, container)
+			if len(stack) > maxDepth {
+				return &shapeViolation{message: "JSON is nested too deeply", limit: maxDepth}
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+	apperrors "github.com/saintgo7/saas-kerp/internal/errors"
+)
+
+// testSecurityConfig creates a test security configuration
+func testSecurityConfig() *config.SecurityConfig {
+	return &config.SecurityConfig{
+		HSTSMaxAge:            31536000,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "DENY",
+		ContentSecurityPolicy: "default-src 'none'",
+		ReferrerPolicy:        "no-referrer",
+		MaxBodySize:           16,
+		MaxUploadSize:         64,
+		MaxJSONDepth:          3,
+		MaxJSONArrayLength:    4,
+	}
+}
+
+// =============================================================================
+// SecurityHeaders Middleware Tests
+// =============================================================================
+
+func TestSecurityHeaders(t *testing.T) {
+	router := gin.New()
+	router.Use(SecurityHeaders(testSecurityConfig()))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+	// Plain HTTP: no HSTS
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+
+	req = httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+}
+
+// =============================================================================
+// BodyLimit Middleware Tests
+// =============================================================================
+
+func TestBodyLimit(t *testing.T) {
+	router := gin.New()
+	router.Use(BodyLimit(testSecurityConfig()))
+	router.POST("/test", func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		chunked     bool
+		wantStatus  int
+	}{
+		{"within limit", "application/json", `{"a":1}`, false, http.StatusOK},
+		{"declared oversize", "application/json", strings.Repeat("x", 32), false, http.StatusRequestEntityTooLarge},
+		{"undeclared oversize", "application/json", strings.Repeat("x", 32), true, http.StatusBadRequest},
+		{"multipart upload limit", "multipart/form-data; boundary=x", strings.Repeat("x", 32), false, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/test", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+// =============================================================================
+// JSONLimits Middleware Tests
+// =============================================================================
+
+func TestJSONLimits(t *testing.T) {
+	cfg := testSecurityConfig()
+	cfg.MaxBodySize = 1 << 10
+	limits := map[string]ArrayLimit{
+		"POST /vouchers":            {Field: "entries", Max: 2},
+		"PUT /vouchers/:id/entries": {Max: 2},
+	}
+
+	router := gin.New()
+	router.Use(BodyLimit(cfg), JSONLimits(cfg, limits))
+	echo := func(c *gin.Context) {
+		var body interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, body)
+	}
+	router.POST("/vouchers", echo)
+	router.PUT("/vouchers/:id/entries", echo)
+	router.POST("/other", echo)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{"within limits", "POST", "/vouchers", "application/json", `{"entries":[{"a":1},{"a":2}]}`, http.StatusOK, ""},
+		{"too deep", "POST", "/other", "application/json", `{"a":{"b":{"c":{}}}}`, http.StatusUnprocessableEntity, "JSON is nested too deeply"},
+		{"array too long", "POST", "/other", "application/json", `[1,2,3,4,5]`, http.StatusUnprocessableEntity, "JSON array is too long"},
+		{"nested array too long", "POST", "/other", "application/json", `{"a":[1,2,3,4,5]}`, http.StatusUnprocessableEntity, "JSON array is too long"},
+		{"route field limit", "POST", "/vouchers", "application/json", `{"entries":[{},{},{}]}`, http.StatusUnprocessableEntity, "Too many items in entries"},
+		{"route field limit applies to the member only", "POST", "/vouchers", "application/json", `{"tags":[1,2,3],"x":{"entries":[1,2,3]}}`, http.StatusOK, ""},
+		{"route body limit", "PUT", "/vouchers/1/entries", "application/json", `[{},{},{}]`, http.StatusUnprocessableEntity, "Too many items in request body"},
+		{"limit of another route", "POST", "/other", "application/json", `{"entries":[{},{},{}]}`, http.StatusOK, ""},
+		{"json suffix type", "POST", "/other", "application/merge-patch+json", `[1,2,3,4,5]`, http.StatusUnprocessableEntity, "JSON array is too long"},
+		{"not json", "POST", "/other", "text/plain", `[1,2,3,4,5]`, http.StatusOK, ""},
+		{"malformed json is left to binding", "POST", "/other", "application/json", `{"a":[1,2`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantMessage != "" {
+				var body struct {
+					Error struct {
+						Code    string `json:"code"`
+						Message string `json:"message"`
+					} `json:"error"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, apperrors.CodePayloadTooComplex, body.Error.Code)
+				assert.Equal(t, tt.wantMessage, body.Error.Message)
+			}
+		})
+	}
+}
+
+func TestJSONLimits_UndeclaredOversizeBody(t *testing.T) {
+	cfg := testSecurityConfig()
+	router := gin.New()
+	router.Use(BodyLimit(cfg), JSONLimits(cfg, nil))
+	router.POST("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"a":"`+strings.Repeat("x", 32)+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
@@ -0,0 +1,51 @@
+package migrate
+
+// douzoneAdapter reads the Excel/text exports of Douzone Smart A and iCUBE:
+// 계정과목및적요등록, 거래처등록, 전기분재무상태표/거래처별초기이월 and 일반전표입력.
+// Voucher lines carry 구분 (1 출금, 2 입금, 3 차변, 4 대변, 5 결차, 6 결대) with
+// separate 차변/대변 amount columns, and dates as 일자 or as 월 and 일.
+var douzoneAdapter Adapter = &tableAdapter{
+	format: FormatDouzone,
+	name:   "더존 Smart A / iCUBE",
+	columns: map[Dataset][]Column{
+		DatasetAccounts: {
+			{Field: fieldCode, Headers: []string{"코드", "계정코드", "계정과목코드"}, Required: true},
+			{Field: fieldName, Headers: []string{"계정과목", "계정과목명", "계정명"}, Required: true},
+			{Field: fieldNameEn, Headers: []string{"영문명", "영문계정과목명"}},
+			{Field: fieldType, Headers: []string{"구분", "계정구분", "대분류"}},
+			{Field: fieldCategory, Headers: []string{"성격", "중분류", "계정성격"}},
+		},
+		DatasetPartners: {
+			{Field: fieldCode, Headers: []string{"코드", "거래처코드"}, Required: true},
+			{Field: fieldName, Headers: []string{"거래처명", "거래처", "상호"}, Required: true},
+			{Field: fieldBusinessNumber, Headers: []string{"사업자등록번호", "사업자번호", "등록번호"}},
+			{Field: fieldPartnerType, Headers: []string{"거래처구분", "구분"}},
+			{Field: fieldRepresentative, Headers: []string{"대표자성명", "대표자명", "대표자"}},
+			{Field: fieldPhone, Headers: []string{"전화번호", "전화"}},
+			{Field: fieldFax, Headers: []string{"팩스번호", "팩스", "FAX"}},
+			{Field: fieldEmail, Headers: []string{"E-mail", "이메일", "전자우편"}},
+			{Field: fieldZipCode, Headers: []string{"우편번호"}},
+			{Field: fieldAddress, Headers: []string{"사업장주소", "주소"}},
+			{Field: fieldAddressDetail, Headers: []string{"상세주소", "나머지주소"}},
+		},
+		DatasetOpeningBalances: {
+			{Field: fieldAccountCode, Headers: []string{"코드", "계정코드"}, Required: true},
+			{Field: fieldPartnerCode, Headers: []string{"거래처코드", "코드"}}, // the second 코드 column
+			{Field: fieldDebit, Headers: []string{"차변", "차변잔액"}},
+			{Field: fieldCredit, Headers: []string{"대변", "대변잔액"}},
+			{Field: fieldAmount, Headers: []string{"금액", "잔액", "전기분금액"}},
+		},
+		DatasetVouchers: {
+			{Field: fieldDate, Headers: []string{"일자", "전표일자", "회계일자"}},
+			{Field: fieldMonth, Headers: []string{"월"}},
+			{Field: fieldDay, Headers: []string{"일"}},
+			{Field: fieldNumber, Headers: []string{"번호", "전표번호"}, Required: true},
+			{Field: fieldKind, Headers: []string{"구분"}},
+			{Field: fieldAccountCode, Headers: []string{"코드", "계정코드"}, Required: true},
+			{Field: fieldPartnerCode, Headers: []string{"거래처코드", "코드"}}, // the second 코드 column
+			{Field: fieldDescription, Headers: []string{"적요", "적요명"}},
+			{Field: fieldDebit, Headers: []string{"차변", "차변금액"}},
+			{Field: fieldCredit, Headers: []string{"대변", "대변금액"}},
+		},
+	},
+}
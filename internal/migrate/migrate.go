@@ -0,0 +1,276 @@
+// Package migrate parses export files of legacy Korean accounting packages
+// (Douzone Smart A/iCUBE, SemusaRang) into records that can be imported as
+// the chart of accounts, partners, opening balances and historical vouchers.
+//
+// Each package is handled by an Adapter that maps its column headers, account
+// classifications and voucher line kinds (출금/입금/차변/대변) onto a common
+// record model. Files are CSV or tab-separated text, as produced by the
+// packages' Excel/text export, in UTF-8 or CP949 (EUC-KR).
+//
+// Parsing never stops at a bad row: problems are collected as Issues with the
+// source row number so that a dry run can report every problem at once.
+package migrate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// Parse errors; problems within rows are reported as Issues instead
+var (
+	ErrUnknownFormat  = errors.New("unknown legacy import format")
+	ErrUnknownDataset = errors.New("unknown legacy import dataset")
+	ErrMissingColumn  = errors.New("required column is missing")
+	ErrEmptyFile      = errors.New("import file has no data rows")
+)
+
+// Format identifies the legacy accounting package that produced the file
+type Format string
+
+const (
+	FormatDouzone    Format = "douzone"    // 더존 Smart A / iCUBE
+	FormatSemusaRang Format = "semusarang" // 세무사랑 Pro
+)
+
+// Dataset is the kind of data contained in an import file
+type Dataset string
+
+const (
+	DatasetAccounts        Dataset = "accounts"
+	DatasetPartners        Dataset = "partners"
+	DatasetOpeningBalances Dataset = "opening_balances"
+	DatasetVouchers        Dataset = "vouchers"
+)
+
+// Datasets lists the datasets in the order they should be imported;
+// each one references records created by the ones before it
+var Datasets = []Dataset{DatasetAccounts, DatasetPartners, DatasetOpeningBalances, DatasetVouchers}
+
+// IsValid checks if the dataset is valid
+func (d Dataset) IsValid() bool {
+	switch d {
+	case DatasetAccounts, DatasetPartners, DatasetOpeningBalances, DatasetVouchers:
+		return true
+	}
+	return false
+}
+
+// Severity of an import issue
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // the import cannot be applied
+	SeverityWarning Severity = "warning" // the row is skipped or adjusted
+)
+
+// Issue is a problem found in a source row. Row is the 1-based line number in
+// the file including the header, or 0 for problems not tied to a row.
+type Issue struct {
+	Row      int      `json:"row"`
+	Field    string   `json:"field,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Options are the import wizard inputs needed to interpret a file
+type Options struct {
+	// Year is the calendar year of voucher dates exported as month and day only
+	Year int
+	// CashAccountCode is the counter account of 출금 (cash out) and 입금 (cash in) lines
+	CashAccountCode string
+}
+
+// DefaultCashAccountCode is 현금 in the standard Korean chart of accounts
+const DefaultCashAccountCode = "101"
+
+// Account is a chart of accounts row
+type Account struct {
+	Row      int
+	Code     string
+	Name     string
+	NameEn   string
+	Type     domain.AccountType
+	Category string
+}
+
+// Partner is a 거래처 row
+type Partner struct {
+	Row            int
+	Code           string
+	Name           string
+	BusinessNumber string
+	PartnerType    string // customer, vendor, both
+	Representative string
+	Phone          string
+	Fax            string
+	Email          string
+	ZipCode        string
+	Address        string
+	AddressDetail  string
+}
+
+// OpeningBalance is a 전기이월 balance of an account, optionally per partner.
+// Files with a single amount column set Amount, which is applied on the
+// account's normal balance side; negative amounts go to the other side.
+type OpeningBalance struct {
+	Row         int
+	AccountCode string
+	PartnerCode string
+	Debit       float64
+	Credit      float64
+	Amount      float64
+}
+
+// Voucher is a historical voucher grouped from its lines by date and number
+type Voucher struct {
+	Row    int
+	Date   time.Time
+	Number string
+	Lines  []VoucherLine
+}
+
+// VoucherLine is one debit or credit line of a voucher
+type VoucherLine struct {
+	Row         int
+	AccountCode string
+	PartnerCode string
+	Description string
+	Debit       float64
+	Credit      float64
+}
+
+// TotalDebit returns the sum of the debit lines
+func (v *Voucher) TotalDebit() float64 {
+	total := 0.0
+	for _, l := range v.Lines {
+		total += l.Debit
+	}
+	return total
+}
+
+// TotalCredit returns the sum of the credit lines
+func (v *Voucher) TotalCredit() float64 {
+	total := 0.0
+	for _, l := range v.Lines {
+		total += l.Credit
+	}
+	return total
+}
+
+// Batch is the parsed content of one import file
+type Batch struct {
+	Format  Format
+	Dataset Dataset
+	Rows    int // data rows read, excluding the header
+
+	Accounts        []Account
+	Partners        []Partner
+	OpeningBalances []OpeningBalance
+	Vouchers        []Voucher
+
+	Issues []Issue
+}
+
+// HasErrors returns true if any issue prevents the batch from being imported
+func (b *Batch) HasErrors() bool {
+	for _, issue := range b.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Records returns the number of parsed records of the batch's dataset
+func (b *Batch) Records() int {
+	switch b.Dataset {
+	case DatasetAccounts:
+		return len(b.Accounts)
+	case DatasetPartners:
+		return len(b.Partners)
+	case DatasetOpeningBalances:
+		return len(b.OpeningBalances)
+	case DatasetVouchers:
+		return len(b.Vouchers)
+	}
+	return 0
+}
+
+func (b *Batch) addError(row int, field, message string) {
+	b.Issues = append(b.Issues, Issue{Row: row, Field: field, Severity: SeverityError, Message: message})
+}
+
+func (b *Batch) addWarning(row int, field, message string) {
+	b.Issues = append(b.Issues, Issue{Row: row, Field: field, Severity: SeverityWarning, Message: message})
+}
+
+// Result reports what an import did, or would do in a dry run
+type Result struct {
+	Format  Format
+	Dataset Dataset
+	DryRun  bool
+	Applied bool
+
+	Rows    int // data rows in the file
+	Records int // accounts, partners, balances or vouchers parsed
+	Created int
+	Updated int // existing records changed by the file
+	Skipped int // records that already exist
+	Issues  []Issue
+}
+
+// HasErrors returns true if any issue prevented the import
+func (r *Result) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Adapter parses the export files of one legacy package
+type Adapter interface {
+	Format() Format
+	// Name is the display name of the package
+	Name() string
+	// Columns lists the accepted header names of each field of a dataset
+	Columns(dataset Dataset) []Column
+	// Parse reads an export file of the dataset
+	Parse(dataset Dataset, data []byte, opts Options) (*Batch, error)
+}
+
+// Column describes a field of an import file and the headers it is recognized by
+type Column struct {
+	Field    string   `json:"field"`
+	Headers  []string `json:"headers"`
+	Required bool     `json:"required"`
+}
+
+var adapters = []Adapter{douzoneAdapter, semusaRangAdapter}
+
+// Adapters returns the supported legacy formats
+func Adapters() []Adapter {
+	return append([]Adapter(nil), adapters...)
+}
+
+// AdapterFor returns the adapter of the format
+func AdapterFor(format Format) (Adapter, error) {
+	for _, a := range adapters {
+		if a.Format() == format {
+			return a, nil
+		}
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Parse parses an export file with the adapter of the format
+func Parse(format Format, dataset Dataset, data []byte, opts Options) (*Batch, error) {
+	adapter, err := AdapterFor(format)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.Parse(dataset, data, opts)
+}
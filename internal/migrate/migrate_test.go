@@ -0,0 +1,129 @@
+package migrate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/korean"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+)
+
+func TestParse_DouzoneAccounts(t *testing.T) {
+	data := "계정과목및적요등록\n" +
+		"코드,계정과목,구분\n" +
+		"101,현금,유동자산\n" +
+		"251,외상매입금,유동부채\n" +
+		"375,이월이익잉여금,이익잉여금\n" +
+		"455,제품매출원가,\n" +
+		"811,복리후생비,판매비와관리비\n" +
+		",계정명없음,\n"
+
+	batch, err := migrate.Parse(migrate.FormatDouzone, migrate.DatasetAccounts, []byte(data), migrate.Options{})
+	require.NoError(t, err)
+
+	require.Len(t, batch.Accounts, 5)
+	assert.Equal(t, 6, batch.Rows)
+	assert.Equal(t, domain.AccountTypeAsset, batch.Accounts[0].Type)
+	assert.Equal(t, domain.AccountTypeLiability, batch.Accounts[1].Type)
+	assert.Equal(t, domain.AccountTypeEquity, batch.Accounts[2].Type)
+	assert.Equal(t, domain.AccountTypeExpense, batch.Accounts[3].Type, "classified by code range")
+	assert.Equal(t, domain.AccountTypeExpense, batch.Accounts[4].Type)
+
+	require.Len(t, batch.Issues, 1)
+	assert.Equal(t, 8, batch.Issues[0].Row)
+	assert.True(t, batch.HasErrors())
+}
+
+func TestParse_CP949(t *testing.T) {
+	data, err := korean.EUCKR.NewEncoder().Bytes([]byte("거래처코드\t상호\t사업자번호\t유형\n00101\t(주)한국상사\t123-45-67890\t매출\n00102\t김철수\t800101-1234567\t\n"))
+	require.NoError(t, err)
+
+	batch, err := migrate.Parse(migrate.FormatSemusaRang, migrate.DatasetPartners, data, migrate.Options{})
+	require.NoError(t, err)
+
+	require.Len(t, batch.Partners, 2)
+	assert.Equal(t, "(주)한국상사", batch.Partners[0].Name)
+	assert.Equal(t, "1234567890", batch.Partners[0].BusinessNumber)
+	assert.Equal(t, "customer", batch.Partners[0].PartnerType)
+
+	// Resident registration numbers are dropped with a warning
+	assert.Empty(t, batch.Partners[1].BusinessNumber)
+	assert.Equal(t, "both", batch.Partners[1].PartnerType)
+	require.Len(t, batch.Issues, 1)
+	assert.Equal(t, migrate.SeverityWarning, batch.Issues[0].Severity)
+	assert.False(t, batch.HasErrors())
+}
+
+func TestParse_DouzoneVouchers(t *testing.T) {
+	// Two 코드 columns: the account code and the partner code
+	data := "월,일,번호,구분,코드,계정과목,코드,거래처,적요,차변,대변\n" +
+		"1,15,00001,1,811,복리후생비,,,직원 식대,\"55,000\",\n" +
+		"1,15,00002,3,108,외상매출금,00101,한국상사,제품 매출,\"1,100,000\",\n" +
+		"1,15,00002,4,404,제품매출,00101,한국상사,제품 매출,,\"1,000,000\"\n" +
+		"1,15,00002,4,255,부가세예수금,00101,한국상사,제품 매출,,\"100,000\"\n" +
+		"[월 계],,,,,,,,,\"1,155,000\",\"1,155,000\"\n" +
+		"1,16,00001,2,108,외상매출금,00101,한국상사,외상대 회수,,\"500,000\"\n"
+
+	batch, err := migrate.Parse(migrate.FormatDouzone, migrate.DatasetVouchers, []byte(data), migrate.Options{Year: 2025})
+	require.NoError(t, err)
+	require.Empty(t, batch.Issues)
+
+	require.Len(t, batch.Vouchers, 3)
+
+	cashOut := batch.Vouchers[0]
+	assert.Equal(t, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), cashOut.Date)
+	require.Len(t, cashOut.Lines, 2)
+	assert.Equal(t, migrate.VoucherLine{Row: 2, AccountCode: "811", Description: "직원 식대", Debit: 55000}, cashOut.Lines[0])
+	assert.Equal(t, migrate.VoucherLine{Row: 2, AccountCode: migrate.DefaultCashAccountCode, Description: "직원 식대", Credit: 55000}, cashOut.Lines[1])
+
+	sale := batch.Vouchers[1]
+	require.Len(t, sale.Lines, 3)
+	assert.Equal(t, "00101", sale.Lines[0].PartnerCode)
+	assert.Equal(t, 1100000.0, sale.TotalDebit())
+	assert.Equal(t, 1100000.0, sale.TotalCredit())
+
+	cashIn := batch.Vouchers[2]
+	assert.Equal(t, "00001", cashIn.Number)
+	assert.Equal(t, 500000.0, cashIn.Lines[0].Debit)
+	assert.Equal(t, migrate.DefaultCashAccountCode, cashIn.Lines[0].AccountCode)
+}
+
+func TestParse_SemusaRangVouchers_Unbalanced(t *testing.T) {
+	data := "일자,전표번호,구분,계정코드,계정명,적요,금액\n" +
+		"2025.03.02,1,차변,146,상품,상품 매입,\"200,000\"\n" +
+		"2025.03.02,1,대변,251,외상매입금,상품 매입,\"190,000\"\n"
+
+	batch, err := migrate.Parse(migrate.FormatSemusaRang, migrate.DatasetVouchers, []byte(data), migrate.Options{})
+	require.NoError(t, err)
+
+	require.Len(t, batch.Vouchers, 1)
+	require.Len(t, batch.Issues, 1)
+	assert.Equal(t, 2, batch.Issues[0].Row)
+	assert.Contains(t, batch.Issues[0].Message, "unbalanced")
+}
+
+func TestParse_OpeningBalances(t *testing.T) {
+	data := "코드,계정과목,금액\n101,현금,\"3,000,000\"\n251,외상매입금,△500000\n"
+
+	batch, err := migrate.Parse(migrate.FormatDouzone, migrate.DatasetOpeningBalances, []byte(data), migrate.Options{})
+	require.NoError(t, err)
+
+	require.Len(t, batch.OpeningBalances, 2)
+	assert.Equal(t, 3000000.0, batch.OpeningBalances[0].Amount)
+	assert.Equal(t, -500000.0, batch.OpeningBalances[1].Amount)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := migrate.Parse("quickbooks", migrate.DatasetAccounts, []byte("x"), migrate.Options{})
+	assert.ErrorIs(t, err, migrate.ErrUnknownFormat)
+
+	_, err = migrate.Parse(migrate.FormatDouzone, migrate.DatasetAccounts, []byte("이름,비고\n현금,\n"), migrate.Options{})
+	assert.ErrorIs(t, err, migrate.ErrMissingColumn)
+
+	_, err = migrate.Parse(migrate.FormatDouzone, migrate.DatasetAccounts, []byte("코드,계정과목\n"), migrate.Options{})
+	assert.ErrorIs(t, err, migrate.ErrEmptyFile)
+}
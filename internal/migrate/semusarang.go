@@ -0,0 +1,52 @@
+package migrate
+
+// semusaRangAdapter reads the exports of SemusaRang Pro: 계정과목등록,
+// 거래처등록, 전기분재무상태표 and 전표 (일반전표 조회). Voucher lines carry
+// 구분 (출금/입금/차변/대변, or their codes 1-6) with a single 금액 column;
+// files with separate 차변/대변 columns are accepted as well.
+var semusaRangAdapter Adapter = &tableAdapter{
+	format: FormatSemusaRang,
+	name:   "세무사랑 Pro",
+	columns: map[Dataset][]Column{
+		DatasetAccounts: {
+			{Field: fieldCode, Headers: []string{"계정코드", "코드"}, Required: true},
+			{Field: fieldName, Headers: []string{"계정명", "계정과목", "계정과목명"}, Required: true},
+			{Field: fieldNameEn, Headers: []string{"영문계정명", "영문명"}},
+			{Field: fieldType, Headers: []string{"계정구분", "구분", "분류"}},
+			{Field: fieldCategory, Headers: []string{"세분류", "중분류"}},
+		},
+		DatasetPartners: {
+			{Field: fieldCode, Headers: []string{"거래처코드", "코드"}, Required: true},
+			{Field: fieldName, Headers: []string{"상호", "거래처명", "상호(성명)"}, Required: true},
+			{Field: fieldBusinessNumber, Headers: []string{"사업자번호", "사업자등록번호", "등록번호"}},
+			{Field: fieldPartnerType, Headers: []string{"유형", "거래처유형", "구분"}},
+			{Field: fieldRepresentative, Headers: []string{"대표자", "대표자명", "성명"}},
+			{Field: fieldPhone, Headers: []string{"전화번호", "연락처"}},
+			{Field: fieldFax, Headers: []string{"팩스", "팩스번호"}},
+			{Field: fieldEmail, Headers: []string{"이메일", "E-mail", "전자우편"}},
+			{Field: fieldZipCode, Headers: []string{"우편번호"}},
+			{Field: fieldAddress, Headers: []string{"주소", "사업장주소"}},
+			{Field: fieldAddressDetail, Headers: []string{"상세주소"}},
+		},
+		DatasetOpeningBalances: {
+			{Field: fieldAccountCode, Headers: []string{"계정코드", "코드"}, Required: true},
+			{Field: fieldPartnerCode, Headers: []string{"거래처코드", "코드"}}, // the second 코드 column
+			{Field: fieldDebit, Headers: []string{"차변", "차변금액"}},
+			{Field: fieldCredit, Headers: []string{"대변", "대변금액"}},
+			{Field: fieldAmount, Headers: []string{"금액", "기초잔액", "잔액"}},
+		},
+		DatasetVouchers: {
+			{Field: fieldDate, Headers: []string{"일자", "전표일자", "거래일자"}},
+			{Field: fieldMonth, Headers: []string{"월"}},
+			{Field: fieldDay, Headers: []string{"일"}},
+			{Field: fieldNumber, Headers: []string{"전표번호", "번호"}, Required: true},
+			{Field: fieldKind, Headers: []string{"구분", "차대구분"}},
+			{Field: fieldAccountCode, Headers: []string{"계정코드", "코드"}, Required: true},
+			{Field: fieldPartnerCode, Headers: []string{"거래처코드", "코드"}}, // the second 코드 column
+			{Field: fieldDescription, Headers: []string{"적요"}},
+			{Field: fieldAmount, Headers: []string{"금액"}},
+			{Field: fieldDebit, Headers: []string{"차변", "차변금액"}},
+			{Field: fieldCredit, Headers: []string{"대변", "대변금액"}},
+		},
+	},
+}
@@ -0,0 +1,644 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// Canonical field names shared by the adapters
+const (
+	fieldCode           = "code"
+	fieldName           = "name"
+	fieldNameEn         = "name_en"
+	fieldType           = "type"
+	fieldCategory       = "category"
+	fieldBusinessNumber = "business_number"
+	fieldPartnerType    = "partner_type"
+	fieldRepresentative = "representative"
+	fieldPhone          = "phone"
+	fieldFax            = "fax"
+	fieldEmail          = "email"
+	fieldZipCode        = "zip_code"
+	fieldAddress        = "address"
+	fieldAddressDetail  = "address_detail"
+	fieldAccountCode    = "account_code"
+	fieldPartnerCode    = "partner_code"
+	fieldDebit          = "debit"
+	fieldCredit         = "credit"
+	fieldAmount         = "amount"
+	fieldDate           = "date"
+	fieldMonth          = "month"
+	fieldDay            = "day"
+	fieldNumber         = "number"
+	fieldKind           = "kind"
+	fieldDescription    = "description"
+)
+
+// headerSearchRows is how many leading rows may hold report titles
+// (company name, period) before the header row
+const headerSearchRows = 10
+
+// summaryMarkers identify subtotal rows that legacy exports insert between data rows
+var summaryMarkers = []string{"합계", "소계", "월계", "누계", "총계"}
+
+// tableAdapter parses delimited text exports by matching header names
+type tableAdapter struct {
+	format  Format
+	name    string
+	columns map[Dataset][]Column
+}
+
+func (a *tableAdapter) Format() Format { return a.format }
+func (a *tableAdapter) Name() string   { return a.name }
+
+func (a *tableAdapter) Columns(dataset Dataset) []Column {
+	return a.columns[dataset]
+}
+
+// Parse decodes the file, locates the header row and parses every data row
+func (a *tableAdapter) Parse(dataset Dataset, data []byte, opts Options) (*Batch, error) {
+	columns, ok := a.columns[dataset]
+	if !ok {
+		return nil, ErrUnknownDataset
+	}
+	if opts.CashAccountCode == "" {
+		opts.CashAccountCode = DefaultCashAccountCode
+	}
+
+	records, err := ReadRecords(data)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newTable(records, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{Format: a.format, Dataset: dataset}
+	vouchers := make(map[string]int)
+	for i := t.header + 1; i < len(records); i++ {
+		r := row{table: t, line: i + 1, values: records[i]}
+		if r.isBlank() || r.isSummary() {
+			continue
+		}
+		batch.Rows++
+		switch dataset {
+		case DatasetAccounts:
+			parseAccount(batch, r)
+		case DatasetPartners:
+			parsePartner(batch, r)
+		case DatasetOpeningBalances:
+			parseOpeningBalance(batch, r)
+		case DatasetVouchers:
+			parseVoucherLine(batch, r, opts, vouchers)
+		}
+	}
+	if batch.Rows == 0 {
+		return nil, ErrEmptyFile
+	}
+	if dataset == DatasetVouchers {
+		checkVoucherBalances(batch)
+	}
+	return batch, nil
+}
+
+// ReadRecords decodes UTF-8 or CP949 text and splits it into records,
+// using tabs as the delimiter when the first line contains one
+func ReadRecords(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if !utf8.Valid(data) {
+		decoded, err := korean.EUCKR.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode CP949: %w", err)
+		}
+		data = decoded
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Contains(firstLine, []byte("\t")) {
+		r.Comma = '\t'
+	}
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+
+	var records [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// table maps the fields of a dataset to column indexes of the header row
+type table struct {
+	header int
+	index  map[string]int
+}
+
+// newTable finds the header row: the first row within headerSearchRows that
+// contains every required column
+func newTable(records [][]string, columns []Column) (*table, error) {
+	var missing string
+	for i := 0; i < len(records) && i < headerSearchRows; i++ {
+		index := matchHeaders(records[i], columns)
+		missing = ""
+		for _, c := range columns {
+			if _, ok := index[c.Field]; c.Required && !ok {
+				missing = c.Headers[0]
+				break
+			}
+		}
+		if missing == "" && len(index) > 0 {
+			return &table{header: i, index: index}, nil
+		}
+	}
+	if missing == "" {
+		missing = columns[0].Headers[0]
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMissingColumn, missing)
+}
+
+// matchHeaders maps fields to the first header cell matching one of their names;
+// whitespace and case are ignored. A repeated header such as the second 코드 of
+// a voucher screen goes to the next field that accepts it.
+func matchHeaders(record []string, columns []Column) map[string]int {
+	index := make(map[string]int)
+cells:
+	for i, cell := range record {
+		cell = normalizeHeader(cell)
+		if cell == "" {
+			continue
+		}
+		for _, c := range columns {
+			if _, ok := index[c.Field]; ok {
+				continue
+			}
+			for _, h := range c.Headers {
+				if cell == normalizeHeader(h) {
+					index[c.Field] = i
+					continue cells
+				}
+			}
+		}
+	}
+	return index
+}
+
+func normalizeHeader(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
+
+// row is one data record of a table
+type row struct {
+	table  *table
+	line   int
+	values []string
+}
+
+func (r row) has(field string) bool {
+	_, ok := r.table.index[field]
+	return ok
+}
+
+func (r row) get(field string) string {
+	i, ok := r.table.index[field]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[i])
+}
+
+func (r row) isBlank() bool {
+	for _, v := range r.values {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isSummary reports subtotal rows such as "[월 계]" or "합 계"
+func (r row) isSummary() bool {
+	for _, v := range r.values {
+		v = strings.Trim(strings.Join(strings.Fields(v), ""), "[]()<>")
+		for _, marker := range summaryMarkers {
+			if v == marker {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// amount parses an amount field; empty is zero. Thousand separators, a
+// trailing 원 and negative markers ("-", "△", "▲" or parentheses) are accepted.
+func (r row) amount(batch *Batch, field string) float64 {
+	s := strings.NewReplacer(",", "", " ", "", "원", "").Replace(r.get(field))
+	if s == "" {
+		return 0
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, s[1:len(s)-1]
+	}
+	for _, marker := range []string{"△", "▲"} {
+		if strings.HasPrefix(s, marker) {
+			negative, s = true, strings.TrimPrefix(s, marker)
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		batch.addError(r.line, field, fmt.Sprintf("invalid amount %q", r.get(field)))
+		return 0
+	}
+	if negative {
+		v = -v
+	}
+	return v
+}
+
+// dateLayouts are the date formats found in legacy exports
+var dateLayouts = []string{"2006-01-02", "2006/01/02", "2006.01.02", "20060102", "2006-1-2", "2006/1/2", "2006.1.2"}
+
+// date parses the date field, or the month and day fields in the given year
+func (r row) date(batch *Batch, year int) (time.Time, bool) {
+	if value := r.get(fieldDate); value != "" {
+		value, _, _ = strings.Cut(value, " ") // drop a time part
+		value = strings.TrimSuffix(value, ".")
+		for _, layout := range dateLayouts {
+			if d, err := time.Parse(layout, value); err == nil {
+				return d, true
+			}
+		}
+		batch.addError(r.line, fieldDate, fmt.Sprintf("invalid date %q", r.get(fieldDate)))
+		return time.Time{}, false
+	}
+
+	if !r.has(fieldMonth) || !r.has(fieldDay) {
+		batch.addError(r.line, fieldDate, "date is required")
+		return time.Time{}, false
+	}
+	if year == 0 {
+		batch.addError(r.line, fieldDate, "the year is required for dates exported as month and day")
+		return time.Time{}, false
+	}
+	month, errM := strconv.Atoi(r.get(fieldMonth))
+	day, errD := strconv.Atoi(r.get(fieldDay))
+	d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if errM != nil || errD != nil || d.Month() != time.Month(month) || d.Day() != day {
+		batch.addError(r.line, fieldDate, fmt.Sprintf("invalid date %s/%s", r.get(fieldMonth), r.get(fieldDay)))
+		return time.Time{}, false
+	}
+	return d, true
+}
+
+// ============================================================================
+// Datasets
+// ============================================================================
+
+func parseAccount(batch *Batch, r row) {
+	account := Account{
+		Row:      r.line,
+		Code:     r.get(fieldCode),
+		Name:     r.get(fieldName),
+		NameEn:   r.get(fieldNameEn),
+		Category: r.get(fieldType),
+	}
+	if account.Code == "" {
+		batch.addError(r.line, fieldCode, "account code is required")
+		return
+	}
+	if len(account.Code) > 10 {
+		batch.addError(r.line, fieldCode, "account code must be at most 10 characters")
+		return
+	}
+	if account.Name == "" {
+		batch.addError(r.line, fieldName, "account name is required")
+		return
+	}
+	if category := r.get(fieldCategory); category != "" {
+		account.Category = category
+	}
+
+	account.Type = accountTypeFromLabel(r.get(fieldType))
+	if account.Type == "" {
+		account.Type = accountTypeFromCode(account.Code)
+	}
+	if account.Type == "" {
+		batch.addError(r.line, fieldType, fmt.Sprintf("cannot determine the account type of %s %s", account.Code, account.Name))
+		return
+	}
+	batch.Accounts = append(batch.Accounts, account)
+}
+
+// accountTypeFromLabel classifies Korean account group labels such as
+// 유동자산, 비유동부채, 자본잉여금, 판매비와관리비 or 영업외수익
+func accountTypeFromLabel(label string) domain.AccountType {
+	if t := domain.AccountType(strings.ToLower(label)); t.IsValid() {
+		return t
+	}
+	rules := []struct {
+		accountType domain.AccountType
+		keywords    []string
+	}{
+		{domain.AccountTypeEquity, []string{"자본", "잉여금", "결손금"}},
+		{domain.AccountTypeAsset, []string{"자산"}},
+		{domain.AccountTypeLiability, []string{"부채"}},
+		{domain.AccountTypeExpense, []string{"원가", "비용", "손실", "관리비", "법인세"}},
+		{domain.AccountTypeRevenue, []string{"수익", "이익", "매출"}},
+	}
+	for _, rule := range rules {
+		for _, keyword := range rule.keywords {
+			if strings.Contains(label, keyword) {
+				return rule.accountType
+			}
+		}
+	}
+	return ""
+}
+
+// accountTypeFromCode classifies codes of the standard Korean chart of accounts
+// shared by Douzone and SemusaRang; longer codes are classified by their first three digits
+func accountTypeFromCode(code string) domain.AccountType {
+	if len(code) < 3 {
+		return ""
+	}
+	n, err := strconv.Atoi(code[:3])
+	if err != nil {
+		return ""
+	}
+	switch {
+	case n >= 101 && n <= 250:
+		return domain.AccountTypeAsset
+	case n >= 251 && n <= 330:
+		return domain.AccountTypeLiability
+	case n >= 331 && n <= 400:
+		return domain.AccountTypeEquity
+	case n >= 401 && n <= 450, n >= 901 && n <= 950:
+		return domain.AccountTypeRevenue
+	case n >= 451 && n <= 900, n >= 951 && n <= 999:
+		return domain.AccountTypeExpense
+	}
+	return ""
+}
+
+func parsePartner(batch *Batch, r row) {
+	partner := Partner{
+		Row:            r.line,
+		Code:           r.get(fieldCode),
+		Name:           r.get(fieldName),
+		PartnerType:    partnerTypeFromLabel(r.get(fieldPartnerType)),
+		Representative: r.get(fieldRepresentative),
+		Phone:          r.get(fieldPhone),
+		Fax:            r.get(fieldFax),
+		Email:          r.get(fieldEmail),
+		ZipCode:        r.get(fieldZipCode),
+		Address:        r.get(fieldAddress),
+		AddressDetail:  r.get(fieldAddressDetail),
+	}
+	if partner.Code == "" {
+		batch.addError(r.line, fieldCode, "partner code is required")
+		return
+	}
+	if len(partner.Code) > 20 {
+		batch.addError(r.line, fieldCode, "partner code must be at most 20 characters")
+		return
+	}
+	if partner.Name == "" {
+		batch.addError(r.line, fieldName, "partner name is required")
+		return
+	}
+
+	// Resident registration numbers share the column in some exports; only
+	// 10-digit business numbers are kept
+	if number := digitsOnly(r.get(fieldBusinessNumber)); number != "" {
+		if len(number) == 10 {
+			partner.BusinessNumber = number
+		} else {
+			batch.addWarning(r.line, fieldBusinessNumber, fmt.Sprintf("business number %q is not 10 digits and is not imported", r.get(fieldBusinessNumber)))
+		}
+	}
+	batch.Partners = append(batch.Partners, partner)
+}
+
+// partnerTypeFromLabel maps 매출/매입 classifications; anything else is both
+func partnerTypeFromLabel(label string) string {
+	label = strings.ToLower(label)
+	switch {
+	case label == "customer" || label == "vendor" || label == "both":
+		return label
+	case strings.Contains(label, "동시") || strings.Contains(label, "공통"):
+		return "both"
+	case strings.Contains(label, "매출") || strings.Contains(label, "고객"):
+		return "customer"
+	case strings.Contains(label, "매입") || strings.Contains(label, "공급"):
+		return "vendor"
+	}
+	return "both"
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+func parseOpeningBalance(batch *Batch, r row) {
+	balance := OpeningBalance{
+		Row:         r.line,
+		AccountCode: r.get(fieldAccountCode),
+		PartnerCode: r.get(fieldPartnerCode),
+	}
+	if balance.AccountCode == "" {
+		batch.addError(r.line, fieldAccountCode, "account code is required")
+		return
+	}
+
+	issues := len(batch.Issues)
+	switch {
+	case r.has(fieldDebit) || r.has(fieldCredit):
+		balance.Debit = r.amount(batch, fieldDebit)
+		balance.Credit = r.amount(batch, fieldCredit)
+	case r.has(fieldAmount):
+		balance.Amount = r.amount(batch, fieldAmount)
+	default:
+		batch.addError(r.line, fieldAmount, "a debit/credit or amount column is required")
+	}
+	if len(batch.Issues) > issues {
+		return
+	}
+
+	// Balances are netted per side; a negative debit is a credit balance
+	if balance.Debit < 0 {
+		balance.Credit, balance.Debit = balance.Credit-balance.Debit, 0
+	}
+	if balance.Credit < 0 {
+		balance.Debit, balance.Credit = balance.Debit-balance.Credit, 0
+	}
+	if balance.Debit == 0 && balance.Credit == 0 && balance.Amount == 0 {
+		return
+	}
+	batch.OpeningBalances = append(batch.OpeningBalances, balance)
+}
+
+// voucherLineKind is the 구분 of a legacy voucher line
+type voucherLineKind int
+
+const (
+	kindUnknown voucherLineKind = iota
+	kindCashOut                 // 1 출금: debit the account, credit cash
+	kindCashIn                  // 2 입금: credit the account, debit cash
+	kindDebit                   // 3 차변, 5 결산차변
+	kindCredit                  // 4 대변, 6 결산대변
+)
+
+// voucherLineKindFromLabel accepts the code, the label or both ("3", "차변", "3.차변")
+func voucherLineKindFromLabel(label string) voucherLineKind {
+	label = strings.Join(strings.Fields(label), "")
+	switch {
+	case strings.Contains(label, "출금"):
+		return kindCashOut
+	case strings.Contains(label, "입금"):
+		return kindCashIn
+	case strings.Contains(label, "차변") || strings.Contains(label, "결차"):
+		return kindDebit
+	case strings.Contains(label, "대변") || strings.Contains(label, "결대"):
+		return kindCredit
+	}
+	if label == "" {
+		return kindUnknown
+	}
+	switch label[0] {
+	case '1':
+		return kindCashOut
+	case '2':
+		return kindCashIn
+	case '3', '5':
+		return kindDebit
+	case '4', '6':
+		return kindCredit
+	}
+	return kindUnknown
+}
+
+// parseVoucherLine adds a line to the voucher of the row's date and number.
+// Cash lines (출금/입금) add the counter line on the cash account.
+// Lines are grouped by date and number; index maps that key to the voucher.
+func parseVoucherLine(batch *Batch, r row, opts Options, index map[string]int) {
+	date, ok := r.date(batch, opts.Year)
+	if !ok {
+		return
+	}
+	number := r.get(fieldNumber)
+	if number == "" {
+		batch.addError(r.line, fieldNumber, "voucher number is required")
+		return
+	}
+	line := VoucherLine{
+		Row:         r.line,
+		AccountCode: r.get(fieldAccountCode),
+		PartnerCode: r.get(fieldPartnerCode),
+		Description: r.get(fieldDescription),
+	}
+	if line.AccountCode == "" {
+		batch.addError(r.line, fieldAccountCode, "account code is required")
+		return
+	}
+
+	issues := len(batch.Issues)
+	debit, credit := r.amount(batch, fieldDebit), r.amount(batch, fieldCredit)
+	amount := r.amount(batch, fieldAmount)
+	if len(batch.Issues) > issues {
+		return
+	}
+	if amount == 0 {
+		amount = debit + credit
+	}
+
+	kind := voucherLineKindFromLabel(r.get(fieldKind))
+	if kind == kindUnknown {
+		switch {
+		case debit != 0 && credit == 0:
+			kind = kindDebit
+		case credit != 0 && debit == 0:
+			kind = kindCredit
+		default:
+			batch.addError(r.line, fieldKind, fmt.Sprintf("cannot determine the debit/credit side of line kind %q", r.get(fieldKind)))
+			return
+		}
+	}
+	if amount == 0 {
+		batch.addWarning(r.line, fieldAmount, "zero amount line is skipped")
+		return
+	}
+
+	lines := make([]VoucherLine, 0, 2)
+	switch kind {
+	case kindDebit:
+		line.Debit = amount
+		lines = append(lines, line)
+	case kindCredit:
+		line.Credit = amount
+		lines = append(lines, line)
+	case kindCashOut:
+		line.Debit = amount
+		cash := VoucherLine{Row: r.line, AccountCode: opts.CashAccountCode, Description: line.Description, Credit: amount}
+		lines = append(lines, line, cash)
+	case kindCashIn:
+		line.Credit = amount
+		cash := VoucherLine{Row: r.line, AccountCode: opts.CashAccountCode, Description: line.Description, Debit: amount}
+		lines = append(lines, cash, line)
+	}
+
+	// A negative amount on one side is the same amount on the other side
+	for i := range lines {
+		if lines[i].Debit < 0 {
+			lines[i].Credit, lines[i].Debit = -lines[i].Debit, 0
+		}
+		if lines[i].Credit < 0 {
+			lines[i].Debit, lines[i].Credit = -lines[i].Credit, 0
+		}
+	}
+
+	key := date.Format("20060102") + "/" + number
+	if i, ok := index[key]; ok {
+		batch.Vouchers[i].Lines = append(batch.Vouchers[i].Lines, lines...)
+		return
+	}
+	index[key] = len(batch.Vouchers)
+	batch.Vouchers = append(batch.Vouchers, Voucher{Row: r.line, Date: date, Number: number, Lines: lines})
+}
+
+// checkVoucherBalances reports vouchers whose debit and credit differ
+func checkVoucherBalances(batch *Batch) {
+	for _, v := range batch.Vouchers {
+		debit, credit := v.TotalDebit(), v.TotalCredit()
+		if math.Abs(debit-credit) > 0.005 {
+			batch.addError(v.Row, fieldNumber, fmt.Sprintf("voucher %s %s is unbalanced: debit %.0f, credit %.0f",
+				v.Date.Format("2006-01-02"), v.Number, debit, credit))
+		}
+	}
+}
@@ -141,5 +141,11 @@ func (m *MockAccountRepository) UpdateSortOrder(ctx context.Context, companyID u
 	return args.Error(0)
 }
 
+// UpdatePostingRules mocks the UpdatePostingRules method
+func (m *MockAccountRepository) UpdatePostingRules(ctx context.Context, companyID, id uuid.UUID, rules *domain.PostingRules) error {
+	args := m.Called(ctx, companyID, id, rules)
+	return args.Error(0)
+}
+
 // Ensure MockAccountRepository implements AccountRepository
 var _ repository.AccountRepository = (*MockAccountRepository)(nil)
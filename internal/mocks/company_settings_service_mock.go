@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// MockCompanySettingsService is a mock implementation of service.CompanySettingsService
+type MockCompanySettingsService struct {
+	mock.Mock
+}
+
+// Get mocks the Get method
+func (m *MockCompanySettingsService) Get(ctx context.Context, companyID uuid.UUID) (domain.CompanySettings, error) {
+	args := m.Called(ctx, companyID)
+	return args.Get(0).(domain.CompanySettings), args.Error(1)
+}
+
+// Update mocks the Update method
+func (m *MockCompanySettingsService) Update(ctx context.Context, companyID uuid.UUID, apply func(*domain.CompanySettings)) (domain.CompanySettings, error) {
+	args := m.Called(ctx, companyID, apply)
+	return args.Get(0).(domain.CompanySettings), args.Error(1)
+}
+
+// Ensure MockCompanySettingsService implements service.CompanySettingsService
+var _ service.CompanySettingsService = (*MockCompanySettingsService)(nil)
@@ -0,0 +1,192 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockGrantRepository is a mock implementation of GrantRepository
+type MockGrantRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockGrantRepository) Create(ctx context.Context, grant *domain.Grant) error {
+	args := m.Called(ctx, grant)
+	return args.Error(0)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockGrantRepository) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Grant), args.Error(1)
+}
+
+// GetByIDForUpdate mocks the GetByIDForUpdate method
+func (m *MockGrantRepository) GetByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Grant), args.Error(1)
+}
+
+// ExistsGrantNo mocks the ExistsGrantNo method
+func (m *MockGrantRepository) ExistsGrantNo(ctx context.Context, companyID uuid.UUID, grantNo string) (bool, error) {
+	args := m.Called(ctx, companyID, grantNo)
+	return args.Bool(0), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockGrantRepository) List(ctx context.Context, filter repository.GrantFilter) ([]domain.Grant, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.Grant), args.Get(1).(int64), args.Error(2)
+}
+
+// ListStartedBy mocks the ListStartedBy method
+func (m *MockGrantRepository) ListStartedBy(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.Grant, error) {
+	args := m.Called(ctx, companyID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Grant), args.Error(1)
+}
+
+// ListForRecognition mocks the ListForRecognition method
+func (m *MockGrantRepository) ListForRecognition(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.Grant, error) {
+	args := m.Called(ctx, companyID, periodEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Grant), args.Error(1)
+}
+
+// RecordReceipt mocks the RecordReceipt method
+func (m *MockGrantRepository) RecordReceipt(ctx context.Context, grant *domain.Grant, receipt *domain.GrantReceipt) error {
+	args := m.Called(ctx, grant, receipt)
+	return args.Error(0)
+}
+
+// LinkReceiptVoucher mocks the LinkReceiptVoucher method
+func (m *MockGrantRepository) LinkReceiptVoucher(ctx context.Context, receipt *domain.GrantReceipt) error {
+	args := m.Called(ctx, receipt)
+	return args.Error(0)
+}
+
+// ListReceipts mocks the ListReceipts method
+func (m *MockGrantRepository) ListReceipts(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantReceipt, error) {
+	args := m.Called(ctx, companyID, grantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GrantReceipt), args.Error(1)
+}
+
+// ListReceiptsUntil mocks the ListReceiptsUntil method
+func (m *MockGrantRepository) ListReceiptsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantReceipt, error) {
+	args := m.Called(ctx, companyID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GrantReceipt), args.Error(1)
+}
+
+// CreateExpense mocks the CreateExpense method
+func (m *MockGrantRepository) CreateExpense(ctx context.Context, expense *domain.GrantExpense) error {
+	args := m.Called(ctx, expense)
+	return args.Error(0)
+}
+
+// GetExpense mocks the GetExpense method
+func (m *MockGrantRepository) GetExpense(ctx context.Context, companyID, grantID, id uuid.UUID) (*domain.GrantExpense, error) {
+	args := m.Called(ctx, companyID, grantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GrantExpense), args.Error(1)
+}
+
+// DeleteExpense mocks the DeleteExpense method
+func (m *MockGrantRepository) DeleteExpense(ctx context.Context, companyID, id uuid.UUID) error {
+	args := m.Called(ctx, companyID, id)
+	return args.Error(0)
+}
+
+// ExistsExpenseVoucher mocks the ExistsExpenseVoucher method
+func (m *MockGrantRepository) ExistsExpenseVoucher(ctx context.Context, companyID, grantID, voucherID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, grantID, voucherID)
+	return args.Bool(0), args.Error(1)
+}
+
+// ListExpenses mocks the ListExpenses method
+func (m *MockGrantRepository) ListExpenses(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantExpense, error) {
+	args := m.Called(ctx, companyID, grantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GrantExpense), args.Error(1)
+}
+
+// ListExpensesUntil mocks the ListExpensesUntil method
+func (m *MockGrantRepository) ListExpensesUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantExpense, error) {
+	args := m.Called(ctx, companyID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GrantExpense), args.Error(1)
+}
+
+// RecordRecognition mocks the RecordRecognition method
+func (m *MockGrantRepository) RecordRecognition(ctx context.Context, grant *domain.Grant, recognition *domain.GrantRecognition) error {
+	args := m.Called(ctx, grant, recognition)
+	return args.Error(0)
+}
+
+// LinkRecognitionVoucher mocks the LinkRecognitionVoucher method
+func (m *MockGrantRepository) LinkRecognitionVoucher(ctx context.Context, recognition *domain.GrantRecognition) error {
+	args := m.Called(ctx, recognition)
+	return args.Error(0)
+}
+
+// ListRecognitions mocks the ListRecognitions method
+func (m *MockGrantRepository) ListRecognitions(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantRecognition, error) {
+	args := m.Called(ctx, companyID, grantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GrantRecognition), args.Error(1)
+}
+
+// ListRecognitionsUntil mocks the ListRecognitionsUntil method
+func (m *MockGrantRepository) ListRecognitionsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantRecognition, error) {
+	args := m.Called(ctx, companyID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GrantRecognition), args.Error(1)
+}
+
+// WithTransaction mocks the WithTransaction method
+func (m *MockGrantRepository) WithTransaction(ctx context.Context, fn func(repo repository.GrantRepository) error) error {
+	args := m.Called(ctx, fn)
+	// Execute the function with the mock itself
+	if err := fn(m); err != nil {
+		return err
+	}
+	return args.Error(0)
+}
+
+// Ensure MockGrantRepository implements repository.GrantRepository
+var _ repository.GrantRepository = (*MockGrantRepository)(nil)
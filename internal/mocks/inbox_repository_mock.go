@@ -0,0 +1,88 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockInboxRepository is a mock implementation of InboxRepository
+type MockInboxRepository struct {
+	mock.Mock
+}
+
+// GetAlias mocks the GetAlias method
+func (m *MockInboxRepository) GetAlias(ctx context.Context, companyID uuid.UUID) (*domain.InboxAlias, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboxAlias), args.Error(1)
+}
+
+// FindAlias mocks the FindAlias method
+func (m *MockInboxRepository) FindAlias(ctx context.Context, alias string) (*domain.InboxAlias, error) {
+	args := m.Called(ctx, alias)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboxAlias), args.Error(1)
+}
+
+// SaveAlias mocks the SaveAlias method
+func (m *MockInboxRepository) SaveAlias(ctx context.Context, alias *domain.InboxAlias) error {
+	args := m.Called(ctx, alias)
+	return args.Error(0)
+}
+
+// ExistsMessage mocks the ExistsMessage method
+func (m *MockInboxRepository) ExistsMessage(ctx context.Context, companyID uuid.UUID, messageID string) (bool, error) {
+	args := m.Called(ctx, companyID, messageID)
+	return args.Bool(0), args.Error(1)
+}
+
+// CreateItem mocks the CreateItem method
+func (m *MockInboxRepository) CreateItem(ctx context.Context, item *domain.InboxItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// GetItem mocks the GetItem method
+func (m *MockInboxRepository) GetItem(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxItem, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboxItem), args.Error(1)
+}
+
+// GetDocument mocks the GetDocument method
+func (m *MockInboxRepository) GetDocument(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxDocument, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboxDocument), args.Error(1)
+}
+
+// ListItems mocks the ListItems method
+func (m *MockInboxRepository) ListItems(ctx context.Context, filter repository.InboxItemFilter) ([]domain.InboxItem, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.InboxItem), args.Get(1).(int64), args.Error(2)
+}
+
+// UpdateItemStatus mocks the UpdateItemStatus method
+func (m *MockInboxRepository) UpdateItemStatus(ctx context.Context, item *domain.InboxItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// Ensure MockInboxRepository implements repository.InboxRepository
+var _ repository.InboxRepository = (*MockInboxRepository)(nil)
@@ -0,0 +1,115 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockInventoryCountRepository is a mock implementation of InventoryCountRepository
+type MockInventoryCountRepository struct {
+	mock.Mock
+}
+
+// CreateItem mocks the CreateItem method
+func (m *MockInventoryCountRepository) CreateItem(ctx context.Context, item *domain.InventoryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// UpdateItem mocks the UpdateItem method
+func (m *MockInventoryCountRepository) UpdateItem(ctx context.Context, item *domain.InventoryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// FindItemByID mocks the FindItemByID method
+func (m *MockInventoryCountRepository) FindItemByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryItem, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InventoryItem), args.Error(1)
+}
+
+// ExistsItemCode mocks the ExistsItemCode method
+func (m *MockInventoryCountRepository) ExistsItemCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, code, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// ListItems mocks the ListItems method
+func (m *MockInventoryCountRepository) ListItems(ctx context.Context, filter repository.InventoryItemFilter) ([]domain.InventoryItem, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.InventoryItem), args.Get(1).(int64), args.Error(2)
+}
+
+// ListActiveItems mocks the ListActiveItems method
+func (m *MockInventoryCountRepository) ListActiveItems(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID) ([]domain.InventoryItem, error) {
+	args := m.Called(ctx, companyID, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.InventoryItem), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockInventoryCountRepository) Create(ctx context.Context, count *domain.InventoryCount) error {
+	args := m.Called(ctx, count)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockInventoryCountRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InventoryCount), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockInventoryCountRepository) List(ctx context.Context, filter repository.InventoryCountFilter) ([]domain.InventoryCount, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.InventoryCount), args.Get(1).(int64), args.Error(2)
+}
+
+// ExistsInProgress mocks the ExistsInProgress method
+func (m *MockInventoryCountRepository) ExistsInProgress(ctx context.Context, companyID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID)
+	return args.Bool(0), args.Error(1)
+}
+
+// RecordCounts mocks the RecordCounts method
+func (m *MockInventoryCountRepository) RecordCounts(ctx context.Context, count *domain.InventoryCount, lines []*domain.InventoryCountLine) error {
+	args := m.Called(ctx, count, lines)
+	return args.Error(0)
+}
+
+// UpdateStatus mocks the UpdateStatus method
+func (m *MockInventoryCountRepository) UpdateStatus(ctx context.Context, count *domain.InventoryCount, from domain.InventoryCountStatus) error {
+	args := m.Called(ctx, count, from)
+	return args.Error(0)
+}
+
+// Post mocks the Post method
+func (m *MockInventoryCountRepository) Post(ctx context.Context, count *domain.InventoryCount) error {
+	args := m.Called(ctx, count)
+	return args.Error(0)
+}
+
+// Unpost mocks the Unpost method
+func (m *MockInventoryCountRepository) Unpost(ctx context.Context, count *domain.InventoryCount) error {
+	args := m.Called(ctx, count)
+	return args.Error(0)
+}
@@ -0,0 +1,123 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockLoanRepository is a mock implementation of LoanRepository
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockLoanRepository) Create(ctx context.Context, loan *domain.Loan) error {
+	args := m.Called(ctx, loan)
+	return args.Error(0)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockLoanRepository) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Loan), args.Error(1)
+}
+
+// GetByIDForUpdate mocks the GetByIDForUpdate method
+func (m *MockLoanRepository) GetByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Loan), args.Error(1)
+}
+
+// ExistsLoanNo mocks the ExistsLoanNo method
+func (m *MockLoanRepository) ExistsLoanNo(ctx context.Context, companyID uuid.UUID, loanNo string) (bool, error) {
+	args := m.Called(ctx, companyID, loanNo)
+	return args.Bool(0), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockLoanRepository) List(ctx context.Context, filter repository.LoanFilter) ([]domain.Loan, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.Loan), args.Get(1).(int64), args.Error(2)
+}
+
+// ListStartedBy mocks the ListStartedBy method
+func (m *MockLoanRepository) ListStartedBy(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.Loan, error) {
+	args := m.Called(ctx, companyID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+
+// ListForAccrual mocks the ListForAccrual method
+func (m *MockLoanRepository) ListForAccrual(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.Loan, error) {
+	args := m.Called(ctx, companyID, periodEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+
+// RecordRepayment mocks the RecordRepayment method
+func (m *MockLoanRepository) RecordRepayment(ctx context.Context, loan *domain.Loan, repayment *domain.LoanRepayment) error {
+	args := m.Called(ctx, loan, repayment)
+	return args.Error(0)
+}
+
+// LinkRepaymentVoucher mocks the LinkRepaymentVoucher method
+func (m *MockLoanRepository) LinkRepaymentVoucher(ctx context.Context, repayment *domain.LoanRepayment) error {
+	args := m.Called(ctx, repayment)
+	return args.Error(0)
+}
+
+// ListRepayments mocks the ListRepayments method
+func (m *MockLoanRepository) ListRepayments(ctx context.Context, companyID, loanID uuid.UUID) ([]domain.LoanRepayment, error) {
+	args := m.Called(ctx, companyID, loanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LoanRepayment), args.Error(1)
+}
+
+// ListRepaymentsUntil mocks the ListRepaymentsUntil method
+func (m *MockLoanRepository) ListRepaymentsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.LoanRepayment, error) {
+	args := m.Called(ctx, companyID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LoanRepayment), args.Error(1)
+}
+
+// RecordAccrual mocks the RecordAccrual method
+func (m *MockLoanRepository) RecordAccrual(ctx context.Context, loan *domain.Loan, accrual *domain.LoanInterestAccrual) error {
+	args := m.Called(ctx, loan, accrual)
+	return args.Error(0)
+}
+
+// WithTransaction mocks the WithTransaction method
+func (m *MockLoanRepository) WithTransaction(ctx context.Context, fn func(repo repository.LoanRepository) error) error {
+	args := m.Called(ctx, fn)
+	// Execute the function with the mock itself
+	if err := fn(m); err != nil {
+		return err
+	}
+	return args.Error(0)
+}
+
+// Ensure MockLoanRepository implements repository.LoanRepository
+var _ repository.LoanRepository = (*MockLoanRepository)(nil)
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockPartitionRepository is a mock implementation of PartitionRepository
+type MockPartitionRepository struct {
+	mock.Mock
+}
+
+// VoucherEntryPartitionYears mocks the VoucherEntryPartitionYears method
+func (m *MockPartitionRepository) VoucherEntryPartitionYears(ctx context.Context) ([]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// DefaultVoucherEntryYears mocks the DefaultVoucherEntryYears method
+func (m *MockPartitionRepository) DefaultVoucherEntryYears(ctx context.Context) ([]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// CreateVoucherEntryPartition mocks the CreateVoucherEntryPartition method
+func (m *MockPartitionRepository) CreateVoucherEntryPartition(ctx context.Context, year, hashPartitions int) (bool, error) {
+	args := m.Called(ctx, year, hashPartitions)
+	return args.Bool(0), args.Error(1)
+}
+
+// Ensure MockPartitionRepository implements PartitionRepository
+var _ repository.PartitionRepository = (*MockPartitionRepository)(nil)
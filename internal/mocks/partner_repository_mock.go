@@ -0,0 +1,158 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockPartnerRepository is a mock implementation of PartnerRepository
+type MockPartnerRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockPartnerRepository) Create(ctx context.Context, partner *domain.Partner) error {
+	args := m.Called(ctx, partner)
+	return args.Error(0)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockPartnerRepository) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Partner, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Partner), args.Error(1)
+}
+
+// GetByCode mocks the GetByCode method
+func (m *MockPartnerRepository) GetByCode(ctx context.Context, companyID uuid.UUID, code string) (*domain.Partner, error) {
+	args := m.Called(ctx, companyID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Partner), args.Error(1)
+}
+
+// GetByBusinessNumber mocks the GetByBusinessNumber method
+func (m *MockPartnerRepository) GetByBusinessNumber(ctx context.Context, companyID uuid.UUID, businessNumber string) (*domain.Partner, error) {
+	args := m.Called(ctx, companyID, businessNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Partner), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockPartnerRepository) List(ctx context.Context, filter *repository.PartnerFilter) ([]domain.Partner, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]domain.Partner), args.Get(1).(int64), args.Error(2)
+}
+
+// Update mocks the Update method
+func (m *MockPartnerRepository) Update(ctx context.Context, partner *domain.Partner) error {
+	args := m.Called(ctx, partner)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockPartnerRepository) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	args := m.Called(ctx, companyID, id)
+	return args.Error(0)
+}
+
+// ExistsByCode mocks the ExistsByCode method
+func (m *MockPartnerRepository) ExistsByCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, code, excludeID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+// ExistsByBusinessNumber mocks the ExistsByBusinessNumber method
+func (m *MockPartnerRepository) ExistsByBusinessNumber(ctx context.Context, companyID uuid.UUID, businessNumber string, excludeID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, businessNumber, excludeID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+// HasVoucherEntries mocks the HasVoucherEntries method
+func (m *MockPartnerRepository) HasVoucherEntries(ctx context.Context, companyID, partnerID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, partnerID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+// HasTaxInvoices mocks the HasTaxInvoices method
+func (m *MockPartnerRepository) HasTaxInvoices(ctx context.Context, companyID, partnerID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, partnerID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+// EntryFiscalYears mocks the EntryFiscalYears method
+func (m *MockPartnerRepository) EntryFiscalYears(ctx context.Context, companyID, partnerID uuid.UUID) ([]int, error) {
+	args := m.Called(ctx, companyID, partnerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// MaxCode mocks the MaxCode method
+func (m *MockPartnerRepository) MaxCode(ctx context.Context, companyID uuid.UUID, prefix string, digits int) (string, error) {
+	args := m.Called(ctx, companyID, prefix, digits)
+	return args.String(0), args.Error(1)
+}
+
+// Merge mocks the Merge method
+func (m *MockPartnerRepository) Merge(ctx context.Context, merge *domain.PartnerMerge, source, target *domain.Partner, entry *domain.AuditLog) error {
+	args := m.Called(ctx, merge, source, target, entry)
+	return args.Error(0)
+}
+
+// CreateBatch mocks the CreateBatch method
+func (m *MockPartnerRepository) CreateBatch(ctx context.Context, partners []domain.Partner) error {
+	args := m.Called(ctx, partners)
+	return args.Error(0)
+}
+
+// UpdateActiveStatus mocks the UpdateActiveStatus method
+func (m *MockPartnerRepository) UpdateActiveStatus(ctx context.Context, companyID uuid.UUID, ids []uuid.UUID, isActive bool) error {
+	args := m.Called(ctx, companyID, ids, isActive)
+	return args.Error(0)
+}
+
+// ListForBusinessVerification mocks the ListForBusinessVerification method
+func (m *MockPartnerRepository) ListForBusinessVerification(ctx context.Context, verifiedBefore time.Time, limit int) ([]domain.Partner, error) {
+	args := m.Called(ctx, verifiedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Partner), args.Error(1)
+}
+
+// UpdateBusinessVerification mocks the UpdateBusinessVerification method
+func (m *MockPartnerRepository) UpdateBusinessVerification(ctx context.Context, partner *domain.Partner) error {
+	args := m.Called(ctx, partner)
+	return args.Error(0)
+}
+
+// GetCustomerCount mocks the GetCustomerCount method
+func (m *MockPartnerRepository) GetCustomerCount(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, companyID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetVendorCount mocks the GetVendorCount method
+func (m *MockPartnerRepository) GetVendorCount(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, companyID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Ensure MockPartnerRepository implements repository.PartnerRepository
+var _ repository.PartnerRepository = (*MockPartnerRepository)(nil)
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockPopbillWebhookRepository is a mock implementation of PopbillWebhookRepository
+type MockPopbillWebhookRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockPopbillWebhookRepository) Create(ctx context.Context, event *domain.PopbillWebhookEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// FindAll mocks the FindAll method
+func (m *MockPopbillWebhookRepository) FindAll(ctx context.Context, filter repository.PopbillWebhookFilter) ([]domain.PopbillWebhookEvent, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]domain.PopbillWebhookEvent), args.Get(1).(int64), args.Error(2)
+}
+
+// ClaimNext mocks the ClaimNext method
+func (m *MockPopbillWebhookRepository) ClaimNext(ctx context.Context, retryBefore, staleBefore time.Time) (*domain.PopbillWebhookEvent, error) {
+	args := m.Called(ctx, retryBefore, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PopbillWebhookEvent), args.Error(1)
+}
+
+// Finish mocks the Finish method
+func (m *MockPopbillWebhookRepository) Finish(ctx context.Context, event *domain.PopbillWebhookEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// Ensure MockPopbillWebhookRepository implements repository.PopbillWebhookRepository
+var _ repository.PopbillWebhookRepository = (*MockPopbillWebhookRepository)(nil)
@@ -0,0 +1,89 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockPrepaidExpenseRepository is a mock implementation of PrepaidExpenseRepository
+type MockPrepaidExpenseRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockPrepaidExpenseRepository) Create(ctx context.Context, expense *domain.PrepaidExpense) error {
+	args := m.Called(ctx, expense)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockPrepaidExpenseRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PrepaidExpense, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PrepaidExpense), args.Error(1)
+}
+
+// ExistsForVoucher mocks the ExistsForVoucher method
+func (m *MockPrepaidExpenseRepository) ExistsForVoucher(ctx context.Context, companyID, voucherID, expenseAccountID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, voucherID, expenseAccountID)
+	return args.Bool(0), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockPrepaidExpenseRepository) List(ctx context.Context, filter repository.PrepaidExpenseFilter) ([]domain.PrepaidExpense, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.PrepaidExpense), args.Get(1).(int64), args.Error(2)
+}
+
+// ListWithLines mocks the ListWithLines method
+func (m *MockPrepaidExpenseRepository) ListWithLines(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID) ([]domain.PrepaidExpense, error) {
+	args := m.Called(ctx, companyID, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PrepaidExpense), args.Error(1)
+}
+
+// UpdatePrepayment mocks the UpdatePrepayment method
+func (m *MockPrepaidExpenseRepository) UpdatePrepayment(ctx context.Context, expense *domain.PrepaidExpense) error {
+	args := m.Called(ctx, expense)
+	return args.Error(0)
+}
+
+// ListForAmortization mocks the ListForAmortization method
+func (m *MockPrepaidExpenseRepository) ListForAmortization(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.PrepaidExpense, error) {
+	args := m.Called(ctx, companyID, periodEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PrepaidExpense), args.Error(1)
+}
+
+// RecordAmortization mocks the RecordAmortization method
+func (m *MockPrepaidExpenseRepository) RecordAmortization(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine) error {
+	args := m.Called(ctx, expense, line)
+	return args.Error(0)
+}
+
+// ReleaseAmortization mocks the ReleaseAmortization method
+func (m *MockPrepaidExpenseRepository) ReleaseAmortization(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine) error {
+	args := m.Called(ctx, expense, line)
+	return args.Error(0)
+}
+
+// LinkAmortizationVoucher mocks the LinkAmortizationVoucher method
+func (m *MockPrepaidExpenseRepository) LinkAmortizationVoucher(ctx context.Context, line *domain.PrepaidExpenseLine) error {
+	args := m.Called(ctx, line)
+	return args.Error(0)
+}
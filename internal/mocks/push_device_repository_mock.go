@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// MockPushDeviceRepository is a mock implementation of repository.PushDeviceRepository
+type MockPushDeviceRepository struct {
+	mock.Mock
+}
+
+// Upsert mocks the Upsert method
+func (m *MockPushDeviceRepository) Upsert(ctx context.Context, device *domain.PushDevice) error {
+	args := m.Called(ctx, device)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockPushDeviceRepository) Delete(ctx context.Context, companyID, userID uuid.UUID, token string) error {
+	args := m.Called(ctx, companyID, userID, token)
+	return args.Error(0)
+}
+
+// DeleteByToken mocks the DeleteByToken method
+func (m *MockPushDeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+// FindByUsers mocks the FindByUsers method
+func (m *MockPushDeviceRepository) FindByUsers(ctx context.Context, companyID uuid.UUID, userIDs []uuid.UUID) ([]domain.PushDevice, error) {
+	args := m.Called(ctx, companyID, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PushDevice), args.Error(1)
+}
@@ -0,0 +1,89 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockRevenueScheduleRepository is a mock implementation of RevenueScheduleRepository
+type MockRevenueScheduleRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockRevenueScheduleRepository) Create(ctx context.Context, schedule *domain.RevenueSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockRevenueScheduleRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.RevenueSchedule, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RevenueSchedule), args.Error(1)
+}
+
+// ExistsForInvoice mocks the ExistsForInvoice method
+func (m *MockRevenueScheduleRepository) ExistsForInvoice(ctx context.Context, companyID, taxInvoiceID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, taxInvoiceID)
+	return args.Bool(0), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockRevenueScheduleRepository) List(ctx context.Context, filter repository.RevenueScheduleFilter) ([]domain.RevenueSchedule, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.RevenueSchedule), args.Get(1).(int64), args.Error(2)
+}
+
+// ListWithLines mocks the ListWithLines method
+func (m *MockRevenueScheduleRepository) ListWithLines(ctx context.Context, companyID uuid.UUID) ([]domain.RevenueSchedule, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RevenueSchedule), args.Error(1)
+}
+
+// UpdateDeferral mocks the UpdateDeferral method
+func (m *MockRevenueScheduleRepository) UpdateDeferral(ctx context.Context, schedule *domain.RevenueSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+// ListForRecognition mocks the ListForRecognition method
+func (m *MockRevenueScheduleRepository) ListForRecognition(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.RevenueSchedule, error) {
+	args := m.Called(ctx, companyID, periodEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RevenueSchedule), args.Error(1)
+}
+
+// RecordRecognition mocks the RecordRecognition method
+func (m *MockRevenueScheduleRepository) RecordRecognition(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine) error {
+	args := m.Called(ctx, schedule, line)
+	return args.Error(0)
+}
+
+// ReleaseRecognition mocks the ReleaseRecognition method
+func (m *MockRevenueScheduleRepository) ReleaseRecognition(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine) error {
+	args := m.Called(ctx, schedule, line)
+	return args.Error(0)
+}
+
+// LinkRecognitionVoucher mocks the LinkRecognitionVoucher method
+func (m *MockRevenueScheduleRepository) LinkRecognitionVoucher(ctx context.Context, line *domain.RevenueScheduleLine) error {
+	args := m.Called(ctx, line)
+	return args.Error(0)
+}
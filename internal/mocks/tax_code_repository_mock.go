@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockTaxCodeRepository is a mock implementation of TaxCodeRepository
+type MockTaxCodeRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockTaxCodeRepository) Create(ctx context.Context, taxCode *domain.TaxCode) error {
+	args := m.Called(ctx, taxCode)
+	return args.Error(0)
+}
+
+// Update mocks the Update method
+func (m *MockTaxCodeRepository) Update(ctx context.Context, taxCode *domain.TaxCode) error {
+	args := m.Called(ctx, taxCode)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockTaxCodeRepository) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	args := m.Called(ctx, companyID, id)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockTaxCodeRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxCode, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxCode), args.Error(1)
+}
+
+// FindAll mocks the FindAll method
+func (m *MockTaxCodeRepository) FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.TaxCode, error) {
+	args := m.Called(ctx, companyID, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TaxCode), args.Error(1)
+}
+
+// ExistsByCode mocks the ExistsByCode method
+func (m *MockTaxCodeRepository) ExistsByCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, code, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// IsInUse mocks the IsInUse method
+func (m *MockTaxCodeRepository) IsInUse(ctx context.Context, companyID, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetVATSummary mocks the GetVATSummary method
+func (m *MockTaxCodeRepository) GetVATSummary(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.VATSummaryItem, error) {
+	args := m.Called(ctx, companyID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.VATSummaryItem), args.Error(1)
+}
+
+// Ensure MockTaxCodeRepository implements TaxCodeRepository
+var _ repository.TaxCodeRepository = (*MockTaxCodeRepository)(nil)
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockTaxInvoiceBulkIssueRepository is a mock implementation of TaxInvoiceBulkIssueRepository
+type MockTaxInvoiceBulkIssueRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockTaxInvoiceBulkIssueRepository) Create(ctx context.Context, issue *domain.TaxInvoiceBulkIssue) error {
+	args := m.Called(ctx, issue)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockTaxInvoiceBulkIssueRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxInvoiceBulkIssue), args.Error(1)
+}
+
+// UpdateItem mocks the UpdateItem method
+func (m *MockTaxInvoiceBulkIssueRepository) UpdateItem(ctx context.Context, item *domain.TaxInvoiceBulkIssueItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// UpdateSummary mocks the UpdateSummary method
+func (m *MockTaxInvoiceBulkIssueRepository) UpdateSummary(ctx context.Context, issue *domain.TaxInvoiceBulkIssue) error {
+	args := m.Called(ctx, issue)
+	return args.Error(0)
+}
+
+// Ensure MockTaxInvoiceBulkIssueRepository implements repository.TaxInvoiceBulkIssueRepository
+var _ repository.TaxInvoiceBulkIssueRepository = (*MockTaxInvoiceBulkIssueRepository)(nil)
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockTaxInvoiceDeliveryRepository is a mock implementation of TaxInvoiceDeliveryRepository
+type MockTaxInvoiceDeliveryRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockTaxInvoiceDeliveryRepository) Create(ctx context.Context, delivery *domain.TaxInvoiceDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+// FindByMessageID mocks the FindByMessageID method
+func (m *MockTaxInvoiceDeliveryRepository) FindByMessageID(ctx context.Context, messageID string) (*domain.TaxInvoiceDelivery, error) {
+	args := m.Called(ctx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxInvoiceDelivery), args.Error(1)
+}
+
+// ListByInvoice mocks the ListByInvoice method
+func (m *MockTaxInvoiceDeliveryRepository) ListByInvoice(ctx context.Context, companyID, invoiceID uuid.UUID) ([]domain.TaxInvoiceDelivery, error) {
+	args := m.Called(ctx, companyID, invoiceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TaxInvoiceDelivery), args.Error(1)
+}
+
+// UpdateBounce mocks the UpdateBounce method
+func (m *MockTaxInvoiceDeliveryRepository) UpdateBounce(ctx context.Context, delivery *domain.TaxInvoiceDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+// Ensure MockTaxInvoiceDeliveryRepository implements repository.TaxInvoiceDeliveryRepository
+var _ repository.TaxInvoiceDeliveryRepository = (*MockTaxInvoiceDeliveryRepository)(nil)
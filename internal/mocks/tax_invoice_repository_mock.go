@@ -0,0 +1,134 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockTaxInvoiceRepository is a mock implementation of TaxInvoiceRepository
+type MockTaxInvoiceRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockTaxInvoiceRepository) Create(ctx context.Context, invoice *domain.TaxInvoice) error {
+	args := m.Called(ctx, invoice)
+	return args.Error(0)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockTaxInvoiceRepository) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoice, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxInvoice), args.Error(1)
+}
+
+// GetByNumber mocks the GetByNumber method
+func (m *MockTaxInvoiceRepository) GetByNumber(ctx context.Context, companyID uuid.UUID, number string, invoiceType domain.TaxInvoiceType) (*domain.TaxInvoice, error) {
+	args := m.Called(ctx, companyID, number, invoiceType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxInvoice), args.Error(1)
+}
+
+// GetByASPInvoiceID mocks the GetByASPInvoiceID method
+func (m *MockTaxInvoiceRepository) GetByASPInvoiceID(ctx context.Context, aspProvider, aspInvoiceID string) (*domain.TaxInvoice, error) {
+	args := m.Called(ctx, aspProvider, aspInvoiceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxInvoice), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockTaxInvoiceRepository) List(ctx context.Context, filter *repository.TaxInvoiceFilter) ([]*domain.TaxInvoice, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.TaxInvoice), args.Get(1).(int64), args.Error(2)
+}
+
+// ListAmendments mocks the ListAmendments method
+func (m *MockTaxInvoiceRepository) ListAmendments(ctx context.Context, companyID, originalID uuid.UUID) ([]*domain.TaxInvoice, error) {
+	args := m.Called(ctx, companyID, originalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaxInvoice), args.Error(1)
+}
+
+// Update mocks the Update method
+func (m *MockTaxInvoiceRepository) Update(ctx context.Context, invoice *domain.TaxInvoice) error {
+	args := m.Called(ctx, invoice)
+	return args.Error(0)
+}
+
+// UpdateStatus mocks the UpdateStatus method
+func (m *MockTaxInvoiceRepository) UpdateStatus(ctx context.Context, companyID, id uuid.UUID, status domain.TaxInvoiceStatus, userID *uuid.UUID) error {
+	args := m.Called(ctx, companyID, id, status, userID)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockTaxInvoiceRepository) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	args := m.Called(ctx, companyID, id)
+	return args.Error(0)
+}
+
+// CreateItem mocks the CreateItem method
+func (m *MockTaxInvoiceRepository) CreateItem(ctx context.Context, item *domain.TaxInvoiceItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// ListItems mocks the ListItems method
+func (m *MockTaxInvoiceRepository) ListItems(ctx context.Context, companyID, invoiceID uuid.UUID) ([]*domain.TaxInvoiceItem, error) {
+	args := m.Called(ctx, companyID, invoiceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaxInvoiceItem), args.Error(1)
+}
+
+// DeleteItems mocks the DeleteItems method
+func (m *MockTaxInvoiceRepository) DeleteItems(ctx context.Context, companyID, invoiceID uuid.UUID) error {
+	args := m.Called(ctx, companyID, invoiceID)
+	return args.Error(0)
+}
+
+// CreateHistory mocks the CreateHistory method
+func (m *MockTaxInvoiceRepository) CreateHistory(ctx context.Context, history *domain.TaxInvoiceHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+// ListHistory mocks the ListHistory method
+func (m *MockTaxInvoiceRepository) ListHistory(ctx context.Context, companyID, invoiceID uuid.UUID) ([]*domain.TaxInvoiceHistory, error) {
+	args := m.Called(ctx, companyID, invoiceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaxInvoiceHistory), args.Error(1)
+}
+
+// GetSummary mocks the GetSummary method
+func (m *MockTaxInvoiceRepository) GetSummary(ctx context.Context, companyID uuid.UUID, startDate, endDate time.Time) (*domain.TaxInvoiceSummary, error) {
+	args := m.Called(ctx, companyID, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaxInvoiceSummary), args.Error(1)
+}
+
+// Ensure MockTaxInvoiceRepository implements repository.TaxInvoiceRepository
+var _ repository.TaxInvoiceRepository = (*MockTaxInvoiceRepository)(nil)
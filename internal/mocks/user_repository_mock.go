@@ -0,0 +1,131 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockUserRepository is a mock implementation of repository.UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+// Update mocks the Update method
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockUserRepository) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	args := m.Called(ctx, companyID, id)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockUserRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// FindByUserID mocks the FindByUserID method
+func (m *MockUserRepository) FindByUserID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// FindMember mocks the FindMember method
+func (m *MockUserRepository) FindMember(ctx context.Context, companyID, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// FindByEmail mocks the FindByEmail method
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// FindByEmailAndCompany mocks the FindByEmailAndCompany method
+func (m *MockUserRepository) FindByEmailAndCompany(ctx context.Context, companyID uuid.UUID, email string) (*domain.User, error) {
+	args := m.Called(ctx, companyID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// FindAll mocks the FindAll method
+func (m *MockUserRepository) FindAll(ctx context.Context, filter repository.UserFilter) ([]domain.User, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+// ExistsByEmail mocks the ExistsByEmail method
+func (m *MockUserRepository) ExistsByEmail(ctx context.Context, companyID uuid.UUID, email string, excludeID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, companyID, email, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// UpdateLastLogin mocks the UpdateLastLogin method
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// ClaimSigningPINAttempt mocks the ClaimSigningPINAttempt method
+func (m *MockUserRepository) ClaimSigningPINAttempt(ctx context.Context, userID uuid.UUID, limit int) error {
+	args := m.Called(ctx, userID, limit)
+	return args.Error(0)
+}
+
+// ResetSigningPINAttempts mocks the ResetSigningPINAttempts method
+func (m *MockUserRepository) ResetSigningPINAttempts(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// LockActiveAdmins mocks the LockActiveAdmins method
+func (m *MockUserRepository) LockActiveAdmins(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+// WithTransaction mocks the WithTransaction method
+func (m *MockUserRepository) WithTransaction(ctx context.Context, fn func(repo repository.UserRepository) error) error {
+	args := m.Called(ctx, fn)
+	// Execute the function with the mock itself
+	if err := fn(m); err != nil {
+		return err
+	}
+	return args.Error(0)
+}
@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// MockVoucherAttachmentRepository is a mock implementation of VoucherAttachmentRepository
+type MockVoucherAttachmentRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockVoucherAttachmentRepository) Create(ctx context.Context, attachment *domain.VoucherAttachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockVoucherAttachmentRepository) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	args := m.Called(ctx, companyID, id)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockVoucherAttachmentRepository) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAttachment, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VoucherAttachment), args.Error(1)
+}
+
+// FindThumbnail mocks the FindThumbnail method
+func (m *MockVoucherAttachmentRepository) FindThumbnail(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAttachment, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VoucherAttachment), args.Error(1)
+}
+
+// FindByVoucher mocks the FindByVoucher method
+func (m *MockVoucherAttachmentRepository) FindByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherAttachment, error) {
+	args := m.Called(ctx, companyID, voucherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.VoucherAttachment), args.Error(1)
+}
+
+// UpdateScan mocks the UpdateScan method
+func (m *MockVoucherAttachmentRepository) UpdateScan(ctx context.Context, attachment *domain.VoucherAttachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+// Quarantine mocks the Quarantine method
+func (m *MockVoucherAttachmentRepository) Quarantine(ctx context.Context, file *domain.QuarantinedFile, attachmentID *uuid.UUID) error {
+	args := m.Called(ctx, file, attachmentID)
+	return args.Error(0)
+}
+
+// Ensure MockVoucherAttachmentRepository implements repository.VoucherAttachmentRepository
+var _ repository.VoucherAttachmentRepository = (*MockVoucherAttachmentRepository)(nil)
@@ -52,6 +52,15 @@ func (m *MockVoucherRepository) FindByNo(ctx context.Context, companyID uuid.UUI
 	return args.Get(0).(*domain.Voucher), args.Error(1)
 }
 
+// FindDetailByID mocks the FindDetailByID method
+func (m *MockVoucherRepository) FindDetailByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
 // FindAll mocks the FindAll method
 func (m *MockVoucherRepository) FindAll(ctx context.Context, filter repository.VoucherFilter) ([]domain.Voucher, int64, error) {
 	args := m.Called(ctx, filter)
@@ -61,6 +70,12 @@ func (m *MockVoucherRepository) FindAll(ctx context.Context, filter repository.V
 	return args.Get(0).([]domain.Voucher), args.Get(1).(int64), args.Error(2)
 }
 
+// FindAllIterator mocks the FindAllIterator method
+func (m *MockVoucherRepository) FindAllIterator(ctx context.Context, filter repository.VoucherFilter, fn func(voucher *domain.Voucher) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
 // FindByDateRange mocks the FindByDateRange method
 func (m *MockVoucherRepository) FindByDateRange(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.Voucher, error) {
 	args := m.Called(ctx, companyID, from, to)
@@ -79,6 +94,33 @@ func (m *MockVoucherRepository) FindByStatus(ctx context.Context, companyID uuid
 	return args.Get(0).([]domain.Voucher), args.Error(1)
 }
 
+// FindPendingAutoReversals mocks the FindPendingAutoReversals method
+func (m *MockVoucherRepository) FindPendingAutoReversals(ctx context.Context, before time.Time) ([]domain.Voucher, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
+// FindScheduled mocks the FindScheduled method
+func (m *MockVoucherRepository) FindScheduled(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
+// FindDueScheduledPostings mocks the FindDueScheduledPostings method
+func (m *MockVoucherRepository) FindDueScheduledPostings(ctx context.Context, asOf time.Time) ([]domain.Voucher, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
 // CreateEntry mocks the CreateEntry method
 func (m *MockVoucherRepository) CreateEntry(ctx context.Context, entry *domain.VoucherEntry) error {
 	args := m.Called(ctx, entry)
@@ -127,12 +169,45 @@ func (m *MockVoucherRepository) UpdateStatus(ctx context.Context, voucher *domai
 	return args.Error(0)
 }
 
+// UpdateReference mocks the UpdateReference method
+func (m *MockVoucherRepository) UpdateReference(ctx context.Context, voucher *domain.Voucher) error {
+	args := m.Called(ctx, voucher)
+	return args.Error(0)
+}
+
+// UpdateTags mocks the UpdateTags method
+func (m *MockVoucherRepository) UpdateTags(ctx context.Context, voucher *domain.Voucher) error {
+	args := m.Called(ctx, voucher)
+	return args.Error(0)
+}
+
+// UpdateSchedule mocks the UpdateSchedule method
+func (m *MockVoucherRepository) UpdateSchedule(ctx context.Context, voucher *domain.Voucher) error {
+	args := m.Called(ctx, voucher)
+	return args.Error(0)
+}
+
+// UpdateReversedAmounts mocks the UpdateReversedAmounts method
+func (m *MockVoucherRepository) UpdateReversedAmounts(ctx context.Context, voucher *domain.Voucher) error {
+	args := m.Called(ctx, voucher)
+	return args.Error(0)
+}
+
 // GenerateVoucherNo mocks the GenerateVoucherNo method
 func (m *MockVoucherRepository) GenerateVoucherNo(ctx context.Context, companyID uuid.UUID, voucherType domain.VoucherType, voucherDate time.Time) (string, error) {
 	args := m.Called(ctx, companyID, voucherType, voucherDate)
 	return args.String(0), args.Error(1)
 }
 
+// FindByIDForUpdate mocks the FindByIDForUpdate method
+func (m *MockVoucherRepository) FindByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
 // WithTransaction mocks the WithTransaction method
 func (m *MockVoucherRepository) WithTransaction(ctx context.Context, fn func(repo repository.VoucherRepository) error) error {
 	args := m.Called(ctx, fn)
@@ -143,5 +218,23 @@ func (m *MockVoucherRepository) WithTransaction(ctx context.Context, fn func(rep
 	return args.Error(0)
 }
 
+// FindSoDViolations mocks the FindSoDViolations method
+func (m *MockVoucherRepository) FindSoDViolations(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.SoDViolation, error) {
+	args := m.Called(ctx, companyID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SoDViolation), args.Error(1)
+}
+
+// FindEntrySuggestions mocks the FindEntrySuggestions method
+func (m *MockVoucherRepository) FindEntrySuggestions(ctx context.Context, filter repository.EntrySuggestionFilter) ([]domain.EntrySuggestion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.EntrySuggestion), args.Error(1)
+}
+
 // Ensure MockVoucherRepository implements VoucherRepository
 var _ repository.VoucherRepository = (*MockVoucherRepository)(nil)
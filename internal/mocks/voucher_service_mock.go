@@ -53,6 +53,15 @@ func (m *MockVoucherService) GetByNo(ctx context.Context, companyID uuid.UUID, v
 	return args.Get(0).(*domain.Voucher), args.Error(1)
 }
 
+// GetDetail mocks the GetDetail method
+func (m *MockVoucherService) GetDetail(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
 // List mocks the List method
 func (m *MockVoucherService) List(ctx context.Context, filter repository.VoucherFilter) ([]domain.Voucher, int64, error) {
 	args := m.Called(ctx, filter)
@@ -62,6 +71,12 @@ func (m *MockVoucherService) List(ctx context.Context, filter repository.Voucher
 	return args.Get(0).([]domain.Voucher), args.Get(1).(int64), args.Error(2)
 }
 
+// Export mocks the Export method
+func (m *MockVoucherService) Export(ctx context.Context, filter repository.VoucherFilter, fn func(voucher *domain.Voucher) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
 // GetByDateRange mocks the GetByDateRange method
 func (m *MockVoucherService) GetByDateRange(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.Voucher, error) {
 	args := m.Called(ctx, companyID, from, to)
@@ -134,6 +149,51 @@ func (m *MockVoucherService) Cancel(ctx context.Context, companyID, voucherID uu
 	return args.Error(0)
 }
 
+// SetTags mocks the SetTags method
+func (m *MockVoucherService) SetTags(ctx context.Context, companyID, voucherID, userID uuid.UUID, tags []string) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, voucherID, userID, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+// Schedule mocks the Schedule method
+func (m *MockVoucherService) Schedule(ctx context.Context, companyID, voucherID, userID uuid.UUID, postDate time.Time) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, voucherID, userID, postDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+// Unschedule mocks the Unschedule method
+func (m *MockVoucherService) Unschedule(ctx context.Context, companyID, voucherID uuid.UUID) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, voucherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+// GetScheduled mocks the GetScheduled method
+func (m *MockVoucherService) GetScheduled(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
+// ProcessScheduledPostings mocks the ProcessScheduledPostings method
+func (m *MockVoucherService) ProcessScheduledPostings(ctx context.Context, asOf time.Time) ([]domain.Voucher, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
 // Reverse mocks the Reverse method
 func (m *MockVoucherService) Reverse(ctx context.Context, companyID, voucherID, userID uuid.UUID, reversalDate time.Time, description string) (*domain.Voucher, error) {
 	args := m.Called(ctx, companyID, voucherID, userID, reversalDate, description)
@@ -143,11 +203,53 @@ func (m *MockVoucherService) Reverse(ctx context.Context, companyID, voucherID,
 	return args.Get(0).(*domain.Voucher), args.Error(1)
 }
 
+// PartialReverse mocks the PartialReverse method
+func (m *MockVoucherService) PartialReverse(ctx context.Context, companyID, voucherID, userID uuid.UUID, reversalDate time.Time, description string, ratio float64, lines []domain.ReversalLine) (*domain.Voucher, error) {
+	args := m.Called(ctx, companyID, voucherID, userID, reversalDate, description, ratio, lines)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+// Correct mocks the Correct method
+func (m *MockVoucherService) Correct(ctx context.Context, companyID, voucherID, userID uuid.UUID, correctionDate time.Time, description string, entries []domain.VoucherEntry) (*domain.VoucherCorrection, error) {
+	args := m.Called(ctx, companyID, voucherID, userID, correctionDate, description, entries)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VoucherCorrection), args.Error(1)
+}
+
+// ProcessAutoReversals mocks the ProcessAutoReversals method
+func (m *MockVoucherService) ProcessAutoReversals(ctx context.Context, asOf time.Time) ([]domain.Voucher, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
+// PostGenerated mocks the PostGenerated method
+func (m *MockVoucherService) PostGenerated(ctx context.Context, voucher *domain.Voucher, userID uuid.UUID) error {
+	args := m.Called(ctx, voucher, userID)
+	return args.Error(0)
+}
+
 // ValidateEntries mocks the ValidateEntries method
-func (m *MockVoucherService) ValidateEntries(ctx context.Context, companyID uuid.UUID, entries []domain.VoucherEntry) error {
-	args := m.Called(ctx, companyID, entries)
+func (m *MockVoucherService) ValidateEntries(ctx context.Context, companyID uuid.UUID, voucherType domain.VoucherType, entries []domain.VoucherEntry) error {
+	args := m.Called(ctx, companyID, voucherType, entries)
 	return args.Error(0)
 }
 
+// SoDViolations mocks the SoDViolations method
+func (m *MockVoucherService) SoDViolations(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.SoDViolation, error) {
+	args := m.Called(ctx, companyID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SoDViolation), args.Error(1)
+}
+
 // Ensure MockVoucherService implements service.VoucherService
 var _ service.VoucherService = (*MockVoucherService)(nil)
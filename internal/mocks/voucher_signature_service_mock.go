@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// MockVoucherSignatureService is a mock implementation of service.VoucherSignatureService
+type MockVoucherSignatureService struct {
+	mock.Mock
+}
+
+// Verify mocks the Verify method
+func (m *MockVoucherSignatureService) Verify(ctx context.Context, companyID, userID uuid.UUID, action domain.SignatureAction, input *service.SignatureInput) (*domain.VoucherSignature, error) {
+	args := m.Called(ctx, companyID, userID, action, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VoucherSignature), args.Error(1)
+}
+
+// Record mocks the Record method
+func (m *MockVoucherSignatureService) Record(ctx context.Context, voucher *domain.Voucher, signature *domain.VoucherSignature) error {
+	args := m.Called(ctx, voucher, signature)
+	return args.Error(0)
+}
+
+// GetByVoucher mocks the GetByVoucher method
+func (m *MockVoucherSignatureService) GetByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherSignature, error) {
+	args := m.Called(ctx, companyID, voucherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.VoucherSignature), args.Error(1)
+}
+
+// Ensure MockVoucherSignatureService implements service.VoucherSignatureService
+var _ service.VoucherSignatureService = (*MockVoucherSignatureService)(nil)
@@ -0,0 +1,354 @@
+// Package pdf implements a minimal PDF writer for printable business documents.
+// Text uses the non-embedded Adobe-Korea1 CID font so Hangul renders without shipping font files.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // register JPEG for DecodeConfig
+	"image/png"
+	"strings"
+)
+
+// Page sizes in points (1/72 inch)
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// fontName is the predefined Korean CID font referenced by every document
+const fontName = "HYGoThic-Medium"
+
+// ErrUnsupportedImage is returned for images that are neither JPEG nor PNG
+var ErrUnsupportedImage = errors.New("unsupported image format (JPEG or PNG required)")
+
+// Document is an in-memory PDF document
+type Document struct {
+	width  float64
+	height float64
+	pages  []*Page
+	images []*Image
+}
+
+// Page is a single page; coordinates are in points from the top-left corner
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+// Image is an image registered with the document
+type Image struct {
+	name       string
+	width      int
+	height     int
+	colorSpace string
+	filter     string
+	data       []byte
+}
+
+// Width returns the pixel width of the image
+func (i *Image) Width() int { return i.width }
+
+// Height returns the pixel height of the image
+func (i *Image) Height() int { return i.height }
+
+// New creates a document with the given page size
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// Width returns the page width
+func (d *Document) Width() float64 { return d.width }
+
+// Height returns the page height
+func (d *Document) Height() float64 { return d.height }
+
+// PageCount returns the number of pages
+func (d *Document) PageCount() int { return len(d.pages) }
+
+// AddPage appends a new blank page
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Pages returns the pages of the document
+func (d *Document) Pages() []*Page { return d.pages }
+
+// AddImage registers a JPEG or PNG image for drawing on pages
+func (d *Document) AddImage(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	img := &Image{
+		name:   fmt.Sprintf("Im%d", len(d.images)+1),
+		width:  cfg.Width,
+		height: cfg.Height,
+	}
+
+	switch format {
+	case "jpeg":
+		img.filter = "DCTDecode"
+		img.data = data
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			img.colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			img.colorSpace = "DeviceCMYK"
+		default:
+			img.colorSpace = "DeviceRGB"
+		}
+	case "png":
+		decoded, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrUnsupportedImage
+		}
+		img.filter = "FlateDecode"
+		img.colorSpace = "DeviceRGB"
+		img.data, err = flatRGB(decoded)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedImage
+	}
+
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+// flatRGB composites the image over white and returns zlib-compressed RGB samples
+func flatRGB(src image.Image) ([]byte, error) {
+	bounds := src.Bounds()
+	canvas := image.NewRGBA(bounds)
+	draw.Draw(canvas, bounds, image.White, image.Point{}, draw.Src)
+	draw.Draw(canvas, bounds, src, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	row := make([]byte, 0, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := canvas.RGBAAt(x, y)
+			row = append(row, c.R, c.G, c.B)
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// TextWidth returns the rendered width of s at the given font size.
+// Latin characters are half-width, everything else is full-width.
+func TextWidth(s string, size float64) float64 {
+	var w float64
+	for _, r := range s {
+		if r >= 0x20 && r <= 0x7e {
+			w += size * 0.5
+		} else {
+			w += size
+		}
+	}
+	return w
+}
+
+// Truncate shortens s with an ellipsis so that it fits within maxWidth
+func Truncate(s string, size, maxWidth float64) string {
+	if TextWidth(s, size) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && TextWidth(string(runes)+"...", size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// Text draws s with its baseline at (x, y)
+func (p *Page) Text(x, y, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F1 %s Tf %s %s Td <%s> Tj ET\n",
+		num(size), num(x), num(p.doc.height-y), encodeText(s))
+}
+
+// TextRight draws s right-aligned to x
+func (p *Page) TextRight(x, y, size float64, s string) {
+	p.Text(x-TextWidth(s, size), y, size, s)
+}
+
+// TextCenter draws s centered on x
+func (p *Page) TextCenter(x, y, size float64, s string) {
+	p.Text(x-TextWidth(s, size)/2, y, size, s)
+}
+
+// SetLineWidth sets the stroke width for subsequent lines and rectangles
+func (p *Page) SetLineWidth(w float64) {
+	fmt.Fprintf(&p.content, "%s w\n", num(w))
+}
+
+// SetGray sets the stroke and fill gray level (0 black, 1 white)
+func (p *Page) SetGray(g float64) {
+	fmt.Fprintf(&p.content, "%s G %s g\n", num(g), num(g))
+}
+
+// Line draws a line between two points
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "%s %s m %s %s l S\n",
+		num(x1), num(p.doc.height-y1), num(x2), num(p.doc.height-y2))
+}
+
+// Rect strokes a rectangle whose top-left corner is (x, y)
+func (p *Page) Rect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re S\n", num(x), num(p.doc.height-y-h), num(w), num(h))
+}
+
+// FillRect fills a rectangle with the given gray level and restores black
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n",
+		num(gray), num(x), num(p.doc.height-y-h), num(w), num(h))
+}
+
+// Image draws a registered image into the box whose top-left corner is (x, y)
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /%s Do Q\n",
+		num(w), num(h), num(x), num(p.doc.height-y-h), img.name)
+}
+
+// Bytes serializes the document
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Fixed objects: 1 catalog, 2 pages, 3 font, 4 CID font, 5 font descriptor
+	const catalogID, pagesID, fontID, cidFontID, descriptorID = 1, 2, 3, 4, 5
+	nextID := 6
+
+	imageIDs := make([]int, len(d.images))
+	for i := range d.images {
+		imageIDs[i] = nextID
+		nextID++
+	}
+	pageIDs := make([]int, len(d.pages))
+	contentIDs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = nextID
+		contentIDs[i] = nextID + 1
+		nextID += 2
+	}
+
+	w.object(catalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+
+	kids := make([]string, len(pageIDs))
+	for i, id := range pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	w.object(pagesID, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pageIDs)))
+
+	w.object(fontID, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniKS-UCS2-H /DescendantFonts [%d 0 R] >>",
+		fontName, cidFontID))
+	w.object(cidFontID, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Korea1) /Supplement 1 >> "+
+		"/FontDescriptor %d 0 R /DW 1000 /W [1 95 500] >>", fontName, descriptorID))
+	w.object(descriptorID, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 6 "+
+		"/FontBBox [-6 -145 1003 880] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>", fontName))
+
+	var xobjects []string
+	for i, img := range d.images {
+		w.stream(imageIDs[i], fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+			img.width, img.height, img.colorSpace, img.filter), img.data)
+		xobjects = append(xobjects, fmt.Sprintf("/%s %d 0 R", img.name, imageIDs[i]))
+	}
+
+	resources := fmt.Sprintf("<< /Font << /F1 %d 0 R >>", fontID)
+	if len(xobjects) > 0 {
+		resources += fmt.Sprintf(" /XObject << %s >>", strings.Join(xobjects, " "))
+	}
+	resources += " >>"
+
+	for i, page := range d.pages {
+		w.object(pageIDs[i], fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pagesID, num(d.width), num(d.height), resources, contentIDs[i]))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		w.stream(contentIDs[i], "/Filter /FlateDecode", compressed.Bytes())
+	}
+
+	w.trailer(nextID, catalogID)
+	return w.buf.Bytes(), nil
+}
+
+// writer tracks object offsets for the cross-reference table
+type writer struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *writer) object(id int, body string) {
+	w.mark(id)
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (w *writer) stream(id int, dict string, data []byte) {
+	w.mark(id)
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< %s /Length %d >>\nstream\n", id, dict, len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *writer) mark(id int) {
+	if w.offsets == nil {
+		w.offsets = make(map[int]int)
+	}
+	w.offsets[id] = w.buf.Len()
+}
+
+func (w *writer) trailer(size, rootID int) {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[id])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, rootID, xref)
+}
+
+// encodeText encodes s as big-endian UCS-2 hex for the UniKS-UCS2-H CMap
+func encodeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xffff {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// num formats a coordinate with at most two decimals
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
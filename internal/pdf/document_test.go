@@ -0,0 +1,66 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+
+	var logo bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	require.NoError(t, png.Encode(&logo, img))
+	im, err := doc.AddImage(logo.Bytes())
+	require.NoError(t, err)
+
+	page := doc.AddPage()
+	page.Text(40, 60, 12, "일반전표 GJ-2024-0001")
+	page.Rect(40, 80, 100, 20)
+	page.Image(im, 40, 10, 40, 20)
+	doc.AddPage().TextRight(500, 60, 10, "1,000")
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), "/Encoding /UniKS-UCS2-H")
+
+	// Every xref entry must point at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xrefOffset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+}
+
+func TestDocument_AddImageRejectsUnknownFormat(t *testing.T) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	_, err := doc.AddImage([]byte("not an image"))
+	assert.Equal(t, pdf.ErrUnsupportedImage, err)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", pdf.Truncate("abc", 10, 100))
+	truncated := pdf.Truncate("가나다라마바사아자차", 10, 50)
+	assert.LessOrEqual(t, pdf.TextWidth(truncated, 10), 50.0)
+	assert.Contains(t, truncated, "...")
+}
@@ -0,0 +1,116 @@
+// Package pii masks personally identifiable information: email addresses,
+// phone numbers and business registration numbers. Masking is partial so that
+// a masked value can still be told apart from others by whoever reads a log
+// line or an export, without disclosing the value itself.
+package pii
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maskRune replaces the masked characters
+const maskRune = '*'
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// Landline and mobile numbers with separators, and mobile numbers without them
+	phonePattern = regexp.MustCompile(`\b0\d{1,2}[-. ]\d{3,4}[-. ]\d{4}\b|\b01[016789]\d{7,8}\b`)
+	// Business registration numbers are written 3-2-5
+	businessNumberPattern = regexp.MustCompile(`\b\d{3}-\d{2}-\d{5}\b`)
+)
+
+// MaskEmail keeps the first two characters of the local part and the domain,
+// e.g. "kim.cs@example.com" becomes "ki****@example.com"
+func MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+	local := []rune(email[:at])
+	keep := 2
+	if len(local) <= keep {
+		keep = len(local) - 1
+	}
+	for i := max(keep, 0); i < len(local); i++ {
+		local[i] = maskRune
+	}
+	return string(local) + email[at:]
+}
+
+// MaskPhone keeps the area or carrier prefix and the last four digits, e.g.
+// "010-1234-5678" becomes "010-****-5678". Separators are kept.
+func MaskPhone(phone string) string {
+	digits := countDigits(phone)
+	prefix := 3
+	if strings.HasPrefix(strings.TrimLeft(phone, "( "), "02") {
+		prefix = 2 // Seoul
+	}
+	if digits < prefix+7 {
+		prefix = 0 // too short to have a prefix, e.g. 1588-1234
+	}
+	return maskDigits(phone, prefix, digits-4)
+}
+
+// MaskBusinessNumber keeps the office and type codes and masks the serial
+// number and check digit, e.g. "123-45-67890" becomes "123-45-*****"
+func MaskBusinessNumber(number string) string {
+	return maskDigits(number, 5, countDigits(number))
+}
+
+// MaskText masks the email addresses, phone numbers and business registration
+// numbers found in free text
+func MaskText(s string) string {
+	if !strings.ContainsAny(s, "@0123456789") {
+		return s
+	}
+	s = emailPattern.ReplaceAllStringFunc(s, MaskEmail)
+	s = businessNumberPattern.ReplaceAllStringFunc(s, MaskBusinessNumber)
+	return phonePattern.ReplaceAllStringFunc(s, MaskPhone)
+}
+
+// MaskColumn masks a value by the name of the column or field holding it:
+// whole values of email, phone and business number columns, and the PII
+// found in any other text
+func MaskColumn(column, value string) string {
+	if value == "" {
+		return value
+	}
+	column = strings.ToLower(column)
+	switch {
+	case strings.Contains(column, "email"):
+		return MaskEmail(value)
+	case strings.Contains(column, "phone"), strings.Contains(column, "mobile"), strings.Contains(column, "fax"):
+		return MaskPhone(value)
+	case strings.Contains(column, "business_number"), strings.Contains(column, "registration_number"):
+		return MaskBusinessNumber(value)
+	}
+	return MaskText(value)
+}
+
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// maskDigits masks the digits of s from the from-th up to the to-th (exclusive),
+// counting digits only
+func maskDigits(s string, from, to int) string {
+	b := []byte(s)
+	n := 0
+	for i, c := range b {
+		if c < '0' || c > '9' {
+			continue
+		}
+		if n >= from && n < to {
+			b[i] = maskRune
+		}
+		n++
+	}
+	return string(b)
+}
@@ -0,0 +1,74 @@
+package pii
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "ki****@example.com", MaskEmail("kim.cs@example.com"))
+	assert.Equal(t, "k*@example.com", MaskEmail("ki@example.com"))
+	assert.Equal(t, "*@example.com", MaskEmail("k@example.com"))
+	assert.Equal(t, "not an email", MaskEmail("not an email"))
+}
+
+func TestMaskPhone(t *testing.T) {
+	tests := map[string]string{
+		"010-1234-5678": "010-****-5678",
+		"01012345678":   "010****5678",
+		"02-123-4567":   "02-***-4567",
+		"031-123-4567":  "031-***-4567",
+		"1588-1234":     "****-1234",
+	}
+	for phone, want := range tests {
+		assert.Equal(t, want, MaskPhone(phone), phone)
+	}
+}
+
+func TestMaskBusinessNumber(t *testing.T) {
+	assert.Equal(t, "123-45-*****", MaskBusinessNumber("123-45-67890"))
+	assert.Equal(t, "12345*****", MaskBusinessNumber("1234567890"))
+}
+
+func TestMaskText(t *testing.T) {
+	assert.Equal(t,
+		"거래처 (주)한빛상사 123-45-***** 담당 le*@hanbit.example, 010-****-5678",
+		MaskText("거래처 (주)한빛상사 123-45-67890 담당 lee@hanbit.example, 010-1234-5678"))
+	assert.Equal(t, "2026년 3월 사무용품 구입 1,250,000원", MaskText("2026년 3월 사무용품 구입 1,250,000원"))
+	assert.Equal(t, "invoice 2026-03-15 voucher V-2026-000123", MaskText("invoice 2026-03-15 voucher V-2026-000123"))
+}
+
+func TestMaskColumn(t *testing.T) {
+	assert.Equal(t, "le*@hanbit.example", MaskColumn("email", "lee@hanbit.example"))
+	assert.Equal(t, "02-****-5678", MaskColumn("Phone", "02-1234-5678"))
+	assert.Equal(t, "123-45-*****", MaskColumn("business_number", "123-45-67890"))
+	assert.Equal(t, "문의 010-****-5678", MaskColumn("description", "문의 010-1234-5678"))
+	assert.Empty(t, MaskColumn("email", ""))
+}
+
+func TestNewCore(t *testing.T) {
+	observed, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(observed, zap.WrapCore(NewCore)).With(zap.String("email", "kim@example.com"))
+
+	logger.Info("login failed for kim@example.com",
+		zap.String("phone", "010-1234-5678"),
+		zap.Int("attempts", 3),
+		zap.Error(errors.New("no user 123-45-67890")),
+	)
+	logger.Debug("dropped")
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "login failed for ki*@example.com", entry.Message)
+	fields := entry.ContextMap()
+	assert.Equal(t, "ki*@example.com", fields["email"])
+	assert.Equal(t, "010-****-5678", fields["phone"])
+	assert.Equal(t, int64(3), fields["attempts"])
+	assert.Equal(t, "no user 123-45-*****", fields["error"])
+}
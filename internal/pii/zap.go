@@ -0,0 +1,59 @@
+package pii
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewCore wraps a zap core so that the messages and errors of log entries are
+// masked with MaskText and their string fields with MaskColumn, taking the
+// field key as the column. Use it with zap.WrapCore. Structured
+// and reflected fields are written as they are; log the values they hold
+// with string fields to have them masked.
+func NewCore(core zapcore.Core) zapcore.Core {
+	return &maskingCore{Core: core}
+}
+
+type maskingCore struct {
+	zapcore.Core
+}
+
+func (c *maskingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &maskingCore{Core: c.Core.With(maskFields(fields))}
+}
+
+func (c *maskingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *maskingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = MaskText(ent.Message)
+	return c.Core.Write(ent, maskFields(fields))
+}
+
+// maskFields returns the fields with their text masked, leaving the caller's slice untouched
+func maskFields(fields []zapcore.Field) []zapcore.Field {
+	if len(fields) == 0 {
+		return fields
+	}
+	masked := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = MaskColumn(f.Key, f.String)
+		case zapcore.ByteStringType:
+			if b, ok := f.Interface.([]byte); ok {
+				f = zap.String(f.Key, MaskColumn(f.Key, string(b)))
+			}
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				f = zap.String(f.Key, MaskText(err.Error()))
+			}
+		}
+		masked[i] = f
+	}
+	return masked
+}
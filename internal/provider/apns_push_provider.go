@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apnsProductionURL = "https://api.push.apple.com"
+	apnsSandboxURL    = "https://api.sandbox.push.apple.com"
+	// apnsTokenLifetime is how long a provider token is reused; APNs rejects
+	// tokens older than an hour and throttles tokens renewed too often
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsConfig holds Apple Push Notification service configuration for
+// token-based authentication
+type APNsConfig struct {
+	KeyID   string // ID of the APNs authentication key
+	TeamID  string
+	KeyFile string // authentication key (.p8)
+	Topic   string // bundle ID of the app
+	Sandbox bool   // use the development environment
+	Timeout time.Duration
+}
+
+// APNsPushProvider implements PushProvider for the Apple Push Notification service
+type APNsPushProvider struct {
+	config   *APNsConfig
+	key      *ecdsa.PrivateKey
+	client   *http.Client
+	priority int
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// NewAPNsPushProvider creates a new APNs push provider from the authentication key file
+func NewAPNsPushProvider(config *APNsConfig) (*APNsPushProvider, error) {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	data, err := os.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read APNs key: %w", err)
+	}
+	parsed, err := parsePKCS8Key(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse APNs key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("parse APNs key: not an ECDSA key")
+	}
+
+	return &APNsPushProvider{
+		config: config,
+		key:    key,
+		// APNs only accepts HTTP/2
+		client:   &http.Client{Timeout: config.Timeout, Transport: &http.Transport{ForceAttemptHTTP2: true}},
+		priority: 1,
+	}, nil
+}
+
+// Type returns the provider type
+func (p *APNsPushProvider) Type() ProviderType {
+	return ProviderTypeAPNs
+}
+
+// Name returns the provider name
+func (p *APNsPushProvider) Name() string {
+	return "Apple Push Notification service"
+}
+
+// IsAvailable checks if the provider is configured
+func (p *APNsPushProvider) IsAvailable(ctx context.Context) bool {
+	return p.config.KeyID != "" && p.config.TeamID != "" && p.config.Topic != ""
+}
+
+// Health returns the health status
+func (p *APNsPushProvider) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeAPNs,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *APNsPushProvider) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider
+func (p *APNsPushProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+// Send delivers the message as an alert notification
+func (p *APNsPushProvider) Send(ctx context.Context, msg *PushMessage) error {
+	if !p.IsAvailable(ctx) {
+		return ErrProviderUnavailable
+	}
+	token, err := p.providerToken()
+	if err != nil {
+		return err
+	}
+
+	// Custom data goes next to the aps dictionary
+	body := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{"title": msg.Title, "body": msg.Body},
+			"sound": "default",
+		},
+	}
+	for k, v := range msg.Data {
+		if k != "aps" {
+			body[k] = v
+		}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	baseURL := apnsProductionURL
+	if p.config.Sandbox {
+		baseURL = apnsSandboxURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/3/device/"+url.PathEscape(msg.Token), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", p.config.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPushSendFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var result struct {
+		Reason string `json:"reason"`
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	_ = json.Unmarshal(respBody, &result)
+	// 410 is returned for tokens no longer active for the topic
+	if resp.StatusCode == http.StatusGone || result.Reason == "BadDeviceToken" || result.Reason == "Unregistered" {
+		return ErrPushTokenInvalid
+	}
+	return fmt.Errorf("%w: APNs returned %d: %s", ErrPushSendFailed, resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
+
+// providerToken returns the cached provider authentication token, signing a
+// new one when it is about to expire
+func (p *APNsPushProvider) providerToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && time.Since(p.issuedAt) < apnsTokenLifetime {
+		return p.token, nil
+	}
+
+	now := time.Now()
+	token, err := signJWT("ES256", p.config.KeyID, map[string]interface{}{
+		"iss": p.config.TeamID,
+		"iat": now.Unix(),
+	}, func(input []byte) ([]byte, error) {
+		digest := sha256.Sum256(input)
+		r, s, err := ecdsa.Sign(rand.Reader, p.key, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		// JWS wants the fixed-size concatenation of r and s
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	p.token = token
+	p.issuedAt = now
+	return token, nil
+}
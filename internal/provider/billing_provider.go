@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Billing errors
+var (
+	ErrBillingRejected = errors.New("billing provider rejected the usage report")
+)
+
+// UsageReport is the usage of a metric by a billing customer in a month
+type UsageReport struct {
+	CustomerID  string // the customer of the tenant at the billing provider
+	Metric      string
+	Quantity    int64
+	PeriodStart time.Time
+	PeriodEnd   time.Time // exclusive
+
+	// IdempotencyKey is the same for every attempt to report the usage, so
+	// that a retried report is not billed twice
+	IdempotencyKey string
+}
+
+// BillingProvider interface for reporting metered usage to a billing provider
+// (Stripe, Toss Payments), which prices and invoices it
+type BillingProvider interface {
+	Provider
+
+	// ReportUsage reports the usage and returns the provider's reference of it
+	ReportUsage(ctx context.Context, report *UsageReport) (string, error)
+}
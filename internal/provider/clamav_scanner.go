@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamavChunkSize is the size of the INSTREAM chunks sent to clamd
+const clamavChunkSize = 64 << 10
+
+// ClamAVConfig holds clamd connection configuration
+type ClamAVConfig struct {
+	Address string // TCP address of clamd, host:port
+	Timeout time.Duration
+}
+
+// ClamAVScanner implements VirusScanner with the clamd INSTREAM protocol
+type ClamAVScanner struct {
+	config   *ClamAVConfig
+	dialer   net.Dialer
+	priority int
+}
+
+// NewClamAVScanner creates a new ClamAV scanner
+func NewClamAVScanner(config *ClamAVConfig) *ClamAVScanner {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &ClamAVScanner{
+		config:   config,
+		dialer:   net.Dialer{Timeout: config.Timeout},
+		priority: 1,
+	}
+}
+
+// Type returns the provider type
+func (p *ClamAVScanner) Type() ProviderType {
+	return ProviderTypeClamAV
+}
+
+// Name returns the provider name
+func (p *ClamAVScanner) Name() string {
+	return "ClamAV"
+}
+
+// IsAvailable checks if the scanner is configured
+func (p *ClamAVScanner) IsAvailable(ctx context.Context) bool {
+	return p.config.Address != ""
+}
+
+// Health pings clamd
+func (p *ClamAVScanner) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeClamAV,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+		return health
+	}
+
+	start := time.Now()
+	reply, err := p.command(ctx, "zPING\x00", nil)
+	health.Latency = time.Since(start)
+	if err == nil && reply != "PONG" {
+		err = fmt.Errorf("unexpected clamd reply: %s", reply)
+	}
+	if err != nil {
+		health.Status = ProviderStatusError
+		health.LastError = err
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *ClamAVScanner) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider; connections are opened per scan
+func (p *ClamAVScanner) Close() error {
+	return nil
+}
+
+// Scan streams the file to clamd and parses its verdict
+func (p *ClamAVScanner) Scan(ctx context.Context, name string, data []byte) (*ScanResult, error) {
+	if !p.IsAvailable(ctx) {
+		return nil, ErrProviderUnavailable
+	}
+
+	reply, err := p.command(ctx, "zINSTREAM\x00", data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+	return parseClamAVReply(reply)
+}
+
+// command sends a clamd command, followed by data as INSTREAM chunks when data is not nil,
+// and returns the reply without its terminator
+func (p *ClamAVScanner) command(ctx context.Context, cmd string, data []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	conn, err := p.dialer.DialContext(ctx, "tcp", p.config.Address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	w := bufio.NewWriter(conn)
+	if _, err := w.WriteString(cmd); err != nil {
+		return "", err
+	}
+	if data != nil {
+		var size [4]byte
+		for len(data) > 0 {
+			n := len(data)
+			if n > clamavChunkSize {
+				n = clamavChunkSize
+			}
+			binary.BigEndian.PutUint32(size[:], uint32(n))
+			if _, err := w.Write(size[:]); err != nil {
+				return "", err
+			}
+			if _, err := w.Write(data[:n]); err != nil {
+				return "", err
+			}
+			data = data[n:]
+		}
+		// A zero-length chunk ends the stream
+		binary.BigEndian.PutUint32(size[:], 0)
+		if _, err := w.Write(size[:]); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(reply, "\x00")), nil
+}
+
+// parseClamAVReply parses an INSTREAM reply: "stream: OK", "stream: <signature> FOUND"
+// or "<message> ERROR"
+func parseClamAVReply(reply string) (*ScanResult, error) {
+	reply = strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case reply == "OK":
+		return &ScanResult{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return &ScanResult{Infected: true, Signature: strings.TrimSuffix(reply, " FOUND")}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrScanFailed, reply)
+}
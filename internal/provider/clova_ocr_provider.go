@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClovaOCRConfig holds Naver CLOVA OCR (receipt) API configuration
+type ClovaOCRConfig struct {
+	InvokeURL string // APIGW invoke URL of the receipt domain
+	SecretKey string
+	Timeout   time.Duration
+}
+
+// ClovaOCRProvider implements ReceiptOCRProvider for Naver CLOVA OCR
+type ClovaOCRProvider struct {
+	config   *ClovaOCRConfig
+	client   *http.Client
+	priority int
+}
+
+// NewClovaOCRProvider creates a new CLOVA OCR provider
+func NewClovaOCRProvider(config *ClovaOCRConfig) *ClovaOCRProvider {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &ClovaOCRProvider{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		priority: 1,
+	}
+}
+
+// Type returns the provider type
+func (p *ClovaOCRProvider) Type() ProviderType {
+	return ProviderTypeClova
+}
+
+// Name returns the provider name
+func (p *ClovaOCRProvider) Name() string {
+	return "Naver CLOVA OCR"
+}
+
+// IsAvailable checks if the provider is configured
+func (p *ClovaOCRProvider) IsAvailable(ctx context.Context) bool {
+	return p.config.InvokeURL != "" && p.config.SecretKey != ""
+}
+
+// Health returns the health status
+func (p *ClovaOCRProvider) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeClova,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *ClovaOCRProvider) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider
+func (p *ClovaOCRProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+// clovaRequest is the request body of the CLOVA OCR API
+type clovaRequest struct {
+	Version   string            `json:"version"`
+	RequestID string            `json:"requestId"`
+	Timestamp int64             `json:"timestamp"`
+	Images    []clovaImageInput `json:"images"`
+}
+
+type clovaImageInput struct {
+	Format string `json:"format"`
+	Name   string `json:"name"`
+	Data   string `json:"data"`
+}
+
+// clovaField is a recognized text field with its normalized value
+type clovaField struct {
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidenceScore"`
+	Formatted  struct {
+		Value string `json:"value"`
+		Year  string `json:"year"`
+		Month string `json:"month"`
+		Day   string `json:"day"`
+	} `json:"formatted"`
+}
+
+// clovaResponse is the subset of the receipt API response used here
+type clovaResponse struct {
+	Images []struct {
+		InferResult string `json:"inferResult"`
+		Message     string `json:"message"`
+		Receipt     struct {
+			Result struct {
+				StoreInfo struct {
+					Name   *clovaField `json:"name"`
+					BizNum *clovaField `json:"bizNum"`
+				} `json:"storeInfo"`
+				PaymentInfo struct {
+					Date     *clovaField `json:"date"`
+					CardInfo struct {
+						Number *clovaField `json:"number"`
+					} `json:"cardInfo"`
+					ConfirmNum *clovaField `json:"confirmNum"`
+				} `json:"paymentInfo"`
+				SubTotal []struct {
+					TaxPrice []clovaField `json:"taxPrice"`
+				} `json:"subTotal"`
+				TotalPrice struct {
+					Price *clovaField `json:"price"`
+				} `json:"totalPrice"`
+			} `json:"result"`
+		} `json:"receipt"`
+	} `json:"images"`
+}
+
+// RecognizeReceipt sends the image to the CLOVA receipt OCR API and maps the result
+func (p *ClovaOCRProvider) RecognizeReceipt(ctx context.Context, companyID uuid.UUID, image *ReceiptImage) (*ReceiptData, error) {
+	if !p.IsAvailable(ctx) {
+		return nil, ErrProviderUnavailable
+	}
+
+	format, err := image.Format()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(clovaRequest{
+		Version:   "V2",
+		RequestID: uuid.New().String(),
+		Timestamp: time.Now().UnixMilli(),
+		Images: []clovaImageInput{{
+			Format: format,
+			Name:   "receipt",
+			Data:   base64.StdEncoding.EncodeToString(image.Data),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.InvokeURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-OCR-SECRET", p.config.SecretKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ErrProviderTimeout
+		}
+		return nil, fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, ErrInvalidCredentials
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, ErrQuotaExceeded
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: clova ocr returned status %d", ErrProviderUnavailable, resp.StatusCode)
+	}
+
+	var parsed clovaResponse
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOCRRecognitionFailed, err)
+	}
+
+	data, err := parsed.toReceiptData()
+	if err != nil {
+		return nil, err
+	}
+	data.RawResponse = raw
+	return data, nil
+}
+
+// toReceiptData maps the first recognized image to ReceiptData
+func (r *clovaResponse) toReceiptData() (*ReceiptData, error) {
+	if len(r.Images) == 0 || r.Images[0].InferResult != "SUCCESS" {
+		return nil, ErrOCRRecognitionFailed
+	}
+	result := r.Images[0].Receipt.Result
+
+	data := &ReceiptData{}
+	var confidenceSum float64
+	var fields int
+	track := func(f *clovaField) bool {
+		if f == nil {
+			return false
+		}
+		confidenceSum += f.Confidence
+		fields++
+		return true
+	}
+
+	if f := result.StoreInfo.Name; track(f) {
+		data.VendorName = f.Text
+	}
+	if f := result.StoreInfo.BizNum; track(f) {
+		data.VendorBusinessNumber = f.Text
+	}
+	if f := result.PaymentInfo.Date; track(f) {
+		data.TransactionDate = f.date()
+	}
+	if f := result.PaymentInfo.CardInfo.Number; f != nil {
+		data.CardNumber = f.Text
+	}
+	if f := result.PaymentInfo.ConfirmNum; f != nil {
+		data.ApprovalNumber = f.Text
+	}
+	if f := result.TotalPrice.Price; track(f) {
+		data.TotalAmount = f.amount()
+	}
+	for _, sub := range result.SubTotal {
+		for i := range sub.TaxPrice {
+			track(&sub.TaxPrice[i])
+			data.TaxAmount += sub.TaxPrice[i].amount()
+		}
+	}
+	if data.TotalAmount > 0 {
+		data.SupplyAmount = data.TotalAmount - data.TaxAmount
+	}
+	if fields > 0 {
+		data.Confidence = confidenceSum / float64(fields)
+	}
+
+	return data, nil
+}
+
+// amount parses the normalized numeric value of a price field
+func (f *clovaField) amount() int64 {
+	value := f.Formatted.Value
+	if value == "" {
+		value = f.Text
+	}
+	value = strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+// date parses the normalized date of a date field
+func (f *clovaField) date() *time.Time {
+	year, errY := strconv.Atoi(f.Formatted.Year)
+	month, errM := strconv.Atoi(f.Formatted.Month)
+	day, errD := strconv.Atoi(f.Formatted.Day)
+	if errY != nil || errM != nil || errD != nil {
+		return nil
+	}
+	if year < 100 {
+		year += 2000
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+// Email errors
+var (
+	ErrEmailNoRecipients = errors.New("email has no recipients")
+	ErrEmailSendFailed   = errors.New("email could not be sent")
+)
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// EmailMessage represents an outgoing email
+type EmailMessage struct {
+	To          []string
+	Cc          []string
+	Subject     string
+	TextBody    string
+	HTMLBody    string // optional alternative to TextBody
+	Attachments []EmailAttachment
+
+	// MessageID is the Message-ID header, including angle brackets. When empty
+	// one is generated; set it to match bounces to the message later.
+	MessageID string
+}
+
+// Recipients returns all envelope recipients
+func (m *EmailMessage) Recipients() []string {
+	return append(append([]string{}, m.To...), m.Cc...)
+}
+
+// EmailProvider interface for outgoing email delivery
+type EmailProvider interface {
+	Provider
+
+	// Send delivers the message
+	Send(ctx context.Context, msg *EmailMessage) error
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fcmScope       = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmSendURL     = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+)
+
+// FCMConfig holds Firebase Cloud Messaging (HTTP v1 API) configuration
+type FCMConfig struct {
+	ProjectID       string // taken from the credentials when empty
+	CredentialsFile string // service account key (JSON) with the Firebase messaging scope
+	Timeout         time.Duration
+}
+
+// fcmServiceAccount is the subset of a service account key file used here
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMPushProvider implements PushProvider for Firebase Cloud Messaging. It
+// authorizes with OAuth 2.0 access tokens obtained for the service account.
+type FCMPushProvider struct {
+	config   *FCMConfig
+	account  fcmServiceAccount
+	key      *rsa.PrivateKey
+	client   *http.Client
+	priority int
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCMPushProvider creates a new FCM push provider from the service account key file
+func NewFCMPushProvider(config *FCMConfig) (*FCMPushProvider, error) {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	data, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read FCM credentials: %w", err)
+	}
+	var account fcmServiceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("parse FCM credentials: %w", err)
+	}
+	parsed, err := parsePKCS8Key(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse FCM private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("parse FCM private key: not an RSA key")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = googleTokenURL
+	}
+	if config.ProjectID == "" {
+		config.ProjectID = account.ProjectID
+	}
+
+	return &FCMPushProvider{
+		config:   config,
+		account:  account,
+		key:      key,
+		client:   &http.Client{Timeout: config.Timeout},
+		priority: 1,
+	}, nil
+}
+
+// Type returns the provider type
+func (p *FCMPushProvider) Type() ProviderType {
+	return ProviderTypeFCM
+}
+
+// Name returns the provider name
+func (p *FCMPushProvider) Name() string {
+	return "Firebase Cloud Messaging"
+}
+
+// IsAvailable checks if the provider is configured
+func (p *FCMPushProvider) IsAvailable(ctx context.Context) bool {
+	return p.config.ProjectID != "" && p.account.ClientEmail != ""
+}
+
+// Health returns the health status
+func (p *FCMPushProvider) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeFCM,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *FCMPushProvider) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider
+func (p *FCMPushProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+// fcmMessage is the request body of the messages:send API
+type fcmMessage struct {
+	Message struct {
+		Token        string            `json:"token"`
+		Notification fcmNotification   `json:"notification"`
+		Data         map[string]string `json:"data,omitempty"`
+	} `json:"message"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Send delivers the message through the FCM HTTP v1 API
+func (p *FCMPushProvider) Send(ctx context.Context, msg *PushMessage) error {
+	if !p.IsAvailable(ctx) {
+		return ErrProviderUnavailable
+	}
+	token, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body fcmMessage
+	body.Message.Token = msg.Token
+	body.Message.Notification = fcmNotification{Title: msg.Title, Body: msg.Body}
+	body.Message.Data = msg.Data
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(fcmSendURL, url.PathEscape(p.config.ProjectID)), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPushSendFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	// Tokens of uninstalled apps are reported as UNREGISTERED, with 404
+	if resp.StatusCode == http.StatusNotFound || strings.Contains(string(respBody), "UNREGISTERED") {
+		return ErrPushTokenInvalid
+	}
+	return fmt.Errorf("%w: FCM returned %d: %s", ErrPushSendFailed, resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
+
+// token returns a cached access token, requesting a new one shortly before it expires
+func (p *FCMPushProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.accessToken != "" && time.Now().Before(p.expiresAt.Add(-time.Minute)) {
+		return p.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT("RS256", "", map[string]interface{}{
+		"iss":   p.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   p.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}, func(input []byte) ([]byte, error) {
+		digest := sha256.Sum256(input)
+		return rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	})
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: FCM token request: %v", ErrPushSendFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("%w: FCM token request returned %d: %s", ErrInvalidCredentials, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("%w: FCM token response: %v", ErrPushSendFailed, err)
+	}
+	p.accessToken = result.AccessToken
+	p.expiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return p.accessToken, nil
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileStorage implements ObjectStorage in a local directory, for development
+// and single-host installs. Objects are written atomically and readable by
+// the owner only.
+type FileStorage struct {
+	dir      string
+	priority int
+}
+
+// NewFileStorage creates a new file storage rooted at dir
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{dir: dir, priority: 1}
+}
+
+// Type returns the provider type
+func (s *FileStorage) Type() ProviderType {
+	return ProviderTypeFile
+}
+
+// Name returns the provider name
+func (s *FileStorage) Name() string {
+	return "File (" + s.dir + ")"
+}
+
+// IsAvailable checks if the directory exists
+func (s *FileStorage) IsAvailable(ctx context.Context) bool {
+	info, err := os.Stat(s.dir)
+	return err == nil && info.IsDir()
+}
+
+// Health checks the directory
+func (s *FileStorage) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeFile,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !s.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+	}
+	return health
+}
+
+// Priority returns the priority
+func (s *FileStorage) Priority() int {
+	return s.priority
+}
+
+// Close closes the provider
+func (s *FileStorage) Close() error {
+	return nil
+}
+
+// Put writes the object to a temporary file and renames it into place
+func (s *FileStorage) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get reads the object
+func (s *FileStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return data, err
+}
+
+// Delete removes the object
+func (s *FileStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key to a file under the directory, refusing keys that would
+// leave it
+func (s *FileStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if key == "" || strings.Contains(key, "..") || clean == "/" {
+		return "", fmt.Errorf("invalid object key: %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
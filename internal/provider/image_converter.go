@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+// Image conversion errors
+var (
+	ErrConversionFailed = errors.New("image conversion failed")
+)
+
+// ImageConverter interface for converting images the server cannot decode
+// itself, such as the HEIC photos of iPhones, to JPEG
+type ImageConverter interface {
+	Provider
+
+	// ConvertToJPEG converts an image to JPEG
+	ConvertToJPEG(ctx context.Context, data []byte) ([]byte, error)
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ImageMagickConfig holds the ImageMagick command configuration
+type ImageMagickConfig struct {
+	Path    string // magick binary, built with libheif for HEIC
+	Timeout time.Duration
+}
+
+// ImageMagickConverter implements ImageConverter by running ImageMagick,
+// streaming the image through stdin and stdout
+type ImageMagickConverter struct {
+	config   *ImageMagickConfig
+	priority int
+}
+
+// NewImageMagickConverter creates a new ImageMagick converter
+func NewImageMagickConverter(config *ImageMagickConfig) *ImageMagickConverter {
+	if config.Path == "" {
+		config.Path = "magick"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &ImageMagickConverter{config: config, priority: 1}
+}
+
+// Type returns the provider type
+func (p *ImageMagickConverter) Type() ProviderType {
+	return ProviderTypeMagick
+}
+
+// Name returns the provider name
+func (p *ImageMagickConverter) Name() string {
+	return "ImageMagick"
+}
+
+// IsAvailable checks if the command is installed
+func (p *ImageMagickConverter) IsAvailable(ctx context.Context) bool {
+	_, err := exec.LookPath(p.config.Path)
+	return err == nil
+}
+
+// Health checks that the command runs
+func (p *ImageMagickConverter) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeMagick,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+		return health
+	}
+
+	start := time.Now()
+	_, err := p.run(ctx, nil, "-version")
+	health.Latency = time.Since(start)
+	if err != nil {
+		health.Status = ProviderStatusError
+		health.LastError = err
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *ImageMagickConverter) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider; a process is started per conversion
+func (p *ImageMagickConverter) Close() error {
+	return nil
+}
+
+// ConvertToJPEG converts the first frame of an image to JPEG, applying its
+// orientation and dropping its metadata
+func (p *ImageMagickConverter) ConvertToJPEG(ctx context.Context, data []byte) ([]byte, error) {
+	if !p.IsAvailable(ctx) {
+		return nil, ErrProviderUnavailable
+	}
+
+	jpeg, err := p.run(ctx, data, "-[0]", "-auto-orient", "-strip", "-quality", "90", "jpeg:-")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConversionFailed, err)
+	}
+	return jpeg, nil
+}
+
+// run runs the command with stdin as input and returns its output
+func (p *ImageMagickConverter) run(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.config.Path, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
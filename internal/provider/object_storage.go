@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+// Object storage errors
+var (
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// ObjectStorage interface for storing large opaque objects, such as tenant
+// backups, outside the database
+type ObjectStorage interface {
+	Provider
+
+	// Put stores an object, replacing any object stored under the key
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns an object, or ErrObjectNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
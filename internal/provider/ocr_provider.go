@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OCR errors
+var (
+	ErrOCRUnsupportedFormat = errors.New("unsupported image format")
+	ErrOCRRecognitionFailed = errors.New("receipt could not be recognized")
+)
+
+// ReceiptImage represents an uploaded receipt image for OCR
+type ReceiptImage struct {
+	Name        string
+	ContentType string // image/jpeg, image/png, application/pdf
+	Data        []byte
+}
+
+// Format returns the image format code used by OCR APIs
+func (i *ReceiptImage) Format() (string, error) {
+	switch i.ContentType {
+	case "image/jpeg", "image/jpg":
+		return "jpg", nil
+	case "image/png":
+		return "png", nil
+	case "application/pdf":
+		return "pdf", nil
+	case "image/tiff":
+		return "tiff", nil
+	}
+	return "", ErrOCRUnsupportedFormat
+}
+
+// ReceiptData represents the fields extracted from a receipt.
+// Fields the provider could not read are left zero.
+type ReceiptData struct {
+	TransactionDate      *time.Time
+	VendorName           string
+	VendorBusinessNumber string
+	TotalAmount          int64
+	SupplyAmount         int64
+	TaxAmount            int64
+	CardNumber           string
+	ApprovalNumber       string
+	Confidence           float64 // 0..1, average over the extracted fields
+	RawResponse          []byte
+}
+
+// ReceiptOCRProvider interface for receipt recognition
+type ReceiptOCRProvider interface {
+	Provider
+
+	// RecognizeReceipt extracts receipt fields from an image
+	RecognizeReceipt(ctx context.Context, companyID uuid.UUID, image *ReceiptImage) (*ReceiptData, error)
+}
@@ -26,6 +26,15 @@ type ProviderType string
 const (
 	ProviderTypePopbill  ProviderType = "popbill"
 	ProviderTypeHometax  ProviderType = "hometax"
+	ProviderTypeClova    ProviderType = "clova"
+	ProviderTypeSMTP     ProviderType = "smtp"
+	ProviderTypeFCM      ProviderType = "fcm"
+	ProviderTypeAPNs     ProviderType = "apns"
+	ProviderTypeClamAV   ProviderType = "clamav"
+	ProviderTypeMagick   ProviderType = "imagemagick"
+	ProviderTypeStripe   ProviderType = "stripe"
+	ProviderTypeS3       ProviderType = "s3"
+	ProviderTypeFile     ProviderType = "file"
 	ProviderTypeMock     ProviderType = "mock"
 )
 
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+// Push errors
+var (
+	// ErrPushTokenInvalid means the device token is no longer registered with
+	// the push service, e.g. the app was uninstalled; the device should be removed
+	ErrPushTokenInvalid = errors.New("push token is no longer valid")
+	ErrPushSendFailed   = errors.New("push notification could not be sent")
+)
+
+// PushMessage represents a push notification to one device
+type PushMessage struct {
+	Token string // device registration token of the push service
+	Title string
+	Body  string
+	// Data is passed to the app with the notification, e.g. the item to open
+	Data map[string]string
+}
+
+// PushProvider interface for push notification delivery (FCM, APNs). The
+// provider type is the platform of the device tokens it accepts.
+type PushProvider interface {
+	Provider
+
+	// Send delivers the message to its device
+	Send(ctx context.Context, msg *PushMessage) error
+}
+
+// signJWT encodes the claims as a compact JWT signed by sign, which receives
+// the signing input and returns the raw signature
+func signJWT(alg, keyID string, claims map[string]interface{}, sign func(input []byte) ([]byte, error)) (string, error) {
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if keyID != "" {
+		header["kid"] = keyID
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	input := enc.EncodeToString(headerJSON) + "." + enc.EncodeToString(claimsJSON)
+	signature, err := sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + enc.EncodeToString(signature), nil
+}
+
+// parsePKCS8Key parses a PEM-encoded PKCS #8 private key, as issued by Google
+// service accounts and Apple for APNs
+func parsePKCS8Key(data string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(data)))
+	if block == nil {
+		return nil, errors.New("no PEM private key found")
+	}
+	return x509.ParsePKCS8PrivateKey(block.Bytes)
+}
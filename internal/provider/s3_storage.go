@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config holds the settings of an S3 or S3-compatible bucket
+type S3Config struct {
+	Endpoint string // overrides https://s3.<region>.amazonaws.com, e.g. a MinIO address
+	Region   string
+	Bucket   string
+
+	// Static credentials of the IAM user or role session
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	Timeout time.Duration
+}
+
+// S3Storage implements ObjectStorage with the S3 REST API, using path-style
+// requests signed with AWS Signature Version 4
+type S3Storage struct {
+	config   *S3Config
+	client   *http.Client
+	now      func() time.Time
+	priority int
+}
+
+// NewS3Storage creates a new S3 storage
+func NewS3Storage(config *S3Config) *S3Storage {
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 5 * time.Minute
+	}
+	return &S3Storage{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		now:      time.Now,
+		priority: 1,
+	}
+}
+
+// Type returns the provider type
+func (s *S3Storage) Type() ProviderType {
+	return ProviderTypeS3
+}
+
+// Name returns the provider name
+func (s *S3Storage) Name() string {
+	return "S3 (" + s.config.Bucket + ")"
+}
+
+// IsAvailable checks if the bucket and credentials are configured
+func (s *S3Storage) IsAvailable(ctx context.Context) bool {
+	return s.config.Bucket != "" && s.config.AccessKeyID != "" && s.config.SecretAccessKey != ""
+}
+
+// Health checks that the bucket is reachable with the credentials
+func (s *S3Storage) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeS3,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !s.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+		return health
+	}
+
+	start := time.Now()
+	resp, err := s.do(ctx, http.MethodHead, "", nil)
+	health.Latency = time.Since(start)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("s3 bucket check failed with status %d", resp.StatusCode)
+		}
+	}
+	if err != nil {
+		health.Status = ProviderStatusError
+		health.LastError = err
+	}
+	return health
+}
+
+// Priority returns the priority
+func (s *S3Storage) Priority() int {
+	return s.priority
+}
+
+// Close closes the provider
+func (s *S3Storage) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// Put uploads an object with a single PUT request
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.failure("put", resp)
+	}
+	return nil
+}
+
+// Get downloads an object
+func (s *S3Storage) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrObjectNotFound
+	}
+	return nil, s.failure("get", resp)
+}
+
+// Delete removes an object; S3 answers 204 whether or not it existed
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.failure("delete", resp)
+	}
+	return nil
+}
+
+// do sends a signed request for an object of the bucket, or for the bucket
+// itself when key is empty
+func (s *S3Storage) do(ctx context.Context, method, key string, payload []byte) (*http.Response, error) {
+	path := "/" + url.PathEscape(s.config.Bucket)
+	for _, segment := range strings.Split(key, "/") {
+		if segment != "" {
+			path += "/" + url.PathEscape(segment)
+		}
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(s.config.Endpoint, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	s.sign(req, payload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s failed: %w", strings.ToLower(method), err)
+	}
+	return resp, nil
+}
+
+// failure returns the error of an unexpected response
+func (s *S3Storage) failure(op string, resp *http.Response) error {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	return fmt.Errorf("s3 %s failed with status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(raw)))
+}
+
+// sign adds the AWS Signature Version 4 authorization of the request. S3
+// also requires the payload hash in the x-amz-content-sha256 header.
+func (s *S3Storage) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := s3HashHex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+	}
+
+	// Canonical headers: host and every header set above, lowercased and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, s3HashHex([]byte(canonicalRequest))}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.config.SecretAccessKey), date)
+	for _, part := range []string{s.config.Region, "s3", "aws4_request"} {
+		key = s3HMAC(key, part)
+	}
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func s3HashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
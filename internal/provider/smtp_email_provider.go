@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig holds SMTP relay configuration
+type SMTPConfig struct {
+	Host     string
+	Port     int // 465 uses implicit TLS; other ports upgrade with STARTTLS when offered
+	Username string
+	Password string
+	From     string
+	FromName string
+	Timeout  time.Duration
+}
+
+// SMTPEmailProvider implements EmailProvider over an SMTP relay
+type SMTPEmailProvider struct {
+	config   *SMTPConfig
+	priority int
+}
+
+// NewSMTPEmailProvider creates a new SMTP email provider
+func NewSMTPEmailProvider(config *SMTPConfig) *SMTPEmailProvider {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &SMTPEmailProvider{
+		config:   config,
+		priority: 1,
+	}
+}
+
+// Type returns the provider type
+func (p *SMTPEmailProvider) Type() ProviderType {
+	return ProviderTypeSMTP
+}
+
+// Name returns the provider name
+func (p *SMTPEmailProvider) Name() string {
+	return "SMTP"
+}
+
+// IsAvailable checks if the provider is configured
+func (p *SMTPEmailProvider) IsAvailable(ctx context.Context) bool {
+	return p.config.Host != "" && p.config.From != ""
+}
+
+// Health returns the health status
+func (p *SMTPEmailProvider) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeSMTP,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *SMTPEmailProvider) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider
+func (p *SMTPEmailProvider) Close() error {
+	return nil
+}
+
+// Send delivers the message through the SMTP relay
+func (p *SMTPEmailProvider) Send(ctx context.Context, msg *EmailMessage) error {
+	recipients := msg.Recipients()
+	if len(recipients) == 0 {
+		return ErrEmailNoRecipients
+	}
+
+	body, err := p.buildMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(p.config.Host, strconv.Itoa(p.config.Port))
+	dialer := &net.Dialer{Timeout: p.config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(p.config.Timeout))
+
+	tlsConfig := &tls.Config{ServerName: p.config.Host}
+	if p.config.Port == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, p.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+	}
+	defer client.Close()
+
+	if p.config.Port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+			}
+		}
+	}
+
+	if p.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		}
+	}
+
+	if err := client.Mail(p.config.From); err != nil {
+		return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("%w: recipient %s: %v", ErrEmailSendFailed, rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("%w: %v", ErrEmailSendFailed, err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage renders the message as MIME (multipart/mixed when there are attachments)
+func (p *SMTPEmailProvider) buildMessage(msg *EmailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+
+	from := (&mail.Address{Name: p.config.FromName, Address: p.config.From}).String()
+	writeHeader(&buf, "From", from)
+	writeHeader(&buf, "To", strings.Join(msg.To, ", "))
+	if len(msg.Cc) > 0 {
+		writeHeader(&buf, "Cc", strings.Join(msg.Cc, ", "))
+	}
+	writeHeader(&buf, "Subject", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	id := msg.MessageID
+	if id == "" {
+		id = NewMessageID(p.config.From)
+	}
+	writeHeader(&buf, "Message-ID", id)
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	mw := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()}))
+	buf.WriteString("\r\n")
+
+	if err := writeBody(mw, msg); err != nil {
+		return nil, err
+	}
+
+	for _, a := range msg.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"name": a.Filename}))
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+		h.Set("Content-Transfer-Encoding", "base64")
+		part, err := mw.CreatePart(h)
+		if err != nil {
+			return nil, err
+		}
+		writeBase64(part, a.Data)
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBody writes the text body, or a multipart/alternative of text and HTML
+func writeBody(mw *multipart.Writer, msg *EmailMessage) error {
+	if msg.HTMLBody == "" {
+		return writeTextPart(mw, "text/plain", msg.TextBody)
+	}
+
+	var alt bytes.Buffer
+	aw := multipart.NewWriter(&alt)
+	if err := writeTextPart(aw, "text/plain", msg.TextBody); err != nil {
+		return err
+	}
+	if err := writeTextPart(aw, "text/html", msg.HTMLBody); err != nil {
+		return err
+	}
+	if err := aw.Close(); err != nil {
+		return err
+	}
+
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": aw.Boundary()}))
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(alt.Bytes())
+	return err
+}
+
+// writeTextPart writes a UTF-8 text part in base64 transfer encoding
+func writeTextPart(mw *multipart.Writer, mediaType, text string) error {
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", mediaType+"; charset=UTF-8")
+	h.Set("Content-Transfer-Encoding", "base64")
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	writeBase64(part, []byte(text))
+	return nil
+}
+
+// writeBase64 writes data base64-encoded in 76-character lines
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
+
+func writeHeader(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key + ": " + value + "\r\n")
+}
+
+// NewMessageID generates a unique Message-ID in the sender's domain
+func NewMessageID(from string) string {
+	domain := "localhost"
+	if i := strings.LastIndexByte(from, '@'); i >= 0 {
+		domain = from[i+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
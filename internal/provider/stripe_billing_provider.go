@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StripeConfig holds Stripe usage-based billing configuration
+type StripeConfig struct {
+	SecretKey   string
+	BaseURL     string // https://api.stripe.com by default
+	EventPrefix string // meter event names are the prefix and the metric, e.g. kerp_vouchers
+	Timeout     time.Duration
+}
+
+// StripeBillingProvider implements BillingProvider with Stripe billing meters.
+// Every usage report is sent as a meter event timestamped at the end of its
+// month; the meters of counted metrics sum their events and the meter of
+// active users takes the last event.
+type StripeBillingProvider struct {
+	config   *StripeConfig
+	client   *http.Client
+	priority int
+}
+
+// NewStripeBillingProvider creates a new Stripe billing provider
+func NewStripeBillingProvider(config *StripeConfig) *StripeBillingProvider {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.stripe.com"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &StripeBillingProvider{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		priority: 1,
+	}
+}
+
+// Type returns the provider type
+func (p *StripeBillingProvider) Type() ProviderType {
+	return ProviderTypeStripe
+}
+
+// Name returns the provider name
+func (p *StripeBillingProvider) Name() string {
+	return "Stripe"
+}
+
+// IsAvailable checks if the provider is configured
+func (p *StripeBillingProvider) IsAvailable(ctx context.Context) bool {
+	return p.config.SecretKey != ""
+}
+
+// Health returns the health status
+func (p *StripeBillingProvider) Health(ctx context.Context) *ProviderHealth {
+	health := &ProviderHealth{
+		Type:        ProviderTypeStripe,
+		Status:      ProviderStatusActive,
+		LastChecked: time.Now(),
+	}
+	if !p.IsAvailable(ctx) {
+		health.Status = ProviderStatusInactive
+	}
+	return health
+}
+
+// Priority returns the priority
+func (p *StripeBillingProvider) Priority() int {
+	return p.priority
+}
+
+// Close closes the provider
+func (p *StripeBillingProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+// ReportUsage creates a meter event for the usage. Stripe deduplicates meter
+// events by identifier, which is the report's idempotency key.
+func (p *StripeBillingProvider) ReportUsage(ctx context.Context, report *UsageReport) (string, error) {
+	form := url.Values{}
+	form.Set("event_name", p.config.EventPrefix+report.Metric)
+	form.Set("identifier", report.IdempotencyKey)
+	form.Set("timestamp", strconv.FormatInt(report.PeriodEnd.Add(-time.Second).Unix(), 10))
+	form.Set("payload[stripe_customer_id]", report.CustomerID)
+	form.Set("payload[value]", strconv.FormatInt(report.Quantity, 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimRight(p.config.BaseURL, "/")+"/v1/billing/meter_events", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.SecretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", report.IdempotencyKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+
+	var body struct {
+		Identifier string `json:"identifier"`
+		Error      struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(raw, &body)
+
+	switch {
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return "", fmt.Errorf("%w: status %d", ErrProviderUnavailable, resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return "", fmt.Errorf("%w: %s", ErrBillingRejected, body.Error.Message)
+	}
+	return body.Identifier, nil
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+// Virus scan errors
+var (
+	ErrScanFailed = errors.New("virus scan failed")
+)
+
+// ScanResult is the verdict of a virus scan
+type ScanResult struct {
+	Infected  bool
+	Signature string // name of the detected malware, when infected
+}
+
+// VirusScanner interface for scanning uploaded files for malware
+type VirusScanner interface {
+	Provider
+
+	// Scan checks the file content. An error means the file could not be scanned
+	// and must not be treated as clean.
+	Scan(ctx context.Context, name string, data []byte) (*ScanResult, error)
+}
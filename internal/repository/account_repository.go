@@ -13,6 +13,8 @@ type AccountFilter struct {
 	ParentID     *uuid.UUID
 	AccountType  *domain.AccountType
 	IsActive     *bool
+	IsCash       *bool
+	IDs          []uuid.UUID // only these accounts
 	SearchTerm   string
 	IncludeTree  bool
 	Page         int
@@ -49,4 +51,7 @@ type AccountRepository interface {
 	// Batch operations
 	CreateBatch(ctx context.Context, accounts []domain.Account) error
 	UpdateSortOrder(ctx context.Context, companyID uuid.UUID, orders map[uuid.UUID]int) error
+
+	// Posting rules
+	UpdatePostingRules(ctx context.Context, companyID, id uuid.UUID, rules *domain.PostingRules) error
 }
@@ -31,8 +31,8 @@ func (r *accountRepositoryGorm) Update(ctx context.Context, account *domain.Acco
 	return r.db.WithContext(ctx).
 		Model(account).
 		Select("code", "name", "name_en", "parent_id", "level", "path",
-			"account_type", "account_nature", "account_category",
-			"is_active", "is_control_account", "allow_direct_posting", "sort_order").
+			"account_type", "account_nature", "account_category", "statement_line",
+			"is_active", "is_control_account", "allow_direct_posting", "is_cash_account", "sort_order").
 		Updates(account).Error
 }
 
@@ -91,6 +91,12 @@ func (r *accountRepositoryGorm) FindAll(ctx context.Context, filter AccountFilte
 	if filter.IsActive != nil {
 		query = query.Where("is_active = ?", *filter.IsActive)
 	}
+	if filter.IsCash != nil {
+		query = query.Where("is_cash_account = ?", *filter.IsCash)
+	}
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
 	if filter.SearchTerm != "" {
 		searchTerm := "%" + strings.ToLower(filter.SearchTerm) + "%"
 		query = query.Where("LOWER(code) LIKE ? OR LOWER(name) LIKE ? OR LOWER(name_en) LIKE ?",
@@ -311,3 +317,12 @@ func (r *accountRepositoryGorm) UpdateSortOrder(ctx context.Context, companyID u
 		return nil
 	})
 }
+
+// UpdatePostingRules replaces the posting rules of an account (nil clears them)
+func (r *accountRepositoryGorm) UpdatePostingRules(ctx context.Context, companyID, id uuid.UUID, rules *domain.PostingRules) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Account{}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Select("posting_rules").
+		Updates(&domain.Account{PostingRules: rules}).Error
+}
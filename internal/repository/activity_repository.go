@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ActivityFilter defines filter options for activity feeds
+type ActivityFilter struct {
+	CompanyID uuid.UUID
+	VoucherID *uuid.UUID            // only the activity of the voucher
+	Kinds     []domain.ActivityKind // empty for all kinds
+	UserID    *uuid.UUID
+	DateFrom  *time.Time
+	DateTo    *time.Time // inclusive day
+	Page      int
+	PageSize  int // zero returns every item
+}
+
+// ActivityRepository defines the interface for the audit log and the activity
+// feeds assembled from it, the voucher status history and the attachments
+type ActivityRepository interface {
+	CreateAuditLog(ctx context.Context, log *domain.AuditLog) error
+
+	// FindAll returns the feed items of the filter newest first with their total count
+	FindAll(ctx context.Context, filter ActivityFilter) ([]domain.Activity, int64, error)
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// activityRepositoryGorm implements ActivityRepository using GORM
+type activityRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository creates a new GORM-based activity repository
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepositoryGorm{db: db}
+}
+
+func (r *activityRepositoryGorm) CreateAuditLog(ctx context.Context, log *domain.AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// activityScan is the flat scan target of the feed query
+type activityScan struct {
+	Kind        string
+	EntityType  string
+	EntityID    uuid.UUID
+	EntityLabel *string
+	UserID      *uuid.UUID
+	UserName    *string
+	FromStatus  *string
+	ToStatus    *string
+	Message     *string
+	OldValues   json.RawMessage
+	NewValues   json.RawMessage
+	OccurredAt  time.Time
+}
+
+func (r *activityRepositoryGorm) FindAll(ctx context.Context, filter ActivityFilter) ([]domain.Activity, int64, error) {
+	feed, args := compileActivityFeed(filter)
+	if feed == "" {
+		return []domain.Activity{}, 0, nil
+	}
+
+	var total int64
+	if err := r.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM ("+feed+") feed", args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT feed.*, u.name AS user_name
+		FROM (` + feed + `) feed
+		LEFT JOIN users u ON u.id = feed.user_id
+		ORDER BY feed.occurred_at DESC`
+	if filter.PageSize > 0 {
+		page := filter.Page
+		if page < 1 {
+			page = 1
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.PageSize, (page-1)*filter.PageSize)
+	}
+
+	var scanned []activityScan
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&scanned).Error; err != nil {
+		return nil, 0, err
+	}
+
+	activities := make([]domain.Activity, len(scanned))
+	for i, s := range scanned {
+		a := domain.Activity{
+			Kind:        domain.ActivityKind(s.Kind),
+			EntityType:  s.EntityType,
+			EntityID:    s.EntityID,
+			EntityLabel: derefString(s.EntityLabel),
+			UserID:      s.UserID,
+			UserName:    derefString(s.UserName),
+			FromStatus:  domain.VoucherStatus(derefString(s.FromStatus)),
+			ToStatus:    domain.VoucherStatus(derefString(s.ToStatus)),
+			Message:     derefString(s.Message),
+			OccurredAt:  s.OccurredAt,
+		}
+		if a.Kind == domain.ActivityEdit {
+			a.Changes = domain.ActivityChanges(s.OldValues, s.NewValues)
+		}
+		activities[i] = a
+	}
+	return activities, total, nil
+}
+
+// compileActivityFeed builds the union of the feed sources selected by the
+// filter, without ordering and pagination
+func compileActivityFeed(filter ActivityFilter) (string, []interface{}) {
+	wants := func(kind domain.ActivityKind) bool {
+		if len(filter.Kinds) == 0 {
+			return true
+		}
+		for _, k := range filter.Kinds {
+			if k == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	// conditions appends the filter conditions on the source's columns
+	var args []interface{}
+	conditions := func(sb *strings.Builder, companyCol, voucherCol, userCol, timeCol string) {
+		sb.WriteString("\n\t\tWHERE " + companyCol + " = ?")
+		args = append(args, filter.CompanyID)
+		if filter.VoucherID != nil {
+			sb.WriteString(" AND " + voucherCol + " = ?")
+			args = append(args, *filter.VoucherID)
+		}
+		if filter.UserID != nil {
+			sb.WriteString(" AND " + userCol + " = ?")
+			args = append(args, *filter.UserID)
+		}
+		if filter.DateFrom != nil {
+			sb.WriteString(" AND " + timeCol + " >= ?")
+			args = append(args, *filter.DateFrom)
+		}
+		if filter.DateTo != nil {
+			sb.WriteString(" AND " + timeCol + " < ?")
+			args = append(args, filter.DateTo.AddDate(0, 0, 1))
+		}
+	}
+
+	var sources []string
+	if wants(domain.ActivityStatusChange) {
+		var sb strings.Builder
+		sb.WriteString(`
+		SELECT 'status_change' AS kind, 'voucher'::text AS entity_type, h.voucher_id AS entity_id, v.voucher_no AS entity_label,
+			h.changed_by AS user_id, h.from_status::varchar AS from_status, h.to_status::varchar AS to_status,
+			h.reason::text AS message, NULL::jsonb AS old_values, NULL::jsonb AS new_values, h.changed_at AS occurred_at
+		FROM voucher_status_history h
+		JOIN vouchers v ON v.id = h.voucher_id`)
+		conditions(&sb, "h.company_id", "h.voucher_id", "h.changed_by", "h.changed_at")
+		sources = append(sources, sb.String())
+	}
+	if wants(domain.ActivityAttachment) {
+		var sb strings.Builder
+		sb.WriteString(`
+		SELECT 'attachment' AS kind, 'voucher'::text AS entity_type, a.voucher_id AS entity_id, v.voucher_no AS entity_label,
+			a.uploaded_by AS user_id, NULL::varchar AS from_status, NULL::varchar AS to_status,
+			a.file_name::text AS message, NULL::jsonb AS old_values, NULL::jsonb AS new_values, a.uploaded_at AS occurred_at
+		FROM voucher_attachments a
+		JOIN vouchers v ON v.id = a.voucher_id`)
+		conditions(&sb, "a.company_id", "a.voucher_id", "a.uploaded_by", "a.uploaded_at")
+		sources = append(sources, sb.String())
+	}
+
+	var actions []string
+	if wants(domain.ActivityComment) {
+		actions = append(actions, domain.AuditActionComment)
+	}
+	if wants(domain.ActivityEdit) {
+		actions = append(actions, domain.AuditActionUpdate)
+	}
+	if len(actions) > 0 {
+		var sb strings.Builder
+		sb.WriteString(`
+		SELECT CASE WHEN l.action = 'comment' THEN 'comment' ELSE 'edit' END AS kind, l.entity_type::text AS entity_type,
+			l.entity_id, v.voucher_no AS entity_label, l.user_id, NULL::varchar AS from_status, NULL::varchar AS to_status,
+			l.new_values->>'comment' AS message, l.old_values, l.new_values, l.created_at AS occurred_at
+		FROM audit_logs l
+		LEFT JOIN vouchers v ON l.entity_type = 'voucher' AND v.id = l.entity_id`)
+		conditions(&sb, "l.company_id", "l.entity_id", "l.user_id", "l.created_at")
+		sb.WriteString(" AND l.entity_id IS NOT NULL AND l.action IN ?")
+		args = append(args, actions)
+		if filter.VoucherID != nil {
+			sb.WriteString(" AND l.entity_type = 'voucher'")
+		}
+		sources = append(sources, sb.String())
+	}
+
+	return strings.Join(sources, "\n\t\tUNION ALL"), args
+}
+
+// derefString returns the string or empty for NULL columns
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// AllocationRunFilter defines filter options for allocation runs
+type AllocationRunFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.AllocationRunStatus
+	Page      int
+	PageSize  int
+}
+
+// AllocationRunRepository defines the interface for allocation run persistence
+type AllocationRunRepository interface {
+	Create(ctx context.Context, run *domain.AllocationRun) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.AllocationRun, error)
+	List(ctx context.Context, filter AllocationRunFilter) ([]domain.AllocationRun, int64, error)
+
+	// ExistsGenerated returns true if the company has a run of the period ending
+	// at periodEnd that is not reversed
+	ExistsGenerated(ctx context.Context, companyID uuid.UUID, periodEnd time.Time) (bool, error)
+
+	// MarkReversed stores the reversal of the run
+	MarkReversed(ctx context.Context, run *domain.AllocationRun) error
+}
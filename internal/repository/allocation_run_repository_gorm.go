@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// allocationRunRepositoryGorm implements AllocationRunRepository using GORM
+type allocationRunRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewAllocationRunRepository creates a new GORM-based allocation run repository
+func NewAllocationRunRepository(db *gorm.DB) AllocationRunRepository {
+	return &allocationRunRepositoryGorm{db: db}
+}
+
+func (r *allocationRunRepositoryGorm) Create(ctx context.Context, run *domain.AllocationRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *allocationRunRepositoryGorm) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.AllocationRun, error) {
+	var run domain.AllocationRun
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&run).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAllocationRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *allocationRunRepositoryGorm) List(ctx context.Context, filter AllocationRunFilter) ([]domain.AllocationRun, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.AllocationRun{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []domain.AllocationRun
+	err := query.
+		Order("period_end DESC, created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&runs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+func (r *allocationRunRepositoryGorm) ExistsGenerated(ctx context.Context, companyID uuid.UUID, periodEnd time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.AllocationRun{}).
+		Where("company_id = ? AND period_end = ? AND status = ?", companyID, periodEnd, domain.AllocationRunStatusGenerated).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *allocationRunRepositoryGorm) MarkReversed(ctx context.Context, run *domain.AllocationRun) error {
+	return r.db.WithContext(ctx).Model(run).
+		Select("status", "reversal_voucher_id", "reversed_by", "reversed_at").
+		Updates(run).Error
+}
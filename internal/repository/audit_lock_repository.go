@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// AuditLockRepository defines the interface for fiscal year audit lock
+// persistence. Every change is written together with its audit log entry.
+type AuditLockRepository interface {
+	// Create stores a lock and the audit entry of its locking
+	Create(ctx context.Context, lock *domain.AuditLock) error
+	// Unlock stores the lifting of an active lock and its audit entry, built by
+	// the caller with the client of the request; it returns
+	// domain.ErrAuditLockAlreadyUnlocked if the lock was lifted meanwhile
+	Unlock(ctx context.Context, lock *domain.AuditLock, entry *domain.AuditLog) error
+
+	// FindActive returns the lock of a fiscal year that has not been lifted
+	FindActive(ctx context.Context, companyID uuid.UUID, year int) (*domain.AuditLock, error)
+	// FindAll lists the locks of the company, lifted ones included, latest year first
+	FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.AuditLock, error)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// auditLockRepositoryGorm implements AuditLockRepository using GORM
+type auditLockRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewAuditLockRepository creates a new GORM-based audit lock repository
+func NewAuditLockRepository(db *gorm.DB) AuditLockRepository {
+	return &auditLockRepositoryGorm{db: db}
+}
+
+func (r *auditLockRepositoryGorm) Create(ctx context.Context, lock *domain.AuditLock) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(lock).Error; err != nil {
+			return err
+		}
+		log, err := lock.AuditLog(domain.AuditActionAuditLock, &lock.LockedBy, nil)
+		if err != nil {
+			return err
+		}
+		return tx.Create(log).Error
+	})
+}
+
+func (r *auditLockRepositoryGorm) Unlock(ctx context.Context, lock *domain.AuditLock, entry *domain.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(lock).
+			Where("unlocked_at IS NULL").
+			Select("unlocked_by", "unlocked_at", "unlock_reason").
+			Updates(lock)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrAuditLockAlreadyUnlocked
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *auditLockRepositoryGorm) FindActive(ctx context.Context, companyID uuid.UUID, year int) (*domain.AuditLock, error) {
+	var lock domain.AuditLock
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND fiscal_year = ? AND unlocked_at IS NULL", companyID, year).
+		First(&lock).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAuditLockNotFound
+		}
+		return nil, err
+	}
+	return &lock, nil
+}
+
+func (r *auditLockRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.AuditLock, error) {
+	var locks []domain.AuditLock
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("fiscal_year DESC, locked_at DESC").
+		Find(&locks).Error
+	return locks, err
+}
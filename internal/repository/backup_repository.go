@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// BackupRepository defines the interface for tenant backup and restore access.
+// Backups are managed by super admins, so lookups are not scoped to a company.
+type BackupRepository interface {
+	// CRUD operations
+	Create(ctx context.Context, backup *domain.TenantBackup) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Query operations (wrapped data keys are not loaded)
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.TenantBackup, error)
+	FindByCompany(ctx context.Context, companyID uuid.UUID, limit int) ([]domain.TenantBackup, error)
+	HasActive(ctx context.Context, companyID uuid.UUID) (bool, error)
+	// HasMonthlySince reports whether a completed monthly backup of the company exists since the time
+	HasMonthlySince(ctx context.Context, companyID uuid.UUID, since time.Time) (bool, error)
+
+	// Worker operations (across all companies)
+	// CreateScheduled queues a backup of every active company for a run of the
+	// schedule, skipping companies already queued for it, and returns the number queued
+	CreateScheduled(ctx context.Context, scheduledFor time.Time) (int64, error)
+	// ClaimNext marks the oldest pending backup, or one left processing since before
+	// staleBefore by a crashed worker, as processing. It returns nil when there is none.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.TenantBackup, error)
+	Finish(ctx context.Context, backup *domain.TenantBackup) error
+	FindExpired(ctx context.Context, asOf time.Time, limit int) ([]domain.TenantBackup, error)
+
+	// Restores
+	CreateRestore(ctx context.Context, restore *domain.BackupRestore) error
+	FindRestore(ctx context.Context, id uuid.UUID) (*domain.BackupRestore, error)
+	// FindWithKey loads a backup with its wrapped data key, for restores
+	FindWithKey(ctx context.Context, id uuid.UUID) (*domain.TenantBackup, error)
+	ClaimNextRestore(ctx context.Context, staleBefore time.Time) (*domain.BackupRestore, error)
+	FinishRestore(ctx context.Context, restore *domain.BackupRestore) error
+}
+
+// StagingDataset is a dataset of a backup restored into the staging database
+type StagingDataset struct {
+	Name    string
+	Columns []string
+	Rows    [][]*string // nil is NULL
+}
+
+// BackupStagingRepository loads restored backups into the staging database
+type BackupStagingRepository interface {
+	// Load creates the schema and one table per dataset, with TEXT columns,
+	// and inserts the rows in a single transaction. comment describes the
+	// origin of the data on the schema.
+	Load(ctx context.Context, schema, comment string, datasets []StagingDataset) error
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// backupListColumns excludes the wrapped data key from listings
+var backupListColumns = []string{
+	"id", "company_id", "trigger", "status", "scheduled_for", "storage_key", "size", "checksum",
+	"row_counts", "monthly", "error", "requested_by", "started_at", "completed_at", "expires_at",
+	"created_at", "updated_at",
+}
+
+// backupActiveStatuses are the statuses of backups and restores not yet finished
+var backupActiveStatuses = []domain.BackupStatus{domain.BackupStatusPending, domain.BackupStatusProcessing}
+
+// backupRepositoryGorm implements BackupRepository using GORM
+type backupRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewBackupRepository creates a new GORM-based backup repository
+func NewBackupRepository(db *gorm.DB) BackupRepository {
+	return &backupRepositoryGorm{db: db}
+}
+
+func (r *backupRepositoryGorm) Create(ctx context.Context, backup *domain.TenantBackup) error {
+	return r.db.WithContext(ctx).Create(backup).Error
+}
+
+func (r *backupRepositoryGorm) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.TenantBackup{}, "id = ?", id).Error
+}
+
+func (r *backupRepositoryGorm) FindByID(ctx context.Context, id uuid.UUID) (*domain.TenantBackup, error) {
+	return r.find(ctx, id, backupListColumns)
+}
+
+func (r *backupRepositoryGorm) FindWithKey(ctx context.Context, id uuid.UUID) (*domain.TenantBackup, error) {
+	return r.find(ctx, id, nil)
+}
+
+func (r *backupRepositoryGorm) find(ctx context.Context, id uuid.UUID, columns []string) (*domain.TenantBackup, error) {
+	var backup domain.TenantBackup
+	query := r.db.WithContext(ctx)
+	if columns != nil {
+		query = query.Select(columns)
+	}
+	err := query.Where("id = ?", id).First(&backup).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBackupNotFound
+		}
+		return nil, err
+	}
+	return &backup, nil
+}
+
+func (r *backupRepositoryGorm) FindByCompany(ctx context.Context, companyID uuid.UUID, limit int) ([]domain.TenantBackup, error) {
+	var backups []domain.TenantBackup
+	err := r.db.WithContext(ctx).
+		Select(backupListColumns).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&backups).Error
+	return backups, err
+}
+
+func (r *backupRepositoryGorm) HasActive(ctx context.Context, companyID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.TenantBackup{}).
+		Where("company_id = ? AND status IN ?", companyID, backupActiveStatuses).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *backupRepositoryGorm) HasMonthlySince(ctx context.Context, companyID uuid.UUID, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.TenantBackup{}).
+		Where("company_id = ? AND status = ? AND monthly AND completed_at >= ?",
+			companyID, domain.BackupStatusCompleted, since).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *backupRepositoryGorm) CreateScheduled(ctx context.Context, scheduledFor time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO tenant_backups (company_id, trigger, status, scheduled_for)
+		SELECT id, ?, ?, ?
+		FROM companies
+		WHERE status IN ?
+		ON CONFLICT (company_id, scheduled_for) WHERE scheduled_for IS NOT NULL DO NOTHING`,
+		domain.BackupTriggerScheduled, domain.BackupStatusPending, scheduledFor,
+		[]domain.CompanyStatus{domain.CompanyStatusActive, domain.CompanyStatusTrial})
+	return result.RowsAffected, result.Error
+}
+
+func (r *backupRepositoryGorm) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.TenantBackup, error) {
+	var backup domain.TenantBackup
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers claim different backups
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select(backupListColumns).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				domain.BackupStatusPending, domain.BackupStatusProcessing, staleBefore).
+			Order("created_at ASC").
+			First(&backup).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		backup.Status = domain.BackupStatusProcessing
+		backup.StartedAt = &now
+		return tx.Model(&backup).Select("status", "started_at").Updates(&backup).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+func (r *backupRepositoryGorm) Finish(ctx context.Context, backup *domain.TenantBackup) error {
+	return r.db.WithContext(ctx).
+		Model(backup).
+		Select("status", "storage_key", "size", "checksum", "key_id", "encrypted_key", "row_counts",
+			"monthly", "error", "completed_at", "expires_at").
+		Updates(backup).Error
+}
+
+func (r *backupRepositoryGorm) FindExpired(ctx context.Context, asOf time.Time, limit int) ([]domain.TenantBackup, error) {
+	var backups []domain.TenantBackup
+	err := r.db.WithContext(ctx).
+		Select(backupListColumns).
+		Where("expires_at < ?", asOf).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&backups).Error
+	return backups, err
+}
+
+func (r *backupRepositoryGorm) CreateRestore(ctx context.Context, restore *domain.BackupRestore) error {
+	return r.db.WithContext(ctx).Create(restore).Error
+}
+
+func (r *backupRepositoryGorm) FindRestore(ctx context.Context, id uuid.UUID) (*domain.BackupRestore, error) {
+	var restore domain.BackupRestore
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&restore).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBackupRestoreNotFound
+		}
+		return nil, err
+	}
+	return &restore, nil
+}
+
+func (r *backupRepositoryGorm) ClaimNextRestore(ctx context.Context, staleBefore time.Time) (*domain.BackupRestore, error) {
+	var restore domain.BackupRestore
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				domain.BackupStatusPending, domain.BackupStatusProcessing, staleBefore).
+			Order("created_at ASC").
+			First(&restore).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		restore.Status = domain.BackupStatusProcessing
+		restore.StartedAt = &now
+		return tx.Model(&restore).Select("status", "started_at").Updates(&restore).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &restore, nil
+}
+
+func (r *backupRepositoryGorm) FinishRestore(ctx context.Context, restore *domain.BackupRestore) error {
+	return r.db.WithContext(ctx).
+		Model(restore).
+		Select("status", "target_schema", "row_counts", "error", "completed_at").
+		Updates(restore).Error
+}
+
+// stagingInsertParams bounds the bind parameters of one INSERT, below the
+// 65535 of the PostgreSQL protocol
+const stagingInsertParams = 30000
+
+// backupStagingRepositoryGorm implements BackupStagingRepository using GORM
+// on the staging database
+type backupStagingRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewBackupStagingRepository creates a new GORM-based staging repository;
+// db is the staging database, not the production one
+func NewBackupStagingRepository(db *gorm.DB) BackupStagingRepository {
+	return &backupStagingRepositoryGorm{db: db}
+}
+
+func (r *backupStagingRepositoryGorm) Load(ctx context.Context, schema, comment string, datasets []StagingDataset) error {
+	quotedSchema := pgx.Identifier{schema}.Sanitize()
+
+	// A restore of a large company can outlast any statement timeout
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE SCHEMA " + quotedSchema).Error; err != nil {
+			return err
+		}
+		// Utility statements take no bind parameters; the literal is quoted here
+		literal := "'" + strings.ReplaceAll(comment, "'", "''") + "'"
+		if err := tx.Exec("COMMENT ON SCHEMA " + quotedSchema + " IS " + literal).Error; err != nil {
+			return err
+		}
+
+		for _, dataset := range datasets {
+			if len(dataset.Columns) == 0 {
+				continue
+			}
+			table := pgx.Identifier{schema, dataset.Name}.Sanitize()
+			columns := make([]string, len(dataset.Columns))
+			definitions := make([]string, len(dataset.Columns))
+			for i, c := range dataset.Columns {
+				columns[i] = pgx.Identifier{c}.Sanitize()
+				definitions[i] = columns[i] + " TEXT"
+			}
+			if err := tx.Exec("CREATE TABLE " + table + " (" + strings.Join(definitions, ", ") + ")").Error; err != nil {
+				return err
+			}
+
+			placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+			insert := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES "
+			batch := max(1, stagingInsertParams/len(columns))
+			for start := 0; start < len(dataset.Rows); start += batch {
+				rows := dataset.Rows[start:min(start+batch, len(dataset.Rows))]
+				values := make([]string, len(rows))
+				args := make([]interface{}, 0, len(rows)*len(columns))
+				for i, row := range rows {
+					values[i] = placeholders
+					for _, v := range row {
+						args = append(args, v)
+					}
+				}
+				if err := tx.Exec(insert+strings.Join(values, ", "), args...).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
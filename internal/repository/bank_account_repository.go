@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// BankAccountRepository defines the interface for company bank account persistence
+type BankAccountRepository interface {
+	Create(ctx context.Context, bank *domain.CompanyBankAccount) error
+	Update(ctx context.Context, bank *domain.CompanyBankAccount) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error)
+	FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CompanyBankAccount, error)
+	// FindByAccounts returns the bank accounts, active or not, booking to the cash accounts
+	FindByAccounts(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID) ([]domain.CompanyBankAccount, error)
+	ExistsByNumber(ctx context.Context, companyID uuid.UUID, bankCode, accountNumber string, excludeID *uuid.UUID) (bool, error)
+	// HasEntries tells whether voucher entries name the bank account
+	HasEntries(ctx context.Context, companyID, id uuid.UUID) (bool, error)
+
+	// RecordBalance stores the reported balance and, when current, makes it
+	// the balance of the bank account
+	RecordBalance(ctx context.Context, bank *domain.CompanyBankAccount, balance *domain.BankAccountBalance, current bool) error
+	// ListBalances returns the latest balances reported for the bank account, latest first
+	ListBalances(ctx context.Context, companyID, bankAccountID uuid.UUID, limit int) ([]domain.BankAccountBalance, error)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// bankAccountRepositoryGorm implements BankAccountRepository using GORM
+type bankAccountRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewBankAccountRepository creates a new GORM-based bank account repository
+func NewBankAccountRepository(db *gorm.DB) BankAccountRepository {
+	return &bankAccountRepositoryGorm{db: db}
+}
+
+func (r *bankAccountRepositoryGorm) Create(ctx context.Context, bank *domain.CompanyBankAccount) error {
+	return r.db.WithContext(ctx).Create(bank).Error
+}
+
+func (r *bankAccountRepositoryGorm) Update(ctx context.Context, bank *domain.CompanyBankAccount) error {
+	// The balance is only moved by RecordBalance
+	return r.db.WithContext(ctx).
+		Omit("balance", "balance_as_of", "balance_source", "created_by").
+		Save(bank).Error
+}
+
+func (r *bankAccountRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.CompanyBankAccount{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrBankAccountNotFound
+	}
+	return nil
+}
+
+func (r *bankAccountRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error) {
+	var bank domain.CompanyBankAccount
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&bank).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBankAccountNotFound
+		}
+		return nil, err
+	}
+	return &bank, nil
+}
+
+func (r *bankAccountRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CompanyBankAccount, error) {
+	query := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var banks []domain.CompanyBankAccount
+	err := query.Order("bank_code ASC, name ASC").Find(&banks).Error
+	return banks, err
+}
+
+func (r *bankAccountRepositoryGorm) FindByAccounts(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID) ([]domain.CompanyBankAccount, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+	var banks []domain.CompanyBankAccount
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND account_id IN ?", companyID, accountIDs).
+		Find(&banks).Error
+	return banks, err
+}
+
+func (r *bankAccountRepositoryGorm) ExistsByNumber(ctx context.Context, companyID uuid.UUID, bankCode, accountNumber string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&domain.CompanyBankAccount{}).
+		Where("company_id = ? AND bank_code = ? AND account_number = ?", companyID, bankCode, accountNumber)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *bankAccountRepositoryGorm) HasEntries(ctx context.Context, companyID, id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.VoucherEntry{}).
+		Where("company_id = ? AND bank_account_id = ?", companyID, id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *bankAccountRepositoryGorm) RecordBalance(ctx context.Context, bank *domain.CompanyBankAccount, balance *domain.BankAccountBalance, current bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(balance).Error; err != nil {
+			return err
+		}
+		if !current {
+			return nil
+		}
+		return tx.Model(bank).
+			Select("balance", "balance_as_of", "balance_source").
+			Updates(bank).Error
+	})
+}
+
+func (r *bankAccountRepositoryGorm) ListBalances(ctx context.Context, companyID, bankAccountID uuid.UUID, limit int) ([]domain.BankAccountBalance, error) {
+	var balances []domain.BankAccountBalance
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND bank_account_id = ?", companyID, bankAccountID).
+		Order("as_of DESC, created_at DESC").
+		Limit(limit).
+		Find(&balances).Error
+	return balances, err
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CloseChecklistRepository defines the interface for period close checklist data access
+type CloseChecklistRepository interface {
+	// Task configuration
+	CreateTask(ctx context.Context, task *domain.CloseChecklistTask) error
+	UpdateTask(ctx context.Context, task *domain.CloseChecklistTask) error
+	DeleteTask(ctx context.Context, companyID, id uuid.UUID) error
+	FindTaskByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseChecklistTask, error)
+	FindTasks(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CloseChecklistTask, error)
+	ExistsByCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error)
+
+	// Period status
+	FindPeriodTask(ctx context.Context, companyID, taskID uuid.UUID, year, month int) (*domain.PeriodCloseTask, error)
+	FindPeriodTasks(ctx context.Context, companyID uuid.UUID, year, month int) ([]domain.PeriodCloseTask, error)
+	SavePeriodTask(ctx context.Context, periodTask *domain.PeriodCloseTask) error
+}
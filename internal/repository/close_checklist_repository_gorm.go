@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// closeChecklistRepositoryGorm implements CloseChecklistRepository using GORM
+type closeChecklistRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewCloseChecklistRepository creates a new GORM-based close checklist repository
+func NewCloseChecklistRepository(db *gorm.DB) CloseChecklistRepository {
+	return &closeChecklistRepositoryGorm{db: db}
+}
+
+func (r *closeChecklistRepositoryGorm) CreateTask(ctx context.Context, task *domain.CloseChecklistTask) error {
+	return r.db.WithContext(ctx).Create(task).Error
+}
+
+func (r *closeChecklistRepositoryGorm) UpdateTask(ctx context.Context, task *domain.CloseChecklistTask) error {
+	return r.db.WithContext(ctx).Save(task).Error
+}
+
+func (r *closeChecklistRepositoryGorm) DeleteTask(ctx context.Context, companyID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.CloseChecklistTask{}).Error
+}
+
+func (r *closeChecklistRepositoryGorm) FindTaskByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseChecklistTask, error) {
+	var task domain.CloseChecklistTask
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&task).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCloseTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *closeChecklistRepositoryGorm) FindTasks(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CloseChecklistTask, error) {
+	var tasks []domain.CloseChecklistTask
+	query := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("sort_order ASC, code ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *closeChecklistRepositoryGorm) ExistsByCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&domain.CloseChecklistTask{}).
+		Where("company_id = ? AND code = ?", companyID, code)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *closeChecklistRepositoryGorm) FindPeriodTask(ctx context.Context, companyID, taskID uuid.UUID, year, month int) (*domain.PeriodCloseTask, error) {
+	var periodTask domain.PeriodCloseTask
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND task_id = ? AND fiscal_year = ? AND fiscal_month = ?", companyID, taskID, year, month).
+		First(&periodTask).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCloseTaskNotFound
+		}
+		return nil, err
+	}
+	return &periodTask, nil
+}
+
+func (r *closeChecklistRepositoryGorm) FindPeriodTasks(ctx context.Context, companyID uuid.UUID, year, month int) ([]domain.PeriodCloseTask, error) {
+	var periodTasks []domain.PeriodCloseTask
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND fiscal_year = ? AND fiscal_month = ?", companyID, year, month).
+		Find(&periodTasks).Error
+	return periodTasks, err
+}
+
+func (r *closeChecklistRepositoryGorm) SavePeriodTask(ctx context.Context, periodTask *domain.PeriodCloseTask) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "company_id"}, {Name: "task_id"}, {Name: "fiscal_year"}, {Name: "fiscal_month"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "note", "completed_at", "completed_by", "updated_at"}),
+		}).
+		Create(periodTask).Error
+}
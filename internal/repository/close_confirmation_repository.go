@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// CloseConfirmationRepository defines the interface for close confirmation
+// persistence. Every change is written together with its audit log entry.
+type CloseConfirmationRepository interface {
+	// Create stores a pending confirmation and the audit entry of its initiation
+	Create(ctx context.Context, confirmation *domain.CloseConfirmation) error
+	// Update stores the status of the confirmation and the audit entry of the action changing it
+	Update(ctx context.Context, confirmation *domain.CloseConfirmation, action string, userID *uuid.UUID) error
+
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseConfirmation, error)
+	// FindPending returns the pending confirmation of a close; month is 0 for year-end closes
+	FindPending(ctx context.Context, companyID uuid.UUID, kind domain.CloseKind, year, month int) (*domain.CloseConfirmation, error)
+	// FindAll lists the confirmations of the company, latest first
+	FindAll(ctx context.Context, companyID uuid.UUID, limit int) ([]domain.CloseConfirmation, error)
+}
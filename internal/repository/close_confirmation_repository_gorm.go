@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// closeConfirmationRepositoryGorm implements CloseConfirmationRepository using GORM
+type closeConfirmationRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewCloseConfirmationRepository creates a new GORM-based close confirmation repository
+func NewCloseConfirmationRepository(db *gorm.DB) CloseConfirmationRepository {
+	return &closeConfirmationRepositoryGorm{db: db}
+}
+
+func (r *closeConfirmationRepositoryGorm) Create(ctx context.Context, confirmation *domain.CloseConfirmation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(confirmation).Error; err != nil {
+			return err
+		}
+		return createCloseAuditLog(tx, confirmation, domain.AuditActionCloseInitiate, &confirmation.InitiatedBy)
+	})
+}
+
+func (r *closeConfirmationRepositoryGorm) Update(ctx context.Context, confirmation *domain.CloseConfirmation, action string, userID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(confirmation).
+			Select("status", "confirmed_by", "confirmed_at", "cancelled_by", "cancelled_at").
+			Updates(confirmation).Error
+		if err != nil {
+			return err
+		}
+		return createCloseAuditLog(tx, confirmation, action, userID)
+	})
+}
+
+func createCloseAuditLog(tx *gorm.DB, confirmation *domain.CloseConfirmation, action string, userID *uuid.UUID) error {
+	log, err := confirmation.AuditLog(action, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Create(log).Error
+}
+
+func (r *closeConfirmationRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseConfirmation, error) {
+	var confirmation domain.CloseConfirmation
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&confirmation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCloseConfirmationNotFound
+		}
+		return nil, err
+	}
+	return &confirmation, nil
+}
+
+func (r *closeConfirmationRepositoryGorm) FindPending(ctx context.Context, companyID uuid.UUID, kind domain.CloseKind, year, month int) (*domain.CloseConfirmation, error) {
+	var confirmation domain.CloseConfirmation
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND kind = ? AND year = ? AND month = ? AND status = ?",
+			companyID, kind, year, month, domain.CloseConfirmationPending).
+		First(&confirmation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCloseConfirmationNotFound
+		}
+		return nil, err
+	}
+	return &confirmation, nil
+}
+
+func (r *closeConfirmationRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID, limit int) ([]domain.CloseConfirmation, error) {
+	var confirmations []domain.CloseConfirmation
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&confirmations).Error
+	return confirmations, err
+}
@@ -12,6 +12,7 @@ type CompanyRepository interface {
 	// CRUD operations
 	Create(ctx context.Context, company *domain.Company) error
 	Update(ctx context.Context, company *domain.Company) error
+	UpdateSettings(ctx context.Context, company *domain.Company) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Query operations
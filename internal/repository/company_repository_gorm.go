@@ -27,6 +27,14 @@ func (r *companyRepositoryGorm) Update(ctx context.Context, company *domain.Comp
 	return r.db.WithContext(ctx).Save(company).Error
 }
 
+// UpdateSettings saves only the settings column
+func (r *companyRepositoryGorm) UpdateSettings(ctx context.Context, company *domain.Company) error {
+	return r.db.WithContext(ctx).
+		Model(company).
+		Select("settings", "updated_at").
+		Updates(company).Error
+}
+
 func (r *companyRepositoryGorm) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).
 		Where("id = ?", id).
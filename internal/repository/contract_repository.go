@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ContractFilter defines filter options for contracts
+type ContractFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.ContractStatus
+	PartnerID *uuid.UUID
+	Page      int
+	PageSize  int
+}
+
+// ContractRepository defines the interface for contract persistence
+type ContractRepository interface {
+	// Create stores the contract with its milestones
+	Create(ctx context.Context, contract *domain.Contract) error
+	UpdateStatus(ctx context.Context, contract *domain.Contract) error
+	// FindByID returns the contract with its milestones in due date order
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Contract, error)
+	ExistsContractNo(ctx context.Context, companyID uuid.UUID, contractNo string) (bool, error)
+	List(ctx context.Context, filter ContractFilter) ([]domain.Contract, int64, error)
+	// ListWithMilestones returns all contracts of the filter with their
+	// milestones, for the billing report; paging is ignored
+	ListWithMilestones(ctx context.Context, filter ContractFilter) ([]domain.Contract, error)
+
+	// UpdateMilestone stores the invoicing and collections of a milestone.
+	// It fails with domain.ErrContractMilestoneChanged when the milestone was
+	// invoiced or collected meanwhile.
+	UpdateMilestone(ctx context.Context, milestone *domain.ContractMilestone, previous domain.ContractMilestoneStatus, previousCollected int64) error
+	CreateCollection(ctx context.Context, collection *domain.ContractCollection) error
+	ListCollections(ctx context.Context, companyID, contractID uuid.UUID) ([]domain.ContractCollection, error)
+
+	// ListForReminder returns the milestones of all companies not invoiced
+	// yet that are due on or before the date and were not notified yet
+	ListForReminder(ctx context.Context, dueBy time.Time) ([]domain.ContractMilestone, error)
+	MarkReminded(ctx context.Context, ids []uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// contractRepositoryGorm implements ContractRepository using GORM
+type contractRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewContractRepository creates a new GORM-based contract repository
+func NewContractRepository(db *gorm.DB) ContractRepository {
+	return &contractRepositoryGorm{db: db}
+}
+
+func (r *contractRepositoryGorm) Create(ctx context.Context, contract *domain.Contract) error {
+	return r.db.WithContext(ctx).Create(contract).Error
+}
+
+func (r *contractRepositoryGorm) UpdateStatus(ctx context.Context, contract *domain.Contract) error {
+	return r.db.WithContext(ctx).Model(contract).
+		Select("status", "updated_by").
+		Updates(contract).Error
+}
+
+func (r *contractRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Contract, error) {
+	var contract domain.Contract
+	err := r.withMilestones(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&contract).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrContractNotFound
+		}
+		return nil, err
+	}
+	return &contract, nil
+}
+
+func (r *contractRepositoryGorm) ExistsContractNo(ctx context.Context, companyID uuid.UUID, contractNo string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.Contract{}).
+		Where("company_id = ? AND contract_no = ?", companyID, contractNo).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *contractRepositoryGorm) List(ctx context.Context, filter ContractFilter) ([]domain.Contract, int64, error) {
+	query := r.filter(r.db.WithContext(ctx).Model(&domain.Contract{}), filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var contracts []domain.Contract
+	err := query.
+		Order("start_date DESC, contract_no ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&contracts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return contracts, total, nil
+}
+
+func (r *contractRepositoryGorm) ListWithMilestones(ctx context.Context, filter ContractFilter) ([]domain.Contract, error) {
+	var contracts []domain.Contract
+	err := r.filter(r.withMilestones(ctx), filter).
+		Order("end_date ASC, contract_no ASC").
+		Find(&contracts).Error
+	return contracts, err
+}
+
+// withMilestones preloads the milestones of the contracts in due date order
+func (r *contractRepositoryGorm) withMilestones(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seq ASC")
+	})
+}
+
+func (r *contractRepositoryGorm) filter(query *gorm.DB, filter ContractFilter) *gorm.DB {
+	query = query.Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.PartnerID != nil {
+		query = query.Where("partner_id = ?", *filter.PartnerID)
+	}
+	return query
+}
+
+func (r *contractRepositoryGorm) UpdateMilestone(ctx context.Context, milestone *domain.ContractMilestone, previous domain.ContractMilestoneStatus, previousCollected int64) error {
+	result := r.db.WithContext(ctx).Model(milestone).
+		Where("status = ? AND collected_amount = ?", previous, previousCollected).
+		Select("status", "invoice_date", "tax_amount", "tax_invoice_id", "voucher_id", "collected_amount").
+		Updates(milestone)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrContractMilestoneChanged
+	}
+	return nil
+}
+
+func (r *contractRepositoryGorm) CreateCollection(ctx context.Context, collection *domain.ContractCollection) error {
+	return r.db.WithContext(ctx).Create(collection).Error
+}
+
+func (r *contractRepositoryGorm) ListCollections(ctx context.Context, companyID, contractID uuid.UUID) ([]domain.ContractCollection, error) {
+	var collections []domain.ContractCollection
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND contract_id = ?", companyID, contractID).
+		Order("collected_date ASC, created_at ASC").
+		Find(&collections).Error
+	return collections, err
+}
+
+func (r *contractRepositoryGorm) ListForReminder(ctx context.Context, dueBy time.Time) ([]domain.ContractMilestone, error) {
+	var milestones []domain.ContractMilestone
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND reminded_at IS NULL AND due_date <= ?", domain.ContractMilestoneScheduled, dueBy).
+		Order("company_id, due_date ASC, seq ASC").
+		Find(&milestones).Error
+	return milestones, err
+}
+
+func (r *contractRepositoryGorm) MarkReminded(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&domain.ContractMilestone{}).
+		Where("id IN ?", ids).
+		Update("reminded_at", at).Error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DataExportWriter receives the rows of an exported dataset
+type DataExportWriter interface {
+	// Columns is called once with the dataset's column names, before any row
+	Columns(columns []string) error
+	// Row is called for each row; NULL values have Valid set to false
+	Row(values []sql.NullString) error
+}
+
+// DataExportRepository defines the interface for tenant data export access
+type DataExportRepository interface {
+	// CRUD operations
+	Create(ctx context.Context, export *domain.DataExport) error
+
+	// Query operations (archive contents are not loaded)
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DataExport, error)
+	FindAll(ctx context.Context, companyID uuid.UUID, limit int) ([]domain.DataExport, error)
+	HasActive(ctx context.Context, companyID uuid.UUID) (bool, error)
+
+	// FindArchive loads an export with its archive, for signed downloads
+	FindArchive(ctx context.Context, id uuid.UUID) (*domain.DataExport, error)
+
+	// Worker operations (across all companies)
+	// ClaimNext marks the oldest pending export, or one left processing since before
+	// staleBefore by a crashed worker, as processing. It returns nil when there is none.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.DataExport, error)
+	Finish(ctx context.Context, export *domain.DataExport) error
+	DeleteExpired(ctx context.Context, asOf time.Time) (int64, error)
+
+	// ExportRows streams every row of the company's dataset in a stable order
+	ExportRows(ctx context.Context, companyID uuid.UUID, dataset domain.DataExportDataset, w DataExportWriter) error
+}
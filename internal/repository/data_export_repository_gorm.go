@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// dataExportQueries selects all columns of each dataset table for one company
+var dataExportQueries = map[domain.DataExportDataset]string{
+	domain.DataExportAccounts:        "SELECT * FROM accounts WHERE company_id = ? ORDER BY code",
+	domain.DataExportPartners:        "SELECT * FROM partners WHERE company_id = ? ORDER BY code",
+	domain.DataExportVouchers:        "SELECT * FROM vouchers WHERE company_id = ? ORDER BY voucher_date, voucher_no",
+	domain.DataExportVoucherEntries:  "SELECT * FROM voucher_entries WHERE company_id = ? ORDER BY voucher_id, line_no",
+	domain.DataExportLedgerBalances:  "SELECT * FROM ledger_balances WHERE company_id = ? ORDER BY fiscal_year, fiscal_month, account_id",
+	domain.DataExportTaxInvoices:     "SELECT * FROM tax_invoices WHERE company_id = ? ORDER BY issue_date, invoice_number",
+	domain.DataExportTaxInvoiceItems: "SELECT * FROM tax_invoice_items WHERE company_id = ? ORDER BY tax_invoice_id, sequence_number",
+}
+
+// dataExportListColumns excludes the archive from listings
+var dataExportListColumns = []string{
+	"id", "company_id", "status", "format", "mask_pii", "file_name", "file_size", "row_counts", "error",
+	"requested_by", "started_at", "completed_at", "expires_at", "created_at", "updated_at",
+}
+
+// dataExportRepositoryGorm implements DataExportRepository using GORM
+type dataExportRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository creates a new GORM-based data export repository
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepositoryGorm{db: db}
+}
+
+func (r *dataExportRepositoryGorm) Create(ctx context.Context, export *domain.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+func (r *dataExportRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).
+		Select(dataExportListColumns).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&export).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID, limit int) ([]domain.DataExport, error) {
+	var exports []domain.DataExport
+	err := r.db.WithContext(ctx).
+		Select(dataExportListColumns).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *dataExportRepositoryGorm) HasActive(ctx context.Context, companyID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.DataExport{}).
+		Where("company_id = ? AND status IN ?", companyID,
+			[]domain.DataExportStatus{domain.DataExportStatusPending, domain.DataExportStatusProcessing}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *dataExportRepositoryGorm) FindArchive(ctx context.Context, id uuid.UUID) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&export).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepositoryGorm) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers claim different exports
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select(dataExportListColumns).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				domain.DataExportStatusPending, domain.DataExportStatusProcessing, staleBefore).
+			Order("created_at ASC").
+			First(&export).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		export.Status = domain.DataExportStatusProcessing
+		export.StartedAt = &now
+		return tx.Model(&export).Select("status", "started_at").Updates(&export).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepositoryGorm) Finish(ctx context.Context, export *domain.DataExport) error {
+	return r.db.WithContext(ctx).
+		Model(export).
+		Select("status", "file_name", "file_size", "file_data", "row_counts", "error", "completed_at", "expires_at").
+		Updates(export).Error
+}
+
+func (r *dataExportRepositoryGorm) DeleteExpired(ctx context.Context, asOf time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", asOf).
+		Delete(&domain.DataExport{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *dataExportRepositoryGorm) ExportRows(ctx context.Context, companyID uuid.UUID, dataset domain.DataExportDataset, w DataExportWriter) error {
+	query, ok := dataExportQueries[dataset]
+	if !ok {
+		return fmt.Errorf("unknown data export dataset: %s", dataset)
+	}
+
+	// Streaming a large table can outlast the statement timeout of API queries
+	return r.db.WithContext(ctx).Clauses(dbresolver.Read).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+			return err
+		}
+
+		rows, err := tx.Raw(query, companyID).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		if err := w.Columns(columns); err != nil {
+			return err
+		}
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		for rows.Next() {
+			if err := rows.Scan(dest...); err != nil {
+				return err
+			}
+			if err := w.Row(values); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+}
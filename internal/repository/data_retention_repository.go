@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DataRetentionRepository defines the interface for personal data deletion
+// requests and the enforcement of retention periods
+type DataRetentionRepository interface {
+	// Deletion requests
+	CreateDeletionRequest(ctx context.Context, req *domain.DataDeletionRequest) error
+	FindDeletionRequest(ctx context.Context, companyID, id uuid.UUID) (*domain.DataDeletionRequest, error)
+	// FindDeletionRequests lists the company's requests with the status, or all
+	// of them when status is nil, earliest due first
+	FindDeletionRequests(ctx context.Context, companyID uuid.UUID, status *domain.DeletionRequestStatus, limit int) ([]domain.DataDeletionRequest, error)
+	HasPendingDeletion(ctx context.Context, companyID uuid.UUID, subjectType domain.DeletionSubjectType, subjectID uuid.UUID) (bool, error)
+	// FinishDeletionRequest saves the outcome of a rejected request
+	FinishDeletionRequest(ctx context.Context, req *domain.DataDeletionRequest) error
+
+	// AnonymizeUser saves the anonymized user, deletes their tokens, clears the
+	// client details of their audit entries and completes the request in one
+	// transaction. It returns domain.ErrUserLastAdmin for the company's only
+	// active admin, holding the active admins locked while it runs.
+	AnonymizeUser(ctx context.Context, req *domain.DataDeletionRequest, user *domain.User) error
+	// AnonymizePartner saves the cleared partner contact and completes the request in one transaction
+	AnonymizePartner(ctx context.Context, req *domain.DataDeletionRequest, partner *domain.Partner) error
+
+	// Worker operations (across all companies)
+	// DeleteStaleTokens deletes refresh and one-time tokens that expired, were
+	// revoked or were used before the time
+	DeleteStaleTokens(ctx context.Context, before time.Time) (int64, error)
+	// ClearAuditClientDetails clears the IP address and user agent of audit
+	// entries created before the time
+	ClearAuditClientDetails(ctx context.Context, before time.Time) (int64, error)
+}
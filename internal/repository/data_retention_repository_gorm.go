@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// dataRetentionRepositoryGorm implements DataRetentionRepository using GORM
+type dataRetentionRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewDataRetentionRepository creates a new GORM-based data retention repository
+func NewDataRetentionRepository(db *gorm.DB) DataRetentionRepository {
+	return &dataRetentionRepositoryGorm{db: db}
+}
+
+func (r *dataRetentionRepositoryGorm) CreateDeletionRequest(ctx context.Context, req *domain.DataDeletionRequest) error {
+	return r.db.WithContext(ctx).Create(req).Error
+}
+
+func (r *dataRetentionRepositoryGorm) FindDeletionRequest(ctx context.Context, companyID, id uuid.UUID) (*domain.DataDeletionRequest, error) {
+	var req domain.DataDeletionRequest
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&req).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDeletionRequestNotFound
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *dataRetentionRepositoryGorm) FindDeletionRequests(ctx context.Context, companyID uuid.UUID, status *domain.DeletionRequestStatus, limit int) ([]domain.DataDeletionRequest, error) {
+	var reqs []domain.DataDeletionRequest
+	query := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	err := query.Order("due_at ASC").Limit(limit).Find(&reqs).Error
+	return reqs, err
+}
+
+func (r *dataRetentionRepositoryGorm) HasPendingDeletion(ctx context.Context, companyID uuid.UUID, subjectType domain.DeletionSubjectType, subjectID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.DataDeletionRequest{}).
+		Where("company_id = ? AND subject_type = ? AND subject_id = ? AND status = ?",
+			companyID, subjectType, subjectID, domain.DeletionRequestPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *dataRetentionRepositoryGorm) FinishDeletionRequest(ctx context.Context, req *domain.DataDeletionRequest) error {
+	return finishDeletionRequest(r.db.WithContext(ctx), req)
+}
+
+func finishDeletionRequest(db *gorm.DB, req *domain.DataDeletionRequest) error {
+	return db.Model(req).
+		Select("status", "processed_by", "processed_at", "rejection_reason").
+		Updates(req).Error
+}
+
+func (r *dataRetentionRepositoryGorm) AnonymizeUser(ctx context.Context, req *domain.DataDeletionRequest, user *domain.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Anonymizing deactivates the user; the lock keeps concurrent demotions
+		// from leaving the company without an admin
+		var admins []uuid.UUID
+		err := tx.Model(&domain.User{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND role = ? AND status = ?", user.CompanyID, domain.UserRoleAdmin, domain.UserStatusActive).
+			Order("id").
+			Pluck("id", &admins).Error
+		if err != nil {
+			return err
+		}
+		if len(admins) == 1 && admins[0] == user.ID {
+			return domain.ErrUserLastAdmin
+		}
+
+		// Invitations and verifications sent to the former address carry it as well
+		var emails []string
+		if err := tx.Model(&domain.User{}).Where("id = ?", user.ID).Pluck("email", &emails).Error; err != nil {
+			return err
+		}
+		tokens := tx.Where("user_id = ?", user.ID)
+		if len(emails) > 0 {
+			tokens = tokens.Or("company_id = ? AND email = ?", user.CompanyID, emails[0])
+		}
+		if err := tokens.Delete(&domain.UserToken{}).Error; err != nil {
+			return err
+		}
+
+		err = tx.Model(user).
+			Select("email", "name", "password_hash", "signing_pin_hash", "status", "last_login_at",
+				"email_verified_at", "must_change_password").
+			Updates(user).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&domain.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		err = tx.Model(&domain.AuditLog{}).
+			Where("user_id = ?", user.ID).
+			Updates(map[string]interface{}{"ip_address": nil, "user_agent": nil}).Error
+		if err != nil {
+			return err
+		}
+		return finishDeletionRequest(tx, req)
+	})
+}
+
+func (r *dataRetentionRepositoryGorm) AnonymizePartner(ctx context.Context, req *domain.DataDeletionRequest, partner *domain.Partner) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(partner).
+			Select("representative", "phone", "fax", "email", "representative_verified").
+			Updates(partner).Error
+		if err != nil {
+			return err
+		}
+		return finishDeletionRequest(tx, req)
+	})
+}
+
+func (r *dataRetentionRepositoryGorm) DeleteStaleTokens(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("expires_at < ? OR (revoked AND updated_at < ?)", before, before).
+			Delete(&domain.RefreshToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+
+		result = tx.Where("expires_at < ? OR used_at < ?", before, before).Delete(&domain.UserToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted += result.RowsAffected
+		return nil
+	})
+	return deleted, err
+}
+
+func (r *dataRetentionRepositoryGorm) ClearAuditClientDetails(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.AuditLog{}).
+		Where("created_at < ? AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)", before).
+		Updates(map[string]interface{}{"ip_address": nil, "user_agent": nil})
+	return result.RowsAffected, result.Error
+}
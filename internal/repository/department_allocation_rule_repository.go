@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DepartmentAllocationRuleRepository defines the interface for department allocation rule data access
+type DepartmentAllocationRuleRepository interface {
+	// CRUD operations
+	Create(ctx context.Context, rule *domain.DepartmentAllocationRule) error
+	Update(ctx context.Context, rule *domain.DepartmentAllocationRule) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Query operations
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DepartmentAllocationRule, error)
+	FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.DepartmentAllocationRule, error)
+}
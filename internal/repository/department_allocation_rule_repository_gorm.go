@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// departmentAllocationRuleRepositoryGorm implements DepartmentAllocationRuleRepository using GORM
+type departmentAllocationRuleRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewDepartmentAllocationRuleRepository creates a new GORM-based allocation rule repository
+func NewDepartmentAllocationRuleRepository(db *gorm.DB) DepartmentAllocationRuleRepository {
+	return &departmentAllocationRuleRepositoryGorm{db: db}
+}
+
+func (r *departmentAllocationRuleRepositoryGorm) Create(ctx context.Context, rule *domain.DepartmentAllocationRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *departmentAllocationRuleRepositoryGorm) Update(ctx context.Context, rule *domain.DepartmentAllocationRule) error {
+	return r.db.WithContext(ctx).
+		Model(rule).
+		Select("name", "source_department_id", "account_from", "account_to", "targets", "is_active", "updated_at").
+		Updates(rule).Error
+}
+
+func (r *departmentAllocationRuleRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.DepartmentAllocationRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAllocationRuleNotFound
+	}
+	return nil
+}
+
+func (r *departmentAllocationRuleRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DepartmentAllocationRule, error) {
+	var rule domain.DepartmentAllocationRule
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&rule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAllocationRuleNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// FindAll returns the rules in application order (creation order)
+func (r *departmentAllocationRuleRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.DepartmentAllocationRule, error) {
+	var rules []domain.DepartmentAllocationRule
+	query := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DocumentLinkRepository defines the interface for links between the company's documents
+type DocumentLinkRepository interface {
+	// Create saves a link; ErrDocumentLinkExists if the documents are already linked
+	Create(ctx context.Context, link *domain.DocumentLink) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DocumentLink, error)
+
+	// DocumentExists checks that the referenced document belongs to the company
+	DocumentExists(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) (bool, error)
+
+	// FindRelated returns the documents linked to the document or related to
+	// it by a voucher's reference, newest first. Links to deleted documents
+	// are omitted.
+	FindRelated(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) ([]domain.RelatedDocument, error)
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// documentSource is the table of a document type and the SQL expression of
+// its label over the table's alias
+type documentSource struct {
+	table string
+	label string
+}
+
+// documentSources maps each linkable document type to its table
+var documentSources = map[domain.DocumentType]documentSource{
+	domain.DocumentVoucher:       {table: "vouchers", label: "%s.voucher_no"},
+	domain.DocumentTaxInvoice:    {table: "tax_invoices", label: "%s.invoice_number"},
+	domain.DocumentLoan:          {table: "loans", label: "%s.loan_no"},
+	domain.DocumentGrant:         {table: "grants", label: "%s.grant_no"},
+	domain.DocumentAllocationRun: {table: "allocation_runs", label: "to_char(%[1]s.period_start, 'YYYY-MM-DD') || ' ~ ' || to_char(%[1]s.period_end, 'YYYY-MM-DD')"},
+}
+
+// documentLinkRepositoryGorm implements DocumentLinkRepository using GORM
+type documentLinkRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewDocumentLinkRepository creates a new GORM-based document link repository
+func NewDocumentLinkRepository(db *gorm.DB) DocumentLinkRepository {
+	return &documentLinkRepositoryGorm{db: db}
+}
+
+func (r *documentLinkRepositoryGorm) Create(ctx context.Context, link *domain.DocumentLink) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(link)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDocumentLinkExists
+	}
+	return nil
+}
+
+func (r *documentLinkRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.DocumentLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDocumentLinkNotFound
+	}
+	return nil
+}
+
+func (r *documentLinkRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DocumentLink, error) {
+	var link domain.DocumentLink
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDocumentLinkNotFound
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *documentLinkRepositoryGorm) DocumentExists(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) (bool, error) {
+	source, ok := documentSources[ref.Type]
+	if !ok {
+		return false, domain.ErrInvalidDocumentType
+	}
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table(source.table).
+		Where("company_id = ? AND id = ?", companyID, ref.ID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// relatedScan is the flat scan target of the related documents query
+type relatedScan struct {
+	DocumentType string
+	DocumentID   uuid.UUID
+	Label        string
+	LinkID       *uuid.UUID
+	Note         *string
+	CreatedBy    *uuid.UUID
+	CreatedAt    time.Time
+}
+
+func (r *documentLinkRepositoryGorm) FindRelated(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) ([]domain.RelatedDocument, error) {
+	query, args := compileRelatedDocuments(companyID, ref)
+
+	var scanned []relatedScan
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&scanned).Error; err != nil {
+		return nil, err
+	}
+
+	related := make([]domain.RelatedDocument, len(scanned))
+	for i, s := range scanned {
+		related[i] = domain.RelatedDocument{
+			Document:  domain.DocumentRef{Type: domain.DocumentType(s.DocumentType), ID: s.DocumentID},
+			Label:     s.Label,
+			LinkID:    s.LinkID,
+			Note:      derefString(s.Note),
+			CreatedBy: s.CreatedBy,
+			CreatedAt: s.CreatedAt,
+		}
+	}
+	return related, nil
+}
+
+// compileRelatedDocuments builds the query of the documents related to ref:
+// the other side of its links, the vouchers referencing it and, for a
+// voucher, the document it references. Each related document is joined to
+// its table for the label, which drops documents deleted since.
+func compileRelatedDocuments(companyID uuid.UUID, ref domain.DocumentRef) (string, []interface{}) {
+	var sb strings.Builder
+	var args []interface{}
+
+	sb.WriteString(`
+		WITH related AS (
+			SELECT CASE WHEN l.source_type = ? AND l.source_id = ? THEN l.target_type ELSE l.source_type END AS document_type,
+				CASE WHEN l.source_type = ? AND l.source_id = ? THEN l.target_id ELSE l.source_id END AS document_id,
+				l.id AS link_id, l.note, l.created_by, l.created_at
+			FROM document_links l
+			WHERE l.company_id = ? AND ((l.source_type = ? AND l.source_id = ?) OR (l.target_type = ? AND l.target_id = ?))
+			UNION ALL
+			SELECT 'voucher', v.id, NULL::uuid, NULL::varchar, v.created_by, v.created_at
+			FROM vouchers v
+			WHERE v.company_id = ? AND v.reference_type = ? AND v.reference_id = ?`)
+	args = append(args, ref.Type, ref.ID, ref.Type, ref.ID, companyID, ref.Type, ref.ID, ref.Type, ref.ID,
+		companyID, ref.Type, ref.ID)
+
+	if ref.Type == domain.DocumentVoucher {
+		sb.WriteString(`
+			UNION ALL
+			SELECT v.reference_type, v.reference_id, NULL::uuid, NULL::varchar, v.created_by, v.created_at
+			FROM vouchers v
+			WHERE v.company_id = ? AND v.id = ? AND v.reference_type IN ? AND v.reference_id IS NOT NULL`)
+		args = append(args, companyID, ref.ID, domain.DocumentTypes)
+	}
+	sb.WriteString(`
+		)
+		SELECT r.*, COALESCE(`)
+
+	labels := make([]string, len(domain.DocumentTypes))
+	joins := make([]string, len(domain.DocumentTypes))
+	for i, docType := range domain.DocumentTypes {
+		source := documentSources[docType]
+		alias := fmt.Sprintf("d%d", i)
+		labels[i] = fmt.Sprintf(source.label, alias)
+		joins[i] = fmt.Sprintf("\n\t\tLEFT JOIN %s %s ON r.document_type = '%s' AND %s.id = r.document_id AND %s.company_id = ?",
+			source.table, alias, docType, alias, alias)
+		args = append(args, companyID)
+	}
+	sb.WriteString(strings.Join(labels, ", "))
+	sb.WriteString(`) AS label
+		FROM related r`)
+	sb.WriteString(strings.Join(joins, ""))
+	sb.WriteString(`
+		WHERE COALESCE(` + strings.Join(labels, ", ") + `) IS NOT NULL
+		ORDER BY r.created_at DESC`)
+
+	return sb.String(), args
+}
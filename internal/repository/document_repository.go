@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// DocumentFileFilter defines the filter for searching the document library
+type DocumentFileFilter struct {
+	CompanyID uuid.UUID
+	FolderIDs []uuid.UUID // the folders searched, those the user may read
+	FolderID  *uuid.UUID  // only the files directly in this folder
+	Query     string      // in the name and description
+	Metadata  map[string]string
+	Entity    *domain.DocumentRef // only the files linked to the document
+	Page      int
+	PageSize  int
+}
+
+// DocumentRepository defines the interface for document library persistence
+type DocumentRepository interface {
+	CreateFolder(ctx context.Context, folder *domain.DocumentFolder) error
+	// UpdateFolder stores the name and parent of the folder
+	UpdateFolder(ctx context.Context, folder *domain.DocumentFolder) error
+	// DeleteFolder deletes a folder with its permissions; it fails with
+	// domain.ErrDocumentFolderNotEmpty while it holds subfolders or files
+	DeleteFolder(ctx context.Context, companyID, id uuid.UUID) error
+	FindFolder(ctx context.Context, companyID, id uuid.UUID) (*domain.DocumentFolder, error)
+	ListFolders(ctx context.Context, companyID uuid.UUID) ([]domain.DocumentFolder, error)
+	ExistsFolderName(ctx context.Context, companyID uuid.UUID, parentID *uuid.UUID, name string, excludeID *uuid.UUID) (bool, error)
+
+	// ListPermissions returns the permissions of all folders of the company
+	ListPermissions(ctx context.Context, companyID uuid.UUID) ([]domain.DocumentFolderPermission, error)
+	// ReplacePermissions sets the permissions of the folder; none makes it
+	// inherit those of its parent
+	ReplacePermissions(ctx context.Context, companyID, folderID uuid.UUID, permissions []domain.DocumentFolderPermission) error
+
+	// CreateFile stores a new file with its first version
+	CreateFile(ctx context.Context, file *domain.DocumentFile, version *domain.DocumentVersion) error
+	// AddVersion stores a new version and makes it current; it fails with
+	// domain.ErrDocumentFileChanged when another version was added since
+	// previous was current
+	AddVersion(ctx context.Context, file *domain.DocumentFile, version *domain.DocumentVersion, previous int) error
+	// UpdateFile stores the folder, name, description and metadata of the file
+	UpdateFile(ctx context.Context, file *domain.DocumentFile) error
+	DeleteFile(ctx context.Context, companyID, id uuid.UUID) error
+	// FindFile returns the file with its versions, without their content, and its links
+	FindFile(ctx context.Context, companyID, id uuid.UUID) (*domain.DocumentFile, error)
+	ExistsFileName(ctx context.Context, folderID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error)
+	// FindVersion returns a version of the file with its content
+	FindVersion(ctx context.Context, companyID, fileID uuid.UUID, version int) (*domain.DocumentVersion, error)
+	UpdateVersionScan(ctx context.Context, version *domain.DocumentVersion) error
+	// DeleteVersion removes a stored version found infected; the file keeps its number
+	DeleteVersion(ctx context.Context, companyID, id uuid.UUID) error
+	// Search returns the files matching the filter, the latest updated first
+	Search(ctx context.Context, filter DocumentFileFilter) ([]domain.DocumentFile, int64, error)
+
+	// CreateLink fails with domain.ErrDocumentFileLinkExists when the file is
+	// already linked to the document
+	CreateLink(ctx context.Context, link *domain.DocumentFileLink) error
+	DeleteLink(ctx context.Context, companyID, fileID, id uuid.UUID) error
+}
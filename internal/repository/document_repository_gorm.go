@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// documentRepositoryGorm implements DocumentRepository using GORM
+type documentRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewDocumentRepository creates a new GORM-based document library repository
+func NewDocumentRepository(db *gorm.DB) DocumentRepository {
+	return &documentRepositoryGorm{db: db}
+}
+
+func (r *documentRepositoryGorm) CreateFolder(ctx context.Context, folder *domain.DocumentFolder) error {
+	return r.db.WithContext(ctx).Create(folder).Error
+}
+
+func (r *documentRepositoryGorm) UpdateFolder(ctx context.Context, folder *domain.DocumentFolder) error {
+	return r.db.WithContext(ctx).Model(folder).
+		Select("name", "parent_id").
+		Updates(folder).Error
+}
+
+func (r *documentRepositoryGorm) DeleteFolder(ctx context.Context, companyID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Model(&domain.DocumentFolder{}).
+			Where("company_id = ? AND parent_id = ?", companyID, id).
+			Count(&children).Error; err != nil {
+			return err
+		}
+		var files int64
+		if err := tx.Model(&domain.DocumentFile{}).
+			Where("company_id = ? AND folder_id = ?", companyID, id).
+			Count(&files).Error; err != nil {
+			return err
+		}
+		if children > 0 || files > 0 {
+			return domain.ErrDocumentFolderNotEmpty
+		}
+
+		result := tx.Where("company_id = ? AND id = ?", companyID, id).Delete(&domain.DocumentFolder{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrDocumentFolderNotFound
+		}
+		return nil
+	})
+}
+
+func (r *documentRepositoryGorm) FindFolder(ctx context.Context, companyID, id uuid.UUID) (*domain.DocumentFolder, error) {
+	var folder domain.DocumentFolder
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&folder).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDocumentFolderNotFound
+		}
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func (r *documentRepositoryGorm) ListFolders(ctx context.Context, companyID uuid.UUID) ([]domain.DocumentFolder, error) {
+	var folders []domain.DocumentFolder
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("name ASC").
+		Find(&folders).Error
+	return folders, err
+}
+
+func (r *documentRepositoryGorm) ExistsFolderName(ctx context.Context, companyID uuid.UUID, parentID *uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.DocumentFolder{}).
+		Where("company_id = ? AND LOWER(name) = LOWER(?)", companyID, name)
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *documentRepositoryGorm) ListPermissions(ctx context.Context, companyID uuid.UUID) ([]domain.DocumentFolderPermission, error) {
+	var permissions []domain.DocumentFolderPermission
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("folder_id, role").
+		Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *documentRepositoryGorm) ReplacePermissions(ctx context.Context, companyID, folderID uuid.UUID, permissions []domain.DocumentFolderPermission) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("company_id = ? AND folder_id = ?", companyID, folderID).
+			Delete(&domain.DocumentFolderPermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+		return tx.Create(&permissions).Error
+	})
+}
+
+func (r *documentRepositoryGorm) CreateFile(ctx context.Context, file *domain.DocumentFile, version *domain.DocumentVersion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(file).Error; err != nil {
+			return err
+		}
+		version.FileID = file.ID
+		return tx.Create(version).Error
+	})
+}
+
+func (r *documentRepositoryGorm) AddVersion(ctx context.Context, file *domain.DocumentFile, version *domain.DocumentVersion, previous int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.DocumentFile{}).
+			Where("company_id = ? AND id = ? AND current_version = ?", file.CompanyID, file.ID, previous).
+			Updates(map[string]interface{}{
+				"current_version": file.CurrentVersion,
+				"file_type":       file.FileType,
+				"file_size":       file.FileSize,
+				"updated_by":      file.UpdatedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrDocumentFileChanged
+		}
+		return tx.Create(version).Error
+	})
+}
+
+func (r *documentRepositoryGorm) UpdateFile(ctx context.Context, file *domain.DocumentFile) error {
+	return r.db.WithContext(ctx).Model(file).
+		Omit(clause.Associations).
+		Select("folder_id", "name", "description", "metadata", "updated_by").
+		Updates(file).Error
+}
+
+func (r *documentRepositoryGorm) DeleteFile(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.DocumentFile{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDocumentFileNotFound
+	}
+	return nil
+}
+
+func (r *documentRepositoryGorm) FindFile(ctx context.Context, companyID, id uuid.UUID) (*domain.DocumentFile, error) {
+	var file domain.DocumentFile
+	err := r.db.WithContext(ctx).
+		Preload("Versions", func(db *gorm.DB) *gorm.DB {
+			return db.Omit("file_data").Order("version DESC")
+		}).
+		Preload("Links", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&file).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDocumentFileNotFound
+		}
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (r *documentRepositoryGorm) ExistsFileName(ctx context.Context, folderID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.DocumentFile{}).
+		Where("folder_id = ? AND LOWER(name) = LOWER(?)", folderID, name)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *documentRepositoryGorm) FindVersion(ctx context.Context, companyID, fileID uuid.UUID, version int) (*domain.DocumentVersion, error) {
+	var v domain.DocumentVersion
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND file_id = ? AND version = ?", companyID, fileID, version).
+		First(&v).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDocumentVersionNotFound
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *documentRepositoryGorm) UpdateVersionScan(ctx context.Context, version *domain.DocumentVersion) error {
+	return r.db.WithContext(ctx).Model(version).
+		Select("scan_status", "scanner", "scanned_at").
+		Updates(version).Error
+}
+
+func (r *documentRepositoryGorm) DeleteVersion(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.DocumentVersion{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDocumentVersionNotFound
+	}
+	return nil
+}
+
+func (r *documentRepositoryGorm) Search(ctx context.Context, filter DocumentFileFilter) ([]domain.DocumentFile, int64, error) {
+	if len(filter.FolderIDs) == 0 {
+		return []domain.DocumentFile{}, 0, nil
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&domain.DocumentFile{}).
+		Where("company_id = ? AND folder_id IN ?", filter.CompanyID, filter.FolderIDs)
+	if filter.FolderID != nil {
+		query = query.Where("folder_id = ?", *filter.FolderID)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where("(name ILIKE ? OR description ILIKE ?)", pattern, pattern)
+	}
+	if len(filter.Metadata) > 0 {
+		metadata, err := json.Marshal(filter.Metadata)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("metadata @> ?::jsonb", string(metadata))
+	}
+	if filter.Entity != nil {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM document_file_links l WHERE l.file_id = document_files.id AND l.entity_type = ? AND l.entity_id = ?)",
+			filter.Entity.Type, filter.Entity.ID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var files []domain.DocumentFile
+	err := query.
+		Order("updated_at DESC, id DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&files).Error
+	return files, total, err
+}
+
+func (r *documentRepositoryGorm) CreateLink(ctx context.Context, link *domain.DocumentFileLink) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(link)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDocumentFileLinkExists
+	}
+	return nil
+}
+
+func (r *documentRepositoryGorm) DeleteLink(ctx context.Context, companyID, fileID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND file_id = ? AND id = ?", companyID, fileID, id).
+		Delete(&domain.DocumentFileLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDocumentFileLinkNotFound
+	}
+	return nil
+}
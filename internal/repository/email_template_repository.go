@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// EmailTemplateRepository defines the interface for the versions of company email templates
+type EmailTemplateRepository interface {
+	// Create saves the template as the next version of its event and makes it
+	// the active one
+	Create(ctx context.Context, template *domain.EmailTemplate) error
+	// Deactivate leaves the event without an active version, so that the
+	// system default applies
+	Deactivate(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) error
+
+	// Query operations
+	FindActive(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) (*domain.EmailTemplate, error)
+	FindAllActive(ctx context.Context, companyID uuid.UUID) ([]domain.EmailTemplate, error)
+	FindVersion(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, version int) (*domain.EmailTemplate, error)
+	// FindVersions lists the versions of the event, newest first
+	FindVersions(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) ([]domain.EmailTemplate, error)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// emailTemplateRepositoryGorm implements EmailTemplateRepository using GORM
+type emailTemplateRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewEmailTemplateRepository creates a new GORM-based email template repository
+func NewEmailTemplateRepository(db *gorm.DB) EmailTemplateRepository {
+	return &emailTemplateRepositoryGorm{db: db}
+}
+
+func (r *emailTemplateRepositoryGorm) Create(ctx context.Context, template *domain.EmailTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the versions of the event serializes concurrent saves
+		var versions []int
+		err := tx.Model(&domain.EmailTemplate{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND event_type = ?", template.CompanyID, template.EventType).
+			Pluck("version", &versions).Error
+		if err != nil {
+			return err
+		}
+		template.Version = 1
+		for _, v := range versions {
+			template.Version = max(template.Version, v+1)
+		}
+
+		err = tx.Model(&domain.EmailTemplate{}).
+			Where("company_id = ? AND event_type = ? AND is_active = ?", template.CompanyID, template.EventType, true).
+			Update("is_active", false).Error
+		if err != nil {
+			return err
+		}
+		template.IsActive = true
+		return tx.Create(template).Error
+	})
+}
+
+func (r *emailTemplateRepositoryGorm) Deactivate(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.EmailTemplate{}).
+		Where("company_id = ? AND event_type = ? AND is_active = ?", companyID, eventType, true).
+		Update("is_active", false).Error
+}
+
+func (r *emailTemplateRepositoryGorm) FindActive(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) (*domain.EmailTemplate, error) {
+	var template domain.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND event_type = ? AND is_active = ?", companyID, eventType, true).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *emailTemplateRepositoryGorm) FindAllActive(ctx context.Context, companyID uuid.UUID) ([]domain.EmailTemplate, error) {
+	var templates []domain.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND is_active = ?", companyID, true).
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *emailTemplateRepositoryGorm) FindVersion(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, version int) (*domain.EmailTemplate, error) {
+	var template domain.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND event_type = ? AND version = ?", companyID, eventType, version).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *emailTemplateRepositoryGorm) FindVersions(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) ([]domain.EmailTemplate, error) {
+	var templates []domain.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND event_type = ?", companyID, eventType).
+		Order("version DESC").
+		Find(&templates).Error
+	return templates, err
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// EmployeeAdvanceFilter defines filter options for employee advances
+type EmployeeAdvanceFilter struct {
+	CompanyID  uuid.UUID
+	EmployeeID *uuid.UUID
+	Status     *domain.EmployeeAdvanceStatus
+	Page       int
+	PageSize   int
+}
+
+// AdvanceSettlementFilter defines filter options for settlements of employee advances
+type AdvanceSettlementFilter struct {
+	CompanyID    uuid.UUID
+	ReviewStatus *domain.SettlementReviewStatus
+	Page         int
+	PageSize     int
+}
+
+// EmployeeAdvanceRepository defines the interface for employee advance persistence
+type EmployeeAdvanceRepository interface {
+	Create(ctx context.Context, advance *domain.EmployeeAdvance) error
+	// FindByID returns the advance with its settlements and their expenses
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.EmployeeAdvance, error)
+	List(ctx context.Context, filter EmployeeAdvanceFilter) ([]domain.EmployeeAdvance, int64, error)
+
+	// CreateSettlement stores the settlement with its expenses and the
+	// settled amount of the advance. It fails with
+	// domain.ErrEmployeeAdvanceChanged when the advance was settled by
+	// another settlement since previousSettled was read.
+	CreateSettlement(ctx context.Context, advance *domain.EmployeeAdvance, settlement *domain.EmployeeAdvanceSettlement, previousSettled int64) error
+	// FindSettlement returns the settlement with its expenses
+	FindSettlement(ctx context.Context, companyID, id uuid.UUID) (*domain.EmployeeAdvanceSettlement, error)
+	ListSettlements(ctx context.Context, filter AdvanceSettlementFilter) ([]domain.EmployeeAdvanceSettlement, int64, error)
+	// UpdateSettlementReview stores the review of the policy violations. It
+	// fails with domain.ErrSettlementReviewNotPending when they were reviewed
+	// meanwhile.
+	UpdateSettlementReview(ctx context.Context, settlement *domain.EmployeeAdvanceSettlement) error
+
+	// Balances returns the advances paid through the date with the part
+	// settled through it, of those not settled by then
+	Balances(ctx context.Context, companyID uuid.UUID, asOf time.Time) ([]domain.EmployeeAdvanceBalance, error)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// employeeAdvanceRepositoryGorm implements EmployeeAdvanceRepository using GORM
+type employeeAdvanceRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewEmployeeAdvanceRepository creates a new GORM-based employee advance repository
+func NewEmployeeAdvanceRepository(db *gorm.DB) EmployeeAdvanceRepository {
+	return &employeeAdvanceRepositoryGorm{db: db}
+}
+
+func (r *employeeAdvanceRepositoryGorm) Create(ctx context.Context, advance *domain.EmployeeAdvance) error {
+	return r.db.WithContext(ctx).Omit("Settlements").Create(advance).Error
+}
+
+func (r *employeeAdvanceRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.EmployeeAdvance, error) {
+	var advance domain.EmployeeAdvance
+	err := r.db.WithContext(ctx).
+		Preload("Settlements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("settlement_date ASC, created_at ASC")
+		}).
+		Preload("Settlements.Expenses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&advance).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrEmployeeAdvanceNotFound
+		}
+		return nil, err
+	}
+	return &advance, nil
+}
+
+func (r *employeeAdvanceRepositoryGorm) List(ctx context.Context, filter EmployeeAdvanceFilter) ([]domain.EmployeeAdvance, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.EmployeeAdvance{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var advances []domain.EmployeeAdvance
+	err := query.
+		Order("advance_date DESC, created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&advances).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return advances, total, nil
+}
+
+func (r *employeeAdvanceRepositoryGorm) CreateSettlement(ctx context.Context, advance *domain.EmployeeAdvance, settlement *domain.EmployeeAdvanceSettlement, previousSettled int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(advance).
+			Where("settled_amount = ?", previousSettled).
+			Select("settled_amount", "status").
+			Updates(advance)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrEmployeeAdvanceChanged
+		}
+		return tx.Create(settlement).Error
+	})
+}
+
+func (r *employeeAdvanceRepositoryGorm) FindSettlement(ctx context.Context, companyID, id uuid.UUID) (*domain.EmployeeAdvanceSettlement, error) {
+	var settlement domain.EmployeeAdvanceSettlement
+	err := r.db.WithContext(ctx).
+		Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&settlement).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAdvanceSettlementNotFound
+		}
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+func (r *employeeAdvanceRepositoryGorm) ListSettlements(ctx context.Context, filter AdvanceSettlementFilter) ([]domain.EmployeeAdvanceSettlement, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.EmployeeAdvanceSettlement{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.ReviewStatus != nil {
+		query = query.Where("review_status = ?", *filter.ReviewStatus)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var settlements []domain.EmployeeAdvanceSettlement
+	err := query.
+		Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Order("settlement_date DESC, created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&settlements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return settlements, total, nil
+}
+
+func (r *employeeAdvanceRepositoryGorm) UpdateSettlementReview(ctx context.Context, settlement *domain.EmployeeAdvanceSettlement) error {
+	result := r.db.WithContext(ctx).Model(settlement).
+		Where("review_status = ?", domain.SettlementReviewPending).
+		Select("review_status", "reviewed_by", "reviewed_at", "review_note").
+		Updates(settlement)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSettlementReviewNotPending
+	}
+	return nil
+}
+
+func (r *employeeAdvanceRepositoryGorm) Balances(ctx context.Context, companyID uuid.UUID, asOf time.Time) ([]domain.EmployeeAdvanceBalance, error) {
+	var balances []domain.EmployeeAdvanceBalance
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT a.id AS advance_id, a.employee_id, u.name AS employee_name, a.purpose,
+			a.advance_date, a.due_date, a.amount,
+			COALESCE(SUM(s.applied_amount) FILTER (WHERE s.settlement_date <= ?), 0) AS settled
+		FROM employee_advances a
+		JOIN users u ON u.id = a.employee_id
+		LEFT JOIN employee_advance_settlements s ON s.advance_id = a.id
+		WHERE a.company_id = ? AND a.advance_date <= ?
+		GROUP BY a.id, u.name
+		HAVING a.amount > COALESCE(SUM(s.applied_amount) FILTER (WHERE s.settlement_date <= ?), 0)
+		ORDER BY u.name, a.advance_date
+	`, asOf, companyID, asOf, asOf).Scan(&balances).Error
+	return balances, err
+}
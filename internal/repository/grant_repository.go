@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// GrantFilter defines filter options for grants
+type GrantFilter struct {
+	CompanyID uuid.UUID
+	Method    *domain.GrantRecognitionMethod
+	Search    string // grant number, program name or agency
+	Page      int
+	PageSize  int
+}
+
+// GrantRepository defines the interface for government grant persistence
+type GrantRepository interface {
+	Create(ctx context.Context, grant *domain.Grant) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error)
+	// GetByIDForUpdate returns the grant and locks its row until the end of
+	// the transaction
+	GetByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error)
+	ExistsGrantNo(ctx context.Context, companyID uuid.UUID, grantNo string) (bool, error)
+	List(ctx context.Context, filter GrantFilter) ([]domain.Grant, int64, error)
+
+	// ListStartedBy returns the grants of the company whose program started on
+	// or before the date
+	ListStartedBy(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.Grant, error)
+	// ListForRecognition returns the grants with deferred income not yet
+	// recognized for the month ending at periodEnd, of one company or of all
+	// when companyID is nil
+	ListForRecognition(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.Grant, error)
+
+	// RecordReceipt stores the receipt and the received amount of the grant
+	RecordReceipt(ctx context.Context, grant *domain.Grant, receipt *domain.GrantReceipt) error
+	// LinkReceiptVoucher stores the voucher of a recorded receipt
+	LinkReceiptVoucher(ctx context.Context, receipt *domain.GrantReceipt) error
+	ListReceipts(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantReceipt, error)
+	// ListReceiptsUntil returns the receipts of the company on or before the date
+	ListReceiptsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantReceipt, error)
+
+	CreateExpense(ctx context.Context, expense *domain.GrantExpense) error
+	GetExpense(ctx context.Context, companyID, grantID, id uuid.UUID) (*domain.GrantExpense, error)
+	DeleteExpense(ctx context.Context, companyID, id uuid.UUID) error
+	ExistsExpenseVoucher(ctx context.Context, companyID, grantID, voucherID uuid.UUID) (bool, error)
+	// ListExpenses returns the expenses linked to the grant in voucher date order
+	ListExpenses(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantExpense, error)
+	// ListExpensesUntil returns the expenses of the company dated on or before the date
+	ListExpensesUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantExpense, error)
+
+	// RecordRecognition stores the recognition and moves the recognized amount
+	// and recognized-through date of the grant
+	RecordRecognition(ctx context.Context, grant *domain.Grant, recognition *domain.GrantRecognition) error
+	// LinkRecognitionVoucher stores the voucher of a recorded recognition
+	LinkRecognitionVoucher(ctx context.Context, recognition *domain.GrantRecognition) error
+	ListRecognitions(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantRecognition, error)
+	// ListRecognitionsUntil returns the recognitions of the company for periods
+	// ending on or before the date
+	ListRecognitionsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantRecognition, error)
+
+	// WithTransaction executes a function within a transaction
+	WithTransaction(ctx context.Context, fn func(repo GrantRepository) error) error
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// grantRepositoryGorm implements GrantRepository using GORM
+type grantRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewGrantRepository creates a new GORM-based grant repository
+func NewGrantRepository(db *gorm.DB) GrantRepository {
+	return &grantRepositoryGorm{db: db}
+}
+
+func (r *grantRepositoryGorm) Create(ctx context.Context, grant *domain.Grant) error {
+	return r.db.WithContext(ctx).Create(grant).Error
+}
+
+func (r *grantRepositoryGorm) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error) {
+	var grant domain.Grant
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&grant).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrGrantNotFound
+		}
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (r *grantRepositoryGorm) GetByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error) {
+	var grant domain.Grant
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&grant).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrGrantNotFound
+		}
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (r *grantRepositoryGorm) ExistsGrantNo(ctx context.Context, companyID uuid.UUID, grantNo string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.Grant{}).
+		Where("company_id = ? AND grant_no = ?", companyID, grantNo).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *grantRepositoryGorm) List(ctx context.Context, filter GrantFilter) ([]domain.Grant, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Grant{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Method != nil {
+		query = query.Where("recognition_method = ?", *filter.Method)
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("grant_no ILIKE ? OR name ILIKE ? OR agency ILIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var grants []domain.Grant
+	err := query.
+		Order("program_start DESC, grant_no ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&grants).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return grants, total, nil
+}
+
+func (r *grantRepositoryGorm) ListStartedBy(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.Grant, error) {
+	var grants []domain.Grant
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND program_start <= ?", companyID, date).
+		Order("program_start ASC, grant_no ASC").
+		Find(&grants).Error
+	return grants, err
+}
+
+func (r *grantRepositoryGorm) ListForRecognition(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.Grant, error) {
+	query := r.db.WithContext(ctx).
+		Where("program_start <= ? AND received_amount > recognized_amount", periodEnd).
+		Where("recognized_through IS NULL OR recognized_through < ?", periodEnd)
+	if companyID != nil {
+		query = query.Where("company_id = ?", *companyID)
+	}
+
+	var grants []domain.Grant
+	err := query.Order("company_id, program_start ASC, grant_no ASC").Find(&grants).Error
+	return grants, err
+}
+
+func (r *grantRepositoryGorm) RecordReceipt(ctx context.Context, grant *domain.Grant, receipt *domain.GrantReceipt) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(receipt).Error; err != nil {
+			return err
+		}
+		return tx.Model(grant).
+			Select("received_amount", "updated_by").
+			Updates(grant).Error
+	})
+}
+
+func (r *grantRepositoryGorm) LinkReceiptVoucher(ctx context.Context, receipt *domain.GrantReceipt) error {
+	return r.db.WithContext(ctx).Model(&domain.GrantReceipt{}).
+		Where("id = ?", receipt.ID).
+		Update("voucher_id", receipt.VoucherID).Error
+}
+
+func (r *grantRepositoryGorm) ListReceipts(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantReceipt, error) {
+	var receipts []domain.GrantReceipt
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND grant_id = ?", companyID, grantID).
+		Order("receipt_date ASC, created_at ASC").
+		Find(&receipts).Error
+	return receipts, err
+}
+
+func (r *grantRepositoryGorm) ListReceiptsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantReceipt, error) {
+	var receipts []domain.GrantReceipt
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND receipt_date <= ?", companyID, date).
+		Order("receipt_date ASC, created_at ASC").
+		Find(&receipts).Error
+	return receipts, err
+}
+
+func (r *grantRepositoryGorm) CreateExpense(ctx context.Context, expense *domain.GrantExpense) error {
+	return r.db.WithContext(ctx).Create(expense).Error
+}
+
+func (r *grantRepositoryGorm) GetExpense(ctx context.Context, companyID, grantID, id uuid.UUID) (*domain.GrantExpense, error) {
+	var expense domain.GrantExpense
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND grant_id = ? AND id = ?", companyID, grantID, id).
+		First(&expense).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrGrantExpenseNotFound
+		}
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (r *grantRepositoryGorm) DeleteExpense(ctx context.Context, companyID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.GrantExpense{}).Error
+}
+
+func (r *grantRepositoryGorm) ExistsExpenseVoucher(ctx context.Context, companyID, grantID, voucherID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.GrantExpense{}).
+		Where("company_id = ? AND grant_id = ? AND voucher_id = ?", companyID, grantID, voucherID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *grantRepositoryGorm) ListExpenses(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantExpense, error) {
+	var expenses []domain.GrantExpense
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND grant_id = ?", companyID, grantID).
+		Order("voucher_date ASC, voucher_no ASC").
+		Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *grantRepositoryGorm) ListExpensesUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantExpense, error) {
+	var expenses []domain.GrantExpense
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND voucher_date <= ?", companyID, date).
+		Order("voucher_date ASC, voucher_no ASC").
+		Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *grantRepositoryGorm) RecordRecognition(ctx context.Context, grant *domain.Grant, recognition *domain.GrantRecognition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(recognition).Error; err != nil {
+			return err
+		}
+		return tx.Model(grant).
+			Select("recognized_amount", "recognized_through").
+			Updates(grant).Error
+	})
+}
+
+func (r *grantRepositoryGorm) LinkRecognitionVoucher(ctx context.Context, recognition *domain.GrantRecognition) error {
+	return r.db.WithContext(ctx).Model(&domain.GrantRecognition{}).
+		Where("id = ?", recognition.ID).
+		Update("voucher_id", recognition.VoucherID).Error
+}
+
+func (r *grantRepositoryGorm) ListRecognitions(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantRecognition, error) {
+	var recognitions []domain.GrantRecognition
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND grant_id = ?", companyID, grantID).
+		Order("period_end ASC").
+		Find(&recognitions).Error
+	return recognitions, err
+}
+
+func (r *grantRepositoryGorm) ListRecognitionsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.GrantRecognition, error) {
+	var recognitions []domain.GrantRecognition
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND period_end <= ?", companyID, date).
+		Order("period_end ASC").
+		Find(&recognitions).Error
+	return recognitions, err
+}
+
+// WithTransaction executes a function within a transaction
+func (r *grantRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo GrantRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&grantRepositoryGorm{db: tx})
+	})
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// InboxItemFilter defines filter options for inbox items
+type InboxItemFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.InboxItemStatus
+	Page      int
+	PageSize  int
+}
+
+// InboxRepository defines the interface for the inbound email inbox
+type InboxRepository interface {
+	// Aliases
+	GetAlias(ctx context.Context, companyID uuid.UUID) (*domain.InboxAlias, error)
+	// FindAlias finds the alias of an inbound address across all companies
+	FindAlias(ctx context.Context, alias string) (*domain.InboxAlias, error)
+	// SaveAlias creates the company's alias or replaces the previous one
+	SaveAlias(ctx context.Context, alias *domain.InboxAlias) error
+
+	// ExistsMessage returns true if items were created from the email before
+	ExistsMessage(ctx context.Context, companyID uuid.UUID, messageID string) (bool, error)
+
+	// CreateItem stores the item together with its document
+	CreateItem(ctx context.Context, item *domain.InboxItem) error
+	// GetItem returns the item with its document, without the content
+	GetItem(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxItem, error)
+	// GetDocument returns a document with its content
+	GetDocument(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxDocument, error)
+	// ListItems lists items oldest first, so that the queue is worked in order
+	ListItems(ctx context.Context, filter InboxItemFilter) ([]domain.InboxItem, int64, error)
+	// UpdateItemStatus closes a pending item; it returns ErrInboxItemClosed if it was closed meanwhile
+	UpdateItemStatus(ctx context.Context, item *domain.InboxItem) error
+}
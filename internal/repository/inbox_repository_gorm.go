@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// inboxRepositoryGorm implements InboxRepository using GORM
+type inboxRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewInboxRepository creates a new GORM-based inbox repository
+func NewInboxRepository(db *gorm.DB) InboxRepository {
+	return &inboxRepositoryGorm{db: db}
+}
+
+// inboxDocumentColumns are the document columns loaded with items, all but the content
+var inboxDocumentColumns = []string{"id", "company_id", "file_name", "content_type", "file_size", "sha256", "created_at"}
+
+func (r *inboxRepositoryGorm) GetAlias(ctx context.Context, companyID uuid.UUID) (*domain.InboxAlias, error) {
+	var alias domain.InboxAlias
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		First(&alias).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInboxAliasNotFound
+		}
+		return nil, err
+	}
+	return &alias, nil
+}
+
+func (r *inboxRepositoryGorm) FindAlias(ctx context.Context, alias string) (*domain.InboxAlias, error) {
+	var found domain.InboxAlias
+	err := r.db.WithContext(ctx).
+		Where("alias = ?", alias).
+		First(&found).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInboxAliasNotFound
+		}
+		return nil, err
+	}
+	return &found, nil
+}
+
+func (r *inboxRepositoryGorm) SaveAlias(ctx context.Context, alias *domain.InboxAlias) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "company_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"alias", "created_at"}),
+		}).
+		Create(alias).Error
+}
+
+func (r *inboxRepositoryGorm) ExistsMessage(ctx context.Context, companyID uuid.UUID, messageID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.InboxItem{}).
+		Where("company_id = ? AND message_id = ?", companyID, messageID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *inboxRepositoryGorm) CreateItem(ctx context.Context, item *domain.InboxItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item.Document).Error; err != nil {
+			return err
+		}
+		item.DocumentID = item.Document.ID
+		return tx.Omit("Document").Create(item).Error
+	})
+}
+
+func (r *inboxRepositoryGorm) GetItem(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxItem, error) {
+	var item domain.InboxItem
+	err := r.db.WithContext(ctx).
+		Preload("Document", func(db *gorm.DB) *gorm.DB {
+			return db.Select(inboxDocumentColumns)
+		}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&item).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInboxItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *inboxRepositoryGorm) GetDocument(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxDocument, error) {
+	var document domain.InboxDocument
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&document).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInboxItemNotFound
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (r *inboxRepositoryGorm) ListItems(ctx context.Context, filter InboxItemFilter) ([]domain.InboxItem, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.InboxItem{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []domain.InboxItem
+	err := query.
+		Preload("Document", func(db *gorm.DB) *gorm.DB {
+			return db.Select(inboxDocumentColumns)
+		}).
+		Order("received_at ASC, id ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// UpdateItemStatus closes a pending item; it returns ErrInboxItemClosed if
+// another clerk closed it first
+func (r *inboxRepositoryGorm) UpdateItemStatus(ctx context.Context, item *domain.InboxItem) error {
+	result := r.db.WithContext(ctx).
+		Model(item).
+		Where("status = ?", domain.InboxItemPending).
+		Select("status", "voucher_id", "processed_by", "processed_at", "note").
+		Updates(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInboxItemClosed
+	}
+	return nil
+}
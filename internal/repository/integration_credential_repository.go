@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// IntegrationCredentialRepository defines the interface for integration credential data access
+type IntegrationCredentialRepository interface {
+	// Save creates the credential or replaces the one with the same provider and identifier
+	Save(ctx context.Context, credential *domain.IntegrationCredential) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.IntegrationCredential, error)
+	// FindByIdentifier returns the active credential of a provider with the identifier
+	FindByIdentifier(ctx context.Context, companyID uuid.UUID, provider domain.IntegrationProvider, identifier string) (*domain.IntegrationCredential, error)
+	FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.IntegrationCredential, error)
+
+	// UpdateTestResult stores the outcome of the last credential test
+	UpdateTestResult(ctx context.Context, credential *domain.IntegrationCredential) error
+}
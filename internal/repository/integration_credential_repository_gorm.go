@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// integrationCredentialRepositoryGorm implements IntegrationCredentialRepository using GORM
+type integrationCredentialRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewIntegrationCredentialRepository creates a new GORM-based integration credential repository
+func NewIntegrationCredentialRepository(db *gorm.DB) IntegrationCredentialRepository {
+	return &integrationCredentialRepositoryGorm{db: db}
+}
+
+func (r *integrationCredentialRepositoryGorm) Save(ctx context.Context, credential *domain.IntegrationCredential) error {
+	// A replaced credential has not been tested yet
+	return r.db.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "company_id"}, {Name: "provider"}, {Name: "identifier"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"hint":                gorm.Expr("EXCLUDED.hint"),
+					"key_id":              gorm.Expr("EXCLUDED.key_id"),
+					"encrypted_key":       gorm.Expr("EXCLUDED.encrypted_key"),
+					"ciphertext":          gorm.Expr("EXCLUDED.ciphertext"),
+					"is_active":           gorm.Expr("EXCLUDED.is_active"),
+					"updated_by":          gorm.Expr("EXCLUDED.updated_by"),
+					"last_tested_at":      nil,
+					"last_test_succeeded": nil,
+					"last_test_error":     nil,
+				}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "created_at"}, {Name: "updated_at"}}},
+		).
+		Create(credential).Error
+}
+
+func (r *integrationCredentialRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.IntegrationCredential{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrIntegrationCredentialNotFound
+	}
+	return nil
+}
+
+func (r *integrationCredentialRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.IntegrationCredential, error) {
+	var credential domain.IntegrationCredential
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&credential).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrIntegrationCredentialNotFound
+		}
+		return nil, err
+	}
+	return &credential, nil
+}
+
+func (r *integrationCredentialRepositoryGorm) FindByIdentifier(ctx context.Context, companyID uuid.UUID, provider domain.IntegrationProvider, identifier string) (*domain.IntegrationCredential, error) {
+	var credential domain.IntegrationCredential
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND provider = ? AND identifier = ? AND is_active = true", companyID, provider, identifier).
+		First(&credential).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrIntegrationCredentialNotFound
+		}
+		return nil, err
+	}
+	return &credential, nil
+}
+
+func (r *integrationCredentialRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.IntegrationCredential, error) {
+	var credentials []domain.IntegrationCredential
+	err := r.db.WithContext(ctx).
+		Omit("encrypted_key", "ciphertext").
+		Where("company_id = ?", companyID).
+		Order("provider, identifier").
+		Find(&credentials).Error
+	return credentials, err
+}
+
+func (r *integrationCredentialRepositoryGorm) UpdateTestResult(ctx context.Context, credential *domain.IntegrationCredential) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.IntegrationCredential{}).
+		Where("company_id = ? AND id = ?", credential.CompanyID, credential.ID).
+		Updates(map[string]interface{}{
+			"last_tested_at":      credential.LastTestedAt,
+			"last_test_succeeded": credential.LastTestSucceeded,
+			"last_test_error":     credential.LastTestError,
+		}).Error
+}
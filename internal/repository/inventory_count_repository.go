@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// InventoryItemFilter defines filter options for inventory items
+type InventoryItemFilter struct {
+	CompanyID  uuid.UUID
+	AccountID  *uuid.UUID
+	Search     string // code or name
+	ActiveOnly bool
+	Page       int
+	PageSize   int
+}
+
+// InventoryCountFilter defines filter options for inventory counts
+type InventoryCountFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.InventoryCountStatus
+	Page      int
+	PageSize  int
+}
+
+// InventoryCountRepository defines the interface for inventory item and count persistence
+type InventoryCountRepository interface {
+	// Items
+	CreateItem(ctx context.Context, item *domain.InventoryItem) error
+	// UpdateItem stores the master data of the item; the quantity on hand is
+	// only changed by posted counts
+	UpdateItem(ctx context.Context, item *domain.InventoryItem) error
+	FindItemByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryItem, error)
+	ExistsItemCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error)
+	ListItems(ctx context.Context, filter InventoryItemFilter) ([]domain.InventoryItem, int64, error)
+	// ListActiveItems returns the active items of the company in code order,
+	// of one inventory account when accountID is set
+	ListActiveItems(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID) ([]domain.InventoryItem, error)
+
+	// Counts
+	// Create stores the count with its snapshot lines
+	Create(ctx context.Context, count *domain.InventoryCount) error
+	// FindByID returns the count with its lines in item code order
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error)
+	List(ctx context.Context, filter InventoryCountFilter) ([]domain.InventoryCount, int64, error)
+	ExistsInProgress(ctx context.Context, companyID uuid.UUID) (bool, error)
+	// RecordCounts stores the counted quantities of the lines while the count
+	// is open, failing with domain.ErrInventoryCountChanged otherwise
+	RecordCounts(ctx context.Context, count *domain.InventoryCount, lines []*domain.InventoryCountLine) error
+	// UpdateStatus stores the status and workflow fields of a count still in
+	// the from status, failing with domain.ErrInventoryCountChanged otherwise
+	UpdateStatus(ctx context.Context, count *domain.InventoryCount, from domain.InventoryCountStatus) error
+	// Post stores the approval of a submitted count and adds the variances of
+	// its lines to the quantities on hand of the items, in one transaction. It
+	// fails with domain.ErrInventoryCountChanged unless the count is submitted.
+	Post(ctx context.Context, count *domain.InventoryCount) error
+	// Unpost returns a posted count without its voucher to submitted and takes
+	// the variances back off the quantities on hand, when the adjustment
+	// voucher failed to post
+	Unpost(ctx context.Context, count *domain.InventoryCount) error
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// inventoryCountRepositoryGorm implements InventoryCountRepository using GORM
+type inventoryCountRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewInventoryCountRepository creates a new GORM-based inventory count repository
+func NewInventoryCountRepository(db *gorm.DB) InventoryCountRepository {
+	return &inventoryCountRepositoryGorm{db: db}
+}
+
+func (r *inventoryCountRepositoryGorm) CreateItem(ctx context.Context, item *domain.InventoryItem) error {
+	return r.db.WithContext(ctx).Create(item).Error
+}
+
+func (r *inventoryCountRepositoryGorm) UpdateItem(ctx context.Context, item *domain.InventoryItem) error {
+	return r.db.WithContext(ctx).Model(item).
+		Select("code", "name", "unit", "account_id", "unit_cost", "is_active").
+		Updates(item).Error
+}
+
+func (r *inventoryCountRepositoryGorm) FindItemByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryItem, error) {
+	var item domain.InventoryItem
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&item).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInventoryItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *inventoryCountRepositoryGorm) ExistsItemCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.InventoryItem{}).
+		Where("company_id = ? AND code = ?", companyID, code)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *inventoryCountRepositoryGorm) ListItems(ctx context.Context, filter InventoryItemFilter) ([]domain.InventoryItem, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.InventoryItem{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.AccountID != nil {
+		query = query.Where("account_id = ?", *filter.AccountID)
+	}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ?", pattern, pattern)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []domain.InventoryItem
+	err := query.
+		Order("code ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *inventoryCountRepositoryGorm) ListActiveItems(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID) ([]domain.InventoryItem, error) {
+	query := r.db.WithContext(ctx).
+		Where("company_id = ? AND is_active = ?", companyID, true)
+	if accountID != nil {
+		query = query.Where("account_id = ?", *accountID)
+	}
+
+	var items []domain.InventoryItem
+	err := query.Order("code ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *inventoryCountRepositoryGorm) Create(ctx context.Context, count *domain.InventoryCount) error {
+	return r.db.WithContext(ctx).Create(count).Error
+}
+
+func (r *inventoryCountRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error) {
+	var count domain.InventoryCount
+	err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("item_code ASC")
+		}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&count).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInventoryCountNotFound
+		}
+		return nil, err
+	}
+	return &count, nil
+}
+
+func (r *inventoryCountRepositoryGorm) List(ctx context.Context, filter InventoryCountFilter) ([]domain.InventoryCount, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.InventoryCount{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var counts []domain.InventoryCount
+	err := query.
+		Order("count_date DESC, created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&counts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return counts, total, nil
+}
+
+func (r *inventoryCountRepositoryGorm) ExistsInProgress(ctx context.Context, companyID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.InventoryCount{}).
+		Where("company_id = ? AND status IN ?", companyID,
+			[]domain.InventoryCountStatus{domain.InventoryCountOpen, domain.InventoryCountSubmitted}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *inventoryCountRepositoryGorm) RecordCounts(ctx context.Context, count *domain.InventoryCount, lines []*domain.InventoryCountLine) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locks the count so that it is not submitted while recording
+		var locked domain.InventoryCount
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND id = ?", count.CompanyID, count.ID).
+			First(&locked).Error
+		if err != nil {
+			return err
+		}
+		if locked.Status != domain.InventoryCountOpen {
+			return domain.ErrInventoryCountChanged
+		}
+		for _, line := range lines {
+			err := tx.Model(line).
+				Select("counted_quantity", "note", "counted_by", "counted_at").
+				Updates(line).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *inventoryCountRepositoryGorm) UpdateStatus(ctx context.Context, count *domain.InventoryCount, from domain.InventoryCountStatus) error {
+	return updateInventoryCountStatus(r.db.WithContext(ctx), count, from)
+}
+
+func (r *inventoryCountRepositoryGorm) Post(ctx context.Context, count *domain.InventoryCount) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateInventoryCountStatus(tx, count, domain.InventoryCountSubmitted); err != nil {
+			return err
+		}
+		for _, line := range count.Lines {
+			variance := line.Variance()
+			if variance == 0 {
+				continue
+			}
+			err := tx.Model(&domain.InventoryItem{}).
+				Where("company_id = ? AND id = ?", count.CompanyID, line.ItemID).
+				Update("quantity", gorm.Expr("quantity + ?", variance)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *inventoryCountRepositoryGorm) Unpost(ctx context.Context, count *domain.InventoryCount) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.InventoryCount{}).
+			Where("company_id = ? AND id = ? AND status = ? AND voucher_id IS NULL", count.CompanyID, count.ID, domain.InventoryCountPosted).
+			Updates(map[string]interface{}{
+				"status":      domain.InventoryCountSubmitted,
+				"approved_by": nil,
+				"approved_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrInventoryCountChanged
+		}
+		for _, line := range count.Lines {
+			variance := line.Variance()
+			if variance == 0 {
+				continue
+			}
+			err := tx.Model(&domain.InventoryItem{}).
+				Where("company_id = ? AND id = ?", count.CompanyID, line.ItemID).
+				Update("quantity", gorm.Expr("quantity - ?", variance)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// updateInventoryCountStatus stores the workflow fields of a count still in the from status
+func updateInventoryCountStatus(db *gorm.DB, count *domain.InventoryCount, from domain.InventoryCountStatus) error {
+	result := db.Model(count).
+		Where("status = ?", from).
+		Select("status", "submitted_by", "submitted_at", "approved_by", "approved_at", "voucher_id").
+		Updates(count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInventoryCountChanged
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// JobFilter defines filter options for background jobs; a nil CompanyID
+// covers all companies
+type JobFilter struct {
+	CompanyID   *uuid.UUID
+	Type        *domain.JobType
+	Status      *domain.JobStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Page        int
+	PageSize    int
+}
+
+// JobRepository defines the interface for background job persistence
+type JobRepository interface {
+	Create(ctx context.Context, job *domain.Job) error
+	// FindByID returns a job without its data
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// FindAll lists jobs without their data, newest first
+	FindAll(ctx context.Context, filter JobFilter) ([]domain.Job, int64, error)
+	// Update stores the state of the job if its status is still expected; it
+	// returns false if the job was changed meanwhile, e.g. cancelled while running
+	Update(ctx context.Context, job *domain.Job, expected domain.JobStatus) (bool, error)
+
+	// Worker operations (across all companies)
+	// ClaimNext marks the oldest queued job due at now, or one left running
+	// since before staleBefore by a crashed worker with attempts left, as
+	// running and counts the attempt. The job is returned with its data, or nil
+	// when there is none.
+	ClaimNext(ctx context.Context, now, staleBefore time.Time) (*domain.Job, error)
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// jobListColumns are the columns of jobs without their data
+var jobListColumns = []string{
+	"id", "company_id", "type", "payload", "status", "attempts", "max_attempts", "last_error", "result",
+	"run_at", "started_at", "finished_at", "created_by", "created_at", "updated_at",
+}
+
+// jobRepositoryGorm implements JobRepository using GORM
+type jobRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new GORM-based job repository
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepositoryGorm{db: db}
+}
+
+func (r *jobRepositoryGorm) Create(ctx context.Context, job *domain.Job) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *jobRepositoryGorm) FindByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	var job domain.Job
+	err := r.db.WithContext(ctx).
+		Select(jobListColumns).
+		Where("id = ?", id).
+		First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepositoryGorm) FindAll(ctx context.Context, filter JobFilter) ([]domain.Job, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Job{})
+	if filter.CompanyID != nil {
+		query = query.Where("company_id = ?", *filter.CompanyID)
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []domain.Job
+	err := query.
+		Select(jobListColumns).
+		Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&jobs).Error
+	return jobs, total, err
+}
+
+func (r *jobRepositoryGorm) Update(ctx context.Context, job *domain.Job, expected domain.JobStatus) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(job).
+		Where("status = ?", expected).
+		Select("status", "attempts", "last_error", "result", "run_at", "started_at", "finished_at").
+		Updates(job)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *jobRepositoryGorm) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*domain.Job, error) {
+	var job domain.Job
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers claim different jobs
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND started_at < ? AND attempts < max_attempts)",
+				domain.JobStatusQueued, now, domain.JobStatusRunning, staleBefore).
+			Order("run_at ASC").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		job.Status = domain.JobStatusRunning
+		job.StartedAt = &now
+		job.Attempts++
+		return tx.Model(&job).Select("status", "started_at", "attempts").Updates(&job).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ledgerBalanceStagingColumns are the ledger_balances columns loaded by COPY
+var ledgerBalanceStagingColumns = []string{
+	"company_id", "account_id", "fiscal_year", "fiscal_month",
+	"opening_debit", "opening_credit", "period_debit", "period_credit", "closing_debit", "closing_credit",
+}
+
+// The staging table lives for the session of the pooled connection; the merge
+// statement empties it, and any leftovers are removed at commit
+const createLedgerBalanceStaging = `
+	CREATE TEMP TABLE IF NOT EXISTS ledger_balances_staging (
+		company_id UUID NOT NULL,
+		account_id UUID NOT NULL,
+		fiscal_year INTEGER NOT NULL,
+		fiscal_month INTEGER NOT NULL,
+		opening_debit DECIMAL(18, 2) NOT NULL,
+		opening_credit DECIMAL(18, 2) NOT NULL,
+		period_debit DECIMAL(18, 2) NOT NULL,
+		period_credit DECIMAL(18, 2) NOT NULL,
+		closing_debit DECIMAL(18, 2) NOT NULL,
+		closing_credit DECIMAL(18, 2) NOT NULL
+	) ON COMMIT DELETE ROWS
+`
+
+const mergeLedgerBalanceStaging = `
+	WITH staged AS (
+		DELETE FROM ledger_balances_staging RETURNING *
+	)
+	INSERT INTO ledger_balances (
+		company_id, account_id, fiscal_year, fiscal_month,
+		opening_debit, opening_credit, period_debit, period_credit, closing_debit, closing_credit, updated_at
+	)
+	SELECT
+		company_id, account_id, fiscal_year, fiscal_month,
+		opening_debit, opening_credit, period_debit, period_credit, closing_debit, closing_credit, NOW()
+	FROM staged
+	ON CONFLICT (company_id, account_id, fiscal_year, fiscal_month) DO UPDATE SET
+		opening_debit = EXCLUDED.opening_debit,
+		opening_credit = EXCLUDED.opening_credit,
+		period_debit = EXCLUDED.period_debit,
+		period_credit = EXCLUDED.period_credit,
+		closing_debit = EXCLUDED.closing_debit,
+		closing_credit = EXCLUDED.closing_credit,
+		updated_at = EXCLUDED.updated_at
+`
+
+// withConnTransaction runs fn in a transaction on a dedicated connection, so that
+// COPY can be sent over the same connection as the transaction's statements
+func (r *ledgerRepositoryGorm) withConnTransaction(ctx context.Context, fn func(txRepo *ledgerRepositoryGorm) error) error {
+	return r.db.WithContext(ctx).Connection(func(db *gorm.DB) error {
+		conn, _ := db.Statement.ConnPool.(*sql.Conn)
+		return db.Transaction(func(tx *gorm.DB) error {
+			return fn(&ledgerRepositoryGorm{db: tx, conn: conn})
+		})
+	})
+}
+
+// copyBalances upserts balances with COPY into a staging table and a single
+// INSERT ... ON CONFLICT from it. It must run in a withConnTransaction transaction.
+func (r *ledgerRepositoryGorm) copyBalances(ctx context.Context, balances []domain.LedgerBalance) error {
+	if err := r.db.WithContext(ctx).Exec(createLedgerBalanceStaging).Error; err != nil {
+		return err
+	}
+
+	copied := false
+	err := r.conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return nil
+		}
+		_, err := c.Conn().CopyFrom(ctx,
+			pgx.Identifier{"ledger_balances_staging"},
+			ledgerBalanceStagingColumns,
+			pgx.CopyFromSlice(len(balances), func(i int) ([]any, error) {
+				b := &balances[i]
+				return []any{
+					b.CompanyID, b.AccountID, b.FiscalYear, b.FiscalMonth,
+					b.OpeningDebit, b.OpeningCredit, b.PeriodDebit, b.PeriodCredit, b.ClosingDebit, b.ClosingCredit,
+				}, nil
+			}),
+		)
+		copied = err == nil
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !copied {
+		// Not a pgx connection
+		return r.insertBalances(ctx, balances)
+	}
+
+	return r.db.WithContext(ctx).Exec(mergeLedgerBalanceStaging).Error
+}
+
+// insertBalances upserts balances with batched multi-row INSERT statements
+func (r *ledgerRepositoryGorm) insertBalances(ctx context.Context, balances []domain.LedgerBalance) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "company_id"}, {Name: "account_id"}, {Name: "fiscal_year"}, {Name: "fiscal_month"}},
+			DoUpdates: clause.AssignmentColumns([]string{"opening_debit", "opening_credit", "period_debit", "period_credit", "closing_debit", "closing_credit", "updated_at"}),
+		}).
+		CreateInBatches(balances, 100).Error
+}
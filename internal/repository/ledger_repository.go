@@ -9,6 +9,20 @@ import (
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
 
+// LedgerTotalsFilter selects the accounts and months of monthly totals. The
+// totals come from ledger_balances, or from the posted entries when they are
+// limited to a department or project.
+type LedgerTotalsFilter struct {
+	CompanyID    uuid.UUID
+	AccountType  domain.AccountType // empty for the designated cash accounts
+	FromYear     int
+	FromMonth    int
+	ToYear       int
+	ToMonth      int
+	DepartmentID *uuid.UUID
+	ProjectID    *uuid.UUID
+}
+
 // LedgerRepository defines the interface for ledger data access
 type LedgerRepository interface {
 	// Ledger balance operations
@@ -17,10 +31,18 @@ type LedgerRepository interface {
 	GetBalancesByType(ctx context.Context, companyID uuid.UUID, year, month int, accountType domain.AccountType) ([]domain.LedgerBalance, error)
 	UpsertBalance(ctx context.Context, balance *domain.LedgerBalance) error
 	UpsertBalances(ctx context.Context, balances []domain.LedgerBalance) error
+	// GetBalanceHistory returns the balances of an account from fromYear/fromMonth
+	// through toYear/toMonth, oldest first, preceded by its latest balance
+	// before the range if there is one
+	GetBalanceHistory(ctx context.Context, companyID, accountID uuid.UUID, fromYear, fromMonth, toYear, toMonth int) ([]domain.LedgerBalance, error)
 
 	// Ledger calculation from vouchers
 	CalculatePeriodBalances(ctx context.Context, companyID uuid.UUID, year, month int) ([]domain.LedgerBalance, error)
 	RecalculateBalances(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth int) error
+	// RecalculateAccountBalances recalculates the balances of the accounts only,
+	// from fromYear/fromMonth through the current month, for postings that
+	// touch a few accounts
+	RecalculateAccountBalances(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, fromYear, fromMonth int) error
 
 	// Account ledger (detailed transactions)
 	GetAccountLedger(ctx context.Context, companyID, accountID uuid.UUID, from, to time.Time) ([]domain.AccountLedgerEntry, error)
@@ -29,6 +51,25 @@ type LedgerRepository interface {
 	// Trial balance
 	GetTrialBalance(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.TrialBalance, error)
 	GetTrialBalanceRange(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth, toYear, toMonth int) (*domain.TrialBalance, error)
+	// GetTrialBalanceByDimension breaks the trial balance of a month down by a
+	// voucher entry dimension, computed from the posted entries of the fiscal
+	// year and the balances carried into it
+	GetTrialBalanceByDimension(ctx context.Context, companyID uuid.UUID, year, month int, dimension domain.TrialBalanceDimension) (*domain.TrialBalance, error)
+
+	// GetMonthlyTotals returns the posted debits and credits by month of the
+	// accounts of the filter, oldest first; months without any are left out
+	GetMonthlyTotals(ctx context.Context, filter LedgerTotalsFilter) ([]domain.LedgerMonthTotal, error)
+
+	// Department reports (posted revenue/expense activity by department and account)
+	GetDepartmentAccountTotals(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.DepartmentAccountTotal, error)
+
+	// Partner ledger (posted AR/AP entries by partner); a nil partnerID covers all partners
+	GetPartnerLedgerEntries(ctx context.Context, companyID uuid.UUID, partnerID *uuid.UUID, from, to time.Time) ([]domain.PartnerLedgerEntry, error)
+	GetPartnerBalances(ctx context.Context, companyID uuid.UUID, partnerID *uuid.UUID, before time.Time) (map[uuid.UUID]float64, error)
+
+	// Cash book (posted entries on cash/bank accounts)
+	GetCashBookEntries(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, from, to time.Time) ([]domain.CashBookEntry, error)
+	GetAccountBalancesBefore(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, before time.Time) (map[uuid.UUID]float64, error)
 
 	// Fiscal period operations
 	GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error)
@@ -36,6 +77,8 @@ type LedgerRepository interface {
 	CreateFiscalPeriod(ctx context.Context, period *domain.FiscalPeriod) error
 	UpdateFiscalPeriod(ctx context.Context, period *domain.FiscalPeriod) error
 	GetOpenPeriods(ctx context.Context, companyID uuid.UUID) ([]domain.FiscalPeriod, error)
+	// IsFiscalYearAuditLocked returns true if the year has an audit lock that has not been lifted
+	IsFiscalYearAuditLocked(ctx context.Context, companyID uuid.UUID, year int) (bool, error)
 
 	// Carry forward
 	CarryForwardBalances(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth, toYear, toMonth int) error
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// Benchmarks of the ledger balance upsert against a migrated database, e.g.
+//
+//	KERP_BENCH_DATABASE_DSN="host=localhost user=kerp dbname=kerp_bench sslmode=disable" \
+//		go test ./internal/repository -run '^$' -bench UpsertBalances -benchtime 5x
+//
+// A benchmark company with its accounts is created and deleted by each run.
+func BenchmarkUpsertBalances(b *testing.B) {
+	dsn := os.Getenv("KERP_BENCH_DATABASE_DSN")
+	if dsn == "" {
+		b.Skip("KERP_BENCH_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: dsn, PreferSimpleProtocol: true}), &gorm.Config{
+		Logger:      logger.Default.LogMode(logger.Silent),
+		PrepareStmt: true,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, accounts := range []int{1000, 10000} {
+		balances := benchmarkBalances(b, db, accounts)
+		repo := &ledgerRepositoryGorm{db: db}
+
+		b.Run(fmt.Sprintf("insert_batches/%d", accounts), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					return (&ledgerRepositoryGorm{db: tx}).insertBalances(ctx, balances)
+				}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("copy/%d", accounts), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := repo.UpsertBalances(ctx, balances); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkBalances creates a company with the number of accounts and returns a
+// period balance for each account
+func benchmarkBalances(b *testing.B, db *gorm.DB, accounts int) []domain.LedgerBalance {
+	b.Helper()
+
+	var companyID uuid.UUID
+	err := db.Raw(`
+		INSERT INTO companies (business_number, company_name, representative)
+		VALUES (?, 'Ledger benchmark', 'Benchmark')
+		RETURNING id
+	`, fmt.Sprintf("9%09d", accounts)).Scan(&companyID).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		db.Exec("DELETE FROM companies WHERE id = ?", companyID)
+	})
+
+	var accountIDs []uuid.UUID
+	err = db.Raw(`
+		INSERT INTO accounts (company_id, code, name, account_type, account_nature)
+		SELECT ?, lpad(g::text, 6, '0'), 'Account ' || g, 'asset', 'debit'
+		FROM generate_series(1, ?) g
+		RETURNING id
+	`, companyID, accounts).Scan(&accountIDs).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	balances := make([]domain.LedgerBalance, len(accountIDs))
+	for i, accountID := range accountIDs {
+		balances[i] = domain.LedgerBalance{
+			CompanyID:     companyID,
+			AccountID:     accountID,
+			FiscalYear:    2026,
+			FiscalMonth:   1,
+			OpeningDebit:  float64(i) * 1000,
+			PeriodDebit:   500000.50,
+			PeriodCredit:  123456.25,
+			ClosingDebit:  float64(i)*1000 + 376544.25,
+			ClosingCredit: 0,
+		}
+	}
+	return balances
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +17,8 @@ import (
 // ledgerRepositoryGorm implements LedgerRepository using GORM
 type ledgerRepositoryGorm struct {
 	db *gorm.DB
+	// conn is the connection db's transaction runs on; set for COPY
+	conn *sql.Conn
 }
 
 // NewLedgerRepository creates a new LedgerRepository with GORM
@@ -49,6 +54,32 @@ func (r *ledgerRepositoryGorm) GetBalances(ctx context.Context, companyID uuid.U
 	return balances, err
 }
 
+// GetBalanceHistory retrieves the monthly balances of an account over a range
+func (r *ledgerRepositoryGorm) GetBalanceHistory(ctx context.Context, companyID, accountID uuid.UUID, fromYear, fromMonth, toYear, toMonth int) ([]domain.LedgerBalance, error) {
+	var prior []domain.LedgerBalance
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND account_id = ?", companyID, accountID).
+		Where("fiscal_year < ? OR (fiscal_year = ? AND fiscal_month < ?)", fromYear, fromYear, fromMonth).
+		Order("fiscal_year DESC, fiscal_month DESC").
+		Limit(1).
+		Find(&prior).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []domain.LedgerBalance
+	err = r.db.WithContext(ctx).
+		Where("company_id = ? AND account_id = ?", companyID, accountID).
+		Where("fiscal_year > ? OR (fiscal_year = ? AND fiscal_month >= ?)", fromYear, fromYear, fromMonth).
+		Where("fiscal_year < ? OR (fiscal_year = ? AND fiscal_month <= ?)", toYear, toYear, toMonth).
+		Order("fiscal_year, fiscal_month").
+		Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(prior, balances...), nil
+}
+
 // GetBalancesByType retrieves ledger balances by account type
 func (r *ledgerRepositoryGorm) GetBalancesByType(ctx context.Context, companyID uuid.UUID, year, month int, accountType domain.AccountType) ([]domain.LedgerBalance, error) {
 	var balances []domain.LedgerBalance
@@ -72,40 +103,53 @@ func (r *ledgerRepositoryGorm) UpsertBalance(ctx context.Context, balance *domai
 		Create(balance).Error
 }
 
-// UpsertBalances inserts or updates multiple ledger balances
+// UpsertBalances inserts or updates multiple ledger balances using COPY
 func (r *ledgerRepositoryGorm) UpsertBalances(ctx context.Context, balances []domain.LedgerBalance) error {
 	if len(balances) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "company_id"}, {Name: "account_id"}, {Name: "fiscal_year"}, {Name: "fiscal_month"}},
-			DoUpdates: clause.AssignmentColumns([]string{"opening_debit", "opening_credit", "period_debit", "period_credit", "closing_debit", "closing_credit", "updated_at"}),
-		}).
-		CreateInBatches(balances, 100).Error
+	if r.conn != nil {
+		return r.copyBalances(ctx, balances)
+	}
+	if _, inTx := r.db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		// The transaction's connection is not reachable for COPY
+		return r.insertBalances(ctx, balances)
+	}
+	return r.withConnTransaction(ctx, func(txRepo *ledgerRepositoryGorm) error {
+		return txRepo.copyBalances(ctx, balances)
+	})
 }
 
 // CalculatePeriodBalances calculates balances from posted vouchers
 func (r *ledgerRepositoryGorm) CalculatePeriodBalances(ctx context.Context, companyID uuid.UUID, year, month int) ([]domain.LedgerBalance, error) {
+	return r.calculatePeriodBalances(ctx, companyID, year, month, nil)
+}
+
+// calculatePeriodBalances calculates the balances of the accounts with posted
+// entries in the month, or of the given accounts only. Given accounts without
+// entries in the month still get a balance carrying their opening, so that a
+// later month opens with it and a stale balance is cleared.
+func (r *ledgerRepositoryGorm) calculatePeriodBalances(ctx context.Context, companyID uuid.UUID, year, month int, accountIDs []uuid.UUID) ([]domain.LedgerBalance, error) {
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	endDate := startDate.AddDate(0, 1, -1)
 
-	var results []struct {
+	type periodTotal struct {
 		AccountID    uuid.UUID `gorm:"column:account_id"`
 		PeriodDebit  float64   `gorm:"column:period_debit"`
 		PeriodCredit float64   `gorm:"column:period_credit"`
 	}
+	var results []periodTotal
 
-	err := r.db.WithContext(ctx).
+	query := r.db.WithContext(ctx).
 		Table("voucher_entries ve").
 		Select("ve.account_id, COALESCE(SUM(ve.debit_amount), 0) as period_debit, COALESCE(SUM(ve.credit_amount), 0) as period_credit").
 		Joins("JOIN vouchers v ON ve.voucher_id = v.id").
-		Where("ve.company_id = ? AND v.status = ? AND v.voucher_date >= ? AND v.voucher_date <= ?",
-			companyID, domain.VoucherStatusPosted, startDate, endDate).
-		Group("ve.account_id").
-		Scan(&results).Error
-
-	if err != nil {
+		Where("ve.company_id = ? AND ve.fiscal_year = ? AND v.status = ? AND v.voucher_date >= ? AND v.voucher_date <= ?",
+			companyID, year, domain.VoucherStatusPosted, startDate, endDate)
+	if accountIDs != nil {
+		query = query.Where("ve.account_id IN ?", accountIDs)
+	}
+	if err := query.Group("ve.account_id").Scan(&results).Error; err != nil {
 		return nil, err
 	}
 
@@ -119,12 +163,60 @@ func (r *ledgerRepositoryGorm) CalculatePeriodBalances(ctx context.Context, comp
 		prevMonth = month - 1
 	}
 
-	prevBalances, _ := r.GetBalances(ctx, companyID, prevYear, prevMonth)
+	prevBalances, _ := r.periodBalances(ctx, companyID, prevYear, prevMonth, accountIDs)
 	prevBalanceMap := make(map[uuid.UUID]*domain.LedgerBalance)
 	for i := range prevBalances {
 		prevBalanceMap[prevBalances[i].AccountID] = &prevBalances[i]
 	}
 
+	// Once the previous year is rolled over, January opens with the balances
+	// the rollover carried forward rather than every December closing
+	if month == 1 {
+		var rolledOver int64
+		if err := r.db.WithContext(ctx).Model(&domain.YearRolloverRun{}).
+			Where("company_id = ? AND from_year = ?", companyID, prevYear).
+			Count(&rolledOver).Error; err != nil {
+			return nil, err
+		}
+		if rolledOver > 0 {
+			january, err := r.periodBalances(ctx, companyID, year, month, accountIDs)
+			if err != nil {
+				return nil, err
+			}
+			prevBalanceMap = make(map[uuid.UUID]*domain.LedgerBalance)
+			for i := range january {
+				january[i].ClosingDebit = january[i].OpeningDebit
+				january[i].ClosingCredit = january[i].OpeningCredit
+				prevBalanceMap[january[i].AccountID] = &january[i]
+			}
+		}
+	}
+
+	// Given accounts without entries are kept when they open with a balance
+	// or already have one for the month
+	if accountIDs != nil {
+		existing, err := r.periodBalances(ctx, companyID, year, month, accountIDs)
+		if err != nil {
+			return nil, err
+		}
+		kept := make(map[uuid.UUID]bool, len(existing)+len(results))
+		for _, b := range existing {
+			kept[b.AccountID] = true
+		}
+		for id := range prevBalanceMap {
+			kept[id] = true
+		}
+		for _, result := range results {
+			delete(kept, result.AccountID)
+		}
+		for _, id := range accountIDs {
+			if kept[id] {
+				results = append(results, periodTotal{AccountID: id})
+				kept[id] = false
+			}
+		}
+	}
+
 	// Build new balances
 	var balances []domain.LedgerBalance
 	for _, result := range results {
@@ -151,21 +243,50 @@ func (r *ledgerRepositoryGorm) CalculatePeriodBalances(ctx context.Context, comp
 	return balances, nil
 }
 
+// periodBalances retrieves the ledger balances of a period without their
+// accounts, of the given accounts only unless accountIDs is nil
+func (r *ledgerRepositoryGorm) periodBalances(ctx context.Context, companyID uuid.UUID, year, month int, accountIDs []uuid.UUID) ([]domain.LedgerBalance, error) {
+	var balances []domain.LedgerBalance
+	query := r.db.WithContext(ctx).
+		Where("company_id = ? AND fiscal_year = ? AND fiscal_month = ?", companyID, year, month)
+	if accountIDs != nil {
+		query = query.Where("account_id IN ?", accountIDs)
+	}
+	err := query.Order("account_id").Find(&balances).Error
+	return balances, err
+}
+
 // RecalculateBalances recalculates all balances from a starting period
 func (r *ledgerRepositoryGorm) RecalculateBalances(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth int) error {
+	return r.recalculateBalances(ctx, companyID, nil, fromYear, fromMonth)
+}
+
+// RecalculateAccountBalances recalculates the balances of the accounts from a
+// starting period, leaving every other account alone
+func (r *ledgerRepositoryGorm) RecalculateAccountBalances(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, fromYear, fromMonth int) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	return r.recalculateBalances(ctx, companyID, accountIDs, fromYear, fromMonth)
+}
+
+// recalculateBalances recalculates the balances month by month through the
+// current month, or through the starting month when it lies ahead
+func (r *ledgerRepositoryGorm) recalculateBalances(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, fromYear, fromMonth int) error {
 	// Get current date to determine end period
 	now := time.Now()
 	endYear := now.Year()
 	endMonth := int(now.Month())
+	if fromYear > endYear || (fromYear == endYear && fromMonth > endMonth) {
+		endYear, endMonth = fromYear, fromMonth
+	}
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txRepo := &ledgerRepositoryGorm{db: tx}
-
+	return r.withConnTransaction(ctx, func(txRepo *ledgerRepositoryGorm) error {
 		year := fromYear
 		month := fromMonth
 
 		for year < endYear || (year == endYear && month <= endMonth) {
-			balances, err := txRepo.CalculatePeriodBalances(ctx, companyID, year, month)
+			balances, err := txRepo.calculatePeriodBalances(ctx, companyID, year, month, accountIDs)
 			if err != nil {
 				return err
 			}
@@ -212,12 +333,13 @@ func (r *ledgerRepositoryGorm) GetAccountLedger(ctx context.Context, companyID,
 		LEFT JOIN partners p ON ve.partner_id = p.id
 		LEFT JOIN departments d ON ve.department_id = d.id
 		WHERE ve.company_id = ? AND ve.account_id = ?
+			AND ve.fiscal_year BETWEEN ? AND ?
 			AND v.voucher_date >= ? AND v.voucher_date <= ?
 			AND v.status = ?
 		ORDER BY v.voucher_date, v.voucher_no, ve.line_no
 	`
 
-	if err := r.db.WithContext(ctx).Raw(query, companyID, accountID, from, to, domain.VoucherStatusPosted).Scan(&entries).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(query, companyID, accountID, from.Year(), to.Year(), from, to, domain.VoucherStatusPosted).Scan(&entries).Error; err != nil {
 		return nil, err
 	}
 
@@ -358,6 +480,397 @@ func (r *ledgerRepositoryGorm) GetTrialBalanceRange(ctx context.Context, company
 	return tb, nil
 }
 
+// trialBalanceDimensionSources maps each dimension to its voucher entry column
+// and the master table its code and name come from
+var trialBalanceDimensionSources = map[domain.TrialBalanceDimension]struct{ column, table string }{
+	domain.TrialBalanceByDepartment: {"department_id", "departments"},
+	domain.TrialBalanceByProject:    {"project_id", "projects"},
+	domain.TrialBalanceByPartner:    {"partner_id", "partners"},
+}
+
+// GetTrialBalanceByDimension generates a trial balance by account and dimension.
+// ledger_balances only hold totals by account, so the amounts of the fiscal
+// year are summed from the posted entries. The balances carried into the year,
+// after any rollover into retained earnings, are the January openings of
+// ledger_balances and go to the rows without the dimension.
+func (r *ledgerRepositoryGorm) GetTrialBalanceByDimension(ctx context.Context, companyID uuid.UUID, year, month int, dimension domain.TrialBalanceDimension) (*domain.TrialBalance, error) {
+	source, ok := trialBalanceDimensionSources[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unsupported trial balance dimension %q", dimension)
+	}
+
+	period, err := r.GetFiscalPeriod(ctx, companyID, year, month)
+	if err != nil && err != domain.ErrFiscalPeriodNotFound {
+		return nil, err
+	}
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	var rows []trialBalanceRow
+
+	query := fmt.Sprintf(`
+		SELECT
+			ve.account_id,
+			a.code as account_code,
+			a.name as account_name,
+			a.account_type,
+			a.level as account_level,
+			COALESCE(a.sort_order, 0) as sort_order,
+			ve.%[1]s as dimension_id,
+			COALESCE(dim.code, '') as dimension_code,
+			COALESCE(dim.name, '') as dimension_name,
+			COALESCE(SUM(CASE WHEN v.voucher_date < ? THEN ve.debit_amount ELSE 0 END), 0) as opening_debit,
+			COALESCE(SUM(CASE WHEN v.voucher_date < ? THEN ve.credit_amount ELSE 0 END), 0) as opening_credit,
+			COALESCE(SUM(CASE WHEN v.voucher_date >= ? THEN ve.debit_amount ELSE 0 END), 0) as period_debit,
+			COALESCE(SUM(CASE WHEN v.voucher_date >= ? THEN ve.credit_amount ELSE 0 END), 0) as period_credit
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN accounts a ON ve.account_id = a.id
+		LEFT JOIN %[2]s dim ON ve.%[1]s = dim.id
+		WHERE ve.company_id = ?
+			AND ve.fiscal_year = ?
+			AND v.status = ?
+			AND v.voucher_date <= ?
+		GROUP BY ve.account_id, a.code, a.name, a.account_type, a.level, a.sort_order, ve.%[1]s, dim.code, dim.name
+	`, source.column, source.table)
+
+	err = r.db.WithContext(ctx).Raw(query, startDate, startDate, startDate, startDate,
+		companyID, year, domain.VoucherStatusPosted, endDate).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var openings []trialBalanceRow
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT
+			lb.account_id,
+			a.code as account_code,
+			a.name as account_name,
+			a.account_type,
+			a.level as account_level,
+			COALESCE(a.sort_order, 0) as sort_order,
+			lb.opening_debit,
+			lb.opening_credit
+		FROM ledger_balances lb
+		JOIN accounts a ON lb.account_id = a.id
+		WHERE lb.company_id = ? AND lb.fiscal_year = ? AND lb.fiscal_month = 1
+			AND (lb.opening_debit <> 0 OR lb.opening_credit <> 0)
+	`, companyID, year).Scan(&openings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := dimensionItems(rows, openings)
+
+	var totalDebit, totalCredit float64
+	for _, item := range items {
+		totalDebit += item.ClosingDebit
+		totalCredit += item.ClosingCredit
+	}
+
+	periodName := ""
+	if period != nil {
+		periodName = period.PeriodName
+	}
+
+	tb := &domain.TrialBalance{
+		CompanyID:   companyID,
+		FiscalYear:  year,
+		FiscalMonth: month,
+		PeriodName:  periodName,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		GeneratedAt: time.Now(),
+		GroupBy:     dimension,
+		Items:       items,
+		TotalDebit:  totalDebit,
+		TotalCredit: totalCredit,
+	}
+
+	tb.Validate()
+	return tb, nil
+}
+
+// trialBalanceRow is a dimensional trial balance item with the sort order of
+// its account
+type trialBalanceRow struct {
+	domain.TrialBalanceItem
+	SortOrder int `gorm:"column:sort_order"`
+}
+
+// dimensionItems adds the openings carried into the year, by account, to the
+// rows of the accounts without the dimension and returns the items with their
+// closing, ordered by account and dimension
+func dimensionItems(rows, openings []trialBalanceRow) []domain.TrialBalanceItem {
+	undimensioned := make(map[uuid.UUID]int)
+	for i := range rows {
+		if rows[i].DimensionID == nil {
+			undimensioned[rows[i].AccountID] = i
+		}
+	}
+	for _, opening := range openings {
+		i, ok := undimensioned[opening.AccountID]
+		if !ok {
+			rows = append(rows, opening)
+			continue
+		}
+		rows[i].OpeningDebit += opening.OpeningDebit
+		rows[i].OpeningCredit += opening.OpeningCredit
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		switch {
+		case a.AccountType != b.AccountType:
+			return a.AccountType < b.AccountType
+		case a.SortOrder != b.SortOrder:
+			return a.SortOrder < b.SortOrder
+		case a.AccountCode != b.AccountCode:
+			return a.AccountCode < b.AccountCode
+		}
+		return a.DimensionCode < b.DimensionCode
+	})
+
+	items := make([]domain.TrialBalanceItem, len(rows))
+	for i := range rows {
+		items[i] = rows[i].TrialBalanceItem
+		items[i].ClosingDebit = items[i].OpeningDebit + items[i].PeriodDebit
+		items[i].ClosingCredit = items[i].OpeningCredit + items[i].PeriodCredit
+	}
+	return items
+}
+
+// GetMonthlyTotals sums the period debits and credits of the accounts by month
+func (r *ledgerRepositoryGorm) GetMonthlyTotals(ctx context.Context, filter LedgerTotalsFilter) ([]domain.LedgerMonthTotal, error) {
+	var totals []domain.LedgerMonthTotal
+	accounts := "a.is_cash_account = true"
+	args := []interface{}{}
+	if filter.AccountType != "" {
+		accounts = "a.account_type = ?"
+		args = append(args, filter.AccountType)
+	}
+
+	if filter.DepartmentID == nil && filter.ProjectID == nil {
+		err := r.db.WithContext(ctx).
+			Table("ledger_balances lb").
+			Select("lb.fiscal_year as year, lb.fiscal_month as month, COALESCE(SUM(lb.period_debit), 0) as debit, COALESCE(SUM(lb.period_credit), 0) as credit").
+			Joins("JOIN accounts a ON lb.account_id = a.id").
+			Where("lb.company_id = ? AND lb.fiscal_year * 12 + lb.fiscal_month BETWEEN ? AND ?",
+				filter.CompanyID, filter.FromYear*12+filter.FromMonth, filter.ToYear*12+filter.ToMonth).
+			Where(accounts, args...).
+			Group("lb.fiscal_year, lb.fiscal_month").
+			Order("lb.fiscal_year, lb.fiscal_month").
+			Scan(&totals).Error
+		return totals, err
+	}
+
+	// ledger_balances only hold totals by account
+	from := time.Date(filter.FromYear, time.Month(filter.FromMonth), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(filter.ToYear, time.Month(filter.ToMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+	query := r.db.WithContext(ctx).
+		Table("voucher_entries ve").
+		Select("EXTRACT(YEAR FROM v.voucher_date)::int as year, EXTRACT(MONTH FROM v.voucher_date)::int as month, COALESCE(SUM(ve.debit_amount), 0) as debit, COALESCE(SUM(ve.credit_amount), 0) as credit").
+		Joins("JOIN vouchers v ON ve.voucher_id = v.id").
+		Joins("JOIN accounts a ON ve.account_id = a.id").
+		Where("ve.company_id = ? AND ve.fiscal_year BETWEEN ? AND ? AND v.status = ? AND v.voucher_date >= ? AND v.voucher_date <= ?",
+			filter.CompanyID, from.Year(), to.Year(), domain.VoucherStatusPosted, from, to).
+		Where(accounts, args...)
+	if filter.DepartmentID != nil {
+		query = query.Where("ve.department_id = ?", *filter.DepartmentID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("ve.project_id = ?", *filter.ProjectID)
+	}
+	err := query.Group("year, month").Order("year, month").Scan(&totals).Error
+	return totals, err
+}
+
+// GetDepartmentAccountTotals sums posted revenue and expense entries by department and account
+func (r *ledgerRepositoryGorm) GetDepartmentAccountTotals(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.DepartmentAccountTotal, error) {
+	var totals []domain.DepartmentAccountTotal
+
+	query := `
+		SELECT
+			ve.department_id,
+			COALESCE(d.code, '') as department_code,
+			COALESCE(d.name, '') as department_name,
+			a.id as account_id,
+			a.code as account_code,
+			a.name as account_name,
+			a.account_type,
+			COALESCE(SUM(ve.debit_amount), 0) as debit,
+			COALESCE(SUM(ve.credit_amount), 0) as credit
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN accounts a ON ve.account_id = a.id
+		LEFT JOIN departments d ON ve.department_id = d.id
+		WHERE ve.company_id = ?
+			AND ve.fiscal_year BETWEEN ? AND ?
+			AND v.status = ?
+			AND v.voucher_date >= ? AND v.voucher_date <= ?
+			AND a.account_type IN (?, ?)
+		GROUP BY ve.department_id, d.code, d.name, a.id, a.code, a.name, a.account_type
+		ORDER BY a.code
+	`
+
+	err := r.db.WithContext(ctx).Raw(query, companyID, from.Year(), to.Year(), domain.VoucherStatusPosted, from, to,
+		domain.AccountTypeRevenue, domain.AccountTypeExpense).Scan(&totals).Error
+	return totals, err
+}
+
+// GetPartnerLedgerEntries retrieves posted entries on balance sheet (AR/AP) accounts by partner
+func (r *ledgerRepositoryGorm) GetPartnerLedgerEntries(ctx context.Context, companyID uuid.UUID, partnerID *uuid.UUID, from, to time.Time) ([]domain.PartnerLedgerEntry, error) {
+	var entries []domain.PartnerLedgerEntry
+
+	query := `
+		SELECT
+			ve.partner_id,
+			v.id as voucher_id,
+			v.voucher_no,
+			v.voucher_date,
+			v.voucher_type,
+			ve.id as entry_id,
+			ve.line_no,
+			a.id as account_id,
+			a.code as account_code,
+			a.name as account_name,
+			ve.description,
+			ve.debit_amount,
+			ve.credit_amount
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN accounts a ON ve.account_id = a.id
+		WHERE ve.company_id = ? AND ve.partner_id IS NOT NULL
+			AND ve.fiscal_year BETWEEN ? AND ?
+			AND v.voucher_date >= ? AND v.voucher_date <= ?
+			AND v.status = ?
+			AND a.account_type IN (?, ?)
+	`
+	args := []interface{}{companyID, from.Year(), to.Year(), from, to, domain.VoucherStatusPosted, domain.AccountTypeAsset, domain.AccountTypeLiability}
+	if partnerID != nil {
+		query += " AND ve.partner_id = ?"
+		args = append(args, *partnerID)
+	}
+	query += " ORDER BY ve.partner_id, v.voucher_date, v.voucher_no, ve.line_no"
+
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetPartnerBalances sums posted AR/AP entries before the date (debit minus credit) by partner
+func (r *ledgerRepositoryGorm) GetPartnerBalances(ctx context.Context, companyID uuid.UUID, partnerID *uuid.UUID, before time.Time) (map[uuid.UUID]float64, error) {
+	var rows []struct {
+		PartnerID uuid.UUID `gorm:"column:partner_id"`
+		Balance   float64   `gorm:"column:balance"`
+	}
+
+	query := `
+		SELECT
+			ve.partner_id,
+			COALESCE(SUM(ve.debit_amount - ve.credit_amount), 0) as balance
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN accounts a ON ve.account_id = a.id
+		WHERE ve.company_id = ? AND ve.partner_id IS NOT NULL
+			AND ve.fiscal_year <= ?
+			AND v.voucher_date < ?
+			AND v.status = ?
+			AND a.account_type IN (?, ?)
+	`
+	args := []interface{}{companyID, before.Year(), before, domain.VoucherStatusPosted, domain.AccountTypeAsset, domain.AccountTypeLiability}
+	if partnerID != nil {
+		query += " AND ve.partner_id = ?"
+		args = append(args, *partnerID)
+	}
+	query += " GROUP BY ve.partner_id"
+
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		balances[row.PartnerID] = row.Balance
+	}
+	return balances, nil
+}
+
+// GetCashBookEntries retrieves posted entries on the accounts with the other-side accounts of each voucher
+func (r *ledgerRepositoryGorm) GetCashBookEntries(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, from, to time.Time) ([]domain.CashBookEntry, error) {
+	var entries []domain.CashBookEntry
+	if len(accountIDs) == 0 {
+		return entries, nil
+	}
+
+	query := `
+		SELECT
+			ve.account_id,
+			v.id as voucher_id,
+			v.voucher_no,
+			v.voucher_date,
+			v.voucher_type,
+			ve.id as entry_id,
+			ve.line_no,
+			ve.description,
+			COALESCE(p.name, '') as partner_name,
+			COALESCE((
+				SELECT string_agg(DISTINCT ca.name, ', ')
+				FROM voucher_entries ce
+				JOIN accounts ca ON ce.account_id = ca.id
+				WHERE ce.voucher_id = v.id AND ce.fiscal_year = ve.fiscal_year
+					AND ce.account_id <> ve.account_id
+					AND (ce.debit_amount > 0) = (ve.credit_amount > 0)
+			), '') as counter_account,
+			ve.debit_amount as receipt,
+			ve.credit_amount as payment
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		LEFT JOIN partners p ON ve.partner_id = p.id
+		WHERE ve.company_id = ? AND ve.account_id IN ?
+			AND ve.fiscal_year BETWEEN ? AND ?
+			AND v.voucher_date >= ? AND v.voucher_date <= ?
+			AND v.status = ?
+		ORDER BY ve.account_id, v.voucher_date, v.voucher_no, ve.line_no
+	`
+
+	if err := r.db.WithContext(ctx).Raw(query, companyID, accountIDs, from.Year(), to.Year(), from, to, domain.VoucherStatusPosted).Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetAccountBalancesBefore sums posted entries before the date (debit minus credit) by account
+func (r *ledgerRepositoryGorm) GetAccountBalancesBefore(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, before time.Time) (map[uuid.UUID]float64, error) {
+	balances := make(map[uuid.UUID]float64)
+	if len(accountIDs) == 0 {
+		return balances, nil
+	}
+
+	var rows []struct {
+		AccountID uuid.UUID `gorm:"column:account_id"`
+		Balance   float64   `gorm:"column:balance"`
+	}
+	err := r.db.WithContext(ctx).
+		Table("voucher_entries ve").
+		Select("ve.account_id, COALESCE(SUM(ve.debit_amount - ve.credit_amount), 0) as balance").
+		Joins("JOIN vouchers v ON ve.voucher_id = v.id").
+		Where("ve.company_id = ? AND ve.account_id IN ? AND ve.fiscal_year <= ? AND v.status = ? AND v.voucher_date < ?",
+			companyID, accountIDs, before.Year(), domain.VoucherStatusPosted, before).
+		Group("ve.account_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		balances[row.AccountID] = row.Balance
+	}
+	return balances, nil
+}
+
 // GetFiscalPeriod retrieves a fiscal period
 func (r *ledgerRepositoryGorm) GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error) {
 	var period domain.FiscalPeriod
@@ -393,6 +906,16 @@ func (r *ledgerRepositoryGorm) UpdateFiscalPeriod(ctx context.Context, period *d
 	return r.db.WithContext(ctx).Save(period).Error
 }
 
+// IsFiscalYearAuditLocked checks for an active audit lock of the year
+func (r *ledgerRepositoryGorm) IsFiscalYearAuditLocked(ctx context.Context, companyID uuid.UUID, year int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.AuditLock{}).
+		Where("company_id = ? AND fiscal_year = ? AND unlocked_at IS NULL", companyID, year).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // GetOpenPeriods retrieves all open fiscal periods
 func (r *ledgerRepositoryGorm) GetOpenPeriods(ctx context.Context, companyID uuid.UUID) ([]domain.FiscalPeriod, error) {
 	var periods []domain.FiscalPeriod
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// The trial balance by partner of February 2026 after 2025 is rolled over
+// balances and matches the trial balance of the ledger balances
+func TestDimensionItems_AcrossYearBoundary(t *testing.T) {
+	companyID := uuid.New()
+	account := func(code string, accountType domain.AccountType) *domain.Account {
+		return &domain.Account{TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+			Code: code, Name: code, AccountType: accountType}
+	}
+	cash := account("101", domain.AccountTypeAsset)
+	retained := account("375", domain.AccountTypeEquity)
+	revenue := account("401", domain.AccountTypeRevenue)
+	expense := account("801", domain.AccountTypeExpense)
+	partnerA, partnerB := uuid.New(), uuid.New()
+
+	// 2025: sales of 1,000,000 to A, expenses of 300,000 paid to B
+	december := []domain.LedgerBalance{
+		{AccountID: cash.ID, Account: cash, ClosingDebit: 1000000, ClosingCredit: 300000},
+		{AccountID: revenue.ID, Account: revenue, ClosingCredit: 1000000},
+		{AccountID: expense.ID, Account: expense, ClosingDebit: 300000},
+	}
+	january, _ := domain.BuildYearOpeningBalances(companyID, 2025, december, nil, retained.ID)
+
+	// 2026: sales of 200,000 to A in January, expenses of 50,000 paid to B in February
+	ledger := map[uuid.UUID]*domain.LedgerBalance{}
+	for i := range january {
+		ledger[january[i].AccountID] = &january[i]
+	}
+	post := func(accountID uuid.UUID, debit, credit float64) {
+		b, ok := ledger[accountID]
+		if !ok {
+			b = &domain.LedgerBalance{AccountID: accountID}
+			ledger[accountID] = b
+		}
+		b.PeriodDebit += debit
+		b.PeriodCredit += credit
+	}
+	post(cash.ID, 200000, 0)
+	post(revenue.ID, 0, 200000)
+	post(expense.ID, 0, 0)
+	var want domain.TrialBalance
+	for _, jan := range ledger {
+		jan.CalculateClosing()
+		feb := domain.LedgerBalance{OpeningDebit: jan.ClosingDebit, OpeningCredit: jan.ClosingCredit}
+		switch jan.AccountID {
+		case cash.ID:
+			feb.PeriodCredit = 50000
+		case expense.ID:
+			feb.PeriodDebit = 50000
+		}
+		feb.CalculateClosing()
+		want.TotalDebit += feb.ClosingDebit
+		want.TotalCredit += feb.ClosingCredit
+	}
+
+	row := func(a *domain.Account, dimensionID *uuid.UUID, code string) trialBalanceRow {
+		return trialBalanceRow{TrialBalanceItem: domain.TrialBalanceItem{
+			AccountID: a.ID, AccountCode: a.Code, AccountType: string(a.AccountType),
+			DimensionID: dimensionID, DimensionCode: code,
+		}}
+	}
+	cashA, cashB := row(cash, &partnerA, "P001"), row(cash, &partnerB, "P002")
+	cashA.OpeningDebit = 200000
+	cashB.PeriodCredit = 50000
+	revenueA := row(revenue, &partnerA, "P001")
+	revenueA.OpeningCredit = 200000
+	expenseB := row(expense, &partnerB, "P002")
+	expenseB.PeriodDebit = 50000
+
+	var openings []trialBalanceRow
+	for _, b := range january {
+		a := map[uuid.UUID]*domain.Account{cash.ID: cash, retained.ID: retained}[b.AccountID]
+		require.NotNil(t, a)
+		opening := row(a, nil, "")
+		opening.OpeningDebit, opening.OpeningCredit = b.OpeningDebit, b.OpeningCredit
+		openings = append(openings, opening)
+	}
+
+	items := dimensionItems([]trialBalanceRow{revenueA, expenseB, cashA, cashB}, openings)
+
+	require.Len(t, items, 6)
+	var got domain.TrialBalance
+	for _, item := range items {
+		got.TotalDebit += item.ClosingDebit
+		got.TotalCredit += item.ClosingCredit
+	}
+	assert.True(t, got.Validate(), "debits %v, credits %v", got.TotalDebit, got.TotalCredit)
+	assert.Equal(t, want.TotalDebit, got.TotalDebit)
+	assert.Equal(t, want.TotalCredit, got.TotalCredit)
+
+	// The carried cash comes first, without a partner; 2025 revenue and
+	// expense are in retained earnings
+	assert.Nil(t, items[0].DimensionID)
+	assert.Equal(t, cash.ID, items[0].AccountID)
+	assert.Equal(t, 700000.0, items[0].ClosingDebit)
+	assert.Equal(t, retained.ID, items[3].AccountID)
+	assert.Equal(t, 700000.0, items[3].ClosingCredit)
+	assert.Equal(t, revenue.ID, items[5].AccountID)
+	assert.Equal(t, 200000.0, items[5].ClosingCredit, "revenue holds 2026 sales only")
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// LoanFilter defines filter options for loans
+type LoanFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.LoanStatus
+	Page      int
+	PageSize  int
+}
+
+// LoanRepository defines the interface for loan persistence
+type LoanRepository interface {
+	// Create stores the loan together with its installments
+	Create(ctx context.Context, loan *domain.Loan) error
+	// GetByID returns the loan with its installments
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error)
+	// GetByIDForUpdate returns the loan with its installments and locks its row
+	// until the end of the transaction
+	GetByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error)
+	ExistsLoanNo(ctx context.Context, companyID uuid.UUID, loanNo string) (bool, error)
+	List(ctx context.Context, filter LoanFilter) ([]domain.Loan, int64, error)
+
+	// ListStartedBy returns the loans of the company started on or before the
+	// date, with their installments
+	ListStartedBy(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.Loan, error)
+	// ListForAccrual returns the active loans whose interest of the month ending
+	// at periodEnd is not yet accrued, of one company or of all when companyID is nil
+	ListForAccrual(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.Loan, error)
+
+	// RecordRepayment stores the repayment and the balances of the loan and its installments
+	RecordRepayment(ctx context.Context, loan *domain.Loan, repayment *domain.LoanRepayment) error
+	// LinkRepaymentVoucher stores the voucher of a recorded repayment
+	LinkRepaymentVoucher(ctx context.Context, repayment *domain.LoanRepayment) error
+	ListRepayments(ctx context.Context, companyID, loanID uuid.UUID) ([]domain.LoanRepayment, error)
+	// ListRepaymentsUntil returns the repayments of the company made on or before the date
+	ListRepaymentsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.LoanRepayment, error)
+
+	// RecordAccrual stores the accrual and moves the accrued-through date of the loan
+	RecordAccrual(ctx context.Context, loan *domain.Loan, accrual *domain.LoanInterestAccrual) error
+
+	// WithTransaction executes a function within a transaction
+	WithTransaction(ctx context.Context, fn func(repo LoanRepository) error) error
+}
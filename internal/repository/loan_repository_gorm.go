@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// loanRepositoryGorm implements LoanRepository using GORM
+type loanRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewLoanRepository creates a new GORM-based loan repository
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &loanRepositoryGorm{db: db}
+}
+
+// preloadInstallments loads the installments of loans in schedule order
+func preloadInstallments(db *gorm.DB) *gorm.DB {
+	return db.Order("seq ASC")
+}
+
+func (r *loanRepositoryGorm) Create(ctx context.Context, loan *domain.Loan) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Installments").Create(loan).Error; err != nil {
+			return err
+		}
+		for i := range loan.Installments {
+			loan.Installments[i].LoanID = loan.ID
+			loan.Installments[i].CompanyID = loan.CompanyID
+		}
+		if len(loan.Installments) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(loan.Installments, 100).Error
+	})
+}
+
+func (r *loanRepositoryGorm) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error) {
+	var loan domain.Loan
+	err := r.db.WithContext(ctx).
+		Preload("Installments", preloadInstallments).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&loan).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLoanNotFound
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func (r *loanRepositoryGorm) GetByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error) {
+	var loan domain.Loan
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Installments", preloadInstallments).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&loan).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrLoanNotFound
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func (r *loanRepositoryGorm) ExistsLoanNo(ctx context.Context, companyID uuid.UUID, loanNo string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.Loan{}).
+		Where("company_id = ? AND loan_no = ?", companyID, loanNo).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *loanRepositoryGorm) List(ctx context.Context, filter LoanFilter) ([]domain.Loan, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Loan{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var loans []domain.Loan
+	err := query.
+		Order("start_date ASC, loan_no ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&loans).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return loans, total, nil
+}
+
+func (r *loanRepositoryGorm) ListStartedBy(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	err := r.db.WithContext(ctx).
+		Preload("Installments", preloadInstallments).
+		Where("company_id = ? AND start_date <= ?", companyID, date).
+		Order("start_date ASC, loan_no ASC").
+		Find(&loans).Error
+	return loans, err
+}
+
+func (r *loanRepositoryGorm) ListForAccrual(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.Loan, error) {
+	query := r.db.WithContext(ctx).
+		Where("status = ? AND start_date <= ?", domain.LoanStatusActive, periodEnd).
+		Where("accrued_through IS NULL OR accrued_through < ?", periodEnd)
+	if companyID != nil {
+		query = query.Where("company_id = ?", *companyID)
+	}
+
+	var loans []domain.Loan
+	err := query.Order("company_id, start_date ASC, loan_no ASC").Find(&loans).Error
+	return loans, err
+}
+
+func (r *loanRepositoryGorm) RecordRepayment(ctx context.Context, loan *domain.Loan, repayment *domain.LoanRepayment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(repayment).Error; err != nil {
+			return err
+		}
+		err := tx.Model(loan).
+			Select("outstanding_principal", "interest_paid_through", "status", "updated_by").
+			Updates(loan).Error
+		if err != nil {
+			return err
+		}
+		for i := range loan.Installments {
+			inst := &loan.Installments[i]
+			err := tx.Model(inst).
+				Where("paid_principal <> ?", inst.PaidPrincipal).
+				Update("paid_principal", inst.PaidPrincipal).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *loanRepositoryGorm) LinkRepaymentVoucher(ctx context.Context, repayment *domain.LoanRepayment) error {
+	return r.db.WithContext(ctx).Model(&domain.LoanRepayment{}).
+		Where("id = ?", repayment.ID).
+		Update("voucher_id", repayment.VoucherID).Error
+}
+
+func (r *loanRepositoryGorm) ListRepayments(ctx context.Context, companyID, loanID uuid.UUID) ([]domain.LoanRepayment, error) {
+	var repayments []domain.LoanRepayment
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND loan_id = ?", companyID, loanID).
+		Order("repayment_date ASC, created_at ASC").
+		Find(&repayments).Error
+	return repayments, err
+}
+
+func (r *loanRepositoryGorm) ListRepaymentsUntil(ctx context.Context, companyID uuid.UUID, date time.Time) ([]domain.LoanRepayment, error) {
+	var repayments []domain.LoanRepayment
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND repayment_date <= ?", companyID, date).
+		Order("repayment_date ASC, created_at ASC").
+		Find(&repayments).Error
+	return repayments, err
+}
+
+func (r *loanRepositoryGorm) RecordAccrual(ctx context.Context, loan *domain.Loan, accrual *domain.LoanInterestAccrual) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(accrual).Error; err != nil {
+			return err
+		}
+		return tx.Model(loan).Update("accrued_through", loan.AccruedThrough).Error
+	})
+}
+
+// WithTransaction executes a function within a transaction
+func (r *loanRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo LoanRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&loanRepositoryGorm{db: tx})
+	})
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// NoteFilter defines filter options for notes
+type NoteFilter struct {
+	CompanyID  uuid.UUID
+	Direction  *domain.NoteDirection
+	Status     *domain.NoteStatus
+	PartnerID  *uuid.UUID
+	MaturityTo *time.Time // notes maturing on or before the date
+	Page       int
+	PageSize   int
+}
+
+// NoteRepository defines the interface for note persistence
+type NoteRepository interface {
+	Create(ctx context.Context, note *domain.Note) error
+	// Update stores the status, settlement and vouchers of an outstanding
+	// note. It fails with domain.ErrNoteNotOutstanding when the note was
+	// discounted or settled meanwhile.
+	Update(ctx context.Context, note *domain.Note) error
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Note, error)
+	ExistsNoteNo(ctx context.Context, companyID uuid.UUID, direction domain.NoteDirection, noteNo string) (bool, error)
+	List(ctx context.Context, filter NoteFilter) ([]domain.Note, int64, error)
+
+	// ListForMaturityAlert returns the outstanding notes of all companies
+	// maturing on or before the date that were not notified yet
+	ListForMaturityAlert(ctx context.Context, maturityBy time.Time) ([]domain.Note, error)
+	MarkMaturityAlerted(ctx context.Context, ids []uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// noteRepositoryGorm implements NoteRepository using GORM
+type noteRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewNoteRepository creates a new GORM-based note repository
+func NewNoteRepository(db *gorm.DB) NoteRepository {
+	return &noteRepositoryGorm{db: db}
+}
+
+func (r *noteRepositoryGorm) Create(ctx context.Context, note *domain.Note) error {
+	return r.db.WithContext(ctx).Create(note).Error
+}
+
+func (r *noteRepositoryGorm) Update(ctx context.Context, note *domain.Note) error {
+	result := r.db.WithContext(ctx).Model(note).
+		Where("status = ?", domain.NoteStatusOutstanding).
+		Select("status", "discounted_date", "discount_charge", "settled_date", "bank_account_id",
+			"voucher_id", "discount_voucher_id", "settlement_voucher_id", "updated_by").
+		Updates(note)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNoteNotOutstanding
+	}
+	return nil
+}
+
+func (r *noteRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Note, error) {
+	var note domain.Note
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&note).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrNoteNotFound
+		}
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (r *noteRepositoryGorm) ExistsNoteNo(ctx context.Context, companyID uuid.UUID, direction domain.NoteDirection, noteNo string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.Note{}).
+		Where("company_id = ? AND direction = ? AND note_no = ?", companyID, direction, noteNo).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *noteRepositoryGorm) List(ctx context.Context, filter NoteFilter) ([]domain.Note, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Note{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Direction != nil {
+		query = query.Where("direction = ?", *filter.Direction)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.PartnerID != nil {
+		query = query.Where("partner_id = ?", *filter.PartnerID)
+	}
+	if filter.MaturityTo != nil {
+		query = query.Where("maturity_date <= ?", *filter.MaturityTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notes []domain.Note
+	err := query.
+		Order("maturity_date ASC, note_no ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&notes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notes, total, nil
+}
+
+func (r *noteRepositoryGorm) ListForMaturityAlert(ctx context.Context, maturityBy time.Time) ([]domain.Note, error) {
+	var notes []domain.Note
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND maturity_alerted_at IS NULL AND maturity_date <= ?", domain.NoteStatusOutstanding, maturityBy).
+		Order("company_id, maturity_date ASC, note_no ASC").
+		Find(&notes).Error
+	return notes, err
+}
+
+func (r *noteRepositoryGorm) MarkMaturityAlerted(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&domain.Note{}).
+		Where("id IN ?", ids).
+		Update("maturity_alerted_at", at).Error
+}
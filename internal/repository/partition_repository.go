@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+)
+
+// PartitionRepository defines the interface for maintaining the fiscal year
+// partitions of voucher_entries
+type PartitionRepository interface {
+	// VoucherEntryPartitionYears returns the fiscal years that have a partition
+	VoucherEntryPartitionYears(ctx context.Context) ([]int, error)
+
+	// DefaultVoucherEntryYears returns the fiscal years of entries that fell into
+	// the default partition because their year had no partition yet
+	DefaultVoucherEntryYears(ctx context.Context) ([]int, error)
+
+	// CreateVoucherEntryPartition creates the partition of the fiscal year, split
+	// into hashPartitions partitions by company when it is greater than 1, and moves
+	// the year's entries out of the default partition. It returns false if the
+	// partition already exists.
+	CreateVoucherEntryPartition(ctx context.Context, year, hashPartitions int) (bool, error)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type partitionRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPartitionRepository creates a new partition maintenance repository
+func NewPartitionRepository(db *gorm.DB) PartitionRepository {
+	return &partitionRepositoryGorm{db: db}
+}
+
+func (r *partitionRepositoryGorm) VoucherEntryPartitionYears(ctx context.Context) ([]int, error) {
+	var years []int
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT substring(c.relname FROM 'voucher_entries_y([0-9]{4})$')::INTEGER AS fiscal_year
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'voucher_entries'::regclass
+			AND c.relname ~ '^voucher_entries_y[0-9]{4}$'
+		ORDER BY fiscal_year
+	`).Scan(&years).Error
+	return years, err
+}
+
+func (r *partitionRepositoryGorm) DefaultVoucherEntryYears(ctx context.Context) ([]int, error) {
+	var years []int
+	err := r.db.WithContext(ctx).
+		Raw("SELECT DISTINCT fiscal_year FROM voucher_entries_default ORDER BY fiscal_year").
+		Scan(&years).Error
+	return years, err
+}
+
+func (r *partitionRepositoryGorm) CreateVoucherEntryPartition(ctx context.Context, year, hashPartitions int) (bool, error) {
+	var created bool
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).
+		Raw("SELECT create_voucher_entries_partition(?, ?)", year, hashPartitions).
+		Scan(&created).Error
+	return created, err
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -10,12 +11,15 @@ import (
 
 // PartnerFilter defines filter criteria for listing partners
 type PartnerFilter struct {
-	CompanyID   uuid.UUID
-	PartnerType string // "customer", "vendor", "both", or empty for all
-	IsActive    *bool
-	SearchTerm  string // Search in code, name, business_number
-	Page        int
-	PageSize    int
+	CompanyID      uuid.UUID
+	PartnerType    string // "customer", "vendor", "both", or empty for all
+	IsActive       *bool
+	SearchTerm     string                // Search in code, name, business_number
+	BusinessStatus domain.BusinessStatus // NTS registration status, e.g. "closed"
+	IDs            []uuid.UUID           // only these partners
+	Codes          []string              // only the partners of these codes
+	Page           int
+	PageSize       int
 }
 
 // PartnerRepository defines the interface for partner data access
@@ -34,11 +38,29 @@ type PartnerRepository interface {
 	ExistsByBusinessNumber(ctx context.Context, companyID uuid.UUID, businessNumber string, excludeID *uuid.UUID) (bool, error)
 	HasVoucherEntries(ctx context.Context, companyID, partnerID uuid.UUID) (bool, error)
 	HasTaxInvoices(ctx context.Context, companyID, partnerID uuid.UUID) (bool, error)
+	// EntryFiscalYears returns the fiscal years the voucher entries of the partner fall in
+	EntryFiscalYears(ctx context.Context, companyID, partnerID uuid.UUID) ([]int, error)
+
+	// MaxCode returns the highest code made of the prefix and digits digits,
+	// deleted partners included, or "" if there is none
+	MaxCode(ctx context.Context, companyID uuid.UUID, prefix string, digits int) (string, error)
+
+	// Merge re-points the voucher entries, invoices, loans and grants of the
+	// source to the target, saves the target and deletes the source, counting
+	// the rows in merge. The entry is stored with the merge as its new values,
+	// all in one transaction.
+	Merge(ctx context.Context, merge *domain.PartnerMerge, source, target *domain.Partner, entry *domain.AuditLog) error
 
 	// Batch operations
 	CreateBatch(ctx context.Context, partners []domain.Partner) error
 	UpdateActiveStatus(ctx context.Context, companyID uuid.UUID, ids []uuid.UUID, isActive bool) error
 
+	// Business number verification
+	// ListForBusinessVerification lists active partners with a business number not
+	// verified since verifiedBefore, across all companies, least recently verified first
+	ListForBusinessVerification(ctx context.Context, verifiedBefore time.Time, limit int) ([]domain.Partner, error)
+	UpdateBusinessVerification(ctx context.Context, partner *domain.Partner) error
+
 	// Statistics
 	GetCustomerCount(ctx context.Context, companyID uuid.UUID) (int64, error)
 	GetVendorCount(ctx context.Context, companyID uuid.UUID) (int64, error)
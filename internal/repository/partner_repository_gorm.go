@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -86,6 +89,15 @@ func (r *partnerRepositoryGorm) List(ctx context.Context, filter *PartnerFilter)
 	if filter.IsActive != nil {
 		query = query.Where("is_active = ?", *filter.IsActive)
 	}
+	if filter.BusinessStatus != "" {
+		query = query.Where("business_status = ?", filter.BusinessStatus)
+	}
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if len(filter.Codes) > 0 {
+		query = query.Where("code IN ?", filter.Codes)
+	}
 	if filter.SearchTerm != "" {
 		searchPattern := "%" + filter.SearchTerm + "%"
 		query = query.Where("code ILIKE ? OR name ILIKE ? OR business_number ILIKE ?",
@@ -189,6 +201,83 @@ func (r *partnerRepositoryGorm) HasTaxInvoices(ctx context.Context, companyID, p
 	return count > 0, nil
 }
 
+// EntryFiscalYears returns the fiscal years the voucher entries of the partner fall in
+func (r *partnerRepositoryGorm) EntryFiscalYears(ctx context.Context, companyID, partnerID uuid.UUID) ([]int, error) {
+	var years []int
+	err := r.db.WithContext(ctx).Model(&domain.VoucherEntry{}).
+		Where("company_id = ? AND partner_id = ?", companyID, partnerID).
+		Distinct().
+		Order("fiscal_year").
+		Pluck("fiscal_year", &years).Error
+	return years, err
+}
+
+// MaxCode returns the highest code made of the prefix and digits digits. The
+// codes of deleted partners count, so that they are not handed out again.
+func (r *partnerRepositoryGorm) MaxCode(ctx context.Context, companyID uuid.UUID, prefix string, digits int) (string, error) {
+	var codes []string
+	err := r.db.WithContext(ctx).Unscoped().Model(&domain.Partner{}).
+		Where("company_id = ? AND code ~ ?", companyID, fmt.Sprintf("^%s[0-9]{%d}$", regexp.QuoteMeta(prefix), digits)).
+		Order("code DESC").
+		Limit(1).
+		Pluck("code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+// partnerMergeTables returns the tables whose rows a merge moves from the
+// source partner to the target, with the count of rows moved in each
+func partnerMergeTables(merge *domain.PartnerMerge) map[string]*int64 {
+	return map[string]*int64{
+		"voucher_entries":     &merge.VoucherEntries,
+		"invoices":            &merge.Invoices,
+		"loans":               &merge.Loans,
+		"grants":              &merge.Grants,
+		"payment_batch_items": &merge.PaymentBatchItems,
+		"notes":               &merge.Notes,
+		"contracts":           &merge.Contracts,
+		"revenue_schedules":   &merge.RevenueSchedules,
+		"prepaid_expenses":    &merge.PrepaidExpenses,
+	}
+}
+
+// Merge consolidates the source partner into the target in one transaction
+func (r *partnerRepositoryGorm) Merge(ctx context.Context, merge *domain.PartnerMerge, source, target *domain.Partner, entry *domain.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for table, count := range partnerMergeTables(merge) {
+			result := tx.Table(table).
+				Where("company_id = ? AND partner_id = ?", source.CompanyID, source.ID).
+				Update("partner_id", target.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			*count = result.RowsAffected
+		}
+
+		// The business number is unique, deleted partners included
+		if merge.BusinessNumberMoved {
+			if err := tx.Table("partners").Where("id = ?", source.ID).Update("business_number", gorm.Expr("NULL")).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(target).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(source).Error; err != nil {
+			return err
+		}
+
+		values, err := json.Marshal(merge)
+		if err != nil {
+			return err
+		}
+		entry.NewValues = values
+		return tx.Create(entry).Error
+	})
+}
+
 // CreateBatch creates multiple partners
 func (r *partnerRepositoryGorm) CreateBatch(ctx context.Context, partners []domain.Partner) error {
 	return r.db.WithContext(ctx).Create(&partners).Error
@@ -218,3 +307,23 @@ func (r *partnerRepositoryGorm) GetVendorCount(ctx context.Context, companyID uu
 		Count(&count).Error
 	return count, err
 }
+
+// ListForBusinessVerification retrieves partners due for business number verification
+func (r *partnerRepositoryGorm) ListForBusinessVerification(ctx context.Context, verifiedBefore time.Time, limit int) ([]domain.Partner, error) {
+	var partners []domain.Partner
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND business_number <> ''", true).
+		Where("business_verified_at IS NULL OR business_verified_at < ?", verifiedBefore).
+		Order("business_verified_at ASC NULLS FIRST").
+		Limit(limit).
+		Find(&partners).Error
+	return partners, err
+}
+
+// UpdateBusinessVerification stores the verification fields of a partner
+func (r *partnerRepositoryGorm) UpdateBusinessVerification(ctx context.Context, partner *domain.Partner) error {
+	return r.db.WithContext(ctx).
+		Model(partner).
+		Select("business_status", "business_tax_type", "business_closed_on", "representative_verified", "business_verified_at").
+		Updates(partner).Error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// A merge moves every table naming the partner, so that no row is left
+// pointing at the deleted source
+func TestPartnerMergeTables(t *testing.T) {
+	var tables []string
+	for table := range partnerMergeTables(&domain.PartnerMerge{}) {
+		tables = append(tables, table)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"voucher_entries", "invoices", "loans", "grants", "payment_batch_items",
+		"notes",
+		"contracts",
+		"revenue_schedules",
+		"prepaid_expenses",
+	}, tables)
+}
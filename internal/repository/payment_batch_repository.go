@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PaymentBatchFilter defines filter options for payment batches
+type PaymentBatchFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.PaymentBatchStatus
+	Page      int
+	PageSize  int
+}
+
+// PaymentBatchRepository defines the interface for payment batch persistence
+type PaymentBatchRepository interface {
+	// FindPayables returns the credit entries of approved or posted vouchers on
+	// the AP accounts of the partners, less what payment batches transfer, and
+	// the payable balance of each partner less what is in payment. No partner
+	// IDs means all partners.
+	FindPayables(ctx context.Context, companyID uuid.UUID, partnerIDs []uuid.UUID) ([]domain.PayableItem, map[uuid.UUID]float64, error)
+	// NextBatchNo returns the next batch number of the transfer date
+	NextBatchNo(ctx context.Context, companyID uuid.UUID, date time.Time) (string, error)
+
+	// Create stores the batch with its items. It fails with
+	// domain.ErrPayablesChanged when another batch took the same payables.
+	Create(ctx context.Context, batch *domain.PaymentBatch) error
+	// FindByID returns the batch with its items in file order
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PaymentBatch, error)
+	List(ctx context.Context, filter PaymentBatchFilter) ([]domain.PaymentBatch, int64, error)
+	// UpdateStatus stores the status and outcome of the batch and its items
+	UpdateStatus(ctx context.Context, batch *domain.PaymentBatch) error
+	// LinkVoucher stores the payment voucher of an executed batch
+	LinkVoucher(ctx context.Context, batch *domain.PaymentBatch) error
+
+	// WithTransaction executes a function within a transaction
+	WithTransaction(ctx context.Context, fn func(repo PaymentBatchRepository) error) error
+}
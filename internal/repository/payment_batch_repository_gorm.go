@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// paymentBatchRepositoryGorm implements PaymentBatchRepository using GORM
+type paymentBatchRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPaymentBatchRepository creates a new GORM-based payment batch repository
+func NewPaymentBatchRepository(db *gorm.DB) PaymentBatchRepository {
+	return &paymentBatchRepositoryGorm{db: db}
+}
+
+// payableVoucherStatuses are the statuses of vouchers whose payables are due
+var payableVoucherStatuses = []domain.VoucherStatus{domain.VoucherStatusApproved, domain.VoucherStatusPosted}
+
+// transferringItemStatuses are the statuses of batch items that take their payable
+var transferringItemStatuses = []domain.PaymentItemStatus{domain.PaymentItemInPayment, domain.PaymentItemPaid}
+
+func (r *paymentBatchRepositoryGorm) FindPayables(ctx context.Context, companyID uuid.UUID, partnerIDs []uuid.UUID) ([]domain.PayableItem, map[uuid.UUID]float64, error) {
+	partnerFilter := ""
+	args := []interface{}{companyID, transferringItemStatuses, companyID, payableVoucherStatuses}
+	if len(partnerIDs) > 0 {
+		partnerFilter = "AND ve.partner_id IN ?"
+		args = append(args, partnerIDs)
+	}
+
+	// Fully reversed vouchers owe nothing any more
+	query := fmt.Sprintf(`
+		SELECT
+			ve.id AS entry_id,
+			v.id AS voucher_id,
+			v.voucher_no,
+			v.voucher_date,
+			COALESCE(NULLIF(ve.description, ''), v.description, '') AS description,
+			ve.account_id,
+			ve.credit_amount - COALESCE(t.amount, 0) AS amount,
+			p.id AS partner_id,
+			p.code AS partner_code,
+			p.name AS partner_name,
+			COALESCE(p.payment_term_days, 0) AS payment_term_days,
+			COALESCE(p.bank_code, '') AS bank_code,
+			COALESCE(p.bank_account_number, '') AS bank_account_number,
+			COALESCE(p.bank_account_holder, '') AS bank_account_holder
+		FROM voucher_entries ve
+		JOIN vouchers v ON v.id = ve.voucher_id
+		JOIN partners p ON p.id = ve.partner_id AND p.ap_account_id = ve.account_id
+		LEFT JOIN (
+			SELECT entry_id, SUM(amount) AS amount
+			FROM payment_batch_items
+			WHERE company_id = ? AND status IN ?
+			GROUP BY entry_id
+		) t ON t.entry_id = ve.id
+		WHERE ve.company_id = ? AND v.status IN ?
+			AND ve.credit_amount > 0
+			AND v.is_reversal = false AND v.reversed_by_id IS NULL
+			AND ve.credit_amount - COALESCE(t.amount, 0) > 0
+			%s
+		ORDER BY v.voucher_date, v.voucher_no, ve.line_no
+	`, partnerFilter)
+
+	var items []domain.PayableItem
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	balances, err := r.payableBalances(ctx, companyID, partnerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, balances, nil
+}
+
+// payableBalances returns the AP balance of each partner in approved or posted
+// vouchers less the items in payment and the paid items whose payment voucher
+// is not approved yet
+func (r *paymentBatchRepositoryGorm) payableBalances(ctx context.Context, companyID uuid.UUID, partnerIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	type partnerAmount struct {
+		PartnerID uuid.UUID
+		Amount    float64
+	}
+
+	partnerFilter := ""
+	args := []interface{}{companyID, payableVoucherStatuses}
+	if len(partnerIDs) > 0 {
+		partnerFilter = "AND ve.partner_id IN ?"
+		args = append(args, partnerIDs)
+	}
+	query := fmt.Sprintf(`
+		SELECT ve.partner_id, SUM(ve.credit_amount - ve.debit_amount) AS amount
+		FROM voucher_entries ve
+		JOIN vouchers v ON v.id = ve.voucher_id
+		JOIN partners p ON p.id = ve.partner_id AND p.ap_account_id = ve.account_id
+		WHERE ve.company_id = ? AND v.status IN ? %s
+		GROUP BY ve.partner_id
+	`, partnerFilter)
+
+	var booked []partnerAmount
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&booked).Error; err != nil {
+		return nil, err
+	}
+
+	pendingFilter := ""
+	args = []interface{}{companyID, domain.PaymentItemInPayment, domain.PaymentItemPaid, payableVoucherStatuses}
+	if len(partnerIDs) > 0 {
+		pendingFilter = "AND i.partner_id IN ?"
+		args = append(args, partnerIDs)
+	}
+	query = fmt.Sprintf(`
+		SELECT i.partner_id, SUM(i.amount) AS amount
+		FROM payment_batch_items i
+		JOIN payment_batches b ON b.id = i.batch_id
+		LEFT JOIN vouchers v ON v.id = b.voucher_id
+		WHERE i.company_id = ?
+			AND (i.status = ? OR (i.status = ? AND (v.id IS NULL OR v.status NOT IN ?)))
+			%s
+		GROUP BY i.partner_id
+	`, pendingFilter)
+
+	var pending []partnerAmount
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&pending).Error; err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uuid.UUID]float64, len(booked))
+	for _, b := range booked {
+		balances[b.PartnerID] += b.Amount
+	}
+	for _, p := range pending {
+		balances[p.PartnerID] -= p.Amount
+	}
+	return balances, nil
+}
+
+func (r *paymentBatchRepositoryGorm) NextBatchNo(ctx context.Context, companyID uuid.UUID, date time.Time) (string, error) {
+	prefix := "PB-" + date.Format("20060102") + "-"
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.PaymentBatch{}).
+		Where("company_id = ? AND batch_no LIKE ?", companyID, prefix+"%").
+		Count(&count).Error
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%03d", prefix, count+1), nil
+}
+
+func (r *paymentBatchRepositoryGorm) Create(ctx context.Context, batch *domain.PaymentBatch) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the company serializes the batches taking its payables
+		var ids []uuid.UUID
+		err := tx.Model(&domain.Company{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", batch.CompanyID).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Items").Create(batch).Error; err != nil {
+			return err
+		}
+		entryIDs := make([]uuid.UUID, len(batch.Items))
+		for i := range batch.Items {
+			batch.Items[i].BatchID = batch.ID
+			batch.Items[i].CompanyID = batch.CompanyID
+			entryIDs[i] = batch.Items[i].EntryID
+		}
+		if len(batch.Items) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(batch.Items, 100).Error; err != nil {
+			return err
+		}
+
+		// Batches created since the payables were read may have taken them
+		var overdrawn int64
+		err = tx.Raw(`
+			SELECT COUNT(*) FROM (
+				SELECT i.entry_id
+				FROM payment_batch_items i
+				JOIN voucher_entries ve ON ve.id = i.entry_id
+				WHERE i.company_id = ? AND i.status IN ? AND i.entry_id IN ?
+				GROUP BY i.entry_id
+				HAVING SUM(i.amount) > MAX(ve.credit_amount)
+			) overdrawn
+		`, batch.CompanyID, transferringItemStatuses, entryIDs).Scan(&overdrawn).Error
+		if err != nil {
+			return err
+		}
+		if overdrawn > 0 {
+			return domain.ErrPayablesChanged
+		}
+		return nil
+	})
+}
+
+func (r *paymentBatchRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PaymentBatch, error) {
+	var batch domain.PaymentBatch
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("seq ASC") }).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&batch).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPaymentBatchNotFound
+		}
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *paymentBatchRepositoryGorm) List(ctx context.Context, filter PaymentBatchFilter) ([]domain.PaymentBatch, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.PaymentBatch{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var batches []domain.PaymentBatch
+	err := query.
+		Order("transfer_date DESC, batch_no DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&batches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return batches, total, nil
+}
+
+func (r *paymentBatchRepositoryGorm) UpdateStatus(ctx context.Context, batch *domain.PaymentBatch) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only a generated batch changes, once
+		result := tx.Model(batch).
+			Where("status = ?", domain.PaymentBatchGenerated).
+			Select("status", "executed_at", "paid_count", "paid_amount", "voucher_id", "updated_by").
+			Updates(batch)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPaymentBatchNotOpen
+		}
+		for i := range batch.Items {
+			item := &batch.Items[i]
+			if err := tx.Model(item).Update("status", item.Status).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *paymentBatchRepositoryGorm) LinkVoucher(ctx context.Context, batch *domain.PaymentBatch) error {
+	return r.db.WithContext(ctx).Model(&domain.PaymentBatch{}).
+		Where("id = ? AND status = ?", batch.ID, domain.PaymentBatchExecuted).
+		Update("voucher_id", batch.VoucherID).Error
+}
+
+// WithTransaction executes a function within a transaction
+func (r *paymentBatchRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo PaymentBatchRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&paymentBatchRepositoryGorm{db: tx})
+	})
+}
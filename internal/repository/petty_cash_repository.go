@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PettyCashClaimFilter defines filter options for petty cash claims
+type PettyCashClaimFilter struct {
+	CompanyID uuid.UUID
+	FundID    uuid.UUID
+	Status    *domain.PettyCashClaimStatus
+	From      *time.Time
+	To        *time.Time
+	Page      int
+	PageSize  int
+}
+
+// PettyCashRepository defines the interface for petty cash persistence
+type PettyCashRepository interface {
+	CreateFund(ctx context.Context, fund *domain.PettyCashFund) error
+	// UpdateFund stores the name, custodian, bank account and activity of the fund
+	UpdateFund(ctx context.Context, fund *domain.PettyCashFund) error
+	FindFund(ctx context.Context, companyID, id uuid.UUID) (*domain.PettyCashFund, error)
+	ListFunds(ctx context.Context, companyID uuid.UUID, departmentID *uuid.UUID) ([]domain.PettyCashFund, error)
+	ExistsFundName(ctx context.Context, companyID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error)
+
+	// CreateClaim stores a claim paid from the fund. It fails with
+	// domain.ErrPettyCashInsufficient when the pending claims would exceed
+	// the float.
+	CreateClaim(ctx context.Context, claim *domain.PettyCashTransaction) error
+	FindClaim(ctx context.Context, companyID, id uuid.UUID) (*domain.PettyCashTransaction, error)
+	// DeleteClaim deletes a pending claim. It fails with
+	// domain.ErrPettyCashClaimReplenished when the claim was replenished.
+	DeleteClaim(ctx context.Context, companyID, id uuid.UUID) error
+	ListClaims(ctx context.Context, filter PettyCashClaimFilter) ([]domain.PettyCashTransaction, int64, error)
+	// PendingClaims returns the claims of the fund not replenished yet in date order
+	PendingClaims(ctx context.Context, companyID, fundID uuid.UUID) ([]domain.PettyCashTransaction, error)
+
+	// CreateReplenishment stores the replenishment and marks its claims
+	// replenished. It fails with domain.ErrPettyCashClaimsChanged when a claim
+	// was replenished or deleted meanwhile.
+	CreateReplenishment(ctx context.Context, replenishment *domain.PettyCashReplenishment) error
+	// ListReplenishments returns the replenishments of the fund, latest first
+	ListReplenishments(ctx context.Context, companyID, fundID uuid.UUID) ([]domain.PettyCashReplenishment, error)
+}
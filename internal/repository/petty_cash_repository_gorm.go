@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// pettyCashRepositoryGorm implements PettyCashRepository using GORM
+type pettyCashRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPettyCashRepository creates a new GORM-based petty cash repository
+func NewPettyCashRepository(db *gorm.DB) PettyCashRepository {
+	return &pettyCashRepositoryGorm{db: db}
+}
+
+func (r *pettyCashRepositoryGorm) CreateFund(ctx context.Context, fund *domain.PettyCashFund) error {
+	return r.db.WithContext(ctx).Create(fund).Error
+}
+
+func (r *pettyCashRepositoryGorm) UpdateFund(ctx context.Context, fund *domain.PettyCashFund) error {
+	return r.db.WithContext(ctx).Model(fund).
+		Select("name", "custodian_id", "bank_account_id", "is_active").
+		Updates(fund).Error
+}
+
+func (r *pettyCashRepositoryGorm) FindFund(ctx context.Context, companyID, id uuid.UUID) (*domain.PettyCashFund, error) {
+	var fund domain.PettyCashFund
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&fund).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPettyCashFundNotFound
+		}
+		return nil, err
+	}
+	return &fund, nil
+}
+
+func (r *pettyCashRepositoryGorm) ListFunds(ctx context.Context, companyID uuid.UUID, departmentID *uuid.UUID) ([]domain.PettyCashFund, error) {
+	query := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+	if departmentID != nil {
+		query = query.Where("department_id = ?", *departmentID)
+	}
+	var funds []domain.PettyCashFund
+	err := query.Order("name ASC").Find(&funds).Error
+	return funds, err
+}
+
+func (r *pettyCashRepositoryGorm) ExistsFundName(ctx context.Context, companyID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.PettyCashFund{}).
+		Where("company_id = ? AND name = ?", companyID, name)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *pettyCashRepositoryGorm) CreateClaim(ctx context.Context, claim *domain.PettyCashTransaction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the fund serializes the claims paid from its cash
+		var fund domain.PettyCashFund
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND id = ?", claim.CompanyID, claim.FundID).
+			First(&fund).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return domain.ErrPettyCashFundNotFound
+			}
+			return err
+		}
+
+		var pending int64
+		err = tx.Model(&domain.PettyCashTransaction{}).
+			Select("COALESCE(SUM(amount), 0)").
+			Where("fund_id = ? AND status = ?", fund.ID, domain.PettyCashClaimPending).
+			Scan(&pending).Error
+		if err != nil {
+			return err
+		}
+		if pending+claim.Amount > fund.FloatAmount {
+			return domain.ErrPettyCashInsufficient
+		}
+		return tx.Create(claim).Error
+	})
+}
+
+func (r *pettyCashRepositoryGorm) FindClaim(ctx context.Context, companyID, id uuid.UUID) (*domain.PettyCashTransaction, error) {
+	var claim domain.PettyCashTransaction
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&claim).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPettyCashClaimNotFound
+		}
+		return nil, err
+	}
+	return &claim, nil
+}
+
+func (r *pettyCashRepositoryGorm) DeleteClaim(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ? AND status = ?", companyID, id, domain.PettyCashClaimPending).
+		Delete(&domain.PettyCashTransaction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPettyCashClaimReplenished
+	}
+	return nil
+}
+
+func (r *pettyCashRepositoryGorm) ListClaims(ctx context.Context, filter PettyCashClaimFilter) ([]domain.PettyCashTransaction, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.PettyCashTransaction{}).
+		Where("company_id = ? AND fund_id = ?", filter.CompanyID, filter.FundID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("transaction_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transaction_date <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var claims []domain.PettyCashTransaction
+	err := query.
+		Order("transaction_date DESC, created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&claims).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return claims, total, nil
+}
+
+func (r *pettyCashRepositoryGorm) PendingClaims(ctx context.Context, companyID, fundID uuid.UUID) ([]domain.PettyCashTransaction, error) {
+	var claims []domain.PettyCashTransaction
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND fund_id = ? AND status = ?", companyID, fundID, domain.PettyCashClaimPending).
+		Order("transaction_date ASC, created_at ASC").
+		Find(&claims).Error
+	return claims, err
+}
+
+func (r *pettyCashRepositoryGorm) CreateReplenishment(ctx context.Context, replenishment *domain.PettyCashReplenishment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Claims").Create(replenishment).Error; err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(replenishment.Claims))
+		for i, claim := range replenishment.Claims {
+			ids[i] = claim.ID
+		}
+		result := tx.Model(&domain.PettyCashTransaction{}).
+			Where("fund_id = ? AND id IN ? AND status = ?", replenishment.FundID, ids, domain.PettyCashClaimPending).
+			Updates(map[string]interface{}{
+				"status":           domain.PettyCashClaimReplenished,
+				"replenishment_id": replenishment.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return domain.ErrPettyCashClaimsChanged
+		}
+		for i := range replenishment.Claims {
+			replenishment.Claims[i].Status = domain.PettyCashClaimReplenished
+			replenishment.Claims[i].ReplenishmentID = &replenishment.ID
+		}
+		return nil
+	})
+}
+
+func (r *pettyCashRepositoryGorm) ListReplenishments(ctx context.Context, companyID, fundID uuid.UUID) ([]domain.PettyCashReplenishment, error) {
+	var replenishments []domain.PettyCashReplenishment
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND fund_id = ?", companyID, fundID).
+		Order("request_date DESC, created_at DESC").
+		Find(&replenishments).Error
+	return replenishments, err
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PlanRepository defines the interface for subscription plans and the usage
+// they limit
+type PlanRepository interface {
+	// FindAll returns the active plans in display order
+	FindAll(ctx context.Context) ([]domain.Plan, error)
+	FindByCode(ctx context.Context, code string) (*domain.Plan, error)
+
+	// GetCompanyPlan returns the company's plan and, after a plan change, its previous plan
+	GetCompanyPlan(ctx context.Context, companyID uuid.UUID) (*domain.CompanyPlan, error)
+
+	// Usage of the limited resources
+	CountActiveUsers(ctx context.Context, companyID uuid.UUID) (int64, error)
+	CountVouchersSince(ctx context.Context, companyID uuid.UUID, since time.Time) (int64, error)
+	StorageBytes(ctx context.Context, companyID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// planRepositoryGorm implements PlanRepository using GORM
+type planRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPlanRepository creates a new GORM-based plan repository
+func NewPlanRepository(db *gorm.DB) PlanRepository {
+	return &planRepositoryGorm{db: db}
+}
+
+func (r *planRepositoryGorm) FindAll(ctx context.Context) ([]domain.Plan, error) {
+	var plans []domain.Plan
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("sort_order ASC, code ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+func (r *planRepositoryGorm) FindByCode(ctx context.Context, code string) (*domain.Plan, error) {
+	var plan domain.Plan
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&plan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *planRepositoryGorm) GetCompanyPlan(ctx context.Context, companyID uuid.UUID) (*domain.CompanyPlan, error) {
+	var row struct {
+		PlanCode         string
+		PreviousPlanCode *string
+		PlanChangedAt    *time.Time
+	}
+	err := r.db.WithContext(ctx).
+		Table("companies").
+		Select("plan_code, previous_plan_code, plan_changed_at").
+		Where("id = ?", companyID).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCompanyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := r.FindByCode(ctx, row.PlanCode)
+	if err != nil {
+		return nil, err
+	}
+	companyPlan := &domain.CompanyPlan{Plan: plan, PlanChangedAt: row.PlanChangedAt}
+	if row.PreviousPlanCode != nil {
+		previous, err := r.FindByCode(ctx, *row.PreviousPlanCode)
+		if err != nil && !errors.Is(err, domain.ErrPlanNotFound) {
+			return nil, err
+		}
+		companyPlan.PreviousPlan = previous
+	}
+	return companyPlan, nil
+}
+
+func (r *planRepositoryGorm) CountActiveUsers(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("user_company_memberships m").
+		Joins("JOIN users u ON u.id = m.user_id").
+		Where("m.company_id = ? AND m.is_active AND u.deleted_at IS NULL AND u.status = ?", companyID, domain.UserStatusActive).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *planRepositoryGorm) CountVouchersSince(ctx context.Context, companyID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.UsageEvent{}).
+		Where("company_id = ? AND metric = ? AND occurred_at >= ?", companyID, domain.UsageMetricVouchers, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *planRepositoryGorm) StorageBytes(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	var attachments, documents int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.VoucherAttachment{}).
+		Where("company_id = ?", companyID).
+		Select("COALESCE(SUM(file_size), 0)").
+		Scan(&attachments).Error
+	if err != nil {
+		return 0, err
+	}
+	// Every version of a library file stays stored
+	err = r.db.WithContext(ctx).
+		Model(&domain.DocumentVersion{}).
+		Where("company_id = ?", companyID).
+		Select("COALESCE(SUM(file_size), 0)").
+		Scan(&documents).Error
+	return attachments + documents, err
+}
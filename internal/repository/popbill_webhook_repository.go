@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PopbillWebhookFilter defines filter options for received Popbill callbacks
+type PopbillWebhookFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.PopbillWebhookStatus
+	ItemKey   string
+	Page      int
+	PageSize  int
+}
+
+// PopbillWebhookRepository defines the interface for received Popbill callback access
+type PopbillWebhookRepository interface {
+	// Create stores a received event; it returns ErrPopbillWebhookDuplicate if an
+	// event with the same key was already stored
+	Create(ctx context.Context, event *domain.PopbillWebhookEvent) error
+
+	// FindAll lists the events applied to the company's invoices, newest first
+	FindAll(ctx context.Context, filter PopbillWebhookFilter) ([]domain.PopbillWebhookEvent, int64, error)
+
+	// Worker operations (across all companies)
+	// ClaimNext marks the oldest received event, one that failed before retryBefore
+	// with attempts left, or one left processing since before staleBefore by a
+	// crashed worker, as processing. It returns nil when there is none.
+	ClaimNext(ctx context.Context, retryBefore, staleBefore time.Time) (*domain.PopbillWebhookEvent, error)
+	Finish(ctx context.Context, event *domain.PopbillWebhookEvent) error
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// popbillWebhookRepositoryGorm implements PopbillWebhookRepository using GORM
+type popbillWebhookRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPopbillWebhookRepository creates a new GORM-based Popbill webhook repository
+func NewPopbillWebhookRepository(db *gorm.DB) PopbillWebhookRepository {
+	return &popbillWebhookRepositoryGorm{db: db}
+}
+
+func (r *popbillWebhookRepositoryGorm) Create(ctx context.Context, event *domain.PopbillWebhookEvent) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_key"}}, DoNothing: true}).
+		Create(event)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPopbillWebhookDuplicate
+	}
+	return nil
+}
+
+func (r *popbillWebhookRepositoryGorm) FindAll(ctx context.Context, filter PopbillWebhookFilter) ([]domain.PopbillWebhookEvent, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.PopbillWebhookEvent{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.ItemKey != "" {
+		query = query.Where("item_key = ?", filter.ItemKey)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []domain.PopbillWebhookEvent
+	err := query.
+		Order("received_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&events).Error
+	return events, total, err
+}
+
+func (r *popbillWebhookRepositoryGorm) ClaimNext(ctx context.Context, retryBefore, staleBefore time.Time) (*domain.PopbillWebhookEvent, error) {
+	var event domain.PopbillWebhookEvent
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers claim different events
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND attempts < ? AND processed_at < ?) OR (status = ? AND started_at < ?)",
+				domain.PopbillWebhookStatusReceived,
+				domain.PopbillWebhookStatusFailed, domain.PopbillWebhookMaxAttempts, retryBefore,
+				domain.PopbillWebhookStatusProcessing, staleBefore).
+			Order("received_at ASC").
+			First(&event).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		event.Status = domain.PopbillWebhookStatusProcessing
+		event.StartedAt = &now
+		event.Attempts++
+		return tx.Model(&event).Select("status", "started_at", "attempts").Updates(&event).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *popbillWebhookRepositoryGorm) Finish(ctx context.Context, event *domain.PopbillWebhookEvent) error {
+	return r.db.WithContext(ctx).
+		Model(event).
+		Select("company_id", "tax_invoice_id", "status", "error", "processed_at").
+		Updates(event).Error
+}
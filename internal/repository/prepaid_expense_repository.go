@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PrepaidExpenseFilter defines filter options for prepaid expenses
+type PrepaidExpenseFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.PrepaidExpenseStatus
+	PartnerID *uuid.UUID
+	AccountID *uuid.UUID // prepaid account
+	Page      int
+	PageSize  int
+}
+
+// PrepaidExpenseRepository defines the interface for prepaid expense persistence
+type PrepaidExpenseRepository interface {
+	// Create stores the prepaid expense with its monthly lines
+	Create(ctx context.Context, expense *domain.PrepaidExpense) error
+	// FindByID returns the prepaid expense with its lines in month order
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PrepaidExpense, error)
+	ExistsForVoucher(ctx context.Context, companyID, voucherID, expenseAccountID uuid.UUID) (bool, error)
+	List(ctx context.Context, filter PrepaidExpenseFilter) ([]domain.PrepaidExpense, int64, error)
+	// ListWithLines returns the prepaid expenses of the company with their
+	// lines, of one prepaid account when accountID is set, for the balance
+	// report
+	ListWithLines(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID) ([]domain.PrepaidExpense, error)
+	UpdatePrepayment(ctx context.Context, expense *domain.PrepaidExpense) error
+
+	// ListForAmortization returns the active prepaid expenses with pending
+	// lines of months ending on or before periodEnd, with their lines, of one
+	// company or of all when companyID is nil
+	ListForAmortization(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.PrepaidExpense, error)
+	// RecordAmortization stores the posted line and the amortized amount and
+	// status of the prepaid expense. It fails with
+	// domain.ErrPrepaidExpenseLineChanged when the line was posted meanwhile.
+	RecordAmortization(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine) error
+	// ReleaseAmortization returns a line recorded without its voucher to
+	// pending and takes it off the amortized amount, when the voucher failed
+	// to post
+	ReleaseAmortization(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine) error
+	// LinkAmortizationVoucher stores the voucher of a posted line
+	LinkAmortizationVoucher(ctx context.Context, line *domain.PrepaidExpenseLine) error
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// prepaidExpenseRepositoryGorm implements PrepaidExpenseRepository using GORM
+type prepaidExpenseRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPrepaidExpenseRepository creates a new GORM-based prepaid expense repository
+func NewPrepaidExpenseRepository(db *gorm.DB) PrepaidExpenseRepository {
+	return &prepaidExpenseRepositoryGorm{db: db}
+}
+
+func (r *prepaidExpenseRepositoryGorm) Create(ctx context.Context, expense *domain.PrepaidExpense) error {
+	return r.db.WithContext(ctx).Create(expense).Error
+}
+
+func (r *prepaidExpenseRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PrepaidExpense, error) {
+	var expense domain.PrepaidExpense
+	err := r.withLines(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&expense).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPrepaidExpenseNotFound
+		}
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (r *prepaidExpenseRepositoryGorm) ExistsForVoucher(ctx context.Context, companyID, voucherID, expenseAccountID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.PrepaidExpense{}).
+		Where("company_id = ? AND voucher_id = ? AND expense_account_id = ?", companyID, voucherID, expenseAccountID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *prepaidExpenseRepositoryGorm) List(ctx context.Context, filter PrepaidExpenseFilter) ([]domain.PrepaidExpense, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.PrepaidExpense{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.PartnerID != nil {
+		query = query.Where("partner_id = ?", *filter.PartnerID)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("prepaid_account_id = ?", *filter.AccountID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var expenses []domain.PrepaidExpense
+	err := query.
+		Order("period_start DESC, voucher_no ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&expenses).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return expenses, total, nil
+}
+
+func (r *prepaidExpenseRepositoryGorm) ListWithLines(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID) ([]domain.PrepaidExpense, error) {
+	query := r.withLines(ctx).Where("company_id = ?", companyID)
+	if accountID != nil {
+		query = query.Where("prepaid_account_id = ?", *accountID)
+	}
+
+	var expenses []domain.PrepaidExpense
+	err := query.Order("period_end ASC, voucher_no ASC").Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *prepaidExpenseRepositoryGorm) UpdatePrepayment(ctx context.Context, expense *domain.PrepaidExpense) error {
+	return r.db.WithContext(ctx).Model(expense).
+		Select("prepayment_voucher_id").
+		Updates(expense).Error
+}
+
+func (r *prepaidExpenseRepositoryGorm) ListForAmortization(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.PrepaidExpense, error) {
+	query := r.withLines(ctx).
+		Where("status = ?", domain.PrepaidExpenseActive).
+		Where("EXISTS (SELECT 1 FROM prepaid_expense_lines l WHERE l.prepaid_expense_id = prepaid_expenses.id AND l.status = ? AND l.period_end <= ?)",
+			domain.PrepaidExpenseLinePending, periodEnd)
+	if companyID != nil {
+		query = query.Where("company_id = ?", *companyID)
+	}
+
+	var expenses []domain.PrepaidExpense
+	err := query.Order("company_id, period_start ASC, voucher_no ASC").Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *prepaidExpenseRepositoryGorm) RecordAmortization(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(line).
+			Where("status = ?", domain.PrepaidExpenseLinePending).
+			Select("status", "voucher_id", "posted_at").
+			Updates(line)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPrepaidExpenseLineChanged
+		}
+		return tx.Model(expense).
+			Select("amortized_amount", "status").
+			Updates(expense).Error
+	})
+}
+
+func (r *prepaidExpenseRepositoryGorm) ReleaseAmortization(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.PrepaidExpenseLine{}).
+			Where("id = ? AND status = ? AND voucher_id IS NULL", line.ID, domain.PrepaidExpenseLinePosted).
+			Updates(map[string]interface{}{"status": domain.PrepaidExpenseLinePending, "posted_at": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPrepaidExpenseLineChanged
+		}
+		return tx.Model(&domain.PrepaidExpense{}).
+			Where("id = ?", expense.ID).
+			Updates(map[string]interface{}{
+				"amortized_amount": gorm.Expr("amortized_amount - ?", line.Amount),
+				"status":           domain.PrepaidExpenseActive,
+			}).Error
+	})
+}
+
+func (r *prepaidExpenseRepositoryGorm) LinkAmortizationVoucher(ctx context.Context, line *domain.PrepaidExpenseLine) error {
+	return r.db.WithContext(ctx).Model(&domain.PrepaidExpenseLine{}).
+		Where("id = ? AND status = ?", line.ID, domain.PrepaidExpenseLinePosted).
+		Update("voucher_id", line.VoucherID).Error
+}
+
+// withLines preloads the lines of the prepaid expenses in month order
+func (r *prepaidExpenseRepositoryGorm) withLines(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seq ASC")
+	})
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// PushDeviceRepository defines the interface for push device persistence
+type PushDeviceRepository interface {
+	// Upsert registers the device, replacing the registration of its token in the company
+	Upsert(ctx context.Context, device *domain.PushDevice) error
+	// Delete removes a device of the user
+	Delete(ctx context.Context, companyID, userID uuid.UUID, token string) error
+	// DeleteByToken removes the registrations of a token the push service
+	// reported as invalid
+	DeleteByToken(ctx context.Context, token string) error
+
+	// FindByUsers lists the devices of the users of the company
+	FindByUsers(ctx context.Context, companyID uuid.UUID, userIDs []uuid.UUID) ([]domain.PushDevice, error)
+}
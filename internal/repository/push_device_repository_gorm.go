@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// pushDeviceRepositoryGorm implements PushDeviceRepository using GORM
+type pushDeviceRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewPushDeviceRepository creates a new GORM-based push device repository
+func NewPushDeviceRepository(db *gorm.DB) PushDeviceRepository {
+	return &pushDeviceRepositoryGorm{db: db}
+}
+
+func (r *pushDeviceRepositoryGorm) Upsert(ctx context.Context, device *domain.PushDevice) error {
+	device.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "company_id"}, {Name: "token"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "device_name", "last_seen_at", "updated_at"}),
+		}).
+		Create(device).Error
+}
+
+func (r *pushDeviceRepositoryGorm) Delete(ctx context.Context, companyID, userID uuid.UUID, token string) error {
+	return r.db.WithContext(ctx).
+		Where("company_id = ? AND user_id = ? AND token = ?", companyID, userID, token).
+		Delete(&domain.PushDevice{}).Error
+}
+
+func (r *pushDeviceRepositoryGorm) DeleteByToken(ctx context.Context, token string) error {
+	return r.db.WithContext(ctx).
+		Where("token = ?", token).
+		Delete(&domain.PushDevice{}).Error
+}
+
+func (r *pushDeviceRepositoryGorm) FindByUsers(ctx context.Context, companyID uuid.UUID, userIDs []uuid.UUID) ([]domain.PushDevice, error) {
+	var devices []domain.PushDevice
+	if len(userIDs) == 0 {
+		return devices, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND user_id IN ?", companyID, userIDs).
+		Order("last_seen_at DESC").
+		Find(&devices).Error
+	return devices, err
+}
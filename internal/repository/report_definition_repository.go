@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ReportDefinitionRepository defines the interface for custom report data access
+type ReportDefinitionRepository interface {
+	// CRUD operations
+	Create(ctx context.Context, def *domain.ReportDefinition) error
+	Update(ctx context.Context, def *domain.ReportDefinition) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Query operations
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportDefinition, error)
+	FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.ReportDefinition, error)
+
+	// Run compiles the query to SQL over posted voucher entries and returns the aggregated rows
+	Run(ctx context.Context, companyID uuid.UUID, query *domain.ReportQuery) ([]domain.ReportResultRow, error)
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// reportDimensionSQL maps each grouping to its entry column and lookup table.
+// Only these fixed fragments are ever interpolated into the compiled SQL.
+var reportDimensionSQL = map[domain.ReportDimension]struct {
+	column string
+	table  string
+}{
+	domain.ReportDimensionDepartment: {"ve.department_id", "departments"},
+	domain.ReportDimensionPartner:    {"ve.partner_id", "partners"},
+	domain.ReportDimensionProject:    {"ve.project_id", "projects"},
+}
+
+// reportDefinitionRepositoryGorm implements ReportDefinitionRepository using GORM
+type reportDefinitionRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewReportDefinitionRepository creates a new GORM-based report definition repository
+func NewReportDefinitionRepository(db *gorm.DB) ReportDefinitionRepository {
+	return &reportDefinitionRepositoryGorm{db: db}
+}
+
+func (r *reportDefinitionRepositoryGorm) Create(ctx context.Context, def *domain.ReportDefinition) error {
+	return r.db.WithContext(ctx).Create(def).Error
+}
+
+func (r *reportDefinitionRepositoryGorm) Update(ctx context.Context, def *domain.ReportDefinition) error {
+	return r.db.WithContext(ctx).
+		Model(def).
+		Select("name", "description", "spec", "updated_at").
+		Updates(def).Error
+}
+
+func (r *reportDefinitionRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.ReportDefinition{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReportDefinitionNotFound
+	}
+	return nil
+}
+
+func (r *reportDefinitionRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportDefinition, error) {
+	var def domain.ReportDefinition
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&def).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrReportDefinitionNotFound
+		}
+		return nil, err
+	}
+	return &def, nil
+}
+
+func (r *reportDefinitionRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.ReportDefinition, error) {
+	var defs []domain.ReportDefinition
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("name ASC").
+		Find(&defs).Error
+	return defs, err
+}
+
+// reportResultScan is the flat scan target of a compiled report query
+type reportResultScan struct {
+	AccountID     uuid.UUID
+	AccountCode   string
+	AccountName   string
+	AccountType   domain.AccountType
+	AccountNature domain.AccountNature
+	Dim0ID        *uuid.UUID `gorm:"column:dim0_id"`
+	Dim0Code      *string    `gorm:"column:dim0_code"`
+	Dim0Name      *string    `gorm:"column:dim0_name"`
+	Dim1ID        *uuid.UUID `gorm:"column:dim1_id"`
+	Dim1Code      *string    `gorm:"column:dim1_code"`
+	Dim1Name      *string    `gorm:"column:dim1_name"`
+	Dim2ID        *uuid.UUID `gorm:"column:dim2_id"`
+	Dim2Code      *string    `gorm:"column:dim2_code"`
+	Dim2Name      *string    `gorm:"column:dim2_name"`
+	Opening       float64
+	Debit         float64
+	Credit        float64
+}
+
+func (r *reportDefinitionRepositoryGorm) Run(ctx context.Context, companyID uuid.UUID, query *domain.ReportQuery) ([]domain.ReportResultRow, error) {
+	sql, args := compileReportQuery(companyID, query)
+
+	var scanned []reportResultScan
+	if err := r.db.WithContext(ctx).Raw(sql, args...).Scan(&scanned).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]domain.ReportResultRow, 0, len(scanned))
+	for _, s := range scanned {
+		row := domain.ReportResultRow{
+			AccountID:     s.AccountID,
+			AccountCode:   s.AccountCode,
+			AccountName:   s.AccountName,
+			AccountType:   s.AccountType,
+			AccountNature: s.AccountNature,
+			Opening:       s.Opening,
+			Debit:         s.Debit,
+			Credit:        s.Credit,
+		}
+		dims := []domain.ReportDimensionValue{
+			reportDimensionValue(s.Dim0ID, s.Dim0Code, s.Dim0Name),
+			reportDimensionValue(s.Dim1ID, s.Dim1Code, s.Dim1Name),
+			reportDimensionValue(s.Dim2ID, s.Dim2Code, s.Dim2Name),
+		}
+		if n := len(query.Spec.GroupBy); n > 0 {
+			row.Dimensions = dims[:n]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// reportDimensionValue builds a grouping value from nullable columns (entries without the dimension)
+func reportDimensionValue(id *uuid.UUID, code, name *string) domain.ReportDimensionValue {
+	v := domain.ReportDimensionValue{ID: id}
+	if code != nil {
+		v.Code = *code
+	}
+	if name != nil {
+		v.Name = *name
+	}
+	return v
+}
+
+// compileReportQuery builds the aggregate SQL for a report query.
+// Opening balances of revenue and expense accounts only include the current fiscal year.
+func compileReportQuery(companyID uuid.UUID, query *domain.ReportQuery) (string, []interface{}) {
+	spec := query.Spec
+
+	var selects, joins, groups, orders []string
+	for i, d := range spec.GroupBy {
+		dim := reportDimensionSQL[d]
+		alias := fmt.Sprintf("g%d", i)
+		selects = append(selects, fmt.Sprintf("%s AS dim%d_id, %s.code AS dim%d_code, %s.name AS dim%d_name",
+			dim.column, i, alias, i, alias, i))
+		joins = append(joins, fmt.Sprintf("LEFT JOIN %s %s ON %s = %s.id", dim.table, alias, dim.column, alias))
+		groups = append(groups, dim.column, alias+".code", alias+".name")
+		orders = append(orders, alias+".code NULLS FIRST")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`
+		SELECT
+			a.id AS account_id,
+			a.code AS account_code,
+			a.name AS account_name,
+			a.account_type,
+			a.account_nature,`)
+	for _, s := range selects {
+		sb.WriteString("\n\t\t\t" + s + ",")
+	}
+	sb.WriteString(`
+			COALESCE(SUM(CASE WHEN v.voucher_date < ?
+				AND (a.account_type IN ('asset', 'liability', 'equity') OR v.voucher_date >= ?)
+				THEN ve.debit_amount - ve.credit_amount ELSE 0 END), 0) AS opening,
+			COALESCE(SUM(CASE WHEN v.voucher_date >= ? THEN ve.debit_amount ELSE 0 END), 0) AS debit,
+			COALESCE(SUM(CASE WHEN v.voucher_date >= ? THEN ve.credit_amount ELSE 0 END), 0) AS credit
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN accounts a ON ve.account_id = a.id`)
+	for _, j := range joins {
+		sb.WriteString("\n\t\t" + j)
+	}
+	sb.WriteString(`
+		WHERE ve.company_id = ? AND ve.fiscal_year <= ? AND v.status = ? AND v.voucher_date <= ?`)
+
+	args := []interface{}{query.FromDate, query.YearStart, query.FromDate, query.FromDate,
+		companyID, query.ToDate.Year(), domain.VoucherStatusPosted, query.ToDate}
+
+	if len(spec.AccountRanges) > 0 {
+		ranges := make([]string, len(spec.AccountRanges))
+		for i, rng := range spec.AccountRanges {
+			ranges[i] = "(a.code >= ? AND a.code <= ?)"
+			args = append(args, rng.From, rng.To)
+		}
+		sb.WriteString("\n\t\t\tAND (" + strings.Join(ranges, " OR ") + ")")
+	}
+	if len(spec.AccountTypes) > 0 {
+		sb.WriteString("\n\t\t\tAND a.account_type IN ?")
+		args = append(args, spec.AccountTypes)
+	}
+	if len(spec.Tags) > 0 {
+		sb.WriteString("\n\t\t\tAND v.tags @> ?::jsonb")
+		args = append(args, voucherTagsJSON(spec.Tags))
+	}
+
+	sb.WriteString("\n\t\tGROUP BY " + strings.Join(append([]string{"a.id", "a.code", "a.name", "a.account_type", "a.account_nature"}, groups...), ", "))
+	sb.WriteString("\n\t\tORDER BY " + strings.Join(append([]string{"a.code"}, orders...), ", "))
+
+	return sb.String(), args
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ReportScheduleRepository defines the interface for report schedule data access
+type ReportScheduleRepository interface {
+	// CRUD operations
+	Create(ctx context.Context, schedule *domain.ReportSchedule) error
+	Update(ctx context.Context, schedule *domain.ReportSchedule) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Query operations
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportSchedule, error)
+	FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.ReportSchedule, error)
+
+	// Worker operations (across all companies)
+	FindDue(ctx context.Context, asOf time.Time, limit int) ([]domain.ReportSchedule, error)
+	// ClaimRun advances next_run_at only if it still holds the expected value,
+	// so that concurrent workers run each occurrence once
+	ClaimRun(ctx context.Context, id uuid.UUID, expected time.Time, next *time.Time) (bool, error)
+	UpdateRunState(ctx context.Context, schedule *domain.ReportSchedule) error
+
+	// Run history
+	CreateRun(ctx context.Context, run *domain.ReportScheduleRun) error
+	UpdateRun(ctx context.Context, run *domain.ReportScheduleRun) error
+	FindRuns(ctx context.Context, companyID, scheduleID uuid.UUID, limit int) ([]domain.ReportScheduleRun, error)
+}
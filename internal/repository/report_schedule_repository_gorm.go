@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// reportScheduleRepositoryGorm implements ReportScheduleRepository using GORM
+type reportScheduleRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewReportScheduleRepository creates a new GORM-based report schedule repository
+func NewReportScheduleRepository(db *gorm.DB) ReportScheduleRepository {
+	return &reportScheduleRepositoryGorm{db: db}
+}
+
+func (r *reportScheduleRepositoryGorm) Create(ctx context.Context, schedule *domain.ReportSchedule) error {
+	return r.db.WithContext(ctx).Create(schedule).Error
+}
+
+func (r *reportScheduleRepositoryGorm) Update(ctx context.Context, schedule *domain.ReportSchedule) error {
+	return r.db.WithContext(ctx).
+		Model(schedule).
+		Select("name", "report_type", "params", "format", "cron_expr", "timezone",
+			"recipients", "is_active", "next_run_at", "failure_count", "updated_at").
+		Updates(schedule).Error
+}
+
+func (r *reportScheduleRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.ReportSchedule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReportScheduleNotFound
+	}
+	return nil
+}
+
+func (r *reportScheduleRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportSchedule, error) {
+	var schedule domain.ReportSchedule
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&schedule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrReportScheduleNotFound
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *reportScheduleRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.ReportSchedule, error) {
+	var schedules []domain.ReportSchedule
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("name ASC").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *reportScheduleRepositoryGorm) FindDue(ctx context.Context, asOf time.Time, limit int) ([]domain.ReportSchedule, error) {
+	var schedules []domain.ReportSchedule
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND next_run_at <= ?", true, asOf).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *reportScheduleRepositoryGorm) ClaimRun(ctx context.Context, id uuid.UUID, expected time.Time, next *time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.ReportSchedule{}).
+		Where("id = ? AND next_run_at = ?", id, expected).
+		Update("next_run_at", next)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *reportScheduleRepositoryGorm) UpdateRunState(ctx context.Context, schedule *domain.ReportSchedule) error {
+	return r.db.WithContext(ctx).
+		Model(schedule).
+		Select("last_run_at", "last_status", "failure_count", "is_active").
+		Updates(schedule).Error
+}
+
+func (r *reportScheduleRepositoryGorm) CreateRun(ctx context.Context, run *domain.ReportScheduleRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *reportScheduleRepositoryGorm) UpdateRun(ctx context.Context, run *domain.ReportScheduleRun) error {
+	return r.db.WithContext(ctx).
+		Model(run).
+		Select("status", "file_name", "file_size", "recipient_count", "error", "finished_at").
+		Updates(run).Error
+}
+
+func (r *reportScheduleRepositoryGorm) FindRuns(ctx context.Context, companyID, scheduleID uuid.UUID, limit int) ([]domain.ReportScheduleRun, error) {
+	var runs []domain.ReportScheduleRun
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND schedule_id = ?", companyID, scheduleID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs).Error
+	return runs, err
+}
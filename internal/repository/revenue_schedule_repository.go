@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// RevenueScheduleFilter defines filter options for revenue schedules
+type RevenueScheduleFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.RevenueScheduleStatus
+	PartnerID *uuid.UUID
+	Page      int
+	PageSize  int
+}
+
+// RevenueScheduleRepository defines the interface for revenue schedule persistence
+type RevenueScheduleRepository interface {
+	// Create stores the schedule with its monthly lines
+	Create(ctx context.Context, schedule *domain.RevenueSchedule) error
+	// FindByID returns the schedule with its lines in month order
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.RevenueSchedule, error)
+	ExistsForInvoice(ctx context.Context, companyID, taxInvoiceID uuid.UUID) (bool, error)
+	List(ctx context.Context, filter RevenueScheduleFilter) ([]domain.RevenueSchedule, int64, error)
+	// ListWithLines returns all schedules of the company with their lines,
+	// for the waterfall report
+	ListWithLines(ctx context.Context, companyID uuid.UUID) ([]domain.RevenueSchedule, error)
+	UpdateDeferral(ctx context.Context, schedule *domain.RevenueSchedule) error
+
+	// ListForRecognition returns the active schedules with pending lines of
+	// months ending on or before periodEnd, with their lines, of one company
+	// or of all when companyID is nil
+	ListForRecognition(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.RevenueSchedule, error)
+	// RecordRecognition stores the posted line and the recognized amount and
+	// status of the schedule. It fails with domain.ErrRevenueScheduleLineChanged
+	// when the line was posted meanwhile.
+	RecordRecognition(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine) error
+	// ReleaseRecognition returns a line recorded without its voucher to
+	// pending and takes it off the recognized amount, when the voucher failed
+	// to post
+	ReleaseRecognition(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine) error
+	// LinkRecognitionVoucher stores the voucher of a posted line
+	LinkRecognitionVoucher(ctx context.Context, line *domain.RevenueScheduleLine) error
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// revenueScheduleRepositoryGorm implements RevenueScheduleRepository using GORM
+type revenueScheduleRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewRevenueScheduleRepository creates a new GORM-based revenue schedule repository
+func NewRevenueScheduleRepository(db *gorm.DB) RevenueScheduleRepository {
+	return &revenueScheduleRepositoryGorm{db: db}
+}
+
+func (r *revenueScheduleRepositoryGorm) Create(ctx context.Context, schedule *domain.RevenueSchedule) error {
+	return r.db.WithContext(ctx).Create(schedule).Error
+}
+
+func (r *revenueScheduleRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.RevenueSchedule, error) {
+	var schedule domain.RevenueSchedule
+	err := r.withLines(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&schedule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrRevenueScheduleNotFound
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *revenueScheduleRepositoryGorm) ExistsForInvoice(ctx context.Context, companyID, taxInvoiceID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.RevenueSchedule{}).
+		Where("company_id = ? AND tax_invoice_id = ?", companyID, taxInvoiceID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *revenueScheduleRepositoryGorm) List(ctx context.Context, filter RevenueScheduleFilter) ([]domain.RevenueSchedule, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.RevenueSchedule{}).
+		Where("company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.PartnerID != nil {
+		query = query.Where("partner_id = ?", *filter.PartnerID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var schedules []domain.RevenueSchedule
+	err := query.
+		Order("service_start DESC, invoice_number ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return schedules, total, nil
+}
+
+func (r *revenueScheduleRepositoryGorm) ListWithLines(ctx context.Context, companyID uuid.UUID) ([]domain.RevenueSchedule, error) {
+	var schedules []domain.RevenueSchedule
+	err := r.withLines(ctx).
+		Where("company_id = ?", companyID).
+		Order("service_end ASC, invoice_number ASC").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *revenueScheduleRepositoryGorm) UpdateDeferral(ctx context.Context, schedule *domain.RevenueSchedule) error {
+	return r.db.WithContext(ctx).Model(schedule).
+		Select("deferral_voucher_id").
+		Updates(schedule).Error
+}
+
+func (r *revenueScheduleRepositoryGorm) ListForRecognition(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) ([]domain.RevenueSchedule, error) {
+	query := r.withLines(ctx).
+		Where("status = ?", domain.RevenueScheduleActive).
+		Where("EXISTS (SELECT 1 FROM revenue_schedule_lines l WHERE l.schedule_id = revenue_schedules.id AND l.status = ? AND l.period_end <= ?)",
+			domain.RevenueScheduleLinePending, periodEnd)
+	if companyID != nil {
+		query = query.Where("company_id = ?", *companyID)
+	}
+
+	var schedules []domain.RevenueSchedule
+	err := query.Order("company_id, service_start ASC, invoice_number ASC").Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *revenueScheduleRepositoryGorm) RecordRecognition(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(line).
+			Where("status = ?", domain.RevenueScheduleLinePending).
+			Select("status", "voucher_id", "posted_at").
+			Updates(line)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRevenueScheduleLineChanged
+		}
+		return tx.Model(schedule).
+			Select("recognized_amount", "status").
+			Updates(schedule).Error
+	})
+}
+
+func (r *revenueScheduleRepositoryGorm) ReleaseRecognition(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.RevenueScheduleLine{}).
+			Where("id = ? AND status = ? AND voucher_id IS NULL", line.ID, domain.RevenueScheduleLinePosted).
+			Updates(map[string]interface{}{"status": domain.RevenueScheduleLinePending, "posted_at": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRevenueScheduleLineChanged
+		}
+		return tx.Model(&domain.RevenueSchedule{}).
+			Where("id = ?", schedule.ID).
+			Updates(map[string]interface{}{
+				"recognized_amount": gorm.Expr("recognized_amount - ?", line.Amount),
+				"status":            domain.RevenueScheduleActive,
+			}).Error
+	})
+}
+
+func (r *revenueScheduleRepositoryGorm) LinkRecognitionVoucher(ctx context.Context, line *domain.RevenueScheduleLine) error {
+	return r.db.WithContext(ctx).Model(&domain.RevenueScheduleLine{}).
+		Where("id = ? AND status = ?", line.ID, domain.RevenueScheduleLinePosted).
+		Update("voucher_id", line.VoucherID).Error
+}
+
+// withLines preloads the lines of the schedules in month order
+func (r *revenueScheduleRepositoryGorm) withLines(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seq ASC")
+	})
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// ScheduledTaskRepository defines the interface for the recurring tasks of the worker
+type ScheduledTaskRepository interface {
+	// Query operations
+	FindByName(ctx context.Context, name string) (*domain.ScheduledTask, error)
+	FindAll(ctx context.Context) ([]domain.ScheduledTask, error)
+	// Update stores the schedule of the task and its next run
+	Update(ctx context.Context, task *domain.ScheduledTask) error
+
+	// Worker operations
+	// FindDue returns the enabled tasks among names due at now that no worker holds
+	FindDue(ctx context.Context, names []string, now time.Time) ([]domain.ScheduledTask, error)
+	// Claim stores the claimed run only if next_run_at still holds the expected
+	// value and no worker holds the task, so that each run is claimed once
+	Claim(ctx context.Context, task *domain.ScheduledTask, expected, now time.Time) (bool, error)
+	// Release stores the outcome of the run if the worker still holds the task
+	Release(ctx context.Context, task *domain.ScheduledTask, owner string) error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// scheduledTaskRepositoryGorm implements ScheduledTaskRepository using GORM
+type scheduledTaskRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewScheduledTaskRepository creates a new GORM-based scheduled task repository
+func NewScheduledTaskRepository(db *gorm.DB) ScheduledTaskRepository {
+	return &scheduledTaskRepositoryGorm{db: db}
+}
+
+func (r *scheduledTaskRepositoryGorm) FindByName(ctx context.Context, name string) (*domain.ScheduledTask, error) {
+	var task domain.ScheduledTask
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrScheduledTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *scheduledTaskRepositoryGorm) FindAll(ctx context.Context) ([]domain.ScheduledTask, error) {
+	var tasks []domain.ScheduledTask
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *scheduledTaskRepositoryGorm) Update(ctx context.Context, task *domain.ScheduledTask) error {
+	return r.db.WithContext(ctx).
+		Model(task).
+		Select("cron_expr", "timezone", "catch_up", "enabled", "next_run_at").
+		Updates(task).Error
+}
+
+func (r *scheduledTaskRepositoryGorm) FindDue(ctx context.Context, names []string, now time.Time) ([]domain.ScheduledTask, error) {
+	var tasks []domain.ScheduledTask
+	err := r.db.WithContext(ctx).
+		Where("name IN ? AND enabled = ? AND next_run_at <= ?", names, true, now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("next_run_at ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *scheduledTaskRepositoryGorm) Claim(ctx context.Context, task *domain.ScheduledTask, expected, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.ScheduledTask{}).
+		Where("name = ? AND next_run_at = ?", task.Name, expected).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Select("next_run_at", "locked_by", "locked_until", "last_scheduled_at", "last_started_at",
+			"last_finished_at", "last_status", "last_error").
+		Updates(task)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *scheduledTaskRepositoryGorm) Release(ctx context.Context, task *domain.ScheduledTask, owner string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.ScheduledTask{}).
+		Where("name = ? AND locked_by = ?", task.Name, owner).
+		Select("locked_by", "locked_until", "last_finished_at", "last_status", "last_error").
+		Updates(task).Error
+}
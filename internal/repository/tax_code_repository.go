@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// TaxCodeRepository defines the interface for tax code data access
+type TaxCodeRepository interface {
+	Create(ctx context.Context, taxCode *domain.TaxCode) error
+	Update(ctx context.Context, taxCode *domain.TaxCode) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxCode, error)
+	FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.TaxCode, error)
+	ExistsByCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error)
+	IsInUse(ctx context.Context, companyID, id uuid.UUID) (bool, error)
+
+	// VAT return aggregation over posted vouchers
+	GetVATSummary(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.VATSummaryItem, error)
+}
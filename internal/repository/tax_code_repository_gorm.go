@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// taxCodeRepositoryGorm implements TaxCodeRepository using GORM
+type taxCodeRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewTaxCodeRepository creates a new GORM-based tax code repository
+func NewTaxCodeRepository(db *gorm.DB) TaxCodeRepository {
+	return &taxCodeRepositoryGorm{db: db}
+}
+
+func (r *taxCodeRepositoryGorm) Create(ctx context.Context, taxCode *domain.TaxCode) error {
+	return r.db.WithContext(ctx).Create(taxCode).Error
+}
+
+func (r *taxCodeRepositoryGorm) Update(ctx context.Context, taxCode *domain.TaxCode) error {
+	return r.db.WithContext(ctx).Save(taxCode).Error
+}
+
+func (r *taxCodeRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.TaxCode{}).Error
+}
+
+func (r *taxCodeRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxCode, error) {
+	var taxCode domain.TaxCode
+	err := r.db.WithContext(ctx).
+		Preload("VATAccount").
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&taxCode).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTaxCodeNotFound
+		}
+		return nil, err
+	}
+	return &taxCode, nil
+}
+
+func (r *taxCodeRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.TaxCode, error) {
+	var taxCodes []domain.TaxCode
+	query := r.db.WithContext(ctx).
+		Preload("VATAccount").
+		Where("company_id = ?", companyID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("tax_type ASC, code ASC").Find(&taxCodes).Error
+	return taxCodes, err
+}
+
+func (r *taxCodeRepositoryGorm) ExistsByCode(ctx context.Context, companyID uuid.UUID, code string, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&domain.TaxCode{}).
+		Where("company_id = ? AND code = ?", companyID, code)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *taxCodeRepositoryGorm) IsInUse(ctx context.Context, companyID, id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.VoucherEntry{}).
+		Where("company_id = ? AND tax_code_id = ?", companyID, id).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetVATSummary aggregates supply and VAT amounts of posted entries per tax code.
+// Sales (output) entries count credits as positive, purchase (input) entries count debits,
+// so reversals and returns net off automatically.
+func (r *taxCodeRepositoryGorm) GetVATSummary(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.VATSummaryItem, error) {
+	var items []domain.VATSummaryItem
+
+	query := `
+		SELECT
+			tc.id as tax_code_id,
+			tc.code,
+			tc.name,
+			tc.tax_type,
+			tc.tax_category,
+			tc.rate,
+			tc.is_deductible,
+			COUNT(ve.id) as entry_count,
+			COALESCE(SUM(
+				CASE WHEN tc.tax_type = ? THEN ve.credit_amount - ve.debit_amount
+				ELSE ve.debit_amount - ve.credit_amount END
+			), 0) as supply_amount,
+			COALESCE(SUM(
+				CASE WHEN (tc.tax_type = ?) = (ve.credit_amount > 0) THEN ve.tax_amount
+				ELSE -ve.tax_amount END
+			), 0) as tax_amount
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN tax_codes tc ON ve.tax_code_id = tc.id
+		WHERE ve.company_id = ? AND ve.is_tax_line = false
+			AND ve.fiscal_year BETWEEN ? AND ?
+			AND v.voucher_date >= ? AND v.voucher_date <= ?
+			AND v.status = ?
+		GROUP BY tc.id, tc.code, tc.name, tc.tax_type, tc.tax_category, tc.rate, tc.is_deductible
+		ORDER BY tc.tax_type DESC, tc.code
+	`
+
+	err := r.db.WithContext(ctx).
+		Raw(query, domain.TaxTypeOutput, domain.TaxTypeOutput, companyID, from.Year(), to.Year(), from, to, domain.VoucherStatusPosted).
+		Scan(&items).Error
+	return items, err
+}
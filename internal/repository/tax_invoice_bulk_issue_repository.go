@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// TaxInvoiceBulkIssueRepository defines the interface for tax invoice bulk issuance data access
+type TaxInvoiceBulkIssueRepository interface {
+	// Create stores a bulk issuance with its items
+	Create(ctx context.Context, issue *domain.TaxInvoiceBulkIssue) error
+	// FindByID returns a bulk issuance with its items in request order
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error)
+
+	// UpdateItem stores the result of one item
+	UpdateItem(ctx context.Context, item *domain.TaxInvoiceBulkIssueItem) error
+	// UpdateSummary stores the status, counts and attempts of a bulk issuance
+	UpdateSummary(ctx context.Context, issue *domain.TaxInvoiceBulkIssue) error
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// taxInvoiceBulkIssueRepositoryGorm implements TaxInvoiceBulkIssueRepository using GORM
+type taxInvoiceBulkIssueRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewTaxInvoiceBulkIssueRepository creates a new GORM-based tax invoice bulk issuance repository
+func NewTaxInvoiceBulkIssueRepository(db *gorm.DB) TaxInvoiceBulkIssueRepository {
+	return &taxInvoiceBulkIssueRepositoryGorm{db: db}
+}
+
+func (r *taxInvoiceBulkIssueRepositoryGorm) Create(ctx context.Context, issue *domain.TaxInvoiceBulkIssue) error {
+	return r.db.WithContext(ctx).Create(issue).Error
+}
+
+func (r *taxInvoiceBulkIssueRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error) {
+	var issue domain.TaxInvoiceBulkIssue
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&issue).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTaxInvoiceBulkIssueNotFound
+		}
+		return nil, err
+	}
+	return &issue, nil
+}
+
+func (r *taxInvoiceBulkIssueRepositoryGorm) UpdateItem(ctx context.Context, item *domain.TaxInvoiceBulkIssueItem) error {
+	return r.db.WithContext(ctx).
+		Model(item).
+		Select("status", "nts_confirm_number", "asp_invoice_id", "error_code", "error_message", "attempts", "updated_at").
+		Updates(item).Error
+}
+
+func (r *taxInvoiceBulkIssueRepositoryGorm) UpdateSummary(ctx context.Context, issue *domain.TaxInvoiceBulkIssue) error {
+	return r.db.WithContext(ctx).
+		Model(issue).
+		Select("status", "succeeded_count", "failed_count", "attempts", "completed_at").
+		Updates(issue).Error
+}
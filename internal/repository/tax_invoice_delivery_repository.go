@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// TaxInvoiceDeliveryRepository defines the interface for tax invoice email delivery data access
+type TaxInvoiceDeliveryRepository interface {
+	Create(ctx context.Context, delivery *domain.TaxInvoiceDelivery) error
+	// FindByMessageID finds a delivery by the Message-ID of its email, across all companies
+	FindByMessageID(ctx context.Context, messageID string) (*domain.TaxInvoiceDelivery, error)
+	// ListByInvoice lists the deliveries of an invoice, most recent first
+	ListByInvoice(ctx context.Context, companyID, invoiceID uuid.UUID) ([]domain.TaxInvoiceDelivery, error)
+
+	// UpdateBounce stores the bounce of a delivery
+	UpdateBounce(ctx context.Context, delivery *domain.TaxInvoiceDelivery) error
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// taxInvoiceDeliveryRepositoryGorm implements TaxInvoiceDeliveryRepository using GORM
+type taxInvoiceDeliveryRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewTaxInvoiceDeliveryRepository creates a new GORM-based tax invoice delivery repository
+func NewTaxInvoiceDeliveryRepository(db *gorm.DB) TaxInvoiceDeliveryRepository {
+	return &taxInvoiceDeliveryRepositoryGorm{db: db}
+}
+
+func (r *taxInvoiceDeliveryRepositoryGorm) Create(ctx context.Context, delivery *domain.TaxInvoiceDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+func (r *taxInvoiceDeliveryRepositoryGorm) FindByMessageID(ctx context.Context, messageID string) (*domain.TaxInvoiceDelivery, error) {
+	var delivery domain.TaxInvoiceDelivery
+	err := r.db.WithContext(ctx).
+		Where("message_id = ?", messageID).
+		First(&delivery).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTaxInvoiceDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *taxInvoiceDeliveryRepositoryGorm) ListByInvoice(ctx context.Context, companyID, invoiceID uuid.UUID) ([]domain.TaxInvoiceDelivery, error) {
+	var deliveries []domain.TaxInvoiceDelivery
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND tax_invoice_id = ?", companyID, invoiceID).
+		Order("sent_at DESC").
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *taxInvoiceDeliveryRepositoryGorm) UpdateBounce(ctx context.Context, delivery *domain.TaxInvoiceDelivery) error {
+	return r.db.WithContext(ctx).
+		Model(delivery).
+		Select("status", "bounced_at", "bounce_reason", "updated_at").
+		Updates(delivery).Error
+}
@@ -17,6 +17,7 @@ type TaxInvoiceFilter struct {
 	InvoiceType    *domain.TaxInvoiceType
 	Status         *domain.TaxInvoiceStatus
 	BusinessNumber *string
+	Unmatched      bool // only invoices without linked voucher
 	Page           int
 	PageSize       int
 }
@@ -26,7 +27,11 @@ type TaxInvoiceRepository interface {
 	Create(ctx context.Context, invoice *domain.TaxInvoice) error
 	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoice, error)
 	GetByNumber(ctx context.Context, companyID uuid.UUID, number string, invoiceType domain.TaxInvoiceType) (*domain.TaxInvoice, error)
+	// GetByASPInvoiceID finds an invoice by its document ID at the ASP, across all companies
+	GetByASPInvoiceID(ctx context.Context, aspProvider, aspInvoiceID string) (*domain.TaxInvoice, error)
 	List(ctx context.Context, filter *TaxInvoiceFilter) ([]*domain.TaxInvoice, int64, error)
+	// ListAmendments lists the amended invoices of an original in the order they were generated
+	ListAmendments(ctx context.Context, companyID, originalID uuid.UUID) ([]*domain.TaxInvoice, error)
 	Update(ctx context.Context, invoice *domain.TaxInvoice) error
 	UpdateStatus(ctx context.Context, companyID, id uuid.UUID, status domain.TaxInvoiceStatus, userID *uuid.UUID) error
 	Delete(ctx context.Context, companyID, id uuid.UUID) error
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -34,7 +33,7 @@ func (r *taxInvoiceRepositoryGorm) GetByID(ctx context.Context, companyID, id uu
 		First(&invoice).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("tax invoice not found")
+			return nil, domain.ErrTaxInvoiceNotFound
 		}
 		return nil, err
 	}
@@ -49,7 +48,22 @@ func (r *taxInvoiceRepositoryGorm) GetByNumber(ctx context.Context, companyID uu
 		First(&invoice).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("tax invoice not found")
+			return nil, domain.ErrTaxInvoiceNotFound
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// GetByASPInvoiceID retrieves a tax invoice by its ASP document ID
+func (r *taxInvoiceRepositoryGorm) GetByASPInvoiceID(ctx context.Context, aspProvider, aspInvoiceID string) (*domain.TaxInvoice, error) {
+	var invoice domain.TaxInvoice
+	err := r.db.WithContext(ctx).
+		Where("asp_provider = ? AND asp_invoice_id = ?", aspProvider, aspInvoiceID).
+		First(&invoice).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTaxInvoiceNotFound
 		}
 		return nil, err
 	}
@@ -78,6 +92,9 @@ func (r *taxInvoiceRepositoryGorm) List(ctx context.Context, filter *TaxInvoiceF
 		query = query.Where("supplier_business_number = ? OR buyer_business_number = ?",
 			*filter.BusinessNumber, *filter.BusinessNumber)
 	}
+	if filter.Unmatched {
+		query = query.Where("voucher_id IS NULL")
+	}
 
 	// Get total count
 	var total int64
@@ -97,6 +114,19 @@ func (r *taxInvoiceRepositoryGorm) List(ctx context.Context, filter *TaxInvoiceF
 	return invoices, total, nil
 }
 
+// ListAmendments lists the amended invoices of an original
+func (r *taxInvoiceRepositoryGorm) ListAmendments(ctx context.Context, companyID, originalID uuid.UUID) ([]*domain.TaxInvoice, error) {
+	var invoices []*domain.TaxInvoice
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND original_invoice_id = ?", companyID, originalID).
+		Order("created_at ASC, invoice_number ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
 // Update updates a tax invoice
 func (r *taxInvoiceRepositoryGorm) Update(ctx context.Context, invoice *domain.TaxInvoice) error {
 	return r.db.WithContext(ctx).Save(invoice).Error
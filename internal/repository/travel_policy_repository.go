@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// TravelPolicyRepository defines the interface for travel policy persistence
+type TravelPolicyRepository interface {
+	Create(ctx context.Context, policy *domain.TravelPolicy) error
+	// Update stores the name, rates, thresholds and activity of the policy
+	Update(ctx context.Context, policy *domain.TravelPolicy) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TravelPolicy, error)
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.TravelPolicy, error)
+	ExistsName(ctx context.Context, companyID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error)
+	// ExistsActive checks for another active policy of the department, or of
+	// the whole company when departmentID is nil
+	ExistsActive(ctx context.Context, companyID uuid.UUID, departmentID, excludeID *uuid.UUID) (bool, error)
+
+	// FindApplicable returns the active policy of the department, or else the
+	// one of the whole company. It fails with domain.ErrTravelPolicyNotFound
+	// when neither exists.
+	FindApplicable(ctx context.Context, companyID uuid.UUID, departmentID *uuid.UUID) (*domain.TravelPolicy, error)
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// travelPolicyRepositoryGorm implements TravelPolicyRepository using GORM
+type travelPolicyRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewTravelPolicyRepository creates a new GORM-based travel policy repository
+func NewTravelPolicyRepository(db *gorm.DB) TravelPolicyRepository {
+	return &travelPolicyRepositoryGorm{db: db}
+}
+
+func (r *travelPolicyRepositoryGorm) Create(ctx context.Context, policy *domain.TravelPolicy) error {
+	return r.db.WithContext(ctx).Create(policy).Error
+}
+
+func (r *travelPolicyRepositoryGorm) Update(ctx context.Context, policy *domain.TravelPolicy) error {
+	return r.db.WithContext(ctx).Model(policy).
+		Select("name", "per_diem_rates", "receipt_threshold", "mileage_rate", "is_active").
+		Updates(policy).Error
+}
+
+func (r *travelPolicyRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.TravelPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTravelPolicyNotFound
+	}
+	return nil
+}
+
+func (r *travelPolicyRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TravelPolicy, error) {
+	var policy domain.TravelPolicy
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTravelPolicyNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *travelPolicyRepositoryGorm) List(ctx context.Context, companyID uuid.UUID) ([]domain.TravelPolicy, error) {
+	var policies []domain.TravelPolicy
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("department_id NULLS FIRST, name ASC").
+		Find(&policies).Error
+	return policies, err
+}
+
+func (r *travelPolicyRepositoryGorm) ExistsName(ctx context.Context, companyID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.TravelPolicy{}).
+		Where("company_id = ? AND name = ?", companyID, name)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *travelPolicyRepositoryGorm) ExistsActive(ctx context.Context, companyID uuid.UUID, departmentID, excludeID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.TravelPolicy{}).
+		Where("company_id = ? AND is_active", companyID)
+	if departmentID != nil {
+		query = query.Where("department_id = ?", *departmentID)
+	} else {
+		query = query.Where("department_id IS NULL")
+	}
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *travelPolicyRepositoryGorm) FindApplicable(ctx context.Context, companyID uuid.UUID, departmentID *uuid.UUID) (*domain.TravelPolicy, error) {
+	query := r.db.WithContext(ctx).Where("company_id = ? AND is_active", companyID)
+	if departmentID != nil {
+		query = query.Where("department_id = ? OR department_id IS NULL", *departmentID)
+	} else {
+		query = query.Where("department_id IS NULL")
+	}
+
+	// The policy of the department overrides the one of the company
+	var policy domain.TravelPolicy
+	err := query.Order("department_id NULLS LAST").First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTravelPolicyNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
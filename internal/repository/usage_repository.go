@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// UsageRepository defines the interface for usage metering persistence
+type UsageRepository interface {
+	// AggregateEvents sets the usage of the counted metrics of every company in
+	// the month from its events. Exported records are left unchanged.
+	AggregateEvents(ctx context.Context, period time.Time) (int64, error)
+
+	// SampleActiveUsers raises the active user usage of every company in the
+	// month to its current number of active members
+	SampleActiveUsers(ctx context.Context, period time.Time) (int64, error)
+
+	// FindRecords returns the company's records of the months from..to
+	FindRecords(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.UsageRecord, error)
+
+	// ListPendingExports returns unexported records of months before the
+	// period, of companies with a billing customer
+	ListPendingExports(ctx context.Context, before time.Time, limit int) ([]domain.PendingUsageExport, error)
+
+	// FinishExport stores the outcome of exporting the record
+	FinishExport(ctx context.Context, record *domain.UsageRecord) error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// usageRepositoryGorm implements UsageRepository using GORM
+type usageRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new GORM-based usage repository
+func NewUsageRepository(db *gorm.DB) UsageRepository {
+	return &usageRepositoryGorm{db: db}
+}
+
+// usageMonthBounds returns the month as a date, and its start and end instants
+func usageMonthBounds(period time.Time) (string, time.Time, time.Time) {
+	return period.Format("2006-01-02"), period, period.AddDate(0, 1, 0)
+}
+
+func (r *usageRepositoryGorm) AggregateEvents(ctx context.Context, period time.Time) (int64, error) {
+	month, start, end := usageMonthBounds(period)
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO usage_records (company_id, period, metric, quantity)
+		SELECT company_id, ?::date, metric, SUM(quantity)
+		FROM usage_events
+		WHERE occurred_at >= ? AND occurred_at < ? AND metric <> ?
+		GROUP BY company_id, metric
+		ON CONFLICT (company_id, period, metric) DO UPDATE
+		SET quantity = EXCLUDED.quantity, updated_at = NOW()
+		WHERE usage_records.exported_at IS NULL AND usage_records.quantity <> EXCLUDED.quantity`,
+		month, start, end, domain.UsageMetricActiveUsers)
+	return result.RowsAffected, result.Error
+}
+
+func (r *usageRepositoryGorm) SampleActiveUsers(ctx context.Context, period time.Time) (int64, error) {
+	month, _, _ := usageMonthBounds(period)
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO usage_records (company_id, period, metric, quantity)
+		SELECT m.company_id, ?::date, ?, COUNT(*)
+		FROM user_company_memberships m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.is_active AND u.deleted_at IS NULL AND u.status = ?
+		GROUP BY m.company_id
+		ON CONFLICT (company_id, period, metric) DO UPDATE
+		SET quantity = EXCLUDED.quantity, updated_at = NOW()
+		WHERE usage_records.exported_at IS NULL AND usage_records.quantity < EXCLUDED.quantity`,
+		month, domain.UsageMetricActiveUsers, domain.UserStatusActive)
+	return result.RowsAffected, result.Error
+}
+
+func (r *usageRepositoryGorm) FindRecords(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.UsageRecord, error) {
+	var records []domain.UsageRecord
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND period >= ?::date AND period <= ?::date", companyID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("period DESC, metric ASC").
+		Find(&records).Error
+	return records, err
+}
+
+func (r *usageRepositoryGorm) ListPendingExports(ctx context.Context, before time.Time, limit int) ([]domain.PendingUsageExport, error) {
+	var pending []domain.PendingUsageExport
+	err := r.db.WithContext(ctx).
+		Table("usage_records r").
+		Select("r.*, c.billing_customer_id").
+		Joins("JOIN companies c ON c.id = r.company_id").
+		Where("r.exported_at IS NULL AND r.period < ?::date", before.Format("2006-01-02")).
+		Where("c.billing_customer_id IS NOT NULL AND c.billing_customer_id <> ''").
+		Order("r.period ASC, r.id ASC").
+		Limit(limit).
+		Scan(&pending).Error
+	return pending, err
+}
+
+func (r *usageRepositoryGorm) FinishExport(ctx context.Context, record *domain.UsageRecord) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UsageRecord{}).
+		Where("id = ? AND exported_at IS NULL", record.ID).
+		Updates(map[string]interface{}{
+			"exported_at":      record.ExportedAt,
+			"export_reference": record.ExportReference,
+			"export_error":     record.ExportError,
+			"updated_at":       time.Now(),
+		}).Error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// UserCompanyMembershipRepository defines the interface for company membership data access.
+// Lookups are by user across companies, so they run outside the tenant context.
+type UserCompanyMembershipRepository interface {
+	Create(ctx context.Context, membership *domain.UserCompanyMembership) error
+
+	// FindByUser returns all memberships of a user with their company loaded
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.UserCompanyMembership, error)
+
+	// FindByUserAndCompany returns the membership of a user in a company
+	FindByUserAndCompany(ctx context.Context, userID, companyID uuid.UUID) (*domain.UserCompanyMembership, error)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// userCompanyMembershipRepositoryGorm implements UserCompanyMembershipRepository using GORM
+type userCompanyMembershipRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewUserCompanyMembershipRepository creates a new GORM-based membership repository
+func NewUserCompanyMembershipRepository(db *gorm.DB) UserCompanyMembershipRepository {
+	return &userCompanyMembershipRepositoryGorm{db: db}
+}
+
+func (r *userCompanyMembershipRepositoryGorm) Create(ctx context.Context, membership *domain.UserCompanyMembership) error {
+	return r.db.WithContext(ctx).Create(membership).Error
+}
+
+// FindByUser lists the memberships with the home company first, then in the order they were granted
+func (r *userCompanyMembershipRepositoryGorm) FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.UserCompanyMembership, error) {
+	var memberships []domain.UserCompanyMembership
+	err := r.db.WithContext(ctx).
+		Preload("Company").
+		Where("user_id = ?", userID).
+		Order("is_default DESC, created_at").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *userCompanyMembershipRepositoryGorm) FindByUserAndCompany(ctx context.Context, userID, companyID uuid.UUID) (*domain.UserCompanyMembership, error) {
+	var membership domain.UserCompanyMembership
+	err := r.db.WithContext(ctx).
+		Preload("Company").
+		Where("user_id = ? AND company_id = ?", userID, companyID).
+		First(&membership).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrMembershipNotFound
+		}
+		return nil, err
+	}
+	return &membership, nil
+}
@@ -28,6 +28,10 @@ type UserRepository interface {
 
 	// Query operations
 	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.User, error)
+	FindByUserID(ctx context.Context, id uuid.UUID) (*domain.User, error) // across companies, for token issuance
+	// FindMember returns the user acting in the company: its own users as they
+	// are, members from other companies with the role of their active membership
+	FindMember(ctx context.Context, companyID, id uuid.UUID) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	FindByEmailAndCompany(ctx context.Context, companyID uuid.UUID, email string) (*domain.User, error)
 	FindAll(ctx context.Context, filter UserFilter) ([]domain.User, int64, error)
@@ -37,6 +41,21 @@ type UserRepository interface {
 
 	// Login helpers
 	UpdateLastLogin(ctx context.Context, userID uuid.UUID) error
+
+	// ClaimSigningPINAttempt counts a signing PIN confirmation before the PIN is
+	// checked. It fails with domain.ErrSigningPINLocked when the user has made
+	// limit attempts since the last correct PIN.
+	ClaimSigningPINAttempt(ctx context.Context, userID uuid.UUID, limit int) error
+	// ResetSigningPINAttempts clears the count after a correct PIN, a new PIN
+	// or an administrator's unlock
+	ResetSigningPINAttempts(ctx context.Context, userID uuid.UUID) error
+
+	// LockActiveAdmins returns the IDs of the company's active admins, locking
+	// their rows until the end of the transaction
+	LockActiveAdmins(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error)
+
+	// Transaction support
+	WithTransaction(ctx context.Context, fn func(repo UserRepository) error) error
 }
 
 // RefreshTokenRepository defines the interface for refresh token data access
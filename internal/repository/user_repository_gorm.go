@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
@@ -48,6 +49,40 @@ func (r *userRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UU
 	return &user, nil
 }
 
+func (r *userRepositoryGorm) FindByUserID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepositoryGorm) FindMember(ctx context.Context, companyID, id uuid.UUID) (*domain.User, error) {
+	user, err := r.FindByUserID(ctx, id)
+	if err != nil || user.CompanyID == companyID {
+		return user, err
+	}
+
+	var membership domain.UserCompanyMembership
+	err = r.db.WithContext(ctx).
+		Where("user_id = ? AND company_id = ? AND is_active = ?", id, companyID, true).
+		First(&membership).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	user.Role = membership.Role
+	return user, nil
+}
+
 func (r *userRepositoryGorm) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 	err := r.db.WithContext(ctx).
@@ -146,6 +181,45 @@ func (r *userRepositoryGorm) UpdateLastLogin(ctx context.Context, userID uuid.UU
 		Update("last_login_at", time.Now()).Error
 }
 
+func (r *userRepositoryGorm) ClaimSigningPINAttempt(ctx context.Context, userID uuid.UUID, limit int) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ? AND signing_pin_attempts < ?", userID, limit).
+		Update("signing_pin_attempts", gorm.Expr("signing_pin_attempts + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSigningPINLocked
+	}
+	return nil
+}
+
+func (r *userRepositoryGorm) ResetSigningPINAttempts(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ? AND signing_pin_attempts > 0", userID).
+		Update("signing_pin_attempts", 0).Error
+}
+
+func (r *userRepositoryGorm) LockActiveAdmins(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("company_id = ? AND role = ? AND status = ?", companyID, domain.UserRoleAdmin, domain.UserStatusActive).
+		Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// WithTransaction executes a function within a transaction
+func (r *userRepositoryGorm) WithTransaction(ctx context.Context, fn func(repo UserRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&userRepositoryGorm{db: tx})
+	})
+}
+
 // refreshTokenRepositoryGorm implements RefreshTokenRepository using GORM
 type refreshTokenRepositoryGorm struct {
 	db *gorm.DB
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// UserTokenRepository defines the interface for invitation and email verification token data access
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *domain.UserToken) error
+	Update(ctx context.Context, token *domain.UserToken) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// FindByHash looks a token up by the hash of its raw value, across companies
+	FindByHash(ctx context.Context, purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error)
+
+	// FindPending returns the unused, unexpired tokens of a company for the purpose
+	FindPending(ctx context.Context, companyID uuid.UUID, purpose domain.UserTokenPurpose) ([]domain.UserToken, error)
+
+	// InvalidatePending consumes the outstanding tokens for an email so only the newest one works
+	InvalidatePending(ctx context.Context, companyID uuid.UUID, purpose domain.UserTokenPurpose, email string) error
+}
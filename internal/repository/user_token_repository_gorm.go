@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// userTokenRepositoryGorm implements UserTokenRepository using GORM
+type userTokenRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository creates a new GORM-based user token repository
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepositoryGorm{db: db}
+}
+
+func (r *userTokenRepositoryGorm) Create(ctx context.Context, token *domain.UserToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *userTokenRepositoryGorm) Update(ctx context.Context, token *domain.UserToken) error {
+	return r.db.WithContext(ctx).
+		Model(token).
+		Select("user_id", "used_at", "updated_at").
+		Updates(token).Error
+}
+
+func (r *userTokenRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&domain.UserToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserTokenNotFound
+	}
+	return nil
+}
+
+func (r *userTokenRepositoryGorm) FindByHash(ctx context.Context, purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	var token domain.UserToken
+	err := r.db.WithContext(ctx).
+		Preload("Company").
+		Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+		First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrUserTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *userTokenRepositoryGorm) FindPending(ctx context.Context, companyID uuid.UUID, purpose domain.UserTokenPurpose) ([]domain.UserToken, error) {
+	var tokens []domain.UserToken
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", companyID, purpose, time.Now()).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *userTokenRepositoryGorm) InvalidatePending(ctx context.Context, companyID uuid.UUID, purpose domain.UserTokenPurpose, email string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserToken{}).
+		Where("company_id = ? AND purpose = ? AND email = ? AND used_at IS NULL", companyID, purpose, email).
+		Updates(map[string]interface{}{"used_at": time.Now(), "updated_at": time.Now()}).Error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// VoucherAnomalyFilter defines filter options for the anomaly review worklist
+type VoucherAnomalyFilter struct {
+	CompanyID uuid.UUID
+	Status    *domain.AnomalyFlagStatus
+	Rule      *domain.AnomalyRule
+	AccountID *uuid.UUID
+	DateFrom  *time.Time // voucher date
+	DateTo    *time.Time
+	Page      int
+	PageSize  int
+}
+
+// VoucherAnomalyRepository defines the interface for anomaly flag persistence
+type VoucherAnomalyRepository interface {
+	// ListUnscored returns posted vouchers of all companies not yet scored, with
+	// their entries, oldest posting first
+	ListUnscored(ctx context.Context, limit int) ([]domain.Voucher, error)
+
+	// AccountAmountStats summarizes the posted entry amounts of the accounts
+	// in vouchers dated from..to
+	AccountAmountStats(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID]domain.AccountAmountStats, error)
+
+	// SaveScore stores the flags of the voucher and marks it as scored
+	SaveScore(ctx context.Context, voucher *domain.Voucher, flags []domain.VoucherAnomalyFlag) error
+
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAnomalyFlag, error)
+	List(ctx context.Context, filter VoucherAnomalyFilter) ([]domain.VoucherAnomalyFlag, int64, error)
+	UpdateReview(ctx context.Context, flag *domain.VoucherAnomalyFlag) error
+}
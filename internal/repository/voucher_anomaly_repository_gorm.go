@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// voucherAnomalyRepositoryGorm implements VoucherAnomalyRepository using GORM
+type voucherAnomalyRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewVoucherAnomalyRepository creates a new GORM-based anomaly flag repository
+func NewVoucherAnomalyRepository(db *gorm.DB) VoucherAnomalyRepository {
+	return &voucherAnomalyRepositoryGorm{db: db}
+}
+
+func (r *voucherAnomalyRepositoryGorm) ListUnscored(ctx context.Context, limit int) ([]domain.Voucher, error) {
+	var vouchers []domain.Voucher
+	err := r.db.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Where("status = ? AND anomaly_scored_at IS NULL", domain.VoucherStatusPosted).
+		Order("posted_at ASC").
+		Limit(limit).
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+func (r *voucherAnomalyRepositoryGorm) AccountAmountStats(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID]domain.AccountAmountStats, error) {
+	stats := make(map[uuid.UUID]domain.AccountAmountStats)
+	if len(accountIDs) == 0 {
+		return stats, nil
+	}
+
+	var rows []domain.AccountAmountStats
+	err := r.db.WithContext(ctx).
+		Table("voucher_entries e").
+		Select(`e.account_id,
+			COUNT(*) AS count,
+			AVG(e.debit_amount + e.credit_amount) AS mean,
+			COALESCE(STDDEV_SAMP(e.debit_amount + e.credit_amount), 0) AS std_dev`).
+		Joins("JOIN vouchers v ON v.id = e.voucher_id").
+		Where("v.company_id = ? AND v.status = ?", companyID, domain.VoucherStatusPosted).
+		Where("v.voucher_date >= ? AND v.voucher_date <= ?", from, to).
+		Where("e.account_id IN ? AND e.is_tax_line = ?", accountIDs, false).
+		Group("e.account_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		stats[row.AccountID] = row
+	}
+	return stats, nil
+}
+
+func (r *voucherAnomalyRepositoryGorm) SaveScore(ctx context.Context, voucher *domain.Voucher, flags []domain.VoucherAnomalyFlag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(flags) > 0 {
+			if err := tx.Create(&flags).Error; err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		voucher.AnomalyScoredAt = &now
+		return tx.Model(&domain.Voucher{}).
+			Where("id = ?", voucher.ID).
+			UpdateColumn("anomaly_scored_at", now).Error
+	})
+}
+
+func (r *voucherAnomalyRepositoryGorm) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAnomalyFlag, error) {
+	var flag domain.VoucherAnomalyFlag
+	err := r.db.WithContext(ctx).
+		Preload("Voucher").
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&flag).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrVoucherAnomalyNotFound
+		}
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *voucherAnomalyRepositoryGorm) List(ctx context.Context, filter VoucherAnomalyFilter) ([]domain.VoucherAnomalyFlag, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.VoucherAnomalyFlag{}).
+		Where("voucher_anomaly_flags.company_id = ?", filter.CompanyID)
+	if filter.Status != nil {
+		query = query.Where("voucher_anomaly_flags.status = ?", *filter.Status)
+	}
+	if filter.Rule != nil {
+		query = query.Where("voucher_anomaly_flags.rule = ?", *filter.Rule)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("voucher_anomaly_flags.account_id = ?", *filter.AccountID)
+	}
+	if filter.DateFrom != nil || filter.DateTo != nil {
+		query = query.Joins("JOIN vouchers ON vouchers.id = voucher_anomaly_flags.voucher_id")
+		if filter.DateFrom != nil {
+			query = query.Where("vouchers.voucher_date >= ?", *filter.DateFrom)
+		}
+		if filter.DateTo != nil {
+			query = query.Where("vouchers.voucher_date <= ?", *filter.DateTo)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Highest scores first; the heuristics all score 1
+	var flags []domain.VoucherAnomalyFlag
+	err := query.
+		Preload("Voucher").
+		Order("voucher_anomaly_flags.score DESC, voucher_anomaly_flags.created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&flags).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return flags, total, nil
+}
+
+func (r *voucherAnomalyRepositoryGorm) UpdateReview(ctx context.Context, flag *domain.VoucherAnomalyFlag) error {
+	return r.db.WithContext(ctx).Model(flag).
+		Select("status", "reviewed_by", "reviewed_at", "review_note").
+		Updates(flag).Error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// VoucherAttachmentRepository defines the interface for voucher attachment data access.
+// Creating and deleting attachments keeps the voucher's attachment_count in step.
+type VoucherAttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.VoucherAttachment) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// FindByID returns the attachment with its file content
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAttachment, error)
+	// FindThumbnail returns the attachment with its thumbnail but without its file content
+	FindThumbnail(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAttachment, error)
+	// FindByVoucher returns the attachments of a voucher without their file content or thumbnail
+	FindByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherAttachment, error)
+
+	// UpdateScan stores the scan verdict of an attachment uploaded unscanned
+	UpdateScan(ctx context.Context, attachment *domain.VoucherAttachment) error
+
+	// Quarantine stores an infected file; when attachmentID is set, that stored
+	// attachment is removed in the same transaction
+	Quarantine(ctx context.Context, file *domain.QuarantinedFile, attachmentID *uuid.UUID) error
+}
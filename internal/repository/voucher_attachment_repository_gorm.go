@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// voucherAttachmentRepositoryGorm implements VoucherAttachmentRepository using GORM
+type voucherAttachmentRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewVoucherAttachmentRepository creates a new GORM-based voucher attachment repository
+func NewVoucherAttachmentRepository(db *gorm.DB) VoucherAttachmentRepository {
+	return &voucherAttachmentRepositoryGorm{db: db}
+}
+
+func (r *voucherAttachmentRepositoryGorm) Create(ctx context.Context, attachment *domain.VoucherAttachment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attachment).Error; err != nil {
+			return err
+		}
+		return adjustAttachmentCount(tx, attachment.VoucherID, 1)
+	})
+}
+
+func (r *voucherAttachmentRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteAttachment(tx, companyID, id)
+	})
+}
+
+func (r *voucherAttachmentRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAttachment, error) {
+	return r.find(ctx, companyID, id, "thumbnail_data")
+}
+
+func (r *voucherAttachmentRepositoryGorm) FindThumbnail(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAttachment, error) {
+	return r.find(ctx, companyID, id, "file_data")
+}
+
+// find loads an attachment without the omitted content column
+func (r *voucherAttachmentRepositoryGorm) find(ctx context.Context, companyID, id uuid.UUID, omit string) (*domain.VoucherAttachment, error) {
+	var attachment domain.VoucherAttachment
+	err := r.db.WithContext(ctx).
+		Omit(omit).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&attachment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *voucherAttachmentRepositoryGorm) FindByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherAttachment, error) {
+	var attachments []domain.VoucherAttachment
+	err := r.db.WithContext(ctx).
+		Omit("file_data", "thumbnail_data").
+		Where("company_id = ? AND voucher_id = ?", companyID, voucherID).
+		Order("uploaded_at ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *voucherAttachmentRepositoryGorm) UpdateScan(ctx context.Context, attachment *domain.VoucherAttachment) error {
+	return r.db.WithContext(ctx).Model(attachment).
+		Select("scan_status", "scanner", "scanned_at").
+		Updates(attachment).Error
+}
+
+func (r *voucherAttachmentRepositoryGorm) Quarantine(ctx context.Context, file *domain.QuarantinedFile, attachmentID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(file).Error; err != nil {
+			return err
+		}
+		if attachmentID == nil {
+			return nil
+		}
+		return deleteAttachment(tx, file.CompanyID, *attachmentID)
+	})
+}
+
+func deleteAttachment(tx *gorm.DB, companyID, id uuid.UUID) error {
+	var attachment domain.VoucherAttachment
+	result := tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "voucher_id"}}}).
+		Where("company_id = ? AND id = ?", companyID, id).
+		Delete(&attachment)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return adjustAttachmentCount(tx, attachment.VoucherID, -1)
+}
+
+func adjustAttachmentCount(tx *gorm.DB, voucherID uuid.UUID, delta int) error {
+	return tx.Model(&domain.Voucher{}).
+		Where("id = ?", voucherID).
+		Update("attachment_count", gorm.Expr("GREATEST(attachment_count + ?, 0)", delta)).Error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// VoucherPrintTemplateRepository defines the interface for voucher print template data access
+type VoucherPrintTemplateRepository interface {
+	FindByCompany(ctx context.Context, companyID uuid.UUID) (*domain.VoucherPrintTemplate, error)
+	Save(ctx context.Context, template *domain.VoucherPrintTemplate) error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// voucherPrintTemplateRepositoryGorm implements VoucherPrintTemplateRepository using GORM
+type voucherPrintTemplateRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewVoucherPrintTemplateRepository creates a new GORM-based voucher print template repository
+func NewVoucherPrintTemplateRepository(db *gorm.DB) VoucherPrintTemplateRepository {
+	return &voucherPrintTemplateRepositoryGorm{db: db}
+}
+
+func (r *voucherPrintTemplateRepositoryGorm) FindByCompany(ctx context.Context, companyID uuid.UUID) (*domain.VoucherPrintTemplate, error) {
+	var template domain.VoucherPrintTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrPrintTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *voucherPrintTemplateRepositoryGorm) Save(ctx context.Context, template *domain.VoucherPrintTemplate) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "company_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "logo_data", "logo_content_type",
+				"show_approval_block", "approval_labels", "show_partner", "footer_text", "updated_at"}),
+		}).
+		Create(template).Error
+}
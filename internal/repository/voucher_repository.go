@@ -9,22 +9,43 @@ import (
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
 
-// VoucherFilter defines filter options for voucher queries
+// VoucherFilter defines filter options for voucher queries.
+// Status and Statuses (likewise VoucherType and VoucherTypes) both apply when set.
 type VoucherFilter struct {
-	CompanyID     uuid.UUID
-	VoucherType   *domain.VoucherType
-	Status        *domain.VoucherStatus
-	DateFrom      *time.Time
-	DateTo        *time.Time
-	AccountID     *uuid.UUID
-	PartnerID     *uuid.UUID
-	DepartmentID  *uuid.UUID
-	SearchTerm    string
-	IncludeEntries bool
-	Page          int
-	PageSize      int
-	SortBy        string
-	SortDesc      bool
+	CompanyID       uuid.UUID
+	VoucherType     *domain.VoucherType
+	VoucherTypes    []domain.VoucherType
+	Status          *domain.VoucherStatus
+	Statuses        []domain.VoucherStatus
+	DateFrom        *time.Time
+	DateTo          *time.Time
+	MinAmount       *float64 // total debit amount, inclusive
+	MaxAmount       *float64
+	CreatedBy       *uuid.UUID
+	ApprovedBy      *uuid.UUID
+	VoucherNoPrefix string
+	AccountID       *uuid.UUID
+	PartnerID       *uuid.UUID
+	DepartmentID    *uuid.UUID
+	Unreferenced    bool     // only vouchers without reference document
+	Tags            []string // only vouchers carrying all of the tags
+	SearchTerm      string
+	IncludeEntries  bool
+	Page            int
+	PageSize        int
+	SortBy          string
+	SortDesc        bool
+}
+
+// EntrySuggestionFilter selects the posted entries entry suggestions are
+// drawn from: those of the partner or with any of the keywords in the entry
+// or voucher description, on vouchers dated on or after Since
+type EntrySuggestionFilter struct {
+	CompanyID uuid.UUID
+	PartnerID *uuid.UUID
+	Keywords  []string
+	Since     time.Time
+	Limit     int
 }
 
 // VoucherRepository defines the interface for voucher data access
@@ -36,11 +57,31 @@ type VoucherRepository interface {
 
 	// Query operations
 	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error)
+	// FindByIDForUpdate is FindByID locking the voucher row until the end of the transaction
+	FindByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error)
 	FindByNo(ctx context.Context, companyID uuid.UUID, voucherNo string) (*domain.Voucher, error)
+
+	// FindDetailByID is FindByID with the reversal vouchers, workflow users and status history
+	FindDetailByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error)
 	FindAll(ctx context.Context, filter VoucherFilter) ([]domain.Voucher, int64, error)
+
+	// FindAllIterator calls fn for every voucher matching the filter, ordered by date
+	// and number, without loading all of them at once; an error from fn stops it
+	FindAllIterator(ctx context.Context, filter VoucherFilter, fn func(voucher *domain.Voucher) error) error
 	FindByDateRange(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.Voucher, error)
 	FindByStatus(ctx context.Context, companyID uuid.UUID, status domain.VoucherStatus) ([]domain.Voucher, error)
 
+	// FindPendingAutoReversals returns posted auto-reversing vouchers across all companies
+	// which have not been reversed yet and are dated before the given date
+	FindPendingAutoReversals(ctx context.Context, before time.Time) ([]domain.Voucher, error)
+
+	// FindScheduled returns the approved vouchers of the company scheduled for posting
+	FindScheduled(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error)
+
+	// FindDueScheduledPostings returns approved vouchers across all companies
+	// scheduled for posting on or before the given date
+	FindDueScheduledPostings(ctx context.Context, asOf time.Time) ([]domain.Voucher, error)
+
 	// Entry operations
 	CreateEntry(ctx context.Context, entry *domain.VoucherEntry) error
 	UpdateEntry(ctx context.Context, entry *domain.VoucherEntry) error
@@ -52,6 +93,29 @@ type VoucherRepository interface {
 	// Workflow operations
 	UpdateStatus(ctx context.Context, voucher *domain.Voucher) error
 
+	// UpdateReference links the voucher to its reference document, whatever its status
+	UpdateReference(ctx context.Context, voucher *domain.Voucher) error
+
+	// UpdateTags saves the tags of the voucher, whatever its status, and adds
+	// the tags the company does not have yet
+	UpdateTags(ctx context.Context, voucher *domain.Voucher) error
+
+	// UpdateSchedule saves the scheduled posting of the voucher
+	UpdateSchedule(ctx context.Context, voucher *domain.Voucher) error
+
+	// UpdateReversedAmounts saves the reversed amounts of the voucher and its
+	// entries and the reversal completing it, whatever its status
+	UpdateReversedAmounts(ctx context.Context, voucher *domain.Voucher) error
+
+	// FindSoDViolations returns the approvals and postings recorded in the status
+	// history from..to whose user created or submitted the voucher. Postings under
+	// the approval exemption are not violations.
+	FindSoDViolations(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.SoDViolation, error)
+
+	// FindEntrySuggestions ranks the account, department and tax code
+	// combinations of the posted entries matching the filter
+	FindEntrySuggestions(ctx context.Context, filter EntrySuggestionFilter) ([]domain.EntrySuggestion, error)
+
 	// Number generation
 	GenerateVoucherNo(ctx context.Context, companyID uuid.UUID, voucherType domain.VoucherType, voucherDate time.Time) (string, error)
 
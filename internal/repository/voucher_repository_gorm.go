@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 
 	"github.com/saintgo7/saas-kerp/internal/domain"
 )
@@ -29,11 +31,15 @@ func (r *voucherRepositoryGorm) Create(ctx context.Context, voucher *domain.Vouc
 		if err := tx.Create(voucher).Error; err != nil {
 			return err
 		}
+		if err := registerVoucherTags(tx, voucher.CompanyID, voucher.Tags); err != nil {
+			return err
+		}
 
 		// Create entries
 		for i := range voucher.Entries {
 			voucher.Entries[i].VoucherID = voucher.ID
 			voucher.Entries[i].CompanyID = voucher.CompanyID
+			voucher.Entries[i].FiscalYear = voucher.FiscalYear()
 			if err := tx.Create(&voucher.Entries[i]).Error; err != nil {
 				return err
 			}
@@ -45,11 +51,22 @@ func (r *voucherRepositoryGorm) Create(ctx context.Context, voucher *domain.Vouc
 
 // Update modifies an existing voucher
 func (r *voucherRepositoryGorm) Update(ctx context.Context, voucher *domain.Voucher) error {
-	return r.db.WithContext(ctx).
-		Model(voucher).
-		Select("voucher_date", "voucher_type", "description", "reference_type", "reference_id",
-			"total_debit", "total_credit", "updated_by").
-		Updates(voucher).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(voucher).
+			Select("voucher_date", "voucher_type", "description", "reference_type", "reference_id",
+				"total_debit", "total_credit", "auto_reverse", "reversed_by_id", "corrected_by_id", "tags", "updated_by").
+			Updates(voucher).Error; err != nil {
+			return err
+		}
+		if err := registerVoucherTags(tx, voucher.CompanyID, voucher.Tags); err != nil {
+			return err
+		}
+
+		// Move the entries to the partition of the new year when the date changes years
+		return tx.Model(&domain.VoucherEntry{}).
+			Where("voucher_id = ? AND fiscal_year <> ?", voucher.ID, voucher.FiscalYear()).
+			Update("fiscal_year", voucher.FiscalYear()).Error
+	})
 }
 
 // Delete removes a voucher by ID (soft delete by setting status to cancelled)
@@ -73,6 +90,62 @@ func (r *voucherRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid
 			return db.Order("line_no ASC")
 		}).
 		Preload("Entries.Account").
+		Preload("Entries.Partner").
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&voucher).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *voucherRepositoryGorm) FindByIDForUpdate(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error) {
+	var voucher domain.Voucher
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Preload("Entries.Account").
+		Preload("Entries.Partner").
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&voucher).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+// FindDetailByID retrieves a voucher with entries, the vouchers it reverses or
+// corrects or is reversed or corrected by, the users of each workflow step and the status history
+func (r *voucherRepositoryGorm) FindDetailByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error) {
+	var voucher domain.Voucher
+	err := r.db.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Preload("Entries.Account").
+		Preload("Entries.Partner").
+		Preload("Entries.Department").
+		Preload("ReversalOf").
+		Preload("ReversedBy").
+		Preload("CorrectionOf").
+		Preload("CorrectedBy").
+		Preload("Creator").
+		Preload("Submitter").
+		Preload("Approver").
+		Preload("Rejecter").
+		Preload("Poster").
+		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("changed_at ASC")
+		}).
+		Preload("StatusHistory.User").
 		Where("company_id = ? AND id = ?", companyID, id).
 		First(&voucher).Error
 	if err != nil {
@@ -107,6 +180,48 @@ func (r *voucherRepositoryGorm) FindAll(ctx context.Context, filter VoucherFilte
 	var vouchers []domain.Voucher
 	var total int64
 
+	query := r.filteredQuery(ctx, filter)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply sorting
+	sortBy := "voucher_date DESC, voucher_no DESC"
+	if filter.SortBy != "" {
+		sortBy = filter.SortBy
+		if filter.SortDesc {
+			sortBy = sortBy + " DESC"
+		}
+	}
+	query = query.Order(sortBy)
+
+	// Apply pagination
+	if filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	// Include entries if requested
+	if filter.IncludeEntries {
+		query = query.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).Preload("Entries.Account")
+	}
+
+	if err := query.Find(&vouchers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return vouchers, total, nil
+}
+
+// filteredQuery builds the voucher query of the filter conditions, without sorting and pagination
+func (r *voucherRepositoryGorm) filteredQuery(ctx context.Context, filter VoucherFilter) *gorm.DB {
 	query := r.db.WithContext(ctx).Model(&domain.Voucher{}).
 		Where("company_id = ?", filter.CompanyID)
 
@@ -114,15 +229,42 @@ func (r *voucherRepositoryGorm) FindAll(ctx context.Context, filter VoucherFilte
 	if filter.VoucherType != nil {
 		query = query.Where("voucher_type = ?", *filter.VoucherType)
 	}
+	if len(filter.VoucherTypes) > 0 {
+		query = query.Where("voucher_type IN ?", filter.VoucherTypes)
+	}
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
 	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
 	if filter.DateFrom != nil {
 		query = query.Where("voucher_date >= ?", *filter.DateFrom)
 	}
 	if filter.DateTo != nil {
 		query = query.Where("voucher_date <= ?", *filter.DateTo)
 	}
+	if filter.MinAmount != nil {
+		query = query.Where("total_debit >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("total_debit <= ?", *filter.MaxAmount)
+	}
+	if filter.CreatedBy != nil {
+		query = query.Where("created_by = ?", *filter.CreatedBy)
+	}
+	if filter.ApprovedBy != nil {
+		query = query.Where("approved_by = ?", *filter.ApprovedBy)
+	}
+	if filter.VoucherNoPrefix != "" {
+		query = query.Where("voucher_no LIKE ?", escapeLike(filter.VoucherNoPrefix)+"%")
+	}
+	if filter.Unreferenced {
+		query = query.Where("reference_id IS NULL")
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where("tags @> ?::jsonb", voucherTagsJSON(filter.Tags))
+	}
 	if filter.SearchTerm != "" {
 		searchTerm := "%" + strings.ToLower(filter.SearchTerm) + "%"
 		query = query.Where("LOWER(voucher_no) LIKE ? OR LOWER(description) LIKE ?",
@@ -148,42 +290,54 @@ func (r *voucherRepositoryGorm) FindAll(ctx context.Context, filter VoucherFilte
 		query = query.Where("id IN (?)", subQuery)
 	}
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+	return query
+}
 
-	// Apply sorting
-	sortBy := "voucher_date DESC, voucher_no DESC"
-	if filter.SortBy != "" {
-		sortBy = filter.SortBy
-		if filter.SortDesc {
-			sortBy = sortBy + " DESC"
-		}
-	}
-	query = query.Order(sortBy)
+// escapeLike escapes the LIKE wildcards of s so that it matches literally
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
 
-	// Apply pagination
-	if filter.PageSize > 0 {
-		offset := (filter.Page - 1) * filter.PageSize
-		if offset < 0 {
-			offset = 0
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// voucherIteratorBatchSize is the number of vouchers read per query by FindAllIterator
+const voucherIteratorBatchSize = 500
+
+// FindAllIterator calls fn for each voucher matching the filter in date and number order.
+// Vouchers are read in batches that continue after the last voucher of the previous
+// batch, so memory use does not grow with the number of vouchers and no query stays
+// open while fn runs. Pagination and sorting options of the filter are ignored.
+func (r *voucherRepositoryGorm) FindAllIterator(ctx context.Context, filter VoucherFilter, fn func(voucher *domain.Voucher) error) error {
+	var last *domain.Voucher
+	for {
+		query := r.filteredQuery(ctx, filter)
+		if last != nil {
+			// The date is passed as text so that it is compared as a DATE, not a timestamp
+			query = query.Where("(voucher_date, voucher_no, id) > (?, ?, ?)",
+				last.VoucherDate.Format("2006-01-02"), last.VoucherNo, last.ID)
 		}
-		query = query.Offset(offset).Limit(filter.PageSize)
-	}
+		query = query.Order("voucher_date, voucher_no, id").Limit(voucherIteratorBatchSize)
 
-	// Include entries if requested
-	if filter.IncludeEntries {
-		query = query.Preload("Entries", func(db *gorm.DB) *gorm.DB {
-			return db.Order("line_no ASC")
-		}).Preload("Entries.Account")
-	}
+		if filter.IncludeEntries {
+			query = query.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+				return db.Order("line_no ASC")
+			}).Preload("Entries.Account")
+		}
 
-	if err := query.Find(&vouchers).Error; err != nil {
-		return nil, 0, err
+		var vouchers []domain.Voucher
+		if err := query.Find(&vouchers).Error; err != nil {
+			return err
+		}
+		for i := range vouchers {
+			if err := fn(&vouchers[i]); err != nil {
+				return err
+			}
+		}
+		if len(vouchers) < voucherIteratorBatchSize {
+			return nil
+		}
+		last = &vouchers[len(vouchers)-1]
 	}
-
-	return vouchers, total, nil
 }
 
 // FindByDateRange retrieves vouchers within a date range
@@ -206,9 +360,60 @@ func (r *voucherRepositoryGorm) FindByStatus(ctx context.Context, companyID uuid
 	return vouchers, err
 }
 
+// FindPendingAutoReversals retrieves posted auto-reversing vouchers not yet reversed
+func (r *voucherRepositoryGorm) FindPendingAutoReversals(ctx context.Context, before time.Time) ([]domain.Voucher, error) {
+	var vouchers []domain.Voucher
+	err := r.db.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Where("auto_reverse = ? AND status = ? AND is_reversal = ? AND reversed_by_id IS NULL", true, domain.VoucherStatusPosted, false).
+		Where("voucher_date < ?", before).
+		Order("company_id, voucher_date ASC, voucher_no ASC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+// FindScheduled retrieves the approved vouchers scheduled for posting, next due first
+func (r *voucherRepositoryGorm) FindScheduled(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error) {
+	var vouchers []domain.Voucher
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND status = ? AND scheduled_post_date IS NOT NULL", companyID, domain.VoucherStatusApproved).
+		Order("scheduled_post_date ASC, voucher_no ASC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+// FindDueScheduledPostings retrieves approved vouchers whose scheduled posting date has arrived
+func (r *voucherRepositoryGorm) FindDueScheduledPostings(ctx context.Context, asOf time.Time) ([]domain.Voucher, error) {
+	var vouchers []domain.Voucher
+	err := r.db.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Where("status = ? AND scheduled_post_date <= ?", domain.VoucherStatusApproved, asOf).
+		Order("company_id, scheduled_post_date ASC, voucher_no ASC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
 // CreateEntry inserts a new voucher entry
 func (r *voucherRepositoryGorm) CreateEntry(ctx context.Context, entry *domain.VoucherEntry) error {
-	return r.db.WithContext(ctx).Create(entry).Error
+	db := r.db.WithContext(ctx)
+	if entry.FiscalYear == 0 {
+		// The partition key comes from the voucher date
+		err := db.Model(&domain.Voucher{}).
+			Select("EXTRACT(YEAR FROM voucher_date)::INTEGER").
+			Where("id = ?", entry.VoucherID).
+			Scan(&entry.FiscalYear).Error
+		if err != nil {
+			return err
+		}
+		if entry.FiscalYear == 0 {
+			return domain.ErrVoucherNotFound
+		}
+	}
+	return db.Create(entry).Error
 }
 
 // UpdateEntry modifies an existing entry
@@ -216,7 +421,8 @@ func (r *voucherRepositoryGorm) UpdateEntry(ctx context.Context, entry *domain.V
 	return r.db.WithContext(ctx).
 		Model(entry).
 		Select("line_no", "account_id", "debit_amount", "credit_amount", "description",
-			"partner_id", "department_id", "project_id", "cost_center_id", "tags").
+			"partner_id", "department_id", "project_id", "cost_center_id", "tags",
+			"tax_code_id", "tax_amount", "is_tax_line").
 		Updates(entry).Error
 }
 
@@ -250,6 +456,7 @@ func (r *voucherRepositoryGorm) FindEntriesByAccount(ctx context.Context, compan
 		Joins("JOIN vouchers v ON voucher_entries.voucher_id = v.id").
 		Where("voucher_entries.company_id = ? AND voucher_entries.account_id = ?", companyID, accountID).
 		Where("v.voucher_date >= ? AND v.voucher_date <= ?", from, to).
+		Where("voucher_entries.fiscal_year BETWEEN ? AND ?", from.Year(), to.Year()).
 		Where("v.status = ?", domain.VoucherStatusPosted).
 		Order("v.voucher_date, v.voucher_no, voucher_entries.line_no").
 		Find(&entries).Error
@@ -277,6 +484,7 @@ func (r *voucherRepositoryGorm) UpdateStatus(ctx context.Context, voucher *domai
 	case domain.VoucherStatusPosted:
 		updates["posted_at"] = voucher.PostedAt
 		updates["posted_by"] = voucher.PostedBy
+		updates["approval_exempt_reason"] = voucher.ApprovalExemptReason
 	}
 
 	return r.db.WithContext(ctx).
@@ -285,11 +493,155 @@ func (r *voucherRepositoryGorm) UpdateStatus(ctx context.Context, voucher *domai
 		Updates(updates).Error
 }
 
+// UpdateReference updates the reference document of a voucher
+func (r *voucherRepositoryGorm) UpdateReference(ctx context.Context, voucher *domain.Voucher) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Voucher{}).
+		Where("id = ? AND company_id = ?", voucher.ID, voucher.CompanyID).
+		Updates(map[string]interface{}{
+			"reference_type": voucher.ReferenceType,
+			"reference_id":   voucher.ReferenceID,
+			"updated_by":     voucher.UpdatedBy,
+			"updated_at":     time.Now(),
+		}).Error
+}
+
+// UpdateTags updates the tags of a voucher and registers the new ones
+func (r *voucherRepositoryGorm) UpdateTags(ctx context.Context, voucher *domain.Voucher) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(voucher).
+			Where("company_id = ?", voucher.CompanyID).
+			Select("tags", "updated_by", "updated_at").
+			Updates(voucher).Error; err != nil {
+			return err
+		}
+		return registerVoucherTags(tx, voucher.CompanyID, voucher.Tags)
+	})
+}
+
+// UpdateSchedule updates the scheduled posting of a voucher
+func (r *voucherRepositoryGorm) UpdateSchedule(ctx context.Context, voucher *domain.Voucher) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Voucher{}).
+		Where("id = ? AND company_id = ?", voucher.ID, voucher.CompanyID).
+		Updates(map[string]interface{}{
+			"scheduled_post_date": voucher.ScheduledPostDate,
+			"scheduled_by":        voucher.ScheduledBy,
+			"updated_at":          time.Now(),
+		}).Error
+}
+
+// UpdateReversedAmounts updates the reversed amounts of a voucher and its entries
+func (r *voucherRepositoryGorm) UpdateReversedAmounts(ctx context.Context, voucher *domain.Voucher) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Voucher{}).
+			Where("id = ? AND company_id = ?", voucher.ID, voucher.CompanyID).
+			Updates(map[string]interface{}{
+				"reversed_amount": voucher.ReversedAmount,
+				"reversed_by_id":  voucher.ReversedByID,
+				"updated_at":      time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+
+		for _, entry := range voucher.Entries {
+			if err := tx.Model(&domain.VoucherEntry{}).
+				Where("id = ? AND fiscal_year = ?", entry.ID, voucher.FiscalYear()).
+				Update("reversed_amount", entry.ReversedAmount).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindSoDViolations finds approvals and postings by the voucher's creator or submitter
+func (r *voucherRepositoryGorm) FindSoDViolations(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.SoDViolation, error) {
+	var violations []domain.SoDViolation
+	err := r.db.WithContext(ctx).
+		Table("voucher_status_history h").
+		Select(`v.id AS voucher_id, v.voucher_no, v.voucher_date, v.voucher_type, v.total_debit,
+			h.to_status AS action, h.changed_by AS user_id, u.name AS user_name,
+			CASE WHEN h.changed_by = v.submitted_by THEN 'submitter' ELSE 'creator' END AS role,
+			h.changed_at`).
+		Joins("JOIN vouchers v ON v.id = h.voucher_id").
+		Joins("LEFT JOIN users u ON u.id = h.changed_by").
+		Where("h.company_id = ? AND h.changed_at >= ? AND h.changed_at < ?", companyID, from, to).
+		Where("h.to_status IN ?", []domain.VoucherStatus{domain.VoucherStatusApproved, domain.VoucherStatusPosted}).
+		Where("h.changed_by = v.created_by OR h.changed_by = v.submitted_by").
+		Where("NOT (h.to_status = ? AND h.reason IS NOT NULL)", domain.VoucherStatusPosted).
+		Order("h.changed_at DESC").
+		Scan(&violations).Error
+	return violations, err
+}
+
+// FindEntrySuggestions groups the matching entries by account, department and
+// tax code. Tax lines and the accounts, departments and tax codes that can no
+// longer be used are left out.
+func (r *voucherRepositoryGorm) FindEntrySuggestions(ctx context.Context, filter EntrySuggestionFilter) ([]domain.EntrySuggestion, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.PartnerID != nil {
+		conditions = append(conditions, "ve.partner_id = ?")
+		args = append(args, *filter.PartnerID)
+	}
+	if len(filter.Keywords) > 0 {
+		keywords := make([]string, len(filter.Keywords))
+		for i, keyword := range filter.Keywords {
+			keywords[i] = "ve.description ILIKE ? OR v.description ILIKE ?"
+			like := "%" + escapeLike(keyword) + "%"
+			args = append(args, like, like)
+		}
+		conditions = append(conditions, "("+strings.Join(keywords, " OR ")+")")
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	// Each condition met scores the entry once
+	score := make([]string, len(conditions))
+	for i, condition := range conditions {
+		score[i] = "CASE WHEN " + condition + " THEN 1 ELSE 0 END"
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			ve.account_id, a.code AS account_code, a.name AS account_name,
+			ve.department_id, COALESCE(d.code, '') AS department_code, COALESCE(d.name, '') AS department_name,
+			ve.tax_code_id, COALESCE(t.code, '') AS tax_code, COALESCE(t.name, '') AS tax_code_name,
+			COUNT(*) AS use_count, SUM(%s) AS score, MAX(v.voucher_date) AS last_used_at
+		FROM voucher_entries ve
+		JOIN vouchers v ON ve.voucher_id = v.id
+		JOIN accounts a ON ve.account_id = a.id
+		LEFT JOIN departments d ON ve.department_id = d.id
+		LEFT JOIN tax_codes t ON ve.tax_code_id = t.id
+		WHERE ve.company_id = ?
+			AND ve.fiscal_year >= ?
+			AND v.status = ?
+			AND v.voucher_date >= ?
+			AND ve.is_tax_line = false
+			AND a.is_active = true AND a.allow_direct_posting = true AND a.is_control_account = false
+			AND (d.id IS NULL OR d.is_active = true)
+			AND (t.id IS NULL OR t.is_active = true)
+			AND (%s)
+		GROUP BY ve.account_id, a.code, a.name, ve.department_id, d.code, d.name, ve.tax_code_id, t.code, t.name
+		ORDER BY score DESC, use_count DESC, last_used_at DESC, a.code
+		LIMIT ?
+	`, strings.Join(score, " + "), strings.Join(conditions, " OR "))
+
+	all := append(append([]interface{}{}, args...), filter.CompanyID, filter.Since.Year(), domain.VoucherStatusPosted, filter.Since)
+	all = append(append(all, args...), filter.Limit)
+
+	var suggestions []domain.EntrySuggestion
+	err := r.db.WithContext(ctx).Raw(query, all...).Scan(&suggestions).Error
+	return suggestions, err
+}
+
 // GenerateVoucherNo generates a unique voucher number
 func (r *voucherRepositoryGorm) GenerateVoucherNo(ctx context.Context, companyID uuid.UUID, voucherType domain.VoucherType, voucherDate time.Time) (string, error) {
 	var voucherNo string
 
-	err := r.db.WithContext(ctx).Raw(
+	// The function updates voucher_sequences; it must not be routed to a replica
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Write).Raw(
 		"SELECT generate_voucher_number(?, ?, ?)",
 		companyID, string(voucherType), voucherDate,
 	).Scan(&voucherNo).Error
@@ -300,7 +652,7 @@ func (r *voucherRepositoryGorm) GenerateVoucherNo(ctx context.Context, companyID
 		prefix := voucherType.GetPrefix()
 
 		var lastNumber int
-		r.db.WithContext(ctx).
+		r.db.WithContext(ctx).Clauses(dbresolver.Write).
 			Table("voucher_sequences").
 			Select("last_number").
 			Where("company_id = ? AND fiscal_year = ? AND voucher_type = ?",
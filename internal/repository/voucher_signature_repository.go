@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// VoucherSignatureRepository defines the interface for approval signature data access.
+// Signatures are append-only audit records.
+type VoucherSignatureRepository interface {
+	Create(ctx context.Context, signature *domain.VoucherSignature) error
+	FindByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherSignature, error)
+}
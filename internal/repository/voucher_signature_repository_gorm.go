@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// voucherSignatureRepositoryGorm implements VoucherSignatureRepository using GORM
+type voucherSignatureRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewVoucherSignatureRepository creates a new GORM-based voucher signature repository
+func NewVoucherSignatureRepository(db *gorm.DB) VoucherSignatureRepository {
+	return &voucherSignatureRepositoryGorm{db: db}
+}
+
+func (r *voucherSignatureRepositoryGorm) Create(ctx context.Context, signature *domain.VoucherSignature) error {
+	return r.db.WithContext(ctx).Omit("User").Create(signature).Error
+}
+
+func (r *voucherSignatureRepositoryGorm) FindByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherSignature, error) {
+	var signatures []domain.VoucherSignature
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("company_id = ? AND voucher_id = ?", companyID, voucherID).
+		Order("signed_at ASC").
+		Find(&signatures).Error
+	return signatures, err
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// VoucherTagRepository defines the interface for the company's voucher tags
+type VoucherTagRepository interface {
+	// CRUD operations
+	Create(ctx context.Context, tag *domain.VoucherTag) error
+
+	// Update saves the tag; a new name is applied to the vouchers carrying the old one
+	Update(ctx context.Context, tag *domain.VoucherTag, oldName string) error
+
+	// Delete deletes the tag and removes it from the vouchers carrying it
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Query operations
+	FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherTag, error)
+	FindByName(ctx context.Context, companyID uuid.UUID, name string) (*domain.VoucherTag, error)
+
+	// FindAll returns the company's tags by name with their voucher counts
+	FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.VoucherTag, error)
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// voucherTagRepositoryGorm implements VoucherTagRepository using GORM
+type voucherTagRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewVoucherTagRepository creates a new GORM-based voucher tag repository
+func NewVoucherTagRepository(db *gorm.DB) VoucherTagRepository {
+	return &voucherTagRepositoryGorm{db: db}
+}
+
+func (r *voucherTagRepositoryGorm) Create(ctx context.Context, tag *domain.VoucherTag) error {
+	return r.db.WithContext(ctx).Create(tag).Error
+}
+
+func (r *voucherTagRepositoryGorm) Update(ctx context.Context, tag *domain.VoucherTag, oldName string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(tag).
+			Select("name", "description", "updated_at").
+			Updates(tag).Error; err != nil {
+			return err
+		}
+		if tag.Name == oldName {
+			return nil
+		}
+		// Drop the old name, and add the new one unless the voucher already has it
+		return tx.Exec(`
+			UPDATE vouchers SET
+				tags = CASE WHEN tags @> ?::jsonb THEN tags - ?::text ELSE (tags - ?::text) || ?::jsonb END
+			WHERE company_id = ? AND tags @> ?::jsonb`,
+			voucherTagsJSON([]string{tag.Name}), oldName, oldName, voucherTagsJSON([]string{tag.Name}),
+			tag.CompanyID, voucherTagsJSON([]string{oldName})).Error
+	})
+}
+
+func (r *voucherTagRepositoryGorm) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var tag domain.VoucherTag
+		if err := tx.Where("company_id = ? AND id = ?", companyID, id).First(&tag).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return domain.ErrVoucherTagNotFound
+			}
+			return err
+		}
+		if err := tx.Exec(`UPDATE vouchers SET tags = tags - ?::text WHERE company_id = ? AND tags @> ?::jsonb`,
+			tag.Name, companyID, voucherTagsJSON([]string{tag.Name})).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag).Error
+	})
+}
+
+func (r *voucherTagRepositoryGorm) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherTag, error) {
+	var tag domain.VoucherTag
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND id = ?", companyID, id).
+		First(&tag).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrVoucherTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *voucherTagRepositoryGorm) FindByName(ctx context.Context, companyID uuid.UUID, name string) (*domain.VoucherTag, error) {
+	var tag domain.VoucherTag
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND name = ?", companyID, name).
+		First(&tag).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrVoucherTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *voucherTagRepositoryGorm) FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.VoucherTag, error) {
+	var tags []domain.VoucherTag
+	err := r.db.WithContext(ctx).
+		Select(`voucher_tags.*,
+			(SELECT COUNT(*) FROM vouchers v
+			WHERE v.company_id = voucher_tags.company_id AND v.tags @> jsonb_build_array(voucher_tags.name)) AS voucher_count`).
+		Where("company_id = ?", companyID).
+		Order("name ASC").
+		Find(&tags).Error
+	return tags, err
+}
+
+// registerVoucherTags adds the tags of a voucher the company does not have yet
+func registerVoucherTags(tx *gorm.DB, companyID uuid.UUID, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	tags := make([]domain.VoucherTag, len(names))
+	for i, name := range names {
+		tags[i].CompanyID = companyID
+		tags[i].Name = name
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}, {Name: "name"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+}
+
+// voucherTagsJSON encodes tag names as the jsonb array matched by the tags containment operator
+func voucherTagsJSON(names []string) string {
+	data, _ := json.Marshal(names)
+	return string(data)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// YearRolloverRepository defines the interface for year rollover run persistence
+type YearRolloverRepository interface {
+	Create(ctx context.Context, run *domain.YearRolloverRun) error
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.YearRolloverRun, error)
+	Exists(ctx context.Context, companyID uuid.UUID, fromYear int) (bool, error)
+
+	// FindDue returns the companies that opened the fiscal periods of toYear
+	// and have ledger balances of the year before that are not rolled over
+	FindDue(ctx context.Context, toYear int) ([]uuid.UUID, error)
+}
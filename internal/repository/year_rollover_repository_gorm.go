@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// yearRolloverRepositoryGorm implements YearRolloverRepository using GORM
+type yearRolloverRepositoryGorm struct {
+	db *gorm.DB
+}
+
+// NewYearRolloverRepository creates a new GORM-based year rollover repository
+func NewYearRolloverRepository(db *gorm.DB) YearRolloverRepository {
+	return &yearRolloverRepositoryGorm{db: db}
+}
+
+func (r *yearRolloverRepositoryGorm) Create(ctx context.Context, run *domain.YearRolloverRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *yearRolloverRepositoryGorm) List(ctx context.Context, companyID uuid.UUID) ([]domain.YearRolloverRun, error) {
+	var runs []domain.YearRolloverRun
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("from_year DESC").
+		Find(&runs).Error
+	return runs, err
+}
+
+func (r *yearRolloverRepositoryGorm) Exists(ctx context.Context, companyID uuid.UUID, fromYear int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.YearRolloverRun{}).
+		Where("company_id = ? AND from_year = ?", companyID, fromYear).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *yearRolloverRepositoryGorm) FindDue(ctx context.Context, toYear int) ([]uuid.UUID, error) {
+	var companyIDs []uuid.UUID
+
+	query := `
+		SELECT DISTINCT fp.company_id
+		FROM fiscal_periods fp
+		WHERE fp.fiscal_year = ?
+			AND EXISTS (
+				SELECT 1 FROM ledger_balances lb
+				WHERE lb.company_id = fp.company_id AND lb.fiscal_year = ?
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM year_rollover_runs yr
+				WHERE yr.company_id = fp.company_id AND yr.from_year = ?
+			)
+		ORDER BY fp.company_id
+	`
+
+	err := r.db.WithContext(ctx).Raw(query, toYear, toYear-1, toYear-1).Scan(&companyIDs).Error
+	return companyIDs, err
+}
@@ -0,0 +1,41 @@
+package router
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+)
+
+var publishOnce sync.Once
+
+// NewDiagnosticsServer returns the server of the diagnostics listener, with
+// the pprof profiles under /debug/pprof/ and the expvar variables under
+// /debug/vars. It is not authenticated, so it must listen on an internal
+// address only; the API serves a summary to super admins instead.
+func NewDiagnosticsServer(cfg *config.DiagnosticsConfig) *http.Server {
+	publishOnce.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() interface{} { return runtime.NumGoroutine() }))
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	// CPU profiles and traces stream for as long as they are asked to, so
+	// there is no write timeout
+	return &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+}
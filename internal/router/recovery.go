@@ -0,0 +1,68 @@
+package router
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/config"
+	"github.com/saintgo7/saas-kerp/internal/external/sentry"
+	"github.com/saintgo7/saas-kerp/internal/middleware"
+)
+
+// newPanicReporter returns the reporter of the configured error tracker, or
+// nil when panics are only logged
+func newPanicReporter(cfg *config.Config, logger *zap.Logger) middleware.PanicReporter {
+	switch cfg.Errors.Reporter {
+	case "sentry":
+		client, err := sentry.NewClient(&sentry.Config{
+			DSN:         cfg.Errors.SentryDSN,
+			Environment: cfg.App.Env,
+			Release:     cfg.App.Version,
+			Timeout:     cfg.Errors.Timeout,
+		})
+		if err != nil {
+			logger.Error("Panics are not reported", zap.Error(err))
+			return nil
+		}
+		return &sentryReporter{client: client, logger: logger}
+	}
+	return nil
+}
+
+// sentryReporter reports panics as Sentry events
+type sentryReporter struct {
+	client *sentry.Client
+	logger *zap.Logger
+}
+
+func (r *sentryReporter) ReportPanic(ctx context.Context, report *middleware.PanicReport) {
+	event := &sentry.Event{
+		Level:     "fatal",
+		Timestamp: report.Time,
+		Exception: &sentry.Exceptions{Values: []sentry.Exception{{
+			Type:       "panic",
+			Value:      fmt.Sprint(report.Value),
+			Stacktrace: sentry.NewStacktrace(report.Frames),
+		}}},
+		Request: &sentry.Request{Method: report.Method, URL: report.URL},
+		Tags: map[string]string{
+			"request_id": report.CorrelationID,
+			"route":      report.Route,
+		},
+	}
+	if report.UserID != "" {
+		event.User = &sentry.User{ID: report.UserID}
+	}
+	if report.CompanyID != "" {
+		event.Tags["company_id"] = report.CompanyID
+	}
+
+	if _, err := r.client.Capture(ctx, event); err != nil {
+		r.logger.Warn("Failed to report panic to Sentry",
+			zap.String("request_id", report.CorrelationID),
+			zap.Error(err),
+		)
+	}
+}
@@ -17,6 +17,7 @@ type Router struct {
 	logger     *zap.Logger
 	jwtService *auth.JWTService
 	handlers   *handler.Handlers
+	rateLimit  *middleware.RateLimitSettings
 }
 
 // New creates a new router with all middleware and routes configured
@@ -52,15 +53,47 @@ func (r *Router) setupMiddleware() {
 	// Logger (skip health check endpoints)
 	r.engine.Use(middleware.Logger(r.logger))
 
-	// Recovery from panics
-	r.engine.Use(middleware.Recovery(r.logger))
+	// Problem details for clients that accept them; before locale so that
+	// the messages it converts are localized
+	r.engine.Use(middleware.ProblemDetails())
+
+	// Response locale; before recovery so panic responses are localized too
+	r.engine.Use(middleware.Locale())
+
+	// Recovery from panics, reported to the error tracker
+	r.engine.Use(middleware.Recovery(r.logger, newPanicReporter(r.config, r.logger)))
 
 	// CORS
 	r.engine.Use(middleware.CORS(&r.config.CORS))
 
-	// Rate limiting (if enabled)
-	if r.config.RateLimit.Enabled {
-		r.engine.Use(middleware.RateLimit(&r.config.RateLimit))
+	// Primary or replica database for the request's reads
+	r.engine.Use(middleware.DBRouting())
+
+	// Security headers and request body limits
+	r.engine.Use(middleware.SecurityHeaders(&r.config.Security))
+	r.engine.Use(middleware.BodyLimit(&r.config.Security))
+
+	// Rate limiting; installed when disabled too so that a reload can enable it
+	r.rateLimit = middleware.NewRateLimitSettings(&r.config.RateLimit)
+	r.engine.Use(r.rateLimit.Middleware())
+
+	// Depth and array limits of JSON bodies; after rate limiting so that
+	// throttled requests are not parsed
+	r.engine.Use(middleware.JSONLimits(&r.config.Security, arrayLimits(&r.config.Security)))
+}
+
+// arrayLimits caps the arrays of the routes that take many items at once
+func arrayLimits(cfg *config.SecurityConfig) map[string]middleware.ArrayLimit {
+	entries := middleware.ArrayLimit{Field: "entries", Max: cfg.MaxVoucherEntries}
+	return map[string]middleware.ArrayLimit{
+		"POST /api/v1/vouchers":                     entries,
+		"PUT /api/v1/vouchers/:id":                  entries,
+		"PUT /api/v1/vouchers/:id/entries":          {Max: cfg.MaxVoucherEntries},
+		"POST /api/v1/vouchers/:id/correct":         entries,
+		"POST /api/v1/vouchers/:id/reverse-partial": {Field: "lines", Max: cfg.MaxVoucherEntries},
+		"POST /api/v1/partners/activate":            {Field: "ids", Max: cfg.MaxBulkItems},
+		"POST /api/v1/partners/deactivate":          {Field: "ids", Max: cfg.MaxBulkItems},
+		"POST /api/v1/tax-invoices/bulk-issue":      {Field: "invoice_ids", Max: cfg.MaxBulkItems},
 	}
 }
 
@@ -70,6 +103,10 @@ func (r *Router) setupRoutes() {
 	r.engine.GET("/health", r.handlers.Health.Check)
 	r.engine.GET("/health/ready", r.handlers.Health.Ready)
 	r.engine.GET("/health/live", r.handlers.Health.Live)
+	r.engine.GET("/health/redis", r.handlers.Health.RedisStatus)
+
+	// Public JWT verification keys (no auth required)
+	r.engine.GET("/.well-known/jwks.json", r.handlers.Auth.JWKS)
 
 	// API routes
 	api := r.engine.Group("/api")
@@ -78,6 +115,11 @@ func (r *Router) setupRoutes() {
 	RegisterV1Routes(api, r.jwtService, r.handlers)
 }
 
+// RateLimit returns the rate limit settings, for applying reloaded configuration
+func (r *Router) RateLimit() *middleware.RateLimitSettings {
+	return r.rateLimit
+}
+
 // Engine returns the underlying gin.Engine
 func (r *Router) Engine() *gin.Engine {
 	return r.engine
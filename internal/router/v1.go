@@ -34,7 +34,22 @@ func registerPublicRoutes(v1 *gin.RouterGroup, h *handler.Handlers) {
 		auth.POST("/login", h.Auth.Login)
 		auth.POST("/register", h.Auth.Register)
 		auth.POST("/forgot-password", h.Auth.ForgotPassword)
+		auth.GET("/invitations/:token", h.Auth.GetInvitation)
+		auth.POST("/invitations/accept", h.Auth.AcceptInvitation)
+		auth.POST("/verify-email", h.Auth.VerifyEmail)
 	}
+
+	// Data export downloads are authorized by the signed link
+	v1.GET("/exports/:id/download", h.DataExport.Download)
+
+	// Popbill callbacks are authorized by their signature
+	v1.POST("/webhooks/popbill", h.PopbillWebhook.Receive)
+
+	// Email bounce notices are authorized by the shared token
+	v1.POST("/webhooks/email/bounces", h.TaxInvoiceSend.ReceiveBounce)
+
+	// Inbound supplier invoices are authorized by the shared token
+	v1.POST("/webhooks/email/inbound", h.Inbox.Receive)
 }
 
 // registerProtectedRoutes registers routes that require authentication but not tenant context
@@ -44,8 +59,19 @@ func registerProtectedRoutes(protected *gin.RouterGroup, h *handler.Handlers) {
 		auth.POST("/refresh", h.Auth.Refresh)
 		auth.POST("/logout", h.Auth.Logout)
 		auth.GET("/me", h.Auth.Me)
+		auth.GET("/companies", h.Auth.Companies)
+		auth.POST("/switch-company", h.Auth.SwitchCompany)
 		auth.PUT("/password", h.Auth.ChangePassword)
+		auth.POST("/verify-email/resend", h.Auth.ResendVerification)
 	}
+
+	// Platform operator routes
+	operator := protected.Group("", middleware.RequireSuperAdmin())
+	h.Diagnostics.RegisterRoutes(operator)
+	h.Backup.RegisterRoutes(operator)
+	h.Ledger.RegisterOperatorRoutes(operator)
+	h.Job.RegisterOperatorRoutes(operator)
+	h.ScheduledTask.RegisterOperatorRoutes(operator)
 }
 
 // registerTenantRoutes registers routes that require both authentication and tenant context
@@ -53,19 +79,117 @@ func registerTenantRoutes(tenant *gin.RouterGroup, h *handler.Handlers) {
 	// Accounting routes
 	h.Account.RegisterRoutes(tenant)
 	h.Partner.RegisterRoutes(tenant)
+	h.PartnerVerify.RegisterRoutes(tenant)
 	h.Voucher.RegisterRoutes(tenant)
+	h.VoucherTag.RegisterRoutes(tenant)
+	h.Activity.RegisterRoutes(tenant)
+	h.DocumentLink.RegisterRoutes(tenant)
 	h.Ledger.RegisterRoutes(tenant)
+	h.CloseChecklist.RegisterRoutes(tenant)
+	h.TaxCode.RegisterRoutes(tenant)
+	h.Receipt.RegisterRoutes(tenant)
+	h.VoucherPrint.RegisterRoutes(tenant)
+	h.Attachment.RegisterRoutes(tenant)
+	h.ReportSchedule.RegisterRoutes(tenant)
+	h.ReportDef.RegisterRoutes(tenant)
+	h.DepartmentPL.RegisterRoutes(tenant)
+	h.PartnerLedger.RegisterRoutes(tenant)
+	h.CashBook.RegisterRoutes(tenant)
 
 	// User management routes
 	h.User.RegisterRoutes(tenant)
+	h.Invitation.RegisterRoutes(tenant.Group("", middleware.RequireAdmin()))
 
 	// Role management routes
 	h.Role.RegisterRoutes(tenant)
 
 	// Company settings routes
 	h.Company.RegisterRoutes(tenant)
+	h.CompanySettings.RegisterRoutes(tenant)
 
 	// Project management routes
 	h.Project.RegisterRoutes(tenant)
+
+	// Tax invoice routes
+	h.TaxInvoiceBulk.RegisterRoutes(tenant)
+	h.TaxInvoiceAmend.RegisterRoutes(tenant)
+	h.APMatching.RegisterRoutes(tenant)
+	h.Inbox.RegisterRoutes(tenant)
+	h.Loan.RegisterRoutes(tenant)
+	h.Grant.RegisterRoutes(tenant)
+	h.AllocationRun.RegisterRoutes(tenant)
+	h.VoucherAnomaly.RegisterRoutes(tenant)
+	h.Compliance.RegisterRoutes(tenant)
+	h.TaxInvoiceSend.RegisterRoutes(tenant)
+
+	// Subscription plan routes
+	h.Plan.RegisterRoutes(tenant)
+
+	// Data export and legacy import routes
+	h.DataExport.RegisterRoutes(tenant.Group("", middleware.RequireAdmin()))
+	h.LegacyImport.RegisterRoutes(tenant.Group("", middleware.RequireAdmin()))
+	h.Credential.RegisterRoutes(tenant.Group("", middleware.RequireAdmin()))
+	h.Usage.RegisterRoutes(tenant.Group("", middleware.RequireAdmin()))
+	h.PopbillWebhook.RegisterRoutes(tenant.Group("", middleware.RequireAdmin()))
+
+	// Data retention and deletion requests; admin checks are per route
+	h.DataRetention.RegisterRoutes(tenant)
+
+	// Background jobs queued by the company's requests
+	h.Job.RegisterRoutes(tenant)
+
+	// Email templates; reads and previews are open, changes need an admin
+	h.EmailTemplate.RegisterRoutes(tenant)
+
+	// Approval inbox and push devices of the mobile app
+	h.Approval.RegisterRoutes(tenant)
+
+	// Main screen summary
+	h.Dashboard.RegisterRoutes(tenant)
+
+	// KPI series of the dashboard charts
+	h.Analytics.RegisterRoutes(tenant)
+
+	// Voucher entry autocomplete from posted history
+	h.EntrySuggestion.RegisterRoutes(tenant)
+
+	// Favorite and recent accounts and partners of entry forms
+	h.Shortcut.RegisterRoutes(tenant)
+
+	// Vouchers typed as entry rows with codes
+	h.QuickVoucher.RegisterRoutes(tenant)
+
+	// Bank accounts of the company and their reported balances
+	h.BankAccount.RegisterRoutes(tenant)
+
+	// Bulk transfer files of the payables due and their execution
+	h.PaymentBatch.RegisterRoutes(tenant)
+
+	// Notes and checks received and issued, discounted and settled
+	h.Note.RegisterRoutes(tenant)
+
+	// Petty cash funds of departments, their claims, replenishment and reconciliation
+	h.PettyCash.RegisterRoutes(tenant)
+
+	// Advances paid to employees, their settlements and the outstanding report
+	h.EmployeeAdvance.RegisterRoutes(tenant)
+
+	// Travel expense policies the settlements of advances are checked against
+	h.TravelPolicy.RegisterRoutes(tenant)
+
+	// Document library with folder permissions, file versions and links to documents
+	h.Document.RegisterRoutes(tenant)
+
+	// Contract register with billing milestones, invoice drafts and collections
+	h.Contract.RegisterRoutes(tenant)
+
+	// Revenue recognition schedules of deferred revenue with the waterfall report
+	h.RevenueSchedule.RegisterRoutes(tenant)
+
+	// Prepaid expenses amortized monthly with their remaining balances
+	h.PrepaidExpense.RegisterRoutes(tenant)
+
+	// Inventory items and stocktakes posting the count variances on approval
+	h.InventoryCount.RegisterRoutes(tenant)
 }
 
@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+)
+
+// LocalKeyManager wraps data keys with master keys held in process memory,
+// typically supplied through the environment
+type LocalKeyManager struct {
+	keyID string
+	keys  map[string][]byte
+}
+
+// NewLocalKeyManager creates a key manager from base64 encoded 32-byte master
+// keys by ID. keyID selects the key new data keys are wrapped with; the other
+// keys are only used to unwrap data keys sealed before a rotation.
+func NewLocalKeyManager(keyID string, keys map[string]string) (*LocalKeyManager, error) {
+	m := &LocalKeyManager{keyID: keyID, keys: make(map[string][]byte, len(keys))}
+	for id, encoded := range keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != dataKeySize {
+			return nil, ErrInvalidMasterKey
+		}
+		m.keys[id] = key
+	}
+	if _, ok := m.keys[keyID]; !ok {
+		return nil, ErrUnknownKey
+	}
+	return m, nil
+}
+
+// KeyID returns the ID of the current master key
+func (m *LocalKeyManager) KeyID() string {
+	return m.keyID
+}
+
+// WrapKey encrypts a data key with the current master key
+func (m *LocalKeyManager) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	return encrypt(m.keys[m.keyID], dataKey, []byte(m.keyID))
+}
+
+// UnwrapKey decrypts a data key with the master key it was wrapped with
+func (m *LocalKeyManager) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := m.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return decrypt(key, wrapped, []byte(keyID))
+}
@@ -0,0 +1,119 @@
+// Package secrets implements envelope encryption of tenant secrets such as
+// integration credentials.
+//
+// Every value is encrypted with its own random data key using AES-256-GCM.
+// The data key is in turn encrypted ("wrapped") by a KeyManager holding the
+// master key: a local key supplied through configuration or the environment,
+// or a key managed by an external KMS such as the Vault transit engine. Only
+// the wrapped data key is stored next to the ciphertext, together with the ID
+// of the master key so that master keys can be rotated.
+//
+// The package also reads configuration secrets, such as the database password,
+// from an external Store: a Vault KV secret or an SSM Parameter Store path.
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// Secret errors
+var (
+	ErrUnknownKey        = errors.New("secret was sealed with an unknown master key")
+	ErrInvalidCiphertext = errors.New("secret cannot be decrypted")
+	ErrInvalidMasterKey  = errors.New("master key must be 32 bytes, base64 encoded")
+)
+
+// dataKeySize is the size of the AES-256 data keys
+const dataKeySize = 32
+
+// KeyManager wraps and unwraps data keys with a master key
+type KeyManager interface {
+	// KeyID identifies the master key new data keys are wrapped with
+	KeyID() string
+	// WrapKey encrypts a data key with the current master key
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped with the master key keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Sealed is an envelope encrypted value
+type Sealed struct {
+	KeyID        string // master key the data key is wrapped with
+	EncryptedKey []byte // wrapped data key
+	Ciphertext   []byte // nonce followed by the AES-GCM ciphertext
+}
+
+// Seal encrypts plaintext with a new data key. aad is authenticated but not
+// encrypted; the same value must be passed to Open, which binds the ciphertext
+// to its owner (e.g. the company and provider) so it cannot be moved to another row.
+func Seal(ctx context.Context, km KeyManager, plaintext, aad []byte) (*Sealed, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	ciphertext, err := encrypt(dataKey, plaintext, aad)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := km.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return &Sealed{KeyID: km.KeyID(), EncryptedKey: wrapped, Ciphertext: ciphertext}, nil
+}
+
+// Open decrypts a sealed value
+func Open(ctx context.Context, km KeyManager, sealed *Sealed, aad []byte) ([]byte, error) {
+	dataKey, err := km.UnwrapKey(ctx, sealed.KeyID, sealed.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(dataKey, sealed.Ciphertext, aad)
+}
+
+// encrypt encrypts plaintext with AES-GCM under a random nonce, which is prepended to the result
+func encrypt(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// decrypt reverses encrypt
+func decrypt(key, ciphertext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrInvalidCiphertext
+	}
+	plaintext, err := gcm.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], aad)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
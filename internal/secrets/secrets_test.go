@@ -0,0 +1,187 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMasterKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes32(b))
+}
+
+func bytes32(b byte) []byte {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = b
+	}
+	return key
+}
+
+func TestSealOpen_RoundTrip(t *testing.T) {
+	km, err := NewLocalKeyManager("k1", map[string]string{"k1": testMasterKey(1)})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	sealed, err := Seal(ctx, km, []byte(`{"secret_key":"s3cr3t"}`), []byte("company/popbill"))
+	require.NoError(t, err)
+	assert.Equal(t, "k1", sealed.KeyID)
+	assert.NotContains(t, string(sealed.Ciphertext), "s3cr3t")
+
+	plaintext, err := Open(ctx, km, sealed, []byte("company/popbill"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"secret_key":"s3cr3t"}`, string(plaintext))
+
+	// A ciphertext copied to another owner does not open
+	_, err = Open(ctx, km, sealed, []byte("other/popbill"))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestSeal_UsesFreshDataKeys(t *testing.T) {
+	km, err := NewLocalKeyManager("k1", map[string]string{"k1": testMasterKey(1)})
+	require.NoError(t, err)
+
+	a, err := Seal(context.Background(), km, []byte("same"), nil)
+	require.NoError(t, err)
+	b, err := Seal(context.Background(), km, []byte("same"), nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a.EncryptedKey, b.EncryptedKey)
+	assert.NotEqual(t, a.Ciphertext, b.Ciphertext)
+}
+
+func TestLocalKeyManager_Rotation(t *testing.T) {
+	ctx := context.Background()
+	old, err := NewLocalKeyManager("k1", map[string]string{"k1": testMasterKey(1)})
+	require.NoError(t, err)
+	sealed, err := Seal(ctx, old, []byte("value"), nil)
+	require.NoError(t, err)
+
+	rotated, err := NewLocalKeyManager("k2", map[string]string{"k1": testMasterKey(1), "k2": testMasterKey(2)})
+	require.NoError(t, err)
+	plaintext, err := Open(ctx, rotated, sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(plaintext))
+
+	resealed, err := Seal(ctx, rotated, plaintext, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", resealed.KeyID)
+
+	retired, err := NewLocalKeyManager("k2", map[string]string{"k2": testMasterKey(2)})
+	require.NoError(t, err)
+	_, err = Open(ctx, retired, sealed, nil)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestNewLocalKeyManager_InvalidKeys(t *testing.T) {
+	_, err := NewLocalKeyManager("k1", map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+
+	_, err = NewLocalKeyManager("k1", map[string]string{"k1": "not base64!"})
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+
+	_, err = NewLocalKeyManager("missing", map[string]string{"k1": testMasterKey(1)})
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestVaultTransitKeyManager(t *testing.T) {
+	// The fake transit engine "encrypts" by prefixing the base64 plaintext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/kerp":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"ciphertext": "vault:v1:" + body["plaintext"]}})
+		case "/v1/transit/decrypt/kerp":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	km := NewVaultTransitKeyManager(&VaultTransitConfig{Address: server.URL, Token: "token", KeyName: "kerp"})
+	ctx := context.Background()
+
+	sealed, err := Seal(ctx, km, []byte("value"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vault:kerp", sealed.KeyID)
+	assert.True(t, strings.HasPrefix(string(sealed.EncryptedKey), "vault:v1:"))
+
+	plaintext, err := Open(ctx, km, sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(plaintext))
+}
+
+func TestVaultKVStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		if r.URL.Path != "/v1/secret/data/kerp/api" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"data":     map[string]interface{}{"database.password": "pw", "redis.db": 2},
+			"metadata": map[string]interface{}{"version": 3},
+		}})
+	}))
+	defer server.Close()
+
+	store := NewVaultKVStore(&VaultKVConfig{Address: server.URL, Token: "token", Path: "kerp/api"})
+	values, err := store.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"database.password": "pw", "redis.db": "2"}, values)
+	assert.Equal(t, "vault:secret/kerp/api", store.Name())
+
+	_, err = NewVaultKVStore(&VaultKVConfig{Address: server.URL, Token: "token", Path: "missing"}).Fetch(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSSMStore(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "AmazonSSM.GetParametersByPath", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "20261014T120000Z", r.Header.Get("X-Amz-Date"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20261014/ap-northeast-2/ssm/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-target, Signature="))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "/kerp/api", body["Path"])
+		assert.Equal(t, true, body["WithDecryption"])
+
+		if body["NextToken"] == nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Parameters": []map[string]string{{"Name": "/kerp/api/database/password", "Value": "pw"}},
+				"NextToken":  "page2",
+			})
+			return
+		}
+		assert.Equal(t, "page2", body["NextToken"])
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"Parameters": []map[string]string{{"Name": "/kerp/api/popbill/webhook_secret", "Value": "hook"}},
+		})
+	}))
+	defer server.Close()
+
+	store := NewSSMStore(&SSMConfig{
+		Region: "ap-northeast-2", Path: "/kerp/api/", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: server.URL,
+	})
+	store.now = func() time.Time { return time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC) }
+
+	values, err := store.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, map[string]string{"database.password": "pw", "popbill.webhook_secret": "hook"}, values)
+}
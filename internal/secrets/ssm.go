@@ -0,0 +1,185 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SSMConfig holds the settings of an AWS Systems Manager Parameter Store path
+type SSMConfig struct {
+	Region string
+	Path   string // parameter path, e.g. /kerp/api; /kerp/api/database/password is "database.password"
+
+	// Static credentials of the IAM user or role session, usually from the
+	// standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN variables
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	Endpoint string // overrides https://ssm.<region>.amazonaws.com, for tests
+	Timeout  time.Duration
+}
+
+// SSMStore reads configuration secrets from the parameters under a Parameter
+// Store path. SecureString parameters are decrypted by SSM.
+type SSMStore struct {
+	config *SSMConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSSMStore creates a Parameter Store store
+func NewSSMStore(config *SSMConfig) *SSMStore {
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://ssm.%s.amazonaws.com", config.Region)
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &SSMStore{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		now:    time.Now,
+	}
+}
+
+// Name returns ssm: and the parameter path
+func (s *SSMStore) Name() string {
+	return "ssm:" + s.config.Path
+}
+
+// Fetch reads every parameter under the path, following pagination
+func (s *SSMStore) Fetch(ctx context.Context) (map[string]string, error) {
+	prefix := "/" + strings.Trim(s.config.Path, "/") + "/"
+	values := make(map[string]string)
+	nextToken := ""
+	for {
+		request := map[string]interface{}{
+			"Path":           strings.TrimSuffix(prefix, "/"),
+			"Recursive":      true,
+			"WithDecryption": true,
+			"MaxResults":     10,
+		}
+		if nextToken != "" {
+			request["NextToken"] = nextToken
+		}
+
+		var page struct {
+			Parameters []struct {
+				Name  string `json:"Name"`
+				Value string `json:"Value"`
+			} `json:"Parameters"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "GetParametersByPath", request, &page); err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			key := strings.ReplaceAll(strings.TrimPrefix(p.Name, prefix), "/", ".")
+			values[key] = p.Value
+		}
+		if page.NextToken == "" {
+			return values, nil
+		}
+		nextToken = page.NextToken
+	}
+}
+
+// call posts a signed request to the SSM JSON API
+func (s *SSMStore) call(ctx context.Context, action string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(s.config.Endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonSSM."+action)
+	s.sign(req, payload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ssm %s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("ssm %s failed: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ssm %s failed with status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// sign adds the AWS Signature Version 4 authorization of the request
+func (s *SSMStore) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+	}
+
+	// Canonical headers: host and every header set above, lowercased and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, hashHex(payload),
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/ssm/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	for _, part := range []string{s.config.Region, "ssm", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Store is an external secret store holding configuration secrets, such as
+// the database password, by configuration key (e.g. "database.password").
+// Stores are read at startup and again on every configuration reload, which
+// is how rotated secrets are picked up.
+type Store interface {
+	// Name identifies the store in logs
+	Name() string
+	// Fetch returns the current secrets by configuration key
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// VaultKVConfig holds the settings of a secret of the Vault KV version 2 engine
+type VaultKVConfig struct {
+	Address string // e.g. https://vault.internal:8200
+	Token   string
+	Mount   string // KV engine mount path, "secret" by default
+	Path    string // secret path; its keys are configuration keys, e.g. "jwt.secret"
+	Timeout time.Duration
+}
+
+// VaultKVStore reads configuration secrets from the latest version of a Vault KV v2 secret
+type VaultKVStore struct {
+	config *VaultKVConfig
+	client *http.Client
+}
+
+// NewVaultKVStore creates a Vault KV store
+func NewVaultKVStore(config *VaultKVConfig) *VaultKVStore {
+	if config.Mount == "" {
+		config.Mount = "secret"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &VaultKVStore{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name returns vault: and the secret path
+func (s *VaultKVStore) Name() string {
+	return "vault:" + s.config.Mount + "/" + s.config.Path
+}
+
+// Fetch reads the secret's key/value pairs
+func (s *VaultKVStore) Fetch(ctx context.Context) (map[string]string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(s.config.Address, "/"), s.config.Mount, strings.Trim(s.config.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.config.Token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault kv read failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("vault kv read failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault kv read failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, fmt.Errorf("vault kv read failed: %w", err)
+	}
+
+	values := make(map[string]string, len(body.Data.Data))
+	for key, value := range body.Data.Data {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = string(encoded)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,114 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultTransitConfig holds the settings of a Vault transit key
+type VaultTransitConfig struct {
+	Address string // e.g. https://vault.internal:8200
+	Token   string
+	KeyName string // transit key; Vault keeps its versions, so rotation needs no configuration change
+	Mount   string // transit engine mount path, "transit" by default
+	Timeout time.Duration
+}
+
+// VaultTransitKeyManager wraps data keys with a key of the Vault transit
+// secrets engine, so the master key never leaves Vault
+type VaultTransitKeyManager struct {
+	config *VaultTransitConfig
+	client *http.Client
+}
+
+// NewVaultTransitKeyManager creates a Vault transit key manager
+func NewVaultTransitKeyManager(config *VaultTransitConfig) *VaultTransitKeyManager {
+	if config.Mount == "" {
+		config.Mount = "transit"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &VaultTransitKeyManager{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// KeyID returns the transit key name prefixed with vault:
+func (m *VaultTransitKeyManager) KeyID() string {
+	return "vault:" + m.config.KeyName
+}
+
+// WrapKey encrypts a data key with the transit key
+func (m *VaultTransitKeyManager) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	err := m.call(ctx, "encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+// UnwrapKey decrypts a data key with the transit key
+func (m *VaultTransitKeyManager) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if keyID != m.KeyID() {
+		return nil, ErrUnknownKey
+	}
+
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := m.call(ctx, "decrypt", map[string]string{"ciphertext": string(wrapped)}, &resp); err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return dataKey, nil
+}
+
+// call posts to a transit endpoint of the key
+func (m *VaultTransitKeyManager) call(ctx context.Context, op string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", strings.TrimRight(m.config.Address, "/"), m.config.Mount, op, m.config.KeyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", m.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault transit %s failed: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("vault transit %s failed: %w", op, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault transit %s failed with status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	return json.Unmarshal(raw, out)
+}
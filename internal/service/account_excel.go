@@ -0,0 +1,556 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/xlsx"
+)
+
+// accountSheetName is the sheet name of chart of accounts workbooks
+const accountSheetName = "계정과목"
+
+// maxAccountCodeLength matches the accounts.code column
+const maxAccountCodeLength = 10
+
+// Chart of accounts workbook fields
+const (
+	accountFieldCode               = "code"
+	accountFieldName               = "name"
+	accountFieldNameEn             = "name_en"
+	accountFieldParentCode         = "parent_code"
+	accountFieldType               = "account_type"
+	accountFieldNature             = "account_nature"
+	accountFieldCategory           = "account_category"
+	accountFieldStatementLine      = "statement_line"
+	accountFieldIsActive           = "is_active"
+	accountFieldIsControlAccount   = "is_control_account"
+	accountFieldAllowDirectPosting = "allow_direct_posting"
+	accountFieldIsCashAccount      = "is_cash_account"
+	accountFieldSortOrder          = "sort_order"
+)
+
+// AccountSheetColumns is the layout of chart of accounts workbooks, in export
+// order. Exports use the field names as the header row; imports also accept
+// the Korean headers, in any column order.
+//
+//   - account_type is asset, liability, equity, revenue or expense (자산, 부채, 자본, 수익, 비용)
+//   - account_nature is debit or credit (차변, 대변); when empty it follows the type
+//   - statement_line is a code of domain.StatementLines of the account's type;
+//     when empty the account rolls up with its parent
+//   - parent_code refers to an account in the file or already in the company;
+//     an empty cell makes a top-level account
+//   - flags are Y or N; empty cells keep the current value, or the default of
+//     new accounts (active and allowing direct posting)
+var AccountSheetColumns = []migrate.Column{
+	{Field: accountFieldCode, Headers: []string{"code", "계정코드", "코드"}, Required: true},
+	{Field: accountFieldName, Headers: []string{"name", "계정과목명", "계정명"}, Required: true},
+	{Field: accountFieldNameEn, Headers: []string{"name_en", "영문명"}},
+	{Field: accountFieldParentCode, Headers: []string{"parent_code", "상위계정코드", "상위코드"}},
+	{Field: accountFieldType, Headers: []string{"account_type", "계정유형", "유형"}, Required: true},
+	{Field: accountFieldNature, Headers: []string{"account_nature", "차대구분"}},
+	{Field: accountFieldCategory, Headers: []string{"account_category", "계정분류"}},
+	{Field: accountFieldStatementLine, Headers: []string{"statement_line", "재무제표과목"}},
+	{Field: accountFieldIsActive, Headers: []string{"is_active", "사용여부"}},
+	{Field: accountFieldIsControlAccount, Headers: []string{"is_control_account", "통제계정"}},
+	{Field: accountFieldAllowDirectPosting, Headers: []string{"allow_direct_posting", "직접전기"}},
+	{Field: accountFieldIsCashAccount, Headers: []string{"is_cash_account", "현금성계정"}},
+	{Field: accountFieldSortOrder, Headers: []string{"sort_order", "정렬순서"}},
+}
+
+// accountTypeNames maps the accepted account type values to types
+var accountTypeNames = map[string]domain.AccountType{
+	"asset": domain.AccountTypeAsset, "자산": domain.AccountTypeAsset,
+	"liability": domain.AccountTypeLiability, "부채": domain.AccountTypeLiability,
+	"equity": domain.AccountTypeEquity, "자본": domain.AccountTypeEquity,
+	"revenue": domain.AccountTypeRevenue, "수익": domain.AccountTypeRevenue,
+	"expense": domain.AccountTypeExpense, "비용": domain.AccountTypeExpense,
+}
+
+// accountNatureNames maps the accepted account nature values to natures
+var accountNatureNames = map[string]domain.AccountNature{
+	"debit": domain.AccountNatureDebit, "차변": domain.AccountNatureDebit,
+	"credit": domain.AccountNatureCredit, "대변": domain.AccountNatureCredit,
+}
+
+// ExportExcel writes the chart of accounts in the AccountSheetColumns layout, by code
+func (s *accountService) ExportExcel(ctx context.Context, companyID uuid.UUID) ([]byte, error) {
+	accounts, _, err := s.repo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID, SortBy: "code"})
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[uuid.UUID]string, len(accounts))
+	for _, a := range accounts {
+		codes[a.ID] = a.Code
+	}
+
+	header := make([]string, len(AccountSheetColumns))
+	for i, c := range AccountSheetColumns {
+		header[i] = c.Field
+	}
+	rows := [][]string{header}
+	for _, a := range accounts {
+		parentCode := ""
+		if a.ParentID != nil {
+			parentCode = codes[*a.ParentID]
+		}
+		rows = append(rows, []string{
+			a.Code,
+			a.Name,
+			a.NameEn,
+			parentCode,
+			string(a.AccountType),
+			string(a.AccountNature),
+			a.AccountCategory,
+			a.StatementLine,
+			formatAccountFlag(a.IsActive),
+			formatAccountFlag(a.IsControlAccount),
+			formatAccountFlag(a.AllowDirectPosting),
+			formatAccountFlag(a.IsCashAccount),
+			strconv.Itoa(a.SortOrder),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := xlsx.Write(&buf, accountSheetName, rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAccountFlag(v bool) string {
+	if v {
+		return "Y"
+	}
+	return "N"
+}
+
+// parseAccountFlag reads Y/N cells; ok is false for unrecognized values
+func parseAccountFlag(value string) (bool, bool) {
+	switch strings.ToUpper(value) {
+	case "Y", "YES", "TRUE", "1", "예", "사용":
+		return true, true
+	case "N", "NO", "FALSE", "0", "아니오", "미사용":
+		return false, true
+	}
+	return false, false
+}
+
+// accountSheetRow is a data row of a chart of accounts workbook. Optional
+// cells that are empty, or whose column is absent, are nil.
+type accountSheetRow struct {
+	row        int
+	code       string
+	name       string
+	nameEn     *string
+	parentCode *string
+	typ        domain.AccountType
+	nature     domain.AccountNature
+	category   *string
+	line       *string
+	flags      map[string]*bool
+	sortOrder  *int
+}
+
+// accountSheetImport is the state of one ImportExcel call
+type accountSheetImport struct {
+	result   *migrate.Result
+	rows     []*accountSheetRow
+	accounts map[string]*domain.Account // existing accounts by code
+}
+
+func (imp *accountSheetImport) addError(row int, field, message string) {
+	imp.result.Issues = append(imp.result.Issues, migrate.Issue{Row: row, Field: field, Severity: migrate.SeverityError, Message: message})
+}
+
+func (imp *accountSheetImport) addWarning(row int, field, message string) {
+	imp.result.Issues = append(imp.result.Issues, migrate.Issue{Row: row, Field: field, Severity: migrate.SeverityWarning, Message: message})
+}
+
+// ImportExcel validates a chart of accounts workbook and, unless it is a dry
+// run or has errors, creates its new accounts and updates the existing ones
+// with the same code. Parents are applied before their children.
+func (s *accountService) ImportExcel(ctx context.Context, companyID uuid.UUID, data []byte, dryRun bool) (*migrate.Result, error) {
+	sheet, err := xlsx.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &accountSheetImport{result: &migrate.Result{Dataset: migrate.DatasetAccounts, DryRun: dryRun}}
+	if err := imp.parse(sheet); err != nil {
+		return nil, err
+	}
+
+	accounts, _, err := s.repo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID})
+	if err != nil {
+		return nil, err
+	}
+	imp.accounts = make(map[string]*domain.Account, len(accounts))
+	byID := make(map[uuid.UUID]*domain.Account, len(accounts))
+	for i := range accounts {
+		imp.accounts[accounts[i].Code] = &accounts[i]
+		byID[accounts[i].ID] = &accounts[i]
+	}
+
+	// The hierarchy after the import: the file's parents override the current ones
+	parents := make(map[string]string, len(accounts)+len(imp.rows))
+	for _, a := range accounts {
+		if a.ParentID != nil {
+			if p, ok := byID[*a.ParentID]; ok {
+				parents[a.Code] = p.Code
+			}
+		}
+	}
+	types := make(map[string]domain.AccountType, len(accounts)+len(imp.rows))
+	for _, a := range accounts {
+		types[a.Code] = a.AccountType
+	}
+	for _, r := range imp.rows {
+		if r.typ != "" {
+			types[r.code] = r.typ
+		}
+		if r.parentCode == nil {
+			continue
+		}
+		if *r.parentCode == "" {
+			delete(parents, r.code)
+		} else {
+			parents[r.code] = *r.parentCode
+		}
+	}
+
+	depths := make(map[string]int, len(imp.rows))
+	for _, r := range imp.rows {
+		depth, err := accountDepth(r.code, parents)
+		if err != nil {
+			imp.addError(r.row, accountFieldParentCode, err.Error())
+			continue
+		}
+		depths[r.code] = depth
+		parent, ok := parents[r.code]
+		if !ok {
+			continue
+		}
+		parentType, ok := types[parent]
+		if !ok {
+			imp.addError(r.row, accountFieldParentCode, fmt.Sprintf("parent account %s is not a valid account of the file or the chart of accounts", parent))
+			continue
+		}
+		if r.typ != "" && parentType != r.typ {
+			imp.addWarning(r.row, accountFieldType, fmt.Sprintf("account %s is %s under the %s account %s", r.code, r.typ, parentType, parent))
+		}
+	}
+
+	var create, update []*accountSheetRow
+	for _, r := range imp.rows {
+		existing, ok := imp.accounts[r.code]
+		if !ok {
+			create = append(create, r)
+			continue
+		}
+		if r.typ != existing.AccountType && r.typ != "" {
+			hasEntries, err := s.repo.HasVoucherEntries(ctx, companyID, existing.ID)
+			if err != nil {
+				return nil, err
+			}
+			if hasEntries {
+				imp.addError(r.row, accountFieldType, fmt.Sprintf("account %s has voucher entries and cannot change from %s to %s", r.code, existing.AccountType, r.typ))
+				continue
+			}
+		}
+		if !r.changes(existing, parents[r.code], imp.accounts) {
+			imp.result.Skipped++
+			continue
+		}
+		update = append(update, r)
+	}
+
+	if dryRun || imp.result.HasErrors() {
+		return imp.result, nil
+	}
+
+	apply := append(create, update...)
+	sort.SliceStable(apply, func(i, j int) bool {
+		if depths[apply[i].code] != depths[apply[j].code] {
+			return depths[apply[i].code] < depths[apply[j].code]
+		}
+		return apply[i].row < apply[j].row
+	})
+	for _, r := range apply {
+		account := &domain.Account{
+			TenantModel:        domain.TenantModel{CompanyID: companyID},
+			IsActive:           true,
+			AllowDirectPosting: true,
+		}
+		existing, isUpdate := imp.accounts[r.code]
+		if isUpdate {
+			copied := *existing
+			account = &copied
+		}
+		r.applyTo(account)
+		account.ParentID = nil
+		if parent, ok := parents[r.code]; ok {
+			if p, ok := imp.accounts[parent]; ok {
+				account.ParentID = &p.ID
+			}
+		}
+
+		if isUpdate {
+			err = s.Update(ctx, account)
+		} else {
+			err = s.Create(ctx, account)
+		}
+		if err != nil {
+			imp.addError(r.row, accountFieldCode, fmt.Sprintf("account %s: %s", r.code, err.Error()))
+			continue
+		}
+		imp.accounts[r.code] = account
+		if isUpdate {
+			imp.result.Updated++
+		} else {
+			imp.result.Created++
+		}
+	}
+	imp.result.Applied = true
+	return imp.result, nil
+}
+
+// parse reads the header row, the first non-blank one, and the data rows below it
+func (imp *accountSheetImport) parse(sheet [][]string) error {
+	headerRow := -1
+	for i, cells := range sheet {
+		if !isBlankRow(cells) {
+			headerRow = i
+			break
+		}
+	}
+	if headerRow < 0 {
+		return migrate.ErrEmptyFile
+	}
+
+	columns := make(map[string]int)
+	for i, cell := range sheet[headerRow] {
+		name := strings.ToLower(strings.TrimSpace(cell))
+		for _, c := range AccountSheetColumns {
+			for _, h := range c.Headers {
+				if _, seen := columns[c.Field]; !seen && name == strings.ToLower(h) {
+					columns[c.Field] = i
+				}
+			}
+		}
+	}
+	for _, c := range AccountSheetColumns {
+		if _, ok := columns[c.Field]; c.Required && !ok {
+			return fmt.Errorf("%w: %s", migrate.ErrMissingColumn, c.Field)
+		}
+	}
+
+	seen := make(map[string]int)
+	for i := headerRow + 1; i < len(sheet); i++ {
+		cells := sheet[i]
+		if isBlankRow(cells) {
+			continue
+		}
+		imp.result.Rows++
+		if r, ok := imp.parseRow(i+1, cells, columns); ok {
+			if first, dup := seen[r.code]; dup {
+				imp.addError(r.row, accountFieldCode, fmt.Sprintf("account code %s is repeated (first on row %d)", r.code, first))
+				continue
+			}
+			seen[r.code] = r.row
+			imp.rows = append(imp.rows, r)
+		}
+	}
+	if imp.result.Rows == 0 {
+		return migrate.ErrEmptyFile
+	}
+	imp.result.Records = len(imp.rows)
+	return nil
+}
+
+// parseRow reads one data row, reporting invalid cells; ok is false when the
+// row has errors
+func (imp *accountSheetImport) parseRow(row int, cells []string, columns map[string]int) (*accountSheetRow, bool) {
+	cell := func(field string) (string, bool) {
+		i, ok := columns[field]
+		if !ok {
+			return "", false
+		}
+		if i >= len(cells) {
+			return "", true
+		}
+		return strings.TrimSpace(cells[i]), true
+	}
+	optional := func(field string) *string {
+		if v, ok := cell(field); ok {
+			return &v
+		}
+		return nil
+	}
+	issues := len(imp.result.Issues)
+
+	r := &accountSheetRow{row: row, flags: make(map[string]*bool)}
+	r.code, _ = cell(accountFieldCode)
+	r.name, _ = cell(accountFieldName)
+	switch {
+	case r.code == "":
+		imp.addError(row, accountFieldCode, "account code is required")
+	case len(r.code) > maxAccountCodeLength:
+		imp.addError(row, accountFieldCode, fmt.Sprintf("account code %s is longer than %d characters", r.code, maxAccountCodeLength))
+	}
+	if r.name == "" {
+		imp.addError(row, accountFieldName, "account name is required")
+	}
+	r.nameEn = optional(accountFieldNameEn)
+	r.parentCode = optional(accountFieldParentCode)
+	if r.parentCode != nil && *r.parentCode == r.code && r.code != "" {
+		imp.addError(row, accountFieldParentCode, fmt.Sprintf("account %s cannot be its own parent", r.code))
+	}
+	r.category = optional(accountFieldCategory)
+	r.line = optional(accountFieldStatementLine)
+
+	value, _ := cell(accountFieldType)
+	if t, ok := accountTypeNames[strings.ToLower(value)]; ok {
+		r.typ = t
+	} else {
+		imp.addError(row, accountFieldType, fmt.Sprintf("account type %q must be asset, liability, equity, revenue or expense", value))
+	}
+	if r.line != nil && r.typ != "" {
+		if err := domain.ValidateStatementLine(r.typ, *r.line); err != nil {
+			imp.addError(row, accountFieldStatementLine, fmt.Sprintf("statement line %q: %s", *r.line, err.Error()))
+		}
+	}
+	if value, _ := cell(accountFieldNature); value != "" {
+		if n, ok := accountNatureNames[strings.ToLower(value)]; ok {
+			r.nature = n
+		} else {
+			imp.addError(row, accountFieldNature, fmt.Sprintf("account nature %q must be debit or credit", value))
+		}
+	}
+
+	for _, field := range []string{accountFieldIsActive, accountFieldIsControlAccount, accountFieldAllowDirectPosting, accountFieldIsCashAccount} {
+		value, _ := cell(field)
+		if value == "" {
+			continue
+		}
+		flag, ok := parseAccountFlag(value)
+		if !ok {
+			imp.addError(row, field, fmt.Sprintf("%q must be Y or N", value))
+			continue
+		}
+		r.flags[field] = &flag
+	}
+	if value, _ := cell(accountFieldSortOrder); value != "" {
+		order, err := strconv.Atoi(value)
+		if err != nil {
+			imp.addError(row, accountFieldSortOrder, fmt.Sprintf("sort order %q must be a whole number", value))
+		} else {
+			r.sortOrder = &order
+		}
+	}
+	return r, len(imp.result.Issues) == issues
+}
+
+func isBlankRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// accountDepth returns the level of the account in the hierarchy, from 0,
+// failing when following its parents leads back to it
+func accountDepth(code string, parents map[string]string) (int, error) {
+	depth := 0
+	visited := map[string]bool{code: true}
+	for current := code; ; depth++ {
+		parent, ok := parents[current]
+		if !ok {
+			return depth, nil
+		}
+		if visited[parent] {
+			return 0, fmt.Errorf("account %s would be its own ancestor through %s", code, parent)
+		}
+		visited[parent] = true
+		current = parent
+	}
+}
+
+// applyTo sets the row's values on the account; absent and empty optional
+// cells keep the account's values
+func (r *accountSheetRow) applyTo(a *domain.Account) {
+	if r.typ != a.AccountType {
+		if r.nature == "" {
+			a.AccountNature = "" // SetDefaults derives it from the new type
+		}
+		a.StatementLine = "" // lines belong to one account type
+	}
+	a.Code = r.code
+	a.Name = r.name
+	a.AccountType = r.typ
+	if r.nature != "" {
+		a.AccountNature = r.nature
+	}
+	if r.nameEn != nil {
+		a.NameEn = *r.nameEn
+	}
+	if r.category != nil {
+		a.AccountCategory = *r.category
+	}
+	if r.line != nil {
+		a.StatementLine = *r.line
+	}
+	if v := r.flags[accountFieldIsActive]; v != nil {
+		a.IsActive = *v
+	}
+	if v := r.flags[accountFieldIsControlAccount]; v != nil {
+		a.IsControlAccount = *v
+	}
+	if v := r.flags[accountFieldAllowDirectPosting]; v != nil {
+		a.AllowDirectPosting = *v
+	}
+	if v := r.flags[accountFieldIsCashAccount]; v != nil {
+		a.IsCashAccount = *v
+	}
+	if r.sortOrder != nil {
+		a.SortOrder = *r.sortOrder
+	}
+	a.SetDefaults()
+}
+
+// changes reports whether applying the row, with its parent after the
+// import, would change the existing account
+func (r *accountSheetRow) changes(existing *domain.Account, parentCode string, accounts map[string]*domain.Account) bool {
+	updated := *existing
+	r.applyTo(&updated)
+	if updated.Name != existing.Name || updated.NameEn != existing.NameEn ||
+		updated.AccountType != existing.AccountType || updated.AccountNature != existing.AccountNature ||
+		updated.AccountCategory != existing.AccountCategory || updated.StatementLine != existing.StatementLine ||
+		updated.IsActive != existing.IsActive || updated.IsControlAccount != existing.IsControlAccount ||
+		updated.AllowDirectPosting != existing.AllowDirectPosting || updated.IsCashAccount != existing.IsCashAccount ||
+		updated.SortOrder != existing.SortOrder {
+		return true
+	}
+
+	currentParent := ""
+	if existing.ParentID != nil {
+		for code, a := range accounts {
+			if a.ID == *existing.ParentID {
+				currentParent = code
+				break
+			}
+		}
+	}
+	return currentParent != parentCode
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+	"github.com/saintgo7/saas-kerp/internal/xlsx"
+)
+
+func newTestAccountWorkbook(t *testing.T, rows ...[]string) []byte {
+	var buf bytes.Buffer
+	header := []string{"계정코드", "계정과목명", "상위계정코드", "계정유형", "사용여부"}
+	require.NoError(t, xlsx.Write(&buf, "accounts", append([][]string{header}, rows...)))
+	return buf.Bytes()
+}
+
+func TestAccountService_ImportExcel_DryRunReportsIssues(t *testing.T) {
+	companyID := newTestCompanyID()
+	repo := new(mocks.MockAccountRepository)
+	existing := newTestAccount(companyID, uuid.New())
+	existing.Code = "101"
+	repo.On("FindAll", mock.Anything, mock.Anything).Return([]domain.Account{*existing}, int64(1), nil)
+
+	data := newTestAccountWorkbook(t,
+		[]string{"102", "보통예금", "101", "자산", "Y"},
+		[]string{"102", "당좌예금", "101", "asset"},
+		[]string{"201", "외상매입금", "299", "liability"},
+		[]string{"301", "자본금", "302", "equity"},
+		[]string{"302", "자본잉여금", "301", "equity"},
+		[]string{"401", "상품매출", "", "income", "maybe"},
+	)
+	result, err := service.NewAccountService(repo).ImportExcel(context.Background(), companyID, data, true)
+	require.NoError(t, err)
+
+	assert.True(t, result.DryRun)
+	assert.False(t, result.Applied)
+	assert.Equal(t, 6, result.Rows)
+	assert.Equal(t, 4, result.Records)
+
+	fields := make(map[int][]string)
+	for _, issue := range result.Issues {
+		assert.Equal(t, migrate.SeverityError, issue.Severity)
+		fields[issue.Row] = append(fields[issue.Row], issue.Field)
+	}
+	assert.Equal(t, map[int][]string{
+		3: {"code"},
+		4: {"parent_code"},
+		5: {"parent_code"},
+		6: {"parent_code"},
+		7: {"account_type", "is_active"},
+	}, fields)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_ImportExcel_AppliesParentsFirst(t *testing.T) {
+	companyID := newTestCompanyID()
+	repo := new(mocks.MockAccountRepository)
+	repo.On("FindAll", mock.Anything, mock.Anything).Return([]domain.Account{}, int64(0), nil)
+	repo.On("ExistsByCode", mock.Anything, companyID, mock.Anything, (*uuid.UUID)(nil)).Return(false, nil)
+	repo.On("FindByID", mock.Anything, companyID, mock.Anything).Return(&domain.Account{Level: 1}, nil)
+	repo.On("UpdatePath", mock.Anything, mock.Anything).Return(nil)
+
+	var created []string
+	var parentIDs []*uuid.UUID
+	repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		account := args.Get(1).(*domain.Account)
+		account.ID = uuid.New()
+		created = append(created, account.Code)
+		parentIDs = append(parentIDs, account.ParentID)
+	}).Return(nil)
+
+	data := newTestAccountWorkbook(t,
+		[]string{"1011", "보통예금", "101", "asset", "N"},
+		[]string{"101", "현금및현금성자산", "", "asset"},
+	)
+	result, err := service.NewAccountService(repo).ImportExcel(context.Background(), companyID, data, false)
+	require.NoError(t, err)
+
+	assert.True(t, result.Applied)
+	assert.Empty(t, result.Issues)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, []string{"101", "1011"}, created)
+	assert.Nil(t, parentIDs[0])
+	require.NotNil(t, parentIDs[1])
+}
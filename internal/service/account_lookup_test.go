@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+func TestAccountService_LookupIsCachedUntilChange(t *testing.T) {
+	companyID := newTestCompanyID()
+	repo := new(mocks.MockAccountRepository)
+	svc := service.NewAccountService(repo)
+	ctx := context.Background()
+
+	cash := newTestAccount(companyID, uuid.New())
+	activeByCode := mock.MatchedBy(func(f repository.AccountFilter) bool {
+		return f.CompanyID == companyID && f.IsActive != nil && *f.IsActive && f.SortBy == "code"
+	})
+	repo.On("FindAll", mock.Anything, activeByCode).Return([]domain.Account{*cash}, int64(1), nil).Twice()
+
+	for i := 0; i < 2; i++ {
+		accounts, err := svc.Lookup(ctx, companyID, "10", 0)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.AccountLookup{domain.NewAccountLookup(cash)}, accounts)
+	}
+	repo.AssertNumberOfCalls(t, "FindAll", 1)
+
+	// A change of the chart of accounts is looked up right away
+	repo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("UpdatePath", mock.Anything, mock.Anything).Return(nil).Once()
+	created := newTestAccount(companyID, uuid.New())
+	require.NoError(t, svc.CreateBatch(ctx, []domain.Account{*created}))
+	_, err := svc.Lookup(ctx, companyID, "10", 0)
+	require.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "FindAll", 2)
+}
@@ -2,13 +2,21 @@ package service
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
 	"github.com/saintgo7/saas-kerp/internal/repository"
 )
 
+// accountLookupCacheTTL bounds how long another API instance may serve lookups
+// of a chart of accounts changed elsewhere; changes through this service take
+// effect immediately.
+const accountLookupCacheTTL = 5 * time.Minute
+
 // AccountService defines the interface for account business logic
 type AccountService interface {
 	// CRUD operations
@@ -21,6 +29,9 @@ type AccountService interface {
 	GetByCode(ctx context.Context, companyID uuid.UUID, code string) (*domain.Account, error)
 	List(ctx context.Context, filter repository.AccountFilter) ([]domain.Account, int64, error)
 	GetByType(ctx context.Context, companyID uuid.UUID, accountType domain.AccountType) ([]domain.Account, error)
+	// Lookup returns the active accounts whose code or name starts with the
+	// query, for type-ahead fields, served from memory
+	Lookup(ctx context.Context, companyID uuid.UUID, query string, limit int) ([]domain.AccountLookup, error)
 
 	// Hierarchy operations
 	GetTree(ctx context.Context, companyID uuid.UUID) ([]domain.Account, error)
@@ -34,16 +45,42 @@ type AccountService interface {
 	// Validation
 	CanDelete(ctx context.Context, companyID, id uuid.UUID) (bool, string, error)
 	CanPost(ctx context.Context, companyID, id uuid.UUID) (bool, string, error)
+
+	// Posting rules
+	GetPostingRules(ctx context.Context, companyID, id uuid.UUID) (*domain.PostingRules, error)
+	UpdatePostingRules(ctx context.Context, companyID, id uuid.UUID, rules *domain.PostingRules) error
+
+	// Excel workbooks in the AccountSheetColumns layout
+	ExportExcel(ctx context.Context, companyID uuid.UUID) ([]byte, error)
+	ImportExcel(ctx context.Context, companyID uuid.UUID, data []byte, dryRun bool) (*migrate.Result, error)
+
+	// Industry templates
+	ListTemplates() []domain.AccountTemplate
+	GetTemplate(industry domain.Industry) (*domain.AccountTemplate, error)
+	ApplyTemplate(ctx context.Context, companyID uuid.UUID, industry domain.Industry, dryRun bool) (*migrate.Result, error)
+
+	// Financial statement lines
+	StatementLines() []domain.StatementLine
+	SetStatementLine(ctx context.Context, companyID, id uuid.UUID, line string) (*domain.Account, error)
+}
+
+// cachedAccountLookups is a lookup cache entry
+type cachedAccountLookups struct {
+	accounts  []domain.AccountLookup
+	expiresAt time.Time
 }
 
 // accountService implements AccountService
 type accountService struct {
 	repo repository.AccountRepository
+
+	lookupMu sync.RWMutex
+	lookups  map[uuid.UUID]cachedAccountLookups
 }
 
 // NewAccountService creates a new AccountService
 func NewAccountService(repo repository.AccountRepository) AccountService {
-	return &accountService{repo: repo}
+	return &accountService{repo: repo, lookups: make(map[uuid.UUID]cachedAccountLookups)}
 }
 
 // Create creates a new account
@@ -81,6 +118,7 @@ func (s *accountService) Create(ctx context.Context, account *domain.Account) er
 	if err := s.repo.Create(ctx, account); err != nil {
 		return err
 	}
+	s.invalidateLookups(account.CompanyID)
 
 	// Update path
 	return s.repo.UpdatePath(ctx, account)
@@ -145,6 +183,7 @@ func (s *accountService) Update(ctx context.Context, account *domain.Account) er
 	if err := s.repo.Update(ctx, account); err != nil {
 		return err
 	}
+	s.invalidateLookups(account.CompanyID)
 
 	// Update path if code or parent changed
 	if account.Code != existing.Code || account.ParentID != existing.ParentID {
@@ -180,7 +219,11 @@ func (s *accountService) Delete(ctx context.Context, companyID, id uuid.UUID) er
 		return domain.ErrAccountHasEntries
 	}
 
-	return s.repo.Delete(ctx, companyID, id)
+	if err := s.repo.Delete(ctx, companyID, id); err != nil {
+		return err
+	}
+	s.invalidateLookups(companyID)
+	return nil
 }
 
 // GetByID retrieves an account by ID
@@ -203,6 +246,44 @@ func (s *accountService) GetByType(ctx context.Context, companyID uuid.UUID, acc
 	return s.repo.FindByType(ctx, companyID, accountType)
 }
 
+// Lookup matches the query to the company's cached active accounts, loading
+// them in code order when they are not cached
+func (s *accountService) Lookup(ctx context.Context, companyID uuid.UUID, query string, limit int) ([]domain.AccountLookup, error) {
+	if limit <= 0 || limit > domain.AccountLookupMaxLimit {
+		limit = domain.AccountLookupLimit
+	}
+
+	s.lookupMu.RLock()
+	entry, ok := s.lookups[companyID]
+	s.lookupMu.RUnlock()
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		isActive := true
+		accounts, _, err := s.repo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID, IsActive: &isActive, SortBy: "code"})
+		if err != nil {
+			return nil, err
+		}
+		entry = cachedAccountLookups{
+			accounts:  make([]domain.AccountLookup, len(accounts)),
+			expiresAt: time.Now().Add(accountLookupCacheTTL),
+		}
+		for i := range accounts {
+			entry.accounts[i] = domain.NewAccountLookup(&accounts[i])
+		}
+		s.lookupMu.Lock()
+		s.lookups[companyID] = entry
+		s.lookupMu.Unlock()
+	}
+	return domain.MatchAccountLookups(entry.accounts, query, limit), nil
+}
+
+// invalidateLookups drops the cached lookups of the company after a change to
+// its chart of accounts
+func (s *accountService) invalidateLookups(companyID uuid.UUID) {
+	s.lookupMu.Lock()
+	defer s.lookupMu.Unlock()
+	delete(s.lookups, companyID)
+}
+
 // GetTree retrieves the full account tree
 func (s *accountService) GetTree(ctx context.Context, companyID uuid.UUID) ([]domain.Account, error) {
 	return s.repo.GetTree(ctx, companyID)
@@ -238,6 +319,9 @@ func (s *accountService) CreateBatch(ctx context.Context, accounts []domain.Acco
 	if err := s.repo.CreateBatch(ctx, accounts); err != nil {
 		return err
 	}
+	for i := range accounts {
+		s.invalidateLookups(accounts[i].CompanyID)
+	}
 
 	// Update paths
 	for i := range accounts {
@@ -302,3 +386,30 @@ func (s *accountService) CanPost(ctx context.Context, companyID, id uuid.UUID) (
 
 	return true, "", nil
 }
+
+// GetPostingRules retrieves the posting rules of an account (nil if none)
+func (s *accountService) GetPostingRules(ctx context.Context, companyID, id uuid.UUID) (*domain.PostingRules, error) {
+	account, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	return account.PostingRules, nil
+}
+
+// UpdatePostingRules replaces the posting rules of an account; nil or empty rules clear them
+func (s *accountService) UpdatePostingRules(ctx context.Context, companyID, id uuid.UUID, rules *domain.PostingRules) error {
+	if _, err := s.repo.FindByID(ctx, companyID, id); err != nil {
+		return err
+	}
+
+	if rules != nil {
+		if err := rules.Validate(); err != nil {
+			return err
+		}
+		if rules.IsEmpty() {
+			rules = nil
+		}
+	}
+
+	return s.repo.UpdatePostingRules(ctx, companyID, id, rules)
+}
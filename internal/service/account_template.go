@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ListTemplates returns the industry templates without their accounts
+func (s *accountService) ListTemplates() []domain.AccountTemplate {
+	return domain.AccountTemplates()
+}
+
+// GetTemplate returns an industry template with its accounts
+func (s *accountService) GetTemplate(industry domain.Industry) (*domain.AccountTemplate, error) {
+	return domain.FindAccountTemplate(industry)
+}
+
+// ApplyTemplate adds the template's accounts that the company does not have
+// yet, and maps existing accounts without a statement line to the template's.
+// Accounts with a template code but another type are left alone with a warning.
+func (s *accountService) ApplyTemplate(ctx context.Context, companyID uuid.UUID, industry domain.Industry, dryRun bool) (*migrate.Result, error) {
+	template, err := domain.FindAccountTemplate(industry)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _, err := s.repo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID})
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*domain.Account, len(accounts))
+	for i := range accounts {
+		byCode[accounts[i].Code] = &accounts[i]
+	}
+
+	result := &migrate.Result{Dataset: migrate.DatasetAccounts, DryRun: dryRun, Rows: len(template.Accounts), Records: len(template.Accounts)}
+	warn := func(row int, message string) {
+		result.Issues = append(result.Issues, migrate.Issue{Row: row, Field: "code", Severity: migrate.SeverityWarning, Message: message})
+	}
+	var create []domain.AccountTemplateEntry
+	var mapLines []*domain.Account
+	for i, e := range template.Accounts {
+		existing, ok := byCode[e.Code]
+		switch {
+		case !ok:
+			create = append(create, e)
+		case existing.AccountType != e.AccountType:
+			warn(i+1, fmt.Sprintf("account %s is %s, not %s, and is skipped", e.Code, existing.AccountType, e.AccountType))
+			result.Skipped++
+		case existing.StatementLine == "" && e.StatementLine != "":
+			updated := *existing
+			updated.StatementLine = e.StatementLine
+			mapLines = append(mapLines, &updated)
+		default:
+			result.Skipped++
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	for _, e := range create {
+		account := e.ToAccount(companyID)
+		if e.ParentCode != "" {
+			parent, ok := byCode[e.ParentCode]
+			if !ok {
+				warn(0, fmt.Sprintf("account %s is skipped because its parent %s could not be created", e.Code, e.ParentCode))
+				result.Skipped++
+				continue
+			}
+			account.ParentID = &parent.ID
+		}
+		if err := s.Create(ctx, &account); err != nil {
+			return nil, fmt.Errorf("create account %s: %w", e.Code, err)
+		}
+		byCode[account.Code] = &account
+		result.Created++
+	}
+	for _, account := range mapLines {
+		if err := s.repo.Update(ctx, account); err != nil {
+			return nil, err
+		}
+		result.Updated++
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// StatementLines returns the financial statement line catalog
+func (s *accountService) StatementLines() []domain.StatementLine {
+	return domain.StatementLines()
+}
+
+// SetStatementLine maps the account to a statement line of its type, or
+// clears the mapping with an empty line
+func (s *accountService) SetStatementLine(ctx context.Context, companyID, id uuid.UUID, line string) (*domain.Account, error) {
+	account, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateStatementLine(account.AccountType, line); err != nil {
+		return nil, err
+	}
+	account.StatementLine = line
+	if err := s.repo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
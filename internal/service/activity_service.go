@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ActivityService defines the interface for activity feeds and comments
+type ActivityService interface {
+	// VoucherActivity returns the whole activity of a voucher, newest first
+	VoucherActivity(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.Activity, error)
+
+	// List returns a page of the company feed of the filter, newest first
+	List(ctx context.Context, filter repository.ActivityFilter) ([]domain.Activity, int64, error)
+
+	// AddComment comments on a voucher in any status
+	AddComment(ctx context.Context, companyID, voucherID, userID uuid.UUID, comment, ipAddress, userAgent string) (*domain.Activity, error)
+}
+
+// activityService implements ActivityService
+type activityService struct {
+	activityRepo repository.ActivityRepository
+	voucherRepo  repository.VoucherRepository
+}
+
+// NewActivityService creates a new ActivityService
+func NewActivityService(activityRepo repository.ActivityRepository, voucherRepo repository.VoucherRepository) ActivityService {
+	return &activityService{
+		activityRepo: activityRepo,
+		voucherRepo:  voucherRepo,
+	}
+}
+
+// VoucherActivity returns the activity of a voucher
+func (s *activityService) VoucherActivity(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.Activity, error) {
+	if _, err := s.voucherRepo.FindByID(ctx, companyID, voucherID); err != nil {
+		return nil, err
+	}
+	activities, _, err := s.activityRepo.FindAll(ctx, repository.ActivityFilter{CompanyID: companyID, VoucherID: &voucherID})
+	return activities, err
+}
+
+// List returns the company feed
+func (s *activityService) List(ctx context.Context, filter repository.ActivityFilter) ([]domain.Activity, int64, error) {
+	return s.activityRepo.FindAll(ctx, filter)
+}
+
+// AddComment records a comment on a voucher in the audit log
+func (s *activityService) AddComment(ctx context.Context, companyID, voucherID, userID uuid.UUID, comment, ipAddress, userAgent string) (*domain.Activity, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := domain.NewVoucherComment(companyID, voucherID, userID, comment)
+	if err != nil {
+		return nil, err
+	}
+	if ipAddress != "" {
+		log.IPAddress = &ipAddress
+	}
+	log.UserAgent = truncateRunes(userAgent, 500)
+	if err := s.activityRepo.CreateAuditLog(ctx, log); err != nil {
+		return nil, err
+	}
+
+	return &domain.Activity{
+		Kind:        domain.ActivityComment,
+		EntityType:  domain.AuditEntityVoucher,
+		EntityID:    voucherID,
+		EntityLabel: voucher.VoucherNo,
+		UserID:      log.UserID,
+		Message:     strings.TrimSpace(comment),
+		OccurredAt:  log.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// allocationRunReferenceType marks vouchers generated by an allocation run
+const allocationRunReferenceType = "allocation_run"
+
+// AllocationRunService defines the interface for period-end cost allocation runs
+type AllocationRunService interface {
+	// Preview computes the allocation of the active rules over the posted
+	// entries of the period without booking it
+	Preview(ctx context.Context, companyID uuid.UUID, periodStart, periodEnd time.Time) (*domain.AllocationRun, error)
+	// Run books the allocation as a draft adjustment voucher dated periodEnd.
+	// A period can be run again once its previous run is reversed.
+	Run(ctx context.Context, companyID, userID uuid.UUID, periodStart, periodEnd time.Time) (*domain.AllocationRun, error)
+	// Reverse cancels the voucher of the run, or reverses it on the period end
+	// when it is already posted
+	Reverse(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.AllocationRun, error)
+
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.AllocationRun, error)
+	List(ctx context.Context, filter repository.AllocationRunFilter) ([]domain.AllocationRun, int64, error)
+}
+
+// allocationRunService implements AllocationRunService
+type allocationRunService struct {
+	repo           repository.AllocationRunRepository
+	ruleRepo       repository.DepartmentAllocationRuleRepository
+	ledgerRepo     repository.LedgerRepository
+	voucherService VoucherService
+}
+
+// NewAllocationRunService creates a new AllocationRunService
+func NewAllocationRunService(
+	repo repository.AllocationRunRepository,
+	ruleRepo repository.DepartmentAllocationRuleRepository,
+	ledgerRepo repository.LedgerRepository,
+	voucherService VoucherService,
+) AllocationRunService {
+	return &allocationRunService{
+		repo:           repo,
+		ruleRepo:       ruleRepo,
+		ledgerRepo:     ledgerRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Preview applies the active rules to the posted revenue and expense totals
+func (s *allocationRunService) Preview(ctx context.Context, companyID uuid.UUID, periodStart, periodEnd time.Time) (*domain.AllocationRun, error) {
+	rules, err := s.ruleRepo.FindAll(ctx, companyID, true)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.ledgerRepo.GetDepartmentAccountTotals(ctx, companyID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewAllocationRun(companyID, periodStart, periodEnd, totals, rules), nil
+}
+
+// Run books the previewed allocation with its voucher
+func (s *allocationRunService) Run(ctx context.Context, companyID, userID uuid.UUID, periodStart, periodEnd time.Time) (*domain.AllocationRun, error) {
+	exists, err := s.repo.ExistsGenerated(ctx, companyID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrAllocationRunExists
+	}
+
+	run, err := s.Preview(ctx, companyID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	entries := run.VoucherEntries()
+	if len(entries) == 0 {
+		return nil, domain.ErrNothingToAllocate
+	}
+
+	// The voucher references the run, so its ID is assigned up front
+	if run.ID, err = uuid.NewV7(); err != nil {
+		return nil, err
+	}
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		VoucherDate:   periodEnd,
+		VoucherType:   domain.VoucherTypeAdjustment,
+		Description:   fmt.Sprintf("공통비 배부 %s", periodEnd.Format("2006-01")),
+		ReferenceType: allocationRunReferenceType,
+		ReferenceID:   &run.ID,
+		Entries:       entries,
+		CreatedBy:     &userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, fmt.Errorf("failed to create allocation voucher: %w", err)
+	}
+
+	run.VoucherID = &voucher.ID
+	run.CreatedBy = &userID
+	if err := s.repo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Reverse undoes the voucher of the run
+func (s *allocationRunService) Reverse(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.AllocationRun, error) {
+	run, err := s.repo.GetByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status == domain.AllocationRunStatusReversed {
+		return nil, domain.ErrAllocationRunReversed
+	}
+
+	if run.VoucherID != nil {
+		voucher, err := s.voucherService.GetByID(ctx, companyID, *run.VoucherID)
+		if err != nil {
+			return nil, err
+		}
+		switch voucher.Status {
+		case domain.VoucherStatusPosted:
+			reversal, err := s.voucherService.Reverse(ctx, companyID, voucher.ID, userID, run.PeriodEnd,
+				fmt.Sprintf("공통비 배부 취소 %s", run.PeriodEnd.Format("2006-01")))
+			if err != nil {
+				return nil, err
+			}
+			run.ReversalVoucherID = &reversal.ID
+		case domain.VoucherStatusCancelled:
+			// Cancelled by hand; there is nothing left to undo
+		default:
+			if err := s.voucherService.Cancel(ctx, companyID, voucher.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now()
+	run.Status = domain.AllocationRunStatusReversed
+	run.ReversedBy = &userID
+	run.ReversedAt = &now
+	if err := s.repo.MarkReversed(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetByID returns an allocation run with its lines
+func (s *allocationRunService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.AllocationRun, error) {
+	return s.repo.GetByID(ctx, companyID, id)
+}
+
+// List lists allocation runs, latest period first
+func (s *allocationRunService) List(ctx context.Context, filter repository.AllocationRunFilter) ([]domain.AllocationRun, int64, error) {
+	return s.repo.List(ctx, filter)
+}
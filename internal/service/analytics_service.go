@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// kpiSeriesCacheTTL is how long KPI series are served from the cache
+const kpiSeriesCacheTTL = 5 * time.Minute
+
+// AnalyticsService defines the interface for the KPI series of dashboard charts
+type AnalyticsService interface {
+	// TimeSeries returns the series of the query, computed from the ledger
+	// balances and cached briefly
+	TimeSeries(ctx context.Context, companyID uuid.UUID, q domain.KPISeriesQuery) (*domain.KPISeries, error)
+}
+
+// analyticsService implements AnalyticsService
+type analyticsService struct {
+	ledgerRepo  repository.LedgerRepository
+	accountRepo repository.AccountRepository
+	cache       ReportCache // nil disables caching
+}
+
+// NewAnalyticsService creates a new AnalyticsService
+func NewAnalyticsService(ledgerRepo repository.LedgerRepository, accountRepo repository.AccountRepository, cache ReportCache) AnalyticsService {
+	return &analyticsService{ledgerRepo: ledgerRepo, accountRepo: accountRepo, cache: cache}
+}
+
+// TimeSeries validates the query and builds its series from the monthly totals
+func (s *analyticsService) TimeSeries(ctx context.Context, companyID uuid.UUID, q domain.KPISeriesQuery) (*domain.KPISeries, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("kerp:kpi-series:%s:%s:%s:%d-%02d:%d-%02d:%s:%s", companyID, q.Metric, q.Granularity,
+		q.FromYear, q.FromMonth, q.ToYear, q.ToMonth, optionalID(q.DepartmentID), optionalID(q.ProjectID))
+	if s.cache != nil {
+		if cached, err := s.cache.Get(ctx, key); err == nil && cached != "" {
+			var series domain.KPISeries
+			if json.Unmarshal([]byte(cached), &series) == nil {
+				return &series, nil
+			}
+		}
+	}
+
+	totals, err := s.ledgerRepo.GetMonthlyTotals(ctx, repository.LedgerTotalsFilter{
+		CompanyID:    companyID,
+		AccountType:  q.Metric.AccountType(),
+		FromYear:     q.FromYear,
+		FromMonth:    q.FromMonth,
+		ToYear:       q.ToYear,
+		ToMonth:      q.ToMonth,
+		DepartmentID: q.DepartmentID,
+		ProjectID:    q.ProjectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	opening := 0.0
+	if q.Metric == domain.KPIMetricCash {
+		from := time.Date(q.FromYear, time.Month(q.FromMonth), 1, 0, 0, 0, 0, time.UTC)
+		if opening, err = cashBalanceBefore(ctx, s.accountRepo, s.ledgerRepo, companyID, from); err != nil {
+			return nil, err
+		}
+	}
+	series := domain.BuildKPISeries(q, totals, opening)
+
+	// A cache failure only costs the next request a recomputation
+	if s.cache != nil {
+		if data, err := json.Marshal(series); err == nil {
+			_ = s.cache.Set(ctx, key, data, kpiSeriesCacheTTL)
+		}
+	}
+	return series, nil
+}
+
+// optionalID formats an optional ID for a cache key
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return "-"
+	}
+	return id.String()
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// apMatchingPageSize is the number of invoices read per query
+const apMatchingPageSize = 500
+
+// apMatchableVoucherStatuses are the statuses of vouchers that can book an invoice
+var apMatchableVoucherStatuses = []domain.VoucherStatus{
+	domain.VoucherStatusDraft,
+	domain.VoucherStatusPending,
+	domain.VoucherStatusApproved,
+	domain.VoucherStatusPosted,
+}
+
+// APMatchingService defines the interface for matching received purchase tax
+// invoices to the purchase vouchers booking them. A match is recorded on both
+// sides: the voucher of the invoice and the reference document of the voucher.
+type APMatchingService interface {
+	// AutoMatch matches the unmatched purchase invoices issued in the period to
+	// purchase vouchers of the supplier within the tolerance
+	AutoMatch(ctx context.Context, companyID, userID uuid.UUID, from, to time.Time, tolerance domain.APMatchTolerance) (*domain.APMatchRun, error)
+
+	// Worklist returns the purchase invoices and vouchers of the period left unmatched
+	Worklist(ctx context.Context, companyID uuid.UUID, from, to time.Time) (*domain.APWorklist, error)
+
+	// Match links an invoice to a voucher chosen by a clerk, regardless of tolerance
+	Match(ctx context.Context, companyID, userID, invoiceID, voucherID uuid.UUID) (*domain.APMatch, error)
+	Unmatch(ctx context.Context, companyID, userID, invoiceID uuid.UUID) error
+}
+
+// apMatchingService implements APMatchingService
+type apMatchingService struct {
+	invoiceRepo repository.TaxInvoiceRepository
+	voucherRepo repository.VoucherRepository
+	partnerRepo repository.PartnerRepository
+}
+
+// NewAPMatchingService creates a new APMatchingService
+func NewAPMatchingService(
+	invoiceRepo repository.TaxInvoiceRepository,
+	voucherRepo repository.VoucherRepository,
+	partnerRepo repository.PartnerRepository,
+) APMatchingService {
+	return &apMatchingService{
+		invoiceRepo: invoiceRepo,
+		voucherRepo: voucherRepo,
+		partnerRepo: partnerRepo,
+	}
+}
+
+// AutoMatch matches each invoice to the closest voucher of the supplier. An
+// invoice whose supplier is not a partner, or which has several equally close
+// vouchers, is left for the worklist.
+func (s *apMatchingService) AutoMatch(ctx context.Context, companyID, userID uuid.UUID, from, to time.Time, tolerance domain.APMatchTolerance) (*domain.APMatchRun, error) {
+	if err := tolerance.Validate(); err != nil {
+		return nil, err
+	}
+
+	invoices, err := s.unmatchedInvoices(ctx, companyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.APMatchRun{Matched: []domain.APMatch{}}
+	partners := make(map[string]*domain.Partner)
+	used := make(map[uuid.UUID]bool)
+	for _, invoice := range invoices {
+		run.Checked++
+
+		number := domain.BusinessNumberDigits(invoice.SupplierBusinessNumber)
+		partner, seen := partners[number]
+		if !seen {
+			partner = s.findPartner(ctx, companyID, number)
+			partners[number] = partner
+		}
+		if partner == nil {
+			continue
+		}
+
+		vouchers, err := s.candidateVouchers(ctx, invoice, partner, tolerance)
+		if err != nil {
+			return nil, err
+		}
+		candidates := make([]domain.APMatch, 0, len(vouchers))
+		byID := make(map[uuid.UUID]*domain.Voucher, len(vouchers))
+		for i := range vouchers {
+			if used[vouchers[i].ID] {
+				continue
+			}
+			candidates = append(candidates, domain.NewAPMatch(invoice, &vouchers[i]))
+			byID[vouchers[i].ID] = &vouchers[i]
+		}
+
+		match, ok := domain.BestAPMatch(candidates, tolerance)
+		if !ok {
+			if len(candidates) > 1 {
+				run.Ambiguous++
+			}
+			continue
+		}
+		if err := s.link(ctx, invoice, byID[match.VoucherID], userID); err != nil {
+			return nil, err
+		}
+		used[match.VoucherID] = true
+		run.Matched = append(run.Matched, match)
+	}
+	return run, nil
+}
+
+// Worklist lists what AutoMatch left unmatched
+func (s *apMatchingService) Worklist(ctx context.Context, companyID uuid.UUID, from, to time.Time) (*domain.APWorklist, error) {
+	invoices, err := s.unmatchedInvoices(ctx, companyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	voucherType := domain.VoucherTypePurchase
+	vouchers, _, err := s.voucherRepo.FindAll(ctx, repository.VoucherFilter{
+		CompanyID:    companyID,
+		VoucherType:  &voucherType,
+		Statuses:     apMatchableVoucherStatuses,
+		DateFrom:     &from,
+		DateTo:       &to,
+		Unreferenced: true,
+		SortBy:       "voucher_date, voucher_no",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if vouchers == nil {
+		vouchers = []domain.Voucher{}
+	}
+	return &domain.APWorklist{Invoices: invoices, Vouchers: vouchers}, nil
+}
+
+// Match links the invoice and the voucher
+func (s *apMatchingService) Match(ctx context.Context, companyID, userID, invoiceID, voucherID uuid.UUID) (*domain.APMatch, error) {
+	invoice, err := s.invoiceRepo.GetByID(ctx, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.CanBeMatched() {
+		return nil, domain.ErrTaxInvoiceNotMatchable
+	}
+	if invoice.VoucherID != nil {
+		return nil, domain.ErrTaxInvoiceAlreadyMatched
+	}
+
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+	if !voucher.CanBeMatched() {
+		return nil, domain.ErrVoucherNotMatchable
+	}
+	if voucher.ReferenceID != nil {
+		return nil, domain.ErrVoucherAlreadyReferenced
+	}
+
+	if err := s.link(ctx, invoice, voucher, userID); err != nil {
+		return nil, err
+	}
+	match := domain.NewAPMatch(invoice, voucher)
+	return &match, nil
+}
+
+// Unmatch removes the link of the invoice and its voucher
+func (s *apMatchingService) Unmatch(ctx context.Context, companyID, userID, invoiceID uuid.UUID) error {
+	invoice, err := s.invoiceRepo.GetByID(ctx, companyID, invoiceID)
+	if err != nil {
+		return err
+	}
+	if !invoice.CanBeMatched() {
+		return domain.ErrTaxInvoiceNotMatchable
+	}
+	if invoice.VoucherID == nil {
+		return domain.ErrTaxInvoiceNotMatched
+	}
+
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, *invoice.VoucherID)
+	if err != nil {
+		return err
+	}
+	// The voucher may have been linked to another document since
+	if voucher.ReferenceType == taxInvoiceReferenceType && voucher.ReferenceID != nil && *voucher.ReferenceID == invoice.ID {
+		voucher.ReferenceType = ""
+		voucher.ReferenceID = nil
+		voucher.UpdatedBy = &userID
+		if err := s.voucherRepo.UpdateReference(ctx, voucher); err != nil {
+			return err
+		}
+	}
+
+	invoice.VoucherID = nil
+	invoice.UpdatedBy = &userID
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return err
+	}
+	s.recordHistory(ctx, invoice, userID, "Unmatched from voucher "+voucher.VoucherNo)
+	return nil
+}
+
+// link records the match on the voucher and the invoice
+func (s *apMatchingService) link(ctx context.Context, invoice *domain.TaxInvoice, voucher *domain.Voucher, userID uuid.UUID) error {
+	voucher.ReferenceType = taxInvoiceReferenceType
+	voucher.ReferenceID = &invoice.ID
+	voucher.UpdatedBy = &userID
+	if err := s.voucherRepo.UpdateReference(ctx, voucher); err != nil {
+		return err
+	}
+
+	invoice.VoucherID = &voucher.ID
+	invoice.UpdatedBy = &userID
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return err
+	}
+	s.recordHistory(ctx, invoice, userID, "Matched to voucher "+voucher.VoucherNo)
+	return nil
+}
+
+// unmatchedInvoices lists the matchable purchase invoices of the period without voucher
+func (s *apMatchingService) unmatchedInvoices(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]*domain.TaxInvoice, error) {
+	invoiceType := domain.TaxInvoiceTypePurchase
+	filter := &repository.TaxInvoiceFilter{
+		CompanyID:   companyID,
+		StartDate:   &from,
+		EndDate:     &to,
+		InvoiceType: &invoiceType,
+		Unmatched:   true,
+		PageSize:    apMatchingPageSize,
+	}
+
+	invoices := []*domain.TaxInvoice{}
+	for filter.Page = 1; ; filter.Page++ {
+		page, total, err := s.invoiceRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, invoice := range page {
+			if invoice.CanBeMatched() {
+				invoices = append(invoices, invoice)
+			}
+		}
+		if len(page) < apMatchingPageSize || int64(filter.Page*apMatchingPageSize) >= total {
+			return invoices, nil
+		}
+	}
+}
+
+// candidateVouchers lists the unlinked purchase vouchers of the partner within the tolerance of the invoice
+func (s *apMatchingService) candidateVouchers(ctx context.Context, invoice *domain.TaxInvoice, partner *domain.Partner, tolerance domain.APMatchTolerance) ([]domain.Voucher, error) {
+	voucherType := domain.VoucherTypePurchase
+	dateFrom := invoice.IssueDate.AddDate(0, 0, -tolerance.DateDays)
+	dateTo := invoice.IssueDate.AddDate(0, 0, tolerance.DateDays)
+	minAmount := float64(invoice.TotalAmount - tolerance.Amount)
+	maxAmount := float64(invoice.TotalAmount + tolerance.Amount)
+
+	vouchers, _, err := s.voucherRepo.FindAll(ctx, repository.VoucherFilter{
+		CompanyID:    invoice.CompanyID,
+		VoucherType:  &voucherType,
+		Statuses:     apMatchableVoucherStatuses,
+		DateFrom:     &dateFrom,
+		DateTo:       &dateTo,
+		MinAmount:    &minAmount,
+		MaxAmount:    &maxAmount,
+		PartnerID:    &partner.ID,
+		Unreferenced: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vouchers for %s: %w", invoice.InvoiceNumber, err)
+	}
+	return vouchers, nil
+}
+
+// findPartner finds the partner of a business number stored with or without
+// dashes, or nil if there is none
+func (s *apMatchingService) findPartner(ctx context.Context, companyID uuid.UUID, number string) *domain.Partner {
+	if number == "" {
+		return nil
+	}
+	for _, candidate := range []string{number, formatBusinessNumber(number)} {
+		if partner, err := s.partnerRepo.GetByBusinessNumber(ctx, companyID, candidate); err == nil {
+			return partner
+		}
+	}
+	return nil
+}
+
+// recordHistory notes a matching event in the invoice history; the status is unchanged
+func (s *apMatchingService) recordHistory(ctx context.Context, invoice *domain.TaxInvoice, userID uuid.UUID, reason string) {
+	_ = s.invoiceRepo.CreateHistory(ctx, &domain.TaxInvoiceHistory{
+		ID:             uuid.New(),
+		TaxInvoiceID:   invoice.ID,
+		CompanyID:      invoice.CompanyID,
+		PreviousStatus: invoice.Status,
+		NewStatus:      invoice.Status,
+		ChangedBy:      &userID,
+		ChangeReason:   reason,
+		CreatedAt:      time.Now(),
+	})
+}
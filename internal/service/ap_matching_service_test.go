@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+func TestAPMatchingService_AutoMatch(t *testing.T) {
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	voucherRepo := new(mocks.MockVoucherRepository)
+	partnerRepo := new(mocks.MockPartnerRepository)
+	svc := service.NewAPMatchingService(invoiceRepo, voucherRepo, partnerRepo)
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	issueDate := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	invoice := &domain.TaxInvoice{
+		ID:                     uuid.New(),
+		CompanyID:              companyID,
+		InvoiceNumber:          "P-001",
+		InvoiceType:            domain.TaxInvoiceTypePurchase,
+		IssueDate:              issueDate,
+		Status:                 domain.TaxInvoiceStatusConfirmed,
+		SupplierBusinessNumber: "1234567891",
+		TotalAmount:            1100000,
+	}
+	unknownSupplier := &domain.TaxInvoice{
+		ID:                     uuid.New(),
+		CompanyID:              companyID,
+		InvoiceNumber:          "P-002",
+		InvoiceType:            domain.TaxInvoiceTypePurchase,
+		IssueDate:              issueDate,
+		Status:                 domain.TaxInvoiceStatusConfirmed,
+		SupplierBusinessNumber: "2208100001",
+		TotalAmount:            50000,
+	}
+	invoiceRepo.On("List", mock.Anything, mock.MatchedBy(func(f *repository.TaxInvoiceFilter) bool {
+		return f.Unmatched && *f.InvoiceType == domain.TaxInvoiceTypePurchase
+	})).Return([]*domain.TaxInvoice{invoice, unknownSupplier}, int64(2), nil)
+
+	// Partners are stored with dashes
+	partner := &domain.Partner{BusinessNumber: "123-45-67891"}
+	partner.ID = uuid.New()
+	partnerRepo.On("GetByBusinessNumber", mock.Anything, companyID, "123-45-67891").Return(partner, nil)
+	partnerRepo.On("GetByBusinessNumber", mock.Anything, companyID, mock.Anything).Return(nil, errors.New("partner not found"))
+
+	far := domain.Voucher{VoucherNo: "202403-0002", VoucherType: domain.VoucherTypePurchase, VoucherDate: issueDate.AddDate(0, 0, 5), TotalDebit: 1100000}
+	far.ID = uuid.New()
+	near := domain.Voucher{VoucherNo: "202403-0001", VoucherType: domain.VoucherTypePurchase, VoucherDate: issueDate.AddDate(0, 0, 1), TotalDebit: 1100000}
+	near.ID, near.CompanyID = uuid.New(), companyID
+	voucherRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(f repository.VoucherFilter) bool {
+		return f.Unreferenced && *f.PartnerID == partner.ID && *f.MinAmount == 1099990
+	})).Return([]domain.Voucher{far, near}, int64(2), nil).Once()
+
+	voucherRepo.On("UpdateReference", mock.Anything, mock.MatchedBy(func(v *domain.Voucher) bool {
+		return v.ID == near.ID && v.ReferenceType == "tax_invoice" && *v.ReferenceID == invoice.ID
+	})).Return(nil).Once()
+	invoiceRepo.On("Update", mock.Anything, invoice).Return(nil).Once()
+	invoiceRepo.On("CreateHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+	run, err := svc.AutoMatch(context.Background(), companyID, userID, issueDate, issueDate, domain.DefaultAPMatchTolerance())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, run.Checked)
+	require.Len(t, run.Matched, 1)
+	assert.Equal(t, near.ID, run.Matched[0].VoucherID)
+	assert.Equal(t, 1, run.Matched[0].DateDifference)
+	assert.Equal(t, &near.ID, invoice.VoucherID)
+	invoiceRepo.AssertExpectations(t)
+	voucherRepo.AssertExpectations(t)
+}
+
+func TestAPMatchingService_Match_Referenced(t *testing.T) {
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	voucherRepo := new(mocks.MockVoucherRepository)
+	svc := service.NewAPMatchingService(invoiceRepo, voucherRepo, new(mocks.MockPartnerRepository))
+
+	companyID := newTestCompanyID()
+	invoice := &domain.TaxInvoice{ID: uuid.New(), CompanyID: companyID, InvoiceType: domain.TaxInvoiceTypePurchase, Status: domain.TaxInvoiceStatusConfirmed}
+	otherID := uuid.New()
+	voucher := &domain.Voucher{VoucherType: domain.VoucherTypePurchase, Status: domain.VoucherStatusPosted, ReferenceType: "legacy_import", ReferenceID: &otherID}
+	voucher.ID = uuid.New()
+	invoiceRepo.On("GetByID", mock.Anything, companyID, invoice.ID).Return(invoice, nil)
+	voucherRepo.On("FindByID", mock.Anything, companyID, voucher.ID).Return(voucher, nil)
+
+	_, err := svc.Match(context.Background(), companyID, newTestUserID(), invoice.ID, voucher.ID)
+	assert.ErrorIs(t, err, domain.ErrVoucherAlreadyReferenced)
+	voucherRepo.AssertNotCalled(t, "UpdateReference", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ApprovalActionRequest is a one-tap approve or reject action, confirmed by
+// the signing PIN of the approver
+type ApprovalActionRequest struct {
+	PIN       string
+	Reason    string // rejection reason
+	IPAddress string
+	UserAgent string
+}
+
+// ApprovalNotifier notifies approvers of documents submitted for approval
+type ApprovalNotifier interface {
+	// NotifySubmitted queues push notifications to the approvers of a voucher
+	// still pending approval. Failures are logged; they never fail the submission.
+	NotifySubmitted(ctx context.Context, voucher *domain.Voucher)
+}
+
+// ApprovalService defines the interface for the approval inbox of the mobile
+// app: the documents waiting for the user, one-tap actions and the devices
+// receiving push notifications of new requests.
+//
+// Vouchers are the only documents with an approval workflow today; other
+// document types are meant to be added to the inbox as they get one.
+type ApprovalService interface {
+	ApprovalNotifier
+
+	// Inbox lists the documents the user may approve, oldest request first
+	Inbox(ctx context.Context, companyID, userID uuid.UUID) ([]domain.ApprovalItem, error)
+
+	// ApproveVoucher and RejectVoucher act on a voucher after checking the PIN
+	ApproveVoucher(ctx context.Context, companyID, userID, voucherID uuid.UUID, req ApprovalActionRequest) (*domain.Voucher, error)
+	RejectVoucher(ctx context.Context, companyID, userID, voucherID uuid.UUID, req ApprovalActionRequest) (*domain.Voucher, error)
+
+	// Device registration for push notifications
+	RegisterDevice(ctx context.Context, companyID, userID uuid.UUID, platform domain.PushPlatform, token, deviceName string) (*domain.PushDevice, error)
+	UnregisterDevice(ctx context.Context, companyID, userID uuid.UUID, token string) error
+
+	// PushSubmitted sends the notifications queued by NotifySubmitted and
+	// returns the number delivered; it is run by the worker
+	PushSubmitted(ctx context.Context, companyID, voucherID uuid.UUID) (int, error)
+}
+
+// approvalService implements ApprovalService
+type approvalService struct {
+	deviceRepo    repository.PushDeviceRepository
+	userRepo      repository.UserRepository
+	vouchers      VoucherService
+	settings      CompanySettingsService
+	signatures    VoucherSignatureService
+	notifications NotificationService
+	jobs          JobQueue
+	logger        *zap.Logger
+}
+
+// NewApprovalService creates a new ApprovalService
+func NewApprovalService(
+	deviceRepo repository.PushDeviceRepository,
+	userRepo repository.UserRepository,
+	vouchers VoucherService,
+	settings CompanySettingsService,
+	signatures VoucherSignatureService,
+	notifications NotificationService,
+	jobs JobQueue,
+	logger *zap.Logger,
+) ApprovalService {
+	return &approvalService{
+		deviceRepo:    deviceRepo,
+		userRepo:      userRepo,
+		vouchers:      vouchers,
+		settings:      settings,
+		signatures:    signatures,
+		notifications: notifications,
+		jobs:          jobs,
+		logger:        logger,
+	}
+}
+
+// Inbox lists the pending vouchers the user may approve. Viewers have none;
+// under segregation of duties the user's own vouchers are left out.
+func (s *approvalService) Inbox(ctx context.Context, companyID, userID uuid.UUID) ([]domain.ApprovalItem, error) {
+	user, err := s.userRepo.FindMember(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	items := []domain.ApprovalItem{}
+	if !user.CanApprove() {
+		return items, nil
+	}
+
+	vouchers, err := s.approvableVouchers(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.userNames(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	// Pending vouchers are found newest first; the inbox shows the oldest request first
+	for i := len(vouchers) - 1; i >= 0; i-- {
+		item := domain.NewVoucherApprovalItem(&vouchers[i])
+		if item.RequestedBy != nil {
+			item.RequesterName = names[*item.RequestedBy]
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// approvableVouchers returns the pending vouchers the user may approve
+func (s *approvalService) approvableVouchers(ctx context.Context, companyID, userID uuid.UUID) ([]domain.Voucher, error) {
+	vouchers, err := s.vouchers.GetPending(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.EnforceSegregationOfDuties {
+		return vouchers, nil
+	}
+	approvable := vouchers[:0]
+	for _, v := range vouchers {
+		if v.CheckSegregationOfDuties(userID) == nil {
+			approvable = append(approvable, v)
+		}
+	}
+	return approvable, nil
+}
+
+// userNames maps the users of the company to their names
+func (s *approvalService) userNames(ctx context.Context, companyID uuid.UUID) (map[uuid.UUID]string, error) {
+	users, _, err := s.userRepo.FindAll(ctx, repository.UserFilter{CompanyID: companyID})
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Name
+	}
+	return names, nil
+}
+
+// ApproveVoucher approves the voucher and records the PIN confirmation as its
+// approval signature
+func (s *approvalService) ApproveVoucher(ctx context.Context, companyID, userID, voucherID uuid.UUID, req ApprovalActionRequest) (*domain.Voucher, error) {
+	if req.PIN == "" {
+		return nil, domain.ErrApprovalPINRequired
+	}
+	signature, err := s.signatures.Verify(ctx, companyID, userID, domain.SignatureActionApprove, &SignatureInput{
+		PIN:       req.PIN,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.vouchers.Approve(ctx, companyID, voucherID, userID); err != nil {
+		return nil, err
+	}
+	voucher, err := s.vouchers.GetByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.signatures.Record(ctx, voucher, signature); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// RejectVoucher rejects the voucher once the PIN is confirmed
+func (s *approvalService) RejectVoucher(ctx context.Context, companyID, userID, voucherID uuid.UUID, req ApprovalActionRequest) (*domain.Voucher, error) {
+	if req.PIN == "" {
+		return nil, domain.ErrApprovalPINRequired
+	}
+	user, err := s.userRepo.FindMember(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := confirmSigningPIN(ctx, s.userRepo, user, req.PIN); err != nil {
+		return nil, err
+	}
+
+	if err := s.vouchers.Reject(ctx, companyID, voucherID, userID, req.Reason); err != nil {
+		return nil, err
+	}
+	return s.vouchers.GetByID(ctx, companyID, voucherID)
+}
+
+// RegisterDevice registers a device of the user for push notifications
+func (s *approvalService) RegisterDevice(ctx context.Context, companyID, userID uuid.UUID, platform domain.PushPlatform, token, deviceName string) (*domain.PushDevice, error) {
+	device, err := domain.NewPushDevice(companyID, userID, platform, token, deviceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deviceRepo.Upsert(ctx, device); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// UnregisterDevice stops push notifications to a device of the user, e.g. on sign-out
+func (s *approvalService) UnregisterDevice(ctx context.Context, companyID, userID uuid.UUID, token string) error {
+	return s.deviceRepo.Delete(ctx, companyID, userID, token)
+}
+
+// NotifySubmitted queues an approval push job for a pending voucher
+func (s *approvalService) NotifySubmitted(ctx context.Context, voucher *domain.Voucher) {
+	if voucher == nil || voucher.Status != domain.VoucherStatusPending {
+		return
+	}
+	_, err := s.jobs.Enqueue(ctx, voucher.CompanyID, domain.JobTypeApprovalPush,
+		domain.ApprovalPushPayload{VoucherID: voucher.ID}, nil, voucher.SubmittedBy)
+	if err != nil {
+		s.logger.Warn("Failed to queue approval push notifications",
+			zap.String("voucher_id", voucher.ID.String()), zap.Error(err))
+	}
+}
+
+// PushSubmitted notifies the devices of the users who may approve the voucher.
+// Vouchers decided before the job runs are skipped; tokens the push service
+// reports as invalid are removed.
+func (s *approvalService) PushSubmitted(ctx context.Context, companyID, voucherID uuid.UUID) (int, error) {
+	voucher, err := s.vouchers.GetByID(ctx, companyID, voucherID)
+	if err != nil {
+		return 0, err
+	}
+	if voucher.Status != domain.VoucherStatusPending {
+		return 0, nil
+	}
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return 0, err
+	}
+
+	active := domain.UserStatusActive
+	users, _, err := s.userRepo.FindAll(ctx, repository.UserFilter{CompanyID: companyID, Status: &active})
+	if err != nil {
+		return 0, err
+	}
+	var approvers []uuid.UUID
+	requester := ""
+	for _, u := range users {
+		if voucher.SubmittedBy != nil && u.ID == *voucher.SubmittedBy {
+			requester = u.Name
+		}
+		if !u.CanApprove() || (voucher.SubmittedBy != nil && u.ID == *voucher.SubmittedBy) {
+			continue
+		}
+		if settings.EnforceSegregationOfDuties && voucher.CheckSegregationOfDuties(u.ID) != nil {
+			continue
+		}
+		approvers = append(approvers, u.ID)
+	}
+
+	devices, err := s.deviceRepo.FindByUsers(ctx, companyID, approvers)
+	if err != nil {
+		return 0, err
+	}
+	msg := voucherApprovalPush(voucher, requester)
+
+	sent := 0
+	var errs []error
+	for _, device := range devices {
+		platform := provider.ProviderType(device.Platform)
+		if !s.notifications.IsPushEnabled(ctx, platform) {
+			continue
+		}
+		msg.Token = device.Token
+		err := s.notifications.SendPush(ctx, platform, &msg)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, provider.ErrPushTokenInvalid):
+			if err := s.deviceRepo.DeleteByToken(ctx, device.Token); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			errs = append(errs, fmt.Errorf("device %s: %w", device.ID, err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// voucherApprovalPush builds the push notification of a voucher submitted for
+// approval; the data lets the app open the voucher in the inbox
+func voucherApprovalPush(voucher *domain.Voucher, requester string) provider.PushMessage {
+	if requester == "" {
+		requester = "사용자"
+	}
+	body := fmt.Sprintf("%s 님이 전표 %s (%s원) 결재를 요청했습니다", requester, voucher.VoucherNo,
+		reportDisplayNumber(reportNumber(voucher.TotalDebit)))
+	if voucher.Description != "" {
+		body += ": " + voucher.Description
+	}
+	return provider.PushMessage{
+		Title: "결재 요청",
+		Body:  body,
+		Data: map[string]string{
+			"type":       string(domain.ApprovalItemVoucher),
+			"id":         voucher.ID.String(),
+			"company_id": voucher.CompanyID.String(),
+		},
+	}
+}
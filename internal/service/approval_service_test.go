@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakePushNotifications records push notifications; tokens in invalid are
+// reported as no longer registered
+type fakePushNotifications struct {
+	service.NotificationService
+	sent    []provider.PushMessage
+	invalid map[string]bool
+}
+
+func (f *fakePushNotifications) IsPushEnabled(ctx context.Context, platform provider.ProviderType) bool {
+	return platform == provider.ProviderTypeFCM
+}
+
+func (f *fakePushNotifications) SendPush(ctx context.Context, platform provider.ProviderType, msg *provider.PushMessage) error {
+	if f.invalid[msg.Token] {
+		return provider.ErrPushTokenInvalid
+	}
+	f.sent = append(f.sent, *msg)
+	return nil
+}
+
+func newApprovalTestUser(companyID uuid.UUID, name string, role domain.UserRole) domain.User {
+	return domain.User{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		Name:        name,
+		Role:        role,
+		Status:      domain.UserStatusActive,
+	}
+}
+
+func newApprovalTestVoucher(companyID uuid.UUID, no string, submittedBy uuid.UUID) domain.Voucher {
+	return domain.Voucher{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		VoucherNo:   no,
+		Status:      domain.VoucherStatusPending,
+		TotalDebit:  1250000,
+		CreatedBy:   &submittedBy,
+		SubmittedBy: &submittedBy,
+	}
+}
+
+func TestApprovalService_Inbox(t *testing.T) {
+	companyID := newTestCompanyID()
+	submitter := newApprovalTestUser(companyID, "김영희", domain.UserRoleUser)
+	approver := newApprovalTestUser(companyID, "이철수", domain.UserRoleAdmin)
+	viewer := newApprovalTestUser(companyID, "박감사", domain.UserRoleViewer)
+	// An outside bookkeeper approves as a member of the company
+	bookkeeper := newApprovalTestUser(uuid.New(), "최세무", domain.UserRoleUser)
+	// Pending vouchers are found newest first
+	newer := newApprovalTestVoucher(companyID, "V-002", approver.ID)
+	older := newApprovalTestVoucher(companyID, "V-001", submitter.ID)
+
+	setup := func(enforceSoD bool) service.ApprovalService {
+		userRepo := new(mocks.MockUserRepository)
+		for _, u := range []domain.User{submitter, approver, viewer, bookkeeper} {
+			u := u
+			userRepo.On("FindMember", mock.Anything, companyID, u.ID).Return(&u, nil).Maybe()
+		}
+		userRepo.On("FindAll", mock.Anything, mock.Anything).Return([]domain.User{submitter, approver, viewer}, int64(3), nil).Maybe()
+		vouchers := new(mocks.MockVoucherService)
+		vouchers.On("GetPending", mock.Anything, companyID).Return([]domain.Voucher{newer, older}, nil).Maybe()
+
+		settings := domain.DefaultCompanySettings()
+		settings.EnforceSegregationOfDuties = enforceSoD
+		return service.NewApprovalService(nil, userRepo, vouchers, newTestSettingsService(settings), nil, nil, nil, zap.NewNop())
+	}
+
+	t.Run("lists the oldest request first with the requester", func(t *testing.T) {
+		items, err := setup(false).Inbox(context.Background(), companyID, approver.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "V-001", items[0].Number)
+		assert.Equal(t, "김영희", items[0].RequesterName)
+		assert.Equal(t, domain.ApprovalItemVoucher, items[0].Type)
+	})
+
+	t.Run("leaves out the user's own vouchers under segregation of duties", func(t *testing.T) {
+		items, err := setup(true).Inbox(context.Background(), companyID, approver.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "V-001", items[0].Number)
+	})
+
+	t.Run("members of other companies approve with their membership role", func(t *testing.T) {
+		items, err := setup(false).Inbox(context.Background(), companyID, bookkeeper.ID)
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+
+	t.Run("viewers have no approvals", func(t *testing.T) {
+		items, err := setup(false).Inbox(context.Background(), companyID, viewer.ID)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
+
+func TestApprovalService_RejectVoucherChecksPIN(t *testing.T) {
+	companyID := newTestCompanyID()
+	approver := newApprovalTestUser(companyID, "이철수", domain.UserRoleAdmin)
+	require.NoError(t, approver.SetSigningPIN("1234"))
+	voucherID := uuid.New()
+
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindMember", mock.Anything, companyID, approver.ID).Return(&approver, nil)
+	userRepo.On("ClaimSigningPINAttempt", mock.Anything, approver.ID, domain.MaxSigningPINAttempts).Return(nil).Twice()
+	vouchers := new(mocks.MockVoucherService)
+	svc := service.NewApprovalService(nil, userRepo, vouchers, nil, nil, nil, nil, zap.NewNop())
+
+	_, err := svc.RejectVoucher(context.Background(), companyID, approver.ID, voucherID, service.ApprovalActionRequest{PIN: "0000"})
+	assert.ErrorIs(t, err, domain.ErrSigningPINInvalid)
+	userRepo.AssertNotCalled(t, "ResetSigningPINAttempts", mock.Anything, mock.Anything)
+	_, err = svc.RejectVoucher(context.Background(), companyID, approver.ID, voucherID, service.ApprovalActionRequest{})
+	assert.ErrorIs(t, err, domain.ErrApprovalPINRequired)
+	vouchers.AssertNotCalled(t, "Reject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The correct PIN clears the incorrect ones counted before it
+	userRepo.On("ResetSigningPINAttempts", mock.Anything, approver.ID).Return(nil).Once()
+	vouchers.On("Reject", mock.Anything, companyID, voucherID, approver.ID, "금액 오류").Return(nil).Once()
+	vouchers.On("GetByID", mock.Anything, companyID, voucherID).Return(&domain.Voucher{Status: domain.VoucherStatusRejected}, nil).Once()
+	voucher, err := svc.RejectVoucher(context.Background(), companyID, approver.ID, voucherID, service.ApprovalActionRequest{PIN: "1234", Reason: "금액 오류"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.VoucherStatusRejected, voucher.Status)
+	vouchers.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestApprovalService_RejectVoucherLocksPIN(t *testing.T) {
+	companyID := newTestCompanyID()
+	voucherID := uuid.New()
+
+	t.Run("refuses even the correct PIN once the attempts are used up", func(t *testing.T) {
+		approver := newApprovalTestUser(companyID, "이철수", domain.UserRoleAdmin)
+		require.NoError(t, approver.SetSigningPIN("1234"))
+		approver.SigningPINAttempts = domain.MaxSigningPINAttempts
+
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindMember", mock.Anything, companyID, approver.ID).Return(&approver, nil)
+		vouchers := new(mocks.MockVoucherService)
+		svc := service.NewApprovalService(nil, userRepo, vouchers, nil, nil, nil, nil, zap.NewNop())
+
+		_, err := svc.RejectVoucher(context.Background(), companyID, approver.ID, voucherID, service.ApprovalActionRequest{PIN: "1234"})
+		assert.ErrorIs(t, err, domain.ErrSigningPINLocked)
+		userRepo.AssertNotCalled(t, "ClaimSigningPINAttempt", mock.Anything, mock.Anything, mock.Anything)
+		vouchers.AssertNotCalled(t, "Reject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses a guess when concurrent guesses used up the attempts", func(t *testing.T) {
+		approver := newApprovalTestUser(companyID, "이철수", domain.UserRoleAdmin)
+		require.NoError(t, approver.SetSigningPIN("1234"))
+		approver.SigningPINAttempts = domain.MaxSigningPINAttempts - 1
+
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindMember", mock.Anything, companyID, approver.ID).Return(&approver, nil)
+		userRepo.On("ClaimSigningPINAttempt", mock.Anything, approver.ID, domain.MaxSigningPINAttempts).Return(domain.ErrSigningPINLocked).Once()
+		vouchers := new(mocks.MockVoucherService)
+		svc := service.NewApprovalService(nil, userRepo, vouchers, nil, nil, nil, nil, zap.NewNop())
+
+		_, err := svc.RejectVoucher(context.Background(), companyID, approver.ID, voucherID, service.ApprovalActionRequest{PIN: "1234"})
+		assert.ErrorIs(t, err, domain.ErrSigningPINLocked)
+		userRepo.AssertNotCalled(t, "ResetSigningPINAttempts", mock.Anything, mock.Anything)
+		vouchers.AssertNotCalled(t, "Reject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestApprovalService_PushSubmitted(t *testing.T) {
+	companyID := newTestCompanyID()
+	submitter := newApprovalTestUser(companyID, "김영희", domain.UserRoleUser)
+	approver := newApprovalTestUser(companyID, "이철수", domain.UserRoleAdmin)
+	viewer := newApprovalTestUser(companyID, "박감사", domain.UserRoleViewer)
+	voucher := newApprovalTestVoucher(companyID, "V-001", submitter.ID)
+
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindAll", mock.Anything, mock.Anything).Return([]domain.User{submitter, approver, viewer}, int64(3), nil)
+	vouchers := new(mocks.MockVoucherService)
+	vouchers.On("GetByID", mock.Anything, companyID, voucher.ID).Return(&voucher, nil)
+	deviceRepo := new(mocks.MockPushDeviceRepository)
+	// Only the approver is notified
+	deviceRepo.On("FindByUsers", mock.Anything, companyID, []uuid.UUID{approver.ID}).Return([]domain.PushDevice{
+		{UserID: approver.ID, Platform: domain.PushPlatformFCM, Token: "phone"},
+		{UserID: approver.ID, Platform: domain.PushPlatformFCM, Token: "uninstalled"},
+		{UserID: approver.ID, Platform: domain.PushPlatformAPNs, Token: "ipad"}, // APNs is not configured
+	}, nil)
+	deviceRepo.On("DeleteByToken", mock.Anything, "uninstalled").Return(nil).Once()
+	notifications := &fakePushNotifications{invalid: map[string]bool{"uninstalled": true}}
+
+	svc := service.NewApprovalService(deviceRepo, userRepo, vouchers, newTestSettingsService(domain.DefaultCompanySettings()), nil, notifications, nil, zap.NewNop())
+	sent, err := svc.PushSubmitted(context.Background(), companyID, voucher.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifications.sent, 1)
+	assert.Equal(t, "phone", notifications.sent[0].Token)
+	assert.Contains(t, notifications.sent[0].Body, "김영희 님이 전표 V-001 (1,250,000원)")
+	assert.Equal(t, voucher.ID.String(), notifications.sent[0].Data["id"])
+	deviceRepo.AssertExpectations(t)
+}
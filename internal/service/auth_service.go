@@ -16,6 +16,8 @@ import (
 type AuthService struct {
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	membershipRepo   repository.UserCompanyMembershipRepository
+	companyRepo      repository.CompanyRepository
 	jwtService       *auth.JWTService
 	logger           *zap.Logger
 }
@@ -24,12 +26,16 @@ type AuthService struct {
 func NewAuthService(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	membershipRepo repository.UserCompanyMembershipRepository,
+	companyRepo repository.CompanyRepository,
 	jwtService *auth.JWTService,
 	logger *zap.Logger,
 ) *AuthService {
 	return &AuthService{
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		membershipRepo:   membershipRepo,
+		companyRepo:      companyRepo,
 		jwtService:       jwtService,
 		logger:           logger,
 	}
@@ -43,11 +49,12 @@ type LoginInput struct {
 
 // LoginOutput represents login response data
 type LoginOutput struct {
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
-	TokenType    string       `json:"token_type"`
-	ExpiresIn    int64        `json:"expires_in"`
-	User         UserResponse `json:"user"`
+	AccessToken        string       `json:"access_token"`
+	RefreshToken       string       `json:"refresh_token"`
+	TokenType          string       `json:"token_type"`
+	ExpiresIn          int64        `json:"expires_in"`
+	MustChangePassword bool         `json:"must_change_password"` // an administrator reset the password
+	User               UserResponse `json:"user"`
 }
 
 // UserResponse represents user data in responses
@@ -122,10 +129,11 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginOutput
 	s.logger.Info("user logged in", zap.String("user_id", user.ID.String()), zap.String("email", user.Email))
 
 	return &LoginOutput{
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		TokenType:    tokenPair.TokenType,
-		ExpiresIn:    tokenPair.ExpiresIn,
+		AccessToken:        tokenPair.AccessToken,
+		RefreshToken:       tokenPair.RefreshToken,
+		TokenType:          tokenPair.TokenType,
+		ExpiresIn:          tokenPair.ExpiresIn,
+		MustChangePassword: user.MustChangePassword,
 		User: UserResponse{
 			ID:        user.ID,
 			CompanyID: user.CompanyID,
@@ -167,39 +175,38 @@ func (s *AuthService) Refresh(ctx context.Context, input RefreshInput) (*Refresh
 	}
 
 	// Find user
-	user, err := s.userRepo.FindByEmail(ctx, "")
+	user, err := s.userRepo.FindByUserID(ctx, rt.UserID)
 	if err != nil {
-		// We need to find user by ID, let's use a direct query
+		if err == domain.ErrUserNotFound {
+			return nil, domain.ErrRefreshTokenNotFound
+		}
 		return nil, err
 	}
+	if !user.IsActive() {
+		return nil, domain.ErrUserInactive
+	}
+
+	// Keep the session in the company it was switched to
+	membership := user.HomeMembership()
+	if rt.CompanyID != nil {
+		m, err := s.resolveMembership(ctx, user, *rt.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		membership = *m
+	}
 
-	// For now, we'll revoke the old refresh token
+	// Revoke the old refresh token
 	if err := s.refreshTokenRepo.RevokeByToken(ctx, input.RefreshToken); err != nil {
 		s.logger.Warn("failed to revoke old refresh token", zap.Error(err))
 	}
 
 	// Generate new token pair
-	tokenPair, err := s.jwtService.GenerateTokenPair(
-		rt.UserID,
-		user.CompanyID,
-		user.Email,
-		user.Name,
-		user.GetRoles(),
-	)
+	tokenPair, err := s.issueTokens(ctx, user, &membership)
 	if err != nil {
 		return nil, err
 	}
 
-	// Store new refresh token
-	newRefreshToken := &domain.RefreshToken{
-		UserID:    rt.UserID,
-		Token:     tokenPair.RefreshToken,
-		ExpiresAt: time.Now().Add(s.jwtService.GetRefreshTokenTTL()),
-	}
-	if err := s.refreshTokenRepo.Create(ctx, newRefreshToken); err != nil {
-		return nil, err
-	}
-
 	return &RefreshOutput{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
@@ -270,6 +277,13 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*Regis
 		return nil, err
 	}
 
+	// Record the home company membership
+	home := user.HomeMembership()
+	if err := s.membershipRepo.Create(ctx, &home); err != nil {
+		s.logger.Error("registration failed: membership storage error", zap.Error(err))
+		return nil, err
+	}
+
 	// Generate token pair
 	tokenPair, err := s.jwtService.GenerateTokenPair(
 		user.ID,
@@ -344,6 +358,7 @@ func (s *AuthService) ChangePassword(ctx context.Context, input ChangePasswordIn
 		s.logger.Error("change password failed: password hashing error", zap.Error(err))
 		return err
 	}
+	user.MustChangePassword = false
 
 	// Update user
 	if err := s.userRepo.Update(ctx, user); err != nil {
@@ -403,3 +418,172 @@ func (s *AuthService) ForgotPassword(ctx context.Context, input ForgotPasswordIn
 		Message:    "If an account with that email exists, a password reset link has been sent",
 	}, nil
 }
+
+// CompanyMembershipOutput represents a company the user can switch to
+type CompanyMembershipOutput struct {
+	CompanyID   uuid.UUID            `json:"company_id"`
+	CompanyCode string               `json:"company_code,omitempty"`
+	CompanyName string               `json:"company_name,omitempty"`
+	Status      domain.CompanyStatus `json:"status,omitempty"`
+	Role        domain.UserRole      `json:"role"`
+	IsDefault   bool                 `json:"is_default"`
+	IsActive    bool                 `json:"is_active"`
+	IsCurrent   bool                 `json:"is_current"`
+}
+
+// ListCompanies returns the companies the user belongs to, home company first.
+// currentCompanyID marks the company the caller's token is scoped to.
+func (s *AuthService) ListCompanies(ctx context.Context, userID, currentCompanyID uuid.UUID) ([]CompanyMembershipOutput, error) {
+	user, err := s.userRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.membershipRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users created before memberships existed may lack a home membership row
+	hasHome := false
+	for _, m := range memberships {
+		if m.CompanyID == user.CompanyID {
+			hasHome = true
+			break
+		}
+	}
+	if !hasHome {
+		home := user.HomeMembership()
+		if company, err := s.companyRepo.FindByID(ctx, user.CompanyID); err == nil {
+			home.Company = company
+		}
+		memberships = append([]domain.UserCompanyMembership{home}, memberships...)
+	}
+
+	result := make([]CompanyMembershipOutput, len(memberships))
+	for i, m := range memberships {
+		out := CompanyMembershipOutput{
+			CompanyID: m.CompanyID,
+			Role:      m.Role,
+			IsDefault: m.CompanyID == user.CompanyID,
+			IsActive:  m.IsActive,
+			IsCurrent: m.CompanyID == currentCompanyID,
+		}
+		if m.CompanyID == user.CompanyID {
+			out.Role = user.Role
+		}
+		if m.Company != nil {
+			out.CompanyCode = m.Company.Code
+			out.CompanyName = m.Company.Name
+			out.Status = m.Company.Status
+		}
+		result[i] = out
+	}
+	return result, nil
+}
+
+// SwitchCompanyInput represents company switch request data
+type SwitchCompanyInput struct {
+	UserID    uuid.UUID
+	CompanyID uuid.UUID
+}
+
+// SwitchCompany issues a new token pair scoped to one of the user's companies,
+// carrying the role the user holds in that company
+func (s *AuthService) SwitchCompany(ctx context.Context, input SwitchCompanyInput) (*LoginOutput, error) {
+	user, err := s.userRepo.FindByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == domain.UserStatusInactive {
+		return nil, domain.ErrUserInactive
+	}
+	if user.Status == domain.UserStatusLocked {
+		return nil, domain.ErrUserLocked
+	}
+
+	membership, err := s.resolveMembership(ctx, user, input.CompanyID)
+	if err != nil {
+		s.logger.Debug("switch company failed",
+			zap.String("user_id", user.ID.String()),
+			zap.String("company_id", input.CompanyID.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tokenPair, err := s.issueTokens(ctx, user, membership)
+	if err != nil {
+		s.logger.Error("switch company failed: token issuance error", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("user switched company",
+		zap.String("user_id", user.ID.String()),
+		zap.String("company_id", membership.CompanyID.String()),
+	)
+
+	return &LoginOutput{
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		TokenType:    tokenPair.TokenType,
+		ExpiresIn:    tokenPair.ExpiresIn,
+		User: UserResponse{
+			ID:        user.ID,
+			CompanyID: membership.CompanyID,
+			Email:     user.Email,
+			Name:      user.Name,
+			Role:      membership.Role,
+			Status:    user.Status,
+		},
+	}, nil
+}
+
+// resolveMembership returns the user's active membership in a company.
+// The home company is always accessible with the role on the user record.
+func (s *AuthService) resolveMembership(ctx context.Context, user *domain.User, companyID uuid.UUID) (*domain.UserCompanyMembership, error) {
+	if companyID == user.CompanyID {
+		home := user.HomeMembership()
+		return &home, nil
+	}
+
+	membership, err := s.membershipRepo.FindByUserAndCompany(ctx, user.ID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !membership.IsActive {
+		return nil, domain.ErrMembershipInactive
+	}
+	if membership.Company != nil && membership.Company.Status == domain.CompanyStatusSuspended {
+		return nil, domain.ErrMembershipInactive
+	}
+	return membership, nil
+}
+
+// issueTokens generates a token pair scoped to the membership's company and stores the refresh token
+func (s *AuthService) issueTokens(ctx context.Context, user *domain.User, membership *domain.UserCompanyMembership) (*auth.TokenPair, error) {
+	tokenPair, err := s.jwtService.GenerateTokenPair(
+		user.ID,
+		membership.CompanyID,
+		user.Email,
+		user.Name,
+		membership.GetRoles(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken := &domain.RefreshToken{
+		UserID:    user.ID,
+		Token:     tokenPair.RefreshToken,
+		ExpiresAt: time.Now().Add(s.jwtService.GetRefreshTokenTTL()),
+	}
+	if membership.CompanyID != user.CompanyID {
+		companyID := membership.CompanyID
+		refreshToken.CompanyID = &companyID
+	}
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+	return tokenPair, nil
+}
@@ -0,0 +1,442 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/cron"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/secrets"
+)
+
+const (
+	// backupListLimit is the number of restore points listed, about two months of nightly backups
+	backupListLimit = 60
+	// backupBatchSize is the number of backups or restores processed per worker run
+	backupBatchSize = 5
+	// backupStaleAfter is how long a backup or restore may stay processing
+	// before another worker assumes the first one crashed and runs it again
+	backupStaleAfter = time.Hour
+	// backupPurgeBatchSize is the number of expired backups deleted per worker run
+	backupPurgeBatchSize = 100
+)
+
+// BackupOptions holds the schedule, retention and storage layout of tenant backups
+type BackupOptions struct {
+	Schedule      *cron.Schedule // nil disables scheduled backups
+	Location      *time.Location // zone of the schedule and of the monthly retention
+	Retention     domain.BackupRetention
+	StoragePrefix string // prefix of the object keys
+}
+
+// BackupService defines the interface for scheduled tenant backups and their
+// restores into the staging database
+type BackupService interface {
+	// Request queues a backup of the company outside the schedule
+	Request(ctx context.Context, companyID, userID uuid.UUID) (*domain.TenantBackup, error)
+	// List retrieves the company's most recent backups, its restore points
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.TenantBackup, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TenantBackup, error)
+
+	// RequestRestore queues the restore of a backup into the staging database
+	RequestRestore(ctx context.Context, backupID, userID uuid.UUID, reason string) (*domain.BackupRestore, error)
+	GetRestore(ctx context.Context, backupID, id uuid.UUID) (*domain.BackupRestore, error)
+
+	// ScheduleDue queues the backups of the last run of the schedule and returns the number queued
+	ScheduleDue(ctx context.Context, now time.Time) (int64, error)
+	// ProcessPending takes queued backups and returns the number processed
+	ProcessPending(ctx context.Context) (int, error)
+	// ProcessRestores loads queued restores and returns the number processed
+	ProcessRestores(ctx context.Context) (int, error)
+	// PurgeExpired deletes the backups whose retention has ended, with their objects
+	PurgeExpired(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// backupService implements BackupService
+type backupService struct {
+	backupRepo  repository.BackupRepository
+	exportRepo  repository.DataExportRepository
+	companyRepo repository.CompanyRepository
+	storage     provider.ObjectStorage
+	keys        secrets.KeyManager
+	staging     repository.BackupStagingRepository
+	options     BackupOptions
+}
+
+// NewBackupService creates a new BackupService. Backups are disabled when
+// storage or keys is nil, and restores when staging is nil.
+func NewBackupService(
+	backupRepo repository.BackupRepository,
+	exportRepo repository.DataExportRepository,
+	companyRepo repository.CompanyRepository,
+	storage provider.ObjectStorage,
+	keys secrets.KeyManager,
+	staging repository.BackupStagingRepository,
+	options BackupOptions,
+) BackupService {
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	return &backupService{
+		backupRepo:  backupRepo,
+		exportRepo:  exportRepo,
+		companyRepo: companyRepo,
+		storage:     storage,
+		keys:        keys,
+		staging:     staging,
+		options:     options,
+	}
+}
+
+// enabled reports whether backups can be stored
+func (s *backupService) enabled() bool {
+	return s.storage != nil && s.keys != nil
+}
+
+// Request creates a pending manual backup unless one is already queued or running
+func (s *backupService) Request(ctx context.Context, companyID, userID uuid.UUID) (*domain.TenantBackup, error) {
+	if !s.enabled() {
+		return nil, domain.ErrBackupsDisabled
+	}
+	if _, err := s.companyRepo.FindByID(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	active, err := s.backupRepo.HasActive(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, domain.ErrBackupInProgress
+	}
+
+	backup := domain.NewManualBackup(companyID, userID)
+	if err := s.backupRepo.Create(ctx, backup); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// List retrieves the company's most recent backups
+func (s *backupService) List(ctx context.Context, companyID uuid.UUID) ([]domain.TenantBackup, error) {
+	if _, err := s.companyRepo.FindByID(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return s.backupRepo.FindByCompany(ctx, companyID, backupListLimit)
+}
+
+// GetByID retrieves a backup without its wrapped data key
+func (s *backupService) GetByID(ctx context.Context, id uuid.UUID) (*domain.TenantBackup, error) {
+	return s.backupRepo.FindByID(ctx, id)
+}
+
+// RequestRestore creates a pending restore of a completed, unexpired backup
+func (s *backupService) RequestRestore(ctx context.Context, backupID, userID uuid.UUID, reason string) (*domain.BackupRestore, error) {
+	if !s.enabled() {
+		return nil, domain.ErrBackupsDisabled
+	}
+	if s.staging == nil {
+		return nil, domain.ErrBackupRestoreDisabled
+	}
+
+	backup, err := s.backupRepo.FindByID(ctx, backupID)
+	if err != nil {
+		return nil, err
+	}
+	restore, err := domain.NewBackupRestore(backup, reason, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.backupRepo.CreateRestore(ctx, restore); err != nil {
+		return nil, err
+	}
+	return restore, nil
+}
+
+// GetRestore retrieves a restore of a backup
+func (s *backupService) GetRestore(ctx context.Context, backupID, id uuid.UUID) (*domain.BackupRestore, error) {
+	restore, err := s.backupRepo.FindRestore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if restore.BackupID != backupID {
+		return nil, domain.ErrBackupRestoreNotFound
+	}
+	return restore, nil
+}
+
+// ScheduleDue queues a backup of every active company for the last run of the
+// schedule. Each run is queued once per company, so the worker may call this
+// as often as it likes; runs missed while it was down are not caught up.
+func (s *backupService) ScheduleDue(ctx context.Context, now time.Time) (int64, error) {
+	if !s.enabled() || s.options.Schedule == nil {
+		return 0, nil
+	}
+	run := s.lastRun(now)
+	// A run older than the retention would be queued again after its backups were purged
+	if run.IsZero() || run.Before(now.AddDate(0, 0, -s.options.Retention.Days)) {
+		return 0, nil
+	}
+	return s.backupRepo.CreateScheduled(ctx, run)
+}
+
+// lastRun returns the last run of the schedule at or before now, looking back
+// up to a month, or the zero time if there is none
+func (s *backupService) lastRun(now time.Time) time.Time {
+	now = now.In(s.options.Location)
+	for _, lookback := range []time.Duration{time.Hour, 24 * time.Hour, 32 * 24 * time.Hour} {
+		var last time.Time
+		for t := s.options.Schedule.Next(now.Add(-lookback)); !t.IsZero() && !t.After(now); t = s.options.Schedule.Next(t) {
+			last = t
+		}
+		if !last.IsZero() {
+			return last
+		}
+	}
+	return time.Time{}
+}
+
+// ProcessPending claims queued backups one at a time and takes them. Failed
+// backups are recorded on the backup and returned as a joined error for logging.
+func (s *backupService) ProcessPending(ctx context.Context) (int, error) {
+	if !s.enabled() {
+		return 0, nil
+	}
+	count := 0
+	var errs []error
+	for count < backupBatchSize {
+		backup, err := s.backupRepo.ClaimNext(ctx, time.Now().Add(-backupStaleAfter))
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if backup == nil {
+			break
+		}
+		count++
+
+		if err := s.take(ctx, backup); err != nil {
+			backup.Fail(err, s.options.Retention, time.Now())
+			errs = append(errs, fmt.Errorf("backup %s: %w", backup.ID, err))
+		}
+		if err := s.backupRepo.Finish(ctx, backup); err != nil {
+			errs = append(errs, fmt.Errorf("backup %s: %w", backup.ID, err))
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// take archives the company's data as a JSON data export, encrypts the archive
+// with a new data key and stores it. The first backup of each month is kept
+// for the monthly retention.
+func (s *backupService) take(ctx context.Context, backup *domain.TenantBackup) error {
+	company, err := s.companyRepo.FindByID(ctx, backup.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	archive, rowCounts, err := buildDataExportArchive(ctx, s.exportRepo, company, domain.DataExportFormatJSON, false, time.Now())
+	if err != nil {
+		return err
+	}
+	sealed, err := secrets.Seal(ctx, s.keys, archive, backup.EncryptionContext())
+	if err != nil {
+		return err
+	}
+
+	key := backup.StorageKeyFor(s.options.StoragePrefix)
+	if err := s.storage.Put(ctx, key, sealed.Ciphertext); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(sealed.Ciphertext)
+
+	now := time.Now()
+	monthly := false
+	if s.options.Retention.MonthlyMonths > 0 {
+		local := now.In(s.options.Location)
+		monthStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, s.options.Location)
+		kept, err := s.backupRepo.HasMonthlySince(ctx, backup.CompanyID, monthStart)
+		if err != nil {
+			return err
+		}
+		monthly = !kept
+	}
+
+	backup.Complete(key, hex.EncodeToString(sum[:]), int64(len(sealed.Ciphertext)), sealed.KeyID, sealed.EncryptedKey,
+		rowCounts, monthly, s.options.Retention, now)
+	return nil
+}
+
+// ProcessRestores claims queued restores one at a time and loads them into
+// the staging database
+func (s *backupService) ProcessRestores(ctx context.Context) (int, error) {
+	if !s.enabled() || s.staging == nil {
+		return 0, nil
+	}
+	count := 0
+	var errs []error
+	for count < backupBatchSize {
+		restore, err := s.backupRepo.ClaimNextRestore(ctx, time.Now().Add(-backupStaleAfter))
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if restore == nil {
+			break
+		}
+		count++
+
+		if err := s.restore(ctx, restore); err != nil {
+			restore.Fail(err, time.Now())
+			errs = append(errs, fmt.Errorf("backup restore %s: %w", restore.ID, err))
+		}
+		if err := s.backupRepo.FinishRestore(ctx, restore); err != nil {
+			errs = append(errs, fmt.Errorf("backup restore %s: %w", restore.ID, err))
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// restore verifies and decrypts the stored archive and loads its datasets
+// into a schema of their own
+func (s *backupService) restore(ctx context.Context, restore *domain.BackupRestore) error {
+	backup, err := s.backupRepo.FindWithKey(ctx, restore.BackupID)
+	if err != nil {
+		return err
+	}
+	if !backup.IsRestorable(time.Now()) {
+		return domain.ErrBackupNotRestorable
+	}
+	company, err := s.companyRepo.FindByID(ctx, restore.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := s.storage.Get(ctx, backup.StorageKey)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(ciphertext)
+	if hex.EncodeToString(sum[:]) != backup.Checksum {
+		return domain.ErrBackupChecksumMismatch
+	}
+	archive, err := secrets.Open(ctx, s.keys, &secrets.Sealed{
+		KeyID:        backup.KeyID,
+		EncryptedKey: backup.EncryptedKey,
+		Ciphertext:   ciphertext,
+	}, backup.EncryptionContext())
+	if err != nil {
+		return err
+	}
+
+	datasets, rowCounts, err := readBackupArchive(archive)
+	if err != nil {
+		return err
+	}
+	schema := restore.StagingSchema(company.Code)
+	comment := fmt.Sprintf("Restore %s of backup %s of company %s (%s), taken at %s: %s",
+		restore.ID, backup.ID, company.Code, company.ID, backup.CompletedAt.Format(time.RFC3339), restore.Reason)
+	if err := s.staging.Load(ctx, schema, comment, datasets); err != nil {
+		return err
+	}
+
+	restore.Complete(schema, rowCounts, time.Now())
+	return nil
+}
+
+// readBackupArchive reads the datasets of a JSON data export archive in the
+// column order of its manifest
+func readBackupArchive(archive []byte) ([]repository.StagingDataset, map[string]int, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var manifest dataExportManifest
+	if err := decodeArchiveFile(files["manifest.json"], &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	if manifest.Format != domain.DataExportFormatJSON {
+		return nil, nil, fmt.Errorf("invalid backup manifest: unsupported format %q", manifest.Format)
+	}
+
+	datasets := make([]repository.StagingDataset, 0, len(manifest.Datasets))
+	rowCounts := make(map[string]int, len(manifest.Datasets))
+	for _, entry := range manifest.Datasets {
+		var objects []map[string]*string
+		if err := decodeArchiveFile(files[entry.File], &objects); err != nil {
+			return nil, nil, fmt.Errorf("invalid backup dataset %s: %w", entry.Name, err)
+		}
+
+		rows := make([][]*string, len(objects))
+		for i, object := range objects {
+			row := make([]*string, len(entry.Columns))
+			for j, column := range entry.Columns {
+				row[j] = object[column]
+			}
+			rows[i] = row
+		}
+		datasets = append(datasets, repository.StagingDataset{Name: string(entry.Name), Columns: entry.Columns, Rows: rows})
+		rowCounts[string(entry.Name)] = len(rows)
+	}
+	return datasets, rowCounts, nil
+}
+
+// decodeArchiveFile decodes a JSON file of an archive
+func decodeArchiveFile(f *zip.File, v interface{}) error {
+	if f == nil {
+		return io.ErrUnexpectedEOF
+	}
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return json.NewDecoder(r).Decode(v)
+}
+
+// PurgeExpired deletes the objects of expired backups, then their rows. A
+// backup whose object cannot be deleted is kept and retried on the next run.
+func (s *backupService) PurgeExpired(ctx context.Context, asOf time.Time) (int, error) {
+	if !s.enabled() {
+		return 0, nil
+	}
+	backups, err := s.backupRepo.FindExpired(ctx, asOf, backupPurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var errs []error
+	for i := range backups {
+		backup := &backups[i]
+		if backup.StorageKey != "" {
+			if err := s.storage.Delete(ctx, backup.StorageKey); err != nil {
+				errs = append(errs, fmt.Errorf("backup %s: %w", backup.ID, err))
+				continue
+			}
+		}
+		if err := s.backupRepo.Delete(ctx, backup.ID); err != nil {
+			errs = append(errs, fmt.Errorf("backup %s: %w", backup.ID, err))
+			continue
+		}
+		count++
+	}
+	return count, errors.Join(errs...)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// defaultBankBalanceHistory is the number of reported balances listed per bank account
+const defaultBankBalanceHistory = 30
+
+// BankAccountService defines the interface for the bank accounts of the company
+type BankAccountService interface {
+	Create(ctx context.Context, bank *domain.CompanyBankAccount) error
+	Update(ctx context.Context, bank *domain.CompanyBankAccount) error
+	// Delete removes a bank account no voucher entry names
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error)
+	List(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CompanyBankAccount, error)
+
+	// RecordBalance stores a balance reported for the bank account by a
+	// statement import, an account inquiry or by hand. The latest one becomes
+	// the balance of the bank account.
+	RecordBalance(ctx context.Context, companyID, bankAccountID, userID uuid.UUID, balance *domain.BankAccountBalance) (*domain.CompanyBankAccount, error)
+	ListBalances(ctx context.Context, companyID, bankAccountID uuid.UUID, limit int) ([]domain.BankAccountBalance, error)
+
+	// Balances compares, per cash account with active bank accounts, the
+	// balances the banks report with the balance in the books
+	Balances(ctx context.Context, companyID uuid.UUID, asOf time.Time) ([]domain.BankBalanceSummary, error)
+}
+
+// bankAccountService implements BankAccountService
+type bankAccountService struct {
+	repo        repository.BankAccountRepository
+	accountRepo repository.AccountRepository
+	ledgerRepo  repository.LedgerRepository
+}
+
+// NewBankAccountService creates a new BankAccountService
+func NewBankAccountService(repo repository.BankAccountRepository, accountRepo repository.AccountRepository, ledgerRepo repository.LedgerRepository) BankAccountService {
+	return &bankAccountService{
+		repo:        repo,
+		accountRepo: accountRepo,
+		ledgerRepo:  ledgerRepo,
+	}
+}
+
+// Create registers a bank account booking to a cash account
+func (s *bankAccountService) Create(ctx context.Context, bank *domain.CompanyBankAccount) error {
+	if err := s.validate(ctx, bank, nil); err != nil {
+		return err
+	}
+	bank.Balance = 0
+	bank.BalanceAsOf = nil
+	bank.BalanceSource = ""
+	return s.repo.Create(ctx, bank)
+}
+
+// Update updates a bank account; its balance is kept
+func (s *bankAccountService) Update(ctx context.Context, bank *domain.CompanyBankAccount) error {
+	existing, err := s.repo.FindByID(ctx, bank.CompanyID, bank.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.validate(ctx, bank, &bank.ID); err != nil {
+		return err
+	}
+
+	// Posted entries name the bank account with its cash account
+	if bank.AccountID != existing.AccountID {
+		inUse, err := s.repo.HasEntries(ctx, bank.CompanyID, bank.ID)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return domain.ErrBankAccountInUse
+		}
+	}
+
+	bank.CreatedAt = existing.CreatedAt
+	bank.Balance = existing.Balance
+	bank.BalanceAsOf = existing.BalanceAsOf
+	bank.BalanceSource = existing.BalanceSource
+	bank.CreatedBy = existing.CreatedBy
+	return s.repo.Update(ctx, bank)
+}
+
+// validate checks the bank account, its cash account and that its number is
+// not registered yet
+func (s *bankAccountService) validate(ctx context.Context, bank *domain.CompanyBankAccount, excludeID *uuid.UUID) error {
+	bank.Normalize()
+	if err := bank.Validate(); err != nil {
+		return err
+	}
+
+	account, err := s.accountRepo.FindByID(ctx, bank.CompanyID, bank.AccountID)
+	if err != nil {
+		return err
+	}
+	if !account.IsCashAccount {
+		return domain.ErrNotCashAccount
+	}
+
+	exists, err := s.repo.ExistsByNumber(ctx, bank.CompanyID, bank.BankCode, bank.AccountNumber, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrBankAccountExists
+	}
+	return nil
+}
+
+// Delete removes a bank account not named by voucher entries
+func (s *bankAccountService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	inUse, err := s.repo.HasEntries(ctx, companyID, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrBankAccountInUse
+	}
+	return s.repo.Delete(ctx, companyID, id)
+}
+
+// GetByID returns a bank account
+func (s *bankAccountService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the bank accounts of the company
+func (s *bankAccountService) List(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CompanyBankAccount, error) {
+	return s.repo.FindAll(ctx, companyID, activeOnly)
+}
+
+// RecordBalance stores the reported balance, which becomes current unless a
+// later balance is already known
+func (s *bankAccountService) RecordBalance(ctx context.Context, companyID, bankAccountID, userID uuid.UUID, balance *domain.BankAccountBalance) (*domain.CompanyBankAccount, error) {
+	bank, err := s.repo.FindByID(ctx, companyID, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := balance.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+
+	balance.CompanyID = companyID
+	balance.BankAccountID = bank.ID
+	balance.CreatedBy = &userID
+	current := bank.ApplyBalance(balance)
+	if err := s.repo.RecordBalance(ctx, bank, balance, current); err != nil {
+		return nil, err
+	}
+	return bank, nil
+}
+
+// ListBalances lists the latest balances reported for the bank account
+func (s *bankAccountService) ListBalances(ctx context.Context, companyID, bankAccountID uuid.UUID, limit int) ([]domain.BankAccountBalance, error) {
+	if _, err := s.repo.FindByID(ctx, companyID, bankAccountID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultBankBalanceHistory
+	}
+	return s.repo.ListBalances(ctx, companyID, bankAccountID, limit)
+}
+
+// Balances groups the active bank accounts by cash account, in account code
+// order, with the book balance of the account at the end of the day
+func (s *bankAccountService) Balances(ctx context.Context, companyID uuid.UUID, asOf time.Time) ([]domain.BankBalanceSummary, error) {
+	banks, err := s.repo.FindAll(ctx, companyID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[uuid.UUID]*domain.BankBalanceSummary)
+	var ids []uuid.UUID
+	for _, bank := range banks {
+		summary, ok := byAccount[bank.AccountID]
+		if !ok {
+			summary = &domain.BankBalanceSummary{AccountID: bank.AccountID}
+			byAccount[bank.AccountID] = summary
+			ids = append(ids, bank.AccountID)
+		}
+		summary.BankBalance += bank.Balance
+		summary.BankAccounts = append(summary.BankAccounts, bank)
+	}
+	if len(ids) == 0 {
+		return []domain.BankBalanceSummary{}, nil
+	}
+
+	dayEnd := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location()).AddDate(0, 0, 1)
+	books, err := s.ledgerRepo.GetAccountBalancesBefore(ctx, companyID, ids, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]domain.BankBalanceSummary, 0, len(ids))
+	for _, id := range ids {
+		summary := byAccount[id]
+		account, err := s.accountRepo.FindByID(ctx, companyID, id)
+		if err != nil {
+			return nil, err
+		}
+		summary.AccountCode = account.Code
+		summary.AccountName = account.Name
+		summary.BookBalance = books[id]
+		summary.Difference = summary.BankBalance - summary.BookBalance
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].AccountCode < summaries[j].AccountCode })
+	return summaries, nil
+}
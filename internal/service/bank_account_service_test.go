@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakeVoucherBanks serves the bank accounts of the company
+type fakeVoucherBanks struct {
+	banks []domain.CompanyBankAccount
+}
+
+func (f *fakeVoucherBanks) FindByAccounts(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID) ([]domain.CompanyBankAccount, error) {
+	return f.banks, nil
+}
+
+// fakeBankAccounts serves the bank accounts of one company
+type fakeBankAccounts struct {
+	repository.BankAccountRepository
+	banks []domain.CompanyBankAccount
+}
+
+func (f *fakeBankAccounts) FindAll(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CompanyBankAccount, error) {
+	return f.banks, nil
+}
+
+// fakeBookBalances serves the book balances of accounts
+type fakeBookBalances struct {
+	repository.LedgerRepository
+	before   time.Time
+	balances map[uuid.UUID]float64
+}
+
+func (f *fakeBookBalances) GetAccountBalancesBefore(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, before time.Time) (map[uuid.UUID]float64, error) {
+	f.before = before
+	return f.balances, nil
+}
+
+func TestVoucherService_CreatePaymentFromBank(t *testing.T) {
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+
+	newPayment := func() *domain.Voucher {
+		voucher := newTestVoucher(companyID)
+		voucher.VoucherType = domain.VoucherTypePayment
+		return voucher
+	}
+	depositID := newTestVoucher(companyID).Entries[1].AccountID
+	bank := func() domain.CompanyBankAccount {
+		b := domain.CompanyBankAccount{Name: "운영자금", AccountID: depositID, IsActive: true}
+		b.ID = uuid.New()
+		return b
+	}
+
+	newService := func(voucher *domain.Voucher, banks ...domain.CompanyBankAccount) (*mocks.MockVoucherRepository, service.VoucherService) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		accountRepo := new(mocks.MockAccountRepository)
+		for _, entry := range voucher.Entries {
+			accountRepo.On("FindByID", mock.Anything, companyID, entry.AccountID).Return(newTestAccount(companyID, entry.AccountID), nil)
+		}
+		voucherRepo.On("FindAll", mock.Anything, mock.Anything).Return([]domain.Voucher{}, int64(0), nil).Maybe()
+		voucherRepo.On("GenerateVoucherNo", mock.Anything, companyID, domain.VoucherTypePayment, mock.Anything).Return("PAY-2024-0001", nil).Maybe()
+		voucherRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+		svc := service.NewVoucherService(voucherRepo, accountRepo, new(mocks.MockTaxCodeRepository),
+			newTestSettingsService(domain.DefaultCompanySettings()), nil, nil, &fakeVoucherBanks{banks: banks})
+		return voucherRepo, svc
+	}
+
+	t.Run("books the payment to the only bank account", func(t *testing.T) {
+		voucher := newPayment()
+		operating := bank()
+		_, svc := newService(voucher, operating)
+
+		require.NoError(t, svc.Create(ctx, voucher))
+		require.NotNil(t, voucher.Entries[1].BankAccountID)
+		assert.Equal(t, operating.ID, *voucher.Entries[1].BankAccountID)
+	})
+
+	t.Run("asks which bank account pays", func(t *testing.T) {
+		voucher := newPayment()
+		voucherRepo, svc := newService(voucher, bank(), bank())
+
+		assert.ErrorIs(t, svc.Create(ctx, voucher), domain.ErrBankAccountRequired)
+		voucherRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves generated vouchers alone", func(t *testing.T) {
+		voucher := newPayment()
+		voucher.ReferenceType = "loan"
+		_, svc := newService(voucher, bank(), bank())
+
+		require.NoError(t, svc.Create(ctx, voucher))
+		assert.Nil(t, voucher.Entries[1].BankAccountID)
+	})
+}
+
+func TestBankAccountService_Balances(t *testing.T) {
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+	depositID := uuid.New()
+
+	repo := &fakeBankAccounts{}
+	for _, balance := range []float64{7000, 3000} {
+		b := domain.CompanyBankAccount{Name: "보통예금", AccountID: depositID, IsActive: true, Balance: balance}
+		b.ID = uuid.New()
+		repo.banks = append(repo.banks, b)
+	}
+	accountRepo := new(mocks.MockAccountRepository)
+	account := newTestAccount(companyID, depositID)
+	account.Code, account.Name = "103", "보통예금"
+	accountRepo.On("FindByID", mock.Anything, companyID, depositID).Return(account, nil)
+	ledgerRepo := &fakeBookBalances{balances: map[uuid.UUID]float64{depositID: 9500}}
+	asOf := time.Date(2024, 6, 30, 15, 0, 0, 0, time.UTC)
+
+	svc := service.NewBankAccountService(repo, accountRepo, ledgerRepo)
+	summaries, err := svc.Balances(ctx, companyID, asOf)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), ledgerRepo.before)
+	assert.Equal(t, "103", summaries[0].AccountCode)
+	assert.Equal(t, 10000.0, summaries[0].BankBalance)
+	assert.Equal(t, 9500.0, summaries[0].BookBalance)
+	assert.Equal(t, 500.0, summaries[0].Difference)
+	assert.Len(t, summaries[0].BankAccounts, 2)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/nts"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// BusinessRevalidationResult summarizes a revalidation run of the worker
+type BusinessRevalidationResult struct {
+	Checked int // partners looked up
+	Closed  int // partners found closed since their last verification
+}
+
+// BusinessVerificationService defines the interface for verifying business
+// registration numbers against NTS
+type BusinessVerificationService interface {
+	// Verify looks up the status of the businesses and stores the result on
+	// the requested partners
+	Verify(ctx context.Context, companyID uuid.UUID, reqs []domain.BusinessVerificationRequest) ([]domain.BusinessVerification, error)
+
+	// RevalidatePartners looks up active partners of all companies that were
+	// not verified since verifiedBefore, at most limit of them
+	RevalidatePartners(ctx context.Context, verifiedBefore time.Time, limit int) (*BusinessRevalidationResult, error)
+}
+
+// businessVerificationService implements BusinessVerificationService
+type businessVerificationService struct {
+	partnerRepo repository.PartnerRepository
+	client      *nts.Client
+}
+
+// NewBusinessVerificationService creates a new BusinessVerificationService
+func NewBusinessVerificationService(partnerRepo repository.PartnerRepository, client *nts.Client) BusinessVerificationService {
+	return &businessVerificationService{
+		partnerRepo: partnerRepo,
+		client:      client,
+	}
+}
+
+// businessCheck is one business number to look up
+type businessCheck struct {
+	number         string // digits only
+	representative string
+	startDate      *time.Time
+}
+
+// Verify verifies the requested business numbers
+func (s *businessVerificationService) Verify(ctx context.Context, companyID uuid.UUID, reqs []domain.BusinessVerificationRequest) ([]domain.BusinessVerification, error) {
+	if !s.client.IsConfigured() {
+		return nil, domain.ErrBusinessVerificationDisabled
+	}
+	if len(reqs) == 0 || len(reqs) > nts.MaxBatchSize {
+		return nil, fmt.Errorf("%w: between 1 and %d businesses are required", domain.ErrInvalidBusinessVerification, nts.MaxBatchSize)
+	}
+
+	partners := make([]*domain.Partner, len(reqs))
+	checks := make([]businessCheck, len(reqs))
+	for i, req := range reqs {
+		number, representative := req.BusinessNumber, req.Representative
+		if req.PartnerID != nil {
+			partner, err := s.partnerRepo.GetByID(ctx, companyID, *req.PartnerID)
+			if err != nil {
+				return nil, ErrPartnerNotFound
+			}
+			partners[i] = partner
+			if number == "" {
+				number = partner.BusinessNumber
+			}
+			if representative == "" {
+				representative = partner.Representative
+			}
+		}
+
+		digits := domain.BusinessNumberDigits(number)
+		if err := domain.ValidateBusinessNumber(digits); err != nil {
+			return nil, fmt.Errorf("businesses[%d]: %w", i, err)
+		}
+		checks[i] = businessCheck{number: digits, representative: representative, startDate: req.StartDate}
+	}
+
+	verifications, err := s.lookup(ctx, checks)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, partner := range partners {
+		if partner == nil {
+			continue
+		}
+		verifications[i].PartnerID = &partner.ID
+		// The result is only stored when the partner still has the verified number
+		if domain.BusinessNumberDigits(partner.BusinessNumber) != verifications[i].BusinessNumber {
+			continue
+		}
+		partner.ApplyBusinessVerification(&verifications[i])
+		if err := s.partnerRepo.UpdateBusinessVerification(ctx, partner); err != nil {
+			return nil, err
+		}
+	}
+	return verifications, nil
+}
+
+// RevalidatePartners refreshes the verification of partners due for it.
+// Numbers with an invalid check digit are marked unregistered without a lookup.
+func (s *businessVerificationService) RevalidatePartners(ctx context.Context, verifiedBefore time.Time, limit int) (*BusinessRevalidationResult, error) {
+	if !s.client.IsConfigured() {
+		return nil, domain.ErrBusinessVerificationDisabled
+	}
+
+	partners, err := s.partnerRepo.ListForBusinessVerification(ctx, verifiedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BusinessRevalidationResult{}
+	var (
+		lookupPartners []*domain.Partner
+		checks         []businessCheck
+	)
+	for i := range partners {
+		partner := &partners[i]
+		digits := domain.BusinessNumberDigits(partner.BusinessNumber)
+		if domain.ValidateBusinessNumber(digits) != nil {
+			verification := domain.BusinessVerification{
+				PartnerID:      &partner.ID,
+				BusinessNumber: digits,
+				Status:         domain.BusinessStatusUnregistered,
+				VerifiedAt:     time.Now(),
+			}
+			if err := s.store(ctx, partner, &verification, result); err != nil {
+				return result, err
+			}
+			continue
+		}
+		lookupPartners = append(lookupPartners, partner)
+		checks = append(checks, businessCheck{number: digits})
+	}
+	if len(checks) == 0 {
+		return result, nil
+	}
+
+	verifications, err := s.lookup(ctx, checks)
+	if err != nil {
+		return result, err
+	}
+	for i, partner := range lookupPartners {
+		verifications[i].PartnerID = &partner.ID
+		if err := s.store(ctx, partner, &verifications[i], result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// store records the verification on the partner and counts it in result
+func (s *businessVerificationService) store(ctx context.Context, partner *domain.Partner, verification *domain.BusinessVerification, result *BusinessRevalidationResult) error {
+	if partner.ApplyBusinessVerification(verification) {
+		result.Closed++
+	}
+	result.Checked++
+	return s.partnerRepo.UpdateBusinessVerification(ctx, partner)
+}
+
+// lookup queries NTS for the status of the businesses and checks the
+// representative of those with an opening date. The result is in the order of checks.
+func (s *businessVerificationService) lookup(ctx context.Context, checks []businessCheck) ([]domain.BusinessVerification, error) {
+	numbers := make([]string, len(checks))
+	for i, check := range checks {
+		numbers[i] = check.number
+	}
+	statuses, err := s.client.Status(ctx, numbers)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrBusinessVerificationFailed, err)
+	}
+
+	now := time.Now()
+	verifications := make([]domain.BusinessVerification, len(checks))
+	for i, status := range statuses {
+		verifications[i] = domain.BusinessVerification{
+			BusinessNumber: checks[i].number,
+			Status:         businessStatusFromNTS(status.StatusCode),
+			VerifiedAt:     now,
+		}
+		if status.IsRegistered() {
+			verifications[i].TaxType = status.TaxType
+			verifications[i].ClosedOn = status.ClosedOn()
+		}
+	}
+
+	var (
+		indexes  []int
+		validate []nts.ValidateRequest
+	)
+	for i, check := range checks {
+		if check.representative == "" || check.startDate == nil {
+			continue
+		}
+		indexes = append(indexes, i)
+		validate = append(validate, nts.ValidateRequest{
+			BusinessNumber: check.number,
+			StartDate:      check.startDate.Format("20060102"),
+			Representative: check.representative,
+		})
+	}
+	if len(validate) == 0 {
+		return verifications, nil
+	}
+
+	results, err := s.client.Validate(ctx, validate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrBusinessVerificationFailed, err)
+	}
+	for j, i := range indexes {
+		matched := results[j].Matched()
+		verifications[i].RepresentativeMatched = &matched
+	}
+	return verifications, nil
+}
+
+// businessStatusFromNTS maps an NTS status code to the business status
+func businessStatusFromNTS(code string) domain.BusinessStatus {
+	switch code {
+	case nts.StatusCodeActive:
+		return domain.BusinessStatusActive
+	case nts.StatusCodeSuspended:
+		return domain.BusinessStatusSuspended
+	case nts.StatusCodeClosed:
+		return domain.BusinessStatusClosed
+	}
+	return domain.BusinessStatusUnregistered
+}
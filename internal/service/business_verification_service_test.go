@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/nts"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// newTestNTSServer serves the status and validate endpoints with fixed answers
+func newTestNTSServer(t *testing.T) *nts.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			w.Write([]byte(`{"data":[
+				{"b_no":"1234567891","b_stt":"계속사업자","b_stt_cd":"01","tax_type":"부가가치세 일반과세자"},
+				{"b_no":"2208100001","b_stt":"폐업자","b_stt_cd":"03","tax_type":"부가가치세 일반과세자","end_dt":"20240131"}
+			]}`))
+		case "/validate":
+			w.Write([]byte(`{"data":[{"b_no":"1234567891","valid":"02","valid_msg":"확인할 수 없습니다."}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return nts.NewClient(nts.Config{BaseURL: server.URL, ServiceKey: "service-key"})
+}
+
+func TestBusinessVerificationService_Verify(t *testing.T) {
+	repo := new(mocks.MockPartnerRepository)
+	svc := service.NewBusinessVerificationService(repo, newTestNTSServer(t))
+
+	companyID := newTestCompanyID()
+	partner := &domain.Partner{BusinessNumber: "123-45-67891", Representative: "홍길동"}
+	partner.ID = uuid.New()
+	repo.On("GetByID", mock.Anything, companyID, partner.ID).Return(partner, nil)
+	repo.On("UpdateBusinessVerification", mock.Anything, partner).Return(nil).Once()
+
+	startDate := time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)
+	verifications, err := svc.Verify(context.Background(), companyID, []domain.BusinessVerificationRequest{
+		{PartnerID: &partner.ID, StartDate: &startDate},
+		{BusinessNumber: "220-81-00001"},
+	})
+	require.NoError(t, err)
+	require.Len(t, verifications, 2)
+
+	assert.Equal(t, domain.BusinessStatusActive, verifications[0].Status)
+	require.NotNil(t, verifications[0].RepresentativeMatched)
+	assert.False(t, *verifications[0].RepresentativeMatched)
+	assert.Equal(t, domain.BusinessStatusActive, partner.BusinessStatus)
+	assert.NotNil(t, partner.BusinessVerifiedAt)
+
+	assert.True(t, verifications[1].IsClosed())
+	assert.Nil(t, verifications[1].PartnerID)
+	repo.AssertExpectations(t)
+
+	_, err = svc.Verify(context.Background(), companyID, []domain.BusinessVerificationRequest{{BusinessNumber: "1234567890"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidBusinessNumber)
+}
+
+func TestBusinessVerificationService_RevalidatePartners(t *testing.T) {
+	repo := new(mocks.MockPartnerRepository)
+	svc := service.NewBusinessVerificationService(repo, newTestNTSServer(t))
+
+	partners := []domain.Partner{
+		{BusinessNumber: "1234567891", BusinessStatus: domain.BusinessStatusActive},
+		{BusinessNumber: "2208100001", BusinessStatus: domain.BusinessStatusActive},
+		{BusinessNumber: "1234567890"},
+	}
+	verifiedBefore := time.Now().Add(-30 * 24 * time.Hour)
+	repo.On("ListForBusinessVerification", mock.Anything, verifiedBefore, 500).Return(partners, nil)
+	repo.On("UpdateBusinessVerification", mock.Anything, mock.Anything).Return(nil).Times(3)
+
+	result, err := svc.RevalidatePartners(context.Background(), verifiedBefore, 500)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Checked)
+	assert.Equal(t, 1, result.Closed)
+	assert.Equal(t, domain.BusinessStatusClosed, partners[1].BusinessStatus)
+	assert.Equal(t, domain.BusinessStatusUnregistered, partners[2].BusinessStatus)
+	repo.AssertExpectations(t)
+}
+
+func TestBusinessVerificationService_Disabled(t *testing.T) {
+	svc := service.NewBusinessVerificationService(new(mocks.MockPartnerRepository), nts.NewClient(nts.Config{}))
+
+	_, err := svc.RevalidatePartners(context.Background(), time.Now(), 100)
+	assert.ErrorIs(t, err, domain.ErrBusinessVerificationDisabled)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// CashBookService defines the interface for the cash and bank book (현금출납장)
+type CashBookService interface {
+	// GetCashBook returns the cash book of the given account, or of all designated cash accounts if nil
+	GetCashBook(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID, from, to time.Time) ([]domain.CashBookAccount, error)
+}
+
+// cashBookService implements CashBookService
+type cashBookService struct {
+	ledgerRepo  repository.LedgerRepository
+	accountRepo repository.AccountRepository
+}
+
+// NewCashBookService creates a new CashBookService
+func NewCashBookService(ledgerRepo repository.LedgerRepository, accountRepo repository.AccountRepository) CashBookService {
+	return &cashBookService{
+		ledgerRepo:  ledgerRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// GetCashBook builds the cash book per account in account code order
+func (s *cashBookService) GetCashBook(ctx context.Context, companyID uuid.UUID, accountID *uuid.UUID, from, to time.Time) ([]domain.CashBookAccount, error) {
+	var accounts []domain.Account
+	if accountID != nil {
+		account, err := s.accountRepo.FindByID(ctx, companyID, *accountID)
+		if err != nil {
+			return nil, err
+		}
+		if !account.IsCashAccount {
+			return nil, domain.ErrNotCashAccount
+		}
+		accounts = []domain.Account{*account}
+	} else {
+		isCash := true
+		var err error
+		accounts, _, err = s.accountRepo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID, IsCash: &isCash, SortBy: "code"})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]uuid.UUID, len(accounts))
+	for i := range accounts {
+		ids[i] = accounts[i].ID
+	}
+	openings, err := s.ledgerRepo.GetAccountBalancesBefore(ctx, companyID, ids, from)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.ledgerRepo.GetCashBookEntries(ctx, companyID, ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byAccount := make(map[uuid.UUID][]domain.CashBookEntry)
+	for _, e := range entries {
+		byAccount[e.AccountID] = append(byAccount[e.AccountID], e)
+	}
+
+	books := make([]domain.CashBookAccount, len(accounts))
+	for i := range accounts {
+		books[i] = *domain.NewCashBookAccount(&accounts[i], openings[accounts[i].ID], byAccount[accounts[i].ID])
+	}
+	return books, nil
+}
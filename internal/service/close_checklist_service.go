@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// CloseChecklistService defines the interface for month-end close checklist business logic
+type CloseChecklistService interface {
+	// Task configuration
+	CreateTask(ctx context.Context, task *domain.CloseChecklistTask) error
+	UpdateTask(ctx context.Context, task *domain.CloseChecklistTask) error
+	DeleteTask(ctx context.Context, companyID, id uuid.UUID) error
+	GetTask(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseChecklistTask, error)
+	ListTasks(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CloseChecklistTask, error)
+
+	// Period checklist
+	GetPeriodChecklist(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.PeriodChecklist, error)
+	UpdatePeriodTask(ctx context.Context, companyID, taskID uuid.UUID, year, month int, status domain.CloseTaskStatus, note string, userID uuid.UUID) (*domain.PeriodCloseTask, error)
+}
+
+// closeChecklistService implements CloseChecklistService
+type closeChecklistService struct {
+	checklistRepo repository.CloseChecklistRepository
+	ledgerRepo    repository.LedgerRepository
+}
+
+// NewCloseChecklistService creates a new CloseChecklistService
+func NewCloseChecklistService(checklistRepo repository.CloseChecklistRepository, ledgerRepo repository.LedgerRepository) CloseChecklistService {
+	return &closeChecklistService{
+		checklistRepo: checklistRepo,
+		ledgerRepo:    ledgerRepo,
+	}
+}
+
+// CreateTask creates a new checklist task
+func (s *closeChecklistService) CreateTask(ctx context.Context, task *domain.CloseChecklistTask) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+
+	exists, err := s.checklistRepo.ExistsByCode(ctx, task.CompanyID, task.Code, nil)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrCloseTaskCodeExists
+	}
+
+	return s.checklistRepo.CreateTask(ctx, task)
+}
+
+// UpdateTask updates a checklist task
+func (s *closeChecklistService) UpdateTask(ctx context.Context, task *domain.CloseChecklistTask) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+
+	exists, err := s.checklistRepo.ExistsByCode(ctx, task.CompanyID, task.Code, &task.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrCloseTaskCodeExists
+	}
+
+	return s.checklistRepo.UpdateTask(ctx, task)
+}
+
+// DeleteTask deletes a checklist task
+func (s *closeChecklistService) DeleteTask(ctx context.Context, companyID, id uuid.UUID) error {
+	if _, err := s.checklistRepo.FindTaskByID(ctx, companyID, id); err != nil {
+		return err
+	}
+	return s.checklistRepo.DeleteTask(ctx, companyID, id)
+}
+
+// GetTask retrieves a checklist task by ID
+func (s *closeChecklistService) GetTask(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseChecklistTask, error) {
+	return s.checklistRepo.FindTaskByID(ctx, companyID, id)
+}
+
+// ListTasks lists the checklist tasks configured for a company
+func (s *closeChecklistService) ListTasks(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.CloseChecklistTask, error) {
+	return s.checklistRepo.FindTasks(ctx, companyID, activeOnly)
+}
+
+// GetPeriodChecklist retrieves the checklist with task status for a fiscal period
+func (s *closeChecklistService) GetPeriodChecklist(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.PeriodChecklist, error) {
+	return loadPeriodChecklist(ctx, s.checklistRepo, companyID, year, month)
+}
+
+// UpdatePeriodTask sets the status of a checklist task for a fiscal period
+func (s *closeChecklistService) UpdatePeriodTask(ctx context.Context, companyID, taskID uuid.UUID, year, month int, status domain.CloseTaskStatus, note string, userID uuid.UUID) (*domain.PeriodCloseTask, error) {
+	if !status.IsValid() {
+		return nil, domain.ErrInvalidCloseTaskStatus
+	}
+
+	// Checklist of a closed period is frozen
+	period, err := s.ledgerRepo.GetFiscalPeriod(ctx, companyID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if !period.IsOpen() {
+		return nil, domain.ErrFiscalPeriodClosed
+	}
+
+	task, err := s.checklistRepo.FindTaskByID(ctx, companyID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodTask, err := s.checklistRepo.FindPeriodTask(ctx, companyID, taskID, year, month)
+	if err != nil {
+		if err != domain.ErrCloseTaskNotFound {
+			return nil, err
+		}
+		periodTask = &domain.PeriodCloseTask{
+			CompanyID:   companyID,
+			TaskID:      taskID,
+			FiscalYear:  year,
+			FiscalMonth: month,
+		}
+	}
+
+	switch status {
+	case domain.CloseTaskStatusCompleted:
+		periodTask.Complete(userID, note)
+	case domain.CloseTaskStatusSkipped:
+		periodTask.Skip(userID, note)
+	default:
+		periodTask.Reset()
+	}
+
+	if err := s.checklistRepo.SavePeriodTask(ctx, periodTask); err != nil {
+		return nil, err
+	}
+
+	periodTask.Task = task
+	return periodTask, nil
+}
+
+// loadPeriodChecklist combines active tasks with their status for a period
+func loadPeriodChecklist(ctx context.Context, repo repository.CloseChecklistRepository, companyID uuid.UUID, year, month int) (*domain.PeriodChecklist, error) {
+	tasks, err := repo.FindTasks(ctx, companyID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	periodTasks, err := repo.FindPeriodTasks(ctx, companyID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	statusByTask := make(map[uuid.UUID]*domain.PeriodCloseTask, len(periodTasks))
+	for i := range periodTasks {
+		statusByTask[periodTasks[i].TaskID] = &periodTasks[i]
+	}
+
+	checklist := &domain.PeriodChecklist{
+		FiscalYear:  year,
+		FiscalMonth: month,
+		Items:       make([]domain.PeriodChecklistItem, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		checklist.Items = append(checklist.Items, domain.PeriodChecklistItem{
+			Task:   task,
+			Status: statusByTask[task.ID],
+		})
+	}
+
+	return checklist, nil
+}
@@ -16,7 +16,6 @@ type CompanyService interface {
 
 	// Update operations
 	Update(ctx context.Context, company *domain.Company) error
-	UpdateSettings(ctx context.Context, company *domain.Company) error
 }
 
 // companyServiceImpl implements CompanyService
@@ -36,7 +35,3 @@ func (s *companyServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain
 func (s *companyServiceImpl) Update(ctx context.Context, company *domain.Company) error {
 	return s.repo.Update(ctx, company)
 }
-
-func (s *companyServiceImpl) UpdateSettings(ctx context.Context, company *domain.Company) error {
-	return s.repo.Update(ctx, company)
-}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// companySettingsCacheTTL bounds how long another API instance may serve settings
+// that were changed elsewhere; updates through this service take effect immediately.
+const companySettingsCacheTTL = 5 * time.Minute
+
+// CompanySettingsService provides company settings to the services that depend on them
+type CompanySettingsService interface {
+	// Get returns the settings of the company, served from cache when possible
+	Get(ctx context.Context, companyID uuid.UUID) (domain.CompanySettings, error)
+
+	// Update applies the changes to the company's settings, validates and saves them
+	Update(ctx context.Context, companyID uuid.UUID, apply func(*domain.CompanySettings)) (domain.CompanySettings, error)
+}
+
+// cachedCompanySettings is a cache entry
+type cachedCompanySettings struct {
+	settings  domain.CompanySettings
+	expiresAt time.Time
+}
+
+// companySettingsService implements CompanySettingsService
+type companySettingsService struct {
+	companyRepo repository.CompanyRepository
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]cachedCompanySettings
+}
+
+// NewCompanySettingsService creates a new CompanySettingsService
+func NewCompanySettingsService(companyRepo repository.CompanyRepository) CompanySettingsService {
+	return &companySettingsService{
+		companyRepo: companyRepo,
+		cache:       make(map[uuid.UUID]cachedCompanySettings),
+	}
+}
+
+// Get returns the cached settings or loads them from the company
+func (s *companySettingsService) Get(ctx context.Context, companyID uuid.UUID) (domain.CompanySettings, error) {
+	s.mu.RLock()
+	entry, ok := s.cache[companyID]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.settings, nil
+	}
+
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return domain.CompanySettings{}, err
+	}
+	s.store(companyID, company.Settings)
+	return company.Settings, nil
+}
+
+// Update applies and saves the settings, refreshing the cache
+func (s *companySettingsService) Update(ctx context.Context, companyID uuid.UUID, apply func(*domain.CompanySettings)) (domain.CompanySettings, error) {
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return domain.CompanySettings{}, err
+	}
+
+	apply(&company.Settings)
+	if err := company.Settings.Validate(); err != nil {
+		return domain.CompanySettings{}, err
+	}
+	if err := s.companyRepo.UpdateSettings(ctx, company); err != nil {
+		return domain.CompanySettings{}, err
+	}
+
+	s.store(companyID, company.Settings)
+	return company.Settings, nil
+}
+
+// store caches the settings of a company
+func (s *companySettingsService) store(companyID uuid.UUID, settings domain.CompanySettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache[companyID] = cachedCompanySettings{
+		settings:  settings,
+		expiresAt: time.Now().Add(companySettingsCacheTTL),
+	}
+}
@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// contractReferenceType marks the receipt vouchers of contract collections
+const contractReferenceType = "contract"
+
+// ContractInvoiceInput describes the invoicing of a milestone
+type ContractInvoiceInput struct {
+	IssueDate     time.Time
+	InvoiceNumber string // defaults to the contract number and milestone sequence
+}
+
+// ContractCollectionInput describes a receipt of an invoiced milestone
+type ContractCollectionInput struct {
+	CollectedDate time.Time
+	Amount        int64
+	BankAccountID uuid.UUID
+}
+
+// ContractService defines the interface for the contract register
+type ContractService interface {
+	Create(ctx context.Context, contract *domain.Contract) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Contract, error)
+	List(ctx context.Context, filter repository.ContractFilter) ([]domain.Contract, int64, error)
+	ListCollections(ctx context.Context, companyID, id uuid.UUID) ([]domain.ContractCollection, error)
+
+	// Invoice drafts the sales tax invoice of a milestone, from the company
+	// to the partner, and its draft sales voucher of the receivable
+	Invoice(ctx context.Context, companyID, userID, contractID, milestoneID uuid.UUID, input ContractInvoiceInput) (*domain.ContractMilestone, error)
+	// Collect records a receipt of an invoiced milestone and generates its
+	// draft receipt voucher against the receivable
+	Collect(ctx context.Context, companyID, userID, contractID, milestoneID uuid.UUID, input ContractCollectionInput) (*domain.ContractCollection, error)
+
+	// BillingReport returns the contracted, invoiced and collected amounts of
+	// the contracts of the filter; paging is ignored
+	BillingReport(ctx context.Context, filter repository.ContractFilter, asOf time.Time) (*domain.ContractBillingReport, error)
+
+	// RemindMilestones emails the admins of each company the milestones not
+	// invoiced yet that are due within domain.ContractMilestoneReminderDays
+	// of today, once per milestone. It returns the number of milestones
+	// notified.
+	RemindMilestones(ctx context.Context, today time.Time) (int, error)
+}
+
+// contractService implements ContractService
+type contractService struct {
+	repo           repository.ContractRepository
+	partnerRepo    repository.PartnerRepository
+	accountRepo    repository.AccountRepository
+	taxCodeRepo    repository.TaxCodeRepository
+	bankRepo       repository.BankAccountRepository
+	companyRepo    repository.CompanyRepository
+	userRepo       repository.UserRepository
+	taxInvoiceRepo repository.TaxInvoiceRepository
+	voucherService VoucherService
+	notifications  NotificationService
+	templates      EmailTemplateService
+}
+
+// NewContractService creates a new ContractService
+func NewContractService(
+	repo repository.ContractRepository,
+	partnerRepo repository.PartnerRepository,
+	accountRepo repository.AccountRepository,
+	taxCodeRepo repository.TaxCodeRepository,
+	bankRepo repository.BankAccountRepository,
+	companyRepo repository.CompanyRepository,
+	userRepo repository.UserRepository,
+	taxInvoiceRepo repository.TaxInvoiceRepository,
+	voucherService VoucherService,
+	notifications NotificationService,
+	templates EmailTemplateService,
+) ContractService {
+	return &contractService{
+		repo:           repo,
+		partnerRepo:    partnerRepo,
+		accountRepo:    accountRepo,
+		taxCodeRepo:    taxCodeRepo,
+		bankRepo:       bankRepo,
+		companyRepo:    companyRepo,
+		userRepo:       userRepo,
+		taxInvoiceRepo: taxInvoiceRepo,
+		voucherService: voucherService,
+		notifications:  notifications,
+		templates:      templates,
+	}
+}
+
+// Create validates the contract, its partner, accounts and tax code and
+// stores it with its milestones. Without a receivable account the partner's
+// is used.
+func (s *contractService) Create(ctx context.Context, contract *domain.Contract) error {
+	partner, err := s.partnerRepo.GetByID(ctx, contract.CompanyID, contract.PartnerID)
+	if err != nil {
+		return err
+	}
+	if contract.ReceivableAccountID == uuid.Nil && partner.ARAccountID != nil {
+		contract.ReceivableAccountID = *partner.ARAccountID
+	}
+	if err := contract.Validate(); err != nil {
+		return err
+	}
+	for _, accountID := range []uuid.UUID{contract.RevenueAccountID, contract.ReceivableAccountID} {
+		if _, err := s.accountRepo.FindByID(ctx, contract.CompanyID, accountID); err != nil {
+			return err
+		}
+	}
+	if contract.TaxCodeID != nil {
+		taxCode, err := s.taxCodeRepo.FindByID(ctx, contract.CompanyID, *contract.TaxCodeID)
+		if err != nil {
+			return err
+		}
+		if taxCode.TaxType != domain.TaxTypeOutput || !taxCode.IsActive {
+			return domain.ErrInvalidContract
+		}
+	}
+	exists, err := s.repo.ExistsContractNo(ctx, contract.CompanyID, contract.ContractNo)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrContractNoExists
+	}
+	return s.repo.Create(ctx, contract)
+}
+
+// GetByID returns a contract with its milestones
+func (s *contractService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Contract, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the contracts of the company, latest first
+func (s *contractService) List(ctx context.Context, filter repository.ContractFilter) ([]domain.Contract, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// ListCollections lists the receipts of a contract in date order
+func (s *contractService) ListCollections(ctx context.Context, companyID, id uuid.UUID) ([]domain.ContractCollection, error) {
+	if _, err := s.repo.FindByID(ctx, companyID, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCollections(ctx, companyID, id)
+}
+
+// Invoice adds the VAT of the contract's tax code to the milestone amount,
+// books the total against the receivable and drafts the tax invoice linked
+// to that voucher. Both are drafts, to be reviewed before issue and posting.
+func (s *contractService) Invoice(ctx context.Context, companyID, userID, contractID, milestoneID uuid.UUID, input ContractInvoiceInput) (*domain.ContractMilestone, error) {
+	contract, err := s.repo.FindByID(ctx, companyID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := contract.Milestone(milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	partner, err := s.partnerRepo.GetByID(ctx, companyID, contract.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tax int64
+	if contract.TaxCodeID != nil {
+		taxCode, err := s.taxCodeRepo.FindByID(ctx, companyID, *contract.TaxCodeID)
+		if err != nil {
+			return nil, err
+		}
+		tax = int64(taxCode.TaxOn(float64(milestone.Amount), company.Settings))
+	}
+	previous := milestone.Status
+	if err := milestone.Invoice(input.IssueDate, tax); err != nil {
+		return nil, err
+	}
+
+	number := strings.TrimSpace(input.InvoiceNumber)
+	if number == "" {
+		number = fmt.Sprintf("%s-%02d", contract.ContractNo, milestone.Seq)
+	}
+	if _, err := s.taxInvoiceRepo.GetByNumber(ctx, companyID, number, domain.TaxInvoiceTypeSales); err == nil {
+		return nil, domain.ErrContractInvoiceNoExists
+	} else if !errors.Is(err, domain.ErrTaxInvoiceNotFound) {
+		return nil, err
+	}
+	invoice := contractTaxInvoice(company, partner, contract, milestone, number, userID)
+	if err := invoice.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrContractInvoiceIncomplete, err)
+	}
+
+	description := fmt.Sprintf("%s %s %s (%s)", contract.ContractNo, contract.Title, milestone.Name, partner.Name)
+	receivable := s.entry(contract, contract.ReceivableAccountID, description)
+	receivable.SetDebit(float64(milestone.Total()))
+	revenue := s.entry(contract, contract.RevenueAccountID, description)
+	revenue.TaxCodeID = contract.TaxCodeID // the voucher splits the VAT line off the tax-inclusive total
+	revenue.SetCredit(float64(milestone.Total()))
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		VoucherDate:   input.IssueDate,
+		VoucherType:   domain.VoucherTypeSales,
+		Description:   description,
+		ReferenceType: taxInvoiceReferenceType,
+		ReferenceID:   &invoice.ID,
+		Entries:       []domain.VoucherEntry{receivable, revenue},
+		CreatedBy:     &userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+
+	invoice.VoucherID = &voucher.ID
+	items := invoice.Items
+	invoice.Items = nil
+	if err := s.taxInvoiceRepo.Create(ctx, invoice); err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if err := s.taxInvoiceRepo.CreateItem(ctx, &items[i]); err != nil {
+			return nil, err
+		}
+	}
+	_ = s.taxInvoiceRepo.CreateHistory(ctx, &domain.TaxInvoiceHistory{
+		ID:           uuid.New(),
+		TaxInvoiceID: invoice.ID,
+		CompanyID:    companyID,
+		NewStatus:    invoice.Status,
+		ChangedBy:    &userID,
+		ChangeReason: fmt.Sprintf("Drafted from contract %s milestone %d", contract.ContractNo, milestone.Seq),
+		CreatedAt:    invoice.CreatedAt,
+	})
+
+	milestone.TaxInvoiceID = &invoice.ID
+	milestone.VoucherID = &voucher.ID
+	if err := s.repo.UpdateMilestone(ctx, milestone, previous, milestone.CollectedAmount); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// contractTaxInvoice returns the draft sales tax invoice of a milestone with
+// a single item of its supply
+func contractTaxInvoice(company *domain.Company, partner *domain.Partner, contract *domain.Contract, milestone *domain.ContractMilestone, number string, userID uuid.UUID) *domain.TaxInvoice {
+	now := time.Now()
+	issueDate := *milestone.InvoiceDate
+	invoice := &domain.TaxInvoice{
+		ID:                     uuid.New(),
+		CompanyID:              company.ID,
+		InvoiceNumber:          number,
+		InvoiceType:            domain.TaxInvoiceTypeSales,
+		IssueDate:              issueDate,
+		Status:                 domain.TaxInvoiceStatusDraft,
+		SupplierBusinessNumber: domain.BusinessNumberDigits(company.BusinessNumber),
+		SupplierName:           company.Name,
+		SupplierCEOName:        company.Representative,
+		SupplierAddress:        company.Address,
+		SupplierEmail:          company.Email,
+		BuyerBusinessNumber:    domain.BusinessNumberDigits(partner.BusinessNumber),
+		BuyerName:              partner.Name,
+		BuyerCEOName:           partner.Representative,
+		BuyerAddress:           partner.Address,
+		BuyerEmail:             partner.Email,
+		SupplyAmount:           milestone.Amount,
+		TaxAmount:              milestone.TaxAmount,
+		TotalAmount:            milestone.Total(),
+		Remarks:                fmt.Sprintf("계약 %s %s", contract.ContractNo, milestone.Name),
+		CreatedBy:              &userID,
+		CreatedAt:              now,
+		UpdatedAt:              now,
+	}
+	invoice.Items = []domain.TaxInvoiceItem{{
+		ID:             uuid.New(),
+		TaxInvoiceID:   invoice.ID,
+		CompanyID:      company.ID,
+		SequenceNumber: 1,
+		SupplyDate:     &issueDate,
+		Description:    fmt.Sprintf("%s %s", contract.Title, milestone.Name),
+		Quantity:       1,
+		UnitPrice:      float64(milestone.Amount),
+		Amount:         milestone.Amount,
+		TaxAmount:      milestone.TaxAmount,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}}
+	return invoice
+}
+
+// Collect books the receipt into the bank account against the receivable.
+// The contract is completed once every milestone is collected.
+func (s *contractService) Collect(ctx context.Context, companyID, userID, contractID, milestoneID uuid.UUID, input ContractCollectionInput) (*domain.ContractCollection, error) {
+	contract, err := s.repo.FindByID(ctx, companyID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := contract.Milestone(milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	bank, err := s.bankRepo.FindByID(ctx, companyID, input.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !bank.IsActive {
+		return nil, domain.ErrBankAccountInactive
+	}
+	previous, previousCollected := milestone.Status, milestone.CollectedAmount
+	if err := milestone.Collect(input.CollectedDate, input.Amount); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("%s %s 수금", contract.ContractNo, milestone.Name)
+	bankAccountID := bank.ID
+	cash := domain.VoucherEntry{
+		CompanyID:     companyID,
+		AccountID:     bank.AccountID,
+		Description:   description,
+		BankAccountID: &bankAccountID,
+	}
+	cash.SetDebit(float64(input.Amount))
+	receivable := s.entry(contract, contract.ReceivableAccountID, description)
+	receivable.SetCredit(float64(input.Amount))
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		VoucherDate:   input.CollectedDate,
+		VoucherType:   domain.VoucherTypeReceipt,
+		Description:   description,
+		ReferenceType: contractReferenceType,
+		ReferenceID:   &contract.ID,
+		Entries:       []domain.VoucherEntry{cash, receivable},
+		CreatedBy:     &userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMilestone(ctx, milestone, previous, previousCollected); err != nil {
+		return nil, err
+	}
+	collection := &domain.ContractCollection{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		ContractID:    contract.ID,
+		MilestoneID:   milestone.ID,
+		CollectedDate: input.CollectedDate,
+		Amount:        input.Amount,
+		BankAccountID: bank.ID,
+		VoucherID:     &voucher.ID,
+		CreatedBy:     &userID,
+	}
+	if err := s.repo.CreateCollection(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	if contract.IsSettled() {
+		contract.Status = domain.ContractStatusCompleted
+		contract.UpdatedBy = &userID
+		if err := s.repo.UpdateStatus(ctx, contract); err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+// entry returns a voucher line of the contract's partner on the account
+func (s *contractService) entry(contract *domain.Contract, accountID uuid.UUID, description string) domain.VoucherEntry {
+	partnerID := contract.PartnerID
+	return domain.VoucherEntry{
+		CompanyID:   contract.CompanyID,
+		AccountID:   accountID,
+		Description: description,
+		PartnerID:   &partnerID,
+	}
+}
+
+// BillingReport sums the milestones of each contract
+func (s *contractService) BillingReport(ctx context.Context, filter repository.ContractFilter, asOf time.Time) (*domain.ContractBillingReport, error) {
+	contracts, err := s.repo.ListWithMilestones(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	partnerNames := make(map[uuid.UUID]string)
+	rows := make([]domain.ContractBillingRow, len(contracts))
+	for i := range contracts {
+		rows[i] = domain.NewContractBillingRow(&contracts[i], s.partnerName(ctx, filter.CompanyID, contracts[i].PartnerID, partnerNames), asOf)
+	}
+	return domain.NewContractBillingReport(asOf, rows), nil
+}
+
+// partnerName returns the name of the partner, looked up once per partner
+func (s *contractService) partnerName(ctx context.Context, companyID, partnerID uuid.UUID, names map[uuid.UUID]string) string {
+	name, ok := names[partnerID]
+	if !ok {
+		if partner, err := s.partnerRepo.GetByID(ctx, companyID, partnerID); err == nil {
+			name = partner.Name
+		}
+		names[partnerID] = name
+	}
+	return name
+}
+
+// RemindMilestones sends one email per company listing its milestones due
+// for billing. Milestones of companies that could not be notified are tried
+// again on the next run.
+func (s *contractService) RemindMilestones(ctx context.Context, today time.Time) (int, error) {
+	if !s.notifications.IsEmailEnabled(ctx) {
+		return 0, nil
+	}
+	milestones, err := s.repo.ListForReminder(ctx, today.AddDate(0, 0, domain.ContractMilestoneReminderDays))
+	if err != nil {
+		return 0, err
+	}
+
+	var companyIDs []uuid.UUID
+	byCompany := make(map[uuid.UUID][]domain.ContractMilestone)
+	for _, m := range milestones {
+		if _, ok := byCompany[m.CompanyID]; !ok {
+			companyIDs = append(companyIDs, m.CompanyID)
+		}
+		byCompany[m.CompanyID] = append(byCompany[m.CompanyID], m)
+	}
+
+	reminded := 0
+	var errs []error
+	for _, companyID := range companyIDs {
+		companyMilestones := byCompany[companyID]
+		if err := s.remindCompany(ctx, companyID, companyMilestones, today); err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		ids := make([]uuid.UUID, len(companyMilestones))
+		for i := range companyMilestones {
+			ids[i] = companyMilestones[i].ID
+		}
+		if err := s.repo.MarkReminded(ctx, ids, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		reminded += len(companyMilestones)
+	}
+	return reminded, errors.Join(errs...)
+}
+
+// remindCompany emails the active admins of the company its milestones due for billing
+func (s *contractService) remindCompany(ctx context.Context, companyID uuid.UUID, milestones []domain.ContractMilestone, today time.Time) error {
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	active, admin := domain.UserStatusActive, domain.UserRoleAdmin
+	admins, _, err := s.userRepo.FindAll(ctx, repository.UserFilter{CompanyID: companyID, Status: &active, Role: &admin})
+	if err != nil {
+		return err
+	}
+	var to []string
+	for _, u := range admins {
+		if u.Email != "" {
+			to = append(to, u.Email)
+		}
+	}
+	if len(to) == 0 {
+		return provider.ErrEmailNoRecipients
+	}
+
+	contracts := make(map[uuid.UUID]*domain.Contract)
+	partnerNames := make(map[uuid.UUID]string)
+	lines := make([]string, len(milestones))
+	for i, m := range milestones {
+		contract, ok := contracts[m.ContractID]
+		if !ok {
+			if contract, err = s.repo.FindByID(ctx, companyID, m.ContractID); err != nil {
+				return err
+			}
+			contracts[m.ContractID] = contract
+		}
+		lines[i] = contractMilestoneLine(contract, &m, s.partnerName(ctx, companyID, contract.PartnerID, partnerNames), today)
+	}
+
+	subject, body := s.templates.Render(ctx, companyID, domain.EmailEventContractMilestone, map[string]string{
+		"company_name":    company.Name,
+		"milestone_count": strconv.Itoa(len(milestones)),
+		"milestones":      strings.Join(lines, "\n"),
+	})
+	return s.notifications.SendEmail(ctx, &provider.EmailMessage{To: to, Subject: subject, TextBody: body})
+}
+
+// contractMilestoneLine describes a milestone of the reminder, with the days
+// to its due date (D-3) or since (D+2)
+func contractMilestoneLine(contract *domain.Contract, m *domain.ContractMilestone, partnerName string, today time.Time) string {
+	days := m.DaysToDue(today)
+	dday := fmt.Sprintf("D-%d", days)
+	if days < 0 {
+		dday = fmt.Sprintf("D+%d", -days)
+	}
+	return fmt.Sprintf("%s %s %s %s %s원 (%s)", m.DueDate.Format("2006-01-02"), contract.ContractNo,
+		partnerName, m.Name, formatPrintAmount(float64(m.Amount)), dday)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// dashboardCacheTTL is how long the company figures of the dashboard are
+// served from the cache; postings show up on the main screen within it
+const dashboardCacheTTL = time.Minute
+
+// dashboardActivityLimit is the number of recent activity items on the dashboard
+const dashboardActivityLimit = 10
+
+// DashboardService defines the interface for the main screen summary
+type DashboardService interface {
+	// Summary returns the dashboard of the company. The company figures are
+	// cached briefly; the pending approvals are counted for the user each time.
+	Summary(ctx context.Context, companyID, userID uuid.UUID) (*domain.DashboardSummary, error)
+}
+
+// dashboardService implements DashboardService
+type dashboardService struct {
+	ledgerRepo  repository.LedgerRepository
+	accountRepo repository.AccountRepository
+	partnerRepo repository.PartnerRepository
+	approvals   ApprovalService
+	activity    ActivityService
+	cache       ReportCache // nil disables caching
+}
+
+// NewDashboardService creates a new DashboardService
+func NewDashboardService(
+	ledgerRepo repository.LedgerRepository,
+	accountRepo repository.AccountRepository,
+	partnerRepo repository.PartnerRepository,
+	approvals ApprovalService,
+	activity ActivityService,
+	cache ReportCache,
+) DashboardService {
+	return &dashboardService{
+		ledgerRepo:  ledgerRepo,
+		accountRepo: accountRepo,
+		partnerRepo: partnerRepo,
+		approvals:   approvals,
+		activity:    activity,
+		cache:       cache,
+	}
+}
+
+// Summary combines the cached company figures with the user's approvals
+func (s *dashboardService) Summary(ctx context.Context, companyID, userID uuid.UUID) (*domain.DashboardSummary, error) {
+	summary, err := s.companySummary(ctx, companyID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.approvals.Inbox(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	summary.PendingApprovals = len(items)
+	return summary, nil
+}
+
+// companySummary computes the figures shared by the users of the company,
+// cached per company and day
+func (s *dashboardService) companySummary(ctx context.Context, companyID uuid.UUID, now time.Time) (*domain.DashboardSummary, error) {
+	key := fmt.Sprintf("kerp:dashboard:%s:%s", companyID, now.Format("2006-01-02"))
+	if s.cache != nil {
+		if cached, err := s.cache.Get(ctx, key); err == nil && cached != "" {
+			var summary domain.DashboardSummary
+			if json.Unmarshal([]byte(cached), &summary) == nil {
+				return &summary, nil
+			}
+		}
+	}
+
+	summary := &domain.DashboardSummary{Year: now.Year(), Month: int(now.Month()), GeneratedAt: now}
+	revenues, err := s.ledgerRepo.GetBalancesByType(ctx, companyID, summary.Year, summary.Month, domain.AccountTypeRevenue)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.ledgerRepo.GetBalancesByType(ctx, companyID, summary.Year, summary.Month, domain.AccountTypeExpense)
+	if err != nil {
+		return nil, err
+	}
+	summary.SetMonthResult(revenues, expenses)
+
+	// Balances before tomorrow include today's vouchers
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	if summary.CashBalance, err = cashBalanceBefore(ctx, s.accountRepo, s.ledgerRepo, companyID, tomorrow); err != nil {
+		return nil, err
+	}
+	if summary.OverdueReceivables, summary.OverduePayables, err = s.partnerOverdue(ctx, companyID, now, tomorrow); err != nil {
+		return nil, err
+	}
+
+	activity, _, err := s.activity.List(ctx, repository.ActivityFilter{CompanyID: companyID, Page: 1, PageSize: dashboardActivityLimit})
+	if err != nil {
+		return nil, err
+	}
+	summary.RecentActivity = activity
+
+	// A cache failure only costs the next request a recomputation
+	if s.cache != nil {
+		if data, err := json.Marshal(summary); err == nil {
+			_ = s.cache.Set(ctx, key, data, dashboardCacheTTL)
+		}
+	}
+	return summary, nil
+}
+
+// cashBalanceBefore sums the balances of the designated cash accounts before the day
+func cashBalanceBefore(ctx context.Context, accountRepo repository.AccountRepository, ledgerRepo repository.LedgerRepository, companyID uuid.UUID, before time.Time) (float64, error) {
+	isCash := true
+	accounts, _, err := accountRepo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID, IsCash: &isCash})
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]uuid.UUID, len(accounts))
+	for i := range accounts {
+		ids[i] = accounts[i].ID
+	}
+	balances, err := ledgerRepo.GetAccountBalancesBefore(ctx, companyID, ids, before)
+	if err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for _, balance := range balances {
+		total += balance
+	}
+	return total, nil
+}
+
+// partnerOverdue computes the overdue receivables and payables from the
+// partner balances and the entries within the longest payment term
+func (s *dashboardService) partnerOverdue(ctx context.Context, companyID uuid.UUID, now, before time.Time) (float64, float64, error) {
+	balances, err := s.ledgerRepo.GetPartnerBalances(ctx, companyID, nil, before)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(balances) == 0 {
+		return 0, 0, nil
+	}
+	partners, _, err := s.partnerRepo.List(ctx, &repository.PartnerFilter{CompanyID: companyID})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	terms := make(map[uuid.UUID]int, len(partners))
+	from := domain.PaymentTermStart(now, domain.DefaultPaymentTermDays)
+	for _, p := range partners {
+		terms[p.ID] = p.PaymentTermDays
+		if start := domain.PaymentTermStart(now, p.PaymentTermDays); start.Before(from) {
+			from = start
+		}
+	}
+	entries, err := s.ledgerRepo.GetPartnerLedgerEntries(ctx, companyID, nil, from, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	receivables, payables := domain.PartnerOverdue(balances, entries, terms, now)
+	return receivables, payables, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/pii"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// dataExportManifest describes the archive contents in manifest.json
+type dataExportManifest struct {
+	CompanyID   string                      `json:"company_id"`
+	CompanyCode string                      `json:"company_code"`
+	CompanyName string                      `json:"company_name"`
+	Format      domain.DataExportFormat     `json:"format"`
+	MaskPII     bool                        `json:"mask_pii,omitempty"`
+	GeneratedAt string                      `json:"generated_at"`
+	Datasets    []dataExportManifestDataset `json:"datasets"`
+}
+
+type dataExportManifestDataset struct {
+	Name    domain.DataExportDataset `json:"name"`
+	File    string                   `json:"file"`
+	Rows    int                      `json:"rows"`
+	Columns []string                 `json:"columns"`
+}
+
+// buildDataExportArchive writes every dataset of the company into a zip archive,
+// one file per dataset plus manifest.json, and returns the row count of each
+// dataset. With maskPII the personal data in the rows is masked; backups must
+// not be masked to be restorable.
+func buildDataExportArchive(ctx context.Context, repo repository.DataExportRepository, company *domain.Company, format domain.DataExportFormat, maskPII bool, generatedAt time.Time) ([]byte, map[string]int, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	manifest := dataExportManifest{
+		CompanyID:   company.ID.String(),
+		CompanyCode: company.Code,
+		CompanyName: company.Name,
+		Format:      format,
+		MaskPII:     maskPII,
+		GeneratedAt: generatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	rowCounts := make(map[string]int, len(domain.FullDataExportDatasets))
+
+	for _, dataset := range domain.FullDataExportDatasets {
+		file := string(dataset) + "." + string(format)
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: file, Method: zip.Deflate, Modified: generatedAt})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var w datasetWriter
+		if format == domain.DataExportFormatJSON {
+			w = &jsonDatasetWriter{w: f}
+		} else {
+			w = newCSVDatasetWriter(f)
+		}
+		if maskPII {
+			w = &maskingDatasetWriter{datasetWriter: w}
+		}
+		if err := repo.ExportRows(ctx, company.ID, dataset, w); err != nil {
+			return nil, nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, nil, err
+		}
+
+		rowCounts[string(dataset)] = w.Count()
+		manifest.Datasets = append(manifest.Datasets, dataExportManifestDataset{
+			Name: dataset, File: file, Rows: w.Count(), Columns: w.ColumnNames(),
+		})
+	}
+
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "manifest.json", Method: zip.Deflate, Modified: generatedAt})
+	if err != nil {
+		return nil, nil, err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), rowCounts, nil
+}
+
+// datasetWriter writes one dataset file of the archive
+type datasetWriter interface {
+	repository.DataExportWriter
+	Close() error
+	Count() int
+	ColumnNames() []string
+}
+
+// maskingDatasetWriter masks the personal data of each row by its column name
+// before writing it
+type maskingDatasetWriter struct {
+	datasetWriter
+	columns []string
+}
+
+func (d *maskingDatasetWriter) Columns(columns []string) error {
+	d.columns = append([]string(nil), columns...)
+	return d.datasetWriter.Columns(columns)
+}
+
+func (d *maskingDatasetWriter) Row(values []sql.NullString) error {
+	for i := range values {
+		if values[i].Valid && i < len(d.columns) {
+			values[i].String = pii.MaskColumn(d.columns[i], values[i].String)
+		}
+	}
+	return d.datasetWriter.Row(values)
+}
+
+// csvDatasetWriter writes a header row and one record per row; NULL is an empty field.
+// A UTF-8 BOM is written so that Excel opens Korean text correctly.
+type csvDatasetWriter struct {
+	w       *csv.Writer
+	columns []string
+	count   int
+	record  []string
+}
+
+func newCSVDatasetWriter(w io.Writer) *csvDatasetWriter {
+	io.WriteString(w, "\ufeff")
+	return &csvDatasetWriter{w: csv.NewWriter(w)}
+}
+
+func (d *csvDatasetWriter) Columns(columns []string) error {
+	d.columns = append([]string(nil), columns...)
+	d.record = make([]string, len(columns))
+	return d.w.Write(d.columns)
+}
+
+func (d *csvDatasetWriter) Row(values []sql.NullString) error {
+	for i, v := range values {
+		d.record[i] = v.String
+	}
+	d.count++
+	return d.w.Write(d.record)
+}
+
+func (d *csvDatasetWriter) Close() error {
+	d.w.Flush()
+	return d.w.Error()
+}
+
+func (d *csvDatasetWriter) Count() int            { return d.count }
+func (d *csvDatasetWriter) ColumnNames() []string { return d.columns }
+
+// jsonDatasetWriter writes a JSON array of objects with keys in column order
+type jsonDatasetWriter struct {
+	w       io.Writer
+	columns []string
+	keys    [][]byte
+	count   int
+	buf     bytes.Buffer
+}
+
+func (d *jsonDatasetWriter) Columns(columns []string) error {
+	d.columns = append([]string(nil), columns...)
+	d.keys = make([][]byte, len(columns))
+	for i, c := range columns {
+		key, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		d.keys[i] = key
+	}
+	_, err := io.WriteString(d.w, "[")
+	return err
+}
+
+func (d *jsonDatasetWriter) Row(values []sql.NullString) error {
+	d.buf.Reset()
+	if d.count > 0 {
+		d.buf.WriteByte(',')
+	}
+	d.buf.WriteString("\n  {")
+	for i, v := range values {
+		if i > 0 {
+			d.buf.WriteByte(',')
+		}
+		d.buf.Write(d.keys[i])
+		d.buf.WriteByte(':')
+		if !v.Valid {
+			d.buf.WriteString("null")
+			continue
+		}
+		value, err := json.Marshal(v.String)
+		if err != nil {
+			return err
+		}
+		d.buf.Write(value)
+	}
+	d.buf.WriteByte('}')
+	d.count++
+	_, err := d.w.Write(d.buf.Bytes())
+	return err
+}
+
+func (d *jsonDatasetWriter) Close() error {
+	closing := "]\n"
+	if d.count > 0 {
+		closing = "\n]\n"
+	}
+	_, err := io.WriteString(d.w, closing)
+	return err
+}
+
+func (d *jsonDatasetWriter) Count() int            { return d.count }
+func (d *jsonDatasetWriter) ColumnNames() []string { return d.columns }
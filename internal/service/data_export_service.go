@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+const (
+	// dataExportListLimit is the number of recent exports listed
+	dataExportListLimit = 20
+	// dataExportBatchSize is the number of exports generated per worker run
+	dataExportBatchSize = 5
+	// dataExportStaleAfter is how long an export may stay processing before
+	// another worker assumes the first one crashed and generates it again
+	dataExportStaleAfter = 30 * time.Minute
+)
+
+// DataExportLink is a signed, expiring download link for an export archive
+type DataExportLink struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// DataExportService defines the interface for tenant data exports
+type DataExportService interface {
+	// Request queues a full export of the company's data for the worker
+	Request(ctx context.Context, companyID, userID uuid.UUID, format domain.DataExportFormat, maskPII bool) (*domain.DataExport, error)
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DataExport, error)
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.DataExport, error)
+
+	// DownloadLink signs a download link for a completed export
+	DownloadLink(export *domain.DataExport) (*DataExportLink, error)
+	// OpenDownload verifies a download link and returns the export with its archive
+	OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*domain.DataExport, error)
+
+	// ProcessPending generates queued exports and returns the number processed
+	ProcessPending(ctx context.Context) (int, error)
+	// PurgeExpired deletes exports whose retention has ended
+	PurgeExpired(ctx context.Context, asOf time.Time) (int64, error)
+}
+
+// dataExportService implements DataExportService
+type dataExportService struct {
+	exportRepo    repository.DataExportRepository
+	companyRepo   repository.CompanyRepository
+	signingSecret []byte
+	linkTTL       time.Duration
+	retention     time.Duration
+}
+
+// NewDataExportService creates a new DataExportService. Download links are
+// unavailable when signingSecret is empty.
+func NewDataExportService(
+	exportRepo repository.DataExportRepository,
+	companyRepo repository.CompanyRepository,
+	signingSecret string,
+	linkTTL, retention time.Duration,
+) DataExportService {
+	return &dataExportService{
+		exportRepo:    exportRepo,
+		companyRepo:   companyRepo,
+		signingSecret: []byte(signingSecret),
+		linkTTL:       linkTTL,
+		retention:     retention,
+	}
+}
+
+// Request creates a pending export unless one is already queued or running
+func (s *dataExportService) Request(ctx context.Context, companyID, userID uuid.UUID, format domain.DataExportFormat, maskPII bool) (*domain.DataExport, error) {
+	export, err := domain.NewDataExport(companyID, format, userID)
+	if err != nil {
+		return nil, err
+	}
+	export.MaskPII = maskPII
+
+	active, err := s.exportRepo.HasActive(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, domain.ErrDataExportInProgress
+	}
+
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// GetByID retrieves an export without its archive
+func (s *dataExportService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.DataExport, error) {
+	return s.exportRepo.FindByID(ctx, companyID, id)
+}
+
+// List retrieves the company's most recent exports
+func (s *dataExportService) List(ctx context.Context, companyID uuid.UUID) ([]domain.DataExport, error) {
+	return s.exportRepo.FindAll(ctx, companyID, dataExportListLimit)
+}
+
+// DownloadLink signs a link valid for the link TTL, or until the archive expires if sooner
+func (s *dataExportService) DownloadLink(export *domain.DataExport) (*DataExportLink, error) {
+	if len(s.signingSecret) == 0 {
+		return nil, domain.ErrDataExportSigningDisabled
+	}
+	now := time.Now()
+	if !export.IsDownloadable(now) {
+		if export.Status == domain.DataExportStatusCompleted {
+			return nil, domain.ErrDataExportExpired
+		}
+		return nil, domain.ErrDataExportNotReady
+	}
+
+	expiresAt := now.Add(s.linkTTL).Truncate(time.Second)
+	if export.ExpiresAt.Before(expiresAt) {
+		expiresAt = export.ExpiresAt.Truncate(time.Second)
+	}
+	expires := expiresAt.Unix()
+	return &DataExportLink{
+		URL: fmt.Sprintf("/api/v1/exports/%s/download?expires=%d&signature=%s",
+			export.ID, expires, s.sign(export.ID, expires)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// OpenDownload checks the link signature and expiry before loading the archive
+func (s *dataExportService) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*domain.DataExport, error) {
+	if len(s.signingSecret) == 0 {
+		return nil, domain.ErrDataExportSigningDisabled
+	}
+	expected := s.sign(id, expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) || time.Now().Unix() > expires {
+		return nil, domain.ErrInvalidDataExportLink
+	}
+
+	export, err := s.exportRepo.FindArchive(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !export.IsDownloadable(time.Now()) {
+		return nil, domain.ErrDataExportExpired
+	}
+	return export, nil
+}
+
+// sign returns the hex HMAC-SHA256 of the export ID and link expiry
+func (s *dataExportService) sign(id uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingSecret)
+	mac.Write([]byte(id.String() + "." + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ProcessPending claims queued exports one at a time and generates their archives.
+// Failed exports are recorded on the export and returned as a joined error for logging.
+func (s *dataExportService) ProcessPending(ctx context.Context) (int, error) {
+	count := 0
+	var errs []error
+	for count < dataExportBatchSize {
+		export, err := s.exportRepo.ClaimNext(ctx, time.Now().Add(-dataExportStaleAfter))
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if export == nil {
+			break
+		}
+		count++
+
+		if err := s.generate(ctx, export); err != nil {
+			export.Fail(err)
+			errs = append(errs, fmt.Errorf("data export %s: %w", export.ID, err))
+		}
+		if err := s.exportRepo.Finish(ctx, export); err != nil {
+			errs = append(errs, fmt.Errorf("data export %s: %w", export.ID, err))
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// generate builds the archive of every dataset and completes the export
+func (s *dataExportService) generate(ctx context.Context, export *domain.DataExport) error {
+	company, err := s.companyRepo.FindByID(ctx, export.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	generatedAt := time.Now()
+	data, rowCounts, err := buildDataExportArchive(ctx, s.exportRepo, company, export.Format, export.MaskPII, generatedAt)
+	if err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%s_export_%s.zip", company.Code, generatedAt.Format("20060102_150405"))
+	export.Complete(fileName, data, rowCounts, s.retention)
+	return nil
+}
+
+// PurgeExpired deletes exports past their retention
+func (s *dataExportService) PurgeExpired(ctx context.Context, asOf time.Time) (int64, error) {
+	return s.exportRepo.DeleteExpired(ctx, asOf)
+}
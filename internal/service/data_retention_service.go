@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// deletionRequestListLimit is the number of deletion requests listed
+const deletionRequestListLimit = 200
+
+// RetentionRun reports what one enforcement of the retention policy removed
+type RetentionRun struct {
+	TokensDeleted       int64
+	AuditEntriesCleared int64
+}
+
+// DataRetentionService defines the interface for the data retention policy
+// and the deletion requests of personal data
+type DataRetentionService interface {
+	// Policy returns the retention rule of every data class
+	Policy() []domain.RetentionRule
+
+	// RequestDeletion files a request to delete the personal data of a user or a
+	// partner contact of the company, due within the deadline of the policy
+	RequestDeletion(ctx context.Context, companyID, userID uuid.UUID, subjectType domain.DeletionSubjectType, subjectID uuid.UUID, reason string) (*domain.DataDeletionRequest, error)
+	GetDeletionRequest(ctx context.Context, companyID, id uuid.UUID) (*domain.DataDeletionRequest, error)
+	// ListDeletionRequests lists the requests with the status, or all of them when nil, earliest due first
+	ListDeletionRequests(ctx context.Context, companyID uuid.UUID, status *domain.DeletionRequestStatus) ([]domain.DataDeletionRequest, error)
+
+	// CompleteDeletion anonymizes the subject's personal data. Vouchers, ledgers
+	// and tax invoices referring to the subject are kept unchanged.
+	CompleteDeletion(ctx context.Context, companyID, id, userID uuid.UUID) (*domain.DataDeletionRequest, error)
+	// RejectDeletion refuses a request, recording the legal basis in the reason
+	RejectDeletion(ctx context.Context, companyID, id, userID uuid.UUID, reason string) (*domain.DataDeletionRequest, error)
+
+	// Enforce deletes stale tokens and clears the client details of audit
+	// entries past their retention, across all companies
+	Enforce(ctx context.Context, now time.Time) (*RetentionRun, error)
+}
+
+// dataRetentionService implements DataRetentionService
+type dataRetentionService struct {
+	retentionRepo repository.DataRetentionRepository
+	userRepo      repository.UserRepository
+	partnerRepo   repository.PartnerRepository
+	policy        domain.RetentionPolicy
+}
+
+// NewDataRetentionService creates a new DataRetentionService
+func NewDataRetentionService(
+	retentionRepo repository.DataRetentionRepository,
+	userRepo repository.UserRepository,
+	partnerRepo repository.PartnerRepository,
+	policy domain.RetentionPolicy,
+) DataRetentionService {
+	return &dataRetentionService{
+		retentionRepo: retentionRepo,
+		userRepo:      userRepo,
+		partnerRepo:   partnerRepo,
+		policy:        policy,
+	}
+}
+
+func (s *dataRetentionService) Policy() []domain.RetentionRule {
+	return s.policy.Rules()
+}
+
+// RequestDeletion creates a pending request unless one is already pending for the subject
+func (s *dataRetentionService) RequestDeletion(ctx context.Context, companyID, userID uuid.UUID, subjectType domain.DeletionSubjectType, subjectID uuid.UUID, reason string) (*domain.DataDeletionRequest, error) {
+	req, err := domain.NewDataDeletionRequest(companyID, subjectType, subjectID, userID, reason, s.policy.DeletionDeadlineDays, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	switch subjectType {
+	case domain.DeletionSubjectUser:
+		if _, err := s.userRepo.FindByID(ctx, companyID, subjectID); err != nil {
+			return nil, err
+		}
+	case domain.DeletionSubjectPartner:
+		if _, err := s.partnerRepo.GetByID(ctx, companyID, subjectID); err != nil {
+			return nil, domain.ErrPartnerNotFound
+		}
+	}
+
+	pending, err := s.retentionRepo.HasPendingDeletion(ctx, companyID, subjectType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, domain.ErrDeletionRequestExists
+	}
+
+	if err := s.retentionRepo.CreateDeletionRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *dataRetentionService) GetDeletionRequest(ctx context.Context, companyID, id uuid.UUID) (*domain.DataDeletionRequest, error) {
+	return s.retentionRepo.FindDeletionRequest(ctx, companyID, id)
+}
+
+func (s *dataRetentionService) ListDeletionRequests(ctx context.Context, companyID uuid.UUID, status *domain.DeletionRequestStatus) ([]domain.DataDeletionRequest, error) {
+	return s.retentionRepo.FindDeletionRequests(ctx, companyID, status, deletionRequestListLimit)
+}
+
+func (s *dataRetentionService) CompleteDeletion(ctx context.Context, companyID, id, userID uuid.UUID) (*domain.DataDeletionRequest, error) {
+	req, err := s.retentionRepo.FindDeletionRequest(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !req.IsPending() {
+		return nil, domain.ErrDeletionRequestClosed
+	}
+
+	switch req.SubjectType {
+	case domain.DeletionSubjectUser:
+		user, err := s.userRepo.FindByID(ctx, companyID, req.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		user.Anonymize()
+		if err := req.Complete(userID, time.Now()); err != nil {
+			return nil, err
+		}
+		if err := s.retentionRepo.AnonymizeUser(ctx, req, user); err != nil {
+			return nil, err
+		}
+	case domain.DeletionSubjectPartner:
+		partner, err := s.partnerRepo.GetByID(ctx, companyID, req.SubjectID)
+		if err != nil {
+			return nil, domain.ErrPartnerNotFound
+		}
+		partner.AnonymizeContact()
+		if err := req.Complete(userID, time.Now()); err != nil {
+			return nil, err
+		}
+		if err := s.retentionRepo.AnonymizePartner(ctx, req, partner); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, domain.ErrInvalidDeletionSubject
+	}
+	return req, nil
+}
+
+func (s *dataRetentionService) RejectDeletion(ctx context.Context, companyID, id, userID uuid.UUID, reason string) (*domain.DataDeletionRequest, error) {
+	req, err := s.retentionRepo.FindDeletionRequest(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Reject(userID, reason, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.retentionRepo.FinishDeletionRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *dataRetentionService) Enforce(ctx context.Context, now time.Time) (*RetentionRun, error) {
+	run := &RetentionRun{}
+
+	tokens, err := s.retentionRepo.DeleteStaleTokens(ctx, s.policy.Rule(domain.RetentionSessions).Cutoff(now))
+	if err != nil {
+		return run, err
+	}
+	run.TokensDeleted = tokens
+
+	cleared, err := s.retentionRepo.ClearAuditClientDetails(ctx, s.policy.Rule(domain.RetentionAuditLogs).Cutoff(now))
+	if err != nil {
+		return run, err
+	}
+	run.AuditEntriesCleared = cleared
+	return run, nil
+}
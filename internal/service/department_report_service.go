@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// DepartmentIncomeQuery holds the options of a department income statement
+type DepartmentIncomeQuery struct {
+	FromDate     time.Time
+	ToDate       time.Time
+	DepartmentID *uuid.UUID // limit to this department and its descendants
+	Rollup       bool       // include descendants in each department's figures
+	Allocate     bool       // apply active allocation rules to shared costs
+}
+
+// DepartmentReportService defines the interface for department-level reports
+type DepartmentReportService interface {
+	// GetIncomeStatement builds the income statement by department (부서별 손익계산서)
+	GetIncomeStatement(ctx context.Context, companyID uuid.UUID, query DepartmentIncomeQuery) ([]domain.DepartmentIncome, error)
+
+	// Allocation rule operations
+	CreateRule(ctx context.Context, rule *domain.DepartmentAllocationRule) error
+	UpdateRule(ctx context.Context, rule *domain.DepartmentAllocationRule) error
+	DeleteRule(ctx context.Context, companyID, id uuid.UUID) error
+	GetRule(ctx context.Context, companyID, id uuid.UUID) (*domain.DepartmentAllocationRule, error)
+	ListRules(ctx context.Context, companyID uuid.UUID) ([]domain.DepartmentAllocationRule, error)
+}
+
+// departmentReportService implements DepartmentReportService
+type departmentReportService struct {
+	ledgerRepo     repository.LedgerRepository
+	departmentRepo repository.DepartmentRepository
+	ruleRepo       repository.DepartmentAllocationRuleRepository
+}
+
+// NewDepartmentReportService creates a new DepartmentReportService
+func NewDepartmentReportService(
+	ledgerRepo repository.LedgerRepository,
+	departmentRepo repository.DepartmentRepository,
+	ruleRepo repository.DepartmentAllocationRuleRepository,
+) DepartmentReportService {
+	return &departmentReportService{
+		ledgerRepo:     ledgerRepo,
+		departmentRepo: departmentRepo,
+		ruleRepo:       ruleRepo,
+	}
+}
+
+// GetIncomeStatement aggregates posted revenue/expense entries by department
+func (s *departmentReportService) GetIncomeStatement(ctx context.Context, companyID uuid.UUID, query DepartmentIncomeQuery) ([]domain.DepartmentIncome, error) {
+	if query.DepartmentID != nil {
+		if _, err := s.departmentRepo.GetByID(ctx, companyID, *query.DepartmentID); err != nil {
+			return nil, domain.ErrDepartmentNotFound
+		}
+	}
+
+	departments, err := s.departmentRepo.GetTree(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.ledgerRepo.GetDepartmentAccountTotals(ctx, companyID, query.FromDate, query.ToDate)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := domain.DepartmentIncomeOptions{Rollup: query.Rollup, RootID: query.DepartmentID}
+	if query.Allocate {
+		opts.Rules, err = s.ruleRepo.FindAll(ctx, companyID, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.BuildDepartmentIncome(departments, totals, opts), nil
+}
+
+// CreateRule validates and creates an allocation rule
+func (s *departmentReportService) CreateRule(ctx context.Context, rule *domain.DepartmentAllocationRule) error {
+	if err := s.validateRule(ctx, rule); err != nil {
+		return err
+	}
+	return s.ruleRepo.Create(ctx, rule)
+}
+
+// UpdateRule validates and updates an allocation rule
+func (s *departmentReportService) UpdateRule(ctx context.Context, rule *domain.DepartmentAllocationRule) error {
+	if err := s.validateRule(ctx, rule); err != nil {
+		return err
+	}
+	return s.ruleRepo.Update(ctx, rule)
+}
+
+// DeleteRule deletes an allocation rule
+func (s *departmentReportService) DeleteRule(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.ruleRepo.Delete(ctx, companyID, id)
+}
+
+// GetRule retrieves an allocation rule
+func (s *departmentReportService) GetRule(ctx context.Context, companyID, id uuid.UUID) (*domain.DepartmentAllocationRule, error) {
+	return s.ruleRepo.FindByID(ctx, companyID, id)
+}
+
+// ListRules retrieves all allocation rules in application order
+func (s *departmentReportService) ListRules(ctx context.Context, companyID uuid.UUID) ([]domain.DepartmentAllocationRule, error) {
+	return s.ruleRepo.FindAll(ctx, companyID, false)
+}
+
+// validateRule validates the rule and checks that its departments exist
+func (s *departmentReportService) validateRule(ctx context.Context, rule *domain.DepartmentAllocationRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if rule.SourceDepartmentID != nil {
+		if _, err := s.departmentRepo.GetByID(ctx, rule.CompanyID, *rule.SourceDepartmentID); err != nil {
+			return domain.ErrDepartmentNotFound
+		}
+	}
+	for _, t := range rule.Targets {
+		if _, err := s.departmentRepo.GetByID(ctx, rule.CompanyID, t.DepartmentID); err != nil {
+			return domain.ErrDepartmentNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// DocumentLinkService defines the interface for linking the company's documents
+type DocumentLinkService interface {
+	// Link links two existing documents of the company
+	Link(ctx context.Context, companyID uuid.UUID, a, b domain.DocumentRef, note string, userID uuid.UUID) (*domain.DocumentLink, error)
+
+	// Unlink deletes a link; the documents themselves are kept
+	Unlink(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Related returns the documents related to an existing document
+	Related(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) ([]domain.RelatedDocument, error)
+}
+
+// documentLinkService implements DocumentLinkService
+type documentLinkService struct {
+	repo repository.DocumentLinkRepository
+}
+
+// NewDocumentLinkService creates a new DocumentLinkService
+func NewDocumentLinkService(repo repository.DocumentLinkRepository) DocumentLinkService {
+	return &documentLinkService{repo: repo}
+}
+
+// Link validates both documents exist and saves the link
+func (s *documentLinkService) Link(ctx context.Context, companyID uuid.UUID, a, b domain.DocumentRef, note string, userID uuid.UUID) (*domain.DocumentLink, error) {
+	link, err := domain.NewDocumentLink(companyID, a, b, note, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range []domain.DocumentRef{a, b} {
+		if err := s.checkExists(ctx, companyID, ref); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// Unlink deletes a link
+func (s *documentLinkService) Unlink(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.repo.Delete(ctx, companyID, id)
+}
+
+// Related returns the documents related to a document
+func (s *documentLinkService) Related(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) ([]domain.RelatedDocument, error) {
+	if !ref.Type.IsValid() {
+		return nil, domain.ErrInvalidDocumentType
+	}
+	if err := s.checkExists(ctx, companyID, ref); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRelated(ctx, companyID, ref)
+}
+
+// checkExists returns ErrDocumentNotFound unless the document belongs to the company
+func (s *documentLinkService) checkExists(ctx context.Context, companyID uuid.UUID, ref domain.DocumentRef) error {
+	exists, err := s.repo.DocumentExists(ctx, companyID, ref)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrDocumentNotFound
+	}
+	return nil
+}
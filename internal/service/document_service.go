@@ -0,0 +1,636 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/filetype"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// DocumentUpload is an uploaded content of a library file
+type DocumentUpload struct {
+	CompanyID uuid.UUID
+	UserID    uuid.UUID
+	FileName  string
+	Data      []byte
+	Comment   string // of the version
+	IPAddress string
+}
+
+// DocumentFileUpdate holds the fields of a file that change; a new folder moves it
+type DocumentFileUpdate struct {
+	FolderID    uuid.UUID
+	Name        string
+	Description string
+	Metadata    map[string]string
+}
+
+// DocumentService defines the interface for the document library. Every call
+// is checked against the access of the user's role to the folder, which the
+// permissions of the folder or its nearest ancestor with any decide. Uploads
+// go through the virus scanner and type checks of voucher attachments.
+type DocumentService interface {
+	// ListFolders returns the folders the user may read, with their access
+	ListFolders(ctx context.Context, companyID, userID uuid.UUID) ([]domain.DocumentFolder, error)
+	CreateFolder(ctx context.Context, userID uuid.UUID, folder *domain.DocumentFolder) error
+	// UpdateFolder renames the folder and moves it under parentID, or to the root when nil
+	UpdateFolder(ctx context.Context, companyID, userID, id uuid.UUID, name string, parentID *uuid.UUID) (*domain.DocumentFolder, error)
+	DeleteFolder(ctx context.Context, companyID, userID, id uuid.UUID) error
+	// FolderPermissions returns the permissions set on the folder itself
+	FolderPermissions(ctx context.Context, companyID, userID, folderID uuid.UUID) ([]domain.DocumentFolderPermission, error)
+	// SetFolderPermissions replaces the permissions of the folder; none makes
+	// it inherit those of its parent
+	SetFolderPermissions(ctx context.Context, companyID, userID, folderID uuid.UUID, permissions []domain.DocumentFolderPermission) ([]domain.DocumentFolderPermission, error)
+
+	// Upload stores a new file with the upload as its first version; the name
+	// of the file defaults to the uploaded file name
+	Upload(ctx context.Context, file *domain.DocumentFile, upload *DocumentUpload) (*domain.DocumentFile, error)
+	// AddVersion stores the upload as the next version of the file
+	AddVersion(ctx context.Context, fileID uuid.UUID, upload *DocumentUpload) (*domain.DocumentFile, error)
+	GetFile(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.DocumentFile, error)
+	UpdateFile(ctx context.Context, companyID, userID, id uuid.UUID, update DocumentFileUpdate) (*domain.DocumentFile, error)
+	DeleteFile(ctx context.Context, companyID, userID, id uuid.UUID) error
+	// Download returns a version of the file with its content, the current one
+	// when version is 0; ipAddress is recorded if it is quarantined
+	Download(ctx context.Context, companyID, userID, fileID uuid.UUID, version int, ipAddress string) (*domain.DocumentVersion, error)
+	// Search searches the folders the user may read; FolderIDs of the filter is ignored
+	Search(ctx context.Context, userID uuid.UUID, filter repository.DocumentFileFilter) ([]domain.DocumentFile, int64, error)
+
+	// Link links the file to an existing business document of the company
+	Link(ctx context.Context, companyID, userID, fileID uuid.UUID, ref domain.DocumentRef) (*domain.DocumentFileLink, error)
+	Unlink(ctx context.Context, companyID, userID, fileID, linkID uuid.UUID) error
+}
+
+// documentService implements DocumentService
+type documentService struct {
+	repo           repository.DocumentRepository
+	attachmentRepo repository.VoucherAttachmentRepository
+	linkRepo       repository.DocumentLinkRepository
+	userRepo       repository.UserRepository
+	scanner        provider.VirusScanner
+	notifications  NotificationService
+	logger         *zap.Logger
+	maxFileSize    int64
+	limits         PlanLimits // nil does not limit library storage
+}
+
+// NewDocumentService creates a new DocumentService. Infected files are
+// quarantined with those of voucher attachments through attachmentRepo.
+// scanner may be nil, in which case files are stored unscanned.
+// limits may be nil, in which case storage is not limited by the plan.
+func NewDocumentService(
+	repo repository.DocumentRepository,
+	attachmentRepo repository.VoucherAttachmentRepository,
+	linkRepo repository.DocumentLinkRepository,
+	userRepo repository.UserRepository,
+	scanner provider.VirusScanner,
+	notifications NotificationService,
+	logger *zap.Logger,
+	maxFileSize int64,
+	limits PlanLimits,
+) DocumentService {
+	return &documentService{
+		repo:           repo,
+		attachmentRepo: attachmentRepo,
+		linkRepo:       linkRepo,
+		userRepo:       userRepo,
+		scanner:        scanner,
+		notifications:  notifications,
+		logger:         logger,
+		maxFileSize:    maxFileSize,
+		limits:         limits,
+	}
+}
+
+// ListFolders returns the readable folders of the company
+func (s *documentService) ListFolders(ctx context.Context, companyID, userID uuid.UUID) ([]domain.DocumentFolder, error) {
+	role, err := s.role(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := s.repo.ListFolders(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := s.repo.ListPermissions(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The folders are returned as well, so the tree is built here
+	tree := domain.NewDocumentTree(folders, permissions)
+	readable := make([]domain.DocumentFolder, 0, len(folders))
+	for _, folder := range folders {
+		id := folder.ID
+		if access := tree.Access(role, &id); access.Allows(domain.DocumentAccessRead) {
+			folder.Access = access
+			readable = append(readable, folder)
+		}
+	}
+	return readable, nil
+}
+
+// CreateFolder adds a folder under a folder the user may write, or at the root
+func (s *documentService) CreateFolder(ctx context.Context, userID uuid.UUID, folder *domain.DocumentFolder) error {
+	if err := folder.Validate(); err != nil {
+		return err
+	}
+	tree, role, err := s.tree(ctx, folder.CompanyID, userID)
+	if err != nil {
+		return err
+	}
+	if err := checkFolderAccess(tree, role, folder.ParentID, domain.DocumentAccessWrite); err != nil {
+		return err
+	}
+	if err := s.checkFolderName(ctx, folder, nil); err != nil {
+		return err
+	}
+	folder.CreatedBy = &userID
+	return s.repo.CreateFolder(ctx, folder)
+}
+
+// UpdateFolder renames or moves a folder the user manages; it can move into
+// folders they may write but not into its own subfolders
+func (s *documentService) UpdateFolder(ctx context.Context, companyID, userID, id uuid.UUID, name string, parentID *uuid.UUID) (*domain.DocumentFolder, error) {
+	folder, err := s.repo.FindFolder(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &folder.ID, domain.DocumentAccessManage); err != nil {
+		return nil, err
+	}
+
+	moved := !sameFolder(folder.ParentID, parentID)
+	folder.Name = name
+	folder.ParentID = parentID
+	if err := folder.Validate(); err != nil {
+		return nil, err
+	}
+	if moved {
+		if err := checkFolderAccess(tree, role, parentID, domain.DocumentAccessWrite); err != nil {
+			return nil, err
+		}
+		if parentID != nil && tree.IsWithin(*parentID, folder.ID) {
+			return nil, domain.ErrDocumentFolderCycle
+		}
+	}
+	if err := s.checkFolderName(ctx, folder, &folder.ID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateFolder(ctx, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// DeleteFolder deletes an empty folder the user manages
+func (s *documentService) DeleteFolder(ctx context.Context, companyID, userID, id uuid.UUID) error {
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return err
+	}
+	if err := checkFolderAccess(tree, role, &id, domain.DocumentAccessManage); err != nil {
+		return err
+	}
+	return s.repo.DeleteFolder(ctx, companyID, id)
+}
+
+// FolderPermissions returns the permissions of a folder the user manages
+func (s *documentService) FolderPermissions(ctx context.Context, companyID, userID, folderID uuid.UUID) ([]domain.DocumentFolderPermission, error) {
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &folderID, domain.DocumentAccessManage); err != nil {
+		return nil, err
+	}
+	permissions, err := s.repo.ListPermissions(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	own := make([]domain.DocumentFolderPermission, 0)
+	for _, p := range permissions {
+		if p.FolderID == folderID {
+			own = append(own, p)
+		}
+	}
+	return own, nil
+}
+
+// SetFolderPermissions validates and replaces the permissions of a folder the
+// user manages. Administrators manage every folder whatever it grants them.
+func (s *documentService) SetFolderPermissions(ctx context.Context, companyID, userID, folderID uuid.UUID, permissions []domain.DocumentFolderPermission) ([]domain.DocumentFolderPermission, error) {
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &folderID, domain.DocumentAccessManage); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[domain.UserRole]bool, len(permissions))
+	for i := range permissions {
+		p := &permissions[i]
+		if !p.Role.IsValid() || !p.Access.IsValid() || seen[p.Role] {
+			return nil, domain.ErrInvalidDocumentAccess
+		}
+		seen[p.Role] = true
+		p.CompanyID = companyID
+		p.FolderID = folderID
+	}
+	if err := s.repo.ReplacePermissions(ctx, companyID, folderID, permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// Upload checks and stores a new file in a folder the user may write
+func (s *documentService) Upload(ctx context.Context, file *domain.DocumentFile, upload *DocumentUpload) (*domain.DocumentFile, error) {
+	if file.Name == "" {
+		file.Name = upload.FileName
+	}
+	file.CompanyID = upload.CompanyID
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	tree, role, err := s.tree(ctx, file.CompanyID, upload.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &file.FolderID, domain.DocumentAccessWrite); err != nil {
+		return nil, err
+	}
+	if err := s.checkFileName(ctx, file, nil); err != nil {
+		return nil, err
+	}
+
+	version, err := s.checkUpload(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+	// The file ID is known up front so that the version can reference it
+	file.ID, err = uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	file.CreatedBy = &upload.UserID
+	file.AddVersion(version)
+	if err := s.repo.CreateFile(ctx, file, version); err != nil {
+		return nil, err
+	}
+	file.Versions = []domain.DocumentVersion{*version}
+	return file, nil
+}
+
+// AddVersion checks and stores the next version of a file the user may write
+func (s *documentService) AddVersion(ctx context.Context, fileID uuid.UUID, upload *DocumentUpload) (*domain.DocumentFile, error) {
+	file, err := s.repo.FindFile(ctx, upload.CompanyID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	tree, role, err := s.tree(ctx, upload.CompanyID, upload.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &file.FolderID, domain.DocumentAccessWrite); err != nil {
+		return nil, err
+	}
+
+	version, err := s.checkUpload(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+	previous := file.CurrentVersion
+	file.AddVersion(version)
+	if err := s.repo.AddVersion(ctx, file, version, previous); err != nil {
+		return nil, err
+	}
+	file.Versions = append([]domain.DocumentVersion{*version}, file.Versions...)
+	return file, nil
+}
+
+// GetFile returns a file of a folder the user may read
+func (s *documentService) GetFile(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.DocumentFile, error) {
+	file, err := s.repo.FindFile(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &file.FolderID, domain.DocumentAccessRead); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// UpdateFile renames, describes or moves a file; the user needs write access
+// to its folder and to the one it moves to
+func (s *documentService) UpdateFile(ctx context.Context, companyID, userID, id uuid.UUID, update DocumentFileUpdate) (*domain.DocumentFile, error) {
+	file, err := s.repo.FindFile(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, folderID := range []uuid.UUID{file.FolderID, update.FolderID} {
+		folderID := folderID
+		if err := checkFolderAccess(tree, role, &folderID, domain.DocumentAccessWrite); err != nil {
+			return nil, err
+		}
+	}
+
+	file.FolderID = update.FolderID
+	file.Name = update.Name
+	file.Description = update.Description
+	file.Metadata = update.Metadata
+	file.UpdatedBy = &userID
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkFileName(ctx, file, &file.ID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateFile(ctx, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// DeleteFile deletes a file with its versions and links
+func (s *documentService) DeleteFile(ctx context.Context, companyID, userID, id uuid.UUID) error {
+	if _, err := s.writableFile(ctx, companyID, userID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteFile(ctx, companyID, id)
+}
+
+// Download returns a version of a readable file, scanning it first if it was
+// stored unscanned
+func (s *documentService) Download(ctx context.Context, companyID, userID, fileID uuid.UUID, version int, ipAddress string) (*domain.DocumentVersion, error) {
+	file, err := s.GetFile(ctx, companyID, userID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		version = file.CurrentVersion
+	}
+	v, err := s.repo.FindVersion(ctx, companyID, fileID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.ScanStatus == domain.AttachmentScanUnscanned && s.scanner != nil {
+		if err := s.scan(ctx, v, ipAddress, true); err != nil {
+			return nil, err
+		}
+		if err := s.repo.UpdateVersionScan(ctx, v); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// Search searches the readable folders of the company
+func (s *documentService) Search(ctx context.Context, userID uuid.UUID, filter repository.DocumentFileFilter) ([]domain.DocumentFile, int64, error) {
+	tree, role, err := s.tree(ctx, filter.CompanyID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.FolderID != nil {
+		if err := checkFolderAccess(tree, role, filter.FolderID, domain.DocumentAccessRead); err != nil {
+			return nil, 0, err
+		}
+	}
+	if filter.Entity != nil && !filter.Entity.Type.IsValid() {
+		return nil, 0, domain.ErrInvalidDocumentType
+	}
+	filter.FolderIDs = tree.Readable(role)
+	return s.repo.Search(ctx, filter)
+}
+
+// Link links a file the user may write to a document of the company
+func (s *documentService) Link(ctx context.Context, companyID, userID, fileID uuid.UUID, ref domain.DocumentRef) (*domain.DocumentFileLink, error) {
+	if !ref.Type.IsValid() {
+		return nil, domain.ErrInvalidDocumentType
+	}
+	if _, err := s.writableFile(ctx, companyID, userID, fileID); err != nil {
+		return nil, err
+	}
+	exists, err := s.linkRepo.DocumentExists(ctx, companyID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrDocumentNotFound
+	}
+
+	link := &domain.DocumentFileLink{
+		FileID:     fileID,
+		EntityType: ref.Type,
+		EntityID:   ref.ID,
+		CreatedBy:  &userID,
+	}
+	link.CompanyID = companyID
+	if err := s.repo.CreateLink(ctx, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// Unlink removes a link of a file the user may write
+func (s *documentService) Unlink(ctx context.Context, companyID, userID, fileID, linkID uuid.UUID) error {
+	if _, err := s.writableFile(ctx, companyID, userID, fileID); err != nil {
+		return err
+	}
+	return s.repo.DeleteLink(ctx, companyID, fileID, linkID)
+}
+
+// role returns the role the user holds in the company, which the folder
+// permissions are resolved for
+func (s *documentService) role(ctx context.Context, companyID, userID uuid.UUID) (domain.UserRole, error) {
+	user, err := s.userRepo.FindMember(ctx, companyID, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Role, nil
+}
+
+// tree loads the folder tree of the company and the role of the user
+func (s *documentService) tree(ctx context.Context, companyID, userID uuid.UUID) (*domain.DocumentTree, domain.UserRole, error) {
+	role, err := s.role(ctx, companyID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	folders, err := s.repo.ListFolders(ctx, companyID)
+	if err != nil {
+		return nil, "", err
+	}
+	permissions, err := s.repo.ListPermissions(ctx, companyID)
+	if err != nil {
+		return nil, "", err
+	}
+	return domain.NewDocumentTree(folders, permissions), role, nil
+}
+
+// writableFile returns a file of a folder the user may write
+func (s *documentService) writableFile(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.DocumentFile, error) {
+	file, err := s.repo.FindFile(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	tree, role, err := s.tree(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolderAccess(tree, role, &file.FolderID, domain.DocumentAccessWrite); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// checkFolderName checks the name is unique among the folder's siblings
+func (s *documentService) checkFolderName(ctx context.Context, folder *domain.DocumentFolder, excludeID *uuid.UUID) error {
+	exists, err := s.repo.ExistsFolderName(ctx, folder.CompanyID, folder.ParentID, folder.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrDocumentFolderExists
+	}
+	return nil
+}
+
+// checkFileName checks the name is unique in the file's folder
+func (s *documentService) checkFileName(ctx context.Context, file *domain.DocumentFile, excludeID *uuid.UUID) error {
+	exists, err := s.repo.ExistsFileName(ctx, file.FolderID, file.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrDocumentFileExists
+	}
+	return nil
+}
+
+// checkUpload checks the size, plan storage, malware and type of an upload
+// and returns it as a version to store
+func (s *documentService) checkUpload(ctx context.Context, upload *DocumentUpload) (*domain.DocumentVersion, error) {
+	if len(upload.Data) == 0 {
+		return nil, domain.ErrAttachmentEmpty
+	}
+	if int64(len(upload.Data)) > s.maxFileSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+	if s.limits != nil {
+		if err := s.limits.CheckLimit(ctx, upload.CompanyID, domain.PlanLimitStorage, int64(len(upload.Data))); err != nil {
+			return nil, err
+		}
+	}
+
+	userID := upload.UserID
+	version := &domain.DocumentVersion{
+		FileName:   upload.FileName,
+		FileSize:   int64(len(upload.Data)),
+		FileData:   upload.Data,
+		ScanStatus: domain.AttachmentScanUnscanned,
+		Comment:    upload.Comment,
+		UploadedBy: &userID,
+	}
+	version.CompanyID = upload.CompanyID
+
+	if err := s.scan(ctx, version, upload.IPAddress, false); err != nil {
+		return nil, err
+	}
+	fileType, err := filetype.Detect(upload.FileName, upload.Data)
+	switch {
+	case errors.Is(err, filetype.ErrNotAllowed):
+		return nil, domain.ErrAttachmentTypeNotAllowed
+	case errors.Is(err, filetype.ErrExtensionMismatch):
+		return nil, domain.ErrAttachmentTypeMismatch
+	}
+	version.FileType = fileType
+	return version, nil
+}
+
+// scan runs the virus scanner on the version and records a clean verdict, as
+// for voucher attachments. Infected files are quarantined, removing the
+// stored version when stored is true.
+func (s *documentService) scan(ctx context.Context, version *domain.DocumentVersion, ipAddress string, stored bool) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	result, err := s.scanner.Scan(ctx, version.FileName, version.FileData)
+	if err != nil {
+		s.logger.Error("document virus scan failed",
+			zap.String("company_id", version.CompanyID.String()),
+			zap.String("file_name", version.FileName),
+			zap.Error(err))
+		return domain.ErrAttachmentScanUnavailable
+	}
+	if !result.Infected {
+		now := time.Now()
+		version.ScanStatus = domain.AttachmentScanClean
+		version.Scanner = string(s.scanner.Type())
+		version.ScannedAt = &now
+		return nil
+	}
+
+	quarantined := domain.QuarantineDocumentVersion(version, result.Signature, string(s.scanner.Type()), ipAddress)
+	if err := s.attachmentRepo.Quarantine(ctx, quarantined, nil); err != nil {
+		return err
+	}
+	if stored {
+		if err := s.repo.DeleteVersion(ctx, version.CompanyID, version.ID); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Warn("security event: infected document quarantined",
+		zap.String("event", "document_infected"),
+		zap.String("company_id", version.CompanyID.String()),
+		zap.String("quarantine_id", quarantined.ID.String()),
+		zap.Stringp("uploaded_by", uuidStringp(version.UploadedBy)),
+		zap.String("ip_address", ipAddress),
+		zap.String("file_name", version.FileName),
+		zap.String("signature", result.Signature))
+	notifyQuarantine(ctx, s.notifications, s.userRepo, s.logger, quarantined, "문서함 파일")
+
+	return fmt.Errorf("%w (%s)", domain.ErrAttachmentInfected, result.Signature)
+}
+
+// checkFolderAccess fails unless the role has the required access to the
+// folder, or to the root when folderID is nil. Folders of other companies are
+// not in the tree and reported missing.
+func checkFolderAccess(tree *domain.DocumentTree, role domain.UserRole, folderID *uuid.UUID, required domain.DocumentAccess) error {
+	if folderID != nil && !tree.Has(*folderID) {
+		return domain.ErrDocumentFolderNotFound
+	}
+	if !tree.Access(role, folderID).Allows(required) {
+		return domain.ErrDocumentAccessDenied
+	}
+	return nil
+}
+
+func sameFolder(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
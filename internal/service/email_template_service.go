@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// EmailTemplatePreviewRequest renders a draft template with sample data. Nil
+// fields preview the current template; Variables override the samples.
+type EmailTemplatePreviewRequest struct {
+	Subject   *string
+	Body      *string
+	Variables map[string]string
+}
+
+// EmailTemplateService defines the interface for the email templates of
+// companies. Emails are rendered from the active version of the company's
+// template, or from the system default of the event when there is none.
+type EmailTemplateService interface {
+	// Query operations; List returns the current template of every event
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.EmailTemplate, error)
+	Get(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) (*domain.EmailTemplate, error)
+	Versions(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) ([]domain.EmailTemplate, error)
+
+	// Save adds a version and makes it active; Restore adds a copy of an older
+	// version; Reset goes back to the system default and returns it
+	Save(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, subject, body string, userID uuid.UUID) (*domain.EmailTemplate, error)
+	Restore(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, version int, userID uuid.UUID) (*domain.EmailTemplate, error)
+	Reset(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) (*domain.EmailTemplate, error)
+
+	// Preview renders a template of the event with sample data
+	Preview(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, req EmailTemplatePreviewRequest) (subject, body string, err error)
+	// Render renders the company's email of the event. It falls back to the
+	// system default if the company's template cannot be read, so that
+	// notifications never fail on a template.
+	Render(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, vars map[string]string) (subject, body string)
+}
+
+// emailTemplateService implements EmailTemplateService
+type emailTemplateService struct {
+	repo repository.EmailTemplateRepository
+}
+
+// NewEmailTemplateService creates a new EmailTemplateService
+func NewEmailTemplateService(repo repository.EmailTemplateRepository) EmailTemplateService {
+	return &emailTemplateService{repo: repo}
+}
+
+// List returns the current template of every event, in display order
+func (s *emailTemplateService) List(ctx context.Context, companyID uuid.UUID) ([]domain.EmailTemplate, error) {
+	active, err := s.repo.FindAllActive(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	byEvent := make(map[domain.EmailEventType]domain.EmailTemplate, len(active))
+	for _, t := range active {
+		byEvent[t.EventType] = t
+	}
+
+	templates := make([]domain.EmailTemplate, 0, len(domain.EmailEventTypes))
+	for _, eventType := range domain.EmailEventTypes {
+		if t, ok := byEvent[eventType]; ok {
+			templates = append(templates, t)
+			continue
+		}
+		templates = append(templates, *domain.DefaultEmailTemplate(companyID, eventType))
+	}
+	return templates, nil
+}
+
+// Get returns the current template of the event
+func (s *emailTemplateService) Get(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) (*domain.EmailTemplate, error) {
+	if !eventType.IsValid() {
+		return nil, domain.ErrInvalidEmailEventType
+	}
+	template, err := s.repo.FindActive(ctx, companyID, eventType)
+	if err == domain.ErrEmailTemplateNotFound {
+		return domain.DefaultEmailTemplate(companyID, eventType), nil
+	}
+	return template, err
+}
+
+// Versions lists the saved versions of the event, newest first
+func (s *emailTemplateService) Versions(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) ([]domain.EmailTemplate, error) {
+	if !eventType.IsValid() {
+		return nil, domain.ErrInvalidEmailEventType
+	}
+	return s.repo.FindVersions(ctx, companyID, eventType)
+}
+
+// Save validates the template and saves it as the next version
+func (s *emailTemplateService) Save(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, subject, body string, userID uuid.UUID) (*domain.EmailTemplate, error) {
+	template := domain.NewEmailTemplate(companyID, eventType, subject, body, userID)
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Restore saves a copy of an older version as the next version
+func (s *emailTemplateService) Restore(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, version int, userID uuid.UUID) (*domain.EmailTemplate, error) {
+	if !eventType.IsValid() {
+		return nil, domain.ErrInvalidEmailEventType
+	}
+	old, err := s.repo.FindVersion(ctx, companyID, eventType, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.Save(ctx, companyID, eventType, old.Subject, old.Body, userID)
+}
+
+// Reset deactivates the company's template; its versions are kept
+func (s *emailTemplateService) Reset(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType) (*domain.EmailTemplate, error) {
+	if !eventType.IsValid() {
+		return nil, domain.ErrInvalidEmailEventType
+	}
+	if err := s.repo.Deactivate(ctx, companyID, eventType); err != nil {
+		return nil, err
+	}
+	return domain.DefaultEmailTemplate(companyID, eventType), nil
+}
+
+// Preview renders the draft, or the current template, with sample data
+func (s *emailTemplateService) Preview(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, req EmailTemplatePreviewRequest) (string, string, error) {
+	template, err := s.Get(ctx, companyID, eventType)
+	if err != nil {
+		return "", "", err
+	}
+	draft := *template
+	if req.Subject != nil {
+		draft.Subject = *req.Subject
+	}
+	if req.Body != nil {
+		draft.Body = *req.Body
+	}
+	if err := draft.Validate(); err != nil {
+		return "", "", err
+	}
+
+	vars := eventType.SampleData()
+	for name, value := range req.Variables {
+		if _, ok := vars[name]; ok {
+			vars[name] = value
+		}
+	}
+	return draft.Render(vars)
+}
+
+// Render renders the current template of the event
+func (s *emailTemplateService) Render(ctx context.Context, companyID uuid.UUID, eventType domain.EmailEventType, vars map[string]string) (string, string) {
+	if template, err := s.repo.FindActive(ctx, companyID, eventType); err == nil {
+		if subject, body, err := template.Render(vars); err == nil {
+			return subject, body
+		}
+	}
+	// The defaults are tested to render
+	subject, body, _ := domain.DefaultEmailTemplate(companyID, eventType).Render(vars)
+	return subject, body
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// employeeAdvanceReferenceType marks vouchers generated for employee advances and their settlements
+const employeeAdvanceReferenceType = "employee_advance"
+
+// AdvanceSettlementInput describes the settlement of an advance. Refunds and
+// reimbursements go through the bank account, by default the one the
+// advance was paid from.
+type AdvanceSettlementInput struct {
+	SettlementDate time.Time
+	Expenses       []domain.AdvanceExpense
+	Refund         int64
+	BankAccountID  *uuid.UUID
+}
+
+// EmployeeAdvanceService defines the interface for employee advances
+type EmployeeAdvanceService interface {
+	// Create records an advance paid to an employee and generates its draft
+	// payment voucher
+	Create(ctx context.Context, advance *domain.EmployeeAdvance) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.EmployeeAdvance, error)
+	List(ctx context.Context, filter repository.EmployeeAdvanceFilter) ([]domain.EmployeeAdvance, int64, error)
+
+	// Settle settles the advance by the expenses claimed and the cash
+	// refunded, and generates the draft voucher offsetting the advance. The
+	// expenses breaking the travel policy are flagged for review.
+	Settle(ctx context.Context, companyID, userID, id uuid.UUID, input AdvanceSettlementInput) (*domain.EmployeeAdvanceSettlement, error)
+	ListSettlements(ctx context.Context, filter repository.AdvanceSettlementFilter) ([]domain.EmployeeAdvanceSettlement, int64, error)
+	// ReviewSettlement records that an approver reviewed the policy
+	// violations of a settlement; claimants cannot review their own
+	ReviewSettlement(ctx context.Context, companyID, userID, id uuid.UUID, note string) (*domain.EmployeeAdvanceSettlement, error)
+
+	// Outstanding reports the advances outstanding at the date by employee
+	Outstanding(ctx context.Context, companyID uuid.UUID, asOf time.Time) ([]domain.EmployeeAdvanceSummary, error)
+}
+
+// employeeAdvanceService implements EmployeeAdvanceService
+type employeeAdvanceService struct {
+	repo           repository.EmployeeAdvanceRepository
+	policyRepo     repository.TravelPolicyRepository
+	userRepo       repository.UserRepository
+	departmentRepo repository.DepartmentRepository
+	accountRepo    repository.AccountRepository
+	bankRepo       repository.BankAccountRepository
+	voucherService VoucherService
+}
+
+// NewEmployeeAdvanceService creates a new EmployeeAdvanceService
+func NewEmployeeAdvanceService(
+	repo repository.EmployeeAdvanceRepository,
+	policyRepo repository.TravelPolicyRepository,
+	userRepo repository.UserRepository,
+	departmentRepo repository.DepartmentRepository,
+	accountRepo repository.AccountRepository,
+	bankRepo repository.BankAccountRepository,
+	voucherService VoucherService,
+) EmployeeAdvanceService {
+	return &employeeAdvanceService{
+		repo:           repo,
+		policyRepo:     policyRepo,
+		userRepo:       userRepo,
+		departmentRepo: departmentRepo,
+		accountRepo:    accountRepo,
+		bankRepo:       bankRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Create validates the advance, its employee, department and accounts, and
+// books it: the advance account against the bank account it is paid from
+func (s *employeeAdvanceService) Create(ctx context.Context, advance *domain.EmployeeAdvance) error {
+	if err := advance.Validate(); err != nil {
+		return err
+	}
+	employee, err := s.userRepo.FindByID(ctx, advance.CompanyID, advance.EmployeeID)
+	if err != nil {
+		return err
+	}
+	if advance.DepartmentID != nil {
+		if _, err := s.departmentRepo.GetByID(ctx, advance.CompanyID, *advance.DepartmentID); err != nil {
+			return domain.ErrDepartmentNotFound
+		}
+	}
+	if _, err := s.accountRepo.FindByID(ctx, advance.CompanyID, advance.AccountID); err != nil {
+		return err
+	}
+	bank, err := s.activeBankAccount(ctx, advance.CompanyID, advance.BankAccountID)
+	if err != nil {
+		return err
+	}
+
+	// The voucher references the advance, so its ID is assigned up front
+	if advance.ID, err = uuid.NewV7(); err != nil {
+		return err
+	}
+	advance.Status = domain.EmployeeAdvanceOutstanding
+	advance.SettledAmount = 0
+
+	description := fmt.Sprintf("가지급금 %s %s", employee.Name, advance.Purpose)
+	receivable := s.entry(advance, advance.AccountID, description)
+	receivable.SetDebit(float64(advance.Amount))
+	voucher, err := s.createVoucher(ctx, advance, advance.AdvanceDate, domain.VoucherTypePayment, description,
+		[]domain.VoucherEntry{receivable, s.bankEntry(advance, bank, description, 0, advance.Amount)}, advance.ID, advance.CreatedBy)
+	if err != nil {
+		return err
+	}
+	advance.VoucherID = &voucher.ID
+	return s.repo.Create(ctx, advance)
+}
+
+// GetByID returns an advance with its settlements
+func (s *employeeAdvanceService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.EmployeeAdvance, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the advances of the company, latest first
+func (s *employeeAdvanceService) List(ctx context.Context, filter repository.EmployeeAdvanceFilter) ([]domain.EmployeeAdvance, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Settle debits the expenses and the refund and credits the advance account
+// by the part applied; expenses beyond the balance are credited to the bank
+// account they are reimbursed from
+func (s *employeeAdvanceService) Settle(ctx context.Context, companyID, userID, id uuid.UUID, input AdvanceSettlementInput) (*domain.EmployeeAdvanceSettlement, error) {
+	advance, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	previousSettled := advance.SettledAmount
+	settlement, err := advance.Settle(input.SettlementDate, input.Expenses, input.Refund)
+	if err != nil {
+		return nil, err
+	}
+	for _, expense := range settlement.Expenses {
+		account, err := s.accountRepo.FindByID(ctx, companyID, expense.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if !account.CanPost() || account.ID == advance.AccountID {
+			return nil, domain.ErrInvalidAdvanceSettlement
+		}
+	}
+	if err := s.checkPolicy(ctx, advance, settlement); err != nil {
+		return nil, err
+	}
+
+	var bank *domain.CompanyBankAccount
+	if settlement.RefundAmount > 0 || settlement.ReimburseAmount > 0 {
+		bankAccountID := advance.BankAccountID
+		if input.BankAccountID != nil {
+			bankAccountID = *input.BankAccountID
+		}
+		if bank, err = s.activeBankAccount(ctx, companyID, bankAccountID); err != nil {
+			return nil, err
+		}
+		settlement.BankAccountID = &bank.ID
+	}
+
+	// The voucher references the settlement, so its ID is assigned up front
+	if settlement.ID, err = uuid.NewV7(); err != nil {
+		return nil, err
+	}
+	description := fmt.Sprintf("가지급금 정산 %s", advance.Purpose)
+	if settlement.ReviewStatus == domain.SettlementReviewPending {
+		// Shown to the approver of the voucher
+		description += fmt.Sprintf(" (여비규정 위반 %d건)", len(settlement.PolicyViolations))
+	}
+	entries := make([]domain.VoucherEntry, 0, len(settlement.Expenses)+3)
+	for _, expense := range settlement.Expenses {
+		line := s.entry(advance, expense.AccountID, expense.Description)
+		line.SetDebit(float64(expense.Amount))
+		entries = append(entries, line)
+	}
+	voucherType := domain.VoucherTypeGeneral
+	if settlement.RefundAmount > 0 {
+		entries = append(entries, s.bankEntry(advance, bank, description, settlement.RefundAmount, 0))
+		voucherType = domain.VoucherTypeReceipt
+	}
+	offset := s.entry(advance, advance.AccountID, description)
+	offset.SetCredit(float64(settlement.AppliedAmount))
+	entries = append(entries, offset)
+	if settlement.ReimburseAmount > 0 {
+		entries = append(entries, s.bankEntry(advance, bank, description, 0, settlement.ReimburseAmount))
+		voucherType = domain.VoucherTypePayment
+	}
+
+	voucher, err := s.createVoucher(ctx, advance, settlement.SettlementDate, voucherType, description, entries, settlement.ID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	settlement.VoucherID = &voucher.ID
+	settlement.CreatedBy = &userID
+	if err := s.repo.CreateSettlement(ctx, advance, settlement, previousSettled); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// ListSettlements lists the settlements of the company, latest first
+func (s *employeeAdvanceService) ListSettlements(ctx context.Context, filter repository.AdvanceSettlementFilter) ([]domain.EmployeeAdvanceSettlement, int64, error) {
+	return s.repo.ListSettlements(ctx, filter)
+}
+
+// ReviewSettlement marks the violations of the settlement as reviewed by a
+// user who may approve and did not claim the expenses
+func (s *employeeAdvanceService) ReviewSettlement(ctx context.Context, companyID, userID, id uuid.UUID, note string) (*domain.EmployeeAdvanceSettlement, error) {
+	settlement, err := s.repo.FindSettlement(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	advance, err := s.repo.FindByID(ctx, companyID, settlement.AdvanceID)
+	if err != nil {
+		return nil, err
+	}
+	reviewer, err := s.userRepo.FindMember(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer.CanApprove() || reviewer.ID == advance.EmployeeID {
+		return nil, domain.ErrSettlementReviewForbidden
+	}
+
+	if err := settlement.ReviewViolations(userID, note); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSettlementReview(ctx, settlement); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// Outstanding totals the balances of the advances paid through the date and
+// not settled by then, by employee
+func (s *employeeAdvanceService) Outstanding(ctx context.Context, companyID uuid.UUID, asOf time.Time) ([]domain.EmployeeAdvanceSummary, error) {
+	balances, err := s.repo.Balances(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SummarizeOutstandingAdvances(balances, asOf), nil
+}
+
+// checkPolicy checks the expenses against the travel policy of the department
+// of the advance, or of the company, by the grade of the employee. Without a
+// policy nothing is checked.
+func (s *employeeAdvanceService) checkPolicy(ctx context.Context, advance *domain.EmployeeAdvance, settlement *domain.EmployeeAdvanceSettlement) error {
+	policy, err := s.policyRepo.FindApplicable(ctx, advance.CompanyID, advance.DepartmentID)
+	if err == domain.ErrTravelPolicyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	employee, err := s.userRepo.FindByID(ctx, advance.CompanyID, advance.EmployeeID)
+	if err != nil {
+		return err
+	}
+	settlement.FlagViolations(policy.ID, policy.Check(settlement.Expenses, employee.Grade))
+	return nil
+}
+
+// activeBankAccount returns the bank account the advance is paid from or into
+func (s *employeeAdvanceService) activeBankAccount(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error) {
+	bank, err := s.bankRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !bank.IsActive {
+		return nil, domain.ErrBankAccountInactive
+	}
+	return bank, nil
+}
+
+// entry returns a voucher entry of the advance on the account, in the
+// department of the advance
+func (s *employeeAdvanceService) entry(advance *domain.EmployeeAdvance, accountID uuid.UUID, description string) domain.VoucherEntry {
+	return domain.VoucherEntry{
+		CompanyID:    advance.CompanyID,
+		AccountID:    accountID,
+		Description:  description,
+		DepartmentID: advance.DepartmentID,
+	}
+}
+
+// bankEntry returns the entry of the cash paid into or out of the bank account
+func (s *employeeAdvanceService) bankEntry(advance *domain.EmployeeAdvance, bank *domain.CompanyBankAccount, description string, debit, credit int64) domain.VoucherEntry {
+	entry := domain.VoucherEntry{
+		CompanyID:     advance.CompanyID,
+		AccountID:     bank.AccountID,
+		Description:   description,
+		BankAccountID: &bank.ID,
+	}
+	if debit > 0 {
+		entry.SetDebit(float64(debit))
+	} else {
+		entry.SetCredit(float64(credit))
+	}
+	return entry
+}
+
+// createVoucher generates a draft voucher of the advance
+func (s *employeeAdvanceService) createVoucher(ctx context.Context, advance *domain.EmployeeAdvance, date time.Time, voucherType domain.VoucherType, description string, entries []domain.VoucherEntry, referenceID uuid.UUID, userID *uuid.UUID) (*domain.Voucher, error) {
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: advance.CompanyID},
+		VoucherDate:   date,
+		VoucherType:   voucherType,
+		Description:   description,
+		ReferenceType: employeeAdvanceReferenceType,
+		ReferenceID:   &referenceID,
+		Entries:       entries,
+		CreatedBy:     userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
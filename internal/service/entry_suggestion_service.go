@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// EntrySuggestionService defines the interface for the autocomplete of
+// voucher entries from the company's history
+type EntrySuggestionService interface {
+	// Suggest returns the combinations most used on posted entries with a
+	// similar description or the same partner, best first
+	Suggest(ctx context.Context, companyID uuid.UUID, description string, partnerID *uuid.UUID) ([]domain.EntrySuggestion, error)
+}
+
+// entrySuggestionService implements EntrySuggestionService
+type entrySuggestionService struct {
+	voucherRepo repository.VoucherRepository
+}
+
+// NewEntrySuggestionService creates a new EntrySuggestionService
+func NewEntrySuggestionService(voucherRepo repository.VoucherRepository) EntrySuggestionService {
+	return &entrySuggestionService{voucherRepo: voucherRepo}
+}
+
+// Suggest searches the posted vouchers of the lookback period
+func (s *entrySuggestionService) Suggest(ctx context.Context, companyID uuid.UUID, description string, partnerID *uuid.UUID) ([]domain.EntrySuggestion, error) {
+	keywords := domain.SuggestionKeywords(description)
+	if len(keywords) == 0 && partnerID == nil {
+		return nil, domain.ErrSuggestionCriteriaRequired
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	suggestions, err := s.voucherRepo.FindEntrySuggestions(ctx, repository.EntrySuggestionFilter{
+		CompanyID: companyID,
+		PartnerID: partnerID,
+		Keywords:  keywords,
+		Since:     today.AddDate(0, -domain.EntrySuggestionLookbackMonths, 0),
+		Limit:     domain.EntrySuggestionLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if suggestions == nil {
+		suggestions = []domain.EntrySuggestion{}
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,39 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+func TestEntrySuggestionService_Suggest(t *testing.T) {
+	voucherRepo := new(mocks.MockVoucherRepository)
+	svc := service.NewEntrySuggestionService(voucherRepo)
+	companyID, partnerID := newTestCompanyID(), uuid.New()
+
+	suggestion := domain.EntrySuggestion{AccountID: uuid.New(), AccountCode: "813100", AccountName: "복리후생비", UseCount: 3, Score: 4}
+	voucherRepo.On("FindEntrySuggestions", mock.Anything, mock.MatchedBy(func(f repository.EntrySuggestionFilter) bool {
+		return f.CompanyID == companyID && *f.PartnerID == partnerID &&
+			assert.ObjectsAreEqual([]string{"직원", "식대"}, f.Keywords) &&
+			f.Limit == domain.EntrySuggestionLimit && !f.Since.IsZero()
+	})).Return([]domain.EntrySuggestion{suggestion}, nil).Once()
+
+	suggestions, err := svc.Suggest(context.Background(), companyID, "직원 식대 (식대)", &partnerID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.EntrySuggestion{suggestion}, suggestions)
+
+	// A description of short words only is no criterion
+	_, err = svc.Suggest(context.Background(), companyID, "a b", nil)
+	assert.ErrorIs(t, err, domain.ErrSuggestionCriteriaRequired)
+
+	voucherRepo.AssertExpectations(t)
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// grantReferenceType marks vouchers generated for a government grant
+const grantReferenceType = "grant"
+
+// GrantExpenseInput links an eligible expense voucher to a grant. The amount
+// defaults to the total of the voucher.
+type GrantExpenseInput struct {
+	VoucherID   uuid.UUID
+	Amount      *int64
+	Description string
+}
+
+// GrantService defines the interface for government grants (정부보조금)
+type GrantService interface {
+	Create(ctx context.Context, grant *domain.Grant) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error)
+	List(ctx context.Context, filter repository.GrantFilter) ([]domain.Grant, int64, error)
+
+	// Receive records grant money paid in by the agency and generates its draft
+	// receipt voucher, deferring the money to the deferred income account
+	Receive(ctx context.Context, companyID, userID, grantID uuid.UUID, receiptDate time.Time, amount int64) (*domain.GrantReceipt, error)
+	ListReceipts(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantReceipt, error)
+
+	// LinkExpense links a posted expense voucher funded by the grant
+	LinkExpense(ctx context.Context, companyID, userID, grantID uuid.UUID, input GrantExpenseInput) (*domain.GrantExpense, error)
+	// UnlinkExpense removes an expense link not yet recognized as income
+	UnlinkExpense(ctx context.Context, companyID, grantID, expenseID uuid.UUID) error
+	ListExpenses(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantExpense, error)
+
+	// RecognizeIncome generates draft vouchers moving the deferred income of the
+	// month ending at periodEnd to grant income, for one company or for all when
+	// companyID is nil. Grants already recognized for the month are skipped.
+	RecognizeIncome(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.GrantRecognitionRun, error)
+	ListRecognitions(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantRecognition, error)
+
+	// Utilization reports the received, spent and recognized amounts of each
+	// grant at the date with the expenses funded by it
+	Utilization(ctx context.Context, companyID uuid.UUID, asOf time.Time) (*domain.GrantUtilizationReport, error)
+}
+
+// grantService implements GrantService
+type grantService struct {
+	repo           repository.GrantRepository
+	accountRepo    repository.AccountRepository
+	voucherRepo    repository.VoucherRepository
+	voucherService VoucherService
+}
+
+// NewGrantService creates a new GrantService
+func NewGrantService(repo repository.GrantRepository, accountRepo repository.AccountRepository, voucherRepo repository.VoucherRepository, voucherService VoucherService) GrantService {
+	return &grantService{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		voucherRepo:    voucherRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Create validates the terms and accounts of the grant and stores it
+func (s *grantService) Create(ctx context.Context, grant *domain.Grant) error {
+	if err := grant.Validate(); err != nil {
+		return err
+	}
+	exists, err := s.repo.ExistsGrantNo(ctx, grant.CompanyID, grant.GrantNo)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrGrantNoExists
+	}
+	for _, accountID := range []uuid.UUID{grant.DeferredIncomeAccountID, grant.IncomeAccountID, grant.BankAccountID} {
+		if _, err := s.accountRepo.FindByID(ctx, grant.CompanyID, accountID); err != nil {
+			return err
+		}
+	}
+
+	grant.ReceivedAmount = 0
+	grant.RecognizedAmount = 0
+	grant.RecognizedThrough = nil
+	return s.repo.Create(ctx, grant)
+}
+
+// GetByID returns a grant
+func (s *grantService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Grant, error) {
+	return s.repo.GetByID(ctx, companyID, id)
+}
+
+// List lists grants
+func (s *grantService) List(ctx context.Context, filter repository.GrantFilter) ([]domain.Grant, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Receive records the receipt with its voucher. The grant is locked for the
+// receipt so that concurrent receipts see each other's amounts, and the
+// receipt is recorded before its voucher is created so that a failure leaves
+// neither behind.
+func (s *grantService) Receive(ctx context.Context, companyID, userID, grantID uuid.UUID, receiptDate time.Time, amount int64) (*domain.GrantReceipt, error) {
+	if receiptDate.IsZero() {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	var receipt *domain.GrantReceipt
+	err := s.repo.WithTransaction(ctx, func(repo repository.GrantRepository) error {
+		grant, err := repo.GetByIDForUpdate(ctx, companyID, grantID)
+		if err != nil {
+			return err
+		}
+		if err := grant.ApplyReceipt(amount); err != nil {
+			return err
+		}
+
+		receipt = &domain.GrantReceipt{
+			CompanyID:   companyID,
+			GrantID:     grant.ID,
+			ReceiptDate: receiptDate,
+			Amount:      amount,
+			CreatedBy:   &userID,
+		}
+		grant.UpdatedBy = &userID
+		if err := repo.RecordReceipt(ctx, grant, receipt); err != nil {
+			return err
+		}
+
+		bank := s.entry(grant, grant.BankAccountID, "정부보조금 수령")
+		bank.SetDebit(float64(amount))
+		deferred := s.entry(grant, grant.DeferredIncomeAccountID, "정부보조금 수령")
+		deferred.SetCredit(float64(amount))
+
+		voucher := &domain.Voucher{
+			TenantModel:   domain.TenantModel{CompanyID: companyID},
+			VoucherDate:   receiptDate,
+			VoucherType:   domain.VoucherTypeReceipt,
+			Description:   fmt.Sprintf("정부보조금 수령 %s (%s)", grant.Name, grant.Agency),
+			ReferenceType: grantReferenceType,
+			ReferenceID:   &grant.ID,
+			Entries:       []domain.VoucherEntry{bank, deferred},
+			CreatedBy:     &userID,
+		}
+		if err := s.voucherService.Create(ctx, voucher); err != nil {
+			return fmt.Errorf("failed to create receipt voucher: %w", err)
+		}
+		receipt.VoucherID = &voucher.ID
+		return repo.LinkReceiptVoucher(ctx, receipt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// ListReceipts lists the receipts of a grant
+func (s *grantService) ListReceipts(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantReceipt, error) {
+	if _, err := s.repo.GetByID(ctx, companyID, grantID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListReceipts(ctx, companyID, grantID)
+}
+
+// LinkExpense checks the voucher falls in the program and the grant still
+// covers the amount, and links it
+func (s *grantService) LinkExpense(ctx context.Context, companyID, userID, grantID uuid.UUID, input GrantExpenseInput) (*domain.GrantExpense, error) {
+	grant, err := s.repo.GetByID(ctx, companyID, grantID)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, input.VoucherID)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.Status != domain.VoucherStatusPosted {
+		return nil, domain.ErrGrantVoucherNotLinkable
+	}
+	if voucher.VoucherDate.Before(grant.ProgramStart) || voucher.VoucherDate.After(grant.ProgramEnd) {
+		return nil, domain.ErrGrantExpenseOutOfPeriod
+	}
+	linked, err := s.repo.ExistsExpenseVoucher(ctx, companyID, grantID, voucher.ID)
+	if err != nil {
+		return nil, err
+	}
+	if linked {
+		return nil, domain.ErrGrantExpenseLinked
+	}
+
+	amount := int64(voucher.TotalDebit)
+	if input.Amount != nil {
+		amount = *input.Amount
+	}
+	if amount <= 0 || amount > int64(voucher.TotalDebit) {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	expenses, err := s.repo.ListExpenses(ctx, companyID, grantID)
+	if err != nil {
+		return nil, err
+	}
+	utilized := amount
+	for _, e := range expenses {
+		utilized += e.Amount
+	}
+	if utilized > grant.AwardedAmount {
+		return nil, domain.ErrGrantAmountExceeded
+	}
+
+	expense := &domain.GrantExpense{
+		CompanyID:   companyID,
+		GrantID:     grant.ID,
+		VoucherID:   voucher.ID,
+		VoucherNo:   voucher.VoucherNo,
+		VoucherDate: voucher.VoucherDate,
+		Amount:      amount,
+		Description: input.Description,
+		LinkedBy:    &userID,
+	}
+	if expense.Description == "" {
+		expense.Description = voucher.Description
+	}
+	if err := s.repo.CreateExpense(ctx, expense); err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+// UnlinkExpense removes the link. Expenses of months already recognized under
+// the expense method stay, as the income booked for them would no longer match.
+func (s *grantService) UnlinkExpense(ctx context.Context, companyID, grantID, expenseID uuid.UUID) error {
+	grant, err := s.repo.GetByID(ctx, companyID, grantID)
+	if err != nil {
+		return err
+	}
+	expense, err := s.repo.GetExpense(ctx, companyID, grantID, expenseID)
+	if err != nil {
+		return err
+	}
+	if grant.RecognitionMethod == domain.GrantRecognitionExpense && grant.RecognizedThrough != nil &&
+		!expense.VoucherDate.After(*grant.RecognizedThrough) {
+		return domain.ErrGrantExpenseRecognized
+	}
+	return s.repo.DeleteExpense(ctx, companyID, expense.ID)
+}
+
+// ListExpenses lists the expenses linked to a grant
+func (s *grantService) ListExpenses(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantExpense, error) {
+	if _, err := s.repo.GetByID(ctx, companyID, grantID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListExpenses(ctx, companyID, grantID)
+}
+
+// RecognizeIncome books the income recognizable by the month end of each grant
+// less the income recognized before
+func (s *grantService) RecognizeIncome(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.GrantRecognitionRun, error) {
+	grants, err := s.repo.ListForRecognition(ctx, companyID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.GrantRecognitionRun{PeriodEnd: periodEnd, Vouchers: []uuid.UUID{}}
+	var errs []error
+	for i := range grants {
+		grant := &grants[i]
+		if !grant.NeedsRecognition(periodEnd) {
+			continue
+		}
+		run.Checked++
+
+		voucher, err := s.recognize(ctx, grant, periodEnd)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("grant %s: %w", grant.GrantNo, err))
+			continue
+		}
+		if voucher != nil {
+			run.Recognized++
+			run.Vouchers = append(run.Vouchers, voucher.ID)
+		}
+	}
+	return run, errors.Join(errs...)
+}
+
+// recognize records the income of the grant recognizable by the month end with
+// its voucher, nil when there is none. The grant is locked and checked again so
+// that concurrent runs do not recognize the month twice.
+func (s *grantService) recognize(ctx context.Context, grant *domain.Grant, periodEnd time.Time) (*domain.Voucher, error) {
+	var voucher *domain.Voucher
+	err := s.repo.WithTransaction(ctx, func(repo repository.GrantRepository) error {
+		locked, err := repo.GetByIDForUpdate(ctx, grant.CompanyID, grant.ID)
+		if err != nil {
+			return err
+		}
+		*grant = *locked
+		if !grant.NeedsRecognition(periodEnd) {
+			return nil
+		}
+
+		var expenses []domain.GrantExpense
+		if grant.RecognitionMethod == domain.GrantRecognitionExpense {
+			expenses, err = repo.ListExpenses(ctx, grant.CompanyID, grant.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		recognition := &domain.GrantRecognition{
+			CompanyID: grant.CompanyID,
+			GrantID:   grant.ID,
+			PeriodEnd: periodEnd,
+			Amount:    grant.RecognizableThrough(periodEnd, expenses) - grant.RecognizedAmount,
+		}
+		if recognition.Amount < 0 {
+			recognition.Amount = 0
+		}
+		grant.RecognizedAmount += recognition.Amount
+		grant.RecognizedThrough = &periodEnd
+		if err := repo.RecordRecognition(ctx, grant, recognition); err != nil {
+			return err
+		}
+		if recognition.Amount == 0 {
+			return nil
+		}
+
+		voucher, err = s.recognitionVoucher(ctx, grant, recognition)
+		if err != nil {
+			return err
+		}
+		recognition.VoucherID = &voucher.ID
+		return repo.LinkRecognitionVoucher(ctx, recognition)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// recognitionVoucher generates the draft recognition voucher of the month end:
+// deferred income against grant income
+func (s *grantService) recognitionVoucher(ctx context.Context, grant *domain.Grant, recognition *domain.GrantRecognition) (*domain.Voucher, error) {
+	deferred := s.entry(grant, grant.DeferredIncomeAccountID, "정부보조금 수익인식")
+	deferred.SetDebit(float64(recognition.Amount))
+	income := s.entry(grant, grant.IncomeAccountID, "정부보조금 수익인식")
+	income.SetCredit(float64(recognition.Amount))
+
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: grant.CompanyID},
+		VoucherDate:   recognition.PeriodEnd,
+		VoucherType:   domain.VoucherTypeAdjustment,
+		Description:   fmt.Sprintf("정부보조금 수익인식 %s (%s) %s", grant.Name, grant.Agency, recognition.PeriodEnd.Format("2006-01")),
+		ReferenceType: grantReferenceType,
+		ReferenceID:   &grant.ID,
+		Entries:       []domain.VoucherEntry{deferred, income},
+		CreatedBy:     grant.CreatedBy,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// entry returns a voucher line of the grant on the account
+func (s *grantService) entry(grant *domain.Grant, accountID uuid.UUID, description string) domain.VoucherEntry {
+	return domain.VoucherEntry{
+		CompanyID:   grant.CompanyID,
+		AccountID:   accountID,
+		Description: description,
+		PartnerID:   grant.PartnerID,
+	}
+}
+
+// ListRecognitions lists the income recognitions of a grant
+func (s *grantService) ListRecognitions(ctx context.Context, companyID, grantID uuid.UUID) ([]domain.GrantRecognition, error) {
+	if _, err := s.repo.GetByID(ctx, companyID, grantID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRecognitions(ctx, companyID, grantID)
+}
+
+// Utilization reports the grants whose program started by the date
+func (s *grantService) Utilization(ctx context.Context, companyID uuid.UUID, asOf time.Time) (*domain.GrantUtilizationReport, error) {
+	grants, err := s.repo.ListStartedBy(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.repo.ListReceiptsUntil(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.repo.ListExpensesUntil(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	recognitions, err := s.repo.ListRecognitionsUntil(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.GrantUtilizationReport{AsOf: asOf, Grants: []domain.GrantUtilization{}}
+	for i := range grants {
+		row := domain.NewGrantUtilization(&grants[i], receipts, expenses, recognitions, asOf)
+		report.Grants = append(report.Grants, row)
+		report.TotalAwarded += row.AwardedAmount
+		report.TotalReceived += row.ReceivedAmount
+		report.TotalUtilized += row.UtilizedAmount
+		report.TotalRecognized += row.RecognizedAmount
+		report.TotalDeferred += row.DeferredBalance
+	}
+	return report, nil
+}
@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// newServiceTestGrant returns a 12,000,000 won grant for the program of 2024
+func newServiceTestGrant(companyID uuid.UUID, method domain.GrantRecognitionMethod) *domain.Grant {
+	userID := newTestUserID()
+	return &domain.Grant{
+		TenantModel:             domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		GrantNo:                 "G-2024-001",
+		Name:                    "스마트공장 구축 지원사업",
+		Agency:                  "중소벤처기업부",
+		AwardedAmount:           12000000,
+		ProgramStart:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ProgramEnd:              time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		RecognitionMethod:       method,
+		DeferredIncomeAccountID: uuid.New(),
+		IncomeAccountID:         uuid.New(),
+		BankAccountID:           uuid.New(),
+		CreatedBy:               &userID,
+	}
+}
+
+func TestGrantService_Receive(t *testing.T) {
+	repo := new(mocks.MockGrantRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewGrantService(repo, nil, nil, voucherService)
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	grant := newServiceTestGrant(companyID, domain.GrantRecognitionExpense)
+	repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("GetByIDForUpdate", mock.Anything, companyID, grant.ID).Return(grant, nil)
+
+	var voucher *domain.Voucher
+	repo.On("RecordReceipt", mock.Anything, grant, mock.AnythingOfType("*domain.GrantReceipt")).Run(func(args mock.Arguments) {
+		// The receipt is recorded before its voucher exists
+		assert.Nil(t, voucher)
+		assert.Nil(t, args.Get(2).(*domain.GrantReceipt).VoucherID)
+	}).Return(nil).Once()
+	voucherService.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		voucher = args.Get(1).(*domain.Voucher)
+		voucher.ID = uuid.New()
+	}).Return(nil).Once()
+	repo.On("LinkReceiptVoucher", mock.Anything, mock.AnythingOfType("*domain.GrantReceipt")).Return(nil).Once()
+
+	receipt, err := svc.Receive(context.Background(), companyID, userID, grant.ID, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), 6000000)
+	require.NoError(t, err)
+	assert.Equal(t, &voucher.ID, receipt.VoucherID)
+	assert.Equal(t, int64(6000000), grant.ReceivedAmount)
+
+	require.NotNil(t, voucher)
+	assert.Equal(t, domain.VoucherTypeReceipt, voucher.VoucherType)
+	require.Len(t, voucher.Entries, 2)
+	assert.Equal(t, grant.BankAccountID, voucher.Entries[0].AccountID)
+	assert.Equal(t, 6000000.0, voucher.Entries[0].DebitAmount)
+	assert.Equal(t, grant.DeferredIncomeAccountID, voucher.Entries[1].AccountID)
+	assert.Equal(t, 6000000.0, voucher.Entries[1].CreditAmount)
+
+	_, err = svc.Receive(context.Background(), companyID, userID, grant.ID, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), 6000001)
+	assert.ErrorIs(t, err, domain.ErrGrantAmountExceeded)
+
+	repo.AssertExpectations(t)
+	voucherService.AssertExpectations(t)
+}
+
+func TestGrantService_LinkExpense(t *testing.T) {
+	repo := new(mocks.MockGrantRepository)
+	voucherRepo := new(mocks.MockVoucherRepository)
+	svc := service.NewGrantService(repo, nil, voucherRepo, nil)
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	grant := newServiceTestGrant(companyID, domain.GrantRecognitionExpense)
+	repo.On("GetByID", mock.Anything, companyID, grant.ID).Return(grant, nil)
+
+	voucher := &domain.Voucher{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		VoucherNo:   "GV-20240210-0001",
+		VoucherDate: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		Status:      domain.VoucherStatusPosted,
+		TotalDebit:  5000000,
+		Description: "설비 구입",
+	}
+	voucherRepo.On("FindByID", mock.Anything, companyID, voucher.ID).Return(voucher, nil)
+	repo.On("ExistsExpenseVoucher", mock.Anything, companyID, grant.ID, voucher.ID).Return(false, nil)
+	repo.On("ListExpenses", mock.Anything, companyID, grant.ID).
+		Return([]domain.GrantExpense{{GrantID: grant.ID, Amount: 8000000}}, nil)
+	repo.On("CreateExpense", mock.Anything, mock.AnythingOfType("*domain.GrantExpense")).Return(nil).Once()
+
+	// The rest of the voucher exceeds what is left of the grant
+	_, err := svc.LinkExpense(context.Background(), companyID, userID, grant.ID, service.GrantExpenseInput{VoucherID: voucher.ID})
+	assert.ErrorIs(t, err, domain.ErrGrantAmountExceeded)
+
+	amount := int64(4000000)
+	expense, err := svc.LinkExpense(context.Background(), companyID, userID, grant.ID, service.GrantExpenseInput{VoucherID: voucher.ID, Amount: &amount})
+	require.NoError(t, err)
+	assert.Equal(t, voucher.VoucherNo, expense.VoucherNo)
+	assert.Equal(t, voucher.VoucherDate, expense.VoucherDate)
+	assert.Equal(t, "설비 구입", expense.Description)
+
+	voucher.Status = domain.VoucherStatusDraft
+	_, err = svc.LinkExpense(context.Background(), companyID, userID, grant.ID, service.GrantExpenseInput{VoucherID: voucher.ID})
+	assert.ErrorIs(t, err, domain.ErrGrantVoucherNotLinkable)
+
+	repo.AssertExpectations(t)
+}
+
+func TestGrantService_RecognizeIncome(t *testing.T) {
+	repo := new(mocks.MockGrantRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewGrantService(repo, nil, nil, voucherService)
+
+	periodEnd := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	byExpense := newServiceTestGrant(newTestCompanyID(), domain.GrantRecognitionExpense)
+	byExpense.ReceivedAmount = 6000000
+	byExpense.RecognizedAmount = 1000000
+	straightLine := newServiceTestGrant(newTestCompanyID(), domain.GrantRecognitionStraightLine)
+	straightLine.ReceivedAmount = 12000000
+	repo.On("ListForRecognition", mock.Anything, (*uuid.UUID)(nil), periodEnd).
+		Return([]domain.Grant{*byExpense, *straightLine}, nil)
+	repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Twice()
+	repo.On("GetByIDForUpdate", mock.Anything, byExpense.CompanyID, byExpense.ID).Return(byExpense, nil).Once()
+	repo.On("GetByIDForUpdate", mock.Anything, straightLine.CompanyID, straightLine.ID).Return(straightLine, nil).Once()
+	repo.On("ListExpenses", mock.Anything, byExpense.CompanyID, byExpense.ID).Return([]domain.GrantExpense{
+		{GrantID: byExpense.ID, VoucherDate: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), Amount: 1000000},
+		{GrantID: byExpense.ID, VoucherDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), Amount: 2500000},
+		{GrantID: byExpense.ID, VoucherDate: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), Amount: 900000},
+	}, nil)
+
+	vouchers := map[uuid.UUID]*domain.Voucher{}
+	voucherService.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		voucher := args.Get(1).(*domain.Voucher)
+		voucher.ID = uuid.New()
+		vouchers[*voucher.ReferenceID] = voucher
+	}).Return(nil).Twice()
+
+	recognitions := map[uuid.UUID]*domain.GrantRecognition{}
+	repo.On("RecordRecognition", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Equal(t, periodEnd, *args.Get(1).(*domain.Grant).RecognizedThrough)
+		recognition := args.Get(2).(*domain.GrantRecognition)
+		recognitions[recognition.GrantID] = recognition
+	}).Return(nil)
+	repo.On("LinkRecognitionVoucher", mock.Anything, mock.AnythingOfType("*domain.GrantRecognition")).Return(nil).Twice()
+
+	run, err := svc.RecognizeIncome(context.Background(), nil, periodEnd)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Checked)
+	assert.Equal(t, 2, run.Recognized)
+
+	// Expenses through March less the income recognized in February
+	assert.Equal(t, int64(2500000), recognitions[byExpense.ID].Amount)
+	voucher := vouchers[byExpense.ID]
+	require.NotNil(t, voucher)
+	assert.Equal(t, domain.VoucherTypeAdjustment, voucher.VoucherType)
+	assert.False(t, voucher.AutoReverse)
+	require.Len(t, voucher.Entries, 2)
+	assert.Equal(t, byExpense.DeferredIncomeAccountID, voucher.Entries[0].AccountID)
+	assert.Equal(t, 2500000.0, voucher.Entries[0].DebitAmount)
+	assert.Equal(t, byExpense.IncomeAccountID, voucher.Entries[1].AccountID)
+	assert.Equal(t, 2500000.0, voucher.Entries[1].CreditAmount)
+
+	// Three of the twelve months of the program
+	assert.Equal(t, int64(3000000), recognitions[straightLine.ID].Amount)
+	assert.Equal(t, &vouchers[straightLine.ID].ID, recognitions[straightLine.ID].VoucherID)
+}
+
+func TestGrantService_RecognizeIncomeConcurrently(t *testing.T) {
+	repo := new(mocks.MockGrantRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewGrantService(repo, nil, nil, voucherService)
+
+	periodEnd := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	grant := newServiceTestGrant(newTestCompanyID(), domain.GrantRecognitionStraightLine)
+	grant.ReceivedAmount = 12000000
+
+	// Another run recognized the month before the grant was locked
+	locked := *grant
+	locked.RecognizedAmount = 3000000
+	locked.RecognizedThrough = &periodEnd
+	repo.On("ListForRecognition", mock.Anything, (*uuid.UUID)(nil), periodEnd).Return([]domain.Grant{*grant}, nil)
+	repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("GetByIDForUpdate", mock.Anything, grant.CompanyID, grant.ID).Return(&locked, nil).Once()
+
+	run, err := svc.RecognizeIncome(context.Background(), nil, periodEnd)
+	require.NoError(t, err)
+	assert.Equal(t, 1, run.Checked)
+	assert.Equal(t, 0, run.Recognized)
+	repo.AssertNotCalled(t, "RecordRecognition", mock.Anything, mock.Anything, mock.Anything)
+	voucherService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
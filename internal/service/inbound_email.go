@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// inboundEmailMaxDepth bounds the nesting of multipart bodies and forwarded messages
+const inboundEmailMaxDepth = 8
+
+// inboundEmail is the part of a received email used by the inbox
+type inboundEmail struct {
+	MessageID   string
+	From        string
+	Subject     string
+	Date        time.Time
+	Recipients  []string // To, Cc and delivery headers, lower case
+	Attachments []inboundAttachment
+}
+
+// inboundAttachment is an invoice file attached to an email
+type inboundAttachment struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// wordDecoder decodes RFC 2047 headers, including the Korean charsets
+// (EUC-KR, ks_c_5601-1987) common in mail from domestic suppliers
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// parseInboundEmail parses a raw RFC 5322 message and collects its PDF and XML
+// attachments, including those of forwarded messages
+func parseInboundEmail(raw []byte) (*inboundEmail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInboundEmail, err)
+	}
+
+	email := &inboundEmail{
+		MessageID: domain.NormalizeMessageID(msg.Header.Get("Message-ID")),
+		Subject:   decodeHeader(msg.Header.Get("Subject")),
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		email.Date = date
+	}
+
+	parser := &mail.AddressParser{WordDecoder: wordDecoder}
+	if from, err := parser.Parse(msg.Header.Get("From")); err == nil {
+		email.From = from.Address
+	} else {
+		email.From = decodeHeader(msg.Header.Get("From"))
+	}
+	for _, name := range []string{"To", "Cc", "Delivered-To", "X-Original-To"} {
+		for _, value := range msg.Header[name] {
+			addresses, err := parser.ParseList(value)
+			if err != nil {
+				continue
+			}
+			for _, a := range addresses {
+				email.Recipients = append(email.Recipients, strings.ToLower(a.Address))
+			}
+		}
+	}
+
+	if err := email.walk(textproto.MIMEHeader(msg.Header), msg.Body, 0); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInboundEmail, err)
+	}
+	return email, nil
+}
+
+// walk collects the invoice attachments of a body part
+func (e *inboundEmail) walk(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > inboundEmailMaxDepth {
+		return fmt.Errorf("message nested too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := e.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+
+	case mediaType == "message/rfc822":
+		inner, err := mail.ReadMessage(transferDecoder(header, body))
+		if err != nil {
+			return nil // a broken forwarded message is skipped, not the email
+		}
+		return e.walk(textproto.MIMEHeader(inner.Header), inner.Body, depth+1)
+	}
+
+	fileName := ""
+	if _, disposition, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		fileName = disposition["filename"]
+	}
+	if fileName == "" {
+		fileName = params["name"]
+	}
+	fileName = decodeHeader(fileName)
+	if fileName == "" {
+		return nil // message text
+	}
+	contentType := domain.InvoiceFileContentType(fileName, mediaType)
+	if contentType == "" {
+		return nil
+	}
+
+	data, err := io.ReadAll(transferDecoder(header, body))
+	if err != nil {
+		return fmt.Errorf("attachment %s: %w", fileName, err)
+	}
+	e.Attachments = append(e.Attachments, inboundAttachment{FileName: fileName, ContentType: contentType, Data: data})
+	return nil
+}
+
+// transferDecoder undoes the Content-Transfer-Encoding of a part
+func transferDecoder(header textproto.MIMEHeader, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// decodeHeader decodes RFC 2047 encoded words, keeping the value if it cannot be decoded
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// SNS message types of the x-amz-sns-message-type header
+const (
+	snsSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsNotification             = "Notification"
+)
+
+// snsMessage is an Amazon SNS message delivered over HTTP
+type snsMessage struct {
+	Type         string `json:"Type"`
+	MessageID    string `json:"MessageId"`
+	SubscribeURL string `json:"SubscribeURL"`
+	Message      string `json:"Message"`
+}
+
+// sesReceivedEmail is the SES notification of a received email, published to
+// SNS by a receipt rule with the email content included
+type sesReceivedEmail struct {
+	NotificationType string `json:"notificationType"`
+	Receipt          struct {
+		Recipients []string `json:"recipients"`
+	} `json:"receipt"`
+	Content string `json:"content"`
+}
+
+// parseSESNotification returns the raw email and envelope recipients of an SES
+// notification. The content is base64 or UTF-8, depending on the encoding of
+// the SNS action; a raw message is never valid base64.
+func parseSESNotification(message string) ([]byte, []string, error) {
+	var notification sesReceivedEmail
+	if err := json.Unmarshal([]byte(message), &notification); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", domain.ErrInvalidInboundEmail, err)
+	}
+	if notification.NotificationType != "Received" || notification.Content == "" {
+		return nil, nil, fmt.Errorf("%w: not a received email with content", domain.ErrInvalidInboundEmail)
+	}
+
+	raw := []byte(notification.Content)
+	if decoded, err := base64.StdEncoding.DecodeString(notification.Content); err == nil {
+		raw = decoded
+	}
+	recipients := make([]string, len(notification.Receipt.Recipients))
+	for i, r := range notification.Receipt.Recipients {
+		recipients[i] = strings.ToLower(r)
+	}
+	return raw, recipients, nil
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// InboxOptions configures the inbound email inbox
+type InboxOptions struct {
+	Domain            string // mail domain of the alias addresses; the inbox is disabled when empty
+	WebhookSecret     string // token of the inbound webhook; the webhook is disabled when empty
+	MaxAttachmentSize int64  // larger attachments are skipped
+}
+
+// InboxReceipt summarizes what was taken from an inbound email
+type InboxReceipt struct {
+	Companies int  `json:"companies"` // companies the email was addressed to
+	Items     int  `json:"items"`     // documents queued
+	Skipped   int  `json:"skipped"`   // invoice attachments over the size limit
+	Duplicate bool `json:"duplicate"` // the email was received before
+}
+
+// InboxService defines the interface for the inbound email inbox. Suppliers
+// email invoices to the company's alias address; their PDF and XML attachments
+// are stored as documents and queued for clerks to convert into vouchers.
+type InboxService interface {
+	// Receive takes the invoice attachments of a raw email for the companies it
+	// is addressed to. envelopeRecipients are added to the header recipients,
+	// so that Bcc'd aliases are found.
+	Receive(ctx context.Context, token string, raw []byte, envelopeRecipients []string) (*InboxReceipt, error)
+
+	// ReceiveSNS handles an Amazon SNS message of an SES receipt rule. A
+	// subscription confirmation is confirmed; other messages return a nil receipt.
+	ReceiveSNS(ctx context.Context, token string, body []byte) (*InboxReceipt, error)
+
+	// Address returns the company's inbound address, creating the alias on first use
+	Address(ctx context.Context, companyID uuid.UUID) (string, error)
+	// RotateAddress replaces the alias, e.g. when the address attracts spam
+	RotateAddress(ctx context.Context, companyID uuid.UUID) (string, error)
+
+	List(ctx context.Context, filter repository.InboxItemFilter) ([]domain.InboxItem, int64, error)
+	Get(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxItem, error)
+	// Document returns the document of an item with its content
+	Document(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxDocument, error)
+
+	// Convert attaches the document to the voucher the clerk created from it and
+	// closes the item. The file is scanned like any uploaded attachment.
+	Convert(ctx context.Context, companyID, userID, id, voucherID uuid.UUID, ipAddress string) (*domain.InboxItem, error)
+	Discard(ctx context.Context, companyID, userID, id uuid.UUID, note string) (*domain.InboxItem, error)
+}
+
+// inboxService implements InboxService
+type inboxService struct {
+	repo        repository.InboxRepository
+	attachments VoucherAttachmentService
+	opts        InboxOptions
+	httpClient  *http.Client
+}
+
+// NewInboxService creates a new InboxService
+func NewInboxService(repo repository.InboxRepository, attachments VoucherAttachmentService, opts InboxOptions) InboxService {
+	opts.Domain = strings.ToLower(strings.TrimSpace(opts.Domain))
+	return &inboxService{
+		repo:        repo,
+		attachments: attachments,
+		opts:        opts,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Receive queues the invoice attachments of the email
+func (s *inboxService) Receive(ctx context.Context, token string, raw []byte, envelopeRecipients []string) (*InboxReceipt, error) {
+	if err := s.authorize(token); err != nil {
+		return nil, err
+	}
+
+	email, err := parseInboundEmail(raw)
+	if err != nil {
+		return nil, err
+	}
+	companies, err := s.recipientCompanies(ctx, append(email.Recipients, envelopeRecipients...))
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &InboxReceipt{Companies: len(companies)}
+	receivedAt := time.Now()
+	for _, companyID := range companies {
+		if email.MessageID != "" {
+			exists, err := s.repo.ExistsMessage(ctx, companyID, email.MessageID)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				receipt.Duplicate = true
+				continue
+			}
+		}
+
+		for _, attachment := range email.Attachments {
+			if s.opts.MaxAttachmentSize > 0 && int64(len(attachment.Data)) > s.opts.MaxAttachmentSize {
+				receipt.Skipped++
+				continue
+			}
+			sum := sha256.Sum256(attachment.Data)
+			item := &domain.InboxItem{
+				TenantModel: domain.TenantModel{CompanyID: companyID},
+				MessageID:   email.MessageID,
+				Sender:      truncateRunes(email.From, 255),
+				Subject:     truncateRunes(email.Subject, 500),
+				ReceivedAt:  receivedAt,
+				Status:      domain.InboxItemPending,
+				Document: &domain.InboxDocument{
+					CompanyID:   companyID,
+					FileName:    truncateRunes(attachment.FileName, 255),
+					ContentType: attachment.ContentType,
+					FileSize:    int64(len(attachment.Data)),
+					SHA256:      hex.EncodeToString(sum[:]),
+					Data:        attachment.Data,
+				},
+			}
+			if err := s.repo.CreateItem(ctx, item); err != nil {
+				return nil, err
+			}
+			receipt.Items++
+		}
+	}
+	return receipt, nil
+}
+
+// ReceiveSNS unwraps the email of an SES notification
+func (s *inboxService) ReceiveSNS(ctx context.Context, token string, body []byte) (*InboxReceipt, error) {
+	if err := s.authorize(token); err != nil {
+		return nil, err
+	}
+
+	var msg snsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInboundEmail, err)
+	}
+	switch msg.Type {
+	case snsSubscriptionConfirmation:
+		return nil, s.confirmSubscription(ctx, msg.SubscribeURL)
+	case snsNotification:
+		raw, recipients, err := parseSESNotification(msg.Message)
+		if err != nil {
+			return nil, err
+		}
+		return s.Receive(ctx, token, raw, recipients)
+	}
+	return nil, nil
+}
+
+// confirmSubscription visits the confirmation link of an SNS subscription.
+// Only AWS links are followed, the token having been checked already.
+func (s *inboxService) confirmSubscription(ctx context.Context, subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+		return fmt.Errorf("%w: invalid subscription confirmation URL", domain.ErrInvalidInboundEmail)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("confirm SNS subscription: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("confirm SNS subscription: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// authorize checks the webhook token
+func (s *inboxService) authorize(token string) error {
+	if s.opts.Domain == "" || s.opts.WebhookSecret == "" {
+		return domain.ErrInboxWebhookDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.WebhookSecret)) != 1 {
+		return domain.ErrInvalidInboxToken
+	}
+	return nil
+}
+
+// recipientCompanies returns the companies of the alias addresses among the
+// recipients. A "+tag" after the alias is ignored, as mail servers do.
+func (s *inboxService) recipientCompanies(ctx context.Context, recipients []string) ([]uuid.UUID, error) {
+	var companies []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, recipient := range recipients {
+		at := strings.LastIndexByte(recipient, '@')
+		if at < 0 || !strings.EqualFold(recipient[at+1:], s.opts.Domain) {
+			continue
+		}
+		local := strings.ToLower(recipient[:at])
+		if plus := strings.IndexByte(local, '+'); plus >= 0 {
+			local = local[:plus]
+		}
+
+		alias, err := s.repo.FindAlias(ctx, local)
+		if errors.Is(err, domain.ErrInboxAliasNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !seen[alias.CompanyID] {
+			seen[alias.CompanyID] = true
+			companies = append(companies, alias.CompanyID)
+		}
+	}
+	return companies, nil
+}
+
+// Address returns the inbound address of the company
+func (s *inboxService) Address(ctx context.Context, companyID uuid.UUID) (string, error) {
+	if s.opts.Domain == "" {
+		return "", domain.ErrInboxWebhookDisabled
+	}
+	alias, err := s.repo.GetAlias(ctx, companyID)
+	if errors.Is(err, domain.ErrInboxAliasNotFound) {
+		return s.RotateAddress(ctx, companyID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return alias.Address(s.opts.Domain), nil
+}
+
+// RotateAddress gives the company a new alias; mail to the old one is no longer taken
+func (s *inboxService) RotateAddress(ctx context.Context, companyID uuid.UUID) (string, error) {
+	if s.opts.Domain == "" {
+		return "", domain.ErrInboxWebhookDisabled
+	}
+	alias, err := domain.NewInboxAlias(companyID)
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.SaveAlias(ctx, alias); err != nil {
+		return "", err
+	}
+	return alias.Address(s.opts.Domain), nil
+}
+
+// List lists the inbox items
+func (s *inboxService) List(ctx context.Context, filter repository.InboxItemFilter) ([]domain.InboxItem, int64, error) {
+	return s.repo.ListItems(ctx, filter)
+}
+
+// Get returns an inbox item
+func (s *inboxService) Get(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxItem, error) {
+	return s.repo.GetItem(ctx, companyID, id)
+}
+
+// Document returns the document of an inbox item
+func (s *inboxService) Document(ctx context.Context, companyID, id uuid.UUID) (*domain.InboxDocument, error) {
+	item, err := s.repo.GetItem(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetDocument(ctx, companyID, item.DocumentID)
+}
+
+// Convert attaches the document to the voucher and marks the item processed
+func (s *inboxService) Convert(ctx context.Context, companyID, userID, id, voucherID uuid.UUID, ipAddress string) (*domain.InboxItem, error) {
+	item, err := s.repo.GetItem(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != domain.InboxItemPending {
+		return nil, domain.ErrInboxItemClosed
+	}
+	document, err := s.repo.GetDocument(ctx, companyID, item.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.attachments.Upload(ctx, &AttachmentUpload{
+		CompanyID:   companyID,
+		VoucherID:   voucherID,
+		UserID:      userID,
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Data:        document.Data,
+		IPAddress:   ipAddress,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := item.Process(voucherID, userID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateItemStatus(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Discard closes the item without voucher
+func (s *inboxService) Discard(ctx context.Context, companyID, userID, id uuid.UUID, note string) (*domain.InboxItem, error) {
+	item, err := s.repo.GetItem(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := item.Discard(note, userID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateItemStatus(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
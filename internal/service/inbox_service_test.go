@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakeAttachmentService records the files it is asked to attach
+type fakeAttachmentService struct {
+	service.VoucherAttachmentService
+
+	uploads []*service.AttachmentUpload
+	err     error
+}
+
+func (f *fakeAttachmentService) Upload(ctx context.Context, upload *service.AttachmentUpload) (*domain.VoucherAttachment, error) {
+	f.uploads = append(f.uploads, upload)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.VoucherAttachment{ID: uuid.New(), VoucherID: upload.VoucherID}, nil
+}
+
+const inboxTestSecret = "inbox-secret"
+
+func newTestInboxService(repo *mocks.MockInboxRepository, attachments service.VoucherAttachmentService) service.InboxService {
+	return service.NewInboxService(repo, attachments, service.InboxOptions{
+		Domain:            "Inbox.Example.com",
+		WebhookSecret:     inboxTestSecret,
+		MaxAttachmentSize: 1024,
+	})
+}
+
+// inboxTestEmail is a supplier email with a PDF invoice under an EUC-KR file
+// name, a forwarded message carrying an XML invoice, and a signature image
+const inboxTestEmail = "From: =?UTF-8?B?6rO16riJ7J6Q?= <billing@supplier.example>\r\n" +
+	"To: k7q2m9xw4dab+invoices@inbox.example.com\r\n" +
+	"Cc: accounting@customer.example\r\n" +
+	"Subject: =?UTF-8?B?7IS46riI6rOE7IKw7IScIOuwnOq4iQ==?=\r\n" +
+	"Message-ID: <abc123@supplier.example>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Please find the invoice attached.\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/octet-stream\r\n" +
+	"Content-Disposition: attachment; filename=\"=?euc-kr?B?vLyx3bDou+q8rS5wZGY=?=\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0xLjQKJSBpbnZvaWNl\r\n" +
+	"--outer\r\n" +
+	"Content-Type: image/png; name=\"logo.png\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw0KGgo=\r\n" +
+	"--outer\r\n" +
+	"Content-Type: message/rfc822\r\n" +
+	"\r\n" +
+	"From: other@supplier.example\r\n" +
+	"Subject: Fwd\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/xml; name=\"invoice.xml\"\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"<TaxInvoice id=3D\"1\"/>\r\n" +
+	"--inner--\r\n" +
+	"--outer--\r\n"
+
+func TestInboxService_Receive(t *testing.T) {
+	repo := new(mocks.MockInboxRepository)
+	svc := newTestInboxService(repo, &fakeAttachmentService{})
+
+	companyID := newTestCompanyID()
+	repo.On("FindAlias", mock.Anything, "k7q2m9xw4dab").Return(&domain.InboxAlias{CompanyID: companyID, Alias: "k7q2m9xw4dab"}, nil)
+	repo.On("ExistsMessage", mock.Anything, companyID, "<abc123@supplier.example>").Return(false, nil).Once()
+	var items []*domain.InboxItem
+	repo.On("CreateItem", mock.Anything, mock.AnythingOfType("*domain.InboxItem")).
+		Run(func(args mock.Arguments) { items = append(items, args.Get(1).(*domain.InboxItem)) }).
+		Return(nil)
+
+	receipt, err := svc.Receive(context.Background(), inboxTestSecret, []byte(inboxTestEmail), nil)
+	require.NoError(t, err)
+	assert.Equal(t, &service.InboxReceipt{Companies: 1, Items: 2}, receipt)
+
+	require.Len(t, items, 2)
+	pdf, xml := items[0], items[1]
+	assert.Equal(t, companyID, pdf.CompanyID)
+	assert.Equal(t, domain.InboxItemPending, pdf.Status)
+	assert.Equal(t, "billing@supplier.example", pdf.Sender)
+	assert.Equal(t, "세금계산서 발급", pdf.Subject)
+	assert.Equal(t, "세금계산서.pdf", pdf.Document.FileName)
+	assert.Equal(t, "application/pdf", pdf.Document.ContentType)
+	assert.Equal(t, "%PDF-1.4\n% invoice", string(pdf.Document.Data))
+	assert.Len(t, pdf.Document.SHA256, 64)
+
+	assert.Equal(t, "invoice.xml", xml.Document.FileName)
+	assert.Equal(t, "application/xml", xml.Document.ContentType)
+	assert.Equal(t, `<TaxInvoice id="1"/>`, strings.TrimSpace(string(xml.Document.Data)))
+
+	// The same email delivered again is not queued twice
+	repo.On("ExistsMessage", mock.Anything, companyID, "<abc123@supplier.example>").Return(true, nil).Once()
+	receipt, err = svc.Receive(context.Background(), inboxTestSecret, []byte(inboxTestEmail), nil)
+	require.NoError(t, err)
+	assert.Equal(t, &service.InboxReceipt{Companies: 1, Duplicate: true}, receipt)
+	repo.AssertNumberOfCalls(t, "CreateItem", 2)
+}
+
+func TestInboxService_ReceiveRejectsToken(t *testing.T) {
+	repo := new(mocks.MockInboxRepository)
+	svc := newTestInboxService(repo, &fakeAttachmentService{})
+
+	_, err := svc.Receive(context.Background(), "wrong", []byte(inboxTestEmail), nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidInboxToken)
+
+	disabled := service.NewInboxService(repo, &fakeAttachmentService{}, service.InboxOptions{Domain: "inbox.example.com"})
+	_, err = disabled.Receive(context.Background(), "", []byte(inboxTestEmail), nil)
+	assert.ErrorIs(t, err, domain.ErrInboxWebhookDisabled)
+	repo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+}
+
+func TestInboxService_ReceiveSNS(t *testing.T) {
+	repo := new(mocks.MockInboxRepository)
+	svc := newTestInboxService(repo, &fakeAttachmentService{})
+
+	// The alias is only in the envelope, as for a Bcc
+	raw := strings.Replace(inboxTestEmail, "k7q2m9xw4dab+invoices@inbox.example.com", "someone@customer.example", 1)
+	notification, _ := json.Marshal(map[string]interface{}{
+		"notificationType": "Received",
+		"receipt":          map[string]interface{}{"recipients": []string{"K7Q2M9XW4DAB@inbox.example.com"}},
+		"content":          base64.StdEncoding.EncodeToString([]byte(raw)),
+	})
+	body, _ := json.Marshal(map[string]string{"Type": "Notification", "MessageId": "sns-1", "Message": string(notification)})
+
+	companyID := newTestCompanyID()
+	repo.On("FindAlias", mock.Anything, "k7q2m9xw4dab").Return(&domain.InboxAlias{CompanyID: companyID, Alias: "k7q2m9xw4dab"}, nil)
+	repo.On("ExistsMessage", mock.Anything, companyID, "<abc123@supplier.example>").Return(false, nil)
+	repo.On("CreateItem", mock.Anything, mock.AnythingOfType("*domain.InboxItem")).Return(nil)
+
+	receipt, err := svc.ReceiveSNS(context.Background(), inboxTestSecret, body)
+	require.NoError(t, err)
+	assert.Equal(t, 2, receipt.Items)
+
+	// Subscription confirmations only follow AWS links
+	confirm, _ := json.Marshal(map[string]string{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://attacker.example/confirm"})
+	_, err = svc.ReceiveSNS(context.Background(), inboxTestSecret, confirm)
+	assert.ErrorIs(t, err, domain.ErrInvalidInboundEmail)
+}
+
+func TestInboxService_Convert(t *testing.T) {
+	repo := new(mocks.MockInboxRepository)
+	attachments := &fakeAttachmentService{}
+	svc := newTestInboxService(repo, attachments)
+
+	companyID, userID, voucherID := newTestCompanyID(), newTestUserID(), uuid.New()
+	item := &domain.InboxItem{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		DocumentID:  uuid.New(),
+		Status:      domain.InboxItemPending,
+	}
+	document := &domain.InboxDocument{ID: item.DocumentID, CompanyID: companyID, FileName: "invoice.pdf", ContentType: "application/pdf", Data: []byte("%PDF")}
+	repo.On("GetItem", mock.Anything, companyID, item.ID).Return(item, nil)
+	repo.On("GetDocument", mock.Anything, companyID, item.DocumentID).Return(document, nil)
+	repo.On("UpdateItemStatus", mock.Anything, item).Return(nil)
+
+	converted, err := svc.Convert(context.Background(), companyID, userID, item.ID, voucherID, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.InboxItemProcessed, converted.Status)
+	assert.Equal(t, voucherID, *converted.VoucherID)
+	assert.Equal(t, userID, *converted.ProcessedBy)
+
+	require.Len(t, attachments.uploads, 1)
+	assert.Equal(t, voucherID, attachments.uploads[0].VoucherID)
+	assert.Equal(t, "invoice.pdf", attachments.uploads[0].FileName)
+	assert.Equal(t, []byte("%PDF"), attachments.uploads[0].Data)
+
+	// A closed item cannot be converted again
+	_, err = svc.Convert(context.Background(), companyID, userID, item.ID, voucherID, "127.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrInboxItemClosed)
+	assert.Len(t, attachments.uploads, 1)
+}
+
+func TestInboxService_AttachmentFailureKeepsItemPending(t *testing.T) {
+	repo := new(mocks.MockInboxRepository)
+	attachments := &fakeAttachmentService{err: domain.ErrAttachmentInfected}
+	svc := newTestInboxService(repo, attachments)
+
+	companyID := newTestCompanyID()
+	item := &domain.InboxItem{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		DocumentID:  uuid.New(),
+		Status:      domain.InboxItemPending,
+	}
+	repo.On("GetItem", mock.Anything, companyID, item.ID).Return(item, nil)
+	repo.On("GetDocument", mock.Anything, companyID, item.DocumentID).Return(&domain.InboxDocument{ID: item.DocumentID, Data: []byte("x")}, nil)
+
+	_, err := svc.Convert(context.Background(), companyID, newTestUserID(), item.ID, uuid.New(), "")
+	assert.ErrorIs(t, err, domain.ErrAttachmentInfected)
+	assert.Equal(t, domain.InboxItemPending, item.Status)
+	repo.AssertNotCalled(t, "UpdateItemStatus", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/secrets"
+)
+
+// IntegrationCredentialService defines the interface for the credentials
+// companies register for external services. Credentials are envelope encrypted
+// before they are stored and only decrypted when a provider is called.
+type IntegrationCredentialService interface {
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.IntegrationCredential, error)
+	SavePopbill(ctx context.Context, companyID, userID uuid.UUID, creds *domain.PopbillCredentials) (*domain.IntegrationCredential, error)
+	SaveBank(ctx context.Context, companyID, userID uuid.UUID, creds *domain.BankCredentials) (*domain.IntegrationCredential, error)
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Test checks a stored credential against its provider and records the result
+	Test(ctx context.Context, companyID, id uuid.UUID) (*domain.IntegrationCredential, error)
+	// TestPopbill checks Popbill credentials without storing them
+	TestPopbill(ctx context.Context, creds *domain.PopbillCredentials) error
+
+	// PopbillConfig returns the decrypted Popbill credentials of a company;
+	// it implements popbill.CredentialSource
+	PopbillConfig(ctx context.Context, companyID uuid.UUID) (*popbill.Config, error)
+}
+
+// integrationCredentialService implements IntegrationCredentialService
+type integrationCredentialService struct {
+	repo           repository.IntegrationCredentialRepository
+	keys           secrets.KeyManager
+	popbillOptions *popbill.ClientOptions
+	testTimeout    time.Duration
+}
+
+// NewIntegrationCredentialService creates a new IntegrationCredentialService.
+// keys may be nil, in which case credentials can be neither stored nor read.
+// Credential tests use popbillOptions like any other Popbill call.
+func NewIntegrationCredentialService(repo repository.IntegrationCredentialRepository, keys secrets.KeyManager, popbillOptions *popbill.ClientOptions, testTimeout time.Duration) IntegrationCredentialService {
+	return &integrationCredentialService{repo: repo, keys: keys, popbillOptions: popbillOptions, testTimeout: testTimeout}
+}
+
+// List returns the credentials of a company without their secrets
+func (s *integrationCredentialService) List(ctx context.Context, companyID uuid.UUID) ([]domain.IntegrationCredential, error) {
+	return s.repo.FindAll(ctx, companyID)
+}
+
+// SavePopbill stores the Popbill credentials of a company, replacing previous ones
+func (s *integrationCredentialService) SavePopbill(ctx context.Context, companyID, userID uuid.UUID, creds *domain.PopbillCredentials) (*domain.IntegrationCredential, error) {
+	creds.Normalize()
+	if err := creds.Validate(); err != nil {
+		return nil, err
+	}
+	return s.save(ctx, companyID, userID, domain.IntegrationProviderPopbill, creds.Identifier(), creds.Hint(), creds)
+}
+
+// SaveBank stores the credentials of a bank account, replacing previous ones of the account
+func (s *integrationCredentialService) SaveBank(ctx context.Context, companyID, userID uuid.UUID, creds *domain.BankCredentials) (*domain.IntegrationCredential, error) {
+	creds.Normalize()
+	if err := creds.Validate(); err != nil {
+		return nil, err
+	}
+	return s.save(ctx, companyID, userID, domain.IntegrationProviderBank, creds.Identifier(), creds.Hint(), creds)
+}
+
+// Delete removes a credential
+func (s *integrationCredentialService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.repo.Delete(ctx, companyID, id)
+}
+
+// Test checks a stored credential. Popbill credentials are used to read the
+// point balance; bank credentials are checked for completeness, as no bank
+// client exists yet. A failed check is recorded rather than returned.
+func (s *integrationCredentialService) Test(ctx context.Context, companyID, id uuid.UUID) (*domain.IntegrationCredential, error) {
+	credential, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var testErr error
+	switch credential.Provider {
+	case domain.IntegrationProviderPopbill:
+		var creds domain.PopbillCredentials
+		if err := s.open(ctx, credential, &creds); err != nil {
+			return nil, err
+		}
+		testErr = s.TestPopbill(ctx, &creds)
+	case domain.IntegrationProviderBank:
+		var creds domain.BankCredentials
+		if err := s.open(ctx, credential, &creds); err != nil {
+			return nil, err
+		}
+		testErr = creds.Validate()
+	}
+
+	credential.RecordTest(testErr, time.Now())
+	if err := s.repo.UpdateTestResult(ctx, credential); err != nil {
+		return nil, err
+	}
+	credential.EncryptedKey, credential.Ciphertext = nil, nil
+	return credential, nil
+}
+
+// TestPopbill obtains an access token and reads the point balance with the credentials
+func (s *integrationCredentialService) TestPopbill(ctx context.Context, creds *domain.PopbillCredentials) error {
+	creds.Normalize()
+	if err := creds.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.testTimeout)
+	defer cancel()
+
+	client := popbill.NewClientWithOptions(popbillConfig(creds, s.testTimeout), s.popbillOptions)
+	if _, err := client.GetBalance(ctx); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrCredentialTestFailed, err)
+	}
+	return nil
+}
+
+// PopbillConfig returns the decrypted Popbill credentials of a company
+func (s *integrationCredentialService) PopbillConfig(ctx context.Context, companyID uuid.UUID) (*popbill.Config, error) {
+	var creds domain.PopbillCredentials
+	credential, err := s.repo.FindByIdentifier(ctx, companyID, domain.IntegrationProviderPopbill, creds.Identifier())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.open(ctx, credential, &creds); err != nil {
+		return nil, err
+	}
+	return popbillConfig(&creds, 0), nil
+}
+
+// save encrypts and stores credentials
+func (s *integrationCredentialService) save(ctx context.Context, companyID, userID uuid.UUID, provider domain.IntegrationProvider, identifier, hint string, creds interface{}) (*domain.IntegrationCredential, error) {
+	if s.keys == nil {
+		return nil, domain.ErrCredentialEncryptionDisabled
+	}
+
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &domain.IntegrationCredential{
+		TenantModel: domain.TenantModel{CompanyID: companyID},
+		Provider:    provider,
+		Identifier:  identifier,
+		Hint:        hint,
+		IsActive:    true,
+		UpdatedBy:   &userID,
+	}
+	sealed, err := secrets.Seal(ctx, s.keys, plaintext, credential.AAD())
+	if err != nil {
+		return nil, err
+	}
+	credential.KeyID, credential.EncryptedKey, credential.Ciphertext = sealed.KeyID, sealed.EncryptedKey, sealed.Ciphertext
+
+	if err := s.repo.Save(ctx, credential); err != nil {
+		return nil, err
+	}
+	credential.EncryptedKey, credential.Ciphertext = nil, nil
+	return credential, nil
+}
+
+// open decrypts the credentials of a stored row into creds
+func (s *integrationCredentialService) open(ctx context.Context, credential *domain.IntegrationCredential, creds interface{}) error {
+	if s.keys == nil {
+		return domain.ErrCredentialEncryptionDisabled
+	}
+
+	plaintext, err := secrets.Open(ctx, s.keys, &secrets.Sealed{
+		KeyID:        credential.KeyID,
+		EncryptedKey: credential.EncryptedKey,
+		Ciphertext:   credential.Ciphertext,
+	}, credential.AAD())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s credential %s: %w", credential.Provider, credential.ID, err)
+	}
+	return json.Unmarshal(plaintext, creds)
+}
+
+func popbillConfig(creds *domain.PopbillCredentials, timeout time.Duration) *popbill.Config {
+	return &popbill.Config{
+		LinkID:    creds.LinkID,
+		SecretKey: creds.SecretKey,
+		IsSandbox: creds.IsSandbox,
+		CorpNum:   creds.CorpNum,
+		UserID:    creds.UserID,
+		Timeout:   timeout,
+	}
+}
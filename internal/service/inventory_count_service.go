@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// inventoryCountReferenceType marks the adjustment vouchers of inventory counts
+const inventoryCountReferenceType = "inventory_count"
+
+// InventoryItemInput describes an inventory item
+type InventoryItemInput struct {
+	Code      string
+	Name      string
+	Unit      string
+	AccountID uuid.UUID
+	UnitCost  int64
+	Quantity  float64 // opening quantity on hand, taken on creation only
+	IsActive  *bool
+}
+
+// InventoryCountInput describes a new inventory count
+type InventoryCountInput struct {
+	CountDate     time.Time
+	Description   string
+	AccountID     *uuid.UUID // items of one inventory account, all when nil
+	LossAccountID uuid.UUID
+	GainAccountID uuid.UUID
+}
+
+// InventoryCountService defines the interface for inventory items and
+// stocktakes (재고실사)
+type InventoryCountService interface {
+	// Items
+	CreateItem(ctx context.Context, companyID, userID uuid.UUID, input InventoryItemInput) (*domain.InventoryItem, error)
+	UpdateItem(ctx context.Context, companyID, id uuid.UUID, input InventoryItemInput) (*domain.InventoryItem, error)
+	GetItem(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryItem, error)
+	ListItems(ctx context.Context, filter repository.InventoryItemFilter) ([]domain.InventoryItem, int64, error)
+
+	// Open starts a count of the active items, snapshotting their quantities
+	// on hand. A company has one count in progress at a time.
+	Open(ctx context.Context, companyID, userID uuid.UUID, input InventoryCountInput) (*domain.InventoryCount, error)
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error)
+	List(ctx context.Context, filter repository.InventoryCountFilter) ([]domain.InventoryCount, int64, error)
+	// Summary totals the variances of the counted items
+	Summary(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCountSummary, error)
+
+	// RecordCounts records counted quantities by item code
+	RecordCounts(ctx context.Context, companyID, userID, id uuid.UUID, entries []domain.InventoryCountEntry) (*domain.InventoryCount, error)
+	// ImportCountSheet records the counted quantities of a CSV or tab
+	// separated count sheet with item code and counted quantity columns
+	ImportCountSheet(ctx context.Context, companyID, userID, id uuid.UUID, data []byte) (*domain.InventoryCount, error)
+
+	// Submit closes the counting for approval
+	Submit(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.InventoryCount, error)
+	// Reopen returns a submitted count to counting for a recount
+	Reopen(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error)
+	// Approve posts the adjustment voucher of the variances at the count date
+	// and sets the quantities on hand to the counted ones. The approver may
+	// approve and did not submit the count.
+	Approve(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.InventoryCount, error)
+	Cancel(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error)
+}
+
+// inventoryCountService implements InventoryCountService
+type inventoryCountService struct {
+	repo           repository.InventoryCountRepository
+	accountRepo    repository.AccountRepository
+	userRepo       repository.UserRepository
+	voucherService VoucherService
+}
+
+// NewInventoryCountService creates a new InventoryCountService
+func NewInventoryCountService(
+	repo repository.InventoryCountRepository,
+	accountRepo repository.AccountRepository,
+	userRepo repository.UserRepository,
+	voucherService VoucherService,
+) InventoryCountService {
+	return &inventoryCountService{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		userRepo:       userRepo,
+		voucherService: voucherService,
+	}
+}
+
+// CreateItem registers an item with its opening quantity on hand
+func (s *inventoryCountService) CreateItem(ctx context.Context, companyID, userID uuid.UUID, input InventoryItemInput) (*domain.InventoryItem, error) {
+	item := &domain.InventoryItem{
+		TenantModel: domain.TenantModel{CompanyID: companyID},
+		Quantity:    input.Quantity,
+		IsActive:    true,
+		CreatedBy:   &userID,
+	}
+	if err := s.applyItem(ctx, item, input); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem changes the master data of an item
+func (s *inventoryCountService) UpdateItem(ctx context.Context, companyID, id uuid.UUID, input InventoryItemInput) (*domain.InventoryItem, error) {
+	item, err := s.repo.FindItemByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyItem(ctx, item, input); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// applyItem sets the master data of the input on the item and checks it,
+// its code and its inventory account
+func (s *inventoryCountService) applyItem(ctx context.Context, item *domain.InventoryItem, input InventoryItemInput) error {
+	item.Code = input.Code
+	item.Name = input.Name
+	item.Unit = input.Unit
+	item.AccountID = input.AccountID
+	item.UnitCost = input.UnitCost
+	if input.IsActive != nil {
+		item.IsActive = *input.IsActive
+	}
+	if err := item.Validate(); err != nil {
+		return err
+	}
+
+	var excludeID *uuid.UUID
+	if item.ID != uuid.Nil {
+		excludeID = &item.ID
+	}
+	exists, err := s.repo.ExistsItemCode(ctx, item.CompanyID, item.Code, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrInventoryItemCodeExists
+	}
+	return s.checkAccount(ctx, item.CompanyID, item.AccountID, domain.ErrInvalidInventoryItem, domain.AccountTypeAsset)
+}
+
+// GetItem returns an item
+func (s *inventoryCountService) GetItem(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryItem, error) {
+	return s.repo.FindItemByID(ctx, companyID, id)
+}
+
+// ListItems lists the items of the company in code order
+func (s *inventoryCountService) ListItems(ctx context.Context, filter repository.InventoryItemFilter) ([]domain.InventoryItem, int64, error) {
+	return s.repo.ListItems(ctx, filter)
+}
+
+// Open snapshots the active items of the count's inventory account, or of all
+func (s *inventoryCountService) Open(ctx context.Context, companyID, userID uuid.UUID, input InventoryCountInput) (*domain.InventoryCount, error) {
+	inProgress, err := s.repo.ExistsInProgress(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if inProgress {
+		return nil, domain.ErrInventoryCountInProgress
+	}
+	if input.AccountID != nil {
+		if err := s.checkAccount(ctx, companyID, *input.AccountID, domain.ErrInvalidInventoryCount, domain.AccountTypeAsset); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkAccount(ctx, companyID, input.LossAccountID, domain.ErrInvalidInventoryCount, domain.AccountTypeExpense); err != nil {
+		return nil, err
+	}
+	if err := s.checkAccount(ctx, companyID, input.GainAccountID, domain.ErrInvalidInventoryCount, domain.AccountTypeRevenue, domain.AccountTypeExpense); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListActiveItems(ctx, companyID, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	count := &domain.InventoryCount{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		CountDate:     input.CountDate,
+		Description:   input.Description,
+		AccountID:     input.AccountID,
+		LossAccountID: input.LossAccountID,
+		GainAccountID: input.GainAccountID,
+		CreatedBy:     &userID,
+	}
+	if err := count.Open(items); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, count); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// GetByID returns a count with its lines
+func (s *inventoryCountService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the counts of the company, latest first
+func (s *inventoryCountService) List(ctx context.Context, filter repository.InventoryCountFilter) ([]domain.InventoryCount, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Summary totals the variances of a count
+func (s *inventoryCountService) Summary(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCountSummary, error) {
+	count, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	return count.Summary(), nil
+}
+
+// RecordCounts stores the counted quantities of the entries
+func (s *inventoryCountService) RecordCounts(ctx context.Context, companyID, userID, id uuid.UUID, entries []domain.InventoryCountEntry) (*domain.InventoryCount, error) {
+	count, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := count.Record(entries, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RecordCounts(ctx, count, lines); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// ImportCountSheet parses the sheet and records its counted quantities
+func (s *inventoryCountService) ImportCountSheet(ctx context.Context, companyID, userID, id uuid.UUID, data []byte) (*domain.InventoryCount, error) {
+	entries, err := parseCountSheet(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.RecordCounts(ctx, companyID, userID, id, entries)
+}
+
+// Count sheet headers, in English or Korean
+var (
+	countSheetCodeHeaders     = []string{"item_code", "code", "품목코드", "코드"}
+	countSheetQuantityHeaders = []string{"counted_quantity", "quantity", "실사수량", "수량"}
+	countSheetNoteHeaders     = []string{"note", "비고"}
+)
+
+// parseCountSheet reads the entries of a count sheet whose first row is the
+// header. Rows without an item code are skipped; quantities may have
+// thousand separators.
+func parseCountSheet(data []byte) ([]domain.InventoryCountEntry, error) {
+	records, err := migrate.ReadRecords(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInventoryCount, err)
+	}
+	if len(records) == 0 {
+		return nil, domain.ErrInventoryCountSheetColumnMissing
+	}
+	column := func(names []string) int {
+		for i, header := range records[0] {
+			header = strings.ToLower(strings.TrimSpace(header))
+			for _, name := range names {
+				if header == name {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	codeCol, quantityCol, noteCol := column(countSheetCodeHeaders), column(countSheetQuantityHeaders), column(countSheetNoteHeaders)
+	if codeCol < 0 || quantityCol < 0 {
+		return nil, domain.ErrInventoryCountSheetColumnMissing
+	}
+
+	var entries []domain.InventoryCountEntry
+	for n, record := range records[1:] {
+		cell := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		code := cell(codeCol)
+		if code == "" {
+			continue
+		}
+		quantity, err := strconv.ParseFloat(strings.ReplaceAll(cell(quantityCol), ",", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: quantity %q", domain.ErrInvalidInventoryCount, n+2, cell(quantityCol))
+		}
+		entries = append(entries, domain.InventoryCountEntry{ItemCode: code, Quantity: quantity, Note: cell(noteCol)})
+	}
+	return entries, nil
+}
+
+// Submit closes the counting once every item is counted
+func (s *inventoryCountService) Submit(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.InventoryCount, error) {
+	count, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := count.Submit(userID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateStatus(ctx, count, domain.InventoryCountOpen); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// Reopen returns a submitted count to counting
+func (s *inventoryCountService) Reopen(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error) {
+	count, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := count.Reopen(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateStatus(ctx, count, domain.InventoryCountSubmitted); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// Approve posts the count and the quantities on hand, then the adjustment
+// voucher on behalf of the approver. The count is claimed first so that a
+// second approval fails before posting another voucher; it returns to
+// submitted when the voucher fails to post.
+func (s *inventoryCountService) Approve(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.InventoryCount, error) {
+	count, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if count.Status != domain.InventoryCountSubmitted {
+		return nil, domain.ErrInventoryCountNotSubmitted
+	}
+	approver, err := s.userRepo.FindMember(ctx, companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !approver.CanApprove() || (count.SubmittedBy != nil && *count.SubmittedBy == userID) {
+		return nil, domain.ErrInventoryCountApprovalForbidden
+	}
+
+	if err := count.Approve(userID, nil, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Post(ctx, count); err != nil {
+		return nil, err
+	}
+
+	voucher := s.adjustmentVoucher(count, userID)
+	if voucher == nil {
+		return count, nil
+	}
+	if err := s.voucherService.PostGenerated(ctx, voucher, userID); err != nil {
+		if undoErr := s.repo.Unpost(ctx, count); undoErr != nil {
+			return nil, errors.Join(err, undoErr)
+		}
+		return nil, err
+	}
+	count.VoucherID = &voucher.ID
+	if err := s.repo.UpdateStatus(ctx, count, domain.InventoryCountPosted); err != nil {
+		return nil, fmt.Errorf("adjustment voucher %s is posted but not linked to the count: %w", voucher.VoucherNo, err)
+	}
+	return count, nil
+}
+
+// adjustmentVoucher books the shortages of each inventory account to the loss
+// account and its overages to the gain account; nil when nothing differs
+func (s *inventoryCountService) adjustmentVoucher(count *domain.InventoryCount, userID uuid.UUID) *domain.Voucher {
+	description := fmt.Sprintf("재고실사 조정 %s", count.Description)
+	entry := func(accountID uuid.UUID) domain.VoucherEntry {
+		return domain.VoucherEntry{CompanyID: count.CompanyID, AccountID: accountID, Description: description}
+	}
+
+	var entries []domain.VoucherEntry
+	for _, variance := range count.Summary().ByAccount {
+		if variance.Shortage > 0 {
+			debit, credit := entry(count.LossAccountID), entry(variance.AccountID)
+			debit.SetDebit(float64(variance.Shortage))
+			credit.SetCredit(float64(variance.Shortage))
+			entries = append(entries, debit, credit)
+		}
+		if variance.Overage > 0 {
+			debit, credit := entry(variance.AccountID), entry(count.GainAccountID)
+			debit.SetDebit(float64(variance.Overage))
+			credit.SetCredit(float64(variance.Overage))
+			entries = append(entries, debit, credit)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: count.CompanyID},
+		VoucherDate:   count.CountDate,
+		VoucherType:   domain.VoucherTypeAdjustment,
+		Description:   description,
+		ReferenceType: inventoryCountReferenceType,
+		ReferenceID:   &count.ID,
+		Entries:       entries,
+		CreatedBy:     &userID,
+	}
+}
+
+// Cancel drops a count not posted yet
+func (s *inventoryCountService) Cancel(ctx context.Context, companyID, id uuid.UUID) (*domain.InventoryCount, error) {
+	count, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	from := count.Status
+	if err := count.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateStatus(ctx, count, from); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// checkAccount returns invalid unless the account of the company is of one of the types
+func (s *inventoryCountService) checkAccount(ctx context.Context, companyID, accountID uuid.UUID, invalid error, types ...domain.AccountType) error {
+	if accountID == uuid.Nil {
+		return invalid
+	}
+	account, err := s.accountRepo.FindByID(ctx, companyID, accountID)
+	if err != nil {
+		return err
+	}
+	for _, accountType := range types {
+		if account.AccountType == accountType {
+			return nil
+		}
+	}
+	return invalid
+}
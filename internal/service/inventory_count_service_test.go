@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// newSubmittedInventoryCount returns a count submitted by another user with a
+// shortage of 2 bolts at 500 won
+func newSubmittedInventoryCount(t *testing.T, companyID uuid.UUID) *domain.InventoryCount {
+	items := []domain.InventoryItem{{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		Code:        "A-001", Name: "볼트", Unit: "EA", AccountID: uuid.New(), UnitCost: 500, Quantity: 100,
+	}}
+	count := &domain.InventoryCount{
+		TenantModel:   domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		CountDate:     time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		Description:   "2026년 상반기 재고실사",
+		LossAccountID: uuid.New(),
+		GainAccountID: uuid.New(),
+	}
+	require.NoError(t, count.Open(items))
+	counted := 98.0
+	count.Lines[0].CountedQuantity = &counted
+	require.NoError(t, count.Submit(uuid.New(), time.Now()))
+	return count
+}
+
+func newInventoryCountApprover(companyID uuid.UUID) *domain.User {
+	return &domain.User{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: newTestUserID()}, CompanyID: companyID},
+		Role:        domain.UserRoleUser,
+		Status:      domain.UserStatusActive,
+	}
+}
+
+func TestInventoryCountService_Approve(t *testing.T) {
+	t.Run("second approval posts no voucher", func(t *testing.T) {
+		repo, users, vouchers := new(mocks.MockInventoryCountRepository), new(mocks.MockUserRepository), new(mocks.MockVoucherService)
+		svc := service.NewInventoryCountService(repo, nil, users, vouchers)
+		ctx := context.Background()
+		companyID, userID := newTestCompanyID(), newTestUserID()
+
+		// Both approvals read the count while it is submitted
+		first, second := newSubmittedInventoryCount(t, companyID), newSubmittedInventoryCount(t, companyID)
+		second.ID = first.ID
+		repo.On("FindByID", ctx, companyID, first.ID).Return(first, nil).Once()
+		repo.On("FindByID", ctx, companyID, first.ID).Return(second, nil).Once()
+		users.On("FindMember", ctx, companyID, userID).Return(newInventoryCountApprover(companyID), nil)
+		repo.On("Post", ctx, first).Return(nil).Once()
+		repo.On("Post", ctx, second).Return(domain.ErrInventoryCountChanged).Once()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).
+			Run(func(args mock.Arguments) { args.Get(1).(*domain.Voucher).ID = uuid.New() }).
+			Return(nil).Once()
+		repo.On("UpdateStatus", ctx, first, domain.InventoryCountPosted).Return(nil).Once()
+
+		approved, err := svc.Approve(ctx, companyID, userID, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InventoryCountPosted, approved.Status)
+		require.NotNil(t, approved.VoucherID)
+
+		_, err = svc.Approve(ctx, companyID, userID, first.ID)
+		assert.ErrorIs(t, err, domain.ErrInventoryCountChanged)
+
+		vouchers.AssertNumberOfCalls(t, "PostGenerated", 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns the count to submitted when the voucher fails", func(t *testing.T) {
+		repo, users, vouchers := new(mocks.MockInventoryCountRepository), new(mocks.MockUserRepository), new(mocks.MockVoucherService)
+		svc := service.NewInventoryCountService(repo, nil, users, vouchers)
+		ctx := context.Background()
+		companyID, userID := newTestCompanyID(), newTestUserID()
+
+		count := newSubmittedInventoryCount(t, companyID)
+		repo.On("FindByID", ctx, companyID, count.ID).Return(count, nil).Once()
+		users.On("FindMember", ctx, companyID, userID).Return(newInventoryCountApprover(companyID), nil)
+		repo.On("Post", ctx, count).Return(nil).Once()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).
+			Return(domain.ErrFiscalPeriodClosed).Once()
+		repo.On("Unpost", ctx, count).Return(nil).Once()
+
+		_, err := svc.Approve(ctx, companyID, userID, count.ID)
+
+		assert.ErrorIs(t, err, domain.ErrFiscalPeriodClosed)
+		repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reports a failed return to submitted", func(t *testing.T) {
+		repo, users, vouchers := new(mocks.MockInventoryCountRepository), new(mocks.MockUserRepository), new(mocks.MockVoucherService)
+		svc := service.NewInventoryCountService(repo, nil, users, vouchers)
+		ctx := context.Background()
+		companyID, userID := newTestCompanyID(), newTestUserID()
+		undoErr := errors.New("connection reset")
+
+		count := newSubmittedInventoryCount(t, companyID)
+		repo.On("FindByID", ctx, companyID, count.ID).Return(count, nil).Once()
+		users.On("FindMember", ctx, companyID, userID).Return(newInventoryCountApprover(companyID), nil)
+		repo.On("Post", ctx, count).Return(nil).Once()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).
+			Return(domain.ErrFiscalPeriodClosed).Once()
+		repo.On("Unpost", ctx, count).Return(undoErr).Once()
+
+		_, err := svc.Approve(ctx, companyID, userID, count.ID)
+
+		assert.ErrorIs(t, err, domain.ErrFiscalPeriodClosed)
+		assert.ErrorIs(t, err, undoErr)
+	})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+)
+
+// RegisterJobHandlers registers the handlers of all job types with the worker's job service
+func RegisterJobHandlers(jobs JobService, ledger LedgerService, imports LegacyImportService, schedules ReportScheduleService, approvals ApprovalService) {
+	jobs.Handle(domain.JobTypeLedgerRecalculation, func(ctx context.Context, job *domain.Job) (interface{}, error) {
+		var payload domain.LedgerRecalculationPayload
+		if err := job.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		return nil, ledger.RecalculateBalances(ctx, job.CompanyID, payload.Year, payload.Month)
+	})
+
+	jobs.Handle(domain.JobTypeLegacyImport, func(ctx context.Context, job *domain.Job) (interface{}, error) {
+		var payload domain.LegacyImportPayload
+		if err := job.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		result, err := imports.Import(ctx, LegacyImportRequest{
+			CompanyID: job.CompanyID,
+			UserID:    payload.UserID,
+			Format:    migrate.Format(payload.Format),
+			Dataset:   migrate.Dataset(payload.Dataset),
+			Data:      job.Data,
+			Options:   migrate.Options{Year: payload.FiscalYear, CashAccountCode: payload.CashAccountCode},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return newLegacyImportJobResult(result), nil
+	})
+
+	jobs.Handle(domain.JobTypeReportDelivery, func(ctx context.Context, job *domain.Job) (interface{}, error) {
+		var payload domain.ReportDeliveryPayload
+		if err := job.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		run, err := schedules.Deliver(ctx, job.CompanyID, payload.ScheduleID, payload.AsOf)
+		if run == nil {
+			return nil, err
+		}
+		return reportDeliveryJobResult{RunID: run.ID.String(), Status: string(run.Status)}, err
+	})
+
+	jobs.Handle(domain.JobTypeApprovalPush, func(ctx context.Context, job *domain.Job) (interface{}, error) {
+		var payload domain.ApprovalPushPayload
+		if err := job.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		sent, err := approvals.PushSubmitted(ctx, job.CompanyID, payload.VoucherID)
+		return approvalPushJobResult{Sent: sent}, err
+	})
+}
+
+// legacyImportJobResult is the outcome of an import job. Files with errors are
+// reported with applied=false; the job still succeeds.
+type legacyImportJobResult struct {
+	Format  string          `json:"format"`
+	Dataset string          `json:"dataset"`
+	Applied bool            `json:"applied"`
+	Rows    int             `json:"rows"`
+	Records int             `json:"records"`
+	Created int             `json:"created"`
+	Updated int             `json:"updated"`
+	Skipped int             `json:"skipped"`
+	Issues  []migrate.Issue `json:"issues,omitempty"`
+}
+
+func newLegacyImportJobResult(r *migrate.Result) legacyImportJobResult {
+	return legacyImportJobResult{
+		Format:  string(r.Format),
+		Dataset: string(r.Dataset),
+		Applied: r.Applied,
+		Rows:    r.Rows,
+		Records: r.Records,
+		Created: r.Created,
+		Updated: r.Updated,
+		Skipped: r.Skipped,
+		Issues:  r.Issues,
+	}
+}
+
+// reportDeliveryJobResult references the schedule run recorded by a delivery
+type reportDeliveryJobResult struct {
+	RunID  string `json:"run_id"`
+	Status string `json:"status"`
+}
+
+// approvalPushJobResult counts the devices notified of an approval request
+type approvalPushJobResult struct {
+	Sent int `json:"sent"`
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+const (
+	// jobBatchSize is the maximum number of jobs run per worker tick
+	jobBatchSize = 20
+	// jobStaleAfter is how long a job may stay running before another worker
+	// assumes the first one crashed
+	jobStaleAfter = time.Hour
+)
+
+// JobHandler runs a job of one type. The result, when not nil, is stored on
+// the job as JSON.
+type JobHandler func(ctx context.Context, job *domain.Job) (interface{}, error)
+
+// JobQueue queues background jobs for the worker. JobService implements it.
+type JobQueue interface {
+	// Enqueue queues a job of the company; data is binary input such as an
+	// uploaded file, nil for most jobs
+	Enqueue(ctx context.Context, companyID uuid.UUID, jobType domain.JobType, payload interface{}, data []byte, createdBy *uuid.UUID) (*domain.Job, error)
+}
+
+// JobService defines the interface for background jobs: the reports, imports
+// and recalculations run by the worker, and their management by operators.
+type JobService interface {
+	JobQueue
+
+	// Query operations; GetForCompany only finds the jobs of the company
+	Get(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	GetForCompany(ctx context.Context, companyID, id uuid.UUID) (*domain.Job, error)
+	List(ctx context.Context, filter repository.JobFilter) ([]domain.Job, int64, error)
+
+	// Retry queues a failed or cancelled job again; Cancel stops an unfinished one
+	Retry(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	Cancel(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+
+	// Handle registers the handler of a job type. The worker registers
+	// handlers for all types before processing; the API only queues jobs.
+	Handle(jobType domain.JobType, handler JobHandler)
+	// ProcessPending runs due jobs and returns the number of attempts made
+	ProcessPending(ctx context.Context) (int, error)
+}
+
+// jobService implements JobService
+type jobService struct {
+	repo     repository.JobRepository
+	handlers map[domain.JobType]JobHandler
+}
+
+// NewJobService creates a new JobService
+func NewJobService(repo repository.JobRepository) JobService {
+	return &jobService{repo: repo, handlers: make(map[domain.JobType]JobHandler)}
+}
+
+// Enqueue stores a queued job, due now
+func (s *jobService) Enqueue(ctx context.Context, companyID uuid.UUID, jobType domain.JobType, payload interface{}, data []byte, createdBy *uuid.UUID) (*domain.Job, error) {
+	job, err := domain.NewJob(companyID, jobType, payload, createdBy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	job.Data = data
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Get retrieves a job of any company
+func (s *jobService) Get(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// GetForCompany retrieves a job of the company
+func (s *jobService) GetForCompany(ctx context.Context, companyID, id uuid.UUID) (*domain.Job, error) {
+	job, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.CompanyID != companyID {
+		return nil, domain.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// List retrieves jobs matching the filter
+func (s *jobService) List(ctx context.Context, filter repository.JobFilter) ([]domain.Job, int64, error) {
+	return s.repo.FindAll(ctx, filter)
+}
+
+// Retry queues a failed or cancelled job again with all its attempts
+func (s *jobService) Retry(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	status := job.Status
+	if err := job.Retry(time.Now()); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, job, status)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, domain.ErrJobNotRetryable
+	}
+	return job, nil
+}
+
+// Cancel stops a queued or running job
+func (s *jobService) Cancel(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	status := job.Status
+	if err := job.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, job, status)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, domain.ErrJobNotCancellable
+	}
+	return job, nil
+}
+
+// Handle registers the handler of a job type
+func (s *jobService) Handle(jobType domain.JobType, handler JobHandler) {
+	s.handlers[jobType] = handler
+}
+
+// ProcessPending claims due jobs one at a time and runs them. Failed attempts
+// are recorded on the job and returned as a joined error for logging.
+func (s *jobService) ProcessPending(ctx context.Context) (int, error) {
+	count := 0
+	var errs []error
+	for count < jobBatchSize {
+		now := time.Now()
+		job, err := s.repo.ClaimNext(ctx, now, now.Add(-jobStaleAfter))
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if job == nil {
+			break
+		}
+		count++
+
+		result, err := s.run(ctx, job)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s (%s): %w", job.ID, job.Type, err))
+		}
+		job.Finish(result, err, time.Now())
+		// A job cancelled while it ran stays cancelled
+		if _, err := s.repo.Update(ctx, job, domain.JobStatusRunning); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// run calls the handler of the job, turning a panic into a failed attempt
+func (s *jobService) run(ctx context.Context, job *domain.Job) (result json.RawMessage, err error) {
+	handler, ok := s.handlers[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("no handler for job type %s", job.Type)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	value, err := handler(ctx, job)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
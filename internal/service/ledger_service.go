@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,33 +22,108 @@ type LedgerService interface {
 
 	// Account ledger (detailed transactions)
 	GetAccountLedger(ctx context.Context, companyID, accountID uuid.UUID, from, to time.Time) ([]domain.AccountLedgerEntry, float64, error)
+	// GetAccountActivity returns the monthly series of an account over the last
+	// months up to the current one
+	GetAccountActivity(ctx context.Context, companyID, accountID uuid.UUID, months int) (*domain.AccountActivity, error)
 
 	// Trial balance
 	GetTrialBalance(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.TrialBalance, error)
 	GetTrialBalanceRange(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth, toYear, toMonth int) (*domain.TrialBalance, error)
+	GetTrialBalanceByDimension(ctx context.Context, companyID uuid.UUID, year, month int, dimension domain.TrialBalanceDimension) (*domain.TrialBalance, error)
+
+	// Financial statements, with accounts rolled up to their statement lines
+	// GetBalanceExceptions lists the accounts closing on the side opposite to
+	// their nature in each of the months ending at year/month
+	GetBalanceExceptions(ctx context.Context, companyID uuid.UUID, year, month, months int) (*domain.BalanceExceptionReport, error)
+
+	// Financial statements; depth is the number of account levels rolled up
+	// below each statement line, domain.StatementDepthAll for all of them
+	GetBalanceSheet(ctx context.Context, companyID uuid.UUID, year, month, depth int) (*domain.FinancialStatement, error)
+	GetIncomeStatement(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth, toYear, toMonth, depth int) (*domain.FinancialStatement, error)
 
 	// Fiscal period management
 	GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error)
 	GetFiscalPeriods(ctx context.Context, companyID uuid.UUID, year int) ([]domain.FiscalPeriod, error)
 	CreateFiscalPeriods(ctx context.Context, companyID uuid.UUID, year int) ([]domain.FiscalPeriod, error)
-	ClosePeriod(ctx context.Context, companyID uuid.UUID, year, month int, userID uuid.UUID) error
+	// ClosePeriod closes a period. Under dual control the first call records a
+	// pending confirmation and closes nothing; the call of another user before
+	// it expires confirms and performs the close. The confirmation is nil when
+	// dual control is off.
+	ClosePeriod(ctx context.Context, companyID uuid.UUID, year, month int, userID uuid.UUID, override bool) (*domain.CloseConfirmation, error)
 	ReopenPeriod(ctx context.Context, companyID uuid.UUID, year, month int) error
 
-	// Year-end closing
-	PerformYearEndClose(ctx context.Context, companyID uuid.UUID, year int, retainedEarningsAccountID uuid.UUID, userID uuid.UUID) error
+	// Year-end closing. The rollover carries balance sheet accounts into January
+	// of the next year and closes revenue and expense into retained earnings.
+	// Dual control applies as to ClosePeriod; the run is nil while the close
+	// awaits confirmation.
+	PerformYearEndClose(ctx context.Context, companyID uuid.UUID, year int, retainedEarningsAccountID uuid.UUID, userID uuid.UUID) (*domain.YearRolloverRun, *domain.CloseConfirmation, error)
+	RollOverOpenedYears(ctx context.Context, toYear int) ([]domain.YearRolloverRun, error)
+	ListYearRollovers(ctx context.Context, companyID uuid.UUID) ([]domain.YearRolloverRun, error)
+
+	// Close confirmations under dual control
+	ListCloseConfirmations(ctx context.Context, companyID uuid.UUID) ([]domain.CloseConfirmation, error)
+	GetCloseConfirmation(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseConfirmation, error)
+	// ConfirmClose performs a pending close initiated by another user; the run
+	// is set for year-end closes. A close overriding the checklist is confirmed
+	// only when canOverride is set.
+	ConfirmClose(ctx context.Context, companyID, id, userID uuid.UUID, canOverride bool) (*domain.CloseConfirmation, *domain.YearRolloverRun, error)
+	CancelClose(ctx context.Context, companyID, id, userID uuid.UUID) (*domain.CloseConfirmation, error)
+
+	// Audit locks freeze a fiscal year once its external audit is signed off:
+	// its periods cannot be reopened and no voucher can be reversed into it.
+	// Unlocking is reserved to platform operators and needs a reason; the
+	// client address and user agent of the request go into its audit entry.
+	ListAuditLocks(ctx context.Context, companyID uuid.UUID) ([]domain.AuditLock, error)
+	LockFiscalYear(ctx context.Context, companyID uuid.UUID, year int, reference string, userID uuid.UUID) (*domain.AuditLock, error)
+	UnlockFiscalYear(ctx context.Context, companyID uuid.UUID, year int, reason string, userID uuid.UUID, ipAddress, userAgent string) (*domain.AuditLock, error)
+}
+
+// closeConfirmationListLimit is the number of close confirmations listed
+const closeConfirmationListLimit = 100
+
+// accountActivityCacheTTL is how long account activity series are served from
+// the cache; postings show up on dashboards within it
+const accountActivityCacheTTL = 5 * time.Minute
+
+// ReportCache caches computed report payloads shared by all API instances.
+// database.RedisCache implements it; Get returns an empty string on a miss.
+type ReportCache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 }
 
 // ledgerService implements LedgerService
 type ledgerService struct {
-	ledgerRepo  repository.LedgerRepository
-	accountRepo repository.AccountRepository
+	ledgerRepo      repository.LedgerRepository
+	accountRepo     repository.AccountRepository
+	checklistRepo   repository.CloseChecklistRepository
+	rolloverRepo    repository.YearRolloverRepository
+	confirmRepo     repository.CloseConfirmationRepository
+	lockRepo        repository.AuditLockRepository
+	settingsService CompanySettingsService
+	cache           ReportCache // nil disables caching
 }
 
 // NewLedgerService creates a new LedgerService
-func NewLedgerService(ledgerRepo repository.LedgerRepository, accountRepo repository.AccountRepository) LedgerService {
+func NewLedgerService(
+	ledgerRepo repository.LedgerRepository,
+	accountRepo repository.AccountRepository,
+	checklistRepo repository.CloseChecklistRepository,
+	rolloverRepo repository.YearRolloverRepository,
+	confirmRepo repository.CloseConfirmationRepository,
+	lockRepo repository.AuditLockRepository,
+	settingsService CompanySettingsService,
+	cache ReportCache,
+) LedgerService {
 	return &ledgerService{
-		ledgerRepo:  ledgerRepo,
-		accountRepo: accountRepo,
+		ledgerRepo:      ledgerRepo,
+		accountRepo:     accountRepo,
+		checklistRepo:   checklistRepo,
+		rolloverRepo:    rolloverRepo,
+		confirmRepo:     confirmRepo,
+		lockRepo:        lockRepo,
+		settingsService: settingsService,
+		cache:           cache,
 	}
 }
 
@@ -100,6 +178,42 @@ func (s *ledgerService) GetAccountLedger(ctx context.Context, companyID, account
 	return entries, openingBalance, nil
 }
 
+// GetAccountActivity computes the activity series from ledger_balances, cached
+// per account and current month
+func (s *ledgerService) GetAccountActivity(ctx context.Context, companyID, accountID uuid.UUID, months int) (*domain.AccountActivity, error) {
+	now := time.Now()
+	toYear, toMonth := now.Year(), int(now.Month())
+	key := fmt.Sprintf("kerp:account-activity:%s:%s:%d-%02d:%d", companyID, accountID, toYear, toMonth, months)
+
+	if s.cache != nil {
+		if cached, err := s.cache.Get(ctx, key); err == nil && cached != "" {
+			var activity domain.AccountActivity
+			if json.Unmarshal([]byte(cached), &activity) == nil {
+				return &activity, nil
+			}
+		}
+	}
+
+	account, err := s.accountRepo.FindByID(ctx, companyID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Date(toYear, time.Month(toMonth)-time.Month(months-1), 1, 0, 0, 0, 0, time.UTC)
+	balances, err := s.ledgerRepo.GetBalanceHistory(ctx, companyID, accountID, from.Year(), int(from.Month()), toYear, toMonth)
+	if err != nil {
+		return nil, err
+	}
+	activity := domain.BuildAccountActivity(account, balances, toYear, toMonth, months)
+
+	// A cache failure only costs the next request a recomputation
+	if s.cache != nil {
+		if data, err := json.Marshal(activity); err == nil {
+			_ = s.cache.Set(ctx, key, data, accountActivityCacheTTL)
+		}
+	}
+	return activity, nil
+}
+
 // GetTrialBalance generates a trial balance report
 func (s *ledgerService) GetTrialBalance(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.TrialBalance, error) {
 	return s.ledgerRepo.GetTrialBalance(ctx, companyID, year, month)
@@ -110,6 +224,65 @@ func (s *ledgerService) GetTrialBalanceRange(ctx context.Context, companyID uuid
 	return s.ledgerRepo.GetTrialBalanceRange(ctx, companyID, fromYear, fromMonth, toYear, toMonth)
 }
 
+// GetTrialBalanceByDimension generates a trial balance by account and department, project or partner
+func (s *ledgerService) GetTrialBalanceByDimension(ctx context.Context, companyID uuid.UUID, year, month int, dimension domain.TrialBalanceDimension) (*domain.TrialBalance, error) {
+	return s.ledgerRepo.GetTrialBalanceByDimension(ctx, companyID, year, month, dimension)
+}
+
+// GetBalanceExceptions checks the ledger balances of each month, oldest first
+func (s *ledgerService) GetBalanceExceptions(ctx context.Context, companyID uuid.UUID, year, month, months int) (*domain.BalanceExceptionReport, error) {
+	var exceptions []domain.BalanceException
+	last := year*12 + month - 1
+	for period := last - months + 1; period <= last; period++ {
+		balances, err := s.ledgerRepo.GetBalances(ctx, companyID, period/12, period%12+1)
+		if err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, domain.FindBalanceExceptions(balances)...)
+	}
+	return domain.NewBalanceExceptionReport(year, month, months, exceptions), nil
+}
+
+// GetBalanceSheet builds the balance sheet at the end of the month
+func (s *ledgerService) GetBalanceSheet(ctx context.Context, companyID uuid.UUID, year, month, depth int) (*domain.FinancialStatement, error) {
+	tb, err := s.ledgerRepo.GetTrialBalance(ctx, companyID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	accounts, lines, err := statementAccounts(ctx, s.accountRepo, companyID)
+	if err != nil {
+		return nil, err
+	}
+	fs := domain.BuildBalanceSheet(tb, lines)
+	fs.AddAccountRollup(tb, accounts, lines, depth)
+	return fs, nil
+}
+
+// GetIncomeStatement builds the income statement of the month range
+func (s *ledgerService) GetIncomeStatement(ctx context.Context, companyID uuid.UUID, fromYear, fromMonth, toYear, toMonth, depth int) (*domain.FinancialStatement, error) {
+	tb, err := s.ledgerRepo.GetTrialBalanceRange(ctx, companyID, fromYear, fromMonth, toYear, toMonth)
+	if err != nil {
+		return nil, err
+	}
+	accounts, lines, err := statementAccounts(ctx, s.accountRepo, companyID)
+	if err != nil {
+		return nil, err
+	}
+	fs := domain.BuildIncomeStatement(tb, lines)
+	fs.AddAccountRollup(tb, accounts, lines, depth)
+	return fs, nil
+}
+
+// statementAccounts returns the accounts of the company with the statement
+// line each of them rolls up to
+func statementAccounts(ctx context.Context, accountRepo repository.AccountRepository, companyID uuid.UUID) ([]domain.Account, map[uuid.UUID]string, error) {
+	accounts, _, err := accountRepo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return accounts, domain.ResolveStatementLines(accounts), nil
+}
+
 // GetFiscalPeriod retrieves a fiscal period
 func (s *ledgerService) GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error) {
 	return s.ledgerRepo.GetFiscalPeriod(ctx, companyID, year, month)
@@ -148,44 +321,75 @@ func (s *ledgerService) CreateFiscalPeriods(ctx context.Context, companyID uuid.
 	return periods, nil
 }
 
-// ClosePeriod closes a fiscal period
-func (s *ledgerService) ClosePeriod(ctx context.Context, companyID uuid.UUID, year, month int, userID uuid.UUID) error {
+// ClosePeriod closes a fiscal period.
+// All mandatory close checklist tasks must be completed unless override is set.
+func (s *ledgerService) ClosePeriod(ctx context.Context, companyID uuid.UUID, year, month int, userID uuid.UUID, override bool) (*domain.CloseConfirmation, error) {
 	// Get period
 	period, err := s.ledgerRepo.GetFiscalPeriod(ctx, companyID, year, month)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !period.IsOpen() {
+		return nil, domain.ErrFiscalPeriodClosed
+	}
+
+	// Enforce close checklist
+	if !override {
+		checklist, err := loadPeriodChecklist(ctx, s.checklistRepo, companyID, year, month)
+		if err != nil {
+			return nil, err
+		}
+		if !checklist.IsComplete() {
+			return nil, domain.ErrCloseChecklistIncomplete
+		}
+	}
+
+	confirmation, err := s.dualControl(ctx, companyID, userID, func(validity time.Duration, now time.Time) *domain.CloseConfirmation {
+		return domain.NewPeriodCloseConfirmation(companyID, year, month, override, userID, validity, now)
+	})
+	if err != nil || (confirmation != nil && confirmation.IsPending()) {
+		return confirmation, err
 	}
 
 	// Recalculate balances before closing
 	if err := s.RecalculateBalances(ctx, companyID, year, month); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Carry forward to next period
-	nextYear, nextMonth := year, month+1
-	if nextMonth > 12 {
-		nextYear++
-		nextMonth = 1
-	}
-	if err := s.ledgerRepo.CarryForwardBalances(ctx, companyID, year, month, nextYear, nextMonth); err != nil {
-		return err
+	// Carry forward to next period. December is carried into the next year by
+	// the year rollover, which leaves revenue and expense accounts behind.
+	if month < 12 {
+		if err := s.ledgerRepo.CarryForwardBalances(ctx, companyID, year, month, year, month+1); err != nil {
+			return nil, err
+		}
 	}
 
 	// Close period
 	if err := period.Close(userID); err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.ledgerRepo.UpdateFiscalPeriod(ctx, period); err != nil {
+		return nil, err
 	}
 
-	return s.ledgerRepo.UpdateFiscalPeriod(ctx, period)
+	return confirmation, s.recordConfirmation(ctx, confirmation)
 }
 
-// ReopenPeriod reopens a closed fiscal period
+// ReopenPeriod reopens a closed fiscal period of a year that is not audit locked
 func (s *ledgerService) ReopenPeriod(ctx context.Context, companyID uuid.UUID, year, month int) error {
 	period, err := s.ledgerRepo.GetFiscalPeriod(ctx, companyID, year, month)
 	if err != nil {
 		return err
 	}
 
+	locked, err := s.ledgerRepo.IsFiscalYearAuditLocked(ctx, companyID, year)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return domain.ErrFiscalYearAuditLocked
+	}
+
 	if period.Status == domain.FiscalPeriodLocked {
 		return domain.ErrFiscalPeriodClosed
 	}
@@ -197,76 +401,285 @@ func (s *ledgerService) ReopenPeriod(ctx context.Context, companyID uuid.UUID, y
 	return s.ledgerRepo.UpdateFiscalPeriod(ctx, period)
 }
 
-// PerformYearEndClose performs year-end closing
-func (s *ledgerService) PerformYearEndClose(ctx context.Context, companyID uuid.UUID, year int, retainedEarningsAccountID uuid.UUID, userID uuid.UUID) error {
-	// This would:
-	// 1. Close all periods for the year
-	// 2. Calculate net income (Revenue - Expense)
-	// 3. Create closing entries to zero out income/expense accounts
-	// 4. Transfer net income to retained earnings
-	// 5. Carry forward balance sheet accounts to next year
+// PerformYearEndClose rolls the year over into the next one with the given
+// retained earnings account
+func (s *ledgerService) PerformYearEndClose(ctx context.Context, companyID uuid.UUID, year int, retainedEarningsAccountID uuid.UUID, userID uuid.UUID) (*domain.YearRolloverRun, *domain.CloseConfirmation, error) {
+	if err := s.checkYearRollover(ctx, companyID, year, retainedEarningsAccountID); err != nil {
+		return nil, nil, err
+	}
+
+	confirmation, err := s.dualControl(ctx, companyID, userID, func(validity time.Duration, now time.Time) *domain.CloseConfirmation {
+		return domain.NewYearEndCloseConfirmation(companyID, year, retainedEarningsAccountID, userID, validity, now)
+	})
+	if err != nil || (confirmation != nil && confirmation.IsPending()) {
+		return nil, confirmation, err
+	}
 
-	// Get December balances
-	balances, err := s.ledgerRepo.GetBalances(ctx, companyID, year, 12)
+	run, err := s.rollOverYear(ctx, companyID, year, retainedEarningsAccountID, domain.YearRolloverTriggerManual, &userID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	return run, confirmation, s.recordConfirmation(ctx, confirmation)
+}
 
-	// Calculate net income from revenue and expense accounts
-	var totalRevenue, totalExpense float64
-	for _, balance := range balances {
-		if balance.Account != nil {
-			switch balance.Account.AccountType {
-			case domain.AccountTypeRevenue:
-				totalRevenue += balance.GetClosingBalance()
-			case domain.AccountTypeExpense:
-				totalExpense += balance.GetClosingBalance()
-			}
+// dualControl applies the two-person rule of the company to a close. It returns
+// nil when dual control is off and the close goes ahead. Otherwise it returns
+// the pending confirmation of another user, confirmed by userID, so that the
+// close goes ahead and is then recorded with recordConfirmation; or, when no
+// close is pending, a new pending confirmation built by initiate, and the close
+// must not be performed.
+func (s *ledgerService) dualControl(ctx context.Context, companyID, userID uuid.UUID, initiate func(validity time.Duration, now time.Time) *domain.CloseConfirmation) (*domain.CloseConfirmation, error) {
+	settings, err := s.settingsService.Get(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.RequireDualCloseControl {
+		return nil, nil
+	}
+
+	now := time.Now()
+	requested := initiate(settings.CloseConfirmationValidity(), now)
+
+	pending, err := s.confirmRepo.FindPending(ctx, companyID, requested.Kind, requested.Year, requested.Month)
+	if err != nil && !errors.Is(err, domain.ErrCloseConfirmationNotFound) {
+		return nil, err
+	}
+	if pending != nil && pending.IsExpired(now) {
+		pending.Expire()
+		if err := s.confirmRepo.Update(ctx, pending, domain.AuditActionCloseExpire, nil); err != nil {
+			return nil, err
 		}
+		pending = nil
 	}
 
-	netIncome := totalRevenue - totalExpense
+	if pending == nil {
+		if err := s.confirmRepo.Create(ctx, requested); err != nil {
+			return nil, err
+		}
+		return requested, nil
+	}
 
-	// Create closing entry balances for next year
-	nextYear := year + 1
-	var nextYearBalances []domain.LedgerBalance
+	if !pending.Matches(requested.Override, requested.RetainedEarningsAccountID) {
+		return nil, domain.ErrCloseConfirmationMismatch
+	}
+	if err := pending.Confirm(userID, now); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
 
-	for _, balance := range balances {
-		if balance.Account == nil {
-			continue
+// recordConfirmation stores the confirmation of a close that was performed
+func (s *ledgerService) recordConfirmation(ctx context.Context, confirmation *domain.CloseConfirmation) error {
+	if confirmation == nil {
+		return nil
+	}
+	return s.confirmRepo.Update(ctx, confirmation, domain.AuditActionCloseConfirm, confirmation.ConfirmedBy)
+}
+
+// ListCloseConfirmations lists the close confirmations of the company, latest first
+func (s *ledgerService) ListCloseConfirmations(ctx context.Context, companyID uuid.UUID) ([]domain.CloseConfirmation, error) {
+	return s.confirmRepo.FindAll(ctx, companyID, closeConfirmationListLimit)
+}
+
+// GetCloseConfirmation retrieves a close confirmation
+func (s *ledgerService) GetCloseConfirmation(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseConfirmation, error) {
+	return s.confirmRepo.FindByID(ctx, companyID, id)
+}
+
+// ConfirmClose performs the close of a pending confirmation with its parameters
+func (s *ledgerService) ConfirmClose(ctx context.Context, companyID, id, userID uuid.UUID, canOverride bool) (*domain.CloseConfirmation, *domain.YearRolloverRun, error) {
+	confirmation, err := s.confirmRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Checked here as well: past this point a close that is not pending anymore
+	// would be initiated again by the confirming user
+	if !confirmation.IsPending() {
+		return nil, nil, domain.ErrCloseConfirmationClosed
+	}
+	if confirmation.IsExpired(time.Now()) {
+		return nil, nil, domain.ErrCloseConfirmationExpired
+	}
+	if userID == confirmation.InitiatedBy {
+		return nil, nil, domain.ErrCloseSelfConfirmation
+	}
+	// The confirming user performs the close and needs the authority of the override
+	if confirmation.Override && !canOverride {
+		return nil, nil, domain.ErrCloseOverrideForbidden
+	}
+
+	switch confirmation.Kind {
+	case domain.CloseKindYearEnd:
+		run, confirmed, err := s.PerformYearEndClose(ctx, companyID, confirmation.Year, *confirmation.RetainedEarningsAccountID, userID)
+		return confirmed, run, err
+	default:
+		confirmed, err := s.ClosePeriod(ctx, companyID, confirmation.Year, confirmation.Month, userID, confirmation.Override)
+		return confirmed, nil, err
+	}
+}
+
+// CancelClose withdraws a pending close
+func (s *ledgerService) CancelClose(ctx context.Context, companyID, id, userID uuid.UUID) (*domain.CloseConfirmation, error) {
+	confirmation, err := s.confirmRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := confirmation.Cancel(userID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.confirmRepo.Update(ctx, confirmation, domain.AuditActionCloseCancel, &userID); err != nil {
+		return nil, err
+	}
+	return confirmation, nil
+}
+
+// ListAuditLocks lists the audit locks of the company, lifted ones included
+func (s *ledgerService) ListAuditLocks(ctx context.Context, companyID uuid.UUID) ([]domain.AuditLock, error) {
+	return s.lockRepo.FindAll(ctx, companyID)
+}
+
+// LockFiscalYear locks a fiscal year after its audit sign-off. All periods of
+// the year must be closed.
+func (s *ledgerService) LockFiscalYear(ctx context.Context, companyID uuid.UUID, year int, reference string, userID uuid.UUID) (*domain.AuditLock, error) {
+	if _, err := s.lockRepo.FindActive(ctx, companyID, year); err == nil {
+		return nil, domain.ErrFiscalYearAuditLocked
+	} else if !errors.Is(err, domain.ErrAuditLockNotFound) {
+		return nil, err
+	}
+
+	periods, err := s.ledgerRepo.GetFiscalPeriods(ctx, companyID, year)
+	if err != nil {
+		return nil, err
+	}
+	if len(periods) == 0 {
+		return nil, domain.ErrFiscalPeriodNotFound
+	}
+	for _, period := range periods {
+		if period.IsOpen() {
+			return nil, domain.ErrAuditLockOpenPeriods
 		}
+	}
 
-		// For balance sheet accounts (Asset, Liability, Equity), carry forward
-		if balance.Account.AccountType == domain.AccountTypeAsset ||
-			balance.Account.AccountType == domain.AccountTypeLiability ||
-			balance.Account.AccountType == domain.AccountTypeEquity {
-
-			nextBalance := domain.LedgerBalance{
-				CompanyID:     companyID,
-				AccountID:     balance.AccountID,
-				FiscalYear:    nextYear,
-				FiscalMonth:   1,
-				OpeningDebit:  balance.ClosingDebit,
-				OpeningCredit: balance.ClosingCredit,
-				ClosingDebit:  balance.ClosingDebit,
-				ClosingCredit: balance.ClosingCredit,
-			}
+	lock := domain.NewAuditLock(companyID, year, reference, userID, time.Now())
+	if err := s.lockRepo.Create(ctx, lock); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
 
-			// Add net income to retained earnings
-			if balance.AccountID == retainedEarningsAccountID {
-				if netIncome > 0 {
-					nextBalance.OpeningCredit += netIncome
-					nextBalance.ClosingCredit += netIncome
-				} else {
-					nextBalance.OpeningDebit += -netIncome
-					nextBalance.ClosingDebit += -netIncome
-				}
-			}
+// UnlockFiscalYear lifts the audit lock of a fiscal year. The audit entry
+// keeps the lock as it was, the reason and the client of the request.
+func (s *ledgerService) UnlockFiscalYear(ctx context.Context, companyID uuid.UUID, year int, reason string, userID uuid.UUID, ipAddress, userAgent string) (*domain.AuditLock, error) {
+	lock, err := s.lockRepo.FindActive(ctx, companyID, year)
+	if err != nil {
+		return nil, err
+	}
 
-			nextYearBalances = append(nextYearBalances, nextBalance)
+	before := *lock
+	if err := lock.Unlock(userID, reason, time.Now()); err != nil {
+		return nil, err
+	}
+	entry, err := lock.AuditLog(domain.AuditActionAuditUnlock, &userID, &before)
+	if err != nil {
+		return nil, err
+	}
+	if ipAddress != "" {
+		entry.IPAddress = &ipAddress
+	}
+	entry.UserAgent = truncateRunes(userAgent, 500)
+
+	if err := s.lockRepo.Unlock(ctx, lock, entry); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// RollOverOpenedYears rolls over the year before toYear for every company that
+// opened the periods of toYear, using the retained earnings account of its
+// settings. Companies without one are skipped and returned in the error.
+func (s *ledgerService) RollOverOpenedYears(ctx context.Context, toYear int) ([]domain.YearRolloverRun, error) {
+	companyIDs, err := s.rolloverRepo.FindDue(ctx, toYear)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []domain.YearRolloverRun
+	var errs []error
+	for _, companyID := range companyIDs {
+		settings, err := s.settingsService.Get(ctx, companyID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
 		}
-		// Revenue and Expense accounts start fresh (zero balance)
+		if settings.RetainedEarningsAccountID == nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, domain.ErrRetainedEarningsAccountRequired))
+			continue
+		}
+
+		run, err := s.rollOverYear(ctx, companyID, toYear-1, *settings.RetainedEarningsAccountID, domain.YearRolloverTriggerAuto, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		runs = append(runs, *run)
+	}
+	return runs, errors.Join(errs...)
+}
+
+// ListYearRollovers lists the rollovers of the company, latest year first
+func (s *ledgerService) ListYearRollovers(ctx context.Context, companyID uuid.UUID) ([]domain.YearRolloverRun, error) {
+	return s.rolloverRepo.List(ctx, companyID)
+}
+
+// rollOverYear sets the January opening balances of the year after fromYear
+// from its December balances and records the run
+func (s *ledgerService) rollOverYear(ctx context.Context, companyID uuid.UUID, fromYear int, retainedEarningsAccountID uuid.UUID, trigger domain.YearRolloverTrigger, userID *uuid.UUID) (*domain.YearRolloverRun, error) {
+	if err := s.checkYearRollover(ctx, companyID, fromYear, retainedEarningsAccountID); err != nil {
+		return nil, err
 	}
 
-	return s.ledgerRepo.UpsertBalances(ctx, nextYearBalances)
+	// December is brought up to date with late postings first
+	if err := s.RecalculateBalances(ctx, companyID, fromYear, 12); err != nil {
+		return nil, err
+	}
+	december, err := s.ledgerRepo.GetBalances(ctx, companyID, fromYear, 12)
+	if err != nil {
+		return nil, err
+	}
+	january, err := s.ledgerRepo.GetBalances(ctx, companyID, fromYear+1, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, run := domain.BuildYearOpeningBalances(companyID, fromYear, december, january, retainedEarningsAccountID)
+	if err := s.ledgerRepo.UpsertBalances(ctx, balances); err != nil {
+		return nil, err
+	}
+
+	run.Trigger = trigger
+	run.CreatedBy = userID
+	if err := s.rolloverRepo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// checkYearRollover checks that fromYear can be rolled over into the account
+func (s *ledgerService) checkYearRollover(ctx context.Context, companyID uuid.UUID, fromYear int, retainedEarningsAccountID uuid.UUID) error {
+	exists, err := s.rolloverRepo.Exists(ctx, companyID, fromYear)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrYearRolledOver
+	}
+
+	account, err := s.accountRepo.FindByID(ctx, companyID, retainedEarningsAccountID)
+	if err != nil {
+		return err
+	}
+	if account.AccountType != domain.AccountTypeEquity {
+		return domain.ErrInvalidRetainedEarningsAccount
+	}
+	return nil
 }
@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// storedConfirmations serves the close confirmations it holds
+type storedConfirmations struct {
+	repository.CloseConfirmationRepository
+	confirmations map[uuid.UUID]*domain.CloseConfirmation
+}
+
+func (r *storedConfirmations) FindByID(ctx context.Context, companyID, id uuid.UUID) (*domain.CloseConfirmation, error) {
+	confirmation, ok := r.confirmations[id]
+	if !ok || confirmation.CompanyID != companyID {
+		return nil, domain.ErrCloseConfirmationNotFound
+	}
+	return confirmation, nil
+}
+
+// closedPeriods reports every fiscal period as closed
+type closedPeriods struct {
+	repository.LedgerRepository
+}
+
+func (closedPeriods) GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error) {
+	return &domain.FiscalPeriod{CompanyID: companyID, FiscalYear: year, FiscalMonth: month, Status: domain.FiscalPeriodClosed}, nil
+}
+
+func TestLedgerService_ConfirmClose_Override(t *testing.T) {
+	ctx := context.Background()
+	companyID, initiator, confirmer := newTestCompanyID(), uuid.New(), newTestUserID()
+
+	confirmation := domain.NewPeriodCloseConfirmation(companyID, 2026, 3, true, initiator, 24*time.Hour, time.Now())
+	confirmation.ID = uuid.New()
+	confirmations := &storedConfirmations{confirmations: map[uuid.UUID]*domain.CloseConfirmation{confirmation.ID: confirmation}}
+	svc := service.NewLedgerService(closedPeriods{}, nil, nil, nil, confirmations, nil, nil, nil)
+
+	t.Run("refuses a confirmer who may not override the checklist", func(t *testing.T) {
+		_, _, err := svc.ConfirmClose(ctx, companyID, confirmation.ID, confirmer, false)
+
+		assert.ErrorIs(t, err, domain.ErrCloseOverrideForbidden)
+		assert.True(t, confirmation.IsPending())
+	})
+
+	t.Run("performs the close for a confirmer who may override it", func(t *testing.T) {
+		// The close itself goes ahead and finds the period already closed
+		_, _, err := svc.ConfirmClose(ctx, companyID, confirmation.ID, confirmer, true)
+
+		assert.ErrorIs(t, err, domain.ErrFiscalPeriodClosed)
+	})
+}
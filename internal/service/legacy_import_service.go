@@ -0,0 +1,486 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/migrate"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// legacyImportReferenceType marks vouchers created by the legacy import
+const legacyImportReferenceType = "legacy_import"
+
+// LegacyImportRequest is one file submitted through the legacy import wizard
+type LegacyImportRequest struct {
+	CompanyID uuid.UUID
+	UserID    uuid.UUID
+	Format    migrate.Format
+	Dataset   migrate.Dataset
+	Data      []byte
+	Options   migrate.Options
+
+	// DryRun validates the file against the company's data without saving anything
+	DryRun bool
+}
+
+// LegacyImportService defines the interface for importing Douzone and SemusaRang data.
+// Datasets are imported one file at a time in migrate.Datasets order, since opening
+// balances and vouchers reference the accounts and partners imported before them.
+type LegacyImportService interface {
+	// Formats lists the supported legacy packages and their file columns
+	Formats() []migrate.Adapter
+	// Import parses and validates the file and, unless it is a dry run or has
+	// errors, creates its records. Opening balances and vouchers are posted.
+	Import(ctx context.Context, req LegacyImportRequest) (*migrate.Result, error)
+}
+
+// legacyImportService implements LegacyImportService
+type legacyImportService struct {
+	accountRepo    repository.AccountRepository
+	partnerRepo    repository.PartnerRepository
+	voucherRepo    repository.VoucherRepository
+	ledgerRepo     repository.LedgerRepository
+	accountService AccountService
+	partnerService PartnerService
+	voucherService VoucherService
+	settings       CompanySettingsService
+}
+
+// NewLegacyImportService creates a new LegacyImportService
+func NewLegacyImportService(
+	accountRepo repository.AccountRepository,
+	partnerRepo repository.PartnerRepository,
+	voucherRepo repository.VoucherRepository,
+	ledgerRepo repository.LedgerRepository,
+	accountService AccountService,
+	partnerService PartnerService,
+	voucherService VoucherService,
+	settings CompanySettingsService,
+) LegacyImportService {
+	return &legacyImportService{
+		accountRepo:    accountRepo,
+		partnerRepo:    partnerRepo,
+		voucherRepo:    voucherRepo,
+		ledgerRepo:     ledgerRepo,
+		accountService: accountService,
+		partnerService: partnerService,
+		voucherService: voucherService,
+		settings:       settings,
+	}
+}
+
+// Formats lists the supported legacy packages
+func (s *legacyImportService) Formats() []migrate.Adapter {
+	return migrate.Adapters()
+}
+
+// Import runs one step of the wizard
+func (s *legacyImportService) Import(ctx context.Context, req LegacyImportRequest) (*migrate.Result, error) {
+	if !req.Dataset.IsValid() {
+		return nil, migrate.ErrUnknownDataset
+	}
+	adapter, err := migrate.AdapterFor(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := adapter.Parse(req.Dataset, req.Data, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &legacyImport{
+		service: s,
+		req:     req,
+		adapter: adapter,
+		batch:   batch,
+		result: &migrate.Result{
+			Format:  req.Format,
+			Dataset: req.Dataset,
+			DryRun:  req.DryRun,
+			Rows:    batch.Rows,
+			Records: batch.Records(),
+			Issues:  batch.Issues,
+		},
+	}
+	if err := imp.load(ctx); err != nil {
+		return nil, err
+	}
+
+	switch req.Dataset {
+	case migrate.DatasetAccounts:
+		err = imp.importAccounts(ctx)
+	case migrate.DatasetPartners:
+		err = imp.importPartners(ctx)
+	case migrate.DatasetOpeningBalances:
+		err = imp.importOpeningBalances(ctx)
+	case migrate.DatasetVouchers:
+		err = imp.importVouchers(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return imp.result, nil
+}
+
+// legacyImport is the state of one Import call
+type legacyImport struct {
+	service *legacyImportService
+	req     LegacyImportRequest
+	adapter migrate.Adapter
+	batch   *migrate.Batch
+	result  *migrate.Result
+
+	accounts     map[string]*domain.Account
+	accountsByID map[uuid.UUID]*domain.Account
+	partners     map[string]*domain.Partner
+}
+
+// load reads the company's accounts and partners, keyed by code
+func (imp *legacyImport) load(ctx context.Context) error {
+	accounts, _, err := imp.service.accountRepo.FindAll(ctx, repository.AccountFilter{CompanyID: imp.req.CompanyID})
+	if err != nil {
+		return err
+	}
+	imp.accounts = make(map[string]*domain.Account, len(accounts))
+	imp.accountsByID = make(map[uuid.UUID]*domain.Account, len(accounts))
+	for i := range accounts {
+		imp.accounts[accounts[i].Code] = &accounts[i]
+		imp.accountsByID[accounts[i].ID] = &accounts[i]
+	}
+
+	partners, _, err := imp.service.partnerRepo.List(ctx, &repository.PartnerFilter{CompanyID: imp.req.CompanyID})
+	if err != nil {
+		return err
+	}
+	imp.partners = make(map[string]*domain.Partner, len(partners))
+	for i := range partners {
+		imp.partners[partners[i].Code] = &partners[i]
+	}
+	return nil
+}
+
+func (imp *legacyImport) addError(row int, field, message string) {
+	imp.result.Issues = append(imp.result.Issues, migrate.Issue{Row: row, Field: field, Severity: migrate.SeverityError, Message: message})
+}
+
+func (imp *legacyImport) addWarning(row int, field, message string) {
+	imp.result.Issues = append(imp.result.Issues, migrate.Issue{Row: row, Field: field, Severity: migrate.SeverityWarning, Message: message})
+}
+
+// shouldApply reports whether records are saved: not in a dry run, and only
+// when validation found no errors so that a file is imported whole or not at all
+func (imp *legacyImport) shouldApply() bool {
+	return !imp.req.DryRun && !imp.result.HasErrors()
+}
+
+// ============================================================================
+// Accounts and partners
+// ============================================================================
+
+func (imp *legacyImport) importAccounts(ctx context.Context) error {
+	seen := make(map[string]int)
+	var create []domain.Account
+	for _, a := range imp.batch.Accounts {
+		if row, ok := seen[a.Code]; ok {
+			imp.addError(a.Row, "code", fmt.Sprintf("account code %s is repeated (first on row %d)", a.Code, row))
+			continue
+		}
+		seen[a.Code] = a.Row
+		if _, ok := imp.accounts[a.Code]; ok {
+			imp.addWarning(a.Row, "code", fmt.Sprintf("account %s already exists and is skipped", a.Code))
+			imp.result.Skipped++
+			continue
+		}
+		create = append(create, domain.Account{
+			TenantModel:        domain.TenantModel{CompanyID: imp.req.CompanyID},
+			Code:               a.Code,
+			Name:               a.Name,
+			NameEn:             a.NameEn,
+			AccountType:        a.Type,
+			AccountCategory:    a.Category,
+			IsActive:           true,
+			AllowDirectPosting: true,
+		})
+	}
+	if !imp.shouldApply() {
+		return nil
+	}
+
+	for i := range create {
+		if err := imp.service.accountService.Create(ctx, &create[i]); err != nil {
+			imp.addError(seen[create[i].Code], "code", fmt.Sprintf("account %s: %s", create[i].Code, err.Error()))
+			continue
+		}
+		imp.result.Created++
+	}
+	imp.result.Applied = true
+	return nil
+}
+
+func (imp *legacyImport) importPartners(ctx context.Context) error {
+	seen := make(map[string]int)
+	businessNumbers := make(map[string]string)
+	for _, p := range imp.partners {
+		if p.BusinessNumber != "" {
+			businessNumbers[p.BusinessNumber] = p.Code
+		}
+	}
+
+	var create []domain.Partner
+	for _, p := range imp.batch.Partners {
+		if row, ok := seen[p.Code]; ok {
+			imp.addError(p.Row, "code", fmt.Sprintf("partner code %s is repeated (first on row %d)", p.Code, row))
+			continue
+		}
+		seen[p.Code] = p.Row
+		if _, ok := imp.partners[p.Code]; ok {
+			imp.addWarning(p.Row, "code", fmt.Sprintf("partner %s already exists and is skipped", p.Code))
+			imp.result.Skipped++
+			continue
+		}
+
+		// Legacy packages allow one business number on several partners (e.g., branches)
+		if code, ok := businessNumbers[p.BusinessNumber]; ok && p.BusinessNumber != "" {
+			imp.addWarning(p.Row, "business_number", fmt.Sprintf("business number is already used by partner %s; %s is imported without it", code, p.Code))
+			p.BusinessNumber = ""
+		}
+		if p.BusinessNumber != "" {
+			businessNumbers[p.BusinessNumber] = p.Code
+		}
+
+		create = append(create, domain.Partner{
+			TenantModel:    domain.TenantModel{CompanyID: imp.req.CompanyID},
+			Code:           p.Code,
+			Name:           p.Name,
+			BusinessNumber: p.BusinessNumber,
+			PartnerType:    p.PartnerType,
+			Representative: p.Representative,
+			Phone:          p.Phone,
+			Fax:            p.Fax,
+			Email:          p.Email,
+			ZipCode:        p.ZipCode,
+			Address:        p.Address,
+			AddressDetail:  p.AddressDetail,
+			IsActive:       true,
+		})
+	}
+	if !imp.shouldApply() {
+		return nil
+	}
+
+	for i := range create {
+		if err := imp.service.partnerService.Create(ctx, &create[i]); err != nil {
+			imp.addError(seen[create[i].Code], "code", fmt.Sprintf("partner %s: %s", create[i].Code, err.Error()))
+			continue
+		}
+		imp.result.Created++
+	}
+	imp.result.Applied = true
+	return nil
+}
+
+// ============================================================================
+// Opening balances and vouchers
+// ============================================================================
+
+// entry builds a voucher entry for a line, reporting unknown codes and
+// accounts that do not accept postings
+func (imp *legacyImport) entry(row int, accountCode, partnerCode, description string, debit, credit float64) (domain.VoucherEntry, bool) {
+	account, ok := imp.accounts[accountCode]
+	if !ok {
+		imp.addError(row, "account_code", fmt.Sprintf("account %s does not exist; import the chart of accounts first", accountCode))
+		return domain.VoucherEntry{}, false
+	}
+	if !account.CanPost() {
+		imp.addError(row, "account_code", fmt.Sprintf("account %s: %s", accountCode, domain.ErrControlAccountPosting.Error()))
+		return domain.VoucherEntry{}, false
+	}
+
+	entry := domain.VoucherEntry{
+		CompanyID:    imp.req.CompanyID,
+		AccountID:    account.ID,
+		Description:  truncateRunes(description, 200),
+		DebitAmount:  debit,
+		CreditAmount: credit,
+	}
+	if partnerCode != "" {
+		partner, ok := imp.partners[partnerCode]
+		if !ok {
+			imp.addError(row, "partner_code", fmt.Sprintf("partner %s does not exist; import partners first", partnerCode))
+			return domain.VoucherEntry{}, false
+		}
+		entry.PartnerID = &partner.ID
+	}
+	return entry, true
+}
+
+// checkPostingRules reports entries that break their account's posting rules
+func (imp *legacyImport) checkPostingRules(row int, entries []domain.VoucherEntry, voucherType domain.VoucherType) bool {
+	ok := true
+	for i := range entries {
+		if err := checkPostingRules(imp.accountsByID[entries[i].AccountID], &entries[i], i+1, voucherType); err != nil {
+			imp.addError(row, "account_code", err.Error())
+			ok = false
+		}
+	}
+	return ok
+}
+
+// importOpeningBalances posts one 전기이월 voucher on the first day of the fiscal year
+func (imp *legacyImport) importOpeningBalances(ctx context.Context) error {
+	if imp.req.Options.Year == 0 {
+		imp.addError(0, "fiscal_year", "the fiscal year of the opening balances is required")
+		return nil
+	}
+	settings, err := imp.service.settings.Get(ctx, imp.req.CompanyID)
+	if err != nil {
+		return err
+	}
+	date := time.Date(imp.req.Options.Year, time.Month(settings.FiscalYearStart), 1, 0, 0, 0, 0, time.UTC)
+
+	var entries []domain.VoucherEntry
+	var totalDebit, totalCredit float64
+	for _, b := range imp.batch.OpeningBalances {
+		debit, credit := b.Debit, b.Credit
+		if b.Amount != 0 {
+			// A single amount is on the account's normal side; negative is the other side
+			account, ok := imp.accounts[b.AccountCode]
+			if ok && account.IsCreditNature() == (b.Amount > 0) {
+				credit = math.Abs(b.Amount)
+			} else {
+				debit = math.Abs(b.Amount)
+			}
+		}
+
+		// An account with balances on both sides is imported as its net balance
+		net := debit - credit
+		debit, credit = math.Max(net, 0), math.Max(-net, 0)
+		if net == 0 {
+			imp.addWarning(b.Row, "amount", fmt.Sprintf("account %s nets to zero and is skipped", b.AccountCode))
+			continue
+		}
+
+		entry, ok := imp.entry(b.Row, b.AccountCode, b.PartnerCode, "전기이월", debit, credit)
+		if !ok {
+			continue
+		}
+		entries = append(entries, entry)
+		totalDebit += debit
+		totalCredit += credit
+	}
+	if len(entries) > 0 && math.Abs(totalDebit-totalCredit) > 0.005 {
+		imp.addError(0, "amount", fmt.Sprintf("opening balances are unbalanced: debit %.0f, credit %.0f", totalDebit, totalCredit))
+	}
+	if len(entries) > 0 {
+		imp.checkPostingRules(0, entries, domain.VoucherTypeAdjustment)
+	}
+	if !imp.shouldApply() {
+		return nil
+	}
+	if len(entries) == 0 {
+		imp.result.Applied = true
+		return nil
+	}
+
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: imp.req.CompanyID},
+		VoucherDate:   date,
+		VoucherType:   domain.VoucherTypeAdjustment,
+		Description:   fmt.Sprintf("전기이월 (%s)", imp.adapter.Name()),
+		ReferenceType: legacyImportReferenceType,
+		CreatedBy:     &imp.req.UserID,
+		Entries:       entries,
+	}
+	if err := imp.createPosted(ctx, voucher); err != nil {
+		imp.addError(0, "", fmt.Sprintf("opening balance voucher: %s", err.Error()))
+		return nil
+	}
+	imp.result.Created = len(entries)
+	imp.result.Applied = true
+	return imp.service.ledgerRepo.RecalculateBalances(ctx, imp.req.CompanyID, date.Year(), int(date.Month()))
+}
+
+// importVouchers posts each historical voucher with its original date
+func (imp *legacyImport) importVouchers(ctx context.Context) error {
+	type pending struct {
+		row     int
+		voucher *domain.Voucher
+	}
+	var create []pending
+	for _, v := range imp.batch.Vouchers {
+		entries := make([]domain.VoucherEntry, 0, len(v.Lines))
+		ok := true
+		description := ""
+		for _, l := range v.Lines {
+			entry, valid := imp.entry(l.Row, l.AccountCode, l.PartnerCode, l.Description, l.Debit, l.Credit)
+			ok = ok && valid
+			entries = append(entries, entry)
+			if description == "" {
+				description = l.Description
+			}
+		}
+		if !ok || !imp.checkPostingRules(v.Row, entries, domain.VoucherTypeGeneral) {
+			continue
+		}
+		if description == "" {
+			description = fmt.Sprintf("%s #%s", imp.adapter.Name(), v.Number)
+		}
+
+		create = append(create, pending{row: v.Row, voucher: &domain.Voucher{
+			TenantModel:   domain.TenantModel{CompanyID: imp.req.CompanyID},
+			VoucherDate:   v.Date,
+			VoucherType:   domain.VoucherTypeGeneral,
+			Description:   truncateRunes(description, 500),
+			ReferenceType: legacyImportReferenceType,
+			CreatedBy:     &imp.req.UserID,
+			Entries:       entries,
+		}})
+	}
+	if !imp.shouldApply() {
+		return nil
+	}
+
+	var earliest time.Time
+	for _, p := range create {
+		if err := imp.createPosted(ctx, p.voucher); err != nil {
+			imp.addError(p.row, "", fmt.Sprintf("voucher %s: %s", p.voucher.VoucherDate.Format("2006-01-02"), err.Error()))
+			continue
+		}
+		imp.result.Created++
+		if earliest.IsZero() || p.voucher.VoucherDate.Before(earliest) {
+			earliest = p.voucher.VoucherDate
+		}
+	}
+	imp.result.Applied = true
+	if earliest.IsZero() {
+		return nil
+	}
+	return imp.service.ledgerRepo.RecalculateBalances(ctx, imp.req.CompanyID, earliest.Year(), int(earliest.Month()))
+}
+
+// createPosted creates the voucher and posts it directly: imported vouchers
+// were already approved in the legacy system
+func (imp *legacyImport) createPosted(ctx context.Context, voucher *domain.Voucher) error {
+	if err := imp.service.voucherService.Create(ctx, voucher); err != nil {
+		return err
+	}
+	if err := voucher.PostWithoutApproval(imp.req.UserID); err != nil {
+		return err
+	}
+	return imp.service.voucherRepo.UpdateStatus(ctx, voucher)
+}
+
+// truncateRunes shortens s to at most n characters
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
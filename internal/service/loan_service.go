@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// loanReferenceType marks vouchers generated for a loan
+const loanReferenceType = "loan"
+
+// LoanRepaymentInput describes a payment to the lender. Omitted amounts default
+// to the scheduled principal and the interest since the last repayment.
+type LoanRepaymentInput struct {
+	RepaymentDate    time.Time
+	Principal        *int64
+	Interest         *int64
+	PaymentAccountID *uuid.UUID // defaults to the settlement account of the loan
+}
+
+// LoanService defines the interface for loans and borrowings
+type LoanService interface {
+	// Create registers a loan and generates its repayment schedule
+	Create(ctx context.Context, loan *domain.Loan) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error)
+	List(ctx context.Context, filter repository.LoanFilter) ([]domain.Loan, int64, error)
+
+	// Repay records a repayment and generates its draft payment voucher, debiting
+	// the loan account with the principal and interest expense with the interest
+	Repay(ctx context.Context, companyID, userID, loanID uuid.UUID, input LoanRepaymentInput) (*domain.LoanRepayment, error)
+	ListRepayments(ctx context.Context, companyID, loanID uuid.UUID) ([]domain.LoanRepayment, error)
+
+	// AccrueInterest generates draft auto-reversing vouchers accruing the unpaid
+	// interest of the active loans at periodEnd, for one company or for all when
+	// companyID is nil. Loans already accrued for the month are skipped.
+	AccrueInterest(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.LoanAccrualRun, error)
+
+	// Outstanding reports the principal owed on each loan at the date
+	Outstanding(ctx context.Context, companyID uuid.UUID, asOf time.Time) (*domain.LoanOutstandingReport, error)
+}
+
+// loanService implements LoanService
+type loanService struct {
+	repo           repository.LoanRepository
+	accountRepo    repository.AccountRepository
+	voucherService VoucherService
+}
+
+// NewLoanService creates a new LoanService
+func NewLoanService(repo repository.LoanRepository, accountRepo repository.AccountRepository, voucherService VoucherService) LoanService {
+	return &loanService{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Create validates the terms and accounts of the loan and stores it with its schedule
+func (s *loanService) Create(ctx context.Context, loan *domain.Loan) error {
+	if err := loan.Validate(); err != nil {
+		return err
+	}
+	exists, err := s.repo.ExistsLoanNo(ctx, loan.CompanyID, loan.LoanNo)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrLoanNoExists
+	}
+	for _, accountID := range []uuid.UUID{loan.LoanAccountID, loan.InterestExpenseAccountID, loan.AccruedInterestAccountID, loan.SettlementAccountID} {
+		if _, err := s.accountRepo.FindByID(ctx, loan.CompanyID, accountID); err != nil {
+			return err
+		}
+	}
+
+	loan.OutstandingPrincipal = loan.Principal
+	loan.InterestPaidThrough = loan.StartDate
+	loan.AccruedThrough = nil
+	loan.Status = domain.LoanStatusActive
+	loan.Installments = loan.BuildSchedule()
+	return s.repo.Create(ctx, loan)
+}
+
+// GetByID returns a loan with its schedule
+func (s *loanService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Loan, error) {
+	return s.repo.GetByID(ctx, companyID, id)
+}
+
+// List lists loans
+func (s *loanService) List(ctx context.Context, filter repository.LoanFilter) ([]domain.Loan, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Repay records the repayment with its voucher. The loan is locked for the
+// repayment so that concurrent repayments see each other's balances, and the
+// repayment is recorded before its voucher is created so that a failure
+// leaves neither behind.
+func (s *loanService) Repay(ctx context.Context, companyID, userID, loanID uuid.UUID, input LoanRepaymentInput) (*domain.LoanRepayment, error) {
+	if input.RepaymentDate.IsZero() {
+		return nil, domain.ErrInvalidLoanRepayment
+	}
+
+	var repayment *domain.LoanRepayment
+	err := s.repo.WithTransaction(ctx, func(repo repository.LoanRepository) error {
+		loan, err := repo.GetByIDForUpdate(ctx, companyID, loanID)
+		if err != nil {
+			return err
+		}
+
+		repayment = &domain.LoanRepayment{
+			CompanyID:     companyID,
+			LoanID:        loan.ID,
+			RepaymentDate: input.RepaymentDate,
+			Principal:     loan.ScheduledPrincipal(input.RepaymentDate),
+			Interest:      loan.InterestFor(loan.InterestPaidThrough, input.RepaymentDate),
+			CreatedBy:     &userID,
+		}
+		if input.Principal != nil {
+			repayment.Principal = *input.Principal
+		}
+		if input.Interest != nil {
+			repayment.Interest = *input.Interest
+		}
+		paymentAccountID := loan.SettlementAccountID
+		if input.PaymentAccountID != nil {
+			paymentAccountID = *input.PaymentAccountID
+		}
+
+		if err := loan.ApplyRepayment(repayment); err != nil {
+			return err
+		}
+		loan.UpdatedBy = &userID
+		if err := repo.RecordRepayment(ctx, loan, repayment); err != nil {
+			return err
+		}
+
+		var entries []domain.VoucherEntry
+		if repayment.Principal > 0 {
+			entry := s.entry(loan, loan.LoanAccountID, "차입금 상환")
+			entry.SetDebit(float64(repayment.Principal))
+			entries = append(entries, entry)
+		}
+		if repayment.Interest > 0 {
+			entry := s.entry(loan, loan.InterestExpenseAccountID, "차입금 이자")
+			entry.SetDebit(float64(repayment.Interest))
+			entries = append(entries, entry)
+		}
+		payment := s.entry(loan, paymentAccountID, "차입금 상환")
+		payment.SetCredit(float64(repayment.Principal + repayment.Interest))
+		entries = append(entries, payment)
+
+		voucher := &domain.Voucher{
+			TenantModel:   domain.TenantModel{CompanyID: companyID},
+			VoucherDate:   repayment.RepaymentDate,
+			VoucherType:   domain.VoucherTypePayment,
+			Description:   fmt.Sprintf("차입금 상환 %s (%s)", loan.LoanNo, loan.Lender),
+			ReferenceType: loanReferenceType,
+			ReferenceID:   &loan.ID,
+			Entries:       entries,
+			CreatedBy:     &userID,
+		}
+		if err := s.voucherService.Create(ctx, voucher); err != nil {
+			return fmt.Errorf("failed to create repayment voucher: %w", err)
+		}
+		repayment.VoucherID = &voucher.ID
+		return repo.LinkRepaymentVoucher(ctx, repayment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repayment, nil
+}
+
+// ListRepayments lists the repayments of a loan
+func (s *loanService) ListRepayments(ctx context.Context, companyID, loanID uuid.UUID) ([]domain.LoanRepayment, error) {
+	if _, err := s.repo.GetByID(ctx, companyID, loanID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRepayments(ctx, companyID, loanID)
+}
+
+// AccrueInterest books the interest unpaid at the month end of each loan. The
+// vouchers reverse on the first day of the next month once posted, when the
+// interest charged by the bank is booked with the repayment.
+func (s *loanService) AccrueInterest(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.LoanAccrualRun, error) {
+	loans, err := s.repo.ListForAccrual(ctx, companyID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.LoanAccrualRun{PeriodEnd: periodEnd, Vouchers: []uuid.UUID{}}
+	var errs []error
+	for i := range loans {
+		loan := &loans[i]
+		if !loan.NeedsAccrual(periodEnd) {
+			continue
+		}
+		run.Checked++
+
+		accrual := &domain.LoanInterestAccrual{
+			CompanyID: loan.CompanyID,
+			LoanID:    loan.ID,
+			PeriodEnd: periodEnd,
+			Amount:    loan.InterestFor(loan.InterestPaidThrough, periodEnd),
+		}
+		if accrual.Amount > 0 {
+			voucher, err := s.accrualVoucher(ctx, loan, accrual)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("loan %s: %w", loan.LoanNo, err))
+				continue
+			}
+			accrual.VoucherID = &voucher.ID
+			run.Accrued++
+			run.Vouchers = append(run.Vouchers, voucher.ID)
+		}
+
+		loan.AccruedThrough = &periodEnd
+		if err := s.repo.RecordAccrual(ctx, loan, accrual); err != nil {
+			errs = append(errs, fmt.Errorf("loan %s: %w", loan.LoanNo, err))
+		}
+	}
+	return run, errors.Join(errs...)
+}
+
+// accrualVoucher generates the draft accrual voucher of the month end:
+// interest expense against accrued interest payable
+func (s *loanService) accrualVoucher(ctx context.Context, loan *domain.Loan, accrual *domain.LoanInterestAccrual) (*domain.Voucher, error) {
+	expense := s.entry(loan, loan.InterestExpenseAccountID, "미지급이자 계상")
+	expense.SetDebit(float64(accrual.Amount))
+	payable := s.entry(loan, loan.AccruedInterestAccountID, "미지급이자 계상")
+	payable.SetCredit(float64(accrual.Amount))
+
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: loan.CompanyID},
+		VoucherDate:   accrual.PeriodEnd,
+		VoucherType:   domain.VoucherTypeAdjustment,
+		Description:   fmt.Sprintf("차입금 미지급이자 %s (%s) %s", loan.LoanNo, loan.Lender, accrual.PeriodEnd.Format("2006-01")),
+		ReferenceType: loanReferenceType,
+		ReferenceID:   &loan.ID,
+		AutoReverse:   true,
+		Entries:       []domain.VoucherEntry{expense, payable},
+		CreatedBy:     loan.CreatedBy,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// entry returns a voucher line of the loan on the account
+func (s *loanService) entry(loan *domain.Loan, accountID uuid.UUID, description string) domain.VoucherEntry {
+	return domain.VoucherEntry{
+		CompanyID:   loan.CompanyID,
+		AccountID:   accountID,
+		Description: description,
+		PartnerID:   loan.PartnerID,
+	}
+}
+
+// Outstanding reports the loans started by the date
+func (s *loanService) Outstanding(ctx context.Context, companyID uuid.UUID, asOf time.Time) (*domain.LoanOutstandingReport, error) {
+	loans, err := s.repo.ListStartedBy(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	repayments, err := s.repo.ListRepaymentsUntil(ctx, companyID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.LoanOutstandingReport{AsOf: asOf, Loans: []domain.LoanOutstanding{}}
+	for i := range loans {
+		row := domain.NewLoanOutstanding(&loans[i], repayments, asOf)
+		if row.Outstanding <= 0 {
+			continue
+		}
+		report.Loans = append(report.Loans, row)
+		report.TotalOutstanding += row.Outstanding
+		report.TotalCurrentPortion += row.CurrentPortion
+		report.TotalAccruedInterest += row.AccruedInterest
+	}
+	return report, nil
+}
@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// newServiceTestLoan returns an active 3,000,000 won loan at 3.65% repaid in
+// three monthly installments, so that a day of interest is 0.01% of the principal
+func newServiceTestLoan(companyID uuid.UUID) *domain.Loan {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	userID := newTestUserID()
+	loan := &domain.Loan{
+		TenantModel:              domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		LoanNo:                   "L-001",
+		Lender:                   "국민은행",
+		Principal:                3000000,
+		InterestRate:             3.65,
+		StartDate:                start,
+		MaturityDate:             time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+		RepaymentMethod:          domain.LoanRepaymentEqualPrincipal,
+		LoanAccountID:            uuid.New(),
+		InterestExpenseAccountID: uuid.New(),
+		AccruedInterestAccountID: uuid.New(),
+		SettlementAccountID:      uuid.New(),
+		OutstandingPrincipal:     3000000,
+		InterestPaidThrough:      start,
+		Status:                   domain.LoanStatusActive,
+		CreatedBy:                &userID,
+	}
+	loan.Installments = loan.BuildSchedule()
+	return loan
+}
+
+func TestLoanService_Repay(t *testing.T) {
+	repo := new(mocks.MockLoanRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewLoanService(repo, nil, voucherService)
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	loan := newServiceTestLoan(companyID)
+	repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("GetByIDForUpdate", mock.Anything, companyID, loan.ID).Return(loan, nil).Once()
+
+	var voucher *domain.Voucher
+	repo.On("RecordRepayment", mock.Anything, loan, mock.AnythingOfType("*domain.LoanRepayment")).Run(func(args mock.Arguments) {
+		// The repayment is recorded before its voucher exists
+		assert.Nil(t, voucher)
+		assert.Nil(t, args.Get(2).(*domain.LoanRepayment).VoucherID)
+	}).Return(nil).Once()
+	voucherService.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		voucher = args.Get(1).(*domain.Voucher)
+		voucher.ID = uuid.New()
+	}).Return(nil).Once()
+	repo.On("LinkRepaymentVoucher", mock.Anything, mock.AnythingOfType("*domain.LoanRepayment")).Return(nil).Once()
+
+	// The scheduled principal and 29 days of interest are paid by default
+	repaymentDate := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	repayment, err := svc.Repay(context.Background(), companyID, userID, loan.ID, service.LoanRepaymentInput{RepaymentDate: repaymentDate})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000000), repayment.Principal)
+	assert.Equal(t, int64(8700), repayment.Interest)
+	assert.Equal(t, &voucher.ID, repayment.VoucherID)
+
+	assert.Equal(t, int64(2000000), loan.OutstandingPrincipal)
+	assert.Equal(t, repaymentDate, loan.InterestPaidThrough)
+
+	require.NotNil(t, voucher)
+	assert.Equal(t, domain.VoucherTypePayment, voucher.VoucherType)
+	assert.Equal(t, &loan.ID, voucher.ReferenceID)
+	require.Len(t, voucher.Entries, 3)
+	assert.Equal(t, loan.LoanAccountID, voucher.Entries[0].AccountID)
+	assert.Equal(t, 1000000.0, voucher.Entries[0].DebitAmount)
+	assert.Equal(t, loan.InterestExpenseAccountID, voucher.Entries[1].AccountID)
+	assert.Equal(t, 8700.0, voucher.Entries[1].DebitAmount)
+	assert.Equal(t, loan.SettlementAccountID, voucher.Entries[2].AccountID)
+	assert.Equal(t, 1008700.0, voucher.Entries[2].CreditAmount)
+
+	repo.AssertExpectations(t)
+	voucherService.AssertExpectations(t)
+}
+
+func TestLoanService_RepayTooLarge(t *testing.T) {
+	repo := new(mocks.MockLoanRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewLoanService(repo, nil, voucherService)
+
+	companyID := newTestCompanyID()
+	loan := newServiceTestLoan(companyID)
+	repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("GetByIDForUpdate", mock.Anything, companyID, loan.ID).Return(loan, nil).Once()
+
+	principal := int64(3000001)
+	_, err := svc.Repay(context.Background(), companyID, newTestUserID(), loan.ID, service.LoanRepaymentInput{
+		RepaymentDate: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		Principal:     &principal,
+	})
+	assert.ErrorIs(t, err, domain.ErrLoanRepaymentTooLarge)
+	voucherService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "RecordRepayment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoanService_RepayConcurrently(t *testing.T) {
+	companyID := newTestCompanyID()
+	repaymentDate := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("checks what concurrent repayments left", func(t *testing.T) {
+		repo := new(mocks.MockLoanRepository)
+		voucherService := new(mocks.MockVoucherService)
+		svc := service.NewLoanService(repo, nil, voucherService)
+
+		// Another repayment took all but 500,000 won before the loan was locked
+		loan := newServiceTestLoan(companyID)
+		require.NoError(t, loan.ApplyRepayment(&domain.LoanRepayment{RepaymentDate: repaymentDate, Principal: 2500000}))
+		repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("GetByIDForUpdate", mock.Anything, companyID, loan.ID).Return(loan, nil).Once()
+
+		principal := int64(1000000)
+		_, err := svc.Repay(context.Background(), companyID, newTestUserID(), loan.ID, service.LoanRepaymentInput{
+			RepaymentDate: repaymentDate,
+			Principal:     &principal,
+		})
+		assert.ErrorIs(t, err, domain.ErrLoanRepaymentTooLarge)
+		assert.Equal(t, int64(500000), loan.OutstandingPrincipal)
+		voucherService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "RecordRepayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves no voucher link when the voucher fails", func(t *testing.T) {
+		repo := new(mocks.MockLoanRepository)
+		voucherService := new(mocks.MockVoucherService)
+		svc := service.NewLoanService(repo, nil, voucherService)
+
+		loan := newServiceTestLoan(companyID)
+		createErr := errors.New("voucher failed")
+		repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("GetByIDForUpdate", mock.Anything, companyID, loan.ID).Return(loan, nil).Once()
+		repo.On("RecordRepayment", mock.Anything, loan, mock.AnythingOfType("*domain.LoanRepayment")).Return(nil).Once()
+		voucherService.On("Create", mock.Anything, mock.Anything).Return(createErr).Once()
+
+		// The failure rolls the recorded repayment back with the transaction
+		_, err := svc.Repay(context.Background(), companyID, newTestUserID(), loan.ID, service.LoanRepaymentInput{RepaymentDate: repaymentDate})
+		assert.ErrorIs(t, err, createErr)
+		repo.AssertNotCalled(t, "LinkRepaymentVoucher", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestLoanService_AccrueInterest(t *testing.T) {
+	repo := new(mocks.MockLoanRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewLoanService(repo, nil, voucherService)
+
+	periodEnd := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	loan := newServiceTestLoan(newTestCompanyID())
+	failing := newServiceTestLoan(newTestCompanyID())
+	failing.LoanNo = "L-002"
+	free := newServiceTestLoan(newTestCompanyID())
+	free.InterestRate = 0
+	repo.On("ListForAccrual", mock.Anything, (*uuid.UUID)(nil), periodEnd).Return([]domain.Loan{*loan, *failing, *free}, nil)
+
+	var voucher *domain.Voucher
+	voucherService.On("Create", mock.Anything, mock.MatchedBy(func(v *domain.Voucher) bool { return v.CompanyID == loan.CompanyID })).
+		Run(func(args mock.Arguments) {
+			voucher = args.Get(1).(*domain.Voucher)
+			voucher.ID = uuid.New()
+		}).Return(nil).Once()
+	voucherService.On("Create", mock.Anything, mock.MatchedBy(func(v *domain.Voucher) bool { return v.CompanyID == failing.CompanyID })).
+		Return(errors.New("period closed")).Once()
+
+	var accruals []*domain.LoanInterestAccrual
+	repo.On("RecordAccrual", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Equal(t, periodEnd, *args.Get(1).(*domain.Loan).AccruedThrough)
+		accruals = append(accruals, args.Get(2).(*domain.LoanInterestAccrual))
+	}).Return(nil)
+
+	run, err := svc.AccrueInterest(context.Background(), nil, periodEnd)
+	assert.ErrorContains(t, err, "L-002")
+	require.NotNil(t, run)
+	assert.Equal(t, 3, run.Checked)
+	assert.Equal(t, 1, run.Accrued)
+	assert.Equal(t, []uuid.UUID{voucher.ID}, run.Vouchers)
+
+	// 29 days of interest, reversed in the next month
+	require.NotNil(t, voucher)
+	assert.True(t, voucher.AutoReverse)
+	assert.Equal(t, periodEnd, voucher.VoucherDate)
+	assert.Equal(t, loan.CreatedBy, voucher.CreatedBy)
+	require.Len(t, voucher.Entries, 2)
+	assert.Equal(t, loan.InterestExpenseAccountID, voucher.Entries[0].AccountID)
+	assert.Equal(t, 8700.0, voucher.Entries[0].DebitAmount)
+	assert.Equal(t, loan.AccruedInterestAccountID, voucher.Entries[1].AccountID)
+	assert.Equal(t, 8700.0, voucher.Entries[1].CreditAmount)
+
+	// The failed loan is left for the next run; the interest-free one is marked done
+	require.Len(t, accruals, 2)
+	assert.Equal(t, int64(8700), accruals[0].Amount)
+	assert.Equal(t, &voucher.ID, accruals[0].VoucherID)
+	assert.Equal(t, int64(0), accruals[1].Amount)
+	assert.Nil(t, accruals[1].VoucherID)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// usageExportBatchSize is the number of records exported per worker run
+const usageExportBatchSize = 100
+
+// MeteringService defines the interface for usage metering and billing export
+type MeteringService interface {
+	// Usage returns the company's usage of the months from..to, newest first
+	Usage(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.UsageMonth, error)
+
+	// Aggregate brings the usage of the current and the previous month up to
+	// date and samples the active users of the current month. It returns the
+	// number of records changed.
+	Aggregate(ctx context.Context, asOf time.Time) (int64, error)
+
+	// Export reports the usage of closed months to the billing provider and
+	// returns the number of records exported
+	Export(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// meteringService implements MeteringService
+type meteringService struct {
+	repo    repository.UsageRepository
+	billing provider.BillingProvider
+}
+
+// NewMeteringService creates a new MeteringService. Usage is not exported
+// when billing is nil.
+func NewMeteringService(repo repository.UsageRepository, billing provider.BillingProvider) MeteringService {
+	return &meteringService{repo: repo, billing: billing}
+}
+
+// Usage returns the monthly usage of the company
+func (s *meteringService) Usage(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.UsageMonth, error) {
+	from, to = domain.UsagePeriodOf(from), domain.UsagePeriodOf(to)
+	if to.Before(from) || !from.AddDate(0, domain.MaxUsagePeriodMonths, 0).After(to) {
+		return nil, domain.ErrInvalidUsagePeriod
+	}
+	records, err := s.repo.FindRecords(ctx, companyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.GroupUsageByMonth(records, from, to), nil
+}
+
+// Aggregate recounts the open months from their events. The previous month
+// is recounted too, so that its last events are included before its export.
+func (s *meteringService) Aggregate(ctx context.Context, asOf time.Time) (int64, error) {
+	current := domain.UsagePeriodOf(asOf)
+	var changed int64
+	for _, period := range []time.Time{current.AddDate(0, -1, 0), current} {
+		n, err := s.repo.AggregateEvents(ctx, period)
+		if err != nil {
+			return changed, fmt.Errorf("usage aggregation of %s: %w", period.Format("2006-01"), err)
+		}
+		changed += n
+	}
+	n, err := s.repo.SampleActiveUsers(ctx, current)
+	if err != nil {
+		return changed, fmt.Errorf("active user sampling: %w", err)
+	}
+	return changed + n, nil
+}
+
+// Export reports the records of closed months one at a time. Failures are
+// stored on the record and retried on the next run; they are returned as a
+// joined error for logging.
+func (s *meteringService) Export(ctx context.Context, asOf time.Time) (int, error) {
+	if s.billing == nil {
+		return 0, nil
+	}
+	pending, err := s.repo.ListPendingExports(ctx, domain.UsagePeriodOf(asOf), usageExportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	var errs []error
+	for i := range pending {
+		record := &pending[i].UsageRecord
+		reference, err := s.billing.ReportUsage(ctx, &provider.UsageReport{
+			CustomerID:     pending[i].BillingCustomerID,
+			Metric:         string(record.Metric),
+			Quantity:       record.Quantity,
+			PeriodStart:    domain.UsagePeriodOf(record.Period),
+			PeriodEnd:      domain.UsagePeriodOf(record.Period).AddDate(0, 1, 0),
+			IdempotencyKey: record.IdempotencyKey(),
+		})
+		if err != nil {
+			record.ExportError = err.Error()
+			errs = append(errs, fmt.Errorf("usage record %s: %w", record.ID, err))
+		} else {
+			now := time.Now()
+			record.ExportedAt = &now
+			record.ExportReference = reference
+			record.ExportError = ""
+			exported++
+		}
+		if err := s.repo.FinishExport(ctx, record); err != nil {
+			errs = append(errs, fmt.Errorf("usage record %s: %w", record.ID, err))
+		}
+	}
+	return exported, errors.Join(errs...)
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// noteReferenceType marks vouchers generated for a note
+const noteReferenceType = "note"
+
+// NoteDiscountInput describes the discounting of a received note at a bank
+type NoteDiscountInput struct {
+	DiscountDate    time.Time
+	Charge          int64      // 할인료 kept by the bank
+	BankAccountID   uuid.UUID  // account the proceeds are paid into
+	ChargeAccountID *uuid.UUID // 매출채권처분손실, required with a charge
+}
+
+// NoteService defines the interface for the notes register
+type NoteService interface {
+	// Register records a received or issued note and generates its draft
+	// voucher against the receivable or payable it settles
+	Register(ctx context.Context, note *domain.Note) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Note, error)
+	List(ctx context.Context, filter repository.NoteFilter) ([]domain.Note, int64, error)
+
+	// Discount sells a received note to a bank and generates the draft
+	// receipt voucher of the proceeds and the charge
+	Discount(ctx context.Context, companyID, userID, id uuid.UUID, input NoteDiscountInput) (*domain.Note, error)
+	// Settle records the collection or payment of a note at maturity and
+	// generates its draft receipt or payment voucher
+	Settle(ctx context.Context, companyID, userID, id uuid.UUID, settledDate time.Time, bankAccountID uuid.UUID) (*domain.Note, error)
+
+	// AlertMaturities emails the admins of each company the outstanding notes
+	// maturing within domain.NoteMaturityAlertDays of today, once per note.
+	// It returns the number of notes notified.
+	AlertMaturities(ctx context.Context, today time.Time) (int, error)
+}
+
+// noteService implements NoteService
+type noteService struct {
+	repo           repository.NoteRepository
+	partnerRepo    repository.PartnerRepository
+	accountRepo    repository.AccountRepository
+	bankRepo       repository.BankAccountRepository
+	companyRepo    repository.CompanyRepository
+	userRepo       repository.UserRepository
+	voucherService VoucherService
+	notifications  NotificationService
+	templates      EmailTemplateService
+}
+
+// NewNoteService creates a new NoteService
+func NewNoteService(
+	repo repository.NoteRepository,
+	partnerRepo repository.PartnerRepository,
+	accountRepo repository.AccountRepository,
+	bankRepo repository.BankAccountRepository,
+	companyRepo repository.CompanyRepository,
+	userRepo repository.UserRepository,
+	voucherService VoucherService,
+	notifications NotificationService,
+	templates EmailTemplateService,
+) NoteService {
+	return &noteService{
+		repo:           repo,
+		partnerRepo:    partnerRepo,
+		accountRepo:    accountRepo,
+		bankRepo:       bankRepo,
+		companyRepo:    companyRepo,
+		userRepo:       userRepo,
+		voucherService: voucherService,
+		notifications:  notifications,
+		templates:      templates,
+	}
+}
+
+// Register validates the note, its partner and accounts, stores it and books
+// it: a received note against the receivable, an issued one against the payable
+func (s *noteService) Register(ctx context.Context, note *domain.Note) error {
+	if err := note.Validate(); err != nil {
+		return err
+	}
+	partner, err := s.partnerRepo.GetByID(ctx, note.CompanyID, note.PartnerID)
+	if err != nil {
+		return err
+	}
+	for _, accountID := range []uuid.UUID{note.NoteAccountID, note.CounterAccountID} {
+		if _, err := s.accountRepo.FindByID(ctx, note.CompanyID, accountID); err != nil {
+			return err
+		}
+	}
+	exists, err := s.repo.ExistsNoteNo(ctx, note.CompanyID, note.Direction, note.NoteNo)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrNoteNoExists
+	}
+
+	note.Status = domain.NoteStatusOutstanding
+	if err := s.repo.Create(ctx, note); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("%s %s (%s)", noteLabel(note), note.NoteNo, partner.Name)
+	noteEntry := s.entry(note, note.NoteAccountID, description)
+	counter := s.entry(note, note.CounterAccountID, description)
+	if note.Direction == domain.NoteReceived {
+		noteEntry.SetDebit(float64(note.Amount))
+		counter.SetCredit(float64(note.Amount))
+	} else {
+		counter.SetDebit(float64(note.Amount))
+		noteEntry.SetCredit(float64(note.Amount))
+	}
+	voucher, err := s.createVoucher(ctx, note, note.BookedDate, domain.VoucherTypeGeneral,
+		description, []domain.VoucherEntry{noteEntry, counter}, note.CreatedBy)
+	if err != nil {
+		return err
+	}
+	note.VoucherID = &voucher.ID
+	return s.repo.Update(ctx, note)
+}
+
+// GetByID returns a note
+func (s *noteService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Note, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the notes of the company in maturity order
+func (s *noteService) List(ctx context.Context, filter repository.NoteFilter) ([]domain.Note, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Discount books the proceeds into the bank account and the charge as an
+// expense against the note
+func (s *noteService) Discount(ctx context.Context, companyID, userID, id uuid.UUID, input NoteDiscountInput) (*domain.Note, error) {
+	note, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	bank, err := s.activeBankAccount(ctx, companyID, input.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Charge > 0 {
+		if input.ChargeAccountID == nil {
+			return nil, domain.ErrInvalidNoteDiscount
+		}
+		if _, err := s.accountRepo.FindByID(ctx, companyID, *input.ChargeAccountID); err != nil {
+			return nil, err
+		}
+	}
+	if err := note.Discount(input.DiscountDate, input.Charge, bank.ID); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("%s 할인 %s", noteLabel(note), note.NoteNo)
+	proceeds := s.bankEntry(note, bank, description)
+	proceeds.SetDebit(float64(note.Amount - input.Charge))
+	entries := []domain.VoucherEntry{proceeds}
+	if input.Charge > 0 {
+		charge := s.entry(note, *input.ChargeAccountID, description)
+		charge.SetDebit(float64(input.Charge))
+		entries = append(entries, charge)
+	}
+	noteEntry := s.entry(note, note.NoteAccountID, description)
+	noteEntry.SetCredit(float64(note.Amount))
+	entries = append(entries, noteEntry)
+
+	voucher, err := s.createVoucher(ctx, note, input.DiscountDate, domain.VoucherTypeReceipt, description, entries, &userID)
+	if err != nil {
+		return nil, err
+	}
+	note.DiscountVoucherID = &voucher.ID
+	note.UpdatedBy = &userID
+	if err := s.repo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// Settle books the collection of a received note into the bank account, or
+// the payment of an issued one from it
+func (s *noteService) Settle(ctx context.Context, companyID, userID, id uuid.UUID, settledDate time.Time, bankAccountID uuid.UUID) (*domain.Note, error) {
+	note, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	bank, err := s.activeBankAccount(ctx, companyID, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := note.Settle(settledDate, bank.ID); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("%s 결제 %s", noteLabel(note), note.NoteNo)
+	cash := s.bankEntry(note, bank, description)
+	noteEntry := s.entry(note, note.NoteAccountID, description)
+	voucherType := domain.VoucherTypeReceipt
+	if note.Direction == domain.NoteReceived {
+		cash.SetDebit(float64(note.Amount))
+		noteEntry.SetCredit(float64(note.Amount))
+	} else {
+		noteEntry.SetDebit(float64(note.Amount))
+		cash.SetCredit(float64(note.Amount))
+		voucherType = domain.VoucherTypePayment
+	}
+
+	voucher, err := s.createVoucher(ctx, note, settledDate, voucherType, description, []domain.VoucherEntry{noteEntry, cash}, &userID)
+	if err != nil {
+		return nil, err
+	}
+	note.SettlementVoucherID = &voucher.ID
+	note.UpdatedBy = &userID
+	if err := s.repo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// activeBankAccount returns a bank account of the company that is in use
+func (s *noteService) activeBankAccount(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error) {
+	bank, err := s.bankRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !bank.IsActive {
+		return nil, domain.ErrBankAccountInactive
+	}
+	return bank, nil
+}
+
+// createVoucher generates a draft voucher of the note
+func (s *noteService) createVoucher(ctx context.Context, note *domain.Note, date time.Time, voucherType domain.VoucherType, description string, entries []domain.VoucherEntry, userID *uuid.UUID) (*domain.Voucher, error) {
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: note.CompanyID},
+		VoucherDate:   date,
+		VoucherType:   voucherType,
+		Description:   description,
+		ReferenceType: noteReferenceType,
+		ReferenceID:   &note.ID,
+		Entries:       entries,
+		CreatedBy:     userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// entry returns a voucher line of the note's partner on the account
+func (s *noteService) entry(note *domain.Note, accountID uuid.UUID, description string) domain.VoucherEntry {
+	partnerID := note.PartnerID
+	return domain.VoucherEntry{
+		CompanyID:   note.CompanyID,
+		AccountID:   accountID,
+		Description: description,
+		PartnerID:   &partnerID,
+	}
+}
+
+// bankEntry returns a voucher line on the cash account of the bank account
+func (s *noteService) bankEntry(note *domain.Note, bank *domain.CompanyBankAccount, description string) domain.VoucherEntry {
+	bankAccountID := bank.ID
+	return domain.VoucherEntry{
+		CompanyID:     note.CompanyID,
+		AccountID:     bank.AccountID,
+		Description:   description,
+		BankAccountID: &bankAccountID,
+	}
+}
+
+// AlertMaturities sends one email per company listing its notes nearing
+// maturity. Notes of companies that could not be notified are tried again
+// on the next run.
+func (s *noteService) AlertMaturities(ctx context.Context, today time.Time) (int, error) {
+	if !s.notifications.IsEmailEnabled(ctx) {
+		return 0, nil
+	}
+	notes, err := s.repo.ListForMaturityAlert(ctx, today.AddDate(0, 0, domain.NoteMaturityAlertDays))
+	if err != nil {
+		return 0, err
+	}
+
+	var companyIDs []uuid.UUID
+	byCompany := make(map[uuid.UUID][]domain.Note)
+	for _, note := range notes {
+		if _, ok := byCompany[note.CompanyID]; !ok {
+			companyIDs = append(companyIDs, note.CompanyID)
+		}
+		byCompany[note.CompanyID] = append(byCompany[note.CompanyID], note)
+	}
+
+	alerted := 0
+	var errs []error
+	for _, companyID := range companyIDs {
+		companyNotes := byCompany[companyID]
+		if err := s.alertCompany(ctx, companyID, companyNotes, today); err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		ids := make([]uuid.UUID, len(companyNotes))
+		for i := range companyNotes {
+			ids[i] = companyNotes[i].ID
+		}
+		if err := s.repo.MarkMaturityAlerted(ctx, ids, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("company %s: %w", companyID, err))
+			continue
+		}
+		alerted += len(companyNotes)
+	}
+	return alerted, errors.Join(errs...)
+}
+
+// alertCompany emails the active admins of the company its notes nearing maturity
+func (s *noteService) alertCompany(ctx context.Context, companyID uuid.UUID, notes []domain.Note, today time.Time) error {
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	active, admin := domain.UserStatusActive, domain.UserRoleAdmin
+	admins, _, err := s.userRepo.FindAll(ctx, repository.UserFilter{CompanyID: companyID, Status: &active, Role: &admin})
+	if err != nil {
+		return err
+	}
+	var to []string
+	for _, u := range admins {
+		if u.Email != "" {
+			to = append(to, u.Email)
+		}
+	}
+	if len(to) == 0 {
+		return provider.ErrEmailNoRecipients
+	}
+
+	partnerNames := make(map[uuid.UUID]string)
+	lines := make([]string, len(notes))
+	for i, note := range notes {
+		name, ok := partnerNames[note.PartnerID]
+		if !ok {
+			if partner, err := s.partnerRepo.GetByID(ctx, companyID, note.PartnerID); err == nil {
+				name = partner.Name
+			}
+			partnerNames[note.PartnerID] = name
+		}
+		lines[i] = noteMaturityLine(&note, name, today)
+	}
+
+	subject, body := s.templates.Render(ctx, companyID, domain.EmailEventNoteMaturity, map[string]string{
+		"company_name": company.Name,
+		"note_count":   strconv.Itoa(len(notes)),
+		"notes":        strings.Join(lines, "\n"),
+	})
+	return s.notifications.SendEmail(ctx, &provider.EmailMessage{To: to, Subject: subject, TextBody: body})
+}
+
+// noteMaturityLine describes a note of the maturity alert, with the days to
+// its maturity (D-3) or since (D+2)
+func noteMaturityLine(note *domain.Note, partnerName string, today time.Time) string {
+	days := note.DaysToMaturity(today)
+	dday := fmt.Sprintf("D-%d", days)
+	if days < 0 {
+		dday = fmt.Sprintf("D+%d", -days)
+	}
+	return fmt.Sprintf("%s %s %s %s %s원 (%s)", note.MaturityDate.Format("2006-01-02"), noteLabel(note),
+		note.NoteNo, partnerName, formatPrintAmount(float64(note.Amount)), dday)
+}
+
+// noteLabel names the note as the books do
+func noteLabel(note *domain.Note) string {
+	switch {
+	case note.Kind == domain.NoteKindCheck:
+		return "수표"
+	case note.Direction == domain.NoteReceived:
+		return "받을어음"
+	}
+	return "지급어음"
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/saintgo7/saas-kerp/internal/provider"
+)
+
+// NotificationService defines the interface for delivering notifications to users
+type NotificationService interface {
+	// IsEmailEnabled returns true if outgoing email is configured
+	IsEmailEnabled(ctx context.Context) bool
+
+	// SendEmail delivers an email, with optional attachments
+	SendEmail(ctx context.Context, msg *provider.EmailMessage) error
+
+	// IsPushEnabled returns true if push notifications to devices of the
+	// platform (provider.ProviderTypeFCM or provider.ProviderTypeAPNs) are configured
+	IsPushEnabled(ctx context.Context, platform provider.ProviderType) bool
+
+	// SendPush delivers a push notification to a device of the platform. It
+	// returns provider.ErrPushTokenInvalid for tokens to forget.
+	SendPush(ctx context.Context, platform provider.ProviderType, msg *provider.PushMessage) error
+}
+
+// notificationService implements NotificationService
+type notificationService struct {
+	email provider.EmailProvider
+	push  map[provider.ProviderType]provider.PushProvider
+}
+
+// NewNotificationService creates a new NotificationService. email may be nil
+// when email is not configured; push holds the configured push providers, if any.
+func NewNotificationService(email provider.EmailProvider, push ...provider.PushProvider) NotificationService {
+	s := &notificationService{email: email, push: make(map[provider.ProviderType]provider.PushProvider)}
+	for _, p := range push {
+		s.push[p.Type()] = p
+	}
+	return s
+}
+
+// IsEmailEnabled returns true if an email provider is available
+func (s *notificationService) IsEmailEnabled(ctx context.Context) bool {
+	return s.email != nil && s.email.IsAvailable(ctx)
+}
+
+// SendEmail delivers the message through the configured email provider
+func (s *notificationService) SendEmail(ctx context.Context, msg *provider.EmailMessage) error {
+	if !s.IsEmailEnabled(ctx) {
+		return provider.ErrProviderUnavailable
+	}
+	if len(msg.Recipients()) == 0 {
+		return provider.ErrEmailNoRecipients
+	}
+	return s.email.Send(ctx, msg)
+}
+
+// IsPushEnabled returns true if a push provider of the platform is available
+func (s *notificationService) IsPushEnabled(ctx context.Context, platform provider.ProviderType) bool {
+	p, ok := s.push[platform]
+	return ok && p.IsAvailable(ctx)
+}
+
+// SendPush delivers the message through the push provider of the platform
+func (s *notificationService) SendPush(ctx context.Context, platform provider.ProviderType, msg *provider.PushMessage) error {
+	if !s.IsPushEnabled(ctx, platform) {
+		return provider.ErrProviderUnavailable
+	}
+	return s.push[platform].Send(ctx, msg)
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// OnboardingService defines the interface for user invitations and email verification
+type OnboardingService interface {
+	// Invite creates an invitation to join the company and emails it to the invitee
+	Invite(ctx context.Context, input InviteInput) (*domain.UserToken, error)
+	ListInvitations(ctx context.Context, companyID uuid.UUID) ([]domain.UserToken, error)
+	CancelInvitation(ctx context.Context, companyID, id uuid.UUID) error
+
+	// GetInvitation returns a still-usable invitation by its raw token
+	GetInvitation(ctx context.Context, token string) (*domain.UserToken, error)
+
+	// AcceptInvitation creates the invitee's account, or adds a company membership
+	// if the email already belongs to a user of another company
+	AcceptInvitation(ctx context.Context, input AcceptInvitationInput) (*domain.User, error)
+
+	// SendEmailVerification emails a verification link to a self-registered user
+	SendEmailVerification(ctx context.Context, userID uuid.UUID) error
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+// InviteInput represents invitation request data
+type InviteInput struct {
+	CompanyID uuid.UUID
+	InvitedBy uuid.UUID
+	Email     string
+	Role      domain.UserRole
+	ExpiresIn time.Duration // zero uses domain.DefaultInviteTTL
+}
+
+// AcceptInvitationInput represents invitation acceptance data.
+// Existing users confirm with their current password; Name is then ignored.
+type AcceptInvitationInput struct {
+	Token    string
+	Name     string
+	Password string
+}
+
+// onboardingService implements OnboardingService
+type onboardingService struct {
+	tokenRepo      repository.UserTokenRepository
+	userRepo       repository.UserRepository
+	membershipRepo repository.UserCompanyMembershipRepository
+	companyRepo    repository.CompanyRepository
+	notifications  NotificationService
+	templates      EmailTemplateService
+	limits         PlanLimits // nil does not limit invitations
+	linkBaseURL    string
+}
+
+// NewOnboardingService creates a new OnboardingService. linkBaseURL is the web app
+// address that invitation and verification links point to.
+func NewOnboardingService(
+	tokenRepo repository.UserTokenRepository,
+	userRepo repository.UserRepository,
+	membershipRepo repository.UserCompanyMembershipRepository,
+	companyRepo repository.CompanyRepository,
+	notifications NotificationService,
+	templates EmailTemplateService,
+	limits PlanLimits,
+	linkBaseURL string,
+) OnboardingService {
+	return &onboardingService{
+		tokenRepo:      tokenRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		companyRepo:    companyRepo,
+		notifications:  notifications,
+		templates:      templates,
+		limits:         limits,
+		linkBaseURL:    strings.TrimRight(linkBaseURL, "/"),
+	}
+}
+
+// Invite replaces any outstanding invitation for the email with a new one
+func (s *onboardingService) Invite(ctx context.Context, input InviteInput) (*domain.UserToken, error) {
+	if !input.Role.IsValid() {
+		return nil, domain.ErrInvalidUserRole
+	}
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+
+	if err := s.checkNotMember(ctx, input.CompanyID, email); err != nil {
+		return nil, err
+	}
+	if err := s.checkUserLimit(ctx, input.CompanyID); err != nil {
+		return nil, err
+	}
+	if !s.notifications.IsEmailEnabled(ctx) {
+		return nil, provider.ErrProviderUnavailable
+	}
+	company, err := s.companyRepo.FindByID(ctx, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := input.ExpiresIn
+	if ttl <= 0 {
+		ttl = domain.DefaultInviteTTL
+	}
+	invite, raw, err := domain.NewUserToken(input.CompanyID, domain.UserTokenPurposeInvite, email, ttl)
+	if err != nil {
+		return nil, err
+	}
+	invite.Role = input.Role
+	invite.CreatedBy = &input.InvitedBy
+
+	if err := s.tokenRepo.InvalidatePending(ctx, input.CompanyID, domain.UserTokenPurposeInvite, email); err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	subject, body := s.templates.Render(ctx, input.CompanyID, domain.EmailEventInvitation, map[string]string{
+		"company_name": company.Name,
+		"role":         string(invite.Role),
+		"invite_link":  s.link("/invite/accept", raw),
+		"expires_at":   invite.ExpiresAt.Format("2006-01-02 15:04"),
+	})
+	msg := &provider.EmailMessage{To: []string{email}, Subject: subject, TextBody: body}
+	if err := s.notifications.SendEmail(ctx, msg); err != nil {
+		// An invitation that never reached the invitee cannot be accepted
+		_ = s.tokenRepo.Delete(ctx, input.CompanyID, invite.ID)
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (s *onboardingService) ListInvitations(ctx context.Context, companyID uuid.UUID) ([]domain.UserToken, error) {
+	return s.tokenRepo.FindPending(ctx, companyID, domain.UserTokenPurposeInvite)
+}
+
+func (s *onboardingService) CancelInvitation(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.tokenRepo.Delete(ctx, companyID, id)
+}
+
+func (s *onboardingService) GetInvitation(ctx context.Context, token string) (*domain.UserToken, error) {
+	invite, err := s.tokenRepo.FindByHash(ctx, domain.UserTokenPurposeInvite, domain.HashUserToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if err := invite.Validate(); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (s *onboardingService) AcceptInvitation(ctx context.Context, input AcceptInvitationInput) (*domain.User, error) {
+	invite, err := s.GetInvitation(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+	// Checked again: members may have joined since the invitation was sent
+	if err := s.checkUserLimit(ctx, invite.CompanyID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, invite.Email)
+	switch {
+	case err == nil:
+		// Existing user of another company: grant a membership
+		if !user.CheckPassword(input.Password) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		if err := s.checkNotMember(ctx, invite.CompanyID, invite.Email); err != nil {
+			return nil, err
+		}
+		membership, err := domain.NewUserCompanyMembership(user.ID, invite.CompanyID, invite.Role)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.membershipRepo.Create(ctx, membership); err != nil {
+			return nil, err
+		}
+		if !user.IsEmailVerified() {
+			user.VerifyEmail()
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+	case err == domain.ErrUserNotFound:
+		user, err = domain.NewUser(invite.CompanyID, invite.Email, input.Password, input.Name, invite.Role)
+		if err != nil {
+			return nil, err
+		}
+		// The invitation link proves ownership of the address
+		user.VerifyEmail()
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		home := user.HomeMembership()
+		if err := s.membershipRepo.Create(ctx, &home); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	invite.UserID = &user.ID
+	invite.MarkUsed()
+	if err := s.tokenRepo.Update(ctx, invite); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *onboardingService) SendEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return domain.ErrEmailVerified
+	}
+	if !s.notifications.IsEmailEnabled(ctx) {
+		return provider.ErrProviderUnavailable
+	}
+
+	token, raw, err := domain.NewUserToken(user.CompanyID, domain.UserTokenPurposeEmailVerification, user.Email, domain.DefaultEmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	token.UserID = &user.ID
+
+	if err := s.tokenRepo.InvalidatePending(ctx, user.CompanyID, domain.UserTokenPurposeEmailVerification, user.Email); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	subject, body := s.templates.Render(ctx, user.CompanyID, domain.EmailEventEmailVerification, map[string]string{
+		"user_name":   user.Name,
+		"verify_link": s.link("/verify-email", raw),
+		"expires_at":  token.ExpiresAt.Format("2006-01-02 15:04"),
+	})
+	return s.notifications.SendEmail(ctx, &provider.EmailMessage{To: []string{user.Email}, Subject: subject, TextBody: body})
+}
+
+func (s *onboardingService) VerifyEmail(ctx context.Context, token string) error {
+	t, err := s.tokenRepo.FindByHash(ctx, domain.UserTokenPurposeEmailVerification, domain.HashUserToken(token))
+	if err != nil {
+		return err
+	}
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if t.UserID == nil {
+		return domain.ErrUserTokenNotFound
+	}
+
+	user, err := s.userRepo.FindByID(ctx, t.CompanyID, *t.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		user.VerifyEmail()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	t.MarkUsed()
+	return s.tokenRepo.Update(ctx, t)
+}
+
+// checkNotMember returns domain.ErrAlreadyMember if the email already has access to the company
+func (s *onboardingService) checkNotMember(ctx context.Context, companyID uuid.UUID, email string) error {
+	if _, err := s.userRepo.FindByEmailAndCompany(ctx, companyID, email); err == nil {
+		return domain.ErrAlreadyMember
+	} else if err != domain.ErrUserNotFound {
+		return err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err == domain.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := s.membershipRepo.FindByUserAndCompany(ctx, user.ID, companyID); err == nil {
+		return domain.ErrAlreadyMember
+	} else if err != domain.ErrMembershipNotFound {
+		return err
+	}
+	return nil
+}
+
+// link builds a web app link carrying the raw token
+func (s *onboardingService) link(path, token string) string {
+	return s.linkBaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// checkUserLimit checks that the company's plan allows another member
+func (s *onboardingService) checkUserLimit(ctx context.Context, companyID uuid.UUID) error {
+	if s.limits == nil {
+		return nil
+	}
+	return s.limits.CheckLimit(ctx, companyID, domain.PlanLimitUsers, 1)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// PartitionService defines the interface for voucher_entries partition maintenance
+type PartitionService interface {
+	// Maintain creates the partitions of the current fiscal year, the configured
+	// years ahead and any year whose entries fell into the default partition.
+	// It returns the fiscal years whose partitions were created.
+	Maintain(ctx context.Context, asOf time.Time) ([]int, error)
+}
+
+type partitionService struct {
+	repo           repository.PartitionRepository
+	yearsAhead     int
+	hashPartitions int
+}
+
+// NewPartitionService creates a new partition maintenance service. New yearly
+// partitions are split by company into hashPartitions partitions when it is
+// greater than 1; existing partitions are not changed.
+func NewPartitionService(repo repository.PartitionRepository, yearsAhead, hashPartitions int) PartitionService {
+	return &partitionService{repo: repo, yearsAhead: yearsAhead, hashPartitions: hashPartitions}
+}
+
+// Maintain creates missing fiscal year partitions
+func (s *partitionService) Maintain(ctx context.Context, asOf time.Time) ([]int, error) {
+	existing, err := s.repo.VoucherEntryPartitionYears(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hasPartition := make(map[int]bool, len(existing))
+	for _, year := range existing {
+		hasPartition[year] = true
+	}
+
+	// Back-dated or far-future vouchers land in the default partition
+	stray, err := s.repo.DefaultVoucherEntryYears(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	years := stray
+	for year := asOf.Year(); year <= asOf.Year()+s.yearsAhead; year++ {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+
+	var created []int
+	for _, year := range years {
+		if hasPartition[year] {
+			continue
+		}
+		ok, err := s.repo.CreateVoucherEntryPartition(ctx, year, s.hashPartitions)
+		if err != nil {
+			return created, err
+		}
+		hasPartition[year] = true
+		if ok {
+			created = append(created, year)
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,33 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+func TestPartitionService_Maintain(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.MockPartitionRepository)
+	svc := service.NewPartitionService(repo, 1, 4)
+
+	repo.On("VoucherEntryPartitionYears", ctx).Return([]int{2024, 2025, 2026}, nil)
+	// A legacy import of 2019 vouchers before its partition existed
+	repo.On("DefaultVoucherEntryYears", ctx).Return([]int{2019}, nil)
+	repo.On("CreateVoucherEntryPartition", ctx, 2019, 4).Return(true, nil).Once()
+	repo.On("CreateVoucherEntryPartition", ctx, 2027, 4).Return(true, nil).Once()
+
+	created, err := svc.Maintain(ctx, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{2019, 2027}, created)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "CreateVoucherEntryPartition", mock.Anything, 2026, mock.Anything)
+}
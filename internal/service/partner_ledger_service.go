@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// PartnerStatementFilter selects the partners included in bulk statements
+type PartnerStatementFilter struct {
+	PartnerType  string // "customer", "vendor", or empty for all
+	IncludeEmpty bool   // include partners without activity or balance
+}
+
+// PartnerLedgerService defines the interface for partner statements of account (거래처원장)
+type PartnerLedgerService interface {
+	// GetLedger returns the posted AR/AP entries of a partner with running balance
+	GetLedger(ctx context.Context, companyID, partnerID uuid.UUID, from, to time.Time) (*domain.PartnerLedger, error)
+
+	// GetStatements returns the statements of all partners matching the filter
+	GetStatements(ctx context.Context, companyID uuid.UUID, from, to time.Time, filter PartnerStatementFilter) ([]domain.PartnerLedger, error)
+
+	// SendStatements emails each partner its statement as a PDF attachment
+	SendStatements(ctx context.Context, companyID uuid.UUID, from, to time.Time, filter PartnerStatementFilter) (*domain.PartnerStatementMailing, error)
+}
+
+// partnerLedgerService implements PartnerLedgerService
+type partnerLedgerService struct {
+	ledgerRepo    repository.LedgerRepository
+	partnerRepo   repository.PartnerRepository
+	companyRepo   repository.CompanyRepository
+	reports       ReportService
+	notifications NotificationService
+	templates     EmailTemplateService
+}
+
+// NewPartnerLedgerService creates a new PartnerLedgerService
+func NewPartnerLedgerService(
+	ledgerRepo repository.LedgerRepository,
+	partnerRepo repository.PartnerRepository,
+	companyRepo repository.CompanyRepository,
+	reports ReportService,
+	notifications NotificationService,
+	templates EmailTemplateService,
+) PartnerLedgerService {
+	return &partnerLedgerService{
+		ledgerRepo:    ledgerRepo,
+		partnerRepo:   partnerRepo,
+		companyRepo:   companyRepo,
+		reports:       reports,
+		notifications: notifications,
+		templates:     templates,
+	}
+}
+
+// GetLedger builds the statement of a single partner
+func (s *partnerLedgerService) GetLedger(ctx context.Context, companyID, partnerID uuid.UUID, from, to time.Time) (*domain.PartnerLedger, error) {
+	partner, err := s.partnerRepo.GetByID(ctx, companyID, partnerID)
+	if err != nil {
+		return nil, domain.ErrPartnerNotFound
+	}
+
+	balances, err := s.ledgerRepo.GetPartnerBalances(ctx, companyID, &partnerID, from)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.ledgerRepo.GetPartnerLedgerEntries(ctx, companyID, &partnerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPartnerLedger(partner, from, to, balances[partnerID], entries), nil
+}
+
+// GetStatements builds statements for all partners in code order
+func (s *partnerLedgerService) GetStatements(ctx context.Context, companyID uuid.UUID, from, to time.Time, filter PartnerStatementFilter) ([]domain.PartnerLedger, error) {
+	partners, _, err := s.partnerRepo.List(ctx, &repository.PartnerFilter{CompanyID: companyID, PartnerType: filter.PartnerType})
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.ledgerRepo.GetPartnerBalances(ctx, companyID, nil, from)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.ledgerRepo.GetPartnerLedgerEntries(ctx, companyID, nil, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byPartner := make(map[uuid.UUID][]domain.PartnerLedgerEntry)
+	for _, e := range entries {
+		byPartner[e.PartnerID] = append(byPartner[e.PartnerID], e)
+	}
+
+	statements := make([]domain.PartnerLedger, 0, len(partners))
+	for i := range partners {
+		partner := &partners[i]
+		ledger := domain.NewPartnerLedger(partner, from, to, balances[partner.ID], byPartner[partner.ID])
+		if ledger.IsEmpty() && !filter.IncludeEmpty {
+			continue
+		}
+		statements = append(statements, *ledger)
+	}
+	return statements, nil
+}
+
+// SendStatements generates the statements and emails each to the partner's address.
+// Individual delivery failures are reported in the result rather than aborting the mailing.
+func (s *partnerLedgerService) SendStatements(ctx context.Context, companyID uuid.UUID, from, to time.Time, filter PartnerStatementFilter) (*domain.PartnerStatementMailing, error) {
+	if !s.notifications.IsEmailEnabled(ctx) {
+		return nil, provider.ErrProviderUnavailable
+	}
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := s.GetStatements(ctx, companyID, from, to, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PartnerStatementMailing{Generated: len(statements), Failures: []domain.PartnerStatementFailure{}}
+	for i := range statements {
+		ledger := &statements[i]
+		if ledger.Partner.Email == "" {
+			result.Skipped++
+			continue
+		}
+		if err := s.mail(ctx, company, ledger); err != nil {
+			result.Failures = append(result.Failures, domain.PartnerStatementFailure{
+				PartnerID:   ledger.Partner.ID,
+				PartnerName: ledger.Partner.Name,
+				Error:       err.Error(),
+			})
+			continue
+		}
+		result.Sent++
+	}
+	return result, nil
+}
+
+// mail renders the statement as PDF and sends it to the partner
+func (s *partnerLedgerService) mail(ctx context.Context, company *domain.Company, ledger *domain.PartnerLedger) error {
+	data, err := s.reports.Export(buildPartnerLedgerTable(company, ledger), domain.ReportFormatPDF)
+	if err != nil {
+		return fmt.Errorf("export statement: %w", err)
+	}
+
+	period := ledger.FromDate.Format("2006-01-02") + " ~ " + ledger.ToDate.Format("2006-01-02")
+	subject, body := s.templates.Render(ctx, company.ID, domain.EmailEventPartnerStatement, map[string]string{
+		"company_name":    company.Name,
+		"partner_name":    ledger.Partner.Name,
+		"period":          period,
+		"closing_balance": reportDisplayNumber(reportNumber(ledger.ClosingBalance)),
+	})
+	msg := &provider.EmailMessage{
+		To:       []string{ledger.Partner.Email},
+		Subject:  subject,
+		TextBody: body,
+		Attachments: []provider.EmailAttachment{{
+			Filename:    fmt.Sprintf("partner_ledger_%s_%s.pdf", ledger.Partner.Code, ledger.ToDate.Format("2006-01")),
+			ContentType: domain.ReportFormatPDF.ContentType(),
+			Data:        data,
+		}},
+	}
+	if err := s.notifications.SendEmail(ctx, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// buildPartnerLedgerTable renders a partner statement as a report table
+func buildPartnerLedgerTable(company *domain.Company, ledger *domain.PartnerLedger) *domain.ReportTable {
+	table := &domain.ReportTable{
+		Title:    "거래처원장",
+		Subtitle: fmt.Sprintf("%s  %s (%s)  %s ~ %s", company.Name, ledger.Partner.Name, ledger.Partner.Code, ledger.FromDate.Format("2006-01-02"), ledger.ToDate.Format("2006-01-02")),
+		Columns: []domain.ReportColumn{
+			{Label: "일자"}, {Label: "전표번호"}, {Label: "계정과목"}, {Label: "적요"},
+			{Label: "차변", Numeric: true}, {Label: "대변", Numeric: true}, {Label: "잔액", Numeric: true},
+		},
+	}
+	table.Rows = append(table.Rows, []string{"", "", "", "전기이월", "", "", reportNumber(ledger.OpeningBalance)})
+	for _, e := range ledger.Entries {
+		table.Rows = append(table.Rows, []string{
+			e.VoucherDate.Format("2006-01-02"), e.VoucherNo, e.AccountName, e.Description,
+			reportNumber(e.DebitAmount), reportNumber(e.CreditAmount), reportNumber(e.Balance),
+		})
+	}
+	table.Rows = append(table.Rows, []string{"", "", "", "합계", reportNumber(ledger.TotalDebit), reportNumber(ledger.TotalCredit), reportNumber(ledger.ClosingBalance)})
+	return table
+}
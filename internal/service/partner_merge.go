@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+)
+
+// Duplicates compares all partners of the company, inactive ones included
+func (s *partnerService) Duplicates(ctx context.Context, companyID uuid.UUID) ([]domain.PartnerDuplicate, error) {
+	partners, _, err := s.repo.List(ctx, &PartnerFilter{CompanyID: companyID})
+	if err != nil {
+		return nil, err
+	}
+	return domain.FindPartnerDuplicates(partners), nil
+}
+
+// Merge moves the rows of the source to the target, which fills its empty
+// fields from the source. Vouchers of audit-locked fiscal years are not
+// re-pointed, and tax invoices cannot follow the source when the target has
+// another business number, so both refuse the merge.
+func (s *partnerService) Merge(ctx context.Context, companyID, sourceID, targetID, userID uuid.UUID, ipAddress, userAgent string) (*domain.PartnerMerge, error) {
+	if sourceID == targetID {
+		return nil, domain.ErrPartnerMergeSelf
+	}
+	source, err := s.repo.GetByID(ctx, companyID, sourceID)
+	if err != nil {
+		return nil, ErrPartnerNotFound
+	}
+	target, err := s.repo.GetByID(ctx, companyID, targetID)
+	if err != nil {
+		return nil, ErrPartnerNotFound
+	}
+
+	if err := s.checkAuditLocks(ctx, companyID, sourceID); err != nil {
+		return nil, err
+	}
+
+	sourceNumber := domain.BusinessNumberDigits(source.BusinessNumber)
+	targetNumber := domain.BusinessNumberDigits(target.BusinessNumber)
+	if sourceNumber != "" && targetNumber != "" && sourceNumber != targetNumber {
+		hasInvoices, err := s.repo.HasTaxInvoices(ctx, companyID, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if hasInvoices {
+			return nil, domain.ErrPartnerMergeBusinessNumber
+		}
+	}
+
+	before := *source
+	merge := &domain.PartnerMerge{SourceID: sourceID, TargetID: targetID}
+	merge.BusinessNumberMoved = target.MergeFrom(source)
+
+	entry, err := merge.AuditLog(companyID, &userID, &before)
+	if err != nil {
+		return nil, err
+	}
+	if ipAddress != "" {
+		entry.IPAddress = &ipAddress
+	}
+	entry.UserAgent = truncateRunes(userAgent, 500)
+
+	if err := s.repo.Merge(ctx, merge, source, target, entry); err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// checkAuditLocks returns domain.ErrFiscalYearAuditLocked if the partner has
+// voucher entries in a fiscal year under an active audit lock
+func (s *partnerService) checkAuditLocks(ctx context.Context, companyID, partnerID uuid.UUID) error {
+	years, err := s.repo.EntryFiscalYears(ctx, companyID, partnerID)
+	if err != nil || len(years) == 0 {
+		return err
+	}
+	locks, err := s.lockRepo.FindAll(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	locked := make(map[int]bool)
+	for i := range locks {
+		if locks[i].IsActive() {
+			locked[locks[i].FiscalYear] = true
+		}
+	}
+	for _, year := range years {
+		if locked[year] {
+			return domain.ErrFiscalYearAuditLocked
+		}
+	}
+	return nil
+}
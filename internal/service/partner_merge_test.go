@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakeAuditLocks serves the locks of one company
+type fakeAuditLocks struct {
+	repository.AuditLockRepository
+	locks []domain.AuditLock
+}
+
+func (f *fakeAuditLocks) FindAll(ctx context.Context, companyID uuid.UUID) ([]domain.AuditLock, error) {
+	return f.locks, nil
+}
+
+func TestPartnerService_Merge(t *testing.T) {
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+	userID := uuid.New()
+
+	newPartners := func() (*domain.Partner, *domain.Partner) {
+		source := &domain.Partner{Code: "P002", Name: "(주)한빛상사", PartnerType: "vendor", BusinessNumber: "1234567890"}
+		source.ID = uuid.New()
+		source.CompanyID = companyID
+		target := &domain.Partner{Code: "P001", Name: "한빛상사", PartnerType: "vendor"}
+		target.ID = uuid.New()
+		target.CompanyID = companyID
+		return source, target
+	}
+
+	t.Run("re-points the source and records the merge", func(t *testing.T) {
+		source, target := newPartners()
+		repo := new(mocks.MockPartnerRepository)
+		lifted := domain.NewAuditLock(companyID, 2024, "", userID, time.Now())
+		now := time.Now()
+		lifted.UnlockedAt = &now
+		svc := service.NewPartnerService(repo, nil, &fakeAuditLocks{locks: []domain.AuditLock{*lifted}})
+
+		repo.On("GetByID", mock.Anything, companyID, source.ID).Return(source, nil)
+		repo.On("GetByID", mock.Anything, companyID, target.ID).Return(target, nil)
+		repo.On("EntryFiscalYears", mock.Anything, companyID, source.ID).Return([]int{2024, 2025}, nil)
+		var entry *domain.AuditLog
+		repo.On("Merge", mock.Anything, mock.Anything, source, target, mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.PartnerMerge).VoucherEntries = 3
+				entry = args.Get(4).(*domain.AuditLog)
+			}).
+			Return(nil)
+
+		merge, err := svc.Merge(ctx, companyID, source.ID, target.ID, userID, "10.0.0.1", "test")
+		require.NoError(t, err)
+		assert.True(t, merge.BusinessNumberMoved)
+		assert.Equal(t, int64(3), merge.VoucherEntries)
+		assert.Equal(t, "1234567890", target.BusinessNumber)
+
+		require.NotNil(t, entry)
+		assert.Equal(t, domain.AuditActionPartnerMerge, entry.Action)
+		assert.Equal(t, target.ID, *entry.EntityID)
+		var old domain.Partner
+		require.NoError(t, json.Unmarshal(entry.OldValues, &old))
+		assert.Equal(t, "P002", old.Code)
+	})
+
+	t.Run("refuses audit-locked years", func(t *testing.T) {
+		source, target := newPartners()
+		repo := new(mocks.MockPartnerRepository)
+		lock := domain.NewAuditLock(companyID, 2024, "", userID, time.Now())
+		svc := service.NewPartnerService(repo, nil, &fakeAuditLocks{locks: []domain.AuditLock{*lock}})
+
+		repo.On("GetByID", mock.Anything, companyID, source.ID).Return(source, nil)
+		repo.On("GetByID", mock.Anything, companyID, target.ID).Return(target, nil)
+		repo.On("EntryFiscalYears", mock.Anything, companyID, source.ID).Return([]int{2024}, nil)
+
+		_, err := svc.Merge(ctx, companyID, source.ID, target.ID, userID, "", "")
+		assert.ErrorIs(t, err, domain.ErrFiscalYearAuditLocked)
+		repo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps tax invoices with their business number", func(t *testing.T) {
+		source, target := newPartners()
+		target.BusinessNumber = "9876543210"
+		repo := new(mocks.MockPartnerRepository)
+		svc := service.NewPartnerService(repo, nil, &fakeAuditLocks{})
+
+		repo.On("GetByID", mock.Anything, companyID, source.ID).Return(source, nil)
+		repo.On("GetByID", mock.Anything, companyID, target.ID).Return(target, nil)
+		repo.On("EntryFiscalYears", mock.Anything, companyID, source.ID).Return(nil, nil)
+		repo.On("HasTaxInvoices", mock.Anything, companyID, source.ID).Return(true, nil)
+
+		_, err := svc.Merge(ctx, companyID, source.ID, target.ID, userID, "", "")
+		assert.ErrorIs(t, err, domain.ErrPartnerMergeBusinessNumber)
+	})
+
+	t.Run("refuses merging a partner into itself", func(t *testing.T) {
+		svc := service.NewPartnerService(new(mocks.MockPartnerRepository), nil, &fakeAuditLocks{})
+		id := uuid.New()
+		_, err := svc.Merge(ctx, companyID, id, id, userID, "", "")
+		assert.ErrorIs(t, err, domain.ErrPartnerMergeSelf)
+	})
+}
+
+func TestPartnerService_CreateGeneratesCode(t *testing.T) {
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+	repo := new(mocks.MockPartnerRepository)
+	settings := new(mocks.MockCompanySettingsService)
+	svc := service.NewPartnerService(repo, settings, &fakeAuditLocks{})
+
+	rule := &domain.PartnerCodeRule{CustomerPrefix: "C", VendorPrefix: "V", Digits: 4}
+	settings.On("Get", mock.Anything, companyID).Return(domain.CompanySettings{PartnerCodeRule: rule}, nil)
+	repo.On("MaxCode", mock.Anything, companyID, "C", 4).Return("C0041", nil)
+	repo.On("ExistsByCode", mock.Anything, companyID, "C0042", (*uuid.UUID)(nil)).Return(false, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	partner := &domain.Partner{Name: "한빛상사", PartnerType: "customer"}
+	partner.CompanyID = companyID
+	require.NoError(t, svc.Create(ctx, partner))
+	assert.Equal(t, "C0042", partner.Code)
+}
@@ -45,6 +45,13 @@ type PartnerService interface {
 
 	// Statistics
 	GetStats(ctx context.Context, companyID uuid.UUID) (*PartnerStats, error)
+
+	// Duplicates
+	// Duplicates returns the pairs of partners that likely are the same business
+	Duplicates(ctx context.Context, companyID uuid.UUID) ([]domain.PartnerDuplicate, error)
+	// Merge consolidates the source partner into the target and deletes it,
+	// recording the merge with the client of the request in the audit log
+	Merge(ctx context.Context, companyID, sourceID, targetID, userID uuid.UUID, ipAddress, userAgent string) (*domain.PartnerMerge, error)
 }
 
 // PartnerStats holds partner statistics
@@ -58,12 +65,15 @@ type PartnerStats struct {
 
 // partnerService implements PartnerService
 type partnerService struct {
-	repo repository.PartnerRepository
+	repo     repository.PartnerRepository
+	settings CompanySettingsService
+	lockRepo repository.AuditLockRepository
 }
 
-// NewPartnerService creates a new PartnerService
-func NewPartnerService(repo repository.PartnerRepository) PartnerService {
-	return &partnerService{repo: repo}
+// NewPartnerService creates a new PartnerService; the settings hold the rule
+// generating partner codes and the audit locks guard the years a merge touches
+func NewPartnerService(repo repository.PartnerRepository, settings CompanySettingsService, lockRepo repository.AuditLockRepository) PartnerService {
+	return &partnerService{repo: repo, settings: settings, lockRepo: lockRepo}
 }
 
 // Create creates a new partner
@@ -72,6 +82,17 @@ func (s *partnerService) Create(ctx context.Context, partner *domain.Partner) er
 	if partner.PartnerType != "customer" && partner.PartnerType != "vendor" && partner.PartnerType != "both" {
 		return ErrPartnerInvalidType
 	}
+	if err := partner.NormalizeBankAccount(); err != nil {
+		return err
+	}
+
+	if partner.Code == "" {
+		code, err := s.nextCode(ctx, partner.CompanyID, partner.PartnerType)
+		if err != nil {
+			return err
+		}
+		partner.Code = code
+	}
 
 	// Check for duplicate code
 	exists, err := s.repo.ExistsByCode(ctx, partner.CompanyID, partner.Code, nil)
@@ -96,19 +117,41 @@ func (s *partnerService) Create(ctx context.Context, partner *domain.Partner) er
 	return s.repo.Create(ctx, partner)
 }
 
+// nextCode generates the code of a new partner by the company's code rule
+func (s *partnerService) nextCode(ctx context.Context, companyID uuid.UUID, partnerType string) (string, error) {
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return "", err
+	}
+	rule := settings.PartnerCodes()
+	last, err := s.repo.MaxCode(ctx, companyID, rule.Prefix(partnerType), rule.Digits)
+	if err != nil {
+		return "", err
+	}
+	return rule.NextCode(partnerType, last)
+}
+
 // Update updates a partner
 func (s *partnerService) Update(ctx context.Context, partner *domain.Partner) error {
 	// Validate partner type
 	if partner.PartnerType != "customer" && partner.PartnerType != "vendor" && partner.PartnerType != "both" {
 		return ErrPartnerInvalidType
 	}
+	if err := partner.NormalizeBankAccount(); err != nil {
+		return err
+	}
 
 	// Check existing
-	_, err := s.repo.GetByID(ctx, partner.CompanyID, partner.ID)
+	existing, err := s.repo.GetByID(ctx, partner.CompanyID, partner.ID)
 	if err != nil {
 		return ErrPartnerNotFound
 	}
 
+	// The NTS status belongs to the old business number
+	if domain.BusinessNumberDigits(existing.BusinessNumber) != domain.BusinessNumberDigits(partner.BusinessNumber) {
+		partner.ResetBusinessVerification()
+	}
+
 	// Check for duplicate code
 	exists, err := s.repo.ExistsByCode(ctx, partner.CompanyID, partner.Code, &partner.ID)
 	if err != nil {
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/banktransfer"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// PaymentBatchInput describes the payables a batch transfers. The payables
+// due by DueBy are taken, of the partners or entries given if any.
+type PaymentBatchInput struct {
+	BankAccountID uuid.UUID
+	TransferDate  time.Time
+	DueBy         *time.Time // defaults to the transfer date
+	PartnerIDs    []uuid.UUID
+	EntryIDs      []uuid.UUID
+	CreatedBy     uuid.UUID
+}
+
+// PaymentBatchFile is the bulk transfer file of a batch
+type PaymentBatchFile struct {
+	Name    string
+	Content []byte
+}
+
+// PaymentBatchService defines the interface for bulk transfers of payables
+type PaymentBatchService interface {
+	// Payables lists the open payables due by the date in due date order
+	Payables(ctx context.Context, companyID uuid.UUID, dueBy time.Time, partnerIDs []uuid.UUID) ([]domain.PayableItem, error)
+
+	// CreateBatch collects the payables due into a batch paid from the bank
+	// account, whose items are in payment until the batch is executed or
+	// cancelled
+	CreateBatch(ctx context.Context, companyID uuid.UUID, input PaymentBatchInput) (*domain.PaymentBatchCreation, error)
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PaymentBatch, error)
+	List(ctx context.Context, filter repository.PaymentBatchFilter) ([]domain.PaymentBatch, int64, error)
+	// File writes the transfer file of the batch in the layout of its bank
+	File(ctx context.Context, companyID, id uuid.UUID) (*PaymentBatchFile, error)
+
+	// Execute records the transfers the bank executed, all but the failed
+	// items, and generates the draft payment voucher of the paid items
+	Execute(ctx context.Context, companyID, userID, id uuid.UUID, executedOn time.Time, failedItemIDs []uuid.UUID) (*domain.PaymentBatch, error)
+	// Cancel cancels a batch that was not executed; its payables are due again
+	Cancel(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.PaymentBatch, error)
+}
+
+// paymentBatchService implements PaymentBatchService
+type paymentBatchService struct {
+	repo           repository.PaymentBatchRepository
+	bankRepo       repository.BankAccountRepository
+	voucherService VoucherService
+}
+
+// NewPaymentBatchService creates a new PaymentBatchService
+func NewPaymentBatchService(repo repository.PaymentBatchRepository, bankRepo repository.BankAccountRepository, voucherService VoucherService) PaymentBatchService {
+	return &paymentBatchService{
+		repo:           repo,
+		bankRepo:       bankRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Payables returns the open payables whose due date is on or before dueBy
+func (s *paymentBatchService) Payables(ctx context.Context, companyID uuid.UUID, dueBy time.Time, partnerIDs []uuid.UUID) ([]domain.PayableItem, error) {
+	items, balances, err := s.repo.FindPayables(ctx, companyID, partnerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	due := []domain.PayableItem{}
+	for _, item := range domain.OpenPayables(items, balances) {
+		if !item.DueDate.After(dueBy) {
+			due = append(due, item)
+		}
+	}
+	return due, nil
+}
+
+// CreateBatch takes the payables due, of the entries given if any, into a
+// batch of the paying bank's layout. Payables that cannot be transferred are
+// returned as skipped.
+func (s *paymentBatchService) CreateBatch(ctx context.Context, companyID uuid.UUID, input PaymentBatchInput) (*domain.PaymentBatchCreation, error) {
+	if input.TransferDate.IsZero() {
+		return nil, domain.ErrInvalidPaymentBatch
+	}
+	dueBy := input.TransferDate
+	if input.DueBy != nil {
+		dueBy = *input.DueBy
+	}
+
+	bank, err := s.bankRepo.FindByID(ctx, companyID, input.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !bank.IsActive {
+		return nil, domain.ErrBankAccountInactive
+	}
+	layout, err := banktransfer.LayoutFor(bank.BankCode)
+	if err != nil {
+		return nil, err
+	}
+
+	payables, err := s.Payables(ctx, companyID, dueBy, input.PartnerIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.EntryIDs) > 0 {
+		wanted := make(map[uuid.UUID]bool, len(input.EntryIDs))
+		for _, id := range input.EntryIDs {
+			wanted[id] = true
+		}
+		selected := payables[:0]
+		for _, p := range payables {
+			if wanted[p.EntryID] {
+				selected = append(selected, p)
+			}
+		}
+		payables = selected
+	}
+
+	items, skipped := domain.NewPaymentBatchItems(companyID, payables)
+	if len(items) == 0 {
+		return nil, domain.ErrNoPayablesDue
+	}
+
+	batchNo, err := s.repo.NextBatchNo(ctx, companyID, input.TransferDate)
+	if err != nil {
+		return nil, err
+	}
+	batch := &domain.PaymentBatch{
+		TenantModel:   domain.TenantModel{CompanyID: companyID},
+		BatchNo:       batchNo,
+		BankAccountID: bank.ID,
+		BankCode:      bank.BankCode,
+		TransferDate:  input.TransferDate,
+		DueBy:         dueBy,
+		Status:        domain.PaymentBatchGenerated,
+		FileName:      batchNo + "." + layout.Extension(),
+		CreatedBy:     &input.CreatedBy,
+	}
+	batch.SetItems(items)
+	if err := s.repo.Create(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	if skipped == nil {
+		skipped = []domain.PaymentBatchSkip{}
+	}
+	return &domain.PaymentBatchCreation{Batch: batch, Skipped: skipped}, nil
+}
+
+// GetByID returns a batch with its items
+func (s *paymentBatchService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PaymentBatch, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the batches of the company, latest first
+func (s *paymentBatchService) List(ctx context.Context, filter repository.PaymentBatchFilter) ([]domain.PaymentBatch, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// File writes the items of the batch as transfers from its bank account. The
+// payee sees the holder of the paying account; the payer sees the partner.
+func (s *paymentBatchService) File(ctx context.Context, companyID, id uuid.UUID) (*PaymentBatchFile, error) {
+	batch, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	bank, err := s.bankRepo.FindByID(ctx, companyID, batch.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := banktransfer.LayoutFor(batch.BankCode)
+	if err != nil {
+		return nil, err
+	}
+
+	payeeMemo := bank.AccountHolder
+	if payeeMemo == "" {
+		payeeMemo = bank.Name
+	}
+	file := &banktransfer.File{
+		AccountNumber: bank.AccountNumber,
+		TransferDate:  batch.TransferDate,
+		Transfers:     make([]banktransfer.Transfer, len(batch.Items)),
+	}
+	for i, item := range batch.Items {
+		file.Transfers[i] = banktransfer.Transfer{
+			BankCode:      item.BankCode,
+			AccountNumber: item.BankAccountNumber,
+			AccountHolder: item.BankAccountHolder,
+			Amount:        item.Amount,
+			PayeeMemo:     payeeMemo,
+			PayerMemo:     item.PayerMemo,
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := layout.Write(&buf, file); err != nil {
+		return nil, err
+	}
+	return &PaymentBatchFile{Name: batch.FileName, Content: buf.Bytes()}, nil
+}
+
+// Execute marks the failed items due again and the others paid, booking the
+// paid items by one draft payment voucher on the execution date. The batch is
+// executed before its voucher is created, in one transaction, so that an
+// executor losing the race to another leaves no voucher behind.
+func (s *paymentBatchService) Execute(ctx context.Context, companyID, userID, id uuid.UUID, executedOn time.Time, failedItemIDs []uuid.UUID) (*domain.PaymentBatch, error) {
+	batch, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Execute(executedOn, failedItemIDs); err != nil {
+		return nil, err
+	}
+	batch.UpdatedBy = &userID
+
+	err = s.repo.WithTransaction(ctx, func(repo repository.PaymentBatchRepository) error {
+		if err := repo.UpdateStatus(ctx, batch); err != nil {
+			return err
+		}
+		if batch.PaidCount == 0 {
+			return nil
+		}
+		voucher, err := s.paymentVoucher(ctx, batch, executedOn, userID)
+		if err != nil {
+			return err
+		}
+		batch.VoucherID = &voucher.ID
+		return repo.LinkVoucher(ctx, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// paymentVoucher generates the draft payment voucher of the paid items:
+// each partner's AP account against the cash account of the paying bank
+func (s *paymentBatchService) paymentVoucher(ctx context.Context, batch *domain.PaymentBatch, executedOn time.Time, userID uuid.UUID) (*domain.Voucher, error) {
+	bank, err := s.bankRepo.FindByID(ctx, batch.CompanyID, batch.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("대량이체 %s", batch.BatchNo)
+	var entries []domain.VoucherEntry
+	for _, item := range batch.Items {
+		if item.Status != domain.PaymentItemPaid {
+			continue
+		}
+		partnerID := item.PartnerID
+		payable := domain.VoucherEntry{
+			CompanyID:   batch.CompanyID,
+			AccountID:   item.AccountID,
+			Description: fmt.Sprintf("%s %s", description, item.VoucherNo),
+			PartnerID:   &partnerID,
+		}
+		payable.SetDebit(float64(item.Amount))
+		entries = append(entries, payable)
+	}
+	cash := domain.VoucherEntry{
+		CompanyID:     batch.CompanyID,
+		AccountID:     bank.AccountID,
+		Description:   description,
+		BankAccountID: &bank.ID,
+	}
+	cash.SetCredit(float64(batch.PaidAmount))
+	entries = append(entries, cash)
+
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: batch.CompanyID},
+		VoucherDate:   executedOn,
+		VoucherType:   domain.VoucherTypePayment,
+		Description:   fmt.Sprintf("%s (%d건)", description, batch.PaidCount),
+		ReferenceType: domain.PaymentBatchReferenceType,
+		ReferenceID:   &batch.ID,
+		Entries:       entries,
+		CreatedBy:     &userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// Cancel releases the items of a batch not executed
+func (s *paymentBatchService) Cancel(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.PaymentBatch, error) {
+	batch, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Cancel(); err != nil {
+		return nil, err
+	}
+	batch.UpdatedBy = &userID
+	if err := s.repo.UpdateStatus(ctx, batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
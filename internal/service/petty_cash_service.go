@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// pettyCashReferenceType marks vouchers generated for petty cash funds and their replenishments
+const pettyCashReferenceType = "petty_cash"
+
+// PettyCashFundUpdate holds the fields of a fund that change after it is
+// established; its float and cash account do not
+type PettyCashFundUpdate struct {
+	Name          string
+	CustodianID   *uuid.UUID
+	BankAccountID uuid.UUID
+	IsActive      bool
+}
+
+// PettyCashService defines the interface for petty cash funds
+type PettyCashService interface {
+	// CreateFund establishes a fund and generates the draft payment voucher
+	// drawing its float from the bank account
+	CreateFund(ctx context.Context, fund *domain.PettyCashFund) error
+	UpdateFund(ctx context.Context, companyID, id uuid.UUID, update PettyCashFundUpdate) (*domain.PettyCashFund, error)
+	GetFund(ctx context.Context, companyID, id uuid.UUID) (*domain.PettyCashFund, error)
+	ListFunds(ctx context.Context, companyID uuid.UUID, departmentID *uuid.UUID) ([]domain.PettyCashFund, error)
+
+	// Claim records an expense paid from the cash of an active fund
+	Claim(ctx context.Context, claim *domain.PettyCashTransaction) error
+	// DeleteClaim deletes a claim not replenished yet
+	DeleteClaim(ctx context.Context, companyID, id uuid.UUID) error
+	ListClaims(ctx context.Context, filter repository.PettyCashClaimFilter) ([]domain.PettyCashTransaction, int64, error)
+
+	// Replenish books the pending claims dated through the date to their
+	// expense accounts by a draft payment voucher from the fund's bank account
+	Replenish(ctx context.Context, companyID, userID, fundID uuid.UUID, requestDate, through time.Time) (*domain.PettyCashReplenishment, error)
+	ListReplenishments(ctx context.Context, companyID, fundID uuid.UUID) ([]domain.PettyCashReplenishment, error)
+
+	// Reconcile reconciles the cash the fund should hold at the end of asOf
+	// with the cash counted, if any
+	Reconcile(ctx context.Context, companyID, fundID uuid.UUID, asOf time.Time, counted *int64) (*domain.PettyCashReconciliation, error)
+}
+
+// pettyCashService implements PettyCashService
+type pettyCashService struct {
+	repo           repository.PettyCashRepository
+	accountRepo    repository.AccountRepository
+	departmentRepo repository.DepartmentRepository
+	userRepo       repository.UserRepository
+	bankRepo       repository.BankAccountRepository
+	ledgerRepo     repository.LedgerRepository
+	voucherService VoucherService
+}
+
+// NewPettyCashService creates a new PettyCashService
+func NewPettyCashService(
+	repo repository.PettyCashRepository,
+	accountRepo repository.AccountRepository,
+	departmentRepo repository.DepartmentRepository,
+	userRepo repository.UserRepository,
+	bankRepo repository.BankAccountRepository,
+	ledgerRepo repository.LedgerRepository,
+	voucherService VoucherService,
+) PettyCashService {
+	return &pettyCashService{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		departmentRepo: departmentRepo,
+		userRepo:       userRepo,
+		bankRepo:       bankRepo,
+		ledgerRepo:     ledgerRepo,
+		voucherService: voucherService,
+	}
+}
+
+// CreateFund validates the fund, its department, custodian and accounts, and
+// books the float: the fund's cash account against the bank account
+func (s *pettyCashService) CreateFund(ctx context.Context, fund *domain.PettyCashFund) error {
+	if err := fund.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.departmentRepo.GetByID(ctx, fund.CompanyID, fund.DepartmentID); err != nil {
+		return domain.ErrDepartmentNotFound
+	}
+	if err := s.checkCustodian(ctx, fund.CompanyID, fund.CustodianID); err != nil {
+		return err
+	}
+	account, err := s.accountRepo.FindByID(ctx, fund.CompanyID, fund.AccountID)
+	if err != nil {
+		return err
+	}
+	if !account.IsCashAccount {
+		return domain.ErrNotCashAccount
+	}
+	bank, err := s.activeBankAccount(ctx, fund.CompanyID, fund.BankAccountID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkFundName(ctx, fund.CompanyID, fund.Name, nil); err != nil {
+		return err
+	}
+
+	// The voucher references the fund, so its ID is assigned up front
+	if fund.ID, err = uuid.NewV7(); err != nil {
+		return err
+	}
+	description := fmt.Sprintf("소액현금 전도 %s", fund.Name)
+	cash := domain.VoucherEntry{
+		CompanyID:    fund.CompanyID,
+		AccountID:    fund.AccountID,
+		Description:  description,
+		DepartmentID: &fund.DepartmentID,
+	}
+	cash.SetDebit(float64(fund.FloatAmount))
+	voucher, err := s.createVoucher(ctx, fund, fund.EstablishedDate, fund.ID, description,
+		[]domain.VoucherEntry{cash, s.bankEntry(fund, bank, description, fund.FloatAmount)}, fund.CreatedBy)
+	if err != nil {
+		return err
+	}
+	fund.VoucherID = &voucher.ID
+	fund.IsActive = true
+	return s.repo.CreateFund(ctx, fund)
+}
+
+// UpdateFund renames the fund, hands it to another custodian, changes the
+// bank account it is replenished from or deactivates it
+func (s *pettyCashService) UpdateFund(ctx context.Context, companyID, id uuid.UUID, update PettyCashFundUpdate) (*domain.PettyCashFund, error) {
+	fund, err := s.repo.FindFund(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	fund.Name = update.Name
+	fund.CustodianID = update.CustodianID
+	fund.BankAccountID = update.BankAccountID
+	fund.IsActive = update.IsActive
+	if err := fund.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkCustodian(ctx, companyID, fund.CustodianID); err != nil {
+		return nil, err
+	}
+	if _, err := s.activeBankAccount(ctx, companyID, fund.BankAccountID); err != nil {
+		return nil, err
+	}
+	if err := s.checkFundName(ctx, companyID, fund.Name, &fund.ID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateFund(ctx, fund); err != nil {
+		return nil, err
+	}
+	return fund, nil
+}
+
+// GetFund returns a fund
+func (s *pettyCashService) GetFund(ctx context.Context, companyID, id uuid.UUID) (*domain.PettyCashFund, error) {
+	return s.repo.FindFund(ctx, companyID, id)
+}
+
+// ListFunds lists the funds of the company, of the department if given
+func (s *pettyCashService) ListFunds(ctx context.Context, companyID uuid.UUID, departmentID *uuid.UUID) ([]domain.PettyCashFund, error) {
+	return s.repo.ListFunds(ctx, companyID, departmentID)
+}
+
+// Claim validates the claim and its expense account; the repository checks
+// the cash on hand covers it
+func (s *pettyCashService) Claim(ctx context.Context, claim *domain.PettyCashTransaction) error {
+	if err := claim.Validate(); err != nil {
+		return err
+	}
+	fund, err := s.repo.FindFund(ctx, claim.CompanyID, claim.FundID)
+	if err != nil {
+		return err
+	}
+	if !fund.IsActive {
+		return domain.ErrPettyCashFundInactive
+	}
+	account, err := s.accountRepo.FindByID(ctx, claim.CompanyID, claim.AccountID)
+	if err != nil {
+		return err
+	}
+	if !account.CanPost() || account.ID == fund.AccountID {
+		return domain.ErrInvalidPettyCashClaim
+	}
+
+	claim.Status = domain.PettyCashClaimPending
+	return s.repo.CreateClaim(ctx, claim)
+}
+
+// DeleteClaim deletes a pending claim
+func (s *pettyCashService) DeleteClaim(ctx context.Context, companyID, id uuid.UUID) error {
+	if _, err := s.repo.FindClaim(ctx, companyID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteClaim(ctx, companyID, id)
+}
+
+// ListClaims lists the claims of a fund, latest first
+func (s *pettyCashService) ListClaims(ctx context.Context, filter repository.PettyCashClaimFilter) ([]domain.PettyCashTransaction, int64, error) {
+	if _, err := s.repo.FindFund(ctx, filter.CompanyID, filter.FundID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListClaims(ctx, filter)
+}
+
+// Replenish debits each claim to its expense account and the department of
+// the fund, and credits the total to the bank account the cash is drawn from
+func (s *pettyCashService) Replenish(ctx context.Context, companyID, userID, fundID uuid.UUID, requestDate, through time.Time) (*domain.PettyCashReplenishment, error) {
+	if requestDate.IsZero() || through.After(requestDate) {
+		return nil, domain.ErrInvalidPettyCashReplenishment
+	}
+	fund, err := s.repo.FindFund(ctx, companyID, fundID)
+	if err != nil {
+		return nil, err
+	}
+	if !fund.IsActive {
+		return nil, domain.ErrPettyCashFundInactive
+	}
+	bank, err := s.activeBankAccount(ctx, companyID, fund.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.PendingClaims(ctx, companyID, fundID)
+	if err != nil {
+		return nil, err
+	}
+	replenishment, err := domain.NewPettyCashReplenishment(fund, pending, requestDate, through)
+	if err != nil {
+		return nil, err
+	}
+
+	// The voucher references the replenishment, so its ID is assigned up front
+	if replenishment.ID, err = uuid.NewV7(); err != nil {
+		return nil, err
+	}
+	description := fmt.Sprintf("소액현금 보충 %s", fund.Name)
+	entries := make([]domain.VoucherEntry, 0, len(replenishment.Claims)+1)
+	for _, claim := range replenishment.Claims {
+		expense := domain.VoucherEntry{
+			CompanyID:    companyID,
+			AccountID:    claim.AccountID,
+			Description:  claimDescription(claim),
+			DepartmentID: &fund.DepartmentID,
+		}
+		expense.SetDebit(float64(claim.Amount))
+		entries = append(entries, expense)
+	}
+	entries = append(entries, s.bankEntry(fund, bank, description, replenishment.Amount))
+
+	voucher, err := s.createVoucher(ctx, fund, requestDate, replenishment.ID,
+		fmt.Sprintf("%s (%d건)", description, replenishment.ClaimCount), entries, &userID)
+	if err != nil {
+		return nil, err
+	}
+	replenishment.VoucherID = &voucher.ID
+	replenishment.CreatedBy = &userID
+	if err := s.repo.CreateReplenishment(ctx, replenishment); err != nil {
+		return nil, err
+	}
+	return replenishment, nil
+}
+
+// ListReplenishments lists the replenishments of a fund, latest first
+func (s *pettyCashService) ListReplenishments(ctx context.Context, companyID, fundID uuid.UUID) ([]domain.PettyCashReplenishment, error) {
+	if _, err := s.repo.FindFund(ctx, companyID, fundID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListReplenishments(ctx, companyID, fundID)
+}
+
+// Reconcile compares the float less the pending claims with the cash counted
+// and the posted balance of the fund's cash account at the end of asOf
+func (s *pettyCashService) Reconcile(ctx context.Context, companyID, fundID uuid.UUID, asOf time.Time, counted *int64) (*domain.PettyCashReconciliation, error) {
+	fund, err := s.repo.FindFund(ctx, companyID, fundID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.PendingClaims(ctx, companyID, fundID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.ledgerRepo.GetAccountBalancesBefore(ctx, companyID, []uuid.UUID{fund.AccountID}, asOf.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPettyCashReconciliation(fund, pending, asOf, balances[fund.AccountID], counted), nil
+}
+
+// claimDescription describes the expense entry of a claim by its payee
+func claimDescription(claim domain.PettyCashTransaction) string {
+	if claim.Payee == "" {
+		return claim.Description
+	}
+	return fmt.Sprintf("%s (%s)", claim.Description, claim.Payee)
+}
+
+// checkCustodian checks the custodian, if any, is a user of the company
+func (s *pettyCashService) checkCustodian(ctx context.Context, companyID uuid.UUID, custodianID *uuid.UUID) error {
+	if custodianID == nil {
+		return nil
+	}
+	_, err := s.userRepo.FindByID(ctx, companyID, *custodianID)
+	return err
+}
+
+// checkFundName checks no other fund of the company has the name
+func (s *pettyCashService) checkFundName(ctx context.Context, companyID uuid.UUID, name string, excludeID *uuid.UUID) error {
+	exists, err := s.repo.ExistsFundName(ctx, companyID, name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrPettyCashFundExists
+	}
+	return nil
+}
+
+// activeBankAccount returns the bank account the fund draws its cash from
+func (s *pettyCashService) activeBankAccount(ctx context.Context, companyID, id uuid.UUID) (*domain.CompanyBankAccount, error) {
+	bank, err := s.bankRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !bank.IsActive {
+		return nil, domain.ErrBankAccountInactive
+	}
+	return bank, nil
+}
+
+// bankEntry credits the amount drawn from the bank account
+func (s *pettyCashService) bankEntry(fund *domain.PettyCashFund, bank *domain.CompanyBankAccount, description string, amount int64) domain.VoucherEntry {
+	entry := domain.VoucherEntry{
+		CompanyID:     fund.CompanyID,
+		AccountID:     bank.AccountID,
+		Description:   description,
+		BankAccountID: &bank.ID,
+	}
+	entry.SetCredit(float64(amount))
+	return entry
+}
+
+// createVoucher generates a draft payment voucher of the fund
+func (s *pettyCashService) createVoucher(ctx context.Context, fund *domain.PettyCashFund, date time.Time, referenceID uuid.UUID, description string, entries []domain.VoucherEntry, userID *uuid.UUID) (*domain.Voucher, error) {
+	voucher := &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: fund.CompanyID},
+		VoucherDate:   date,
+		VoucherType:   domain.VoucherTypePayment,
+		Description:   description,
+		ReferenceType: pettyCashReferenceType,
+		ReferenceID:   &referenceID,
+		Entries:       entries,
+		CreatedBy:     userID,
+	}
+	if err := s.voucherService.Create(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
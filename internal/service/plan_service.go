@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// Plan enforcement modes
+const (
+	PlanEnforce = "enforce" // operations over a limit fail with a domain.PlanLimitError
+	PlanWarn    = "warn"    // operations over a limit are logged and allowed
+	PlanOff     = "off"     // limits are not checked
+)
+
+// PlanLimits checks the company's subscription plan before its usage of a
+// limited resource grows. PlanService implements it; a nil PlanLimits does
+// not limit anything.
+type PlanLimits interface {
+	// CheckLimit returns a domain.PlanLimitError if adding to the company's
+	// usage of the resource exceeds its plan
+	CheckLimit(ctx context.Context, companyID uuid.UUID, limit domain.PlanLimit, adding int64) error
+}
+
+// PlanOptions configures plan enforcement
+type PlanOptions struct {
+	Enforcement  string        // PlanEnforce, PlanWarn or PlanOff
+	GracePercent int           // usage allowed above a limit before operations fail
+	GracePeriod  time.Duration // after a plan change, the previous plan's higher limits still apply
+}
+
+// PlanService defines the interface for subscription plans
+type PlanService interface {
+	PlanLimits
+
+	// List returns the plans available
+	List(ctx context.Context) ([]domain.Plan, error)
+
+	// Subscription returns the company's plan with its usage of every limited resource
+	Subscription(ctx context.Context, companyID uuid.UUID) (*domain.CompanyPlan, []domain.PlanUsage, error)
+}
+
+// planService implements PlanService
+type planService struct {
+	repo    repository.PlanRepository
+	options PlanOptions
+	logger  *zap.Logger
+}
+
+// NewPlanService creates a new PlanService
+func NewPlanService(repo repository.PlanRepository, options PlanOptions, logger *zap.Logger) PlanService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &planService{repo: repo, options: options, logger: logger}
+}
+
+// List returns the active plans
+func (s *planService) List(ctx context.Context) ([]domain.Plan, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// Subscription returns the plan and usage of the company
+func (s *planService) Subscription(ctx context.Context, companyID uuid.UUID) (*domain.CompanyPlan, []domain.PlanUsage, error) {
+	plan, err := s.repo.GetCompanyPlan(ctx, companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	usage := make([]domain.PlanUsage, 0, len(domain.PlanLimits))
+	for _, limit := range domain.PlanLimits {
+		current, err := s.current(ctx, companyID, limit, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		usage = append(usage, domain.PlanUsage{
+			Limit:   limit,
+			Current: current,
+			Max:     plan.EffectiveMax(limit, s.options.GracePeriod, now),
+		})
+	}
+	return plan, usage, nil
+}
+
+// CheckLimit checks the company's plan; the usage is only read when the plan limits the resource
+func (s *planService) CheckLimit(ctx context.Context, companyID uuid.UUID, limit domain.PlanLimit, adding int64) error {
+	if s.options.Enforcement == PlanOff {
+		return nil
+	}
+	plan, err := s.repo.GetCompanyPlan(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	max := plan.EffectiveMax(limit, s.options.GracePeriod, now)
+	if max == nil {
+		return nil
+	}
+	current, err := s.current(ctx, companyID, limit, now)
+	if err != nil {
+		return err
+	}
+
+	err = domain.CheckPlanLimit(limit, plan.Plan.Code, max, current, adding, s.options.GracePercent)
+	var limitErr *domain.PlanLimitError
+	if !errors.As(err, &limitErr) {
+		return err
+	}
+	if plan.Plan.UpgradeTo != nil {
+		upgrade, err := s.repo.FindByCode(ctx, *plan.Plan.UpgradeTo)
+		if err != nil && !errors.Is(err, domain.ErrPlanNotFound) {
+			return err
+		}
+		limitErr.UpgradeTo = upgrade
+	}
+	if s.options.Enforcement == PlanWarn {
+		s.logger.Warn("Plan limit exceeded",
+			zap.String("company_id", companyID.String()),
+			zap.String("plan", plan.Plan.Code),
+			zap.String("limit", string(limit)),
+			zap.Int64("max", limitErr.Max),
+			zap.Int64("current", current),
+		)
+		return nil
+	}
+	return limitErr
+}
+
+// current returns the company's usage of the resource
+func (s *planService) current(ctx context.Context, companyID uuid.UUID, limit domain.PlanLimit, now time.Time) (int64, error) {
+	switch limit {
+	case domain.PlanLimitUsers:
+		return s.repo.CountActiveUsers(ctx, companyID)
+	case domain.PlanLimitVouchers:
+		return s.repo.CountVouchersSince(ctx, companyID, domain.UsagePeriodOf(now))
+	case domain.PlanLimitStorage:
+		return s.repo.StorageBytes(ctx, companyID)
+	}
+	return 0, nil
+}
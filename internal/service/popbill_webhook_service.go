@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+const (
+	// popbillWebhookBatchSize is the number of callbacks applied per worker run
+	popbillWebhookBatchSize = 50
+	// popbillWebhookRetryAfter is how long a failed callback waits before it is retried
+	popbillWebhookRetryAfter = time.Minute
+	// popbillWebhookStaleAfter is how long a callback may stay processing before
+	// another worker assumes the first one crashed
+	popbillWebhookStaleAfter = 5 * time.Minute
+	// popbillWebhookSecretGrace is how long callbacks signed with a rotated
+	// secret are accepted, so that the secret can be changed at Popbill and
+	// here in either order
+	popbillWebhookSecretGrace = time.Hour
+)
+
+// PopbillWebhookService defines the interface for Popbill tax invoice callbacks.
+// Callbacks are verified and stored when they arrive and applied to the tax
+// invoices by the worker, so that Popbill gets its response without waiting.
+type PopbillWebhookService interface {
+	// Receive verifies the signature and timestamp of a callback and stores it.
+	// A callback that was already received returns ErrPopbillWebhookDuplicate.
+	Receive(ctx context.Context, body []byte, timestamp, signature, remoteAddr string) (*domain.PopbillWebhookEvent, error)
+	List(ctx context.Context, filter repository.PopbillWebhookFilter) ([]domain.PopbillWebhookEvent, int64, error)
+
+	// ProcessPending applies stored callbacks to their invoices and returns the number processed
+	ProcessPending(ctx context.Context) (int, error)
+
+	// RotateSecret replaces the signing secret of callbacks; the previous one
+	// stays accepted for a grace period
+	RotateSecret(secret string)
+}
+
+// popbillWebhookService implements PopbillWebhookService
+type popbillWebhookService struct {
+	repo        repository.PopbillWebhookRepository
+	invoiceRepo repository.TaxInvoiceRepository
+	tolerance   time.Duration
+
+	mu             sync.RWMutex
+	secret         []byte
+	previousSecret []byte
+	previousUntil  time.Time
+}
+
+// NewPopbillWebhookService creates a new PopbillWebhookService.
+// Callbacks are rejected when secret is empty.
+func NewPopbillWebhookService(
+	repo repository.PopbillWebhookRepository,
+	invoiceRepo repository.TaxInvoiceRepository,
+	secret string,
+	tolerance time.Duration,
+) PopbillWebhookService {
+	return &popbillWebhookService{
+		repo:        repo,
+		invoiceRepo: invoiceRepo,
+		secret:      []byte(secret),
+		tolerance:   tolerance,
+	}
+}
+
+// Receive stores a verified callback for the worker
+func (s *popbillWebhookService) Receive(ctx context.Context, body []byte, timestamp, signature, remoteAddr string) (*domain.PopbillWebhookEvent, error) {
+	s.mu.RLock()
+	secret, previous := s.secret, s.previousSecret
+	if time.Now().After(s.previousUntil) {
+		previous = nil
+	}
+	s.mu.RUnlock()
+
+	if len(secret) == 0 {
+		return nil, domain.ErrPopbillWebhookDisabled
+	}
+	now := time.Now()
+	if err := popbill.VerifyWebhook(secret, timestamp, signature, body, s.tolerance, now); err != nil {
+		if len(previous) == 0 || popbill.VerifyWebhook(previous, timestamp, signature, body, s.tolerance, now) != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := popbill.ParseWebhookEvent(body)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &domain.PopbillWebhookEvent{
+		EventKey:   payload.Key(body),
+		EventType:  payload.EventType,
+		CorpNum:    payload.CorpNum,
+		ItemKey:    payload.ItemKey,
+		StateCode:  payload.StateCode,
+		Payload:    body,
+		OccurredAt: payload.OccurredAt(),
+		Status:     domain.PopbillWebhookStatusReceived,
+		RemoteAddr: remoteAddr,
+		ReceivedAt: now,
+	}
+	if err := s.repo.Create(ctx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// List returns the callbacks applied to the company's invoices
+func (s *popbillWebhookService) List(ctx context.Context, filter repository.PopbillWebhookFilter) ([]domain.PopbillWebhookEvent, int64, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+	return s.repo.FindAll(ctx, filter)
+}
+
+// RotateSecret replaces the signing secret; an unchanged secret is ignored
+func (s *popbillWebhookService) RotateSecret(secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if secret == string(s.secret) {
+		return
+	}
+	s.previousSecret = s.secret
+	s.previousUntil = time.Now().Add(popbillWebhookSecretGrace)
+	s.secret = []byte(secret)
+}
+
+// ProcessPending claims stored callbacks one at a time and applies them.
+// Failures are recorded on the event and returned as a joined error for logging.
+func (s *popbillWebhookService) ProcessPending(ctx context.Context) (int, error) {
+	count := 0
+	var errs []error
+	for count < popbillWebhookBatchSize {
+		now := time.Now()
+		event, err := s.repo.ClaimNext(ctx, now.Add(-popbillWebhookRetryAfter), now.Add(-popbillWebhookStaleAfter))
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if event == nil {
+			break
+		}
+		count++
+
+		status, err := s.apply(ctx, event)
+		if status == domain.PopbillWebhookStatusFailed {
+			errs = append(errs, fmt.Errorf("popbill webhook %s: %w", event.ID, err))
+		}
+		event.Finish(status, err, time.Now())
+		if err := s.repo.Finish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("popbill webhook %s: %w", event.ID, err))
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// apply updates the invoice of the callback and records the status change in its
+// history. The returned error explains ignored callbacks as well as failures.
+func (s *popbillWebhookService) apply(ctx context.Context, event *domain.PopbillWebhookEvent) (domain.PopbillWebhookStatus, error) {
+	payload, err := popbill.ParseWebhookEvent(event.Payload)
+	if err != nil {
+		return domain.PopbillWebhookStatusIgnored, err
+	}
+
+	invoice, err := s.invoiceRepo.GetByASPInvoiceID(ctx, popbill.ASPProvider, payload.ItemKey)
+	if errors.Is(err, domain.ErrTaxInvoiceNotFound) {
+		return domain.PopbillWebhookStatusIgnored, err
+	}
+	if err != nil {
+		return domain.PopbillWebhookStatusFailed, err
+	}
+	event.CompanyID = &invoice.CompanyID
+	event.TaxInvoiceID = &invoice.ID
+
+	previous := invoice.Status
+	if status, ok := payload.InvoiceStatus(); ok && invoice.CanAdvanceTo(status) {
+		invoice.Status = status
+	}
+	var confirmedAt *time.Time
+	if invoice.Status == domain.TaxInvoiceStatusConfirmed {
+		confirmedAt = payload.NTSResultAt()
+	}
+	ntsChanged := invoice.ApplyNTSResult(payload.NTSConfirmNum, payload.NTSSentAt(), confirmedAt)
+
+	if invoice.Status == previous && !ntsChanged {
+		return domain.PopbillWebhookStatusIgnored, fmt.Errorf("state %d does not change the invoice in status %s", payload.StateCode, previous)
+	}
+
+	invoice.UpdatedAt = time.Now()
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return domain.PopbillWebhookStatusFailed, err
+	}
+	if invoice.Status == previous {
+		return domain.PopbillWebhookStatusProcessed, nil
+	}
+
+	reason := fmt.Sprintf("Popbill %s (state %d)", payload.EventType, payload.StateCode)
+	if payload.NTSResult != "" {
+		reason += ": " + payload.NTSResult
+	}
+	history := &domain.TaxInvoiceHistory{
+		ID:             uuid.New(),
+		TaxInvoiceID:   invoice.ID,
+		CompanyID:      invoice.CompanyID,
+		PreviousStatus: previous,
+		NewStatus:      invoice.Status,
+		ChangeReason:   reason,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.invoiceRepo.CreateHistory(ctx, history); err != nil {
+		return domain.PopbillWebhookStatusFailed, err
+	}
+	return domain.PopbillWebhookStatusProcessed, nil
+}
@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+func newTestWebhookService() (*mocks.MockPopbillWebhookRepository, *mocks.MockTaxInvoiceRepository, service.PopbillWebhookService) {
+	repo := new(mocks.MockPopbillWebhookRepository)
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	return repo, invoiceRepo, service.NewPopbillWebhookService(repo, invoiceRepo, testWebhookSecret, 5*time.Minute)
+}
+
+func signTestWebhook(body []byte) (string, string) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return timestamp, popbill.SignWebhook([]byte(testWebhookSecret), timestamp, body)
+}
+
+func TestPopbillWebhookService_Receive(t *testing.T) {
+	repo, _, svc := newTestWebhookService()
+	body := []byte(`{"eventID":"evt-1","eventType":"NTS","itemKey":"024010100000000001","stateCode":304}`)
+	timestamp, signature := signTestWebhook(body)
+
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.PopbillWebhookEvent) bool {
+		return e.EventKey == "id:evt-1" && e.ItemKey == "024010100000000001" && e.Status == domain.PopbillWebhookStatusReceived
+	})).Return(nil).Once()
+
+	event, err := svc.Receive(context.Background(), body, timestamp, signature, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 304, event.StateCode)
+	assert.Equal(t, "10.0.0.1", event.RemoteAddr)
+
+	repo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrPopbillWebhookDuplicate).Once()
+	_, err = svc.Receive(context.Background(), body, timestamp, signature, "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrPopbillWebhookDuplicate)
+	repo.AssertExpectations(t)
+}
+
+func TestPopbillWebhookService_Receive_InvalidSignature(t *testing.T) {
+	repo, _, svc := newTestWebhookService()
+	body := []byte(`{"itemKey":"024010100000000001","stateCode":304}`)
+	timestamp, _ := signTestWebhook(body)
+
+	_, err := svc.Receive(context.Background(), body, timestamp, "bad", "10.0.0.1")
+	assert.ErrorIs(t, err, popbill.ErrInvalidWebhookSignature)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	disabled := service.NewPopbillWebhookService(repo, nil, "", time.Minute)
+	_, err = disabled.Receive(context.Background(), body, timestamp, "bad", "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrPopbillWebhookDisabled)
+}
+
+func TestPopbillWebhookService_RotateSecret(t *testing.T) {
+	repo, _, svc := newTestWebhookService()
+	body := []byte(`{"eventID":"evt-2","itemKey":"024010100000000001","stateCode":304}`)
+	timestamp, oldSignature := signTestWebhook(body)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc.RotateSecret("rotated-secret")
+
+	// Both the new and the previous secret are accepted during the grace period
+	_, err := svc.Receive(context.Background(), body, timestamp, popbill.SignWebhook([]byte("rotated-secret"), timestamp, body), "10.0.0.1")
+	assert.NoError(t, err)
+	_, err = svc.Receive(context.Background(), body, timestamp, oldSignature, "10.0.0.1")
+	assert.NoError(t, err)
+
+	// A second rotation retires the first secret
+	svc.RotateSecret("third-secret")
+	_, err = svc.Receive(context.Background(), body, timestamp, oldSignature, "10.0.0.1")
+	assert.ErrorIs(t, err, popbill.ErrInvalidWebhookSignature)
+}
+
+func TestPopbillWebhookService_ProcessPending(t *testing.T) {
+	repo, invoiceRepo, svc := newTestWebhookService()
+	invoice := &domain.TaxInvoice{
+		ID:           uuid.New(),
+		CompanyID:    newTestCompanyID(),
+		Status:       domain.TaxInvoiceStatusTransmitted,
+		ASPProvider:  popbill.ASPProvider,
+		ASPInvoiceID: "024010100000000001",
+	}
+	confirmed := &domain.PopbillWebhookEvent{
+		ID:      uuid.New(),
+		ItemKey: invoice.ASPInvoiceID,
+		Payload: []byte(`{"eventType":"NTS","itemKey":"024010100000000001","stateCode":304,
+			"ntsconfirmNum":"20240102-41000000-00000001","ntsresultDT":"20240102100000"}`),
+		Status: domain.PopbillWebhookStatusProcessing,
+	}
+	// Arrives after the confirmation and must not move the invoice back
+	late := &domain.PopbillWebhookEvent{
+		ID:      uuid.New(),
+		ItemKey: invoice.ASPInvoiceID,
+		Payload: []byte(`{"eventType":"Issue","itemKey":"024010100000000001","stateCode":300}`),
+		Status:  domain.PopbillWebhookStatusProcessing,
+	}
+	unknown := &domain.PopbillWebhookEvent{
+		ID:      uuid.New(),
+		ItemKey: "999",
+		Payload: []byte(`{"itemKey":"999","stateCode":304}`),
+		Status:  domain.PopbillWebhookStatusProcessing,
+	}
+
+	repo.On("ClaimNext", mock.Anything, mock.Anything, mock.Anything).Return(confirmed, nil).Once()
+	repo.On("ClaimNext", mock.Anything, mock.Anything, mock.Anything).Return(late, nil).Once()
+	repo.On("ClaimNext", mock.Anything, mock.Anything, mock.Anything).Return(unknown, nil).Once()
+	repo.On("ClaimNext", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+	invoiceRepo.On("GetByASPInvoiceID", mock.Anything, popbill.ASPProvider, invoice.ASPInvoiceID).Return(invoice, nil)
+	invoiceRepo.On("GetByASPInvoiceID", mock.Anything, popbill.ASPProvider, "999").Return(nil, domain.ErrTaxInvoiceNotFound)
+	invoiceRepo.On("Update", mock.Anything, invoice).Return(nil).Once()
+	invoiceRepo.On("CreateHistory", mock.Anything, mock.MatchedBy(func(h *domain.TaxInvoiceHistory) bool {
+		return h.PreviousStatus == domain.TaxInvoiceStatusTransmitted && h.NewStatus == domain.TaxInvoiceStatusConfirmed
+	})).Return(nil).Once()
+
+	count, err := svc.ProcessPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	assert.Equal(t, domain.TaxInvoiceStatusConfirmed, invoice.Status)
+	assert.Equal(t, "20240102-41000000-00000001", invoice.NTSConfirmNumber)
+	require.NotNil(t, invoice.NTSConfirmedAt)
+
+	assert.Equal(t, domain.PopbillWebhookStatusProcessed, confirmed.Status)
+	assert.Equal(t, invoice.CompanyID, *confirmed.CompanyID)
+	assert.Equal(t, domain.PopbillWebhookStatusIgnored, late.Status)
+	assert.Equal(t, domain.PopbillWebhookStatusIgnored, unknown.Status)
+	assert.Nil(t, unknown.CompanyID)
+	invoiceRepo.AssertExpectations(t)
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// prepaidExpenseReferenceType marks the prepayment and amortization vouchers
+// of a prepaid expense
+const prepaidExpenseReferenceType = "prepaid_expense"
+
+// PrepaidExpenseInput describes the prepaid expense of a posted voucher
+type PrepaidExpenseInput struct {
+	VoucherID        uuid.UUID
+	ExpenseAccountID uuid.UUID
+	PrepaidAccountID uuid.UUID
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Amount           *int64 // defaults to the expense debited by the voucher
+	Description      string // defaults to the voucher number and period
+}
+
+// PrepaidExpenseService defines the interface for amortization schedules of
+// prepaid expenses (선급비용)
+type PrepaidExpenseService interface {
+	// Create marks the expense of a posted voucher as prepaid over a period
+	// and generates the draft prepayment voucher moving it from the expense
+	// to the prepaid account at the voucher date
+	Create(ctx context.Context, companyID, userID uuid.UUID, input PrepaidExpenseInput) (*domain.PrepaidExpense, error)
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PrepaidExpense, error)
+	List(ctx context.Context, filter repository.PrepaidExpenseFilter) ([]domain.PrepaidExpense, int64, error)
+
+	// Amortize posts the amortization vouchers of the months ending on or
+	// before periodEnd, for one company or for all when companyID is nil.
+	// Prepaid expenses whose prepayment voucher is not posted yet, or whose
+	// month is in a closed period, wait for a later run.
+	Amortize(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.PrepaidAmortizationRun, error)
+
+	// BalanceReport lists the remaining balance of each prepaid expense at
+	// the date, of one prepaid account when accountID is set
+	BalanceReport(ctx context.Context, companyID uuid.UUID, asOf time.Time, accountID *uuid.UUID) (*domain.PrepaidExpenseBalanceReport, error)
+}
+
+// prepaidExpenseService implements PrepaidExpenseService
+type prepaidExpenseService struct {
+	repo           repository.PrepaidExpenseRepository
+	accountRepo    repository.AccountRepository
+	voucherRepo    repository.VoucherRepository
+	voucherService VoucherService
+}
+
+// NewPrepaidExpenseService creates a new PrepaidExpenseService
+func NewPrepaidExpenseService(
+	repo repository.PrepaidExpenseRepository,
+	accountRepo repository.AccountRepository,
+	voucherRepo repository.VoucherRepository,
+	voucherService VoucherService,
+) PrepaidExpenseService {
+	return &prepaidExpenseService{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		voucherRepo:    voucherRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Create builds the monthly lines of the prepaid expense, stores it and links
+// the prepayment voucher. The partner is the one of the expense entries, if
+// any.
+func (s *prepaidExpenseService) Create(ctx context.Context, companyID, userID uuid.UUID, input PrepaidExpenseInput) (*domain.PrepaidExpense, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, input.VoucherID)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.Status != domain.VoucherStatusPosted || voucher.IsReversal {
+		return nil, domain.ErrPrepaidExpenseVoucher
+	}
+	var expensed float64
+	var partnerID *uuid.UUID
+	for _, entry := range voucher.Entries {
+		if entry.AccountID != input.ExpenseAccountID || entry.DebitAmount <= 0 {
+			continue
+		}
+		expensed += entry.DebitAmount
+		if partnerID == nil {
+			partnerID = entry.PartnerID
+		}
+	}
+	if expensed == 0 {
+		return nil, domain.ErrPrepaidExpenseVoucher
+	}
+	exists, err := s.repo.ExistsForVoucher(ctx, companyID, voucher.ID, input.ExpenseAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrPrepaidExpenseExists
+	}
+
+	amount := int64(math.Round(expensed))
+	if input.Amount != nil {
+		if *input.Amount > amount {
+			return nil, domain.ErrInvalidPrepaidExpense
+		}
+		amount = *input.Amount
+	}
+	description := input.Description
+	if description == "" {
+		description = fmt.Sprintf("%s 선급비용 %s~%s", voucher.VoucherNo,
+			input.PeriodStart.Format("2006-01-02"), input.PeriodEnd.Format("2006-01-02"))
+	}
+
+	expense := &domain.PrepaidExpense{
+		TenantModel:      domain.TenantModel{CompanyID: companyID},
+		VoucherID:        voucher.ID,
+		VoucherNo:        voucher.VoucherNo,
+		PartnerID:        partnerID,
+		Description:      description,
+		PeriodStart:      input.PeriodStart,
+		PeriodEnd:        input.PeriodEnd,
+		Amount:           amount,
+		ExpenseAccountID: input.ExpenseAccountID,
+		PrepaidAccountID: input.PrepaidAccountID,
+		PrepaymentDate:   voucher.VoucherDate,
+		CreatedBy:        &userID,
+	}
+	if err := expense.Validate(); err != nil {
+		return nil, err
+	}
+	expenseAccount, err := s.accountRepo.FindByID(ctx, companyID, expense.ExpenseAccountID)
+	if err != nil {
+		return nil, err
+	}
+	prepaidAccount, err := s.accountRepo.FindByID(ctx, companyID, expense.PrepaidAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if expenseAccount.AccountType != domain.AccountTypeExpense || prepaidAccount.AccountType != domain.AccountTypeAsset {
+		return nil, domain.ErrInvalidPrepaidExpense
+	}
+
+	if err := s.repo.Create(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	// Prepayment: prepaid against the expense, reviewed before posting
+	prepayment := s.voucher(expense, expense.PrepaymentDate,
+		fmt.Sprintf("선급비용 대체 %s", expense.Description),
+		expense.PrepaidAccountID, expense.ExpenseAccountID, expense.Amount)
+	if err := s.voucherService.Create(ctx, prepayment); err != nil {
+		return nil, err
+	}
+	expense.PrepaymentVoucherID = &prepayment.ID
+	if err := s.repo.UpdatePrepayment(ctx, expense); err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+// GetByID returns a prepaid expense with its monthly lines
+func (s *prepaidExpenseService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.PrepaidExpense, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the prepaid expenses of the company, latest period start first
+func (s *prepaidExpenseService) List(ctx context.Context, filter repository.PrepaidExpenseFilter) ([]domain.PrepaidExpense, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Amortize posts each due line of the prepaid expenses with a posted
+// prepayment, the expense against the prepaid account at the month end, on
+// behalf of the creator of the prepaid expense
+func (s *prepaidExpenseService) Amortize(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.PrepaidAmortizationRun, error) {
+	expenses, err := s.repo.ListForAmortization(ctx, companyID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.PrepaidAmortizationRun{PeriodEnd: periodEnd, Vouchers: []uuid.UUID{}}
+	var errs []error
+	for i := range expenses {
+		expense := &expenses[i]
+		lines := expense.DueLines(periodEnd)
+		if len(lines) == 0 {
+			continue
+		}
+		run.Checked++
+
+		posted, err := s.prepaymentPosted(ctx, expense)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("prepaid expense %s: %w", expense.VoucherNo, err))
+			continue
+		}
+		if !posted {
+			run.Waiting++
+			continue
+		}
+
+		userID := uuid.Nil
+		if expense.CreatedBy != nil {
+			userID = *expense.CreatedBy
+		}
+		for _, line := range lines {
+			voucher, err := s.amortize(ctx, expense, line, userID)
+			if errors.Is(err, domain.ErrFiscalPeriodClosed) {
+				run.Waiting++
+				break
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("prepaid expense %s: %w", expense.VoucherNo, err))
+				break
+			}
+			if voucher != nil {
+				run.Amortized++
+				run.Vouchers = append(run.Vouchers, voucher.ID)
+			}
+		}
+	}
+	return run, errors.Join(errs...)
+}
+
+// amortize records the line posted, then posts its voucher, nil for a line
+// rounded to nothing. The line is recorded first so that a run repeated after
+// a failure does not post the month again; it returns to pending when the
+// voucher fails to post.
+func (s *prepaidExpenseService) amortize(ctx context.Context, expense *domain.PrepaidExpense, line *domain.PrepaidExpenseLine, userID uuid.UUID) (*domain.Voucher, error) {
+	expense.Amortize(line, nil, time.Now())
+	if err := s.repo.RecordAmortization(ctx, expense, line); err != nil {
+		return nil, err
+	}
+	if line.Amount == 0 {
+		return nil, nil
+	}
+
+	voucher := s.voucher(expense, line.PeriodEnd,
+		fmt.Sprintf("선급비용 상각 %s %s", expense.Description, line.PeriodEnd.Format("2006-01")),
+		expense.ExpenseAccountID, expense.PrepaidAccountID, line.Amount)
+	if err := s.voucherService.PostGenerated(ctx, voucher, userID); err != nil {
+		if undoErr := s.repo.ReleaseAmortization(ctx, expense, line); undoErr != nil {
+			return nil, errors.Join(err, undoErr)
+		}
+		return nil, err
+	}
+	line.VoucherID = &voucher.ID
+	if err := s.repo.LinkAmortizationVoucher(ctx, line); err != nil {
+		return nil, fmt.Errorf("amortization voucher %s is posted but not linked to its line: %w", voucher.VoucherNo, err)
+	}
+	return voucher, nil
+}
+
+// prepaymentPosted tells whether the prepayment voucher of the prepaid
+// expense is posted
+func (s *prepaidExpenseService) prepaymentPosted(ctx context.Context, expense *domain.PrepaidExpense) (bool, error) {
+	if expense.PrepaymentVoucherID == nil {
+		return false, nil
+	}
+	voucher, err := s.voucherRepo.FindByID(ctx, expense.CompanyID, *expense.PrepaymentVoucherID)
+	if err != nil {
+		return false, err
+	}
+	return voucher.Status == domain.VoucherStatusPosted, nil
+}
+
+// voucher returns an adjustment voucher of the prepaid expense moving the
+// amount from the credit to the debit account
+func (s *prepaidExpenseService) voucher(expense *domain.PrepaidExpense, date time.Time, description string, debitAccountID, creditAccountID uuid.UUID, amount int64) *domain.Voucher {
+	debit := domain.VoucherEntry{
+		CompanyID:   expense.CompanyID,
+		AccountID:   debitAccountID,
+		Description: description,
+		PartnerID:   expense.PartnerID,
+	}
+	debit.SetDebit(float64(amount))
+	credit := domain.VoucherEntry{
+		CompanyID:   expense.CompanyID,
+		AccountID:   creditAccountID,
+		Description: description,
+		PartnerID:   expense.PartnerID,
+	}
+	credit.SetCredit(float64(amount))
+
+	return &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: expense.CompanyID},
+		VoucherDate:   date,
+		VoucherType:   domain.VoucherTypeAdjustment,
+		Description:   description,
+		ReferenceType: prepaidExpenseReferenceType,
+		ReferenceID:   &expense.ID,
+		Entries:       []domain.VoucherEntry{debit, credit},
+		CreatedBy:     expense.CreatedBy,
+	}
+}
+
+// BalanceReport builds the balance report of the prepaid expenses of the company
+func (s *prepaidExpenseService) BalanceReport(ctx context.Context, companyID uuid.UUID, asOf time.Time, accountID *uuid.UUID) (*domain.PrepaidExpenseBalanceReport, error) {
+	expenses, err := s.repo.ListWithLines(ctx, companyID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPrepaidExpenseBalanceReport(expenses, asOf), nil
+}
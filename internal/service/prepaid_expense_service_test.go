@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// newServiceTestPrepaidExpense returns a 900,000 won premium of the first
+// quarter of 2026 whose prepayment voucher is posted
+func newServiceTestPrepaidExpense(t *testing.T, companyID uuid.UUID, voucherRepo *mocks.MockVoucherRepository) domain.PrepaidExpense {
+	userID := newTestUserID()
+	prepaymentID := uuid.New()
+	expense := domain.PrepaidExpense{
+		TenantModel:         domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		VoucherID:           uuid.New(),
+		VoucherNo:           "GEN-2026-000001",
+		Description:         "화재보험료",
+		PeriodStart:         time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:           time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Amount:              900000,
+		ExpenseAccountID:    uuid.New(),
+		PrepaidAccountID:    uuid.New(),
+		PrepaymentDate:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		PrepaymentVoucherID: &prepaymentID,
+		CreatedBy:           &userID,
+	}
+	require.NoError(t, expense.Validate())
+
+	prepayment := &domain.Voucher{Status: domain.VoucherStatusPosted}
+	voucherRepo.On("FindByID", mock.Anything, companyID, prepaymentID).Return(prepayment, nil)
+	return expense
+}
+
+func TestPrepaidExpenseService_Amortize(t *testing.T) {
+	ctx := context.Background()
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	periodEnd := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	t.Run("records each month before posting its voucher", func(t *testing.T) {
+		repo, voucherRepo, vouchers := new(mocks.MockPrepaidExpenseRepository), new(mocks.MockVoucherRepository), new(mocks.MockVoucherService)
+		svc := service.NewPrepaidExpenseService(repo, nil, voucherRepo, vouchers)
+		expense := newServiceTestPrepaidExpense(t, companyID, voucherRepo)
+
+		var steps []string
+		repo.On("ListForAmortization", ctx, &companyID, periodEnd).Return([]domain.PrepaidExpense{expense}, nil).Once()
+		repo.On("RecordAmortization", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			line := args.Get(2).(*domain.PrepaidExpenseLine)
+			assert.Nil(t, line.VoucherID)
+			steps = append(steps, "record")
+		}).Return(nil).Twice()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Voucher).ID = uuid.New()
+			steps = append(steps, "post")
+		}).Return(nil).Twice()
+		repo.On("LinkAmortizationVoucher", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			assert.NotNil(t, args.Get(1).(*domain.PrepaidExpenseLine).VoucherID)
+			steps = append(steps, "link")
+		}).Return(nil).Twice()
+
+		run, err := svc.Amortize(ctx, &companyID, periodEnd)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, run.Amortized)
+		assert.Len(t, run.Vouchers, 2)
+		assert.Equal(t, []string{"record", "post", "link", "record", "post", "link"}, steps)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("posts no voucher when the line is not recorded", func(t *testing.T) {
+		repo, voucherRepo, vouchers := new(mocks.MockPrepaidExpenseRepository), new(mocks.MockVoucherRepository), new(mocks.MockVoucherService)
+		svc := service.NewPrepaidExpenseService(repo, nil, voucherRepo, vouchers)
+		expense := newServiceTestPrepaidExpense(t, companyID, voucherRepo)
+
+		repo.On("ListForAmortization", ctx, &companyID, periodEnd).Return([]domain.PrepaidExpense{expense}, nil).Once()
+		repo.On("RecordAmortization", ctx, mock.Anything, mock.Anything).Return(domain.ErrPrepaidExpenseLineChanged).Once()
+
+		run, err := svc.Amortize(ctx, &companyID, periodEnd)
+
+		assert.ErrorIs(t, err, domain.ErrPrepaidExpenseLineChanged)
+		assert.Zero(t, run.Amortized)
+		vouchers.AssertNotCalled(t, "PostGenerated", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns the line to pending when its voucher fails", func(t *testing.T) {
+		repo, voucherRepo, vouchers := new(mocks.MockPrepaidExpenseRepository), new(mocks.MockVoucherRepository), new(mocks.MockVoucherService)
+		svc := service.NewPrepaidExpenseService(repo, nil, voucherRepo, vouchers)
+		expense := newServiceTestPrepaidExpense(t, companyID, voucherRepo)
+		postErr := errors.New("connection reset")
+
+		repo.On("ListForAmortization", ctx, &companyID, periodEnd).Return([]domain.PrepaidExpense{expense}, nil).Once()
+		repo.On("RecordAmortization", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).Return(postErr).Once()
+		repo.On("ReleaseAmortization", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+		run, err := svc.Amortize(ctx, &companyID, periodEnd)
+
+		assert.ErrorIs(t, err, postErr)
+		assert.Zero(t, run.Amortized)
+		repo.AssertNotCalled(t, "LinkAmortizationVoucher", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// QuickVoucherService defines the interface for vouchers typed as shorthand
+// entry rows, with codes in place of IDs
+type QuickVoucherService interface {
+	// Create resolves the codes of the entries, balances the entry typed
+	// without an amount and creates the voucher with them
+	Create(ctx context.Context, voucher *domain.Voucher, entries []domain.QuickEntry) error
+}
+
+// quickVoucherService implements QuickVoucherService
+type quickVoucherService struct {
+	vouchers       VoucherService
+	accountRepo    repository.AccountRepository
+	partnerRepo    repository.PartnerRepository
+	departmentRepo repository.DepartmentRepository
+	projectRepo    repository.ProjectRepository
+	taxCodeRepo    repository.TaxCodeRepository
+}
+
+// NewQuickVoucherService creates a new QuickVoucherService
+func NewQuickVoucherService(
+	vouchers VoucherService,
+	accountRepo repository.AccountRepository,
+	partnerRepo repository.PartnerRepository,
+	departmentRepo repository.DepartmentRepository,
+	projectRepo repository.ProjectRepository,
+	taxCodeRepo repository.TaxCodeRepository,
+) QuickVoucherService {
+	return &quickVoucherService{
+		vouchers:       vouchers,
+		accountRepo:    accountRepo,
+		partnerRepo:    partnerRepo,
+		departmentRepo: departmentRepo,
+		projectRepo:    projectRepo,
+		taxCodeRepo:    taxCodeRepo,
+	}
+}
+
+// Create reports all unknown codes at once, so that the rows can be corrected
+// in one go
+func (s *quickVoucherService) Create(ctx context.Context, voucher *domain.Voucher, entries []domain.QuickEntry) error {
+	if len(entries) == 0 {
+		return domain.ErrVoucherNoEntries
+	}
+	entries = append([]domain.QuickEntry(nil), entries...)
+	if err := domain.BalanceQuickEntries(entries); err != nil {
+		return err
+	}
+
+	codes, err := s.resolve(ctx, voucher.CompanyID, entries)
+	if err != nil {
+		return err
+	}
+	unknown := &domain.UnknownCodesError{}
+	voucher.Entries = make([]domain.VoucherEntry, len(entries))
+	for i, e := range entries {
+		entry := &voucher.Entries[i]
+		entry.CompanyID = voucher.CompanyID
+		entry.Description = e.Description
+		if e.Side == domain.AccountNatureDebit {
+			entry.DebitAmount = e.Amount
+		} else {
+			entry.CreditAmount = e.Amount
+		}
+
+		lookup := func(field, code string) *uuid.UUID {
+			if code == "" {
+				return nil
+			}
+			if id, ok := codes[field][code]; ok {
+				return &id
+			}
+			unknown.Codes = append(unknown.Codes, domain.UnknownEntryCode{LineNo: i + 1, Field: field, Code: code})
+			return nil
+		}
+		if id := lookup("account", e.AccountCode); id != nil {
+			entry.AccountID = *id
+		}
+		entry.PartnerID = lookup("partner", e.PartnerCode)
+		entry.DepartmentID = lookup("department", e.DepartmentCode)
+		entry.ProjectID = lookup("project", e.ProjectCode)
+		entry.TaxCodeID = lookup("tax_code", e.TaxCode)
+	}
+	if len(unknown.Codes) > 0 {
+		return unknown
+	}
+
+	return s.vouchers.Create(ctx, voucher)
+}
+
+// resolve looks up the codes used by the entries, returning the IDs by code
+// by field. Codes that match nothing are left out.
+func (s *quickVoucherService) resolve(ctx context.Context, companyID uuid.UUID, entries []domain.QuickEntry) (map[string]map[string]uuid.UUID, error) {
+	used := map[string]map[string]bool{
+		"account": {}, "partner": {}, "department": {}, "project": {}, "tax_code": {},
+	}
+	for _, e := range entries {
+		used["account"][e.AccountCode] = true
+		used["partner"][e.PartnerCode] = true
+		used["department"][e.DepartmentCode] = true
+		used["project"][e.ProjectCode] = true
+		used["tax_code"][e.TaxCode] = true
+	}
+	for _, field := range used {
+		delete(field, "")
+	}
+	codes := map[string]map[string]uuid.UUID{
+		"account": {}, "partner": {}, "department": {}, "project": {}, "tax_code": {},
+	}
+
+	for code := range used["account"] {
+		account, err := s.accountRepo.FindByCode(ctx, companyID, code)
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		codes["account"][code] = account.ID
+	}
+
+	if len(used["partner"]) > 0 {
+		partnerCodes := make([]string, 0, len(used["partner"]))
+		for code := range used["partner"] {
+			partnerCodes = append(partnerCodes, code)
+		}
+		partners, _, err := s.partnerRepo.List(ctx, &repository.PartnerFilter{CompanyID: companyID, Codes: partnerCodes})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range partners {
+			codes["partner"][p.Code] = p.ID
+		}
+	}
+
+	// A company has few departments and tax codes; they are matched in full
+	if len(used["department"]) > 0 {
+		departments, _, err := s.departmentRepo.List(ctx, &repository.DepartmentFilter{CompanyID: companyID})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range departments {
+			codes["department"][d.Code] = d.ID
+		}
+	}
+
+	for code := range used["project"] {
+		project, err := s.projectRepo.FindByCode(ctx, companyID, code)
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		codes["project"][code] = project.ID
+	}
+
+	if len(used["tax_code"]) > 0 {
+		taxCodes, err := s.taxCodeRepo.FindAll(ctx, companyID, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range taxCodes {
+			codes["tax_code"][t.Code] = t.ID
+		}
+	}
+	return codes, nil
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+func TestQuickVoucherService_Create(t *testing.T) {
+	vouchers := new(mocks.MockVoucherService)
+	accountRepo := new(mocks.MockAccountRepository)
+	partnerRepo := new(mocks.MockPartnerRepository)
+	taxCodeRepo := new(mocks.MockTaxCodeRepository)
+	svc := service.NewQuickVoucherService(vouchers, accountRepo, partnerRepo, nil, nil, taxCodeRepo)
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+
+	supplies := &domain.Account{Code: "830100"}
+	supplies.ID = uuid.New()
+	payables := &domain.Account{Code: "253000"}
+	payables.ID = uuid.New()
+	vendor := domain.Partner{Code: "P001"}
+	vendor.ID = uuid.New()
+	vat := domain.TaxCode{Code: "P10"}
+	vat.ID = uuid.New()
+
+	accountRepo.On("FindByCode", mock.Anything, companyID, "830100").Return(supplies, nil)
+	accountRepo.On("FindByCode", mock.Anything, companyID, "253000").Return(payables, nil)
+	accountRepo.On("FindByCode", mock.Anything, companyID, "999999").Return(nil, domain.ErrAccountNotFound)
+	partnerRepo.On("List", mock.Anything, mock.MatchedBy(func(f *repository.PartnerFilter) bool {
+		return f.CompanyID == companyID && len(f.Codes) > 0
+	})).Return([]domain.Partner{vendor}, int64(1), nil)
+	taxCodeRepo.On("FindAll", mock.Anything, companyID, false).Return([]domain.TaxCode{vat}, nil)
+	vouchers.On("Create", mock.Anything, mock.MatchedBy(func(v *domain.Voucher) bool {
+		return len(v.Entries) == 2 && v.Entries[0].AccountID == supplies.ID && *v.Entries[0].TaxCodeID == vat.ID &&
+			v.Entries[1].CreditAmount == 110000 && *v.Entries[1].PartnerID == vendor.ID
+	})).Return(nil).Once()
+
+	voucher := &domain.Voucher{}
+	voucher.CompanyID = companyID
+	entries := []domain.QuickEntry{
+		{AccountCode: "830100", Side: domain.AccountNatureDebit, Amount: 110000, TaxCode: "P10"},
+		{AccountCode: "253000", Side: domain.AccountNatureCredit, PartnerCode: "P001"},
+	}
+	require.NoError(t, svc.Create(ctx, voucher, entries))
+
+	// All unknown codes are reported with their line
+	entries[0].AccountCode = "999999"
+	entries[1].PartnerCode = "P404"
+	err := svc.Create(ctx, voucher, entries)
+	var unknown *domain.UnknownCodesError
+	require.True(t, errors.As(err, &unknown))
+	assert.Equal(t, []domain.UnknownEntryCode{
+		{LineNo: 1, Field: "account", Code: "999999"}, {LineNo: 2, Field: "partner", Code: "P404"},
+	}, unknown.Codes)
+
+	vouchers.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ReceiptSuggestionInput holds the accounts used to build a voucher suggestion from a receipt
+type ReceiptSuggestionInput struct {
+	ExpenseAccountID uuid.UUID  // debit side (expense or asset)
+	PaymentAccountID uuid.UUID  // credit side (cash, card payable, ...)
+	TaxCodeID        *uuid.UUID // optional; an active input tax code at the company's default VAT rate is used when omitted
+}
+
+// ReceiptService defines the interface for receipt recognition and voucher suggestion
+type ReceiptService interface {
+	SuggestFromReceipt(ctx context.Context, companyID uuid.UUID, image *provider.ReceiptImage, input ReceiptSuggestionInput) (*domain.ReceiptSuggestion, error)
+}
+
+// receiptService implements ReceiptService
+type receiptService struct {
+	ocr         provider.ReceiptOCRProvider
+	partnerRepo repository.PartnerRepository
+	taxCodeRepo repository.TaxCodeRepository
+	settings    CompanySettingsService
+}
+
+// NewReceiptService creates a new ReceiptService. ocr may be nil when OCR is not configured.
+func NewReceiptService(ocr provider.ReceiptOCRProvider, partnerRepo repository.PartnerRepository, taxCodeRepo repository.TaxCodeRepository, settings CompanySettingsService) ReceiptService {
+	return &receiptService{
+		ocr:         ocr,
+		partnerRepo: partnerRepo,
+		taxCodeRepo: taxCodeRepo,
+		settings:    settings,
+	}
+}
+
+// SuggestFromReceipt recognizes a receipt image and proposes a purchase voucher.
+// The expense entry carries the tax-inclusive total and the tax code, so the VAT line
+// is generated when the suggestion is saved.
+func (s *receiptService) SuggestFromReceipt(ctx context.Context, companyID uuid.UUID, image *provider.ReceiptImage, input ReceiptSuggestionInput) (*domain.ReceiptSuggestion, error) {
+	if s.ocr == nil || !s.ocr.IsAvailable(ctx) {
+		return nil, provider.ErrProviderUnavailable
+	}
+
+	data, err := s.ocr.RecognizeReceipt(ctx, companyID, image)
+	if err != nil {
+		return nil, err
+	}
+	if data.TotalAmount <= 0 {
+		return nil, domain.ErrReceiptAmountMissing
+	}
+
+	suggestion := &domain.ReceiptSuggestion{
+		TransactionDate:      data.TransactionDate,
+		VendorName:           data.VendorName,
+		VendorBusinessNumber: formatBusinessNumber(data.VendorBusinessNumber),
+		TotalAmount:          float64(data.TotalAmount),
+		SupplyAmount:         float64(data.SupplyAmount),
+		TaxAmount:            float64(data.TaxAmount),
+		Confidence:           data.Confidence,
+		VoucherType:          domain.VoucherTypePurchase,
+		Description:          data.VendorName,
+	}
+
+	if data.TransactionDate != nil {
+		suggestion.VoucherDate = *data.TransactionDate
+	} else {
+		now := time.Now()
+		suggestion.VoucherDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		suggestion.Warnings = append(suggestion.Warnings, "transaction date not recognized; today's date was used")
+	}
+	if suggestion.Description == "" {
+		suggestion.Description = "영수증"
+		suggestion.Warnings = append(suggestion.Warnings, "vendor name not recognized")
+	}
+
+	// Match vendor to an existing partner by business number
+	if suggestion.VendorBusinessNumber != "" {
+		if partner, err := s.partnerRepo.GetByBusinessNumber(ctx, companyID, suggestion.VendorBusinessNumber); err == nil {
+			suggestion.PartnerID = &partner.ID
+		} else {
+			suggestion.Warnings = append(suggestion.Warnings, "no partner registered with the vendor business number")
+		}
+	}
+
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	taxCode, err := s.resolveTaxCode(ctx, companyID, input.TaxCodeID, data.TaxAmount > 0, settings.TaxRate)
+	if err != nil {
+		return nil, err
+	}
+	if taxCode != nil {
+		suggestion.TaxCodeID = &taxCode.ID
+		supply, tax := taxCode.SplitInclusiveFor(suggestion.TotalAmount, settings)
+		if data.TaxAmount > 0 && math.Abs(tax-suggestion.TaxAmount) > 1 {
+			suggestion.Warnings = append(suggestion.Warnings,
+				fmt.Sprintf("VAT on receipt (%.0f) differs from tax code calculation (%.0f)", suggestion.TaxAmount, tax))
+		}
+		suggestion.SupplyAmount, suggestion.TaxAmount = supply, tax
+	} else if data.TaxAmount > 0 {
+		suggestion.Warnings = append(suggestion.Warnings, "no active input tax code; VAT is not separated")
+	}
+
+	expense := domain.VoucherEntry{
+		CompanyID:   companyID,
+		AccountID:   input.ExpenseAccountID,
+		Description: suggestion.Description,
+		PartnerID:   suggestion.PartnerID,
+		TaxCodeID:   suggestion.TaxCodeID,
+	}
+	expense.SetDebit(suggestion.TotalAmount)
+
+	payment := domain.VoucherEntry{
+		CompanyID:   companyID,
+		AccountID:   input.PaymentAccountID,
+		Description: suggestion.Description,
+		PartnerID:   suggestion.PartnerID,
+	}
+	payment.SetCredit(suggestion.TotalAmount)
+
+	suggestion.Entries = []domain.VoucherEntry{expense, payment}
+	return suggestion, nil
+}
+
+// resolveTaxCode returns the requested tax code or, when the receipt shows VAT,
+// the first active deductible input tax code at the default VAT rate
+func (s *receiptService) resolveTaxCode(ctx context.Context, companyID uuid.UUID, taxCodeID *uuid.UUID, hasVAT bool, defaultRate float64) (*domain.TaxCode, error) {
+	if taxCodeID != nil {
+		taxCode, err := s.taxCodeRepo.FindByID(ctx, companyID, *taxCodeID)
+		if err != nil {
+			return nil, err
+		}
+		if !taxCode.IsActive {
+			return nil, domain.ErrTaxCodeInactive
+		}
+		if taxCode.TaxType != domain.TaxTypeInput {
+			return nil, domain.ErrReceiptTaxCodeType
+		}
+		return taxCode, nil
+	}
+
+	if !hasVAT {
+		return nil, nil
+	}
+
+	taxCodes, err := s.taxCodeRepo.FindAll(ctx, companyID, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range taxCodes {
+		tc := &taxCodes[i]
+		if tc.TaxType == domain.TaxTypeInput && tc.TaxCategory == domain.TaxCategoryTaxable &&
+			tc.IsDeductible && tc.Rate == defaultRate {
+			return tc, nil
+		}
+	}
+	return nil, nil
+}
+
+// formatBusinessNumber normalizes a 10-digit business number to 000-00-00000.
+// Values that are not 10 digits are returned as recognized.
+func formatBusinessNumber(value string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+	if len(digits) != 10 {
+		return strings.TrimSpace(value)
+	}
+	return digits[:3] + "-" + digits[3:5] + "-" + digits[5:]
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ReportDefinitionService defines the interface for saved custom reports
+type ReportDefinitionService interface {
+	// CRUD operations
+	Create(ctx context.Context, def *domain.ReportDefinition) error
+	Update(ctx context.Context, def *domain.ReportDefinition) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Query operations
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportDefinition, error)
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.ReportDefinition, error)
+
+	// Run executes a saved definition. Nil dates fall back to the definition's period.
+	Run(ctx context.Context, companyID, id uuid.UUID, from, to *time.Time) (*domain.ReportTable, error)
+
+	// Preview executes an unsaved spec
+	Preview(ctx context.Context, companyID uuid.UUID, spec domain.ReportSpec, from, to *time.Time) (*domain.ReportTable, error)
+}
+
+// reportDefinitionService implements ReportDefinitionService
+type reportDefinitionService struct {
+	definitionRepo repository.ReportDefinitionRepository
+	companyRepo    repository.CompanyRepository
+}
+
+// NewReportDefinitionService creates a new ReportDefinitionService
+func NewReportDefinitionService(definitionRepo repository.ReportDefinitionRepository, companyRepo repository.CompanyRepository) ReportDefinitionService {
+	return &reportDefinitionService{
+		definitionRepo: definitionRepo,
+		companyRepo:    companyRepo,
+	}
+}
+
+// Create validates and saves a new definition
+func (s *reportDefinitionService) Create(ctx context.Context, def *domain.ReportDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+	return s.definitionRepo.Create(ctx, def)
+}
+
+// Update validates and saves a definition
+func (s *reportDefinitionService) Update(ctx context.Context, def *domain.ReportDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+	return s.definitionRepo.Update(ctx, def)
+}
+
+// Delete deletes a definition
+func (s *reportDefinitionService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.definitionRepo.Delete(ctx, companyID, id)
+}
+
+// GetByID retrieves a definition
+func (s *reportDefinitionService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportDefinition, error) {
+	return s.definitionRepo.FindByID(ctx, companyID, id)
+}
+
+// List retrieves the company's definitions
+func (s *reportDefinitionService) List(ctx context.Context, companyID uuid.UUID) ([]domain.ReportDefinition, error) {
+	return s.definitionRepo.FindAll(ctx, companyID)
+}
+
+// Run executes a saved definition
+func (s *reportDefinitionService) Run(ctx context.Context, companyID, id uuid.UUID, from, to *time.Time) (*domain.ReportTable, error) {
+	def, err := s.definitionRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	table, err := s.run(ctx, companyID, def.Spec, from, to)
+	if err != nil {
+		return nil, err
+	}
+	table.Title = def.Name
+	return table, nil
+}
+
+// Preview executes an unsaved spec
+func (s *reportDefinitionService) Preview(ctx context.Context, companyID uuid.UUID, spec domain.ReportSpec, from, to *time.Time) (*domain.ReportTable, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	table, err := s.run(ctx, companyID, spec, from, to)
+	if err != nil {
+		return nil, err
+	}
+	table.Title = "사용자 정의 보고서"
+	return table, nil
+}
+
+// run binds the spec to a date range, queries the ledger and lays out the table
+func (s *reportDefinitionService) run(ctx context.Context, companyID uuid.UUID, spec domain.ReportSpec, from, to *time.Time) (*domain.ReportTable, error) {
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &domain.ReportQuery{Spec: spec}
+	if from != nil && to != nil {
+		query.FromDate, query.ToDate = *from, *to
+	} else {
+		rng := spec.Period.Resolve(time.Now())
+		query.FromDate, query.ToDate = rng.StartDate(), rng.EndDate()
+	}
+	if query.ToDate.Before(query.FromDate) {
+		return nil, domain.ErrInvalidReportDateRange
+	}
+	query.YearStart = domain.FiscalYearStartOf(query.FromDate, company.Settings.FiscalYearStart)
+
+	rows, err := s.definitionRepo.Run(ctx, companyID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	table := buildCustomReportTable(spec, rows, i18n.FromContext(ctx))
+	table.Subtitle = company.Name + "  " + query.FromDate.Format("2006-01-02") + " ~ " + query.ToDate.Format("2006-01-02")
+	roundReportAmounts(table, company.Settings)
+	return table, nil
+}
+
+// buildCustomReportTable lays out result rows as account, grouping and amount columns,
+// followed by a total row. Opening and closing are only totalled with debit-positive signs.
+func buildCustomReportTable(spec domain.ReportSpec, rows []domain.ReportResultRow, loc i18n.Locale) *domain.ReportTable {
+	table := &domain.ReportTable{
+		Columns: []domain.ReportColumn{{Label: i18n.T(loc, "report.account_code")}, {Label: i18n.T(loc, "report.account_name")}},
+	}
+	for _, d := range spec.GroupBy {
+		table.Columns = append(table.Columns, domain.ReportColumn{Label: d.LocalizedLabel(loc)})
+	}
+	for _, c := range spec.Columns {
+		table.Columns = append(table.Columns, domain.ReportColumn{Label: c.LocalizedLabel(loc), Numeric: true})
+	}
+
+	totals := make([]float64, len(spec.Columns))
+	for i := range rows {
+		row := &rows[i]
+		if !spec.IncludeZero && row.IsZero() {
+			continue
+		}
+
+		cells := []string{row.AccountCode, row.AccountName}
+		for _, dim := range row.Dimensions {
+			cells = append(cells, customReportDimensionCell(dim, loc))
+		}
+		for j, c := range spec.Columns {
+			amount := row.Amount(c, spec.NaturalSign)
+			totals[j] += amount
+			cells = append(cells, reportNumber(amount))
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+
+	total := []string{"", i18n.T(loc, "report.total")}
+	for range spec.GroupBy {
+		total = append(total, "")
+	}
+	for j, c := range spec.Columns {
+		if spec.NaturalSign && (c == domain.ReportColumnOpening || c == domain.ReportColumnClosing) {
+			total = append(total, "")
+			continue
+		}
+		total = append(total, reportNumber(totals[j]))
+	}
+	table.Rows = append(table.Rows, total)
+	return table
+}
+
+// customReportDimensionCell formats a grouping value as "code name"
+func customReportDimensionCell(v domain.ReportDimensionValue, loc i18n.Locale) string {
+	if v.ID == nil {
+		return i18n.T(loc, "report.unassigned")
+	}
+	if v.Code == "" {
+		return v.Name
+	}
+	return v.Code + " " + v.Name
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"strconv"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+)
+
+// Report page layout (points, A4 portrait)
+const (
+	reportMarginX    = 40.0
+	reportWidth      = pdf.A4Width - 2*reportMarginX
+	reportTableTop   = 100.0
+	reportRowH       = 16.0
+	reportBottom     = pdf.A4Height - 60
+	reportFontSize   = 8.0
+	reportNumericW   = 78.0
+	reportCellMargin = 4.0
+)
+
+// exportReportCSV renders the table as CSV with a UTF-8 BOM so Excel opens Korean text correctly
+func exportReportCSV(table *domain.ReportTable) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(table.Columns))
+	for i, c := range table.Columns {
+		header[i] = c.Label
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range table.Rows {
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportReportPDF renders the table across as many pages as needed
+func exportReportPDF(table *domain.ReportTable) ([]byte, error) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	widths := reportColumnWidths(table)
+
+	rowsPerPage := int(math.Floor((reportBottom - reportTableTop - reportRowH) / reportRowH))
+	pageCount := (len(table.Rows) + rowsPerPage - 1) / rowsPerPage
+	if pageCount == 0 {
+		pageCount = 1
+	}
+
+	for pageNo := 1; pageNo <= pageCount; pageNo++ {
+		page := doc.AddPage()
+		page.SetLineWidth(0.5)
+
+		page.TextCenter(pdf.A4Width/2, 56, 16, table.Title)
+		page.Text(reportMarginX, 84, 9, table.Subtitle)
+
+		// Header
+		x := reportMarginX
+		for i, c := range table.Columns {
+			page.FillRect(x, reportTableTop, widths[i], reportRowH, 0.92)
+			page.Rect(x, reportTableTop, widths[i], reportRowH)
+			page.TextCenter(x+widths[i]/2, reportTableTop+11, reportFontSize, c.Label)
+			x += widths[i]
+		}
+
+		start := (pageNo - 1) * rowsPerPage
+		end := start + rowsPerPage
+		if end > len(table.Rows) {
+			end = len(table.Rows)
+		}
+		y := reportTableTop + reportRowH
+		for _, row := range table.Rows[start:end] {
+			x := reportMarginX
+			for i, c := range table.Columns {
+				page.Rect(x, y, widths[i], reportRowH)
+				cell := ""
+				if i < len(row) {
+					cell = row[i]
+				}
+				if c.Numeric {
+					page.TextRight(x+widths[i]-reportCellMargin, y+11, reportFontSize, reportDisplayNumber(cell))
+				} else {
+					page.Text(x+reportCellMargin, y+11, reportFontSize,
+						pdf.Truncate(cell, reportFontSize, widths[i]-2*reportCellMargin))
+				}
+				x += widths[i]
+			}
+			y += reportRowH
+		}
+
+		page.TextRight(pdf.A4Width-reportMarginX, pdf.A4Height-30, 8, strconv.Itoa(pageNo)+" / "+strconv.Itoa(pageCount))
+	}
+
+	return doc.Bytes()
+}
+
+// reportColumnWidths gives numeric columns a fixed width and shares the rest
+// among text columns in proportion to their widest cell
+func reportColumnWidths(table *domain.ReportTable) []float64 {
+	widths := make([]float64, len(table.Columns))
+	natural := make([]float64, len(table.Columns))
+	remaining := reportWidth
+	textTotal := 0.0
+
+	for i, c := range table.Columns {
+		if c.Numeric {
+			widths[i] = reportNumericW
+			remaining -= reportNumericW
+			continue
+		}
+		w := pdf.TextWidth(c.Label, reportFontSize)
+		for _, row := range table.Rows {
+			if i < len(row) {
+				w = math.Max(w, pdf.TextWidth(row[i], reportFontSize))
+			}
+		}
+		natural[i] = w + 2*reportCellMargin
+		textTotal += natural[i]
+	}
+
+	for i, c := range table.Columns {
+		if !c.Numeric && textTotal > 0 {
+			widths[i] = remaining * natural[i] / textTotal
+		}
+	}
+	return widths
+}
+
+// reportDisplayNumber formats a plain numeric cell with thousands separators
+func reportDisplayNumber(cell string) string {
+	v, err := strconv.ParseFloat(cell, 64)
+	if err != nil {
+		return cell
+	}
+	if v == 0 {
+		return "0"
+	}
+	return formatPrintAmount(v)
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/cron"
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// reportScheduleBatchSize is the maximum number of due schedules processed per worker tick
+const reportScheduleBatchSize = 100
+
+// ReportScheduleService defines the interface for scheduled report delivery
+type ReportScheduleService interface {
+	// CRUD operations
+	Create(ctx context.Context, schedule *domain.ReportSchedule) error
+	Update(ctx context.Context, schedule *domain.ReportSchedule) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	// Query operations
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportSchedule, error)
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.ReportSchedule, error)
+	GetRuns(ctx context.Context, companyID, scheduleID uuid.UUID, limit int) ([]domain.ReportScheduleRun, error)
+
+	// RunNow generates and emails the report immediately, outside the schedule
+	RunNow(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportScheduleRun, error)
+
+	// ProcessDue runs every schedule due as of asOf and returns the number of
+	// runs; with a job queue the runs are queued as report delivery jobs
+	ProcessDue(ctx context.Context, asOf time.Time) (int, error)
+	// Deliver runs a claimed occurrence of the schedule, as queued by ProcessDue
+	Deliver(ctx context.Context, companyID, id uuid.UUID, asOf time.Time) (*domain.ReportScheduleRun, error)
+}
+
+// reportScheduleService implements ReportScheduleService
+type reportScheduleService struct {
+	scheduleRepo  repository.ReportScheduleRepository
+	userRepo      repository.UserRepository
+	reports       ReportService
+	notifications NotificationService
+	templates     EmailTemplateService
+	jobs          JobQueue // nil runs due schedules inline
+}
+
+// NewReportScheduleService creates a new ReportScheduleService
+func NewReportScheduleService(
+	scheduleRepo repository.ReportScheduleRepository,
+	userRepo repository.UserRepository,
+	reports ReportService,
+	notifications NotificationService,
+	templates EmailTemplateService,
+	jobs JobQueue,
+) ReportScheduleService {
+	return &reportScheduleService{
+		scheduleRepo:  scheduleRepo,
+		userRepo:      userRepo,
+		reports:       reports,
+		notifications: notifications,
+		templates:     templates,
+		jobs:          jobs,
+	}
+}
+
+// Create validates the schedule and computes its first run
+func (s *reportScheduleService) Create(ctx context.Context, schedule *domain.ReportSchedule) error {
+	if err := s.prepare(schedule, time.Now()); err != nil {
+		return err
+	}
+	return s.scheduleRepo.Create(ctx, schedule)
+}
+
+// Update validates the schedule and recomputes its next run
+func (s *reportScheduleService) Update(ctx context.Context, schedule *domain.ReportSchedule) error {
+	if err := s.prepare(schedule, time.Now()); err != nil {
+		return err
+	}
+	return s.scheduleRepo.Update(ctx, schedule)
+}
+
+// Delete deletes a schedule and its run history
+func (s *reportScheduleService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.scheduleRepo.Delete(ctx, companyID, id)
+}
+
+// GetByID retrieves a schedule
+func (s *reportScheduleService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportSchedule, error) {
+	return s.scheduleRepo.FindByID(ctx, companyID, id)
+}
+
+// List retrieves the company's schedules
+func (s *reportScheduleService) List(ctx context.Context, companyID uuid.UUID) ([]domain.ReportSchedule, error) {
+	return s.scheduleRepo.FindAll(ctx, companyID)
+}
+
+// GetRuns retrieves the most recent runs of a schedule
+func (s *reportScheduleService) GetRuns(ctx context.Context, companyID, scheduleID uuid.UUID, limit int) ([]domain.ReportScheduleRun, error) {
+	if _, err := s.scheduleRepo.FindByID(ctx, companyID, scheduleID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return s.scheduleRepo.FindRuns(ctx, companyID, scheduleID, limit)
+}
+
+// RunNow runs the schedule once; the outcome is recorded on the returned run
+func (s *reportScheduleService) RunNow(ctx context.Context, companyID, id uuid.UUID) (*domain.ReportScheduleRun, error) {
+	schedule, err := s.scheduleRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.execute(ctx, schedule, true, time.Now())
+	if run == nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// ProcessDue claims and runs due schedules. Failed runs are recorded and alerted,
+// and are also returned as a joined error for logging.
+func (s *reportScheduleService) ProcessDue(ctx context.Context, asOf time.Time) (int, error) {
+	due, err := s.scheduleRepo.FindDue(ctx, asOf, reportScheduleBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var errs []error
+	for i := range due {
+		schedule := &due[i]
+		next, err := s.nextRun(schedule, asOf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+			continue
+		}
+
+		// Another worker may have picked up the same occurrence
+		claimed, err := s.scheduleRepo.ClaimRun(ctx, schedule.ID, *schedule.NextRunAt, next)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		schedule.NextRunAt = next
+
+		count++
+		if s.jobs != nil {
+			payload := domain.ReportDeliveryPayload{ScheduleID: schedule.ID, AsOf: asOf}
+			if _, err := s.jobs.Enqueue(ctx, schedule.CompanyID, domain.JobTypeReportDelivery, payload, nil, nil); err != nil {
+				errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+			}
+			continue
+		}
+		if _, err := s.execute(ctx, schedule, false, asOf); err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+		}
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// Deliver runs the schedule for the occurrence claimed as of asOf
+func (s *reportScheduleService) Deliver(ctx context.Context, companyID, id uuid.UUID, asOf time.Time) (*domain.ReportScheduleRun, error) {
+	schedule, err := s.scheduleRepo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.execute(ctx, schedule, false, asOf)
+}
+
+// prepare validates the schedule and sets its next run time (nil when inactive)
+func (s *reportScheduleService) prepare(schedule *domain.ReportSchedule, now time.Time) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	next, err := s.nextRun(schedule, now)
+	if err != nil {
+		return err
+	}
+	schedule.NextRunAt = next
+	if !schedule.IsActive {
+		schedule.NextRunAt = nil
+	}
+	return nil
+}
+
+// nextRun computes the first run after the given time in the schedule's time zone
+func (s *reportScheduleService) nextRun(schedule *domain.ReportSchedule, after time.Time) (*time.Time, error) {
+	expr, err := cron.Parse(schedule.CronExpr)
+	if err != nil {
+		return nil, domain.ErrInvalidCronExpression
+	}
+	loc, err := schedule.Location()
+	if err != nil {
+		return nil, domain.ErrInvalidTimezone
+	}
+	next := expr.Next(after.In(loc))
+	if next.IsZero() {
+		return nil, domain.ErrInvalidCronExpression
+	}
+	return &next, nil
+}
+
+// execute generates and delivers the report, recording the run and the schedule's run state.
+// The returned run is nil only if the run itself could not be recorded.
+func (s *reportScheduleService) execute(ctx context.Context, schedule *domain.ReportSchedule, manual bool, asOf time.Time) (*domain.ReportScheduleRun, error) {
+	loc, err := schedule.Location()
+	if err != nil {
+		loc = time.UTC
+	}
+	rng := schedule.Params.Period.Resolve(asOf.In(loc))
+
+	run := &domain.ReportScheduleRun{
+		CompanyID:  schedule.CompanyID,
+		ScheduleID: schedule.ID,
+		Status:     domain.ReportRunStatusRunning,
+		Manual:     manual,
+		PeriodFrom: rng.StartDate(),
+		PeriodTo:   rng.EndDate(),
+		StartedAt:  time.Now(),
+	}
+	if err := s.scheduleRepo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	runErr := s.deliver(ctx, schedule, rng, run)
+	run.Finish(runErr)
+	if err := s.scheduleRepo.UpdateRun(ctx, run); err != nil {
+		return run, err
+	}
+
+	if runErr != nil {
+		paused := schedule.RecordFailure(run.StartedAt)
+		s.alertFailure(ctx, schedule, run, paused)
+	} else {
+		schedule.RecordSuccess(run.StartedAt)
+	}
+	if err := s.scheduleRepo.UpdateRunState(ctx, schedule); err != nil {
+		return run, err
+	}
+	return run, runErr
+}
+
+// deliver generates the export and emails it to the recipients
+func (s *reportScheduleService) deliver(ctx context.Context, schedule *domain.ReportSchedule, rng domain.ReportRange, run *domain.ReportScheduleRun) error {
+	table, err := s.reports.Generate(ctx, schedule.CompanyID, schedule.ReportType, rng)
+	if err != nil {
+		return fmt.Errorf("generate report: %w", err)
+	}
+	data, err := s.reports.Export(table, schedule.Format)
+	if err != nil {
+		return fmt.Errorf("export report: %w", err)
+	}
+
+	run.FileName = reportFileName(schedule.ReportType, rng, schedule.Format)
+	run.FileSize = len(data)
+	run.RecipientCount = len(schedule.Recipients)
+
+	subject, body := s.templates.Render(ctx, schedule.CompanyID, domain.EmailEventReportDelivery, map[string]string{
+		"schedule_name": schedule.Name,
+		"report_title":  table.Title,
+		"period_from":   run.PeriodFrom.Format("2006-01-02"),
+		"period_to":     run.PeriodTo.Format("2006-01-02"),
+	})
+	msg := &provider.EmailMessage{
+		To:       schedule.Recipients,
+		Subject:  subject,
+		TextBody: body,
+		Attachments: []provider.EmailAttachment{{
+			Filename:    run.FileName,
+			ContentType: schedule.Format.ContentType(),
+			Data:        data,
+		}},
+	}
+	if err := s.notifications.SendEmail(ctx, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// alertFailure notifies the schedule owner of a failed run; alert delivery errors are ignored
+func (s *reportScheduleService) alertFailure(ctx context.Context, schedule *domain.ReportSchedule, run *domain.ReportScheduleRun, paused bool) {
+	if schedule.CreatedBy == nil {
+		return
+	}
+	owner, err := s.userRepo.FindByID(ctx, schedule.CompanyID, *schedule.CreatedBy)
+	if err != nil || owner.Email == "" {
+		return
+	}
+
+	pauseNotice := ""
+	if paused {
+		pauseNotice = fmt.Sprintf("\n연속 %d회 실패하여 일정이 일시 중지되었습니다. 설정을 확인한 뒤 다시 활성화해 주세요.", domain.MaxReportScheduleFailures)
+	}
+	subject, body := s.templates.Render(ctx, schedule.CompanyID, domain.EmailEventReportFailure, map[string]string{
+		"schedule_name": schedule.Name,
+		"started_at":    run.StartedAt.Format("2006-01-02 15:04"),
+		"error":         run.Error,
+		"failure_count": strconv.Itoa(schedule.FailureCount),
+		"pause_notice":  pauseNotice,
+	})
+
+	_ = s.notifications.SendEmail(ctx, &provider.EmailMessage{To: []string{owner.Email}, Subject: subject, TextBody: body})
+}
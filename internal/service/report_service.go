@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/i18n"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ReportService defines the interface for generating exportable reports
+type ReportService interface {
+	// Generate builds the report for the month range as a table
+	Generate(ctx context.Context, companyID uuid.UUID, reportType domain.ReportType, rng domain.ReportRange) (*domain.ReportTable, error)
+
+	// Export renders the table in the given format
+	Export(table *domain.ReportTable, format domain.ReportFormat) ([]byte, error)
+}
+
+// reportService implements ReportService
+type reportService struct {
+	ledgerRepo  repository.LedgerRepository
+	accountRepo repository.AccountRepository
+	taxCodeRepo repository.TaxCodeRepository
+	companyRepo repository.CompanyRepository
+}
+
+// NewReportService creates a new ReportService
+func NewReportService(ledgerRepo repository.LedgerRepository, accountRepo repository.AccountRepository, taxCodeRepo repository.TaxCodeRepository, companyRepo repository.CompanyRepository) ReportService {
+	return &reportService{
+		ledgerRepo:  ledgerRepo,
+		accountRepo: accountRepo,
+		taxCodeRepo: taxCodeRepo,
+		companyRepo: companyRepo,
+	}
+}
+
+// Generate builds the requested report
+func (s *reportService) Generate(ctx context.Context, companyID uuid.UUID, reportType domain.ReportType, rng domain.ReportRange) (*domain.ReportTable, error) {
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var table *domain.ReportTable
+	switch reportType {
+	case domain.ReportTypeTrialBalance:
+		table, err = s.trialBalance(ctx, companyID, rng)
+	case domain.ReportTypeBalanceSheet:
+		table, err = s.balanceSheet(ctx, companyID, rng)
+	case domain.ReportTypeIncomeStatement:
+		table, err = s.incomeStatement(ctx, companyID, rng)
+	case domain.ReportTypeVATReturn:
+		table, err = s.vatReturn(ctx, companyID, rng)
+	default:
+		return nil, domain.ErrInvalidReportType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loc := i18n.FromContext(ctx)
+	table.Title = reportType.LocalizedLabel(loc)
+	period := rng.StartDate().Format("2006-01-02") + " ~ " + rng.EndDate().Format("2006-01-02")
+	if reportType == domain.ReportTypeBalanceSheet {
+		period = i18n.Tf(loc, "report.as_of", rng.EndDate().Format("2006-01-02"))
+	}
+	table.Subtitle = company.Name + "  " + period + "  " + i18n.Tf(loc, "report.currency", company.Settings.DefaultCurrency)
+	roundReportAmounts(table, company.Settings)
+	return table, nil
+}
+
+// Export renders the table as CSV or PDF
+func (s *reportService) Export(table *domain.ReportTable, format domain.ReportFormat) ([]byte, error) {
+	switch format {
+	case domain.ReportFormatCSV:
+		return exportReportCSV(table)
+	case domain.ReportFormatPDF:
+		return exportReportPDF(table)
+	}
+	return nil, domain.ErrInvalidReportFormat
+}
+
+func (s *reportService) trialBalance(ctx context.Context, companyID uuid.UUID, rng domain.ReportRange) (*domain.ReportTable, error) {
+	tb, err := s.ledgerRepo.GetTrialBalanceRange(ctx, companyID, rng.FromYear, rng.FromMonth, rng.ToYear, rng.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := i18n.FromContext(ctx)
+	table := &domain.ReportTable{
+		Columns: []domain.ReportColumn{
+			{Label: i18n.T(loc, "report.account_code")}, {Label: i18n.T(loc, "report.account_name")},
+			{Label: domain.ReportColumnOpening.LocalizedLabel(loc), Numeric: true},
+			{Label: domain.ReportColumnDebit.LocalizedLabel(loc), Numeric: true}, {Label: domain.ReportColumnCredit.LocalizedLabel(loc), Numeric: true},
+			{Label: domain.ReportColumnClosing.LocalizedLabel(loc), Numeric: true},
+		},
+	}
+	for _, item := range tb.Items {
+		table.Rows = append(table.Rows, []string{
+			item.AccountCode, item.AccountName,
+			reportNumber(item.OpeningDebit - item.OpeningCredit),
+			reportNumber(item.PeriodDebit), reportNumber(item.PeriodCredit),
+			reportNumber(item.ClosingDebit - item.ClosingCredit),
+		})
+	}
+	table.Rows = append(table.Rows, []string{"", i18n.T(loc, "report.total"), "", reportNumber(tb.TotalDebit), reportNumber(tb.TotalCredit), ""})
+	return table, nil
+}
+
+func (s *reportService) balanceSheet(ctx context.Context, companyID uuid.UUID, rng domain.ReportRange) (*domain.ReportTable, error) {
+	tb, err := s.ledgerRepo.GetTrialBalance(ctx, companyID, rng.ToYear, rng.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+	accounts, lines, err := statementAccounts(ctx, s.accountRepo, companyID)
+	if err != nil {
+		return nil, err
+	}
+	fs := domain.BuildBalanceSheet(tb, lines)
+	fs.AddAccountRollup(tb, accounts, lines, domain.StatementDepthAll)
+	return financialStatementTable(i18n.FromContext(ctx), fs), nil
+}
+
+func (s *reportService) incomeStatement(ctx context.Context, companyID uuid.UUID, rng domain.ReportRange) (*domain.ReportTable, error) {
+	tb, err := s.ledgerRepo.GetTrialBalanceRange(ctx, companyID, rng.FromYear, rng.FromMonth, rng.ToYear, rng.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+	accounts, lines, err := statementAccounts(ctx, s.accountRepo, companyID)
+	if err != nil {
+		return nil, err
+	}
+	fs := domain.BuildIncomeStatement(tb, lines)
+	fs.AddAccountRollup(tb, accounts, lines, domain.StatementDepthAll)
+	return financialStatementTable(i18n.FromContext(ctx), fs), nil
+}
+
+// financialStatementTable lists the statement rows under their account type,
+// each indented by its level; account rows carry their code
+func financialStatementTable(loc i18n.Locale, fs *domain.FinancialStatement) *domain.ReportTable {
+	table := &domain.ReportTable{Columns: sectionedReportColumns(loc)}
+	for _, row := range fs.Rows {
+		section := ""
+		if row.AccountType != "" {
+			section = i18n.Label(loc, "account_type", string(row.AccountType))
+		}
+		code := ""
+		if row.AccountID != nil {
+			code = row.Code
+		}
+		name := strings.Repeat("  ", row.Level-1) + row.LocalizedName(loc)
+		table.Rows = append(table.Rows, []string{section, code, name, reportNumber(row.Amount)})
+	}
+	return table
+}
+
+func (s *reportService) vatReturn(ctx context.Context, companyID uuid.UUID, rng domain.ReportRange) (*domain.ReportTable, error) {
+	items, err := s.taxCodeRepo.GetVATSummary(ctx, companyID, rng.StartDate(), rng.EndDate())
+	if err != nil {
+		return nil, err
+	}
+	vat := domain.NewVATReturn(rng.StartDate(), rng.EndDate(), items)
+
+	loc := i18n.FromContext(ctx)
+	sales, purchase := i18n.T(loc, "report.sales"), i18n.T(loc, "report.purchase")
+	table := &domain.ReportTable{
+		Columns: []domain.ReportColumn{
+			{Label: i18n.T(loc, "report.section")}, {Label: i18n.T(loc, "report.tax_code")}, {Label: i18n.T(loc, "report.tax_name")},
+			{Label: i18n.T(loc, "report.count"), Numeric: true}, {Label: i18n.T(loc, "report.supply_amount"), Numeric: true}, {Label: i18n.T(loc, "report.tax_amount"), Numeric: true},
+		},
+	}
+	for _, item := range vat.Items {
+		direction := sales
+		if item.TaxType == domain.TaxTypeInput {
+			direction = purchase
+		}
+		table.Rows = append(table.Rows, []string{
+			direction, item.Code, item.Name,
+			strconv.Itoa(item.EntryCount), reportNumber(item.SupplyAmount), reportNumber(item.TaxAmount),
+		})
+	}
+	table.Rows = append(table.Rows,
+		[]string{sales, "", i18n.T(loc, "report.output_tax"), "", reportNumber(vat.OutputSupplyAmount), reportNumber(vat.OutputTaxAmount)},
+		[]string{purchase, "", i18n.T(loc, "report.input_tax"), "", reportNumber(vat.InputSupplyAmount), reportNumber(vat.DeductibleInputTaxAmount)},
+		[]string{"", "", i18n.T(loc, "report.payable_tax"), "", "", reportNumber(vat.PayableTaxAmount)},
+	)
+	return table, nil
+}
+
+// sectionedReportColumns returns the section/account/amount columns of the balance sheet and income statement
+func sectionedReportColumns(loc i18n.Locale) []domain.ReportColumn {
+	return []domain.ReportColumn{
+		{Label: i18n.T(loc, "report.section")}, {Label: i18n.T(loc, "report.account_code")},
+		{Label: i18n.T(loc, "report.account_name")}, {Label: i18n.T(loc, "report.amount"), Numeric: true},
+	}
+}
+
+// reportNumber formats an amount as a plain number for export
+func reportNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// roundReportAmounts rounds the numeric cells to the company's decimal places
+// using its rounding rule
+func roundReportAmounts(table *domain.ReportTable, settings domain.CompanySettings) {
+	for _, row := range table.Rows {
+		for i, c := range table.Columns {
+			if !c.Numeric || i >= len(row) {
+				continue
+			}
+			if v, err := strconv.ParseFloat(row[i], 64); err == nil {
+				row[i] = reportNumber(settings.RoundAmount(v))
+			}
+		}
+	}
+}
+
+// reportFileName returns the export file name, e.g. "income_statement_2026-09.pdf"
+func reportFileName(reportType domain.ReportType, rng domain.ReportRange, format domain.ReportFormat) string {
+	period := fmt.Sprintf("%04d-%02d", rng.ToYear, rng.ToMonth)
+	if rng.FromYear != rng.ToYear || rng.FromMonth != rng.ToMonth {
+		period = fmt.Sprintf("%04d-%02d_%04d-%02d", rng.FromYear, rng.FromMonth, rng.ToYear, rng.ToMonth)
+	}
+	return fmt.Sprintf("%s_%s.%s", reportType, period, format)
+}
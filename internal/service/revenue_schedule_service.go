@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// revenueScheduleReferenceType marks the deferral and recognition vouchers of
+// a revenue schedule
+const revenueScheduleReferenceType = "revenue_schedule"
+
+// RevenueScheduleInput describes the deferral of a sales tax invoice
+type RevenueScheduleInput struct {
+	TaxInvoiceID             uuid.UUID
+	ServiceStart             time.Time
+	ServiceEnd               time.Time
+	RevenueAccountID         uuid.UUID
+	DeferredRevenueAccountID uuid.UUID
+	Amount                   *int64 // defaults to the supply amount of the invoice
+	Description              string // defaults to the invoice number and service period
+}
+
+// RevenueScheduleService defines the interface for revenue recognition
+// schedules of deferred revenue (선수수익)
+type RevenueScheduleService interface {
+	// Create defers the revenue of a sales tax invoice over its service period
+	// and generates the draft deferral voucher moving it from revenue to
+	// deferred revenue at the issue date
+	Create(ctx context.Context, companyID, userID uuid.UUID, input RevenueScheduleInput) (*domain.RevenueSchedule, error)
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.RevenueSchedule, error)
+	List(ctx context.Context, filter repository.RevenueScheduleFilter) ([]domain.RevenueSchedule, int64, error)
+
+	// RecognizeRevenue posts the recognition vouchers of the months ending on
+	// or before periodEnd, for one company or for all when companyID is nil.
+	// Schedules whose deferral voucher is not posted yet, or whose month is in
+	// a closed period, wait for a later run.
+	RecognizeRevenue(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.RevenueRecognitionRun, error)
+
+	// Waterfall spreads the deferred revenue at the date over the following
+	// months
+	Waterfall(ctx context.Context, companyID uuid.UUID, asOf time.Time, months int) (*domain.DeferredRevenueWaterfall, error)
+}
+
+// revenueScheduleService implements RevenueScheduleService
+type revenueScheduleService struct {
+	repo           repository.RevenueScheduleRepository
+	taxInvoiceRepo repository.TaxInvoiceRepository
+	partnerRepo    repository.PartnerRepository
+	accountRepo    repository.AccountRepository
+	voucherRepo    repository.VoucherRepository
+	voucherService VoucherService
+}
+
+// NewRevenueScheduleService creates a new RevenueScheduleService
+func NewRevenueScheduleService(
+	repo repository.RevenueScheduleRepository,
+	taxInvoiceRepo repository.TaxInvoiceRepository,
+	partnerRepo repository.PartnerRepository,
+	accountRepo repository.AccountRepository,
+	voucherRepo repository.VoucherRepository,
+	voucherService VoucherService,
+) RevenueScheduleService {
+	return &revenueScheduleService{
+		repo:           repo,
+		taxInvoiceRepo: taxInvoiceRepo,
+		partnerRepo:    partnerRepo,
+		accountRepo:    accountRepo,
+		voucherRepo:    voucherRepo,
+		voucherService: voucherService,
+	}
+}
+
+// Create builds the monthly lines of the schedule, stores it and links the
+// deferral voucher. The partner is the one with the buyer's business number,
+// if registered.
+func (s *revenueScheduleService) Create(ctx context.Context, companyID, userID uuid.UUID, input RevenueScheduleInput) (*domain.RevenueSchedule, error) {
+	invoice, err := s.taxInvoiceRepo.GetByID(ctx, companyID, input.TaxInvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.InvoiceType != domain.TaxInvoiceTypeSales || invoice.Status == domain.TaxInvoiceStatusCancelled {
+		return nil, domain.ErrRevenueScheduleInvoice
+	}
+	exists, err := s.repo.ExistsForInvoice(ctx, companyID, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrRevenueScheduleExists
+	}
+
+	amount := invoice.SupplyAmount
+	if input.Amount != nil {
+		if *input.Amount > invoice.SupplyAmount {
+			return nil, domain.ErrInvalidRevenueSchedule
+		}
+		amount = *input.Amount
+	}
+	description := input.Description
+	if description == "" {
+		description = fmt.Sprintf("%s 선수수익 %s~%s", invoice.InvoiceNumber,
+			input.ServiceStart.Format("2006-01-02"), input.ServiceEnd.Format("2006-01-02"))
+	}
+
+	schedule := &domain.RevenueSchedule{
+		TenantModel:              domain.TenantModel{CompanyID: companyID},
+		TaxInvoiceID:             invoice.ID,
+		InvoiceNumber:            invoice.InvoiceNumber,
+		Description:              description,
+		ServiceStart:             input.ServiceStart,
+		ServiceEnd:               input.ServiceEnd,
+		Amount:                   amount,
+		RevenueAccountID:         input.RevenueAccountID,
+		DeferredRevenueAccountID: input.DeferredRevenueAccountID,
+		DeferralDate:             invoice.IssueDate,
+		CreatedBy:                &userID,
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	for _, accountID := range []uuid.UUID{schedule.RevenueAccountID, schedule.DeferredRevenueAccountID} {
+		if _, err := s.accountRepo.FindByID(ctx, companyID, accountID); err != nil {
+			return nil, err
+		}
+	}
+	partner, err := s.partnerRepo.GetByBusinessNumber(ctx, companyID, invoice.BuyerBusinessNumber)
+	if err == nil {
+		schedule.PartnerID = &partner.ID
+	} else if !errors.Is(err, domain.ErrPartnerNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	// Deferral: revenue against deferred revenue, reviewed before posting
+	deferral := s.voucher(schedule, domain.VoucherTypeAdjustment, schedule.DeferralDate,
+		fmt.Sprintf("선수수익 이연 %s", schedule.Description),
+		schedule.RevenueAccountID, schedule.DeferredRevenueAccountID, schedule.Amount)
+	if err := s.voucherService.Create(ctx, deferral); err != nil {
+		return nil, err
+	}
+	schedule.DeferralVoucherID = &deferral.ID
+	if err := s.repo.UpdateDeferral(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// GetByID returns a schedule with its monthly lines
+func (s *revenueScheduleService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.RevenueSchedule, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the schedules of the company, latest service start first
+func (s *revenueScheduleService) List(ctx context.Context, filter repository.RevenueScheduleFilter) ([]domain.RevenueSchedule, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// RecognizeRevenue posts each due line of the schedules with a posted
+// deferral, deferred revenue against revenue at the month end, on behalf of
+// the creator of the schedule
+func (s *revenueScheduleService) RecognizeRevenue(ctx context.Context, companyID *uuid.UUID, periodEnd time.Time) (*domain.RevenueRecognitionRun, error) {
+	schedules, err := s.repo.ListForRecognition(ctx, companyID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.RevenueRecognitionRun{PeriodEnd: periodEnd, Vouchers: []uuid.UUID{}}
+	var errs []error
+	for i := range schedules {
+		schedule := &schedules[i]
+		lines := schedule.DueLines(periodEnd)
+		if len(lines) == 0 {
+			continue
+		}
+		run.Checked++
+
+		posted, err := s.deferralPosted(ctx, schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("revenue schedule %s: %w", schedule.InvoiceNumber, err))
+			continue
+		}
+		if !posted {
+			run.Waiting++
+			continue
+		}
+
+		userID := uuid.Nil
+		if schedule.CreatedBy != nil {
+			userID = *schedule.CreatedBy
+		}
+		for _, line := range lines {
+			voucher, err := s.recognize(ctx, schedule, line, userID)
+			if errors.Is(err, domain.ErrFiscalPeriodClosed) {
+				run.Waiting++
+				break
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("revenue schedule %s: %w", schedule.InvoiceNumber, err))
+				break
+			}
+			if voucher != nil {
+				run.Recognized++
+				run.Vouchers = append(run.Vouchers, voucher.ID)
+			}
+		}
+	}
+	return run, errors.Join(errs...)
+}
+
+// recognize records the line posted, then posts its voucher, nil for a line
+// rounded to nothing. The line is recorded first so that a run repeated after
+// a failure does not recognize the month again; it returns to pending when
+// the voucher fails to post.
+func (s *revenueScheduleService) recognize(ctx context.Context, schedule *domain.RevenueSchedule, line *domain.RevenueScheduleLine, userID uuid.UUID) (*domain.Voucher, error) {
+	schedule.Recognize(line, nil, time.Now())
+	if err := s.repo.RecordRecognition(ctx, schedule, line); err != nil {
+		return nil, err
+	}
+	if line.Amount == 0 {
+		return nil, nil
+	}
+
+	voucher := s.voucher(schedule, domain.VoucherTypeAdjustment, line.PeriodEnd,
+		fmt.Sprintf("선수수익 수익인식 %s %s", schedule.Description, line.PeriodEnd.Format("2006-01")),
+		schedule.DeferredRevenueAccountID, schedule.RevenueAccountID, line.Amount)
+	if err := s.voucherService.PostGenerated(ctx, voucher, userID); err != nil {
+		if undoErr := s.repo.ReleaseRecognition(ctx, schedule, line); undoErr != nil {
+			return nil, errors.Join(err, undoErr)
+		}
+		return nil, err
+	}
+	line.VoucherID = &voucher.ID
+	if err := s.repo.LinkRecognitionVoucher(ctx, line); err != nil {
+		return nil, fmt.Errorf("recognition voucher %s is posted but not linked to its line: %w", voucher.VoucherNo, err)
+	}
+	return voucher, nil
+}
+
+// deferralPosted tells whether the deferral voucher of the schedule is posted
+func (s *revenueScheduleService) deferralPosted(ctx context.Context, schedule *domain.RevenueSchedule) (bool, error) {
+	if schedule.DeferralVoucherID == nil {
+		return false, nil
+	}
+	voucher, err := s.voucherRepo.FindByID(ctx, schedule.CompanyID, *schedule.DeferralVoucherID)
+	if err != nil {
+		return false, err
+	}
+	return voucher.Status == domain.VoucherStatusPosted, nil
+}
+
+// voucher returns a voucher of the schedule moving the amount from the debit
+// to the credit account
+func (s *revenueScheduleService) voucher(schedule *domain.RevenueSchedule, voucherType domain.VoucherType, date time.Time, description string, debitAccountID, creditAccountID uuid.UUID, amount int64) *domain.Voucher {
+	debit := domain.VoucherEntry{
+		CompanyID:   schedule.CompanyID,
+		AccountID:   debitAccountID,
+		Description: description,
+		PartnerID:   schedule.PartnerID,
+	}
+	debit.SetDebit(float64(amount))
+	credit := domain.VoucherEntry{
+		CompanyID:   schedule.CompanyID,
+		AccountID:   creditAccountID,
+		Description: description,
+		PartnerID:   schedule.PartnerID,
+	}
+	credit.SetCredit(float64(amount))
+
+	return &domain.Voucher{
+		TenantModel:   domain.TenantModel{CompanyID: schedule.CompanyID},
+		VoucherDate:   date,
+		VoucherType:   voucherType,
+		Description:   description,
+		ReferenceType: revenueScheduleReferenceType,
+		ReferenceID:   &schedule.ID,
+		Entries:       []domain.VoucherEntry{debit, credit},
+		CreatedBy:     schedule.CreatedBy,
+	}
+}
+
+// Waterfall builds the waterfall of all schedules of the company
+func (s *revenueScheduleService) Waterfall(ctx context.Context, companyID uuid.UUID, asOf time.Time, months int) (*domain.DeferredRevenueWaterfall, error) {
+	schedules, err := s.repo.ListWithLines(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewDeferredRevenueWaterfall(schedules, asOf, months), nil
+}
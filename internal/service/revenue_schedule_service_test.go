@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// newServiceTestRevenueSchedule returns 1,200,000 won of maintenance from
+// January 15 to April 14, 2026 whose deferral voucher is posted
+func newServiceTestRevenueSchedule(t *testing.T, companyID uuid.UUID, voucherRepo *mocks.MockVoucherRepository) domain.RevenueSchedule {
+	userID := newTestUserID()
+	deferralID := uuid.New()
+	schedule := domain.RevenueSchedule{
+		TenantModel:              domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		TaxInvoiceID:             uuid.New(),
+		InvoiceNumber:            "20260115-0001",
+		Description:              "유지보수",
+		ServiceStart:             time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		ServiceEnd:               time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC),
+		Amount:                   1200000,
+		RevenueAccountID:         uuid.New(),
+		DeferredRevenueAccountID: uuid.New(),
+		DeferralDate:             time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		DeferralVoucherID:        &deferralID,
+		CreatedBy:                &userID,
+	}
+	require.NoError(t, schedule.Validate())
+
+	deferral := &domain.Voucher{Status: domain.VoucherStatusPosted}
+	voucherRepo.On("FindByID", mock.Anything, companyID, deferralID).Return(deferral, nil)
+	return schedule
+}
+
+func TestRevenueScheduleService_RecognizeRevenue(t *testing.T) {
+	ctx := context.Background()
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	periodEnd := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	t.Run("posts the due months at their month end", func(t *testing.T) {
+		repo, voucherRepo, vouchers := new(mocks.MockRevenueScheduleRepository), new(mocks.MockVoucherRepository), new(mocks.MockVoucherService)
+		svc := service.NewRevenueScheduleService(repo, nil, nil, nil, voucherRepo, vouchers)
+		schedule := newServiceTestRevenueSchedule(t, companyID, voucherRepo)
+
+		var steps []string
+		var posted []*domain.Voucher
+		repo.On("ListForRecognition", ctx, (*uuid.UUID)(nil), periodEnd).Return([]domain.RevenueSchedule{schedule}, nil).Once()
+		repo.On("RecordRecognition", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			assert.Nil(t, args.Get(2).(*domain.RevenueScheduleLine).VoucherID)
+			steps = append(steps, "record")
+		}).Return(nil).Twice()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).Run(func(args mock.Arguments) {
+			voucher := args.Get(1).(*domain.Voucher)
+			voucher.ID = uuid.New()
+			posted = append(posted, voucher)
+			steps = append(steps, "post")
+		}).Return(nil).Twice()
+		repo.On("LinkRecognitionVoucher", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			assert.NotNil(t, args.Get(1).(*domain.RevenueScheduleLine).VoucherID)
+			steps = append(steps, "link")
+		}).Return(nil).Twice()
+
+		run, err := svc.RecognizeRevenue(ctx, nil, periodEnd)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Checked)
+		assert.Equal(t, 2, run.Recognized)
+		assert.Equal(t, []string{"record", "post", "link", "record", "post", "link"}, steps)
+
+		require.Len(t, posted, 2)
+		assert.Equal(t, []uuid.UUID{posted[0].ID, posted[1].ID}, run.Vouchers)
+		assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), posted[0].VoucherDate)
+		assert.Equal(t, domain.VoucherTypeAdjustment, posted[1].VoucherType)
+		require.Len(t, posted[1].Entries, 2)
+		assert.Equal(t, schedule.DeferredRevenueAccountID, posted[1].Entries[0].AccountID)
+		assert.Equal(t, 373333.0, posted[1].Entries[0].DebitAmount)
+		assert.Equal(t, schedule.RevenueAccountID, posted[1].Entries[1].AccountID)
+		assert.Equal(t, 373333.0, posted[1].Entries[1].CreditAmount)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns the month to pending while its period is closed", func(t *testing.T) {
+		repo, voucherRepo, vouchers := new(mocks.MockRevenueScheduleRepository), new(mocks.MockVoucherRepository), new(mocks.MockVoucherService)
+		svc := service.NewRevenueScheduleService(repo, nil, nil, nil, voucherRepo, vouchers)
+		schedule := newServiceTestRevenueSchedule(t, companyID, voucherRepo)
+
+		repo.On("ListForRecognition", ctx, &companyID, periodEnd).Return([]domain.RevenueSchedule{schedule}, nil).Once()
+		repo.On("RecordRecognition", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+		vouchers.On("PostGenerated", ctx, mock.AnythingOfType("*domain.Voucher"), userID).Return(domain.ErrFiscalPeriodClosed).Once()
+		repo.On("ReleaseRecognition", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+		run, err := svc.RecognizeRevenue(ctx, &companyID, periodEnd)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Waiting)
+		assert.Zero(t, run.Recognized)
+		repo.AssertNotCalled(t, "LinkRecognitionVoucher", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("posts no voucher for a month recognized meanwhile", func(t *testing.T) {
+		repo, voucherRepo, vouchers := new(mocks.MockRevenueScheduleRepository), new(mocks.MockVoucherRepository), new(mocks.MockVoucherService)
+		svc := service.NewRevenueScheduleService(repo, nil, nil, nil, voucherRepo, vouchers)
+		schedule := newServiceTestRevenueSchedule(t, companyID, voucherRepo)
+
+		repo.On("ListForRecognition", ctx, &companyID, periodEnd).Return([]domain.RevenueSchedule{schedule}, nil).Once()
+		repo.On("RecordRecognition", ctx, mock.Anything, mock.Anything).Return(domain.ErrRevenueScheduleLineChanged).Once()
+
+		run, err := svc.RecognizeRevenue(ctx, &companyID, periodEnd)
+
+		assert.ErrorIs(t, err, domain.ErrRevenueScheduleLineChanged)
+		assert.Zero(t, run.Recognized)
+		vouchers.AssertNotCalled(t, "PostGenerated", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// schedulerBatchSize is the maximum number of runs made per scheduler tick,
+// which bounds the runs made up at once under the "all" catch-up policy
+const schedulerBatchSize = 50
+
+// ScheduledTaskFunc runs a recurring task for its scheduled time
+type ScheduledTaskFunc func(ctx context.Context, scheduledAt time.Time) error
+
+// UpdateScheduledTaskRequest changes the schedule of a task; nil fields are kept
+type UpdateScheduledTaskRequest struct {
+	CronExpr *string
+	Timezone *string
+	CatchUp  *domain.CatchUpPolicy
+	Enabled  *bool
+}
+
+// SchedulerService defines the interface for the recurring tasks of the
+// worker. Every worker replica registers the tasks and calls RunDue; the
+// claims of the runs in the database make each scheduled time run once.
+type SchedulerService interface {
+	// Query and management operations, for platform operators
+	List(ctx context.Context) ([]domain.ScheduledTask, error)
+	Get(ctx context.Context, name string) (*domain.ScheduledTask, error)
+	Update(ctx context.Context, name string, req UpdateScheduledTaskRequest) (*domain.ScheduledTask, error)
+
+	// Register sets the function of a task stored in scheduled_tasks; only
+	// registered tasks are run by this worker
+	Register(name string, fn ScheduledTaskFunc)
+	// RunDue claims and runs the due tasks and returns the number of runs
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// schedulerService implements SchedulerService
+type schedulerService struct {
+	repo  repository.ScheduledTaskRepository
+	owner string
+	tasks map[string]ScheduledTaskFunc
+}
+
+// NewSchedulerService creates a new SchedulerService; owner identifies the
+// worker holding the tasks it runs, e.g. its host name and process ID
+func NewSchedulerService(repo repository.ScheduledTaskRepository, owner string) SchedulerService {
+	return &schedulerService{repo: repo, owner: owner, tasks: make(map[string]ScheduledTaskFunc)}
+}
+
+// List retrieves all scheduled tasks
+func (s *schedulerService) List(ctx context.Context) ([]domain.ScheduledTask, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// Get retrieves a scheduled task by name
+func (s *schedulerService) Get(ctx context.Context, name string) (*domain.ScheduledTask, error) {
+	return s.repo.FindByName(ctx, name)
+}
+
+// Update changes the schedule of a task. The next run is computed again from
+// now, so runs missed under the old schedule are not made up.
+func (s *schedulerService) Update(ctx context.Context, name string, req UpdateScheduledTaskRequest) (*domain.ScheduledTask, error) {
+	task, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if req.CronExpr != nil {
+		task.CronExpr = *req.CronExpr
+	}
+	if req.Timezone != nil {
+		task.Timezone = *req.Timezone
+	}
+	if req.CatchUp != nil {
+		task.CatchUp = *req.CatchUp
+	}
+	if req.Enabled != nil {
+		task.Enabled = *req.Enabled
+	}
+	if err := task.Reschedule(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Register sets the function of a task
+func (s *schedulerService) Register(name string, fn ScheduledTaskFunc) {
+	s.tasks[name] = fn
+}
+
+// RunDue claims the due tasks one run at a time and runs them. A run claimed
+// by another worker meanwhile is left to it. Failed runs are recorded on the
+// task and returned as a joined error for logging.
+func (s *schedulerService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	if len(s.tasks) == 0 {
+		return 0, nil
+	}
+	names := make([]string, 0, len(s.tasks))
+	for name := range s.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	count := 0
+	var errs []error
+	for count < schedulerBatchSize {
+		tasks, err := s.repo.FindDue(ctx, names, now)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		ran := false
+		for i := range tasks {
+			if count >= schedulerBatchSize {
+				break
+			}
+			claimed, err := s.runTask(ctx, &tasks[i], now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("task %s: %w", tasks[i].Name, err))
+			}
+			if claimed {
+				count++
+				ran = true
+			}
+		}
+		// Tasks with more runs to make up are due again
+		if !ran {
+			break
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// runTask claims the due run of the task and runs it. It returns true if this
+// worker claimed the run, whether it was run or dropped by the skip policy.
+func (s *schedulerService) runTask(ctx context.Context, task *domain.ScheduledTask, now time.Time) (bool, error) {
+	expected := task.NextRunAt
+	scheduledAt, run, err := task.Claim(s.owner, now)
+	if err != nil {
+		return false, err
+	}
+	claimed, err := s.repo.Claim(ctx, task, expected, now)
+	if err != nil || !claimed || !run {
+		return claimed, err
+	}
+
+	err = s.call(ctx, task.Name, scheduledAt)
+	task.Finish(err, time.Now())
+	if releaseErr := s.repo.Release(ctx, task, s.owner); releaseErr != nil {
+		return true, errors.Join(err, releaseErr)
+	}
+	return true, err
+}
+
+// call runs the function of the task, turning a panic into a failed run
+func (s *schedulerService) call(ctx context.Context, name string, scheduledAt time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+	return s.tasks[name](ctx, scheduledAt)
+}
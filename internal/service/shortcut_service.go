@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// ShortcutStore keeps the ranked per-user lists of shortcuts as sorted sets.
+// database.RedisCache implements it.
+type ShortcutStore interface {
+	ZAdd(ctx context.Context, key string, score float64, members ...string) error
+	ZRem(ctx context.Context, key string, members ...string) error
+	ZRevRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+	ZCard(ctx context.Context, key string) (int64, error)
+	ZRemRangeByRank(ctx context.Context, key string, start, stop int64) error
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+}
+
+// ShortcutRecorder records the accounts and partners a user used on a voucher
+type ShortcutRecorder interface {
+	RecordVoucher(ctx context.Context, userID uuid.UUID, voucher *domain.Voucher)
+}
+
+// ShortcutService defines the interface for the favorite and recent accounts
+// and partners of users, offered first by entry forms
+type ShortcutService interface {
+	ShortcutRecorder
+
+	// List returns the user's shortcuts of the kind, favorites first
+	List(ctx context.Context, companyID, userID uuid.UUID, kind domain.ShortcutKind) ([]domain.Shortcut, error)
+	// AddFavorite and RemoveFavorite change the user's favorites of the kind
+	AddFavorite(ctx context.Context, companyID, userID uuid.UUID, kind domain.ShortcutKind, id uuid.UUID) error
+	RemoveFavorite(ctx context.Context, companyID, userID uuid.UUID, kind domain.ShortcutKind, id uuid.UUID) error
+}
+
+// shortcutService implements ShortcutService
+type shortcutService struct {
+	store       ShortcutStore // nil disables shortcuts
+	accountRepo repository.AccountRepository
+	partnerRepo repository.PartnerRepository
+}
+
+// NewShortcutService creates a new ShortcutService; without a store users
+// have no shortcuts
+func NewShortcutService(store ShortcutStore, accountRepo repository.AccountRepository, partnerRepo repository.PartnerRepository) ShortcutService {
+	return &shortcutService{store: store, accountRepo: accountRepo, partnerRepo: partnerRepo}
+}
+
+// List merges the favorites with the recent items and looks them up. Items
+// deleted since they were used are left out.
+func (s *shortcutService) List(ctx context.Context, companyID, userID uuid.UUID, kind domain.ShortcutKind) ([]domain.Shortcut, error) {
+	if !kind.IsValid() {
+		return nil, domain.ErrInvalidShortcutKind
+	}
+	if s.store == nil {
+		return []domain.Shortcut{}, nil
+	}
+	favorites, err := s.members(ctx, favoritesKey(companyID, userID, kind))
+	if err != nil {
+		return nil, err
+	}
+	recent, err := s.members(ctx, recentKey(companyID, userID, kind))
+	if err != nil {
+		return nil, err
+	}
+	ids := domain.ShortcutOrder(favorites, recent)
+	if len(ids) == 0 {
+		return []domain.Shortcut{}, nil
+	}
+
+	found, err := s.lookup(ctx, companyID, kind, ids)
+	if err != nil {
+		return nil, err
+	}
+	isFavorite := make(map[uuid.UUID]bool, len(favorites))
+	for _, id := range favorites {
+		isFavorite[id] = true
+	}
+	shortcuts := make([]domain.Shortcut, 0, len(ids))
+	for _, id := range ids {
+		if shortcut, ok := found[id]; ok {
+			shortcut.Favorite = isFavorite[id]
+			shortcuts = append(shortcuts, shortcut)
+		}
+	}
+	return shortcuts, nil
+}
+
+// AddFavorite checks the item exists and adds it as the newest favorite
+func (s *shortcutService) AddFavorite(ctx context.Context, companyID, userID uuid.UUID, kind domain.ShortcutKind, id uuid.UUID) error {
+	if !kind.IsValid() {
+		return domain.ErrInvalidShortcutKind
+	}
+	if s.store == nil {
+		return domain.ErrShortcutsDisabled
+	}
+	if kind == domain.ShortcutAccounts {
+		if _, err := s.accountRepo.FindByID(ctx, companyID, id); err != nil {
+			return err
+		}
+	} else if _, err := s.partnerRepo.GetByID(ctx, companyID, id); err != nil {
+		return err
+	}
+
+	key := favoritesKey(companyID, userID, kind)
+	count, err := s.store.ZCard(ctx, key)
+	if err != nil {
+		return err
+	}
+	if count >= domain.ShortcutFavoriteLimit {
+		// Adding a favorite again only moves it to the front
+		favorites, err := s.members(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(favorites, id) {
+			return domain.ErrTooManyFavorites
+		}
+	}
+	return s.store.ZAdd(ctx, key, float64(time.Now().UnixMilli()), id.String())
+}
+
+// RemoveFavorite removes the item from the favorites; removing an item that
+// is not a favorite is not an error
+func (s *shortcutService) RemoveFavorite(ctx context.Context, companyID, userID uuid.UUID, kind domain.ShortcutKind, id uuid.UUID) error {
+	if !kind.IsValid() {
+		return domain.ErrInvalidShortcutKind
+	}
+	if s.store == nil {
+		return domain.ErrShortcutsDisabled
+	}
+	return s.store.ZRem(ctx, favoritesKey(companyID, userID, kind), id.String())
+}
+
+// RecordVoucher moves the accounts and partners of the voucher's entries to
+// the front of the user's recent items. Shortcuts only save typing, so
+// failures are ignored.
+func (s *shortcutService) RecordVoucher(ctx context.Context, userID uuid.UUID, voucher *domain.Voucher) {
+	if s.store == nil || voucher == nil {
+		return
+	}
+	used := map[domain.ShortcutKind][]string{}
+	for _, e := range voucher.Entries {
+		if e.IsTaxLine {
+			continue
+		}
+		used[domain.ShortcutAccounts] = append(used[domain.ShortcutAccounts], e.AccountID.String())
+		if e.PartnerID != nil {
+			used[domain.ShortcutPartners] = append(used[domain.ShortcutPartners], e.PartnerID.String())
+		}
+	}
+
+	score := float64(time.Now().UnixMilli())
+	for kind, members := range used {
+		key := recentKey(voucher.CompanyID, userID, kind)
+		if s.store.ZAdd(ctx, key, score, members...) != nil {
+			continue
+		}
+		_ = s.store.ZRemRangeByRank(ctx, key, 0, -domain.ShortcutListLimit-1)
+		_ = s.store.Expire(ctx, key, domain.ShortcutRecentTTL)
+	}
+}
+
+// members returns the IDs of a sorted set, highest score first
+func (s *shortcutService) members(ctx context.Context, key string) ([]uuid.UUID, error) {
+	members, err := s.store.ZRevRange(ctx, key, 0, domain.ShortcutListLimit-1)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		if id, err := uuid.Parse(member); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// lookup returns the accounts or partners of the IDs by ID
+func (s *shortcutService) lookup(ctx context.Context, companyID uuid.UUID, kind domain.ShortcutKind, ids []uuid.UUID) (map[uuid.UUID]domain.Shortcut, error) {
+	found := make(map[uuid.UUID]domain.Shortcut, len(ids))
+	if kind == domain.ShortcutAccounts {
+		accounts, _, err := s.accountRepo.FindAll(ctx, repository.AccountFilter{CompanyID: companyID, IDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range accounts {
+			found[a.ID] = domain.Shortcut{ID: a.ID, Code: a.Code, Name: a.Name}
+		}
+		return found, nil
+	}
+	partners, _, err := s.partnerRepo.List(ctx, &repository.PartnerFilter{CompanyID: companyID, IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range partners {
+		found[p.ID] = domain.Shortcut{ID: p.ID, Code: p.Code, Name: p.Name}
+	}
+	return found, nil
+}
+
+// favoritesKey is the sorted set of a user's favorites, scored by when they were added
+func favoritesKey(companyID, userID uuid.UUID, kind domain.ShortcutKind) string {
+	return fmt.Sprintf("kerp:favorites:%s:%s:%s", companyID, userID, kind)
+}
+
+// recentKey is the sorted set of a user's recent items, scored by their last use
+func recentKey(companyID, userID uuid.UUID, kind domain.ShortcutKind) string {
+	return fmt.Sprintf("kerp:recent:%s:%s:%s", companyID, userID, kind)
+}
@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// memoryShortcutStore keeps sorted sets in memory
+type memoryShortcutStore struct {
+	sets map[string]map[string]float64
+}
+
+func (m *memoryShortcutStore) ZAdd(_ context.Context, key string, score float64, members ...string) error {
+	if m.sets[key] == nil {
+		m.sets[key] = map[string]float64{}
+	}
+	for _, member := range members {
+		m.sets[key][member] = score
+	}
+	return nil
+}
+
+func (m *memoryShortcutStore) ZRem(_ context.Context, key string, members ...string) error {
+	for _, member := range members {
+		delete(m.sets[key], member)
+	}
+	return nil
+}
+
+func (m *memoryShortcutStore) ZRevRange(_ context.Context, key string, start, stop int64) ([]string, error) {
+	members := make([]string, 0, len(m.sets[key]))
+	for member := range m.sets[key] {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return m.sets[key][members[i]] > m.sets[key][members[j]] })
+	if stop >= int64(len(members)) {
+		stop = int64(len(members)) - 1
+	}
+	if start > stop {
+		return nil, nil
+	}
+	return members[start : stop+1], nil
+}
+
+func (m *memoryShortcutStore) ZCard(_ context.Context, key string) (int64, error) {
+	return int64(len(m.sets[key])), nil
+}
+
+func (m *memoryShortcutStore) ZRemRangeByRank(context.Context, string, int64, int64) error {
+	return nil
+}
+
+func (m *memoryShortcutStore) Expire(context.Context, string, time.Duration) error { return nil }
+
+func TestShortcutService_FavoritesFirst(t *testing.T) {
+	accountRepo := new(mocks.MockAccountRepository)
+	store := &memoryShortcutStore{sets: map[string]map[string]float64{}}
+	svc := service.NewShortcutService(store, accountRepo, new(mocks.MockPartnerRepository))
+	ctx := context.Background()
+	companyID, userID := newTestCompanyID(), uuid.New()
+
+	cash := domain.Account{Code: "101000", Name: "현금"}
+	cash.ID = uuid.New()
+	meals := domain.Account{Code: "813100", Name: "복리후생비"}
+	meals.ID = uuid.New()
+	voucher := &domain.Voucher{Entries: []domain.VoucherEntry{{AccountID: cash.ID}, {AccountID: meals.ID}}}
+	voucher.CompanyID = companyID
+	svc.RecordVoucher(ctx, userID, voucher)
+
+	accountRepo.On("FindByID", mock.Anything, companyID, meals.ID).Return(&meals, nil).Once()
+	require.NoError(t, svc.AddFavorite(ctx, companyID, userID, domain.ShortcutAccounts, meals.ID))
+
+	accountRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(f repository.AccountFilter) bool {
+		return f.CompanyID == companyID && len(f.IDs) == 2
+	})).Return([]domain.Account{cash, meals}, int64(2), nil).Once()
+	shortcuts, err := svc.List(ctx, companyID, userID, domain.ShortcutAccounts)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Shortcut{
+		{ID: meals.ID, Code: "813100", Name: "복리후생비", Favorite: true},
+		{ID: cash.ID, Code: "101000", Name: "현금"},
+	}, shortcuts)
+
+	_, err = svc.List(ctx, companyID, userID, "vouchers")
+	assert.ErrorIs(t, err, domain.ErrInvalidShortcutKind)
+
+	accountRepo.AssertExpectations(t)
+}
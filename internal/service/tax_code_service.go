@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// TaxCodeService defines the interface for tax code business logic
+type TaxCodeService interface {
+	Create(ctx context.Context, taxCode *domain.TaxCode) error
+	Update(ctx context.Context, taxCode *domain.TaxCode) error
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxCode, error)
+	List(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.TaxCode, error)
+
+	// VAT return report
+	GetVATReturn(ctx context.Context, companyID uuid.UUID, from, to time.Time) (*domain.VATReturn, error)
+}
+
+// taxCodeService implements TaxCodeService
+type taxCodeService struct {
+	taxCodeRepo repository.TaxCodeRepository
+	accountRepo repository.AccountRepository
+}
+
+// NewTaxCodeService creates a new TaxCodeService
+func NewTaxCodeService(taxCodeRepo repository.TaxCodeRepository, accountRepo repository.AccountRepository) TaxCodeService {
+	return &taxCodeService{
+		taxCodeRepo: taxCodeRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// Create creates a new tax code
+func (s *taxCodeService) Create(ctx context.Context, taxCode *domain.TaxCode) error {
+	if err := s.validate(ctx, taxCode); err != nil {
+		return err
+	}
+
+	exists, err := s.taxCodeRepo.ExistsByCode(ctx, taxCode.CompanyID, taxCode.Code, nil)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrTaxCodeExists
+	}
+
+	return s.taxCodeRepo.Create(ctx, taxCode)
+}
+
+// Update updates a tax code
+func (s *taxCodeService) Update(ctx context.Context, taxCode *domain.TaxCode) error {
+	if err := s.validate(ctx, taxCode); err != nil {
+		return err
+	}
+
+	exists, err := s.taxCodeRepo.ExistsByCode(ctx, taxCode.CompanyID, taxCode.Code, &taxCode.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrTaxCodeExists
+	}
+
+	return s.taxCodeRepo.Update(ctx, taxCode)
+}
+
+// Delete deletes a tax code that is not referenced by any entry
+func (s *taxCodeService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	if _, err := s.taxCodeRepo.FindByID(ctx, companyID, id); err != nil {
+		return err
+	}
+
+	inUse, err := s.taxCodeRepo.IsInUse(ctx, companyID, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrTaxCodeInUse
+	}
+
+	return s.taxCodeRepo.Delete(ctx, companyID, id)
+}
+
+// GetByID retrieves a tax code by ID
+func (s *taxCodeService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxCode, error) {
+	return s.taxCodeRepo.FindByID(ctx, companyID, id)
+}
+
+// List lists the tax codes of a company
+func (s *taxCodeService) List(ctx context.Context, companyID uuid.UUID, activeOnly bool) ([]domain.TaxCode, error) {
+	return s.taxCodeRepo.FindAll(ctx, companyID, activeOnly)
+}
+
+// GetVATReturn aggregates posted entries into VAT return figures for the date range
+func (s *taxCodeService) GetVATReturn(ctx context.Context, companyID uuid.UUID, from, to time.Time) (*domain.VATReturn, error) {
+	items, err := s.taxCodeRepo.GetVATSummary(ctx, companyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewVATReturn(from, to, items), nil
+}
+
+// validate validates the tax code and its VAT account
+func (s *taxCodeService) validate(ctx context.Context, taxCode *domain.TaxCode) error {
+	if err := taxCode.Validate(); err != nil {
+		return err
+	}
+
+	if taxCode.VATAccountID != nil {
+		account, err := s.accountRepo.FindByID(ctx, taxCode.CompanyID, *taxCode.VATAccountID)
+		if err != nil {
+			return err
+		}
+		if !account.CanPost() {
+			return domain.ErrControlAccountPosting
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// taxInvoiceReferenceType marks vouchers generated for a tax invoice
+const taxInvoiceReferenceType = "tax_invoice"
+
+// TaxInvoiceAmendmentService defines the interface for amended tax invoices (수정세금계산서)
+type TaxInvoiceAmendmentService interface {
+	// Amend generates the draft amended invoices of an issued invoice. When the
+	// original is linked to a voucher, a draft adjusting voucher is generated for
+	// each amended invoice so that the VAT return follows once it is posted.
+	Amend(ctx context.Context, companyID, userID, invoiceID uuid.UUID, amendment *domain.TaxInvoiceAmendment) ([]*domain.TaxInvoice, error)
+	ListAmendments(ctx context.Context, companyID, invoiceID uuid.UUID) ([]*domain.TaxInvoice, error)
+}
+
+// taxInvoiceAmendmentService implements TaxInvoiceAmendmentService
+type taxInvoiceAmendmentService struct {
+	repo           repository.TaxInvoiceRepository
+	voucherRepo    repository.VoucherRepository
+	voucherService VoucherService
+	taxCodeRepo    repository.TaxCodeRepository
+}
+
+// NewTaxInvoiceAmendmentService creates a new TaxInvoiceAmendmentService
+func NewTaxInvoiceAmendmentService(
+	repo repository.TaxInvoiceRepository,
+	voucherRepo repository.VoucherRepository,
+	voucherService VoucherService,
+	taxCodeRepo repository.TaxCodeRepository,
+) TaxInvoiceAmendmentService {
+	return &taxInvoiceAmendmentService{
+		repo:           repo,
+		voucherRepo:    voucherRepo,
+		voucherService: voucherService,
+		taxCodeRepo:    taxCodeRepo,
+	}
+}
+
+// Amend generates the amended invoices and their adjusting vouchers. Vouchers
+// are created first, as they are the part most likely to be refused, for
+// example when the period of the original is closed.
+func (s *taxInvoiceAmendmentService) Amend(ctx context.Context, companyID, userID, invoiceID uuid.UUID, amendment *domain.TaxInvoiceAmendment) ([]*domain.TaxInvoice, error) {
+	original, err := s.repo.GetByID(ctx, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListItems(ctx, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		original.Items = append(original.Items, *item)
+	}
+
+	existing, err := s.repo.ListAmendments(ctx, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if original.IsReversedBy(existing) {
+		return nil, domain.ErrTaxInvoiceAlreadyAmended
+	}
+
+	now := time.Now()
+	invoices, err := domain.BuildAmendedInvoices(original, amendment, len(existing)+1, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.VoucherID != nil {
+		if err := s.createVouchers(ctx, original, invoices, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	numbers := make([]string, len(invoices))
+	for i, invoice := range invoices {
+		if err := s.create(ctx, invoice); err != nil {
+			return nil, err
+		}
+		numbers[i] = invoice.InvoiceNumber
+
+		_ = s.repo.CreateHistory(ctx, &domain.TaxInvoiceHistory{
+			ID:           uuid.New(),
+			TaxInvoiceID: invoice.ID,
+			CompanyID:    companyID,
+			NewStatus:    invoice.Status,
+			ChangedBy:    &userID,
+			ChangeReason: fmt.Sprintf("Amendment of %s (%s)", original.InvoiceNumber, amendment.Reason.Label()),
+			CreatedAt:    now,
+		})
+	}
+
+	_ = s.repo.CreateHistory(ctx, &domain.TaxInvoiceHistory{
+		ID:             uuid.New(),
+		TaxInvoiceID:   original.ID,
+		CompanyID:      companyID,
+		PreviousStatus: original.Status,
+		NewStatus:      original.Status,
+		ChangedBy:      &userID,
+		ChangeReason:   fmt.Sprintf("Amended (%s): %s", amendment.Reason.Label(), strings.Join(numbers, ", ")),
+		CreatedAt:      now,
+	})
+
+	return invoices, nil
+}
+
+// ListAmendments returns the amended invoices of an original
+func (s *taxInvoiceAmendmentService) ListAmendments(ctx context.Context, companyID, invoiceID uuid.UUID) ([]*domain.TaxInvoice, error) {
+	if _, err := s.repo.GetByID(ctx, companyID, invoiceID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAmendments(ctx, companyID, invoiceID)
+}
+
+// create stores an invoice and its items; items are stored separately
+func (s *taxInvoiceAmendmentService) create(ctx context.Context, invoice *domain.TaxInvoice) error {
+	items := invoice.Items
+	invoice.Items = nil
+	if err := s.repo.Create(ctx, invoice); err != nil {
+		return err
+	}
+	for i := range items {
+		if err := s.repo.CreateItem(ctx, &items[i]); err != nil {
+			return err
+		}
+	}
+	invoice.Items = items
+	return nil
+}
+
+// createVouchers generates a draft voucher per amended invoice from the voucher
+// of the original, scaled to the amended amounts
+func (s *taxInvoiceAmendmentService) createVouchers(ctx context.Context, original *domain.TaxInvoice, invoices []*domain.TaxInvoice, userID uuid.UUID) error {
+	source, err := s.voucherRepo.FindByID(ctx, original.CompanyID, *original.VoucherID)
+	if err != nil {
+		return err
+	}
+
+	for _, invoice := range invoices {
+		entries := amendmentVoucherEntries(source, original, invoice)
+		if len(entries) == 0 {
+			continue
+		}
+		if invoice.AmendmentReason == domain.TaxInvoiceAmendmentLocalLC && invoice.TaxAmount == 0 {
+			if err := s.useZeroRatedTaxCode(ctx, original.CompanyID, entries); err != nil {
+				return err
+			}
+		}
+
+		voucher := &domain.Voucher{
+			TenantModel:   domain.TenantModel{CompanyID: original.CompanyID},
+			VoucherDate:   invoice.IssueDate,
+			VoucherType:   source.VoucherType,
+			Description:   fmt.Sprintf("수정세금계산서 %s (%s) 당초 %s", invoice.InvoiceNumber, invoice.AmendmentReason.Label(), source.VoucherNo),
+			ReferenceType: taxInvoiceReferenceType,
+			ReferenceID:   &invoice.ID,
+			Entries:       entries,
+			CreatedBy:     &userID,
+		}
+		if err := s.voucherService.Create(ctx, voucher); err != nil {
+			return fmt.Errorf("failed to create voucher for %s: %w", invoice.InvoiceNumber, err)
+		}
+		invoice.VoucherID = &voucher.ID
+	}
+	return nil
+}
+
+// useZeroRatedTaxCode moves the supply lines of a zero-rated amendment to the
+// company's zero-rated output tax code, so that they are reported as 영세율
+// supplies rather than split again at the taxable rate. Without such a code the
+// lines are left without tax code.
+func (s *taxInvoiceAmendmentService) useZeroRatedTaxCode(ctx context.Context, companyID uuid.UUID, entries []domain.VoucherEntry) error {
+	taxCodes, err := s.taxCodeRepo.FindAll(ctx, companyID, true)
+	if err != nil {
+		return err
+	}
+	var zeroRated *uuid.UUID
+	for i := range taxCodes {
+		if taxCodes[i].TaxType == domain.TaxTypeOutput && taxCodes[i].TaxCategory == domain.TaxCategoryZeroRated {
+			zeroRated = &taxCodes[i].ID
+			break
+		}
+	}
+	for i := range entries {
+		if entries[i].TaxCodeID != nil {
+			entries[i].TaxCodeID = zeroRated
+			entries[i].TaxAmount = 0
+		}
+	}
+	return nil
+}
+
+// amendmentVoucherEntries copies the entries of the original voucher scaled to
+// the amended invoice: VAT lines by the tax ratio, supply lines (with a tax
+// code) by the supply ratio and all other lines by the total ratio. Negative
+// amounts move to the other side. Rounding differences are absorbed by the
+// largest counter line so that the voucher balances.
+func amendmentVoucherEntries(source *domain.Voucher, original, amended *domain.TaxInvoice) []domain.VoucherEntry {
+	ratio := func(amended, original int64) float64 {
+		if original == 0 {
+			return 0
+		}
+		return float64(amended) / float64(original)
+	}
+	supplyRatio := ratio(amended.SupplyAmount, original.SupplyAmount)
+	taxRatio := ratio(amended.TaxAmount, original.TaxAmount)
+	totalRatio := ratio(amended.TotalAmount, original.TotalAmount)
+
+	var (
+		entries      []domain.VoucherEntry
+		debit        float64
+		credit       float64
+		counter      = -1
+		counterValue float64
+	)
+	for _, e := range source.Entries {
+		r := totalRatio
+		switch {
+		case e.IsTaxLine:
+			r = taxRatio
+		case e.TaxCodeID != nil:
+			r = supplyRatio
+		}
+		amount := math.Round(e.GetAmount() * r)
+		if amount == 0 {
+			continue
+		}
+
+		entry := domain.VoucherEntry{
+			CompanyID:     source.CompanyID,
+			AccountID:     e.AccountID,
+			Description:   e.Description,
+			PartnerID:     e.PartnerID,
+			DepartmentID:  e.DepartmentID,
+			ProjectID:     e.ProjectID,
+			CostCenterID:  e.CostCenterID,
+			BankAccountID: e.BankAccountID,
+			TaxCodeID:     e.TaxCodeID,
+			TaxAmount:     math.Abs(math.Round(e.TaxAmount * taxRatio)),
+			IsTaxLine:     e.IsTaxLine,
+		}
+		if e.IsDebit() == (amount > 0) {
+			entry.SetDebit(math.Abs(amount))
+			debit += math.Abs(amount)
+		} else {
+			entry.SetCredit(math.Abs(amount))
+			credit += math.Abs(amount)
+		}
+		if !e.IsTaxLine && e.TaxCodeID == nil && math.Abs(amount) > counterValue {
+			counter, counterValue = len(entries), math.Abs(amount)
+		}
+		entries = append(entries, entry)
+	}
+
+	if diff := debit - credit; diff != 0 && counter >= 0 {
+		entry := &entries[counter]
+		if entry.IsDebit() {
+			entry.SetDebit(entry.DebitAmount - diff)
+		} else {
+			entry.SetCredit(entry.CreditAmount + diff)
+		}
+	}
+	return entries
+}
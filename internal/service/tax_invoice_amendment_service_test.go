@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+func TestTaxInvoiceAmendmentService_Amend_Return(t *testing.T) {
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	voucherRepo := new(mocks.MockVoucherRepository)
+	voucherService := new(mocks.MockVoucherService)
+	svc := service.NewTaxInvoiceAmendmentService(invoiceRepo, voucherRepo, voucherService, nil)
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	receivable, revenue, vatPayable, taxCode := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	source := &domain.Voucher{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		VoucherNo:   "SA-202401-0001",
+		VoucherType: domain.VoucherTypeSales,
+		Entries: []domain.VoucherEntry{
+			{AccountID: receivable, DebitAmount: 1100000},
+			{AccountID: revenue, CreditAmount: 1000000, TaxCodeID: &taxCode, TaxAmount: 100000},
+			{AccountID: vatPayable, CreditAmount: 100000, TaxCodeID: &taxCode, TaxAmount: 100000, IsTaxLine: true},
+		},
+	}
+	original := &domain.TaxInvoice{
+		ID:                     uuid.New(),
+		CompanyID:              companyID,
+		InvoiceNumber:          "INV-001",
+		InvoiceType:            domain.TaxInvoiceTypeSales,
+		IssueDate:              time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Status:                 domain.TaxInvoiceStatusConfirmed,
+		SupplierBusinessNumber: "1234567890",
+		SupplierName:           "공급자",
+		BuyerBusinessNumber:    "2345678901",
+		BuyerName:              "공급받는자",
+		SupplyAmount:           1000000,
+		TaxAmount:              100000,
+		TotalAmount:            1100000,
+		VoucherID:              &source.ID,
+	}
+	previous := &domain.TaxInvoice{ID: uuid.New(), AmendmentReason: domain.TaxInvoiceAmendmentSupplyChange}
+
+	invoiceRepo.On("GetByID", mock.Anything, companyID, original.ID).Return(original, nil)
+	invoiceRepo.On("ListItems", mock.Anything, companyID, original.ID).Return([]*domain.TaxInvoiceItem{}, nil)
+	invoiceRepo.On("ListAmendments", mock.Anything, companyID, original.ID).Return([]*domain.TaxInvoice{previous}, nil)
+	voucherRepo.On("FindByID", mock.Anything, companyID, source.ID).Return(source, nil)
+
+	var voucher *domain.Voucher
+	voucherService.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		voucher = args.Get(1).(*domain.Voucher)
+		voucher.ID = uuid.New()
+	}).Return(nil).Once()
+	invoiceRepo.On("Create", mock.Anything, mock.MatchedBy(func(i *domain.TaxInvoice) bool {
+		return i.InvoiceNumber == "INV-001-M2" && i.TotalAmount == -330000
+	})).Return(nil).Once()
+	invoiceRepo.On("CreateItem", mock.Anything, mock.Anything).Return(nil).Once()
+	invoiceRepo.On("CreateHistory", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	returnDate := time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)
+	invoices, err := svc.Amend(context.Background(), companyID, userID, original.ID, &domain.TaxInvoiceAmendment{
+		Reason: domain.TaxInvoiceAmendmentReturn, IssueDate: &returnDate, SupplyAmount: 300000,
+	})
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+	assert.Equal(t, &voucher.ID, invoices[0].VoucherID)
+
+	// The return reverses 30% of the original voucher on the return date
+	require.NotNil(t, voucher)
+	assert.Equal(t, returnDate, voucher.VoucherDate)
+	assert.Equal(t, &invoices[0].ID, voucher.ReferenceID)
+	require.Len(t, voucher.Entries, 3)
+	assert.Equal(t, 330000.0, voucher.Entries[0].CreditAmount)
+	assert.Equal(t, 300000.0, voucher.Entries[1].DebitAmount)
+	assert.Equal(t, 30000.0, voucher.Entries[1].TaxAmount)
+	assert.Equal(t, 30000.0, voucher.Entries[2].DebitAmount)
+	assert.True(t, voucher.Entries[2].IsTaxLine)
+
+	invoiceRepo.AssertExpectations(t)
+	voucherService.AssertExpectations(t)
+}
+
+func TestTaxInvoiceAmendmentService_Amend_AlreadyReversed(t *testing.T) {
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	svc := service.NewTaxInvoiceAmendmentService(invoiceRepo, nil, nil, nil)
+
+	companyID := newTestCompanyID()
+	original := &domain.TaxInvoice{ID: uuid.New(), CompanyID: companyID, InvoiceType: domain.TaxInvoiceTypeSales, Status: domain.TaxInvoiceStatusIssued}
+	cancelled := &domain.TaxInvoice{ID: uuid.New(), AmendmentReason: domain.TaxInvoiceAmendmentDuplicate, Status: domain.TaxInvoiceStatusIssued}
+	invoiceRepo.On("GetByID", mock.Anything, companyID, original.ID).Return(original, nil)
+	invoiceRepo.On("ListItems", mock.Anything, companyID, original.ID).Return([]*domain.TaxInvoiceItem{}, nil)
+	invoiceRepo.On("ListAmendments", mock.Anything, companyID, original.ID).Return([]*domain.TaxInvoice{cancelled}, nil)
+
+	_, err := svc.Amend(context.Background(), companyID, newTestUserID(), original.ID, &domain.TaxInvoiceAmendment{
+		Reason: domain.TaxInvoiceAmendmentDuplicate,
+	})
+	assert.ErrorIs(t, err, domain.ErrTaxInvoiceAlreadyAmended)
+	invoiceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
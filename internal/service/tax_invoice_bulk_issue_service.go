@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// TaxInvoiceBulkIssueService defines the interface for issuing many tax invoices
+// through Popbill at once. Every invoice is issued independently, so one
+// rejected invoice does not stop the others.
+type TaxInvoiceBulkIssueService interface {
+	// Issue issues the draft sales invoices and returns the result of each
+	Issue(ctx context.Context, companyID, userID uuid.UUID, invoiceIDs []uuid.UUID) (*domain.TaxInvoiceBulkIssue, error)
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error)
+
+	// Retry issues again only the invoices of a bulk issuance that were not issued
+	Retry(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error)
+}
+
+// taxInvoiceBulkIssueService implements TaxInvoiceBulkIssueService
+type taxInvoiceBulkIssueService struct {
+	repo        repository.TaxInvoiceBulkIssueRepository
+	invoiceRepo repository.TaxInvoiceRepository
+	popbill     *popbill.Services
+	concurrency int
+	maxInvoices int
+}
+
+// NewTaxInvoiceBulkIssueService creates a new TaxInvoiceBulkIssueService.
+// At most concurrency invoices are sent to Popbill at a time.
+func NewTaxInvoiceBulkIssueService(
+	repo repository.TaxInvoiceBulkIssueRepository,
+	invoiceRepo repository.TaxInvoiceRepository,
+	popbillServices *popbill.Services,
+	concurrency int,
+	maxInvoices int,
+) TaxInvoiceBulkIssueService {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &taxInvoiceBulkIssueService{
+		repo:        repo,
+		invoiceRepo: invoiceRepo,
+		popbill:     popbillServices,
+		concurrency: concurrency,
+		maxInvoices: maxInvoices,
+	}
+}
+
+// Issue records the bulk issuance and issues its invoices
+func (s *taxInvoiceBulkIssueService) Issue(ctx context.Context, companyID, userID uuid.UUID, invoiceIDs []uuid.UUID) (*domain.TaxInvoiceBulkIssue, error) {
+	issue, err := domain.NewTaxInvoiceBulkIssue(companyID, userID, invoiceIDs, s.maxInvoices)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the credentials first so that a company without any records nothing
+	pb, err := s.popbill.ForCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, issue); err != nil {
+		return nil, err
+	}
+	if err := s.run(ctx, pb, issue, userID); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// GetByID returns a bulk issuance with the result of each invoice
+func (s *taxInvoiceBulkIssueService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// Retry issues the failed invoices of a bulk issuance again. Invoices that were
+// issued are left alone, so retrying a completed bulk issuance changes nothing.
+func (s *taxInvoiceBulkIssueService) Retry(ctx context.Context, companyID, userID, id uuid.UUID) (*domain.TaxInvoiceBulkIssue, error) {
+	issue, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue.FailedCount == 0 && issue.Status != domain.TaxInvoiceBulkIssueStatusProcessing {
+		return issue, nil
+	}
+
+	pb, err := s.popbill.ForCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue.Attempts++
+	if err := s.run(ctx, pb, issue, userID); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// run issues the invoices of every item not yet succeeded, concurrency at a
+// time, and stores the results. The request context is detached from its
+// cancellation so that invoices issued at Popbill are always recorded.
+func (s *taxInvoiceBulkIssueService) run(ctx context.Context, pb *popbill.Service, issue *domain.TaxInvoiceBulkIssue, userID uuid.UUID) error {
+	ctx = context.WithoutCancel(ctx)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, s.concurrency)
+	for i := range issue.Items {
+		item := &issue.Items[i]
+		if item.Status == domain.TaxInvoiceBulkIssueItemSucceeded {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			s.issueItem(ctx, pb, item, userID)
+			if err := s.repo.UpdateItem(ctx, item); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("bulk issue item %s: %w", item.ID, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	issue.Summarize(time.Now())
+	if err := s.repo.UpdateSummary(ctx, issue); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// issueItem issues the invoice of one item and records the outcome on it
+func (s *taxInvoiceBulkIssueService) issueItem(ctx context.Context, pb *popbill.Service, item *domain.TaxInvoiceBulkIssueItem, userID uuid.UUID) {
+	item.Attempts++
+	item.UpdatedAt = time.Now()
+
+	invoice, err := s.issueInvoice(ctx, pb, item, userID)
+	if err != nil {
+		item.Fail(bulkIssueErrorCode(err), err)
+		return
+	}
+	item.Succeed(invoice)
+}
+
+// issueInvoice issues a draft sales invoice through Popbill. An invoice already
+// issued through Popbill, by an earlier attempt whose result was lost, is
+// returned as it is instead of being sent again.
+func (s *taxInvoiceBulkIssueService) issueInvoice(ctx context.Context, pb *popbill.Service, item *domain.TaxInvoiceBulkIssueItem, userID uuid.UUID) (*domain.TaxInvoice, error) {
+	invoice, err := s.invoiceRepo.GetByID(ctx, item.CompanyID, item.TaxInvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.ASPProvider == popbill.ASPProvider && invoice.ASPInvoiceID != "" && invoice.Status != domain.TaxInvoiceStatusDraft {
+		return invoice, nil
+	}
+	if !invoice.CanBeIssuedByASP() {
+		return nil, fmt.Errorf("%w: invoice is %s %s", domain.ErrTaxInvoiceNotIssuable, invoice.InvoiceType, invoice.Status)
+	}
+
+	items, err := s.invoiceRepo.ListItems(ctx, item.CompanyID, item.TaxInvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range items {
+		invoice.Items = append(invoice.Items, *line)
+	}
+
+	previous := invoice.Status
+	if _, err := pb.IssueTaxInvoice(ctx, invoice); err != nil {
+		return nil, err
+	}
+	// The Popbill document number is kept on the item even if saving the invoice fails
+	item.ASPInvoiceID = invoice.ASPInvoiceID
+	item.NTSConfirmNumber = invoice.NTSConfirmNumber
+
+	invoice.Items = nil // items are unchanged and saved separately
+	invoice.UpdatedBy = &userID
+	invoice.UpdatedAt = time.Now()
+	if err := s.invoiceRepo.Update(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("invoice was issued as %s but could not be saved: %w", invoice.ASPInvoiceID, err)
+	}
+
+	history := &domain.TaxInvoiceHistory{
+		ID:             uuid.New(),
+		TaxInvoiceID:   invoice.ID,
+		CompanyID:      invoice.CompanyID,
+		PreviousStatus: previous,
+		NewStatus:      invoice.Status,
+		ChangedBy:      &userID,
+		ChangeReason:   "Invoice issued via Popbill",
+		CreatedAt:      time.Now(),
+	}
+	_ = s.invoiceRepo.CreateHistory(ctx, history)
+
+	return invoice, nil
+}
+
+// bulkIssueErrorCode classifies why an invoice could not be issued. Popbill
+// rejections need the invoice to be corrected; unavailability can be retried as is.
+func bulkIssueErrorCode(err error) string {
+	var (
+		pbErr     *popbill.PopbillError
+		statusErr *popbill.StatusError
+		netErr    net.Error
+	)
+	switch {
+	case errors.Is(err, domain.ErrTaxInvoiceNotFound):
+		return domain.BulkIssueErrorNotFound
+	case errors.Is(err, domain.ErrTaxInvoiceNotIssuable):
+		return domain.BulkIssueErrorInvalidStatus
+	case errors.As(err, &pbErr):
+		return domain.BulkIssueErrorPopbillRejected
+	case errors.As(err, &statusErr):
+		if statusErr.StatusCode < 500 && statusErr.StatusCode != http.StatusTooManyRequests {
+			return domain.BulkIssueErrorPopbillRejected
+		}
+		return domain.BulkIssueErrorPopbillUnavailable
+	case errors.Is(err, popbill.ErrCircuitOpen), errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return domain.BulkIssueErrorPopbillUnavailable
+	}
+	return domain.BulkIssueErrorInternal
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/external/popbill"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakePopbillIssuer issues invoices, rejecting the management keys in reject once each
+type fakePopbillIssuer struct {
+	mu     sync.Mutex
+	reject map[string]bool
+	issued []string
+}
+
+func (f *fakePopbillIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/TAXINVOICE/Token" {
+		w.Write([]byte(`{"session_token":"token-1"}`))
+		return
+	}
+
+	var invoice popbill.TaxInvoice
+	_ = json.NewDecoder(r.Body).Decode(&invoice)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.reject[invoice.InvoicerMgtKey] {
+		delete(f.reject, invoice.InvoicerMgtKey)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-11000010,"message":"invalid buyer business number"}`))
+		return
+	}
+	f.issued = append(f.issued, invoice.InvoicerMgtKey)
+	w.Write([]byte(`{"ntsConfirmNum":"NTS-` + invoice.InvoicerMgtKey + `","itemKey":"ITEM-` + invoice.InvoicerMgtKey + `"}`))
+}
+
+type testPopbillCredentials struct{}
+
+func (testPopbillCredentials) PopbillConfig(ctx context.Context, companyID uuid.UUID) (*popbill.Config, error) {
+	return &popbill.Config{LinkID: "KERP", SecretKey: "secret", CorpNum: "1234567890"}, nil
+}
+
+func TestTaxInvoiceBulkIssueService_IssueAndRetry(t *testing.T) {
+	fake := &fakePopbillIssuer{reject: map[string]bool{"INV-2": true}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	repo := new(mocks.MockTaxInvoiceBulkIssueRepository)
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	services := popbill.NewServices(testPopbillCredentials{}, time.Second, &popbill.ClientOptions{BaseURL: server.URL})
+	svc := service.NewTaxInvoiceBulkIssueService(repo, invoiceRepo, services, 2, 10)
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	newInvoice := func(number string, invoiceType domain.TaxInvoiceType) *domain.TaxInvoice {
+		invoice := &domain.TaxInvoice{
+			ID:            uuid.New(),
+			CompanyID:     companyID,
+			InvoiceNumber: number,
+			InvoiceType:   invoiceType,
+			Status:        domain.TaxInvoiceStatusDraft,
+			IssueDate:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+		invoiceRepo.On("GetByID", mock.Anything, companyID, invoice.ID).Return(invoice, nil)
+		return invoice
+	}
+	first := newInvoice("INV-1", domain.TaxInvoiceTypeSales)
+	second := newInvoice("INV-2", domain.TaxInvoiceTypeSales)
+	purchase := newInvoice("INV-3", domain.TaxInvoiceTypePurchase)
+	missing := uuid.New()
+	invoiceRepo.On("GetByID", mock.Anything, companyID, missing).Return(nil, domain.ErrTaxInvoiceNotFound)
+
+	invoiceRepo.On("ListItems", mock.Anything, companyID, mock.Anything).Return([]*domain.TaxInvoiceItem{}, nil)
+	invoiceRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	invoiceRepo.On("CreateHistory", mock.Anything, mock.Anything).Return(nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("UpdateItem", mock.Anything, mock.Anything).Return(nil)
+	repo.On("UpdateSummary", mock.Anything, mock.Anything).Return(nil)
+
+	issue, err := svc.Issue(context.Background(), companyID, userID, []uuid.UUID{first.ID, second.ID, purchase.ID, missing})
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.TaxInvoiceBulkIssueStatusPartiallyFailed, issue.Status)
+	assert.Equal(t, 1, issue.SucceededCount)
+	assert.Equal(t, 3, issue.FailedCount)
+	assert.Equal(t, "NTS-INV-1", issue.Items[0].NTSConfirmNumber)
+	assert.Equal(t, "ITEM-INV-1", issue.Items[0].ASPInvoiceID)
+	assert.Equal(t, domain.BulkIssueErrorPopbillRejected, issue.Items[1].ErrorCode)
+	assert.Equal(t, domain.BulkIssueErrorInvalidStatus, issue.Items[2].ErrorCode)
+	assert.Equal(t, domain.BulkIssueErrorNotFound, issue.Items[3].ErrorCode)
+	assert.Equal(t, domain.TaxInvoiceStatusTransmitted, first.Status)
+	assert.Equal(t, domain.TaxInvoiceStatusDraft, second.Status)
+
+	// The retry sends only the failed invoices; the first is not issued twice
+	repo.On("FindByID", mock.Anything, companyID, issue.ID).Return(issue, nil)
+	retried, err := svc.Retry(context.Background(), companyID, userID, issue.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"INV-1", "INV-2"}, fake.issued)
+	assert.Equal(t, 2, retried.Attempts)
+	assert.Equal(t, 2, retried.SucceededCount)
+	assert.Equal(t, domain.TaxInvoiceBulkIssueItemSucceeded, retried.Items[1].Status)
+	assert.Equal(t, 1, retried.Items[0].Attempts)
+	assert.Equal(t, 2, retried.Items[1].Attempts)
+	assert.Equal(t, domain.BulkIssueErrorInvalidStatus, retried.Items[2].ErrorCode)
+	repo.AssertExpectations(t)
+}
+
+func TestTaxInvoiceBulkIssueService_Issue_Invalid(t *testing.T) {
+	repo := new(mocks.MockTaxInvoiceBulkIssueRepository)
+	services := popbill.NewServices(testPopbillCredentials{}, time.Second, &popbill.ClientOptions{})
+	svc := service.NewTaxInvoiceBulkIssueService(repo, nil, services, 2, 1)
+
+	_, err := svc.Issue(context.Background(), newTestCompanyID(), newTestUserID(), []uuid.UUID{uuid.New(), uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrInvalidTaxInvoiceBulkIssue)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
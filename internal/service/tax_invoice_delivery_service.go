@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// TaxInvoiceSendOptions selects the recipients of an emailed tax invoice
+type TaxInvoiceSendOptions struct {
+	To      string   // defaults to the buyer email of the invoice
+	Cc      []string // optional
+	Message string   // optional note above the standard text
+}
+
+// TaxInvoiceDeliveryService defines the interface for emailing tax invoices to
+// the buyer. Every email is recorded with its Message-ID so that a bounce
+// reported later by the mail provider can be traced back to the invoice.
+type TaxInvoiceDeliveryService interface {
+	// Send renders the invoice as PDF and emails it. A failed send is recorded
+	// as well and returned with the error.
+	Send(ctx context.Context, companyID, userID, invoiceID uuid.UUID, opts TaxInvoiceSendOptions) (*domain.TaxInvoiceDelivery, error)
+	ListDeliveries(ctx context.Context, companyID, invoiceID uuid.UUID) ([]domain.TaxInvoiceDelivery, error)
+
+	// RecordBounce marks the delivery of a bounced email after checking the
+	// webhook token. Bounces of other emails return ErrTaxInvoiceDeliveryNotFound.
+	RecordBounce(ctx context.Context, token string, bounce *domain.EmailBounce) (*domain.TaxInvoiceDelivery, error)
+}
+
+// taxInvoiceDeliveryService implements TaxInvoiceDeliveryService
+type taxInvoiceDeliveryService struct {
+	repo          repository.TaxInvoiceDeliveryRepository
+	invoiceRepo   repository.TaxInvoiceRepository
+	notifications NotificationService
+	from          string
+	bounceSecret  []byte
+}
+
+// NewTaxInvoiceDeliveryService creates a new TaxInvoiceDeliveryService. from is
+// the sender address, used for the domain of the Message-ID. Bounce notices are
+// rejected when bounceSecret is empty.
+func NewTaxInvoiceDeliveryService(
+	repo repository.TaxInvoiceDeliveryRepository,
+	invoiceRepo repository.TaxInvoiceRepository,
+	notifications NotificationService,
+	from string,
+	bounceSecret string,
+) TaxInvoiceDeliveryService {
+	return &taxInvoiceDeliveryService{
+		repo:          repo,
+		invoiceRepo:   invoiceRepo,
+		notifications: notifications,
+		from:          from,
+		bounceSecret:  []byte(bounceSecret),
+	}
+}
+
+// Send emails the invoice PDF and records the delivery on the invoice
+func (s *taxInvoiceDeliveryService) Send(ctx context.Context, companyID, userID, invoiceID uuid.UUID, opts TaxInvoiceSendOptions) (*domain.TaxInvoiceDelivery, error) {
+	if !s.notifications.IsEmailEnabled(ctx) {
+		return nil, provider.ErrProviderUnavailable
+	}
+
+	invoice, err := s.invoiceRepo.GetByID(ctx, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.CanBeSent() {
+		return nil, fmt.Errorf("%w: invoice is %s", domain.ErrTaxInvoiceNotSendable, invoice.Status)
+	}
+	to := strings.TrimSpace(opts.To)
+	if to == "" {
+		to = invoice.BuyerEmail
+	}
+	if to == "" {
+		return nil, domain.ErrTaxInvoiceNoRecipient
+	}
+
+	items, err := s.invoiceRepo.ListItems(ctx, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		invoice.Items = append(invoice.Items, *item)
+	}
+	data, err := renderTaxInvoicePDF(invoice)
+	if err != nil {
+		return nil, fmt.Errorf("render tax invoice: %w", err)
+	}
+
+	now := time.Now()
+	delivery := &domain.TaxInvoiceDelivery{
+		TenantModel:  domain.TenantModel{CompanyID: companyID},
+		TaxInvoiceID: invoice.ID,
+		Recipient:    to,
+		Cc:           strings.Join(opts.Cc, ", "),
+		MessageID:    provider.NewMessageID(s.from),
+		Status:       domain.TaxInvoiceDeliverySent,
+		SentBy:       &userID,
+		SentAt:       now,
+	}
+	sendErr := s.notifications.SendEmail(ctx, taxInvoiceEmail(invoice, delivery, opts, data))
+	if sendErr != nil {
+		delivery.Status = domain.TaxInvoiceDeliveryFailed
+		delivery.Error = sendErr.Error()
+	}
+
+	if err := s.repo.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	reason := "Emailed to " + to
+	if sendErr != nil {
+		reason = fmt.Sprintf("Email to %s failed: %v", to, sendErr)
+	}
+	s.recordHistory(ctx, invoice, &userID, reason, now)
+
+	if sendErr != nil {
+		return delivery, fmt.Errorf("send email: %w", sendErr)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries returns the emails sent for an invoice
+func (s *taxInvoiceDeliveryService) ListDeliveries(ctx context.Context, companyID, invoiceID uuid.UUID) ([]domain.TaxInvoiceDelivery, error) {
+	if _, err := s.invoiceRepo.GetByID(ctx, companyID, invoiceID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByInvoice(ctx, companyID, invoiceID)
+}
+
+// RecordBounce marks the delivery as bounced and notes it in the invoice history.
+// A repeated notice for the same message changes nothing.
+func (s *taxInvoiceDeliveryService) RecordBounce(ctx context.Context, token string, bounce *domain.EmailBounce) (*domain.TaxInvoiceDelivery, error) {
+	if len(s.bounceSecret) == 0 {
+		return nil, domain.ErrEmailBounceWebhookDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), s.bounceSecret) != 1 {
+		return nil, domain.ErrInvalidEmailBounceToken
+	}
+	messageID := domain.NormalizeMessageID(bounce.MessageID)
+	if messageID == "" {
+		return nil, fmt.Errorf("%w: message_id is required", domain.ErrInvalidEmailBounceNotice)
+	}
+
+	delivery, err := s.repo.FindByMessageID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	at := bounce.BouncedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if !delivery.Bounce(bounce.Reason, at) {
+		return delivery, nil
+	}
+	delivery.UpdatedAt = time.Now()
+	if err := s.repo.UpdateBounce(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.invoiceRepo.GetByID(ctx, delivery.CompanyID, delivery.TaxInvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	recipient := bounce.Recipient
+	if recipient == "" {
+		recipient = delivery.Recipient
+	}
+	reason := "Email to " + recipient + " bounced"
+	if bounce.Reason != "" {
+		reason += ": " + bounce.Reason
+	}
+	s.recordHistory(ctx, invoice, nil, reason, at)
+	return delivery, nil
+}
+
+// recordHistory notes a delivery event in the invoice history; the status is unchanged
+func (s *taxInvoiceDeliveryService) recordHistory(ctx context.Context, invoice *domain.TaxInvoice, userID *uuid.UUID, reason string, at time.Time) {
+	_ = s.invoiceRepo.CreateHistory(ctx, &domain.TaxInvoiceHistory{
+		ID:             uuid.New(),
+		TaxInvoiceID:   invoice.ID,
+		CompanyID:      invoice.CompanyID,
+		PreviousStatus: invoice.Status,
+		NewStatus:      invoice.Status,
+		ChangedBy:      userID,
+		ChangeReason:   reason,
+		CreatedAt:      at,
+	})
+}
+
+// taxInvoiceEmail composes the email of an invoice with its PDF attached
+func taxInvoiceEmail(invoice *domain.TaxInvoice, delivery *domain.TaxInvoiceDelivery, opts TaxInvoiceSendOptions, data []byte) *provider.EmailMessage {
+	lines := []string{invoice.BuyerName + " 귀하", ""}
+	if opts.Message != "" {
+		lines = append(lines, opts.Message, "")
+	}
+	lines = append(lines,
+		fmt.Sprintf("%s에서 발행한 전자세금계산서를 첨부와 같이 보내드립니다.", invoice.SupplierName),
+		"",
+		"작성일자: "+invoice.IssueDate.Format("2006-01-02"),
+		"공급가액: "+formatPrintAmount(float64(invoice.SupplyAmount)),
+		"세액: "+formatPrintAmount(float64(invoice.TaxAmount)),
+		"합계금액: "+formatPrintAmount(float64(invoice.TotalAmount)),
+	)
+	if invoice.NTSConfirmNumber != "" {
+		lines = append(lines, "승인번호: "+invoice.NTSConfirmNumber)
+	}
+	lines = append(lines, "", invoice.SupplierName)
+
+	return &provider.EmailMessage{
+		To:       []string{delivery.Recipient},
+		Cc:       opts.Cc,
+		Subject:  fmt.Sprintf("[%s] 전자세금계산서 %s", invoice.SupplierName, invoice.InvoiceNumber),
+		TextBody: strings.Join(lines, "\n"),
+		Attachments: []provider.EmailAttachment{{
+			Filename:    fmt.Sprintf("tax_invoice_%s.pdf", invoice.InvoiceNumber),
+			ContentType: domain.ReportFormatPDF.ContentType(),
+			Data:        data,
+		}},
+		MessageID: delivery.MessageID,
+	}
+}
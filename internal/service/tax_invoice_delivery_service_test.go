@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakeEmailProvider records the messages it is asked to send
+type fakeEmailProvider struct {
+	provider.EmailProvider
+
+	sent []*provider.EmailMessage
+	err  error
+}
+
+func (f *fakeEmailProvider) IsAvailable(ctx context.Context) bool { return true }
+
+func (f *fakeEmailProvider) Send(ctx context.Context, msg *provider.EmailMessage) error {
+	f.sent = append(f.sent, msg)
+	return f.err
+}
+
+func TestTaxInvoiceDeliveryService_SendAndBounce(t *testing.T) {
+	repo := new(mocks.MockTaxInvoiceDeliveryRepository)
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	email := &fakeEmailProvider{}
+	svc := service.NewTaxInvoiceDeliveryService(repo, invoiceRepo, service.NewNotificationService(email), "noreply@kerp.example", "bounce-secret")
+
+	companyID, userID := newTestCompanyID(), newTestUserID()
+	supplyDate := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	invoice := &domain.TaxInvoice{
+		ID:                     uuid.New(),
+		CompanyID:              companyID,
+		InvoiceNumber:          "INV-001",
+		InvoiceType:            domain.TaxInvoiceTypeSales,
+		IssueDate:              supplyDate,
+		Status:                 domain.TaxInvoiceStatusConfirmed,
+		SupplierBusinessNumber: "1234567890",
+		SupplierName:           "공급자",
+		BuyerBusinessNumber:    "2345678901",
+		BuyerName:              "공급받는자",
+		BuyerEmail:             "buyer@example.com",
+		SupplyAmount:           1000000,
+		TaxAmount:              100000,
+		TotalAmount:            1100000,
+		NTSConfirmNumber:       "20240304-41000000-00000001",
+	}
+	invoiceRepo.On("GetByID", mock.Anything, companyID, invoice.ID).Return(invoice, nil)
+	invoiceRepo.On("ListItems", mock.Anything, companyID, invoice.ID).Return([]*domain.TaxInvoiceItem{
+		{SupplyDate: &supplyDate, Description: "컨설팅", Quantity: 1, UnitPrice: 1000000, Amount: 1000000, TaxAmount: 100000},
+	}, nil)
+	invoiceRepo.On("CreateHistory", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	var stored *domain.TaxInvoiceDelivery
+	repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.TaxInvoiceDelivery)
+	}).Return(nil).Once()
+
+	delivery, err := svc.Send(context.Background(), companyID, userID, invoice.ID, service.TaxInvoiceSendOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, email.sent, 1)
+	msg := email.sent[0]
+	assert.Equal(t, []string{"buyer@example.com"}, msg.To)
+	assert.Equal(t, delivery.MessageID, msg.MessageID)
+	assert.Contains(t, delivery.MessageID, "@kerp.example>")
+	require.Len(t, msg.Attachments, 1)
+	assert.True(t, bytes.HasPrefix(msg.Attachments[0].Data, []byte("%PDF-")))
+	assert.Equal(t, domain.TaxInvoiceDeliverySent, stored.Status)
+
+	// The provider reports the bounce without angle brackets; a repeated notice is ignored
+	repo.On("FindByMessageID", mock.Anything, delivery.MessageID).Return(stored, nil).Twice()
+	repo.On("UpdateBounce", mock.Anything, stored).Return(nil).Once()
+	bounce := &domain.EmailBounce{MessageID: delivery.MessageID[1 : len(delivery.MessageID)-1], Reason: "550 mailbox unavailable"}
+
+	_, err = svc.RecordBounce(context.Background(), "wrong", bounce)
+	assert.ErrorIs(t, err, domain.ErrInvalidEmailBounceToken)
+
+	_, err = svc.RecordBounce(context.Background(), "bounce-secret", bounce)
+	require.NoError(t, err)
+	_, err = svc.RecordBounce(context.Background(), "bounce-secret", bounce)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.TaxInvoiceDeliveryBounced, stored.Status)
+	assert.Equal(t, "550 mailbox unavailable", stored.BounceReason)
+	repo.AssertExpectations(t)
+	invoiceRepo.AssertExpectations(t)
+}
+
+func TestTaxInvoiceDeliveryService_Send_Draft(t *testing.T) {
+	invoiceRepo := new(mocks.MockTaxInvoiceRepository)
+	repo := new(mocks.MockTaxInvoiceDeliveryRepository)
+	svc := service.NewTaxInvoiceDeliveryService(repo, invoiceRepo, service.NewNotificationService(&fakeEmailProvider{}), "noreply@kerp.example", "")
+
+	companyID := newTestCompanyID()
+	invoice := &domain.TaxInvoice{ID: uuid.New(), CompanyID: companyID, Status: domain.TaxInvoiceStatusDraft, BuyerEmail: "buyer@example.com"}
+	invoiceRepo.On("GetByID", mock.Anything, companyID, invoice.ID).Return(invoice, nil)
+
+	_, err := svc.Send(context.Background(), companyID, newTestUserID(), invoice.ID, service.TaxInvoiceSendOptions{})
+	assert.ErrorIs(t, err, domain.ErrTaxInvoiceNotSendable)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
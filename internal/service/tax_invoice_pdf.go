@@ -0,0 +1,318 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+)
+
+// Tax invoice page layout (points, A4 portrait), following the standard
+// 전자세금계산서 form: title, the supplier and buyer side by side, the amounts,
+// the items and the total.
+const (
+	taxPrintTitleY     = 70.0
+	taxPrintPartyTop   = 104.0
+	taxPrintRowH       = 20.0
+	taxPrintSideW      = 18.0
+	taxPrintLabelW     = 50.0
+	taxPrintHalfW      = printContentWidth / 2
+	taxPrintItemHdrH   = 18.0
+	taxPrintItemRowH   = 18.0
+	taxPrintMaxItems   = 16
+	taxPrintFooterY    = pdf.A4Height - 40
+	taxPrintPartyRows  = 5
+	taxPrintAmountsTop = taxPrintPartyTop + taxPrintPartyRows*taxPrintRowH + 10
+)
+
+// taxPrintParty is one side of the invoice (공급자 or 공급받는자)
+type taxPrintParty struct {
+	title          string
+	businessNumber string
+	name           string
+	ceoName        string
+	address        string
+	businessType   string
+	businessItem   string
+	email          string
+}
+
+// renderTaxInvoicePDF lays out the invoice on a single page. Items beyond what
+// the page holds are summarized on the last line as "외 N건", as on the paper form.
+func renderTaxInvoicePDF(invoice *domain.TaxInvoice) ([]byte, error) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	page := doc.AddPage()
+	page.SetLineWidth(0.5)
+
+	drawTaxInvoiceTitle(page, invoice)
+
+	supplier := taxPrintParty{
+		title:          "공급자",
+		businessNumber: formatBusinessNumber(invoice.SupplierBusinessNumber),
+		name:           invoice.SupplierName,
+		ceoName:        invoice.SupplierCEOName,
+		address:        invoice.SupplierAddress,
+		businessType:   invoice.SupplierBusinessType,
+		businessItem:   invoice.SupplierBusinessItem,
+		email:          invoice.SupplierEmail,
+	}
+	buyer := taxPrintParty{
+		title:          "공급받는자",
+		businessNumber: formatBusinessNumber(invoice.BuyerBusinessNumber),
+		name:           invoice.BuyerName,
+		ceoName:        invoice.BuyerCEOName,
+		address:        invoice.BuyerAddress,
+		businessType:   invoice.BuyerBusinessType,
+		businessItem:   invoice.BuyerBusinessItem,
+		email:          invoice.BuyerEmail,
+	}
+	drawTaxInvoiceParty(page, supplier, printMarginX)
+	drawTaxInvoiceParty(page, buyer, printMarginX+taxPrintHalfW)
+
+	y := drawTaxInvoiceAmounts(page, invoice, taxPrintAmountsTop)
+	y = drawTaxInvoiceItems(page, invoice.Items, y+10)
+	y = drawTaxInvoiceTotal(page, invoice, y+10)
+
+	if invoice.NTSConfirmNumber != "" {
+		page.Text(printMarginX, y+18, 8, "본 세금계산서는 국세청에 전송된 전자세금계산서이며 홈택스에서 조회할 수 있습니다.")
+	}
+	page.Line(printMarginX, taxPrintFooterY-12, printRight, taxPrintFooterY-12)
+	page.TextRight(printRight, taxPrintFooterY, 8, "관리번호 "+invoice.InvoiceNumber)
+
+	return doc.Bytes()
+}
+
+// drawTaxInvoiceTitle draws the title and the NTS approval number box
+func drawTaxInvoiceTitle(page *pdf.Page, invoice *domain.TaxInvoice) {
+	title := "전자세금계산서"
+	if invoice.AmendmentReason != 0 {
+		title = "수정전자세금계산서"
+	}
+	page.TextCenter(pdf.A4Width/2, taxPrintTitleY, 20, spaceOut(title))
+
+	boxW := 200.0
+	x := printRight - boxW
+	page.FillRect(x, 36, taxPrintLabelW, taxPrintRowH, 0.92)
+	page.Rect(x, 36, taxPrintLabelW, taxPrintRowH)
+	page.Rect(x+taxPrintLabelW, 36, boxW-taxPrintLabelW, taxPrintRowH)
+	page.TextCenter(x+taxPrintLabelW/2, 36+13.5, 9, "승인번호")
+	page.Text(x+taxPrintLabelW+5, 36+13.5, 9, pdf.Truncate(blankDash(invoice.NTSConfirmNumber), 9, boxW-taxPrintLabelW-10))
+}
+
+// drawTaxInvoiceParty draws one party box: a vertical title and five rows of details
+func drawTaxInvoiceParty(page *pdf.Page, party taxPrintParty, x float64) {
+	top := taxPrintPartyTop
+	height := taxPrintPartyRows * taxPrintRowH
+
+	page.FillRect(x, top, taxPrintSideW, height, 0.92)
+	page.Rect(x, top, taxPrintSideW, height)
+	runes := []rune(party.title)
+	lineH := 13.0
+	start := top + (height-float64(len(runes))*lineH)/2 + 10
+	for i, r := range runes {
+		page.TextCenter(x+taxPrintSideW/2, start+float64(i)*lineH, 9, string(r))
+	}
+
+	x += taxPrintSideW
+	width := taxPrintHalfW - taxPrintSideW
+	halfValueW := (width - 2*taxPrintLabelW) / 2
+
+	rows := [][]string{
+		{"등록번호", party.businessNumber},
+		{"상호", party.name, "성명", party.ceoName},
+		{"사업장주소", party.address},
+		{"업태", party.businessType, "종목", party.businessItem},
+		{"이메일", party.email},
+	}
+	for i, row := range rows {
+		y := top + float64(i)*taxPrintRowH
+		if len(row) == 2 {
+			drawTaxPrintField(page, x, y, taxPrintLabelW, width-taxPrintLabelW, row[0], row[1])
+			continue
+		}
+		drawTaxPrintField(page, x, y, taxPrintLabelW, halfValueW, row[0], row[1])
+		drawTaxPrintField(page, x+taxPrintLabelW+halfValueW, y, taxPrintLabelW, halfValueW, row[2], row[3])
+	}
+}
+
+// drawTaxPrintField draws a shaded label cell followed by its value cell
+func drawTaxPrintField(page *pdf.Page, x, y, labelW, valueW float64, label, value string) {
+	page.FillRect(x, y, labelW, taxPrintRowH, 0.92)
+	page.Rect(x, y, labelW, taxPrintRowH)
+	page.Rect(x+labelW, y, valueW, taxPrintRowH)
+	page.TextCenter(x+labelW/2, y+13.5, 8, label)
+	page.Text(x+labelW+4, y+13.5, 8, pdf.Truncate(value, 8, valueW-8))
+}
+
+// drawTaxInvoiceAmounts draws 작성일자, 공급가액, 세액 and 수정사유 with the 비고 row,
+// returning the y below them
+func drawTaxInvoiceAmounts(page *pdf.Page, invoice *domain.TaxInvoice, y float64) float64 {
+	reason := ""
+	if invoice.AmendmentReason != 0 {
+		reason = invoice.AmendmentReason.Label()
+	}
+	columns := []printColumn{
+		{label: "작성일자", width: 100},
+		{label: "공급가액", width: 130, right: true},
+		{label: "세액", width: 130, right: true},
+		{label: "수정사유"},
+	}
+	fillColumnWidths(columns)
+	drawTaxPrintHeader(page, columns, y)
+	y += taxPrintItemHdrH
+
+	values := []string{
+		invoice.IssueDate.Format("2006-01-02"),
+		formatPrintAmount(float64(invoice.SupplyAmount)),
+		formatPrintAmount(float64(invoice.TaxAmount)),
+		reason,
+	}
+	drawTaxPrintRow(page, columns, values, y)
+	y += taxPrintItemRowH
+
+	remarks := invoice.Remarks
+	if invoice.OriginalNTSConfirmNumber != "" {
+		remarks = strings.TrimSpace("당초승인번호 " + invoice.OriginalNTSConfirmNumber + "  " + remarks)
+	}
+	labelW := columns[0].width
+	page.FillRect(printMarginX, y, labelW, taxPrintItemRowH, 0.92)
+	page.Rect(printMarginX, y, labelW, taxPrintItemRowH)
+	page.Rect(printMarginX+labelW, y, printContentWidth-labelW, taxPrintItemRowH)
+	page.TextCenter(printMarginX+labelW/2, y+12.5, 9, "비고")
+	page.Text(printMarginX+labelW+4, y+12.5, 8, pdf.Truncate(remarks, 8, printContentWidth-labelW-8))
+	return y + taxPrintItemRowH
+}
+
+// drawTaxInvoiceItems draws the items table, returning the y below the last row
+func drawTaxInvoiceItems(page *pdf.Page, items []domain.TaxInvoiceItem, y float64) float64 {
+	columns := []printColumn{
+		{label: "월", width: 24},
+		{label: "일", width: 24},
+		{label: "품목"},
+		{label: "규격", width: 55},
+		{label: "수량", width: 45, right: true},
+		{label: "단가", width: 65, right: true},
+		{label: "공급가액", width: 75, right: true},
+		{label: "세액", width: 65, right: true},
+		{label: "비고", width: 50},
+	}
+	fillColumnWidths(columns)
+	drawTaxPrintHeader(page, columns, y)
+	y += taxPrintItemHdrH
+
+	shown := items
+	if len(items) > taxPrintMaxItems {
+		shown = items[:taxPrintMaxItems-1]
+	}
+	for _, item := range shown {
+		month, day := "", ""
+		if item.SupplyDate != nil {
+			month = strconv.Itoa(int(item.SupplyDate.Month()))
+			day = strconv.Itoa(item.SupplyDate.Day())
+		}
+		drawTaxPrintRow(page, columns, []string{
+			month, day, item.Description, item.Specification,
+			formatPrintQuantity(item.Quantity), formatPrintAmount(item.UnitPrice),
+			formatPrintAmount(float64(item.Amount)), formatPrintAmount(float64(item.TaxAmount)), item.Remarks,
+		}, y)
+		y += taxPrintItemRowH
+	}
+
+	if rest := items[len(shown):]; len(rest) > 0 {
+		var amount, tax int64
+		for _, item := range rest {
+			amount += item.Amount
+			tax += item.TaxAmount
+		}
+		drawTaxPrintRow(page, columns, []string{
+			"", "", fmt.Sprintf("외 %d건", len(rest)), "", "", "",
+			formatPrintAmount(float64(amount)), formatPrintAmount(float64(tax)), "",
+		}, y)
+		y += taxPrintItemRowH
+	}
+
+	// The form always shows at least four item lines
+	for i := len(items); i < 4; i++ {
+		drawTaxPrintRow(page, columns, make([]string, len(columns)), y)
+		y += taxPrintItemRowH
+	}
+	return y
+}
+
+// drawTaxInvoiceTotal draws the 합계금액 line with the payment columns of the form
+func drawTaxInvoiceTotal(page *pdf.Page, invoice *domain.TaxInvoice, y float64) float64 {
+	columns := []printColumn{
+		{label: "합계금액", width: 110, right: true},
+		{label: "현금", width: 75, right: true},
+		{label: "수표", width: 75, right: true},
+		{label: "어음", width: 75, right: true},
+		{label: "외상미수금", width: 80, right: true},
+		{label: ""},
+	}
+	fillColumnWidths(columns)
+	drawTaxPrintHeader(page, columns, y)
+	y += taxPrintItemHdrH
+
+	drawTaxPrintRow(page, columns, []string{formatPrintAmount(float64(invoice.TotalAmount)), "", "", "", "", ""}, y)
+
+	// The last column spans both rows and reads 이 금액을 청구 함
+	last := columns[len(columns)-1]
+	x := printRight - last.width
+	page.FillRect(x, y-taxPrintItemHdrH, last.width, taxPrintItemHdrH+taxPrintItemRowH, 1)
+	page.Rect(x, y-taxPrintItemHdrH, last.width, taxPrintItemHdrH+taxPrintItemRowH)
+	page.TextCenter(x+last.width/2, y+3, 10, "이 금액을 청구 함")
+	return y + taxPrintItemRowH
+}
+
+// fillColumnWidths gives the column without a width the rest of the content width
+func fillColumnWidths(columns []printColumn) {
+	fixed := 0.0
+	for _, c := range columns {
+		fixed += c.width
+	}
+	for i := range columns {
+		if columns[i].width == 0 {
+			columns[i].width = printContentWidth - fixed
+		}
+	}
+}
+
+// drawTaxPrintHeader draws a shaded header row of the columns
+func drawTaxPrintHeader(page *pdf.Page, columns []printColumn, y float64) {
+	x := printMarginX
+	for _, c := range columns {
+		page.FillRect(x, y, c.width, taxPrintItemHdrH, 0.92)
+		page.Rect(x, y, c.width, taxPrintItemHdrH)
+		page.TextCenter(x+c.width/2, y+12.5, 9, c.label)
+		x += c.width
+	}
+}
+
+// drawTaxPrintRow draws one bordered row of the columns
+func drawTaxPrintRow(page *pdf.Page, columns []printColumn, values []string, y float64) {
+	x := printMarginX
+	for i, c := range columns {
+		page.Rect(x, y, c.width, taxPrintItemRowH)
+		text := pdf.Truncate(values[i], 8, c.width-6)
+		if c.right {
+			page.TextRight(x+c.width-3, y+12.5, 8, text)
+		} else {
+			page.Text(x+3, y+12.5, 8, text)
+		}
+		x += c.width
+	}
+}
+
+// formatPrintQuantity formats a quantity without trailing zeros; zero prints blank
+func formatPrintQuantity(quantity float64) string {
+	if quantity == 0 {
+		return ""
+	}
+	if quantity == math.Trunc(quantity) {
+		return formatPrintAmount(quantity)
+	}
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
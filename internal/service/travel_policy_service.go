@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// TravelPolicyUpdate holds the fields of a policy that change; the department
+// it covers does not
+type TravelPolicyUpdate struct {
+	Name             string
+	PerDiemRates     []domain.PerDiemRate
+	ReceiptThreshold int64
+	MileageRate      int64
+	IsActive         bool
+}
+
+// TravelPolicyService defines the interface for travel expense policies
+type TravelPolicyService interface {
+	// Create adds the policy of the company, or of a department overriding it
+	Create(ctx context.Context, policy *domain.TravelPolicy) error
+	Update(ctx context.Context, companyID, id uuid.UUID, update TravelPolicyUpdate) (*domain.TravelPolicy, error)
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TravelPolicy, error)
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.TravelPolicy, error)
+}
+
+// travelPolicyService implements TravelPolicyService
+type travelPolicyService struct {
+	repo           repository.TravelPolicyRepository
+	departmentRepo repository.DepartmentRepository
+}
+
+// NewTravelPolicyService creates a new TravelPolicyService
+func NewTravelPolicyService(repo repository.TravelPolicyRepository, departmentRepo repository.DepartmentRepository) TravelPolicyService {
+	return &travelPolicyService{repo: repo, departmentRepo: departmentRepo}
+}
+
+// Create validates the policy and its department; each department and the
+// company as a whole have one active policy
+func (s *travelPolicyService) Create(ctx context.Context, policy *domain.TravelPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if policy.DepartmentID != nil {
+		if _, err := s.departmentRepo.GetByID(ctx, policy.CompanyID, *policy.DepartmentID); err != nil {
+			return domain.ErrDepartmentNotFound
+		}
+	}
+	policy.IsActive = true
+	if err := s.checkUnique(ctx, policy, nil); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, policy)
+}
+
+// Update changes the rates and thresholds of the policy, renames or
+// deactivates it
+func (s *travelPolicyService) Update(ctx context.Context, companyID, id uuid.UUID, update TravelPolicyUpdate) (*domain.TravelPolicy, error) {
+	policy, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	policy.Name = update.Name
+	policy.PerDiemRates = update.PerDiemRates
+	policy.ReceiptThreshold = update.ReceiptThreshold
+	policy.MileageRate = update.MileageRate
+	policy.IsActive = update.IsActive
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, policy, &policy.ID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Delete deletes a policy; settlements checked against it keep their violations
+func (s *travelPolicyService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.repo.Delete(ctx, companyID, id)
+}
+
+// GetByID returns a policy
+func (s *travelPolicyService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.TravelPolicy, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List lists the policies of the company, the company-wide ones first
+func (s *travelPolicyService) List(ctx context.Context, companyID uuid.UUID) ([]domain.TravelPolicy, error) {
+	return s.repo.List(ctx, companyID)
+}
+
+// checkUnique checks the name of the policy and, when it is active, that no
+// other active policy covers its department
+func (s *travelPolicyService) checkUnique(ctx context.Context, policy *domain.TravelPolicy, excludeID *uuid.UUID) error {
+	exists, err := s.repo.ExistsName(ctx, policy.CompanyID, policy.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrTravelPolicyExists
+	}
+	if !policy.IsActive {
+		return nil
+	}
+	exists, err = s.repo.ExistsActive(ctx, policy.CompanyID, policy.DepartmentID, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrTravelPolicyScopeExists
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/saintgo7/saas-kerp/internal/auth"
 	"github.com/saintgo7/saas-kerp/internal/domain"
 	"github.com/saintgo7/saas-kerp/internal/repository"
 )
@@ -16,6 +17,7 @@ var (
 	ErrUserCannotDeleteSelf  = errors.New("cannot delete your own account")
 	ErrUserCannotDeactivateSelf = errors.New("cannot deactivate your own account")
 	ErrInvalidCurrentPassword = errors.New("invalid current password")
+	ErrUserLastAdmin         = domain.ErrUserLastAdmin
 )
 
 // UserService defines the interface for user business logic
@@ -32,10 +34,21 @@ type UserService interface {
 	// Password management
 	ChangePassword(ctx context.Context, companyID, userID uuid.UUID, currentPassword, newPassword string) error
 	ResetPassword(ctx context.Context, companyID, userID uuid.UUID, newPassword string) error
+	// ForcePasswordReset replaces the password with a temporary one that must be changed at next login
+	ForcePasswordReset(ctx context.Context, companyID, userID uuid.UUID) (string, error)
 
-	// Status management
+	// Signing PIN confirming voucher approval signatures. Setting a new PIN
+	// clears the incorrect PINs counted against the old one.
+	SetSigningPIN(ctx context.Context, companyID, userID uuid.UUID, currentPassword, pin string) error
+	// UnlockSigningPIN clears the incorrect PINs that locked the member's signing
+	UnlockSigningPIN(ctx context.Context, companyID, userID uuid.UUID) error
+
+	// Role and status management. These refuse to leave the company without an active admin.
+	AssignRole(ctx context.Context, companyID, id uuid.UUID, role domain.UserRole) error
 	Activate(ctx context.Context, companyID, id uuid.UUID) error
 	Deactivate(ctx context.Context, companyID, id uuid.UUID) error
+	Lock(ctx context.Context, companyID, id uuid.UUID) error
+	Unlock(ctx context.Context, companyID, id uuid.UUID) error
 
 	// Statistics
 	GetStats(ctx context.Context, companyID uuid.UUID) (*UserStats, error)
@@ -54,12 +67,14 @@ type UserStats struct {
 
 // userServiceImpl implements UserService
 type userServiceImpl struct {
-	repo repository.UserRepository
+	repo             repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	limits           PlanLimits // nil does not limit user creation
 }
 
 // NewUserService creates a new user service
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userServiceImpl{repo: repo}
+func NewUserService(repo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, limits PlanLimits) UserService {
+	return &userServiceImpl{repo: repo, refreshTokenRepo: refreshTokenRepo, limits: limits}
 }
 
 func (s *userServiceImpl) Create(ctx context.Context, user *domain.User) error {
@@ -71,6 +86,11 @@ func (s *userServiceImpl) Create(ctx context.Context, user *domain.User) error {
 	if exists {
 		return ErrUserEmailExists
 	}
+	if s.limits != nil {
+		if err := s.limits.CheckLimit(ctx, user.CompanyID, domain.PlanLimitUsers, 1); err != nil {
+			return err
+		}
+	}
 
 	return s.repo.Create(ctx, user)
 }
@@ -85,12 +105,27 @@ func (s *userServiceImpl) Update(ctx context.Context, user *domain.User) error {
 		return ErrUserEmailExists
 	}
 
+	if user.Role != domain.UserRoleAdmin || user.Status != domain.UserStatusActive {
+		return s.withoutLastAdmin(ctx, user.CompanyID, user.ID, func(repo repository.UserRepository) error {
+			return repo.Update(ctx, user)
+		})
+	}
+
 	return s.repo.Update(ctx, user)
 }
 
 func (s *userServiceImpl) Delete(ctx context.Context, companyID, id uuid.UUID) error {
 	// Note: Caller should check if user is trying to delete themselves
-	return s.repo.Delete(ctx, companyID, id)
+	err := s.withoutLastAdmin(ctx, companyID, id, func(repo repository.UserRepository) error {
+		if _, err := repo.FindByID(ctx, companyID, id); err != nil {
+			return err
+		}
+		return repo.Delete(ctx, companyID, id)
+	})
+	if err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeByUserID(ctx, id)
 }
 
 func (s *userServiceImpl) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.User, error) {
@@ -116,6 +151,7 @@ func (s *userServiceImpl) ChangePassword(ctx context.Context, companyID, userID
 	if err := user.SetPassword(newPassword); err != nil {
 		return err
 	}
+	user.MustChangePassword = false
 
 	return s.repo.Update(ctx, user)
 }
@@ -134,26 +170,140 @@ func (s *userServiceImpl) ResetPassword(ctx context.Context, companyID, userID u
 	return s.repo.Update(ctx, user)
 }
 
-func (s *userServiceImpl) Activate(ctx context.Context, companyID, id uuid.UUID) error {
-	user, err := s.repo.FindByID(ctx, companyID, id)
+func (s *userServiceImpl) SetSigningPIN(ctx context.Context, companyID, userID uuid.UUID, currentPassword, pin string) error {
+	user, err := s.repo.FindByID(ctx, companyID, userID)
 	if err != nil {
 		return err
 	}
 
-	user.Status = domain.UserStatusActive
-	return s.repo.Update(ctx, user)
+	// Re-confirm identity before changing the signing PIN
+	if !user.CheckPassword(currentPassword) {
+		return ErrInvalidCurrentPassword
+	}
+
+	if err := user.SetSigningPIN(pin); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+	return s.repo.ResetSigningPINAttempts(ctx, user.ID)
+}
+
+func (s *userServiceImpl) UnlockSigningPIN(ctx context.Context, companyID, userID uuid.UUID) error {
+	user, err := s.repo.FindMember(ctx, companyID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.ResetSigningPINAttempts(ctx, user.ID)
+}
+
+func (s *userServiceImpl) ForcePasswordReset(ctx context.Context, companyID, userID uuid.UUID) (string, error) {
+	user, err := s.repo.FindByID(ctx, companyID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	temporary, err := auth.GenerateTemporaryPassword()
+	if err != nil {
+		return "", err
+	}
+	if err := user.SetPassword(temporary); err != nil {
+		return "", err
+	}
+	user.MustChangePassword = true
+	if err := s.repo.Update(ctx, user); err != nil {
+		return "", err
+	}
+
+	// End existing sessions so the temporary password is the only way in
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
+		return "", err
+	}
+	return temporary, nil
+}
+
+func (s *userServiceImpl) AssignRole(ctx context.Context, companyID, id uuid.UUID, role domain.UserRole) error {
+	if !role.IsValid() {
+		return domain.ErrInvalidUserRole
+	}
+	assign := func(repo repository.UserRepository) error {
+		user, err := repo.FindByID(ctx, companyID, id)
+		if err != nil {
+			return err
+		}
+		user.Role = role
+		return repo.Update(ctx, user)
+	}
+	if role == domain.UserRoleAdmin {
+		return assign(s.repo)
+	}
+	return s.withoutLastAdmin(ctx, companyID, id, assign)
+}
+
+func (s *userServiceImpl) Activate(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.setStatus(ctx, companyID, id, domain.UserStatusActive)
 }
 
 func (s *userServiceImpl) Deactivate(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.setStatus(ctx, companyID, id, domain.UserStatusInactive)
+}
+
+func (s *userServiceImpl) Lock(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.setStatus(ctx, companyID, id, domain.UserStatusLocked)
+}
+
+func (s *userServiceImpl) Unlock(ctx context.Context, companyID, id uuid.UUID) error {
 	user, err := s.repo.FindByID(ctx, companyID, id)
 	if err != nil {
 		return err
 	}
+	if user.Status != domain.UserStatusLocked {
+		return nil
+	}
 
-	user.Status = domain.UserStatusInactive
+	user.Status = domain.UserStatusActive
 	return s.repo.Update(ctx, user)
 }
 
+// setStatus changes the user status, ending the user's sessions when access is withdrawn
+func (s *userServiceImpl) setStatus(ctx context.Context, companyID, id uuid.UUID, status domain.UserStatus) error {
+	update := func(repo repository.UserRepository) error {
+		user, err := repo.FindByID(ctx, companyID, id)
+		if err != nil {
+			return err
+		}
+		user.Status = status
+		return repo.Update(ctx, user)
+	}
+	if status == domain.UserStatusActive {
+		return update(s.repo)
+	}
+
+	if err := s.withoutLastAdmin(ctx, companyID, id, update); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeByUserID(ctx, id)
+}
+
+// withoutLastAdmin runs change, which withdraws admin access from the user id,
+// in a transaction holding the company's active admins locked. It returns
+// ErrUserLastAdmin if the user is the only active admin left; concurrent
+// changes wait for the lock and count the admins the others left.
+func (s *userServiceImpl) withoutLastAdmin(ctx context.Context, companyID, id uuid.UUID, change func(repo repository.UserRepository) error) error {
+	return s.repo.WithTransaction(ctx, func(repo repository.UserRepository) error {
+		admins, err := repo.LockActiveAdmins(ctx, companyID)
+		if err != nil {
+			return err
+		}
+		if len(admins) == 1 && admins[0] == id {
+			return ErrUserLastAdmin
+		}
+		return change(repo)
+	})
+}
+
 func (s *userServiceImpl) GetStats(ctx context.Context, companyID uuid.UUID) (*UserStats, error) {
 	stats := &UserStats{}
 
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// revokedSessions records the users whose sessions were ended
+type revokedSessions struct {
+	repository.RefreshTokenRepository
+	users []uuid.UUID
+}
+
+func (r *revokedSessions) RevokeByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.users = append(r.users, userID)
+	return nil
+}
+
+func newTestAdmin(companyID uuid.UUID) *domain.User {
+	return &domain.User{
+		TenantModel: domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+		Role:        domain.UserRoleAdmin,
+		Status:      domain.UserStatusActive,
+	}
+}
+
+func TestUserService_LastAdmin(t *testing.T) {
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+
+	removals := []struct {
+		name   string
+		remove func(svc service.UserService, admin *domain.User) error
+	}{
+		{"demote", func(svc service.UserService, admin *domain.User) error {
+			return svc.AssignRole(ctx, companyID, admin.ID, domain.UserRoleUser)
+		}},
+		{"lock", func(svc service.UserService, admin *domain.User) error {
+			return svc.Lock(ctx, companyID, admin.ID)
+		}},
+		{"deactivate", func(svc service.UserService, admin *domain.User) error {
+			return svc.Deactivate(ctx, companyID, admin.ID)
+		}},
+		{"delete", func(svc service.UserService, admin *domain.User) error {
+			return svc.Delete(ctx, companyID, admin.ID)
+		}},
+	}
+
+	for _, removal := range removals {
+		t.Run(removal.name+" refuses the last active admin", func(t *testing.T) {
+			repo, sessions := new(mocks.MockUserRepository), &revokedSessions{}
+			svc := service.NewUserService(repo, sessions, nil)
+			admin := newTestAdmin(companyID)
+
+			repo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+			repo.On("LockActiveAdmins", ctx, companyID).Return([]uuid.UUID{admin.ID}, nil).Once()
+
+			err := removal.remove(svc, admin)
+
+			assert.ErrorIs(t, err, service.ErrUserLastAdmin)
+			repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			assert.Empty(t, sessions.users)
+			repo.AssertExpectations(t)
+		})
+
+		t.Run(removal.name+" goes ahead while another admin remains", func(t *testing.T) {
+			repo, sessions := new(mocks.MockUserRepository), &revokedSessions{}
+			svc := service.NewUserService(repo, sessions, nil)
+			admin := newTestAdmin(companyID)
+
+			repo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+			repo.On("LockActiveAdmins", ctx, companyID).Return([]uuid.UUID{admin.ID, uuid.New()}, nil).Once()
+			repo.On("FindByID", ctx, companyID, admin.ID).Return(admin, nil).Once()
+			repo.On("Update", ctx, admin).Return(nil).Maybe()
+			repo.On("Delete", ctx, companyID, admin.ID).Return(nil).Maybe()
+
+			require.NoError(t, removal.remove(svc, admin))
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("ends the sessions of a locked admin", func(t *testing.T) {
+		repo, sessions := new(mocks.MockUserRepository), &revokedSessions{}
+		svc := service.NewUserService(repo, sessions, nil)
+		admin := newTestAdmin(companyID)
+
+		repo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		repo.On("LockActiveAdmins", ctx, companyID).Return([]uuid.UUID{admin.ID, uuid.New()}, nil).Once()
+		repo.On("FindByID", ctx, companyID, admin.ID).Return(admin, nil).Once()
+		repo.On("Update", ctx, admin).Return(nil).Once()
+
+		require.NoError(t, svc.Lock(ctx, companyID, admin.ID))
+		assert.Equal(t, domain.UserStatusLocked, admin.Status)
+		assert.Equal(t, []uuid.UUID{admin.ID}, sessions.users)
+	})
+
+	t.Run("promotion takes no lock", func(t *testing.T) {
+		repo := new(mocks.MockUserRepository)
+		svc := service.NewUserService(repo, &revokedSessions{}, nil)
+		user := newTestAdmin(companyID)
+		user.Role = domain.UserRoleUser
+
+		repo.On("FindByID", ctx, companyID, user.ID).Return(user, nil).Once()
+		repo.On("Update", ctx, user).Return(nil).Once()
+
+		require.NoError(t, svc.AssignRole(ctx, companyID, user.ID, domain.UserRoleAdmin))
+		assert.Equal(t, domain.UserRoleAdmin, user.Role)
+		repo.AssertNotCalled(t, "LockActiveAdmins", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_UnlockSigningPIN(t *testing.T) {
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+	repo := new(mocks.MockUserRepository)
+	svc := service.NewUserService(repo, &revokedSessions{}, nil)
+	user := newTestAdmin(companyID)
+	user.SigningPINAttempts = domain.MaxSigningPINAttempts
+
+	repo.On("FindMember", ctx, companyID, user.ID).Return(user, nil).Once()
+	repo.On("ResetSigningPINAttempts", ctx, user.ID).Return(nil).Once()
+
+	require.NoError(t, svc.UnlockSigningPIN(ctx, companyID, user.ID))
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// anomalyScoringBatchSize bounds the vouchers scored per run
+const anomalyScoringBatchSize = 500
+
+// VoucherAnomalyService defines the interface for anomaly scoring of postings
+type VoucherAnomalyService interface {
+	// ScorePending scores the posted vouchers not yet scored and returns how many
+	// were scored
+	ScorePending(ctx context.Context) (int, error)
+
+	// Score flags one posted voucher against the usual amounts of its accounts
+	Score(ctx context.Context, voucher *domain.Voucher) ([]domain.VoucherAnomalyFlag, error)
+
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAnomalyFlag, error)
+	List(ctx context.Context, filter repository.VoucherAnomalyFilter) ([]domain.VoucherAnomalyFlag, int64, error)
+
+	// Review dismisses or confirms an open flag
+	Review(ctx context.Context, companyID, userID, id uuid.UUID, status domain.AnomalyFlagStatus, note string) (*domain.VoucherAnomalyFlag, error)
+}
+
+// voucherAnomalyService implements VoucherAnomalyService
+type voucherAnomalyService struct {
+	repo repository.VoucherAnomalyRepository
+}
+
+// NewVoucherAnomalyService creates a new VoucherAnomalyService
+func NewVoucherAnomalyService(repo repository.VoucherAnomalyRepository) VoucherAnomalyService {
+	return &voucherAnomalyService{repo: repo}
+}
+
+// ScorePending scores a batch of unscored vouchers
+func (s *voucherAnomalyService) ScorePending(ctx context.Context) (int, error) {
+	vouchers, err := s.repo.ListUnscored(ctx, anomalyScoringBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	scored := 0
+	for i := range vouchers {
+		if _, err := s.Score(ctx, &vouchers[i]); err != nil {
+			return scored, fmt.Errorf("voucher %s: %w", vouchers[i].ID, err)
+		}
+		scored++
+	}
+	return scored, nil
+}
+
+// Score compares the entries with the postings of the lookback period before
+// the voucher date and stores the flags
+func (s *voucherAnomalyService) Score(ctx context.Context, voucher *domain.Voucher) ([]domain.VoucherAnomalyFlag, error) {
+	seen := make(map[uuid.UUID]bool)
+	var accountIDs []uuid.UUID
+	for _, e := range voucher.Entries {
+		if !seen[e.AccountID] {
+			seen[e.AccountID] = true
+			accountIDs = append(accountIDs, e.AccountID)
+		}
+	}
+
+	from := voucher.VoucherDate.AddDate(0, -domain.AnomalyStatsLookbackMonths, 0)
+	stats, err := s.repo.AccountAmountStats(ctx, voucher.CompanyID, accountIDs, from, voucher.VoucherDate)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := domain.ScoreVoucherAnomalies(voucher, stats)
+	if err := s.repo.SaveScore(ctx, voucher, flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// GetByID returns an anomaly flag with its voucher
+func (s *voucherAnomalyService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherAnomalyFlag, error) {
+	return s.repo.GetByID(ctx, companyID, id)
+}
+
+// List returns the review worklist, highest scores first
+func (s *voucherAnomalyService) List(ctx context.Context, filter repository.VoucherAnomalyFilter) ([]domain.VoucherAnomalyFlag, int64, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Review records the review of a flag
+func (s *voucherAnomalyService) Review(ctx context.Context, companyID, userID, id uuid.UUID, status domain.AnomalyFlagStatus, note string) (*domain.VoucherAnomalyFlag, error) {
+	flag, err := s.repo.GetByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := flag.Review(userID, status, note); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateReview(ctx, flag); err != nil {
+		return nil, err
+	}
+	return flag, nil
+}
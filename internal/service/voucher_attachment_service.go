@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/filetype"
+	"github.com/saintgo7/saas-kerp/internal/imaging"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// AttachmentUpload is an uploaded voucher attachment
+type AttachmentUpload struct {
+	CompanyID   uuid.UUID
+	VoucherID   uuid.UUID
+	UserID      uuid.UUID
+	FileName    string
+	ContentType string // as declared by the client; the stored type is sniffed from Data
+	Data        []byte
+	IPAddress   string
+}
+
+// VoucherAttachmentService defines the interface for voucher attachments.
+// Files pass through the virus scanner, when one is configured, before they
+// are stored or first served; infected files are quarantined. Only documents
+// and images whose content matches their extension are accepted, and images
+// are stored with a thumbnail.
+type VoucherAttachmentService interface {
+	Upload(ctx context.Context, upload *AttachmentUpload) (*domain.VoucherAttachment, error)
+	List(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherAttachment, error)
+	// Download returns the attachment with its content; ipAddress is recorded if it is quarantined
+	Download(ctx context.Context, companyID, voucherID, id uuid.UUID, ipAddress string) (*domain.VoucherAttachment, error)
+	// Thumbnail returns the attachment with its JPEG preview but without its content
+	Thumbnail(ctx context.Context, companyID, voucherID, id uuid.UUID) (*domain.VoucherAttachment, error)
+	Delete(ctx context.Context, companyID, voucherID, id uuid.UUID) error
+}
+
+// voucherAttachmentService implements VoucherAttachmentService
+type voucherAttachmentService struct {
+	repo          repository.VoucherAttachmentRepository
+	voucherRepo   repository.VoucherRepository
+	userRepo      repository.UserRepository
+	scanner       provider.VirusScanner
+	converter     provider.ImageConverter
+	notifications NotificationService
+	logger        *zap.Logger
+	maxFileSize   int64
+	thumbnailSize int        // longest side in pixels; 0 disables thumbnails
+	limits        PlanLimits // nil does not limit attachment storage
+}
+
+// NewVoucherAttachmentService creates a new VoucherAttachmentService.
+// scanner may be nil, in which case attachments are stored unscanned.
+// converter may be nil, in which case HEIC photos are stored as uploaded, without thumbnail.
+// limits may be nil, in which case storage is not limited by the plan.
+func NewVoucherAttachmentService(
+	repo repository.VoucherAttachmentRepository,
+	voucherRepo repository.VoucherRepository,
+	userRepo repository.UserRepository,
+	scanner provider.VirusScanner,
+	converter provider.ImageConverter,
+	notifications NotificationService,
+	logger *zap.Logger,
+	maxFileSize int64,
+	thumbnailSize int,
+	limits PlanLimits,
+) VoucherAttachmentService {
+	return &voucherAttachmentService{
+		repo:          repo,
+		voucherRepo:   voucherRepo,
+		userRepo:      userRepo,
+		scanner:       scanner,
+		converter:     converter,
+		notifications: notifications,
+		logger:        logger,
+		maxFileSize:   maxFileSize,
+		thumbnailSize: thumbnailSize,
+		limits:        limits,
+	}
+}
+
+// Upload scans and stores an attachment of a voucher. The file is scanned
+// before its type is checked so that malware is quarantined and reported
+// whatever its name.
+func (s *voucherAttachmentService) Upload(ctx context.Context, upload *AttachmentUpload) (*domain.VoucherAttachment, error) {
+	if len(upload.Data) == 0 {
+		return nil, domain.ErrAttachmentEmpty
+	}
+	if int64(len(upload.Data)) > s.maxFileSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+	if _, err := s.voucherRepo.FindByID(ctx, upload.CompanyID, upload.VoucherID); err != nil {
+		return nil, err
+	}
+	if s.limits != nil {
+		if err := s.limits.CheckLimit(ctx, upload.CompanyID, domain.PlanLimitStorage, int64(len(upload.Data))); err != nil {
+			return nil, err
+		}
+	}
+
+	userID := upload.UserID
+	attachment := &domain.VoucherAttachment{
+		VoucherID:  upload.VoucherID,
+		CompanyID:  upload.CompanyID,
+		FileName:   upload.FileName,
+		FileSize:   int64(len(upload.Data)),
+		FileType:   upload.ContentType,
+		FileData:   upload.Data,
+		ScanStatus: domain.AttachmentScanUnscanned,
+		UploadedAt: time.Now(),
+		UploadedBy: &userID,
+	}
+
+	if err := s.scan(ctx, attachment, upload.IPAddress, false); err != nil {
+		return nil, err
+	}
+	fileType, err := filetype.Detect(upload.FileName, upload.Data)
+	switch {
+	case errors.Is(err, filetype.ErrNotAllowed):
+		return nil, domain.ErrAttachmentTypeNotAllowed
+	case errors.Is(err, filetype.ErrExtensionMismatch):
+		return nil, domain.ErrAttachmentTypeMismatch
+	}
+	attachment.FileType = fileType
+	if fileType == filetype.HEIC {
+		s.convertHEIC(ctx, attachment)
+	}
+	if filetype.IsImage(attachment.FileType) {
+		s.renderThumbnail(attachment)
+	}
+
+	if err := s.repo.Create(ctx, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// List returns the attachments of a voucher without their content
+func (s *voucherAttachmentService) List(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherAttachment, error) {
+	if _, err := s.voucherRepo.FindByID(ctx, companyID, voucherID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByVoucher(ctx, companyID, voucherID)
+}
+
+// Download returns an attachment, scanning it first if it was stored unscanned
+func (s *voucherAttachmentService) Download(ctx context.Context, companyID, voucherID, id uuid.UUID, ipAddress string) (*domain.VoucherAttachment, error) {
+	attachment, err := s.repo.FindByID(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.VoucherID != voucherID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+
+	if attachment.ScanStatus == domain.AttachmentScanUnscanned && s.scanner != nil {
+		if err := s.scan(ctx, attachment, ipAddress, true); err != nil {
+			return nil, err
+		}
+		if err := s.repo.UpdateScan(ctx, attachment); err != nil {
+			return nil, err
+		}
+	}
+	return attachment, nil
+}
+
+// Thumbnail returns the thumbnail of an image attachment
+func (s *voucherAttachmentService) Thumbnail(ctx context.Context, companyID, voucherID, id uuid.UUID) (*domain.VoucherAttachment, error) {
+	attachment, err := s.repo.FindThumbnail(ctx, companyID, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.VoucherID != voucherID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	if !attachment.HasThumbnail {
+		return nil, domain.ErrAttachmentNoThumbnail
+	}
+	return attachment, nil
+}
+
+// Delete removes an attachment of a voucher
+func (s *voucherAttachmentService) Delete(ctx context.Context, companyID, voucherID, id uuid.UUID) error {
+	attachments, err := s.repo.FindByVoucher(ctx, companyID, voucherID)
+	if err != nil {
+		return err
+	}
+	for _, a := range attachments {
+		if a.ID == id {
+			return s.repo.Delete(ctx, companyID, id)
+		}
+	}
+	return domain.ErrAttachmentNotFound
+}
+
+// convertHEIC replaces a HEIC photo with its JPEG conversion, which browsers
+// can display and the thumbnailer can decode. The photo is kept as uploaded
+// when no converter is configured or the conversion fails.
+func (s *voucherAttachmentService) convertHEIC(ctx context.Context, attachment *domain.VoucherAttachment) {
+	if s.converter == nil {
+		return
+	}
+	data, err := s.converter.ConvertToJPEG(ctx, attachment.FileData)
+	if err != nil {
+		s.logger.Warn("failed to convert HEIC attachment",
+			zap.String("company_id", attachment.CompanyID.String()),
+			zap.String("file_name", attachment.FileName),
+			zap.Error(err))
+		return
+	}
+
+	attachment.FileName = strings.TrimSuffix(attachment.FileName, filepath.Ext(attachment.FileName)) + ".jpg"
+	attachment.FileType = filetype.JPEG
+	attachment.FileData = data
+	attachment.FileSize = int64(len(data))
+}
+
+// renderThumbnail stores the preview of an image attachment. Formats the
+// thumbnailer cannot decode (WebP, TIFF, BMP, unconverted HEIC) get none.
+func (s *voucherAttachmentService) renderThumbnail(attachment *domain.VoucherAttachment) {
+	if s.thumbnailSize <= 0 {
+		return
+	}
+	data, err := imaging.Thumbnail(attachment.FileData, s.thumbnailSize)
+	if err != nil {
+		if !errors.Is(err, imaging.ErrUnsupportedImage) {
+			s.logger.Warn("failed to render attachment thumbnail",
+				zap.String("company_id", attachment.CompanyID.String()),
+				zap.String("file_name", attachment.FileName),
+				zap.Error(err))
+		}
+		return
+	}
+	attachment.ThumbnailData = data
+	attachment.HasThumbnail = true
+}
+
+// scan runs the virus scanner on the attachment and records a clean verdict.
+// Infected files are quarantined, removing the stored attachment when stored is
+// true, and ErrAttachmentInfected is returned with the detected signature.
+// Files that cannot be scanned are rejected rather than treated as clean.
+func (s *voucherAttachmentService) scan(ctx context.Context, attachment *domain.VoucherAttachment, ipAddress string, stored bool) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	result, err := s.scanner.Scan(ctx, attachment.FileName, attachment.FileData)
+	if err != nil {
+		s.logger.Error("attachment virus scan failed",
+			zap.String("company_id", attachment.CompanyID.String()),
+			zap.String("voucher_id", attachment.VoucherID.String()),
+			zap.String("file_name", attachment.FileName),
+			zap.Error(err))
+		return domain.ErrAttachmentScanUnavailable
+	}
+	if !result.Infected {
+		attachment.MarkScanned(string(s.scanner.Type()), time.Now())
+		return nil
+	}
+
+	var attachmentID *uuid.UUID
+	if stored {
+		attachmentID = &attachment.ID
+	}
+	quarantined := domain.QuarantineAttachment(attachment, result.Signature, string(s.scanner.Type()), ipAddress)
+	if err := s.repo.Quarantine(ctx, quarantined, attachmentID); err != nil {
+		return err
+	}
+
+	s.logger.Warn("security event: infected attachment quarantined",
+		zap.String("event", "attachment_infected"),
+		zap.String("company_id", attachment.CompanyID.String()),
+		zap.String("voucher_id", attachment.VoucherID.String()),
+		zap.String("quarantine_id", quarantined.ID.String()),
+		zap.Stringp("uploaded_by", uuidStringp(attachment.UploadedBy)),
+		zap.String("ip_address", ipAddress),
+		zap.String("file_name", attachment.FileName),
+		zap.String("signature", result.Signature))
+	notifyQuarantine(ctx, s.notifications, s.userRepo, s.logger, quarantined, "전표 첨부파일")
+
+	return fmt.Errorf("%w (%s)", domain.ErrAttachmentInfected, result.Signature)
+}
+
+// notifyQuarantine emails the company's active administrators about a
+// quarantined file; source names where it was uploaded, e.g. 전표 첨부파일.
+// Delivery problems are logged; they do not change the outcome of the upload.
+func notifyQuarantine(
+	ctx context.Context,
+	notifications NotificationService,
+	userRepo repository.UserRepository,
+	logger *zap.Logger,
+	file *domain.QuarantinedFile,
+	source string,
+) {
+	if !notifications.IsEmailEnabled(ctx) {
+		return
+	}
+
+	role, status := domain.UserRoleAdmin, domain.UserStatusActive
+	admins, _, err := userRepo.FindAll(ctx, repository.UserFilter{
+		CompanyID: file.CompanyID,
+		Role:      &role,
+		Status:    &status,
+	})
+	if err != nil {
+		logger.Error("failed to find administrators for quarantine notice", zap.Error(err))
+		return
+	}
+	var to []string
+	for _, admin := range admins {
+		to = append(to, admin.Email)
+	}
+	if len(to) == 0 {
+		return
+	}
+
+	uploader := "-"
+	if file.UploadedBy != nil {
+		uploader = file.UploadedBy.String()
+		if user, err := userRepo.FindByID(ctx, file.CompanyID, *file.UploadedBy); err == nil {
+			uploader = fmt.Sprintf("%s (%s)", user.Name, user.Email)
+		}
+	}
+
+	err = notifications.SendEmail(ctx, &provider.EmailMessage{
+		To:      to,
+		Subject: "[K-ERP] 악성코드가 포함된 첨부파일 차단",
+		TextBody: strings.Join([]string{
+			source + "에서 악성코드가 발견되어 격리했습니다.",
+			"",
+			"파일명: " + file.FileName,
+			"탐지명: " + file.Signature,
+			"업로드 사용자: " + uploader,
+			"IP 주소: " + file.IPAddress,
+			"탐지 시각: " + file.DetectedAt.Format("2006-01-02 15:04:05"),
+			"격리 ID: " + file.ID.String(),
+			"",
+			"업로드한 사용자의 PC 점검을 권장합니다.",
+		}, "\n"),
+	})
+	if err != nil && !errors.Is(err, provider.ErrProviderUnavailable) {
+		logger.Error("failed to send quarantine notice", zap.Error(err))
+	}
+}
+
+func uuidStringp(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
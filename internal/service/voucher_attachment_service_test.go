@@ -0,0 +1,268 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/mocks"
+	"github.com/saintgo7/saas-kerp/internal/provider"
+	"github.com/saintgo7/saas-kerp/internal/service"
+)
+
+// fakeVirusScanner returns a fixed verdict
+type fakeVirusScanner struct {
+	result *provider.ScanResult
+	err    error
+	calls  int
+}
+
+func (s *fakeVirusScanner) Type() provider.ProviderType                         { return provider.ProviderTypeClamAV }
+func (s *fakeVirusScanner) Name() string                                        { return "Fake Scanner" }
+func (s *fakeVirusScanner) IsAvailable(ctx context.Context) bool                { return true }
+func (s *fakeVirusScanner) Health(ctx context.Context) *provider.ProviderHealth { return nil }
+func (s *fakeVirusScanner) Priority() int                                       { return 0 }
+func (s *fakeVirusScanner) Close() error                                        { return nil }
+
+func (s *fakeVirusScanner) Scan(ctx context.Context, name string, data []byte) (*provider.ScanResult, error) {
+	s.calls++
+	return s.result, s.err
+}
+
+// fakeImageConverter converts every image to a fixed JPEG
+type fakeImageConverter struct {
+	jpeg []byte
+}
+
+func (c *fakeImageConverter) Type() provider.ProviderType                         { return provider.ProviderTypeMagick }
+func (c *fakeImageConverter) Name() string                                        { return "Fake Converter" }
+func (c *fakeImageConverter) IsAvailable(ctx context.Context) bool                { return true }
+func (c *fakeImageConverter) Health(ctx context.Context) *provider.ProviderHealth { return nil }
+func (c *fakeImageConverter) Priority() int                                       { return 0 }
+func (c *fakeImageConverter) Close() error                                        { return nil }
+
+func (c *fakeImageConverter) ConvertToJPEG(ctx context.Context, data []byte) ([]byte, error) {
+	return c.jpeg, nil
+}
+
+func newTestAttachmentService(scanner provider.VirusScanner) (*mocks.MockVoucherAttachmentRepository, *mocks.MockVoucherRepository, service.VoucherAttachmentService) {
+	repo := new(mocks.MockVoucherAttachmentRepository)
+	voucherRepo := new(mocks.MockVoucherRepository)
+	svc := service.NewVoucherAttachmentService(repo, voucherRepo, nil, scanner, nil, service.NewNotificationService(nil), zap.NewNop(), 1024, 0, nil)
+	return repo, voucherRepo, svc
+}
+
+// newTestImageAttachmentService renders 64 pixel thumbnails and stores files up to 1MB
+func newTestImageAttachmentService(converter provider.ImageConverter) (*mocks.MockVoucherAttachmentRepository, *mocks.MockVoucherRepository, service.VoucherAttachmentService) {
+	repo := new(mocks.MockVoucherAttachmentRepository)
+	voucherRepo := new(mocks.MockVoucherRepository)
+	svc := service.NewVoucherAttachmentService(repo, voucherRepo, nil, nil, converter, service.NewNotificationService(nil), zap.NewNop(), 1<<20, 64, nil)
+	return repo, voucherRepo, svc
+}
+
+func testImage(t *testing.T, encode func(*bytes.Buffer, image.Image) error) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, encode(&buf, image.NewRGBA(image.Rect(0, 0, 400, 300))))
+	return buf.Bytes()
+}
+
+func newTestUpload(data string) *service.AttachmentUpload {
+	return &service.AttachmentUpload{
+		CompanyID:   newTestCompanyID(),
+		VoucherID:   uuid.New(),
+		UserID:      newTestUserID(),
+		FileName:    "invoice.pdf",
+		ContentType: "application/pdf",
+		Data:        []byte(data),
+		IPAddress:   "10.0.0.1",
+	}
+}
+
+func TestVoucherAttachmentService_Upload_Clean(t *testing.T) {
+	scanner := &fakeVirusScanner{result: &provider.ScanResult{}}
+	repo, voucherRepo, svc := newTestAttachmentService(scanner)
+	upload := newTestUpload("%PDF-1.7")
+
+	voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.VoucherAttachment")).Return(nil)
+
+	attachment, err := svc.Upload(context.Background(), upload)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.AttachmentScanClean, attachment.ScanStatus)
+	assert.Equal(t, "clamav", attachment.Scanner)
+	assert.NotNil(t, attachment.ScannedAt)
+	assert.Equal(t, 1, scanner.calls)
+	repo.AssertExpectations(t)
+}
+
+func TestVoucherAttachmentService_Upload_Infected(t *testing.T) {
+	scanner := &fakeVirusScanner{result: &provider.ScanResult{Infected: true, Signature: "Eicar-Test-Signature"}}
+	repo, voucherRepo, svc := newTestAttachmentService(scanner)
+	upload := newTestUpload("X5O!P%@AP")
+
+	voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+	repo.On("Quarantine", mock.Anything, mock.MatchedBy(func(f *domain.QuarantinedFile) bool {
+		return f.Signature == "Eicar-Test-Signature" && f.IPAddress == "10.0.0.1" && f.FileName == "invoice.pdf"
+	}), (*uuid.UUID)(nil)).Return(nil)
+
+	attachment, err := svc.Upload(context.Background(), upload)
+
+	assert.Nil(t, attachment)
+	assert.True(t, errors.Is(err, domain.ErrAttachmentInfected))
+	assert.Contains(t, err.Error(), "Eicar-Test-Signature")
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestVoucherAttachmentService_Upload_ScanFailed(t *testing.T) {
+	scanner := &fakeVirusScanner{err: provider.ErrScanFailed}
+	repo, voucherRepo, svc := newTestAttachmentService(scanner)
+	upload := newTestUpload("%PDF-1.7")
+
+	voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+
+	_, err := svc.Upload(context.Background(), upload)
+
+	assert.ErrorIs(t, err, domain.ErrAttachmentScanUnavailable)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestVoucherAttachmentService_Upload_TooLarge(t *testing.T) {
+	scanner := &fakeVirusScanner{result: &provider.ScanResult{}}
+	_, _, svc := newTestAttachmentService(scanner)
+
+	_, err := svc.Upload(context.Background(), newTestUpload(string(make([]byte, 2048))))
+
+	assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+	assert.Zero(t, scanner.calls)
+}
+
+func TestVoucherAttachmentService_Download_QuarantinesUnscannedInfected(t *testing.T) {
+	scanner := &fakeVirusScanner{result: &provider.ScanResult{Infected: true, Signature: "Win.Trojan.Agent"}}
+	repo, _, svc := newTestAttachmentService(scanner)
+	companyID := newTestCompanyID()
+	stored := &domain.VoucherAttachment{
+		ID:         uuid.New(),
+		VoucherID:  uuid.New(),
+		CompanyID:  companyID,
+		FileName:   "scan.jpg",
+		FileData:   []byte("data"),
+		ScanStatus: domain.AttachmentScanUnscanned,
+	}
+
+	repo.On("FindByID", mock.Anything, companyID, stored.ID).Return(stored, nil)
+	repo.On("Quarantine", mock.Anything, mock.AnythingOfType("*domain.QuarantinedFile"), &stored.ID).Return(nil)
+
+	_, err := svc.Download(context.Background(), companyID, stored.VoucherID, stored.ID, "10.0.0.2")
+
+	assert.ErrorIs(t, err, domain.ErrAttachmentInfected)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "UpdateScan", mock.Anything, mock.Anything)
+}
+
+func TestVoucherAttachmentService_Upload_RejectsDisguisedExecutable(t *testing.T) {
+	scanner := &fakeVirusScanner{result: &provider.ScanResult{}}
+	repo, voucherRepo, svc := newTestAttachmentService(scanner)
+	upload := newTestUpload("MZ\x90\x00\x03\x00\x00\x00\x04\x00")
+
+	voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+
+	_, err := svc.Upload(context.Background(), upload)
+
+	assert.ErrorIs(t, err, domain.ErrAttachmentTypeNotAllowed)
+	assert.Equal(t, 1, scanner.calls, "scanned before the type is checked")
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestVoucherAttachmentService_Upload_SniffsTypeAndRendersThumbnail(t *testing.T) {
+	repo, voucherRepo, svc := newTestImageAttachmentService(nil)
+	upload := newTestUpload("")
+	upload.FileName = "receipt.png"
+	upload.ContentType = "application/octet-stream"
+	upload.Data = testImage(t, func(buf *bytes.Buffer, img image.Image) error { return png.Encode(buf, img) })
+
+	voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.VoucherAttachment")).Return(nil)
+
+	attachment, err := svc.Upload(context.Background(), upload)
+
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", attachment.FileType)
+	assert.True(t, attachment.HasThumbnail)
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(attachment.ThumbnailData))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 64, cfg.Width)
+	assert.Equal(t, 48, cfg.Height)
+}
+
+func TestVoucherAttachmentService_Upload_HEIC(t *testing.T) {
+	heic := "\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic"
+	converted := testImage(t, func(buf *bytes.Buffer, img image.Image) error { return jpeg.Encode(buf, img, nil) })
+
+	t.Run("converted to JPEG", func(t *testing.T) {
+		repo, voucherRepo, svc := newTestImageAttachmentService(&fakeImageConverter{jpeg: converted})
+		upload := newTestUpload(heic)
+		upload.FileName = "IMG_0001.HEIC"
+
+		voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.VoucherAttachment")).Return(nil)
+
+		attachment, err := svc.Upload(context.Background(), upload)
+
+		require.NoError(t, err)
+		assert.Equal(t, "IMG_0001.jpg", attachment.FileName)
+		assert.Equal(t, "image/jpeg", attachment.FileType)
+		assert.Equal(t, int64(len(converted)), attachment.FileSize)
+		assert.True(t, attachment.HasThumbnail)
+	})
+
+	t.Run("stored as uploaded without converter", func(t *testing.T) {
+		repo, voucherRepo, svc := newTestImageAttachmentService(nil)
+		upload := newTestUpload(heic)
+		upload.FileName = "IMG_0001.HEIC"
+
+		voucherRepo.On("FindByID", mock.Anything, upload.CompanyID, upload.VoucherID).Return(&domain.Voucher{}, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.VoucherAttachment")).Return(nil)
+
+		attachment, err := svc.Upload(context.Background(), upload)
+
+		require.NoError(t, err)
+		assert.Equal(t, "IMG_0001.HEIC", attachment.FileName)
+		assert.Equal(t, "image/heic", attachment.FileType)
+		assert.False(t, attachment.HasThumbnail)
+	})
+}
+
+func TestVoucherAttachmentService_Thumbnail(t *testing.T) {
+	repo, _, svc := newTestImageAttachmentService(nil)
+	companyID := newTestCompanyID()
+	photo := &domain.VoucherAttachment{ID: uuid.New(), VoucherID: uuid.New(), HasThumbnail: true, ThumbnailData: []byte{0xFF, 0xD8}}
+	document := &domain.VoucherAttachment{ID: uuid.New(), VoucherID: photo.VoucherID}
+
+	repo.On("FindThumbnail", mock.Anything, companyID, photo.ID).Return(photo, nil)
+	repo.On("FindThumbnail", mock.Anything, companyID, document.ID).Return(document, nil)
+
+	got, err := svc.Thumbnail(context.Background(), companyID, photo.VoucherID, photo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, photo.ThumbnailData, got.ThumbnailData)
+
+	_, err = svc.Thumbnail(context.Background(), companyID, photo.VoucherID, document.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNoThumbnail)
+
+	_, err = svc.Thumbnail(context.Background(), companyID, uuid.New(), photo.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound, "attachment of another voucher")
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+)
+
+// voucherPrintData is everything needed to lay out a printed voucher
+type voucherPrintData struct {
+	Voucher    *domain.Voucher
+	Company    *domain.Company
+	Template   *domain.VoucherPrintTemplate
+	PreparedBy string
+	ApprovedBy string
+	PostedBy   string
+	Signatures []domain.VoucherSignature // latest per action, approval first
+	PrintedAt  time.Time
+}
+
+// Voucher page layout (points, A4 portrait)
+const (
+	printMarginX       = 40.0
+	printContentWidth  = pdf.A4Width - 2*printMarginX
+	printRight         = printMarginX + printContentWidth
+	printInfoTop       = 122.0
+	printInfoRowH      = 18.0
+	printTableTop      = printInfoTop + 3*printInfoRowH + 14
+	printHeaderRowH    = 18.0
+	printRowH          = 16.0
+	printFooterY       = pdf.A4Height - 30
+	printBodyBottom    = pdf.A4Height - 80
+	printApprovalBoxW  = 50.0
+	printApprovalHdrH  = 14.0
+	printApprovalBoxH  = 36.0
+	printSignatureRowH = 26.0
+)
+
+// printColumn is a column of the entries table
+type printColumn struct {
+	label string
+	width float64
+	right bool
+}
+
+// renderVoucherPDF lays out the voucher across as many pages as its entries need
+func renderVoucherPDF(data *voucherPrintData) ([]byte, error) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+
+	var logo *pdf.Image
+	if data.Template.HasLogo() {
+		img, err := doc.AddImage(data.Template.LogoData)
+		if err != nil {
+			return nil, err
+		}
+		logo = img
+	}
+
+	signatureImages := make(map[uuid.UUID]*pdf.Image)
+	for _, sig := range data.Signatures {
+		if !sig.HasImage() {
+			continue
+		}
+		img, err := doc.AddImage(sig.ImageData)
+		if err != nil {
+			return nil, err
+		}
+		signatureImages[sig.ID] = img
+	}
+
+	columns := printColumns(data.Template.ShowPartner)
+	reserved := 2*printRowH + float64(len(data.Signatures))*printSignatureRowH
+	rowsPerPage := int(math.Floor((printBodyBottom - printTableTop - printHeaderRowH - reserved) / printRowH))
+	entries := data.Voucher.Entries
+
+	pageCount := (len(entries) + rowsPerPage - 1) / rowsPerPage
+	if pageCount == 0 {
+		pageCount = 1
+	}
+
+	for pageNo := 1; pageNo <= pageCount; pageNo++ {
+		page := doc.AddPage()
+		page.SetLineWidth(0.5)
+
+		drawPrintHeader(page, data, logo, signatureImages)
+		drawPrintInfo(page, data)
+
+		start := (pageNo - 1) * rowsPerPage
+		end := start + rowsPerPage
+		if end > len(entries) {
+			end = len(entries)
+		}
+		y := drawPrintEntries(page, columns, entries[start:end], data.Template.ShowPartner)
+
+		if pageNo == pageCount {
+			y = drawPrintTotals(page, columns, data.Voucher, y)
+			drawPrintSignoff(page, data, y+14)
+			drawPrintSignatures(page, data, signatureImages, y+24)
+		}
+
+		drawPrintFooter(page, data, pageNo, pageCount)
+	}
+
+	return doc.Bytes()
+}
+
+// printColumns returns the entries table columns; the description absorbs the remaining width
+func printColumns(showPartner bool) []printColumn {
+	columns := []printColumn{
+		{label: "번호", width: 30},
+		{label: "계정코드", width: 55},
+		{label: "계정과목", width: 95},
+	}
+	if showPartner {
+		columns = append(columns, printColumn{label: "거래처", width: 85})
+	}
+	columns = append(columns,
+		printColumn{label: "적요"},
+		printColumn{label: "차변", width: 80, right: true},
+		printColumn{label: "대변", width: 80, right: true},
+	)
+
+	fixed := 0.0
+	for _, c := range columns {
+		fixed += c.width
+	}
+	for i := range columns {
+		if columns[i].width == 0 {
+			columns[i].width = printContentWidth - fixed
+		}
+	}
+	return columns
+}
+
+// drawPrintHeader draws the logo, title, company line and approval block
+func drawPrintHeader(page *pdf.Page, data *voucherPrintData, logo *pdf.Image, signatureImages map[uuid.UUID]*pdf.Image) {
+	if logo != nil {
+		h := 36.0
+		w := h * float64(logo.Width()) / float64(logo.Height())
+		if w > 120 {
+			w = 120
+			h = w * float64(logo.Height()) / float64(logo.Width())
+		}
+		page.Image(logo, printMarginX, 40, w, h)
+	}
+
+	title := data.Template.Title
+	if title == "" {
+		title = data.Voucher.GetTypeLabel()
+	}
+	page.TextCenter(pdf.A4Width/2, 72, 20, spaceOut(title))
+
+	company := data.Company.Name
+	if data.Company.BusinessNumber != "" {
+		company += "  (사업자등록번호 " + data.Company.BusinessNumber + ")"
+	}
+	page.Text(printMarginX, 108, 9, company)
+
+	if data.Template.ShowApprovalBlock {
+		drawApprovalBlock(page, data, signatureImages)
+	}
+}
+
+// drawApprovalBlock draws the 결재란; the first box names the preparer and the last the approver,
+// with the approver's captured signature
+func drawApprovalBlock(page *pdf.Page, data *voucherPrintData, signatureImages map[uuid.UUID]*pdf.Image) {
+	approval := data.signature(domain.SignatureActionApprove)
+
+	labels := data.Template.ApprovalLabels
+	x := printRight - float64(len(labels))*printApprovalBoxW
+	top := 36.0
+
+	for i, label := range labels {
+		bx := x + float64(i)*printApprovalBoxW
+		page.FillRect(bx, top, printApprovalBoxW, printApprovalHdrH, 0.92)
+		page.Rect(bx, top, printApprovalBoxW, printApprovalHdrH)
+		page.Rect(bx, top+printApprovalHdrH, printApprovalBoxW, printApprovalBoxH)
+		page.TextCenter(bx+printApprovalBoxW/2, top+10, 8, label)
+
+		name := ""
+		switch {
+		case i == len(labels)-1:
+			name = data.ApprovedBy
+		case i == 0:
+			name = data.PreparedBy
+		}
+		if name != "" {
+			page.TextCenter(bx+printApprovalBoxW/2, top+printApprovalHdrH+printApprovalBoxH-5, 7,
+				pdf.Truncate(name, 7, printApprovalBoxW-4))
+		}
+
+		if i == len(labels)-1 && approval != nil {
+			if img := signatureImages[approval.ID]; img != nil {
+				drawFittedImage(page, img, bx+2, top+printApprovalHdrH+2, printApprovalBoxW-4, printApprovalBoxH-14)
+			} else {
+				page.TextCenter(bx+printApprovalBoxW/2, top+printApprovalHdrH+16, 6, "PIN 확인")
+			}
+		}
+	}
+}
+
+// drawPrintSignatures lists the captured signatures with signer, time, method and document digest
+func drawPrintSignatures(page *pdf.Page, data *voucherPrintData, signatureImages map[uuid.UUID]*pdf.Image, y float64) {
+	imageW := 70.0
+	for i, sig := range data.Signatures {
+		top := y + float64(i)*printSignatureRowH
+		page.Rect(printMarginX, top, printContentWidth, printSignatureRowH)
+
+		signer := ""
+		if sig.User != nil {
+			signer = sig.User.Name
+		}
+		digest := sig.DocumentHash
+		if len(digest) > 16 {
+			digest = digest[:16]
+		}
+		line := fmt.Sprintf("전자서명 %s   %s   %s   %s   SHA-256 %s",
+			signatureActionLabel(sig.Action), blankDash(signer), sig.SignedAt.Format("2006-01-02 15:04"),
+			signatureMethodLabel(sig.Method), digest)
+		if !sig.Matches(data.Voucher) {
+			line += " (서명 후 변경됨)"
+		}
+		page.Text(printMarginX+5, top+16, 8, pdf.Truncate(line, 8, printContentWidth-imageW-15))
+
+		if img := signatureImages[sig.ID]; img != nil {
+			drawFittedImage(page, img, printRight-imageW-3, top+2, imageW, printSignatureRowH-4)
+		}
+	}
+}
+
+// drawFittedImage draws the image scaled to fit the box, keeping its aspect ratio and centering it
+func drawFittedImage(page *pdf.Page, img *pdf.Image, x, y, w, h float64) {
+	iw, ih := float64(img.Width()), float64(img.Height())
+	scale := math.Min(w/iw, h/ih)
+	dw, dh := iw*scale, ih*scale
+	page.Image(img, x+(w-dw)/2, y+(h-dh)/2, dw, dh)
+}
+
+// signature returns the printed signature for the action, or nil
+func (d *voucherPrintData) signature(action domain.SignatureAction) *domain.VoucherSignature {
+	for i := range d.Signatures {
+		if d.Signatures[i].Action == action {
+			return &d.Signatures[i]
+		}
+	}
+	return nil
+}
+
+// signatureActionLabel returns the Korean label of a signature action
+func signatureActionLabel(action domain.SignatureAction) string {
+	if action == domain.SignatureActionPost {
+		return "전기"
+	}
+	return "승인"
+}
+
+// signatureMethodLabel returns the Korean label of a signature method
+func signatureMethodLabel(method domain.SignatureMethod) string {
+	switch method {
+	case domain.SignatureMethodImage:
+		return "서명"
+	case domain.SignatureMethodPIN:
+		return "PIN"
+	}
+	return "서명+PIN"
+}
+
+// drawPrintInfo draws the voucher number, date, type, status and description
+func drawPrintInfo(page *pdf.Page, data *voucherPrintData) {
+	v := data.Voucher
+	labelW := 70.0
+	valueW := printContentWidth/2 - labelW
+
+	rows := [][]string{
+		{"전표번호", v.VoucherNo, "전표일자", v.VoucherDate.Format("2006-01-02")},
+		{"전표유형", v.GetTypeLabel(), "상태", v.GetStatusLabel()},
+	}
+	for i, row := range rows {
+		y := printInfoTop + float64(i)*printInfoRowH
+		for j := 0; j < 2; j++ {
+			x := printMarginX + float64(j)*(labelW+valueW)
+			page.FillRect(x, y, labelW, printInfoRowH, 0.92)
+			page.Rect(x, y, labelW, printInfoRowH)
+			page.Rect(x+labelW, y, valueW, printInfoRowH)
+			page.TextCenter(x+labelW/2, y+12.5, 9, row[j*2])
+			page.Text(x+labelW+5, y+12.5, 9, row[j*2+1])
+		}
+	}
+
+	y := printInfoTop + 2*printInfoRowH
+	page.FillRect(printMarginX, y, labelW, printInfoRowH, 0.92)
+	page.Rect(printMarginX, y, labelW, printInfoRowH)
+	page.Rect(printMarginX+labelW, y, printContentWidth-labelW, printInfoRowH)
+	page.TextCenter(printMarginX+labelW/2, y+12.5, 9, "적요")
+	page.Text(printMarginX+labelW+5, y+12.5, 9, pdf.Truncate(v.Description, 9, printContentWidth-labelW-10))
+}
+
+// drawPrintEntries draws the table header and entry rows, returning the y below the last row
+func drawPrintEntries(page *pdf.Page, columns []printColumn, entries []domain.VoucherEntry, showPartner bool) float64 {
+	y := printTableTop
+	x := printMarginX
+	for _, c := range columns {
+		page.FillRect(x, y, c.width, printHeaderRowH, 0.92)
+		page.Rect(x, y, c.width, printHeaderRowH)
+		page.TextCenter(x+c.width/2, y+12.5, 9, c.label)
+		x += c.width
+	}
+	y += printHeaderRowH
+
+	for _, entry := range entries {
+		values := []string{strconv.Itoa(entry.LineNo), "", ""}
+		if entry.Account != nil {
+			values[1] = entry.Account.Code
+			values[2] = entry.Account.Name
+		}
+		if showPartner {
+			partner := ""
+			if entry.Partner != nil {
+				partner = entry.Partner.Name
+			}
+			values = append(values, partner)
+		}
+		values = append(values, entry.Description, formatPrintAmount(entry.DebitAmount), formatPrintAmount(entry.CreditAmount))
+
+		drawPrintRow(page, columns, values, y)
+		y += printRowH
+	}
+	return y
+}
+
+// drawPrintRow draws one bordered table row
+func drawPrintRow(page *pdf.Page, columns []printColumn, values []string, y float64) {
+	x := printMarginX
+	for i, c := range columns {
+		page.Rect(x, y, c.width, printRowH)
+		text := pdf.Truncate(values[i], 8, c.width-6)
+		if c.right {
+			page.TextRight(x+c.width-3, y+11, 8, text)
+		} else {
+			page.Text(x+3, y+11, 8, text)
+		}
+		x += c.width
+	}
+}
+
+// drawPrintTotals draws the 합계 row below the entries
+func drawPrintTotals(page *pdf.Page, columns []printColumn, v *domain.Voucher, y float64) float64 {
+	labelW := 0.0
+	for _, c := range columns[:len(columns)-2] {
+		labelW += c.width
+	}
+	debitW := columns[len(columns)-2].width
+	creditW := columns[len(columns)-1].width
+
+	page.FillRect(printMarginX, y, labelW, printRowH, 0.92)
+	page.Rect(printMarginX, y, labelW, printRowH)
+	page.TextCenter(printMarginX+labelW/2, y+11, 9, "합  계")
+
+	page.Rect(printMarginX+labelW, y, debitW, printRowH)
+	page.TextRight(printMarginX+labelW+debitW-3, y+11, 8, formatPrintAmount(v.TotalDebit))
+	page.Rect(printMarginX+labelW+debitW, y, creditW, printRowH)
+	page.TextRight(printRight-3, y+11, 8, formatPrintAmount(v.TotalCredit))
+
+	return y + printRowH
+}
+
+// drawPrintSignoff prints who prepared, approved and posted the voucher
+func drawPrintSignoff(page *pdf.Page, data *voucherPrintData, y float64) {
+	v := data.Voucher
+	parts := []string{"작성: " + blankDash(data.PreparedBy)}
+	approved := "승인: " + blankDash(data.ApprovedBy)
+	if v.ApprovedAt != nil {
+		approved += " (" + v.ApprovedAt.Format("2006-01-02") + ")"
+	}
+	parts = append(parts, approved)
+	posted := "전기: " + blankDash(data.PostedBy)
+	if v.PostedAt != nil {
+		posted += " (" + v.PostedAt.Format("2006-01-02") + ")"
+	}
+	parts = append(parts, posted)
+
+	page.Text(printMarginX, y, 8, strings.Join(parts, "    "))
+}
+
+// drawPrintFooter draws the template footer text, print time and page number
+func drawPrintFooter(page *pdf.Page, data *voucherPrintData, pageNo, pageCount int) {
+	page.Line(printMarginX, printFooterY-12, printRight, printFooterY-12)
+	if data.Template.FooterText != "" {
+		page.Text(printMarginX, printFooterY, 8, pdf.Truncate(data.Template.FooterText, 8, printContentWidth-170))
+	}
+	page.TextRight(printRight, printFooterY, 8,
+		fmt.Sprintf("출력일시 %s   %d / %d", data.PrintedAt.Format("2006-01-02 15:04"), pageNo, pageCount))
+}
+
+// formatPrintAmount formats an amount with thousands separators; zero prints blank
+func formatPrintAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+	s := strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+	s = strings.TrimSuffix(s, ".00")
+
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	if amount < 0 {
+		b.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString(frac)
+	return b.String()
+}
+
+// spaceOut separates the characters of a short title (e.g., 일 반 전 표)
+func spaceOut(s string) string {
+	runes := []rune(s)
+	if len(runes) > 6 {
+		return s
+	}
+	parts := make([]string, len(runes))
+	for i, r := range runes {
+		parts[i] = string(r)
+	}
+	return strings.Join(parts, " ")
+}
+
+// blankDash returns "-" for empty names
+func blankDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
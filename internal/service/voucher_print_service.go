@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// VoucherPrintService defines the interface for printable voucher documents (전표 출력)
+type VoucherPrintService interface {
+	// Template customization
+	GetTemplate(ctx context.Context, companyID uuid.UUID) (*domain.VoucherPrintTemplate, error)
+	UpdateTemplate(ctx context.Context, template *domain.VoucherPrintTemplate) error
+
+	// PrintVoucher renders the voucher as PDF
+	PrintVoucher(ctx context.Context, companyID, voucherID uuid.UUID) (*domain.Voucher, []byte, error)
+}
+
+// voucherPrintService implements VoucherPrintService
+type voucherPrintService struct {
+	voucherRepo   repository.VoucherRepository
+	companyRepo   repository.CompanyRepository
+	userRepo      repository.UserRepository
+	templateRepo  repository.VoucherPrintTemplateRepository
+	signatureRepo repository.VoucherSignatureRepository
+}
+
+// NewVoucherPrintService creates a new VoucherPrintService
+func NewVoucherPrintService(
+	voucherRepo repository.VoucherRepository,
+	companyRepo repository.CompanyRepository,
+	userRepo repository.UserRepository,
+	templateRepo repository.VoucherPrintTemplateRepository,
+	signatureRepo repository.VoucherSignatureRepository,
+) VoucherPrintService {
+	return &voucherPrintService{
+		voucherRepo:   voucherRepo,
+		companyRepo:   companyRepo,
+		userRepo:      userRepo,
+		templateRepo:  templateRepo,
+		signatureRepo: signatureRepo,
+	}
+}
+
+// GetTemplate returns the company's template, or the default template if none is saved
+func (s *voucherPrintService) GetTemplate(ctx context.Context, companyID uuid.UUID) (*domain.VoucherPrintTemplate, error) {
+	template, err := s.templateRepo.FindByCompany(ctx, companyID)
+	if err == domain.ErrPrintTemplateNotFound {
+		return domain.NewVoucherPrintTemplate(companyID), nil
+	}
+	return template, err
+}
+
+// UpdateTemplate validates and saves the company's template
+func (s *voucherPrintService) UpdateTemplate(ctx context.Context, template *domain.VoucherPrintTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	// Reject logos the renderer cannot embed
+	if template.HasLogo() {
+		if _, err := pdf.New(pdf.A4Width, pdf.A4Height).AddImage(template.LogoData); err != nil {
+			return domain.ErrPrintTemplateLogoFormat
+		}
+	}
+
+	return s.templateRepo.Save(ctx, template)
+}
+
+// PrintVoucher renders the voucher with the company header, entries and approval block
+func (s *voucherPrintService) PrintVoucher(ctx context.Context, companyID, voucherID uuid.UUID) (*domain.Voucher, []byte, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	company, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := s.GetTemplate(ctx, companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signatures, err := s.signatureRepo.FindByVoucher(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := &voucherPrintData{
+		Voucher:    voucher,
+		Company:    company,
+		Template:   template,
+		PreparedBy: s.userName(ctx, companyID, voucher.CreatedBy),
+		ApprovedBy: s.userName(ctx, companyID, voucher.ApprovedBy),
+		PostedBy:   s.userName(ctx, companyID, voucher.PostedBy),
+		Signatures: latestSignatures(signatures),
+		PrintedAt:  time.Now(),
+	}
+
+	pdf, err := renderVoucherPDF(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return voucher, pdf, nil
+}
+
+// userName resolves a user's display name; unknown users print blank
+func (s *voucherPrintService) userName(ctx context.Context, companyID uuid.UUID, userID *uuid.UUID) string {
+	if userID == nil {
+		return ""
+	}
+	user, err := s.userRepo.FindByID(ctx, companyID, *userID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
+
+// latestSignatures keeps the most recent signature per action, approval before posting.
+// A voucher rejected and re-approved prints only the signature of the final approval.
+func latestSignatures(signatures []domain.VoucherSignature) []domain.VoucherSignature {
+	var result []domain.VoucherSignature
+	for _, action := range []domain.SignatureAction{domain.SignatureActionApprove, domain.SignatureActionPost} {
+		for i := len(signatures) - 1; i >= 0; i-- {
+			if signatures[i].Action == action {
+				result = append(result, signatures[i])
+				break
+			}
+		}
+	}
+	return result
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,7 +22,9 @@ type VoucherService interface {
 	// Query operations
 	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error)
 	GetByNo(ctx context.Context, companyID uuid.UUID, voucherNo string) (*domain.Voucher, error)
+	GetDetail(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error)
 	List(ctx context.Context, filter repository.VoucherFilter) ([]domain.Voucher, int64, error)
+	Export(ctx context.Context, filter repository.VoucherFilter, fn func(voucher *domain.Voucher) error) error
 	GetByDateRange(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.Voucher, error)
 	GetPending(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error)
 
@@ -30,31 +34,91 @@ type VoucherService interface {
 	RemoveEntry(ctx context.Context, entryID uuid.UUID) error
 	ReplaceEntries(ctx context.Context, voucherID uuid.UUID, entries []domain.VoucherEntry) error
 
-	// Workflow operations
+	// Workflow operations. Submit posts the voucher right away when the
+	// company's approval exemption covers it.
 	Submit(ctx context.Context, companyID, voucherID, userID uuid.UUID) error
 	Approve(ctx context.Context, companyID, voucherID, userID uuid.UUID) error
 	Reject(ctx context.Context, companyID, voucherID, userID uuid.UUID, reason string) error
 	Post(ctx context.Context, companyID, voucherID, userID uuid.UUID) error
 	Cancel(ctx context.Context, companyID, voucherID uuid.UUID) error
 
+	// SetTags replaces the tags of a voucher, whatever its status, since
+	// analyses are often tagged after posting
+	SetTags(ctx context.Context, companyID, voucherID, userID uuid.UUID, tags []string) (*domain.Voucher, error)
+
+	// Scheduled posting: approved vouchers posted by the worker once their
+	// date arrives and their period is open
+	Schedule(ctx context.Context, companyID, voucherID, userID uuid.UUID, postDate time.Time) (*domain.Voucher, error)
+	Unschedule(ctx context.Context, companyID, voucherID uuid.UUID) (*domain.Voucher, error)
+	GetScheduled(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error)
+	ProcessScheduledPostings(ctx context.Context, asOf time.Time) ([]domain.Voucher, error)
+
+	// SoDViolations lists approvals and postings by the creator or submitter of
+	// the voucher made from..to, including those before enforcement was enabled
+	SoDViolations(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.SoDViolation, error)
+
 	// Reversal
 	Reverse(ctx context.Context, companyID, voucherID, userID uuid.UUID, reversalDate time.Time, description string) (*domain.Voucher, error)
 
+	// Partial reversal of some entries or a share of the voucher; the entries'
+	// reversed amounts block reversing more than was posted
+	PartialReverse(ctx context.Context, companyID, voucherID, userID uuid.UUID, reversalDate time.Time, description string, ratio float64, lines []domain.ReversalLine) (*domain.Voucher, error)
+
+	// Correction (대체 전표): reverses a posted voucher and creates the draft
+	// replacing it in one transaction. Without entries the draft copies the
+	// original's.
+	Correct(ctx context.Context, companyID, voucherID, userID uuid.UUID, correctionDate time.Time, description string, entries []domain.VoucherEntry) (*domain.VoucherCorrection, error)
+
+	// Auto-reversal (run by the worker)
+	ProcessAutoReversals(ctx context.Context, asOf time.Time) ([]domain.Voucher, error)
+
+	// PostGenerated creates a voucher generated by the system and posts it
+	// right away on behalf of the user, as the worker books scheduled
+	// recognitions. It fails with domain.ErrFiscalPeriodClosed, creating
+	// nothing, when the period of the voucher is closed.
+	PostGenerated(ctx context.Context, voucher *domain.Voucher, userID uuid.UUID) error
+
 	// Validation
-	ValidateEntries(ctx context.Context, companyID uuid.UUID, entries []domain.VoucherEntry) error
+	ValidateEntries(ctx context.Context, companyID uuid.UUID, voucherType domain.VoucherType, entries []domain.VoucherEntry) error
+}
+
+// VoucherLedger is the ledger vouchers are posted to: it brings the balances
+// of some accounts up to date and tells whether a period is open and whether
+// a year is locked after its audit. repository.LedgerRepository implements it.
+type VoucherLedger interface {
+	RecalculateAccountBalances(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, fromYear, fromMonth int) error
+	GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error)
+	IsFiscalYearAuditLocked(ctx context.Context, companyID uuid.UUID, year int) (bool, error)
+}
+
+// VoucherBankAccounts finds the bank accounts of cash accounts, active or not,
+// which the entries paying from or into a bank name.
+// repository.BankAccountRepository implements it.
+type VoucherBankAccounts interface {
+	FindByAccounts(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID) ([]domain.CompanyBankAccount, error)
 }
 
 // voucherService implements VoucherService
 type voucherService struct {
 	voucherRepo repository.VoucherRepository
 	accountRepo repository.AccountRepository
+	taxCodeRepo repository.TaxCodeRepository
+	settings    CompanySettingsService
+	ledger      VoucherLedger       // nil leaves the balances to a full recalculation
+	limits      PlanLimits          // nil does not limit voucher creation
+	banks       VoucherBankAccounts // nil does not check the bank accounts of entries
 }
 
 // NewVoucherService creates a new VoucherService
-func NewVoucherService(voucherRepo repository.VoucherRepository, accountRepo repository.AccountRepository) VoucherService {
+func NewVoucherService(voucherRepo repository.VoucherRepository, accountRepo repository.AccountRepository, taxCodeRepo repository.TaxCodeRepository, settings CompanySettingsService, ledger VoucherLedger, limits PlanLimits, banks VoucherBankAccounts) VoucherService {
 	return &voucherService{
 		voucherRepo: voucherRepo,
 		accountRepo: accountRepo,
+		taxCodeRepo: taxCodeRepo,
+		settings:    settings,
+		ledger:      ledger,
+		limits:      limits,
+		banks:       banks,
 	}
 }
 
@@ -64,13 +128,31 @@ func (s *voucherService) Create(ctx context.Context, voucher *domain.Voucher) er
 	if err := voucher.Validate(); err != nil {
 		return err
 	}
+	if err := voucher.SetTags(voucher.Tags); err != nil {
+		return err
+	}
 
 	// Validate entries
 	if len(voucher.Entries) == 0 {
 		return domain.ErrVoucherNoEntries
 	}
 
-	if err := s.ValidateEntries(ctx, voucher.CompanyID, voucher.Entries); err != nil {
+	settings, err := s.settings.Get(ctx, voucher.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	// Round amounts and split tax-inclusive entries into supply and VAT lines
+	entries, err := s.applyTaxCodes(ctx, voucher.CompanyID, roundEntryAmounts(voucher.Entries, settings), settings)
+	if err != nil {
+		return err
+	}
+	voucher.Entries = entries
+
+	if err := s.ValidateEntries(ctx, voucher.CompanyID, voucher.VoucherType, voucher.Entries); err != nil {
+		return err
+	}
+	if err := s.checkBankAccounts(ctx, voucher, voucher.Entries); err != nil {
 		return err
 	}
 
@@ -82,6 +164,22 @@ func (s *voucherService) Create(ctx context.Context, voucher *domain.Voucher) er
 		return err
 	}
 
+	// Reversals, corrections and vouchers generated from a source document,
+	// which carry its reference, are not checked for duplicates
+	if voucher.ReferenceType == "" && !voucher.IsReversal && voucher.CorrectionOfID == nil {
+		if err := s.checkDuplicates(ctx, voucher, settings); err != nil {
+			return err
+		}
+	}
+
+	// The monthly voucher limit of the plan; reversals and corrections are not
+	// limited, so that mistakes can always be corrected
+	if s.limits != nil && !voucher.IsReversal && voucher.CorrectionOfID == nil {
+		if err := s.limits.CheckLimit(ctx, voucher.CompanyID, domain.PlanLimitVouchers, 1); err != nil {
+			return err
+		}
+	}
+
 	// Generate voucher number
 	voucherNo, err := s.voucherRepo.GenerateVoucherNo(ctx, voucher.CompanyID, voucher.VoucherType, voucher.VoucherDate)
 	if err != nil {
@@ -100,6 +198,45 @@ func (s *voucherService) Create(ctx context.Context, voucher *domain.Voucher) er
 	return s.voucherRepo.Create(ctx, voucher)
 }
 
+// checkDuplicates looks for vouchers booking the same amounts to the same
+// accounts and partners within the company's window around the voucher date
+func (s *voucherService) checkDuplicates(ctx context.Context, voucher *domain.Voucher, settings domain.CompanySettings) error {
+	mode := settings.DuplicateCheck()
+	if mode == domain.DuplicateCheckOff {
+		return nil
+	}
+
+	days := settings.DuplicateCheckWindow()
+	from := voucher.VoucherDate.AddDate(0, 0, -days)
+	to := voucher.VoucherDate.AddDate(0, 0, days)
+	candidates, _, err := s.voucherRepo.FindAll(ctx, repository.VoucherFilter{
+		CompanyID:      voucher.CompanyID,
+		DateFrom:       &from,
+		DateTo:         &to,
+		MinAmount:      &voucher.TotalDebit,
+		MaxAmount:      &voucher.TotalDebit,
+		IncludeEntries: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var duplicates []domain.Voucher
+	for i := range candidates {
+		if voucher.IsDuplicateOf(&candidates[i]) {
+			duplicates = append(duplicates, candidates[i])
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	if mode == domain.DuplicateCheckBlock {
+		return &domain.DuplicateVoucherError{Duplicates: duplicates}
+	}
+	voucher.SuspectedDuplicates = duplicates
+	return nil
+}
+
 // Update updates an existing voucher
 func (s *voucherService) Update(ctx context.Context, voucher *domain.Voucher) error {
 	// Get existing voucher
@@ -117,6 +254,9 @@ func (s *voucherService) Update(ctx context.Context, voucher *domain.Voucher) er
 	if err := voucher.Validate(); err != nil {
 		return err
 	}
+	if err := voucher.SetTags(voucher.Tags); err != nil {
+		return err
+	}
 
 	return s.voucherRepo.Update(ctx, voucher)
 }
@@ -147,11 +287,21 @@ func (s *voucherService) GetByNo(ctx context.Context, companyID uuid.UUID, vouch
 	return s.voucherRepo.FindByNo(ctx, companyID, voucherNo)
 }
 
+// GetDetail retrieves a voucher with its reversal links, workflow users and status history
+func (s *voucherService) GetDetail(ctx context.Context, companyID, id uuid.UUID) (*domain.Voucher, error) {
+	return s.voucherRepo.FindDetailByID(ctx, companyID, id)
+}
+
 // List retrieves vouchers with filtering and pagination
 func (s *voucherService) List(ctx context.Context, filter repository.VoucherFilter) ([]domain.Voucher, int64, error) {
 	return s.voucherRepo.FindAll(ctx, filter)
 }
 
+// Export calls fn for every voucher matching the filter without loading them all at once
+func (s *voucherService) Export(ctx context.Context, filter repository.VoucherFilter, fn func(voucher *domain.Voucher) error) error {
+	return s.voucherRepo.FindAllIterator(ctx, filter, fn)
+}
+
 // GetByDateRange retrieves vouchers within a date range
 func (s *voucherService) GetByDateRange(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.Voucher, error) {
 	return s.voucherRepo.FindByDateRange(ctx, companyID, from, to)
@@ -179,14 +329,24 @@ func (s *voucherService) AddEntry(ctx context.Context, voucherID uuid.UUID, entr
 		return domain.ErrVoucherCannotEdit
 	}
 
-	// Validate account
-	if err := s.validateAccountForPosting(ctx, entry.CompanyID, entry.AccountID); err != nil {
+	// Validate account and its posting rules
+	account, err := s.validateAccountForPosting(ctx, entry.CompanyID, entry.AccountID)
+	if err != nil {
 		return err
 	}
+	if err := checkPostingRules(account, entry, len(voucher.Entries)+1, voucher.VoucherType); err != nil {
+		return err
+	}
+	entries := []domain.VoucherEntry{*entry}
+	if err := s.checkBankAccounts(ctx, voucher, entries); err != nil {
+		return err
+	}
+	entry.BankAccountID = entries[0].BankAccountID
 
 	// Set line number
 	entry.LineNo = len(voucher.Entries) + 1
 	entry.VoucherID = voucherID
+	entry.FiscalYear = voucher.FiscalYear()
 
 	if err := s.voucherRepo.CreateEntry(ctx, entry); err != nil {
 		return err
@@ -228,8 +388,22 @@ func (s *voucherService) ReplaceEntries(ctx context.Context, voucherID uuid.UUID
 		return domain.ErrVoucherCannotEdit
 	}
 
+	settings, err := s.settings.Get(ctx, voucher.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	// Round amounts and split tax-inclusive entries into supply and VAT lines
+	entries, err = s.applyTaxCodes(ctx, voucher.CompanyID, roundEntryAmounts(entries, settings), settings)
+	if err != nil {
+		return err
+	}
+
 	// Validate all entries
-	if err := s.ValidateEntries(ctx, voucher.CompanyID, entries); err != nil {
+	if err := s.ValidateEntries(ctx, voucher.CompanyID, voucher.VoucherType, entries); err != nil {
+		return err
+	}
+	if err := s.checkBankAccounts(ctx, voucher, entries); err != nil {
 		return err
 	}
 
@@ -244,6 +418,7 @@ func (s *voucherService) ReplaceEntries(ctx context.Context, voucherID uuid.UUID
 			entries[i].VoucherID = voucherID
 			entries[i].CompanyID = voucher.CompanyID
 			entries[i].LineNo = i + 1
+			entries[i].FiscalYear = voucher.FiscalYear()
 			if err := repo.CreateEntry(ctx, &entries[i]); err != nil {
 				return err
 			}
@@ -272,8 +447,26 @@ func (s *voucherService) Submit(ctx context.Context, companyID, voucherID, userI
 	if err := voucher.Submit(userID); err != nil {
 		return err
 	}
+	if err := s.voucherRepo.UpdateStatus(ctx, voucher); err != nil {
+		return err
+	}
 
-	return s.voucherRepo.UpdateStatus(ctx, voucher)
+	// Exempt vouchers are posted in a second step so the status history shows
+	// both the submission and the exempt posting
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if !settings.ApprovalExempt(voucher) {
+		return nil
+	}
+	if err := voucher.PostExempt(userID, settings.ApprovalExemption.Reason(voucher)); err != nil {
+		return err
+	}
+	if err := s.voucherRepo.UpdateStatus(ctx, voucher); err != nil {
+		return err
+	}
+	return s.updateBalances(ctx, voucher)
 }
 
 // Approve approves a voucher
@@ -283,6 +476,9 @@ func (s *voucherService) Approve(ctx context.Context, companyID, voucherID, user
 		return err
 	}
 
+	if err := s.checkSegregationOfDuties(ctx, voucher, userID); err != nil {
+		return err
+	}
 	if err := voucher.Approve(userID); err != nil {
 		return err
 	}
@@ -304,18 +500,215 @@ func (s *voucherService) Reject(ctx context.Context, companyID, voucherID, userI
 	return s.voucherRepo.UpdateStatus(ctx, voucher)
 }
 
-// Post posts a voucher to the ledger
+// Post posts a voucher to the ledger. Companies that do not require approval
+// may post draft and pending vouchers directly.
 func (s *voucherService) Post(ctx context.Context, companyID, voucherID, userID uuid.UUID) error {
 	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
 	if err != nil {
 		return err
 	}
 
-	if err := voucher.Post(userID); err != nil {
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if settings.EnforceSegregationOfDuties {
+		if err := voucher.CheckSegregationOfDuties(userID); err != nil {
+			return err
+		}
+	}
+
+	post := voucher.Post
+	if !settings.ApprovalRequired() {
+		post = voucher.PostWithoutApproval
+	}
+	if err := post(userID); err != nil {
 		return err
 	}
 
-	return s.voucherRepo.UpdateStatus(ctx, voucher)
+	if err := s.voucherRepo.UpdateStatus(ctx, voucher); err != nil {
+		return err
+	}
+	return s.updateBalances(ctx, voucher)
+}
+
+// SetTags replaces the tags of a voucher
+func (s *voucherService) SetTags(ctx context.Context, companyID, voucherID, userID uuid.UUID, tags []string) (*domain.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := voucher.SetTags(tags); err != nil {
+		return nil, err
+	}
+	voucher.UpdatedBy = &userID
+	if err := s.voucherRepo.UpdateTags(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// Schedule schedules an approved voucher to be posted on postDate, checking
+// segregation of duties as its posting would
+func (s *voucherService) Schedule(ctx context.Context, companyID, voucherID, userID uuid.UUID, postDate time.Time) (*domain.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settings.Get(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.EnforceSegregationOfDuties {
+		if err := voucher.CheckSegregationOfDuties(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := voucher.Schedule(userID, postDate, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.voucherRepo.UpdateSchedule(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// Unschedule cancels the scheduled posting of a voucher, which stays approved
+func (s *voucherService) Unschedule(ctx context.Context, companyID, voucherID uuid.UUID) (*domain.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := voucher.Unschedule(); err != nil {
+		return nil, err
+	}
+	if err := s.voucherRepo.UpdateSchedule(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// GetScheduled retrieves the vouchers waiting for their scheduled posting
+func (s *voucherService) GetScheduled(ctx context.Context, companyID uuid.UUID) ([]domain.Voucher, error) {
+	return s.voucherRepo.FindScheduled(ctx, companyID)
+}
+
+// ProcessScheduledPostings posts the approved vouchers whose scheduled date
+// has arrived, on behalf of the user who scheduled them. Vouchers of a closed
+// period wait until it is reopened.
+func (s *voucherService) ProcessScheduledPostings(ctx context.Context, asOf time.Time) ([]domain.Voucher, error) {
+	due, err := s.voucherRepo.FindDueScheduledPostings(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	var posted []domain.Voucher
+	var errs []error
+	for i := range due {
+		voucher := &due[i]
+		if !voucher.DueForPosting(asOf) {
+			continue
+		}
+		open, err := s.periodOpen(ctx, voucher.CompanyID, voucher.VoucherDate)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("voucher %s: %w", voucher.ID, err))
+			continue
+		}
+		if !open {
+			continue
+		}
+
+		userID := uuid.Nil
+		if voucher.ScheduledBy != nil {
+			userID = *voucher.ScheduledBy
+		} else if voucher.ApprovedBy != nil {
+			userID = *voucher.ApprovedBy
+		}
+		if err := voucher.Post(userID); err != nil {
+			errs = append(errs, fmt.Errorf("voucher %s: %w", voucher.ID, err))
+			continue
+		}
+		if err := s.voucherRepo.UpdateStatus(ctx, voucher); err != nil {
+			errs = append(errs, fmt.Errorf("voucher %s: %w", voucher.ID, err))
+			continue
+		}
+		if err := s.updateBalances(ctx, voucher); err != nil {
+			errs = append(errs, err)
+		}
+
+		posted = append(posted, *voucher)
+	}
+
+	return posted, errors.Join(errs...)
+}
+
+// periodOpen returns false if the fiscal period of the date is closed.
+// Periods that are not set up yet are open.
+func (s *voucherService) periodOpen(ctx context.Context, companyID uuid.UUID, date time.Time) (bool, error) {
+	if s.ledger == nil {
+		return true, nil
+	}
+	year, month, _ := date.Date()
+	period, err := s.ledger.GetFiscalPeriod(ctx, companyID, year, int(month))
+	if errors.Is(err, domain.ErrFiscalPeriodNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return period.CanPost(), nil
+}
+
+// checkAuditLock returns domain.ErrFiscalYearAuditLocked if the year is
+// locked after its audit
+func (s *voucherService) checkAuditLock(ctx context.Context, companyID uuid.UUID, year int) error {
+	if s.ledger == nil {
+		return nil
+	}
+	locked, err := s.ledger.IsFiscalYearAuditLocked(ctx, companyID, year)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return domain.ErrFiscalYearAuditLocked
+	}
+	return nil
+}
+
+// updateBalances recalculates the balances of the posted voucher's accounts
+// from its period on. The voucher stays posted when this fails; the balances
+// are then caught up by the next recalculation of the period.
+func (s *voucherService) updateBalances(ctx context.Context, voucher *domain.Voucher) error {
+	if s.ledger == nil {
+		return nil
+	}
+	year, month, _ := voucher.VoucherDate.Date()
+	if err := s.ledger.RecalculateAccountBalances(ctx, voucher.CompanyID, voucher.AccountIDs(), year, int(month)); err != nil {
+		return fmt.Errorf("voucher %s is posted but its ledger balances are not updated: %w", voucher.VoucherNo, err)
+	}
+	return nil
+}
+
+// checkSegregationOfDuties rejects the approval of a voucher by its creator or
+// submitter when the company enforces segregation of duties
+func (s *voucherService) checkSegregationOfDuties(ctx context.Context, voucher *domain.Voucher, userID uuid.UUID) error {
+	settings, err := s.settings.Get(ctx, voucher.CompanyID)
+	if err != nil {
+		return err
+	}
+	if !settings.EnforceSegregationOfDuties {
+		return nil
+	}
+	return voucher.CheckSegregationOfDuties(userID)
+}
+
+// SoDViolations lists past approvals and postings by the creator or submitter
+func (s *voucherService) SoDViolations(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.SoDViolation, error) {
+	return s.voucherRepo.FindSoDViolations(ctx, companyID, from, to)
 }
 
 // Cancel cancels a voucher
@@ -332,7 +725,7 @@ func (s *voucherService) Cancel(ctx context.Context, companyID, voucherID uuid.U
 	return s.voucherRepo.UpdateStatus(ctx, voucher)
 }
 
-// Reverse creates a reversal voucher
+// Reverse creates a reversal voucher of what is left after partial reversals
 func (s *voucherService) Reverse(ctx context.Context, companyID, voucherID, userID uuid.UUID, reversalDate time.Time, description string) (*domain.Voucher, error) {
 	// Get original voucher
 	original, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
@@ -350,34 +743,251 @@ func (s *voucherService) Reverse(ctx context.Context, companyID, voucherID, user
 		return nil, domain.ErrVoucherAlreadyReversed
 	}
 
-	// Create reversal voucher
-	reversal := &domain.Voucher{
+	var reversal *domain.Voucher
+	err = s.voucherRepo.WithTransaction(ctx, func(repo repository.VoucherRepository) error {
+		tx := *s
+		tx.voucherRepo = repo
+
+		if err := tx.lockForReversal(ctx, original); err != nil {
+			return err
+		}
+		var err error
+		reversal, err = tx.createReversal(ctx, original, userID, reversalDate, description, original.RemainingReversalLines())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// PartialReverse creates a reversal of a share of a posted voucher: ratio of
+// every entry when ratio is set, otherwise the amounts of the lines. The
+// voucher counts as reversed once nothing is left of its entries.
+func (s *voucherService) PartialReverse(ctx context.Context, companyID, voucherID, userID uuid.UUID, reversalDate time.Time, description string, ratio float64, lines []domain.ReversalLine) (*domain.Voucher, error) {
+	original, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+	if !original.Status.CanReverse() {
+		return nil, domain.ErrVoucherCannotReverse
+	}
+	if original.ReversedByID != nil {
+		return nil, domain.ErrVoucherAlreadyReversed
+	}
+
+	if description == "" {
+		description = fmt.Sprintf("부분 역분개: %s", original.VoucherNo)
+	}
+
+	var reversal *domain.Voucher
+	err = s.voucherRepo.WithTransaction(ctx, func(repo repository.VoucherRepository) error {
+		tx := *s
+		tx.voucherRepo = repo
+
+		if err := tx.lockForReversal(ctx, original); err != nil {
+			return err
+		}
+		// The ratio applies to the entries as reversed so far
+		if ratio != 0 {
+			var err error
+			if lines, err = original.ProportionalReversalLines(ratio); err != nil {
+				return err
+			}
+		}
+		var err error
+		reversal, err = tx.createReversal(ctx, original, userID, reversalDate, description, lines)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// Correct reverses a posted voucher and creates the corrected draft replacing
+// it, both dated correctionDate, linking the three vouchers. The draft copies
+// the original's header and, when no entries are given, what is left of its
+// entries after partial reversals.
+func (s *voucherService) Correct(ctx context.Context, companyID, voucherID, userID uuid.UUID, correctionDate time.Time, description string, entries []domain.VoucherEntry) (*domain.VoucherCorrection, error) {
+	original, err := s.voucherRepo.FindByID(ctx, companyID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+	if !original.Status.CanReverse() {
+		return nil, domain.ErrVoucherCannotReverse
+	}
+	if original.ReversedByID != nil {
+		return nil, domain.ErrVoucherAlreadyReversed
+	}
+
+	if description == "" {
+		description = original.Description
+	}
+
+	correction := &domain.Voucher{
 		TenantModel: domain.TenantModel{
 			CompanyID: companyID,
 		},
+		VoucherDate:    correctionDate,
+		VoucherType:    original.VoucherType,
+		Description:    description,
+		ReferenceType:  original.ReferenceType,
+		ReferenceID:    original.ReferenceID,
+		AutoReverse:    original.AutoReverse,
+		Tags:           original.Tags,
+		CorrectionOfID: &original.ID,
+		CreatedBy:      &userID,
+	}
+
+	var reversal *domain.Voucher
+	err = s.voucherRepo.WithTransaction(ctx, func(repo repository.VoucherRepository) error {
+		tx := *s
+		tx.voucherRepo = repo
+
+		if err := tx.lockForReversal(ctx, original); err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			entries = original.RemainingEntries()
+		}
+		for i := range entries {
+			entries[i].CompanyID = companyID
+		}
+		correction.Entries = entries
+
+		var err error
+		reversal, err = tx.createReversal(ctx, original, userID, correctionDate, fmt.Sprintf("수정 역분개: %s", original.VoucherNo), original.RemainingReversalLines())
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(ctx, correction); err != nil {
+			return err
+		}
+
+		original.CorrectedByID = &correction.ID
+		return repo.Update(ctx, original)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.VoucherCorrection{Original: original, Reversal: reversal, Correction: correction}, nil
+}
+
+// ProcessAutoReversals generates reversals for auto-reversing vouchers whose next period has opened.
+// Each reversal is dated the first day of that period and posted on behalf of the original poster.
+// Vouchers whose reversal falls in a closed period wait until it is reopened.
+func (s *voucherService) ProcessAutoReversals(ctx context.Context, asOf time.Time) ([]domain.Voucher, error) {
+	periodStart := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, asOf.Location())
+
+	pending, err := s.voucherRepo.FindPendingAutoReversals(ctx, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var reversals []domain.Voucher
+	var errs []error
+	for i := range pending {
+		original := &pending[i]
+		if !original.NeedsAutoReversal(asOf) {
+			continue
+		}
+		reversalDate := original.AutoReversalDate()
+		open, err := s.periodOpen(ctx, original.CompanyID, reversalDate)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("voucher %s: %w", original.ID, err))
+			continue
+		}
+		if !open {
+			continue
+		}
+
+		userID := uuid.Nil
+		if original.PostedBy != nil {
+			userID = *original.PostedBy
+		} else if original.CreatedBy != nil {
+			userID = *original.CreatedBy
+		}
+
+		// Reversal of a posted accrual is posted immediately; the original
+		// stays pending unless both succeed
+		description := fmt.Sprintf("자동 역분개: %s", original.VoucherNo)
+		var reversal *domain.Voucher
+		err = s.voucherRepo.WithTransaction(ctx, func(repo repository.VoucherRepository) error {
+			tx := *s
+			tx.voucherRepo = repo
+
+			if err := tx.lockForReversal(ctx, original); err != nil {
+				return err
+			}
+			var err error
+			reversal, err = tx.createReversal(ctx, original, userID, reversalDate, description, original.RemainingReversalLines())
+			if err != nil {
+				return err
+			}
+			return tx.postGenerated(ctx, reversal, userID)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("voucher %s: %w", original.ID, err))
+			continue
+		}
+		if err := s.updateBalances(ctx, reversal); err != nil {
+			errs = append(errs, err)
+		}
+
+		reversals = append(reversals, *reversal)
+	}
+
+	return reversals, errors.Join(errs...)
+}
+
+// lockForReversal re-reads the original inside the transaction with its row
+// locked, so that concurrent reversals of it wait for each other and each
+// checks what the others left, and checks that it can still be reversed
+func (s *voucherService) lockForReversal(ctx context.Context, original *domain.Voucher) error {
+	locked, err := s.voucherRepo.FindByIDForUpdate(ctx, original.CompanyID, original.ID)
+	if err != nil {
+		return err
+	}
+	if !locked.Status.CanReverse() {
+		return domain.ErrVoucherCannotReverse
+	}
+	if locked.ReversedByID != nil {
+		return domain.ErrVoucherAlreadyReversed
+	}
+	*original = *locked
+	return nil
+}
+
+// createReversal creates a reversal voucher for the original and links both
+// ways. It runs in a transaction after lockForReversal. No reversal is dated
+// into a fiscal year locked after its audit.
+func (s *voucherService) createReversal(ctx context.Context, original *domain.Voucher, userID uuid.UUID, reversalDate time.Time, description string, lines []domain.ReversalLine) (*domain.Voucher, error) {
+	if err := s.checkAuditLock(ctx, original.CompanyID, reversalDate.Year()); err != nil {
+		return nil, err
+	}
+
+	// Create reversed entries (swap debit and credit)
+	entries, err := original.ReversalEntries(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create reversal voucher, tagged like the original so that tag analyses net out
+	reversal := &domain.Voucher{
+		TenantModel: domain.TenantModel{
+			CompanyID: original.CompanyID,
+		},
 		VoucherDate:   reversalDate,
 		VoucherType:   original.VoucherType,
 		Status:        domain.VoucherStatusDraft,
 		Description:   description,
 		IsReversal:    true,
 		ReversalOfID:  &original.ID,
+		Tags:          original.Tags,
 		CreatedBy:     &userID,
-	}
-
-	// Create reversed entries (swap debit and credit)
-	for _, entry := range original.Entries {
-		reversalEntry := domain.VoucherEntry{
-			CompanyID:    companyID,
-			AccountID:    entry.AccountID,
-			DebitAmount:  entry.CreditAmount,  // Swap
-			CreditAmount: entry.DebitAmount,   // Swap
-			Description:  entry.Description,
-			PartnerID:    entry.PartnerID,
-			DepartmentID: entry.DepartmentID,
-			ProjectID:    entry.ProjectID,
-			CostCenterID: entry.CostCenterID,
-		}
-		reversal.Entries = append(reversal.Entries, reversalEntry)
+		Entries:       entries,
 	}
 
 	// Create the reversal voucher
@@ -385,27 +995,76 @@ func (s *voucherService) Reverse(ctx context.Context, companyID, voucherID, user
 		return nil, err
 	}
 
-	// Update original voucher to reference the reversal
-	original.ReversedByID = &reversal.ID
-	if err := s.voucherRepo.Update(ctx, original); err != nil {
+	// Update original voucher with the reversed amounts, referencing the
+	// reversal once it is reversed in full
+	if original.IsFullyReversed() {
+		original.ReversedByID = &reversal.ID
+	}
+	if err := s.voucherRepo.UpdateReversedAmounts(ctx, original); err != nil {
 		return nil, err
 	}
 
 	return reversal, nil
 }
 
+// PostGenerated creates the voucher and posts it in an open period
+func (s *voucherService) PostGenerated(ctx context.Context, voucher *domain.Voucher, userID uuid.UUID) error {
+	open, err := s.periodOpen(ctx, voucher.CompanyID, voucher.VoucherDate)
+	if err != nil {
+		return err
+	}
+	if !open {
+		return domain.ErrFiscalPeriodClosed
+	}
+	err = s.voucherRepo.WithTransaction(ctx, func(repo repository.VoucherRepository) error {
+		tx := *s
+		tx.voucherRepo = repo
+
+		if err := tx.Create(ctx, voucher); err != nil {
+			return err
+		}
+		return tx.postGenerated(ctx, voucher, userID)
+	})
+	if err != nil {
+		return err
+	}
+	return s.updateBalances(ctx, voucher)
+}
+
+// postGenerated moves a generated voucher, such as a reversal, through
+// submit, approve and post. The caller updates the ledger balances once the
+// voucher is committed.
+func (s *voucherService) postGenerated(ctx context.Context, voucher *domain.Voucher, userID uuid.UUID) error {
+	steps := []func(uuid.UUID) error{voucher.Submit, voucher.Approve, voucher.Post}
+	for _, step := range steps {
+		if err := step(userID); err != nil {
+			return err
+		}
+		if err := s.voucherRepo.UpdateStatus(ctx, voucher); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ValidateEntries validates all entries for a voucher
-func (s *voucherService) ValidateEntries(ctx context.Context, companyID uuid.UUID, entries []domain.VoucherEntry) error {
+func (s *voucherService) ValidateEntries(ctx context.Context, companyID uuid.UUID, voucherType domain.VoucherType, entries []domain.VoucherEntry) error {
 	var totalDebit, totalCredit float64
 
-	for _, entry := range entries {
+	for i, entry := range entries {
 		// Validate entry
 		if err := entry.Validate(); err != nil {
 			return err
 		}
 
 		// Validate account can accept postings
-		if err := s.validateAccountForPosting(ctx, companyID, entry.AccountID); err != nil {
+		account, err := s.validateAccountForPosting(ctx, companyID, entry.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// Enforce account posting rules
+		if err := checkPostingRules(account, &entry, i+1, voucherType); err != nil {
 			return err
 		}
 
@@ -421,16 +1080,122 @@ func (s *voucherService) ValidateEntries(ctx context.Context, companyID uuid.UUI
 	return nil
 }
 
+// checkBankAccounts checks the bank accounts named by the entries of a
+// voucher and assigns the bank account paid from to the credit entries of a
+// payment when their cash account has only one. Reversals, corrections and
+// vouchers generated from a source document need not name one.
+func (s *voucherService) checkBankAccounts(ctx context.Context, voucher *domain.Voucher, entries []domain.VoucherEntry) error {
+	if s.banks == nil {
+		return nil
+	}
+
+	accountIDs := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		accountIDs = append(accountIDs, entry.AccountID)
+	}
+	banks, err := s.banks.FindByAccounts(ctx, voucher.CompanyID, accountIDs)
+	if err != nil {
+		return err
+	}
+
+	required := voucher.ReferenceType == "" && !voucher.IsReversal && voucher.CorrectionOfID == nil
+	return domain.AssignEntryBankAccounts(voucher.VoucherType, entries, banks, required)
+}
+
 // validateAccountForPosting checks if an account can accept postings
-func (s *voucherService) validateAccountForPosting(ctx context.Context, companyID, accountID uuid.UUID) error {
+func (s *voucherService) validateAccountForPosting(ctx context.Context, companyID, accountID uuid.UUID) (*domain.Account, error) {
 	account, err := s.accountRepo.FindByID(ctx, companyID, accountID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !account.CanPost() {
-		return domain.ErrControlAccountPosting
+		return nil, domain.ErrControlAccountPosting
 	}
 
+	return account, nil
+}
+
+// checkPostingRules validates an entry against the posting rules of its account
+func checkPostingRules(account *domain.Account, entry *domain.VoucherEntry, lineNo int, voucherType domain.VoucherType) error {
+	if account.PostingRules == nil {
+		return nil
+	}
+	if err := account.PostingRules.Check(entry, voucherType); err != nil {
+		return &domain.PostingRuleViolation{LineNo: lineNo, AccountCode: account.Code, Err: err}
+	}
 	return nil
 }
+
+// roundEntryAmounts rounds entry amounts to the company's decimal places
+func roundEntryAmounts(entries []domain.VoucherEntry, settings domain.CompanySettings) []domain.VoucherEntry {
+	for i := range entries {
+		entries[i].DebitAmount = settings.RoundAmount(entries[i].DebitAmount)
+		entries[i].CreditAmount = settings.RoundAmount(entries[i].CreditAmount)
+	}
+	return entries
+}
+
+// applyTaxCodes expands entries that reference a tax code. The entry amount is treated as
+// tax-inclusive: it is reduced to the supply amount and a VAT line on the tax code's VAT
+// account is added on the same side, so the voucher stays balanced. VAT is rounded with the
+// company's rounding rule. Entries that already contain generated VAT lines (e.g., reversals)
+// are returned unchanged.
+func (s *voucherService) applyTaxCodes(ctx context.Context, companyID uuid.UUID, entries []domain.VoucherEntry, settings domain.CompanySettings) ([]domain.VoucherEntry, error) {
+	for _, entry := range entries {
+		if entry.IsTaxLine {
+			return entries, nil
+		}
+	}
+
+	taxCodes := make(map[uuid.UUID]*domain.TaxCode)
+	result := make([]domain.VoucherEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.TaxCodeID == nil {
+			result = append(result, entry)
+			continue
+		}
+
+		taxCode, ok := taxCodes[*entry.TaxCodeID]
+		if !ok {
+			var err error
+			taxCode, err = s.taxCodeRepo.FindByID(ctx, companyID, *entry.TaxCodeID)
+			if err != nil {
+				return nil, err
+			}
+			if !taxCode.IsActive {
+				return nil, domain.ErrTaxCodeInactive
+			}
+			taxCodes[*entry.TaxCodeID] = taxCode
+		}
+
+		supply, tax := taxCode.SplitInclusiveFor(entry.GetAmount(), settings)
+		entry.TaxAmount = tax
+		if !taxCode.GeneratesVATLine() || tax == 0 {
+			// Non-deductible input VAT remains part of the cost
+			result = append(result, entry)
+			continue
+		}
+
+		taxLine := domain.VoucherEntry{
+			CompanyID:    entry.CompanyID,
+			AccountID:    *taxCode.VATAccountID,
+			Description:  entry.Description,
+			PartnerID:    entry.PartnerID,
+			DepartmentID: entry.DepartmentID,
+			TaxCodeID:    entry.TaxCodeID,
+			TaxAmount:    tax,
+			IsTaxLine:    true,
+		}
+		if entry.IsDebit() {
+			entry.SetDebit(supply)
+			taxLine.SetDebit(tax)
+		} else {
+			entry.SetCredit(supply)
+			taxLine.SetCredit(tax)
+		}
+		result = append(result, entry, taxLine)
+	}
+
+	return result, nil
+}
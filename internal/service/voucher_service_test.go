@@ -24,10 +24,16 @@ import (
 func newTestVoucherService() (*mocks.MockVoucherRepository, *mocks.MockAccountRepository, service.VoucherService) {
 	voucherRepo := new(mocks.MockVoucherRepository)
 	accountRepo := new(mocks.MockAccountRepository)
-	svc := service.NewVoucherService(voucherRepo, accountRepo)
+	svc := service.NewVoucherService(voucherRepo, accountRepo, new(mocks.MockTaxCodeRepository), newTestSettingsService(domain.DefaultCompanySettings()), nil, nil, nil)
 	return voucherRepo, accountRepo, svc
 }
 
+func newTestSettingsService(settings domain.CompanySettings) *mocks.MockCompanySettingsService {
+	settingsService := new(mocks.MockCompanySettingsService)
+	settingsService.On("Get", mock.Anything, mock.Anything).Return(settings, nil).Maybe()
+	return settingsService
+}
+
 func newTestCompanyID() uuid.UUID {
 	return uuid.MustParse("00000000-0000-0000-0000-000000000001")
 }
@@ -71,6 +77,7 @@ func newTestVoucher(companyID uuid.UUID) *domain.Voucher {
 		Status:      domain.VoucherStatusDraft,
 		Entries: []domain.VoucherEntry{
 			{
+				BaseModel:    domain.BaseModel{ID: uuid.New()},
 				CompanyID:    companyID,
 				AccountID:    accountID1,
 				DebitAmount:  1000,
@@ -78,6 +85,7 @@ func newTestVoucher(companyID uuid.UUID) *domain.Voucher {
 				Description:  "Debit entry",
 			},
 			{
+				BaseModel:    domain.BaseModel{ID: uuid.New()},
 				CompanyID:    companyID,
 				AccountID:    accountID2,
 				DebitAmount:  0,
@@ -105,6 +113,9 @@ func TestVoucherService_Create(t *testing.T) {
 			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(account, nil).Once()
 		}
 
+		// Mock duplicate check
+		voucherRepo.On("FindAll", ctx, mock.AnythingOfType("repository.VoucherFilter")).Return([]domain.Voucher{}, int64(0), nil).Once()
+
 		// Mock voucher number generation
 		voucherRepo.On("GenerateVoucherNo", ctx, companyID, voucher.VoucherType, mock.AnythingOfType("time.Time")).
 			Return("GEN-2024-0001", nil).Once()
@@ -123,6 +134,99 @@ func TestVoucherService_Create(t *testing.T) {
 		accountRepo.AssertExpectations(t)
 	})
 
+	t.Run("splits tax-coded entry into supply and VAT lines", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		accountRepo := new(mocks.MockAccountRepository)
+		taxCodeRepo := new(mocks.MockTaxCodeRepository)
+		svc := service.NewVoucherService(voucherRepo, accountRepo, taxCodeRepo, newTestSettingsService(domain.DefaultCompanySettings()), nil, nil, nil)
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		voucher := newTestVoucher(companyID)
+		voucher.VoucherType = domain.VoucherTypeSales
+
+		vatAccountID := uuid.New()
+		taxCode := &domain.TaxCode{
+			TenantModel:  domain.TenantModel{BaseModel: domain.BaseModel{ID: uuid.New()}, CompanyID: companyID},
+			Code:         "S10",
+			Name:         "과세매출 10%",
+			TaxType:      domain.TaxTypeOutput,
+			TaxCategory:  domain.TaxCategoryTaxable,
+			Rate:         10,
+			VATAccountID: &vatAccountID,
+			IsActive:     true,
+		}
+		voucher.Entries[0].DebitAmount = 11000
+		voucher.Entries[1].CreditAmount = 11000
+		voucher.Entries[1].TaxCodeID = &taxCode.ID
+
+		taxCodeRepo.On("FindByID", ctx, companyID, taxCode.ID).Return(taxCode, nil).Once()
+		for _, accountID := range []uuid.UUID{voucher.Entries[0].AccountID, voucher.Entries[1].AccountID, vatAccountID} {
+			accountRepo.On("FindByID", ctx, companyID, accountID).Return(newTestAccount(companyID, accountID), nil).Once()
+		}
+		voucherRepo.On("FindAll", ctx, mock.AnythingOfType("repository.VoucherFilter")).Return([]domain.Voucher{}, int64(0), nil).Once()
+		voucherRepo.On("GenerateVoucherNo", ctx, companyID, voucher.VoucherType, mock.AnythingOfType("time.Time")).
+			Return("SJ-2024-0001", nil).Once()
+		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+
+		err := svc.Create(ctx, voucher)
+
+		require.NoError(t, err)
+		require.Len(t, voucher.Entries, 3)
+		assert.Equal(t, 10000.0, voucher.Entries[1].CreditAmount)
+		assert.Equal(t, 1000.0, voucher.Entries[1].TaxAmount)
+		assert.False(t, voucher.Entries[1].IsTaxLine)
+		assert.Equal(t, vatAccountID, voucher.Entries[2].AccountID)
+		assert.Equal(t, 1000.0, voucher.Entries[2].CreditAmount)
+		assert.True(t, voucher.Entries[2].IsTaxLine)
+		assert.Equal(t, 3, voucher.Entries[2].LineNo)
+		assert.Equal(t, voucher.TotalDebit, voucher.TotalCredit)
+		voucherRepo.AssertExpectations(t)
+		accountRepo.AssertExpectations(t)
+		taxCodeRepo.AssertExpectations(t)
+	})
+
+	t.Run("warns about and blocks suspected duplicates", func(t *testing.T) {
+		for _, mode := range []domain.DuplicateCheckMode{domain.DuplicateCheckWarn, domain.DuplicateCheckBlock} {
+			settings := domain.DefaultCompanySettings()
+			settings.DuplicateCheckMode = mode
+
+			voucherRepo := new(mocks.MockVoucherRepository)
+			accountRepo := new(mocks.MockAccountRepository)
+			svc := service.NewVoucherService(voucherRepo, accountRepo, new(mocks.MockTaxCodeRepository), newTestSettingsService(settings), nil, nil, nil)
+			ctx := context.Background()
+			companyID := newTestCompanyID()
+			voucher := newTestVoucher(companyID)
+
+			existing := newTestVoucher(companyID)
+			existing.VoucherNo = "GEN-2024-0001"
+			existing.Entries[0], existing.Entries[1] = existing.Entries[1], existing.Entries[0]
+			existing.CalculateTotals()
+
+			for _, entry := range voucher.Entries {
+				accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(newTestAccount(companyID, entry.AccountID), nil).Once()
+			}
+			voucherRepo.On("FindAll", ctx, mock.AnythingOfType("repository.VoucherFilter")).Return([]domain.Voucher{*existing}, int64(1), nil).Once()
+
+			if mode == domain.DuplicateCheckBlock {
+				err := svc.Create(ctx, voucher)
+
+				var duplicate *domain.DuplicateVoucherError
+				require.ErrorAs(t, err, &duplicate)
+				assert.Equal(t, "GEN-2024-0001", duplicate.Duplicates[0].VoucherNo)
+				continue
+			}
+
+			voucherRepo.On("GenerateVoucherNo", ctx, companyID, voucher.VoucherType, mock.AnythingOfType("time.Time")).
+				Return("GEN-2024-0002", nil).Once()
+			voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+
+			require.NoError(t, svc.Create(ctx, voucher))
+			require.Len(t, voucher.SuspectedDuplicates, 1)
+			assert.Equal(t, existing.ID, voucher.SuspectedDuplicates[0].ID)
+			voucherRepo.AssertExpectations(t)
+		}
+	})
+
 	t.Run("fails with no entries", func(t *testing.T) {
 		_, _, svc := newTestVoucherService()
 		ctx := context.Background()
@@ -214,6 +318,8 @@ func TestVoucherService_Create(t *testing.T) {
 			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(account, nil).Once()
 		}
 
+		voucherRepo.On("FindAll", ctx, mock.AnythingOfType("repository.VoucherFilter")).Return([]domain.Voucher{}, int64(0), nil).Once()
+
 		// Mock voucher number generation failure
 		genErr := errors.New("sequence error")
 		voucherRepo.On("GenerateVoucherNo", ctx, companyID, voucher.VoucherType, mock.AnythingOfType("time.Time")).
@@ -323,6 +429,48 @@ func TestVoucherService_Delete(t *testing.T) {
 	})
 }
 
+func TestVoucherService_SetTags(t *testing.T) {
+	t.Run("tags a posted voucher", func(t *testing.T) {
+		voucherRepo, _, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		voucherID := uuid.New()
+
+		existingVoucher := newTestVoucher(companyID)
+		existingVoucher.ID = voucherID
+		existingVoucher.Status = domain.VoucherStatusPosted
+
+		voucherRepo.On("FindByID", ctx, companyID, voucherID).Return(existingVoucher, nil).Once()
+		voucherRepo.On("UpdateTags", ctx, mock.MatchedBy(func(v *domain.Voucher) bool {
+			return len(v.Tags) == 2 && v.Tags[0] == "audit-adjustment" && v.Tags[1] == "covid-subsidy"
+		})).Return(nil).Once()
+
+		voucher, err := svc.SetTags(ctx, companyID, voucherID, userID, []string{"audit-adjustment", " covid-subsidy", "audit-adjustment"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &userID, voucher.UpdatedBy)
+		voucherRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid tag", func(t *testing.T) {
+		voucherRepo, _, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		voucherID := uuid.New()
+
+		existingVoucher := newTestVoucher(companyID)
+		existingVoucher.ID = voucherID
+
+		voucherRepo.On("FindByID", ctx, companyID, voucherID).Return(existingVoucher, nil).Once()
+
+		_, err := svc.SetTags(ctx, companyID, voucherID, newTestUserID(), []string{"a;b"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidVoucherTagName)
+		voucherRepo.AssertNotCalled(t, "UpdateTags")
+	})
+}
+
 // ============================================================================
 // Workflow Tests
 // ============================================================================
@@ -366,6 +514,43 @@ func TestVoucherService_Submit(t *testing.T) {
 
 		assert.Equal(t, domain.ErrVoucherCannotSubmit, err)
 	})
+
+	t.Run("posts voucher covered by the approval exemption", func(t *testing.T) {
+		settings := domain.DefaultCompanySettings()
+		settings.ApprovalExemption = &domain.ApprovalExemption{
+			Enabled:      true,
+			MaxAmount:    5000,
+			VoucherTypes: []domain.VoucherType{domain.VoucherTypeGeneral},
+		}
+
+		voucherRepo := new(mocks.MockVoucherRepository)
+		svc := service.NewVoucherService(voucherRepo, new(mocks.MockAccountRepository), new(mocks.MockTaxCodeRepository), newTestSettingsService(settings), nil, nil, nil)
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		voucherID := uuid.New()
+
+		existingVoucher := newTestVoucher(companyID)
+		existingVoucher.ID = voucherID
+		existingVoucher.CalculateTotals()
+
+		var statuses []domain.VoucherStatus
+		voucherRepo.On("FindByID", ctx, companyID, voucherID).Return(existingVoucher, nil).Once()
+		voucherRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*domain.Voucher")).
+			Run(func(args mock.Arguments) {
+				statuses = append(statuses, args.Get(1).(*domain.Voucher).Status)
+			}).Return(nil).Twice()
+
+		err := svc.Submit(ctx, companyID, voucherID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.VoucherStatus{domain.VoucherStatusPending, domain.VoucherStatusPosted}, statuses)
+		assert.Equal(t, &userID, existingVoucher.SubmittedBy)
+		assert.Equal(t, &userID, existingVoucher.PostedBy)
+		assert.Nil(t, existingVoucher.ApprovedBy)
+		assert.NotEmpty(t, existingVoucher.ApprovalExemptReason)
+		voucherRepo.AssertExpectations(t)
+	})
 }
 
 func TestVoucherService_Approve(t *testing.T) {
@@ -407,6 +592,32 @@ func TestVoucherService_Approve(t *testing.T) {
 
 		assert.Equal(t, domain.ErrVoucherCannotApprove, err)
 	})
+
+	t.Run("rejects approval by the submitter under segregation of duties", func(t *testing.T) {
+		settings := domain.DefaultCompanySettings()
+		settings.EnforceSegregationOfDuties = true
+
+		voucherRepo := new(mocks.MockVoucherRepository)
+		svc := service.NewVoucherService(voucherRepo, new(mocks.MockAccountRepository), new(mocks.MockTaxCodeRepository), newTestSettingsService(settings), nil, nil, nil)
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		voucherID := uuid.New()
+
+		existingVoucher := newTestVoucher(companyID)
+		existingVoucher.ID = voucherID
+		existingVoucher.Status = domain.VoucherStatusPending
+		existingVoucher.SubmittedBy = &userID
+
+		voucherRepo.On("FindByID", ctx, companyID, voucherID).Return(existingVoucher, nil)
+
+		assert.Equal(t, domain.ErrSegregationOfDuties, svc.Approve(ctx, companyID, voucherID, userID))
+		assert.Equal(t, domain.VoucherStatusPending, existingVoucher.Status)
+
+		existingVoucher.Status = domain.VoucherStatusApproved
+		assert.Equal(t, domain.ErrSegregationOfDuties, svc.Post(ctx, companyID, voucherID, userID))
+		voucherRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
 }
 
 func TestVoucherService_Reject(t *testing.T) {
@@ -473,6 +684,91 @@ func TestVoucherService_Post(t *testing.T) {
 
 		assert.Equal(t, domain.ErrVoucherCannotPost, err)
 	})
+
+	t.Run("posts draft voucher directly when approval is not required", func(t *testing.T) {
+		requireApproval := false
+		settings := domain.DefaultCompanySettings()
+		settings.RequireApproval = &requireApproval
+
+		voucherRepo := new(mocks.MockVoucherRepository)
+		svc := service.NewVoucherService(voucherRepo, new(mocks.MockAccountRepository), new(mocks.MockTaxCodeRepository), newTestSettingsService(settings), nil, nil, nil)
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		voucherID := uuid.New()
+
+		existingVoucher := newTestVoucher(companyID)
+		existingVoucher.ID = voucherID
+		existingVoucher.Status = domain.VoucherStatusDraft
+		existingVoucher.CalculateTotals()
+
+		voucherRepo.On("FindByID", ctx, companyID, voucherID).Return(existingVoucher, nil).Once()
+		voucherRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+
+		err := svc.Post(ctx, companyID, voucherID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.VoucherStatusPosted, existingVoucher.Status)
+		assert.Equal(t, &userID, existingVoucher.PostedBy)
+		voucherRepo.AssertExpectations(t)
+	})
+}
+
+type recalculation struct {
+	companyID           uuid.UUID
+	accountIDs          []uuid.UUID
+	fromYear, fromMonth int
+}
+
+// recordingLedger records the balance recalculations requested by posting;
+// the periods listed in closed are closed, the others open
+type recordingLedger struct {
+	calls  []recalculation
+	closed map[int]bool // by year*100+month
+	locked map[int]bool // audit locked years
+}
+
+func (r *recordingLedger) RecalculateAccountBalances(ctx context.Context, companyID uuid.UUID, accountIDs []uuid.UUID, fromYear, fromMonth int) error {
+	r.calls = append(r.calls, recalculation{companyID, accountIDs, fromYear, fromMonth})
+	return nil
+}
+
+func (r *recordingLedger) GetFiscalPeriod(ctx context.Context, companyID uuid.UUID, year, month int) (*domain.FiscalPeriod, error) {
+	status := domain.FiscalPeriodOpen
+	if r.closed[year*100+month] {
+		status = domain.FiscalPeriodClosed
+	}
+	return &domain.FiscalPeriod{CompanyID: companyID, FiscalYear: year, FiscalMonth: month, Status: status}, nil
+}
+
+func (r *recordingLedger) IsFiscalYearAuditLocked(ctx context.Context, companyID uuid.UUID, year int) (bool, error) {
+	return r.locked[year], nil
+}
+
+func TestVoucherService_Post_RecalculatesPostedAccounts(t *testing.T) {
+	voucherRepo := new(mocks.MockVoucherRepository)
+	balances := &recordingLedger{}
+	svc := service.NewVoucherService(voucherRepo, new(mocks.MockAccountRepository), new(mocks.MockTaxCodeRepository), newTestSettingsService(domain.DefaultCompanySettings()), balances, nil, nil)
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+
+	voucher := newTestVoucher(companyID)
+	voucher.Status = domain.VoucherStatusApproved
+	voucher.VoucherDate = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	// A second line on the first account is recalculated once
+	voucher.Entries = append(voucher.Entries, domain.VoucherEntry{AccountID: voucher.Entries[0].AccountID, DebitAmount: 100})
+
+	voucherRepo.On("FindByID", ctx, companyID, voucher.ID).Return(voucher, nil).Once()
+	voucherRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+
+	require.NoError(t, svc.Post(ctx, companyID, voucher.ID, newTestUserID()))
+	require.Len(t, balances.calls, 1)
+	assert.Equal(t, recalculation{
+		companyID:  companyID,
+		accountIDs: []uuid.UUID{voucher.Entries[0].AccountID, voucher.Entries[1].AccountID},
+		fromYear:   2025,
+		fromMonth:  3,
+	}, balances.calls[0])
 }
 
 func TestVoucherService_Cancel(t *testing.T) {
@@ -534,6 +830,7 @@ func TestVoucherService_FullWorkflow(t *testing.T) {
 			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(account, nil).Once()
 		}
 
+		voucherRepo.On("FindAll", ctx, mock.AnythingOfType("repository.VoucherFilter")).Return([]domain.Voucher{}, int64(0), nil).Once()
 		voucherRepo.On("GenerateVoucherNo", ctx, companyID, voucher.VoucherType, mock.AnythingOfType("time.Time")).
 			Return("GEN-2024-0001", nil).Once()
 		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
@@ -588,8 +885,10 @@ func TestVoucherService_Reverse(t *testing.T) {
 		originalVoucher.Status = domain.VoucherStatusPosted
 		originalVoucher.VoucherNo = "GEN-2024-0001"
 
-		// Find original
+		// Find original, then again locked in the transaction
 		voucherRepo.On("FindByID", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
 
 		// Validate accounts for reversal entries
 		for _, entry := range originalVoucher.Entries {
@@ -605,7 +904,7 @@ func TestVoucherService_Reverse(t *testing.T) {
 		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
 
 		// Update original to reference reversal
-		voucherRepo.On("Update", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateReversedAmounts", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
 
 		reversal, err := svc.Reverse(ctx, companyID, originalVoucher.ID, userID, reversalDate, description)
 
@@ -656,6 +955,343 @@ func TestVoucherService_Reverse(t *testing.T) {
 
 		assert.Equal(t, domain.ErrVoucherAlreadyReversed, err)
 	})
+
+	t.Run("fails to reverse into an audit locked year", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		accountRepo := new(mocks.MockAccountRepository)
+		ledger := &recordingLedger{locked: map[int]bool{2025: true}}
+		svc := service.NewVoucherService(voucherRepo, accountRepo, new(mocks.MockTaxCodeRepository), newTestSettingsService(domain.DefaultCompanySettings()), ledger, nil, nil)
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+
+		originalVoucher := newTestVoucher(companyID)
+		originalVoucher.Status = domain.VoucherStatusPosted
+
+		voucherRepo.On("FindByID", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Twice()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Twice()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Twice()
+
+		_, err := svc.Reverse(ctx, companyID, originalVoucher.ID, newTestUserID(), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), "Reversal")
+		assert.Equal(t, domain.ErrFiscalYearAuditLocked, err)
+
+		for _, entry := range originalVoucher.Entries {
+			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(newTestAccount(companyID, entry.AccountID), nil).Once()
+		}
+		voucherRepo.On("GenerateVoucherNo", ctx, companyID, originalVoucher.VoucherType, mock.AnythingOfType("time.Time")).
+			Return("GEN-2026-0001", nil).Once()
+		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateReversedAmounts", ctx, originalVoucher).Return(nil).Once()
+
+		_, err = svc.Reverse(ctx, companyID, originalVoucher.ID, newTestUserID(), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), "Reversal")
+		require.NoError(t, err)
+		voucherRepo.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_PartialReverse(t *testing.T) {
+	t.Run("reverses a share of posted voucher", func(t *testing.T) {
+		voucherRepo, accountRepo, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+
+		originalVoucher := newTestVoucher(companyID)
+		originalVoucher.Status = domain.VoucherStatusPosted
+		originalVoucher.VoucherNo = "GEN-2024-0001"
+
+		voucherRepo.On("FindByID", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+		for _, entry := range originalVoucher.Entries {
+			account := newTestAccount(companyID, entry.AccountID)
+			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(account, nil).Once()
+		}
+		voucherRepo.On("GenerateVoucherNo", ctx, companyID, originalVoucher.VoucherType, mock.AnythingOfType("time.Time")).
+			Return("GEN-2024-0002", nil).Once()
+		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateReversedAmounts", ctx, originalVoucher).Return(nil).Once()
+
+		reversal, err := svc.PartialReverse(ctx, companyID, originalVoucher.ID, userID, time.Now(), "", 0.25, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "부분 역분개: GEN-2024-0001", reversal.Description)
+		assert.Equal(t, 250.0, reversal.TotalDebit)
+		assert.Equal(t, 250.0, originalVoucher.ReversedAmount)
+		assert.Nil(t, originalVoucher.ReversedByID, "voucher is not fully reversed")
+
+		voucherRepo.AssertExpectations(t)
+		accountRepo.AssertExpectations(t)
+	})
+
+	t.Run("blocks reversing more than was posted", func(t *testing.T) {
+		voucherRepo, _, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+
+		originalVoucher := newTestVoucher(companyID)
+		originalVoucher.Status = domain.VoucherStatusPosted
+		originalVoucher.Entries[0].ReversedAmount = 800
+		originalVoucher.Entries[1].ReversedAmount = 800
+
+		voucherRepo.On("FindByID", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+
+		_, err := svc.PartialReverse(ctx, companyID, originalVoucher.ID, newTestUserID(), time.Now(), "", 0.5, nil)
+
+		assert.Equal(t, domain.ErrVoucherOverReversal, err)
+		voucherRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("checks what concurrent reversals left", func(t *testing.T) {
+		voucherRepo, _, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+
+		// Read before another partial reversal of 80% committed
+		stale := newTestVoucher(companyID)
+		stale.Status = domain.VoucherStatusPosted
+		current := *stale
+		current.Entries = append([]domain.VoucherEntry(nil), stale.Entries...)
+		current.Entries[0].ReversedAmount = 800
+		current.Entries[1].ReversedAmount = 800
+		current.ReversedAmount = 800
+
+		voucherRepo.On("FindByID", ctx, companyID, stale.ID).Return(stale, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, stale.ID).Return(&current, nil).Once()
+
+		_, err := svc.PartialReverse(ctx, companyID, stale.ID, newTestUserID(), time.Now(), "", 0.5, nil)
+
+		assert.Equal(t, domain.ErrVoucherOverReversal, err)
+		voucherRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		voucherRepo.AssertNotCalled(t, "UpdateReversedAmounts", mock.Anything, mock.Anything)
+	})
+}
+
+func TestVoucherService_Correct(t *testing.T) {
+	t.Run("reverses posted voucher and creates corrected draft", func(t *testing.T) {
+		voucherRepo, accountRepo, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		correctionDate := time.Now()
+
+		originalVoucher := newTestVoucher(companyID)
+		originalVoucher.Status = domain.VoucherStatusPosted
+		originalVoucher.VoucherNo = "GEN-2024-0001"
+		originalVoucher.Description = "Office supplies"
+
+		voucherRepo.On("FindByID", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+
+		// Accounts are validated for the reversal and the correction
+		for _, entry := range originalVoucher.Entries {
+			account := newTestAccount(companyID, entry.AccountID)
+			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(account, nil).Twice()
+		}
+		voucherRepo.On("GenerateVoucherNo", ctx, companyID, originalVoucher.VoucherType, mock.AnythingOfType("time.Time")).
+			Return("GEN-2024-0002", nil).Twice()
+		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).
+			Run(func(args mock.Arguments) { args.Get(1).(*domain.Voucher).ID = uuid.New() }).
+			Return(nil).Twice()
+
+		// Original updated with the reversal, then with the correction
+		voucherRepo.On("UpdateReversedAmounts", ctx, originalVoucher).Return(nil).Once()
+		voucherRepo.On("Update", ctx, originalVoucher).Return(nil).Once()
+
+		correction, err := svc.Correct(ctx, companyID, originalVoucher.ID, userID, correctionDate, "", nil)
+
+		require.NoError(t, err)
+		assert.True(t, correction.Reversal.IsReversal)
+		assert.Equal(t, &originalVoucher.ID, correction.Reversal.ReversalOfID)
+		assert.Equal(t, &correction.Reversal.ID, originalVoucher.ReversedByID)
+
+		assert.Equal(t, domain.VoucherStatusDraft, correction.Correction.Status)
+		assert.Equal(t, &originalVoucher.ID, correction.Correction.CorrectionOfID)
+		assert.Equal(t, &correction.Correction.ID, originalVoucher.CorrectedByID)
+		assert.Equal(t, "Office supplies", correction.Correction.Description)
+		require.Len(t, correction.Correction.Entries, len(originalVoucher.Entries))
+		assert.Equal(t, originalVoucher.Entries[0].DebitAmount, correction.Correction.Entries[0].DebitAmount)
+
+		voucherRepo.AssertExpectations(t)
+		accountRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails to correct already reversed voucher", func(t *testing.T) {
+		voucherRepo, _, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		reversalID := uuid.New()
+
+		originalVoucher := newTestVoucher(companyID)
+		originalVoucher.Status = domain.VoucherStatusPosted
+		originalVoucher.ReversedByID = &reversalID
+
+		voucherRepo.On("FindByID", ctx, companyID, originalVoucher.ID).Return(originalVoucher, nil).Once()
+
+		_, err := svc.Correct(ctx, companyID, originalVoucher.ID, newTestUserID(), time.Now(), "", nil)
+
+		assert.Equal(t, domain.ErrVoucherAlreadyReversed, err)
+	})
+}
+
+func TestVoucherService_ProcessScheduledPostings(t *testing.T) {
+	voucherRepo := new(mocks.MockVoucherRepository)
+	ledger := &recordingLedger{closed: map[int]bool{202502: true}}
+	svc := service.NewVoucherService(voucherRepo, new(mocks.MockAccountRepository), new(mocks.MockTaxCodeRepository), newTestSettingsService(domain.DefaultCompanySettings()), ledger, nil, nil)
+	ctx := context.Background()
+	companyID := newTestCompanyID()
+	userID := newTestUserID()
+	asOf := time.Date(2025, 3, 20, 6, 0, 0, 0, time.UTC)
+	postDate := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	scheduled := func(voucherDate time.Time) domain.Voucher {
+		v := newTestVoucher(companyID)
+		v.Status = domain.VoucherStatusApproved
+		v.VoucherDate = voucherDate
+		v.ScheduledPostDate = &postDate
+		v.ScheduledBy = &userID
+		return *v
+	}
+	open := scheduled(time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC))
+	closed := scheduled(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+
+	voucherRepo.On("FindDueScheduledPostings", ctx, asOf).Return([]domain.Voucher{closed, open}, nil).Once()
+	voucherRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+
+	posted, err := svc.ProcessScheduledPostings(ctx, asOf)
+
+	require.NoError(t, err)
+	require.Len(t, posted, 1, "the voucher of the closed period waits")
+	assert.Equal(t, open.ID, posted[0].ID)
+	assert.Equal(t, domain.VoucherStatusPosted, posted[0].Status)
+	assert.Equal(t, &userID, posted[0].PostedBy)
+	require.Len(t, ledger.calls, 1)
+	voucherRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ProcessAutoReversals(t *testing.T) {
+	t.Run("posts reversal dated first day of next period", func(t *testing.T) {
+		voucherRepo, accountRepo, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		asOf := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+
+		accrual := newTestVoucher(companyID)
+		accrual.VoucherNo = "GEN-2024-0001"
+		accrual.VoucherDate = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		accrual.Status = domain.VoucherStatusPosted
+		accrual.PostedBy = &userID
+		accrual.AutoReverse = true
+
+		voucherRepo.On("FindPendingAutoReversals", ctx, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.Voucher{*accrual}, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, accrual.ID).Return(accrual, nil).Once()
+		for _, entry := range accrual.Entries {
+			account := newTestAccount(companyID, entry.AccountID)
+			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(account, nil).Once()
+		}
+		voucherRepo.On("GenerateVoucherNo", ctx, companyID, accrual.VoucherType, mock.AnythingOfType("time.Time")).
+			Return("GEN-2024-0002", nil).Once()
+		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateReversedAmounts", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Times(3)
+
+		reversals, err := svc.ProcessAutoReversals(ctx, asOf)
+
+		require.NoError(t, err)
+		require.Len(t, reversals, 1)
+		assert.True(t, reversals[0].IsReversal)
+		assert.Equal(t, &accrual.ID, reversals[0].ReversalOfID)
+		assert.Equal(t, domain.VoucherStatusPosted, reversals[0].Status)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), reversals[0].VoucherDate)
+		voucherRepo.AssertExpectations(t)
+		accountRepo.AssertExpectations(t)
+	})
+
+	t.Run("waits while the reversal period is closed", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		ledger := &recordingLedger{closed: map[int]bool{202402: true}}
+		svc := service.NewVoucherService(voucherRepo, new(mocks.MockAccountRepository), new(mocks.MockTaxCodeRepository), newTestSettingsService(domain.DefaultCompanySettings()), ledger, nil, nil)
+		ctx := context.Background()
+		asOf := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+
+		accrual := newTestVoucher(newTestCompanyID())
+		accrual.VoucherDate = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		accrual.Status = domain.VoucherStatusPosted
+		accrual.AutoReverse = true
+
+		voucherRepo.On("FindPendingAutoReversals", ctx, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.Voucher{*accrual}, nil).Once()
+
+		reversals, err := svc.ProcessAutoReversals(ctx, asOf)
+
+		require.NoError(t, err)
+		assert.Empty(t, reversals)
+		assert.Empty(t, ledger.calls)
+		voucherRepo.AssertNotCalled(t, "WithTransaction", mock.Anything, mock.Anything)
+		voucherRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		voucherRepo.AssertExpectations(t)
+	})
+
+	t.Run("leaves the voucher pending when posting the reversal fails", func(t *testing.T) {
+		voucherRepo, accountRepo := new(mocks.MockVoucherRepository), new(mocks.MockAccountRepository)
+		ledger := &recordingLedger{}
+		svc := service.NewVoucherService(voucherRepo, accountRepo, new(mocks.MockTaxCodeRepository), newTestSettingsService(domain.DefaultCompanySettings()), ledger, nil, nil)
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		userID := newTestUserID()
+		asOf := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+		postErr := errors.New("connection reset")
+
+		accrual := newTestVoucher(companyID)
+		accrual.VoucherNo = "GEN-2024-0001"
+		accrual.VoucherDate = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		accrual.Status = domain.VoucherStatusPosted
+		accrual.PostedBy = &userID
+		accrual.AutoReverse = true
+
+		voucherRepo.On("FindPendingAutoReversals", ctx, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.Voucher{*accrual}, nil).Once()
+		voucherRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		voucherRepo.On("FindByIDForUpdate", ctx, companyID, accrual.ID).Return(accrual, nil).Once()
+		for _, entry := range accrual.Entries {
+			accountRepo.On("FindByID", ctx, companyID, entry.AccountID).Return(newTestAccount(companyID, entry.AccountID), nil).Once()
+		}
+		voucherRepo.On("GenerateVoucherNo", ctx, companyID, accrual.VoucherType, mock.AnythingOfType("time.Time")).
+			Return("GEN-2024-0002", nil).Once()
+		voucherRepo.On("Create", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateReversedAmounts", ctx, mock.AnythingOfType("*domain.Voucher")).Return(nil).Once()
+		voucherRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*domain.Voucher")).Return(postErr).Once()
+
+		reversals, err := svc.ProcessAutoReversals(ctx, asOf)
+
+		// The transaction rolls back the reversal and the link on the original,
+		// so that the next run retries it
+		assert.ErrorIs(t, err, postErr)
+		assert.Empty(t, reversals)
+		assert.Empty(t, ledger.calls)
+		voucherRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips vouchers whose next period has not started", func(t *testing.T) {
+		voucherRepo, _, svc := newTestVoucherService()
+		ctx := context.Background()
+		asOf := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+
+		voucherRepo.On("FindPendingAutoReversals", ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.Voucher{}, nil).Once()
+
+		reversals, err := svc.ProcessAutoReversals(ctx, asOf)
+
+		require.NoError(t, err)
+		assert.Empty(t, reversals)
+		voucherRepo.AssertExpectations(t)
+	})
 }
 
 // ============================================================================
@@ -756,7 +1392,7 @@ func TestVoucherService_ValidateEntries(t *testing.T) {
 		accountRepo.On("FindByID", ctx, companyID, accountID1).Return(newTestAccount(companyID, accountID1), nil).Once()
 		accountRepo.On("FindByID", ctx, companyID, accountID2).Return(newTestAccount(companyID, accountID2), nil).Once()
 
-		err := svc.ValidateEntries(ctx, companyID, entries)
+		err := svc.ValidateEntries(ctx, companyID, domain.VoucherTypeGeneral, entries)
 
 		require.NoError(t, err)
 		accountRepo.AssertExpectations(t)
@@ -787,8 +1423,46 @@ func TestVoucherService_ValidateEntries(t *testing.T) {
 		accountRepo.On("FindByID", ctx, companyID, accountID1).Return(newTestAccount(companyID, accountID1), nil).Once()
 		accountRepo.On("FindByID", ctx, companyID, accountID2).Return(newTestAccount(companyID, accountID2), nil).Once()
 
-		err := svc.ValidateEntries(ctx, companyID, entries)
+		err := svc.ValidateEntries(ctx, companyID, domain.VoucherTypeGeneral, entries)
 
 		assert.Equal(t, domain.ErrVoucherUnbalanced, err)
 	})
+
+	t.Run("rejects entry violating account posting rules", func(t *testing.T) {
+		_, accountRepo, svc := newTestVoucherService()
+		ctx := context.Background()
+		companyID := newTestCompanyID()
+		accountID1 := uuid.New()
+		accountID2 := uuid.New()
+
+		entries := []domain.VoucherEntry{
+			{
+				CompanyID:    companyID,
+				AccountID:    accountID1,
+				DebitAmount:  1000,
+				CreditAmount: 0,
+			},
+			{
+				CompanyID:    companyID,
+				AccountID:    accountID2,
+				DebitAmount:  0,
+				CreditAmount: 1000, // No partner
+			},
+		}
+
+		receivable := newTestAccount(companyID, accountID2)
+		receivable.Code = "108"
+		receivable.PostingRules = &domain.PostingRules{RequirePartner: true}
+
+		accountRepo.On("FindByID", ctx, companyID, accountID1).Return(newTestAccount(companyID, accountID1), nil).Once()
+		accountRepo.On("FindByID", ctx, companyID, accountID2).Return(receivable, nil).Once()
+
+		err := svc.ValidateEntries(ctx, companyID, domain.VoucherTypeGeneral, entries)
+
+		var violation *domain.PostingRuleViolation
+		require.ErrorAs(t, err, &violation)
+		assert.Equal(t, 2, violation.LineNo)
+		assert.Equal(t, "108", violation.AccountCode)
+		assert.ErrorIs(t, err, domain.ErrPostingRulePartnerRequired)
+	})
 }
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/pdf"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// SignatureInput is the signature artifact submitted with an approve or post action
+type SignatureInput struct {
+	ImageData []byte // handwritten signature (JPEG or PNG)
+	PIN       string // signing PIN confirmation
+	IPAddress string
+	UserAgent string
+}
+
+// IsEmpty returns true if neither a signature image nor a PIN was submitted
+func (i *SignatureInput) IsEmpty() bool {
+	return i == nil || (len(i.ImageData) == 0 && i.PIN == "")
+}
+
+// VoucherSignatureService defines the interface for approval signature capture (전자서명)
+type VoucherSignatureService interface {
+	// Verify checks the submitted signature before the workflow action runs.
+	// It returns a nil signature when none was submitted and the company does not require one.
+	Verify(ctx context.Context, companyID, userID uuid.UUID, action domain.SignatureAction, input *SignatureInput) (*domain.VoucherSignature, error)
+
+	// Record persists a verified signature against the voucher as it stands after the action
+	Record(ctx context.Context, voucher *domain.Voucher, signature *domain.VoucherSignature) error
+
+	// GetByVoucher returns the voucher's signatures in signing order
+	GetByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherSignature, error)
+}
+
+// voucherSignatureService implements VoucherSignatureService
+type voucherSignatureService struct {
+	signatureRepo repository.VoucherSignatureRepository
+	userRepo      repository.UserRepository
+	companyRepo   repository.CompanyRepository
+}
+
+// NewVoucherSignatureService creates a new VoucherSignatureService
+func NewVoucherSignatureService(
+	signatureRepo repository.VoucherSignatureRepository,
+	userRepo repository.UserRepository,
+	companyRepo repository.CompanyRepository,
+) VoucherSignatureService {
+	return &voucherSignatureService{
+		signatureRepo: signatureRepo,
+		userRepo:      userRepo,
+		companyRepo:   companyRepo,
+	}
+}
+
+// Verify validates the signature image and confirms the signer's PIN
+func (s *voucherSignatureService) Verify(ctx context.Context, companyID, userID uuid.UUID, action domain.SignatureAction, input *SignatureInput) (*domain.VoucherSignature, error) {
+	if !action.IsValid() {
+		return nil, domain.ErrInvalidSignatureAction
+	}
+
+	if input.IsEmpty() {
+		company, err := s.companyRepo.FindByID(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		if company.Settings.RequireApprovalSignature {
+			return nil, domain.ErrSignatureRequired
+		}
+		return nil, nil
+	}
+
+	userAgent := input.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	signature := &domain.VoucherSignature{
+		TenantModel: domain.TenantModel{CompanyID: companyID},
+		Action:      action,
+		UserID:      userID,
+		IPAddress:   input.IPAddress,
+		UserAgent:   userAgent,
+	}
+
+	if len(input.ImageData) > 0 {
+		if len(input.ImageData) > domain.MaxSignatureImageSize {
+			return nil, domain.ErrSignatureImageSize
+		}
+		// Reject images the voucher print cannot embed
+		if _, err := pdf.New(pdf.A4Width, pdf.A4Height).AddImage(input.ImageData); err != nil {
+			return nil, domain.ErrSignatureImageFormat
+		}
+		signature.ImageData = input.ImageData
+		signature.ImageContentType = http.DetectContentType(input.ImageData)
+		signature.Method = domain.SignatureMethodImage
+	}
+
+	if input.PIN != "" {
+		user, err := s.userRepo.FindMember(ctx, companyID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := confirmSigningPIN(ctx, s.userRepo, user, input.PIN); err != nil {
+			return nil, err
+		}
+		if signature.Method == domain.SignatureMethodImage {
+			signature.Method = domain.SignatureMethodImagePIN
+		} else {
+			signature.Method = domain.SignatureMethodPIN
+		}
+	}
+
+	return signature, nil
+}
+
+// confirmSigningPIN checks the user's signing PIN. Each confirmation is
+// counted before the PIN is checked, so that concurrent guesses cannot go past
+// domain.MaxSigningPINAttempts, and the count clears when the PIN is correct.
+func confirmSigningPIN(ctx context.Context, users repository.UserRepository, user *domain.User, pin string) error {
+	if !user.HasSigningPIN() {
+		return domain.ErrSigningPINNotSet
+	}
+	if user.IsSigningPINLocked() {
+		return domain.ErrSigningPINLocked
+	}
+	if err := users.ClaimSigningPINAttempt(ctx, user.ID, domain.MaxSigningPINAttempts); err != nil {
+		return err
+	}
+	if !user.CheckSigningPIN(pin) {
+		return domain.ErrSigningPINInvalid
+	}
+	return users.ResetSigningPINAttempts(ctx, user.ID)
+}
+
+// Record binds the signature to the voucher content and stores it
+func (s *voucherSignatureService) Record(ctx context.Context, voucher *domain.Voucher, signature *domain.VoucherSignature) error {
+	signature.VoucherID = voucher.ID
+	signature.DocumentHash = domain.VoucherDigest(voucher)
+	signature.SignedAt = time.Now()
+	return s.signatureRepo.Create(ctx, signature)
+}
+
+// GetByVoucher returns the voucher's signatures
+func (s *voucherSignatureService) GetByVoucher(ctx context.Context, companyID, voucherID uuid.UUID) ([]domain.VoucherSignature, error) {
+	return s.signatureRepo.FindByVoucher(ctx, companyID, voucherID)
+}
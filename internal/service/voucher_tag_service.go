@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/saintgo7/saas-kerp/internal/domain"
+	"github.com/saintgo7/saas-kerp/internal/repository"
+)
+
+// VoucherTagService defines the interface for managing the company's voucher tags
+type VoucherTagService interface {
+	Create(ctx context.Context, tag *domain.VoucherTag) error
+
+	// Update saves the tag; renaming it renames it on the tagged vouchers
+	Update(ctx context.Context, tag *domain.VoucherTag) error
+
+	// Delete deletes the tag and removes it from the tagged vouchers
+	Delete(ctx context.Context, companyID, id uuid.UUID) error
+
+	GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherTag, error)
+	List(ctx context.Context, companyID uuid.UUID) ([]domain.VoucherTag, error)
+}
+
+// voucherTagService implements VoucherTagService
+type voucherTagService struct {
+	repo repository.VoucherTagRepository
+}
+
+// NewVoucherTagService creates a new VoucherTagService
+func NewVoucherTagService(repo repository.VoucherTagRepository) VoucherTagService {
+	return &voucherTagService{repo: repo}
+}
+
+// Create validates and saves a new tag
+func (s *voucherTagService) Create(ctx context.Context, tag *domain.VoucherTag) error {
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkNameAvailable(ctx, tag); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, tag)
+}
+
+// Update validates and saves a tag
+func (s *voucherTagService) Update(ctx context.Context, tag *domain.VoucherTag) error {
+	existing, err := s.repo.FindByID(ctx, tag.CompanyID, tag.ID)
+	if err != nil {
+		return err
+	}
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	if tag.Name != existing.Name {
+		if err := s.checkNameAvailable(ctx, tag); err != nil {
+			return err
+		}
+	}
+	return s.repo.Update(ctx, tag, existing.Name)
+}
+
+// checkNameAvailable returns ErrVoucherTagExists if another tag of the company has the name
+func (s *voucherTagService) checkNameAvailable(ctx context.Context, tag *domain.VoucherTag) error {
+	other, err := s.repo.FindByName(ctx, tag.CompanyID, tag.Name)
+	switch {
+	case err == domain.ErrVoucherTagNotFound:
+		return nil
+	case err != nil:
+		return err
+	case other.ID != tag.ID:
+		return domain.ErrVoucherTagExists
+	}
+	return nil
+}
+
+// Delete deletes a tag
+func (s *voucherTagService) Delete(ctx context.Context, companyID, id uuid.UUID) error {
+	return s.repo.Delete(ctx, companyID, id)
+}
+
+// GetByID retrieves a tag
+func (s *voucherTagService) GetByID(ctx context.Context, companyID, id uuid.UUID) (*domain.VoucherTag, error) {
+	return s.repo.FindByID(ctx, companyID, id)
+}
+
+// List retrieves the company's tags with their voucher counts
+func (s *voucherTagService) List(ctx context.Context, companyID uuid.UUID) ([]domain.VoucherTag, error) {
+	return s.repo.FindAll(ctx, companyID)
+}
@@ -0,0 +1,191 @@
+// Package validation converts request binding errors into structured field errors
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field     string `json:"field"`
+	Rule      string `json:"rule,omitempty"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message"`
+	MessageKo string `json:"message_ko,omitempty"`
+}
+
+func init() {
+	// Report fields by their json/form names instead of Go struct field names
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName returns the request name of a struct field
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.SplitN(f.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// FieldErrors converts a binding error into field errors. Errors that do not
+// belong to a single field (malformed JSON, empty or oversized body) are
+// reported with an empty Field.
+func FieldErrors(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, fromValidatorError(fe))
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	var maxBytesErr *http.MaxBytesError
+	var numErr *strconv.NumError
+	var timeErr *time.ParseError
+	switch {
+	case errors.As(err, &typeErr):
+		return []FieldError{{
+			Field:     typeErr.Field,
+			Rule:      "type",
+			Param:     typeErr.Type.String(),
+			Message:   fmt.Sprintf("%s must be of type %s", label(typeErr.Field), typeErr.Type),
+			MessageKo: fmt.Sprintf("%s의 값 형식이 올바르지 않습니다", label(typeErr.Field)),
+		}}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return []FieldError{{Rule: "json", Message: "Malformed JSON body", MessageKo: "JSON 형식이 올바르지 않습니다"}}
+	case errors.Is(err, io.EOF):
+		return []FieldError{{Rule: "required", Message: "Request body is required", MessageKo: "요청 본문이 비어 있습니다"}}
+	case errors.As(err, &maxBytesErr):
+		return []FieldError{{
+			Rule:      "max_bytes",
+			Param:     strconv.FormatInt(maxBytesErr.Limit, 10),
+			Message:   fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit),
+			MessageKo: fmt.Sprintf("요청 본문이 최대 크기(%d바이트)를 초과했습니다", maxBytesErr.Limit),
+		}}
+	case errors.As(err, &numErr):
+		return []FieldError{{
+			Rule:      "type",
+			Message:   fmt.Sprintf("%q is not a valid number", numErr.Num),
+			MessageKo: fmt.Sprintf("%q은(는) 올바른 숫자가 아닙니다", numErr.Num),
+		}}
+	case errors.As(err, &timeErr):
+		return []FieldError{{
+			Rule:      "format",
+			Param:     timeErr.Layout,
+			Message:   fmt.Sprintf("%q does not match format %s", timeErr.Value, timeErr.Layout),
+			MessageKo: fmt.Sprintf("%q은(는) %s 형식이 아닙니다", timeErr.Value, timeErr.Layout),
+		}}
+	}
+	return []FieldError{{Rule: "invalid", Message: err.Error(), MessageKo: "요청 값이 올바르지 않습니다"}}
+}
+
+// fromValidatorError builds the field error for a failed validation rule
+func fromValidatorError(fe validator.FieldError) FieldError {
+	field := fe.Namespace()
+	// Drop the request struct name: CreateVoucherRequest.entries[0].amount -> entries[0].amount
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+	msg, msgKo := messages(fe)
+	return FieldError{
+		Field:     field,
+		Rule:      fe.Tag(),
+		Param:     fe.Param(),
+		Message:   label(field) + " " + msg,
+		MessageKo: label(field) + msgKo,
+	}
+}
+
+// messages returns the English and Korean rule descriptions, without the field name
+func messages(fe validator.FieldError) (string, string) {
+	p := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		return "is required", "은(는) 필수 항목입니다"
+	case "email":
+		return "must be a valid email address", "은(는) 올바른 이메일 주소가 아닙니다"
+	case "uuid":
+		return "must be a valid UUID", "은(는) 올바른 UUID가 아닙니다"
+	case "numeric":
+		return "must contain only digits", "은(는) 숫자만 입력할 수 있습니다"
+	case "oneof":
+		values := strings.ReplaceAll(p, " ", ", ")
+		return "must be one of: " + values, "은(는) 다음 중 하나여야 합니다: " + values
+	case "datetime":
+		return "must match format " + p, "은(는) " + p + " 형식이어야 합니다"
+	case "min", "max", "len":
+		return sizeMessages(fe.Tag(), p, fe.Kind())
+	case "gt":
+		return "must be greater than " + p, "은(는) " + p + "보다 커야 합니다"
+	case "gte":
+		return "must be at least " + p, "은(는) " + p + " 이상이어야 합니다"
+	case "lt":
+		return "must be less than " + p, "은(는) " + p + "보다 작아야 합니다"
+	case "lte":
+		return "must be at most " + p, "은(는) " + p + " 이하여야 합니다"
+	}
+	return "failed the " + fe.Tag() + " rule", "이(가) " + fe.Tag() + " 규칙을 만족하지 않습니다"
+}
+
+// sizeMessages describes min/max/len, which compare length for strings and
+// collections and value for numbers
+func sizeMessages(tag, p string, kind reflect.Kind) (string, string) {
+	var unit, unitKo string
+	switch kind {
+	case reflect.String:
+		unit, unitKo = " characters", "자"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit, unitKo = " items", "개"
+	}
+
+	switch tag {
+	case "min":
+		if unit == "" {
+			return "must be at least " + p, "은(는) " + p + " 이상이어야 합니다"
+		}
+		return "must have at least " + p + unit, "은(는) " + p + unitKo + " 이상이어야 합니다"
+	case "max":
+		if unit == "" {
+			return "must be at most " + p, "은(는) " + p + " 이하여야 합니다"
+		}
+		return "must have at most " + p + unit, "은(는) " + p + unitKo + " 이하여야 합니다"
+	}
+	if unit == "" {
+		return "must equal " + p, "은(는) " + p + "이어야 합니다"
+	}
+	return "must have exactly " + p + unit, "은(는) " + p + unitKo + "여야 합니다"
+}
+
+// label names the field in messages, falling back for body-level errors
+func label(field string) string {
+	if field == "" {
+		return "value"
+	}
+	return field
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEntry struct {
+	AccountID string `json:"account_id" binding:"required,uuid"`
+}
+
+type testRequest struct {
+	Name    string      `json:"name" binding:"required,max=5"`
+	Type    string      `json:"type" binding:"omitempty,oneof=asset liability"`
+	Amount  int64       `json:"amount" binding:"gte=0"`
+	Entries []testEntry `json:"entries" binding:"required,min=1,dive"`
+}
+
+func bindJSON(t *testing.T, body string) error {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	var obj testRequest
+	return binding.JSON.Bind(req, &obj)
+}
+
+func TestFieldErrors_ValidationRules(t *testing.T) {
+	err := bindJSON(t, `{"name":"toolong","type":"equity","amount":-1,"entries":[{"account_id":"x"}]}`)
+	require.Error(t, err)
+
+	fields := FieldErrors(err)
+	require.Len(t, fields, 4)
+
+	byField := make(map[string]FieldError)
+	for _, f := range fields {
+		byField[f.Field] = f
+	}
+
+	assert.Equal(t, "max", byField["name"].Rule)
+	assert.Equal(t, "name must have at most 5 characters", byField["name"].Message)
+	assert.Equal(t, "name은(는) 5자 이하여야 합니다", byField["name"].MessageKo)
+
+	assert.Equal(t, "oneof", byField["type"].Rule)
+	assert.Equal(t, "type must be one of: asset, liability", byField["type"].Message)
+
+	assert.Equal(t, "gte", byField["amount"].Rule)
+	assert.Equal(t, "0", byField["amount"].Param)
+
+	assert.Equal(t, "uuid", byField["entries[0].account_id"].Rule)
+}
+
+func TestFieldErrors_Required(t *testing.T) {
+	fields := FieldErrors(bindJSON(t, `{"amount":1}`))
+	require.Len(t, fields, 2)
+	assert.Equal(t, FieldError{
+		Field:     "name",
+		Rule:      "required",
+		Message:   "name is required",
+		MessageKo: "name은(는) 필수 항목입니다",
+	}, fields[0])
+	assert.Equal(t, "entries", fields[1].Field)
+}
+
+func TestFieldErrors_MalformedBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+		rule  string
+	}{
+		{"wrong type", `{"name":"a","amount":"ten","entries":[]}`, "amount", "type"},
+		{"syntax error", `{"name":`, "", "json"},
+		{"empty body", ``, "", "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := FieldErrors(bindJSON(t, tt.body))
+			require.Len(t, fields, 1)
+			assert.Equal(t, tt.field, fields[0].Field)
+			assert.Equal(t, tt.rule, fields[0].Rule)
+			assert.NotEmpty(t, fields[0].MessageKo)
+		})
+	}
+}
+
+func TestFieldErrors_Nil(t *testing.T) {
+	assert.Nil(t, FieldErrors(nil))
+}
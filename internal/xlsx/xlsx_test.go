@@ -0,0 +1,68 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"code", "name", "parent_code"},
+		{"101", "현금 & 예금", ""},
+		{},
+		{"102", "<보통예금>", "101"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, "계정과목", rows))
+
+	got, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	assert.Equal(t, rows[0], got[0])
+	assert.Equal(t, []string{"101", "현금 & 예금"}, got[1])
+	assert.Empty(t, got[2])
+	assert.Equal(t, rows[3], got[3])
+}
+
+func TestReadSharedStrings(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/sharedStrings.xml": `<sst><si><t>현금</t></si><si><r><t>보통</t></r><r><t>예금</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` +
+			`<row r="2"><c r="A2"><v>101</v></c><c r="C2" t="s"><v>0</v></c></row>` +
+			`<row r="3"><c r="B3" t="s"><v>1</v></c><c r="D3" t="b"><v>1</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	got, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Empty(t, got[0])
+	assert.Equal(t, []string{"101", "", "현금"}, got[1])
+	assert.Equal(t, []string{"", "보통예금", "", "TRUE"}, got[2])
+}
+
+func TestReadInvalidWorkbook(t *testing.T) {
+	_, err := Read([]byte("code,name\n101,현금\n"))
+	assert.ErrorIs(t, err, ErrInvalidWorkbook)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", ColumnName(0))
+	assert.Equal(t, "Z", ColumnName(25))
+	assert.Equal(t, "AA", ColumnName(26))
+	assert.Equal(t, 27, columnIndex("AB12"))
+}
@@ -0,0 +1,217 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// e2ePassword satisfies the password policy
+const e2ePassword = "E2e-Passw0rd!"
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	User        struct {
+		ID        string `json:"id"`
+		CompanyID string `json:"company_id"`
+	} `json:"user"`
+}
+
+type idResponse struct {
+	ID string `json:"id"`
+}
+
+type voucherResponse struct {
+	ID          string  `json:"id"`
+	VoucherNo   string  `json:"voucher_no"`
+	Status      string  `json:"status"`
+	TotalDebit  float64 `json:"total_debit"`
+	TotalCredit float64 `json:"total_credit"`
+}
+
+type trialBalanceResponse struct {
+	Items []struct {
+		AccountCode   string  `json:"account_code"`
+		ClosingDebit  float64 `json:"closing_debit"`
+		ClosingCredit float64 `json:"closing_credit"`
+		IsSubTotal    bool    `json:"is_sub_total"`
+		IsTotal       bool    `json:"is_total"`
+	} `json:"items"`
+	TotalDebit  float64 `json:"total_debit"`
+	TotalCredit float64 `json:"total_credit"`
+	IsBalanced  bool    `json:"is_balanced"`
+}
+
+type fiscalPeriodResponse struct {
+	FiscalYear  int    `json:"fiscal_year"`
+	FiscalMonth int    `json:"fiscal_month"`
+	Status      string `json:"status"`
+}
+
+// TestAccountingFlow walks a new company through its first month: sign-up,
+// chart of accounts, vouchers through approval and posting, reports and the
+// period close
+func TestAccountingFlow(t *testing.T) {
+	api := newClient(t)
+	year := time.Now().Year()
+	month := 1
+	voucherDate := fmt.Sprintf("%d-01-15", year)
+
+	// The business number must be unique across tenants
+	businessNumber := fmt.Sprintf("%010d", uuid.New().ID())
+	suffix := uuid.New().String()[:8]
+
+	var admin, approver *client
+	t.Run("register", func(t *testing.T) {
+		var registered tokenResponse
+		api.must(http.StatusCreated, http.MethodPost, "/auth/register", map[string]interface{}{
+			"company_name":    "E2E 주식회사 " + suffix,
+			"business_number": businessNumber,
+			"email":           "owner-" + suffix + "@e2e.kerp.test",
+			"password":        e2ePassword,
+			"name":            "E2E Owner",
+		}, &registered)
+		require.NotEmpty(t, registered.AccessToken)
+		admin = api.as(registered.AccessToken)
+
+		// Vouchers are approved and posted by someone other than their author
+		email := "approver-" + suffix + "@e2e.kerp.test"
+		admin.must(http.StatusCreated, http.MethodPost, "/users", map[string]interface{}{
+			"email":    email,
+			"password": e2ePassword,
+			"name":     "E2E Approver",
+			"role":     "admin",
+		}, nil)
+		var login tokenResponse
+		api.must(http.StatusOK, http.MethodPost, "/auth/login", map[string]interface{}{
+			"email":    email,
+			"password": e2ePassword,
+		}, &login)
+		approver = api.as(login.AccessToken)
+	})
+	require.NotNil(t, approver, "registration failed")
+
+	accounts := map[string]string{}
+	t.Run("chart of accounts", func(t *testing.T) {
+		for _, account := range []struct{ code, name, accountType string }{
+			{"10100", "현금", "asset"},
+			{"10300", "보통예금", "asset"},
+			{"40100", "상품매출", "revenue"},
+			{"81100", "복리후생비", "expense"},
+		} {
+			var created idResponse
+			admin.must(http.StatusCreated, http.MethodPost, "/accounts", map[string]interface{}{
+				"code":         account.code,
+				"name":         account.name,
+				"account_type": account.accountType,
+			}, &created)
+			accounts[account.code] = created.ID
+		}
+
+		admin.must(http.StatusCreated, http.MethodPost, fmt.Sprintf("/fiscal-periods/create/%d", year), nil, nil)
+	})
+	require.Len(t, accounts, 4, "chart of accounts incomplete")
+
+	var vouchers []voucherResponse
+	t.Run("vouchers", func(t *testing.T) {
+		for _, v := range []struct {
+			description   string
+			debit, credit string
+			amount        float64
+		}{
+			{"현금 매출", "10100", "40100", 1100000},
+			{"예금 입금", "10300", "10100", 500000},
+			{"직원 식대", "81100", "10100", 45000},
+		} {
+			var created voucherResponse
+			admin.must(http.StatusCreated, http.MethodPost, "/vouchers", map[string]interface{}{
+				"voucher_date": voucherDate,
+				"voucher_type": "general",
+				"description":  v.description,
+				"entries": []map[string]interface{}{
+					{"account_id": accounts[v.debit], "debit_amount": v.amount},
+					{"account_id": accounts[v.credit], "credit_amount": v.amount},
+				},
+			}, &created)
+			assert.Equal(t, "draft", created.Status)
+			assert.Equal(t, v.amount, created.TotalDebit)
+			vouchers = append(vouchers, created)
+		}
+
+		// Unbalanced vouchers are refused
+		status, _ := admin.do(http.MethodPost, "/vouchers", map[string]interface{}{
+			"voucher_date": voucherDate,
+			"voucher_type": "general",
+			"entries": []map[string]interface{}{
+				{"account_id": accounts["10100"], "debit_amount": 1000},
+				{"account_id": accounts["40100"], "credit_amount": 900},
+			},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+	})
+	require.Len(t, vouchers, 3, "voucher creation failed")
+
+	t.Run("approve and post", func(t *testing.T) {
+		for _, v := range vouchers {
+			admin.must(http.StatusOK, http.MethodPost, "/vouchers/"+v.ID+"/submit", nil, nil)
+
+			// The author cannot approve their own voucher
+			status, _ := admin.do(http.MethodPost, "/vouchers/"+v.ID+"/approve", nil)
+			assert.Equal(t, http.StatusForbidden, status)
+
+			approver.must(http.StatusOK, http.MethodPost, "/vouchers/"+v.ID+"/approve", nil, nil)
+			var posted voucherResponse
+			approver.must(http.StatusOK, http.MethodPost, "/vouchers/"+v.ID+"/post", nil, &posted)
+			assert.Equal(t, "posted", posted.Status)
+		}
+	})
+
+	t.Run("reports", func(t *testing.T) {
+		var tb trialBalanceResponse
+		admin.must(http.StatusOK, http.MethodGet, fmt.Sprintf("/reports/trial-balance?year=%d&month=%d", year, month), nil, &tb)
+		assert.True(t, tb.IsBalanced)
+		assert.Equal(t, tb.TotalDebit, tb.TotalCredit)
+
+		closing := map[string]float64{}
+		for _, item := range tb.Items {
+			if !item.IsSubTotal && !item.IsTotal {
+				closing[item.AccountCode] = item.ClosingDebit - item.ClosingCredit
+			}
+		}
+		assert.Equal(t, float64(1100000-500000-45000), closing["10100"])
+		assert.Equal(t, float64(500000), closing["10300"])
+		assert.Equal(t, float64(-1100000), closing["40100"])
+		assert.Equal(t, float64(45000), closing["81100"])
+
+		admin.must(http.StatusOK, http.MethodGet, fmt.Sprintf("/reports/income-statement?from_year=%d&from_month=%d&to_year=%d&to_month=%d", year, month, year, month), nil, nil)
+		admin.must(http.StatusOK, http.MethodGet, fmt.Sprintf("/reports/balance-sheet?year=%d&month=%d", year, month), nil, nil)
+	})
+
+	t.Run("close period", func(t *testing.T) {
+		admin.must(http.StatusOK, http.MethodPost, "/fiscal-periods/close", map[string]interface{}{
+			"year":     year,
+			"month":    month,
+			"override": true, // the default checklist is not worked through here
+		}, nil)
+
+		var period fiscalPeriodResponse
+		admin.must(http.StatusOK, http.MethodGet, fmt.Sprintf("/fiscal-periods/%d/%d", year, month), nil, &period)
+		assert.Equal(t, "closed", period.Status)
+
+		// A closed period cannot be closed again
+		status, _ := admin.do(http.MethodPost, "/fiscal-periods/close", map[string]interface{}{
+			"year":     year,
+			"month":    month,
+			"override": true,
+		})
+		assert.NotEqual(t, http.StatusOK, status)
+	})
+}
@@ -0,0 +1,85 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// envelope is the response body of every API endpoint
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details string `json:"details"`
+	} `json:"error"`
+}
+
+// client calls the API as a signed-in user, or anonymously without a token
+type client struct {
+	t     *testing.T
+	token string
+}
+
+func newClient(t *testing.T) *client {
+	return &client{t: t}
+}
+
+// as returns a client using the access token
+func (c *client) as(token string) *client {
+	return &client{t: c.t, token: token}
+}
+
+// do sends a JSON request to a /api/v1 path and returns the status and envelope
+func (c *client) do(method, path string, body interface{}) (int, *envelope) {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, server.URL+"/api/v1"+path, reader)
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := server.Client().Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(c.t, err)
+
+	var env envelope
+	require.NoError(c.t, json.Unmarshal(raw, &env), "%s %s: %s", method, path, raw)
+	return resp.StatusCode, &env
+}
+
+// must sends the request, requires the status and decodes the data into out
+func (c *client) must(status int, method, path string, body, out interface{}) {
+	c.t.Helper()
+
+	got, env := c.do(method, path, body)
+	if got != status {
+		msg := ""
+		if env.Error != nil {
+			msg = env.Error.Code + ": " + env.Error.Message + " " + env.Error.Details
+		}
+		require.Equal(c.t, status, got, "%s %s: %s", method, path, msg)
+	}
+	if out != nil {
+		require.NoError(c.t, json.Unmarshal(env.Data, out), "%s %s", method, path)
+	}
+}
@@ -0,0 +1,223 @@
+//go:build e2e
+// +build e2e
+
+// Package e2e runs API scenarios against a fully wired API server backed by
+// real PostgreSQL, Redis and NATS instances (tests/docker-compose.test.yml
+// locally, service containers in CI). Every run migrates a database of its
+// own, which is dropped afterwards unless E2E_KEEP_DB is set.
+package e2e
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/zap"
+
+	"github.com/saintgo7/saas-kerp/internal/auth"
+	"github.com/saintgo7/saas-kerp/internal/config"
+	"github.com/saintgo7/saas-kerp/internal/database"
+	"github.com/saintgo7/saas-kerp/internal/handler"
+	"github.com/saintgo7/saas-kerp/internal/router"
+)
+
+// migrationsDir holds the migrations applied to the test database
+const migrationsDir = "../../db/migrations"
+
+// server is the API under test, started once for the package
+var server *httptest.Server
+
+// environment returns the variable, or def when it is unset
+func environment(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
+
+// Dependencies default to the ports published by tests/docker-compose.test.yml
+var (
+	dbHost     = environment("TEST_DB_HOST", "localhost")
+	dbPort     = environment("TEST_DB_PORT", "5433")
+	dbUser     = environment("TEST_DB_USER", "kerp_test")
+	dbPassword = environment("TEST_DB_PASSWORD", "kerp_test_password")
+	dbName     = environment("TEST_DB_NAME", "kerp_test")
+	redisHost  = environment("TEST_REDIS_HOST", "localhost")
+	redisPort  = environment("TEST_REDIS_PORT", "6380")
+	natsURL    = environment("TEST_NATS_URL", "nats://localhost:4223")
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run boots the API on a freshly migrated database and runs the tests
+func run(m *testing.M) int {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	name, err := createDatabase(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v (are the test services up? make test-up)\n", err)
+		return 1
+	}
+	if os.Getenv("E2E_KEEP_DB") == "" {
+		defer dropDatabase(name)
+	} else {
+		fmt.Fprintf(os.Stderr, "e2e: keeping database %s\n", name)
+	}
+
+	if err := migrate(ctx, name); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v\n", err)
+		return 1
+	}
+
+	cleanup, err := startServer(ctx, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v\n", err)
+		return 1
+	}
+	defer cleanup()
+
+	return m.Run()
+}
+
+// openDB connects to a database of the test server
+func openDB(name string) (*sql.DB, error) {
+	return sql.Open("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPassword, dbHost, dbPort, name))
+}
+
+// createDatabase creates an empty database for the run. Domain models name
+// the kerp schema, so it is created and searched first.
+func createDatabase(ctx context.Context) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	name := "kerp_e2e_" + hex.EncodeToString(suffix)
+
+	admin, err := openDB(dbName)
+	if err != nil {
+		return "", err
+	}
+	defer admin.Close()
+	if _, err := admin.ExecContext(ctx, "CREATE DATABASE "+name); err != nil {
+		return "", fmt.Errorf("create database %s: %w", name, err)
+	}
+	if _, err := admin.ExecContext(ctx, "ALTER DATABASE "+name+" SET search_path = kerp, public"); err != nil {
+		return "", fmt.Errorf("set search path of %s: %w", name, err)
+	}
+	return name, nil
+}
+
+// dropDatabase removes the database of the run
+func dropDatabase(name string) {
+	admin, err := openDB(dbName)
+	if err != nil {
+		return
+	}
+	defer admin.Close()
+	if _, err := admin.Exec("DROP DATABASE IF EXISTS " + name + " WITH (FORCE)"); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: drop database %s: %v\n", name, err)
+	}
+}
+
+// migrate applies the up migrations in order
+func migrate(ctx context.Context, name string) error {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	db, err := openDB(name)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS kerp"); err != nil {
+		return fmt.Errorf("create schema: %w", err)
+	}
+	for _, file := range files {
+		script, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		// Without arguments the statements of a file run in one simple query
+		if _, err := db.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("migration %s: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// startServer wires the API the way cmd/api does and serves it over HTTP
+func startServer(ctx context.Context, name string) (func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Database.Host = dbHost
+	cfg.Database.Port, _ = strconv.Atoi(dbPort)
+	cfg.Database.User = dbUser
+	cfg.Database.Password = dbPassword
+	cfg.Database.Name = name
+	cfg.Redis.Host = redisHost
+	cfg.Redis.Port, _ = strconv.Atoi(redisPort)
+	cfg.NATS.URL = natsURL
+	cfg.JWT.Secret = "e2e-secret-key-for-api-scenarios"
+	cfg.RateLimit.Enabled = false
+
+	logger := zap.NewNop()
+	if os.Getenv("E2E_LOG") != "" {
+		logger, _ = zap.NewDevelopment()
+	}
+
+	db, err := database.NewPostgresDB(&cfg.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+
+	rdb := database.NewRedisClient(&cfg.Redis)
+	redisResilience := database.NewRedisResilience(&cfg.Redis, logger)
+	rdb.AddHook(redisResilience)
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := database.PingRedis(pingCtx, rdb); err != nil {
+		_ = database.CloseDB(db)
+		return nil, fmt.Errorf("connect to redis: %w", err)
+	}
+
+	nc, err := database.NewNATSConnection(&cfg.NATS)
+	if err != nil {
+		_ = database.CloseDB(db)
+		return nil, fmt.Errorf("connect to nats: %w", err)
+	}
+
+	jwtService, err := auth.LoadJWTService(&cfg.JWT)
+	if err != nil {
+		_ = database.CloseDB(db)
+		return nil, err
+	}
+	handlers := handler.NewHandlers(db, rdb, redisResilience, nc, logger, jwtService, &cfg.OCR, &cfg.Email, &cfg.Export, &cfg.Attachment, &cfg.Credentials, &cfg.Popbill, &cfg.NTS, &cfg.Inbox, &cfg.Plan, &cfg.Backup, &cfg.Retention, cfg.App.Version)
+	r := router.New(cfg, logger, jwtService, handlers)
+	server = httptest.NewServer(r.Engine())
+
+	return func() {
+		server.Close()
+		database.CloseNATS(nc)
+		_ = database.CloseRedis(rdb)
+		_ = database.CloseDB(db)
+	}, nil
+}
@@ -0,0 +1,198 @@
+//go:build load
+// +build load
+
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// environment returns the variable, or def when it is unset
+func environment(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
+
+// The API under test runs separately, e.g. make run against make dev-up
+var (
+	baseURL         = environment("LOAD_BASE_URL", "http://localhost:8080")
+	duration, _     = time.ParseDuration(environment("LOAD_DURATION", "30s"))
+	seedVouchers, _ = strconv.Atoi(environment("LOAD_SEED_VOUCHERS", "50"))
+)
+
+const loadPassword = "Load-Passw0rd!"
+
+var httpClient = &http.Client{
+	Timeout:   30 * time.Second,
+	Transport: &http.Transport{MaxIdleConnsPerHost: 100},
+}
+
+// call sends a JSON request to a /api/v1 path, records its latency under
+// name and decodes the data of the response into out
+func call(result *Result, name, token, method, path string, body, out interface{}, status int) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, baseURL+"/api/v1"+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if result != nil {
+		result.Observe(name, time.Since(start))
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != status {
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, raw)
+	}
+	if out == nil {
+		return nil
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// company is a registered company with a small chart of accounts. Vouchers
+// are approved and posted by someone other than their author.
+type company struct {
+	owner, approver string
+	accounts        map[string]string
+	year, month     int
+}
+
+var (
+	setupOnce  sync.Once
+	setupValue *company
+	setupErr   error
+)
+
+// loadCompany registers the company used by all tests and benchmarks of the run
+func loadCompany(tb testing.TB) *company {
+	tb.Helper()
+	setupOnce.Do(func() { setupValue, setupErr = registerCompany() })
+	if setupErr != nil {
+		tb.Fatalf("setup against %s: %v", baseURL, setupErr)
+	}
+	return setupValue
+}
+
+func registerCompany() (*company, error) {
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := call(nil, "", "", http.MethodPost, "/auth/register", map[string]interface{}{
+		"company_name":    "Load " + suffix,
+		"business_number": suffix[len(suffix)-10:],
+		"email":           "owner-" + suffix + "@load.kerp.test",
+		"password":        loadPassword,
+		"name":            "Load Owner",
+	}, &token, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	c := &company{owner: token.AccessToken, accounts: map[string]string{}, year: time.Now().Year(), month: 1}
+
+	email := "approver-" + suffix + "@load.kerp.test"
+	if err := call(nil, "", c.owner, http.MethodPost, "/users", map[string]interface{}{
+		"email": email, "password": loadPassword, "name": "Load Approver", "role": "admin",
+	}, nil, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	if err := call(nil, "", "", http.MethodPost, "/auth/login", map[string]interface{}{
+		"email": email, "password": loadPassword,
+	}, &token, http.StatusOK); err != nil {
+		return nil, err
+	}
+	c.approver = token.AccessToken
+
+	for _, account := range []struct{ code, name, accountType string }{
+		{"10100", "현금", "asset"},
+		{"40100", "상품매출", "revenue"},
+		{"81100", "복리후생비", "expense"},
+	} {
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := call(nil, "", c.owner, http.MethodPost, "/accounts", map[string]interface{}{
+			"code": account.code, "name": account.name, "account_type": account.accountType,
+		}, &created, http.StatusCreated); err != nil {
+			return nil, err
+		}
+		c.accounts[account.code] = created.ID
+	}
+	if err := call(nil, "", c.owner, http.MethodPost, fmt.Sprintf("/fiscal-periods/create/%d", c.year), nil, nil, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// postVoucher creates, submits, approves and posts a balanced voucher
+func (c *company) postVoucher(result *Result, i int) error {
+	debit, credit := "10100", "40100"
+	if i%2 == 1 {
+		debit, credit = "81100", "10100"
+	}
+	amount := 10000 + i
+
+	var voucher struct {
+		ID string `json:"id"`
+	}
+	if err := call(result, "create_voucher", c.owner, http.MethodPost, "/vouchers", map[string]interface{}{
+		"voucher_date": fmt.Sprintf("%d-01-15", c.year),
+		"voucher_type": "general",
+		"description":  fmt.Sprintf("Load voucher %d", i),
+		"entries": []map[string]interface{}{
+			{"account_id": c.accounts[debit], "debit_amount": amount},
+			{"account_id": c.accounts[credit], "credit_amount": amount},
+		},
+	}, &voucher, http.StatusCreated); err != nil {
+		return err
+	}
+	if err := call(result, "submit_voucher", c.owner, http.MethodPost, "/vouchers/"+voucher.ID+"/submit", nil, nil, http.StatusOK); err != nil {
+		return err
+	}
+	if err := call(result, "approve_voucher", c.approver, http.MethodPost, "/vouchers/"+voucher.ID+"/approve", nil, nil, http.StatusOK); err != nil {
+		return err
+	}
+	return call(result, "post_voucher", c.approver, http.MethodPost, "/vouchers/"+voucher.ID+"/post", nil, nil, http.StatusOK)
+}
+
+// trialBalance reads the monthly trial balance
+func (c *company) trialBalance(result *Result) error {
+	return call(result, "trial_balance", c.owner, http.MethodGet,
+		fmt.Sprintf("/reports/trial-balance?year=%d&month=%d", c.year, c.month), nil, nil, http.StatusOK)
+}
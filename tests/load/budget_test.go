@@ -0,0 +1,142 @@
+//go:build load
+// +build load
+
+package load
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var seedOnce sync.Once
+
+// seedTrialBalance posts the vouchers the trial balance is computed from
+func seedTrialBalance(tb testing.TB, c *company) {
+	tb.Helper()
+	var err error
+	seedOnce.Do(func() {
+		for i := 0; i < seedVouchers && err == nil; i++ {
+			err = c.postVoucher(nil, i)
+		}
+	})
+	if err != nil {
+		tb.Fatalf("seed vouchers: %v", err)
+	}
+}
+
+// runFor runs iterate on workers goroutines until the duration has passed
+func runFor(workers int, duration time.Duration, iterate func(result *Result, i int) error) *Result {
+	result := NewResult()
+	deadline := time.Now().Add(duration)
+	var next int64
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				result.Done(iterate(result, int(atomic.AddInt64(&next, 1))))
+			}
+		}()
+	}
+	wg.Wait()
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// TestPerformanceBudget runs every scenario with the budgeted number of
+// workers for LOAD_DURATION and fails when the API is over budget
+func TestPerformanceBudget(t *testing.T) {
+	budget, err := LoadBudget()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := loadCompany(t)
+
+	scenarios := map[string]func(result *Result, i int) error{
+		ScenarioVoucherCreatePost: c.postVoucher,
+		ScenarioTrialBalance: func(result *Result, i int) error {
+			return c.trialBalance(result)
+		},
+	}
+	for _, name := range []string{ScenarioVoucherCreatePost, ScenarioTrialBalance} {
+		name, iterate := name, scenarios[name]
+		t.Run(name, func(t *testing.T) {
+			if name == ScenarioTrialBalance {
+				seedTrialBalance(t, c)
+			}
+			scenario := budget[name]
+			result := runFor(scenario.Workers, duration, iterate)
+
+			t.Logf("%d iterations (%.1f/s), %d errors", result.Iterations, result.PerSecond(), result.Errors)
+			for request := range scenario.Requests {
+				t.Logf("%s: p95 %v, p99 %v", request,
+					result.Percentile(request, 95).Round(time.Millisecond), result.Percentile(request, 99).Round(time.Millisecond))
+			}
+			for _, violation := range scenario.Check(result) {
+				t.Error(violation)
+			}
+		})
+	}
+}
+
+// TestK6Budget runs the embedded k6 scenarios when k6 is installed
+func TestK6Budget(t *testing.T) {
+	for _, name := range []string{ScenarioVoucherCreatePost, ScenarioTrialBalance} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			err := RunK6(context.Background(), name, baseURL, duration.String(), os.Stdout, os.Stderr)
+			if errors.Is(err, ErrK6NotInstalled) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// BenchmarkVoucherCreatePost measures the full posting flow of a voucher, e.g.
+//
+//	go test -tags load ./tests/load -run '^$' -bench VoucherCreatePost -benchtime 200x
+func BenchmarkVoucherCreatePost(b *testing.B) {
+	c := loadCompany(b)
+	result := NewResult()
+	var next int64
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := c.postVoucher(result, int(atomic.AddInt64(&next, 1))); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.StopTimer()
+	b.ReportMetric(float64(result.Percentile("post_voucher", 95).Microseconds())/1000, "post-p95-ms")
+}
+
+// BenchmarkTrialBalance measures the trial balance of a company with posted vouchers
+func BenchmarkTrialBalance(b *testing.B) {
+	c := loadCompany(b)
+	seedTrialBalance(b, c)
+	result := NewResult()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := c.trialBalance(result); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.StopTimer()
+	b.ReportMetric(float64(result.Percentile("trial_balance", 95).Microseconds())/1000, "p95-ms")
+}
@@ -0,0 +1,71 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// ErrK6NotInstalled is returned by RunK6 when the k6 binary is not on the PATH
+var ErrK6NotInstalled = errors.New("k6 is not installed")
+
+// K6Scripts maps the scenarios of the budget to their k6 scripts
+var K6Scripts = map[string]string{
+	ScenarioVoucherCreatePost: "k6/voucher-create-post.js",
+	ScenarioTrialBalance:      "k6/trial-balance.js",
+}
+
+// RunK6 runs the k6 script of a scenario against the API at baseURL. The
+// scripts turn the budget into k6 thresholds, so k6 fails the run when the
+// budget is exceeded.
+func RunK6(ctx context.Context, scenario, baseURL, duration string, stdout, stderr io.Writer) error {
+	script, ok := K6Scripts[scenario]
+	if !ok {
+		return fmt.Errorf("no k6 script for scenario %q", scenario)
+	}
+	k6, err := exec.LookPath("k6")
+	if err != nil {
+		return ErrK6NotInstalled
+	}
+
+	// The scripts read the budget relative to themselves
+	dir, err := os.MkdirTemp("", "kerp-load-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err := extract(dir); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, k6, "run", "--duration", duration, filepath.Join(dir, script))
+	cmd.Env = append(os.Environ(), "BASE_URL="+baseURL)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("k6 %s: %w", scenario, err)
+	}
+	return nil
+}
+
+// extract writes the embedded files to dir
+func extract(dir string) error {
+	return fs.WalkDir(Files, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, path)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := Files.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -0,0 +1,104 @@
+// Shared setup of the budgeted scenarios. The budget is read from
+// ../budget.json, which the Go load tests enforce as well.
+import http from 'k6/http';
+import { check, fail } from 'k6';
+
+export const budget = JSON.parse(open('../budget.json'));
+
+export const env = {
+  BASE_URL: __ENV.BASE_URL || 'http://localhost:8080',
+  API_VERSION: '/api/v1',
+};
+
+const password = 'Load-Passw0rd!';
+
+// options returns the k6 options of a scenario, with its budget as thresholds
+export function options(scenario) {
+  const b = budget[scenario];
+  const thresholds = {
+    http_req_failed: [`rate<${b.max_error_rate}`],
+    iterations: [`rate>${b.min_per_second}`],
+  };
+  for (const [name, limit] of Object.entries(b.requests)) {
+    thresholds[`http_req_duration{name:${name}}`] = [`p(95)<${limit.p95_ms}`, `p(99)<${limit.p99_ms}`];
+  }
+  return {
+    vus: b.workers,
+    duration: __ENV.DURATION || '1m',
+    thresholds: thresholds,
+    tags: { scenario: scenario },
+  };
+}
+
+export function headers(token) {
+  return {
+    'Content-Type': 'application/json',
+    Authorization: `Bearer ${token}`,
+  };
+}
+
+// request sends a JSON request and returns the data of the response, failing
+// the iteration on an unexpected status
+export function request(method, path, token, body, name, status) {
+  const res = http.request(method, `${env.BASE_URL}${env.API_VERSION}${path}`, body ? JSON.stringify(body) : null, {
+    headers: token ? headers(token) : { 'Content-Type': 'application/json' },
+    tags: { name: name },
+  });
+  if (!check(res, { [`${name} status is ${status}`]: (r) => r.status === status })) {
+    return null;
+  }
+  return JSON.parse(res.body).data;
+}
+
+// setupCompany registers a company with a small chart of accounts and an
+// approver, as vouchers are approved and posted by someone other than their author
+export function setupCompany() {
+  const suffix = `${Date.now()}${Math.floor(Math.random() * 1000)}`;
+  const registered = request('POST', '/auth/register', null, {
+    company_name: `Load ${suffix}`,
+    business_number: suffix.slice(-10).padStart(10, '9'),
+    email: `owner-${suffix}@load.kerp.test`,
+    password: password,
+    name: 'Load Owner',
+  }, 'setup', 201);
+  if (!registered) {
+    fail('registration failed');
+  }
+  const owner = registered.access_token;
+
+  const approverEmail = `approver-${suffix}@load.kerp.test`;
+  request('POST', '/users', owner, { email: approverEmail, password: password, name: 'Load Approver', role: 'admin' }, 'setup', 201);
+  const login = request('POST', '/auth/login', null, { email: approverEmail, password: password }, 'setup', 200);
+  if (!login) {
+    fail('approver login failed');
+  }
+
+  const accounts = {};
+  for (const [code, name, type] of [['10100', '현금', 'asset'], ['40100', '상품매출', 'revenue'], ['81100', '복리후생비', 'expense']]) {
+    accounts[code] = request('POST', '/accounts', owner, { code: code, name: name, account_type: type }, 'setup', 201).id;
+  }
+
+  const year = new Date().getFullYear();
+  request('POST', `/fiscal-periods/create/${year}`, owner, null, 'setup', 201);
+
+  return { owner: owner, approver: login.access_token, accounts: accounts, year: year, month: 1 };
+}
+
+// postVoucher creates, submits, approves and posts a balanced voucher
+export function postVoucher(data, debit, credit, amount) {
+  const voucher = request('POST', '/vouchers', data.owner, {
+    voucher_date: `${data.year}-01-15`,
+    voucher_type: 'general',
+    description: `Load VU${__VU} ITER${__ITER}`,
+    entries: [
+      { account_id: data.accounts[debit], debit_amount: amount },
+      { account_id: data.accounts[credit], credit_amount: amount },
+    ],
+  }, 'create_voucher', 201);
+  if (!voucher) {
+    return false;
+  }
+  return request('POST', `/vouchers/${voucher.id}/submit`, data.owner, null, 'submit_voucher', 200) !== null &&
+    request('POST', `/vouchers/${voucher.id}/approve`, data.approver, null, 'approve_voucher', 200) !== null &&
+    request('POST', `/vouchers/${voucher.id}/post`, data.approver, null, 'post_voucher', 200) !== null;
+}
@@ -0,0 +1,19 @@
+// Trial balance latency: the monthly trial balance of a company with posted
+// vouchers is read concurrently.
+import { options as budgeted, request, setupCompany, postVoucher } from './common.js';
+
+export const options = budgeted('trial_balance');
+
+const seedVouchers = parseInt(__ENV.SEED_VOUCHERS || '50', 10);
+
+export function setup() {
+  const data = setupCompany();
+  for (let i = 0; i < seedVouchers; i++) {
+    postVoucher(data, i % 2 === 0 ? '10100' : '81100', i % 2 === 0 ? '40100' : '10100', 10000 + i);
+  }
+  return data;
+}
+
+export default function (data) {
+  request('GET', `/reports/trial-balance?year=${data.year}&month=${data.month}`, data.owner, null, 'trial_balance', 200);
+}
@@ -0,0 +1,14 @@
+// Voucher posting throughput: every iteration creates, submits, approves and
+// posts a voucher of the same company, so that postings contend for its ledger.
+import { options as budgeted, setupCompany, postVoucher } from './common.js';
+
+export const options = budgeted('voucher_create_post');
+
+export function setup() {
+  return setupCompany();
+}
+
+export default function (data) {
+  const [debit, credit] = __ITER % 2 === 0 ? ['10100', '40100'] : ['81100', '10100'];
+  postVoucher(data, debit, credit, 10000 + __ITER);
+}
@@ -0,0 +1,156 @@
+// Package load holds the performance budget of the API and the load tests
+// enforcing it against a running server. The budget in budget.json is shared
+// by the Go load tests and benchmarks (build tag load) and by the k6
+// scenarios embedded from k6/, so both fail on the same numbers.
+package load
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Files holds the budget and the k6 scenarios
+//
+//go:embed budget.json k6/*.js
+var Files embed.FS
+
+// Scenarios of the budget
+const (
+	ScenarioVoucherCreatePost = "voucher_create_post"
+	ScenarioTrialBalance      = "trial_balance"
+)
+
+// Budget is the performance budget of each scenario
+type Budget map[string]ScenarioBudget
+
+// ScenarioBudget bounds a scenario run by Workers concurrent users
+type ScenarioBudget struct {
+	Description  string                   `json:"description"`
+	Workers      int                      `json:"workers"`
+	MinPerSecond float64                  `json:"min_per_second"` // completed iterations
+	MaxErrorRate float64                  `json:"max_error_rate"`
+	Requests     map[string]RequestBudget `json:"requests"` // by request name
+}
+
+// RequestBudget bounds the latency of a request
+type RequestBudget struct {
+	P95Ms float64 `json:"p95_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+// LoadBudget returns the embedded budget
+func LoadBudget() (Budget, error) {
+	data, err := Files.ReadFile("budget.json")
+	if err != nil {
+		return nil, err
+	}
+	var budget Budget
+	if err := json.Unmarshal(data, &budget); err != nil {
+		return nil, fmt.Errorf("budget.json: %w", err)
+	}
+	return budget, nil
+}
+
+// Result is the outcome of a scenario run. Workers record into it concurrently.
+type Result struct {
+	Iterations int
+	Errors     int
+	Elapsed    time.Duration
+	Latencies  map[string][]time.Duration // by request name
+
+	mu sync.Mutex
+}
+
+// NewResult returns an empty result
+func NewResult() *Result {
+	return &Result{Latencies: make(map[string][]time.Duration)}
+}
+
+// Observe records the latency of a request
+func (r *Result) Observe(request string, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Latencies[request] = append(r.Latencies[request], latency)
+}
+
+// Done records a completed iteration, or a failed one when err is not nil
+func (r *Result) Done(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.Errors++
+		return
+	}
+	r.Iterations++
+}
+
+// PerSecond returns the iterations completed per second
+func (r *Result) PerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Iterations) / r.Elapsed.Seconds()
+}
+
+// ErrorRate returns the share of iterations that failed
+func (r *Result) ErrorRate() float64 {
+	if r.Iterations+r.Errors == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Iterations+r.Errors)
+}
+
+// Percentile returns the latency of the request below which p percent of them completed
+func (r *Result) Percentile(request string, p float64) time.Duration {
+	latencies := append([]time.Duration(nil), r.Latencies[request]...)
+	if len(latencies) == 0 {
+		return 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rank := int(p/100*float64(len(latencies))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(latencies) {
+		rank = len(latencies) - 1
+	}
+	return latencies[rank]
+}
+
+// Check returns the budget violations of the result, empty when it is within budget
+func (b ScenarioBudget) Check(r *Result) []string {
+	var violations []string
+	if perSecond := r.PerSecond(); perSecond < b.MinPerSecond {
+		violations = append(violations, fmt.Sprintf("throughput %.1f/s is below %.1f/s", perSecond, b.MinPerSecond))
+	}
+	if rate := r.ErrorRate(); rate > b.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.2f%% is above %.2f%%", rate*100, b.MaxErrorRate*100))
+	}
+
+	requests := make([]string, 0, len(b.Requests))
+	for request := range b.Requests {
+		requests = append(requests, request)
+	}
+	sort.Strings(requests)
+	for _, request := range requests {
+		limit := b.Requests[request]
+		if len(r.Latencies[request]) == 0 {
+			violations = append(violations, fmt.Sprintf("%s was not measured", request))
+			continue
+		}
+		for _, bound := range []struct {
+			p  float64
+			ms float64
+		}{{95, limit.P95Ms}, {99, limit.P99Ms}} {
+			got := r.Percentile(request, bound.p)
+			if bound.ms > 0 && got > time.Duration(bound.ms*float64(time.Millisecond)) {
+				violations = append(violations, fmt.Sprintf("%s p%.0f %v is above %vms", request, bound.p, got.Round(time.Millisecond), bound.ms))
+			}
+		}
+	}
+	return violations
+}
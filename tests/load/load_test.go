@@ -0,0 +1,54 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBudget(t *testing.T) {
+	budget, err := LoadBudget()
+	require.NoError(t, err)
+
+	for name, script := range K6Scripts {
+		scenario, ok := budget[name]
+		require.True(t, ok, "%s has no budget", name)
+		assert.Positive(t, scenario.Workers, name)
+		assert.NotEmpty(t, scenario.Requests, name)
+		_, err := Files.ReadFile(script)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestScenarioBudget_Check(t *testing.T) {
+	budget := ScenarioBudget{
+		MinPerSecond: 10,
+		MaxErrorRate: 0.05,
+		Requests:     map[string]RequestBudget{"post_voucher": {P95Ms: 100, P99Ms: 200}},
+	}
+	result := NewResult()
+	for i := 1; i <= 100; i++ {
+		result.Observe("post_voucher", time.Duration(i)*time.Millisecond)
+		result.Done(nil)
+	}
+	result.Elapsed = 5 * time.Second
+
+	assert.Equal(t, 95*time.Millisecond, result.Percentile("post_voucher", 95))
+	assert.Empty(t, budget.Check(result))
+
+	// Slow, failing and too few iterations
+	for i := 0; i < 5; i++ {
+		result.Observe("post_voucher", time.Second)
+	}
+	for i := 0; i < 10; i++ {
+		result.Done(assert.AnError)
+	}
+	result.Elapsed = 20 * time.Second
+	assert.Equal(t, []string{
+		"throughput 5.0/s is below 10.0/s",
+		"error rate 9.09% is above 5.00%",
+		"post_voucher p99 1s is above 200ms",
+	}, budget.Check(result))
+}